@@ -2,7 +2,9 @@
 package accounts
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/url"
@@ -59,8 +61,13 @@ func (n AccountDeleteResult) GetResponse() *api.Response {
 }
 
 type AccountListResult struct {
-	Items    []*Account
-	response *api.Response
+	Items        []*Account `json:"items,omitempty"`
+	ResponseType string     `json:"response_type,omitempty"`
+	ListToken    string     `json:"list_token,omitempty"`
+	SortBy       string     `json:"sort_by,omitempty"`
+	SortDir      string     `json:"sort_dir,omitempty"`
+	RemovedIds   []string   `json:"removed_ids,omitempty"`
+	response     *api.Response
 }
 
 func (n AccountListResult) GetItems() []*Account {
@@ -290,32 +297,65 @@ func (c *Client) List(ctx context.Context, authMethodId string, opt ...Option) (
 	opts, apiOpts := getOpts(opt...)
 	opts.queryMap["auth_method_id"] = authMethodId
 
-	req, err := c.client.NewRequest(ctx, "GET", "accounts", nil, apiOpts...)
-	if err != nil {
-		return nil, fmt.Errorf("error creating List request: %w", err)
-	}
+	// Unless the caller asked to direct the pagination itself, the pages of
+	// a paginated listing are requested until the listing is complete.
+	target := new(AccountListResult)
+	var pages int
+	for {
+		req, err := c.client.NewRequest(ctx, "GET", "accounts", nil, apiOpts...)
+		if err != nil {
+			return nil, fmt.Errorf("error creating List request: %w", err)
+		}
 
-	if len(opts.queryMap) > 0 {
-		q := url.Values{}
-		for k, v := range opts.queryMap {
-			q.Add(k, v)
+		if len(opts.queryMap) > 0 {
+			q := url.Values{}
+			for k, v := range opts.queryMap {
+				q.Add(k, v)
+			}
+			req.URL.RawQuery = q.Encode()
 		}
-		req.URL.RawQuery = q.Encode()
-	}
 
-	resp, err := c.client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("error performing client request during List call: %w", err)
-	}
+		resp, err := c.client.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("error performing client request during List call: %w", err)
+		}
 
-	target := new(AccountListResult)
-	apiErr, err := resp.Decode(target)
-	if err != nil {
-		return nil, fmt.Errorf("error decoding List response: %w", err)
+		page := new(AccountListResult)
+		apiErr, err := resp.Decode(page)
+		if err != nil {
+			return nil, fmt.Errorf("error decoding List response: %w", err)
+		}
+		if apiErr != nil {
+			return nil, apiErr
+		}
+		pages++
+		target.Items = append(target.Items, page.Items...)
+		target.RemovedIds = append(target.RemovedIds, page.RemovedIds...)
+		target.ResponseType = page.ResponseType
+		target.ListToken = page.ListToken
+		target.SortBy = page.SortBy
+		target.SortDir = page.SortDir
+		target.response = resp
+		if opts.withClientDirectedPagination || page.ResponseType != "delta" {
+			break
+		}
+		opts.queryMap["list_token"] = page.ListToken
 	}
-	if apiErr != nil {
-		return nil, apiErr
+
+	if pages > 1 {
+		// The response only holds the last page, so replace its body with the
+		// whole listing for callers reading the raw response.
+		b, err := json.Marshal(target)
+		if err != nil {
+			return nil, fmt.Errorf("error encoding List result: %w", err)
+		}
+		target.response.Body = bytes.NewBuffer(b)
+		dec := json.NewDecoder(bytes.NewReader(b))
+		dec.UseNumber()
+		target.response.Map = make(map[string]any)
+		if err := dec.Decode(&target.response.Map); err != nil {
+			return nil, fmt.Errorf("error decoding List result: %w", err)
+		}
 	}
-	target.response = resp
 	return target, nil
 }
@@ -3,13 +3,19 @@ package accounts
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/mitchellh/mapstructure"
 )
 
 type PasswordAccountAttributes struct {
-	LoginName string `json:"login_name,omitempty"`
-	Password  string `json:"password,omitempty"`
+	LoginName          string            `json:"login_name,omitempty"`
+	Password           string            `json:"password,omitempty"`
+	FailedAttemptCount uint32            `json:"failed_attempt_count,omitempty"`
+	Locked             bool              `json:"locked,omitempty"`
+	LockedUntil        time.Time         `json:"locked_until,omitempty"`
+	TotpEnrolled       bool              `json:"totp_enrolled,omitempty"`
+	CustomAttributes   map[string]string `json:"custom_attributes,omitempty"`
 }
 
 func AttributesMapToPasswordAccountAttributes(in map[string]interface{}) (*PasswordAccountAttributes, error) {
@@ -0,0 +1,151 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package accounts
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/boundary/api"
+)
+
+// TotpEnrollResult is the result of enrolling a TOTP secret for an account.
+// The secret and recovery codes are only returned by EnrollTotp.
+type TotpEnrollResult struct {
+	Item          *Account `json:"item,omitempty"`
+	Secret        string   `json:"secret,omitempty"`
+	Url           string   `json:"url,omitempty"`
+	RecoveryCodes []string `json:"recovery_codes,omitempty"`
+	response      *api.Response
+}
+
+func (n TotpEnrollResult) GetItem() *Account {
+	return n.Item
+}
+
+func (n TotpEnrollResult) GetResponse() *api.Response {
+	return n.response
+}
+
+// EnrollTotp generates a new TOTP secret and recovery codes for a password
+// account, replacing any it has. The secret must be confirmed with
+// ConfirmTotp before it is required to authenticate. Replacing a confirmed
+// secret requires either a code generated from it or an unused recovery
+// code; both are ignored if the account has no confirmed secret.
+func (c *Client) EnrollTotp(ctx context.Context, accountId, code, recoveryCode string, opt ...Option) (*TotpEnrollResult, error) {
+	if accountId == "" {
+		return nil, fmt.Errorf("empty accountId value passed into EnrollTotp request")
+	}
+	if c.client == nil {
+		return nil, fmt.Errorf("nil client in EnrollTotp request")
+	}
+
+	_, apiOpts := getOpts(opt...)
+
+	reqBody := map[string]any{}
+	if code != "" {
+		reqBody["code"] = code
+	}
+	if recoveryCode != "" {
+		reqBody["recovery_code"] = recoveryCode
+	}
+
+	req, err := c.client.NewRequest(ctx, "POST", fmt.Sprintf("accounts/%s:enroll-totp", accountId), reqBody, apiOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating EnrollTotp request: %w", err)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error performing client request during EnrollTotp call: %w", err)
+	}
+
+	target := new(TotpEnrollResult)
+	apiErr, err := resp.Decode(target)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding EnrollTotp response: %w", err)
+	}
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	target.response = resp
+	return target, nil
+}
+
+// ConfirmTotp confirms the TOTP secret enrolled for a password account with
+// a code generated from it.
+func (c *Client) ConfirmTotp(ctx context.Context, accountId, code string, opt ...Option) (*AccountUpdateResult, error) {
+	if accountId == "" {
+		return nil, fmt.Errorf("empty accountId value passed into ConfirmTotp request")
+	}
+	if code == "" {
+		return nil, fmt.Errorf("empty code value passed into ConfirmTotp request")
+	}
+	if c.client == nil {
+		return nil, fmt.Errorf("nil client in ConfirmTotp request")
+	}
+
+	_, apiOpts := getOpts(opt...)
+
+	reqBody := map[string]any{
+		"code": code,
+	}
+
+	req, err := c.client.NewRequest(ctx, "POST", fmt.Sprintf("accounts/%s:confirm-totp", accountId), reqBody, apiOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating ConfirmTotp request: %w", err)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error performing client request during ConfirmTotp call: %w", err)
+	}
+
+	target := new(AccountUpdateResult)
+	target.Item = new(Account)
+	apiErr, err := resp.Decode(target.Item)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding ConfirmTotp response: %w", err)
+	}
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	target.response = resp
+	return target, nil
+}
+
+// RemoveTotp removes the TOTP secret and recovery codes of a password
+// account.
+func (c *Client) RemoveTotp(ctx context.Context, accountId string, opt ...Option) (*AccountUpdateResult, error) {
+	if accountId == "" {
+		return nil, fmt.Errorf("empty accountId value passed into RemoveTotp request")
+	}
+	if c.client == nil {
+		return nil, fmt.Errorf("nil client in RemoveTotp request")
+	}
+
+	_, apiOpts := getOpts(opt...)
+
+	req, err := c.client.NewRequest(ctx, "POST", fmt.Sprintf("accounts/%s:remove-totp", accountId), map[string]any{}, apiOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating RemoveTotp request: %w", err)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error performing client request during RemoveTotp call: %w", err)
+	}
+
+	target := new(AccountUpdateResult)
+	target.Item = new(Account)
+	apiErr, err := resp.Decode(target.Item)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding RemoveTotp response: %w", err)
+	}
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	target.response = resp
+	return target, nil
+}
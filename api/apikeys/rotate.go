@@ -0,0 +1,69 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package apikeys
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/boundary/api"
+)
+
+// Rotate replaces the value of an api key. The previous value stops being
+// valid immediately and the new value is only returned in the response to
+// this call.
+func (c *Client) Rotate(ctx context.Context, apiKeyId string, version uint32, opt ...Option) (*ApiKeyUpdateResult, error) {
+	if apiKeyId == "" {
+		return nil, fmt.Errorf("empty apiKeyId value passed into Rotate request")
+	}
+	if c.client == nil {
+		return nil, fmt.Errorf("nil client in Rotate request")
+	}
+
+	opts, apiOpts := getOpts(opt...)
+
+	if version == 0 {
+		if !opts.withAutomaticVersioning {
+			return nil, errors.New("zero version number passed into Rotate request and automatic versioning not specified")
+		}
+		existingTarget, existingErr := c.Read(ctx, apiKeyId, opt...)
+		if existingErr != nil {
+			if api.AsServerError(existingErr) != nil {
+				return nil, fmt.Errorf("error from controller when performing initial check-and-set read: %w", existingErr)
+			}
+			return nil, fmt.Errorf("error performing initial check-and-set read: %w", existingErr)
+		}
+		if existingTarget == nil {
+			return nil, errors.New("nil resource found when performing initial check-and-set read")
+		}
+		version = existingTarget.Item.Version
+	}
+
+	reqBody := map[string]any{
+		"version": version,
+	}
+
+	req, err := c.client.NewRequest(ctx, "POST", fmt.Sprintf("api-keys/%s:rotate", apiKeyId), reqBody, apiOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating Rotate request: %w", err)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error performing client request during Rotate call: %w", err)
+	}
+
+	target := new(ApiKeyUpdateResult)
+	target.Item = new(ApiKey)
+	apiErr, err := resp.Decode(target.Item)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding Rotate response: %w", err)
+	}
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	target.response = resp
+	return target, nil
+}
@@ -21,6 +21,9 @@ type OidcAuthMethodAttributes struct {
 	AllowedAudiences                  []string `json:"allowed_audiences,omitempty"`
 	ClaimsScopes                      []string `json:"claims_scopes,omitempty"`
 	AccountClaimMaps                  []string `json:"account_claim_maps,omitempty"`
+	Prompts                           []string `json:"prompts,omitempty"`
+	AcrValues                         []string `json:"acr_values,omitempty"`
+	EnablePkce                        bool     `json:"enable_pkce,omitempty"`
 	DisableDiscoveredConfigValidation bool     `json:"disable_discovered_config_validation,omitempty"`
 	DryRun                            bool     `json:"dry_run,omitempty"`
 }
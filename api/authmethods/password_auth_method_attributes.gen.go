@@ -8,8 +8,16 @@ import (
 )
 
 type PasswordAuthMethodAttributes struct {
-	MinLoginNameLength uint32 `json:"min_login_name_length,omitempty"`
-	MinPasswordLength  uint32 `json:"min_password_length,omitempty"`
+	MinLoginNameLength   uint32 `json:"min_login_name_length,omitempty"`
+	MinPasswordLength    uint32 `json:"min_password_length,omitempty"`
+	MaxFailedAttempts    uint32 `json:"max_failed_attempts,omitempty"`
+	LockoutDuration      uint32 `json:"lockout_duration,omitempty"`
+	SourceIpRateLimit    uint32 `json:"source_ip_rate_limit,omitempty"`
+	MfaRequired          bool   `json:"mfa_required,omitempty"`
+	MinPasswordClasses   uint32 `json:"min_password_classes,omitempty"`
+	DenyCommonPasswords  bool   `json:"deny_common_passwords,omitempty"`
+	PasswordHistoryCount uint32 `json:"password_history_count,omitempty"`
+	MaxPasswordAge       uint32 `json:"max_password_age,omitempty"`
 }
 
 func AttributesMapToPasswordAuthMethodAttributes(in map[string]interface{}) (*PasswordAuthMethodAttributes, error) {
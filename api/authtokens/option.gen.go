@@ -4,6 +4,7 @@
 package authtokens
 
 import (
+	"fmt"
 	"strconv"
 	"strings"
 
@@ -20,12 +21,15 @@ import (
 type Option func(*options)
 
 type options struct {
-	postMap                 map[string]interface{}
-	queryMap                map[string]string
-	withAutomaticVersioning bool
-	withSkipCurlOutput      bool
-	withFilter              string
-	withRecursive           bool
+	postMap                      map[string]interface{}
+	queryMap                     map[string]string
+	withAutomaticVersioning      bool
+	withSkipCurlOutput           bool
+	withFilter                   string
+	withPageSize                 uint32
+	withListToken                string
+	withClientDirectedPagination bool
+	withRecursive                bool
 }
 
 func getDefaultOptions() options {
@@ -49,6 +53,12 @@ func getOpts(opt ...Option) (options, []api.Option) {
 	if opts.withFilter != "" {
 		opts.queryMap["filter"] = opts.withFilter
 	}
+	if opts.withPageSize != 0 {
+		opts.queryMap["page_size"] = strconv.FormatUint(uint64(opts.withPageSize), 10)
+	}
+	if opts.withListToken != "" {
+		opts.queryMap["list_token"] = opts.withListToken
+	}
 	if opts.withRecursive {
 		opts.queryMap["recursive"] = strconv.FormatBool(opts.withRecursive)
 	}
@@ -82,6 +92,32 @@ func WithFilter(filter string) Option {
 	}
 }
 
+// WithPageSize tells the API to paginate the listing, returning up to the
+// provided number of items per page. Unless WithClientDirectedPagination is
+// used, all pages are requested and returned in a single result.
+func WithPageSize(pageSize uint32) Option {
+	return func(o *options) {
+		o.withPageSize = pageSize
+	}
+}
+
+// WithListToken tells the API to continue the listing that returned the
+// provided list token. The list token of a complete listing requests the items
+// changed, and the ids of the items removed, since the listing started.
+func WithListToken(listToken string) Option {
+	return func(o *options) {
+		o.withListToken = listToken
+	}
+}
+
+// WithClientDirectedPagination tells the API to return a single page of a
+// paginated listing. Use the list token of the result to request the next page.
+func WithClientDirectedPagination(with bool) Option {
+	return func(o *options) {
+		o.withClientDirectedPagination = with
+	}
+}
+
 // WithRecursive tells the API to use recursion for listing operations on this
 // resource
 func WithRecursive(recurse bool) Option {
@@ -89,3 +125,27 @@ func WithRecursive(recurse bool) Option {
 		o.withRecursive = true
 	}
 }
+
+func WithAccountId(inAccountId string) Option {
+	return func(o *options) {
+		o.queryMap["account_id"] = fmt.Sprintf("%v", inAccountId)
+	}
+}
+
+func WithApproximateLastUsedAfter(inApproximateLastUsedAfter string) Option {
+	return func(o *options) {
+		o.queryMap["approximate_last_used_after"] = fmt.Sprintf("%v", inApproximateLastUsedAfter)
+	}
+}
+
+func WithApproximateLastUsedBefore(inApproximateLastUsedBefore string) Option {
+	return func(o *options) {
+		o.queryMap["approximate_last_used_before"] = fmt.Sprintf("%v", inApproximateLastUsedBefore)
+	}
+}
+
+func WithUserId(inUserId string) Option {
+	return func(o *options) {
+		o.queryMap["user_id"] = fmt.Sprintf("%v", inUserId)
+	}
+}
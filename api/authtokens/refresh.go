@@ -0,0 +1,45 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package authtokens
+
+import (
+	"context"
+	"fmt"
+)
+
+// Refresh extends the expiration time of an auth token that is neither
+// expired nor stale. The controller never extends the expiration time past
+// its configured maximum lifetime of an auth token.
+func (c *Client) Refresh(ctx context.Context, authTokenId string, opt ...Option) (*AuthTokenUpdateResult, error) {
+	if authTokenId == "" {
+		return nil, fmt.Errorf("empty authTokenId value passed into Refresh request")
+	}
+	if c.client == nil {
+		return nil, fmt.Errorf("nil client in Refresh request")
+	}
+
+	_, apiOpts := getOpts(opt...)
+
+	req, err := c.client.NewRequest(ctx, "POST", fmt.Sprintf("auth-tokens/%s:refresh", authTokenId), map[string]any{}, apiOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating Refresh request: %w", err)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error performing client request during Refresh call: %w", err)
+	}
+
+	target := new(AuthTokenUpdateResult)
+	target.Item = new(AuthToken)
+	apiErr, err := resp.Decode(target.Item)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding Refresh response: %w", err)
+	}
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	target.response = resp
+	return target, nil
+}
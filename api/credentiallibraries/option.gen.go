@@ -4,6 +4,7 @@
 package credentiallibraries
 
 import (
+	"strconv"
 	"strings"
 
 	"github.com/hashicorp/boundary/api"
@@ -19,11 +20,14 @@ import (
 type Option func(*options)
 
 type options struct {
-	postMap                 map[string]interface{}
-	queryMap                map[string]string
-	withAutomaticVersioning bool
-	withSkipCurlOutput      bool
-	withFilter              string
+	postMap                      map[string]interface{}
+	queryMap                     map[string]string
+	withAutomaticVersioning      bool
+	withSkipCurlOutput           bool
+	withFilter                   string
+	withPageSize                 uint32
+	withListToken                string
+	withClientDirectedPagination bool
 }
 
 func getDefaultOptions() options {
@@ -47,6 +51,12 @@ func getOpts(opt ...Option) (options, []api.Option) {
 	if opts.withFilter != "" {
 		opts.queryMap["filter"] = opts.withFilter
 	}
+	if opts.withPageSize != 0 {
+		opts.queryMap["page_size"] = strconv.FormatUint(uint64(opts.withPageSize), 10)
+	}
+	if opts.withListToken != "" {
+		opts.queryMap["list_token"] = opts.withListToken
+	}
 	return opts, apiOpts
 }
 
@@ -77,6 +87,32 @@ func WithFilter(filter string) Option {
 	}
 }
 
+// WithPageSize tells the API to paginate the listing, returning up to the
+// provided number of items per page. Unless WithClientDirectedPagination is
+// used, all pages are requested and returned in a single result.
+func WithPageSize(pageSize uint32) Option {
+	return func(o *options) {
+		o.withPageSize = pageSize
+	}
+}
+
+// WithListToken tells the API to continue the listing that returned the
+// provided list token. The list token of a complete listing requests the items
+// changed, and the ids of the items removed, since the listing started.
+func WithListToken(listToken string) Option {
+	return func(o *options) {
+		o.withListToken = listToken
+	}
+}
+
+// WithClientDirectedPagination tells the API to return a single page of a
+// paginated listing. Use the list token of the result to request the next page.
+func WithClientDirectedPagination(with bool) Option {
+	return func(o *options) {
+		o.withClientDirectedPagination = with
+	}
+}
+
 func WithAttributes(inAttributes map[string]interface{}) Option {
 	return func(o *options) {
 		o.postMap["attributes"] = inAttributes
@@ -0,0 +1,68 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package roles
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// Explain returns whether the user is authorized to perform the action on a
+// resource of the given type in the scope, and the grants which contributed to
+// the decision. Use WithResourceId to explain an action on a specific
+// resource, WithPin for resources pinned to a parent, and WithAccountId to
+// choose the account used for grant templates. The caller must be able to read
+// the user. Grant conditions are evaluated against the explain request, e.g.
+// the caller's address and the current time, rather than those of the user.
+func (c *Client) Explain(ctx context.Context, scopeId, userId, resourceType, action string, opt ...Option) (*ExplanationReadResult, error) {
+	switch {
+	case scopeId == "":
+		return nil, fmt.Errorf("empty scopeId value passed into Explain request")
+	case userId == "":
+		return nil, fmt.Errorf("empty userId value passed into Explain request")
+	case resourceType == "":
+		return nil, fmt.Errorf("empty resourceType value passed into Explain request")
+	case action == "":
+		return nil, fmt.Errorf("empty action value passed into Explain request")
+	}
+
+	opts, apiOpts := getOpts(opt...)
+	opts.queryMap["scope_id"] = scopeId
+	opts.queryMap["user_id"] = userId
+	opts.queryMap["resource_type"] = resourceType
+	opts.queryMap["action"] = action
+
+	if c.client == nil {
+		return nil, fmt.Errorf("nil client")
+	}
+
+	req, err := c.client.NewRequest(ctx, "GET", "roles:explain", nil, apiOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating Explain request: %w", err)
+	}
+
+	q := url.Values{}
+	for k, v := range opts.queryMap {
+		q.Add(k, v)
+	}
+	req.URL.RawQuery = q.Encode()
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error performing client request during Explain call: %w", err)
+	}
+
+	target := new(ExplanationReadResult)
+	target.Item = new(Explanation)
+	apiErr, err := resp.Decode(target.Item)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding Explain response: %w", err)
+	}
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	target.response = resp
+	return target, nil
+}
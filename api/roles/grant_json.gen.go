@@ -2,7 +2,14 @@
 package roles
 
 type GrantJson struct {
-	Id      string   `json:"id,omitempty"`
-	Type    string   `json:"type,omitempty"`
-	Actions []string `json:"actions,omitempty"`
+	Id             string   `json:"id,omitempty"`
+	Type           string   `json:"type,omitempty"`
+	Actions        []string `json:"actions,omitempty"`
+	Deny           bool     `json:"deny,omitempty"`
+	Days           []string `json:"days,omitempty"`
+	Hours          []string `json:"hours,omitempty"`
+	TimeZone       string   `json:"time_zone,omitempty"`
+	SourceCidrs    []string `json:"source_cidrs,omitempty"`
+	Filter         string   `json:"filter,omitempty"`
+	ResourceFilter string   `json:"resource_filter,omitempty"`
 }
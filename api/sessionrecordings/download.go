@@ -0,0 +1,52 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sessionrecordings
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+)
+
+// Download returns the contents of the session recording with the provided
+// id. The contents are a header followed by one event per line, each of which
+// is a JSON object. The caller must close the returned reader.
+func (c *Client) Download(ctx context.Context, id string, opt ...Option) (io.ReadCloser, error) {
+	if id == "" {
+		return nil, fmt.Errorf("empty id value passed into Download request")
+	}
+	if c.client == nil {
+		return nil, errors.New("nil client")
+	}
+
+	opts, apiOpts := getOpts(opt...)
+
+	req, err := c.client.NewRequest(ctx, "GET", fmt.Sprintf("session-recordings/%s:download", url.PathEscape(id)), nil, apiOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating Download request: %w", err)
+	}
+
+	if len(opts.queryMap) > 0 {
+		q := url.Values{}
+		for k, v := range opts.queryMap {
+			q.Add(k, v)
+		}
+		req.URL.RawQuery = q.Encode()
+	}
+
+	resp, err := c.client.Do(req, apiOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error performing client request during Download call: %w", err)
+	}
+	if resp.StatusCode() >= 400 {
+		apiErr, err := resp.Decode(nil)
+		if err != nil {
+			return nil, fmt.Errorf("error decoding Download response: %w", err)
+		}
+		return nil, apiErr
+	}
+	return resp.HttpResponse().Body, nil
+}
@@ -1,12 +1,22 @@
 // Code generated by "make api"; DO NOT EDIT.
 package sessions
 
+import (
+	"time"
+)
+
 type Connection struct {
-	ClientTcpAddress   string `json:"client_tcp_address,omitempty"`
-	ClientTcpPort      uint32 `json:"client_tcp_port,omitempty"`
-	EndpointTcpAddress string `json:"endpoint_tcp_address,omitempty"`
-	EndpointTcpPort    uint32 `json:"endpoint_tcp_port,omitempty"`
-	BytesUp            int64  `json:"bytes_up,string,omitempty"`
-	BytesDown          int64  `json:"bytes_down,string,omitempty"`
-	ClosedReason       string `json:"closed_reason,omitempty"`
+	Id                 string    `json:"id,omitempty"`
+	ClientTcpAddress   string    `json:"client_tcp_address,omitempty"`
+	ClientTcpPort      uint32    `json:"client_tcp_port,omitempty"`
+	EndpointTcpAddress string    `json:"endpoint_tcp_address,omitempty"`
+	EndpointTcpPort    uint32    `json:"endpoint_tcp_port,omitempty"`
+	BytesUp            int64     `json:"bytes_up,string,omitempty"`
+	BytesDown          int64     `json:"bytes_down,string,omitempty"`
+	ClosedReason       string    `json:"closed_reason,omitempty"`
+	UserClientIp       string    `json:"user_client_ip,omitempty"`
+	Status             string    `json:"status,omitempty"`
+	CreatedTime        time.Time `json:"created_time,omitempty"`
+	ConnectedTime      time.Time `json:"connected_time,omitempty"`
+	ClosedTime         time.Time `json:"closed_time,omitempty"`
 }
@@ -0,0 +1,69 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sessions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/hashicorp/boundary/api"
+)
+
+type ConnectionListResult struct {
+	Items    []*Connection `json:"items,omitempty"`
+	response *api.Response
+}
+
+func (n ConnectionListResult) GetItems() []*Connection {
+	return n.Items
+}
+
+func (n ConnectionListResult) GetResponse() *api.Response {
+	return n.response
+}
+
+// ListConnections returns all the connections of the session, including the
+// closed connections. WithFilter can be used to only return the matching
+// connections.
+func (c *Client) ListConnections(ctx context.Context, sessionId string, opt ...Option) (*ConnectionListResult, error) {
+	if sessionId == "" {
+		return nil, fmt.Errorf("empty sessionId value passed into ListConnections request")
+	}
+	if c.client == nil {
+		return nil, errors.New("nil client")
+	}
+
+	opts, apiOpts := getOpts(opt...)
+
+	req, err := c.client.NewRequest(ctx, "GET", fmt.Sprintf("sessions/%s:list-connections", url.PathEscape(sessionId)), nil, apiOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating ListConnections request: %w", err)
+	}
+
+	if len(opts.queryMap) > 0 {
+		q := url.Values{}
+		for k, v := range opts.queryMap {
+			q.Add(k, v)
+		}
+		req.URL.RawQuery = q.Encode()
+	}
+
+	resp, err := c.client.Do(req, apiOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error performing client request during ListConnections call: %w", err)
+	}
+
+	target := new(ConnectionListResult)
+	apiErr, err := resp.Decode(target)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding ListConnections response: %w", err)
+	}
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	target.response = resp
+	return target, nil
+}
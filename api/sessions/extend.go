@@ -0,0 +1,86 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sessions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/hashicorp/boundary/api"
+)
+
+// Extend extends the expiration time of the session by the duration, which
+// is truncated to seconds. The workers proxying the connections of the session
+// keep them open until the new expiration time, but new connections to the
+// session can only be made until the expiration time of the session's
+// certificate, which is not extended.
+func (c *Client) Extend(ctx context.Context, sessionId string, version uint32, duration time.Duration, opt ...Option) (*SessionUpdateResult, error) {
+	if sessionId == "" {
+		return nil, fmt.Errorf("empty sessionId value passed into Extend request")
+	}
+	if c.client == nil {
+		return nil, errors.New("nil client")
+	}
+	if duration < time.Second {
+		return nil, errors.New("duration passed into Extend request must be at least one second")
+	}
+
+	opts, apiOpts := getOpts(opt...)
+
+	if version == 0 {
+		if !opts.withAutomaticVersioning {
+			return nil, errors.New("zero version number passed into Extend request")
+		}
+		existingSession, existingErr := c.Read(ctx, sessionId, opt...)
+		if existingErr != nil {
+			if api.AsServerError(existingErr) != nil {
+				return nil, fmt.Errorf("error from controller when performing initial check-and-set read: %w", existingErr)
+			}
+			return nil, fmt.Errorf("error performing initial check-and-set read: %w", existingErr)
+		}
+		if existingSession == nil {
+			return nil, errors.New("nil resource response found when performing initial check-and-set read")
+		}
+		if existingSession.Item == nil {
+			return nil, errors.New("nil resource found when performing initial check-and-set read")
+		}
+		version = existingSession.Item.Version
+	}
+
+	opts.postMap["version"] = version
+	opts.postMap["duration_seconds"] = uint32(duration / time.Second)
+
+	req, err := c.client.NewRequest(ctx, "POST", fmt.Sprintf("sessions/%s:extend", url.PathEscape(sessionId)), opts.postMap, apiOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating Extend request: %w", err)
+	}
+
+	if len(opts.queryMap) > 0 {
+		q := url.Values{}
+		for k, v := range opts.queryMap {
+			q.Add(k, v)
+		}
+		req.URL.RawQuery = q.Encode()
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error performing client request during Extend call: %w", err)
+	}
+
+	target := new(SessionUpdateResult)
+	target.Item = new(Session)
+	apiErr, err := resp.Decode(target.Item)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding Extend response: %w", err)
+	}
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	target.response = resp
+	return target, nil
+}
@@ -0,0 +1,218 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sessions
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/boundary/api"
+)
+
+// StateChangeEvent is the type of the server-sent events holding a state
+// change.
+const StateChangeEvent = "state-change"
+
+// watchRetryWait is how long a Watcher waits before watching again.
+const watchRetryWait = time.Second
+
+// errStreamEnded is returned by readEvent when the stream of state changes
+// ends.
+var errStreamEnded = errors.New("state change stream ended")
+
+// Watcher receives the state changes of the sessions, and of their
+// connections, streamed by the controller.
+type Watcher struct {
+	client      *Client
+	scopeId     string
+	opts        options
+	apiOpts     []api.Option
+	lastEventId string
+
+	ctx    context.Context
+	body   io.ReadCloser
+	reader *bufio.Reader
+	closed bool
+}
+
+// Watch starts watching the state changes of the sessions in the scope, or in
+// the scope and its children when WithRecursive is set. WithFilter only keeps
+// the matching state changes, and WithSince, given a time formatted as RFC
+// 3339, also returns the state changes which happened from then on. The
+// watcher watches until ctx is done.
+func (c *Client) Watch(ctx context.Context, scopeId string, opt ...Option) (*Watcher, error) {
+	if scopeId == "" {
+		return nil, fmt.Errorf("empty scopeId value passed into Watch request")
+	}
+	if c.client == nil {
+		return nil, errors.New("nil client")
+	}
+
+	opts, apiOpts := getOpts(opt...)
+	w := &Watcher{
+		client:  c,
+		scopeId: scopeId,
+		opts:    opts,
+		apiOpts: apiOpts,
+		ctx:     ctx,
+	}
+	if err := w.connect(); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// connect starts streaming the state changes, from the last event received if
+// any.
+func (w *Watcher) connect() error {
+	req, err := w.client.client.NewRequest(w.ctx, "GET", "sessions:watch", nil, w.apiOpts...)
+	if err != nil {
+		return fmt.Errorf("error creating Watch request: %w", err)
+	}
+
+	q := url.Values{}
+	q.Add("scope_id", w.scopeId)
+	for k, v := range w.opts.queryMap {
+		if k == "since" && w.lastEventId != "" {
+			continue
+		}
+		q.Add(k, v)
+	}
+	req.URL.RawQuery = q.Encode()
+	req.Header.Set("Accept", "text/event-stream")
+	if w.lastEventId != "" {
+		req.Header.Set("Last-Event-ID", w.lastEventId)
+	}
+
+	resp, err := w.client.client.Do(req, w.apiOpts...)
+	if err != nil {
+		return fmt.Errorf("error performing client request during Watch call: %w", err)
+	}
+	if resp.StatusCode() != http.StatusOK {
+		apiErr, err := resp.Decode(nil)
+		if err != nil {
+			return fmt.Errorf("error decoding Watch response: %w", err)
+		}
+		if apiErr != nil {
+			return apiErr
+		}
+		return fmt.Errorf("unexpected status code %d in Watch response", resp.StatusCode())
+	}
+	w.body = resp.HttpResponse().Body
+	w.reader = bufio.NewReader(w.body)
+	return nil
+}
+
+// Next returns the next state change, waiting for it to happen. When the
+// controller ends the stream, the watcher watches again from the last state
+// change it received. Next returns an error when the context given to Watch is
+// done, or if the controller refuses to stream the state changes.
+func (w *Watcher) Next() (*StateChange, error) {
+	for {
+		if w.closed {
+			return nil, errors.New("watcher is closed")
+		}
+		if w.reader == nil {
+			select {
+			case <-w.ctx.Done():
+				return nil, w.ctx.Err()
+			case <-time.After(watchRetryWait):
+			}
+			if err := w.connect(); err != nil {
+				var apiErr *api.Error
+				if errors.As(err, &apiErr) || w.ctx.Err() != nil {
+					return nil, err
+				}
+				// The controller may be restarting; try again.
+				continue
+			}
+		}
+		sc, err := w.readEvent()
+		switch {
+		case err == nil:
+			return sc, nil
+		case w.ctx.Err() != nil:
+			return nil, w.ctx.Err()
+		case !errors.Is(err, errStreamEnded):
+			return nil, err
+		}
+		// Watch again from the last event.
+		w.disconnect()
+	}
+}
+
+// readEvent reads the stream until it reads a state change event.
+func (w *Watcher) readEvent() (*StateChange, error) {
+	var id, typ string
+	var data strings.Builder
+	for {
+		line, err := w.reader.ReadString('\n')
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", errStreamEnded, err)
+		}
+		line = strings.TrimRight(line, "\r\n")
+		if line == "" {
+			// An empty line dispatches the event.
+			if typ == StateChangeEvent && data.Len() > 0 {
+				sc := new(StateChange)
+				if err := json.Unmarshal([]byte(data.String()), sc); err != nil {
+					return nil, fmt.Errorf("error decoding state change: %w", err)
+				}
+				if id != "" {
+					w.lastEventId = id
+				}
+				return sc, nil
+			}
+			id, typ = "", ""
+			data.Reset()
+			continue
+		}
+		if strings.HasPrefix(line, ":") {
+			// A comment, sent to keep the connection alive.
+			continue
+		}
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+		switch field {
+		case "id":
+			id = value
+		case "event":
+			typ = value
+		case "data":
+			if data.Len() > 0 {
+				data.WriteByte('\n')
+			}
+			data.WriteString(value)
+		}
+	}
+}
+
+// LastEventId returns the id of the last state change event received, which
+// the watcher sends to the controller when it watches again.
+func (w *Watcher) LastEventId() string {
+	return w.lastEventId
+}
+
+// Close stops receiving the state changes.
+func (w *Watcher) Close() error {
+	w.closed = true
+	return w.disconnect()
+}
+
+func (w *Watcher) disconnect() error {
+	if w.body == nil {
+		return nil
+	}
+	err := w.body.Close()
+	w.body, w.reader = nil, nil
+	return err
+}
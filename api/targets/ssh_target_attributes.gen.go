@@ -8,7 +8,8 @@ import (
 )
 
 type SshTargetAttributes struct {
-	DefaultPort uint32 `json:"default_port,omitempty"`
+	DefaultPort uint32   `json:"default_port,omitempty"`
+	HostKeys    []string `json:"host_keys,omitempty"`
 }
 
 func AttributesMapToSshTargetAttributes(in map[string]interface{}) (*SshTargetAttributes, error) {
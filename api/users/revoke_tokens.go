@@ -0,0 +1,44 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package users
+
+import (
+	"context"
+	"fmt"
+)
+
+// RevokeTokens deletes all the auth tokens of the user, signing the user out
+// of every client. Sessions already authorized for the user are not affected.
+func (c *Client) RevokeTokens(ctx context.Context, userId string, opt ...Option) (*UserUpdateResult, error) {
+	if userId == "" {
+		return nil, fmt.Errorf("empty userId value passed into RevokeTokens request")
+	}
+	if c.client == nil {
+		return nil, fmt.Errorf("nil client in RevokeTokens request")
+	}
+
+	_, apiOpts := getOpts(opt...)
+
+	req, err := c.client.NewRequest(ctx, "POST", fmt.Sprintf("users/%s:revoke-tokens", userId), map[string]any{}, apiOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating RevokeTokens request: %w", err)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error performing client request during RevokeTokens call: %w", err)
+	}
+
+	target := new(UserUpdateResult)
+	target.Item = new(User)
+	apiErr, err := resp.Decode(target.Item)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding RevokeTokens response: %w", err)
+	}
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	target.response = resp
+	return target, nil
+}
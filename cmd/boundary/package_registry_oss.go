@@ -7,4 +7,16 @@ import (
 	// Enable tcp target support.
 	_ "github.com/hashicorp/boundary/internal/daemon/controller/handlers/targets/tcp"
 	_ "github.com/hashicorp/boundary/internal/target/tcp"
+
+	// Enable ssh target support.
+	_ "github.com/hashicorp/boundary/internal/daemon/controller/handlers/targets/ssh"
+	_ "github.com/hashicorp/boundary/internal/target/ssh"
+
+	// Enable http target support.
+	_ "github.com/hashicorp/boundary/internal/daemon/controller/handlers/targets/http"
+	_ "github.com/hashicorp/boundary/internal/target/http"
+
+	// Enable postgres target support.
+	_ "github.com/hashicorp/boundary/internal/daemon/controller/handlers/targets/postgres"
+	_ "github.com/hashicorp/boundary/internal/target/postgres"
 )
@@ -31,9 +31,12 @@ const (
 	PrincipalIdsField                           = "principal_ids"
 	PrincipalsField                             = "principals"
 	GrantScopeIdField                           = "grant_scope_id"
+	GrantScopeIdsField                          = "grant_scope_ids"
 	GrantsField                                 = "grants"
 	GrantStringsField                           = "grant_strings"
 	PrimaryAuthMethodIdField                    = "primary_auth_method_id"
+	MaxSessionsPerUserField                     = "max_sessions_per_user"
+	MaxSessionsPerTargetField                   = "max_sessions_per_target"
 	TargetIdField                               = "target_id"
 	HostIdField                                 = "host_id"
 	HostSetIdField                              = "host_set_id"
@@ -50,6 +53,11 @@ const (
 	WorkerFilterField                           = "worker_filter"
 	EgressWorkerFilterField                     = "egress_worker_filter"
 	IngressWorkerFilterField                    = "ingress_worker_filter"
+	EnableSessionRecordingField                 = "enable_session_recording"
+	SessionIdleTimeoutField                     = "session_idle_timeout"
+	SessionIdField                              = "session_id"
+	ConnectionIdField                           = "connection_id"
+	EndTimeField                                = "end_time"
 	AccountIdsField                             = "account_ids"
 	AccountsField                               = "accounts"
 	LoginNameField                              = "login_name"
@@ -75,6 +83,9 @@ const (
 	InjectedApplicationCredentialSourceIdsField = "injected_application_credential_source_ids"
 	InjectedApplicationCredentialSourcesField   = "injected_application_credential_sources"
 	ConnectionsField                            = "connections"
+	ConnectionCountField                        = "connection_count"
+	TotalBytesUpField                           = "total_bytes_up"
+	TotalBytesDownField                         = "total_bytes_down"
 	CredentialTypeField                         = "credential_type"
 	CredentialMappingOverridesField             = "credential_mapping_overrides"
 	MetricNamespace                             = "boundary"
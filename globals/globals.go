@@ -13,6 +13,12 @@ const (
 	AnyAuthenticatedUserId = "u_auth"
 	AnonymousUserId        = "u_anon"
 	RecoveryUserId         = "u_recovery"
+
+	// The special grant scope ids of a role: its own scope, the direct child
+	// scopes of its scope and all of the scopes below its scope.
+	GrantScopeThis        = "this"
+	GrantScopeChildren    = "children"
+	GrantScopeDescendants = "descendants"
 )
 
 type (
@@ -8,6 +8,7 @@ import (
 
 	"github.com/hashicorp/boundary/internal/gen/controller/api"
 	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/accounts"
+	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/apikeys"
 	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/authmethods"
 	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/authtokens"
 	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/credentiallibraries"
@@ -21,6 +22,8 @@ import (
 	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/plugins"
 	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/roles"
 	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/scopes"
+	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/serviceaccounts"
+	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/sessionrecordings"
 	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/sessions"
 	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/targets"
 	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/users"
@@ -240,6 +243,39 @@ var inputStructs = []*structInfo{
 		createResponseTypes: []string{CreateResponseType, ReadResponseType, UpdateResponseType, DeleteResponseType, ListResponseType},
 		recursiveListing:    true,
 	},
+	// Service account related resources
+	{
+		inProto: &serviceaccounts.ServiceAccount{},
+		outFile: "serviceaccounts/service_account.gen.go",
+		templates: []*template.Template{
+			clientTemplate,
+			commonCreateTemplate,
+			readTemplate,
+			updateTemplate,
+			deleteTemplate,
+			listTemplate,
+		},
+		pluralResourceName:  "service-accounts",
+		versionEnabled:      true,
+		createResponseTypes: []string{CreateResponseType, ReadResponseType, UpdateResponseType, DeleteResponseType, ListResponseType},
+		recursiveListing:    true,
+	},
+	{
+		inProto: &apikeys.ApiKey{},
+		outFile: "apikeys/api_key.gen.go",
+		templates: []*template.Template{
+			clientTemplate,
+			commonCreateTemplate,
+			readTemplate,
+			updateTemplate,
+			deleteTemplate,
+			listTemplate,
+		},
+		pluralResourceName:  "api-keys",
+		parentTypeName:      "service-account",
+		versionEnabled:      true,
+		createResponseTypes: []string{CreateResponseType, ReadResponseType, UpdateResponseType, DeleteResponseType, ListResponseType},
+	},
 	// Group related resources
 	{
 		inProto:     &groups.Member{},
@@ -284,6 +320,16 @@ var inputStructs = []*structInfo{
 		outFile:     "roles/grant_json.gen.go",
 		skipOptions: true,
 	},
+	{
+		inProto:     &roles.ExplainedGrant{},
+		outFile:     "roles/explained_grant.gen.go",
+		skipOptions: true,
+	},
+	{
+		inProto:             &roles.Explanation{},
+		outFile:             "roles/explanation.gen.go",
+		createResponseTypes: []string{ReadResponseType},
+	},
 	{
 		inProto: &roles.Role{},
 		outFile: "roles/role.gen.go",
@@ -304,6 +350,33 @@ var inputStructs = []*structInfo{
 				SliceType: "[]string",
 				VarName:   "grantStrings",
 			},
+			"GrantScopes": {
+				SliceType: "[]string",
+				VarName:   "grantScopeIds",
+			},
+		},
+		extraFields: []fieldInfo{
+			{
+				Name:        "AccountId",
+				ProtoName:   "account_id",
+				FieldType:   "string",
+				SkipDefault: true,
+				Query:       true,
+			},
+			{
+				Name:        "ResourceId",
+				ProtoName:   "resource_id",
+				FieldType:   "string",
+				SkipDefault: true,
+				Query:       true,
+			},
+			{
+				Name:        "Pin",
+				ProtoName:   "pin",
+				FieldType:   "string",
+				SkipDefault: true,
+				Query:       true,
+			},
 		},
 		pluralResourceName:  "roles",
 		versionEnabled:      true,
@@ -329,6 +402,24 @@ var inputStructs = []*structInfo{
 			mapstructureConversionTemplate,
 		},
 	},
+	{
+		inProto:        &authmethods.LdapAuthMethodAttributes{},
+		outFile:        "authmethods/ldap_auth_method_attributes.gen.go",
+		subtypeName:    "LdapAuthMethod",
+		parentTypeName: "AuthMethod",
+		templates: []*template.Template{
+			mapstructureConversionTemplate,
+		},
+	},
+	{
+		inProto:        &authmethods.JwtAuthMethodAttributes{},
+		outFile:        "authmethods/jwt_auth_method_attributes.gen.go",
+		subtypeName:    "JwtAuthMethod",
+		parentTypeName: "AuthMethod",
+		templates: []*template.Template{
+			mapstructureConversionTemplate,
+		},
+	},
 	{
 		inProto:     &authmethods.OidcAuthMethodAuthenticateStartResponse{},
 		outFile:     "authmethods/oidc_auth_method_authenticate_start_response.gen.go",
@@ -375,6 +466,12 @@ var inputStructs = []*structInfo{
 		outFile:        "accounts/password_account_attributes.gen.go",
 		subtypeName:    "PasswordAccount",
 		parentTypeName: "Account",
+		fieldOverrides: []fieldInfo{
+			{
+				Name:      "CustomAttributes",
+				FieldType: "map[string]string",
+			},
+		},
 		templates: []*template.Template{
 			mapstructureConversionTemplate,
 		},
@@ -388,6 +485,24 @@ var inputStructs = []*structInfo{
 			mapstructureConversionTemplate,
 		},
 	},
+	{
+		inProto:        &accounts.LdapAccountAttributes{},
+		outFile:        "accounts/ldap_account_attributes.gen.go",
+		subtypeName:    "LdapAccount",
+		parentTypeName: "Account",
+		templates: []*template.Template{
+			mapstructureConversionTemplate,
+		},
+	},
+	{
+		inProto:        &accounts.JwtAccountAttributes{},
+		outFile:        "accounts/jwt_account_attributes.gen.go",
+		subtypeName:    "JwtAccount",
+		parentTypeName: "Account",
+		templates: []*template.Template{
+			mapstructureConversionTemplate,
+		},
+	},
 	{
 		inProto: &accounts.Account{},
 		outFile: "accounts/account.gen.go",
@@ -420,6 +535,45 @@ var inputStructs = []*structInfo{
 			mapstructureConversionTemplate,
 		},
 	},
+	{
+		inProto:        &managedgroups.LdapManagedGroupAttributes{},
+		outFile:        "managedgroups/ldap_managed_group_attributes.gen.go",
+		subtypeName:    "LdapManagedGroup",
+		parentTypeName: "ManagedGroup",
+		templates: []*template.Template{
+			mapstructureConversionTemplate,
+		},
+	},
+	{
+		inProto:     &managedgroups.PasswordManagedGroupAttributes{},
+		outFile:     "managedgroups/password_managed_group_attributes.gen.go",
+		subtypeName: "PasswordManagedGroup",
+		fieldOverrides: []fieldInfo{
+			{
+				Name:        "Filter",
+				SkipDefault: true,
+			},
+		},
+		parentTypeName: "ManagedGroup",
+		templates: []*template.Template{
+			mapstructureConversionTemplate,
+		},
+	},
+	{
+		inProto:     &managedgroups.JwtManagedGroupAttributes{},
+		outFile:     "managedgroups/jwt_managed_group_attributes.gen.go",
+		subtypeName: "JwtManagedGroup",
+		fieldOverrides: []fieldInfo{
+			{
+				Name:        "Filter",
+				SkipDefault: true,
+			},
+		},
+		parentTypeName: "ManagedGroup",
+		templates: []*template.Template{
+			mapstructureConversionTemplate,
+		},
+	},
 	{
 		inProto: &managedgroups.ManagedGroup{},
 		outFile: "managedgroups/managedgroups.gen.go",
@@ -446,6 +600,36 @@ var inputStructs = []*structInfo{
 			deleteTemplate,
 			listTemplate,
 		},
+		extraFields: []fieldInfo{
+			{
+				Name:        "UserId",
+				ProtoName:   "user_id",
+				FieldType:   "string",
+				SkipDefault: true,
+				Query:       true,
+			},
+			{
+				Name:        "AccountId",
+				ProtoName:   "account_id",
+				FieldType:   "string",
+				SkipDefault: true,
+				Query:       true,
+			},
+			{
+				Name:        "ApproximateLastUsedBefore",
+				ProtoName:   "approximate_last_used_before",
+				FieldType:   "string",
+				SkipDefault: true,
+				Query:       true,
+			},
+			{
+				Name:        "ApproximateLastUsedAfter",
+				ProtoName:   "approximate_last_used_after",
+				FieldType:   "string",
+				SkipDefault: true,
+				Query:       true,
+			},
+		},
 		pluralResourceName:  "auth-tokens",
 		createResponseTypes: []string{CreateResponseType, ReadResponseType, UpdateResponseType, DeleteResponseType, ListResponseType},
 		recursiveListing:    true,
@@ -820,6 +1004,24 @@ var inputStructs = []*structInfo{
 			mapstructureConversionTemplate,
 		},
 	},
+	{
+		inProto:        &targets.HttpTargetAttributes{},
+		outFile:        "targets/http_target_attributes.gen.go",
+		subtypeName:    "HttpTarget",
+		parentTypeName: "Target",
+		templates: []*template.Template{
+			mapstructureConversionTemplate,
+		},
+	},
+	{
+		inProto:        &targets.PostgresTargetAttributes{},
+		outFile:        "targets/postgres_target_attributes.gen.go",
+		subtypeName:    "PostgresTarget",
+		parentTypeName: "Target",
+		templates: []*template.Template{
+			mapstructureConversionTemplate,
+		},
+	},
 	{
 		inProto: &targets.Target{},
 		outFile: "targets/target.gen.go",
@@ -901,6 +1103,10 @@ var inputStructs = []*structInfo{
 		inProto: &sessions.SessionState{},
 		outFile: "sessions/state.gen.go",
 	},
+	{
+		inProto: &sessions.StateChange{},
+		outFile: "sessions/state_change.gen.go",
+	},
 	{
 		inProto: &sessions.Connection{},
 		outFile: "sessions/connection.gen.go",
@@ -920,6 +1126,13 @@ var inputStructs = []*structInfo{
 			readTemplate,
 			listTemplate,
 		},
+		fieldOverrides: []fieldInfo{
+			// int64 fields get marshalled by protobuf as strings, so we have
+			// to tell the json parser that their json representation is a
+			// string but they go into Go int64 types.
+			{Name: "TotalBytesUp", JsonTags: []string{"string"}},
+			{Name: "TotalBytesDown", JsonTags: []string{"string"}},
+		},
 		extraFields: []fieldInfo{
 			{
 				Name:      "IncludeTerminated",
@@ -927,12 +1140,30 @@ var inputStructs = []*structInfo{
 				FieldType: "bool",
 				Query:     true,
 			},
+			{
+				Name:      "Since",
+				ProtoName: "since",
+				FieldType: "string",
+				Query:     true,
+			},
 		},
 		pluralResourceName:  "sessions",
 		createResponseTypes: []string{CreateResponseType, ReadResponseType, UpdateResponseType, DeleteResponseType, ListResponseType},
 		fieldFilter:         []string{"private_key"},
 		recursiveListing:    true,
 	},
+	{
+		inProto: &sessionrecordings.SessionRecording{},
+		outFile: "sessionrecordings/session_recording.gen.go",
+		templates: []*template.Template{
+			clientTemplate,
+			readTemplate,
+			listTemplate,
+		},
+		pluralResourceName:  "session-recordings",
+		createResponseTypes: []string{ReadResponseType, ListResponseType},
+		recursiveListing:    true,
+	},
 	{
 		inProto: &workers.Certificate{},
 		outFile: "workers/certificate.gen.go",
@@ -0,0 +1,103 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package jwt
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/boundary/internal/auth/jwt/store"
+	"github.com/hashicorp/boundary/internal/errors"
+	"google.golang.org/protobuf/proto"
+)
+
+// defaultAcctClaimMapTableName defines the default table name for an AccountClaimMap
+const defaultAcctClaimMapTableName = "auth_jwt_account_claim_map"
+
+// AccountToClaim defines the account claims a custom claim can be mapped to.
+type AccountToClaim string
+
+const (
+	ToSubClaim   AccountToClaim = "sub"
+	ToNameClaim  AccountToClaim = "name"
+	ToEmailClaim AccountToClaim = "email"
+)
+
+// ConvertToAccountToClaim converts a string to an AccountToClaim, returning
+// an error if it's not a supported account claim.
+func ConvertToAccountToClaim(ctx context.Context, s string) (AccountToClaim, error) {
+	const op = "jwt.ConvertToAccountToClaim"
+	switch AccountToClaim(s) {
+	case ToSubClaim, ToNameClaim, ToEmailClaim:
+		return AccountToClaim(s), nil
+	default:
+		return "", errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("%s is not a valid ToAccountClaim value", s))
+	}
+}
+
+// AccountClaimMap defines an optional map from a custom claim to an account
+// claim. They are value objects of an AuthMethod.
+type AccountClaimMap struct {
+	*store.AccountClaimMap
+	tableName string
+}
+
+// NewAccountClaimMap creates a new in memory AccountClaimMap assigned to a JWT
+// auth method.
+func NewAccountClaimMap(ctx context.Context, authMethodId, fromClaim string, toClaim string) (*AccountClaimMap, error) {
+	const op = "jwt.NewAccountClaimMap"
+	cm := &AccountClaimMap{
+		AccountClaimMap: &store.AccountClaimMap{
+			JwtMethodId: authMethodId,
+			FromClaim:   fromClaim,
+			ToClaim:     toClaim,
+		},
+	}
+	if err := cm.validate(ctx, op); err != nil {
+		return nil, err // intentionally not wrapped
+	}
+	return cm, nil
+}
+
+// validate the AccountClaimMap. On success, it will return nil.
+func (cm *AccountClaimMap) validate(ctx context.Context, caller errors.Op) error {
+	if cm.JwtMethodId == "" {
+		return errors.New(ctx, errors.InvalidParameter, caller, "missing jwt auth method id")
+	}
+	if cm.FromClaim == "" {
+		return errors.New(ctx, errors.InvalidParameter, caller, "missing from claim")
+	}
+	if _, err := ConvertToAccountToClaim(ctx, cm.ToClaim); err != nil {
+		return errors.Wrap(ctx, err, caller)
+	}
+	return nil
+}
+
+// AllocAccountClaimMap makes an empty one in memory
+func AllocAccountClaimMap() AccountClaimMap {
+	return AccountClaimMap{
+		AccountClaimMap: &store.AccountClaimMap{},
+	}
+}
+
+// Clone an AccountClaimMap
+func (cm *AccountClaimMap) Clone() *AccountClaimMap {
+	cp := proto.Clone(cm.AccountClaimMap)
+	return &AccountClaimMap{
+		AccountClaimMap: cp.(*store.AccountClaimMap),
+	}
+}
+
+// TableName returns the table name.
+func (cm *AccountClaimMap) TableName() string {
+	if cm.tableName != "" {
+		return cm.tableName
+	}
+	return defaultAcctClaimMapTableName
+}
+
+// SetTableName sets the table name.
+func (cm *AccountClaimMap) SetTableName(n string) {
+	cm.tableName = n
+}
@@ -0,0 +1,332 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package jwt
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/boundary/internal/auth/jwt/store"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"google.golang.org/protobuf/proto"
+)
+
+// defaultAuthMethodTableName defines the default table name for an AuthMethod
+const defaultAuthMethodTableName = "auth_jwt_method"
+
+// AuthMethod contains a JWT auth method configuration. It is owned by a
+// scope. AuthMethods can have Accounts, ManagedGroups, JwksCaCerts,
+// PublicKeys, BoundAudiences, SigningAlgorithms and AccountClaimMaps.
+//
+// A JWT auth method performs no interactive login: workloads present a token
+// issued by an external identity provider, which is verified using either the
+// keys published at JwksUrl or the static PublicKeys.
+type AuthMethod struct {
+	*store.AuthMethod
+	tableName string
+}
+
+// NewAuthMethod creates a new in memory AuthMethod assigned to scopeId.
+// Exactly one of WithJwksUrl or WithPublicKeys is required, as are
+// WithIssuer and WithBoundAudiences.
+//
+// Supports the options of WithName, WithDescription, WithIssuer, WithJwksUrl,
+// WithJwksCaCerts, WithPublicKeys, WithBoundAudiences, WithSigningAlgs and
+// WithAccountClaimMaps. All other options are ignored.
+func NewAuthMethod(ctx context.Context, scopeId string, opt ...Option) (*AuthMethod, error) {
+	const op = "jwt.NewAuthMethod"
+	opts := getOpts(opt...)
+	a := &AuthMethod{
+		AuthMethod: &store.AuthMethod{
+			ScopeId:     scopeId,
+			Name:        opts.withName,
+			Description: opts.withDescription,
+			Issuer:      opts.withIssuer,
+			JwksUrl:     opts.withJwksUrl,
+		},
+	}
+	if len(opts.withJwksCaCerts) > 0 {
+		a.JwksCaCerts = make([]string, 0, len(opts.withJwksCaCerts))
+		a.JwksCaCerts = append(a.JwksCaCerts, opts.withJwksCaCerts...)
+	}
+	if len(opts.withPublicKeys) > 0 {
+		a.PublicKeys = make([]string, 0, len(opts.withPublicKeys))
+		a.PublicKeys = append(a.PublicKeys, opts.withPublicKeys...)
+	}
+	if len(opts.withBoundAudiences) > 0 {
+		a.BoundAudiences = make([]string, 0, len(opts.withBoundAudiences))
+		a.BoundAudiences = append(a.BoundAudiences, opts.withBoundAudiences...)
+	}
+	if len(opts.withSigningAlgs) > 0 {
+		a.SigningAlgorithms = make([]string, 0, len(opts.withSigningAlgs))
+		a.SigningAlgorithms = append(a.SigningAlgorithms, opts.withSigningAlgs...)
+	}
+	if len(opts.withAccountClaimMaps) > 0 {
+		a.AccountClaimMaps = make([]string, 0, len(opts.withAccountClaimMaps))
+		a.AccountClaimMaps = append(a.AccountClaimMaps, opts.withAccountClaimMaps...)
+	}
+	if err := a.validate(ctx, op); err != nil {
+		return nil, err // intentionally not wrapped.
+	}
+	return a, nil
+}
+
+// validate the AuthMethod. On success, it will return nil.
+func (a *AuthMethod) validate(ctx context.Context, caller errors.Op) error {
+	if a.ScopeId == "" {
+		return errors.New(ctx, errors.InvalidParameter, caller, "missing scope id")
+	}
+	switch {
+	case a.JwksUrl == "" && len(a.PublicKeys) == 0:
+		return errors.New(ctx, errors.InvalidParameter, caller, "missing jwks url or public keys")
+	case a.JwksUrl != "" && len(a.PublicKeys) > 0:
+		return errors.New(ctx, errors.InvalidParameter, caller, "jwks url and public keys are mutually exclusive")
+	}
+	if a.JwksUrl != "" {
+		if err := validateJwksUrl(ctx, caller, a.JwksUrl); err != nil {
+			return err
+		}
+	}
+	if len(a.JwksCaCerts) > 0 && a.JwksUrl == "" {
+		return errors.New(ctx, errors.InvalidParameter, caller, "jwks ca certs require a jwks url")
+	}
+	if _, err := ParseAccountClaimMaps(ctx, a.AccountClaimMaps...); err != nil {
+		return errors.Wrap(ctx, err, caller)
+	}
+	// without them any token signed by the keys would be accepted, including
+	// tokens the identity provider issued to other relying parties.
+	if len(a.BoundAudiences) == 0 {
+		return errors.New(ctx, errors.InvalidParameter, caller, "missing bound audiences")
+	}
+	if a.Issuer == "" {
+		return errors.New(ctx, errors.InvalidParameter, caller, "missing issuer")
+	}
+	return nil
+}
+
+func validateJwksUrl(ctx context.Context, caller errors.Op, u string) error {
+	parsed, err := url.Parse(u)
+	if err != nil {
+		return errors.New(ctx, errors.InvalidParameter, caller, fmt.Sprintf("%q is not a valid url", u), errors.WithWrap(err))
+	}
+	switch parsed.Scheme {
+	case "http", "https":
+	default:
+		return errors.New(ctx, errors.InvalidParameter, caller, fmt.Sprintf("%q scheme must be http or https", u))
+	}
+	if parsed.Host == "" {
+		return errors.New(ctx, errors.InvalidParameter, caller, fmt.Sprintf("%q is missing a host", u))
+	}
+	return nil
+}
+
+// AllocAuthMethod makes an empty one in memory
+func AllocAuthMethod() AuthMethod {
+	return AuthMethod{
+		AuthMethod: &store.AuthMethod{},
+	}
+}
+
+// Clone an AuthMethod.
+func (a *AuthMethod) Clone() *AuthMethod {
+	cp := proto.Clone(a.AuthMethod)
+	return &AuthMethod{
+		AuthMethod: cp.(*store.AuthMethod),
+	}
+}
+
+// TableName returns the table name.
+func (a *AuthMethod) TableName() string {
+	if a.tableName != "" {
+		return a.tableName
+	}
+	return defaultAuthMethodTableName
+}
+
+// SetTableName sets the table name.
+func (a *AuthMethod) SetTableName(n string) {
+	a.tableName = n
+}
+
+// oplog will create oplog metadata for the AuthMethod.
+func (a *AuthMethod) oplog(op oplog.OpType) oplog.Metadata {
+	metadata := oplog.Metadata{
+		"resource-public-id": []string{a.GetPublicId()},
+		"resource-type":      []string{"jwt auth method"},
+		"op-type":            []string{op.String()},
+		"scope-id":           []string{a.ScopeId},
+	}
+	return metadata
+}
+
+type convertedValues struct {
+	Certs            []any
+	PublicKeys       []any
+	BoundAudiences   []any
+	SigningAlgs      []any
+	AccountClaimMaps []any
+}
+
+// convertValueObjects converts the embedded value objects. It will return an
+// error if the AuthMethod's public id is not set.
+func (am *AuthMethod) convertValueObjects(ctx context.Context) (*convertedValues, error) {
+	const op = "jwt.(AuthMethod).convertValueObjects"
+	if am.PublicId == "" {
+		return nil, errors.New(ctx, errors.InvalidPublicId, op, "missing public id")
+	}
+	var err error
+	var addCerts, addKeys, addAuds, addAlgs, addClaimMaps []any
+	if addCerts, err = am.convertCertificates(ctx); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	if addKeys, err = am.convertPublicKeys(ctx); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	if addAuds, err = am.convertBoundAudiences(ctx); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	if addAlgs, err = am.convertSigningAlgs(ctx); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	if addClaimMaps, err = am.convertAccountClaimMaps(ctx); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return &convertedValues{
+		Certs:            addCerts,
+		PublicKeys:       addKeys,
+		BoundAudiences:   addAuds,
+		SigningAlgs:      addAlgs,
+		AccountClaimMaps: addClaimMaps,
+	}, nil
+}
+
+// convertCertificates converts the embedded jwks ca certs from []string to
+// []any where each slice element is a *Certificate.
+func (am *AuthMethod) convertCertificates(ctx context.Context) ([]any, error) {
+	const op = "jwt.(AuthMethod).convertCertificates"
+	if am.PublicId == "" {
+		return nil, errors.New(ctx, errors.InvalidPublicId, op, "missing public id")
+	}
+	newInterfaces := make([]any, 0, len(am.JwksCaCerts))
+	for _, cert := range am.JwksCaCerts {
+		obj, err := NewCertificate(ctx, am.PublicId, cert)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		newInterfaces = append(newInterfaces, obj)
+	}
+	return newInterfaces, nil
+}
+
+// convertPublicKeys converts the embedded public keys from []string to []any
+// where each slice element is a *PublicKey.
+func (am *AuthMethod) convertPublicKeys(ctx context.Context) ([]any, error) {
+	const op = "jwt.(AuthMethod).convertPublicKeys"
+	if am.PublicId == "" {
+		return nil, errors.New(ctx, errors.InvalidPublicId, op, "missing public id")
+	}
+	newInterfaces := make([]any, 0, len(am.PublicKeys))
+	for _, k := range am.PublicKeys {
+		obj, err := NewPublicKey(ctx, am.PublicId, k)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		newInterfaces = append(newInterfaces, obj)
+	}
+	return newInterfaces, nil
+}
+
+// convertBoundAudiences converts the embedded bound audiences from []string
+// to []any where each slice element is a *BoundAudience.
+func (am *AuthMethod) convertBoundAudiences(ctx context.Context) ([]any, error) {
+	const op = "jwt.(AuthMethod).convertBoundAudiences"
+	if am.PublicId == "" {
+		return nil, errors.New(ctx, errors.InvalidPublicId, op, "missing public id")
+	}
+	newInterfaces := make([]any, 0, len(am.BoundAudiences))
+	for _, a := range am.BoundAudiences {
+		obj, err := NewBoundAudience(ctx, am.PublicId, a)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		newInterfaces = append(newInterfaces, obj)
+	}
+	return newInterfaces, nil
+}
+
+// convertSigningAlgs converts the embedded signing algorithms from []string
+// to []any where each slice element is a *SigningAlg.
+func (am *AuthMethod) convertSigningAlgs(ctx context.Context) ([]any, error) {
+	const op = "jwt.(AuthMethod).convertSigningAlgs"
+	if am.PublicId == "" {
+		return nil, errors.New(ctx, errors.InvalidPublicId, op, "missing public id")
+	}
+	newInterfaces := make([]any, 0, len(am.SigningAlgorithms))
+	for _, a := range am.SigningAlgorithms {
+		obj, err := NewSigningAlg(ctx, am.PublicId, a)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		newInterfaces = append(newInterfaces, obj)
+	}
+	return newInterfaces, nil
+}
+
+// convertAccountClaimMaps converts the embedded account claim maps from
+// []string to []any where each slice element is a *AccountClaimMap.
+func (am *AuthMethod) convertAccountClaimMaps(ctx context.Context) ([]any, error) {
+	const op = "jwt.(AuthMethod).convertAccountClaimMaps"
+	if am.PublicId == "" {
+		return nil, errors.New(ctx, errors.InvalidPublicId, op, "missing public id")
+	}
+	claimMaps, err := ParseAccountClaimMaps(ctx, am.AccountClaimMaps...)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	newInterfaces := make([]any, 0, len(claimMaps))
+	for _, m := range claimMaps {
+		obj, err := NewAccountClaimMap(ctx, am.PublicId, m.From, m.To)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		newInterfaces = append(newInterfaces, obj)
+	}
+	return newInterfaces, nil
+}
+
+// ClaimMap defines the To and From of a jwt account claim map
+type ClaimMap struct {
+	To   string
+	From string
+}
+
+// ParseAccountClaimMaps will parse the inbound claim maps, each of the form
+// "from=to", and return them sorted by their from claim.
+func ParseAccountClaimMaps(ctx context.Context, m ...string) ([]ClaimMap, error) {
+	const op = "jwt.ParseAccountClaimMaps"
+	claimMaps := make([]ClaimMap, 0, len(m))
+	seenTo := make(map[string]struct{}, len(m))
+	for _, s := range m {
+		from, to, ok := strings.Cut(s, "=")
+		from, to = strings.TrimSpace(from), strings.TrimSpace(to)
+		if !ok || from == "" || to == "" {
+			return nil, errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("%q is not a valid claim map", s))
+		}
+		toClaim, err := ConvertToAccountToClaim(ctx, to)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		if _, ok := seenTo[string(toClaim)]; ok {
+			return nil, errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("%q is mapped more than once", to))
+		}
+		seenTo[string(toClaim)] = struct{}{}
+		claimMaps = append(claimMaps, ClaimMap{From: from, To: string(toClaim)})
+	}
+	sort.Slice(claimMaps, func(i, j int) bool { return claimMaps[i].From < claimMaps[j].From })
+	return claimMaps, nil
+}
@@ -0,0 +1,251 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package jwt
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/errors"
+	capjwt "github.com/hashicorp/cap/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testPublicKeyPem(t *testing.T) (*ecdsa.PrivateKey, string) {
+	t.Helper()
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKIXPublicKey(&priv.PublicKey)
+	require.NoError(t, err)
+	return priv, string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+}
+
+// testSignedToken returns a compact ES256 JWT with the given claims signed by
+// priv.
+func testSignedToken(t *testing.T, priv *ecdsa.PrivateKey, claims map[string]any) string {
+	t.Helper()
+	enc := base64.RawURLEncoding
+	header, err := json.Marshal(map[string]string{"alg": "ES256", "typ": "JWT"})
+	require.NoError(t, err)
+	payload, err := json.Marshal(claims)
+	require.NoError(t, err)
+	signingInput := enc.EncodeToString(header) + "." + enc.EncodeToString(payload)
+	digest := sha256.Sum256([]byte(signingInput))
+	r, s, err := ecdsa.Sign(rand.Reader, priv, digest[:])
+	require.NoError(t, err)
+	sig := make([]byte, 64)
+	r.FillBytes(sig[:32])
+	s.FillBytes(sig[32:])
+	return signingInput + "." + enc.EncodeToString(sig)
+}
+
+func TestNewAuthMethod(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	_, keyPem := testPublicKeyPem(t)
+
+	tests := []struct {
+		name            string
+		scopeId         string
+		opts            []Option
+		wantErrMatch    *errors.Template
+		wantErrContains string
+	}{
+		{
+			name:    "valid-jwks-url",
+			scopeId: "global",
+			opts:    []Option{WithJwksUrl("https://example.com/.well-known/jwks"), WithIssuer("https://example.com"), WithBoundAudiences("boundary")},
+		},
+		{
+			name:    "valid-public-keys",
+			scopeId: "global",
+			opts:    []Option{WithPublicKeys(keyPem), WithIssuer("https://example.com"), WithBoundAudiences("boundary"), WithAccountClaimMaps("oid=sub")},
+		},
+		{
+			name:            "missing-bound-audiences",
+			scopeId:         "global",
+			opts:            []Option{WithPublicKeys(keyPem), WithIssuer("https://example.com")},
+			wantErrMatch:    errors.T(errors.InvalidParameter),
+			wantErrContains: "missing bound audiences",
+		},
+		{
+			name:            "missing-issuer",
+			scopeId:         "global",
+			opts:            []Option{WithPublicKeys(keyPem), WithBoundAudiences("boundary")},
+			wantErrMatch:    errors.T(errors.InvalidParameter),
+			wantErrContains: "missing issuer",
+		},
+		{
+			name:            "missing-scope",
+			opts:            []Option{WithPublicKeys(keyPem)},
+			wantErrMatch:    errors.T(errors.InvalidParameter),
+			wantErrContains: "missing scope id",
+		},
+		{
+			name:            "missing-keys",
+			scopeId:         "global",
+			wantErrMatch:    errors.T(errors.InvalidParameter),
+			wantErrContains: "missing jwks url or public keys",
+		},
+		{
+			name:            "url-and-keys",
+			scopeId:         "global",
+			opts:            []Option{WithJwksUrl("https://example.com/jwks"), WithPublicKeys(keyPem)},
+			wantErrMatch:    errors.T(errors.InvalidParameter),
+			wantErrContains: "mutually exclusive",
+		},
+		{
+			name:            "bad-url-scheme",
+			scopeId:         "global",
+			opts:            []Option{WithJwksUrl("ftp://example.com/jwks")},
+			wantErrMatch:    errors.T(errors.InvalidParameter),
+			wantErrContains: "scheme must be http or https",
+		},
+		{
+			name:            "ca-certs-without-url",
+			scopeId:         "global",
+			opts:            []Option{WithPublicKeys(keyPem), WithJwksCaCerts("cert")},
+			wantErrMatch:    errors.T(errors.InvalidParameter),
+			wantErrContains: "jwks ca certs require a jwks url",
+		},
+		{
+			name:            "bad-claim-map",
+			scopeId:         "global",
+			opts:            []Option{WithPublicKeys(keyPem), WithAccountClaimMaps("oid=uid")},
+			wantErrMatch:    errors.T(errors.InvalidParameter),
+			wantErrContains: "uid",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert, require := assert.New(t), require.New(t)
+			got, err := NewAuthMethod(ctx, tt.scopeId, tt.opts...)
+			if tt.wantErrMatch != nil {
+				require.Error(err)
+				assert.Truef(errors.Match(tt.wantErrMatch, err), "want err code: %q got: %q", tt.wantErrMatch.Code, err)
+				assert.Contains(err.Error(), tt.wantErrContains)
+				return
+			}
+			require.NoError(err)
+			assert.Equal(tt.scopeId, got.ScopeId)
+		})
+	}
+}
+
+func TestParseAccountClaimMaps(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		maps    []string
+		want    []ClaimMap
+		wantErr bool
+	}{
+		{
+			name: "empty",
+			want: []ClaimMap{},
+		},
+		{
+			name: "sorted-by-from",
+			maps: []string{"upn=email", " oid = sub "},
+			want: []ClaimMap{{From: "oid", To: "sub"}, {From: "upn", To: "email"}},
+		},
+		{
+			name:    "missing-separator",
+			maps:    []string{"oid"},
+			wantErr: true,
+		},
+		{
+			name:    "unknown-to-claim",
+			maps:    []string{"oid=uid"},
+			wantErr: true,
+		},
+		{
+			name:    "duplicate-to-claim",
+			maps:    []string{"oid=sub", "uid=sub"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := ParseAccountClaimMaps(ctx, tt.maps...)
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.True(t, errors.Match(errors.T(errors.InvalidParameter), err))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestAuthMethod_accountClaims(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	tokenClaims := map[string]any{
+		"iss":   "https://issuer.example.com",
+		"sub":   "repo:hashicorp/boundary",
+		"oid":   "alice-oid",
+		"name":  "Alice",
+		"email": 42,
+	}
+
+	am := AllocAuthMethod()
+	got, err := am.accountClaims(ctx, tokenClaims)
+	require.NoError(t, err)
+	assert.Equal(t, "https://issuer.example.com", got.issuer)
+	assert.Equal(t, "repo:hashicorp/boundary", got.subject)
+	assert.Equal(t, "Alice", got.fullName)
+	assert.Empty(t, got.email, "non-string claims are ignored")
+	assert.NotEmpty(t, got.raw)
+
+	am.AccountClaimMaps = []string{"oid=sub"}
+	got, err = am.accountClaims(ctx, tokenClaims)
+	require.NoError(t, err)
+	assert.Equal(t, "alice-oid", got.subject)
+}
+
+func TestKeySets_get(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	priv, keyPem := testPublicKeyPem(t)
+
+	am := AllocAuthMethod()
+	am.PublicKeys = []string{keyPem}
+	ks, err := keySetCache().get(ctx, &am)
+	require.NoError(t, err)
+
+	token := testSignedToken(t, priv, map[string]any{
+		"sub": "workload",
+		"exp": time.Now().Add(time.Minute).Unix(),
+	})
+
+	claims, err := ks.VerifySignature(ctx, token)
+	require.NoError(t, err)
+	assert.Equal(t, "workload", claims["sub"])
+
+	_, err = keySetCache().get(ctx, nil)
+	require.Error(t, err)
+
+	v, err := capjwt.NewValidator(ks)
+	require.NoError(t, err)
+	_, err = v.Validate(ctx, token, capjwt.Expected{SigningAlgorithms: []capjwt.Alg{capjwt.RS256}})
+	assert.Error(t, err, "token signed with an algorithm that is not allowed")
+}
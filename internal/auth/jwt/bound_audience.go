@@ -0,0 +1,79 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package jwt
+
+import (
+	"context"
+
+	"github.com/hashicorp/boundary/internal/auth/jwt/store"
+	"github.com/hashicorp/boundary/internal/errors"
+	"google.golang.org/protobuf/proto"
+)
+
+// defaultBoundAudienceTableName defines the default table name for a bound
+// audience
+const defaultBoundAudienceTableName = "auth_jwt_bound_audience"
+
+// BoundAudience defines an audience of a JWT AuthMethod. If an AuthMethod
+// has any BoundAudiences, the aud claim of a presented token must contain at
+// least one of them. BoundAudiences are value objects of an AuthMethod.
+type BoundAudience struct {
+	*store.BoundAudience
+	tableName string
+}
+
+// NewBoundAudience creates a new in memory bound audience assigned to a JWT
+// auth method.
+func NewBoundAudience(ctx context.Context, authMethodId string, audience string) (*BoundAudience, error) {
+	const op = "jwt.NewBoundAudience"
+	a := &BoundAudience{
+		BoundAudience: &store.BoundAudience{
+			JwtMethodId: authMethodId,
+			Audience:    audience,
+		},
+	}
+	if err := a.validate(ctx, op); err != nil {
+		return nil, err // intentionally not wrapped
+	}
+	return a, nil
+}
+
+// validate the BoundAudience and on success return nil
+func (a *BoundAudience) validate(ctx context.Context, caller errors.Op) error {
+	if a.JwtMethodId == "" {
+		return errors.New(ctx, errors.InvalidParameter, caller, "missing jwt auth method id")
+	}
+	if a.Audience == "" {
+		return errors.New(ctx, errors.InvalidParameter, caller, "empty audience")
+	}
+	return nil
+}
+
+// AllocBoundAudience makes an empty one in memory
+func AllocBoundAudience() BoundAudience {
+	return BoundAudience{
+		BoundAudience: &store.BoundAudience{},
+	}
+}
+
+// Clone a BoundAudience
+func (a *BoundAudience) Clone() *BoundAudience {
+	cp := proto.Clone(a.BoundAudience)
+	return &BoundAudience{
+		BoundAudience: cp.(*store.BoundAudience),
+	}
+}
+
+// TableName returns the table name.
+func (a *BoundAudience) TableName() string {
+	if a.tableName != "" {
+		return a.tableName
+	}
+	return defaultBoundAudienceTableName
+}
+
+// SetTableName sets the table name.
+func (a *BoundAudience) SetTableName(n string) {
+	a.tableName = n
+}
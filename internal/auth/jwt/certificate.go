@@ -0,0 +1,111 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package jwt
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+
+	"github.com/hashicorp/boundary/internal/auth/jwt/store"
+	"github.com/hashicorp/boundary/internal/errors"
+	"google.golang.org/protobuf/proto"
+)
+
+// defaultCertificateTableName defines the default table name for a certificate
+const defaultCertificateTableName = "auth_jwt_certificate"
+
+// Certificate defines a certificate to use as part of a trust root when
+// fetching the auth method's JWKS. It is assigned to a JWT
+// AuthMethod and updates/deletes to that AuthMethod are cascaded to its
+// Certificates. Certificates are value objects of an AuthMethod, therefore
+// there's no need for oplog metadata, since only the AuthMethod will have
+// metadata because it's the root aggregate.
+type Certificate struct {
+	*store.Certificate
+	tableName string
+}
+
+// NewCertificate creates a new in memory certificate assigned to a JWT auth
+// method.
+func NewCertificate(ctx context.Context, authMethodId string, certificatePem string) (*Certificate, error) {
+	const op = "jwt.NewCertificate"
+	c := &Certificate{
+		Certificate: &store.Certificate{
+			JwtMethodId: authMethodId,
+			Cert:        certificatePem,
+		},
+	}
+	if err := c.validate(ctx, op); err != nil {
+		return nil, err // intentionally not wrapped
+	}
+	return c, nil
+}
+
+// validate the Certificate and on success return nil
+func (c *Certificate) validate(ctx context.Context, caller errors.Op) error {
+	if c.JwtMethodId == "" {
+		return errors.New(ctx, errors.InvalidParameter, caller, "missing jwt auth method id")
+	}
+	if c.Cert == "" {
+		return errors.New(ctx, errors.InvalidParameter, caller, "empty cert")
+	}
+	if _, err := ParseCertificates(ctx, c.Cert); err != nil {
+		return errors.Wrap(ctx, err, caller)
+	}
+	return nil
+}
+
+// ParseCertificates will parse a number of certificate PEMs to x509s.
+func ParseCertificates(ctx context.Context, pems ...string) ([]*x509.Certificate, error) {
+	const op = "jwt.ParseCertificates"
+	if len(pems) == 0 {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "no PEMs provided")
+	}
+	var certs []*x509.Certificate
+	for _, p := range pems {
+		if p == "" {
+			return nil, errors.New(ctx, errors.InvalidParameter, op, "empty certificate PEM")
+		}
+		block, _ := pem.Decode([]byte(p))
+		if block == nil {
+			return nil, errors.New(ctx, errors.InvalidParameter, op, "failed to parse certificate PEM")
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("failed to parse certificate: %s", err.Error()), errors.WithWrap(err))
+		}
+		certs = append(certs, cert)
+	}
+	return certs, nil
+}
+
+// AllocCertificate makes an empty one in memory
+func AllocCertificate() Certificate {
+	return Certificate{
+		Certificate: &store.Certificate{},
+	}
+}
+
+// Clone a Certificate
+func (c *Certificate) Clone() *Certificate {
+	cp := proto.Clone(c.Certificate)
+	return &Certificate{
+		Certificate: cp.(*store.Certificate),
+	}
+}
+
+// TableName returns the table name.
+func (c *Certificate) TableName() string {
+	if c.tableName != "" {
+		return c.tableName
+	}
+	return defaultCertificateTableName
+}
+
+// SetTableName sets the table name.
+func (c *Certificate) SetTableName(n string) {
+	c.tableName = n
+}
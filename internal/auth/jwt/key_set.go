@@ -0,0 +1,86 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package jwt
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/boundary/internal/errors"
+	capjwt "github.com/hashicorp/cap/jwt"
+)
+
+var (
+	// cachedKeySets provides a cache of JWKS key sets. This cache can't be
+	// done within the Repository, since a new Repository is created for every
+	// request.
+	cachedKeySets     *keySets
+	initCachedKeySets sync.Once
+)
+
+// keySetCache returns the cache of key sets
+func keySetCache() *keySets {
+	initCachedKeySets.Do(func() {
+		cachedKeySets = &keySets{
+			cache: map[string]*cachedKeySet{},
+			mu:    &sync.RWMutex{},
+		}
+	})
+	return cachedKeySets
+}
+
+// cachedKeySet is a key set along with the auth method configuration it was
+// created from.
+type cachedKeySet struct {
+	config string
+	keySet capjwt.KeySet
+}
+
+// keySets is a cache of the JWKS key sets used by the Repository to verify
+// token signatures. The cached key sets are preferred since they maintain a
+// cache of the keys fetched from the JWKS URL.
+type keySets struct {
+	cache map[string]*cachedKeySet
+	mu    *sync.RWMutex
+}
+
+// get returns the key set for the AuthMethod. Key sets for static public keys
+// are created on every call. Key sets for a JWKS URL are cached, and the
+// cached key set is replaced whenever the AuthMethod's JWKS URL or CA
+// certificates have changed since it was cached.
+func (c *keySets) get(ctx context.Context, am *AuthMethod) (capjwt.KeySet, error) {
+	const op = "jwt.(keySets).get"
+	if am == nil || am.AuthMethod == nil {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing auth method")
+	}
+	if am.JwksUrl == "" {
+		keys, err := ParsePublicKeys(ctx, am.PublicKeys...)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		ks, err := capjwt.NewStaticKeySet(keys)
+		if err != nil {
+			return nil, errors.New(ctx, errors.InvalidParameter, op, "unable to create static key set", errors.WithWrap(err))
+		}
+		return ks, nil
+	}
+
+	caPem := strings.Join(am.JwksCaCerts, "\n")
+	config := am.JwksUrl + "\n" + caPem
+	c.mu.RLock()
+	cached, ok := c.cache[am.PublicId]
+	c.mu.RUnlock()
+	if ok && cached.config == config {
+		return cached.keySet, nil
+	}
+	ks, err := capjwt.NewJSONWebKeySet(ctx, am.JwksUrl, caPem)
+	if err != nil {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "unable to create jwks key set", errors.WithWrap(err))
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.cache[am.PublicId] = &cachedKeySet{config: config, keySet: ks}
+	return ks, nil
+}
@@ -0,0 +1,178 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package jwt
+
+import (
+	"github.com/hashicorp/boundary/internal/db"
+)
+
+// getOpts - iterate the inbound Options and return a struct.
+func getOpts(opt ...Option) options {
+	opts := getDefaultOptions()
+	for _, o := range opt {
+		o(&opts)
+	}
+	return opts
+}
+
+// Option - how Options are passed as arguments.
+type Option func(*options)
+
+// options = how options are represented
+type options struct {
+	withName                string
+	withDescription         string
+	withLimit               int
+	withPage                *db.Page
+	withOrderByCreateTime   bool
+	ascending               bool
+	withUnauthenticatedUser bool
+	withPublicId            string
+	withIssuer              string
+	withJwksUrl             string
+	withJwksCaCerts         []string
+	withPublicKeys          []string
+	withBoundAudiences      []string
+	withSigningAlgs         []string
+	withAccountClaimMaps    []string
+	withFullName            string
+	withEmail               string
+	withReader              db.Reader
+}
+
+func getDefaultOptions() options {
+	return options{}
+}
+
+// WithName provides an optional name.
+func WithName(name string) Option {
+	return func(o *options) {
+		o.withName = name
+	}
+}
+
+// WithDescription provides an optional description.
+func WithDescription(desc string) Option {
+	return func(o *options) {
+		o.withDescription = desc
+	}
+}
+
+// WithLimit provides an option to provide a limit.  Intentionally allowing
+// negative integers.   If WithLimit < 0, then unlimited results are returned.
+// If WithLimit == 0, then default limits are used for results.
+func WithLimit(l int) Option {
+	return func(o *options) {
+		o.withLimit = l
+	}
+}
+
+// WithPage provides an option to list one page of resources. Use WithLimit to
+// set the size of the page.
+func WithPage(p *db.Page) Option {
+	return func(o *options) {
+		o.withPage = p
+	}
+}
+
+// WithOrderByCreateTime provides an option to specify ordering by the
+// CreateTime field.
+func WithOrderByCreateTime(ascending bool) Option {
+	return func(o *options) {
+		o.withOrderByCreateTime = true
+		o.ascending = ascending
+	}
+}
+
+// WithUnauthenticatedUser provides an option for filtering results for
+// an unauthenticated users.
+func WithUnauthenticatedUser(enabled bool) Option {
+	return func(o *options) {
+		o.withUnauthenticatedUser = enabled
+	}
+}
+
+// WithPublicId provides an option for passing a public id to the operation
+func WithPublicId(publicId string) Option {
+	return func(o *options) {
+		o.withPublicId = publicId
+	}
+}
+
+// WithIssuer provides an optional expected issuer of presented tokens.
+func WithIssuer(issuer string) Option {
+	return func(o *options) {
+		o.withIssuer = issuer
+	}
+}
+
+// WithJwksUrl provides an optional URL of the JSON Web Key Set used to verify
+// token signatures.
+func WithJwksUrl(url string) Option {
+	return func(o *options) {
+		o.withJwksUrl = url
+	}
+}
+
+// WithJwksCaCerts provides optional PEM encoded CA certificates used to
+// verify the TLS certificate of the JWKS URL.
+func WithJwksCaCerts(certs ...string) Option {
+	return func(o *options) {
+		o.withJwksCaCerts = certs
+	}
+}
+
+// WithPublicKeys provides optional PEM encoded public keys used to verify
+// token signatures.
+func WithPublicKeys(keys ...string) Option {
+	return func(o *options) {
+		o.withPublicKeys = keys
+	}
+}
+
+// WithBoundAudiences provides optional audiences, one of which must be in
+// the aud claim of presented tokens.
+func WithBoundAudiences(auds ...string) Option {
+	return func(o *options) {
+		o.withBoundAudiences = auds
+	}
+}
+
+// WithSigningAlgs provides optional signing algorithms allowed for presented
+// tokens.
+func WithSigningAlgs(algs ...string) Option {
+	return func(o *options) {
+		o.withSigningAlgs = algs
+	}
+}
+
+// WithAccountClaimMaps provides optional maps of custom claims to account
+// claims in the form "from=to".
+func WithAccountClaimMaps(claimMaps ...string) Option {
+	return func(o *options) {
+		o.withAccountClaimMaps = claimMaps
+	}
+}
+
+// WithFullName provides an optional full name for the account.
+func WithFullName(n string) Option {
+	return func(o *options) {
+		o.withFullName = n
+	}
+}
+
+// WithEmail provides an optional email address for the account.
+func WithEmail(email string) Option {
+	return func(o *options) {
+		o.withEmail = email
+	}
+}
+
+// WithReader provides an option for specifying a reader to use for the
+// operation.
+func WithReader(reader db.Reader) Option {
+	return func(o *options) {
+		o.withReader = reader
+	}
+}
@@ -0,0 +1,106 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package jwt
+
+import (
+	"context"
+	"crypto"
+	"fmt"
+
+	"github.com/hashicorp/boundary/internal/auth/jwt/store"
+	"github.com/hashicorp/boundary/internal/errors"
+	capjwt "github.com/hashicorp/cap/jwt"
+	"google.golang.org/protobuf/proto"
+)
+
+// defaultPublicKeyTableName defines the default table name for a public key
+const defaultPublicKeyTableName = "auth_jwt_public_key"
+
+// PublicKey defines a static key used to verify the signatures of presented
+// tokens. It is assigned to a JWT AuthMethod and updates/deletes to that
+// AuthMethod are cascaded to its PublicKeys. PublicKeys are value objects of
+// an AuthMethod, therefore there's no need for oplog metadata, since only the
+// AuthMethod will have metadata because it's the root aggregate.
+type PublicKey struct {
+	*store.PublicKey
+	tableName string
+}
+
+// NewPublicKey creates a new in memory public key assigned to a JWT auth
+// method.
+func NewPublicKey(ctx context.Context, authMethodId string, keyPem string) (*PublicKey, error) {
+	const op = "jwt.NewPublicKey"
+	k := &PublicKey{
+		PublicKey: &store.PublicKey{
+			JwtMethodId: authMethodId,
+			Key:         keyPem,
+		},
+	}
+	if err := k.validate(ctx, op); err != nil {
+		return nil, err // intentionally not wrapped
+	}
+	return k, nil
+}
+
+// validate the PublicKey and on success return nil
+func (k *PublicKey) validate(ctx context.Context, caller errors.Op) error {
+	if k.JwtMethodId == "" {
+		return errors.New(ctx, errors.InvalidParameter, caller, "missing jwt auth method id")
+	}
+	if k.Key == "" {
+		return errors.New(ctx, errors.InvalidParameter, caller, "empty public key")
+	}
+	if _, err := ParsePublicKeys(ctx, k.Key); err != nil {
+		return errors.Wrap(ctx, err, caller)
+	}
+	return nil
+}
+
+// ParsePublicKeys will parse a number of public key PEMs.
+func ParsePublicKeys(ctx context.Context, pems ...string) ([]crypto.PublicKey, error) {
+	const op = "jwt.ParsePublicKeys"
+	if len(pems) == 0 {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "no PEMs provided")
+	}
+	keys := make([]crypto.PublicKey, 0, len(pems))
+	for _, p := range pems {
+		if p == "" {
+			return nil, errors.New(ctx, errors.InvalidParameter, op, "empty public key PEM")
+		}
+		key, err := capjwt.ParsePublicKeyPEM([]byte(p))
+		if err != nil {
+			return nil, errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("failed to parse public key: %s", err.Error()), errors.WithWrap(err))
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// AllocPublicKey makes an empty one in memory
+func AllocPublicKey() PublicKey {
+	return PublicKey{
+		PublicKey: &store.PublicKey{},
+	}
+}
+
+// Clone a PublicKey
+func (k *PublicKey) Clone() *PublicKey {
+	cp := proto.Clone(k.PublicKey)
+	return &PublicKey{
+		PublicKey: cp.(*store.PublicKey),
+	}
+}
+
+// TableName returns the table name.
+func (k *PublicKey) TableName() string {
+	if k.tableName != "" {
+		return k.tableName
+	}
+	return defaultPublicKeyTableName
+}
+
+// SetTableName sets the table name.
+func (k *PublicKey) SetTableName(n string) {
+	k.tableName = n
+}
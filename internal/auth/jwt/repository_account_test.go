@@ -0,0 +1,322 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package jwt
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/auth/jwt/store"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_CreateAccount(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	org, _ := iam.TestScopes(t, iamRepo)
+	ctx := context.Background()
+	_, keyPem := testPublicKeyPem(t)
+
+	repo, err := NewRepository(ctx, rw, rw, kmsCache)
+	require.NoError(t, err)
+
+	am := TestAuthMethod(t, conn, org.PublicId,
+		WithIssuer("https://issuer.example.com"),
+		WithBoundAudiences("boundary"),
+		WithPublicKeys(keyPem),
+	)
+
+	tests := []struct {
+		name      string
+		scopeId   string
+		in        *Account
+		opts      []Option
+		wantIsErr errors.Code
+	}{
+		{
+			name:      "nil-account",
+			scopeId:   org.PublicId,
+			wantIsErr: errors.InvalidParameter,
+		},
+		{
+			name:      "nil-embedded-account",
+			scopeId:   org.PublicId,
+			in:        &Account{},
+			wantIsErr: errors.InvalidParameter,
+		},
+		{
+			name:      "missing-auth-method-id",
+			scopeId:   org.PublicId,
+			in:        &Account{Account: &store.Account{Subject: "missing-auth-method-id"}},
+			wantIsErr: errors.InvalidParameter,
+		},
+		{
+			name:      "missing-subject",
+			scopeId:   org.PublicId,
+			in:        &Account{Account: &store.Account{AuthMethodId: am.PublicId}},
+			wantIsErr: errors.InvalidParameter,
+		},
+		{
+			name:    "public-id-set",
+			scopeId: org.PublicId,
+			in: &Account{Account: &store.Account{
+				AuthMethodId: am.PublicId,
+				Subject:      "public-id-set",
+				PublicId:     AccountPrefix + "_1234567890",
+			}},
+			wantIsErr: errors.InvalidParameter,
+		},
+		{
+			name:      "missing-scope-id",
+			in:        &Account{Account: &store.Account{AuthMethodId: am.PublicId, Subject: "missing-scope-id"}},
+			wantIsErr: errors.InvalidParameter,
+		},
+		{
+			name:      "bad-public-id-prefix",
+			scopeId:   org.PublicId,
+			in:        &Account{Account: &store.Account{AuthMethodId: am.PublicId, Subject: "bad-prefix"}},
+			opts:      []Option{WithPublicId("acctoidc_1234567890")},
+			wantIsErr: errors.InvalidParameter,
+		},
+		{
+			name:    "valid",
+			scopeId: org.PublicId,
+			in: &Account{Account: &store.Account{
+				AuthMethodId: am.PublicId,
+				Subject:      "valid",
+				Name:         "valid",
+				Description:  "description",
+			}},
+		},
+		{
+			name:    "valid-with-public-id",
+			scopeId: org.PublicId,
+			in:      &Account{Account: &store.Account{AuthMethodId: am.PublicId, Subject: "valid-with-public-id"}},
+			opts:    []Option{WithPublicId(AccountPrefix + "_1234567890")},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, err := repo.CreateAccount(ctx, tt.scopeId, tt.in, tt.opts...)
+			if tt.wantIsErr != 0 {
+				require.Error(err)
+				assert.Truef(errors.Match(errors.T(tt.wantIsErr), err), "want err code: %q got: %q", tt.wantIsErr, err)
+				assert.Nil(got)
+				return
+			}
+			require.NoError(err)
+			assert.Equal(tt.in.Subject, got.Subject)
+			assert.Equal(tt.in.Name, got.Name)
+			assert.Equal(tt.in.Description, got.Description)
+			assert.Equal(uint32(1), got.Version)
+			wantId := getOpts(tt.opts...).withPublicId
+			if wantId == "" {
+				// an account created through the api has the same id it
+				// would be given when its subject authenticates.
+				wantId, err = newAccountId(ctx, am.PublicId, tt.in.Subject)
+				require.NoError(err)
+			}
+			assert.Equal(wantId, got.PublicId)
+			assert.NoError(db.TestVerifyOplog(t, rw, got.PublicId, db.WithOperation(oplog.OpType_OP_TYPE_CREATE), db.WithCreateNotBefore(10*time.Second)))
+		})
+	}
+
+	t.Run("duplicate-subject", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		in := &Account{Account: &store.Account{AuthMethodId: am.PublicId, Subject: "duplicate"}}
+		_, err := repo.CreateAccount(ctx, org.PublicId, in)
+		require.NoError(err)
+		_, err = repo.CreateAccount(ctx, org.PublicId, in, WithPublicId(AccountPrefix+"_0987654321"))
+		require.Error(err)
+		assert.True(errors.Match(errors.T(errors.NotUnique), err))
+	})
+}
+
+func TestRepository_LookupListDeleteAccount(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	org, _ := iam.TestScopes(t, iamRepo)
+	ctx := context.Background()
+	assert, require := assert.New(t), require.New(t)
+	_, keyPem := testPublicKeyPem(t)
+
+	repo, err := NewRepository(ctx, rw, rw, kmsCache)
+	require.NoError(err)
+
+	opts := []Option{
+		WithIssuer("https://issuer.example.com"),
+		WithBoundAudiences("boundary"),
+		WithPublicKeys(keyPem),
+	}
+	am := TestAuthMethod(t, conn, org.PublicId, opts...)
+	otherAm := TestAuthMethod(t, conn, org.PublicId, opts...)
+	a1 := TestAccount(t, conn, am, "one")
+	a2 := TestAccount(t, conn, am, "two")
+	TestAccount(t, conn, otherAm, "one")
+
+	_, err = repo.LookupAccount(ctx, "")
+	assert.True(errors.Match(errors.T(errors.InvalidPublicId), err))
+	got, err := repo.LookupAccount(ctx, AccountPrefix+"_1234567890")
+	require.NoError(err)
+	assert.Nil(got)
+	got, err = repo.LookupAccount(ctx, a1.PublicId)
+	require.NoError(err)
+	require.NotNil(got)
+	assert.Equal("one", got.Subject)
+	assert.Equal(am.PublicId, got.AuthMethodId)
+
+	_, err = repo.ListAccounts(ctx, "")
+	assert.True(errors.Match(errors.T(errors.InvalidParameter), err))
+	accts, err := repo.ListAccounts(ctx, am.PublicId)
+	require.NoError(err)
+	ids := make([]string, 0, len(accts))
+	for _, a := range accts {
+		ids = append(ids, a.PublicId)
+	}
+	assert.ElementsMatch([]string{a1.PublicId, a2.PublicId}, ids)
+	accts, err = repo.ListAccounts(ctx, am.PublicId, WithLimit(1))
+	require.NoError(err)
+	assert.Len(accts, 1)
+
+	_, err = repo.DeleteAccount(ctx, org.PublicId, "")
+	assert.True(errors.Match(errors.T(errors.InvalidPublicId), err))
+	_, err = repo.DeleteAccount(ctx, "", a1.PublicId)
+	assert.True(errors.Match(errors.T(errors.InvalidParameter), err))
+	n, err := repo.DeleteAccount(ctx, org.PublicId, a1.PublicId)
+	require.NoError(err)
+	assert.Equal(1, n)
+	assert.NoError(db.TestVerifyOplog(t, rw, a1.PublicId, db.WithOperation(oplog.OpType_OP_TYPE_DELETE), db.WithCreateNotBefore(10*time.Second)))
+	got, err = repo.LookupAccount(ctx, a1.PublicId)
+	require.NoError(err)
+	assert.Nil(got)
+}
+
+func TestRepository_UpdateAccount(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	org, _ := iam.TestScopes(t, iamRepo)
+	ctx := context.Background()
+	_, keyPem := testPublicKeyPem(t)
+
+	repo, err := NewRepository(ctx, rw, rw, kmsCache)
+	require.NoError(t, err)
+
+	am := TestAuthMethod(t, conn, org.PublicId,
+		WithIssuer("https://issuer.example.com"),
+		WithBoundAudiences("boundary"),
+		WithPublicKeys(keyPem),
+	)
+	TestAccount(t, conn, am, "taken", WithName("taken"))
+
+	tests := []struct {
+		name      string
+		scopeId   string
+		update    func(a *Account) *Account
+		mask      []string
+		version   uint32
+		wantIsErr errors.Code
+	}{
+		{
+			name:      "nil-account",
+			scopeId:   org.PublicId,
+			update:    func(*Account) *Account { return nil },
+			mask:      []string{NameField},
+			version:   1,
+			wantIsErr: errors.InvalidParameter,
+		},
+		{
+			name:      "missing-public-id",
+			scopeId:   org.PublicId,
+			update:    func(a *Account) *Account { a.PublicId = ""; return a },
+			mask:      []string{NameField},
+			version:   1,
+			wantIsErr: errors.InvalidPublicId,
+		},
+		{
+			name:      "missing-version",
+			scopeId:   org.PublicId,
+			update:    func(a *Account) *Account { return a },
+			mask:      []string{NameField},
+			wantIsErr: errors.InvalidParameter,
+		},
+		{
+			name:      "missing-scope-id",
+			update:    func(a *Account) *Account { return a },
+			mask:      []string{NameField},
+			version:   1,
+			wantIsErr: errors.InvalidParameter,
+		},
+		{
+			name:      "subject-is-immutable",
+			scopeId:   org.PublicId,
+			update:    func(a *Account) *Account { a.Subject = "changed"; return a },
+			mask:      []string{"Subject"},
+			version:   1,
+			wantIsErr: errors.InvalidFieldMask,
+		},
+		{
+			name:      "empty-mask",
+			scopeId:   org.PublicId,
+			update:    func(a *Account) *Account { return a },
+			version:   1,
+			wantIsErr: errors.EmptyFieldMask,
+		},
+		{
+			name:      "duplicate-name",
+			scopeId:   org.PublicId,
+			update:    func(a *Account) *Account { a.Name = "taken"; return a },
+			mask:      []string{NameField},
+			version:   1,
+			wantIsErr: errors.NotUnique,
+		},
+		{
+			name:    "name-and-description",
+			scopeId: org.PublicId,
+			update: func(a *Account) *Account {
+				a.Name = "updated"
+				a.Description = "updated"
+				return a
+			},
+			mask:    []string{NameField, DescriptionField},
+			version: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			orig := TestAccount(t, conn, am, tt.name, WithName(tt.name))
+			got, n, err := repo.UpdateAccount(ctx, tt.scopeId, tt.update(orig.Clone()), tt.version, tt.mask)
+			if tt.wantIsErr != 0 {
+				require.Error(err)
+				assert.Truef(errors.Match(errors.T(tt.wantIsErr), err), "want err code: %q got: %q", tt.wantIsErr, err)
+				assert.Equal(db.NoRowsAffected, n)
+				return
+			}
+			require.NoError(err)
+			assert.Equal(1, n)
+			assert.Equal("updated", got.Name)
+			assert.Equal("updated", got.Description)
+			assert.Equal(orig.Subject, got.Subject)
+			assert.Equal(uint32(2), got.Version)
+			assert.NoError(db.TestVerifyOplog(t, rw, got.PublicId, db.WithOperation(oplog.OpType_OP_TYPE_UPDATE), db.WithCreateNotBefore(10*time.Second)))
+		})
+	}
+}
@@ -0,0 +1,365 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package jwt
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_CreateAuthMethod(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	org, _ := iam.TestScopes(t, iamRepo)
+	ctx := context.Background()
+	_, keyPem := testPublicKeyPem(t)
+
+	repo, err := NewRepository(ctx, rw, rw, kmsCache)
+	require.NoError(t, err)
+
+	newAm := func(opt ...Option) *AuthMethod {
+		am := AllocAuthMethod()
+		am.ScopeId = org.PublicId
+		opts := getOpts(opt...)
+		am.Name = opts.withName
+		am.Issuer = opts.withIssuer
+		am.JwksUrl = opts.withJwksUrl
+		am.PublicKeys = opts.withPublicKeys
+		am.BoundAudiences = opts.withBoundAudiences
+		am.SigningAlgorithms = opts.withSigningAlgs
+		am.AccountClaimMaps = opts.withAccountClaimMaps
+		return &am
+	}
+	valid := []Option{
+		WithIssuer("https://issuer.example.com"),
+		WithBoundAudiences("boundary", "other"),
+		WithSigningAlgs("ES256"),
+		WithPublicKeys(keyPem),
+	}
+
+	tests := []struct {
+		name      string
+		am        *AuthMethod
+		opt       []Option
+		wantIsErr errors.Code
+	}{
+		{
+			name:      "nil-auth-method",
+			wantIsErr: errors.InvalidParameter,
+		},
+		{
+			name: "public-id-set",
+			am: func() *AuthMethod {
+				am := newAm(valid...)
+				am.PublicId = AuthMethodPrefix + "_1234567890"
+				return am
+			}(),
+			wantIsErr: errors.InvalidParameter,
+		},
+		{
+			name:      "missing-issuer",
+			am:        newAm(WithBoundAudiences("boundary"), WithPublicKeys(keyPem)),
+			wantIsErr: errors.InvalidParameter,
+		},
+		{
+			name:      "missing-bound-audiences",
+			am:        newAm(WithIssuer("https://issuer.example.com"), WithPublicKeys(keyPem)),
+			wantIsErr: errors.InvalidParameter,
+		},
+		{
+			name:      "missing-keys",
+			am:        newAm(WithIssuer("https://issuer.example.com"), WithBoundAudiences("boundary")),
+			wantIsErr: errors.InvalidParameter,
+		},
+		{
+			name:      "bad-public-id-prefix",
+			am:        newAm(valid...),
+			opt:       []Option{WithPublicId("amoidc_1234567890")},
+			wantIsErr: errors.InvalidParameter,
+		},
+		{
+			name: "valid",
+			am:   newAm(append(valid, WithName("valid"), WithAccountClaimMaps("oid=sub"))...),
+		},
+		{
+			name: "valid-jwks-url",
+			am: newAm(
+				WithIssuer("https://issuer.example.com"),
+				WithBoundAudiences("boundary"),
+				WithJwksUrl("https://issuer.example.com/.well-known/jwks.json"),
+			),
+		},
+		{
+			name: "valid-with-public-id",
+			am:   newAm(valid...),
+			opt:  []Option{WithPublicId(AuthMethodPrefix + "_1234567890")},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, err := repo.CreateAuthMethod(ctx, tt.am, tt.opt...)
+			if tt.wantIsErr != 0 {
+				require.Error(err)
+				assert.Truef(errors.Match(errors.T(tt.wantIsErr), err), "want err code: %q got: %q", tt.wantIsErr, err)
+				assert.Nil(got)
+				return
+			}
+			require.NoError(err)
+			assert.NotEmpty(got.PublicId)
+			assert.Equal(uint32(1), got.Version)
+			assert.Equal(tt.am.Issuer, got.Issuer)
+			assert.ElementsMatch(tt.am.BoundAudiences, got.BoundAudiences)
+			assert.ElementsMatch(tt.am.PublicKeys, got.PublicKeys)
+			assert.ElementsMatch(tt.am.SigningAlgorithms, got.SigningAlgorithms)
+			assert.ElementsMatch(tt.am.AccountClaimMaps, got.AccountClaimMaps)
+			assert.NoError(db.TestVerifyOplog(t, rw, got.PublicId, db.WithOperation(oplog.OpType_OP_TYPE_CREATE), db.WithCreateNotBefore(10*time.Second)))
+
+			found, err := repo.LookupAuthMethod(ctx, got.PublicId)
+			require.NoError(err)
+			assert.Equal(got.Version, found.Version)
+			assert.Equal(got.Issuer, found.Issuer)
+			assert.Equal(got.JwksUrl, found.JwksUrl)
+			assert.ElementsMatch(got.BoundAudiences, found.BoundAudiences)
+			assert.ElementsMatch(got.PublicKeys, found.PublicKeys)
+		})
+	}
+
+	t.Run("duplicate-name", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		_, err := repo.CreateAuthMethod(ctx, newAm(append(valid, WithName("dup"))...))
+		require.NoError(err)
+		_, err = repo.CreateAuthMethod(ctx, newAm(append(valid, WithName("dup"))...))
+		require.Error(err)
+		assert.True(errors.Match(errors.T(errors.NotUnique), err))
+	})
+}
+
+func TestRepository_LookupListDeleteAuthMethod(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	org, proj := iam.TestScopes(t, iamRepo)
+	org2, _ := iam.TestScopes(t, iamRepo)
+	ctx := context.Background()
+	assert, require := assert.New(t), require.New(t)
+	_, keyPem := testPublicKeyPem(t)
+
+	repo, err := NewRepository(ctx, rw, rw, kmsCache)
+	require.NoError(err)
+
+	opts := []Option{
+		WithIssuer("https://issuer.example.com"),
+		WithBoundAudiences("boundary"),
+		WithPublicKeys(keyPem),
+	}
+	am1 := TestAuthMethod(t, conn, org.PublicId, opts...)
+	am2 := TestAuthMethod(t, conn, org.PublicId, opts...)
+	am3 := TestAuthMethod(t, conn, org2.PublicId, opts...)
+
+	_, err = repo.LookupAuthMethod(ctx, "")
+	assert.True(errors.Match(errors.T(errors.InvalidParameter), err))
+	got, err := repo.LookupAuthMethod(ctx, AuthMethodPrefix+"_1234567890")
+	require.NoError(err)
+	assert.Nil(got)
+	got, err = repo.LookupAuthMethod(ctx, am1.PublicId)
+	require.NoError(err)
+	require.NotNil(got)
+	assert.Equal([]string{"boundary"}, got.BoundAudiences)
+	assert.Equal([]string{keyPem}, got.PublicKeys)
+
+	_, err = repo.ListAuthMethods(ctx, nil)
+	assert.True(errors.Match(errors.T(errors.InvalidParameter), err))
+	ams, err := repo.ListAuthMethods(ctx, []string{org.PublicId})
+	require.NoError(err)
+	assert.ElementsMatch([]string{am1.PublicId, am2.PublicId}, authMethodIds(ams))
+	ams, err = repo.ListAuthMethods(ctx, []string{org.PublicId, org2.PublicId})
+	require.NoError(err)
+	assert.ElementsMatch([]string{am1.PublicId, am2.PublicId, am3.PublicId}, authMethodIds(ams))
+	ams, err = repo.ListAuthMethods(ctx, []string{proj.PublicId})
+	require.NoError(err)
+	assert.Empty(ams)
+	ams, err = repo.ListAuthMethods(ctx, []string{org.PublicId, org2.PublicId}, WithLimit(1))
+	require.NoError(err)
+	assert.Len(ams, 1)
+
+	_, err = repo.DeleteAuthMethod(ctx, "")
+	assert.True(errors.Match(errors.T(errors.InvalidPublicId), err))
+	n, err := repo.DeleteAuthMethod(ctx, am1.PublicId)
+	require.NoError(err)
+	assert.Equal(1, n)
+	assert.NoError(db.TestVerifyOplog(t, rw, am1.PublicId, db.WithOperation(oplog.OpType_OP_TYPE_DELETE), db.WithCreateNotBefore(10*time.Second)))
+	n, err = repo.DeleteAuthMethod(ctx, am1.PublicId)
+	require.NoError(err)
+	assert.Equal(0, n, "deleting a deleted auth method is not an error")
+	got, err = repo.LookupAuthMethod(ctx, am1.PublicId)
+	require.NoError(err)
+	assert.Nil(got)
+}
+
+func TestRepository_UpdateAuthMethod(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	org, _ := iam.TestScopes(t, iamRepo)
+	ctx := context.Background()
+	_, keyPem := testPublicKeyPem(t)
+	_, otherKeyPem := testPublicKeyPem(t)
+
+	repo, err := NewRepository(ctx, rw, rw, kmsCache)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		update    func(am *AuthMethod) *AuthMethod
+		mask      []string
+		version   func(am *AuthMethod) uint32
+		wantIsErr errors.Code
+		want      func(t *testing.T, got *AuthMethod)
+	}{
+		{
+			name:      "missing-public-id",
+			update:    func(am *AuthMethod) *AuthMethod { am.PublicId = ""; return am },
+			mask:      []string{NameField},
+			wantIsErr: errors.InvalidParameter,
+		},
+		{
+			name:      "empty-mask",
+			update:    func(am *AuthMethod) *AuthMethod { return am },
+			wantIsErr: errors.EmptyFieldMask,
+		},
+		{
+			name:      "version-mismatch",
+			update:    func(am *AuthMethod) *AuthMethod { am.Name = "mismatch"; return am },
+			mask:      []string{NameField},
+			version:   func(am *AuthMethod) uint32 { return am.Version + 1 },
+			wantIsErr: errors.VersionMismatch,
+		},
+		{
+			name:      "clear-issuer",
+			update:    func(am *AuthMethod) *AuthMethod { am.Issuer = ""; return am },
+			mask:      []string{IssuerField},
+			wantIsErr: errors.InvalidParameter,
+		},
+		{
+			name:      "clear-bound-audiences",
+			update:    func(am *AuthMethod) *AuthMethod { am.BoundAudiences = nil; return am },
+			mask:      []string{BoundAudiencesField},
+			wantIsErr: errors.InvalidParameter,
+		},
+		{
+			name:      "clear-public-keys",
+			update:    func(am *AuthMethod) *AuthMethod { am.PublicKeys = nil; return am },
+			mask:      []string{PublicKeysField},
+			wantIsErr: errors.InvalidParameter,
+		},
+		{
+			name: "jwks-url-and-public-keys",
+			update: func(am *AuthMethod) *AuthMethod {
+				am.JwksUrl = "https://issuer.example.com/.well-known/jwks.json"
+				return am
+			},
+			mask:      []string{JwksUrlField},
+			wantIsErr: errors.InvalidParameter,
+		},
+		{
+			name: "name-and-issuer",
+			update: func(am *AuthMethod) *AuthMethod {
+				am.Name = "updated"
+				am.Issuer = "https://other.example.com"
+				return am
+			},
+			mask: []string{NameField, IssuerField},
+			want: func(t *testing.T, got *AuthMethod) {
+				assert.Equal(t, "updated", got.Name)
+				assert.Equal(t, "https://other.example.com", got.Issuer)
+				assert.Equal(t, []string{"boundary"}, got.BoundAudiences)
+			},
+		},
+		{
+			name: "value-objects",
+			update: func(am *AuthMethod) *AuthMethod {
+				am.BoundAudiences = []string{"a", "b"}
+				am.PublicKeys = []string{otherKeyPem}
+				am.AccountClaimMaps = []string{"oid=sub"}
+				return am
+			},
+			mask: []string{BoundAudiencesField, PublicKeysField, AccountClaimMapsField},
+			want: func(t *testing.T, got *AuthMethod) {
+				assert.ElementsMatch(t, []string{"a", "b"}, got.BoundAudiences)
+				assert.Equal(t, []string{otherKeyPem}, got.PublicKeys)
+				assert.Equal(t, []string{"oid=sub"}, got.AccountClaimMaps)
+			},
+		},
+		{
+			name: "public-keys-to-jwks-url",
+			update: func(am *AuthMethod) *AuthMethod {
+				am.PublicKeys = nil
+				am.JwksUrl = "https://issuer.example.com/.well-known/jwks.json"
+				return am
+			},
+			mask: []string{PublicKeysField, JwksUrlField},
+			want: func(t *testing.T, got *AuthMethod) {
+				assert.Empty(t, got.PublicKeys)
+				assert.Equal(t, "https://issuer.example.com/.well-known/jwks.json", got.JwksUrl)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			orig := TestAuthMethod(t, conn, org.PublicId,
+				WithIssuer("https://issuer.example.com"),
+				WithBoundAudiences("boundary"),
+				WithPublicKeys(keyPem),
+			)
+			orig, err := repo.LookupAuthMethod(ctx, orig.PublicId)
+			require.NoError(err)
+			version := orig.Version
+			if tt.version != nil {
+				version = tt.version(orig)
+			}
+			got, n, err := repo.UpdateAuthMethod(ctx, tt.update(orig.Clone()), version, tt.mask)
+			if tt.wantIsErr != 0 {
+				require.Error(err)
+				assert.Truef(errors.Match(errors.T(tt.wantIsErr), err), "want err code: %q got: %q", tt.wantIsErr, err)
+				assert.Equal(db.NoRowsAffected, n)
+				return
+			}
+			require.NoError(err)
+			assert.Equal(1, n)
+			assert.Equal(orig.Version+1, got.Version)
+			tt.want(t, got)
+			assert.NoError(db.TestVerifyOplog(t, rw, orig.PublicId, db.WithOperation(oplog.OpType_OP_TYPE_UPDATE), db.WithCreateNotBefore(10*time.Second)))
+
+			found, err := repo.LookupAuthMethod(ctx, orig.PublicId)
+			require.NoError(err)
+			tt.want(t, found)
+		})
+	}
+}
+
+func authMethodIds(ams []*AuthMethod) []string {
+	ids := make([]string, 0, len(ams))
+	for _, am := range ams {
+		ids = append(ids, am.PublicId)
+	}
+	return ids
+}
@@ -0,0 +1,339 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package jwt
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/hashicorp/go-dbw"
+	"github.com/hashicorp/go-secure-stdlib/strutil"
+)
+
+const (
+	VersionField           = "Version"
+	NameField              = "Name"
+	DescriptionField       = "Description"
+	IssuerField            = "Issuer"
+	JwksUrlField           = "JwksUrl"
+	JwksCaCertsField       = "JwksCaCerts"
+	PublicKeysField        = "PublicKeys"
+	BoundAudiencesField    = "BoundAudiences"
+	SigningAlgorithmsField = "SigningAlgorithms"
+	AccountClaimMapsField  = "AccountClaimMaps"
+	FilterField            = "Filter"
+)
+
+// valueObjectFields are the AuthMethod fields that are stored as value
+// objects rather than columns of the auth method.
+var valueObjectFields = []string{
+	JwksCaCertsField,
+	PublicKeysField,
+	BoundAudiencesField,
+	SigningAlgorithmsField,
+	AccountClaimMapsField,
+}
+
+// UpdateAuthMethod will retrieve the auth method from the repository, and
+// update it based on the field masks provided.
+//
+// fieldMaskPaths provides field_mask.proto paths for fields that should be
+// updated. Fields will be set to NULL if the field is a zero value and
+// included in fieldMask. The AuthMethod's value objects of JwksCaCerts,
+// PublicKeys, BoundAudiences, SigningAlgorithms and AccountClaimMaps are also
+// updatable and are replaced as a whole when they're included in the
+// fieldMask. The updated AuthMethod must still have exactly one of JwksUrl or
+// PublicKeys. If no updatable fields are included in the fieldMaskPaths, then
+// an error is returned.
+//
+// No options are currently supported.
+func (r *Repository) UpdateAuthMethod(ctx context.Context, am *AuthMethod, version uint32, fieldMaskPaths []string, _ ...Option) (*AuthMethod, int, error) {
+	const op = "jwt.(Repository).UpdateAuthMethod"
+	if am == nil || am.AuthMethod == nil {
+		return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing auth method")
+	}
+	if am.PublicId == "" {
+		return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing public id")
+	}
+	if err := validateFieldMask(ctx, fieldMaskPaths); err != nil {
+		return nil, db.NoRowsAffected, errors.Wrap(ctx, err, op)
+	}
+
+	dbMask, nullFields := dbw.BuildUpdatePaths(
+		map[string]any{
+			NameField:              am.Name,
+			DescriptionField:       am.Description,
+			IssuerField:            am.Issuer,
+			JwksUrlField:           am.JwksUrl,
+			JwksCaCertsField:       am.JwksCaCerts,
+			PublicKeysField:        am.PublicKeys,
+			BoundAudiencesField:    am.BoundAudiences,
+			SigningAlgorithmsField: am.SigningAlgorithms,
+			AccountClaimMapsField:  am.AccountClaimMaps,
+		},
+		fieldMaskPaths,
+		nil,
+	)
+	if len(dbMask) == 0 && len(nullFields) == 0 {
+		return nil, db.NoRowsAffected, errors.New(ctx, errors.EmptyFieldMask, op, "empty field mask")
+	}
+
+	origAm, err := r.lookupAuthMethod(ctx, am.PublicId)
+	if err != nil {
+		return nil, db.NoRowsAffected, errors.Wrap(ctx, err, op)
+	}
+	if origAm == nil {
+		return nil, db.NoRowsAffected, errors.New(ctx, errors.RecordNotFound, op, fmt.Sprintf("auth method %s", am.PublicId))
+	}
+	// there's no reason to continue if another controller has already updated this auth method.
+	if origAm.Version != version {
+		return nil, db.NoRowsAffected, errors.New(ctx, errors.VersionMismatch, op, fmt.Sprintf("update version %d doesn't match db version %d", version, origAm.Version))
+	}
+	am.ScopeId = origAm.ScopeId
+	if err := applyUpdate(am, origAm, fieldMaskPaths).validate(ctx, op); err != nil {
+		return nil, db.NoRowsAffected, err // intentionally not wrapped.
+	}
+
+	addCerts, deleteCerts, err := replaceValueObjects(ctx, JwksCaCertsField, am, origAm, dbMask, nullFields, (*AuthMethod).convertCertificates)
+	if err != nil {
+		return nil, db.NoRowsAffected, errors.Wrap(ctx, err, op)
+	}
+	addKeys, deleteKeys, err := replaceValueObjects(ctx, PublicKeysField, am, origAm, dbMask, nullFields, (*AuthMethod).convertPublicKeys)
+	if err != nil {
+		return nil, db.NoRowsAffected, errors.Wrap(ctx, err, op)
+	}
+	addAuds, deleteAuds, err := replaceValueObjects(ctx, BoundAudiencesField, am, origAm, dbMask, nullFields, (*AuthMethod).convertBoundAudiences)
+	if err != nil {
+		return nil, db.NoRowsAffected, errors.Wrap(ctx, err, op)
+	}
+	addAlgs, deleteAlgs, err := replaceValueObjects(ctx, SigningAlgorithmsField, am, origAm, dbMask, nullFields, (*AuthMethod).convertSigningAlgs)
+	if err != nil {
+		return nil, db.NoRowsAffected, errors.Wrap(ctx, err, op)
+	}
+	addMaps, deleteMaps, err := replaceValueObjects(ctx, AccountClaimMapsField, am, origAm, dbMask, nullFields, (*AuthMethod).convertAccountClaimMaps)
+	if err != nil {
+		return nil, db.NoRowsAffected, errors.Wrap(ctx, err, op)
+	}
+
+	var filteredDbMask, filteredNullFields []string
+	for _, f := range dbMask {
+		if strutil.StrListContains(valueObjectFields, f) {
+			continue
+		}
+		filteredDbMask = append(filteredDbMask, f)
+	}
+	for _, f := range nullFields {
+		if strutil.StrListContains(valueObjectFields, f) {
+			continue
+		}
+		filteredNullFields = append(filteredNullFields, f)
+	}
+
+	valueObjects := []struct {
+		name        string
+		add, delete []any
+	}{
+		{name: "jwks ca certs", add: addCerts, delete: deleteCerts},
+		{name: "public keys", add: addKeys, delete: deleteKeys},
+		{name: "bound audiences", add: addAuds, delete: deleteAuds},
+		{name: "signing algorithms", add: addAlgs, delete: deleteAlgs},
+		{name: "account claim maps", add: addMaps, delete: deleteMaps},
+	}
+
+	// handle no changes...
+	changed := len(filteredDbMask) > 0 || len(filteredNullFields) > 0
+	for _, vo := range valueObjects {
+		changed = changed || len(vo.add) > 0 || len(vo.delete) > 0
+	}
+	if !changed {
+		return origAm, db.NoRowsAffected, nil
+	}
+
+	oplogWrapper, err := r.kms.GetWrapper(ctx, origAm.ScopeId, kms.KeyPurposeOplog)
+	if err != nil {
+		return nil, db.NoRowsAffected, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get oplog wrapper"))
+	}
+
+	var updatedAm *AuthMethod
+	var rowsUpdated int
+	_, err = r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(reader db.Reader, w db.Writer) error {
+			msgs := make([]*oplog.Message, 0, 1+2*len(valueObjects))
+			ticket, err := w.GetTicket(ctx, am)
+			if err != nil {
+				return errors.Wrap(ctx, err, op, errors.WithMsg("unable to get ticket"))
+			}
+			var authMethodOplogMsg oplog.Message
+			switch {
+			case len(filteredDbMask) == 0 && len(filteredNullFields) == 0:
+				// the auth method's fields are not being updated, just it's value objects, so we need to just update the auth
+				// method's version.
+				updatedAm = am.Clone()
+				updatedAm.Version = uint32(version) + 1
+				rowsUpdated, err = w.Update(ctx, updatedAm, []string{VersionField}, nil, db.NewOplogMsg(&authMethodOplogMsg), db.WithVersion(&version))
+				if err != nil {
+					return errors.Wrap(ctx, err, op, errors.WithMsg("unable to update auth method version"))
+				}
+			default:
+				updatedAm = am.Clone()
+				rowsUpdated, err = w.Update(ctx, updatedAm, filteredDbMask, filteredNullFields, db.NewOplogMsg(&authMethodOplogMsg), db.WithVersion(&version))
+				if err != nil {
+					return errors.Wrap(ctx, err, op, errors.WithMsg("unable to update auth method"))
+				}
+			}
+			if rowsUpdated != 1 {
+				return errors.New(ctx, errors.MultipleRecords, op, fmt.Sprintf("updated auth method and %d rows updated", rowsUpdated))
+			}
+			msgs = append(msgs, &authMethodOplogMsg)
+
+			for _, vo := range valueObjects {
+				if len(vo.delete) > 0 {
+					deleteOplogMsgs := make([]*oplog.Message, 0, len(vo.delete))
+					rowsDeleted, err := w.DeleteItems(ctx, vo.delete, db.NewOplogMsgs(&deleteOplogMsgs))
+					if err != nil {
+						return errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("unable to delete %s", vo.name)))
+					}
+					if rowsDeleted != len(vo.delete) {
+						return errors.New(ctx, errors.MultipleRecords, op, fmt.Sprintf("%s deleted %d did not match request for %d", vo.name, rowsDeleted, len(vo.delete)))
+					}
+					msgs = append(msgs, deleteOplogMsgs...)
+				}
+				if len(vo.add) > 0 {
+					addOplogMsgs := make([]*oplog.Message, 0, len(vo.add))
+					if err := w.CreateItems(ctx, vo.add, db.NewOplogMsgs(&addOplogMsgs)); err != nil {
+						return errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("unable to add %s", vo.name)))
+					}
+					msgs = append(msgs, addOplogMsgs...)
+				}
+			}
+
+			metadata := updatedAm.oplog(oplog.OpType_OP_TYPE_UPDATE)
+			if err := w.WriteOplogEntryWith(ctx, oplogWrapper, ticket, metadata, msgs); err != nil {
+				return errors.Wrap(ctx, err, op, errors.WithMsg("unable to write oplog"))
+			}
+			// we need a new repo, that's using the same reader/writer as this TxHandler
+			txRepo := &Repository{
+				reader: reader,
+				writer: w,
+				kms:    r.kms,
+			}
+			updatedAm, err = txRepo.lookupAuthMethod(ctx, updatedAm.PublicId)
+			if err != nil {
+				return errors.Wrap(ctx, err, op, errors.WithMsg("unable to lookup auth method after update"))
+			}
+			if updatedAm == nil {
+				return errors.New(ctx, errors.RecordNotFound, op, "unable to lookup auth method after update")
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, db.NoRowsAffected, errors.Wrap(ctx, err, op)
+	}
+	return updatedAm, rowsUpdated, nil
+}
+
+// replaceValueObjects returns the value objects to add and delete in order to
+// replace the original auth method's value objects for the named field with
+// the new auth method's. Value objects are replaced as a whole. Nothing is
+// returned if the field is not in the dbMask or nullFields, or if it's
+// unchanged.
+func replaceValueObjects(
+	ctx context.Context,
+	field string,
+	new, orig *AuthMethod,
+	dbMask, nullFields []string,
+	convert func(*AuthMethod, context.Context) ([]any, error),
+) (add []any, del []any, e error) {
+	const op = "jwt.replaceValueObjects"
+	if !strutil.StrListContains(dbMask, field) && !strutil.StrListContains(nullFields, field) {
+		return nil, nil, nil
+	}
+	updated := applyUpdate(new, orig, []string{field})
+	if strutil.EquivalentSlices(valueObjectStrings(field, updated), valueObjectStrings(field, orig)) {
+		return nil, nil, nil
+	}
+	var err error
+	if del, err = convert(orig, ctx); err != nil {
+		return nil, nil, errors.Wrap(ctx, err, op)
+	}
+	if add, err = convert(updated, ctx); err != nil {
+		return nil, nil, errors.Wrap(ctx, err, op)
+	}
+	return add, del, nil
+}
+
+func valueObjectStrings(field string, am *AuthMethod) []string {
+	switch field {
+	case JwksCaCertsField:
+		return am.JwksCaCerts
+	case PublicKeysField:
+		return am.PublicKeys
+	case BoundAudiencesField:
+		return am.BoundAudiences
+	case SigningAlgorithmsField:
+		return am.SigningAlgorithms
+	case AccountClaimMapsField:
+		return am.AccountClaimMaps
+	default:
+		return nil
+	}
+}
+
+// validateFieldMask check the field mask to ensure all the fields are updatable
+func validateFieldMask(ctx context.Context, fieldMaskPaths []string) error {
+	const op = "jwt.validateFieldMask"
+	for _, f := range fieldMaskPaths {
+		switch {
+		case strings.EqualFold(NameField, f):
+		case strings.EqualFold(DescriptionField, f):
+		case strings.EqualFold(IssuerField, f):
+		case strings.EqualFold(JwksUrlField, f):
+		case strings.EqualFold(JwksCaCertsField, f):
+		case strings.EqualFold(PublicKeysField, f):
+		case strings.EqualFold(BoundAudiencesField, f):
+		case strings.EqualFold(SigningAlgorithmsField, f):
+		case strings.EqualFold(AccountClaimMapsField, f):
+		default:
+			return errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("invalid field mask: %s", f))
+		}
+	}
+	return nil
+}
+
+// applyUpdate takes the new and applies it to the orig using the field masks
+func applyUpdate(new, orig *AuthMethod, fieldMaskPaths []string) *AuthMethod {
+	cp := orig.Clone()
+	for _, f := range fieldMaskPaths {
+		switch {
+		case strings.EqualFold(NameField, f):
+			cp.Name = new.Name
+		case strings.EqualFold(DescriptionField, f):
+			cp.Description = new.Description
+		case strings.EqualFold(IssuerField, f):
+			cp.Issuer = new.Issuer
+		case strings.EqualFold(JwksUrlField, f):
+			cp.JwksUrl = new.JwksUrl
+		case strings.EqualFold(JwksCaCertsField, f):
+			cp.JwksCaCerts = append([]string(nil), new.JwksCaCerts...)
+		case strings.EqualFold(PublicKeysField, f):
+			cp.PublicKeys = append([]string(nil), new.PublicKeys...)
+		case strings.EqualFold(BoundAudiencesField, f):
+			cp.BoundAudiences = append([]string(nil), new.BoundAudiences...)
+		case strings.EqualFold(SigningAlgorithmsField, f):
+			cp.SigningAlgorithms = append([]string(nil), new.SigningAlgorithms...)
+		case strings.EqualFold(AccountClaimMapsField, f):
+			cp.AccountClaimMaps = append([]string(nil), new.AccountClaimMaps...)
+		}
+	}
+	return cp
+}
@@ -0,0 +1,129 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package jwt
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/boundary/internal/auth"
+	"github.com/hashicorp/boundary/internal/errors"
+	capjwt "github.com/hashicorp/cap/jwt"
+)
+
+// Authenticate validates the token against the configuration of the auth
+// method with the authMethodId: its signature must verify against the auth
+// method's JWKS or public keys, and its iss, aud, alg and time based claims
+// must be as expected. An auth method without an issuer or bound audiences
+// can't authenticate tokens. If the token is valid, the account for its (mapped)
+// sub claim is created or updated with the token's claims, the account's
+// managed group memberships are refreshed, and the account is returned.
+// Returns nil if the token is not valid.
+func (r *Repository) Authenticate(ctx context.Context, authMethodId, token string) (*Account, error) {
+	const op = "jwt.(Repository).Authenticate"
+	if authMethodId == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing auth method id", errors.WithoutEvent())
+	}
+	if token == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing token", errors.WithoutEvent())
+	}
+
+	am, err := r.lookupAuthMethod(ctx, authMethodId)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	if am == nil {
+		return nil, errors.New(ctx, errors.RecordNotFound, op, fmt.Sprintf("auth method %s not found", authMethodId))
+	}
+	if am.Issuer == "" || len(am.BoundAudiences) == 0 {
+		// an auth method created before they were required must be updated
+		// before tokens can be validated against it.
+		return nil, errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("auth method %s is missing an issuer or bound audiences", authMethodId))
+	}
+
+	keySet, err := keySetCache().get(ctx, am)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	validator, err := capjwt.NewValidator(keySet)
+	if err != nil {
+		return nil, errors.New(ctx, errors.Internal, op, "unable to create validator", errors.WithWrap(err))
+	}
+	algs := make([]capjwt.Alg, 0, len(am.SigningAlgorithms))
+	for _, a := range am.SigningAlgorithms {
+		algs = append(algs, capjwt.Alg(a))
+	}
+	tokenClaims, err := validator.Validate(ctx, token, capjwt.Expected{
+		Issuer:            am.Issuer,
+		Audiences:         am.BoundAudiences,
+		SigningAlgorithms: algs,
+	})
+	if err != nil {
+		// the token was rejected, which is not an error of the repository.
+		return nil, nil
+	}
+
+	claims, err := am.accountClaims(ctx, tokenClaims)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	if claims.subject == "" {
+		// a token without a subject can't be mapped to an account.
+		return nil, nil
+	}
+
+	acct, err := r.upsertAccount(ctx, am, claims)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+
+	mgs, err := r.ListManagedGroups(ctx, am.PublicId, WithLimit(-1))
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to list managed groups"))
+	}
+	matched, err := auth.MatchManagedGroupFilters(ctx, mgs, map[string]any{"token": tokenClaims})
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	if _, _, err := r.SetManagedGroupMemberships(ctx, am, acct, matched); err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to set managed group memberships"))
+	}
+	return acct, nil
+}
+
+// accountClaims reads the account values from the claims of a validated
+// token, using the auth method's account claim maps to determine which claims
+// hold the subject, full name and email. Claims which are not strings are
+// ignored.
+func (am *AuthMethod) accountClaims(ctx context.Context, tokenClaims map[string]any) (*accountClaims, error) {
+	const op = "jwt.(AuthMethod).accountClaims"
+	claimMaps, err := ParseAccountClaimMaps(ctx, am.AccountClaimMaps...)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	fromClaim := map[AccountToClaim]string{
+		ToSubClaim:   string(ToSubClaim),
+		ToNameClaim:  string(ToNameClaim),
+		ToEmailClaim: string(ToEmailClaim),
+	}
+	for _, m := range claimMaps {
+		fromClaim[AccountToClaim(m.To)] = m.From
+	}
+	stringClaim := func(name string) string {
+		s, _ := tokenClaims[name].(string)
+		return s
+	}
+	raw, err := json.Marshal(tokenClaims)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithCode(errors.Encode))
+	}
+	return &accountClaims{
+		issuer:   stringClaim("iss"),
+		subject:  stringClaim(fromClaim[ToSubClaim]),
+		fullName: stringClaim(fromClaim[ToNameClaim]),
+		email:    stringClaim(fromClaim[ToEmailClaim]),
+		raw:      string(raw),
+	}, nil
+}
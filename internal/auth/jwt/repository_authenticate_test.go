@@ -0,0 +1,430 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package jwt
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_Authenticate(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	org, _ := iam.TestScopes(t, iamRepo)
+	ctx := context.Background()
+
+	repo, err := NewRepository(ctx, rw, rw, kmsCache)
+	require.NoError(t, err)
+
+	priv, keyPem := testPublicKeyPem(t)
+	otherPriv, _ := testPublicKeyPem(t)
+	const (
+		issuer   = "https://issuer.example.com"
+		audience = "boundary"
+	)
+	am := TestAuthMethod(t, conn, org.PublicId,
+		WithIssuer(issuer),
+		WithBoundAudiences(audience),
+		WithSigningAlgs("ES256"),
+		WithPublicKeys(keyPem),
+	)
+	rsOnlyAm := TestAuthMethod(t, conn, org.PublicId,
+		WithIssuer(issuer),
+		WithBoundAudiences(audience),
+		WithSigningAlgs("RS256"),
+		WithPublicKeys(keyPem),
+	)
+
+	claims := func(overrides map[string]any) map[string]any {
+		c := map[string]any{
+			"iss": issuer,
+			"aud": audience,
+			"sub": "workload",
+			"iat": time.Now().Unix(),
+			"exp": time.Now().Add(5 * time.Minute).Unix(),
+		}
+		for k, v := range overrides {
+			if v == nil {
+				delete(c, k)
+				continue
+			}
+			c[k] = v
+		}
+		return c
+	}
+
+	tests := []struct {
+		name         string
+		authMethodId string
+		token        string
+		wantSubject  string
+		wantIsErr    errors.Code
+	}{
+		{
+			name:      "missing-auth-method-id",
+			token:     testSignedToken(t, priv, claims(nil)),
+			wantIsErr: errors.InvalidParameter,
+		},
+		{
+			name:         "missing-token",
+			authMethodId: am.PublicId,
+			wantIsErr:    errors.InvalidParameter,
+		},
+		{
+			name:         "unknown-auth-method",
+			authMethodId: AuthMethodPrefix + "_1234567890",
+			token:        testSignedToken(t, priv, claims(nil)),
+			wantIsErr:    errors.RecordNotFound,
+		},
+		{
+			name:         "valid",
+			authMethodId: am.PublicId,
+			token:        testSignedToken(t, priv, claims(nil)),
+			wantSubject:  "workload",
+		},
+		{
+			name:         "bad-signature",
+			authMethodId: am.PublicId,
+			token:        testSignedToken(t, otherPriv, claims(nil)),
+		},
+		{
+			name:         "malformed-token",
+			authMethodId: am.PublicId,
+			token:        "not-a-jwt",
+		},
+		{
+			name:         "wrong-issuer",
+			authMethodId: am.PublicId,
+			token:        testSignedToken(t, priv, claims(map[string]any{"iss": "https://other.example.com"})),
+		},
+		{
+			name:         "missing-issuer",
+			authMethodId: am.PublicId,
+			token:        testSignedToken(t, priv, claims(map[string]any{"iss": nil})),
+		},
+		{
+			name:         "wrong-audience",
+			authMethodId: am.PublicId,
+			token:        testSignedToken(t, priv, claims(map[string]any{"aud": "other"})),
+		},
+		{
+			name:         "missing-audience",
+			authMethodId: am.PublicId,
+			token:        testSignedToken(t, priv, claims(map[string]any{"aud": nil})),
+		},
+		{
+			name:         "one-of-many-audiences",
+			authMethodId: am.PublicId,
+			token:        testSignedToken(t, priv, claims(map[string]any{"aud": []string{"other", audience}})),
+			wantSubject:  "workload",
+		},
+		{
+			name:         "wrong-alg",
+			authMethodId: rsOnlyAm.PublicId,
+			token:        testSignedToken(t, priv, claims(nil)),
+		},
+		{
+			name:         "expired",
+			authMethodId: am.PublicId,
+			token: testSignedToken(t, priv, claims(map[string]any{
+				"iat": time.Now().Add(-time.Hour).Unix(),
+				"exp": time.Now().Add(-30 * time.Minute).Unix(),
+			})),
+		},
+		{
+			name:         "not-yet-valid",
+			authMethodId: am.PublicId,
+			token: testSignedToken(t, priv, claims(map[string]any{
+				"nbf": time.Now().Add(time.Hour).Unix(),
+				"exp": time.Now().Add(2 * time.Hour).Unix(),
+			})),
+		},
+		{
+			name:         "missing-sub",
+			authMethodId: am.PublicId,
+			token:        testSignedToken(t, priv, claims(map[string]any{"sub": nil})),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, err := repo.Authenticate(ctx, tt.authMethodId, tt.token)
+			if tt.wantIsErr != 0 {
+				require.Error(err)
+				assert.Truef(errors.Match(errors.T(tt.wantIsErr), err), "want err code: %q got: %q", tt.wantIsErr, err)
+				assert.Nil(got)
+				return
+			}
+			require.NoError(err)
+			if tt.wantSubject == "" {
+				assert.Nil(got, "token should have been rejected")
+				return
+			}
+			require.NotNil(got)
+			assert.Equal(tt.authMethodId, got.AuthMethodId)
+			assert.Equal(tt.wantSubject, got.Subject)
+			assert.Equal(issuer, got.Issuer)
+		})
+	}
+
+	t.Run("missing-issuer-on-auth-method", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		// auth methods created before an issuer was required can still
+		// exist in the database.
+		legacy := TestAuthMethod(t, conn, org.PublicId,
+			WithIssuer(issuer),
+			WithBoundAudiences(audience),
+			WithSigningAlgs("ES256"),
+			WithPublicKeys(keyPem),
+		)
+		_, err := rw.Exec(ctx, "update auth_jwt_method set issuer = null where public_id = ?", []any{legacy.PublicId})
+		require.NoError(err)
+
+		got, err := repo.Authenticate(ctx, legacy.PublicId, testSignedToken(t, priv, claims(nil)))
+		require.Error(err)
+		assert.True(errors.Match(errors.T(errors.InvalidParameter), err))
+		assert.Nil(got)
+	})
+}
+
+func TestRepository_Authenticate_Jwks(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	org, _ := iam.TestScopes(t, iamRepo)
+	ctx := context.Background()
+	assert, require := assert.New(t), require.New(t)
+
+	repo, err := NewRepository(ctx, rw, rw, kmsCache)
+	require.NoError(err)
+
+	priv, _ := testPublicKeyPem(t)
+	otherPriv, _ := testPublicKeyPem(t)
+	enc := base64.RawURLEncoding
+	jwks, err := json.Marshal(map[string]any{
+		"keys": []map[string]string{
+			{
+				"kty": "EC",
+				"crv": "P-256",
+				"use": "sig",
+				"alg": "ES256",
+				"x":   enc.EncodeToString(priv.PublicKey.X.FillBytes(make([]byte, 32))),
+				"y":   enc.EncodeToString(priv.PublicKey.Y.FillBytes(make([]byte, 32))),
+			},
+		},
+	})
+	require.NoError(err)
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(jwks)
+	}))
+	t.Cleanup(srv.Close)
+	caPem := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw}))
+
+	const (
+		issuer   = "https://issuer.example.com"
+		audience = "boundary"
+	)
+	am := TestAuthMethod(t, conn, org.PublicId,
+		WithIssuer(issuer),
+		WithBoundAudiences(audience),
+		WithSigningAlgs("ES256"),
+		WithJwksUrl(srv.URL),
+		WithJwksCaCerts(caPem),
+	)
+	claims := map[string]any{
+		"iss": issuer,
+		"aud": audience,
+		"sub": "workload",
+		"exp": time.Now().Add(5 * time.Minute).Unix(),
+	}
+
+	got, err := repo.Authenticate(ctx, am.PublicId, testSignedToken(t, priv, claims))
+	require.NoError(err)
+	require.NotNil(got)
+	assert.Equal("workload", got.Subject)
+
+	got, err = repo.Authenticate(ctx, am.PublicId, testSignedToken(t, otherPriv, claims))
+	require.NoError(err)
+	assert.Nil(got, "token signed by a key not in the jwks")
+}
+
+func TestRepository_Authenticate_UpsertAccount(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	org, _ := iam.TestScopes(t, iamRepo)
+	ctx := context.Background()
+	assert, require := assert.New(t), require.New(t)
+
+	repo, err := NewRepository(ctx, rw, rw, kmsCache)
+	require.NoError(err)
+
+	priv, keyPem := testPublicKeyPem(t)
+	const issuer = "https://issuer.example.com"
+	am := TestAuthMethod(t, conn, org.PublicId,
+		WithIssuer(issuer),
+		WithBoundAudiences("boundary"),
+		WithSigningAlgs("ES256"),
+		WithPublicKeys(keyPem),
+		WithAccountClaimMaps("oid=sub"),
+	)
+	token := func(name, email string) string {
+		c := map[string]any{
+			"iss": issuer,
+			"aud": "boundary",
+			"sub": "ignored-by-claim-map",
+			"oid": "workload-oid",
+			"exp": time.Now().Add(5 * time.Minute).Unix(),
+		}
+		if name != "" {
+			c["name"] = name
+		}
+		if email != "" {
+			c["email"] = email
+		}
+		return testSignedToken(t, priv, c)
+	}
+
+	created, err := repo.Authenticate(ctx, am.PublicId, token("Alice", "alice@example.com"))
+	require.NoError(err)
+	require.NotNil(created)
+	assert.Equal("workload-oid", created.Subject)
+	assert.Equal("Alice", created.FullName)
+	assert.Equal("alice@example.com", created.Email)
+	assert.Contains(created.TokenClaims, `"oid":"workload-oid"`)
+	wantId, err := newAccountId(ctx, am.PublicId, "workload-oid")
+	require.NoError(err)
+	assert.Equal(wantId, created.PublicId)
+
+	updated, err := repo.Authenticate(ctx, am.PublicId, token("Alice Smith", ""))
+	require.NoError(err)
+	require.NotNil(updated)
+	assert.Equal(created.PublicId, updated.PublicId)
+	assert.Equal("Alice Smith", updated.FullName)
+	assert.Empty(updated.Email, "claims missing from the token are cleared")
+	assert.Greater(updated.Version, created.Version)
+
+	accts, err := repo.ListAccounts(ctx, am.PublicId)
+	require.NoError(err)
+	assert.Len(accts, 1)
+
+	// an account created through the api is used when its subject
+	// authenticates.
+	pre := TestAccount(t, conn, am, "precreated")
+	got, err := repo.upsertAccount(ctx, am, &accountClaims{issuer: issuer, subject: "precreated", email: "pre@example.com"})
+	require.NoError(err)
+	assert.Equal(pre.PublicId, got.PublicId)
+	assert.Equal("pre@example.com", got.Email)
+
+	_, err = repo.upsertAccount(ctx, am, &accountClaims{issuer: issuer})
+	assert.True(errors.Match(errors.T(errors.InvalidParameter), err))
+	_, err = repo.upsertAccount(ctx, nil, &accountClaims{issuer: issuer, subject: "s"})
+	assert.True(errors.Match(errors.T(errors.InvalidParameter), err))
+	_, err = repo.upsertAccount(ctx, am, nil)
+	assert.True(errors.Match(errors.T(errors.InvalidParameter), err))
+}
+
+func TestRepository_Authenticate_ManagedGroups(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	org, _ := iam.TestScopes(t, iamRepo)
+	ctx := context.Background()
+	assert, require := assert.New(t), require.New(t)
+
+	repo, err := NewRepository(ctx, rw, rw, kmsCache)
+	require.NoError(err)
+
+	priv, keyPem := testPublicKeyPem(t)
+	const issuer = "https://issuer.example.com"
+	am := TestAuthMethod(t, conn, org.PublicId,
+		WithIssuer(issuer),
+		WithBoundAudiences("boundary"),
+		WithSigningAlgs("ES256"),
+		WithPublicKeys(keyPem),
+	)
+	otherAm := TestAuthMethod(t, conn, org.PublicId,
+		WithIssuer(issuer),
+		WithBoundAudiences("boundary"),
+		WithSigningAlgs("ES256"),
+		WithPublicKeys(keyPem),
+	)
+	prodMg := TestManagedGroup(t, conn, am, `"/token/env" == "prod"`)
+	ciMg := TestManagedGroup(t, conn, am, `"/token/repository" matches "^hashicorp/.*"`)
+	// a matching group of another auth method must never be joined.
+	otherMg := TestManagedGroup(t, conn, otherAm, `"/token/env" == "prod"`)
+
+	token := func(extra map[string]any) string {
+		c := map[string]any{
+			"iss": issuer,
+			"aud": "boundary",
+			"sub": "workload",
+			"exp": time.Now().Add(5 * time.Minute).Unix(),
+		}
+		for k, v := range extra {
+			c[k] = v
+		}
+		return testSignedToken(t, priv, c)
+	}
+	memberships := func(acctId string) []string {
+		t.Helper()
+		mgs, err := repo.ListManagedGroupMembershipsByMember(ctx, acctId)
+		require.NoError(err)
+		ids := make([]string, 0, len(mgs))
+		for _, m := range mgs {
+			ids = append(ids, m.ManagedGroupId)
+		}
+		return ids
+	}
+
+	acct, err := repo.Authenticate(ctx, am.PublicId, token(map[string]any{"env": "prod", "repository": "hashicorp/boundary"}))
+	require.NoError(err)
+	require.NotNil(acct)
+	assert.ElementsMatch([]string{prodMg.PublicId, ciMg.PublicId}, memberships(acct.PublicId))
+	acctId := acct.PublicId
+
+	// a rejected token doesn't change the memberships.
+	acct, err = repo.Authenticate(ctx, am.PublicId, token(map[string]any{"env": "dev", "aud": "other"}))
+	require.NoError(err)
+	assert.Nil(acct)
+	assert.ElementsMatch([]string{prodMg.PublicId, ciMg.PublicId}, memberships(acctId))
+
+	acct, err = repo.Authenticate(ctx, am.PublicId, token(map[string]any{"env": "dev", "repository": "hashicorp/boundary"}))
+	require.NoError(err)
+	require.NotNil(acct)
+	assert.ElementsMatch([]string{ciMg.PublicId}, memberships(acct.PublicId), "memberships are removed when the claims no longer match")
+
+	acct, err = repo.Authenticate(ctx, am.PublicId, token(nil))
+	require.NoError(err)
+	require.NotNil(acct)
+	assert.Empty(memberships(acct.PublicId))
+
+	members, err := repo.ListManagedGroupMembershipsByGroup(ctx, otherMg.PublicId)
+	require.NoError(err)
+	assert.Empty(members)
+
+}
@@ -0,0 +1,283 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package jwt
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/auth/jwt/store"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/intglobals"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testManagedGroupFilter = `"/token/env" == "prod"`
+
+func TestRepository_CreateManagedGroup(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	org, _ := iam.TestScopes(t, iamRepo)
+	ctx := context.Background()
+	_, keyPem := testPublicKeyPem(t)
+
+	repo, err := NewRepository(ctx, rw, rw, kmsCache)
+	require.NoError(t, err)
+
+	am := TestAuthMethod(t, conn, org.PublicId,
+		WithIssuer("https://issuer.example.com"),
+		WithBoundAudiences("boundary"),
+		WithPublicKeys(keyPem),
+	)
+
+	tests := []struct {
+		name      string
+		scopeId   string
+		in        *ManagedGroup
+		wantIsErr errors.Code
+	}{
+		{
+			name:      "nil-managed-group",
+			scopeId:   org.PublicId,
+			wantIsErr: errors.InvalidParameter,
+		},
+		{
+			name:      "nil-embedded-managed-group",
+			scopeId:   org.PublicId,
+			in:        &ManagedGroup{},
+			wantIsErr: errors.InvalidParameter,
+		},
+		{
+			name:      "missing-auth-method-id",
+			scopeId:   org.PublicId,
+			in:        &ManagedGroup{ManagedGroup: &store.ManagedGroup{Filter: testManagedGroupFilter}},
+			wantIsErr: errors.InvalidParameter,
+		},
+		{
+			name:      "missing-filter",
+			scopeId:   org.PublicId,
+			in:        &ManagedGroup{ManagedGroup: &store.ManagedGroup{AuthMethodId: am.PublicId}},
+			wantIsErr: errors.InvalidParameter,
+		},
+		{
+			name:    "public-id-set",
+			scopeId: org.PublicId,
+			in: &ManagedGroup{ManagedGroup: &store.ManagedGroup{
+				AuthMethodId: am.PublicId,
+				Filter:       testManagedGroupFilter,
+				PublicId:     intglobals.JwtManagedGroupPrefix + "_1234567890",
+			}},
+			wantIsErr: errors.InvalidParameter,
+		},
+		{
+			name:      "missing-scope-id",
+			in:        &ManagedGroup{ManagedGroup: &store.ManagedGroup{AuthMethodId: am.PublicId, Filter: testManagedGroupFilter}},
+			wantIsErr: errors.InvalidParameter,
+		},
+		{
+			name:    "valid",
+			scopeId: org.PublicId,
+			in: &ManagedGroup{ManagedGroup: &store.ManagedGroup{
+				AuthMethodId: am.PublicId,
+				Filter:       testManagedGroupFilter,
+				Name:         "valid",
+				Description:  "description",
+			}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, err := repo.CreateManagedGroup(ctx, tt.scopeId, tt.in)
+			if tt.wantIsErr != 0 {
+				require.Error(err)
+				assert.Truef(errors.Match(errors.T(tt.wantIsErr), err), "want err code: %q got: %q", tt.wantIsErr, err)
+				assert.Nil(got)
+				return
+			}
+			require.NoError(err)
+			assert.NotEmpty(got.PublicId)
+			assert.Equal(tt.in.Filter, got.Filter)
+			assert.Equal(tt.in.Name, got.Name)
+			assert.Equal(uint32(1), got.Version)
+			assert.NoError(db.TestVerifyOplog(t, rw, got.PublicId, db.WithOperation(oplog.OpType_OP_TYPE_CREATE), db.WithCreateNotBefore(10*time.Second)))
+
+			found, err := repo.LookupManagedGroup(ctx, got.PublicId)
+			require.NoError(err)
+			assert.Equal(got.Filter, found.Filter)
+		})
+	}
+
+	t.Run("duplicate-name", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		in := &ManagedGroup{ManagedGroup: &store.ManagedGroup{AuthMethodId: am.PublicId, Filter: testManagedGroupFilter, Name: "dup"}}
+		_, err := repo.CreateManagedGroup(ctx, org.PublicId, in)
+		require.NoError(err)
+		_, err = repo.CreateManagedGroup(ctx, org.PublicId, in)
+		require.Error(err)
+		assert.True(errors.Match(errors.T(errors.NotUnique), err))
+	})
+}
+
+func TestRepository_LookupListDeleteManagedGroup(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	org, _ := iam.TestScopes(t, iamRepo)
+	ctx := context.Background()
+	assert, require := assert.New(t), require.New(t)
+	_, keyPem := testPublicKeyPem(t)
+
+	repo, err := NewRepository(ctx, rw, rw, kmsCache)
+	require.NoError(err)
+
+	opts := []Option{
+		WithIssuer("https://issuer.example.com"),
+		WithBoundAudiences("boundary"),
+		WithPublicKeys(keyPem),
+	}
+	am := TestAuthMethod(t, conn, org.PublicId, opts...)
+	otherAm := TestAuthMethod(t, conn, org.PublicId, opts...)
+	mg1 := TestManagedGroup(t, conn, am, testManagedGroupFilter)
+	mg2 := TestManagedGroup(t, conn, am, testManagedGroupFilter)
+	TestManagedGroup(t, conn, otherAm, testManagedGroupFilter)
+
+	_, err = repo.LookupManagedGroup(ctx, "")
+	assert.True(errors.Match(errors.T(errors.InvalidPublicId), err))
+	got, err := repo.LookupManagedGroup(ctx, intglobals.JwtManagedGroupPrefix+"_1234567890")
+	require.NoError(err)
+	assert.Nil(got)
+	got, err = repo.LookupManagedGroup(ctx, mg1.PublicId)
+	require.NoError(err)
+	require.NotNil(got)
+	assert.Equal(am.PublicId, got.AuthMethodId)
+
+	_, err = repo.ListManagedGroups(ctx, "")
+	assert.True(errors.Match(errors.T(errors.InvalidParameter), err))
+	mgs, err := repo.ListManagedGroups(ctx, am.PublicId)
+	require.NoError(err)
+	ids := make([]string, 0, len(mgs))
+	for _, mg := range mgs {
+		ids = append(ids, mg.PublicId)
+	}
+	assert.ElementsMatch([]string{mg1.PublicId, mg2.PublicId}, ids)
+	mgs, err = repo.ListManagedGroups(ctx, am.PublicId, WithLimit(1))
+	require.NoError(err)
+	assert.Len(mgs, 1)
+
+	_, err = repo.DeleteManagedGroup(ctx, org.PublicId, "")
+	assert.True(errors.Match(errors.T(errors.InvalidPublicId), err))
+	_, err = repo.DeleteManagedGroup(ctx, "", mg1.PublicId)
+	assert.True(errors.Match(errors.T(errors.InvalidParameter), err))
+	n, err := repo.DeleteManagedGroup(ctx, org.PublicId, mg1.PublicId)
+	require.NoError(err)
+	assert.Equal(1, n)
+	assert.NoError(db.TestVerifyOplog(t, rw, mg1.PublicId, db.WithOperation(oplog.OpType_OP_TYPE_DELETE), db.WithCreateNotBefore(10*time.Second)))
+	got, err = repo.LookupManagedGroup(ctx, mg1.PublicId)
+	require.NoError(err)
+	assert.Nil(got)
+}
+
+func TestRepository_UpdateManagedGroup(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	org, _ := iam.TestScopes(t, iamRepo)
+	ctx := context.Background()
+	_, keyPem := testPublicKeyPem(t)
+
+	repo, err := NewRepository(ctx, rw, rw, kmsCache)
+	require.NoError(t, err)
+
+	am := TestAuthMethod(t, conn, org.PublicId,
+		WithIssuer("https://issuer.example.com"),
+		WithBoundAudiences("boundary"),
+		WithPublicKeys(keyPem),
+	)
+
+	tests := []struct {
+		name      string
+		update    func(mg *ManagedGroup) *ManagedGroup
+		mask      []string
+		version   uint32
+		wantIsErr errors.Code
+	}{
+		{
+			name:      "missing-public-id",
+			update:    func(mg *ManagedGroup) *ManagedGroup { mg.PublicId = ""; return mg },
+			mask:      []string{NameField},
+			version:   1,
+			wantIsErr: errors.InvalidPublicId,
+		},
+		{
+			name:      "missing-version",
+			update:    func(mg *ManagedGroup) *ManagedGroup { return mg },
+			mask:      []string{NameField},
+			wantIsErr: errors.InvalidParameter,
+		},
+		{
+			name:      "invalid-field",
+			update:    func(mg *ManagedGroup) *ManagedGroup { return mg },
+			mask:      []string{"AuthMethodId"},
+			version:   1,
+			wantIsErr: errors.InvalidFieldMask,
+		},
+		{
+			name:      "clear-filter",
+			update:    func(mg *ManagedGroup) *ManagedGroup { mg.Filter = ""; return mg },
+			mask:      []string{FilterField},
+			version:   1,
+			wantIsErr: errors.InvalidParameter,
+		},
+		{
+			name:      "invalid-filter",
+			update:    func(mg *ManagedGroup) *ManagedGroup { mg.Filter = `"/token/env" ==`; return mg },
+			mask:      []string{FilterField},
+			version:   1,
+			wantIsErr: errors.InvalidParameter,
+		},
+		{
+			name: "name-and-filter",
+			update: func(mg *ManagedGroup) *ManagedGroup {
+				mg.Name = "updated"
+				mg.Filter = `"/token/env" == "dev"`
+				return mg
+			},
+			mask:    []string{NameField, FilterField},
+			version: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			orig := TestManagedGroup(t, conn, am, testManagedGroupFilter, WithName(tt.name))
+			got, n, err := repo.UpdateManagedGroup(ctx, org.PublicId, tt.update(orig.Clone()), tt.version, tt.mask)
+			if tt.wantIsErr != 0 {
+				require.Error(err)
+				assert.Truef(errors.Match(errors.T(tt.wantIsErr), err), "want err code: %q got: %q", tt.wantIsErr, err)
+				assert.Equal(db.NoRowsAffected, n)
+				return
+			}
+			require.NoError(err)
+			assert.Equal(1, n)
+			assert.Equal("updated", got.Name)
+			assert.Equal(`"/token/env" == "dev"`, got.Filter)
+			assert.Equal(uint32(2), got.Version)
+			assert.NoError(db.TestVerifyOplog(t, rw, got.PublicId, db.WithOperation(oplog.OpType_OP_TYPE_UPDATE), db.WithCreateNotBefore(10*time.Second)))
+		})
+	}
+}
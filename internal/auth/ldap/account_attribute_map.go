@@ -0,0 +1,103 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package ldap
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/boundary/internal/auth/ldap/store"
+	"github.com/hashicorp/boundary/internal/errors"
+	"google.golang.org/protobuf/proto"
+)
+
+// defaultAcctAttrMapTableName defines the default table name for an AccountAttributeMap
+const defaultAcctAttrMapTableName = "auth_ldap_account_attribute_map"
+
+// AccountToAttribute defines the account attributes a directory attribute can
+// be mapped to.
+type AccountToAttribute string
+
+const (
+	ToFullNameAttribute AccountToAttribute = "fullName"
+	ToEmailAttribute    AccountToAttribute = "email"
+)
+
+// ConvertToAccountToAttribute converts a string to an AccountToAttribute,
+// returning an error if it's not a supported account attribute.
+func ConvertToAccountToAttribute(ctx context.Context, s string) (AccountToAttribute, error) {
+	const op = "ldap.ConvertToAccountToAttribute"
+	switch AccountToAttribute(s) {
+	case ToFullNameAttribute, ToEmailAttribute:
+		return AccountToAttribute(s), nil
+	default:
+		return "", errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("%s is not a valid ToAccountAttribute value", s))
+	}
+}
+
+// AccountAttributeMap defines an optional map from a directory attribute to
+// an account attribute. They are value objects of an AuthMethod.
+type AccountAttributeMap struct {
+	*store.AccountAttributeMap
+	tableName string
+}
+
+// NewAccountAttributeMap creates a new in memory AccountAttributeMap assigned
+// to an LDAP auth method.
+func NewAccountAttributeMap(ctx context.Context, authMethodId, fromAttribute string, toAttribute string) (*AccountAttributeMap, error) {
+	const op = "ldap.NewAccountAttributeMap"
+	am := &AccountAttributeMap{
+		AccountAttributeMap: &store.AccountAttributeMap{
+			LdapMethodId:  authMethodId,
+			FromAttribute: fromAttribute,
+			ToAttribute:   toAttribute,
+		},
+	}
+	if err := am.validate(ctx, op); err != nil {
+		return nil, err // intentionally not wrapped
+	}
+	return am, nil
+}
+
+// validate the AccountAttributeMap. On success, it will return nil.
+func (am *AccountAttributeMap) validate(ctx context.Context, caller errors.Op) error {
+	if am.LdapMethodId == "" {
+		return errors.New(ctx, errors.InvalidParameter, caller, "missing ldap auth method id")
+	}
+	if am.FromAttribute == "" {
+		return errors.New(ctx, errors.InvalidParameter, caller, "missing from attribute")
+	}
+	if _, err := ConvertToAccountToAttribute(ctx, am.ToAttribute); err != nil {
+		return errors.Wrap(ctx, err, caller)
+	}
+	return nil
+}
+
+// AllocAccountAttributeMap makes an empty one in memory
+func AllocAccountAttributeMap() AccountAttributeMap {
+	return AccountAttributeMap{
+		AccountAttributeMap: &store.AccountAttributeMap{},
+	}
+}
+
+// Clone an AccountAttributeMap
+func (am *AccountAttributeMap) Clone() *AccountAttributeMap {
+	cp := proto.Clone(am.AccountAttributeMap)
+	return &AccountAttributeMap{
+		AccountAttributeMap: cp.(*store.AccountAttributeMap),
+	}
+}
+
+// TableName returns the table name.
+func (am *AccountAttributeMap) TableName() string {
+	if am.tableName != "" {
+		return am.tableName
+	}
+	return defaultAcctAttrMapTableName
+}
+
+// SetTableName sets the table name.
+func (am *AccountAttributeMap) SetTableName(n string) {
+	am.tableName = n
+}
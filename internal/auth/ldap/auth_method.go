@@ -0,0 +1,331 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package ldap
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/boundary/internal/auth/ldap/store"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/libs/crypto"
+	"github.com/hashicorp/boundary/internal/oplog"
+	wrapping "github.com/hashicorp/go-kms-wrapping/v2"
+	"github.com/hashicorp/go-kms-wrapping/v2/extras/structwrapping"
+	"google.golang.org/protobuf/proto"
+)
+
+// defaultAuthMethodTableName defines the default table name for an AuthMethod
+const defaultAuthMethodTableName = "auth_ldap_method"
+
+// AuthMethod contains an LDAP auth method configuration. It is owned by a
+// scope. AuthMethods can have Accounts, ManagedGroups, Urls, Certificates and
+// AccountAttributeMaps.
+type AuthMethod struct {
+	*store.AuthMethod
+	tableName string
+}
+
+// NewAuthMethod creates a new in memory AuthMethod assigned to scopeId.
+// WithUrls is required; the first URL has the highest connection priority.
+//
+// The bind password given WithBindCredential will be encrypted when stored in
+// the database and an hmac representation will also be stored whenever the
+// password changes. The password is not returned via the API, the hmac is
+// returned so callers can determine if it's been updated.
+//
+// Supports the options of WithName, WithDescription, WithUrls, WithStartTls,
+// WithInsecureTls, WithDiscoverDn, WithAnonGroupSearch, WithUpnDomain,
+// WithUserDn, WithUserAttr, WithUserFilter, WithEnableGroups, WithGroupDn,
+// WithGroupAttr, WithGroupFilter, WithCertificates, WithBindCredential and
+// WithAccountAttributeMaps. All other options are ignored.
+func NewAuthMethod(ctx context.Context, scopeId string, opt ...Option) (*AuthMethod, error) {
+	const op = "ldap.NewAuthMethod"
+	opts := getOpts(opt...)
+	a := &AuthMethod{
+		AuthMethod: &store.AuthMethod{
+			ScopeId:         scopeId,
+			Name:            opts.withName,
+			Description:     opts.withDescription,
+			StartTls:        opts.withStartTls,
+			InsecureTls:     opts.withInsecureTls,
+			DiscoverDn:      opts.withDiscoverDn,
+			AnonGroupSearch: opts.withAnonGroupSearch,
+			UpnDomain:       opts.withUpnDomain,
+			UserDn:          opts.withUserDn,
+			UserAttr:        opts.withUserAttr,
+			UserFilter:      opts.withUserFilter,
+			EnableGroups:    opts.withEnableGroups,
+			GroupDn:         opts.withGroupDn,
+			GroupAttr:       opts.withGroupAttr,
+			GroupFilter:     opts.withGroupFilter,
+			BindDn:          opts.withBindDn,
+			BindPassword:    opts.withBindPassword,
+		},
+	}
+	if len(opts.withUrls) > 0 {
+		a.Urls = make([]string, 0, len(opts.withUrls))
+		a.Urls = append(a.Urls, opts.withUrls...)
+	}
+	if len(opts.withCertificates) > 0 {
+		a.Certificates = make([]string, 0, len(opts.withCertificates))
+		a.Certificates = append(a.Certificates, opts.withCertificates...)
+	}
+	if len(opts.withAccountAttributeMaps) > 0 {
+		a.AccountAttributeMaps = make([]string, 0, len(opts.withAccountAttributeMaps))
+		a.AccountAttributeMaps = append(a.AccountAttributeMaps, opts.withAccountAttributeMaps...)
+	}
+	if err := a.validate(ctx, op); err != nil {
+		return nil, err // intentionally not wrapped.
+	}
+	return a, nil
+}
+
+// validate the AuthMethod. On success, it will return nil.
+func (a *AuthMethod) validate(ctx context.Context, caller errors.Op) error {
+	if a.ScopeId == "" {
+		return errors.New(ctx, errors.InvalidParameter, caller, "missing scope id")
+	}
+	if len(a.Urls) == 0 {
+		return errors.New(ctx, errors.InvalidParameter, caller, "missing urls")
+	}
+	for _, u := range a.Urls {
+		if err := validateUrl(ctx, caller, u); err != nil {
+			return err
+		}
+	}
+	if a.BindPassword != "" && a.BindDn == "" {
+		return errors.New(ctx, errors.InvalidParameter, caller, "bind password requires a bind dn")
+	}
+	if _, err := ParseAccountAttributeMaps(ctx, a.AccountAttributeMaps...); err != nil {
+		return errors.Wrap(ctx, err, caller)
+	}
+	return nil
+}
+
+func validateUrl(ctx context.Context, caller errors.Op, u string) error {
+	parsed, err := url.Parse(u)
+	if err != nil {
+		return errors.New(ctx, errors.InvalidParameter, caller, fmt.Sprintf("%q is not a valid url", u), errors.WithWrap(err))
+	}
+	switch parsed.Scheme {
+	case "ldap", "ldaps":
+	default:
+		return errors.New(ctx, errors.InvalidParameter, caller, fmt.Sprintf("%q scheme must be ldap or ldaps", u))
+	}
+	if parsed.Host == "" {
+		return errors.New(ctx, errors.InvalidParameter, caller, fmt.Sprintf("%q is missing a host", u))
+	}
+	return nil
+}
+
+// AllocAuthMethod makes an empty one in memory
+func AllocAuthMethod() AuthMethod {
+	return AuthMethod{
+		AuthMethod: &store.AuthMethod{},
+	}
+}
+
+// Clone an AuthMethod.
+func (a *AuthMethod) Clone() *AuthMethod {
+	cp := proto.Clone(a.AuthMethod)
+	return &AuthMethod{
+		AuthMethod: cp.(*store.AuthMethod),
+	}
+}
+
+// TableName returns the table name.
+func (a *AuthMethod) TableName() string {
+	if a.tableName != "" {
+		return a.tableName
+	}
+	return defaultAuthMethodTableName
+}
+
+// SetTableName sets the table name.
+func (a *AuthMethod) SetTableName(n string) {
+	a.tableName = n
+}
+
+// oplog will create oplog metadata for the AuthMethod.
+func (a *AuthMethod) oplog(op oplog.OpType) oplog.Metadata {
+	metadata := oplog.Metadata{
+		"resource-public-id": []string{a.GetPublicId()},
+		"resource-type":      []string{"ldap auth method"},
+		"op-type":            []string{op.String()},
+		"scope-id":           []string{a.ScopeId},
+	}
+	return metadata
+}
+
+// encrypt the auth method's bind password before writing it to the db
+func (a *AuthMethod) encrypt(ctx context.Context, cipher wrapping.Wrapper) error {
+	const op = "ldap.(AuthMethod).encrypt"
+	if cipher == nil {
+		return errors.New(ctx, errors.InvalidParameter, op, "missing cipher")
+	}
+	if err := structwrapping.WrapStruct(ctx, cipher, a.AuthMethod, nil); err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithCode(errors.Encrypt))
+	}
+	keyId, err := cipher.KeyId(ctx)
+	if err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithCode(errors.Encrypt), errors.WithMsg("failed to read cipher key id"))
+	}
+	a.KeyId = keyId
+	if err := a.hmacBindPassword(ctx, cipher); err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	return nil
+}
+
+// decrypt the auth method's bind password after reading it from the db
+func (a *AuthMethod) decrypt(ctx context.Context, cipher wrapping.Wrapper) error {
+	const op = "ldap.(AuthMethod).decrypt"
+	if cipher == nil {
+		return errors.New(ctx, errors.InvalidParameter, op, "missing cipher")
+	}
+	if err := structwrapping.UnwrapStruct(ctx, cipher, a.AuthMethod, nil); err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithCode(errors.Decrypt))
+	}
+	return nil
+}
+
+// hmacBindPassword before writing it to the db
+func (a *AuthMethod) hmacBindPassword(ctx context.Context, cipher wrapping.Wrapper) error {
+	const op = "ldap.(AuthMethod).hmacBindPassword"
+	if cipher == nil {
+		return errors.New(ctx, errors.InvalidParameter, op, "missing cipher")
+	}
+	hm, err := crypto.HmacSha256(ctx, []byte(a.BindPassword), cipher, []byte(a.PublicId), nil, crypto.WithBase64Encoding())
+	if err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithCode(errors.Code(errors.Encryption)))
+	}
+	a.BindPasswordHmac = hm
+	return nil
+}
+
+type convertedValues struct {
+	Urls                 []any
+	Certs                []any
+	AccountAttributeMaps []any
+}
+
+// convertValueObjects converts the embedded value objects. It will return an
+// error if the AuthMethod's public id is not set.
+func (am *AuthMethod) convertValueObjects(ctx context.Context) (*convertedValues, error) {
+	const op = "ldap.(AuthMethod).convertValueObjects"
+	if am.PublicId == "" {
+		return nil, errors.New(ctx, errors.InvalidPublicId, op, "missing public id")
+	}
+	var err error
+	var addUrls, addCerts, addAttrMaps []any
+	if addUrls, err = am.convertUrls(ctx); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	if addCerts, err = am.convertCertificates(ctx); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	if addAttrMaps, err = am.convertAccountAttributeMaps(ctx); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return &convertedValues{
+		Urls:                 addUrls,
+		Certs:                addCerts,
+		AccountAttributeMaps: addAttrMaps,
+	}, nil
+}
+
+// convertUrls converts the embedded urls from []string to []any where each
+// slice element is a *Url. The connection priority of each url is its
+// position in the slice, starting at 1.
+func (am *AuthMethod) convertUrls(ctx context.Context) ([]any, error) {
+	const op = "ldap.(AuthMethod).convertUrls"
+	if am.PublicId == "" {
+		return nil, errors.New(ctx, errors.InvalidPublicId, op, "missing public id")
+	}
+	newInterfaces := make([]any, 0, len(am.Urls))
+	for priority, u := range am.Urls {
+		obj, err := NewUrl(ctx, am.PublicId, priority+1, u)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		newInterfaces = append(newInterfaces, obj)
+	}
+	return newInterfaces, nil
+}
+
+// convertCertificates converts the embedded certificates from []string to
+// []any where each slice element is a *Certificate.
+func (am *AuthMethod) convertCertificates(ctx context.Context) ([]any, error) {
+	const op = "ldap.(AuthMethod).convertCertificates"
+	if am.PublicId == "" {
+		return nil, errors.New(ctx, errors.InvalidPublicId, op, "missing public id")
+	}
+	newInterfaces := make([]any, 0, len(am.Certificates))
+	for _, cert := range am.Certificates {
+		obj, err := NewCertificate(ctx, am.PublicId, cert)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		newInterfaces = append(newInterfaces, obj)
+	}
+	return newInterfaces, nil
+}
+
+// convertAccountAttributeMaps converts the embedded account attribute maps
+// from []string to []any where each slice element is a *AccountAttributeMap.
+func (am *AuthMethod) convertAccountAttributeMaps(ctx context.Context) ([]any, error) {
+	const op = "ldap.(AuthMethod).convertAccountAttributeMaps"
+	if am.PublicId == "" {
+		return nil, errors.New(ctx, errors.InvalidPublicId, op, "missing public id")
+	}
+	attrMaps, err := ParseAccountAttributeMaps(ctx, am.AccountAttributeMaps...)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	newInterfaces := make([]any, 0, len(attrMaps))
+	for _, m := range attrMaps {
+		obj, err := NewAccountAttributeMap(ctx, am.PublicId, m.From, m.To)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		newInterfaces = append(newInterfaces, obj)
+	}
+	return newInterfaces, nil
+}
+
+// AttributeMap defines the To and From of an ldap account attribute map
+type AttributeMap struct {
+	To   string
+	From string
+}
+
+// ParseAccountAttributeMaps will parse the inbound attribute maps, each of
+// the form "from=to", and return them sorted by their from attribute.
+func ParseAccountAttributeMaps(ctx context.Context, m ...string) ([]AttributeMap, error) {
+	const op = "ldap.ParseAccountAttributeMaps"
+	attrMaps := make([]AttributeMap, 0, len(m))
+	seenTo := make(map[string]struct{}, len(m))
+	for _, s := range m {
+		from, to, ok := strings.Cut(s, "=")
+		from, to = strings.TrimSpace(from), strings.TrimSpace(to)
+		if !ok || from == "" || to == "" {
+			return nil, errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("%q is not a valid attribute map", s))
+		}
+		toAttr, err := ConvertToAccountToAttribute(ctx, to)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		if _, ok := seenTo[string(toAttr)]; ok {
+			return nil, errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("%q is mapped more than once", to))
+		}
+		seenTo[string(toAttr)] = struct{}{}
+		attrMaps = append(attrMaps, AttributeMap{From: from, To: string(toAttr)})
+	}
+	sort.Slice(attrMaps, func(i, j int) bool { return attrMaps[i].From < attrMaps[j].From })
+	return attrMaps, nil
+}
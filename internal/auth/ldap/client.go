@@ -0,0 +1,374 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package ldap
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/go-multierror"
+)
+
+const (
+	// DefaultUserAttr is the user entry attribute matched against the login
+	// name when the auth method doesn't specify one.
+	DefaultUserAttr = "cn"
+
+	// DefaultUserFilter is the user search filter template used when the auth
+	// method doesn't specify one.
+	DefaultUserFilter = "({{.UserAttr}}={{.Username}})"
+
+	// DefaultGroupAttr is the group entry attribute holding the group's name
+	// when the auth method doesn't specify one.
+	DefaultGroupAttr = "cn"
+
+	// DefaultGroupFilter is the group search filter template used when the
+	// auth method doesn't specify one.
+	DefaultGroupFilter = "(|(memberUid={{.Username}})(member={{.UserDN}})(uniqueMember={{.UserDN}}))"
+
+	// defaultFullNameAttr and defaultEmailAttr are the directory attributes
+	// used for an account's full name and email unless they're overridden by
+	// the auth method's account attribute maps.
+	defaultFullNameAttr = "displayName"
+	defaultEmailAttr    = "mail"
+
+	// dialTimeout bounds how long connecting to a single directory url may
+	// take before the next url is tried.
+	dialTimeout = 10 * time.Second
+
+	// requestTimeout bounds how long any single request to the directory may
+	// take.
+	requestTimeout = 30 * time.Second
+)
+
+// directoryEntry is what's learned about a user from the directory when they
+// authenticate.
+type directoryEntry struct {
+	dn       string
+	fullName string
+	email    string
+	groups   []string
+}
+
+// authenticateWithDirectory authenticates the login name and password against
+// the auth method's directory and returns the user's entry. The directory's
+// urls are tried in order until a connection succeeds.
+//
+// An errors.Unauthorized error is returned when the directory rejects the
+// credentials.
+func authenticateWithDirectory(ctx context.Context, am *AuthMethod, loginName, password string) (*directoryEntry, error) {
+	const op = "ldap.authenticateWithDirectory"
+	switch {
+	case am == nil || am.AuthMethod == nil:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing auth method")
+	case loginName == "":
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing login name")
+	case password == "":
+		// an empty password would result in an unauthenticated bind, which
+		// most directories treat as a successful anonymous bind.
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing password")
+	}
+
+	conn, err := dial(ctx, am)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	defer conn.Close()
+
+	userDn, entry, err := bindUser(ctx, conn, am, loginName, password)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	de := &directoryEntry{dn: userDn}
+	if entry != nil {
+		de.dn = entry.DN
+		fullNameAttr, emailAttr, err := accountAttributes(ctx, am)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		de.fullName = entry.GetAttributeValue(fullNameAttr)
+		de.email = entry.GetAttributeValue(emailAttr)
+	}
+
+	if am.EnableGroups {
+		groups, err := searchGroups(ctx, conn, am, loginName, de.dn)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		de.groups = groups
+	}
+	return de, nil
+}
+
+// dial connects to the first of the auth method's urls which accepts a
+// connection, issuing a StartTLS command when it's configured.
+func dial(ctx context.Context, am *AuthMethod) (*ldap.Conn, error) {
+	const op = "ldap.dial"
+	if len(am.Urls) == 0 {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing urls")
+	}
+	var roots *x509.CertPool
+	if len(am.Certificates) > 0 {
+		roots = x509.NewCertPool()
+		for _, c := range am.Certificates {
+			if ok := roots.AppendCertsFromPEM([]byte(c)); !ok {
+				return nil, errors.New(ctx, errors.InvalidParameter, op, "unable to parse certificate")
+			}
+		}
+	}
+
+	var dialErrs *multierror.Error
+	for _, u := range am.Urls {
+		parsed, err := url.Parse(u)
+		if err != nil {
+			dialErrs = multierror.Append(dialErrs, fmt.Errorf("%s: %w", u, err))
+			continue
+		}
+		tlsConfig := &tls.Config{
+			ServerName:         parsed.Hostname(),
+			RootCAs:            roots,
+			InsecureSkipVerify: am.InsecureTls,
+			MinVersion:         tls.VersionTLS12,
+		}
+		conn, err := ldap.DialURL(u, ldap.DialWithTLSDialer(tlsConfig, &net.Dialer{Timeout: dialTimeout}))
+		if err != nil {
+			dialErrs = multierror.Append(dialErrs, fmt.Errorf("%s: %w", u, err))
+			continue
+		}
+		conn.SetTimeout(requestTimeout)
+		if parsed.Scheme == "ldap" && am.StartTls {
+			if err := conn.StartTLS(tlsConfig); err != nil {
+				conn.Close()
+				dialErrs = multierror.Append(dialErrs, fmt.Errorf("%s: start tls: %w", u, err))
+				continue
+			}
+		}
+		return conn, nil
+	}
+	return nil, errors.New(ctx, errors.Unavailable, op, "unable to connect to any directory url", errors.WithWrap(dialErrs.ErrorOrNil()))
+}
+
+// bindUser binds to the directory as the user and returns the DN used to bind
+// and, when the auth method has a user dn to search, the user's entry.
+//
+// The bind DN is discovered with a search when discover_dn is set or a bind
+// dn is configured; otherwise it's the UPN "loginName@upnDomain" when a UPN
+// domain is configured, and "userAttr=loginName,userDn" when it's not.
+func bindUser(ctx context.Context, conn *ldap.Conn, am *AuthMethod, loginName, password string) (string, *ldap.Entry, error) {
+	const op = "ldap.bindUser"
+	var bindDn string
+	var entry *ldap.Entry
+	switch {
+	case am.DiscoverDn || am.BindDn != "":
+		if err := serviceBind(ctx, conn, am); err != nil {
+			return "", nil, errors.Wrap(ctx, err, op)
+		}
+		var err error
+		if entry, err = searchUser(ctx, conn, am, loginName); err != nil {
+			return "", nil, errors.Wrap(ctx, err, op)
+		}
+		if entry == nil {
+			// intentionally the same as invalid credentials so the existence
+			// of a user isn't revealed.
+			return "", nil, errors.New(ctx, errors.Unauthorized, op, "authentication failed")
+		}
+		bindDn = entry.DN
+	case am.UpnDomain != "":
+		bindDn = fmt.Sprintf("%s@%s", loginName, am.UpnDomain)
+	default:
+		if am.UserDn == "" {
+			return "", nil, errors.New(ctx, errors.InvalidParameter, op, "auth method needs a user dn, upn domain or discover dn to bind users")
+		}
+		bindDn = fmt.Sprintf("%s=%s,%s", userAttr(am), escapeDnValue(loginName), am.UserDn)
+	}
+
+	if err := conn.Bind(bindDn, password); err != nil {
+		if ldap.IsErrorWithCode(err, ldap.LDAPResultInvalidCredentials) {
+			return "", nil, errors.New(ctx, errors.Unauthorized, op, "authentication failed")
+		}
+		return "", nil, errors.Wrap(ctx, err, op, errors.WithCode(errors.Unavailable), errors.WithMsg("unable to bind user"))
+	}
+
+	if entry == nil && am.UserDn != "" {
+		var err error
+		if entry, err = searchUser(ctx, conn, am, loginName); err != nil {
+			return "", nil, errors.Wrap(ctx, err, op)
+		}
+	}
+	return bindDn, entry, nil
+}
+
+// serviceBind binds with the auth method's bind dn and password, or
+// anonymously when it doesn't have a bind dn.
+func serviceBind(ctx context.Context, conn *ldap.Conn, am *AuthMethod) error {
+	const op = "ldap.serviceBind"
+	var err error
+	switch am.BindDn {
+	case "":
+		err = conn.UnauthenticatedBind("")
+	default:
+		err = conn.Bind(am.BindDn, am.BindPassword)
+	}
+	if err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithCode(errors.Unavailable), errors.WithMsg("unable to bind to search the directory"))
+	}
+	return nil
+}
+
+// searchUser returns the single entry under the auth method's user dn which
+// matches its user filter. It returns nil when no entry matches.
+func searchUser(ctx context.Context, conn *ldap.Conn, am *AuthMethod, loginName string) (*ldap.Entry, error) {
+	const op = "ldap.searchUser"
+	if am.UserDn == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "auth method needs a user dn to search for users")
+	}
+	filterTemplate := am.UserFilter
+	if filterTemplate == "" {
+		filterTemplate = DefaultUserFilter
+	}
+	filter, err := renderFilter(ctx, filterTemplate, struct {
+		UserAttr string
+		Username string
+	}{
+		UserAttr: userAttr(am),
+		Username: ldap.EscapeFilter(loginName),
+	})
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	fullNameAttr, emailAttr, err := accountAttributes(ctx, am)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	req := ldap.NewSearchRequest(am.UserDn, ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 2, 0, false,
+		filter, []string{fullNameAttr, emailAttr}, nil)
+	res, err := conn.Search(req)
+	if err != nil {
+		if ldap.IsErrorWithCode(err, ldap.LDAPResultSizeLimitExceeded) {
+			return nil, errors.New(ctx, errors.NotSpecificIntegrity, op, "user search matched more than one entry")
+		}
+		return nil, errors.Wrap(ctx, err, op, errors.WithCode(errors.Unavailable), errors.WithMsg("unable to search for user"))
+	}
+	switch len(res.Entries) {
+	case 0:
+		return nil, nil
+	case 1:
+		return res.Entries[0], nil
+	default:
+		return nil, errors.New(ctx, errors.NotSpecificIntegrity, op, "user search matched more than one entry")
+	}
+}
+
+// searchGroups returns the names of the groups under the auth method's group
+// dn which match its group filter.
+func searchGroups(ctx context.Context, conn *ldap.Conn, am *AuthMethod, loginName, userDn string) ([]string, error) {
+	const op = "ldap.searchGroups"
+	if am.GroupDn == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "auth method needs a group dn to search for groups")
+	}
+	if am.AnonGroupSearch {
+		if err := conn.UnauthenticatedBind(""); err != nil {
+			return nil, errors.Wrap(ctx, err, op, errors.WithCode(errors.Unavailable), errors.WithMsg("unable to bind anonymously to search for groups"))
+		}
+	}
+	filterTemplate := am.GroupFilter
+	if filterTemplate == "" {
+		filterTemplate = DefaultGroupFilter
+	}
+	filter, err := renderFilter(ctx, filterTemplate, struct {
+		UserDN   string
+		Username string
+	}{
+		UserDN:   ldap.EscapeFilter(userDn),
+		Username: ldap.EscapeFilter(loginName),
+	})
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	groupAttr := am.GroupAttr
+	if groupAttr == "" {
+		groupAttr = DefaultGroupAttr
+	}
+	req := ldap.NewSearchRequest(am.GroupDn, ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 0, 0, false,
+		filter, []string{groupAttr}, nil)
+	res, err := conn.Search(req)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithCode(errors.Unavailable), errors.WithMsg("unable to search for groups"))
+	}
+	var groups []string
+	for _, e := range res.Entries {
+		groups = append(groups, e.GetAttributeValues(groupAttr)...)
+	}
+	return groups, nil
+}
+
+// accountAttributes returns the directory attributes to use for an account's
+// full name and email.
+func accountAttributes(ctx context.Context, am *AuthMethod) (fullName string, email string, e error) {
+	const op = "ldap.accountAttributes"
+	fullName, email = defaultFullNameAttr, defaultEmailAttr
+	attrMaps, err := ParseAccountAttributeMaps(ctx, am.AccountAttributeMaps...)
+	if err != nil {
+		return "", "", errors.Wrap(ctx, err, op)
+	}
+	for _, m := range attrMaps {
+		switch AccountToAttribute(m.To) {
+		case ToFullNameAttribute:
+			fullName = m.From
+		case ToEmailAttribute:
+			email = m.From
+		}
+	}
+	return fullName, email, nil
+}
+
+func userAttr(am *AuthMethod) string {
+	if am.UserAttr != "" {
+		return am.UserAttr
+	}
+	return DefaultUserAttr
+}
+
+func renderFilter(ctx context.Context, filterTemplate string, data any) (string, error) {
+	const op = "ldap.renderFilter"
+	t, err := template.New("filter").Parse(filterTemplate)
+	if err != nil {
+		return "", errors.New(ctx, errors.InvalidParameter, op, "unable to parse filter template", errors.WithWrap(err))
+	}
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, data); err != nil {
+		return "", errors.New(ctx, errors.InvalidParameter, op, "unable to render filter template", errors.WithWrap(err))
+	}
+	return buf.String(), nil
+}
+
+// escapeDnValue escapes an attribute value for use in a DN as described in
+// RFC 4514, section 2.4.
+func escapeDnValue(v string) string {
+	var b strings.Builder
+	for i, r := range v {
+		switch {
+		case strings.ContainsRune(`,+"\<>;=`, r),
+			i == 0 && (r == ' ' || r == '#'),
+			i == len(v)-1 && r == ' ':
+			b.WriteRune('\\')
+			b.WriteRune(r)
+		case r == 0:
+			b.WriteString(`\00`)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
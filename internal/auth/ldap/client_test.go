@@ -0,0 +1,228 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package ldap
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/auth/ldap/store"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testDirectoryEntries() []*TestEntry {
+	return []*TestEntry{
+		{
+			DN:       "cn=admin,dc=example,dc=org",
+			Password: "admin-password",
+		},
+		{
+			DN:       "uid=alice,ou=people,dc=example,dc=org",
+			Password: "alice-password",
+			Attributes: map[string][]string{
+				"uid":               {"alice"},
+				"cn":                {"Alice"},
+				"displayName":       {"Alice Eve"},
+				"mail":              {"alice@example.org"},
+				"altMail":           {"eve@example.org"},
+				"userPrincipalName": {"alice@example.org"},
+			},
+		},
+		{
+			DN:       "uid=bob,ou=people,dc=example,dc=org",
+			Password: "bob-password",
+			Attributes: map[string][]string{
+				"uid": {"bob"},
+				"cn":  {"Bob"},
+			},
+		},
+		{
+			DN: "cn=admins,ou=groups,dc=example,dc=org",
+			Attributes: map[string][]string{
+				"cn":     {"admins"},
+				"member": {"uid=alice,ou=people,dc=example,dc=org"},
+			},
+		},
+		{
+			DN: "cn=developers,ou=groups,dc=example,dc=org",
+			Attributes: map[string][]string{
+				"cn":        {"developers"},
+				"memberUid": {"alice", "bob"},
+			},
+		},
+	}
+}
+
+func Test_authenticateWithDirectory(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	plain := NewTestDirectory(t, false, testDirectoryEntries()...)
+	ldaps := NewTestDirectory(t, true, testDirectoryEntries()...)
+
+	// an address which refuses connections, to exercise failing over to the
+	// next url.
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	unreachable := "ldap://" + ln.Addr().String()
+	require.NoError(t, ln.Close())
+
+	tests := []struct {
+		name      string
+		am        *store.AuthMethod
+		loginName string
+		password  string
+		want      *directoryEntry
+		wantCode  errors.Code
+	}{
+		{
+			name: "user-dn-template",
+			am: &store.AuthMethod{
+				Urls:     []string{plain.Url()},
+				UserDn:   "ou=people,dc=example,dc=org",
+				UserAttr: "uid",
+			},
+			loginName: "alice",
+			password:  "alice-password",
+			want: &directoryEntry{
+				dn:       "uid=alice,ou=people,dc=example,dc=org",
+				fullName: "Alice Eve",
+				email:    "alice@example.org",
+			},
+		},
+		{
+			name: "invalid-password",
+			am: &store.AuthMethod{
+				Urls:     []string{plain.Url()},
+				UserDn:   "ou=people,dc=example,dc=org",
+				UserAttr: "uid",
+			},
+			loginName: "alice",
+			password:  "bad-password",
+			wantCode:  errors.Unauthorized,
+		},
+		{
+			name: "discover-dn-with-groups-and-start-tls",
+			am: &store.AuthMethod{
+				Urls:                 []string{unreachable, plain.Url()},
+				StartTls:             true,
+				Certificates:         []string{plain.Cert()},
+				DiscoverDn:           true,
+				BindDn:               "cn=admin,dc=example,dc=org",
+				BindPassword:         "admin-password",
+				UserDn:               "dc=example,dc=org",
+				UserAttr:             "uid",
+				EnableGroups:         true,
+				GroupDn:              "ou=groups,dc=example,dc=org",
+				AccountAttributeMaps: []string{"cn=fullName", "altMail=email"},
+			},
+			loginName: "alice",
+			password:  "alice-password",
+			want: &directoryEntry{
+				dn:       "uid=alice,ou=people,dc=example,dc=org",
+				fullName: "Alice",
+				email:    "eve@example.org",
+				groups:   []string{"admins", "developers"},
+			},
+		},
+		{
+			name: "discover-dn-unknown-user",
+			am: &store.AuthMethod{
+				Urls:       []string{plain.Url()},
+				DiscoverDn: true,
+				UserDn:     "dc=example,dc=org",
+				UserAttr:   "uid",
+			},
+			loginName: "carol",
+			password:  "carol-password",
+			wantCode:  errors.Unauthorized,
+		},
+		{
+			name: "upn-with-ldaps",
+			am: &store.AuthMethod{
+				Urls:        []string{ldaps.Url()},
+				InsecureTls: true,
+				UpnDomain:   "example.org",
+			},
+			loginName: "alice",
+			password:  "alice-password",
+			want: &directoryEntry{
+				dn: "alice@example.org",
+			},
+		},
+		{
+			name: "anon-group-search",
+			am: &store.AuthMethod{
+				Urls:            []string{ldaps.Url()},
+				Certificates:    []string{ldaps.Cert()},
+				UserDn:          "ou=people,dc=example,dc=org",
+				UserAttr:        "uid",
+				EnableGroups:    true,
+				AnonGroupSearch: true,
+				GroupDn:         "ou=groups,dc=example,dc=org",
+				GroupFilter:     "(memberUid={{.Username}})",
+			},
+			loginName: "bob",
+			password:  "bob-password",
+			want: &directoryEntry{
+				dn:     "uid=bob,ou=people,dc=example,dc=org",
+				groups: []string{"developers"},
+			},
+		},
+		{
+			name: "untrusted-certificate",
+			am: &store.AuthMethod{
+				Urls:     []string{ldaps.Url()},
+				UserDn:   "ou=people,dc=example,dc=org",
+				UserAttr: "uid",
+			},
+			loginName: "alice",
+			password:  "alice-password",
+			wantCode:  errors.Unavailable,
+		},
+		{
+			name: "missing-password",
+			am: &store.AuthMethod{
+				Urls:   []string{plain.Url()},
+				UserDn: "ou=people,dc=example,dc=org",
+			},
+			loginName: "alice",
+			wantCode:  errors.InvalidParameter,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			assert, require := assert.New(t), require.New(t)
+			got, err := authenticateWithDirectory(ctx, &AuthMethod{AuthMethod: tc.am}, tc.loginName, tc.password)
+			if tc.wantCode != 0 {
+				require.Error(err)
+				assert.Truef(errors.Match(errors.T(tc.wantCode), err), "want err code: %q got: %q", tc.wantCode, err)
+				return
+			}
+			require.NoError(err)
+			assert.Equal(tc.want, got)
+		})
+	}
+}
+
+func Test_escapeDnValue(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{in: "alice", want: "alice"},
+		{in: "doe, john", want: `doe\, john`},
+		{in: "#admin ", want: `\#admin\ `},
+		{in: " a=b+c", want: `\ a\=b\+c`},
+		{in: `<"x";\>`, want: `\<\"x\"\;\\\>`},
+	}
+	for _, tc := range tests {
+		assert.Equal(t, tc.want, escapeDnValue(tc.in), tc.in)
+	}
+}
@@ -0,0 +1,272 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package ldap
+
+import (
+	"github.com/hashicorp/boundary/internal/db"
+)
+
+// getOpts - iterate the inbound Options and return a struct.
+func getOpts(opt ...Option) options {
+	opts := getDefaultOptions()
+	for _, o := range opt {
+		o(&opts)
+	}
+	return opts
+}
+
+// Option - how Options are passed as arguments.
+type Option func(*options)
+
+// options = how options are represented
+type options struct {
+	withName                 string
+	withDescription          string
+	withLimit                int
+	withPage                 *db.Page
+	withOrderByCreateTime    bool
+	ascending                bool
+	withUnauthenticatedUser  bool
+	withPublicId             string
+	withUrls                 []string
+	withStartTls             bool
+	withInsecureTls          bool
+	withDiscoverDn           bool
+	withAnonGroupSearch      bool
+	withUpnDomain            string
+	withUserDn               string
+	withUserAttr             string
+	withUserFilter           string
+	withEnableGroups         bool
+	withGroupDn              string
+	withGroupAttr            string
+	withGroupFilter          string
+	withCertificates         []string
+	withBindDn               string
+	withBindPassword         string
+	withAccountAttributeMaps []string
+	withFullName             string
+	withEmail                string
+	withDn                   string
+	withMemberOfGroups       []string
+	withReader               db.Reader
+}
+
+func getDefaultOptions() options {
+	return options{}
+}
+
+// WithName provides an optional name.
+func WithName(name string) Option {
+	return func(o *options) {
+		o.withName = name
+	}
+}
+
+// WithDescription provides an optional description.
+func WithDescription(desc string) Option {
+	return func(o *options) {
+		o.withDescription = desc
+	}
+}
+
+// WithLimit provides an option to provide a limit.  Intentionally allowing
+// negative integers.   If WithLimit < 0, then unlimited results are returned.
+// If WithLimit == 0, then default limits are used for results.
+func WithLimit(l int) Option {
+	return func(o *options) {
+		o.withLimit = l
+	}
+}
+
+// WithPage provides an option to list one page of resources. Use WithLimit to
+// set the size of the page.
+func WithPage(p *db.Page) Option {
+	return func(o *options) {
+		o.withPage = p
+	}
+}
+
+// WithOrderByCreateTime provides an option to specify ordering by the
+// CreateTime field.
+func WithOrderByCreateTime(ascending bool) Option {
+	return func(o *options) {
+		o.withOrderByCreateTime = true
+		o.ascending = ascending
+	}
+}
+
+// WithUnauthenticatedUser provides an option for filtering results for
+// an unauthenticated users.
+func WithUnauthenticatedUser(enabled bool) Option {
+	return func(o *options) {
+		o.withUnauthenticatedUser = enabled
+	}
+}
+
+// WithPublicId provides an option for passing a public id to the operation
+func WithPublicId(publicId string) Option {
+	return func(o *options) {
+		o.withPublicId = publicId
+	}
+}
+
+// WithUrls provides the ordered list of directory URLs to try when
+// connecting. The first URL has the highest connection priority.
+func WithUrls(urls ...string) Option {
+	return func(o *options) {
+		o.withUrls = urls
+	}
+}
+
+// WithStartTls provides an option to issue a StartTLS command after
+// connecting to an ldap:// URL.
+func WithStartTls(enabled bool) Option {
+	return func(o *options) {
+		o.withStartTls = enabled
+	}
+}
+
+// WithInsecureTls provides an option to skip verification of the directory's
+// TLS certificate.
+func WithInsecureTls(enabled bool) Option {
+	return func(o *options) {
+		o.withInsecureTls = enabled
+	}
+}
+
+// WithDiscoverDn provides an option to discover the user's DN with an
+// anonymous (or bind DN) search before binding as the user.
+func WithDiscoverDn(enabled bool) Option {
+	return func(o *options) {
+		o.withDiscoverDn = enabled
+	}
+}
+
+// WithAnonGroupSearch provides an option to search for groups anonymously
+// rather than as the authenticated user.
+func WithAnonGroupSearch(enabled bool) Option {
+	return func(o *options) {
+		o.withAnonGroupSearch = enabled
+	}
+}
+
+// WithUpnDomain provides an optional userPrincipalName domain used to build
+// a bind name of the form "username@domain".
+func WithUpnDomain(domain string) Option {
+	return func(o *options) {
+		o.withUpnDomain = domain
+	}
+}
+
+// WithUserDn provides an optional base DN for user searches.
+func WithUserDn(dn string) Option {
+	return func(o *options) {
+		o.withUserDn = dn
+	}
+}
+
+// WithUserAttr provides an optional attribute that matches the login name.
+func WithUserAttr(attr string) Option {
+	return func(o *options) {
+		o.withUserAttr = attr
+	}
+}
+
+// WithUserFilter provides an optional filter template for user searches.
+func WithUserFilter(filter string) Option {
+	return func(o *options) {
+		o.withUserFilter = filter
+	}
+}
+
+// WithEnableGroups provides an option to look up the user's groups during
+// authentication.
+func WithEnableGroups(enabled bool) Option {
+	return func(o *options) {
+		o.withEnableGroups = enabled
+	}
+}
+
+// WithGroupDn provides an optional base DN for group searches.
+func WithGroupDn(dn string) Option {
+	return func(o *options) {
+		o.withGroupDn = dn
+	}
+}
+
+// WithGroupAttr provides an optional attribute that holds a group's name.
+func WithGroupAttr(attr string) Option {
+	return func(o *options) {
+		o.withGroupAttr = attr
+	}
+}
+
+// WithGroupFilter provides an optional filter template for group searches.
+func WithGroupFilter(filter string) Option {
+	return func(o *options) {
+		o.withGroupFilter = filter
+	}
+}
+
+// WithCertificates provides optional PEM encoded CA certificates used to
+// verify the directory's TLS certificate.
+func WithCertificates(certs ...string) Option {
+	return func(o *options) {
+		o.withCertificates = certs
+	}
+}
+
+// WithBindCredential provides an optional DN and password used to bind
+// before searching the directory.
+func WithBindCredential(dn, password string) Option {
+	return func(o *options) {
+		o.withBindDn = dn
+		o.withBindPassword = password
+	}
+}
+
+// WithAccountAttributeMaps provides optional maps of directory attributes to
+// account attributes in the form "from=to".
+func WithAccountAttributeMaps(attrMaps ...string) Option {
+	return func(o *options) {
+		o.withAccountAttributeMaps = attrMaps
+	}
+}
+
+// WithFullName provides an optional full name for the account.
+func WithFullName(n string) Option {
+	return func(o *options) {
+		o.withFullName = n
+	}
+}
+
+// WithEmail provides an optional email address for the account.
+func WithEmail(email string) Option {
+	return func(o *options) {
+		o.withEmail = email
+	}
+}
+
+// WithDn provides an optional DN for the account.
+func WithDn(dn string) Option {
+	return func(o *options) {
+		o.withDn = dn
+	}
+}
+
+// WithMemberOfGroups provides optional group names for the account.
+func WithMemberOfGroups(groups ...string) Option {
+	return func(o *options) {
+		o.withMemberOfGroups = groups
+	}
+}
+
+// WithReader provides an option for specifying a reader to use for the
+// operation.
+func WithReader(reader db.Reader) Option {
+	return func(o *options) {
+		o.withReader = reader
+	}
+}
@@ -0,0 +1,18 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package ldap
+
+const (
+	acctUpsertQuery = `
+	insert into auth_ldap_account
+			(%s)
+	values
+			(%s)
+	on conflict on constraint
+			auth_ldap_account_auth_method_id_login_name_uq
+	do update set
+			%s
+	returning public_id, version
+       `
+)
@@ -0,0 +1,416 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package ldap
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/db/timestamp"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/hashicorp/boundary/internal/pagination"
+	"github.com/hashicorp/go-kms-wrapping/v2/extras/structwrapping"
+)
+
+// CreateAuthMethod creates am (*AuthMethod) in the repo along with its
+// associated embedded value objects of Urls, Certificates and
+// AccountAttributeMaps and returns the newly created AuthMethod (with its
+// PublicId set)
+//
+// The AuthMethod's public id and version must be empty (zero values).
+//
+// WithPublicId is the only supported option.
+func (r *Repository) CreateAuthMethod(ctx context.Context, am *AuthMethod, opt ...Option) (*AuthMethod, error) {
+	const op = "ldap.(Repository).CreateAuthMethod"
+	if am == nil || am.AuthMethod == nil {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing auth method")
+	}
+	if am.PublicId != "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "public id must be empty")
+	}
+	if am.Version != 0 {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "version must be empty")
+	}
+	if err := am.validate(ctx, op); err != nil {
+		return nil, err // validate properly sets the op to the caller, the code and the msg, so just return it.
+	}
+
+	opts := getOpts(opt...)
+	am.PublicId = opts.withPublicId
+	if am.PublicId == "" {
+		id, err := newAuthMethodId(ctx)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		am.PublicId = id
+	} else {
+		if !strings.HasPrefix(am.PublicId, AuthMethodPrefix+"_") {
+			return nil, errors.New(ctx, errors.InvalidParameter, op, "wrong auth method id prefix")
+		}
+	}
+
+	vo, err := am.convertValueObjects(ctx)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+
+	oplogWrapper, err := r.kms.GetWrapper(ctx, am.ScopeId, kms.KeyPurposeOplog)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get oplog wrapper"))
+	}
+
+	if am.BindPassword != "" {
+		databaseWrapper, err := r.kms.GetWrapper(ctx, am.ScopeId, kms.KeyPurposeDatabase)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get database wrapper"))
+		}
+		if err := am.encrypt(ctx, databaseWrapper); err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+	}
+
+	var returnedAuthMethod *AuthMethod
+	_, err = r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(reader db.Reader, w db.Writer) error {
+			msgs := make([]*oplog.Message, 0, 4)
+			ticket, err := w.GetTicket(ctx, am)
+			if err != nil {
+				return errors.Wrap(ctx, err, op, errors.WithMsg("unable to get ticket"))
+			}
+
+			cp := am.Clone()
+			var amOplogMsg oplog.Message
+			if err := w.Create(ctx, cp, db.NewOplogMsg(&amOplogMsg)); err != nil {
+				return err
+			}
+			msgs = append(msgs, &amOplogMsg)
+
+			for _, items := range [][]any{vo.Urls, vo.Certs, vo.AccountAttributeMaps} {
+				if len(items) == 0 {
+					continue
+				}
+				itemOplogMsgs := make([]*oplog.Message, 0, len(items))
+				if err := w.CreateItems(ctx, items, db.NewOplogMsgs(&itemOplogMsgs)); err != nil {
+					return err
+				}
+				msgs = append(msgs, itemOplogMsgs...)
+			}
+
+			metadata := am.oplog(oplog.OpType_OP_TYPE_CREATE)
+			if err := w.WriteOplogEntryWith(ctx, oplogWrapper, ticket, metadata, msgs); err != nil {
+				return errors.Wrap(ctx, err, op, errors.WithMsg("unable to write oplog"))
+			}
+
+			// we need a new repo, that's using the same reader/writer as this
+			// TxHandler, to return the auth method with its value objects and
+			// db generated fields.
+			txRepo := &Repository{
+				reader: reader,
+				writer: w,
+				kms:    r.kms,
+			}
+			returnedAuthMethod, err = txRepo.lookupAuthMethod(ctx, am.PublicId)
+			if err != nil {
+				return errors.Wrap(ctx, err, op, errors.WithMsg("unable to lookup auth method after create"))
+			}
+			if returnedAuthMethod == nil {
+				return errors.New(ctx, errors.RecordNotFound, op, "unable to lookup auth method after create")
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return returnedAuthMethod, nil
+}
+
+// LookupAuthMethod will lookup an auth method in the repo, along with its
+// associated Value Objects of Urls, Certificates and AccountAttributeMaps. If
+// it's not found, it will return nil, nil. No options are currently supported.
+func (r *Repository) LookupAuthMethod(ctx context.Context, publicId string, _ ...Option) (*AuthMethod, error) {
+	const op = "ldap.(Repository).LookupAuthMethod"
+	if publicId == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing public id")
+	}
+	return r.lookupAuthMethod(ctx, publicId)
+}
+
+// ListAuthMethods returns a slice of AuthMethods for the scopeIds. The
+// WithLimit, WithPage and WithOrderByCreateTime options are supported and all
+// other options are ignored.
+//
+// LDAP auth methods have no operational state, so unlike OIDC auth methods
+// they are always listed for unauthenticated users.
+func (r *Repository) ListAuthMethods(ctx context.Context, scopeIds []string, opt ...Option) ([]*AuthMethod, error) {
+	const op = "ldap.(Repository).ListAuthMethods"
+	if len(scopeIds) == 0 {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing scope IDs")
+	}
+	authMethods, err := r.getAuthMethods(ctx, "", scopeIds, opt...)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return authMethods, nil
+}
+
+// ListDeletedAuthMethodIds returns the auth methods of all subtypes in one of
+// scopeIds deleted after since.
+func (r *Repository) ListDeletedAuthMethodIds(ctx context.Context, scopeIds []string, since time.Time) ([]*pagination.DeletedResource, error) {
+	const op = "ldap.(Repository).ListDeletedAuthMethodIds"
+	deleted, err := pagination.ListDeletedIds(ctx, r.reader, "auth_method", scopeIds, since)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return deleted, nil
+}
+
+// DeleteAuthMethod will delete the auth method from the repository.  It is
+// idempotent so if the auth method was not found, return 0 (no rows affected)
+// and nil.  No options are currently supported.
+func (r *Repository) DeleteAuthMethod(ctx context.Context, publicId string, _ ...Option) (int, error) {
+	const op = "ldap.(Repository).DeleteAuthMethod"
+	if publicId == "" {
+		return db.NoRowsAffected, errors.New(ctx, errors.InvalidPublicId, op, "missing public id")
+	}
+	am, err := r.LookupAuthMethod(ctx, publicId)
+	if err != nil {
+		return db.NoRowsAffected, errors.Wrap(ctx, err, op)
+	}
+	if am == nil {
+		// already deleted and this is not an error.
+		return db.NoRowsAffected, nil
+	}
+
+	oplogWrapper, err := r.kms.GetWrapper(ctx, am.ScopeId, kms.KeyPurposeOplog)
+	if err != nil {
+		return db.NoRowsAffected, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get oplog wrapper"))
+	}
+	metadata := am.oplog(oplog.OpType_OP_TYPE_DELETE)
+	var rowsDeleted int
+	_, err = r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) error {
+			cp := am.Clone()
+			rowsDeleted, err = w.Delete(ctx, cp, db.WithOplog(oplogWrapper, metadata))
+			if err != nil {
+				return err
+			}
+			if rowsDeleted > 1 {
+				return errors.New(ctx, errors.MultipleRecords, op, "more than 1 auth method would have been deleted")
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return db.NoRowsAffected, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("unable to delete %s", publicId)))
+	}
+	return rowsDeleted, nil
+}
+
+// lookupAuthMethod will lookup a single auth method
+func (r *Repository) lookupAuthMethod(ctx context.Context, authMethodId string, opt ...Option) (*AuthMethod, error) {
+	const op = "ldap.(Repository).lookupAuthMethod"
+	ams, err := r.getAuthMethods(ctx, authMethodId, nil, opt...)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	switch {
+	case len(ams) == 0:
+		return nil, nil // not an error to return no rows for a "lookup"
+	case len(ams) > 1:
+		return nil, errors.New(ctx, errors.NotSpecificIntegrity, op, fmt.Sprintf("%s matched more than 1 ", authMethodId))
+	default:
+		return ams[0], nil
+	}
+}
+
+// getAuthMethods allows the caller to either lookup a specific AuthMethod via
+// its id or search for a set AuthMethods within a set of scopes.  Passing both
+// scopeIds and a authMethod is an error. The WithLimit, WithPage and
+// WithOrderByCreateTime options are supported and all other options are
+// ignored.
+//
+// The AuthMethod returned has its value objects populated (Urls, Certificates
+// and AccountAttributeMaps), with the Urls ordered by connection priority. The
+// AuthMethod returned has its IsPrimaryAuthMethod bool set.
+//
+// When no record is found it returns nil, nil
+func (r *Repository) getAuthMethods(ctx context.Context, authMethodId string, scopeIds []string, opt ...Option) ([]*AuthMethod, error) {
+	const op = "ldap.(Repository).getAuthMethods"
+	if authMethodId == "" && len(scopeIds) == 0 {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing search criteria: both auth method id and Scope IDs are empty")
+	}
+	if authMethodId != "" && len(scopeIds) > 0 {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "searching for both an auth method id and Scope IDs is not supported")
+	}
+
+	const aggregateDelimiter = "|"
+
+	dbArgs := []db.Option{}
+	opts := getOpts(opt...)
+	limit := r.defaultLimit
+	if opts.withLimit != 0 {
+		// non-zero signals an override of the default limit for the repo.
+		limit = opts.withLimit
+	}
+	dbArgs = append(dbArgs, db.WithLimit(limit), db.WithPage(opts.withPage))
+
+	if opts.withOrderByCreateTime {
+		if opts.ascending {
+			dbArgs = append(dbArgs, db.WithOrder("create_time asc"))
+		} else {
+			dbArgs = append(dbArgs, db.WithOrder("create_time"))
+		}
+	}
+
+	var where string
+	var args []any
+	switch {
+	case authMethodId != "":
+		where, args = "public_id = ?", append(args, authMethodId)
+	default:
+		where, args = "scope_id in(?)", append(args, scopeIds)
+	}
+
+	var aggAuthMethods []*authMethodAgg
+	if err := r.reader.SearchWhere(ctx, &aggAuthMethods, where, args, dbArgs...); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+
+	if len(aggAuthMethods) == 0 { // we're done if nothing is found.
+		return nil, nil
+	}
+
+	authMethods := make([]*AuthMethod, 0, len(aggAuthMethods))
+	for _, agg := range aggAuthMethods {
+		if agg.KeyId != "" {
+			databaseWrapper, err := r.kms.GetWrapper(ctx, agg.ScopeId, kms.KeyPurposeDatabase, kms.WithKeyId(agg.KeyId))
+			if err != nil {
+				return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get database wrapper"))
+			}
+			if err := structwrapping.UnwrapStruct(ctx, databaseWrapper, agg, nil); err != nil {
+				return nil, errors.Wrap(ctx, err, op, errors.WithCode(errors.Decrypt))
+			}
+		}
+		am := AllocAuthMethod()
+		am.PublicId = agg.PublicId
+		am.ScopeId = agg.ScopeId
+		am.IsPrimaryAuthMethod = agg.IsPrimaryAuthMethod
+		am.Name = agg.Name
+		am.Description = agg.Description
+		am.CreateTime = agg.CreateTime
+		am.UpdateTime = agg.UpdateTime
+		am.Version = agg.Version
+		am.StartTls = agg.StartTls
+		am.InsecureTls = agg.InsecureTls
+		am.DiscoverDn = agg.DiscoverDn
+		am.AnonGroupSearch = agg.AnonGroupSearch
+		am.UpnDomain = agg.UpnDomain
+		am.UserDn = agg.UserDn
+		am.UserAttr = agg.UserAttr
+		am.UserFilter = agg.UserFilter
+		am.EnableGroups = agg.EnableGroups
+		am.GroupDn = agg.GroupDn
+		am.GroupAttr = agg.GroupAttr
+		am.GroupFilter = agg.GroupFilter
+		am.BindDn = agg.BindDn
+		am.CtBindPassword = agg.CtBindPassword
+		am.BindPassword = agg.BindPassword
+		am.BindPasswordHmac = agg.BindPasswordHmac
+		am.KeyId = agg.KeyId
+		if agg.Urls != "" {
+			urls, err := orderedUrls(ctx, strings.Split(agg.Urls, aggregateDelimiter))
+			if err != nil {
+				return nil, errors.Wrap(ctx, err, op)
+			}
+			am.Urls = urls
+		}
+		if agg.Certs != "" {
+			am.Certificates = strings.Split(agg.Certs, aggregateDelimiter)
+		}
+		if agg.AccountAttributeMaps != "" {
+			am.AccountAttributeMaps = strings.Split(agg.AccountAttributeMaps, aggregateDelimiter)
+		}
+		authMethods = append(authMethods, &am)
+	}
+	return authMethods, nil
+}
+
+// orderedUrls converts the "priority=url" values aggregated by the
+// ldap_auth_method_with_value_obj view into urls sorted by their connection
+// priority.
+func orderedUrls(ctx context.Context, prioritized []string) ([]string, error) {
+	const op = "ldap.orderedUrls"
+	type priorityUrl struct {
+		priority int
+		url      string
+	}
+	pus := make([]priorityUrl, 0, len(prioritized))
+	for _, s := range prioritized {
+		p, u, ok := strings.Cut(s, "=")
+		if !ok {
+			return nil, errors.New(ctx, errors.Internal, op, fmt.Sprintf("%q is not a prioritized url", s))
+		}
+		priority, err := strconv.Atoi(p)
+		if err != nil {
+			return nil, errors.New(ctx, errors.Internal, op, fmt.Sprintf("%q is not a prioritized url", s), errors.WithWrap(err))
+		}
+		pus = append(pus, priorityUrl{priority: priority, url: u})
+	}
+	sort.Slice(pus, func(i, j int) bool { return pus[i].priority < pus[j].priority })
+	urls := make([]string, 0, len(pus))
+	for _, pu := range pus {
+		urls = append(urls, pu.url)
+	}
+	return urls, nil
+}
+
+// authMethodAgg is a view that aggregates the auth method's value objects in to
+// string fields delimited with the aggregateDelimiter of "|"
+type authMethodAgg struct {
+	PublicId             string `gorm:"primary_key"`
+	ScopeId              string
+	IsPrimaryAuthMethod  bool
+	Name                 string
+	Description          string
+	CreateTime           *timestamp.Timestamp
+	UpdateTime           *timestamp.Timestamp
+	Version              uint32
+	StartTls             bool
+	InsecureTls          bool
+	DiscoverDn           bool
+	AnonGroupSearch      bool
+	UpnDomain            string
+	UserDn               string
+	UserAttr             string
+	UserFilter           string
+	EnableGroups         bool
+	GroupDn              string
+	GroupAttr            string
+	GroupFilter          string
+	BindDn               string
+	CtBindPassword       []byte `gorm:"column:bind_password" wrapping:"ct,bind_password"`
+	BindPassword         string `gorm:"-" wrapping:"pt,bind_password"`
+	BindPasswordHmac     string
+	KeyId                string
+	Urls                 string
+	Certs                string
+	AccountAttributeMaps string
+}
+
+// TableName returns the table name for gorm
+func (agg *authMethodAgg) TableName() string { return "ldap_auth_method_with_value_obj" }
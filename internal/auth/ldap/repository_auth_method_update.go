@@ -0,0 +1,407 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package ldap
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/hashicorp/go-dbw"
+	"github.com/hashicorp/go-secure-stdlib/strutil"
+)
+
+const (
+	VersionField              = "Version"
+	NameField                 = "Name"
+	DescriptionField          = "Description"
+	StartTlsField             = "StartTls"
+	InsecureTlsField          = "InsecureTls"
+	DiscoverDnField           = "DiscoverDn"
+	AnonGroupSearchField      = "AnonGroupSearch"
+	UpnDomainField            = "UpnDomain"
+	UserDnField               = "UserDn"
+	UserAttrField             = "UserAttr"
+	UserFilterField           = "UserFilter"
+	EnableGroupsField         = "EnableGroups"
+	GroupDnField              = "GroupDn"
+	GroupAttrField            = "GroupAttr"
+	GroupFilterField          = "GroupFilter"
+	BindDnField               = "BindDn"
+	BindPasswordField         = "BindPassword"
+	CtBindPasswordField       = "CtBindPassword"
+	BindPasswordHmacField     = "BindPasswordHmac"
+	KeyIdField                = "KeyId"
+	UrlsField                 = "Urls"
+	CertificatesField         = "Certificates"
+	AccountAttributeMapsField = "AccountAttributeMaps"
+	GroupNamesField           = "GroupNames"
+)
+
+// UpdateAuthMethod will retrieve the auth method from the repository, and
+// update it based on the field masks provided.
+//
+// fieldMaskPaths provides field_mask.proto paths for fields that should be
+// updated. Fields will be set to NULL if the field is a zero value and
+// included in fieldMask. Boolean fields are set to false rather than NULL.
+// The AuthMethod's value objects of Urls, Certificates and
+// AccountAttributeMaps are also updatable and are replaced as a whole when
+// they're included in the fieldMask. Urls cannot be removed. If no updatable
+// fields are included in the fieldMaskPaths, then an error is returned.
+//
+// No options are currently supported.
+func (r *Repository) UpdateAuthMethod(ctx context.Context, am *AuthMethod, version uint32, fieldMaskPaths []string, _ ...Option) (*AuthMethod, int, error) {
+	const op = "ldap.(Repository).UpdateAuthMethod"
+	if am == nil || am.AuthMethod == nil {
+		return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing auth method")
+	}
+	if am.PublicId == "" {
+		return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing public id")
+	}
+	if err := validateFieldMask(ctx, fieldMaskPaths); err != nil {
+		return nil, db.NoRowsAffected, errors.Wrap(ctx, err, op)
+	}
+
+	dbMask, nullFields := dbw.BuildUpdatePaths(
+		map[string]any{
+			NameField:                 am.Name,
+			DescriptionField:          am.Description,
+			StartTlsField:             am.StartTls,
+			InsecureTlsField:          am.InsecureTls,
+			DiscoverDnField:           am.DiscoverDn,
+			AnonGroupSearchField:      am.AnonGroupSearch,
+			UpnDomainField:            am.UpnDomain,
+			UserDnField:               am.UserDn,
+			UserAttrField:             am.UserAttr,
+			UserFilterField:           am.UserFilter,
+			EnableGroupsField:         am.EnableGroups,
+			GroupDnField:              am.GroupDn,
+			GroupAttrField:            am.GroupAttr,
+			GroupFilterField:          am.GroupFilter,
+			BindDnField:               am.BindDn,
+			BindPasswordField:         am.BindPassword,
+			UrlsField:                 am.Urls,
+			CertificatesField:         am.Certificates,
+			AccountAttributeMapsField: am.AccountAttributeMaps,
+		},
+		fieldMaskPaths,
+		nil,
+	)
+	if len(dbMask) == 0 && len(nullFields) == 0 {
+		return nil, db.NoRowsAffected, errors.New(ctx, errors.EmptyFieldMask, op, "empty field mask")
+	}
+	if strutil.StrListContains(nullFields, UrlsField) {
+		return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "urls cannot be removed")
+	}
+
+	origAm, err := r.lookupAuthMethod(ctx, am.PublicId)
+	if err != nil {
+		return nil, db.NoRowsAffected, errors.Wrap(ctx, err, op)
+	}
+	if origAm == nil {
+		return nil, db.NoRowsAffected, errors.New(ctx, errors.RecordNotFound, op, fmt.Sprintf("auth method %s", am.PublicId))
+	}
+	// there's no reason to continue if another controller has already updated this auth method.
+	if origAm.Version != version {
+		return nil, db.NoRowsAffected, errors.New(ctx, errors.VersionMismatch, op, fmt.Sprintf("update version %d doesn't match db version %d", version, origAm.Version))
+	}
+	am.ScopeId = origAm.ScopeId
+	if err := applyUpdate(am, origAm, fieldMaskPaths).validate(ctx, op); err != nil {
+		return nil, db.NoRowsAffected, err // intentionally not wrapped.
+	}
+
+	addUrls, deleteUrls, err := replaceValueObjects(ctx, UrlsField, am, origAm, dbMask, nullFields, (*AuthMethod).convertUrls)
+	if err != nil {
+		return nil, db.NoRowsAffected, errors.Wrap(ctx, err, op)
+	}
+	addCerts, deleteCerts, err := replaceValueObjects(ctx, CertificatesField, am, origAm, dbMask, nullFields, (*AuthMethod).convertCertificates)
+	if err != nil {
+		return nil, db.NoRowsAffected, errors.Wrap(ctx, err, op)
+	}
+	addMaps, deleteMaps, err := replaceValueObjects(ctx, AccountAttributeMapsField, am, origAm, dbMask, nullFields, (*AuthMethod).convertAccountAttributeMaps)
+	if err != nil {
+		return nil, db.NoRowsAffected, errors.Wrap(ctx, err, op)
+	}
+
+	var filteredDbMask, filteredNullFields []string
+	for _, f := range dbMask {
+		switch f {
+		case UrlsField, CertificatesField, AccountAttributeMapsField:
+			continue
+		default:
+			filteredDbMask = append(filteredDbMask, f)
+		}
+	}
+	for _, f := range nullFields {
+		switch f {
+		case UrlsField, CertificatesField, AccountAttributeMapsField:
+			continue
+		case StartTlsField, InsecureTlsField, DiscoverDnField, AnonGroupSearchField, EnableGroupsField:
+			// the boolean columns are not nullable, so their zero value is
+			// written as false.
+			filteredDbMask = append(filteredDbMask, f)
+		default:
+			filteredNullFields = append(filteredNullFields, f)
+		}
+	}
+
+	// handle no changes...
+	if len(filteredDbMask) == 0 &&
+		len(filteredNullFields) == 0 &&
+		len(addUrls) == 0 &&
+		len(deleteUrls) == 0 &&
+		len(addCerts) == 0 &&
+		len(deleteCerts) == 0 &&
+		len(addMaps) == 0 &&
+		len(deleteMaps) == 0 {
+		return origAm, db.NoRowsAffected, nil
+	}
+
+	// BindPassword is a bit odd, because it uses the Struct wrapping, we need
+	// to add the encrypted fields to the dbMask or nullFields
+	if strutil.StrListContains(filteredDbMask, BindPasswordField) {
+		filteredDbMask = append(filteredDbMask, CtBindPasswordField, BindPasswordHmacField, KeyIdField)
+		databaseWrapper, err := r.kms.GetWrapper(ctx, origAm.ScopeId, kms.KeyPurposeDatabase)
+		if err != nil {
+			return nil, db.NoRowsAffected, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get database wrapper"))
+		}
+		if err := am.encrypt(ctx, databaseWrapper); err != nil {
+			return nil, db.NoRowsAffected, errors.Wrap(ctx, err, op)
+		}
+	}
+	if strutil.StrListContains(filteredNullFields, BindPasswordField) {
+		filteredNullFields = append(filteredNullFields, CtBindPasswordField, BindPasswordHmacField, KeyIdField)
+	}
+
+	oplogWrapper, err := r.kms.GetWrapper(ctx, origAm.ScopeId, kms.KeyPurposeOplog)
+	if err != nil {
+		return nil, db.NoRowsAffected, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get oplog wrapper"))
+	}
+
+	var updatedAm *AuthMethod
+	var rowsUpdated int
+	_, err = r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(reader db.Reader, w db.Writer) error {
+			msgs := make([]*oplog.Message, 0, 7) // AuthMethod, Urls*2, Certs*2, AttributeMaps*2
+			ticket, err := w.GetTicket(ctx, am)
+			if err != nil {
+				return errors.Wrap(ctx, err, op, errors.WithMsg("unable to get ticket"))
+			}
+			var authMethodOplogMsg oplog.Message
+			switch {
+			case len(filteredDbMask) == 0 && len(filteredNullFields) == 0:
+				// the auth method's fields are not being updated, just it's value objects, so we need to just update the auth
+				// method's version.
+				updatedAm = am.Clone()
+				updatedAm.Version = uint32(version) + 1
+				rowsUpdated, err = w.Update(ctx, updatedAm, []string{VersionField}, nil, db.NewOplogMsg(&authMethodOplogMsg), db.WithVersion(&version))
+				if err != nil {
+					return errors.Wrap(ctx, err, op, errors.WithMsg("unable to update auth method version"))
+				}
+			default:
+				updatedAm = am.Clone()
+				rowsUpdated, err = w.Update(ctx, updatedAm, filteredDbMask, filteredNullFields, db.NewOplogMsg(&authMethodOplogMsg), db.WithVersion(&version))
+				if err != nil {
+					return errors.Wrap(ctx, err, op, errors.WithMsg("unable to update auth method"))
+				}
+			}
+			if rowsUpdated != 1 {
+				return errors.New(ctx, errors.MultipleRecords, op, fmt.Sprintf("updated auth method and %d rows updated", rowsUpdated))
+			}
+			msgs = append(msgs, &authMethodOplogMsg)
+
+			for _, vo := range []struct {
+				name        string
+				add, delete []any
+			}{
+				{name: "urls", add: addUrls, delete: deleteUrls},
+				{name: "certificates", add: addCerts, delete: deleteCerts},
+				{name: "account attribute maps", add: addMaps, delete: deleteMaps},
+			} {
+				if len(vo.delete) > 0 {
+					deleteOplogMsgs := make([]*oplog.Message, 0, len(vo.delete))
+					rowsDeleted, err := w.DeleteItems(ctx, vo.delete, db.NewOplogMsgs(&deleteOplogMsgs))
+					if err != nil {
+						return errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("unable to delete %s", vo.name)))
+					}
+					if rowsDeleted != len(vo.delete) {
+						return errors.New(ctx, errors.MultipleRecords, op, fmt.Sprintf("%s deleted %d did not match request for %d", vo.name, rowsDeleted, len(vo.delete)))
+					}
+					msgs = append(msgs, deleteOplogMsgs...)
+				}
+				if len(vo.add) > 0 {
+					addOplogMsgs := make([]*oplog.Message, 0, len(vo.add))
+					if err := w.CreateItems(ctx, vo.add, db.NewOplogMsgs(&addOplogMsgs)); err != nil {
+						return errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("unable to add %s", vo.name)))
+					}
+					msgs = append(msgs, addOplogMsgs...)
+				}
+			}
+
+			metadata := updatedAm.oplog(oplog.OpType_OP_TYPE_UPDATE)
+			if err := w.WriteOplogEntryWith(ctx, oplogWrapper, ticket, metadata, msgs); err != nil {
+				return errors.Wrap(ctx, err, op, errors.WithMsg("unable to write oplog"))
+			}
+			// we need a new repo, that's using the same reader/writer as this TxHandler
+			txRepo := &Repository{
+				reader: reader,
+				writer: w,
+				kms:    r.kms,
+			}
+			updatedAm, err = txRepo.lookupAuthMethod(ctx, updatedAm.PublicId)
+			if err != nil {
+				return errors.Wrap(ctx, err, op, errors.WithMsg("unable to lookup auth method after update"))
+			}
+			if updatedAm == nil {
+				return errors.New(ctx, errors.RecordNotFound, op, "unable to lookup auth method after update")
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, db.NoRowsAffected, errors.Wrap(ctx, err, op)
+	}
+	return updatedAm, rowsUpdated, nil
+}
+
+// replaceValueObjects returns the value objects to add and delete in order to
+// replace the original auth method's value objects for the named field with
+// the new auth method's. Value objects are replaced as a whole, since the
+// position of a url determines its connection priority. Nothing is returned
+// if the field is not in the dbMask or nullFields, or if it's unchanged.
+func replaceValueObjects(
+	ctx context.Context,
+	field string,
+	new, orig *AuthMethod,
+	dbMask, nullFields []string,
+	convert func(*AuthMethod, context.Context) ([]any, error),
+) (add []any, del []any, e error) {
+	const op = "ldap.replaceValueObjects"
+	if !strutil.StrListContains(dbMask, field) && !strutil.StrListContains(nullFields, field) {
+		return nil, nil, nil
+	}
+	updated := applyUpdate(new, orig, []string{field})
+	if equalStrings(valueObjectStrings(field, updated), valueObjectStrings(field, orig)) {
+		return nil, nil, nil
+	}
+	var err error
+	if del, err = convert(orig, ctx); err != nil {
+		return nil, nil, errors.Wrap(ctx, err, op)
+	}
+	if add, err = convert(updated, ctx); err != nil {
+		return nil, nil, errors.Wrap(ctx, err, op)
+	}
+	return add, del, nil
+}
+
+func valueObjectStrings(field string, am *AuthMethod) []string {
+	switch field {
+	case UrlsField:
+		return am.Urls
+	case CertificatesField:
+		return am.Certificates
+	case AccountAttributeMapsField:
+		return am.AccountAttributeMaps
+	default:
+		return nil
+	}
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// validateFieldMask check the field mask to ensure all the fields are updatable
+func validateFieldMask(ctx context.Context, fieldMaskPaths []string) error {
+	const op = "ldap.validateFieldMask"
+	for _, f := range fieldMaskPaths {
+		switch {
+		case strings.EqualFold(NameField, f):
+		case strings.EqualFold(DescriptionField, f):
+		case strings.EqualFold(StartTlsField, f):
+		case strings.EqualFold(InsecureTlsField, f):
+		case strings.EqualFold(DiscoverDnField, f):
+		case strings.EqualFold(AnonGroupSearchField, f):
+		case strings.EqualFold(UpnDomainField, f):
+		case strings.EqualFold(UserDnField, f):
+		case strings.EqualFold(UserAttrField, f):
+		case strings.EqualFold(UserFilterField, f):
+		case strings.EqualFold(EnableGroupsField, f):
+		case strings.EqualFold(GroupDnField, f):
+		case strings.EqualFold(GroupAttrField, f):
+		case strings.EqualFold(GroupFilterField, f):
+		case strings.EqualFold(BindDnField, f):
+		case strings.EqualFold(BindPasswordField, f):
+		case strings.EqualFold(UrlsField, f):
+		case strings.EqualFold(CertificatesField, f):
+		case strings.EqualFold(AccountAttributeMapsField, f):
+		default:
+			return errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("invalid field mask: %s", f))
+		}
+	}
+	return nil
+}
+
+// applyUpdate takes the new and applies it to the orig using the field masks
+func applyUpdate(new, orig *AuthMethod, fieldMaskPaths []string) *AuthMethod {
+	cp := orig.Clone()
+	for _, f := range fieldMaskPaths {
+		switch {
+		case strings.EqualFold(NameField, f):
+			cp.Name = new.Name
+		case strings.EqualFold(DescriptionField, f):
+			cp.Description = new.Description
+		case strings.EqualFold(StartTlsField, f):
+			cp.StartTls = new.StartTls
+		case strings.EqualFold(InsecureTlsField, f):
+			cp.InsecureTls = new.InsecureTls
+		case strings.EqualFold(DiscoverDnField, f):
+			cp.DiscoverDn = new.DiscoverDn
+		case strings.EqualFold(AnonGroupSearchField, f):
+			cp.AnonGroupSearch = new.AnonGroupSearch
+		case strings.EqualFold(UpnDomainField, f):
+			cp.UpnDomain = new.UpnDomain
+		case strings.EqualFold(UserDnField, f):
+			cp.UserDn = new.UserDn
+		case strings.EqualFold(UserAttrField, f):
+			cp.UserAttr = new.UserAttr
+		case strings.EqualFold(UserFilterField, f):
+			cp.UserFilter = new.UserFilter
+		case strings.EqualFold(EnableGroupsField, f):
+			cp.EnableGroups = new.EnableGroups
+		case strings.EqualFold(GroupDnField, f):
+			cp.GroupDn = new.GroupDn
+		case strings.EqualFold(GroupAttrField, f):
+			cp.GroupAttr = new.GroupAttr
+		case strings.EqualFold(GroupFilterField, f):
+			cp.GroupFilter = new.GroupFilter
+		case strings.EqualFold(BindDnField, f):
+			cp.BindDn = new.BindDn
+		case strings.EqualFold(BindPasswordField, f):
+			cp.BindPassword = new.BindPassword
+		case strings.EqualFold(UrlsField, f):
+			cp.Urls = append([]string(nil), new.Urls...)
+		case strings.EqualFold(CertificatesField, f):
+			cp.Certificates = append([]string(nil), new.Certificates...)
+		case strings.EqualFold(AccountAttributeMapsField, f):
+			cp.AccountAttributeMaps = append([]string(nil), new.AccountAttributeMaps...)
+		}
+	}
+	return cp
+}
@@ -0,0 +1,70 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package ldap
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/boundary/internal/errors"
+)
+
+// Authenticate authenticates the loginName and password against the
+// directory of the auth method with the authMethodId. If authentication is
+// successful, the account for the loginName is created or updated with the
+// user's attributes and groups from the directory, the account's managed
+// group memberships are refreshed, and the account is returned. Returns nil if
+// the directory rejects the credentials.
+func (r *Repository) Authenticate(ctx context.Context, authMethodId, loginName, password string) (*Account, error) {
+	const op = "ldap.(Repository).Authenticate"
+	if authMethodId == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing auth method id", errors.WithoutEvent())
+	}
+	if loginName == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing login name", errors.WithoutEvent())
+	}
+	if password == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing password", errors.WithoutEvent())
+	}
+
+	am, err := r.lookupAuthMethod(ctx, authMethodId)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	if am == nil {
+		return nil, errors.New(ctx, errors.RecordNotFound, op, fmt.Sprintf("auth method %s not found", authMethodId))
+	}
+
+	entry, err := authenticateWithDirectory(ctx, am, loginName, password)
+	switch {
+	case errors.Match(errors.T(errors.Unauthorized), err):
+		return nil, nil
+	case err != nil:
+		return nil, errors.Wrap(ctx, err, op)
+	}
+
+	acct, err := r.upsertAccount(ctx, am, loginName, entry)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+
+	mgs, err := r.ListManagedGroups(ctx, am.PublicId, WithLimit(-1))
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to list managed groups"))
+	}
+	matched := make([]*ManagedGroup, 0, len(mgs))
+	for _, mg := range mgs {
+		ok, err := mg.matches(ctx, entry.groups)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		if ok {
+			matched = append(matched, mg)
+		}
+	}
+	if _, _, err := r.SetManagedGroupMemberships(ctx, am, acct, matched); err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to set managed group memberships"))
+	}
+	return acct, nil
+}
@@ -0,0 +1,139 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package ldap
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_AuthMethod(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	org, _ := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	assert, require := assert.New(t), require.New(t)
+
+	repo, err := NewRepository(ctx, rw, rw, kmsCache)
+	require.NoError(err)
+
+	am, err := NewAuthMethod(ctx, org.PublicId,
+		WithName("ldap"),
+		WithUrls("ldaps://ldap2.example.org", "ldap://ldap1.example.org"),
+		WithStartTls(true),
+		WithUserDn("ou=people,dc=example,dc=org"),
+		WithBindCredential("cn=admin,dc=example,dc=org", "admin-password"),
+		WithAccountAttributeMaps("displayName=fullName"),
+	)
+	require.NoError(err)
+	created, err := repo.CreateAuthMethod(ctx, am)
+	require.NoError(err)
+	assert.Equal([]string{"ldaps://ldap2.example.org", "ldap://ldap1.example.org"}, created.Urls)
+	assert.Equal("admin-password", created.BindPassword)
+	assert.NotEmpty(created.BindPasswordHmac)
+	assert.NotEmpty(created.KeyId)
+
+	found, err := repo.LookupAuthMethod(ctx, created.PublicId)
+	require.NoError(err)
+	assert.Equal(created.Urls, found.Urls)
+	assert.Equal([]string{"displayName=fullName"}, found.AccountAttributeMaps)
+
+	// reordering the urls changes their connection priority, and clearing the
+	// bind dn and password removes the encrypted password.
+	update := AllocAuthMethod()
+	update.PublicId = created.PublicId
+	update.Urls = []string{"ldap://ldap1.example.org", "ldaps://ldap2.example.org"}
+	updated, rowsUpdated, err := repo.UpdateAuthMethod(ctx, &update, created.Version,
+		[]string{UrlsField, StartTlsField, BindDnField, BindPasswordField})
+	require.NoError(err)
+	assert.Equal(1, rowsUpdated)
+	assert.Equal(update.Urls, updated.Urls)
+	assert.False(updated.StartTls)
+	assert.Empty(updated.BindDn)
+	assert.Empty(updated.BindPassword)
+	assert.Empty(updated.BindPasswordHmac)
+	assert.Empty(updated.KeyId)
+
+	_, _, err = repo.UpdateAuthMethod(ctx, &update, updated.Version, []string{UrlsField, "ClientId"})
+	assert.Error(err)
+
+	deleted, err := repo.DeleteAuthMethod(ctx, created.PublicId)
+	require.NoError(err)
+	assert.Equal(1, deleted)
+}
+
+func TestRepository_Authenticate(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	org, _ := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	databaseWrapper, err := kmsCache.GetWrapper(ctx, org.PublicId, kms.KeyPurposeDatabase)
+	require.NoError(t, err)
+	assert, require := assert.New(t), require.New(t)
+
+	directory := NewTestDirectory(t, false, testDirectoryEntries()...)
+	am := TestAuthMethod(t, conn, databaseWrapper, org.PublicId, []string{directory.Url()},
+		WithUserDn("ou=people,dc=example,dc=org"),
+		WithUserAttr("uid"),
+		WithEnableGroups(true),
+		WithGroupDn("ou=groups,dc=example,dc=org"),
+	)
+	admins := TestManagedGroup(t, conn, am, []string{"ADMINS"})
+	developers := TestManagedGroup(t, conn, am, []string{"developers", "testers"})
+	TestManagedGroup(t, conn, am, []string{"testers"})
+
+	repo, err := NewRepository(ctx, rw, rw, kmsCache)
+	require.NoError(err)
+
+	acct, err := repo.Authenticate(ctx, am.PublicId, "Alice", "bad-password")
+	require.NoError(err)
+	assert.Nil(acct)
+
+	acct, err = repo.Authenticate(ctx, am.PublicId, "Alice", "alice-password")
+	require.NoError(err)
+	require.NotNil(acct)
+	wantId, err := newAccountId(ctx, am.PublicId, "alice")
+	require.NoError(err)
+	assert.Equal(wantId, acct.PublicId)
+	assert.Equal("alice", acct.LoginName)
+	assert.Equal("Alice Eve", acct.FullName)
+	assert.Equal("alice@example.org", acct.Email)
+	assert.Equal("uid=alice,ou=people,dc=example,dc=org", acct.Dn)
+	groups, err := acct.MemberOfGroupNames(ctx)
+	require.NoError(err)
+	assert.Equal([]string{"admins", "developers"}, groups)
+
+	memberships, err := repo.ListManagedGroupMembershipsByMember(ctx, acct.PublicId)
+	require.NoError(err)
+	var mgIds []string
+	for _, m := range memberships {
+		mgIds = append(mgIds, m.ManagedGroupId)
+	}
+	assert.ElementsMatch([]string{admins.PublicId, developers.PublicId}, mgIds)
+
+	// alice is removed from the admins group in the directory, so she's
+	// removed from the admins managed group the next time she authenticates.
+	entries := testDirectoryEntries()
+	entries[3].Attributes["member"] = nil
+	directory.SetEntries(entries...)
+	acct, err = repo.Authenticate(ctx, am.PublicId, "alice", "alice-password")
+	require.NoError(err)
+	require.NotNil(acct)
+	memberships, err = repo.ListManagedGroupMembershipsByMember(ctx, acct.PublicId)
+	require.NoError(err)
+	require.Len(memberships, 1)
+	assert.Equal(developers.PublicId, memberships[0].ManagedGroupId)
+}
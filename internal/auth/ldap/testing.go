@@ -0,0 +1,78 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package ldap
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/db"
+	wrapping "github.com/hashicorp/go-kms-wrapping/v2"
+	"github.com/stretchr/testify/require"
+)
+
+// TestAuthMethod creates a test ldap auth method. All the options supported by
+// NewAuthMethod are supported.
+func TestAuthMethod(t testing.TB, conn *db.DB, databaseWrapper wrapping.Wrapper, scopeId string, urls []string, opt ...Option) *AuthMethod {
+	t.Helper()
+	ctx := context.Background()
+	require := require.New(t)
+	rw := db.New(conn)
+
+	opt = append(opt, WithUrls(urls...))
+	am, err := NewAuthMethod(ctx, scopeId, opt...)
+	require.NoError(err)
+	id, err := newAuthMethodId(ctx)
+	require.NoError(err)
+	am.PublicId = id
+	if am.BindPassword != "" {
+		require.NoError(am.encrypt(ctx, databaseWrapper))
+	}
+	require.NoError(rw.Create(ctx, am))
+
+	vo, err := am.convertValueObjects(ctx)
+	require.NoError(err)
+	for _, items := range [][]any{vo.Urls, vo.Certs, vo.AccountAttributeMaps} {
+		if len(items) > 0 {
+			require.NoError(rw.CreateItems(ctx, items))
+		}
+	}
+	return am
+}
+
+// TestAccount creates a test ldap auth account.
+func TestAccount(t testing.TB, conn *db.DB, am *AuthMethod, loginName string, opt ...Option) *Account {
+	t.Helper()
+	require := require.New(t)
+	rw := db.New(conn)
+	ctx := context.Background()
+
+	a, err := NewAccount(ctx, am.PublicId, loginName, opt...)
+	require.NoError(err)
+
+	id, err := newAccountId(ctx, am.GetPublicId(), a.LoginName)
+	require.NoError(err)
+	a.PublicId = id
+
+	require.NoError(rw.Create(ctx, a))
+	return a
+}
+
+// TestManagedGroup creates a test ldap managed group.
+func TestManagedGroup(t testing.TB, conn *db.DB, am *AuthMethod, groupNames []string, opt ...Option) *ManagedGroup {
+	t.Helper()
+	require := require.New(t)
+	rw := db.New(conn)
+	ctx := context.Background()
+
+	mg, err := NewManagedGroup(ctx, am.PublicId, groupNames, opt...)
+	require.NoError(err)
+
+	id, err := newManagedGroupId(ctx)
+	require.NoError(err)
+	mg.PublicId = id
+
+	require.NoError(rw.Create(ctx, mg))
+	return mg
+}
@@ -0,0 +1,427 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package ldap
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"math/big"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	ber "github.com/go-asn1-ber/asn1-ber"
+	"github.com/go-ldap/ldap/v3"
+	"github.com/stretchr/testify/require"
+)
+
+const startTlsOid = "1.3.6.1.4.1.1466.20037"
+
+// TestEntry is an entry in a TestDirectory. Attribute names are matched case
+// insensitively. An entry with a Password can be bound to.
+type TestEntry struct {
+	DN         string
+	Password   string
+	Attributes map[string][]string
+}
+
+// TestDirectory is a minimal in-memory LDAP directory for tests. It supports
+// simple binds, anonymous binds, searches with and/or/not/equality/substring/
+// present filters, StartTLS and LDAPS. It's not a complete or conformant LDAP
+// server and should only be used for testing.
+type TestDirectory struct {
+	t         testing.TB
+	ln        net.Listener
+	tlsConfig *tls.Config
+	ldaps     bool
+	certPem   string
+
+	mu      sync.Mutex
+	entries []*TestEntry
+	conns   map[net.Conn]struct{}
+	closed  bool
+	wg      sync.WaitGroup
+}
+
+// NewTestDirectory starts a TestDirectory listening on the loopback interface
+// and registers its shutdown with t.Cleanup. When ldaps is true the directory
+// only accepts LDAPS connections; otherwise it accepts plain LDAP connections
+// which can be upgraded with StartTLS.
+func NewTestDirectory(t testing.TB, ldaps bool, entries ...*TestEntry) *TestDirectory {
+	t.Helper()
+	require := require.New(t)
+
+	certPem, cert := testDirectoryCertificate(t)
+	d := &TestDirectory{
+		t:         t,
+		ldaps:     ldaps,
+		certPem:   certPem,
+		tlsConfig: &tls.Config{Certificates: []tls.Certificate{cert}, MinVersion: tls.VersionTLS12},
+		entries:   entries,
+		conns:     make(map[net.Conn]struct{}),
+	}
+	var err error
+	switch ldaps {
+	case true:
+		d.ln, err = tls.Listen("tcp", "127.0.0.1:0", d.tlsConfig)
+	default:
+		d.ln, err = net.Listen("tcp", "127.0.0.1:0")
+	}
+	require.NoError(err)
+
+	d.wg.Add(1)
+	go d.serve()
+	t.Cleanup(d.Stop)
+	return d
+}
+
+// Url returns the url of the directory.
+func (d *TestDirectory) Url() string {
+	scheme := "ldap"
+	if d.ldaps {
+		scheme = "ldaps"
+	}
+	return fmt.Sprintf("%s://%s", scheme, d.ln.Addr().String())
+}
+
+// Cert returns the PEM encoded certificate used by the directory for TLS.
+func (d *TestDirectory) Cert() string {
+	return d.certPem
+}
+
+// SetEntries replaces the directory's entries.
+func (d *TestDirectory) SetEntries(entries ...*TestEntry) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.entries = entries
+}
+
+// Stop the directory, closing all of its connections.
+func (d *TestDirectory) Stop() {
+	d.mu.Lock()
+	if d.closed {
+		d.mu.Unlock()
+		return
+	}
+	d.closed = true
+	for c := range d.conns {
+		_ = c.Close()
+	}
+	d.mu.Unlock()
+	_ = d.ln.Close()
+	d.wg.Wait()
+}
+
+func (d *TestDirectory) serve() {
+	defer d.wg.Done()
+	for {
+		conn, err := d.ln.Accept()
+		if err != nil {
+			return
+		}
+		d.mu.Lock()
+		if d.closed {
+			d.mu.Unlock()
+			_ = conn.Close()
+			return
+		}
+		d.conns[conn] = struct{}{}
+		d.mu.Unlock()
+		d.wg.Add(1)
+		go d.handle(conn)
+	}
+}
+
+func (d *TestDirectory) handle(conn net.Conn) {
+	defer d.wg.Done()
+	defer func() {
+		d.mu.Lock()
+		delete(d.conns, conn)
+		d.mu.Unlock()
+		_ = conn.Close()
+	}()
+	for {
+		req, err := ber.ReadPacket(conn)
+		if err != nil {
+			return
+		}
+		if len(req.Children) < 2 {
+			return
+		}
+		msgId, ok := req.Children[0].Value.(int64)
+		if !ok {
+			return
+		}
+		op := req.Children[1]
+		switch op.Tag {
+		case ldap.ApplicationBindRequest:
+			code := d.bind(op)
+			if err := writeResult(conn, msgId, ldap.ApplicationBindResponse, code); err != nil {
+				return
+			}
+		case ldap.ApplicationUnbindRequest:
+			return
+		case ldap.ApplicationSearchRequest:
+			if err := d.search(conn, msgId, op); err != nil {
+				return
+			}
+		case ldap.ApplicationExtendedRequest:
+			if len(op.Children) == 0 || op.Children[0].Data.String() != startTlsOid || d.ldaps {
+				if err := writeResult(conn, msgId, ldap.ApplicationExtendedResponse, ldap.LDAPResultProtocolError); err != nil {
+					return
+				}
+				continue
+			}
+			if err := writeResult(conn, msgId, ldap.ApplicationExtendedResponse, ldap.LDAPResultSuccess); err != nil {
+				return
+			}
+			tlsConn := tls.Server(conn, d.tlsConfig)
+			if err := tlsConn.Handshake(); err != nil {
+				return
+			}
+			d.mu.Lock()
+			delete(d.conns, conn)
+			d.conns[tlsConn] = struct{}{}
+			d.mu.Unlock()
+			conn = tlsConn
+		default:
+			if err := writeResult(conn, msgId, ldap.ApplicationExtendedResponse, ldap.LDAPResultUnwillingToPerform); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// bind returns the result code of a simple bind request.
+func (d *TestDirectory) bind(op *ber.Packet) uint16 {
+	if len(op.Children) < 3 {
+		return ldap.LDAPResultProtocolError
+	}
+	name, _ := op.Children[1].Value.(string)
+	password := op.Children[2].Data.String()
+	if name == "" && password == "" {
+		return ldap.LDAPResultSuccess
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for _, e := range d.entries {
+		if e.Password == "" || e.Password != password {
+			continue
+		}
+		if strings.EqualFold(e.DN, name) {
+			return ldap.LDAPResultSuccess
+		}
+		for _, upn := range e.attribute("userPrincipalName") {
+			if strings.EqualFold(upn, name) {
+				return ldap.LDAPResultSuccess
+			}
+		}
+	}
+	return ldap.LDAPResultInvalidCredentials
+}
+
+func (d *TestDirectory) search(w io.Writer, msgId int64, op *ber.Packet) error {
+	if len(op.Children) < 8 {
+		return writeResult(w, msgId, ldap.ApplicationSearchResultDone, ldap.LDAPResultProtocolError)
+	}
+	base, _ := op.Children[0].Value.(string)
+	sizeLimit, _ := op.Children[3].Value.(int64)
+	filter := op.Children[6]
+	var attrs []string
+	for _, a := range op.Children[7].Children {
+		if s, ok := a.Value.(string); ok {
+			attrs = append(attrs, s)
+		}
+	}
+
+	d.mu.Lock()
+	var matched []*TestEntry
+	for _, e := range d.entries {
+		if !strings.HasSuffix(strings.ToLower(e.DN), strings.ToLower(base)) {
+			continue
+		}
+		if e.matches(filter) {
+			matched = append(matched, e)
+		}
+	}
+	d.mu.Unlock()
+
+	code := uint16(ldap.LDAPResultSuccess)
+	if sizeLimit > 0 && int64(len(matched)) > sizeLimit {
+		matched, code = matched[:sizeLimit], ldap.LDAPResultSizeLimitExceeded
+	}
+	for _, e := range matched {
+		if _, err := w.Write(e.searchResultEntry(msgId, attrs).Bytes()); err != nil {
+			return err
+		}
+	}
+	return writeResult(w, msgId, ldap.ApplicationSearchResultDone, code)
+}
+
+func (e *TestEntry) attribute(name string) []string {
+	for k, v := range e.Attributes {
+		if strings.EqualFold(k, name) {
+			return v
+		}
+	}
+	return nil
+}
+
+// matches evaluates a search filter against the entry.
+func (e *TestEntry) matches(f *ber.Packet) bool {
+	switch f.Tag {
+	case ldap.FilterAnd:
+		for _, c := range f.Children {
+			if !e.matches(c) {
+				return false
+			}
+		}
+		return true
+	case ldap.FilterOr:
+		for _, c := range f.Children {
+			if e.matches(c) {
+				return true
+			}
+		}
+		return false
+	case ldap.FilterNot:
+		return len(f.Children) == 1 && !e.matches(f.Children[0])
+	case ldap.FilterEqualityMatch:
+		if len(f.Children) != 2 {
+			return false
+		}
+		attr, _ := f.Children[0].Value.(string)
+		value, _ := f.Children[1].Value.(string)
+		for _, v := range e.attribute(attr) {
+			if strings.EqualFold(v, value) {
+				return true
+			}
+		}
+		return false
+	case ldap.FilterSubstrings:
+		if len(f.Children) != 2 {
+			return false
+		}
+		attr, _ := f.Children[0].Value.(string)
+		for _, v := range e.attribute(attr) {
+			if substringsMatch(strings.ToLower(v), f.Children[1].Children) {
+				return true
+			}
+		}
+		return false
+	case ldap.FilterPresent:
+		attr := f.Data.String()
+		return strings.EqualFold(attr, "objectClass") || len(e.attribute(attr)) > 0
+	default:
+		return false
+	}
+}
+
+func substringsMatch(v string, parts []*ber.Packet) bool {
+	for _, p := range parts {
+		s := strings.ToLower(p.Data.String())
+		switch p.Tag {
+		case ldap.FilterSubstringsInitial:
+			if !strings.HasPrefix(v, s) {
+				return false
+			}
+			v = v[len(s):]
+		case ldap.FilterSubstringsAny:
+			i := strings.Index(v, s)
+			if i < 0 {
+				return false
+			}
+			v = v[i+len(s):]
+		case ldap.FilterSubstringsFinal:
+			if !strings.HasSuffix(v, s) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (e *TestEntry) searchResultEntry(msgId int64, attrs []string) *ber.Packet {
+	p := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "LDAP Response")
+	p.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagInteger, msgId, "MessageID"))
+	op := ber.Encode(ber.ClassApplication, ber.TypeConstructed, ldap.ApplicationSearchResultEntry, nil, "Search Result Entry")
+	op.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, e.DN, "DN"))
+	attributes := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "Attributes")
+	for name, values := range e.Attributes {
+		if len(attrs) > 0 && !containsFold(attrs, name) {
+			continue
+		}
+		attr := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "Attribute")
+		attr.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, name, "Type"))
+		set := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSet, nil, "Values")
+		for _, v := range values {
+			set.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, v, "Value"))
+		}
+		attr.AppendChild(set)
+		attributes.AppendChild(attr)
+	}
+	op.AppendChild(attributes)
+	p.AppendChild(op)
+	return p
+}
+
+func containsFold(ss []string, s string) bool {
+	for _, v := range ss {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
+
+func writeResult(w io.Writer, msgId int64, tag ber.Tag, code uint16) error {
+	p := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "LDAP Response")
+	p.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagInteger, msgId, "MessageID"))
+	op := ber.Encode(ber.ClassApplication, ber.TypeConstructed, tag, nil, "Response")
+	op.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagEnumerated, int64(code), "Result Code"))
+	op.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", "Matched DN"))
+	op.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", "Diagnostic Message"))
+	p.AppendChild(op)
+	_, err := w.Write(p.Bytes())
+	return err
+}
+
+// testDirectoryCertificate returns a self-signed certificate for the loopback
+// interface, both PEM encoded and ready for use by a tls.Config.
+func testDirectoryCertificate(t testing.TB) (string, tls.Certificate) {
+	t.Helper()
+	require := require.New(t)
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "localhost"},
+		NotBefore:             time.Now().Add(-time.Minute),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		DNSNames:              []string{"localhost"},
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(err)
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	require.NoError(err)
+	certPem := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPem := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer})
+	cert, err := tls.X509KeyPair(certPem, keyPem)
+	require.NoError(err)
+	return string(certPem), cert
+}
@@ -0,0 +1,83 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package ldap
+
+import (
+	"context"
+
+	"github.com/hashicorp/boundary/internal/auth/ldap/store"
+	"github.com/hashicorp/boundary/internal/errors"
+	"google.golang.org/protobuf/proto"
+)
+
+// defaultUrlTableName defines the default table name for a Url
+const defaultUrlTableName = "auth_ldap_url"
+
+// Url defines a directory URL for an LDAP AuthMethod. Urls are tried in
+// order of their connection priority when authenticating. Urls are value
+// objects of an AuthMethod, therefore there's no need for oplog metadata,
+// since only the AuthMethod will have metadata because it's the root
+// aggregate.
+type Url struct {
+	*store.Url
+	tableName string
+}
+
+// NewUrl creates a new in memory Url assigned to an LDAP auth method.
+func NewUrl(ctx context.Context, authMethodId string, priority int, u string) (*Url, error) {
+	const op = "ldap.NewUrl"
+	if priority <= 0 {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "priority must be greater than zero")
+	}
+	obj := &Url{
+		Url: &store.Url{
+			LdapMethodId:       authMethodId,
+			ConnectionPriority: uint32(priority),
+			ServerUrl:          u,
+		},
+	}
+	if err := obj.validate(ctx, op); err != nil {
+		return nil, err // intentionally not wrapped
+	}
+	return obj, nil
+}
+
+// validate the Url and on success return nil
+func (u *Url) validate(ctx context.Context, caller errors.Op) error {
+	if u.LdapMethodId == "" {
+		return errors.New(ctx, errors.InvalidParameter, caller, "missing ldap auth method id")
+	}
+	if u.ConnectionPriority == 0 {
+		return errors.New(ctx, errors.InvalidParameter, caller, "missing connection priority")
+	}
+	return validateUrl(ctx, caller, u.ServerUrl)
+}
+
+// AllocUrl makes an empty one in memory
+func AllocUrl() Url {
+	return Url{
+		Url: &store.Url{},
+	}
+}
+
+// Clone a Url
+func (u *Url) Clone() *Url {
+	cp := proto.Clone(u.Url)
+	return &Url{
+		Url: cp.(*store.Url),
+	}
+}
+
+// TableName returns the table name.
+func (u *Url) TableName() string {
+	if u.tableName != "" {
+		return u.tableName
+	}
+	return defaultUrlTableName
+}
+
+// SetTableName sets the table name.
+func (u *Url) SetTableName(n string) {
+	u.tableName = n
+}
@@ -0,0 +1,56 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package auth
+
+import (
+	"context"
+
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/go-bexpr"
+	"github.com/mitchellh/pointerstructure"
+)
+
+// FilterManagedGroup is a ManagedGroup whose membership is determined by a
+// go-bexpr filter evaluated against data about the account, such as the
+// claims of an OIDC account or the fields of a password account.
+type FilterManagedGroup interface {
+	ManagedGroup
+	GetFilter() string
+}
+
+// ValidateManagedGroupFilter returns an error if filter is not a valid
+// go-bexpr filter.
+func ValidateManagedGroupFilter(ctx context.Context, filter string) error {
+	const op = "auth.ValidateManagedGroupFilter"
+	if filter == "" {
+		return errors.New(ctx, errors.InvalidParameter, op, "missing filter")
+	}
+	if _, err := bexpr.CreateEvaluator(filter); err != nil {
+		return errors.New(ctx, errors.InvalidParameter, op, "error evaluating filter expression", errors.WithWrap(err))
+	}
+	return nil
+}
+
+// MatchManagedGroupFilters returns the subset of mgs whose filter matches
+// data. A filter which selects a value not present in data does not match.
+func MatchManagedGroupFilters[T FilterManagedGroup](ctx context.Context, mgs []T, data any) ([]T, error) {
+	const op = "auth.MatchManagedGroupFilters"
+	matched := make([]T, 0, len(mgs))
+	for _, mg := range mgs {
+		eval, err := bexpr.CreateEvaluator(mg.GetFilter())
+		if err != nil {
+			// We check all filters on ingress so this should never happen,
+			// but we validate anyways
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		match, err := eval.Evaluate(data)
+		if err != nil && !errors.Is(err, pointerstructure.ErrNotFound) {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		if match {
+			matched = append(matched, mg)
+		}
+	}
+	return matched, nil
+}
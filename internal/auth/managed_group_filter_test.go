@@ -0,0 +1,55 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package auth
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testFilterManagedGroup struct {
+	ManagedGroup
+	filter string
+}
+
+func (mg testFilterManagedGroup) GetFilter() string { return mg.filter }
+
+func TestValidateManagedGroupFilter(t *testing.T) {
+	ctx := context.Background()
+	err := ValidateManagedGroupFilter(ctx, "")
+	require.Error(t, err)
+	assert.True(t, errors.Match(errors.T(errors.InvalidParameter), err))
+
+	err = ValidateManagedGroupFilter(ctx, `"/account/name" ==`)
+	require.Error(t, err)
+	assert.True(t, errors.Match(errors.T(errors.InvalidParameter), err))
+
+	assert.NoError(t, ValidateManagedGroupFilter(ctx, `"/account/name" == "alice"`))
+}
+
+func TestMatchManagedGroupFilters(t *testing.T) {
+	ctx := context.Background()
+	data := map[string]any{
+		"account": map[string]any{
+			"name": "alice",
+			"custom_attributes": map[string]string{
+				"department": "eng",
+			},
+		},
+	}
+	byName := testFilterManagedGroup{filter: `"/account/name" == "alice"`}
+	byAttr := testFilterManagedGroup{filter: `"/account/custom_attributes/department" == "sales"`}
+	missing := testFilterManagedGroup{filter: `"/account/custom_attributes/title" == "cto"`}
+
+	got, err := MatchManagedGroupFilters(ctx, []testFilterManagedGroup{byName, byAttr, missing}, data)
+	require.NoError(t, err)
+	assert.Equal(t, []testFilterManagedGroup{byName}, got)
+
+	_, err = MatchManagedGroupFilters(ctx, []testFilterManagedGroup{{filter: "not a filter"}}, data)
+	assert.Error(t, err)
+}
@@ -5,6 +5,7 @@ package oidc
 
 import (
 	"context"
+	"encoding/json"
 	"net/url"
 
 	"github.com/hashicorp/boundary/internal/auth/oidc/store"
@@ -121,6 +122,30 @@ func (a *Account) GetLoginName() string {
 	return ""
 }
 
+// Claims returns the decoded claims of the ID token and userinfo the account
+// last authenticated with. Where both contain a claim, the value from the ID
+// token is used.
+func (a *Account) Claims(ctx context.Context) (map[string]any, error) {
+	const op = "oidc.(Account).Claims"
+	var claims map[string]any
+	for _, raw := range []string{a.GetUserinfoClaims(), a.GetTokenClaims()} {
+		if raw == "" {
+			continue
+		}
+		var m map[string]any
+		if err := json.Unmarshal([]byte(raw), &m); err != nil {
+			return nil, errors.Wrap(ctx, err, op, errors.WithCode(errors.Decode))
+		}
+		if claims == nil {
+			claims = make(map[string]any, len(m))
+		}
+		for k, v := range m {
+			claims[k] = v
+		}
+	}
+	return claims, nil
+}
+
 // oplog will create oplog metadata for the Account.
 func (c *Account) oplog(op oplog.OpType, authMethodScopeId string) oplog.Metadata {
 	metadata := oplog.Metadata{
@@ -375,3 +375,51 @@ func TestAccount_SetTableName(t *testing.T) {
 		})
 	}
 }
+
+func TestAccount_Claims(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	tests := []struct {
+		name      string
+		token     string
+		userinfo  string
+		want      map[string]any
+		wantErrIs errors.Code
+	}{
+		{
+			name: "no-claims",
+		},
+		{
+			name:  "token-claims",
+			token: `{"sub":"alice","team":"blue"}`,
+			want:  map[string]any{"sub": "alice", "team": "blue"},
+		},
+		{
+			name:     "token-claims-take-precedence",
+			token:    `{"sub":"alice","team":"blue"}`,
+			userinfo: `{"team":"red","email":"alice@example.com"}`,
+			want:     map[string]any{"sub": "alice", "team": "blue", "email": "alice@example.com"},
+		},
+		{
+			name:      "bad-claims",
+			userinfo:  `{"team":`,
+			wantErrIs: errors.Decode,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			a := AllocAccount()
+			a.TokenClaims = tt.token
+			a.UserinfoClaims = tt.userinfo
+			got, err := a.Claims(ctx)
+			if tt.wantErrIs != errors.Unknown {
+				require.Error(err)
+				assert.True(errors.Match(errors.T(tt.wantErrIs), err))
+				return
+			}
+			require.NoError(err)
+			assert.Equal(tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oidc
+
+import (
+	"context"
+
+	"github.com/hashicorp/boundary/internal/auth/oidc/store"
+	"github.com/hashicorp/boundary/internal/errors"
+	"google.golang.org/protobuf/proto"
+)
+
+// defaultAcrValueTableName defines the default table name for an AcrValue
+const defaultAcrValueTableName = "auth_oidc_acr_value"
+
+// AcrValue defines an optional authentication context class reference which
+// is requested from the provider during authentication.  When an auth method
+// has AcrValues, the "acr" claim of an ID Token must match one of them.
+//
+// see: https://openid.net/specs/openid-connect-core-1_0.html#AuthRequest
+type AcrValue struct {
+	*store.AcrValue
+	tableName string
+}
+
+// NewAcrValue creates a new in memory acr value assigned to an OIDC
+// AuthMethod. It supports no options.
+func NewAcrValue(ctx context.Context, authMethodId, acrValue string) (*AcrValue, error) {
+	const op = "oidc.NewAcrValue"
+	a := &AcrValue{
+		AcrValue: &store.AcrValue{
+			OidcMethodId: authMethodId,
+			AcrValue:     acrValue,
+		},
+	}
+	if err := a.validate(ctx, op); err != nil {
+		return nil, err // intentionally not wrapped
+	}
+	return a, nil
+}
+
+// validate the AcrValue.  On success, it will return nil.
+func (a *AcrValue) validate(ctx context.Context, caller errors.Op) error {
+	if a.OidcMethodId == "" {
+		return errors.New(ctx, errors.InvalidParameter, caller, "missing oidc auth method id")
+	}
+	if a.AcrValue.AcrValue == "" {
+		return errors.New(ctx, errors.InvalidParameter, caller, "missing acr value")
+	}
+	return nil
+}
+
+// AllocAcrValue makes an empty one in memory
+func AllocAcrValue() AcrValue {
+	return AcrValue{
+		AcrValue: &store.AcrValue{},
+	}
+}
+
+// Clone an AcrValue
+func (a *AcrValue) Clone() *AcrValue {
+	cp := proto.Clone(a.AcrValue)
+	return &AcrValue{
+		AcrValue: cp.(*store.AcrValue),
+	}
+}
+
+// TableName returns the table name.
+func (a *AcrValue) TableName() string {
+	if a.tableName != "" {
+		return a.tableName
+	}
+	return defaultAcrValueTableName
+}
+
+// SetTableName sets the table name.
+func (a *AcrValue) SetTableName(n string) {
+	a.tableName = n
+}
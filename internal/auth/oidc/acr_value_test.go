@@ -0,0 +1,116 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oidc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAcrValue_Create(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	org, _ := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+
+	databaseWrapper, err := kmsCache.GetWrapper(ctx, org.PublicId, kms.KeyPurposeDatabase)
+	require.NoError(t, err)
+
+	testAuthMethod := TestAuthMethod(t, conn, databaseWrapper, org.PublicId, InactiveState, "alice_rp", "my-dogs-name",
+		WithIssuer(TestConvertToUrls(t, "https://alice.com")[0]), WithApiUrl(TestConvertToUrls(t, "https://api.com")[0]))
+
+	type args struct {
+		authMethodId string
+		acrValue     string
+	}
+	tests := []struct {
+		name               string
+		args               args
+		createResource     bool
+		createWantErrMatch *errors.Template
+		want               *AcrValue
+		wantErrMatch       *errors.Template
+	}{
+		{
+			name: "valid",
+			args: args{
+				authMethodId: testAuthMethod.PublicId,
+				acrValue:     "phr",
+			},
+			createResource: true,
+			want: func() *AcrValue {
+				want := AllocAcrValue()
+				want.OidcMethodId = testAuthMethod.PublicId
+				want.AcrValue.AcrValue = "phr"
+				return &want
+			}(),
+		},
+		{
+			name: "dup",
+			args: args{
+				authMethodId: testAuthMethod.PublicId,
+				acrValue:     "phr",
+			},
+			createResource: true,
+			want: func() *AcrValue {
+				want := AllocAcrValue()
+				want.OidcMethodId = testAuthMethod.PublicId
+				want.AcrValue.AcrValue = "phr"
+				return &want
+			}(),
+			createWantErrMatch: errors.T(errors.NotUnique),
+		},
+		{
+			name: "empty-auth-method",
+			args: args{
+				authMethodId: "",
+				acrValue:     "phr",
+			},
+			wantErrMatch: errors.T(errors.InvalidParameter),
+		},
+		{
+			name: "empty-acr-value",
+			args: args{
+				authMethodId: testAuthMethod.PublicId,
+				acrValue:     "",
+			},
+			wantErrMatch: errors.T(errors.InvalidParameter),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, err := NewAcrValue(ctx, tt.args.authMethodId, tt.args.acrValue)
+			if tt.wantErrMatch != nil {
+				require.Error(err)
+				assert.Nil(got)
+				assert.Truef(errors.Match(tt.wantErrMatch, err), "wanted error %s and got: %s", tt.wantErrMatch.Code, err.Error())
+				return
+			}
+			require.NoError(err)
+			assert.Equal(tt.want, got)
+			if tt.createResource {
+				err := rw.Create(ctx, got)
+				if tt.createWantErrMatch != nil {
+					require.Error(err)
+					assert.Truef(errors.Match(tt.createWantErrMatch, err), "wanted error %s and got: %s", tt.createWantErrMatch.Code, err.Error())
+					return
+				}
+				assert.NoError(err)
+				found := AllocAcrValue()
+				require.NoError(rw.LookupWhere(ctx, &found, "oidc_method_id = ? and acr_value = ?", []any{tt.args.authMethodId, tt.args.acrValue}))
+			}
+		})
+	}
+}
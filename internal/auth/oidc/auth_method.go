@@ -61,7 +61,8 @@ type AuthMethod struct {
 // See: https://openid.net/specs/openid-connect-core-1_0.html
 //
 // Supports the options of WithMaxAge, WithSigningAlgs, WithAudClaims,
-// WithApiUrl and WithCertificates and all other options are ignored.
+// WithApiUrl, WithCertificates, WithPrompts, WithAcrValues and WithEnablePkce
+// and all other options are ignored.
 func NewAuthMethod(ctx context.Context, scopeId string, clientId string, clientSecret ClientSecret, opt ...Option) (*AuthMethod, error) {
 	const op = "oidc.NewAuthMethod"
 	opts := getOpts(opt...)
@@ -83,6 +84,7 @@ func NewAuthMethod(ctx context.Context, scopeId string, clientId string, clientS
 			ClientSecret:     string(clientSecret),
 			MaxAge:           int32(opts.withMaxAge),
 			ClaimsScopes:     opts.withClaimsScopes,
+			EnablePkce:       opts.withEnablePkce,
 		},
 	}
 	if opts.withApiUrl != nil {
@@ -107,6 +109,16 @@ func NewAuthMethod(ctx context.Context, scopeId string, clientId string, clientS
 			a.SigningAlgs = append(a.SigningAlgs, string(alg))
 		}
 	}
+	if len(opts.withPrompts) > 0 {
+		a.Prompts = make([]string, 0, len(opts.withPrompts))
+		for _, p := range opts.withPrompts {
+			a.Prompts = append(a.Prompts, string(p))
+		}
+	}
+	if len(opts.withAcrValues) > 0 {
+		a.AcrValues = make([]string, 0, len(opts.withAcrValues))
+		a.AcrValues = append(a.AcrValues, opts.withAcrValues...)
+	}
 	if len(opts.withAccountClaimMap) > 0 {
 		a.AccountClaimMaps = make([]string, 0, len(opts.withAccountClaimMap))
 		for k, v := range opts.withAccountClaimMap {
@@ -159,6 +171,9 @@ func (a *AuthMethod) validate(ctx context.Context, caller errors.Op) error {
 	if a.MaxAge < -1 {
 		return errors.New(ctx, errors.InvalidParameter, caller, "max age cannot be less than -1")
 	}
+	if err := ValidatePrompts(ctx, a.Prompts...); err != nil {
+		return errors.Wrap(ctx, err, caller)
+	}
 	return nil
 }
 
@@ -282,6 +297,8 @@ type convertedValues struct {
 	Certs            []any
 	ClaimsScopes     []any
 	AccountClaimMaps []any
+	Prompts          []any
+	AcrValues        []any
 }
 
 // convertValueObjects converts the embedded value objects. It will return an
@@ -292,7 +309,7 @@ func (am *AuthMethod) convertValueObjects(ctx context.Context) (*convertedValues
 		return nil, errors.New(ctx, errors.InvalidPublicId, op, "missing public id")
 	}
 	var err error
-	var addAlgs, addAuds, addCerts, addScopes, addAccountClaimMaps []any
+	var addAlgs, addAuds, addCerts, addScopes, addAccountClaimMaps, addPrompts, addAcrValues []any
 	if addAlgs, err = am.convertSigningAlgs(ctx); err != nil {
 		return nil, errors.Wrap(ctx, err, op)
 	}
@@ -308,12 +325,20 @@ func (am *AuthMethod) convertValueObjects(ctx context.Context) (*convertedValues
 	if addAccountClaimMaps, err = am.convertAccountClaimMaps(ctx); err != nil {
 		return nil, errors.Wrap(ctx, err, op)
 	}
+	if addPrompts, err = am.convertPrompts(ctx); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	if addAcrValues, err = am.convertAcrValues(ctx); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
 	return &convertedValues{
 		Algs:             addAlgs,
 		Auds:             addAuds,
 		Certs:            addCerts,
 		ClaimsScopes:     addScopes,
 		AccountClaimMaps: addAccountClaimMaps,
+		Prompts:          addPrompts,
+		AcrValues:        addAcrValues,
 	}, nil
 }
 
@@ -393,6 +418,44 @@ func (am *AuthMethod) convertClaimsScopes(ctx context.Context) ([]any, error) {
 	return newInterfaces, nil
 }
 
+// convertPrompts converts the embedded prompts from []string to
+// []interface{} where each slice element is a *Prompt. It will return an
+// error if the AuthMethod's public id is not set.
+func (am *AuthMethod) convertPrompts(ctx context.Context) ([]any, error) {
+	const op = "oidc.(AuthMethod).convertPrompts"
+	if am.PublicId == "" {
+		return nil, errors.New(ctx, errors.InvalidPublicId, op, "missing public id")
+	}
+	newInterfaces := make([]any, 0, len(am.Prompts))
+	for _, p := range am.Prompts {
+		obj, err := NewPrompt(ctx, am.PublicId, PromptParam(p))
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		newInterfaces = append(newInterfaces, obj)
+	}
+	return newInterfaces, nil
+}
+
+// convertAcrValues converts the embedded acr values from []string to
+// []interface{} where each slice element is an *AcrValue. It will return an
+// error if the AuthMethod's public id is not set.
+func (am *AuthMethod) convertAcrValues(ctx context.Context) ([]any, error) {
+	const op = "oidc.(AuthMethod).convertAcrValues"
+	if am.PublicId == "" {
+		return nil, errors.New(ctx, errors.InvalidPublicId, op, "missing public id")
+	}
+	newInterfaces := make([]any, 0, len(am.AcrValues))
+	for _, a := range am.AcrValues {
+		obj, err := NewAcrValue(ctx, am.PublicId, a)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		newInterfaces = append(newInterfaces, obj)
+	}
+	return newInterfaces, nil
+}
+
 // convertAccountClaimMaps converts the embedded account claim maps from
 // []string to []interface{} where each slice element is a *AccountClaimMap. It
 // will return an error if the AuthMethod's public id is not set or it can
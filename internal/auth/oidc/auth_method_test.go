@@ -591,6 +591,22 @@ func Test_convertValueObjects(t *testing.T) {
 		testAccountClaimMaps = append(testAccountClaimMaps, obj)
 	}
 
+	testPromptValues := []string{string(Login), string(Consent)}
+	testPrompts := make([]any, 0, len(testPromptValues))
+	for _, p := range testPromptValues {
+		obj, err := NewPrompt(ctx, testPublicId, PromptParam(p))
+		require.NoError(t, err)
+		testPrompts = append(testPrompts, obj)
+	}
+
+	testAcrs := []string{"phr", "phrh"}
+	testAcrValues := make([]any, 0, len(testAcrs))
+	for _, a := range testAcrs {
+		obj, err := NewAcrValue(ctx, testPublicId, a)
+		require.NoError(t, err)
+		testAcrValues = append(testAcrValues, obj)
+	}
+
 	tests := []struct {
 		name            string
 		authMethodId    string
@@ -599,6 +615,8 @@ func Test_convertValueObjects(t *testing.T) {
 		certs           []string
 		scopes          []string
 		maps            []string
+		prompts         []string
+		acrs            []string
 		wantValues      *convertedValues
 		wantErrMatch    *errors.Template
 		wantErrContains string
@@ -611,12 +629,16 @@ func Test_convertValueObjects(t *testing.T) {
 			certs:        testCerts,
 			scopes:       testScopes,
 			maps:         testClaimMaps,
+			prompts:      testPromptValues,
+			acrs:         testAcrs,
 			wantValues: &convertedValues{
 				Algs:             testSigningAlgs,
 				Auds:             testAudiences,
 				Certs:            testCertificates,
 				ClaimsScopes:     testClaimsScopes,
 				AccountClaimMaps: testAccountClaimMaps,
+				Prompts:          testPrompts,
+				AcrValues:        testAcrValues,
 			},
 		},
 		{
@@ -636,6 +658,8 @@ func Test_convertValueObjects(t *testing.T) {
 					Certificates:     tt.certs,
 					ClaimsScopes:     tt.scopes,
 					AccountClaimMaps: tt.maps,
+					Prompts:          tt.prompts,
+					AcrValues:        tt.acrs,
 				},
 			}
 
@@ -693,6 +717,22 @@ func Test_convertValueObjects(t *testing.T) {
 				assert.Equal(want, got)
 			}
 
+			convertedPrompts, err := am.convertPrompts(ctx)
+			if tt.wantErrMatch != nil {
+				require.Error(err)
+				assert.Truef(errors.Match(tt.wantErrMatch, err), "wanted err %q and got: %+v", tt.wantErrMatch.Code, err)
+			} else {
+				assert.Equal(tt.wantValues.Prompts, convertedPrompts)
+			}
+
+			convertedAcrValues, err := am.convertAcrValues(ctx)
+			if tt.wantErrMatch != nil {
+				require.Error(err)
+				assert.Truef(errors.Match(tt.wantErrMatch, err), "wanted err %q and got: %+v", tt.wantErrMatch.Code, err)
+			} else {
+				assert.Equal(tt.wantValues.AcrValues, convertedAcrValues)
+			}
+
 			values, err := am.convertValueObjects(ctx)
 			if tt.wantErrMatch != nil {
 				require.Error(err)
@@ -726,6 +766,10 @@ func (a converted) Less(i, j int) bool {
 		return a[i].(*AudClaim).GetAud() < a[j].(*AudClaim).GetAud()
 	case *ClaimsScope:
 		return a[i].(*ClaimsScope).GetScope() < a[j].(*ClaimsScope).GetScope()
+	case *Prompt:
+		return a[i].(*Prompt).GetPrompt() < a[j].(*Prompt).GetPrompt()
+	case *AcrValue:
+		return a[i].(*AcrValue).GetAcrValue() < a[j].(*AcrValue).GetAcrValue()
 	}
 	return false
 }
@@ -736,4 +780,6 @@ func testSortConverted(t *testing.T, c *convertedValues) {
 	sort.Sort(converted(c.AccountClaimMaps))
 	sort.Sort(converted(c.Auds))
 	sort.Sort(converted(c.ClaimsScopes))
+	sort.Sort(converted(c.Prompts))
+	sort.Sort(converted(c.AcrValues))
 }
@@ -33,6 +33,9 @@ type options struct {
 	withAudClaims           []string
 	withSigningAlgs         []Alg
 	withClaimsScopes        []string
+	withPrompts             []PromptParam
+	withAcrValues           []string
+	withEnablePkce          bool
 	withEmail               string
 	withFullName            string
 	withOrderByCreateTime   bool
@@ -48,6 +51,7 @@ type options struct {
 	withOperationalState    AuthMethodState
 	withAccountClaimMap     map[string]AccountToClaim
 	withReader              db.Reader
+	withPage                *db.Page
 }
 
 func getDefaultOptions() options {
@@ -70,6 +74,14 @@ func WithName(name string) Option {
 	}
 }
 
+// WithPage provides an option to list one page of resources. Use WithLimit to
+// set the size of the page.
+func WithPage(p *db.Page) Option {
+	return func(o *options) {
+		o.withPage = p
+	}
+}
+
 // WithLimit provides an option to provide a limit.  Intentionally allowing
 // negative integers.   If WithLimit < 0, then unlimited results are returned.
 // If WithLimit == 0, then default limits are used for results.
@@ -127,6 +139,29 @@ func WithClaimsScopes(claimsScope ...string) Option {
 	}
 }
 
+// WithPrompts provides optional prompts
+func WithPrompts(prompt ...PromptParam) Option {
+	return func(o *options) {
+		o.withPrompts = prompt
+	}
+}
+
+// WithAcrValues provides optional authentication context class references
+func WithAcrValues(acr ...string) Option {
+	return func(o *options) {
+		o.withAcrValues = acr
+	}
+}
+
+// WithEnablePkce provides an option to use PKCE for authentication requests
+//
+// see: https://tools.ietf.org/html/rfc7636
+func WithEnablePkce(enable bool) Option {
+	return func(o *options) {
+		o.withEnablePkce = enable
+	}
+}
+
 // WithEmail provides an optional email address for the account.
 func WithEmail(email string) Option {
 	return func(o *options) {
@@ -97,6 +97,29 @@ func Test_GetOpts(t *testing.T) {
 		testOpts.withClaimsScopes = scopes
 		assert.Equal(opts, testOpts)
 	})
+	t.Run("WithPrompts", func(t *testing.T) {
+		assert := assert.New(t)
+		prompts := []PromptParam{Login, Consent}
+		opts := getOpts(WithPrompts(prompts...))
+		testOpts := getDefaultOptions()
+		testOpts.withPrompts = prompts
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithAcrValues", func(t *testing.T) {
+		assert := assert.New(t)
+		acrs := []string{"phr", "phrh"}
+		opts := getOpts(WithAcrValues(acrs...))
+		testOpts := getDefaultOptions()
+		testOpts.withAcrValues = acrs
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithEnablePkce", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithEnablePkce(true))
+		testOpts := getDefaultOptions()
+		testOpts.withEnablePkce = true
+		assert.Equal(opts, testOpts)
+	})
 	t.Run("WithEmail", func(t *testing.T) {
 		assert := assert.New(t)
 		opts := getOpts(WithEmail("bob@alice.com"))
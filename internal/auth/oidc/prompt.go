@@ -0,0 +1,123 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oidc
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/boundary/internal/auth/oidc/store"
+	"github.com/hashicorp/boundary/internal/errors"
+	"google.golang.org/protobuf/proto"
+)
+
+// PromptParam represents OIDC prompt values
+type PromptParam string
+
+const (
+	// Prompt values defined by OIDC which specify whether the Authorization
+	// Server prompts the End-User for reauthentication and consent.
+	//
+	// See: https://openid.net/specs/openid-connect-core-1_0.html#AuthRequest
+	None          PromptParam = "none"
+	Login         PromptParam = "login"
+	Consent       PromptParam = "consent"
+	SelectAccount PromptParam = "select_account"
+)
+
+var supportedPrompts = map[PromptParam]bool{
+	None:          true,
+	Login:         true,
+	Consent:       true,
+	SelectAccount: true,
+}
+
+// SupportedPrompt returns true iff the provided prompt is supported.
+func SupportedPrompt(p PromptParam) bool {
+	return supportedPrompts[p]
+}
+
+// ValidatePrompts validates the set of prompts.  It returns an error if a
+// prompt isn't supported or if "none" is combined with any other prompt.
+func ValidatePrompts(ctx context.Context, prompts ...string) error {
+	const op = "oidc.ValidatePrompts"
+	for _, p := range prompts {
+		if !SupportedPrompt(PromptParam(p)) {
+			return errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("unsupported prompt: %s", p))
+		}
+		if PromptParam(p) == None && len(prompts) > 1 {
+			return errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("%s cannot be combined with other prompts", None))
+		}
+	}
+	return nil
+}
+
+// defaultPromptTableName defines the default table name for a Prompt
+const defaultPromptTableName = "auth_oidc_prompt"
+
+// Prompt defines an optional OIDC prompt value which is sent to the provider
+// with authentication requests. It is assigned to an OIDC AuthMethod and
+// updates/deletes to that AuthMethod are cascaded to its Prompts. Prompts are
+// value objects of an AuthMethod, therefore there's no need for oplog
+// metadata, since only the AuthMethod will have metadata because it's the
+// root aggregate.
+type Prompt struct {
+	*store.Prompt
+	tableName string
+}
+
+// NewPrompt creates a new in memory prompt assigned to an OIDC AuthMethod. It
+// supports no options.
+func NewPrompt(ctx context.Context, authMethodId string, p PromptParam) (*Prompt, error) {
+	const op = "oidc.NewPrompt"
+	prompt := &Prompt{
+		Prompt: &store.Prompt{
+			OidcMethodId: authMethodId,
+			Prompt:       string(p),
+		},
+	}
+	if err := prompt.validate(ctx, op); err != nil {
+		return nil, err // intentionally not wrapped
+	}
+	return prompt, nil
+}
+
+// validate the Prompt.  On success, it will return nil.
+func (p *Prompt) validate(ctx context.Context, caller errors.Op) error {
+	if p.OidcMethodId == "" {
+		return errors.New(ctx, errors.InvalidParameter, caller, "missing oidc auth method id")
+	}
+	if !SupportedPrompt(PromptParam(p.Prompt.Prompt)) {
+		return errors.New(ctx, errors.InvalidParameter, caller, fmt.Sprintf("unsupported prompt: %s", p.Prompt.Prompt))
+	}
+	return nil
+}
+
+// AllocPrompt makes an empty one in memory
+func AllocPrompt() Prompt {
+	return Prompt{
+		Prompt: &store.Prompt{},
+	}
+}
+
+// Clone a Prompt
+func (p *Prompt) Clone() *Prompt {
+	cp := proto.Clone(p.Prompt)
+	return &Prompt{
+		Prompt: cp.(*store.Prompt),
+	}
+}
+
+// TableName returns the table name.
+func (p *Prompt) TableName() string {
+	if p.tableName != "" {
+		return p.tableName
+	}
+	return defaultPromptTableName
+}
+
+// SetTableName sets the table name.
+func (p *Prompt) SetTableName(n string) {
+	p.tableName = n
+}
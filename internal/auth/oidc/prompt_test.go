@@ -0,0 +1,176 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oidc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrompt_Create(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	org, _ := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+
+	databaseWrapper, err := kmsCache.GetWrapper(ctx, org.PublicId, kms.KeyPurposeDatabase)
+	require.NoError(t, err)
+
+	testAuthMethod := TestAuthMethod(t, conn, databaseWrapper, org.PublicId, InactiveState, "alice_rp", "my-dogs-name",
+		WithIssuer(TestConvertToUrls(t, "https://alice.com")[0]), WithApiUrl(TestConvertToUrls(t, "https://api.com")[0]))
+
+	type args struct {
+		authMethodId string
+		prompt       PromptParam
+	}
+	tests := []struct {
+		name               string
+		args               args
+		createResource     bool
+		createWantErrMatch *errors.Template
+		want               *Prompt
+		wantErrMatch       *errors.Template
+	}{
+		{
+			name: "valid",
+			args: args{
+				authMethodId: testAuthMethod.PublicId,
+				prompt:       Login,
+			},
+			createResource: true,
+			want: func() *Prompt {
+				want := AllocPrompt()
+				want.OidcMethodId = testAuthMethod.PublicId
+				want.Prompt.Prompt = string(Login)
+				return &want
+			}(),
+		},
+		{
+			name: "dup",
+			args: args{
+				authMethodId: testAuthMethod.PublicId,
+				prompt:       Login,
+			},
+			createResource: true,
+			want: func() *Prompt {
+				want := AllocPrompt()
+				want.OidcMethodId = testAuthMethod.PublicId
+				want.Prompt.Prompt = string(Login)
+				return &want
+			}(),
+			createWantErrMatch: errors.T(errors.NotUnique),
+		},
+		{
+			name: "none-with-other-prompts",
+			args: args{
+				authMethodId: testAuthMethod.PublicId,
+				prompt:       None,
+			},
+			createResource: true,
+			want: func() *Prompt {
+				want := AllocPrompt()
+				want.OidcMethodId = testAuthMethod.PublicId
+				want.Prompt.Prompt = string(None)
+				return &want
+			}(),
+			createWantErrMatch: errors.T(errors.Exception),
+		},
+		{
+			name: "empty-auth-method",
+			args: args{
+				authMethodId: "",
+				prompt:       Consent,
+			},
+			wantErrMatch: errors.T(errors.InvalidParameter),
+		},
+		{
+			name: "unsupported-prompt",
+			args: args{
+				authMethodId: testAuthMethod.PublicId,
+				prompt:       "always",
+			},
+			wantErrMatch: errors.T(errors.InvalidParameter),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, err := NewPrompt(ctx, tt.args.authMethodId, tt.args.prompt)
+			if tt.wantErrMatch != nil {
+				require.Error(err)
+				assert.Nil(got)
+				assert.Truef(errors.Match(tt.wantErrMatch, err), "wanted error %s and got: %s", tt.wantErrMatch.Code, err.Error())
+				return
+			}
+			require.NoError(err)
+			assert.Equal(tt.want, got)
+			if tt.createResource {
+				err := rw.Create(ctx, got)
+				if tt.createWantErrMatch != nil {
+					require.Error(err)
+					assert.Truef(errors.Match(tt.createWantErrMatch, err), "wanted error %s and got: %s", tt.createWantErrMatch.Code, err.Error())
+					return
+				}
+				assert.NoError(err)
+				found := AllocPrompt()
+				require.NoError(rw.LookupWhere(ctx, &found, "oidc_method_id = ? and prompt = ?", []any{tt.args.authMethodId, string(tt.args.prompt)}))
+			}
+		})
+	}
+}
+
+func TestValidatePrompts(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	tests := []struct {
+		name            string
+		prompts         []string
+		wantErrContains string
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name:    "none",
+			prompts: []string{string(None)},
+		},
+		{
+			name:    "multiple",
+			prompts: []string{string(Login), string(Consent), string(SelectAccount)},
+		},
+		{
+			name:            "unsupported",
+			prompts:         []string{string(Login), "always"},
+			wantErrContains: "unsupported prompt: always",
+		},
+		{
+			name:            "none-with-others",
+			prompts:         []string{string(Login), string(None)},
+			wantErrContains: "none cannot be combined with other prompts",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			err := ValidatePrompts(ctx, tt.prompts...)
+			if tt.wantErrContains != "" {
+				assert.Error(err)
+				assert.Truef(errors.Match(errors.T(errors.InvalidParameter), err), "wanted error %s and got: %s", errors.InvalidParameter, err)
+				assert.Contains(err.Error(), tt.wantErrContains)
+				return
+			}
+			assert.NoError(err)
+		})
+	}
+}
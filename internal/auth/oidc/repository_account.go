@@ -7,11 +7,13 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/boundary/internal/db"
 	"github.com/hashicorp/boundary/internal/errors"
 	"github.com/hashicorp/boundary/internal/kms"
 	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/hashicorp/boundary/internal/pagination"
 	"github.com/hashicorp/go-dbw"
 )
 
@@ -145,13 +147,24 @@ func (r *Repository) ListAccounts(ctx context.Context, withAuthMethodId string,
 		limit = opts.withLimit
 	}
 	var accts []*Account
-	err := r.reader.SearchWhere(ctx, &accts, "auth_method_id = ?", []any{withAuthMethodId}, db.WithLimit(limit))
+	err := r.reader.SearchWhere(ctx, &accts, "auth_method_id = ?", []any{withAuthMethodId}, db.WithLimit(limit), db.WithPage(opts.withPage))
 	if err != nil {
 		return nil, errors.Wrap(ctx, err, op)
 	}
 	return accts, nil
 }
 
+// ListDeletedAccountIds returns the accounts of all subtypes of the auth method
+// deleted after since.
+func (r *Repository) ListDeletedAccountIds(ctx context.Context, authMethodId string, since time.Time) ([]*pagination.DeletedResource, error) {
+	const op = "oidc.(Repository).ListDeletedAccountIds"
+	deleted, err := pagination.ListDeletedIdsByParent(ctx, r.reader, "auth_account", authMethodId, since)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return deleted, nil
+}
+
 // DeleteAccount deletes the account for the provided id from the repository returning a count of the
 // number of records deleted.  All options are ignored.
 func (r *Repository) DeleteAccount(ctx context.Context, scopeId, withPublicId string, opt ...Option) (int, error) {
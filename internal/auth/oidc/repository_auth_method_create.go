@@ -122,6 +122,20 @@ func (r *Repository) CreateAuthMethod(ctx context.Context, am *AuthMethod, opt .
 				}
 				msgs = append(msgs, accountClaimMapsOplogMsgs...)
 			}
+			if len(vo.Prompts) > 0 {
+				promptsOplogMsgs := make([]*oplog.Message, 0, len(vo.Prompts))
+				if err := w.CreateItems(ctx, vo.Prompts, db.NewOplogMsgs(&promptsOplogMsgs)); err != nil {
+					return err
+				}
+				msgs = append(msgs, promptsOplogMsgs...)
+			}
+			if len(vo.AcrValues) > 0 {
+				acrValuesOplogMsgs := make([]*oplog.Message, 0, len(vo.AcrValues))
+				if err := w.CreateItems(ctx, vo.AcrValues, db.NewOplogMsgs(&acrValuesOplogMsgs)); err != nil {
+					return err
+				}
+				msgs = append(msgs, acrValuesOplogMsgs...)
+			}
 			metadata := am.oplog(oplog.OpType_OP_TYPE_CREATE)
 			if err := w.WriteOplogEntryWith(ctx, oplogWrapper, ticket, metadata, msgs); err != nil {
 				return errors.Wrap(ctx, err, op, errors.WithMsg("unable to write oplog"))
@@ -7,11 +7,13 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/boundary/internal/db"
 	"github.com/hashicorp/boundary/internal/db/timestamp"
 	"github.com/hashicorp/boundary/internal/errors"
 	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/pagination"
 	"github.com/hashicorp/go-kms-wrapping/v2/extras/structwrapping"
 )
 
@@ -44,6 +46,17 @@ func (r *Repository) ListAuthMethods(ctx context.Context, scopeIds []string, opt
 	return authMethods, nil
 }
 
+// ListDeletedAuthMethodIds returns the auth methods of all subtypes in one of
+// scopeIds deleted after since.
+func (r *Repository) ListDeletedAuthMethodIds(ctx context.Context, scopeIds []string, since time.Time) ([]*pagination.DeletedResource, error) {
+	const op = "oidc.(Repository).ListDeletedAuthMethodIds"
+	deleted, err := pagination.ListDeletedIds(ctx, r.reader, "auth_method", scopeIds, since)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return deleted, nil
+}
+
 // lookupAuthMethod will lookup a single auth method
 func (r *Repository) lookupAuthMethod(ctx context.Context, authMethodId string, opt ...Option) (*AuthMethod, error) {
 	const op = "oidc.(Repository).lookupAuthMethod"
@@ -91,7 +104,7 @@ func (r *Repository) getAuthMethods(ctx context.Context, authMethodId string, sc
 		// non-zero signals an override of the default limit for the repo.
 		limit = opts.withLimit
 	}
-	dbArgs = append(dbArgs, db.WithLimit(limit))
+	dbArgs = append(dbArgs, db.WithLimit(limit), db.WithPage(opts.withPage))
 
 	if opts.withOrderByCreateTime {
 		if opts.ascending {
@@ -152,6 +165,7 @@ func (r *Repository) getAuthMethods(ctx context.Context, authMethodId string, sc
 		am.KeyId = agg.KeyId
 		am.MaxAge = int32(agg.MaxAge)
 		am.ApiUrl = agg.ApiUrl
+		am.EnablePkce = agg.EnablePkce
 		if agg.Algs != "" {
 			am.SigningAlgs = strings.Split(agg.Algs, aggregateDelimiter)
 		}
@@ -167,6 +181,12 @@ func (r *Repository) getAuthMethods(ctx context.Context, authMethodId string, sc
 		if agg.AccountClaimMaps != "" {
 			am.AccountClaimMaps = strings.Split(agg.AccountClaimMaps, aggregateDelimiter)
 		}
+		if agg.Prompts != "" {
+			am.Prompts = strings.Split(agg.Prompts, aggregateDelimiter)
+		}
+		if agg.AcrValues != "" {
+			am.AcrValues = strings.Split(agg.AcrValues, aggregateDelimiter)
+		}
 		authMethods = append(authMethods, &am)
 	}
 	return authMethods, nil
@@ -192,12 +212,15 @@ type authMethodAgg struct {
 	ClientSecretHmac                  string
 	KeyId                             string
 	MaxAge                            int
+	EnablePkce                        bool
 	Algs                              string
 	ApiUrl                            string
 	Auds                              string
 	Certs                             string
 	ClaimsScopes                      string
 	AccountClaimMaps                  string
+	Prompts                           string
+	AcrValues                         string
 }
 
 // TableName returns the table name for gorm
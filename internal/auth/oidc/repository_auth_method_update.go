@@ -37,6 +37,9 @@ const (
 	CertificatesField                      = "Certificates"
 	ClaimsScopesField                      = "ClaimsScopes"
 	AccountClaimMapsField                  = "AccountClaimMaps"
+	PromptsField                           = "Prompts"
+	AcrValuesField                         = "AcrValues"
+	EnablePkceField                        = "EnablePkce"
 	TokenClaimsField                       = "TokenClaims"
 	UserinfoClaimsField                    = "UserinfoClaims"
 	KeyIdField                             = "KeyId"
@@ -58,8 +61,9 @@ const (
 // fieldMaskPaths provides field_mask.proto paths for fields that should
 // be updated.  Fields will be set to NULL if the field is a
 // zero value and included in fieldMask. Name, Description, Issuer,
-// ClientId, ClientSecret, MaxAge are all updatable fields.  The AuthMethod's
-// Value Objects of SigningAlgs, CallbackUrls, AudClaims and Certificates are
+// ClientId, ClientSecret, MaxAge and EnablePkce are all updatable fields.  The
+// AuthMethod's Value Objects of SigningAlgs, CallbackUrls, AudClaims,
+// Certificates, ClaimsScopes, AccountClaimMaps, Prompts and AcrValues are
 // also updatable. if no updatable fields are included in the fieldMaskPaths,
 // then an error is returned.
 //
@@ -109,9 +113,14 @@ func (r *Repository) UpdateAuthMethod(ctx context.Context, am *AuthMethod, versi
 			CertificatesField:     am.Certificates,
 			ClaimsScopesField:     am.ClaimsScopes,
 			AccountClaimMapsField: am.AccountClaimMaps,
+			PromptsField:          am.Prompts,
+			AcrValuesField:        am.AcrValues,
+			EnablePkceField:       am.EnablePkce,
 		},
 		fieldMaskPaths,
-		nil,
+		// the enable_pkce column is not nullable, so false is a value and not
+		// a request to reset the field.
+		[]string{EnablePkceField},
 	)
 	if len(dbMask) == 0 && len(nullFields) == 0 {
 		return nil, db.NoRowsAffected, errors.New(ctx, errors.EmptyFieldMask, op, "empty field mask")
@@ -173,6 +182,16 @@ func (r *Repository) UpdateAuthMethod(ctx context.Context, am *AuthMethod, versi
 		return nil, db.NoRowsAffected, errors.Wrap(ctx, err, op)
 	}
 
+	addPrompts, deletePrompts, err := valueObjectChanges(ctx, origAm.PublicId, PromptsVO, am.Prompts, origAm.Prompts, dbMask, nullFields)
+	if err != nil {
+		return nil, db.NoRowsAffected, errors.Wrap(ctx, err, op)
+	}
+
+	addAcrs, deleteAcrs, err := valueObjectChanges(ctx, origAm.PublicId, AcrValuesVO, am.AcrValues, origAm.AcrValues, dbMask, nullFields)
+	if err != nil {
+		return nil, db.NoRowsAffected, errors.Wrap(ctx, err, op)
+	}
+
 	addMaps, deleteMaps, err := valueObjectChanges(ctx, origAm.PublicId, AccountClaimMapsVO, am.AccountClaimMaps, origAm.AccountClaimMaps, dbMask, nullFields)
 	if err != nil {
 		return nil, db.NoRowsAffected, errors.Wrap(ctx, err, op)
@@ -196,7 +215,7 @@ func (r *Repository) UpdateAuthMethod(ctx context.Context, am *AuthMethod, versi
 	var filteredDbMask, filteredNullFields []string
 	for _, f := range dbMask {
 		switch f {
-		case SigningAlgsField, AudClaimsField, CertificatesField, ClaimsScopesField, AccountClaimMapsField:
+		case SigningAlgsField, AudClaimsField, CertificatesField, ClaimsScopesField, AccountClaimMapsField, PromptsField, AcrValuesField:
 			continue
 		default:
 			filteredDbMask = append(filteredDbMask, f)
@@ -204,7 +223,7 @@ func (r *Repository) UpdateAuthMethod(ctx context.Context, am *AuthMethod, versi
 	}
 	for _, f := range nullFields {
 		switch f {
-		case SigningAlgsField, AudClaimsField, CertificatesField, ClaimsScopesField, AccountClaimMapsField:
+		case SigningAlgsField, AudClaimsField, CertificatesField, ClaimsScopesField, AccountClaimMapsField, PromptsField, AcrValuesField:
 			continue
 		default:
 			filteredNullFields = append(filteredNullFields, f)
@@ -223,7 +242,11 @@ func (r *Repository) UpdateAuthMethod(ctx context.Context, am *AuthMethod, versi
 		len(addScopes) == 0 &&
 		len(deleteScopes) == 0 &&
 		len(addMaps) == 0 &&
-		len(deleteMaps) == 0 {
+		len(deleteMaps) == 0 &&
+		len(addPrompts) == 0 &&
+		len(deletePrompts) == 0 &&
+		len(addAcrs) == 0 &&
+		len(deleteAcrs) == 0 {
 		return origAm, db.NoRowsAffected, nil
 	}
 
@@ -386,6 +409,44 @@ func (r *Repository) UpdateAuthMethod(ctx context.Context, am *AuthMethod, versi
 				msgs = append(msgs, addMapsOplogMsgs...)
 			}
 
+			if len(deletePrompts) > 0 {
+				deletePromptsOplogMsgs := make([]*oplog.Message, 0, len(deletePrompts))
+				rowsDeleted, err := w.DeleteItems(ctx, deletePrompts, db.NewOplogMsgs(&deletePromptsOplogMsgs))
+				if err != nil {
+					return errors.Wrap(ctx, err, op, errors.WithMsg("unable to delete prompts"))
+				}
+				if rowsDeleted != len(deletePrompts) {
+					return errors.New(ctx, errors.MultipleRecords, op, fmt.Sprintf("prompts deleted %d did not match request for %d", rowsDeleted, len(deletePrompts)))
+				}
+				msgs = append(msgs, deletePromptsOplogMsgs...)
+			}
+			if len(addPrompts) > 0 {
+				addPromptsOplogMsgs := make([]*oplog.Message, 0, len(addPrompts))
+				if err := w.CreateItems(ctx, addPrompts, db.NewOplogMsgs(&addPromptsOplogMsgs)); err != nil {
+					return errors.Wrap(ctx, err, op, errors.WithMsg("unable to add prompts"))
+				}
+				msgs = append(msgs, addPromptsOplogMsgs...)
+			}
+
+			if len(deleteAcrs) > 0 {
+				deleteAcrsOplogMsgs := make([]*oplog.Message, 0, len(deleteAcrs))
+				rowsDeleted, err := w.DeleteItems(ctx, deleteAcrs, db.NewOplogMsgs(&deleteAcrsOplogMsgs))
+				if err != nil {
+					return errors.Wrap(ctx, err, op, errors.WithMsg("unable to delete acr values"))
+				}
+				if rowsDeleted != len(deleteAcrs) {
+					return errors.New(ctx, errors.MultipleRecords, op, fmt.Sprintf("acr values deleted %d did not match request for %d", rowsDeleted, len(deleteAcrs)))
+				}
+				msgs = append(msgs, deleteAcrsOplogMsgs...)
+			}
+			if len(addAcrs) > 0 {
+				addAcrsOplogMsgs := make([]*oplog.Message, 0, len(addAcrs))
+				if err := w.CreateItems(ctx, addAcrs, db.NewOplogMsgs(&addAcrsOplogMsgs)); err != nil {
+					return errors.Wrap(ctx, err, op, errors.WithMsg("unable to add acr values"))
+				}
+				msgs = append(msgs, addAcrsOplogMsgs...)
+			}
+
 			metadata := updatedAm.oplog(oplog.OpType_OP_TYPE_UPDATE)
 			if err := w.WriteOplogEntryWith(ctx, oplogWrapper, ticket, metadata, msgs); err != nil {
 				return errors.Wrap(ctx, err, op, errors.WithMsg("unable to write oplog"))
@@ -426,12 +487,14 @@ const (
 	AudClaimVO         voName = "AudClaims"
 	ClaimsScopesVO     voName = "ClaimsScopes"
 	AccountClaimMapsVO voName = "AccountClaimMaps"
+	PromptsVO          voName = "Prompts"
+	AcrValuesVO        voName = "AcrValues"
 )
 
 // validVoName decides if the name is valid
 func validVoName(name voName) bool {
 	switch name {
-	case SigningAlgVO, CertificateVO, AudClaimVO, ClaimsScopesVO, AccountClaimMapsVO:
+	case SigningAlgVO, CertificateVO, AudClaimVO, ClaimsScopesVO, AccountClaimMapsVO, PromptsVO, AcrValuesVO:
 		return true
 	default:
 		return false
@@ -459,6 +522,14 @@ var supportedFactories = map[voName]factoryFunc{
 		str := fmt.Sprintf("%s", i)
 		return NewClaimsScope(ctx, publicId, str)
 	},
+	PromptsVO: func(ctx context.Context, publicId string, i any) (any, error) {
+		str := fmt.Sprintf("%s", i)
+		return NewPrompt(ctx, publicId, PromptParam(str))
+	},
+	AcrValuesVO: func(ctx context.Context, publicId string, i any) (any, error) {
+		str := fmt.Sprintf("%s", i)
+		return NewAcrValue(ctx, publicId, str)
+	},
 	AccountClaimMapsVO: func(ctx context.Context, publicId string, i any) (any, error) {
 		const op = "oidc.AccountClaimMapsFactory"
 		str := fmt.Sprintf("%s", i)
@@ -580,6 +651,9 @@ func validateFieldMask(ctx context.Context, fieldMaskPaths []string) error {
 		case strings.EqualFold(CertificatesField, f):
 		case strings.EqualFold(ClaimsScopesField, f):
 		case strings.EqualFold(AccountClaimMapsField, f):
+		case strings.EqualFold(PromptsField, f):
+		case strings.EqualFold(AcrValuesField, f):
+		case strings.EqualFold(EnablePkceField, f):
 		default:
 			return errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("invalid field mask: %s", f))
 		}
@@ -604,6 +678,8 @@ func applyUpdate(new, orig *AuthMethod, fieldMaskPaths []string) *AuthMethod {
 			cp.ClientSecret = new.ClientSecret
 		case MaxAgeField:
 			cp.MaxAge = new.MaxAge
+		case EnablePkceField:
+			cp.EnablePkce = new.EnablePkce
 		case ApiUrlField:
 			cp.ApiUrl = new.ApiUrl
 		case SigningAlgsField:
@@ -646,6 +722,22 @@ func applyUpdate(new, orig *AuthMethod, fieldMaskPaths []string) *AuthMethod {
 				cp.AccountClaimMaps = make([]string, 0, len(new.AccountClaimMaps))
 				cp.AccountClaimMaps = append(cp.AccountClaimMaps, new.AccountClaimMaps...)
 			}
+		case PromptsField:
+			switch {
+			case len(new.Prompts) == 0:
+				cp.Prompts = nil
+			default:
+				cp.Prompts = make([]string, 0, len(new.Prompts))
+				cp.Prompts = append(cp.Prompts, new.Prompts...)
+			}
+		case AcrValuesField:
+			switch {
+			case len(new.AcrValues) == 0:
+				cp.AcrValues = nil
+			default:
+				cp.AcrValues = make([]string, 0, len(new.AcrValues))
+				cp.AcrValues = append(cp.AcrValues, new.AcrValues...)
+			}
 		}
 	}
 	return cp
@@ -7,11 +7,13 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/boundary/internal/db"
 	"github.com/hashicorp/boundary/internal/errors"
 	"github.com/hashicorp/boundary/internal/kms"
 	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/hashicorp/boundary/internal/pagination"
 	"github.com/hashicorp/go-dbw"
 )
 
@@ -109,13 +111,24 @@ func (r *Repository) ListManagedGroups(ctx context.Context, withAuthMethodId str
 		limit = opts.withLimit
 	}
 	var mgs []*ManagedGroup
-	err := r.reader.SearchWhere(ctx, &mgs, "auth_method_id = ?", []any{withAuthMethodId}, db.WithLimit(limit))
+	err := r.reader.SearchWhere(ctx, &mgs, "auth_method_id = ?", []any{withAuthMethodId}, db.WithLimit(limit), db.WithPage(opts.withPage))
 	if err != nil {
 		return nil, errors.Wrap(ctx, err, op)
 	}
 	return mgs, nil
 }
 
+// ListDeletedManagedGroupIds returns the managed groups of all subtypes of the
+// auth method deleted after since.
+func (r *Repository) ListDeletedManagedGroupIds(ctx context.Context, authMethodId string, since time.Time) ([]*pagination.DeletedResource, error) {
+	const op = "oidc.(Repository).ListDeletedManagedGroupIds"
+	deleted, err := pagination.ListDeletedIdsByParent(ctx, r.reader, "auth_managed_group", authMethodId, since)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return deleted, nil
+}
+
 // DeleteManagedGroup deletes the managed group for the provided id from the
 // repository returning a count of the number of records deleted. All options
 // are ignored.
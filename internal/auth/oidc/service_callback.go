@@ -10,12 +10,12 @@ import (
 	"time"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/hashicorp/boundary/internal/auth"
 	"github.com/hashicorp/boundary/internal/auth/oidc/request"
 	"github.com/hashicorp/boundary/internal/authtoken"
 	"github.com/hashicorp/boundary/internal/errors"
 	"github.com/hashicorp/cap/oidc"
-	"github.com/hashicorp/go-bexpr"
-	"github.com/mitchellh/pointerstructure"
+	"github.com/hashicorp/go-secure-stdlib/strutil"
 )
 
 // Callback is an oidc domain service function for processing a successful OIDC
@@ -154,6 +154,16 @@ func Callback(
 	if len(am.AudClaims) > 0 {
 		opts = append(opts, oidc.WithAudiences(am.AudClaims...))
 	}
+	switch {
+	case reqState.PkceVerifier != "":
+		verifier, err := newPkceVerifier(ctx, reqState.PkceVerifier)
+		if err != nil {
+			return "", errors.Wrap(ctx, err, op)
+		}
+		opts = append(opts, oidc.WithPKCE(verifier))
+	case am.EnablePkce:
+		return "", errors.New(ctx, errors.InvalidParameter, op, "request state is missing the pkce verifier required by the auth method")
+	}
 	if strings.TrimSpace(am.ApiUrl) == "" {
 		return "", errors.New(ctx, errors.InvalidParameter, op, "empty api URL")
 	}
@@ -186,42 +196,55 @@ func Callback(
 		}
 	}
 
+	if err := verifyAuthContext(ctx, am, reqState.CreateTime.GetTimestamp().AsTime(), idTkClaims); err != nil {
+		return "", errors.Wrap(ctx, err, op)
+	}
+
+	if err := createPendingToken(ctx, r, iamRepoFn, atRepoFn, am, reqState.TokenRequestId, idTkClaims, userInfoClaims); err != nil {
+		return "", errors.Wrap(ctx, err, op)
+	}
+	// tada!  we can return a final redirect URL for the successful authentication.
+	return reqState.FinalRedirectUrl, nil
+}
+
+// createPendingToken upserts the account for the provider's claims, sets the
+// account's managed group memberships and creates a pending auth token with
+// the tokenRequestId for the account's user, so it can be retrieved by the
+// polling client that initiated the authentication attempt.
+func createPendingToken(
+	ctx context.Context,
+	r *Repository,
+	iamRepoFn IamRepoFactory,
+	atRepoFn AuthTokenRepoFactory,
+	am *AuthMethod,
+	tokenRequestId string,
+	idTkClaims, userInfoClaims map[string]any,
+) error {
+	const op = "oidc.createPendingToken"
 	acct, err := r.upsertAccount(ctx, am, idTkClaims, userInfoClaims)
 	if err != nil {
-		return "", errors.Wrap(ctx, err, op)
+		return errors.Wrap(ctx, err, op)
 	}
 
 	// Get the set of all managed groups so we can filter
 	mgs, err := r.ListManagedGroups(ctx, am.GetPublicId())
 	if err != nil {
-		return "", errors.Wrap(ctx, err, op)
+		return errors.Wrap(ctx, err, op)
 	}
 	if len(mgs) > 0 {
-		matchedMgs := make([]*ManagedGroup, 0, len(mgs))
 		evalData := map[string]any{
 			"token":    idTkClaims,
 			"userinfo": userInfoClaims,
 		}
-		// Iterate through and check claims against filters
-		for _, mg := range mgs {
-			eval, err := bexpr.CreateEvaluator(mg.Filter)
-			if err != nil {
-				// We check all filters on ingress so this should never happen,
-				// but we validate anyways
-				return "", errors.Wrap(ctx, err, op)
-			}
-			match, err := eval.Evaluate(evalData)
-			if err != nil && !errors.Is(err, pointerstructure.ErrNotFound) {
-				return "", errors.Wrap(ctx, err, op)
-			}
-			if match {
-				matchedMgs = append(matchedMgs, mg)
-			}
+		// Check claims against filters
+		matchedMgs, err := auth.MatchManagedGroupFilters(ctx, mgs, evalData)
+		if err != nil {
+			return errors.Wrap(ctx, err, op)
 		}
 		// We always pass it in, even if none match, because in that case we
 		// need to remove any mappings that exist
 		if _, _, err := r.SetManagedGroupMemberships(ctx, am, acct, matchedMgs); err != nil {
-			return "", errors.Wrap(ctx, err, op)
+			return errors.Wrap(ctx, err, op)
 		}
 	}
 
@@ -230,17 +253,17 @@ func Callback(
 	// autovivify users for the scope.
 	iamRepo, err := iamRepoFn()
 	if err != nil {
-		return "", errors.Wrap(ctx, err, op)
+		return errors.Wrap(ctx, err, op)
 	}
 
 	_, err = iamRepo.LookupScope(ctx, am.ScopeId)
 	if err != nil {
-		return "", errors.Wrap(ctx, err, op, errors.WithMsg("unable to lookup account scope: "+am.ScopeId))
+		return errors.Wrap(ctx, err, op, errors.WithMsg("unable to lookup account scope: "+am.ScopeId))
 	}
 
 	user, err := iamRepo.LookupUserWithLogin(ctx, acct.PublicId)
 	if err != nil {
-		return "", errors.Wrap(ctx, err, op)
+		return errors.Wrap(ctx, err, op)
 	}
 
 	// Now we need to check filters and assign managed groups by filter.
@@ -250,14 +273,91 @@ func Callback(
 	// that initialed the authentication attempt.
 	tokenRepo, err := atRepoFn()
 	if err != nil {
-		return "", errors.Wrap(ctx, err, op)
+		return errors.Wrap(ctx, err, op)
 	}
-	if _, err := tokenRepo.CreateAuthToken(ctx, user, acct.PublicId, authtoken.WithPublicId(reqState.TokenRequestId), authtoken.WithStatus(authtoken.PendingStatus)); err != nil {
+	if _, err := tokenRepo.CreateAuthToken(ctx, user, acct.PublicId, authtoken.WithPublicId(tokenRequestId), authtoken.WithStatus(authtoken.PendingStatus)); err != nil {
 		if errors.Match(errors.T(errors.NotUnique), err) {
-			return "", errors.New(ctx, errors.Forbidden, op, "not a unique request", errors.WithWrap(err))
+			return errors.New(ctx, errors.Forbidden, op, "not a unique request", errors.WithWrap(err))
 		}
-		return "", errors.Wrap(ctx, err, op)
+		return errors.Wrap(ctx, err, op)
 	}
-	// tada!  we can return a final redirect URL for the successful authentication.
-	return reqState.FinalRedirectUrl, nil
+	return nil
+}
+
+// authTimeLeeway is the clock skew allowed when comparing the auth_time claim
+// of an ID Token with the start of the authentication attempt.
+const authTimeLeeway = 1 * time.Minute
+
+// verifyAuthContext verifies the ID Token claims satisfy the authentication
+// context requested by the auth method. When the auth method has acr values,
+// the token's acr claim must be one of them. When the auth method requires the
+// user to actively authenticate (a max age of -1 or a "login" prompt), the
+// token's auth_time must not be before the attemptStart and when the auth
+// method has a positive max age, the auth_time must be within it.
+func verifyAuthContext(ctx context.Context, am *AuthMethod, attemptStart time.Time, idTkClaims map[string]any) error {
+	const op = "oidc.verifyAuthContext"
+	if am == nil {
+		return errors.New(ctx, errors.InvalidParameter, op, "missing auth method")
+	}
+	if len(am.AcrValues) > 0 {
+		acr, _ := idTkClaims["acr"].(string)
+		if !strutil.StrListContains(am.AcrValues, acr) {
+			return errors.New(ctx, errors.Forbidden, op, fmt.Sprintf("acr claim %q is not one of the auth method's acr values", acr))
+		}
+	}
+
+	var authAfter time.Time
+	switch {
+	case am.MaxAge == -1 || strutil.StrListContains(am.Prompts, string(Login)):
+		authAfter = attemptStart
+	case am.MaxAge > 0:
+		authAfter = time.Now().Add(-time.Duration(am.MaxAge) * time.Second)
+	}
+	if authAfter.IsZero() {
+		return nil
+	}
+	at, ok := idTkClaims["auth_time"].(float64)
+	if !ok {
+		return errors.New(ctx, errors.Forbidden, op, "missing auth_time claim")
+	}
+	authTime := time.Unix(int64(at), 0)
+	if authTime.Add(authTimeLeeway).Before(authAfter) {
+		return errors.New(ctx, errors.Forbidden, op, fmt.Sprintf("auth_time %s is before the required authentication time of %s", authTime.UTC().Format(time.RFC3339), authAfter.UTC().Format(time.RFC3339)))
+	}
+	return nil
+}
+
+// pkceVerifier is an oidc.CodeVerifier for a verifier that was generated when
+// the authentication attempt was started and carried in the request state.
+type pkceVerifier struct {
+	verifier  string
+	challenge string
+}
+
+func newPkceVerifier(ctx context.Context, verifier string) (*pkceVerifier, error) {
+	const op = "oidc.newPkceVerifier"
+	if verifier == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing verifier")
+	}
+	v := &pkceVerifier{verifier: verifier}
+	c, err := oidc.CreateCodeChallenge(v)
+	if err != nil {
+		return nil, errors.New(ctx, errors.Unknown, op, "unable to create code challenge", errors.WithWrap(err))
+	}
+	v.challenge = c
+	return v, nil
+}
+
+// Verifier returns the code verifier.
+func (v *pkceVerifier) Verifier() string { return v.verifier }
+
+// Challenge returns the code challenge of the verifier.
+func (v *pkceVerifier) Challenge() string { return v.challenge }
+
+// Method returns the code challenge method, which is always S256.
+func (v *pkceVerifier) Method() oidc.ChallengeMethod { return oidc.S256 }
+
+// Copy returns a copy of the verifier.
+func (v *pkceVerifier) Copy() oidc.CodeVerifier {
+	return &pkceVerifier{verifier: v.verifier, challenge: v.challenge}
 }
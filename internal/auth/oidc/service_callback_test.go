@@ -13,7 +13,9 @@ import (
 	"testing"
 	"time"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/hashicorp/boundary/globals"
+	"github.com/hashicorp/boundary/internal/auth/oidc/request"
 	"github.com/hashicorp/boundary/internal/auth/oidc/store"
 	authStore "github.com/hashicorp/boundary/internal/auth/store"
 	"github.com/hashicorp/boundary/internal/authtoken"
@@ -600,6 +602,227 @@ func Test_StartAuth_to_Callback(t *testing.T) {
 		require.NoError(err)
 		assert.Equal(tk.Status, string(authtoken.PendingStatus))
 	})
+	t.Run("startAuth-to-Callback-with-pkce-prompts-and-acr-values", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		ctx := context.Background()
+
+		conn, _ := db.TestSetup(t, "postgres")
+		rw := db.New(conn)
+		// start with no tokens in the db
+		_, err := rw.Exec(ctx, "delete from auth_token", nil)
+		require.NoError(err)
+
+		rootWrapper := db.TestWrapper(t)
+		kmsCache := kms.TestKms(t, conn, rootWrapper)
+
+		// func pointers for the test controller.
+		iamRepoFn := func() (*iam.Repository, error) {
+			return iam.NewRepository(rw, rw, kmsCache)
+		}
+		repoFn := func() (*Repository, error) {
+			return NewRepository(ctx, rw, rw, kmsCache)
+		}
+		atRepoFn := func() (*authtoken.Repository, error) {
+			return authtoken.NewRepository(rw, rw, kmsCache)
+		}
+		atRepo, err := atRepoFn()
+		require.NoError(err)
+
+		controller := startTestControllerSrv(t, repoFn, iamRepoFn, atRepoFn)
+
+		iamRepo := iam.TestRepo(t, conn, rootWrapper)
+		org, _ := iam.TestScopes(t, iamRepo)
+
+		databaseWrapper, err := kmsCache.GetWrapper(ctx, org.PublicId, kms.KeyPurposeDatabase)
+		require.NoError(err)
+
+		tp := oidc.StartTestProvider(t)
+		tpCert, err := ParseCertificates(ctx, tp.CACert())
+		require.NoError(err)
+		_, _, tpAlg, _ := tp.SigningKeys()
+
+		endToEndAuthMethod := TestAuthMethod(t, conn, databaseWrapper, org.PublicId, ActivePublicState,
+			"end-to-end-rp", "fido",
+			WithCertificates(tpCert...),
+			WithSigningAlgs(Alg(tpAlg)),
+			WithIssuer(TestConvertToUrls(t, tp.Addr())[0]),
+			WithApiUrl(TestConvertToUrls(t, controller.Addr())[0]),
+			WithEnablePkce(true),
+			WithPrompts(Login, Consent),
+			WithAcrValues("phr", "phrh"))
+
+		org, _ = iamRepo.LookupScope(ctx, org.PublicId)
+		iam.TestSetPrimaryAuthMethod(t, iamRepo, org, endToEndAuthMethod.PublicId)
+		controller.SetAuthMethod(endToEndAuthMethod)
+
+		authUrl, _, err := StartAuth(ctx, repoFn, endToEndAuthMethod.PublicId)
+		require.NoError(err)
+
+		authParams, err := url.ParseQuery(authUrl.RawQuery)
+		require.NoError(err)
+		require.Equal(1, len(authParams["state"]))
+		assert.Equal("login consent", authParams.Get("prompt"))
+		assert.Equal("phr phrh", authParams.Get("acr_values"))
+		assert.Equal(string(oidc.S256), authParams.Get("code_challenge_method"))
+
+		// the pkce verifier is only carried in the encrypted state, so decrypt
+		// it to configure the TestProvider with the verifier it must receive.
+		stateWrapper, err := UnwrapMessage(ctx, authParams.Get("state"))
+		require.NoError(err)
+		requestWrapper, err := requestWrappingWrapper(ctx, kmsCache, endToEndAuthMethod.ScopeId, endToEndAuthMethod.PublicId)
+		require.NoError(err)
+		stateBytes, err := decryptMessage(ctx, requestWrapper, stateWrapper)
+		require.NoError(err)
+		var reqState request.State
+		require.NoError(proto.Unmarshal(stateBytes, &reqState))
+		require.NotEmpty(reqState.PkceVerifier)
+		verifier, err := newPkceVerifier(ctx, reqState.PkceVerifier)
+		require.NoError(err)
+		assert.Equal(verifier.Challenge(), authParams.Get("code_challenge"))
+
+		tp.SetPKCEVerifier(verifier)
+		tp.SetCustomClaims(map[string]any{"acr": "phrh"})
+		tp.SetExpectedState(authParams.Get("state"))
+		tp.SetExpectedAuthNonce(authParams.Get("nonce"))
+		tp.SetExpectedAuthCode("simple")
+		tp.SetClientCreds(endToEndAuthMethod.ClientId, endToEndAuthMethod.ClientSecret)
+		tp.SetAllowedRedirectURIs([]string{controller.CallbackUrl()})
+
+		resp, err := tp.HTTPClient().Get(authUrl.String())
+		require.NoError(err)
+		defer resp.Body.Close()
+		contents, err := ioutil.ReadAll(resp.Body)
+		require.NoError(err)
+		require.Containsf(string(contents), "Congratulations", "expected \"Congratulations\" on successful oidc authentication and got: %s", string(contents))
+
+		var tokens []*authtoken.AuthToken
+		err = rw.SearchWhere(ctx, &tokens, "1=?", []any{1})
+		require.NoError(err)
+		require.Equal(1, len(tokens))
+		tk, err := atRepo.LookupAuthToken(ctx, tokens[0].PublicId)
+		require.NoError(err)
+		assert.Equal(tk.Status, string(authtoken.PendingStatus))
+	})
+}
+
+func Test_verifyAuthContext(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	now := time.Now()
+	tests := []struct {
+		name            string
+		am              *AuthMethod
+		attemptStart    time.Time
+		claims          map[string]any
+		wantErrMatch    *errors.Template
+		wantErrContains string
+	}{
+		{
+			name:            "missing-auth-method",
+			wantErrMatch:    errors.T(errors.InvalidParameter),
+			wantErrContains: "missing auth method",
+		},
+		{
+			name:   "no-requirements",
+			am:     &AuthMethod{AuthMethod: &store.AuthMethod{}},
+			claims: map[string]any{},
+		},
+		{
+			name:   "matching-acr",
+			am:     &AuthMethod{AuthMethod: &store.AuthMethod{AcrValues: []string{"phr", "phrh"}}},
+			claims: map[string]any{"acr": "phrh"},
+		},
+		{
+			name:            "mismatched-acr",
+			am:              &AuthMethod{AuthMethod: &store.AuthMethod{AcrValues: []string{"phr", "phrh"}}},
+			claims:          map[string]any{"acr": "pwd"},
+			wantErrMatch:    errors.T(errors.Forbidden),
+			wantErrContains: `acr claim "pwd" is not one of the auth method's acr values`,
+		},
+		{
+			name:            "missing-acr",
+			am:              &AuthMethod{AuthMethod: &store.AuthMethod{AcrValues: []string{"phr"}}},
+			claims:          map[string]any{},
+			wantErrMatch:    errors.T(errors.Forbidden),
+			wantErrContains: `acr claim "" is not one of the auth method's acr values`,
+		},
+		{
+			name:         "login-prompt-recent-auth",
+			am:           &AuthMethod{AuthMethod: &store.AuthMethod{Prompts: []string{string(Login)}}},
+			attemptStart: now,
+			claims:       map[string]any{"auth_time": float64(now.Unix())},
+		},
+		{
+			name:            "login-prompt-stale-auth",
+			am:              &AuthMethod{AuthMethod: &store.AuthMethod{Prompts: []string{string(Login)}}},
+			attemptStart:    now,
+			claims:          map[string]any{"auth_time": float64(now.Add(-time.Hour).Unix())},
+			wantErrMatch:    errors.T(errors.Forbidden),
+			wantErrContains: "is before the required authentication time",
+		},
+		{
+			name:            "login-prompt-missing-auth-time",
+			am:              &AuthMethod{AuthMethod: &store.AuthMethod{Prompts: []string{string(Login)}}},
+			attemptStart:    now,
+			claims:          map[string]any{},
+			wantErrMatch:    errors.T(errors.Forbidden),
+			wantErrContains: "missing auth_time claim",
+		},
+		{
+			name:            "zero-max-age-stale-auth",
+			am:              &AuthMethod{AuthMethod: &store.AuthMethod{MaxAge: -1}},
+			attemptStart:    now,
+			claims:          map[string]any{"auth_time": float64(now.Add(-time.Hour).Unix())},
+			wantErrMatch:    errors.T(errors.Forbidden),
+			wantErrContains: "is before the required authentication time",
+		},
+		{
+			name:   "max-age-within",
+			am:     &AuthMethod{AuthMethod: &store.AuthMethod{MaxAge: 3600}},
+			claims: map[string]any{"auth_time": float64(now.Add(-30 * time.Minute).Unix())},
+		},
+		{
+			name:            "max-age-exceeded",
+			am:              &AuthMethod{AuthMethod: &store.AuthMethod{MaxAge: 60}},
+			claims:          map[string]any{"auth_time": float64(now.Add(-time.Hour).Unix())},
+			wantErrMatch:    errors.T(errors.Forbidden),
+			wantErrContains: "is before the required authentication time",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			err := verifyAuthContext(ctx, tt.am, tt.attemptStart, tt.claims)
+			if tt.wantErrMatch != nil {
+				require.Error(err)
+				assert.Truef(errors.Match(tt.wantErrMatch, err), "wanted error %s and got: %s", tt.wantErrMatch.Code, err.Error())
+				assert.Contains(err.Error(), tt.wantErrContains)
+				return
+			}
+			require.NoError(err)
+		})
+	}
+}
+
+func Test_newPkceVerifier(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	t.Run("missing-verifier", func(t *testing.T) {
+		_, err := newPkceVerifier(ctx, "")
+		require.Error(t, err)
+		assert.True(t, errors.Match(errors.T(errors.InvalidParameter), err))
+	})
+	t.Run("matches-cap-verifier", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		want, err := oidc.NewCodeVerifier()
+		require.NoError(err)
+		got, err := newPkceVerifier(ctx, want.Verifier())
+		require.NoError(err)
+		assert.Equal(want.Verifier(), got.Verifier())
+		assert.Equal(want.Challenge(), got.Challenge())
+		assert.Equal(want.Method(), got.Method())
+		assert.Equal(got, got.Copy())
+	})
 }
 
 func Test_ManagedGroupFiltering(t *testing.T) {
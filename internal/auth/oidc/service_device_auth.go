@@ -0,0 +1,366 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/auth/oidc/request"
+	"github.com/hashicorp/boundary/internal/authtoken"
+	"github.com/hashicorp/boundary/internal/db/timestamp"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/cap/jwt"
+	"github.com/hashicorp/go-secure-stdlib/strutil"
+	"golang.org/x/oauth2"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// deviceCodeGrantType is the grant type used to exchange a device code
+	// for the user's tokens.
+	// See: https://tools.ietf.org/html/rfc8628#section-3.4
+	deviceCodeGrantType = "urn:ietf:params:oauth:grant-type:device_code"
+
+	// defaultDeviceInterval is the polling interval used when the provider
+	// doesn't return one.
+	// See: https://tools.ietf.org/html/rfc8628#section-3.2
+	defaultDeviceInterval = 5 * time.Second
+)
+
+// DeviceAuthorization is the result of starting a device authorization grant
+// with the auth method's provider.  The user visits the VerificationUri and
+// enters the UserCode (or visits the VerificationUriComplete) to authenticate,
+// while the client polls for the results at the Interval.
+type DeviceAuthorization struct {
+	UserCode                string
+	VerificationUri         string
+	VerificationUriComplete string
+	Interval                time.Duration
+}
+
+// StartDeviceAuth accepts a request to start an OIDC device authorization
+// grant (RFC 8628), for clients which are unable to receive the provider's
+// callback.  It returns the DeviceAuthorization for the user and a tokenId.
+// The tokenId is an encrypted payload for the POST request to the token
+// endpoint, which polls the provider for the results of the user's
+// authentication attempt on the client's behalf.
+//
+// If the auth method is in an InactiveState or its provider doesn't support
+// the device authorization grant, then an error is returned.
+func StartDeviceAuth(ctx context.Context, oidcRepoFn OidcRepoFactory, authMethodId string) (*DeviceAuthorization, string, error) {
+	const op = "oidc.StartDeviceAuth"
+	if authMethodId == "" {
+		return nil, "", errors.New(ctx, errors.InvalidParameter, op, "missing auth method id")
+	}
+	if oidcRepoFn == nil {
+		return nil, "", errors.New(ctx, errors.InvalidParameter, op, "missing oidc repo function")
+	}
+	r, err := oidcRepoFn()
+	if err != nil {
+		return nil, "", errors.Wrap(ctx, err, op)
+	}
+	am, err := r.lookupAuthMethod(ctx, authMethodId)
+	if err != nil {
+		return nil, "", errors.Wrap(ctx, err, op)
+	}
+	if am == nil {
+		return nil, "", errors.New(ctx, errors.RecordNotFound, op, fmt.Sprintf("auth method %s not found", authMethodId))
+	}
+	if am.OperationalState == string(InactiveState) {
+		return nil, "", errors.New(ctx, errors.AuthMethodInactive, op, "not allowed to start authentication attempt")
+	}
+
+	provider, err := providerCache().get(ctx, am)
+	if err != nil {
+		return nil, "", errors.Wrap(ctx, err, op)
+	}
+	client, err := provider.HTTPClient()
+	if err != nil {
+		return nil, "", errors.New(ctx, errors.Unknown, op, "unable to get provider http client", errors.WithWrap(err))
+	}
+	endpoints, err := discoverDeviceEndpoints(ctx, client, am.Issuer)
+	if err != nil {
+		return nil, "", errors.Wrap(ctx, err, op)
+	}
+
+	// the "openid" scope is required for oidc flows
+	scopes := strutil.RemoveDuplicates(append([]string{"openid"}, am.ClaimsScopes...), false)
+	form := url.Values{}
+	form.Set("client_id", am.ClientId)
+	form.Set("scope", strings.Join(scopes, " "))
+	var resp struct {
+		DeviceCode              string `json:"device_code"`
+		UserCode                string `json:"user_code"`
+		VerificationUri         string `json:"verification_uri"`
+		VerificationUrl         string `json:"verification_url"` // some providers use the draft spec's name
+		VerificationUriComplete string `json:"verification_uri_complete"`
+		ExpiresIn               int64  `json:"expires_in"`
+		Interval                int64  `json:"interval"`
+	}
+	errResp, err := postProviderForm(ctx, client, am, endpoints.DeviceAuthorization, form, &resp)
+	if err != nil {
+		return nil, "", errors.Wrap(ctx, err, op)
+	}
+	if errResp != nil {
+		return nil, "", errors.New(ctx, errors.Unknown, op, fmt.Sprintf("provider rejected device authorization request: %s", errResp))
+	}
+	if resp.VerificationUri == "" {
+		resp.VerificationUri = resp.VerificationUrl
+	}
+	switch {
+	case resp.DeviceCode == "":
+		return nil, "", errors.New(ctx, errors.Unknown, op, "provider response is missing a device code")
+	case resp.UserCode == "":
+		return nil, "", errors.New(ctx, errors.Unknown, op, "provider response is missing a user code")
+	case resp.VerificationUri == "":
+		return nil, "", errors.New(ctx, errors.Unknown, op, "provider response is missing a verification uri")
+	}
+
+	now := time.Now()
+	expIn := AttemptExpiration
+	if resp.ExpiresIn > 0 {
+		expIn = time.Duration(resp.ExpiresIn) * time.Second
+	}
+	tokenRequestId, err := authtoken.NewAuthTokenId()
+	if err != nil {
+		return nil, "", errors.Wrap(ctx, err, op)
+	}
+	t := &request.Token{
+		RequestId:      tokenRequestId,
+		CreateTime:     &timestamp.Timestamp{Timestamp: timestamppb.New(now.Truncate(time.Second))},
+		ExpirationTime: &timestamp.Timestamp{Timestamp: timestamppb.New(now.Add(expIn).Truncate(time.Second))},
+		DeviceCode:     resp.DeviceCode,
+	}
+	requestWrapper, err := requestWrappingWrapper(ctx, r.kms, am.ScopeId, authMethodId)
+	if err != nil {
+		return nil, "", errors.Wrap(ctx, err, op)
+	}
+	encodedEncryptedTk, err := encryptMessage(ctx, requestWrapper, am, t)
+	if err != nil {
+		return nil, "", errors.Wrap(ctx, err, op)
+	}
+
+	interval := defaultDeviceInterval
+	if resp.Interval > 0 {
+		interval = time.Duration(resp.Interval) * time.Second
+	}
+	return &DeviceAuthorization{
+		UserCode:                resp.UserCode,
+		VerificationUri:         resp.VerificationUri,
+		VerificationUriComplete: resp.VerificationUriComplete,
+		Interval:                interval,
+	}, encodedEncryptedTk, nil
+}
+
+// exchangeDeviceCode polls the auth method's provider for the tokens of a
+// device authorization grant.  It returns false when the user hasn't completed
+// their authentication yet.  When the provider returns the user's tokens, the
+// ID Token is validated, the account is upserted and a pending token is
+// created for the reqTk's request id, just like a Callback.
+func exchangeDeviceCode(
+	ctx context.Context,
+	oidcRepoFn OidcRepoFactory,
+	iamRepoFn IamRepoFactory,
+	atRepoFn AuthTokenRepoFactory,
+	authMethodId string,
+	reqTk *request.Token,
+) (bool, error) {
+	const op = "oidc.exchangeDeviceCode"
+	if reqTk == nil || reqTk.DeviceCode == "" {
+		return false, errors.New(ctx, errors.InvalidParameter, op, "missing device code")
+	}
+	r, err := oidcRepoFn()
+	if err != nil {
+		return false, errors.Wrap(ctx, err, op)
+	}
+	am, err := r.lookupAuthMethod(ctx, authMethodId)
+	if err != nil {
+		return false, errors.Wrap(ctx, err, op)
+	}
+	if am == nil {
+		return false, errors.New(ctx, errors.RecordNotFound, op, fmt.Sprintf("auth method %s not found", authMethodId))
+	}
+	if am.OperationalState == string(InactiveState) {
+		return false, errors.New(ctx, errors.AuthMethodInactive, op, "not allowed to complete authentication attempt")
+	}
+	provider, err := providerCache().get(ctx, am)
+	if err != nil {
+		return false, errors.Wrap(ctx, err, op)
+	}
+	client, err := provider.HTTPClient()
+	if err != nil {
+		return false, errors.New(ctx, errors.Unknown, op, "unable to get provider http client", errors.WithWrap(err))
+	}
+	endpoints, err := discoverDeviceEndpoints(ctx, client, am.Issuer)
+	if err != nil {
+		return false, errors.Wrap(ctx, err, op)
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", deviceCodeGrantType)
+	form.Set("device_code", reqTk.DeviceCode)
+	form.Set("client_id", am.ClientId)
+	var tkResp struct {
+		AccessToken string `json:"access_token"`
+		TokenType   string `json:"token_type"`
+		IdToken     string `json:"id_token"`
+	}
+	errResp, err := postProviderForm(ctx, client, am, endpoints.Token, form, &tkResp)
+	if err != nil {
+		return false, errors.Wrap(ctx, err, op)
+	}
+	if errResp != nil {
+		switch errResp.Error {
+		case "authorization_pending", "slow_down":
+			return false, nil
+		case "access_denied":
+			return false, errors.New(ctx, errors.Forbidden, op, "user denied the device authorization request")
+		case "expired_token":
+			return false, errors.New(ctx, errors.AuthAttemptExpired, op, "device code has expired")
+		default:
+			return false, errors.New(ctx, errors.Unknown, op, fmt.Sprintf("provider rejected device code exchange: %s", errResp))
+		}
+	}
+	if tkResp.IdToken == "" {
+		return false, errors.New(ctx, errors.Unknown, op, "provider response is missing an id token")
+	}
+
+	keySet, err := jwt.NewOIDCDiscoveryKeySet(ctx, am.Issuer, strings.Join(am.Certificates, "\n"))
+	if err != nil {
+		return false, errors.New(ctx, errors.Unknown, op, "unable to get provider key set", errors.WithWrap(err))
+	}
+	validator, err := jwt.NewValidator(keySet)
+	if err != nil {
+		return false, errors.New(ctx, errors.Unknown, op, "unable to create id token validator", errors.WithWrap(err))
+	}
+	audiences := am.AudClaims
+	if len(audiences) == 0 {
+		audiences = []string{am.ClientId}
+	}
+	algs := make([]jwt.Alg, 0, len(am.SigningAlgs))
+	for _, a := range am.SigningAlgs {
+		algs = append(algs, jwt.Alg(a))
+	}
+	idTkClaims, err := validator.Validate(ctx, tkResp.IdToken, jwt.Expected{
+		Issuer:            am.Issuer,
+		Audiences:         audiences,
+		SigningAlgorithms: algs,
+	})
+	if err != nil {
+		return false, errors.New(ctx, errors.Unknown, op, "unable to validate id token", errors.WithWrap(err))
+	}
+	if err := verifyAuthContext(ctx, am, reqTk.CreateTime.GetTimestamp().AsTime(), idTkClaims); err != nil {
+		return false, errors.Wrap(ctx, err, op)
+	}
+
+	userInfoClaims := map[string]any{} // intentionally, NOT nil for call to createPendingToken(...)
+	if tkResp.AccessToken != "" {
+		sub, ok := idTkClaims["sub"].(string)
+		if !ok {
+			return false, errors.New(ctx, errors.Unknown, op, "subject is not present in ID Token")
+		}
+		ts := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: tkResp.AccessToken, TokenType: tkResp.TokenType})
+		if err := provider.UserInfo(ctx, ts, sub, &userInfoClaims); err != nil {
+			return false, errors.New(ctx, errors.Unknown, op, "unable to get user info from provider", errors.WithWrap(err))
+		}
+	}
+
+	if err := createPendingToken(ctx, r, iamRepoFn, atRepoFn, am, reqTk.RequestId, idTkClaims, userInfoClaims); err != nil {
+		return false, errors.Wrap(ctx, err, op)
+	}
+	return true, nil
+}
+
+// deviceEndpoints are the provider endpoints used by the device authorization
+// grant.
+type deviceEndpoints struct {
+	DeviceAuthorization string `json:"device_authorization_endpoint"`
+	Token               string `json:"token_endpoint"`
+}
+
+// discoverDeviceEndpoints gets the device authorization grant endpoints from
+// the issuer's discovery document.
+func discoverDeviceEndpoints(ctx context.Context, client *http.Client, issuer string) (*deviceEndpoints, error) {
+	const op = "oidc.discoverDeviceEndpoints"
+	wellKnown := strings.TrimSuffix(issuer, "/") + "/.well-known/openid-configuration"
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, wellKnown, nil)
+	if err != nil {
+		return nil, errors.New(ctx, errors.Unknown, op, "unable to create discovery request", errors.WithWrap(err))
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, errors.New(ctx, errors.Unknown, op, "unable to get discovery document", errors.WithWrap(err))
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.New(ctx, errors.Unknown, op, fmt.Sprintf("unable to get discovery document: %s", resp.Status))
+	}
+	var ep deviceEndpoints
+	if err := json.NewDecoder(resp.Body).Decode(&ep); err != nil {
+		return nil, errors.New(ctx, errors.Unknown, op, "unable to decode discovery document", errors.WithWrap(err))
+	}
+	if ep.DeviceAuthorization == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "provider does not support the device authorization grant")
+	}
+	if ep.Token == "" {
+		return nil, errors.New(ctx, errors.Unknown, op, "provider discovery document is missing a token endpoint")
+	}
+	return &ep, nil
+}
+
+// providerErrorResponse is an OAuth 2.0 error response.
+// See: https://tools.ietf.org/html/rfc6749#section-5.2
+type providerErrorResponse struct {
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+func (e *providerErrorResponse) String() string {
+	if e.ErrorDescription == "" {
+		return e.Error
+	}
+	return fmt.Sprintf("%s: %s", e.Error, e.ErrorDescription)
+}
+
+// postProviderForm posts the form to the provider's endpoint, authenticating
+// with the auth method's client id and secret, and decodes a successful
+// response into the result.  If the provider returns an OAuth 2.0 error
+// response, it's returned instead.
+func postProviderForm(ctx context.Context, client *http.Client, am *AuthMethod, endpoint string, form url.Values, result any) (*providerErrorResponse, error) {
+	const op = "oidc.postProviderForm"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, errors.New(ctx, errors.Unknown, op, "unable to create provider request", errors.WithWrap(err))
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	req.SetBasicAuth(url.QueryEscape(am.ClientId), url.QueryEscape(am.ClientSecret))
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, errors.New(ctx, errors.Unknown, op, "unable to send provider request", errors.WithWrap(err))
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, errors.New(ctx, errors.Unknown, op, "unable to read provider response", errors.WithWrap(err))
+	}
+	if resp.StatusCode != http.StatusOK {
+		var errResp providerErrorResponse
+		if err := json.Unmarshal(body, &errResp); err != nil || errResp.Error == "" {
+			return nil, errors.New(ctx, errors.Unknown, op, fmt.Sprintf("unexpected provider response: %s", resp.Status))
+		}
+		return &errResp, nil
+	}
+	if err := json.Unmarshal(body, result); err != nil {
+		return nil, errors.New(ctx, errors.Unknown, op, "unable to decode provider response", errors.WithWrap(err))
+	}
+	return nil, nil
+}
@@ -0,0 +1,265 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oidc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/authtoken"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/cap/oidc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testDeviceProvider is a minimal functional fake of a provider which supports
+// the device authorization grant, since the cap TestProvider doesn't.
+type testDeviceProvider struct {
+	srv *httptest.Server
+
+	mu           sync.Mutex
+	clientId     string
+	clientSecret string
+	disableGrant bool
+	pending      int
+	acr          string
+}
+
+const (
+	testDeviceCode = "test-device-code"
+	testUserCode   = "WDJB-MJHT"
+	testSubject    = "alice@example.com"
+	testKeyId      = "device-key"
+)
+
+func startTestDeviceProvider(t *testing.T, clientId, clientSecret string) *testDeviceProvider {
+	t.Helper()
+	p := &testDeviceProvider{clientId: clientId, clientSecret: clientSecret}
+	pub, priv := oidc.TestGenerateKeys(t)
+	ecPub := pub.(*ecdsa.PublicKey)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, _ *http.Request) {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		doc := map[string]any{
+			"issuer":                                p.srv.URL,
+			"authorization_endpoint":                p.srv.URL + "/authorize",
+			"token_endpoint":                        p.srv.URL + "/token",
+			"userinfo_endpoint":                     p.srv.URL + "/userinfo",
+			"jwks_uri":                              p.srv.URL + "/.well-known/jwks.json",
+			"id_token_signing_alg_values_supported": []string{string(oidc.ES256)},
+		}
+		if !p.disableGrant {
+			doc["device_authorization_endpoint"] = p.srv.URL + "/device"
+		}
+		writeJSON(w, http.StatusOK, doc)
+	})
+	mux.HandleFunc("/.well-known/jwks.json", func(w http.ResponseWriter, _ *http.Request) {
+		coord := func(b []byte) string {
+			padded := make([]byte, 32)
+			copy(padded[32-len(b):], b)
+			return base64.RawURLEncoding.EncodeToString(padded)
+		}
+		writeJSON(w, http.StatusOK, map[string]any{
+			"keys": []map[string]any{{
+				"kty": "EC",
+				"crv": "P-256",
+				"alg": string(oidc.ES256),
+				"use": "sig",
+				"kid": testKeyId,
+				"x":   coord(ecPub.X.Bytes()),
+				"y":   coord(ecPub.Y.Bytes()),
+			}},
+		})
+	})
+	mux.HandleFunc("/device", func(w http.ResponseWriter, req *http.Request) {
+		if !p.validClient(req) {
+			writeJSON(w, http.StatusUnauthorized, map[string]any{"error": "invalid_client"})
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]any{
+			"device_code":               testDeviceCode,
+			"user_code":                 testUserCode,
+			"verification_uri":          p.srv.URL + "/activate",
+			"verification_uri_complete": p.srv.URL + "/activate?user_code=" + testUserCode,
+			"expires_in":                600,
+			"interval":                  2,
+		})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, req *http.Request) {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		switch {
+		case !p.validClient(req):
+			writeJSON(w, http.StatusUnauthorized, map[string]any{"error": "invalid_client"})
+			return
+		case req.FormValue("grant_type") != deviceCodeGrantType || req.FormValue("device_code") != testDeviceCode:
+			writeJSON(w, http.StatusBadRequest, map[string]any{"error": "invalid_grant"})
+			return
+		case p.pending > 0:
+			p.pending--
+			writeJSON(w, http.StatusBadRequest, map[string]any{"error": "authorization_pending"})
+			return
+		}
+		now := time.Now()
+		claims := map[string]any{
+			"iss":       p.srv.URL,
+			"sub":       testSubject,
+			"aud":       p.clientId,
+			"iat":       now.Unix(),
+			"exp":       now.Add(time.Minute).Unix(),
+			"auth_time": now.Unix(),
+			"email":     testSubject,
+		}
+		if p.acr != "" {
+			claims["acr"] = p.acr
+		}
+		writeJSON(w, http.StatusOK, map[string]any{
+			"access_token": "test-access-token",
+			"token_type":   "Bearer",
+			"expires_in":   60,
+			"id_token":     oidc.TestSignJWT(t, priv, string(oidc.ES256), claims, []byte(testKeyId)),
+		})
+	})
+	mux.HandleFunc("/userinfo", func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]any{
+			"sub":   testSubject,
+			"email": testSubject,
+			"name":  "Alice Doe-Smith",
+		})
+	})
+	p.srv = httptest.NewTLSServer(mux)
+	t.Cleanup(p.srv.Close)
+	return p
+}
+
+func (p *testDeviceProvider) validClient(req *http.Request) bool {
+	id, secret, ok := req.BasicAuth()
+	return ok && id == p.clientId && secret == p.clientSecret
+}
+
+func (p *testDeviceProvider) caCert() string {
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: p.srv.Certificate().Raw}))
+}
+
+func (p *testDeviceProvider) set(fn func(p *testDeviceProvider)) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	fn(p)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func Test_DeviceAuth(t *testing.T) {
+	// DO NOT run these tests under t.Parallel(), since they depend on the
+	// state of the testDeviceProvider
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	rootWrapper := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, rootWrapper)
+
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iam.NewRepository(rw, rw, kmsCache)
+	}
+	repoFn := func() (*Repository, error) {
+		return NewRepository(ctx, rw, rw, kmsCache)
+	}
+	atRepoFn := func() (*authtoken.Repository, error) {
+		return authtoken.NewRepository(rw, rw, kmsCache)
+	}
+
+	iamRepo := iam.TestRepo(t, conn, rootWrapper)
+	org, _ := iam.TestScopes(t, iamRepo)
+	databaseWrapper, err := kmsCache.GetWrapper(ctx, org.PublicId, kms.KeyPurposeDatabase)
+	require.NoError(t, err)
+
+	tp := startTestDeviceProvider(t, "alice-rp", "fido")
+	tpCert, err := ParseCertificates(ctx, tp.caCert())
+	require.NoError(t, err)
+
+	testAuthMethod := TestAuthMethod(t, conn, databaseWrapper, org.PublicId, ActivePublicState,
+		"alice-rp", "fido",
+		WithCertificates(tpCert...),
+		WithSigningAlgs(ES256),
+		WithAcrValues("phr"),
+		WithIssuer(TestConvertToUrls(t, tp.srv.URL)[0]),
+		WithApiUrl(TestConvertToUrls(t, "https://alice.com")[0]))
+	iam.TestSetPrimaryAuthMethod(t, iamRepo, org, testAuthMethod.PublicId)
+
+	t.Run("missing-auth-method-id", func(t *testing.T) {
+		_, _, err := StartDeviceAuth(ctx, repoFn, "")
+		require.Error(t, err)
+		assert.True(t, errors.Match(errors.T(errors.InvalidParameter), err))
+	})
+	t.Run("unsupported-grant", func(t *testing.T) {
+		tp.set(func(p *testDeviceProvider) { p.disableGrant = true })
+		defer tp.set(func(p *testDeviceProvider) { p.disableGrant = false })
+		_, _, err := StartDeviceAuth(ctx, repoFn, testAuthMethod.PublicId)
+		require.Error(t, err)
+		assert.True(t, errors.Match(errors.T(errors.InvalidParameter), err))
+		assert.Contains(t, err.Error(), "provider does not support the device authorization grant")
+	})
+	t.Run("success", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		tp.set(func(p *testDeviceProvider) {
+			p.pending = 1
+			p.acr = "phr"
+		})
+
+		deviceAuth, tokenId, err := StartDeviceAuth(ctx, repoFn, testAuthMethod.PublicId)
+		require.NoError(err)
+		assert.NotEmpty(tokenId)
+		assert.Equal(testUserCode, deviceAuth.UserCode)
+		assert.Equal(tp.srv.URL+"/activate", deviceAuth.VerificationUri)
+		assert.Equal(tp.srv.URL+"/activate?user_code="+testUserCode, deviceAuth.VerificationUriComplete)
+		assert.Equal(2*time.Second, deviceAuth.Interval)
+
+		// the user hasn't authenticated yet
+		tk, err := TokenRequest(ctx, kmsCache, repoFn, iamRepoFn, atRepoFn, testAuthMethod.PublicId, tokenId)
+		require.NoError(err)
+		assert.Nil(tk)
+
+		tk, err = TokenRequest(ctx, kmsCache, repoFn, iamRepoFn, atRepoFn, testAuthMethod.PublicId, tokenId)
+		require.NoError(err)
+		require.NotNil(tk)
+		assert.Equal(string(authtoken.IssuedStatus), tk.Status)
+		assert.NotEmpty(tk.Token)
+
+		// the token has been issued, so it can't be retrieved again.
+		_, err = TokenRequest(ctx, kmsCache, repoFn, iamRepoFn, atRepoFn, testAuthMethod.PublicId, tokenId)
+		require.Error(err)
+	})
+	t.Run("mismatched-acr", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		tp.set(func(p *testDeviceProvider) {
+			p.pending = 0
+			p.acr = "pwd"
+		})
+
+		_, tokenId, err := StartDeviceAuth(ctx, repoFn, testAuthMethod.PublicId)
+		require.NoError(err)
+		tk, err := TokenRequest(ctx, kmsCache, repoFn, iamRepoFn, atRepoFn, testAuthMethod.PublicId, tokenId)
+		require.Error(err)
+		assert.Nil(tk)
+		assert.True(errors.Match(errors.T(errors.Forbidden), err))
+	})
+}
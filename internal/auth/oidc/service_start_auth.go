@@ -93,6 +93,14 @@ func StartAuth(ctx context.Context, oidcRepoFn OidcRepoFactory, authMethodId str
 		Nonce:              nonce,
 		ProviderConfigHash: hash,
 	}
+	var verifier oidc.CodeVerifier
+	if am.EnablePkce {
+		verifier, err = oidc.NewCodeVerifier()
+		if err != nil {
+			return nil, "", errors.New(ctx, errors.Unknown, op, "unable to generate pkce verifier", errors.WithWrap(err))
+		}
+		st.PkceVerifier = verifier.Verifier()
+	}
 
 	requestWrapper, err := requestWrappingWrapper(ctx, r.kms, am.ScopeId, authMethodId)
 	if err != nil {
@@ -117,6 +125,19 @@ func StartAuth(ctx context.Context, oidcRepoFn OidcRepoFactory, authMethodId str
 	if len(am.ClaimsScopes) > 0 {
 		oidcOpts = append(oidcOpts, oidc.WithScopes(am.ClaimsScopes...))
 	}
+	if len(am.Prompts) > 0 {
+		prompts := make([]oidc.Prompt, 0, len(am.Prompts))
+		for _, p := range am.Prompts {
+			prompts = append(prompts, oidc.Prompt(p))
+		}
+		oidcOpts = append(oidcOpts, oidc.WithPrompts(prompts...))
+	}
+	if len(am.AcrValues) > 0 {
+		oidcOpts = append(oidcOpts, oidc.WithACRValues(am.AcrValues...))
+	}
+	if verifier != nil {
+		oidcOpts = append(oidcOpts, oidc.WithPKCE(verifier))
+	}
 
 	// a bare min oidc.Request needed for the provider.AuthURL(...) call.  We've intentionally not populated
 	// things like Audiences, because this oidc.Request isn't cached and not intended for use in future legs
@@ -25,11 +25,21 @@ import (
 // * Use the authtoken.(Repository).IssueAuthToken to issue the request id's
 // token and mark it as issued in the repo.  If the token is already issue, an
 // error is returned.
-func TokenRequest(ctx context.Context, kms *kms.Kms, atRepoFn AuthTokenRepoFactory, authMethodId, tokenRequestId string) (*authtoken.AuthToken, error) {
+//
+// * If the request was started with StartDeviceAuth and there's no pending
+// token yet, poll the provider with the request's device code.  Once the user
+// has authenticated, a pending token is created (see Callback) and issued.
+func TokenRequest(ctx context.Context, kms *kms.Kms, oidcRepoFn OidcRepoFactory, iamRepoFn IamRepoFactory, atRepoFn AuthTokenRepoFactory, authMethodId, tokenRequestId string) (*authtoken.AuthToken, error) {
 	const op = "oidc.TokenRequest"
 	if kms == nil {
 		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing kms")
 	}
+	if oidcRepoFn == nil {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing oidc repo function")
+	}
+	if iamRepoFn == nil {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing iam repo function")
+	}
 	if atRepoFn == nil {
 		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing auth token repo function")
 	}
@@ -84,12 +94,27 @@ func TokenRequest(ctx context.Context, kms *kms.Kms, atRepoFn AuthTokenRepoFacto
 	}
 	authTk, err := tokenRepo.IssueAuthToken(ctx, reqTk.RequestId)
 	if err != nil {
-		if errors.Match(errors.T(errors.RecordNotFound), err) {
+		if !errors.Match(errors.T(errors.RecordNotFound), err) {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		if reqTk.DeviceCode == "" {
 			// We don't have it -- at least not yet. So don't mark it as an
 			// error, but nothing is returned.
 			return nil, nil
 		}
-		return nil, errors.Wrap(ctx, err, op)
+		// device authorization grants don't have a callback, so we poll the
+		// provider on behalf of the client.
+		complete, err := exchangeDeviceCode(ctx, oidcRepoFn, iamRepoFn, atRepoFn, authMethodId, &reqTk)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		if !complete {
+			return nil, nil
+		}
+		authTk, err = tokenRepo.IssueAuthToken(ctx, reqTk.RequestId)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
 	}
 	if authTk.Token == "" {
 		return nil, errors.New(ctx, errors.Internal, op, "issued token is missing")
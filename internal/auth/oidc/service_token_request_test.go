@@ -37,6 +37,12 @@ func Test_TokenRequest(t *testing.T) {
 	orgDatabaseWrapper, err := kmsCache.GetWrapper(ctx, org.PublicId, kms.KeyPurposeDatabase)
 	require.NoError(t, err)
 
+	repoFn := func() (*Repository, error) {
+		return NewRepository(ctx, rw, rw, kmsCache)
+	}
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iam.NewRepository(rw, rw, kmsCache)
+	}
 	atRepoFn := func() (*authtoken.Repository, error) {
 		r, err := authtoken.NewRepository(rw, rw, kmsCache)
 		require.NoError(t, err)
@@ -61,6 +67,8 @@ func Test_TokenRequest(t *testing.T) {
 	tests := []struct {
 		name            string
 		kms             *kms.Kms
+		oidcRepoFn      OidcRepoFactory
+		iamRepoFn       IamRepoFactory
 		atRepoFn        AuthTokenRepoFactory
 		authMethodId    string
 		tokenRequest    string
@@ -70,10 +78,28 @@ func Test_TokenRequest(t *testing.T) {
 	}{
 		{
 			name:            "missing-kms",
+			oidcRepoFn:      repoFn,
+			iamRepoFn:       iamRepoFn,
 			atRepoFn:        atRepoFn,
 			wantErrMatch:    errors.T(errors.InvalidParameter),
 			wantErrContains: "missing kms",
 		},
+		{
+			name:            "missing-oidcRepoFn",
+			kms:             kmsCache,
+			iamRepoFn:       iamRepoFn,
+			atRepoFn:        atRepoFn,
+			wantErrMatch:    errors.T(errors.InvalidParameter),
+			wantErrContains: "missing oidc repo function",
+		},
+		{
+			name:            "missing-iamRepoFn",
+			kms:             kmsCache,
+			oidcRepoFn:      repoFn,
+			atRepoFn:        atRepoFn,
+			wantErrMatch:    errors.T(errors.InvalidParameter),
+			wantErrContains: "missing iam repo function",
+		},
 		{
 			name:            "missing-repoFn",
 			kms:             kmsCache,
@@ -83,6 +109,8 @@ func Test_TokenRequest(t *testing.T) {
 		{
 			name:            "bad-wrapper",
 			kms:             kmsCache,
+			oidcRepoFn:      repoFn,
+			iamRepoFn:       iamRepoFn,
 			atRepoFn:        atRepoFn,
 			authMethodId:    testAuthMethod.PublicId,
 			tokenRequest:    "bad-wrapper",
@@ -92,6 +120,8 @@ func Test_TokenRequest(t *testing.T) {
 		{
 			name:         "missing-wrapper-scope-id",
 			kms:          kmsCache,
+			oidcRepoFn:   repoFn,
+			iamRepoFn:    iamRepoFn,
 			atRepoFn:     atRepoFn,
 			authMethodId: testAuthMethod.PublicId,
 			tokenRequest: func() string {
@@ -108,6 +138,8 @@ func Test_TokenRequest(t *testing.T) {
 		{
 			name:         "missing-auth-method-id",
 			kms:          kmsCache,
+			oidcRepoFn:   repoFn,
+			iamRepoFn:    iamRepoFn,
 			atRepoFn:     atRepoFn,
 			authMethodId: "",
 			tokenRequest: func() string {
@@ -122,6 +154,8 @@ func Test_TokenRequest(t *testing.T) {
 		{
 			name:         "missing-wrapper-auth-method-id",
 			kms:          kmsCache,
+			oidcRepoFn:   repoFn,
+			iamRepoFn:    iamRepoFn,
 			atRepoFn:     atRepoFn,
 			authMethodId: testAuthMethod.PublicId,
 			tokenRequest: func() string {
@@ -138,6 +172,8 @@ func Test_TokenRequest(t *testing.T) {
 		{
 			name:         "dek-not-found",
 			kms:          kms.TestKms(t, conn, db.TestWrapper(t)),
+			oidcRepoFn:   repoFn,
+			iamRepoFn:    iamRepoFn,
 			atRepoFn:     atRepoFn,
 			authMethodId: testAuthMethod.PublicId,
 			tokenRequest: func() string {
@@ -152,6 +188,8 @@ func Test_TokenRequest(t *testing.T) {
 		{
 			name:         "expired",
 			kms:          kmsCache,
+			oidcRepoFn:   repoFn,
+			iamRepoFn:    iamRepoFn,
 			atRepoFn:     atRepoFn,
 			authMethodId: testAuthMethod.PublicId,
 			tokenRequest: func() string {
@@ -164,8 +202,10 @@ func Test_TokenRequest(t *testing.T) {
 			wantErrContains: "request token id has expired",
 		},
 		{
-			name: "atRepoFn-error",
-			kms:  kmsCache,
+			name:       "atRepoFn-error",
+			kms:        kmsCache,
+			oidcRepoFn: repoFn,
+			iamRepoFn:  iamRepoFn,
 			atRepoFn: func() (*authtoken.Repository, error) {
 				return nil, errors.New(ctx, errors.Unknown, "test op", "atRepoFn-error", errors.WithoutEvent())
 			},
@@ -182,6 +222,8 @@ func Test_TokenRequest(t *testing.T) {
 		{
 			name:         "error-unmarshal",
 			kms:          kmsCache,
+			oidcRepoFn:   repoFn,
+			iamRepoFn:    iamRepoFn,
 			atRepoFn:     atRepoFn,
 			authMethodId: testAuthMethod.PublicId,
 			tokenRequest: func() string {
@@ -207,6 +249,8 @@ func Test_TokenRequest(t *testing.T) {
 		{
 			name:         "error-missing-exp",
 			kms:          kmsCache,
+			oidcRepoFn:   repoFn,
+			iamRepoFn:    iamRepoFn,
 			atRepoFn:     atRepoFn,
 			authMethodId: testAuthMethod.PublicId,
 			tokenRequest: func() string {
@@ -239,6 +283,8 @@ func Test_TokenRequest(t *testing.T) {
 		{
 			name:         "error-missing-request-id",
 			kms:          kmsCache,
+			oidcRepoFn:   repoFn,
+			iamRepoFn:    iamRepoFn,
 			atRepoFn:     atRepoFn,
 			authMethodId: testAuthMethod.PublicId,
 			tokenRequest: func() string {
@@ -270,6 +316,8 @@ func Test_TokenRequest(t *testing.T) {
 		{
 			name:         "error-issuing-token-forbidden-code",
 			kms:          kmsCache,
+			oidcRepoFn:   repoFn,
+			iamRepoFn:    iamRepoFn,
 			atRepoFn:     atRepoFn,
 			authMethodId: testAuthMethod.PublicId,
 			tokenRequest: func() string {
@@ -301,6 +349,8 @@ func Test_TokenRequest(t *testing.T) {
 		{
 			name:         "mismatched-auth-method-id",
 			kms:          kmsCache,
+			oidcRepoFn:   repoFn,
+			iamRepoFn:    iamRepoFn,
 			atRepoFn:     atRepoFn,
 			authMethodId: "not-a-match",
 			tokenRequest: func() string {
@@ -315,6 +365,8 @@ func Test_TokenRequest(t *testing.T) {
 		{
 			name:         "success",
 			kms:          kmsCache,
+			oidcRepoFn:   repoFn,
+			iamRepoFn:    iamRepoFn,
 			atRepoFn:     atRepoFn,
 			authMethodId: testAuthMethod.PublicId,
 			tokenRequest: func() string {
@@ -328,7 +380,7 @@ func Test_TokenRequest(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			assert, require := assert.New(t), require.New(t)
-			gotTk, err := TokenRequest(ctx, tt.kms, tt.atRepoFn, tt.authMethodId, tt.tokenRequest)
+			gotTk, err := TokenRequest(ctx, tt.kms, tt.oidcRepoFn, tt.iamRepoFn, tt.atRepoFn, tt.authMethodId, tt.tokenRequest)
 			if tt.wantErrMatch != nil {
 				require.Error(err)
 				assert.Truef(errors.Match(tt.wantErrMatch, err), "wanted %q and got: %+v", tt.wantErrMatch.Code, err)
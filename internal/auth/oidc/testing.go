@@ -39,8 +39,9 @@ import (
 const TestFakeManagedGroupFilter = `"/foo" == "bar"`
 
 // TestAuthMethod creates a test oidc auth method.  WithName, WithDescription,
-// WithMaxAge, WithApiUrl, WithIssuer, WithCertificates, WithAudClaims, and
-// WithSigningAlgs options are supported.
+// WithMaxAge, WithApiUrl, WithIssuer, WithCertificates, WithAudClaims,
+// WithSigningAlgs, WithClaimsScopes, WithAccountClaimMap, WithPrompts,
+// WithAcrValues and WithEnablePkce options are supported.
 func TestAuthMethod(
 	t testing.TB,
 	conn *db.DB,
@@ -123,6 +124,26 @@ func TestAuthMethod(
 		require.NoError(rw.CreateItems(ctx, newAccountClaimMaps))
 		require.Equal(len(opts.withAccountClaimMap), len(authMethod.AccountClaimMaps))
 	}
+	if len(opts.withPrompts) > 0 {
+		newPrompts := make([]any, 0, len(opts.withPrompts))
+		for _, p := range opts.withPrompts {
+			prompt, err := NewPrompt(ctx, authMethod.PublicId, p)
+			require.NoError(err)
+			newPrompts = append(newPrompts, prompt)
+		}
+		require.NoError(rw.CreateItems(ctx, newPrompts))
+		require.Equal(len(opts.withPrompts), len(authMethod.Prompts))
+	}
+	if len(opts.withAcrValues) > 0 {
+		newAcrValues := make([]any, 0, len(opts.withAcrValues))
+		for _, a := range opts.withAcrValues {
+			acr, err := NewAcrValue(ctx, authMethod.PublicId, a)
+			require.NoError(err)
+			newAcrValues = append(newAcrValues, acr)
+		}
+		require.NoError(rw.CreateItems(ctx, newAcrValues))
+		require.Equal(len(opts.withAcrValues), len(authMethod.AcrValues))
+	}
 	authMethod.OperationalState = string(state)
 	rowsUpdated, err := rw.Update(ctx, authMethod, []string{OperationalStateField}, nil)
 	require.NoError(err)
@@ -157,6 +178,12 @@ func TestSortAuthMethods(t testing.TB, methods []*AuthMethod) {
 		sort.Slice(am.AccountClaimMaps, func(a, b int) bool {
 			return am.AccountClaimMaps[a] < am.AccountClaimMaps[b]
 		})
+		sort.Slice(am.Prompts, func(a, b int) bool {
+			return am.Prompts[a] < am.Prompts[b]
+		})
+		sort.Slice(am.AcrValues, func(a, b int) bool {
+			return am.AcrValues[a] < am.AcrValues[b]
+		})
 	}
 }
 
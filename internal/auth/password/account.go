@@ -4,6 +4,10 @@
 package password
 
 import (
+	"context"
+	"encoding/json"
+	"strings"
+
 	"github.com/hashicorp/boundary/internal/auth/password/store"
 	"github.com/hashicorp/boundary/internal/errors"
 	"github.com/hashicorp/boundary/internal/oplog"
@@ -26,8 +30,9 @@ func allocAccount() *Account {
 	}
 }
 
-// NewAccount creates a new in memory Account. LoginName, name, and
-// description are the only valid options. All other options are ignored.
+// NewAccount creates a new in memory Account. LoginName, name, description
+// and custom attributes are the only valid options. All other options are
+// ignored.
 func NewAccount(authMethodId string, opt ...Option) (*Account, error) {
 	const op = "password.NewAccount"
 	// NOTE(mgaffney): The scopeId in the embedded *store.Account is
@@ -45,9 +50,57 @@ func NewAccount(authMethodId string, opt ...Option) (*Account, error) {
 			Description:  opts.withDescription,
 		},
 	}
+	if len(opts.withCustomAttributes) > 0 {
+		for k := range opts.withCustomAttributes {
+			if strings.TrimSpace(k) == "" {
+				return nil, errors.NewDeprecated(errors.InvalidParameter, op, "custom attribute names cannot be empty")
+			}
+		}
+		encoded, err := json.Marshal(opts.withCustomAttributes)
+		if err != nil {
+			return nil, errors.WrapDeprecated(err, op, errors.WithCode(errors.InvalidParameter))
+		}
+		a.CustomAttributes = string(encoded)
+	}
 	return a, nil
 }
 
+// GetCustomAttributeMap returns the decoded custom attributes of the account.
+func (a *Account) GetCustomAttributeMap(ctx context.Context) (map[string]string, error) {
+	const op = "password.(Account).GetCustomAttributeMap"
+	if a.GetCustomAttributes() == "" {
+		return nil, nil
+	}
+	var attrs map[string]string
+	if err := json.Unmarshal([]byte(a.GetCustomAttributes()), &attrs); err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithCode(errors.InvalidParameter), errors.WithMsg("unable to decode custom attributes"))
+	}
+	return attrs, nil
+}
+
+// filterData returns the data password managed group filters are evaluated
+// against. The account's fields are available under "/account" and its custom
+// attributes under "/account/custom_attributes".
+func (a *Account) filterData(ctx context.Context) (map[string]any, error) {
+	const op = "password.(Account).filterData"
+	attrs, err := a.GetCustomAttributeMap(ctx)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	customAttrs := make(map[string]any, len(attrs))
+	for k, v := range attrs {
+		customAttrs[k] = v
+	}
+	return map[string]any{
+		"account": map[string]any{
+			"login_name":        a.GetLoginName(),
+			"name":              a.GetName(),
+			"description":       a.GetDescription(),
+			"custom_attributes": customAttrs,
+		},
+	}, nil
+}
+
 func (a *Account) clone() *Account {
 	cp := proto.Clone(a.Account)
 	return &Account{
@@ -220,3 +220,50 @@ func (c *Argon2Credential) oplog(op oplog.OpType) oplog.Metadata {
 	}
 	return metadata
 }
+
+// An Argon2History is a previous password of an Account. It is a copy of
+// the Argon2Credential which was replaced when the password was changed.
+type Argon2History struct {
+	*store.Argon2History
+	tableName string
+}
+
+func allocArgon2History() *Argon2History {
+	return &Argon2History{
+		Argon2History: &store.Argon2History{},
+	}
+}
+
+// TableName returns the table name.
+func (h *Argon2History) TableName() string {
+	if h != nil && h.tableName != "" {
+		return h.tableName
+	}
+	return "auth_password_argon2_history"
+}
+
+// SetTableName sets the table name.
+func (h *Argon2History) SetTableName(n string) {
+	h.tableName = n
+}
+
+func (h *Argon2History) encrypt(ctx context.Context, cipher wrapping.Wrapper) error {
+	const op = "password.(Argon2History).encrypt"
+	if err := structwrapping.WrapStruct(ctx, cipher, h.Argon2History, nil); err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithCode(errors.Encrypt))
+	}
+	keyId, err := cipher.KeyId(ctx)
+	if err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithCode(errors.Encrypt), errors.WithMsg("error reading cipher key id"))
+	}
+	h.KeyId = keyId
+	return nil
+}
+
+func (h *Argon2History) decrypt(ctx context.Context, cipher wrapping.Wrapper) error {
+	const op = "password.(Argon2History).decrypt"
+	if err := structwrapping.UnwrapStruct(ctx, cipher, h.Argon2History, nil); err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithCode(errors.Decrypt))
+	}
+	return nil
+}
@@ -3,6 +3,8 @@
 
 package password
 
+import "github.com/hashicorp/boundary/internal/db"
+
 // GetOpts - iterate the inbound Options and return a struct.
 func GetOpts(opt ...Option) options {
 	opts := getDefaultOptions()
@@ -27,6 +29,8 @@ type options struct {
 	withPassword          bool
 	withOrderByCreateTime bool
 	ascending             bool
+	withPage              *db.Page
+	withCustomAttributes  map[string]string
 }
 
 func getDefaultOptions() options {
@@ -63,6 +67,14 @@ func WithLoginName(loginName string) Option {
 	}
 }
 
+// WithPage provides an option to list one page of resources. Use WithLimit to
+// set the size of the page.
+func WithPage(p *db.Page) Option {
+	return func(o *options) {
+		o.withPage = p
+	}
+}
+
 // WithLimit provides an option to provide a limit.  Intentionally allowing
 // negative integers.   If WithLimit < 0, then unlimited results are returned.
 // If WithLimit == 0, then default limits are used for results.
@@ -95,3 +107,10 @@ func WithOrderByCreateTime(ascending bool) Option {
 		o.ascending = ascending
 	}
 }
+
+// WithCustomAttributes provides optional user-defined account attributes.
+func WithCustomAttributes(attrs map[string]string) Option {
+	return func(o *options) {
+		o.withCustomAttributes = attrs
+	}
+}
@@ -68,4 +68,11 @@ func Test_GetOpts(t *testing.T) {
 		testOpts.ascending = true
 		assert.Equal(opts, testOpts)
 	})
+	t.Run("WithCustomAttributes", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := GetOpts(WithCustomAttributes(map[string]string{"department": "eng"}))
+		testOpts := getDefaultOptions()
+		testOpts.withCustomAttributes = map[string]string{"department": "eng"}
+		assert.Equal(opts, testOpts)
+	})
 }
@@ -0,0 +1,69 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package password
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/hashicorp/boundary/internal/errors"
+)
+
+// commonPasswordList is a list of commonly used passwords, one per line.
+//
+//go:embed common_passwords.txt
+var commonPasswordList string
+
+// commonPasswords is the set of lowercased common passwords rejected when
+// the password auth method denies common passwords.
+var commonPasswords = func() map[string]struct{} {
+	m := make(map[string]struct{})
+	for _, p := range strings.Split(commonPasswordList, "\n") {
+		if p = strings.TrimSpace(p); p != "" && !strings.HasPrefix(p, "#") {
+			m[strings.ToLower(p)] = struct{}{}
+		}
+	}
+	return m
+}()
+
+// passwordClasses returns the number of character classes in password:
+// lowercase letters, uppercase letters, digits and symbols. Letters without
+// case count as lowercase.
+func passwordClasses(password string) int {
+	var lower, upper, digit, symbol int
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			upper = 1
+		case unicode.IsLetter(r):
+			lower = 1
+		case unicode.IsDigit(r):
+			digit = 1
+		default:
+			symbol = 1
+		}
+	}
+	return lower + upper + digit + symbol
+}
+
+// checkPassword returns an error if password does not satisfy the
+// complexity policy of the current configuration. The minimum length and
+// the password history are checked by the callers.
+//
+// Returns an error with code PasswordTooWeak or PasswordDenied.
+func (c *currentConfig) checkPassword(ctx context.Context, password string) error {
+	const op = "password.(currentConfig).checkPassword"
+	if c.MinPasswordClasses > passwordClasses(password) {
+		return errors.New(ctx, errors.PasswordTooWeak, op, fmt.Sprintf("password must contain at least %v of lowercase letters, uppercase letters, digits and symbols", c.MinPasswordClasses))
+	}
+	if c.DenyCommonPasswords {
+		if _, ok := commonPasswords[strings.ToLower(password)]; ok {
+			return errors.New(ctx, errors.PasswordDenied, op, "password is too common")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package password
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPasswordClasses(t *testing.T) {
+	tests := []struct {
+		password string
+		want     int
+	}{
+		{"", 0},
+		{"abcdefgh", 1},
+		{"ABCDEFGH", 1},
+		{"12345678", 1},
+		{"!@#$%^&*", 1},
+		{"abcdEFGH", 2},
+		{"abcd1234", 2},
+		{"abCD12!@", 4},
+		{"pass word", 2},
+		{"пароль1", 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.password, func(t *testing.T) {
+			assert.Equal(t, tt.want, passwordClasses(tt.password))
+		})
+	}
+}
+
+func TestCurrentConfig_checkPassword(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name     string
+		cc       *currentConfig
+		password string
+		wantErr  errors.Code
+	}{
+		{
+			name:     "no-policy",
+			cc:       &currentConfig{},
+			password: "password",
+		},
+		{
+			name:     "enough-classes",
+			cc:       &currentConfig{MinPasswordClasses: 3},
+			password: "Passw0rd",
+		},
+		{
+			name:     "too-few-classes",
+			cc:       &currentConfig{MinPasswordClasses: 3},
+			password: "password1",
+			wantErr:  errors.PasswordTooWeak,
+		},
+		{
+			name:     "common",
+			cc:       &currentConfig{DenyCommonPasswords: true},
+			password: "Password1",
+			wantErr:  errors.PasswordDenied,
+		},
+		{
+			name:     "uncommon",
+			cc:       &currentConfig{DenyCommonPasswords: true},
+			password: "correct horse battery staple",
+		},
+		{
+			name:     "common-allowed",
+			cc:       &currentConfig{},
+			password: "letmein",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cc.checkPassword(ctx, tt.password)
+			if tt.wantErr != 0 {
+				assert.Truef(t, errors.Match(errors.T(tt.wantErr), err), "unexpected error %v", err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestCommonPasswords(t *testing.T) {
+	assert.Contains(t, commonPasswords, "password")
+	assert.NotContains(t, commonPasswords, "")
+	for p := range commonPasswords {
+		assert.NotContains(t, p, "#")
+	}
+}
@@ -4,6 +4,8 @@
 package password
 
 import (
+	"context"
+
 	"github.com/hashicorp/boundary/internal/auth"
 	"github.com/hashicorp/boundary/internal/db"
 	"github.com/hashicorp/boundary/internal/errors"
@@ -12,7 +14,7 @@ import (
 )
 
 func init() {
-	if err := subtypes.Register(auth.Domain, Subtype, AuthMethodPrefix, intglobals.OldPasswordAccountPrefix, intglobals.NewPasswordAccountPrefix); err != nil {
+	if err := subtypes.Register(auth.Domain, Subtype, AuthMethodPrefix, intglobals.OldPasswordAccountPrefix, intglobals.NewPasswordAccountPrefix, intglobals.PasswordManagedGroupPrefix); err != nil {
 		panic(err)
 	}
 }
@@ -41,3 +43,12 @@ func newAccountId() (string, error) {
 	}
 	return id, nil
 }
+
+func newManagedGroupId(ctx context.Context) (string, error) {
+	const op = "password.newManagedGroupId"
+	id, err := db.NewPublicId(intglobals.PasswordManagedGroupPrefix)
+	if err != nil {
+		return "", errors.Wrap(ctx, err, op)
+	}
+	return id, nil
+}
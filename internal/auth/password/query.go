@@ -23,7 +23,10 @@ select acct.name,                        -- Account.Name
        conf.iterations,                  -- Argon2Configuration.Iterations
        conf.memory,                      -- Argon2Configuration.Memory
        conf.threads,                     -- Argon2Configuration.Threads
-       meth.password_conf_id = cred.password_conf_id as is_current_conf
+       meth.password_conf_id = cred.password_conf_id as is_current_conf,
+       meth.max_password_age > 0
+         and cred.create_time + make_interval(secs => meth.max_password_age) <= now()
+                                         as password_expired
   from auth_password_argon2_cred cred,
        auth_password_argon2_conf conf,
        auth_password_account acct,
@@ -43,5 +46,120 @@ select *
          from auth_password_account
         where public_id = @public_id
     );
+`
+	lookupLockoutQuery = `
+select acct.public_id                       as account_id,
+       meth.max_failed_attempts,
+       coalesce(lo.failed_attempt_count, 0) as failed_attempt_count,
+       lo.locked_time is not null
+         and (meth.lockout_duration = 0
+              or lo.locked_time + make_interval(secs => meth.lockout_duration) > now())
+                                            as locked,
+       lo.locked_time is not null
+         and meth.lockout_duration > 0
+         and lo.locked_time + make_interval(secs => meth.lockout_duration) <= now()
+                                            as lock_expired
+  from auth_password_account acct
+  join auth_password_method meth
+    on acct.auth_method_id = meth.public_id
+  left join auth_password_account_lockout lo
+    on lo.password_account_id = acct.public_id
+ where acct.auth_method_id = @auth_method_id
+   and acct.login_name = @login_name;
+`
+	recordFailedAttemptQuery = `
+insert into auth_password_account_lockout as lo
+       (password_account_id, failed_attempt_count, locked_time)
+values (@account_id, 1, case when @max_failed_attempts <= 1 then now() end)
+    on conflict (password_account_id) do update
+   set failed_attempt_count = lo.failed_attempt_count + 1,
+       locked_time = case
+                       when lo.failed_attempt_count + 1 >= @max_failed_attempts then now()
+                       else lo.locked_time
+                     end
+returning failed_attempt_count,
+          locked_time is not null as locked;
+`
+	resetLockoutQuery = `
+delete from auth_password_account_lockout
+ where password_account_id = @account_id;
+`
+	lookupAccountLockoutQuery = `
+select lo.failed_attempt_count,
+       lo.locked_time is not null
+         and (meth.lockout_duration = 0
+              or lo.locked_time + make_interval(secs => meth.lockout_duration) > now())
+                                       as locked,
+       lo.locked_time is not null
+         and meth.lockout_duration > 0
+         and lo.locked_time + make_interval(secs => meth.lockout_duration) <= now()
+                                       as lock_expired,
+       case
+         when meth.lockout_duration > 0
+         then lo.locked_time + make_interval(secs => meth.lockout_duration)
+       end                             as locked_until
+  from auth_password_account_lockout lo
+  join auth_password_account acct
+    on lo.password_account_id = acct.public_id
+  join auth_password_method meth
+    on acct.auth_method_id = meth.public_id
+ where lo.password_account_id = @account_id;
+`
+	deleteTotpQuery = `
+delete from auth_password_totp
+ where password_account_id = @account_id;
+`
+	useTotpStepQuery = `
+update auth_password_totp
+   set confirmed = true,
+       last_used_step = @step
+ where password_account_id = @account_id
+   and last_used_step < @step;
+`
+	useRecoveryCodeQuery = `
+delete from auth_password_recovery_code
+ where password_account_id = @account_id
+   and code_hash = @code_hash;
+`
+	passwordHistoryQuery = `
+select hist.private_id,
+       hist.salt,
+       hist.derived_key,
+       hist.key_id,
+       conf.iterations,
+       conf.memory,
+       conf.threads,
+       conf.key_length
+  from (
+        select private_id, password_conf_id, salt, derived_key, key_id
+          from auth_password_argon2_cred
+         where password_account_id = @account_id
+         union all
+       (select private_id, password_conf_id, salt, derived_key, key_id
+          from auth_password_argon2_history
+         where password_account_id = @account_id
+         order by create_time desc
+         limit @history_count)
+       ) hist
+  join auth_password_argon2_conf conf
+    on hist.password_conf_id = conf.private_id;
+`
+	insertPasswordHistoryQuery = `
+insert into auth_password_argon2_history
+       (private_id, password_account_id, password_conf_id, salt, derived_key, key_id)
+select private_id, password_account_id, password_conf_id, salt, derived_key, key_id
+  from auth_password_argon2_cred
+ where password_account_id = @account_id;
+`
+	prunePasswordHistoryQuery = `
+delete from auth_password_argon2_history
+ where password_account_id = @account_id
+   and private_id not in (
+       select private_id
+         from auth_password_argon2_history
+        where password_account_id = @account_id
+        order by create_time desc
+        limit @history_count
+   );
 `
 )
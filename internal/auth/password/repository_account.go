@@ -8,12 +8,14 @@ import (
 	"fmt"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/boundary/internal/db"
 	"github.com/hashicorp/boundary/internal/errors"
 	"github.com/hashicorp/boundary/internal/intglobals"
 	"github.com/hashicorp/boundary/internal/kms"
 	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/hashicorp/boundary/internal/pagination"
 	"github.com/hashicorp/go-dbw"
 )
 
@@ -29,7 +31,8 @@ import (
 // are ignored.
 //
 // Both a.Name and a.Description are optional. If a.Name is set, it must be
-// unique within a.AuthMethodId.
+// unique within a.AuthMethodId. The new account becomes a member of the
+// auth method's managed groups whose filters it matches.
 func (r *Repository) CreateAccount(ctx context.Context, scopeId string, a *Account, opt ...Option) (*Account, error) {
 	const op = "password.(Repository).CreateAccount"
 	if a == nil {
@@ -81,6 +84,9 @@ func (r *Repository) CreateAccount(ctx context.Context, scopeId string, a *Accou
 		if cc.MinPasswordLength > len(opts.password) {
 			return nil, errors.New(ctx, errors.PasswordTooShort, op, fmt.Sprintf("must be longer than %v", cc.MinPasswordLength))
 		}
+		if err := cc.checkPassword(ctx, opts.password); err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
 		if cred, err = newArgon2Credential(a.PublicId, opts.password, cc.argon2()); err != nil {
 			return nil, errors.Wrap(ctx, err, op)
 		}
@@ -98,7 +104,7 @@ func (r *Repository) CreateAccount(ctx context.Context, scopeId string, a *Accou
 	var newCred *Argon2Credential
 	var newAccount *Account
 	_, err = r.writer.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{},
-		func(_ db.Reader, w db.Writer) error {
+		func(reader db.Reader, w db.Writer) error {
 			newAccount = a.clone()
 			if err := w.Create(ctx, newAccount, db.WithOplog(oplogWrapper, a.oplog(oplog.OpType_OP_TYPE_CREATE))); err != nil {
 				return errors.Wrap(ctx, err, op)
@@ -113,6 +119,9 @@ func (r *Repository) CreateAccount(ctx context.Context, scopeId string, a *Accou
 					return errors.Wrap(ctx, err, op)
 				}
 			}
+			if err := setAccountManagedGroups(ctx, reader, w, oplogWrapper, scopeId, newAccount); err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
 			return nil
 		},
 	)
@@ -142,6 +151,16 @@ func (r *Repository) LookupAccount(ctx context.Context, withPublicId string, opt
 		}
 		return nil, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("failed for %s", withPublicId)))
 	}
+	lo, err := r.lookupAccountLockout(ctx, withPublicId)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("failed for %s", withPublicId)))
+	}
+	a.FailedAttemptCount = lo.FailedAttemptCount
+	a.Locked = lo.Locked
+	a.LockedUntil = lo.LockedUntil
+	if a.TotpEnrolled, err = r.totpEnrolled(ctx, withPublicId); err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("failed for %s", withPublicId)))
+	}
 	return a, nil
 }
 
@@ -158,13 +177,24 @@ func (r *Repository) ListAccounts(ctx context.Context, withAuthMethodId string,
 		limit = opts.withLimit
 	}
 	var accts []*Account
-	err := r.reader.SearchWhere(ctx, &accts, "auth_method_id = ?", []any{withAuthMethodId}, db.WithLimit(limit))
+	err := r.reader.SearchWhere(ctx, &accts, "auth_method_id = ?", []any{withAuthMethodId}, db.WithLimit(limit), db.WithPage(opts.withPage))
 	if err != nil {
 		return nil, errors.Wrap(ctx, err, op)
 	}
 	return accts, nil
 }
 
+// ListDeletedAccountIds returns the accounts of all subtypes of the auth method
+// deleted after since.
+func (r *Repository) ListDeletedAccountIds(ctx context.Context, authMethodId string, since time.Time) ([]*pagination.DeletedResource, error) {
+	const op = "password.(Repository).ListDeletedAccountIds"
+	deleted, err := pagination.ListDeletedIdsByParent(ctx, r.reader, "auth_account", authMethodId, since)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return deleted, nil
+}
+
 // DeleteAccount deletes the account for the provided id from the repository returning a count of the
 // number of records deleted.  All options are ignored.
 func (r *Repository) DeleteAccount(ctx context.Context, scopeId, withPublicId string, opt ...Option) (int, error) {
@@ -223,10 +253,11 @@ func validLoginName(u string) bool {
 // Account containing the updated values and a count of the number of
 // records updated. a is not changed.
 //
-// a must contain a valid PublicId. Only a.Name, a.Description and
-// a.LoginName can be updated. If a.Name is set to a non-empty string, it
-// must be unique within a.AuthMethodId. If a.LoginName is set to a
-// non-empty string, it must be unique within a.AuthMethodId.
+// a must contain a valid PublicId. Only a.Name, a.Description, a.LoginName
+// and a.CustomAttributes can be updated. If a.Name is set to a non-empty
+// string, it must be unique within a.AuthMethodId. If a.LoginName is set to a
+// non-empty string, it must be unique within a.AuthMethodId. The account's
+// managed group memberships are recalculated after the update.
 //
 // An attribute of a will be set to NULL in the database if the attribute
 // in a is the zero value and it is included in fieldMaskPaths. a.LoginName
@@ -260,6 +291,10 @@ func (r *Repository) UpdateAccount(ctx context.Context, scopeId string, a *Accou
 					fmt.Sprintf("invalid username: must be all-lowercase alphanumeric, period or hyphen, got %s", a.LoginName))
 			}
 			changeLoginName = true
+		case strings.EqualFold("CustomAttributes", f):
+			if _, err := a.GetCustomAttributeMap(ctx); err != nil {
+				return nil, db.NoRowsAffected, errors.Wrap(ctx, err, op)
+			}
 		default:
 			return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidFieldMask, op, f)
 		}
@@ -267,9 +302,10 @@ func (r *Repository) UpdateAccount(ctx context.Context, scopeId string, a *Accou
 	var dbMask, nullFields []string
 	dbMask, nullFields = dbw.BuildUpdatePaths(
 		map[string]any{
-			"Name":        a.Name,
-			"Description": a.Description,
-			"LoginName":   a.LoginName,
+			"Name":             a.Name,
+			"Description":      a.Description,
+			"LoginName":        a.LoginName,
+			"CustomAttributes": a.CustomAttributes,
 		},
 		fieldMaskPaths,
 		nil,
@@ -302,7 +338,7 @@ func (r *Repository) UpdateAccount(ctx context.Context, scopeId string, a *Accou
 	var rowsUpdated int
 	var returnedAccount *Account
 	_, err = r.writer.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{},
-		func(_ db.Reader, w db.Writer) error {
+		func(reader db.Reader, w db.Writer) error {
 			returnedAccount = a.clone()
 			var err error
 			rowsUpdated, err = w.Update(ctx, returnedAccount, dbMask, nullFields, db.WithOplog(oplogWrapper, metadata), db.WithVersion(&version))
@@ -312,6 +348,12 @@ func (r *Repository) UpdateAccount(ctx context.Context, scopeId string, a *Accou
 			if rowsUpdated > 1 {
 				return errors.New(ctx, errors.MultipleRecords, op, "more than 1 resource would have been updated")
 			}
+			if rowsUpdated == 0 {
+				return nil
+			}
+			if err := setAccountManagedGroups(ctx, reader, w, oplogWrapper, scopeId, returnedAccount); err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
 			return nil
 		},
 	)
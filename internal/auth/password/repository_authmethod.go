@@ -7,11 +7,13 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/boundary/internal/db"
 	"github.com/hashicorp/boundary/internal/errors"
 	"github.com/hashicorp/boundary/internal/kms"
 	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/hashicorp/boundary/internal/pagination"
 	"github.com/hashicorp/go-dbw"
 )
 
@@ -125,6 +127,17 @@ func (r *Repository) ListAuthMethods(ctx context.Context, scopeIds []string, opt
 	return authMethods, nil
 }
 
+// ListDeletedAuthMethodIds returns the auth methods of all subtypes in one of
+// scopeIds deleted after since.
+func (r *Repository) ListDeletedAuthMethodIds(ctx context.Context, scopeIds []string, since time.Time) ([]*pagination.DeletedResource, error) {
+	const op = "password.(Repository).ListDeletedAuthMethodIds"
+	deleted, err := pagination.ListDeletedIds(ctx, r.reader, "auth_method", scopeIds, since)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return deleted, nil
+}
+
 // DeleteAuthMethod deletes the auth method for the provided id from the repository returning a count of the
 // number of records deleted.  All options are ignored.
 func (r *Repository) DeleteAuthMethod(ctx context.Context, scopeId, publicId string, opt ...Option) (int, error) {
@@ -196,6 +209,14 @@ func (r *Repository) UpdateAuthMethod(ctx context.Context, authMethod *AuthMetho
 		case strings.EqualFold("Description", f):
 		case strings.EqualFold("MinLoginNameLength", f):
 		case strings.EqualFold("MinPasswordLength", f):
+		case strings.EqualFold("MaxFailedAttempts", f):
+		case strings.EqualFold("LockoutDuration", f):
+		case strings.EqualFold("SourceIpRateLimit", f):
+		case strings.EqualFold("MfaRequired", f):
+		case strings.EqualFold("MinPasswordClasses", f):
+		case strings.EqualFold("DenyCommonPasswords", f):
+		case strings.EqualFold("PasswordHistoryCount", f):
+		case strings.EqualFold("MaxPasswordAge", f):
 		default:
 			return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidFieldMask, op, f)
 		}
@@ -203,13 +224,26 @@ func (r *Repository) UpdateAuthMethod(ctx context.Context, authMethod *AuthMetho
 	var dbMask, nullFields []string
 	dbMask, nullFields = dbw.BuildUpdatePaths(
 		map[string]any{
-			"Name":               authMethod.Name,
-			"Description":        authMethod.Description,
-			"MinPasswordLength":  authMethod.MinPasswordLength,
-			"MinLoginNameLength": authMethod.MinLoginNameLength,
+			"Name":                 authMethod.Name,
+			"Description":          authMethod.Description,
+			"MinPasswordLength":    authMethod.MinPasswordLength,
+			"MinLoginNameLength":   authMethod.MinLoginNameLength,
+			"MaxFailedAttempts":    authMethod.MaxFailedAttempts,
+			"LockoutDuration":      authMethod.LockoutDuration,
+			"SourceIpRateLimit":    authMethod.SourceIpRateLimit,
+			"MfaRequired":          authMethod.MfaRequired,
+			"MinPasswordClasses":   authMethod.MinPasswordClasses,
+			"DenyCommonPasswords":  authMethod.DenyCommonPasswords,
+			"PasswordHistoryCount": authMethod.PasswordHistoryCount,
+			"MaxPasswordAge":       authMethod.MaxPasswordAge,
 		},
 		fieldMaskPaths,
-		nil,
+		// zero disables lockout, rate limiting, mfa and the password policy,
+		// so it is a value and not a request to reset the field to its default
+		[]string{
+			"MaxFailedAttempts", "LockoutDuration", "SourceIpRateLimit", "MfaRequired",
+			"MinPasswordClasses", "DenyCommonPasswords", "PasswordHistoryCount", "MaxPasswordAge",
+		},
 	)
 	if len(dbMask) == 0 && len(nullFields) == 0 {
 		return nil, db.NoRowsAffected, errors.New(ctx, errors.EmptyFieldMask, op, "field mask must not be empty")
@@ -316,7 +350,7 @@ func (r *Repository) getAuthMethods(ctx context.Context, authMethodId string, sc
 		// non-zero signals an override of the default limit for the repo.
 		limit = opts.withLimit
 	}
-	dbArgs = append(dbArgs, db.WithLimit(limit))
+	dbArgs = append(dbArgs, db.WithLimit(limit), db.WithPage(opts.withPage))
 
 	if opts.withOrderByCreateTime {
 		if opts.ascending {
@@ -123,9 +123,13 @@ func (r *Repository) setArgon2Conf(ctx context.Context, scopeId string, c *Argon
 }
 
 type currentConfig struct {
-	ConfType           string
-	MinLoginNameLength int
-	MinPasswordLength  int
+	ConfType             string
+	MinLoginNameLength   int
+	MinPasswordLength    int
+	MinPasswordClasses   int
+	DenyCommonPasswords  bool
+	PasswordHistoryCount int
+	MaxPasswordAge       int
 
 	*Argon2Configuration
 }
@@ -0,0 +1,93 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package password
+
+import (
+	"context"
+	"crypto/subtle"
+	"database/sql"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/go-kms-wrapping/v2/extras/structwrapping"
+	"golang.org/x/crypto/argon2"
+)
+
+// previousPassword is the current or a previous password of an account with
+// the argon2 settings used to derive its key.
+type previousPassword struct {
+	PrivateId  string
+	CtSalt     []byte `gorm:"column:salt" wrapping:"ct,entry_salt"`
+	Salt       []byte `gorm:"-" wrapping:"pt,entry_salt"`
+	DerivedKey []byte
+	KeyId      string
+	Iterations uint32
+	Memory     uint32
+	Threads    uint32
+	KeyLength  uint32
+}
+
+// checkPasswordHistory returns an error with code PasswordReused if password
+// is the current password of accountId or one of its last historyCount
+// previous passwords. Nothing is checked if historyCount is 0.
+func (r *Repository) checkPasswordHistory(ctx context.Context, scopeId, accountId, password string, historyCount int) error {
+	const op = "password.(Repository).checkPasswordHistory"
+	if historyCount <= 0 {
+		return nil
+	}
+	rows, err := r.reader.Query(ctx, passwordHistoryQuery, []any{
+		sql.Named("account_id", accountId),
+		sql.Named("history_count", historyCount),
+	})
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	defer rows.Close()
+	var previous []*previousPassword
+	for rows.Next() {
+		var p previousPassword
+		if err := r.reader.ScanRows(ctx, rows, &p); err != nil {
+			return errors.Wrap(ctx, err, op)
+		}
+		previous = append(previous, &p)
+	}
+	if err := rows.Err(); err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+
+	for _, p := range previous {
+		databaseWrapper, err := r.kms.GetWrapper(ctx, scopeId, kms.KeyPurposeDatabase, kms.WithKeyId(p.KeyId))
+		if err != nil {
+			return errors.Wrap(ctx, err, op, errors.WithCode(errors.Encrypt), errors.WithMsg("unable to get database wrapper"))
+		}
+		if err := structwrapping.UnwrapStruct(ctx, databaseWrapper, p, nil); err != nil {
+			return errors.Wrap(ctx, err, op, errors.WithCode(errors.Decrypt), errors.WithMsg("unable to decrypt previous password"))
+		}
+		key := argon2.IDKey([]byte(password), p.Salt, p.Iterations, p.Memory, uint8(p.Threads), p.KeyLength)
+		if subtle.ConstantTimeCompare(key, p.DerivedKey) == 1 {
+			return errors.New(ctx, errors.PasswordReused, op, "password was used before")
+		}
+	}
+	return nil
+}
+
+// recordPasswordHistory adds the current password of accountId to its
+// password history and removes all but the last historyCount previous
+// passwords. It must be called before the current credential is deleted.
+func recordPasswordHistory(ctx context.Context, w db.Writer, accountId string, historyCount int) error {
+	const op = "password.recordPasswordHistory"
+	if historyCount > 0 {
+		if _, err := w.Exec(ctx, insertPasswordHistoryQuery, []any{sql.Named("account_id", accountId)}); err != nil {
+			return errors.Wrap(ctx, err, op)
+		}
+	}
+	if _, err := w.Exec(ctx, prunePasswordHistoryQuery, []any{
+		sql.Named("account_id", accountId),
+		sql.Named("history_count", historyCount),
+	}); err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	return nil
+}
@@ -0,0 +1,207 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package password
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/db/timestamp"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/observability/event"
+)
+
+// lockoutOperation is the audit request operation of the event written when
+// an account is locked.
+const lockoutOperation = "password-account-lockout"
+
+// lockoutState is the lockout state of an account and the lockout setting of
+// its auth method at the time of an authentication attempt.
+type lockoutState struct {
+	AccountId          string
+	MaxFailedAttempts  uint32
+	FailedAttemptCount uint32
+	Locked             bool
+	LockExpired        bool
+}
+
+// accountLockout is the lockout state of an account as reported on the
+// account.
+type accountLockout struct {
+	FailedAttemptCount uint32
+	Locked             bool
+	LockExpired        bool
+	LockedUntil        *timestamp.Timestamp
+}
+
+// lookupLockoutState returns the lockout state of the account with
+// loginName in authMethodId. Returns nil if there is no such account.
+func (r *Repository) lookupLockoutState(ctx context.Context, authMethodId, loginName string) (*lockoutState, error) {
+	const op = "password.(Repository).lookupLockoutState"
+	rows, err := r.reader.Query(ctx, lookupLockoutQuery, []any{sql.Named("auth_method_id", authMethodId), sql.Named("login_name", loginName)})
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	defer rows.Close()
+	var states []lockoutState
+	for rows.Next() {
+		var s lockoutState
+		if err := r.reader.ScanRows(ctx, rows, &s); err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		states = append(states, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	switch {
+	case len(states) == 0:
+		return nil, nil
+	case len(states) > 1:
+		// this should never happen
+		return nil, errors.New(ctx, errors.Unknown, op, "multiple accounts returned for user name")
+	}
+	return &states[0], nil
+}
+
+// recordFailedAttempt records a failed authentication attempt for the account
+// and locks the account when it reaches the max failed attempts of its auth
+// method. It reports whether the account is locked after the attempt.
+func (r *Repository) recordFailedAttempt(ctx context.Context, state *lockoutState) (bool, error) {
+	const op = "password.(Repository).recordFailedAttempt"
+	var locked bool
+	_, err := r.writer.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) error {
+			if state.LockExpired {
+				// the lockout duration has passed, so the failed attempts
+				// start over
+				if _, err := w.Exec(ctx, resetLockoutQuery, []any{sql.Named("account_id", state.AccountId)}); err != nil {
+					return errors.Wrap(ctx, err, op)
+				}
+			}
+			rows, err := w.Query(ctx, recordFailedAttemptQuery, []any{
+				sql.Named("account_id", state.AccountId),
+				sql.Named("max_failed_attempts", state.MaxFailedAttempts),
+			})
+			if err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			defer rows.Close()
+			var result struct {
+				FailedAttemptCount uint32
+				Locked             bool
+			}
+			for rows.Next() {
+				if err := w.ScanRows(ctx, rows, &result); err != nil {
+					return errors.Wrap(ctx, err, op)
+				}
+			}
+			if err := rows.Err(); err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			locked = result.Locked
+			return nil
+		},
+	)
+	if err != nil {
+		return false, errors.Wrap(ctx, err, op)
+	}
+	return locked, nil
+}
+
+// resetLockout clears the failed authentication attempts of accountId and
+// unlocks it.
+func (r *Repository) resetLockout(ctx context.Context, accountId string) error {
+	const op = "password.(Repository).resetLockout"
+	if _, err := r.writer.Exec(ctx, resetLockoutQuery, []any{sql.Named("account_id", accountId)}); err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	return nil
+}
+
+// lookupAccountLockout returns the lockout state of accountId. An account
+// without failed authentication attempts has the zero state.
+func (r *Repository) lookupAccountLockout(ctx context.Context, accountId string) (*accountLockout, error) {
+	const op = "password.(Repository).lookupAccountLockout"
+	rows, err := r.reader.Query(ctx, lookupAccountLockoutQuery, []any{sql.Named("account_id", accountId)})
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	defer rows.Close()
+	var lo accountLockout
+	for rows.Next() {
+		if err := r.reader.ScanRows(ctx, rows, &lo); err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	if lo.LockExpired {
+		// the failed attempts start over with the next attempt
+		return &accountLockout{}, nil
+	}
+	if !lo.Locked {
+		lo.LockedUntil = nil
+	}
+	return &lo, nil
+}
+
+// UnlockAccount clears the failed authentication attempts of accountId and
+// unlocks it if it is locked. The unlocked account is returned.
+//
+// Returns nil, error with code RecordNotFound if the account doesn't exist.
+func (r *Repository) UnlockAccount(ctx context.Context, accountId string) (*Account, error) {
+	const op = "password.(Repository).UnlockAccount"
+	if accountId == "" {
+		return nil, errors.New(ctx, errors.InvalidPublicId, op, "missing account id")
+	}
+	acct, err := r.LookupAccount(ctx, accountId)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	if acct == nil {
+		return nil, errors.New(ctx, errors.RecordNotFound, op, "account not found")
+	}
+	if err := r.resetLockout(ctx, accountId); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	acct.FailedAttemptCount = 0
+	acct.Locked = false
+	acct.LockedUntil = nil
+	return acct, nil
+}
+
+// auditLockout writes an audit event recording that accountId in
+// authMethodId was locked after too many failed authentication attempts.
+func auditLockout(ctx context.Context, authMethodId, accountId string) {
+	const op = "password.auditLockout"
+	id, err := event.NewId(event.IdPrefix)
+	if err != nil {
+		event.WriteError(ctx, op, err, event.WithInfoMsg("unable to generate lockout event id"))
+		return
+	}
+	opts := []event.Option{
+		// the lockout is its own event and not part of the audit event of
+		// the authenticate request
+		event.WithId(id),
+		event.WithFlush(),
+		event.WithRequest(&event.Request{
+			Operation: lockoutOperation,
+			Endpoint:  "/v1/accounts/" + accountId,
+		}),
+		event.WithAuth(&event.Auth{
+			UserInfo: &event.UserInfo{AuthAccountId: accountId},
+		}),
+	}
+	if ri, ok := event.RequestInfoFromContext(ctx); ok {
+		info := *ri
+		info.EventId = id
+		opts = append(opts, event.WithRequestInfo(&info))
+	}
+	if err := event.WriteAudit(ctx, op, opts...); err != nil {
+		event.WriteError(ctx, op, err, event.WithInfoMsg("unable to write lockout audit event", "auth_method_id", authMethodId, "account_id", accountId))
+	}
+}
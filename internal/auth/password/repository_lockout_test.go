@@ -0,0 +1,95 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package password
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/auth/password/store"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_AuthenticateLockout(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	assert, require := assert.New(t), require.New(t)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	o, _ := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	ctx := context.Background()
+
+	repo, err := NewRepository(rw, rw, kmsCache)
+	require.NoError(err)
+	require.NotNil(repo)
+
+	authMethod := TestAuthMethods(t, conn, o.GetPublicId(), 1)[0]
+	authMethod.MaxFailedAttempts = 2
+	authMethod, _, err = repo.UpdateAuthMethod(ctx, authMethod, authMethod.Version, []string{"MaxFailedAttempts"})
+	require.NoError(err)
+	authMethodId := authMethod.GetPublicId()
+
+	loginName := "kazmierczak"
+	passwd := "12345678"
+	acct, err := repo.CreateAccount(ctx, o.GetPublicId(), &Account{
+		Account: &store.Account{
+			AuthMethodId: authMethodId,
+			LoginName:    loginName,
+		},
+	}, WithPassword(passwd))
+	require.NoError(err)
+
+	// a failed attempt is counted and a successful one resets the count
+	got, err := repo.Authenticate(ctx, o.GetPublicId(), authMethodId, loginName, "wrong-password")
+	require.NoError(err)
+	assert.Nil(got)
+	looked, err := repo.LookupAccount(ctx, acct.GetPublicId())
+	require.NoError(err)
+	assert.Equal(uint32(1), looked.GetFailedAttemptCount())
+	assert.False(looked.GetLocked())
+
+	got, err = repo.Authenticate(ctx, o.GetPublicId(), authMethodId, loginName, passwd)
+	require.NoError(err)
+	require.NotNil(got)
+	looked, err = repo.LookupAccount(ctx, acct.GetPublicId())
+	require.NoError(err)
+	assert.Zero(looked.GetFailedAttemptCount())
+
+	// reaching max failed attempts locks the account, even for the right
+	// password
+	for i := 0; i < 2; i++ {
+		got, err = repo.Authenticate(ctx, o.GetPublicId(), authMethodId, loginName, "wrong-password")
+		require.NoError(err)
+		assert.Nil(got)
+	}
+	looked, err = repo.LookupAccount(ctx, acct.GetPublicId())
+	require.NoError(err)
+	assert.Equal(uint32(2), looked.GetFailedAttemptCount())
+	assert.True(looked.GetLocked())
+	assert.Nil(looked.GetLockedUntil(), "a lockout duration of 0 locks until unlocked")
+
+	got, err = repo.Authenticate(ctx, o.GetPublicId(), authMethodId, loginName, passwd)
+	require.Error(err)
+	assert.Truef(errors.Match(errors.T(errors.PasswordAccountLocked), err), "unexpected error %s", err)
+	assert.Nil(got)
+
+	// unlocking clears the lockout
+	unlocked, err := repo.UnlockAccount(ctx, acct.GetPublicId())
+	require.NoError(err)
+	assert.False(unlocked.GetLocked())
+	assert.Zero(unlocked.GetFailedAttemptCount())
+
+	got, err = repo.Authenticate(ctx, o.GetPublicId(), authMethodId, loginName, passwd)
+	require.NoError(err)
+	assert.NotNil(got)
+
+	_, err = repo.UnlockAccount(ctx, "acctpw_doesntexist")
+	require.Error(err)
+	assert.True(errors.Match(errors.T(errors.RecordNotFound), err))
+}
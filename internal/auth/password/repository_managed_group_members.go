@@ -0,0 +1,174 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package password
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/boundary/internal/auth"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/oplog"
+	wrapping "github.com/hashicorp/go-kms-wrapping/v2"
+)
+
+// setManagedGroupMembers recalculates, using the reader and writer of an
+// ongoing transaction, whether each of accts is a member of each of mgs. The
+// managed groups and accounts must all belong to the auth method. Memberships
+// which no longer match are deleted and new matches are added.
+func setManagedGroupMembers(ctx context.Context, reader db.Reader, w db.Writer, oplogWrapper wrapping.Wrapper, scopeId, authMethodId string, accts []*Account, mgs []*ManagedGroup) error {
+	const op = "password.setManagedGroupMembers"
+	if len(accts) == 0 || len(mgs) == 0 {
+		return nil
+	}
+
+	type membership struct{ mgId, acctId string }
+	wanted := make(map[membership]bool)
+	acctIds := make([]string, 0, len(accts))
+	for _, acct := range accts {
+		acctIds = append(acctIds, acct.PublicId)
+		data, err := acct.filterData(ctx)
+		if err != nil {
+			return errors.Wrap(ctx, err, op)
+		}
+		matched, err := auth.MatchManagedGroupFilters(ctx, mgs, data)
+		if err != nil {
+			return errors.Wrap(ctx, err, op)
+		}
+		for _, mg := range matched {
+			wanted[membership{mgId: mg.PublicId, acctId: acct.PublicId}] = true
+		}
+	}
+	mgIds := make([]string, 0, len(mgs))
+	for _, mg := range mgs {
+		mgIds = append(mgIds, mg.PublicId)
+	}
+
+	var current []*ManagedGroupMemberAccount
+	if err := reader.SearchWhere(ctx, &current, "managed_group_id in (?) and member_id in (?)", []any{mgIds, acctIds}, db.WithLimit(-1)); err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithMsg("unable to retrieve current managed group memberships"))
+	}
+
+	toDelete := make([]any, 0, len(current))
+	for _, m := range current {
+		k := membership{mgId: m.ManagedGroupId, acctId: m.MemberId}
+		if wanted[k] {
+			// Already a member, so there's nothing to add
+			delete(wanted, k)
+			continue
+		}
+		delMg := AllocManagedGroupMemberAccount()
+		delMg.ManagedGroupId = m.ManagedGroupId
+		delMg.MemberId = m.MemberId
+		toDelete = append(toDelete, delMg)
+	}
+	if len(toDelete) == 0 && len(wanted) == 0 {
+		return nil
+	}
+
+	metadata := oplog.Metadata{
+		"op-type":        []string{oplog.OpType_OP_TYPE_UPDATE.String()},
+		"scope-id":       []string{scopeId},
+		"auth-method-id": []string{authMethodId},
+	}
+	// We need a ticket, which won't be redeemed until all the other writes
+	// are successful, since we write oplog entries for deletes and adds.
+	mgTicket, err := w.GetTicket(ctx, AllocManagedGroup())
+	if err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithMsg("unable to get ticket for password managed groups"))
+	}
+	var msgs []*oplog.Message
+
+	if len(toDelete) > 0 {
+		metadata["op-type"] = append(metadata["op-type"], oplog.OpType_OP_TYPE_DELETE.String())
+		deleteOplogMsgs := make([]*oplog.Message, 0, len(toDelete))
+		rowsDeleted, err := w.DeleteItems(ctx, toDelete, db.NewOplogMsgs(&deleteOplogMsgs))
+		if err != nil {
+			return errors.Wrap(ctx, err, op, errors.WithMsg("unable to delete managed group member accounts"))
+		}
+		if rowsDeleted != len(toDelete) {
+			return errors.New(ctx, errors.MultipleRecords, op, fmt.Sprintf("managed group member accounts deleted %d did not match request for %d", rowsDeleted, len(toDelete)))
+		}
+		msgs = append(msgs, deleteOplogMsgs...)
+	}
+
+	if len(wanted) > 0 {
+		metadata["op-type"] = append(metadata["op-type"], oplog.OpType_OP_TYPE_CREATE.String())
+		addOplogMsgs := make([]*oplog.Message, 0, len(wanted))
+		toAdd := make([]any, 0, len(wanted))
+		for m := range wanted {
+			newMg := AllocManagedGroupMemberAccount()
+			newMg.ManagedGroupId = m.mgId
+			newMg.MemberId = m.acctId
+			toAdd = append(toAdd, newMg)
+		}
+		if err := w.CreateItems(ctx, toAdd, db.NewOplogMsgs(&addOplogMsgs)); err != nil {
+			return errors.Wrap(ctx, err, op, errors.WithMsg("unable to add managed group member accounts"))
+		}
+		msgs = append(msgs, addOplogMsgs...)
+	}
+
+	if err := w.WriteOplogEntryWith(ctx, oplogWrapper, mgTicket, metadata, msgs); err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithMsg("unable to write oplog"))
+	}
+	return nil
+}
+
+// setAccountManagedGroups recalculates, using the reader and writer of an
+// ongoing transaction, the memberships of acct in the managed groups of its
+// auth method.
+func setAccountManagedGroups(ctx context.Context, reader db.Reader, w db.Writer, oplogWrapper wrapping.Wrapper, scopeId string, acct *Account) error {
+	const op = "password.setAccountManagedGroups"
+	var mgs []*ManagedGroup
+	if err := reader.SearchWhere(ctx, &mgs, "auth_method_id = ?", []any{acct.AuthMethodId}, db.WithLimit(-1)); err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	if err := setManagedGroupMembers(ctx, reader, w, oplogWrapper, scopeId, acct.AuthMethodId, []*Account{acct}, mgs); err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	return nil
+}
+
+// ListManagedGroupMembershipsByMember lists managed group memberships via the
+// member (account) ID and supports WithLimit option.
+func (r *Repository) ListManagedGroupMembershipsByMember(ctx context.Context, withAcctId string, opt ...Option) ([]*ManagedGroupMemberAccount, error) {
+	const op = "password.(Repository).ListManagedGroupMembershipsByMember"
+	if withAcctId == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing account id")
+	}
+	opts := GetOpts(opt...)
+	limit := r.defaultLimit
+	if opts.withLimit != 0 {
+		// non-zero signals an override of the default limit for the repo.
+		limit = opts.withLimit
+	}
+	var mgs []*ManagedGroupMemberAccount
+	err := r.reader.SearchWhere(ctx, &mgs, "member_id = ?", []any{withAcctId}, db.WithLimit(limit))
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return mgs, nil
+}
+
+// ListManagedGroupMembershipsByGroup lists managed group memberships via the
+// group ID and supports WithLimit option.
+func (r *Repository) ListManagedGroupMembershipsByGroup(ctx context.Context, withGroupId string, opt ...Option) ([]*ManagedGroupMemberAccount, error) {
+	const op = "password.(Repository).ListManagedGroupMembershipsByGroup"
+	if withGroupId == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing managed group id")
+	}
+	opts := GetOpts(opt...)
+	limit := r.defaultLimit
+	if opts.withLimit != 0 {
+		// non-zero signals an override of the default limit for the repo.
+		limit = opts.withLimit
+	}
+	var mgs []*ManagedGroupMemberAccount
+	err := r.reader.SearchWhere(ctx, &mgs, "managed_group_id = ?", []any{withGroupId}, db.WithLimit(limit))
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return mgs, nil
+}
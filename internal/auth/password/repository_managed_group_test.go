@@ -0,0 +1,122 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package password
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccount_filterData(t *testing.T) {
+	ctx := context.Background()
+	a, err := NewAccount("apm_1234567890", WithLoginName("alice"), WithName("Alice"),
+		WithCustomAttributes(map[string]string{"department": "eng"}))
+	require.NoError(t, err)
+	data, err := a.filterData(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"account": map[string]any{
+			"login_name":        "alice",
+			"name":              "Alice",
+			"description":       "",
+			"custom_attributes": map[string]any{"department": "eng"},
+		},
+	}, data)
+
+	_, err = NewAccount("apm_1234567890", WithCustomAttributes(map[string]string{"": "eng"}))
+	assert.Error(t, err)
+}
+
+func TestRepository_ManagedGroupMemberships(t *testing.T) {
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	org, _ := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	authMethod := TestAuthMethods(t, conn, org.GetPublicId(), 1)[0]
+
+	repo, err := NewRepository(rw, rw, kmsCache)
+	require.NoError(t, err)
+
+	memberIds := func(t *testing.T, mgId string) []string {
+		t.Helper()
+		ms, err := repo.ListManagedGroupMembershipsByGroup(ctx, mgId)
+		require.NoError(t, err)
+		var ids []string
+		for _, m := range ms {
+			ids = append(ids, m.GetMemberId())
+		}
+		return ids
+	}
+
+	newAcct := func(t *testing.T, loginName, department string) *Account {
+		t.Helper()
+		a, err := NewAccount(authMethod.GetPublicId(), WithLoginName(loginName),
+			WithCustomAttributes(map[string]string{"department": department}))
+		require.NoError(t, err)
+		a, err = repo.CreateAccount(ctx, org.GetPublicId(), a)
+		require.NoError(t, err)
+		return a
+	}
+
+	alice := newAcct(t, "alice", "eng")
+	newAcct(t, "bob", "sales")
+
+	t.Run("invalid-filter", func(t *testing.T) {
+		mg := AllocManagedGroup()
+		mg.AuthMethodId = authMethod.GetPublicId()
+		mg.Filter = `"/account/login_name" ==`
+		_, err := repo.CreateManagedGroup(ctx, org.GetPublicId(), mg)
+		require.Error(t, err)
+		assert.True(t, errors.Match(errors.T(errors.InvalidParameter), err))
+	})
+
+	mg, err := NewManagedGroup(ctx, authMethod.GetPublicId(), `"/account/custom_attributes/department" == "eng"`, WithName("eng"))
+	require.NoError(t, err)
+	mg, err = repo.CreateManagedGroup(ctx, org.GetPublicId(), mg)
+	require.NoError(t, err)
+	assert.Equal(t, []string{alice.GetPublicId()}, memberIds(t, mg.GetPublicId()))
+
+	// new accounts are added to the managed groups they match
+	carol := newAcct(t, "carol", "eng")
+	assert.ElementsMatch(t, []string{alice.GetPublicId(), carol.GetPublicId()}, memberIds(t, mg.GetPublicId()))
+
+	// changing an account's custom attributes updates its memberships
+	alice.CustomAttributes = `{"department":"sales"}`
+	alice, _, err = repo.UpdateAccount(ctx, org.GetPublicId(), alice, alice.GetVersion(), []string{"CustomAttributes"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{carol.GetPublicId()}, memberIds(t, mg.GetPublicId()))
+	ms, err := repo.ListManagedGroupMembershipsByMember(ctx, alice.GetPublicId())
+	require.NoError(t, err)
+	assert.Empty(t, ms)
+
+	// changing the filter recalculates the members
+	mg.Filter = `"/account/custom_attributes/department" == "sales"`
+	mg, _, err = repo.UpdateManagedGroup(ctx, org.GetPublicId(), mg, mg.GetVersion(), []string{"Filter"})
+	require.NoError(t, err)
+	assert.Len(t, memberIds(t, mg.GetPublicId()), 2)
+	assert.Contains(t, memberIds(t, mg.GetPublicId()), alice.GetPublicId())
+
+	got, err := repo.LookupManagedGroup(ctx, mg.GetPublicId())
+	require.NoError(t, err)
+	assert.Equal(t, mg.GetFilter(), got.GetFilter())
+	mgs, err := repo.ListManagedGroups(ctx, authMethod.GetPublicId())
+	require.NoError(t, err)
+	assert.Len(t, mgs, 1)
+
+	deleted, err := repo.DeleteManagedGroup(ctx, org.GetPublicId(), mg.GetPublicId())
+	require.NoError(t, err)
+	assert.Equal(t, 1, deleted)
+	got, err = repo.LookupManagedGroup(ctx, mg.GetPublicId())
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
@@ -21,7 +21,8 @@ type authAccount struct {
 	*Account
 	*Argon2Credential
 	*Argon2Configuration
-	IsCurrentConf bool
+	IsCurrentConf   bool
+	PasswordExpired bool
 }
 
 // Authenticate authenticates loginName and password match for loginName in
@@ -35,6 +36,20 @@ type authAccount struct {
 // Authenticate will update the stored values for password to the current
 // password settings for authMethodId if authentication is successful and
 // the stored values are not using the current password settings.
+//
+// When the auth method has a max failed attempts, each failed attempt is
+// recorded on the account and the account is locked when it reaches the max.
+// Returns nil, error with code PasswordAccountLocked if the account is
+// locked; the password is not checked. A successful authentication clears
+// the failed attempts of the account.
+//
+// The returned account has TotpEnrolled set when it has a confirmed TOTP
+// secret. Its authentication is then only complete after VerifyTotp, which
+// clears the failed attempts instead.
+//
+// Returns nil, error with code PasswordExpired if the password is older than
+// the max password age of authMethodId. The password must then be changed
+// with ChangeExpiredPassword.
 func (r *Repository) Authenticate(ctx context.Context, scopeId, authMethodId, loginName, password string) (*Account, error) {
 	const op = "password.(Repository).Authenticate"
 	if authMethodId == "" {
@@ -55,13 +70,43 @@ func (r *Repository) Authenticate(ctx context.Context, scopeId, authMethodId, lo
 		return nil, errors.Wrap(ctx, err, op, errors.WithCode(errors.Encrypt), errors.WithMsg("unable to get database wrapper"))
 	}
 
+	lockout, err := r.lookupLockoutState(ctx, authMethodId, loginName)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	if lockout != nil && lockout.Locked {
+		return nil, errors.New(ctx, errors.PasswordAccountLocked, op, "account is locked", errors.WithoutEvent())
+	}
+
 	acct, err := r.authenticate(ctx, scopeId, authMethodId, loginName, password)
 	if err != nil {
 		return nil, errors.Wrap(ctx, err, op)
 	}
 	if acct == nil {
+		if lockout != nil && lockout.MaxFailedAttempts > 0 {
+			locked, err := r.recordFailedAttempt(ctx, lockout)
+			if err != nil {
+				return nil, errors.Wrap(ctx, err, op)
+			}
+			if locked {
+				auditLockout(ctx, authMethodId, lockout.AccountId)
+			}
+		}
 		return nil, nil
 	}
+	enrolled, err := r.totpEnrolled(ctx, acct.PublicId)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	acct.TotpEnrolled = enrolled
+	if !enrolled && lockout != nil && (lockout.FailedAttemptCount > 0 || lockout.LockExpired) {
+		if err := r.resetLockout(ctx, acct.PublicId); err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+	}
+	if acct.PasswordExpired {
+		return nil, errors.New(ctx, errors.PasswordExpired, op, "password expired", errors.WithoutEvent())
+	}
 
 	if !acct.IsCurrentConf {
 		cc, err := r.currentConfig(ctx, authMethodId)
@@ -110,9 +155,15 @@ func (r *Repository) Authenticate(ctx context.Context, scopeId, authMethodId, lo
 // the stored password. The account for the accountId is returned with a
 // new CredentialId if password is successfully changed.
 //
+// The new password must satisfy the password policy of the auth method of
+// accountId: it must not be one of the previous passwords kept in the
+// password history of the account.
+//
 // Returns nil, db.ErrorRecordNotFound if the account doesn't exist.
 // Returns nil, nil if old does not match the stored password for accountId.
 // Returns nil, error with code PasswordsEqual if old and new are equal.
+// Returns nil, error with code PasswordTooShort, PasswordTooWeak,
+// PasswordDenied or PasswordReused if new does not satisfy the policy.
 func (r *Repository) ChangePassword(ctx context.Context, scopeId, accountId, old, new string, version uint32) (*Account, error) {
 	const op = "password.(Repository).ChangePassword"
 	if accountId == "" {
@@ -166,6 +217,12 @@ func (r *Repository) ChangePassword(ctx context.Context, scopeId, accountId, old
 	if cc.MinPasswordLength > len(new) {
 		return nil, errors.New(ctx, errors.PasswordTooShort, op, fmt.Sprintf("must be at least %d", cc.MinPasswordLength))
 	}
+	if err := cc.checkPassword(ctx, new); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	if err := r.checkPasswordHistory(ctx, scopeId, accountId, new, cc.PasswordHistoryCount); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
 	newCred, err := newArgon2Credential(accountId, new, cc.argon2())
 	if err != nil {
 		return nil, errors.Wrap(ctx, err, op)
@@ -191,6 +248,9 @@ func (r *Repository) ChangePassword(ctx context.Context, scopeId, accountId, old
 				return errors.New(ctx, errors.MultipleRecords, op, fmt.Sprintf("updated account and %d rows updated", rowsUpdated))
 			}
 
+			if err := recordPasswordHistory(ctx, w, accountId, cc.PasswordHistoryCount); err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
 			rowsDeleted, err := w.Delete(ctx, oldCred, db.WithOplog(oplogWrapper, oldCred.oplog(oplog.OpType_OP_TYPE_DELETE)))
 			if err != nil {
 				return errors.Wrap(ctx, err, op)
@@ -261,6 +321,11 @@ func (r *Repository) authenticate(ctx context.Context, scopeId, authMethodId, lo
 
 // SetPassword sets the password for accountId to password. If password
 // contains an empty string, the password for accountId will be deleted.
+//
+// A new password must satisfy the password policy of the auth method of
+// accountId, including its password history. Returns nil, error with code
+// PasswordTooShort, PasswordTooWeak, PasswordDenied or PasswordReused if it
+// does not.
 func (r *Repository) SetPassword(ctx context.Context, scopeId, accountId, password string, version uint32) (*Account, error) {
 	const op = "password.(Repository).SetPassword"
 	if accountId == "" {
@@ -283,6 +348,7 @@ func (r *Repository) SetPassword(ctx context.Context, scopeId, accountId, passwo
 	}
 
 	var newCred *Argon2Credential
+	var historyCount int
 	if password != "" {
 		cc, err := r.currentConfigForAccount(ctx, accountId)
 		if err != nil {
@@ -294,6 +360,13 @@ func (r *Repository) SetPassword(ctx context.Context, scopeId, accountId, passwo
 		if cc.MinPasswordLength > len(password) {
 			return nil, errors.New(ctx, errors.PasswordTooShort, op, fmt.Sprintf("password must be at least %v", cc.MinPasswordLength))
 		}
+		if err := cc.checkPassword(ctx, password); err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		if err := r.checkPasswordHistory(ctx, scopeId, accountId, password, cc.PasswordHistoryCount); err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		historyCount = cc.PasswordHistoryCount
 		newCred, err = newArgon2Credential(accountId, password, cc.argon2())
 		if err != nil {
 			return nil, errors.Wrap(ctx, err, op)
@@ -325,6 +398,11 @@ func (r *Repository) SetPassword(ctx context.Context, scopeId, accountId, passwo
 				}
 			}
 			if oldCred.PrivateId != "" {
+				if newCred != nil {
+					if err := recordPasswordHistory(ctx, w, accountId, historyCount); err != nil {
+						return errors.Wrap(ctx, err, op)
+					}
+				}
 				dCred := oldCred.clone()
 				rowsDeleted, err := w.Delete(ctx, dCred, db.WithOplog(oplogWrapper, oldCred.oplog(oplog.OpType_OP_TYPE_DELETE)))
 				if err != nil {
@@ -345,3 +423,35 @@ func (r *Repository) SetPassword(ctx context.Context, scopeId, accountId, passwo
 	}
 	return acct, nil
 }
+
+// ChangeExpiredPassword changes the expired password of the account for
+// loginName in authMethodId from old to new. It is used at authentication
+// when Authenticate returns an error with code PasswordExpired, since the
+// account cannot authenticate to use ChangePassword. The account is
+// returned if the password is changed.
+//
+// Returns nil, nil if old does not match the stored password for loginName.
+// The errors returned for new are the same as for ChangePassword.
+func (r *Repository) ChangeExpiredPassword(ctx context.Context, scopeId, authMethodId, loginName, old, new string) (*Account, error) {
+	const op = "password.(Repository).ChangeExpiredPassword"
+	switch {
+	case scopeId == "":
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing scopeId")
+	case authMethodId == "":
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing authMethodId")
+	case loginName == "":
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing loginName")
+	}
+	acct, err := r.authenticate(ctx, scopeId, authMethodId, loginName, old)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	if acct == nil {
+		return nil, nil
+	}
+	updated, err := r.ChangePassword(ctx, scopeId, acct.GetPublicId(), old, new, acct.GetVersion())
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return updated, nil
+}
@@ -0,0 +1,114 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package password
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/auth/password/store"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_PasswordPolicy(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	assert, require := assert.New(t), require.New(t)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	o, _ := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	ctx := context.Background()
+	scopeId := o.GetPublicId()
+
+	repo, err := NewRepository(rw, rw, kmsCache)
+	require.NoError(err)
+	require.NotNil(repo)
+
+	authMethod := TestAuthMethods(t, conn, scopeId, 1)[0]
+	authMethod.MinPasswordClasses = 3
+	authMethod.DenyCommonPasswords = true
+	authMethod.PasswordHistoryCount = 2
+	authMethod, _, err = repo.UpdateAuthMethod(ctx, authMethod, authMethod.Version,
+		[]string{"MinPasswordClasses", "DenyCommonPasswords", "PasswordHistoryCount"})
+	require.NoError(err)
+	authMethodId := authMethod.GetPublicId()
+
+	_, err = repo.CreateAccount(ctx, scopeId, &Account{
+		Account: &store.Account{
+			AuthMethodId: authMethodId,
+			LoginName:    "weak",
+		},
+	}, WithPassword("alllowercase"))
+	require.Error(err)
+	assert.Truef(errors.Match(errors.T(errors.PasswordTooWeak), err), "unexpected error %s", err)
+
+	loginName := "kazmierczak"
+	first := "First-passw0rd"
+	acct, err := repo.CreateAccount(ctx, scopeId, &Account{
+		Account: &store.Account{
+			AuthMethodId: authMethodId,
+			LoginName:    loginName,
+		},
+	}, WithPassword(first))
+	require.NoError(err)
+
+	_, err = repo.SetPassword(ctx, scopeId, acct.GetPublicId(), "Password1", acct.GetVersion())
+	require.Error(err)
+	assert.Truef(errors.Match(errors.T(errors.PasswordDenied), err), "unexpected error %s", err)
+
+	// the current password and the previous passwords cannot be reused
+	second := "Second-passw0rd"
+	acct, err = repo.ChangePassword(ctx, scopeId, acct.GetPublicId(), first, second, acct.GetVersion())
+	require.NoError(err)
+	require.NotNil(acct)
+	_, err = repo.SetPassword(ctx, scopeId, acct.GetPublicId(), second, acct.GetVersion())
+	require.Error(err)
+	assert.Truef(errors.Match(errors.T(errors.PasswordReused), err), "unexpected error %s", err)
+	_, err = repo.ChangePassword(ctx, scopeId, acct.GetPublicId(), second, first, acct.GetVersion())
+	require.Error(err)
+	assert.Truef(errors.Match(errors.T(errors.PasswordReused), err), "unexpected error %s", err)
+
+	// only the last password history count passwords are kept
+	third := "Third-passw0rd"
+	acct, err = repo.ChangePassword(ctx, scopeId, acct.GetPublicId(), second, third, acct.GetVersion())
+	require.NoError(err)
+	fourth := "Fourth-passw0rd"
+	acct, err = repo.SetPassword(ctx, scopeId, acct.GetPublicId(), fourth, acct.GetVersion())
+	require.NoError(err)
+	var previous []*Argon2History
+	require.NoError(rw.SearchWhere(ctx, &previous, "password_account_id = ?", []any{acct.GetPublicId()}))
+	assert.Len(previous, 2)
+	acct, err = repo.ChangePassword(ctx, scopeId, acct.GetPublicId(), fourth, first, acct.GetVersion())
+	require.NoError(err)
+	require.NotNil(acct)
+
+	// an expired password must be changed before authenticating
+	authMethod.MaxPasswordAge = 1
+	authMethod, _, err = repo.UpdateAuthMethod(ctx, authMethod, authMethod.Version, []string{"MaxPasswordAge"})
+	require.NoError(err)
+	time.Sleep(2 * time.Second)
+	got, err := repo.Authenticate(ctx, scopeId, authMethodId, loginName, first)
+	require.Error(err)
+	assert.Truef(errors.Match(errors.T(errors.PasswordExpired), err), "unexpected error %s", err)
+	assert.Nil(got)
+
+	got, err = repo.ChangeExpiredPassword(ctx, scopeId, authMethodId, loginName, "wrong-password", "Fifth-passw0rd")
+	require.NoError(err)
+	assert.Nil(got)
+	_, err = repo.ChangeExpiredPassword(ctx, scopeId, authMethodId, loginName, first, fourth)
+	require.Error(err)
+	assert.Truef(errors.Match(errors.T(errors.PasswordReused), err), "unexpected error %s", err)
+	got, err = repo.ChangeExpiredPassword(ctx, scopeId, authMethodId, loginName, first, "Fifth-passw0rd")
+	require.NoError(err)
+	require.NotNil(got)
+	got, err = repo.Authenticate(ctx, scopeId, authMethodId, loginName, "Fifth-passw0rd")
+	require.NoError(err)
+	assert.NotNil(got)
+}
@@ -0,0 +1,326 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package password
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/kms"
+)
+
+// EnrollTotp generates a new TOTP secret and recovery codes for accountId,
+// replacing any it has. The secret is not required to authenticate until it
+// is confirmed with ConfirmTotp. The secret is encrypted with the database
+// key of scopeId.
+//
+// If the account has a confirmed secret, either code, generated from it, or
+// one of the account's unused recovery codes must be provided to replace it.
+// They are verified as with VerifyTotp, so an invalid one counts as a failed
+// authentication attempt of the account.
+//
+// The returned TotpEnrollment holds the only copy of the unencrypted secret
+// and recovery codes. Returns nil, error with code RecordNotFound if the
+// account doesn't exist, nil, error with code PasswordTotpInvalid if code or
+// recoveryCode is required and not valid and nil, error with code
+// PasswordAccountLocked if the account is locked.
+func (r *Repository) EnrollTotp(ctx context.Context, scopeId, accountId, code, recoveryCode string) (*TotpEnrollment, error) {
+	const op = "password.(Repository).EnrollTotp"
+	if scopeId == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing scope id")
+	}
+	if accountId == "" {
+		return nil, errors.New(ctx, errors.InvalidPublicId, op, "missing account id")
+	}
+	acct, err := r.LookupAccount(ctx, accountId)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	if acct == nil {
+		return nil, errors.New(ctx, errors.RecordNotFound, op, "account not found")
+	}
+	enrolled, err := r.totpEnrolled(ctx, accountId)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	if enrolled {
+		if code == "" && recoveryCode == "" {
+			return nil, errors.New(ctx, errors.PasswordTotpInvalid, op, "missing code or recovery code for the confirmed totp secret", errors.WithoutEvent())
+		}
+		verified, err := r.VerifyTotp(ctx, scopeId, acct.GetAuthMethodId(), accountId, code, recoveryCode)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		if verified == nil {
+			return nil, errors.New(ctx, errors.PasswordTotpInvalid, op, "invalid code", errors.WithoutEvent())
+		}
+	}
+
+	t, err := newTotp(ctx, accountId)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	codes, hashes, err := newRecoveryCodes(ctx, accountId)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	databaseWrapper, err := r.kms.GetWrapper(ctx, scopeId, kms.KeyPurposeDatabase)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get database wrapper"))
+	}
+	newTotp := t.clone()
+	if err := newTotp.encrypt(ctx, databaseWrapper); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+
+	_, err = r.writer.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) error {
+			// deleting the old secret deletes its recovery codes too
+			if _, err := w.Exec(ctx, deleteTotpQuery, []any{sql.Named("account_id", accountId)}); err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			if err := w.Create(ctx, newTotp); err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			items := make([]any, 0, len(hashes))
+			for _, h := range hashes {
+				items = append(items, h)
+			}
+			if err := w.CreateItems(ctx, items); err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	acct.TotpEnrolled = false
+	return &TotpEnrollment{
+		Account:       acct,
+		Secret:        totpEncoding.EncodeToString(t.GetSecret()),
+		Url:           t.url(acct.GetLoginName()),
+		RecoveryCodes: codes,
+	}, nil
+}
+
+// ConfirmTotp confirms the TOTP secret enrolled for accountId with a code
+// generated from it. Once confirmed, a code from the secret is required to
+// authenticate as the account. The confirmed account is returned.
+//
+// Returns nil, error with code RecordNotFound if the account has no secret
+// and nil, error with code PasswordTotpInvalid if code does not match it.
+func (r *Repository) ConfirmTotp(ctx context.Context, scopeId, accountId, code string) (*Account, error) {
+	const op = "password.(Repository).ConfirmTotp"
+	if scopeId == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing scope id")
+	}
+	if accountId == "" {
+		return nil, errors.New(ctx, errors.InvalidPublicId, op, "missing account id")
+	}
+	if code == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing code")
+	}
+	t, err := r.lookupTotp(ctx, scopeId, accountId)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	if t == nil {
+		return nil, errors.New(ctx, errors.RecordNotFound, op, "no totp secret enrolled")
+	}
+	step, ok := t.verify(code, time.Now())
+	if !ok {
+		return nil, errors.New(ctx, errors.PasswordTotpInvalid, op, "invalid code", errors.WithoutEvent())
+	}
+	if err := r.useTotpStep(ctx, t, step); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	acct, err := r.LookupAccount(ctx, accountId)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	if acct == nil {
+		return nil, errors.New(ctx, errors.RecordNotFound, op, "account not found")
+	}
+	return acct, nil
+}
+
+// RemoveTotp removes the TOTP secret and recovery codes of accountId. The
+// account is returned.
+//
+// Returns nil, error with code RecordNotFound if the account doesn't exist.
+func (r *Repository) RemoveTotp(ctx context.Context, accountId string) (*Account, error) {
+	const op = "password.(Repository).RemoveTotp"
+	if accountId == "" {
+		return nil, errors.New(ctx, errors.InvalidPublicId, op, "missing account id")
+	}
+	acct, err := r.LookupAccount(ctx, accountId)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	if acct == nil {
+		return nil, errors.New(ctx, errors.RecordNotFound, op, "account not found")
+	}
+	if _, err := r.writer.Exec(ctx, deleteTotpQuery, []any{sql.Named("account_id", accountId)}); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	acct.TotpEnrolled = false
+	return acct, nil
+}
+
+// VerifyTotp verifies the second factor of accountId in authMethodId after
+// it authenticated with its password. Either code, generated from the
+// confirmed TOTP secret of the account, or one of its unused recovery codes
+// must be provided; a recovery code can only be used once.
+//
+// The account is returned if the second factor is valid and nil if it is
+// not. Each invalid attempt counts as a failed authentication attempt of the
+// account and a valid one clears them. Returns nil, error with code
+// PasswordAccountLocked if the account is locked.
+func (r *Repository) VerifyTotp(ctx context.Context, scopeId, authMethodId, accountId, code, recoveryCode string) (*Account, error) {
+	const op = "password.(Repository).VerifyTotp"
+	switch {
+	case scopeId == "":
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing scope id", errors.WithoutEvent())
+	case authMethodId == "":
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing auth method id", errors.WithoutEvent())
+	case accountId == "":
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing account id", errors.WithoutEvent())
+	case code == "" && recoveryCode == "":
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing code or recovery code", errors.WithoutEvent())
+	}
+	acct, err := r.LookupAccount(ctx, accountId)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	if acct == nil || acct.GetAuthMethodId() != authMethodId {
+		return nil, nil
+	}
+	lockout, err := r.lookupLockoutState(ctx, authMethodId, acct.GetLoginName())
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	if lockout != nil && lockout.Locked {
+		return nil, errors.New(ctx, errors.PasswordAccountLocked, op, "account is locked", errors.WithoutEvent())
+	}
+
+	var valid bool
+	switch {
+	case recoveryCode != "":
+		valid, err = r.useRecoveryCode(ctx, accountId, recoveryCode)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+	default:
+		t, err := r.lookupTotp(ctx, scopeId, accountId)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		if t != nil && t.GetConfirmed() {
+			if step, ok := t.verify(code, time.Now()); ok {
+				switch err := r.useTotpStep(ctx, t, step); {
+				case err == nil:
+					valid = true
+				case errors.Match(errors.T(errors.PasswordTotpInvalid), err):
+					// the code was used by a concurrent attempt
+				default:
+					return nil, errors.Wrap(ctx, err, op)
+				}
+			}
+		}
+	}
+
+	if !valid {
+		if lockout != nil && lockout.MaxFailedAttempts > 0 {
+			locked, err := r.recordFailedAttempt(ctx, lockout)
+			if err != nil {
+				return nil, errors.Wrap(ctx, err, op)
+			}
+			if locked {
+				auditLockout(ctx, authMethodId, accountId)
+			}
+		}
+		return nil, nil
+	}
+	if lockout != nil && (lockout.FailedAttemptCount > 0 || lockout.LockExpired) {
+		if err := r.resetLockout(ctx, accountId); err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+	}
+	acct.FailedAttemptCount = 0
+	acct.Locked = false
+	acct.LockedUntil = nil
+	return acct, nil
+}
+
+// lookupTotp returns the decrypted TOTP secret of accountId. Returns nil if
+// the account has no secret.
+func (r *Repository) lookupTotp(ctx context.Context, scopeId, accountId string) (*Totp, error) {
+	const op = "password.(Repository).lookupTotp"
+	t := allocTotp()
+	if err := r.reader.LookupWhere(ctx, t, "password_account_id = ?", []any{accountId}); err != nil {
+		if errors.IsNotFoundError(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	databaseWrapper, err := r.kms.GetWrapper(ctx, scopeId, kms.KeyPurposeDatabase, kms.WithKeyId(t.GetKeyId()))
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get database wrapper"))
+	}
+	if err := t.decrypt(ctx, databaseWrapper); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return t, nil
+}
+
+// totpEnrolled reports whether accountId has a confirmed TOTP secret.
+func (r *Repository) totpEnrolled(ctx context.Context, accountId string) (bool, error) {
+	const op = "password.(Repository).totpEnrolled"
+	t := allocTotp()
+	if err := r.reader.LookupWhere(ctx, t, "password_account_id = ?", []any{accountId}); err != nil {
+		if errors.IsNotFoundError(err) {
+			return false, nil
+		}
+		return false, errors.Wrap(ctx, err, op)
+	}
+	return t.GetConfirmed(), nil
+}
+
+// useTotpStep confirms t and records step as its last used time step.
+// Returns an error with code PasswordTotpInvalid if a code from step or a
+// later time step was already used.
+func (r *Repository) useTotpStep(ctx context.Context, t *Totp, step int64) error {
+	const op = "password.(Repository).useTotpStep"
+	rowsUpdated, err := r.writer.Exec(ctx, useTotpStepQuery, []any{
+		sql.Named("account_id", t.GetPasswordAccountId()),
+		sql.Named("step", step),
+	})
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	if rowsUpdated == 0 {
+		return errors.New(ctx, errors.PasswordTotpInvalid, op, "code already used", errors.WithoutEvent())
+	}
+	t.Confirmed = true
+	t.LastUsedStep = step
+	return nil
+}
+
+// useRecoveryCode deletes the recovery code of accountId matching code and
+// reports whether there was one.
+func (r *Repository) useRecoveryCode(ctx context.Context, accountId, code string) (bool, error) {
+	const op = "password.(Repository).useRecoveryCode"
+	rowsDeleted, err := r.writer.Exec(ctx, useRecoveryCodeQuery, []any{
+		sql.Named("account_id", accountId),
+		sql.Named("code_hash", hashRecoveryCode(code)),
+	})
+	if err != nil {
+		return false, errors.Wrap(ctx, err, op)
+	}
+	return rowsDeleted == 1, nil
+}
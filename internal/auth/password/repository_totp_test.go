@@ -0,0 +1,167 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package password
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/auth/password/store"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_Totp(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	assert, require := assert.New(t), require.New(t)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	o, _ := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	ctx := context.Background()
+	scopeId := o.GetPublicId()
+
+	repo, err := NewRepository(rw, rw, kmsCache)
+	require.NoError(err)
+	require.NotNil(repo)
+
+	authMethod := TestAuthMethods(t, conn, scopeId, 1)[0]
+	authMethodId := authMethod.GetPublicId()
+	loginName := "kazmierczak"
+	passwd := "12345678"
+	acct, err := repo.CreateAccount(ctx, scopeId, &Account{
+		Account: &store.Account{
+			AuthMethodId: authMethodId,
+			LoginName:    loginName,
+		},
+	}, WithPassword(passwd))
+	require.NoError(err)
+
+	enrollment, err := repo.EnrollTotp(ctx, scopeId, acct.GetPublicId(), "", "")
+	require.NoError(err)
+	require.NotNil(enrollment)
+	assert.NotEmpty(enrollment.Secret)
+	assert.Contains(enrollment.Url, "otpauth://totp/")
+	assert.Len(enrollment.RecoveryCodes, recoveryCodes)
+	secret, err := totpEncoding.DecodeString(enrollment.Secret)
+	require.NoError(err)
+	code := func() string {
+		return totpCode(secret, time.Now().Unix()/int64(totpPeriod.Seconds()))
+	}
+
+	// an unconfirmed secret is not required to authenticate
+	got, err := repo.Authenticate(ctx, scopeId, authMethodId, loginName, passwd)
+	require.NoError(err)
+	require.NotNil(got)
+	assert.False(got.GetTotpEnrolled())
+
+	_, err = repo.ConfirmTotp(ctx, scopeId, acct.GetPublicId(), "abcdef")
+	require.Error(err)
+	assert.True(errors.Match(errors.T(errors.PasswordTotpInvalid), err))
+
+	confirmed, err := repo.ConfirmTotp(ctx, scopeId, acct.GetPublicId(), code())
+	require.NoError(err)
+	assert.True(confirmed.GetTotpEnrolled())
+
+	got, err = repo.Authenticate(ctx, scopeId, authMethodId, loginName, passwd)
+	require.NoError(err)
+	require.NotNil(got)
+	assert.True(got.GetTotpEnrolled())
+
+	// the code used to confirm cannot be replayed
+	got, err = repo.VerifyTotp(ctx, scopeId, authMethodId, acct.GetPublicId(), code(), "")
+	require.NoError(err)
+	assert.Nil(got)
+
+	// a recovery code can only be used once
+	got, err = repo.VerifyTotp(ctx, scopeId, authMethodId, acct.GetPublicId(), "", enrollment.RecoveryCodes[0])
+	require.NoError(err)
+	assert.NotNil(got)
+	got, err = repo.VerifyTotp(ctx, scopeId, authMethodId, acct.GetPublicId(), "", enrollment.RecoveryCodes[0])
+	require.NoError(err)
+	assert.Nil(got)
+
+	// the second factor must be for an account of the auth method
+	other := TestAuthMethods(t, conn, scopeId, 1)[0]
+	got, err = repo.VerifyTotp(ctx, scopeId, other.GetPublicId(), acct.GetPublicId(), "", enrollment.RecoveryCodes[1])
+	require.NoError(err)
+	assert.Nil(got)
+
+	removed, err := repo.RemoveTotp(ctx, acct.GetPublicId())
+	require.NoError(err)
+	assert.False(removed.GetTotpEnrolled())
+	looked, err := repo.LookupAccount(ctx, acct.GetPublicId())
+	require.NoError(err)
+	assert.False(looked.GetTotpEnrolled())
+	got, err = repo.VerifyTotp(ctx, scopeId, authMethodId, acct.GetPublicId(), "", enrollment.RecoveryCodes[1])
+	require.NoError(err)
+	assert.Nil(got, "recovery codes are removed with the secret")
+
+	_, err = repo.EnrollTotp(ctx, scopeId, "acctpw_doesntexist", "", "")
+	require.Error(err)
+	assert.True(errors.Match(errors.T(errors.RecordNotFound), err))
+}
+
+func TestRepository_EnrollTotp_Replace(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	assert, require := assert.New(t), require.New(t)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	o, _ := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	ctx := context.Background()
+	scopeId := o.GetPublicId()
+
+	repo, err := NewRepository(rw, rw, kmsCache)
+	require.NoError(err)
+
+	authMethodId := TestAuthMethods(t, conn, scopeId, 1)[0].GetPublicId()
+	acct := TestAccount(t, conn, authMethodId, "kazmierczak")
+	acctId := acct.GetPublicId()
+	codeFor := func(e *TotpEnrollment) string {
+		secret, err := totpEncoding.DecodeString(e.Secret)
+		require.NoError(err)
+		return totpCode(secret, time.Now().Unix()/int64(totpPeriod.Seconds()))
+	}
+
+	// an unconfirmed secret can be replaced without a code
+	first, err := repo.EnrollTotp(ctx, scopeId, acctId, "", "")
+	require.NoError(err)
+	second, err := repo.EnrollTotp(ctx, scopeId, acctId, "", "")
+	require.NoError(err)
+	_, err = repo.ConfirmTotp(ctx, scopeId, acctId, codeFor(first))
+	require.Error(err, "the replaced secret cannot be confirmed")
+	_, err = repo.ConfirmTotp(ctx, scopeId, acctId, codeFor(second))
+	require.NoError(err)
+
+	// a confirmed secret is kept unless a code or recovery code is provided
+	_, err = repo.EnrollTotp(ctx, scopeId, acctId, "", "")
+	require.Error(err)
+	assert.True(errors.Match(errors.T(errors.PasswordTotpInvalid), err))
+	_, err = repo.EnrollTotp(ctx, scopeId, acctId, "abcdef", "")
+	require.Error(err)
+	assert.True(errors.Match(errors.T(errors.PasswordTotpInvalid), err))
+	_, err = repo.EnrollTotp(ctx, scopeId, acctId, "", first.RecoveryCodes[0])
+	require.Error(err, "recovery codes of a replaced secret are not valid")
+	assert.True(errors.Match(errors.T(errors.PasswordTotpInvalid), err))
+	looked, err := repo.LookupAccount(ctx, acctId)
+	require.NoError(err)
+	assert.True(looked.GetTotpEnrolled())
+
+	third, err := repo.EnrollTotp(ctx, scopeId, acctId, "", second.RecoveryCodes[0])
+	require.NoError(err)
+	assert.False(third.Account.GetTotpEnrolled())
+	_, err = repo.ConfirmTotp(ctx, scopeId, acctId, codeFor(third))
+	require.NoError(err)
+	_, err = repo.EnrollTotp(ctx, scopeId, acctId, "", second.RecoveryCodes[1])
+	require.Error(err, "the recovery codes were replaced")
+	assert.True(errors.Match(errors.T(errors.PasswordTotpInvalid), err))
+	_, err = repo.EnrollTotp(ctx, scopeId, acctId, "", third.RecoveryCodes[0])
+	require.NoError(err)
+}
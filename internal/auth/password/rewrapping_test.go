@@ -85,3 +85,127 @@ func TestRewrap_argon2ConfigRewrapFn(t *testing.T) {
 		assert.NotEqual(t, cred.GetCtSalt(), got.GetCtSalt())
 	})
 }
+
+func TestRewrap_totpRewrapFn(t *testing.T) {
+	ctx := context.Background()
+	t.Run("errors-on-query-error", func(t *testing.T) {
+		conn, mock := db.TestSetupWithMock(t)
+		wrapper := db.TestWrapper(t)
+		mock.ExpectQuery(
+			`SELECT \* FROM "kms_schema_version" WHERE 1=1 ORDER BY "kms_schema_version"\."version" LIMIT 1`,
+		).WillReturnRows(sqlmock.NewRows([]string{"version", "create_time"}).AddRow(migrations.Version, time.Now()))
+		kmsCache := kms.TestKms(t, conn, wrapper)
+		rw := db.New(conn)
+		mock.ExpectQuery(
+			`SELECT \* FROM "auth_password_totp" WHERE key_id=\$1`,
+		).WillReturnError(errors.New("Query error"))
+		err := totpRewrapFn(ctx, "some_id", "some_scope", rw, rw, kmsCache)
+		require.Error(t, err)
+	})
+	t.Run("success", func(t *testing.T) {
+		conn, _ := db.TestSetup(t, "postgres")
+
+		rw := db.New(conn)
+		wrapper := db.TestWrapper(t)
+
+		org, _ := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+		aut := TestAuthMethods(t, conn, org.GetPublicId(), 1)[0]
+		acct := TestAccount(t, conn, aut.PublicId, "name")
+
+		kmsCache := kms.TestKms(t, conn, wrapper)
+		wrapper, _ = kmsCache.GetWrapper(context.Background(), org.GetPublicId(), kms.KeyPurposeDatabase)
+
+		// actually store it
+		secret, err := newTotp(ctx, acct.PublicId)
+		require.NoError(t, err)
+		require.NoError(t, secret.encrypt(ctx, wrapper))
+		assert.NoError(t, rw.Create(ctx, secret))
+
+		// now things are stored in the db, we can rotate and rewrap
+		assert.NoError(t, kmsCache.RotateKeys(ctx, org.Scope.GetPublicId()))
+		assert.NoError(t, totpRewrapFn(ctx, secret.KeyId, org.Scope.GetPublicId(), rw, rw, kmsCache))
+
+		// now we pull the secret back from the db, decrypt it with the new key, and ensure things match
+		got := allocTotp()
+		assert.NoError(t, rw.LookupWhere(ctx, got, "password_account_id = ?", []any{acct.PublicId}))
+
+		// fetch the new key version
+		kmsWrapper, err := kmsCache.GetWrapper(ctx, org.Scope.GetPublicId(), kms.KeyPurposeDatabase, kms.WithKeyId(got.GetKeyId()))
+		assert.NoError(t, err)
+		newKeyVersion, err := kmsWrapper.KeyId(ctx)
+		assert.NoError(t, err)
+
+		// decrypt with the new key version and check to make sure things match
+		assert.NoError(t, got.decrypt(ctx, kmsWrapper))
+		assert.NotEmpty(t, got.GetKeyId())
+		assert.NotEqual(t, secret.GetKeyId(), got.GetKeyId())
+		assert.Equal(t, newKeyVersion, got.GetKeyId())
+		assert.Equal(t, secret.GetSecret(), got.GetSecret())
+		assert.NotEqual(t, secret.GetCtSecret(), got.GetCtSecret())
+	})
+}
+
+func TestRewrap_argon2HistoryRewrapFn(t *testing.T) {
+	ctx := context.Background()
+	t.Run("errors-on-query-error", func(t *testing.T) {
+		conn, mock := db.TestSetupWithMock(t)
+		wrapper := db.TestWrapper(t)
+		mock.ExpectQuery(
+			`SELECT \* FROM "kms_schema_version" WHERE 1=1 ORDER BY "kms_schema_version"\."version" LIMIT 1`,
+		).WillReturnRows(sqlmock.NewRows([]string{"version", "create_time"}).AddRow(migrations.Version, time.Now()))
+		kmsCache := kms.TestKms(t, conn, wrapper)
+		rw := db.New(conn)
+		mock.ExpectQuery(
+			`SELECT \* FROM "auth_password_argon2_history" WHERE key_id=\$1`,
+		).WillReturnError(errors.New("Query error"))
+		err := argon2HistoryRewrapFn(ctx, "some_id", "some_scope", rw, rw, kmsCache)
+		require.Error(t, err)
+	})
+	t.Run("success", func(t *testing.T) {
+		conn, _ := db.TestSetup(t, "postgres")
+
+		rw := db.New(conn)
+		wrapper := db.TestWrapper(t)
+
+		org, _ := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+		aut := TestAuthMethods(t, conn, org.GetPublicId(), 1)[0]
+		acct := TestAccount(t, conn, aut.PublicId, "name")
+
+		kmsCache := kms.TestKms(t, conn, wrapper)
+		wrapper, _ = kmsCache.GetWrapper(context.Background(), org.GetPublicId(), kms.KeyPurposeDatabase)
+
+		// actually store it
+		id, err := newArgon2CredentialId()
+		require.NoError(t, err)
+		previous := allocArgon2History()
+		previous.PrivateId = id
+		previous.PasswordAccountId = acct.PublicId
+		previous.PasswordConfId = aut.PasswordConfId
+		previous.Salt = []byte("salt")
+		previous.DerivedKey = []byte("derived key")
+		require.NoError(t, previous.encrypt(ctx, wrapper))
+		assert.NoError(t, rw.Create(ctx, previous))
+
+		// now things are stored in the db, we can rotate and rewrap
+		assert.NoError(t, kmsCache.RotateKeys(ctx, org.Scope.GetPublicId()))
+		assert.NoError(t, argon2HistoryRewrapFn(ctx, previous.KeyId, org.Scope.GetPublicId(), rw, rw, kmsCache))
+
+		// now we pull the previous password back from the db, decrypt it with the new key, and ensure things match
+		got := allocArgon2History()
+		assert.NoError(t, rw.LookupWhere(ctx, got, "private_id = ?", []any{previous.PrivateId}))
+
+		// fetch the new key version
+		kmsWrapper, err := kmsCache.GetWrapper(ctx, org.Scope.GetPublicId(), kms.KeyPurposeDatabase, kms.WithKeyId(got.GetKeyId()))
+		assert.NoError(t, err)
+		newKeyVersion, err := kmsWrapper.KeyId(ctx)
+		assert.NoError(t, err)
+
+		// decrypt with the new key version and check to make sure things match
+		assert.NoError(t, got.decrypt(ctx, kmsWrapper))
+		assert.NotEmpty(t, got.GetKeyId())
+		assert.NotEqual(t, previous.GetKeyId(), got.GetKeyId())
+		assert.Equal(t, newKeyVersion, got.GetKeyId())
+		assert.Equal(t, previous.GetSalt(), got.GetSalt())
+		assert.NotEqual(t, previous.GetCtSalt(), got.GetCtSalt())
+	})
+}
@@ -98,3 +98,24 @@ func TestAccount(t testing.TB, conn *db.DB, authMethodId, loginName string, opt
 	require.NoError(err2)
 	return cat
 }
+
+// TestManagedGroup creates a password managed group in the provided DB with
+// the provided auth method id and filter. Memberships are not calculated. If
+// any errors are encountered during the creation of the managed group, the
+// test will fail.
+func TestManagedGroup(t testing.TB, conn *db.DB, authMethodId, filter string, opt ...Option) *ManagedGroup {
+	t.Helper()
+	require := require.New(t)
+	rw := db.New(conn)
+	ctx := context.Background()
+
+	mg, err := NewManagedGroup(ctx, authMethodId, filter, opt...)
+	require.NoError(err)
+
+	id, err := newManagedGroupId(ctx)
+	require.NoError(err)
+	mg.PublicId = id
+
+	require.NoError(rw.Create(ctx, mg))
+	return mg
+}
@@ -0,0 +1,228 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package password
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/auth/password/store"
+	"github.com/hashicorp/boundary/internal/errors"
+	wrapping "github.com/hashicorp/go-kms-wrapping/v2"
+	"github.com/hashicorp/go-kms-wrapping/v2/extras/structwrapping"
+	"google.golang.org/protobuf/proto"
+)
+
+// The TOTP parameters follow RFC 6238 with the defaults understood by
+// authenticator apps: HMAC-SHA1, 30 second time steps and 6 digit codes.
+const (
+	totpIssuer        = "Boundary"
+	totpSecretLen     = 20
+	totpPeriod        = 30 * time.Second
+	totpDigits        = 6
+	totpSkewSteps     = 1
+	recoveryCodes     = 10
+	recoveryCodeBytes = 10
+)
+
+// totpEncoding is the base32 encoding used for TOTP secrets and recovery
+// codes.
+var totpEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// Totp is a time-based one-time password secret enrolled for an Account as
+// a second authentication factor.
+type Totp struct {
+	*store.Totp
+	tableName string
+}
+
+func allocTotp() *Totp {
+	return &Totp{
+		Totp: &store.Totp{},
+	}
+}
+
+func newTotp(ctx context.Context, accountId string) (*Totp, error) {
+	const op = "password.newTotp"
+	if accountId == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing accountId")
+	}
+	secret := make([]byte, totpSecretLen)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithCode(errors.Io))
+	}
+	return &Totp{
+		Totp: &store.Totp{
+			PasswordAccountId: accountId,
+			Secret:            secret,
+		},
+	}, nil
+}
+
+func (t *Totp) clone() *Totp {
+	cp := proto.Clone(t.Totp)
+	return &Totp{
+		Totp: cp.(*store.Totp),
+	}
+}
+
+// TableName returns the table name.
+func (t *Totp) TableName() string {
+	if t != nil && t.tableName != "" {
+		return t.tableName
+	}
+	return "auth_password_totp"
+}
+
+// SetTableName sets the table name.
+func (t *Totp) SetTableName(n string) {
+	t.tableName = n
+}
+
+func (t *Totp) encrypt(ctx context.Context, cipher wrapping.Wrapper) error {
+	const op = "password.(Totp).encrypt"
+	if err := structwrapping.WrapStruct(ctx, cipher, t.Totp, nil); err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithCode(errors.Encrypt))
+	}
+	keyId, err := cipher.KeyId(ctx)
+	if err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithCode(errors.Encrypt), errors.WithMsg("error reading cipher key id"))
+	}
+	t.KeyId = keyId
+	return nil
+}
+
+func (t *Totp) decrypt(ctx context.Context, cipher wrapping.Wrapper) error {
+	const op = "password.(Totp).decrypt"
+	if err := structwrapping.UnwrapStruct(ctx, cipher, t.Totp, nil); err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithCode(errors.Decrypt))
+	}
+	return nil
+}
+
+// url returns the otpauth URL of the secret which authenticator apps use to
+// enroll it.
+func (t *Totp) url(loginName string) string {
+	v := url.Values{}
+	v.Set("secret", totpEncoding.EncodeToString(t.GetSecret()))
+	v.Set("issuer", totpIssuer)
+	v.Set("algorithm", "SHA1")
+	v.Set("digits", fmt.Sprint(totpDigits))
+	v.Set("period", fmt.Sprint(int(totpPeriod.Seconds())))
+	u := url.URL{
+		Scheme:   "otpauth",
+		Host:     "totp",
+		Path:     "/" + totpIssuer + ":" + loginName,
+		RawQuery: v.Encode(),
+	}
+	return u.String()
+}
+
+// verify reports whether code was generated from the secret within
+// totpSkewSteps time steps of now, and returns its time step. Codes from
+// the last used time step or earlier are rejected so a code cannot be
+// replayed.
+func (t *Totp) verify(code string, now time.Time) (int64, bool) {
+	code = strings.TrimSpace(code)
+	if len(code) != totpDigits {
+		return 0, false
+	}
+	current := now.Unix() / int64(totpPeriod.Seconds())
+	for step := current - totpSkewSteps; step <= current+totpSkewSteps; step++ {
+		if step <= t.GetLastUsedStep() {
+			continue
+		}
+		if subtle.ConstantTimeCompare([]byte(totpCode(t.GetSecret(), step)), []byte(code)) == 1 {
+			return step, true
+		}
+	}
+	return 0, false
+}
+
+// totpCode returns the code for secret at time step as defined by RFC 4226
+// and RFC 6238.
+func totpCode(secret []byte, step int64) string {
+	var msg [8]byte
+	binary.BigEndian.PutUint64(msg[:], uint64(step))
+	mac := hmac.New(sha1.New, secret)
+	mac.Write(msg[:])
+	sum := mac.Sum(nil)
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	mod := uint32(1)
+	for i := 0; i < totpDigits; i++ {
+		mod *= 10
+	}
+	return fmt.Sprintf("%0*d", totpDigits, value%mod)
+}
+
+// RecoveryCode is the hash of a single use code an Account can use instead
+// of a code generated from its Totp secret.
+type RecoveryCode struct {
+	*store.RecoveryCode
+	tableName string
+}
+
+// newRecoveryCodes returns recoveryCodes new recovery codes for accountId
+// and their hashes to store.
+func newRecoveryCodes(ctx context.Context, accountId string) ([]string, []*RecoveryCode, error) {
+	const op = "password.newRecoveryCodes"
+	codes := make([]string, 0, recoveryCodes)
+	hashes := make([]*RecoveryCode, 0, recoveryCodes)
+	for i := 0; i < recoveryCodes; i++ {
+		b := make([]byte, recoveryCodeBytes)
+		if _, err := rand.Read(b); err != nil {
+			return nil, nil, errors.Wrap(ctx, err, op, errors.WithCode(errors.Io))
+		}
+		code := strings.ToLower(totpEncoding.EncodeToString(b))
+		codes = append(codes, code)
+		hashes = append(hashes, &RecoveryCode{
+			RecoveryCode: &store.RecoveryCode{
+				PasswordAccountId: accountId,
+				CodeHash:          hashRecoveryCode(code),
+			},
+		})
+	}
+	return codes, hashes, nil
+}
+
+// hashRecoveryCode returns the hash stored for code. Recovery codes are
+// random, so an unsalted hash is enough to protect them.
+func hashRecoveryCode(code string) []byte {
+	code = strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+	sum := sha256.Sum256([]byte(code))
+	return sum[:]
+}
+
+// TableName returns the table name.
+func (c *RecoveryCode) TableName() string {
+	if c != nil && c.tableName != "" {
+		return c.tableName
+	}
+	return "auth_password_recovery_code"
+}
+
+// SetTableName sets the table name.
+func (c *RecoveryCode) SetTableName(n string) {
+	c.tableName = n
+}
+
+// TotpEnrollment is a newly enrolled TOTP secret and the recovery codes of
+// an Account. The secret and codes are only available when enrolled.
+type TotpEnrollment struct {
+	Account       *Account
+	Secret        string
+	Url           string
+	RecoveryCodes []string
+}
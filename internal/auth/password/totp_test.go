@@ -0,0 +1,97 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package password
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/auth/password/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTotpCode(t *testing.T) {
+	// The SHA1 test vectors of RFC 6238 appendix B, truncated to 6 digits.
+	secret := []byte("12345678901234567890")
+	tests := []struct {
+		unix int64
+		want string
+	}{
+		{unix: 59, want: "287082"},
+		{unix: 1111111109, want: "081804"},
+		{unix: 1111111111, want: "050471"},
+		{unix: 1234567890, want: "005924"},
+		{unix: 2000000000, want: "279037"},
+		{unix: 20000000000, want: "353130"},
+	}
+	for _, tt := range tests {
+		step := tt.unix / int64(totpPeriod.Seconds())
+		assert.Equal(t, tt.want, totpCode(secret, step), "unix time %d", tt.unix)
+	}
+}
+
+func TestTotp_verify(t *testing.T) {
+	secret := []byte("12345678901234567890")
+	now := time.Unix(1111111109, 0)
+	current := now.Unix() / int64(totpPeriod.Seconds())
+	totp := &Totp{Totp: &store.Totp{Secret: secret}}
+
+	step, ok := totp.verify(totpCode(secret, current), now)
+	assert.True(t, ok)
+	assert.Equal(t, current, step)
+
+	// codes of the adjacent time steps are accepted for clock skew
+	_, ok = totp.verify(totpCode(secret, current-1), now)
+	assert.True(t, ok)
+	_, ok = totp.verify(totpCode(secret, current+1), now)
+	assert.True(t, ok)
+	_, ok = totp.verify(totpCode(secret, current-2), now)
+	assert.False(t, ok)
+
+	_, ok = totp.verify("", now)
+	assert.False(t, ok)
+	_, ok = totp.verify("12345", now)
+	assert.False(t, ok)
+
+	// a code from the last used time step cannot be replayed
+	totp.LastUsedStep = current
+	_, ok = totp.verify(totpCode(secret, current), now)
+	assert.False(t, ok)
+	_, ok = totp.verify(totpCode(secret, current+1), now)
+	assert.True(t, ok)
+}
+
+func TestTotp_url(t *testing.T) {
+	totp, err := newTotp(context.Background(), "acctpw_1234567890")
+	require.NoError(t, err)
+	assert.Len(t, totp.GetSecret(), totpSecretLen)
+
+	u, err := url.Parse(totp.url("alice"))
+	require.NoError(t, err)
+	assert.Equal(t, "otpauth", u.Scheme)
+	assert.Equal(t, "totp", u.Host)
+	assert.Equal(t, "/Boundary:alice", u.Path)
+	assert.Equal(t, totpEncoding.EncodeToString(totp.GetSecret()), u.Query().Get("secret"))
+	assert.Equal(t, "Boundary", u.Query().Get("issuer"))
+}
+
+func TestNewRecoveryCodes(t *testing.T) {
+	codes, hashes, err := newRecoveryCodes(context.Background(), "acctpw_1234567890")
+	require.NoError(t, err)
+	require.Len(t, codes, recoveryCodes)
+	require.Len(t, hashes, recoveryCodes)
+	seen := make(map[string]bool)
+	for i, code := range codes {
+		assert.Len(t, code, 16)
+		assert.False(t, seen[code], "duplicate recovery code")
+		seen[code] = true
+		assert.Equal(t, "acctpw_1234567890", hashes[i].GetPasswordAccountId())
+		assert.Equal(t, hashRecoveryCode(code), hashes[i].GetCodeHash())
+	}
+	// recovery codes are matched case insensitively and without separators
+	assert.Equal(t, hashRecoveryCode("abcdefgh"), hashRecoveryCode("ABCD-EFGH"))
+}
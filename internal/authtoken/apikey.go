@@ -0,0 +1,188 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package authtoken
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/authtoken/store"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/db/timestamp"
+	"github.com/hashicorp/boundary/internal/errors"
+	wrapping "github.com/hashicorp/go-kms-wrapping/v2"
+	"github.com/hashicorp/go-kms-wrapping/v2/extras/structwrapping"
+	"github.com/hashicorp/go-secure-stdlib/base62"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// ApiKeyPrefix is the prefix of api key ids. An api key is presented the
+	// same way as an auth token: the id followed by the encrypted key value.
+	ApiKeyPrefix = "ak"
+
+	// defaultApiKeyTableName is the table where api keys are stored.
+	defaultApiKeyTableName = "auth_api_key"
+)
+
+// An ApiKey is a long-lived credential of a service account. Like an auth
+// token it is validated by the repository, but it is not tied to an auth
+// account and carries its own expiration and optional cidr restrictions.
+type ApiKey struct {
+	*store.ApiKey
+	tableName string `gorm:"-"`
+}
+
+// NewApiKey creates a new in-memory api key for the service account. The
+// WithName, WithDescription, WithExpirationTime and WithAllowedCidrs options
+// are supported and all other options are ignored.
+func NewApiKey(ctx context.Context, serviceAccountId string, opt ...Option) (*ApiKey, error) {
+	const op = "authtoken.NewApiKey"
+	if serviceAccountId == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing service account id")
+	}
+	opts := getOpts(opt...)
+	k := &ApiKey{
+		ApiKey: &store.ApiKey{
+			ServiceAccountId: serviceAccountId,
+			Name:             opts.withName,
+			Description:      opts.withDescription,
+		},
+	}
+	if !opts.withExpirationTime.IsZero() {
+		k.ExpirationTime = timestamp.New(opts.withExpirationTime.Truncate(time.Second))
+	}
+	if err := k.setAllowedCidrs(ctx, opts.withAllowedCidrs); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return k, nil
+}
+
+func allocApiKey() *ApiKey {
+	return &ApiKey{
+		ApiKey: &store.ApiKey{},
+	}
+}
+
+func (k *ApiKey) clone() *ApiKey {
+	cp := proto.Clone(k.ApiKey)
+	return &ApiKey{
+		ApiKey: cp.(*store.ApiKey),
+	}
+}
+
+// GetAllowedCidrList returns the cidr blocks requests using the api key must
+// come from.
+func (k *ApiKey) GetAllowedCidrList() []string {
+	if k.GetAllowedCidrs() == "" {
+		return nil
+	}
+	return strings.Split(k.GetAllowedCidrs(), "\n")
+}
+
+// setAllowedCidrs validates and sets the cidr blocks of the api key.
+func (k *ApiKey) setAllowedCidrs(ctx context.Context, cidrs []string) error {
+	const op = "authtoken.(ApiKey).setAllowedCidrs"
+	for _, c := range cidrs {
+		if _, _, err := net.ParseCIDR(c); err != nil {
+			return errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("%q is not a valid cidr block", c))
+		}
+	}
+	k.AllowedCidrs = strings.Join(cidrs, "\n")
+	return nil
+}
+
+// allowsAddress reports whether a request from the client ip may use the api
+// key. An api key without cidr blocks allows every address.
+func (k *ApiKey) allowsAddress(clientIp string) bool {
+	cidrs := k.GetAllowedCidrList()
+	if len(cidrs) == 0 {
+		return true
+	}
+	ip := net.ParseIP(clientIp)
+	if ip == nil {
+		return false
+	}
+	for _, c := range cidrs {
+		_, n, err := net.ParseCIDR(c)
+		if err != nil {
+			continue
+		}
+		if n.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// expired reports whether the api key has an expiration time which is before
+// now.
+func (k *ApiKey) expired(now time.Time) bool {
+	if k.GetExpirationTime().GetTimestamp() == nil {
+		return false
+	}
+	return now.After(k.GetExpirationTime().AsTime().Add(-timeSkew))
+}
+
+// newApiKeyValue generates a new api key value.
+func newApiKeyValue(ctx context.Context) (string, error) {
+	const op = "authtoken.newApiKeyValue"
+	token, err := base62.Random(tokenLength)
+	if err != nil {
+		return "", errors.Wrap(ctx, err, op, errors.WithCode(errors.Io))
+	}
+	return fmt.Sprintf("%s%s", TokenValueVersionPrefix, token), nil
+}
+
+// NewApiKeyId creates a new id for an api key.
+func NewApiKeyId(ctx context.Context) (string, error) {
+	const op = "authtoken.NewApiKeyId"
+	id, err := db.NewPublicId(ApiKeyPrefix)
+	if err != nil {
+		return "", errors.Wrap(ctx, err, op)
+	}
+	return id, nil
+}
+
+// encrypt the api key value using the provided cipher (wrapping.Wrapper)
+func (k *ApiKey) encrypt(ctx context.Context, cipher wrapping.Wrapper) error {
+	const op = "authtoken.(ApiKey).encrypt"
+	// structwrapping doesn't support embedding, so we'll pass in the store.ApiKey directly
+	if err := structwrapping.WrapStruct(ctx, cipher, k.ApiKey, nil); err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithCode(errors.Encrypt))
+	}
+	keyId, err := cipher.KeyId(ctx)
+	if err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithCode(errors.Encrypt), errors.WithMsg("unable to get cipher key id"))
+	}
+	k.KeyId = keyId
+	return nil
+}
+
+// decrypt the api key value using the provided cipher (wrapping.Wrapper)
+func (k *ApiKey) decrypt(ctx context.Context, cipher wrapping.Wrapper) error {
+	const op = "authtoken.(ApiKey).decrypt"
+	// structwrapping doesn't support embedding, so we'll pass in the store.ApiKey directly
+	if err := structwrapping.UnwrapStruct(ctx, cipher, k.ApiKey, nil); err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithCode(errors.Decrypt))
+	}
+	return nil
+}
+
+// TableName returns the table name for the api key.
+func (k *ApiKey) TableName() string {
+	if k.tableName != "" {
+		return k.tableName
+	}
+	return defaultApiKeyTableName
+}
+
+// SetTableName sets the table name. If the caller attempts to set the name to
+// "" the name will be reset to the default name.
+func (k *ApiKey) SetTableName(n string) {
+	k.tableName = n
+}
@@ -0,0 +1,109 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package authtoken
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db/timestamp"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewApiKey(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	exp := time.Now().Add(time.Hour)
+	tests := []struct {
+		name             string
+		serviceAccountId string
+		opts             []Option
+		wantCidrs        []string
+		wantIsErr        errors.Code
+	}{
+		{
+			name:      "missing-service-account",
+			wantIsErr: errors.InvalidParameter,
+		},
+		{
+			name:             "valid",
+			serviceAccountId: "sa_1234567890",
+			opts:             []Option{WithName("name"), WithDescription("desc"), WithExpirationTime(exp)},
+		},
+		{
+			name:             "valid-cidrs",
+			serviceAccountId: "sa_1234567890",
+			opts:             []Option{WithAllowedCidrs([]string{"10.0.0.0/8", "2001:db8::/32"})},
+			wantCidrs:        []string{"10.0.0.0/8", "2001:db8::/32"},
+		},
+		{
+			name:             "invalid-cidr",
+			serviceAccountId: "sa_1234567890",
+			opts:             []Option{WithAllowedCidrs([]string{"10.0.0.1"})},
+			wantIsErr:        errors.InvalidParameter,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, err := NewApiKey(ctx, tt.serviceAccountId, tt.opts...)
+			if tt.wantIsErr != 0 {
+				assert.Truef(errors.Match(errors.T(tt.wantIsErr), err), "Unexpected error %s", err)
+				assert.Nil(got)
+				return
+			}
+			require.NoError(err)
+			assert.Equal(tt.serviceAccountId, got.GetServiceAccountId())
+			assert.Equal(tt.wantCidrs, got.GetAllowedCidrList())
+		})
+	}
+}
+
+func TestApiKey_allowsAddress(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	open, err := NewApiKey(ctx, "sa_1234567890")
+	require.NoError(t, err)
+	restricted, err := NewApiKey(ctx, "sa_1234567890", WithAllowedCidrs([]string{"10.0.0.0/8", "2001:db8::/32"}))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		key      *ApiKey
+		clientIp string
+		want     bool
+	}{
+		{name: "no-cidrs", key: open, clientIp: "192.168.1.1", want: true},
+		{name: "no-cidrs-no-ip", key: open, want: true},
+		{name: "in-v4-cidr", key: restricted, clientIp: "10.1.2.3", want: true},
+		{name: "in-v6-cidr", key: restricted, clientIp: "2001:db8::1", want: true},
+		{name: "outside-cidrs", key: restricted, clientIp: "192.168.1.1", want: false},
+		{name: "invalid-ip", key: restricted, clientIp: "not-an-ip", want: false},
+		{name: "missing-ip", key: restricted, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.key.allowsAddress(tt.clientIp))
+		})
+	}
+}
+
+func TestApiKey_expired(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	now := time.Now()
+
+	k, err := NewApiKey(ctx, "sa_1234567890")
+	require.NoError(t, err)
+	assert.False(t, k.expired(now), "api key without expiration time expired")
+
+	k.ExpirationTime = timestamp.New(now.Add(time.Hour))
+	assert.False(t, k.expired(now))
+
+	k.ExpirationTime = timestamp.New(now.Add(-time.Hour))
+	assert.True(t, k.expired(now))
+}
@@ -12,8 +12,9 @@ import (
 )
 
 var (
-	defaultTokenTimeToLiveDuration  = 7 * 24 * time.Hour
-	defaultTokenTimeToStaleDuration = 24 * time.Hour
+	defaultTokenTimeToLiveDuration    = 7 * 24 * time.Hour
+	defaultTokenTimeToStaleDuration   = 24 * time.Hour
+	defaultTokenMaxTimeToLiveDuration = 30 * 24 * time.Hour
 )
 
 // getOpts - iterate the inbound Options and return a struct
@@ -30,21 +31,32 @@ type Option func(*options)
 
 // options = how options are represented
 type options struct {
-	withTokenValue               bool
-	withTokenTimeToLiveDuration  time.Duration
-	withTokenTimeToStaleDuration time.Duration
-	withLimit                    int
-	withStatus                   Status
-	withPublicId                 string
-	withPasswordOptions          []password.Option
-	withIamOptions               []iam.Option
+	withTokenValue                 bool
+	withTokenTimeToLiveDuration    time.Duration
+	withTokenTimeToStaleDuration   time.Duration
+	withTokenMaxTimeToLiveDuration time.Duration
+	withLimit                      int
+	withStatus                     Status
+	withPublicId                   string
+	withPasswordOptions            []password.Option
+	withIamOptions                 []iam.Option
+	withPage                       *db.Page
+	withName                       string
+	withDescription                string
+	withExpirationTime             time.Time
+	withAllowedCidrs               []string
+	withIamUserId                  string
+	withAuthAccountId              string
+	withLastAccessedBefore         time.Time
+	withLastAccessedAfter          time.Time
 }
 
 func getDefaultOptions() options {
 	return options{
-		withLimit:                    db.DefaultLimit,
-		withTokenTimeToLiveDuration:  defaultTokenTimeToLiveDuration,
-		withTokenTimeToStaleDuration: defaultTokenTimeToStaleDuration,
+		withLimit:                      db.DefaultLimit,
+		withTokenTimeToLiveDuration:    defaultTokenTimeToLiveDuration,
+		withTokenTimeToStaleDuration:   defaultTokenTimeToStaleDuration,
+		withTokenMaxTimeToLiveDuration: defaultTokenMaxTimeToLiveDuration,
 	}
 }
 
@@ -74,6 +86,25 @@ func WithTokenTimeToStaleDuration(dur time.Duration) Option {
 	}
 }
 
+// WithTokenMaxTimeToLiveDuration allows setting the maximum lifetime of an
+// auth token, measured from its creation. Refreshing an auth token never
+// extends its expiration time past it.
+func WithTokenMaxTimeToLiveDuration(dur time.Duration) Option {
+	return func(o *options) {
+		if dur > 0 {
+			o.withTokenMaxTimeToLiveDuration = dur
+		}
+	}
+}
+
+// WithPage provides an option to list one page of auth tokens. Use WithLimit
+// to set the size of the page.
+func WithPage(p *db.Page) Option {
+	return func(o *options) {
+		o.withPage = p
+	}
+}
+
 // WithLimit provides an option to provide a limit.  Intentionally allowing
 // negative integers.   If WithLimit < 0, then unlimited results are returned.
 // If WithLimit == 0, then default limits are used for results.
@@ -116,3 +147,64 @@ func WithIamOptions(with ...iam.Option) Option {
 		o.withIamOptions = with
 	}
 }
+
+// WithName provides an optional name for an api key.
+func WithName(name string) Option {
+	return func(o *options) {
+		o.withName = name
+	}
+}
+
+// WithDescription provides an optional description for an api key.
+func WithDescription(desc string) Option {
+	return func(o *options) {
+		o.withDescription = desc
+	}
+}
+
+// WithExpirationTime provides the time an api key expires. A zero time means
+// the api key does not expire.
+func WithExpirationTime(t time.Time) Option {
+	return func(o *options) {
+		o.withExpirationTime = t
+	}
+}
+
+// WithAllowedCidrs provides the cidr blocks requests using an api key must
+// come from.
+func WithAllowedCidrs(cidrs []string) Option {
+	return func(o *options) {
+		o.withAllowedCidrs = cidrs
+	}
+}
+
+// WithIamUserId limits the listed auth tokens to the ones of the given user.
+func WithIamUserId(id string) Option {
+	return func(o *options) {
+		o.withIamUserId = id
+	}
+}
+
+// WithAuthAccountId limits the listed auth tokens to the ones of the given
+// account.
+func WithAuthAccountId(id string) Option {
+	return func(o *options) {
+		o.withAuthAccountId = id
+	}
+}
+
+// WithLastAccessedBefore limits the listed auth tokens to the ones last used
+// before the given time.
+func WithLastAccessedBefore(t time.Time) Option {
+	return func(o *options) {
+		o.withLastAccessedBefore = t
+	}
+}
+
+// WithLastAccessedAfter limits the listed auth tokens to the ones last used
+// after the given time.
+func WithLastAccessedAfter(t time.Time) Option {
+	return func(o *options) {
+		o.withLastAccessedAfter = t
+	}
+}
@@ -40,6 +40,17 @@ func Test_GetOpts(t *testing.T) {
 		assert.Equal(opts, testOpts)
 	})
 
+	t.Run("WithTokenMaxTimeToLiveDuration", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithTokenMaxTimeToLiveDuration(48 * time.Hour))
+		testOpts := getDefaultOptions()
+		testOpts.withTokenMaxTimeToLiveDuration = 48 * time.Hour
+		assert.Equal(opts, testOpts)
+
+		opts = getOpts(WithTokenMaxTimeToLiveDuration(0))
+		assert.Equal(opts, getDefaultOptions())
+	})
+
 	t.Run("withStatus", func(t *testing.T) {
 		assert := assert.New(t)
 		opts := getOpts(WithStatus(IssuedStatus))
@@ -71,4 +82,71 @@ func Test_GetOpts(t *testing.T) {
 		opts = getOpts(WithIamOptions(iam.WithName("foobar")))
 		assert.NotEmpty(opts.withIamOptions)
 	})
+
+	t.Run("WithName", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithName("test-name"))
+		testOpts := getDefaultOptions()
+		testOpts.withName = "test-name"
+		assert.Equal(opts, testOpts)
+	})
+
+	t.Run("WithDescription", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithDescription("test-desc"))
+		testOpts := getDefaultOptions()
+		testOpts.withDescription = "test-desc"
+		assert.Equal(opts, testOpts)
+	})
+
+	t.Run("WithExpirationTime", func(t *testing.T) {
+		assert := assert.New(t)
+		exp := time.Now().Add(time.Hour)
+		opts := getOpts(WithExpirationTime(exp))
+		testOpts := getDefaultOptions()
+		testOpts.withExpirationTime = exp
+		assert.Equal(opts, testOpts)
+	})
+
+	t.Run("WithAllowedCidrs", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithAllowedCidrs([]string{"10.0.0.0/8"}))
+		testOpts := getDefaultOptions()
+		testOpts.withAllowedCidrs = []string{"10.0.0.0/8"}
+		assert.Equal(opts, testOpts)
+	})
+
+	t.Run("WithIamUserId", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithIamUserId("u_1234567890"))
+		testOpts := getDefaultOptions()
+		testOpts.withIamUserId = "u_1234567890"
+		assert.Equal(opts, testOpts)
+	})
+
+	t.Run("WithAuthAccountId", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithAuthAccountId("acctpw_1234567890"))
+		testOpts := getDefaultOptions()
+		testOpts.withAuthAccountId = "acctpw_1234567890"
+		assert.Equal(opts, testOpts)
+	})
+
+	t.Run("WithLastAccessedBefore", func(t *testing.T) {
+		assert := assert.New(t)
+		before := time.Now().Add(-time.Hour)
+		opts := getOpts(WithLastAccessedBefore(before))
+		testOpts := getDefaultOptions()
+		testOpts.withLastAccessedBefore = before
+		assert.Equal(opts, testOpts)
+	})
+
+	t.Run("WithLastAccessedAfter", func(t *testing.T) {
+		assert := assert.New(t)
+		after := time.Now().Add(-time.Hour)
+		opts := getOpts(WithLastAccessedAfter(after))
+		testOpts := getDefaultOptions()
+		testOpts.withLastAccessedAfter = after
+		assert.Equal(opts, testOpts)
+	})
 }
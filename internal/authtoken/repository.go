@@ -6,6 +6,7 @@ package authtoken
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/golang/protobuf/ptypes"
@@ -14,6 +15,7 @@ import (
 	"github.com/hashicorp/boundary/internal/errors"
 	"github.com/hashicorp/boundary/internal/iam"
 	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/pagination"
 )
 
 var (
@@ -30,6 +32,7 @@ type Repository struct {
 	limit               int
 	timeToLiveDuration  time.Duration
 	timeToStaleDuration time.Duration
+	maxTimeToLive       time.Duration
 }
 
 // NewRepository creates a new Repository. The returned repository is not safe for concurrent go
@@ -54,6 +57,7 @@ func NewRepository(r db.Reader, w db.Writer, kms *kms.Kms, opt ...Option) (*Repo
 		limit:               opts.withLimit,
 		timeToLiveDuration:  opts.withTokenTimeToLiveDuration,
 		timeToStaleDuration: opts.withTokenTimeToStaleDuration,
+		maxTimeToLive:       opts.withTokenMaxTimeToLiveDuration,
 	}, nil
 }
 
@@ -91,7 +95,7 @@ func (r *Repository) CreateAuthToken(ctx context.Context, withIamUser *iam.User,
 
 	switch {
 	case opts.withStatus != "":
-		at.Status = string(PendingStatus)
+		at.Status = string(opts.withStatus)
 	default:
 		at.Status = string(IssuedStatus)
 	}
@@ -189,7 +193,8 @@ func (r *Repository) LookupAuthToken(ctx context.Context, id string, opt ...Opti
 // approximate last accessed time may be updated depending on how long it has been since the last time the token
 // was validated.  If a token is returned it is guaranteed to be valid. For security reasons, the actual token
 // value is not included in the returned AuthToken. If no valid auth token is found nil, nil is returned.
-// All options are ignored.
+// A token with TotpEnrollmentStatus is returned as well; callers must check its status and only allow
+// enrolling a second factor with it. All options are ignored.
 //
 // NOTE: Do not log or add the token string to any errors to avoid leaking it as it is a secret.
 func (r *Repository) ValidateToken(ctx context.Context, id, token string, opt ...Option) (*AuthToken, error) {
@@ -212,6 +217,15 @@ func (r *Repository) ValidateToken(ctx context.Context, id, token string, opt ..
 	if retAT == nil {
 		return nil, nil
 	}
+	switch retAT.GetStatus() {
+	case string(IssuedStatus), string(TotpEnrollmentStatus):
+		// a token waiting for a second factor to be enrolled is valid, and
+		// callers restrict what it allows
+	default:
+		// a pending token, such as one waiting for a second factor, is not
+		// valid until it is issued
+		return nil, nil
+	}
 
 	// If the token is too old or stale invalidate it and return nothing.
 	exp, err := ptypes.Timestamp(retAT.GetExpirationTime().GetTimestamp())
@@ -291,7 +305,8 @@ func (r *Repository) ValidateToken(ctx context.Context, id, token string, opt ..
 }
 
 // ListAuthTokens lists auth tokens in the given scopes and supports the
-// WithLimit option.
+// WithLimit, WithPage, WithIamUserId, WithAuthAccountId,
+// WithLastAccessedBefore and WithLastAccessedAfter options.
 func (r *Repository) ListAuthTokens(ctx context.Context, withScopeIds []string, opt ...Option) ([]*AuthToken, error) {
 	const op = "authtoken.(Repository).ListAuthTokens"
 	if len(withScopeIds) == 0 {
@@ -299,10 +314,29 @@ func (r *Repository) ListAuthTokens(ctx context.Context, withScopeIds []string,
 	}
 	opts := getOpts(opt...)
 
+	where := []string{"auth_account_id in (select public_id from auth_account where scope_id in (?))"}
+	args := []any{withScopeIds}
+	if opts.withIamUserId != "" {
+		where = append(where, "iam_user_id = ?")
+		args = append(args, opts.withIamUserId)
+	}
+	if opts.withAuthAccountId != "" {
+		where = append(where, "auth_account_id = ?")
+		args = append(args, opts.withAuthAccountId)
+	}
+	if !opts.withLastAccessedBefore.IsZero() {
+		where = append(where, "approximate_last_access_time < ?")
+		args = append(args, opts.withLastAccessedBefore)
+	}
+	if !opts.withLastAccessedAfter.IsZero() {
+		where = append(where, "approximate_last_access_time > ?")
+		args = append(args, opts.withLastAccessedAfter)
+	}
+
 	// use the view, to bring in the required account columns. Just don't forget
 	// to convert them before returning them
 	var atvs []*authTokenView
-	if err := r.reader.SearchWhere(ctx, &atvs, "auth_account_id in (select public_id from auth_account where scope_id in (?))", []any{withScopeIds}, db.WithLimit(opts.withLimit)); err != nil {
+	if err := r.reader.SearchWhere(ctx, &atvs, strings.Join(where, " and "), args, db.WithLimit(opts.withLimit), db.WithPage(opts.withPage)); err != nil {
 		return nil, errors.Wrap(ctx, err, op)
 	}
 	authTokens := make([]*AuthToken, 0, len(atvs))
@@ -315,6 +349,17 @@ func (r *Repository) ListAuthTokens(ctx context.Context, withScopeIds []string,
 	return authTokens, nil
 }
 
+// ListDeletedIds returns the auth tokens in one of scopeIds deleted after
+// since.
+func (r *Repository) ListDeletedIds(ctx context.Context, scopeIds []string, since time.Time) ([]*pagination.DeletedResource, error) {
+	const op = "authtoken.(Repository).ListDeletedIds"
+	deleted, err := pagination.ListDeletedIds(ctx, r.reader, "auth_token", scopeIds, since)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return deleted, nil
+}
+
 // DeleteAuthToken deletes the token with the provided id from the repository returning a count of the
 // number of records deleted.  All options are ignored.
 func (r *Repository) DeleteAuthToken(ctx context.Context, id string, opt ...Option) (int, error) {
@@ -360,6 +405,125 @@ func (r *Repository) DeleteAuthToken(ctx context.Context, id string, opt ...Opti
 	return rowsDeleted, nil
 }
 
+// RefreshAuthToken extends the expiration time of the issued auth token with
+// the provided id to the token time-to-live from now, but never past the
+// maximum time-to-live from the creation of the token. The approximate last
+// accessed time of the token is updated as well. Expired and stale tokens
+// cannot be refreshed. For security reasons, the actual token value is not
+// included in the returned AuthToken. All options are ignored.
+//
+// Note: no oplog entries are created for auth token operations (this is intentional).
+func (r *Repository) RefreshAuthToken(ctx context.Context, id string, opt ...Option) (*AuthToken, error) {
+	const op = "authtoken.(Repository).RefreshAuthToken"
+	if id == "" {
+		return nil, errors.New(ctx, errors.InvalidPublicId, op, "missing public id")
+	}
+
+	at, err := r.LookupAuthToken(ctx, id)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	if at == nil {
+		return nil, errors.New(ctx, errors.RecordNotFound, op, fmt.Sprintf("auth token %q not found", id))
+	}
+	if at.GetStatus() != string(IssuedStatus) {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "auth token has not been issued")
+	}
+
+	now := time.Now()
+	exp := at.GetExpirationTime().AsTime()
+	lastAccessed := at.GetApproximateLastAccessTime().AsTime()
+	if now.After(exp.Add(-timeSkew)) || now.Sub(lastAccessed)+timeSkew >= r.timeToStaleDuration {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "auth token is expired or stale")
+	}
+
+	// We truncate the expiration time to the nearest second like
+	// CreateAuthToken does.
+	newExp := now.Add(r.timeToLiveDuration).Truncate(time.Second)
+	if maxExp := at.GetCreateTime().AsTime().Add(r.maxTimeToLive); newExp.After(maxExp) {
+		newExp = maxExp
+	}
+	fieldMask := []string{"ExpirationTime"}
+	if !newExp.After(exp) {
+		// refreshing never shortens the lifetime of a token
+		fieldMask = nil
+	}
+
+	_, err = r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(reader db.Reader, w db.Writer) error {
+			updateAt := allocAuthToken()
+			updateAt.PublicId = id
+			updateAt.ExpirationTime = timestamp.New(newExp)
+			// Setting the ApproximateLastAccessTime to null through using the null mask allows a defined db's
+			// trigger to set ApproximateLastAccessTime to the commit timestamp.
+			// Tokens are not replicated, so they don't need oplog entries.
+			rowsUpdated, err := w.Update(ctx, updateAt, fieldMask, []string{"ApproximateLastAccessTime"}, db.WithWhere("status = ?", IssuedStatus))
+			if err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			if rowsUpdated == 0 {
+				return errors.New(ctx, errors.RecordNotFound, op, "issued auth token not found")
+			}
+			if rowsUpdated > 1 {
+				return errors.New(ctx, errors.MultipleRecords, op, "more than 1 resource would have been updated")
+			}
+
+			// we need a new repo, that's using the same reader/writer as this TxHandler
+			txRepo := Repository{
+				reader: reader,
+				writer: w,
+				kms:    r.kms,
+			}
+			at, err = txRepo.LookupAuthToken(ctx, id)
+			if err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			if at == nil {
+				return errors.New(ctx, errors.RecordNotFound, op, "refreshed auth token not found")
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, err // error already wrapped when raised from r.DoTx(...)
+	}
+	return at, nil
+}
+
+// DeleteAuthTokensForUser deletes all the auth tokens of the user with the
+// provided id, returning a count of the number of tokens deleted. All options
+// are ignored.
+func (r *Repository) DeleteAuthTokensForUser(ctx context.Context, userId string, opt ...Option) (int, error) {
+	const op = "authtoken.(Repository).DeleteAuthTokensForUser"
+	if userId == "" {
+		return db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing user id")
+	}
+
+	const sql = `delete from auth_token where auth_account_id in (select public_id from auth_account where iam_user_id = ?)`
+	var rowsDeleted int
+	_, err := r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) error {
+			var err error
+			// tokens are not replicated, so they don't need oplog entries.
+			rowsDeleted, err = w.Exec(ctx, sql, []any{userId})
+			if err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return db.NoRowsAffected, errors.Wrap(ctx, err, op, errors.WithMsg(userId))
+	}
+	return rowsDeleted, nil
+}
+
 // IssueAuthToken will retrieve the "pending" token and update it's status to
 // "issued".  If the token has already been issued, an error is returned with a
 // nil token.  If no token is found for the tokenRequestId an error is returned
@@ -419,6 +583,65 @@ func (r *Repository) IssueAuthToken(ctx context.Context, tokenRequestId string)
 	return at, nil
 }
 
+// IssueTotpEnrollmentToken issues the auth token tokenId if it has
+// TotpEnrollmentStatus and is for accountId, once the account confirmed its
+// TOTP second factor. Any other tokens of the account with
+// TotpEnrollmentStatus are deleted, so only the token used to enroll the
+// second factor is issued without it. The issued token is returned, or nil
+// if tokenId is not such a token.
+//
+// Note: no oplog entries are created for auth token operations (this is intentional).
+func (r *Repository) IssueTotpEnrollmentToken(ctx context.Context, accountId, tokenId string) (*AuthToken, error) {
+	const op = "authtoken.(Repository).IssueTotpEnrollmentToken"
+	if accountId == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing account id")
+	}
+
+	var at *AuthToken
+	_, err := r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(reader db.Reader, w db.Writer) error {
+			at = nil
+			if tokenId != "" {
+				updateAt := allocAuthToken()
+				updateAt.PublicId = tokenId
+				updateAt.Status = string(IssuedStatus)
+				rowsUpdated, err := w.Update(ctx, updateAt, []string{"Status"}, []string{"ApproximateLastAccessTime"},
+					db.WithWhere("status = ? and auth_account_id = ?", TotpEnrollmentStatus, accountId))
+				if err != nil {
+					return errors.Wrap(ctx, err, op)
+				}
+				if rowsUpdated > 1 {
+					return errors.New(ctx, errors.MultipleRecords, op, "more than 1 resource would have been updated")
+				}
+				if rowsUpdated == 1 {
+					// we need a new repo, that's using the same reader/writer as this TxHandler
+					txRepo := Repository{
+						reader: reader,
+						writer: w,
+						kms:    r.kms,
+					}
+					var err error
+					at, err = txRepo.LookupAuthToken(ctx, tokenId)
+					if err != nil {
+						return errors.Wrap(ctx, err, op)
+					}
+				}
+			}
+			const sql = `delete from auth_token where status = ? and auth_account_id = ?`
+			if _, err := w.Exec(ctx, sql, []any{string(TotpEnrollmentStatus), accountId}); err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			return nil
+		})
+	if err != nil {
+		return nil, err // error already wrapped when raised from r.DoTx(...)
+	}
+	return at, nil
+}
+
 // CloseExpiredPendingTokens will close expired pending tokens in the repo.
 // This function should called on a periodic basis a Controllers via it's
 // "ticker" pattern.
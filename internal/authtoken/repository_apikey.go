@@ -0,0 +1,391 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package authtoken
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/db/timestamp"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/pagination"
+	dbw "github.com/hashicorp/go-dbw"
+)
+
+// CreateApiKey inserts an api key for the service account into the repository
+// and returns it. The returned api key contains the key value, which is never
+// returned again. The WithName, WithDescription, WithExpirationTime,
+// WithAllowedCidrs and WithPublicId options are supported and all other
+// options are ignored.
+func (r *Repository) CreateApiKey(ctx context.Context, serviceAccount *iam.ServiceAccount, opt ...Option) (*ApiKey, error) {
+	const op = "authtoken.(Repository).CreateApiKey"
+	if serviceAccount == nil || serviceAccount.ServiceAccount == nil {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing service account")
+	}
+	if serviceAccount.GetPublicId() == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing service account id")
+	}
+	if serviceAccount.GetScopeId() == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing scope id")
+	}
+	k, err := NewApiKey(ctx, serviceAccount.GetPublicId(), opt...)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	opts := getOpts(opt...)
+	if opts.withPublicId == "" {
+		id, err := NewApiKeyId(ctx)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		opts.withPublicId = id
+	}
+	if !strings.HasPrefix(opts.withPublicId, ApiKeyPrefix+"_") {
+		return nil, errors.New(ctx, errors.InvalidPublicId, op, fmt.Sprintf("passed-in public ID %q has wrong prefix", opts.withPublicId))
+	}
+	k.PublicId = opts.withPublicId
+	k.ScopeId = serviceAccount.GetScopeId()
+	if k.Token, err = newApiKeyValue(ctx); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+
+	databaseWrapper, err := r.kms.GetWrapper(ctx, k.GetScopeId(), kms.KeyPurposeDatabase)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get database wrapper"))
+	}
+
+	newApiKey := k.clone()
+	if err := newApiKey.encrypt(ctx, databaseWrapper); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	_, err = r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) error {
+			// api keys, like tokens, are not replicated, so they don't need
+			// oplog entries.
+			if err := w.Create(ctx, newApiKey); err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		if errors.IsUniqueError(err) {
+			return nil, errors.New(ctx, errors.NotUnique, op, fmt.Sprintf("api key %s already exists for service account %s", k.GetName(), k.GetServiceAccountId()))
+		}
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	newApiKey.CtToken = nil
+	newApiKey.KeyId = ""
+	return newApiKey, nil
+}
+
+// LookupApiKey returns the ApiKey for the provided id. Returns nil, nil if no
+// ApiKey is found for id. For security reasons, the key value is not included
+// in the returned ApiKey. All exported options are ignored.
+func (r *Repository) LookupApiKey(ctx context.Context, id string, opt ...Option) (*ApiKey, error) {
+	const op = "authtoken.(Repository).LookupApiKey"
+	if id == "" {
+		return nil, errors.New(ctx, errors.InvalidPublicId, op, "missing public id")
+	}
+	opts := getOpts(opt...)
+
+	k := allocApiKey()
+	k.PublicId = id
+	if err := r.reader.LookupByPublicId(ctx, k); err != nil {
+		if errors.IsNotFoundError(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	if opts.withTokenValue {
+		databaseWrapper, err := r.kms.GetWrapper(ctx, k.GetScopeId(), kms.KeyPurposeDatabase, kms.WithKeyId(k.GetKeyId()))
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op, errors.WithCode(errors.Encrypt), errors.WithMsg("unable to get database wrapper"))
+		}
+		if err := k.decrypt(ctx, databaseWrapper); err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+	}
+	k.CtToken = nil
+	k.KeyId = ""
+	return k, nil
+}
+
+// ValidateApiKey returns an api key from storage if the api key with the
+// provided id and value exists, has not expired and may be used from the
+// client ip. The approximate last accessed time may be updated depending on
+// how long it has been since the last time the key was validated. If no valid
+// api key is found nil, nil is returned. For security reasons, the key value
+// is not included in the returned ApiKey. All options are ignored.
+//
+// NOTE: Do not log or add the token string to any errors to avoid leaking it as it is a secret.
+func (r *Repository) ValidateApiKey(ctx context.Context, id, token, clientIp string, _ ...Option) (*ApiKey, error) {
+	const op = "authtoken.(Repository).ValidateApiKey"
+	if token == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing token")
+	}
+	if id == "" {
+		return nil, errors.New(ctx, errors.InvalidPublicId, op, "missing public id")
+	}
+
+	k, err := r.LookupApiKey(ctx, id, withTokenValue())
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	if k == nil {
+		return nil, nil
+	}
+
+	now := time.Now()
+	// Unlike auth tokens, expired api keys are not deleted so that they can
+	// still be listed and read by administrators.
+	if k.expired(now) {
+		return nil, nil
+	}
+	if k.GetToken() != token {
+		return nil, nil
+	}
+	if !k.allowsAddress(clientIp) {
+		return nil, nil
+	}
+	k.Token = ""
+
+	lastAccessed := k.GetApproximateLastAccessTime().AsTime()
+	if now.Sub(lastAccessed)+timeSkew >= lastAccessedUpdateDuration {
+		// To save the db from being updated too frequently, we only update the
+		// LastAccessTime if it hasn't been updated within lastAccessedUpdateDuration.
+		_, err = r.writer.DoTx(
+			ctx,
+			db.StdRetryCnt,
+			db.ExpBackoff{},
+			func(_ db.Reader, w db.Writer) error {
+				upd := k.clone()
+				// Setting the ApproximateLastAccessTime to null through using the null mask allows a defined db's
+				// trigger to set ApproximateLastAccessTime to the commit timestamp.
+				rowsUpdated, err := w.Update(ctx, upd, nil, []string{"ApproximateLastAccessTime"})
+				if err != nil {
+					return errors.Wrap(ctx, err, op)
+				}
+				if rowsUpdated > 1 {
+					return errors.New(ctx, errors.MultipleRecords, op, "more than 1 resource would have been updated")
+				}
+				return nil
+			},
+		)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op, errors.WithMsg(id))
+		}
+	}
+	return k, nil
+}
+
+// ListApiKeys lists the api keys of the service account and supports the
+// WithLimit and WithPage options.
+func (r *Repository) ListApiKeys(ctx context.Context, serviceAccountId string, opt ...Option) ([]*ApiKey, error) {
+	const op = "authtoken.(Repository).ListApiKeys"
+	if serviceAccountId == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing service account id")
+	}
+	opts := getOpts(opt...)
+
+	var keys []*ApiKey
+	if err := r.reader.SearchWhere(ctx, &keys, "service_account_id = ?", []any{serviceAccountId}, db.WithLimit(opts.withLimit), db.WithPage(opts.withPage)); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	for _, k := range keys {
+		k.CtToken = nil
+		k.KeyId = ""
+	}
+	return keys, nil
+}
+
+// ListDeletedApiKeyIds returns the api keys of the service account deleted
+// after since.
+func (r *Repository) ListDeletedApiKeyIds(ctx context.Context, serviceAccountId string, since time.Time) ([]*pagination.DeletedResource, error) {
+	const op = "authtoken.(Repository).ListDeletedApiKeyIds"
+	deleted, err := pagination.ListDeletedIdsByParent(ctx, r.reader, defaultApiKeyTableName, serviceAccountId, since)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return deleted, nil
+}
+
+// UpdateApiKey updates the repository entry for the api key with the values
+// in k for the fields listed in fieldMaskPaths. Name, Description,
+// ExpirationTime and AllowedCidrs are the only updatable fields. A field is
+// set to null if it is a zero value and included in fieldMaskPaths.
+func (r *Repository) UpdateApiKey(ctx context.Context, k *ApiKey, version uint32, fieldMaskPaths []string, _ ...Option) (*ApiKey, int, error) {
+	const op = "authtoken.(Repository).UpdateApiKey"
+	if k == nil || k.ApiKey == nil {
+		return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing api key")
+	}
+	if k.PublicId == "" {
+		return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidPublicId, op, "missing public id")
+	}
+	if version == 0 {
+		return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing version")
+	}
+	for _, f := range fieldMaskPaths {
+		switch {
+		case strings.EqualFold("Name", f):
+		case strings.EqualFold("Description", f):
+		case strings.EqualFold("ExpirationTime", f):
+		case strings.EqualFold("AllowedCidrs", f):
+			if err := k.setAllowedCidrs(ctx, k.GetAllowedCidrList()); err != nil {
+				return nil, db.NoRowsAffected, errors.Wrap(ctx, err, op)
+			}
+		default:
+			return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidFieldMask, op, fmt.Sprintf("invalid field mask: %s", f))
+		}
+	}
+	var expirationTime *timestamp.Timestamp
+	if k.GetExpirationTime().GetTimestamp() != nil {
+		expirationTime = k.GetExpirationTime()
+	}
+	dbMask, nullFields := dbw.BuildUpdatePaths(
+		map[string]any{
+			"Name":           k.Name,
+			"Description":    k.Description,
+			"ExpirationTime": expirationTime,
+			"AllowedCidrs":   k.AllowedCidrs,
+		},
+		fieldMaskPaths,
+		nil,
+	)
+	if len(dbMask) == 0 && len(nullFields) == 0 {
+		return nil, db.NoRowsAffected, errors.New(ctx, errors.EmptyFieldMask, op, "empty field mask")
+	}
+
+	var rowsUpdated int
+	var returnedKey *ApiKey
+	_, err := r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) error {
+			returnedKey = k.clone()
+			returnedKey.CtToken = nil
+			returnedKey.Token = ""
+			var err error
+			rowsUpdated, err = w.Update(ctx, returnedKey, dbMask, nullFields, db.WithVersion(&version))
+			if err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			if rowsUpdated > 1 {
+				return errors.New(ctx, errors.MultipleRecords, op, "more than 1 resource would have been updated")
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		if errors.IsUniqueError(err) {
+			return nil, db.NoRowsAffected, errors.New(ctx, errors.NotUnique, op, fmt.Sprintf("api key %s already exists for service account %s", k.GetName(), k.GetServiceAccountId()))
+		}
+		return nil, db.NoRowsAffected, errors.Wrap(ctx, err, op, errors.WithMsg(k.PublicId))
+	}
+	returnedKey.CtToken = nil
+	returnedKey.KeyId = ""
+	return returnedKey, rowsUpdated, nil
+}
+
+// RotateApiKey replaces the value of the api key with a new one. The previous
+// value stops being valid immediately. The returned api key contains the new
+// value, which is never returned again.
+func (r *Repository) RotateApiKey(ctx context.Context, id string, version uint32, _ ...Option) (*ApiKey, error) {
+	const op = "authtoken.(Repository).RotateApiKey"
+	if id == "" {
+		return nil, errors.New(ctx, errors.InvalidPublicId, op, "missing public id")
+	}
+	if version == 0 {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing version")
+	}
+	k, err := r.LookupApiKey(ctx, id)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	if k == nil {
+		return nil, errors.New(ctx, errors.RecordNotFound, op, fmt.Sprintf("api key %s not found", id))
+	}
+	if k.Token, err = newApiKeyValue(ctx); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	databaseWrapper, err := r.kms.GetWrapper(ctx, k.GetScopeId(), kms.KeyPurposeDatabase)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get database wrapper"))
+	}
+	rotated := k.clone()
+	if err := rotated.encrypt(ctx, databaseWrapper); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	_, err = r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) error {
+			rowsUpdated, err := w.Update(ctx, rotated, []string{"CtToken", "KeyId"}, nil, db.WithVersion(&version))
+			if err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			if rowsUpdated == 0 {
+				return errors.New(ctx, errors.VersionMismatch, op, fmt.Sprintf("api key %s is not at version %d", id, version))
+			}
+			if rowsUpdated != 1 {
+				return errors.New(ctx, errors.MultipleRecords, op, fmt.Sprintf("updated api key and %d rows updated", rowsUpdated))
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg(id))
+	}
+	rotated.CtToken = nil
+	rotated.KeyId = ""
+	return rotated, nil
+}
+
+// DeleteApiKey deletes, and so revokes, the api key with the provided id
+// returning a count of the number of records deleted. All options are
+// ignored.
+func (r *Repository) DeleteApiKey(ctx context.Context, id string, _ ...Option) (int, error) {
+	const op = "authtoken.(Repository).DeleteApiKey"
+	if id == "" {
+		return db.NoRowsAffected, errors.New(ctx, errors.InvalidPublicId, op, "missing public id")
+	}
+	k, err := r.LookupApiKey(ctx, id)
+	if err != nil {
+		return db.NoRowsAffected, errors.Wrap(ctx, err, op)
+	}
+	if k == nil {
+		return db.NoRowsAffected, nil
+	}
+
+	var rowsDeleted int
+	_, err = r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) error {
+			rowsDeleted, err = w.Delete(ctx, k.clone())
+			if err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			if rowsDeleted > 1 {
+				return errors.New(ctx, errors.MultipleRecords, op, "more than 1 resource would have been deleted")
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return db.NoRowsAffected, errors.Wrap(ctx, err, op, errors.WithMsg(id))
+	}
+	return rowsDeleted, nil
+}
@@ -0,0 +1,232 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package authtoken
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/hashicorp/boundary/internal/pagination"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_CreateApiKey(t *testing.T) {
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	repo, err := NewRepository(rw, rw, kms)
+	require.NoError(t, err)
+
+	org, _ := iam.TestScopes(t, iamRepo)
+	sa := iam.TestServiceAccount(t, iamRepo, org.GetPublicId())
+
+	tests := []struct {
+		name      string
+		sa        *iam.ServiceAccount
+		opts      []Option
+		wantIsErr errors.Code
+	}{
+		{
+			name:      "nil-service-account",
+			wantIsErr: errors.InvalidParameter,
+		},
+		{
+			name: "valid",
+			sa:   sa,
+			opts: []Option{WithName("valid"), WithAllowedCidrs([]string{"10.0.0.0/8"}), WithExpirationTime(time.Now().Add(time.Hour))},
+		},
+		{
+			name:      "duplicate-name",
+			sa:        sa,
+			opts:      []Option{WithName("valid")},
+			wantIsErr: errors.NotUnique,
+		},
+		{
+			name:      "bad-public-id",
+			sa:        sa,
+			opts:      []Option{WithPublicId("at_1234567890")},
+			wantIsErr: errors.InvalidPublicId,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, err := repo.CreateApiKey(ctx, tt.sa, tt.opts...)
+			if tt.wantIsErr != 0 {
+				assert.Truef(errors.Match(errors.T(tt.wantIsErr), err), "Unexpected error %s", err)
+				assert.Nil(got)
+				return
+			}
+			require.NoError(err)
+			assert.NotEmpty(got.GetToken())
+			assert.Empty(got.GetCtToken())
+			assert.Equal(sa.GetPublicId(), got.GetServiceAccountId())
+			assert.Equal(sa.GetScopeId(), got.GetScopeId())
+
+			// api keys are not replicated, so they don't need oplog entries.
+			assert.Error(db.TestVerifyOplog(t, rw, got.GetPublicId(), db.WithOperation(oplog.OpType_OP_TYPE_CREATE)))
+
+			found, err := repo.LookupApiKey(ctx, got.GetPublicId())
+			require.NoError(err)
+			assert.Empty(found.GetToken())
+			assert.Equal(got.GetAllowedCidrs(), found.GetAllowedCidrs())
+		})
+	}
+}
+
+func TestRepository_ValidateApiKey(t *testing.T) {
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	repo, err := NewRepository(rw, rw, kms)
+	require.NoError(t, err)
+
+	org, _ := iam.TestScopes(t, iamRepo)
+	open := TestApiKey(t, conn, kms, org.GetPublicId())
+	restricted := TestApiKey(t, conn, kms, org.GetPublicId(), WithAllowedCidrs([]string{"10.0.0.0/8"}))
+	expired := TestApiKey(t, conn, kms, org.GetPublicId(), WithExpirationTime(time.Now().Add(time.Second)))
+	_, err = rw.Exec(ctx, "update auth_api_key set expiration_time = create_time where public_id = ?", []any{expired.GetPublicId()})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		id       string
+		token    string
+		clientIp string
+		wantKey  bool
+	}{
+		{name: "valid", id: open.GetPublicId(), token: open.GetToken(), clientIp: "192.168.1.1", wantKey: true},
+		{name: "wrong-token", id: open.GetPublicId(), token: restricted.GetToken(), clientIp: "192.168.1.1"},
+		{name: "in-cidr", id: restricted.GetPublicId(), token: restricted.GetToken(), clientIp: "10.1.1.1", wantKey: true},
+		{name: "outside-cidr", id: restricted.GetPublicId(), token: restricted.GetToken(), clientIp: "192.168.1.1"},
+		{name: "expired", id: expired.GetPublicId(), token: expired.GetToken(), clientIp: "192.168.1.1"},
+		{name: "not-found", id: "ak_1234567890", token: open.GetToken(), clientIp: "192.168.1.1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, err := repo.ValidateApiKey(ctx, tt.id, tt.token, tt.clientIp)
+			require.NoError(err)
+			if !tt.wantKey {
+				assert.Nil(got)
+				return
+			}
+			require.NotNil(got)
+			assert.Empty(got.GetToken())
+		})
+	}
+}
+
+func TestRepository_UpdateApiKey(t *testing.T) {
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	repo, err := NewRepository(rw, rw, kms)
+	require.NoError(t, err)
+
+	org, _ := iam.TestScopes(t, iamRepo)
+	k := TestApiKey(t, conn, kms, org.GetPublicId(), WithName("name"), WithAllowedCidrs([]string{"10.0.0.0/8"}))
+
+	upd := k.clone()
+	upd.Name = "updated"
+	upd.AllowedCidrs = ""
+	got, rowsUpdated, err := repo.UpdateApiKey(ctx, upd, k.GetVersion(), []string{"Name", "AllowedCidrs"})
+	require.NoError(t, err)
+	assert.Equal(t, 1, rowsUpdated)
+	assert.Equal(t, "updated", got.GetName())
+	assert.Empty(t, got.GetAllowedCidrs())
+
+	upd.AllowedCidrs = "not-a-cidr"
+	_, _, err = repo.UpdateApiKey(ctx, upd, got.GetVersion(), []string{"AllowedCidrs"})
+	assert.Truef(t, errors.Match(errors.T(errors.InvalidParameter), err), "Unexpected error %s", err)
+
+	_, _, err = repo.UpdateApiKey(ctx, upd, got.GetVersion(), []string{"Token"})
+	assert.Truef(t, errors.Match(errors.T(errors.InvalidFieldMask), err), "Unexpected error %s", err)
+}
+
+func TestRepository_RotateApiKey(t *testing.T) {
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	repo, err := NewRepository(rw, rw, kms)
+	require.NoError(t, err)
+
+	org, _ := iam.TestScopes(t, iamRepo)
+	k := TestApiKey(t, conn, kms, org.GetPublicId())
+
+	rotated, err := repo.RotateApiKey(ctx, k.GetPublicId(), k.GetVersion())
+	require.NoError(t, err)
+	assert.NotEmpty(t, rotated.GetToken())
+	assert.NotEqual(t, k.GetToken(), rotated.GetToken())
+
+	got, err := repo.ValidateApiKey(ctx, k.GetPublicId(), k.GetToken(), "")
+	require.NoError(t, err)
+	assert.Nil(t, got, "previous api key value is still valid")
+
+	got, err = repo.ValidateApiKey(ctx, k.GetPublicId(), rotated.GetToken(), "")
+	require.NoError(t, err)
+	assert.NotNil(t, got)
+
+	_, err = repo.RotateApiKey(ctx, k.GetPublicId(), k.GetVersion())
+	assert.Truef(t, errors.Match(errors.T(errors.VersionMismatch), err), "rotated with a stale version: %v", err)
+}
+
+func TestRepository_DeleteApiKey(t *testing.T) {
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	repo, err := NewRepository(rw, rw, kms)
+	require.NoError(t, err)
+
+	org, _ := iam.TestScopes(t, iamRepo)
+	k := TestApiKey(t, conn, kms, org.GetPublicId())
+
+	keys, err := repo.ListApiKeys(ctx, k.GetServiceAccountId())
+	require.NoError(t, err)
+	assert.Len(t, keys, 1)
+
+	since := time.Now().Add(-time.Minute)
+	deleted, err := repo.DeleteApiKey(ctx, k.GetPublicId())
+	require.NoError(t, err)
+	assert.Equal(t, 1, deleted)
+
+	got, err := repo.ValidateApiKey(ctx, k.GetPublicId(), k.GetToken(), "")
+	require.NoError(t, err)
+	assert.Nil(t, got)
+
+	removed, err := repo.ListDeletedApiKeyIds(ctx, k.GetServiceAccountId(), since)
+	require.NoError(t, err)
+	assert.Equal(t, []*pagination.DeletedResource{{PublicId: k.GetPublicId(), ScopeId: org.GetPublicId(), ParentId: k.GetServiceAccountId()}}, removed)
+
+	other := TestApiKey(t, conn, kms, org.GetPublicId())
+	removed, err = repo.ListDeletedApiKeyIds(ctx, other.GetServiceAccountId(), since)
+	require.NoError(t, err)
+	assert.Empty(t, removed)
+
+	deleted, err = repo.DeleteApiKey(ctx, k.GetPublicId())
+	require.NoError(t, err)
+	assert.Equal(t, 0, deleted)
+}
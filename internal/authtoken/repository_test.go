@@ -713,6 +713,203 @@ func TestRepository_ListAuthTokens_Multiple_Scopes(t *testing.T) {
 	assert.Equal(t, total, len(got))
 }
 
+func TestRepository_ListAuthTokens_Filters(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	repo, err := NewRepository(rw, rw, kms)
+	require.NoError(t, err)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	org, _ := iam.TestScopes(t, iamRepo)
+
+	at1 := TestAuthToken(t, conn, kms, org.GetPublicId())
+	iamUser, _, err := iamRepo.LookupUser(ctx, at1.GetIamUserId())
+	require.NoError(t, err)
+	at2, err := repo.CreateAuthToken(ctx, iamUser, at1.GetAuthAccountId())
+	require.NoError(t, err)
+	at3 := TestAuthToken(t, conn, kms, org.GetPublicId())
+
+	ids := func(ats []*AuthToken) []string {
+		var ret []string
+		for _, at := range ats {
+			ret = append(ret, at.GetPublicId())
+		}
+		sort.Strings(ret)
+		return ret
+	}
+	sorted := func(in ...string) []string {
+		sort.Strings(in)
+		return in
+	}
+
+	tests := []struct {
+		name string
+		opts []Option
+		want []string
+	}{
+		{
+			name: "no-filter",
+			want: sorted(at1.GetPublicId(), at2.GetPublicId(), at3.GetPublicId()),
+		},
+		{
+			name: "user",
+			opts: []Option{WithIamUserId(at1.GetIamUserId())},
+			want: sorted(at1.GetPublicId(), at2.GetPublicId()),
+		},
+		{
+			name: "account",
+			opts: []Option{WithAuthAccountId(at3.GetAuthAccountId())},
+			want: sorted(at3.GetPublicId()),
+		},
+		{
+			name: "last-accessed-before",
+			opts: []Option{WithLastAccessedBefore(time.Now().Add(-time.Hour))},
+		},
+		{
+			name: "last-accessed-after",
+			opts: []Option{WithLastAccessedAfter(time.Now().Add(-time.Hour))},
+			want: sorted(at1.GetPublicId(), at2.GetPublicId(), at3.GetPublicId()),
+		},
+		{
+			name: "user-and-last-accessed-after",
+			opts: []Option{WithIamUserId(at3.GetIamUserId()), WithLastAccessedAfter(time.Now().Add(-time.Hour))},
+			want: sorted(at3.GetPublicId()),
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := repo.ListAuthTokens(ctx, []string{org.GetPublicId()}, tt.opts...)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, ids(got))
+		})
+	}
+}
+
+func TestRepository_RefreshAuthToken(t *testing.T) {
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	org, _ := iam.TestScopes(t, iamRepo)
+
+	t.Run("missing-id", func(t *testing.T) {
+		repo, err := NewRepository(rw, rw, kms)
+		require.NoError(t, err)
+		got, err := repo.RefreshAuthToken(ctx, "")
+		assert.Truef(t, errors.Match(errors.T(errors.InvalidPublicId), err), "unexpected error: %v", err)
+		assert.Nil(t, got)
+	})
+
+	t.Run("not-found", func(t *testing.T) {
+		repo, err := NewRepository(rw, rw, kms)
+		require.NoError(t, err)
+		id, err := NewAuthTokenId()
+		require.NoError(t, err)
+		got, err := repo.RefreshAuthToken(ctx, id)
+		assert.Truef(t, errors.Match(errors.T(errors.RecordNotFound), err), "unexpected error: %v", err)
+		assert.Nil(t, got)
+	})
+
+	t.Run("pending", func(t *testing.T) {
+		repo, err := NewRepository(rw, rw, kms)
+		require.NoError(t, err)
+		at := TestAuthToken(t, conn, kms, org.GetPublicId(), WithStatus(PendingStatus))
+		got, err := repo.RefreshAuthToken(ctx, at.GetPublicId())
+		assert.Truef(t, errors.Match(errors.T(errors.InvalidParameter), err), "unexpected error: %v", err)
+		assert.Nil(t, got)
+	})
+
+	t.Run("extends-expiration", func(t *testing.T) {
+		at := TestAuthToken(t, conn, kms, org.GetPublicId())
+		repo, err := NewRepository(rw, rw, kms, WithTokenTimeToLiveDuration(defaultTokenTimeToLiveDuration+time.Hour))
+		require.NoError(t, err)
+		got, err := repo.RefreshAuthToken(ctx, at.GetPublicId())
+		require.NoError(t, err)
+		require.NotNil(t, got)
+		assert.Empty(t, got.GetToken())
+		assert.True(t, got.GetExpirationTime().AsTime().After(at.GetExpirationTime().AsTime()))
+	})
+
+	t.Run("capped-at-max-time-to-live", func(t *testing.T) {
+		at := TestAuthToken(t, conn, kms, org.GetPublicId())
+		repo, err := NewRepository(rw, rw, kms,
+			WithTokenTimeToLiveDuration(defaultTokenTimeToLiveDuration+time.Hour),
+			WithTokenMaxTimeToLiveDuration(defaultTokenTimeToLiveDuration+time.Minute))
+		require.NoError(t, err)
+		got, err := repo.RefreshAuthToken(ctx, at.GetPublicId())
+		require.NoError(t, err)
+		require.NotNil(t, got)
+		maxExp := got.GetCreateTime().AsTime().Add(defaultTokenTimeToLiveDuration + time.Minute)
+		assert.False(t, got.GetExpirationTime().AsTime().After(maxExp))
+	})
+
+	t.Run("never-shortens", func(t *testing.T) {
+		at := TestAuthToken(t, conn, kms, org.GetPublicId())
+		repo, err := NewRepository(rw, rw, kms, WithTokenTimeToLiveDuration(time.Hour))
+		require.NoError(t, err)
+		got, err := repo.RefreshAuthToken(ctx, at.GetPublicId())
+		require.NoError(t, err)
+		require.NotNil(t, got)
+		assert.True(t, got.GetExpirationTime().AsTime().Equal(at.GetExpirationTime().AsTime()))
+	})
+
+	t.Run("stale", func(t *testing.T) {
+		at := TestAuthToken(t, conn, kms, org.GetPublicId())
+		repo, err := NewRepository(rw, rw, kms, WithTokenTimeToStaleDuration(time.Millisecond))
+		require.NoError(t, err)
+		time.Sleep(10 * time.Millisecond)
+		got, err := repo.RefreshAuthToken(ctx, at.GetPublicId())
+		assert.Truef(t, errors.Match(errors.T(errors.InvalidParameter), err), "unexpected error: %v", err)
+		assert.Nil(t, got)
+	})
+}
+
+func TestRepository_DeleteAuthTokensForUser(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	repo, err := NewRepository(rw, rw, kms)
+	require.NoError(t, err)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	org, _ := iam.TestScopes(t, iamRepo)
+
+	at1 := TestAuthToken(t, conn, kms, org.GetPublicId())
+	iamUser, _, err := iamRepo.LookupUser(ctx, at1.GetIamUserId())
+	require.NoError(t, err)
+	at2, err := repo.CreateAuthToken(ctx, iamUser, at1.GetAuthAccountId())
+	require.NoError(t, err)
+	other := TestAuthToken(t, conn, kms, org.GetPublicId())
+
+	_, err = repo.DeleteAuthTokensForUser(ctx, "")
+	assert.Truef(t, errors.Match(errors.T(errors.InvalidParameter), err), "unexpected error: %v", err)
+
+	deleted, err := repo.DeleteAuthTokensForUser(ctx, iamUser.GetPublicId())
+	require.NoError(t, err)
+	assert.Equal(t, 2, deleted)
+
+	for _, id := range []string{at1.GetPublicId(), at2.GetPublicId()} {
+		got, err := repo.LookupAuthToken(ctx, id)
+		require.NoError(t, err)
+		assert.Nil(t, got)
+	}
+	got, err := repo.LookupAuthToken(ctx, other.GetPublicId())
+	require.NoError(t, err)
+	assert.NotNil(t, got)
+
+	deleted, err = repo.DeleteAuthTokensForUser(ctx, iamUser.GetPublicId())
+	require.NoError(t, err)
+	assert.Equal(t, 0, deleted)
+}
+
 func Test_IssuePendingToken(t *testing.T) {
 	t.Parallel()
 	ctx := context.Background()
@@ -780,6 +977,68 @@ func Test_IssuePendingToken(t *testing.T) {
 	}
 }
 
+func TestRepository_IssueTotpEnrollmentToken(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	repo, err := NewRepository(rw, rw, kmsCache)
+	require.NoError(t, err)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	org, _ := iam.TestScopes(t, iamRepo)
+
+	enrolling := TestAuthToken(t, conn, kmsCache, org.GetPublicId(), WithStatus(TotpEnrollmentStatus))
+	acctId := enrolling.GetAuthAccountId()
+	iamUser, _, err := iamRepo.LookupUser(ctx, enrolling.GetIamUserId())
+	require.NoError(t, err)
+	sibling, err := repo.CreateAuthToken(ctx, iamUser, acctId, WithStatus(TotpEnrollmentStatus))
+	require.NoError(t, err)
+	issued, err := repo.CreateAuthToken(ctx, iamUser, acctId)
+	require.NoError(t, err)
+	other := TestAuthToken(t, conn, kmsCache, org.GetPublicId(), WithStatus(TotpEnrollmentStatus))
+
+	// a token waiting for enrollment is valid, so it can be restricted
+	got, err := repo.ValidateToken(ctx, enrolling.GetPublicId(), enrolling.GetToken())
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.Equal(t, string(TotpEnrollmentStatus), got.GetStatus())
+	_, err = repo.RefreshAuthToken(ctx, enrolling.GetPublicId())
+	assert.Error(t, err)
+
+	_, err = repo.IssueTotpEnrollmentToken(ctx, "", enrolling.GetPublicId())
+	assert.Truef(t, errors.Match(errors.T(errors.InvalidParameter), err), "unexpected error: %v", err)
+
+	// only a token of the account is issued
+	got, err = repo.IssueTotpEnrollmentToken(ctx, acctId, other.GetPublicId())
+	require.NoError(t, err)
+	assert.Nil(t, got)
+
+	got, err = repo.IssueTotpEnrollmentToken(ctx, acctId, enrolling.GetPublicId())
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.Equal(t, string(IssuedStatus), got.GetStatus())
+
+	// the other tokens of the account waiting for enrollment are deleted
+	got, err = repo.LookupAuthToken(ctx, sibling.GetPublicId())
+	require.NoError(t, err)
+	assert.Nil(t, got)
+	got, err = repo.LookupAuthToken(ctx, issued.GetPublicId())
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.Equal(t, string(IssuedStatus), got.GetStatus())
+	got, err = repo.LookupAuthToken(ctx, other.GetPublicId())
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.Equal(t, string(TotpEnrollmentStatus), got.GetStatus())
+
+	// an issued token is not issued again
+	got, err = repo.IssueTotpEnrollmentToken(ctx, acctId, issued.GetPublicId())
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
+
 func Test_CloseExpiredPendingTokens(t *testing.T) {
 	t.Parallel()
 	ctx := context.Background()
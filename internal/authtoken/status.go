@@ -25,4 +25,9 @@ const (
 	// SystemErrorStatus means that the system encountered an error before
 	// issuing the token. This is a final status.
 	SystemErrorStatus Status = "system error"
+
+	// TotpEnrollmentStatus means the token was issued to an account that must
+	// enroll a TOTP second factor before it can be used for anything else. It
+	// becomes IssuedStatus once the second factor is confirmed.
+	TotpEnrollmentStatus Status = "totp enrollment required"
 )
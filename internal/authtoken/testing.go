@@ -46,3 +46,20 @@ func TestAuthToken(t testing.TB, conn *db.DB, kms *kms.Kms, scopeId string, opt
 	require.NoError(t, err)
 	return at
 }
+
+// TestApiKey creates a service account in the scope and an api key for it.
+// The returned api key contains the key value.
+func TestApiKey(t testing.TB, conn *db.DB, kms *kms.Kms, scopeId string, opt ...Option) *ApiKey {
+	t.Helper()
+	ctx := context.Background()
+	rw := db.New(conn)
+	iamRepo, err := iam.NewRepository(rw, rw, kms)
+	require.NoError(t, err)
+	sa := iam.TestServiceAccount(t, iamRepo, scopeId)
+
+	repo, err := NewRepository(rw, rw, kms)
+	require.NoError(t, err)
+	k, err := repo.CreateApiKey(ctx, sa, opt...)
+	require.NoError(t, err)
+	return k
+}
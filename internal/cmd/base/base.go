@@ -114,9 +114,12 @@ type Command struct {
 	FlagAuthMethodId      string
 	FlagHostCatalogId     string
 	FlagCredentialStoreId string
+	FlagServiceAccountId  string
 	FlagVersion           int
 	FlagRecursive         bool
 	FlagFilter            string
+	FlagPageSize          uint
+	FlagListToken         string
 	FlagTags              map[string][]string
 
 	// Attribute values
@@ -338,7 +338,12 @@ func (c *Command) PrintJsonItems(resp *api.Response) bool {
 	// {"items": []}}. However, we decode into a RawMessage which makes it much
 	// more efficient on both the decoding and encoding side.
 	type inMsg struct {
-		Items json.RawMessage `json:"items"`
+		Items        json.RawMessage `json:"items"`
+		ResponseType string          `json:"response_type"`
+		ListToken    string          `json:"list_token"`
+		SortBy       string          `json:"sort_by"`
+		SortDir      string          `json:"sort_dir"`
+		RemovedIds   []string        `json:"removed_ids"`
 	}
 	var input inMsg
 	if resp.Body.Bytes() != nil {
@@ -347,12 +352,23 @@ func (c *Command) PrintJsonItems(resp *api.Response) bool {
 			return false
 		}
 	}
+	// The pagination fields are only set for paginated listings.
 	output := struct {
-		StatusCode int             `json:"status_code"`
-		Items      json.RawMessage `json:"items"`
+		StatusCode   int             `json:"status_code"`
+		Items        json.RawMessage `json:"items"`
+		ResponseType string          `json:"response_type,omitempty"`
+		ListToken    string          `json:"list_token,omitempty"`
+		SortBy       string          `json:"sort_by,omitempty"`
+		SortDir      string          `json:"sort_dir,omitempty"`
+		RemovedIds   []string        `json:"removed_ids,omitempty"`
 	}{
-		StatusCode: resp.HttpResponse().StatusCode,
-		Items:      input.Items,
+		StatusCode:   resp.HttpResponse().StatusCode,
+		Items:        input.Items,
+		ResponseType: input.ResponseType,
+		ListToken:    input.ListToken,
+		SortBy:       input.SortBy,
+		SortDir:      input.SortDir,
+		RemovedIds:   input.RemovedIds,
 	}
 	b, err := JsonFormatter{}.Format(output)
 	if err != nil {
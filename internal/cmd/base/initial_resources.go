@@ -48,15 +48,15 @@ func (b *Server) CreateInitialLoginRole(ctx context.Context) (*iam.Role, error)
 	if err != nil {
 		return nil, fmt.Errorf("error creating in memory role for generated grants: %w", err)
 	}
-	role, err := iamRepo.CreateRole(ctx, pr)
+	role, _, err := iamRepo.CreateRole(ctx, pr)
 	if err != nil {
 		return nil, fmt.Errorf("error creating role for default generated grants: %w", err)
 	}
 	if _, err := iamRepo.AddRoleGrants(ctx, role.PublicId, role.Version, []string{
 		"id=*;type=scope;actions=list,no-op",
 		"id=*;type=auth-method;actions=authenticate,list",
-		"id={{.Account.Id}};actions=read,change-password",
-		"id=*;type=auth-token;actions=list,read:self,delete:self",
+		"id={{.Account.Id}};actions=read,change-password,enroll-totp,confirm-totp",
+		"id=*;type=auth-token;actions=list,read:self,delete:self,refresh:self",
 	}); err != nil {
 		return nil, fmt.Errorf("error creating grant for default generated grants: %w", err)
 	}
@@ -202,7 +202,7 @@ func (b *Server) CreateInitialPasswordAuthMethod(ctx context.Context) (*password
 		if err != nil {
 			return nil, fmt.Errorf("error creating in memory role for generated grants: %w", err)
 		}
-		defPermsRole, err := iamRepo.CreateRole(ctx, pr)
+		defPermsRole, _, err := iamRepo.CreateRole(ctx, pr)
 		if err != nil {
 			return nil, fmt.Errorf("error creating role for default generated grants: %w", err)
 		}
@@ -599,7 +599,7 @@ func (b *Server) RegisterHostPlugin(ctx context.Context, name string, plg plgpb.
 }
 
 // unprivilegedDevUserRoleSetup adds dev user to the role that grants
-// list/read:self/cancel:self on sessions and read:self/delete:self/list on
+// list/read:self/cancel:self/list-connections:self on sessions and read:self/delete:self/refresh:self/list on
 // tokens. It also creates a role with an `authorize-session` grant for the
 // provided targetId.
 func unprivilegedDevUserRoleSetup(ctx context.Context, repo *iam.Repository, userId, projectId, targetId string) error {
@@ -657,7 +657,7 @@ func unprivilegedDevUserRoleSetup(ctx context.Context, repo *iam.Repository, use
 		return fmt.Errorf("failed to create role object: %w", err)
 	}
 
-	asRole, err = repo.CreateRole(ctx, asRole)
+	asRole, _, err = repo.CreateRole(ctx, asRole)
 	if err != nil {
 		return fmt.Errorf("failed to create role for unprivileged user: %w", err)
 	}
@@ -6,6 +6,7 @@ package cmd
 import (
 	"github.com/hashicorp/boundary/internal/cmd/base"
 	"github.com/hashicorp/boundary/internal/cmd/commands/accountscmd"
+	"github.com/hashicorp/boundary/internal/cmd/commands/apikeyscmd"
 	"github.com/hashicorp/boundary/internal/cmd/commands/authenticate"
 	"github.com/hashicorp/boundary/internal/cmd/commands/authmethodscmd"
 	"github.com/hashicorp/boundary/internal/cmd/commands/authtokenscmd"
@@ -25,6 +26,8 @@ import (
 	"github.com/hashicorp/boundary/internal/cmd/commands/rolescmd"
 	"github.com/hashicorp/boundary/internal/cmd/commands/scopescmd"
 	"github.com/hashicorp/boundary/internal/cmd/commands/server"
+	"github.com/hashicorp/boundary/internal/cmd/commands/serviceaccountscmd"
+	"github.com/hashicorp/boundary/internal/cmd/commands/sessionrecordingscmd"
 	"github.com/hashicorp/boundary/internal/cmd/commands/sessionscmd"
 	"github.com/hashicorp/boundary/internal/cmd/commands/targetscmd"
 	"github.com/hashicorp/boundary/internal/cmd/commands/userscmd"
@@ -74,6 +77,16 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 				Command: base.NewCommand(ui),
 			}, nil
 		},
+		"authenticate ldap": func() (cli.Command, error) {
+			return &authenticate.LdapCommand{
+				Command: base.NewCommand(ui),
+			}, nil
+		},
+		"authenticate jwt": func() (cli.Command, error) {
+			return &authenticate.JwtCommand{
+				Command: base.NewCommand(ui),
+			}, nil
+		},
 
 		"accounts": func() (cli.Command, error) {
 			return &accountscmd.Command{
@@ -110,6 +123,30 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 				Func:    "change-password",
 			}, nil
 		},
+		"accounts unlock": func() (cli.Command, error) {
+			return &accountscmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "unlock",
+			}, nil
+		},
+		"accounts enroll-totp": func() (cli.Command, error) {
+			return &accountscmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "enroll-totp",
+			}, nil
+		},
+		"accounts confirm-totp": func() (cli.Command, error) {
+			return &accountscmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "confirm-totp",
+			}, nil
+		},
+		"accounts remove-totp": func() (cli.Command, error) {
+			return &accountscmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "remove-totp",
+			}, nil
+		},
 		"accounts create": func() (cli.Command, error) {
 			return &accountscmd.Command{
 				Command: base.NewCommand(ui),
@@ -128,6 +165,18 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 				Func:    "create",
 			}, nil
 		},
+		"accounts create ldap": func() (cli.Command, error) {
+			return &accountscmd.LdapCommand{
+				Command: base.NewCommand(ui),
+				Func:    "create",
+			}, nil
+		},
+		"accounts create jwt": func() (cli.Command, error) {
+			return &accountscmd.JwtCommand{
+				Command: base.NewCommand(ui),
+				Func:    "create",
+			}, nil
+		},
 		"accounts update": func() (cli.Command, error) {
 			return &accountscmd.Command{
 				Command: base.NewCommand(ui),
@@ -146,6 +195,60 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 				Func:    "update",
 			}, nil
 		},
+		"accounts update ldap": func() (cli.Command, error) {
+			return &accountscmd.LdapCommand{
+				Command: base.NewCommand(ui),
+				Func:    "update",
+			}, nil
+		},
+		"accounts update jwt": func() (cli.Command, error) {
+			return &accountscmd.JwtCommand{
+				Command: base.NewCommand(ui),
+				Func:    "update",
+			}, nil
+		},
+
+		"api-keys": func() (cli.Command, error) {
+			return &apikeyscmd.Command{
+				Command: base.NewCommand(ui),
+			}, nil
+		},
+		"api-keys create": func() (cli.Command, error) {
+			return &apikeyscmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "create",
+			}, nil
+		},
+		"api-keys read": func() (cli.Command, error) {
+			return &apikeyscmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "read",
+			}, nil
+		},
+		"api-keys update": func() (cli.Command, error) {
+			return &apikeyscmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "update",
+			}, nil
+		},
+		"api-keys delete": func() (cli.Command, error) {
+			return &apikeyscmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "delete",
+			}, nil
+		},
+		"api-keys list": func() (cli.Command, error) {
+			return &apikeyscmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "list",
+			}, nil
+		},
+		"api-keys rotate": func() (cli.Command, error) {
+			return &apikeyscmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "rotate",
+			}, nil
+		},
 
 		"auth-methods": func() (cli.Command, error) {
 			return &authmethodscmd.Command{
@@ -188,6 +291,18 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 				Func:    "create",
 			}, nil
 		},
+		"auth-methods create ldap": func() (cli.Command, error) {
+			return &authmethodscmd.LdapCommand{
+				Command: base.NewCommand(ui),
+				Func:    "create",
+			}, nil
+		},
+		"auth-methods create jwt": func() (cli.Command, error) {
+			return &authmethodscmd.JwtCommand{
+				Command: base.NewCommand(ui),
+				Func:    "create",
+			}, nil
+		},
 		"auth-methods update": func() (cli.Command, error) {
 			return &authmethodscmd.Command{
 				Command: base.NewCommand(ui),
@@ -206,6 +321,18 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 				Func:    "update",
 			}, nil
 		},
+		"auth-methods update ldap": func() (cli.Command, error) {
+			return &authmethodscmd.LdapCommand{
+				Command: base.NewCommand(ui),
+				Func:    "update",
+			}, nil
+		},
+		"auth-methods update jwt": func() (cli.Command, error) {
+			return &authmethodscmd.JwtCommand{
+				Command: base.NewCommand(ui),
+				Func:    "update",
+			}, nil
+		},
 		"auth-methods change-state oidc": func() (cli.Command, error) {
 			return &authmethodscmd.OidcCommand{
 				Command: base.NewCommand(ui),
@@ -236,6 +363,12 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 				Func:    "list",
 			}, nil
 		},
+		"auth-tokens refresh": func() (cli.Command, error) {
+			return &authtokenscmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "refresh",
+			}, nil
+		},
 
 		"config": func() (cli.Command, error) {
 			return &config.Command{
@@ -817,6 +950,24 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 				Func:    "create",
 			}, nil
 		},
+		"managed-groups create ldap": func() (cli.Command, error) {
+			return &managedgroupscmd.LdapCommand{
+				Command: base.NewCommand(ui),
+				Func:    "create",
+			}, nil
+		},
+		"managed-groups create password": func() (cli.Command, error) {
+			return &managedgroupscmd.PasswordCommand{
+				Command: base.NewCommand(ui),
+				Func:    "create",
+			}, nil
+		},
+		"managed-groups create jwt": func() (cli.Command, error) {
+			return &managedgroupscmd.JwtCommand{
+				Command: base.NewCommand(ui),
+				Func:    "create",
+			}, nil
+		},
 		"managed-groups update": func() (cli.Command, error) {
 			return &managedgroupscmd.Command{
 				Command: base.NewCommand(ui),
@@ -829,6 +980,24 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 				Func:    "update",
 			}, nil
 		},
+		"managed-groups update ldap": func() (cli.Command, error) {
+			return &managedgroupscmd.LdapCommand{
+				Command: base.NewCommand(ui),
+				Func:    "update",
+			}, nil
+		},
+		"managed-groups update password": func() (cli.Command, error) {
+			return &managedgroupscmd.PasswordCommand{
+				Command: base.NewCommand(ui),
+				Func:    "update",
+			}, nil
+		},
+		"managed-groups update jwt": func() (cli.Command, error) {
+			return &managedgroupscmd.JwtCommand{
+				Command: base.NewCommand(ui),
+				Func:    "update",
+			}, nil
+		},
 
 		"roles": func() (cli.Command, error) {
 			return &rolescmd.Command{
@@ -901,6 +1070,29 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 				Func:    "remove-grants",
 			}, nil
 		},
+		"roles add-grant-scopes": func() (cli.Command, error) {
+			return &rolescmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "add-grant-scopes",
+			}, nil
+		},
+		"roles set-grant-scopes": func() (cli.Command, error) {
+			return &rolescmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "set-grant-scopes",
+			}, nil
+		},
+		"roles remove-grant-scopes": func() (cli.Command, error) {
+			return &rolescmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "remove-grant-scopes",
+			}, nil
+		},
+		"roles explain": func() (cli.Command, error) {
+			return &rolescmd.ExplainCommand{
+				Command: base.NewCommand(ui),
+			}, nil
+		},
 
 		"scopes": func() (cli.Command, error) {
 			return &scopescmd.Command{
@@ -958,6 +1150,65 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 			}, nil
 		},
 
+		"session-recordings": func() (cli.Command, error) {
+			return &sessionrecordingscmd.Command{
+				Command: base.NewCommand(ui),
+			}, nil
+		},
+		"session-recordings read": func() (cli.Command, error) {
+			return &sessionrecordingscmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "read",
+			}, nil
+		},
+		"session-recordings list": func() (cli.Command, error) {
+			return &sessionrecordingscmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "list",
+			}, nil
+		},
+		"session-recordings download": func() (cli.Command, error) {
+			return &sessionrecordingscmd.DownloadCommand{
+				Command: base.NewCommand(ui),
+			}, nil
+		},
+
+		"service-accounts": func() (cli.Command, error) {
+			return &serviceaccountscmd.Command{
+				Command: base.NewCommand(ui),
+			}, nil
+		},
+		"service-accounts create": func() (cli.Command, error) {
+			return &serviceaccountscmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "create",
+			}, nil
+		},
+		"service-accounts read": func() (cli.Command, error) {
+			return &serviceaccountscmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "read",
+			}, nil
+		},
+		"service-accounts update": func() (cli.Command, error) {
+			return &serviceaccountscmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "update",
+			}, nil
+		},
+		"service-accounts delete": func() (cli.Command, error) {
+			return &serviceaccountscmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "delete",
+			}, nil
+		},
+		"service-accounts list": func() (cli.Command, error) {
+			return &serviceaccountscmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "list",
+			}, nil
+		},
+
 		"sessions": func() (cli.Command, error) {
 			return &sessionscmd.Command{
 				Command: base.NewCommand(ui),
@@ -981,6 +1232,22 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 				Func:    "cancel",
 			}, nil
 		},
+		"sessions extend": func() (cli.Command, error) {
+			return &sessionscmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "extend",
+			}, nil
+		},
+		"sessions list-connections": func() (cli.Command, error) {
+			return &sessionscmd.ListConnectionsCommand{
+				Command: base.NewCommand(ui),
+			}, nil
+		},
+		"sessions watch": func() (cli.Command, error) {
+			return &sessionscmd.WatchCommand{
+				Command: base.NewCommand(ui),
+			}, nil
+		},
 
 		"targets": func() (cli.Command, error) {
 			return &targetscmd.Command{
@@ -1029,6 +1296,18 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 				Func:    "create",
 			}, nil
 		},
+		"targets create http": func() (cli.Command, error) {
+			return &targetscmd.HttpCommand{
+				Command: base.NewCommand(ui),
+				Func:    "create",
+			}, nil
+		},
+		"targets create postgres": func() (cli.Command, error) {
+			return &targetscmd.PostgresCommand{
+				Command: base.NewCommand(ui),
+				Func:    "create",
+			}, nil
+		},
 		"targets update": func() (cli.Command, error) {
 			return &targetscmd.Command{
 				Command: base.NewCommand(ui),
@@ -1047,6 +1326,18 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 				Func:    "update",
 			}, nil
 		},
+		"targets update http": func() (cli.Command, error) {
+			return &targetscmd.HttpCommand{
+				Command: base.NewCommand(ui),
+				Func:    "update",
+			}, nil
+		},
+		"targets update postgres": func() (cli.Command, error) {
+			return &targetscmd.PostgresCommand{
+				Command: base.NewCommand(ui),
+				Func:    "update",
+			}, nil
+		},
 		"targets add-host-sources": func() (cli.Command, error) {
 			return &targetscmd.Command{
 				Command: base.NewCommand(ui),
@@ -1137,6 +1428,12 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 				Func:    "remove-accounts",
 			}, nil
 		},
+		"users revoke-tokens": func() (cli.Command, error) {
+			return &userscmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "revoke-tokens",
+			}, nil
+		},
 
 		"workers": func() (cli.Command, error) {
 			return &workerscmd.Command{
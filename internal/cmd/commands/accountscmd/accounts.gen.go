@@ -93,7 +93,7 @@ var flagsMap = map[string][]string{
 
 	"delete": {"id"},
 
-	"list": {"auth-method-id", "filter"},
+	"list": {"auth-method-id", "filter", "page-size", "list-token"},
 }
 
 func (c *Command) Flags() *base.FlagSets {
@@ -191,6 +191,14 @@ func (c *Command) Run(args []string) int {
 		opts = append(opts, accounts.WithFilter(c.FlagFilter))
 	}
 
+	if c.FlagPageSize != 0 {
+		opts = append(opts, accounts.WithPageSize(uint32(c.FlagPageSize)))
+	}
+
+	if c.FlagListToken != "" {
+		opts = append(opts, accounts.WithListToken(c.FlagListToken))
+	}
+
 	var version uint32
 
 	switch c.Func {
@@ -24,18 +24,26 @@ func init() {
 	extraFlagsFunc = extraFlagsFuncImpl
 	extraFlagsHandlingFunc = extraFlagsHandlingFuncImpl
 	executeExtraActions = executeExtraActionsImpl
+	printCustomActionOutput = printCustomActionOutputImpl
 }
 
 type extraCmdVars struct {
 	flagPassword        string
 	flagCurrentPassword string
 	flagNewPassword     string
+	flagCode            string
+	flagRecoveryCode    string
+	enrollment          *accounts.TotpEnrollResult
 }
 
 func extraActionsFlagsMapFuncImpl() map[string][]string {
 	return map[string][]string{
 		"change-password": {"id", "current-password", "new-password", "version"},
 		"set-password":    {"id", "password", "version"},
+		"unlock":          {"id"},
+		"enroll-totp":     {"id", "code", "recovery-code"},
+		"confirm-totp":    {"id", "code"},
+		"remove-totp":     {"id"},
 	}
 }
 
@@ -47,6 +55,18 @@ func extraSynopsisFuncImpl(c *Command) string {
 	case "set-password":
 		return "Directly set the password on an account"
 
+	case "unlock":
+		return "Unlock an account locked after failed authentication attempts"
+
+	case "enroll-totp":
+		return "Enroll a TOTP second factor for an account"
+
+	case "confirm-totp":
+		return "Confirm the TOTP second factor enrolled for an account"
+
+	case "remove-totp":
+		return "Remove the TOTP second factor of an account"
+
 	default:
 		return ""
 	}
@@ -91,6 +111,58 @@ func (c *Command) extraHelpFunc(helpMap map[string]func() string) string {
 			"",
 			"",
 		})
+	case "unlock":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary accounts unlock [options] [args]",
+			"",
+			"  This command allows clearing the failed authentication attempts on account-type resources and unlocking them, if the types match and the operation is allowed by the given account type. Example:",
+			"",
+			"    Unlock a password-type account:",
+			"",
+			`      $ boundary accounts unlock -id acctpw_1234567890`,
+			"",
+			"",
+		})
+	case "enroll-totp":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary accounts enroll-totp [options] [args]",
+			"",
+			"  This command allows enrolling a new TOTP secret and recovery codes on account-type resources, replacing any existing ones, if the types match and the operation is allowed by the given account type. The secret and recovery codes are only shown once, and the secret must be confirmed with confirm-totp before it is required to authenticate. Replacing a confirmed secret requires a code generated from it or one of the account's recovery codes. Example:",
+			"",
+			"    Enroll a TOTP secret on a password-type account:",
+			"",
+			`      $ boundary accounts enroll-totp -id acctpw_1234567890`,
+			"",
+			"    Replace the confirmed TOTP secret of a password-type account:",
+			"",
+			`      $ boundary accounts enroll-totp -id acctpw_1234567890 -code 123456`,
+			"",
+			"",
+		})
+	case "confirm-totp":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary accounts confirm-totp [options] [args]",
+			"",
+			"  This command allows confirming the TOTP secret enrolled on account-type resources with a code generated from it, if the types match and the operation is allowed by the given account type. Example:",
+			"",
+			"    Confirm the TOTP secret of a password-type account:",
+			"",
+			`      $ boundary accounts confirm-totp -id acctpw_1234567890 -code 123456`,
+			"",
+			"",
+		})
+	case "remove-totp":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary accounts remove-totp [options] [args]",
+			"",
+			"  This command allows removing the TOTP secret and recovery codes of account-type resources, if the types match and the operation is allowed by the given account type. Example:",
+			"",
+			"    Remove the TOTP secret of a password-type account:",
+			"",
+			`      $ boundary accounts remove-totp -id acctpw_1234567890`,
+			"",
+			"",
+		})
 	}
 	return helpStr + c.Flags().Help()
 }
@@ -116,6 +188,18 @@ func extraFlagsFuncImpl(c *Command, _ *base.FlagSets, f *base.FlagSet) {
 				Target: &c.flagNewPassword,
 				Usage:  "The new password for the account. If not specified, the command will prompt for the password to be entered in a non-echoing way.",
 			})
+		case "code":
+			f.StringVar(&base.StringVar{
+				Name:   "code",
+				Target: &c.flagCode,
+				Usage:  "A code generated from the enrolled TOTP secret.",
+			})
+		case "recovery-code":
+			f.StringVar(&base.StringVar{
+				Name:   "recovery-code",
+				Target: &c.flagRecoveryCode,
+				Usage:  "One of the unused recovery codes of the account, in place of a code.",
+			})
 		}
 	}
 }
@@ -241,10 +325,60 @@ func executeExtraActionsImpl(c *Command, origResp *api.Response, origItem *accou
 			return nil, nil, nil, err
 		}
 		return result.GetResponse(), result.GetItem(), nil, err
+	case "unlock":
+		result, err := accountClient.Unlock(c.Context, c.FlagId, opts...)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		return result.GetResponse(), result.GetItem(), nil, err
+	case "enroll-totp":
+		result, err := accountClient.EnrollTotp(c.Context, c.FlagId, c.flagCode, c.flagRecoveryCode, opts...)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		c.enrollment = result
+		return result.GetResponse(), result.GetItem(), nil, err
+	case "confirm-totp":
+		result, err := accountClient.ConfirmTotp(c.Context, c.FlagId, c.flagCode, opts...)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		return result.GetResponse(), result.GetItem(), nil, err
+	case "remove-totp":
+		result, err := accountClient.RemoveTotp(c.Context, c.FlagId, opts...)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		return result.GetResponse(), result.GetItem(), nil, err
 	}
 	return origResp, origItem, origItems, origError
 }
 
+func printCustomActionOutputImpl(c *Command) (bool, error) {
+	switch c.Func {
+	case "enroll-totp":
+		if base.Format(c.UI) != "table" {
+			return false, nil
+		}
+		ret := []string{
+			printItemTable(c.enrollment.GetItem(), c.enrollment.GetResponse()),
+			"",
+			"TOTP enrollment:",
+			fmt.Sprintf("  Secret: %s", c.enrollment.Secret),
+			fmt.Sprintf("  URL:    %s", c.enrollment.Url),
+			"",
+			"  Recovery Codes:",
+			base.WrapSlice(4, c.enrollment.RecoveryCodes),
+			"",
+			"  The secret and recovery codes will not be shown again. Confirm the",
+			"  secret with a code generated from it using confirm-totp.",
+		}
+		c.UI.Output(strings.Join(ret, "\n"))
+		return true, nil
+	}
+	return false, nil
+}
+
 func (c *Command) printListTable(items []*accounts.Account) string {
 	if len(items) == 0 {
 		return "No accounts found"
@@ -369,5 +503,9 @@ func printItemTable(item *accounts.Account, resp *api.Response) string {
 }
 
 var keySubstMap = map[string]string{
-	"login_name": "Login Name",
+	"login_name":           "Login Name",
+	"failed_attempt_count": "Failed Attempt Count",
+	"locked_until":         "Locked Until",
+	"totp_enrolled":        "TOTP Enrolled",
+	"custom_attributes":    "Custom Attributes",
 }
@@ -0,0 +1,85 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package accountscmd
+
+import (
+	"github.com/hashicorp/boundary/api/accounts"
+	"github.com/hashicorp/boundary/internal/cmd/base"
+)
+
+func init() {
+	extraJwtActionsFlagsMapFunc = extraJwtActionsFlagsMapFuncImpl
+	extraJwtFlagsFunc = extraJwtFlagsFuncImpl
+	extraJwtFlagsHandlingFunc = extraJwtFlagsHandlingFuncImpl
+}
+
+func extraJwtActionsFlagsMapFuncImpl() map[string][]string {
+	return map[string][]string{
+		"create": {subjectFlagName},
+	}
+}
+
+type extraJwtCmdVars struct {
+	flagSubject string
+}
+
+func (c *JwtCommand) extraJwtHelpFunc(helpMap map[string]func() string) string {
+	var helpStr string
+	switch c.Func {
+	case "create":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary accounts create jwt [options] [args]",
+			"",
+			"  Create a jwt-type account. Example:",
+			"",
+			`    $ boundary accounts create jwt -auth-method-id amjwt_1234567890 -subject "system:serviceaccount:ci:deployer" -description "Jwt account for the CI deployer"`,
+			"",
+			"",
+		})
+
+	case "update":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary accounts update jwt [options] [args]",
+			"",
+			"  Update a jwt-type account given its ID. Example:",
+			"",
+			`    $ boundary accounts update jwt -id acctjwt_1234567890 -name "deployer" -description "Jwt account for the CI deployer"`,
+			"",
+			"",
+		})
+	}
+	return helpStr + c.Flags().Help()
+}
+
+func extraJwtFlagsFuncImpl(c *JwtCommand, set *base.FlagSets, f *base.FlagSet) {
+	f = set.NewFlagSet("Jwt Account Options")
+
+	for _, name := range flagsJwtMap[c.Func] {
+		switch name {
+		case subjectFlagName:
+			f.StringVar(&base.StringVar{
+				Name:   subjectFlagName,
+				Target: &c.flagSubject,
+				Usage:  "The subject (sub claim) of the tokens presented by this account.",
+			})
+		}
+	}
+}
+
+func extraJwtFlagsHandlingFuncImpl(c *JwtCommand, _ *base.FlagSets, opts *[]accounts.Option) bool {
+	switch c.flagSubject {
+	case "null", "":
+		if c.Func == "create" {
+			c.UI.Error("Subject must be passed in via -subject")
+			return false
+		}
+	default:
+		if c.Func != "create" {
+			c.UI.Error("-subject can only be set when creating a jwt account")
+			return false
+		}
+		*opts = append(*opts, accounts.WithJwtAccountSubject(c.flagSubject))
+	}
+	return true
+}
@@ -0,0 +1,81 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package accountscmd
+
+import (
+	"github.com/hashicorp/boundary/api/accounts"
+	"github.com/hashicorp/boundary/internal/cmd/base"
+)
+
+func init() {
+	extraLdapActionsFlagsMapFunc = extraLdapActionsFlagsMapFuncImpl
+	extraLdapFlagsFunc = extraLdapFlagsFuncImpl
+	extraLdapFlagsHandlingFunc = extraLdapFlagsHandlingFuncImpl
+}
+
+func extraLdapActionsFlagsMapFuncImpl() map[string][]string {
+	return map[string][]string{
+		"create": {"login-name"},
+	}
+}
+
+type extraLdapCmdVars struct {
+	flagLoginName string
+}
+
+func (c *LdapCommand) extraLdapHelpFunc(helpMap map[string]func() string) string {
+	var helpStr string
+	switch c.Func {
+	case "create":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary accounts create ldap [options] [args]",
+			"",
+			"  Create a ldap-type account. Example:",
+			"",
+			`    $ boundary accounts create ldap -login-name prodops -description "Ldap account for ProdOps"`,
+			"",
+			"",
+		})
+
+	case "update":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary accounts update ldap [options] [args]",
+			"",
+			"  Update a ldap-type account given its ID. Example:",
+			"",
+			`    $ boundary accounts update ldap -id acctldap_1234567890 -name "devops" -description "Ldap account for DevOps"`,
+			"",
+			"",
+		})
+	}
+	return helpStr + c.Flags().Help()
+}
+
+func extraLdapFlagsFuncImpl(c *LdapCommand, set *base.FlagSets, f *base.FlagSet) {
+	f = set.NewFlagSet("Ldap Account Options")
+
+	for _, name := range flagsLdapMap[c.Func] {
+		switch name {
+		case "login-name":
+			f.StringVar(&base.StringVar{
+				Name:   "login-name",
+				Target: &c.flagLoginName,
+				Usage:  "The login name for the account in the directory.",
+			})
+		}
+	}
+}
+
+func extraLdapFlagsHandlingFuncImpl(c *LdapCommand, _ *base.FlagSets, opts *[]accounts.Option) bool {
+	switch c.flagLoginName {
+	case "null", "":
+		if c.Func == "create" {
+			c.UI.Error("Login name must be passed in via -login-name")
+			return false
+		}
+	default:
+		*opts = append(*opts, accounts.WithLdapAccountLoginName(c.flagLoginName))
+	}
+	return true
+}
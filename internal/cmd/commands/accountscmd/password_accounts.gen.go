@@ -175,6 +175,14 @@ func (c *PasswordCommand) Run(args []string) int {
 		opts = append(opts, accounts.WithFilter(c.FlagFilter))
 	}
 
+	if c.FlagPageSize != 0 {
+		opts = append(opts, accounts.WithPageSize(uint32(c.FlagPageSize)))
+	}
+
+	if c.FlagListToken != "" {
+		opts = append(opts, accounts.WithListToken(c.FlagListToken))
+	}
+
 	var version uint32
 
 	switch c.Func {
@@ -24,14 +24,15 @@ func init() {
 
 func extraPasswordActionsFlagsMapFuncImpl() map[string][]string {
 	return map[string][]string{
-		"create": {"login-name", "password"},
-		"update": {"login-name"},
+		"create": {"login-name", "password", "custom-attribute"},
+		"update": {"login-name", "custom-attribute"},
 	}
 }
 
 type extraPasswordCmdVars struct {
-	flagLoginName string
-	flagPassword  string
+	flagLoginName        string
+	flagPassword         string
+	flagCustomAttributes []string
 }
 
 func (c *PasswordCommand) extraPasswordHelpFunc(helpMap map[string]func() string) string {
@@ -43,7 +44,7 @@ func (c *PasswordCommand) extraPasswordHelpFunc(helpMap map[string]func() string
 			"",
 			"  Create a password-type account. Example:",
 			"",
-			`    $ boundary accounts create password -login-name prodops -description "Password account for ProdOps"`,
+			`    $ boundary accounts create password -login-name prodops -description "Password account for ProdOps" -custom-attribute employment=contractor`,
 			"",
 			"",
 		})
@@ -79,6 +80,12 @@ func extraPasswordFlagsFuncImpl(c *PasswordCommand, set *base.FlagSets, f *base.
 				Target: &c.flagPassword,
 				Usage:  "The password for the account. If blank, the command will prompt for the password to be entered interactively in a non-echoing way. Otherwise, this can refer to a file on disk (file://) from which a password will be read or an env var (env://) from which the password will be read.",
 			})
+		case "custom-attribute":
+			f.StringSliceVar(&base.StringSliceVar{
+				Name:   "custom-attribute",
+				Target: &c.flagCustomAttributes,
+				Usage:  `A user-defined attribute of the account in the form "key=value", which password managed group filters can match on. May be specified multiple times. On update, replaces all of the account's custom attributes; use "null" to remove them.`,
+			})
 		}
 	}
 }
@@ -97,6 +104,23 @@ func extraPasswordFlagsHandlingFuncImpl(c *PasswordCommand, _ *base.FlagSets, op
 		*opts = append(*opts, accounts.WithPasswordAccountLoginName(c.flagLoginName))
 	}
 
+	switch {
+	case len(c.flagCustomAttributes) == 0:
+	case len(c.flagCustomAttributes) == 1 && c.flagCustomAttributes[0] == "null":
+		*opts = append(*opts, accounts.DefaultPasswordAccountCustomAttributes())
+	default:
+		attrs := make(map[string]string, len(c.flagCustomAttributes))
+		for _, kv := range c.flagCustomAttributes {
+			k, v, ok := strings.Cut(kv, "=")
+			if !ok || strings.TrimSpace(k) == "" {
+				c.UI.Error(fmt.Sprintf("Custom attribute %q must be in the form \"key=value\"", kv))
+				return false
+			}
+			attrs[strings.TrimSpace(k)] = v
+		}
+		*opts = append(*opts, accounts.WithPasswordAccountCustomAttributes(attrs))
+	}
+
 	if strutil.StrListContains(flagsPasswordMap[c.Func], "password") {
 		switch c.flagPassword {
 		case "":
@@ -0,0 +1,267 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package apikeyscmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/boundary/api"
+	"github.com/hashicorp/boundary/api/apikeys"
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/mitchellh/go-wordwrap"
+)
+
+func init() {
+	extraActionsFlagsMapFunc = extraActionsFlagsMapFuncImpl
+	extraSynopsisFunc = extraSynopsisFuncImpl
+	extraFlagsFunc = extraFlagsFuncImpl
+	extraFlagsHandlingFunc = extraFlagsHandlingFuncImpl
+	executeExtraActions = executeExtraActionsImpl
+}
+
+type extraCmdVars struct {
+	flagExpirationTime string
+	flagAllowedCidrs   []string
+}
+
+func extraActionsFlagsMapFuncImpl() map[string][]string {
+	return map[string][]string{
+		"create": {"expiration-time", "allowed-cidr"},
+		"update": {"expiration-time", "allowed-cidr"},
+		"rotate": {"id", "version"},
+	}
+}
+
+func extraSynopsisFuncImpl(c *Command) string {
+	switch c.Func {
+	case "rotate":
+		return wordwrap.WrapString("Rotate the token of an api key within Boundary", base.TermWidth)
+	}
+
+	return ""
+}
+
+func (c *Command) extraHelpFunc(helpMap map[string]func() string) string {
+	var helpStr string
+	switch c.Func {
+	case "":
+		return base.WrapForHelpText([]string{
+			"Usage: boundary api-keys [sub command] [options] [args]",
+			"",
+			"  This command allows operations on Boundary api key resources. An api key belongs to a service account and can be used in place of an auth token. Example:",
+			"",
+			"    Create an api key:",
+			"",
+			`      $ boundary api-keys create -service-account-id sa_1234567890 -expiration-time 2030-01-01T00:00:00Z -allowed-cidr 10.0.0.0/8`,
+			"",
+			"  Please see the api-keys subcommand help for detailed usage information.",
+		})
+
+	case "rotate":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary api-keys rotate [options] [args]",
+			"",
+			"  Replace the token of an api key given its ID. The previous token stops working immediately and the new token is only shown once. Example:",
+			"",
+			`    $ boundary api-keys rotate -id ak_1234567890`,
+			"",
+			"",
+		})
+
+	default:
+		helpStr = helpMap["base"]()
+	}
+	return helpStr + c.Flags().Help()
+}
+
+func extraFlagsFuncImpl(c *Command, _ *base.FlagSets, f *base.FlagSet) {
+	for _, name := range flagsMap[c.Func] {
+		switch name {
+		case "expiration-time":
+			f.StringVar(&base.StringVar{
+				Name:   "expiration-time",
+				Target: &c.flagExpirationTime,
+				Usage:  `The time after which the api key can no longer be used, in RFC3339 format. Use "null" to remove the expiration time on update.`,
+			})
+		case "allowed-cidr":
+			f.StringSliceVar(&base.StringSliceVar{
+				Name:   "allowed-cidr",
+				Target: &c.flagAllowedCidrs,
+				Usage:  `A CIDR block from which the api key may be used. May be specified multiple times. Use "null" to allow any address on update.`,
+			})
+		}
+	}
+}
+
+func extraFlagsHandlingFuncImpl(c *Command, _ *base.FlagSets, opts *[]apikeys.Option) bool {
+	switch c.flagExpirationTime {
+	case "":
+	case "null":
+		*opts = append(*opts, apikeys.DefaultExpirationTime())
+	default:
+		t, err := time.Parse(time.RFC3339, c.flagExpirationTime)
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("Error parsing %q as an RFC3339 time: %s", c.flagExpirationTime, err))
+			return false
+		}
+		*opts = append(*opts, apikeys.WithExpirationTime(t))
+	}
+
+	switch len(c.flagAllowedCidrs) {
+	case 0:
+	case 1:
+		if c.flagAllowedCidrs[0] == "null" {
+			*opts = append(*opts, apikeys.DefaultAllowedCidrs())
+			break
+		}
+		fallthrough
+	default:
+		*opts = append(*opts, apikeys.WithAllowedCidrs(c.flagAllowedCidrs))
+	}
+
+	return true
+}
+
+func executeExtraActionsImpl(c *Command, origResp *api.Response, origItem *apikeys.ApiKey, origItems []*apikeys.ApiKey, origError error, apiKeyClient *apikeys.Client, version uint32, opts []apikeys.Option) (*api.Response, *apikeys.ApiKey, []*apikeys.ApiKey, error) {
+	switch c.Func {
+	case "rotate":
+		result, err := apiKeyClient.Rotate(c.Context, c.FlagId, version, opts...)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		return result.GetResponse(), result.GetItem(), nil, err
+	}
+	return origResp, origItem, origItems, origError
+}
+
+func (c *Command) printListTable(items []*apikeys.ApiKey) string {
+	if len(items) == 0 {
+		return "No api keys found"
+	}
+
+	var output []string
+	output = []string{
+		"",
+		"Api Key information:",
+	}
+
+	for i, item := range items {
+		if i > 0 {
+			output = append(output, "")
+		}
+		if item.Id != "" {
+			output = append(output,
+				fmt.Sprintf("  ID:                    %s", item.Id),
+			)
+		} else {
+			output = append(output,
+				fmt.Sprintf("  ID:                    %s", "(not available)"),
+			)
+		}
+		if item.Version > 0 {
+			output = append(output,
+				fmt.Sprintf("    Version:             %d", item.Version),
+			)
+		}
+		if item.Name != "" {
+			output = append(output,
+				fmt.Sprintf("    Name:                %s", item.Name),
+			)
+		}
+		if item.Description != "" {
+			output = append(output,
+				fmt.Sprintf("    Description:         %s", item.Description),
+			)
+		}
+		if !item.ExpirationTime.IsZero() {
+			output = append(output,
+				fmt.Sprintf("    Expiration Time:     %s", item.ExpirationTime.Local().Format(time.RFC1123)),
+			)
+		}
+		if len(item.AuthorizedActions) > 0 {
+			output = append(output,
+				"    Authorized Actions:",
+				base.WrapSlice(6, item.AuthorizedActions),
+			)
+		}
+	}
+
+	return base.WrapForHelpText(output)
+}
+
+func printItemTable(item *apikeys.ApiKey, resp *api.Response) string {
+	nonAttributeMap := map[string]any{}
+	if item.Id != "" {
+		nonAttributeMap["ID"] = item.Id
+	}
+	if item.ServiceAccountId != "" {
+		nonAttributeMap["Service Account ID"] = item.ServiceAccountId
+	}
+	if item.Version != 0 {
+		nonAttributeMap["Version"] = item.Version
+	}
+	if !item.CreatedTime.IsZero() {
+		nonAttributeMap["Created Time"] = item.CreatedTime.Local().Format(time.RFC1123)
+	}
+	if !item.UpdatedTime.IsZero() {
+		nonAttributeMap["Updated Time"] = item.UpdatedTime.Local().Format(time.RFC1123)
+	}
+	if !item.ExpirationTime.IsZero() {
+		nonAttributeMap["Expiration Time"] = item.ExpirationTime.Local().Format(time.RFC1123)
+	}
+	if !item.ApproximateLastUsedTime.IsZero() {
+		nonAttributeMap["Approximate Last Used Time"] = item.ApproximateLastUsedTime.Local().Format(time.RFC1123)
+	}
+	if item.Name != "" {
+		nonAttributeMap["Name"] = item.Name
+	}
+	if item.Description != "" {
+		nonAttributeMap["Description"] = item.Description
+	}
+	if item.Token != "" {
+		nonAttributeMap["Token"] = item.Token
+	}
+
+	maxLength := base.MaxAttributesLength(nonAttributeMap, nil, nil)
+
+	ret := []string{
+		"",
+		"Api Key information:",
+		base.WrapMap(2, maxLength+2, nonAttributeMap),
+	}
+
+	if item.Scope != nil {
+		ret = append(ret,
+			"",
+			"  Scope:",
+			base.ScopeInfoForOutput(item.Scope, maxLength),
+		)
+	}
+
+	if len(item.AllowedCidrs) > 0 {
+		ret = append(ret,
+			"",
+			"  Allowed CIDRs:",
+			base.WrapSlice(4, item.AllowedCidrs),
+		)
+	}
+
+	if len(item.AuthorizedActions) > 0 {
+		ret = append(ret,
+			"",
+			"  Authorized Actions:",
+			base.WrapSlice(4, item.AuthorizedActions),
+		)
+	}
+
+	if item.Token != "" {
+		ret = append(ret,
+			"",
+			"  The token is only shown once. Store it securely; it cannot be retrieved again.",
+		)
+	}
+
+	return base.WrapForHelpText(ret)
+}
@@ -9,6 +9,8 @@ import (
 	"strings"
 
 	"github.com/hashicorp/boundary/api/authmethods"
+	"github.com/hashicorp/boundary/internal/auth/jwt"
+	"github.com/hashicorp/boundary/internal/auth/ldap"
 	"github.com/hashicorp/boundary/internal/auth/oidc"
 	"github.com/hashicorp/boundary/internal/auth/password"
 	"github.com/hashicorp/boundary/internal/cmd/base"
@@ -50,6 +52,14 @@ func (c *Command) Help() string {
 		"",
 		"      $ boundary authenticate oidc -auth-method-id amoidc_1234567890",
 		"",
+		"    Authenticate with an LDAP auth method using a specific auth method ID:",
+		"",
+		"      $ boundary authenticate ldap -auth-method-id amldap_1234567890",
+		"",
+		"    Authenticate with a JWT auth method using a specific auth method ID:",
+		"",
+		"      $ boundary authenticate jwt -auth-method-id amjwt_1234567890",
+		"",
 		"  Please see the auth method subcommand help for detailed usage information.",
 	}) + c.Flags().Help()
 }
@@ -108,8 +118,16 @@ func (c *Command) Run(args []string) int {
 		cmd := OidcCommand{Command: c.Command, Opts: []common.Option{common.WithSkipScopeIdFlag(true)}}
 		cmd.Run([]string{})
 
+	case strings.HasPrefix(c.FlagAuthMethodId, ldap.AuthMethodPrefix):
+		cmd := LdapCommand{Command: c.Command, Opts: []common.Option{common.WithSkipScopeIdFlag(true)}}
+		cmd.Run([]string{})
+
+	case strings.HasPrefix(c.FlagAuthMethodId, jwt.AuthMethodPrefix):
+		cmd := JwtCommand{Command: c.Command, Opts: []common.Option{common.WithSkipScopeIdFlag(true)}}
+		cmd.Run([]string{})
+
 	default:
-		c.PrintCliError(fmt.Errorf("The primary auth method was of an unsupported type. The given ID was %s; only 'ampw' (password) and 'amoidc' (OIDC) auth method prefixes are supported.", c.FlagAuthMethodId))
+		c.PrintCliError(fmt.Errorf("The primary auth method was of an unsupported type. The given ID was %s; only 'ampw' (password), 'amoidc' (OIDC), 'amldap' (LDAP) and 'amjwt' (JWT) auth method prefixes are supported.", c.FlagAuthMethodId))
 		return cli.RunResultHelp
 	}
 
@@ -32,6 +32,8 @@ type OidcCommand struct {
 
 	Opts       []common.Option
 	parsedOpts *common.Options
+
+	flagDevice bool
 }
 
 func (c *OidcCommand) Synopsis() string {
@@ -46,6 +48,10 @@ func (c *OidcCommand) Help() string {
 		"",
 		`    $ boundary authenticate oidc -auth-method-id amoidc_1234567890`,
 		"",
+		"  On hosts without a browser, use the provider's device authorization flow and complete the authentication on another device:",
+		"",
+		`    $ boundary authenticate oidc -auth-method-id amoidc_1234567890 -device`,
+		"",
 		"",
 	}) + c.Flags().Help()
 }
@@ -61,6 +67,12 @@ func (c *OidcCommand) Flags() *base.FlagSets {
 		Usage:  "The auth-method resource to use for the operation",
 	})
 
+	f.BoolVar(&base.BoolVar{
+		Name:   "device",
+		Target: &c.flagDevice,
+		Usage:  "Use the provider's device authorization flow instead of opening a browser. The provider must support the device authorization grant.",
+	})
+
 	if c.parsedOpts == nil || !c.parsedOpts.WithSkipScopeIdFlag {
 		f.StringVar(&base.StringVar{
 			Name:   "scope-id",
@@ -127,7 +139,13 @@ func (c *OidcCommand) Run(args []string) int {
 		c.FlagAuthMethodId = pri
 	}
 
-	result, err := aClient.Authenticate(c.Context, c.FlagAuthMethodId, "start", nil)
+	var startAttrs map[string]any
+	if c.flagDevice {
+		startAttrs = map[string]any{
+			"device_flow": true,
+		}
+	}
+	result, err := aClient.Authenticate(c.Context, c.FlagAuthMethodId, "start", startAttrs)
 	if err != nil {
 		if apiErr := api.AsServerError(err); apiErr != nil {
 			c.PrintApiError(apiErr, "Error from controller when performing authentication start")
@@ -143,13 +161,27 @@ func (c *OidcCommand) Run(args []string) int {
 		return base.CommandCliError
 	}
 
-	if base.Format(c.UI) == "table" {
-		c.UI.Output("Opening returned authentication URL in your browser...")
-	}
-	if err := util.OpenURL(startResp.AuthUrl); err != nil {
-		c.UI.Error(fmt.Errorf("Unable to open authentication URL in browser: %w", err).Error())
-		c.UI.Warn("Please open the following URL manually in your web browser:")
-		c.UI.Output(startResp.AuthUrl)
+	pollInterval := 1500 * time.Millisecond
+	switch {
+	case c.flagDevice:
+		if startResp.Interval > 0 {
+			pollInterval = time.Duration(startResp.Interval) * time.Second
+		}
+		verificationUri, codeAction := startResp.VerificationUri, "enter"
+		if startResp.VerificationUriComplete != "" {
+			verificationUri, codeAction = startResp.VerificationUriComplete, "confirm"
+		}
+		c.UI.Warn(fmt.Sprintf("To authenticate, open the following URL in a web browser on any device and %s the code %s:", codeAction, startResp.UserCode))
+		c.UI.Output(verificationUri)
+	default:
+		if base.Format(c.UI) == "table" {
+			c.UI.Output("Opening returned authentication URL in your browser...")
+		}
+		if err := util.OpenURL(startResp.AuthUrl); err != nil {
+			c.UI.Error(fmt.Errorf("Unable to open authentication URL in browser: %w", err).Error())
+			c.UI.Warn("Please open the following URL manually in your web browser:")
+			c.UI.Output(startResp.AuthUrl)
+		}
 	}
 
 	var watchCode int
@@ -164,7 +196,7 @@ func (c *OidcCommand) Run(args []string) int {
 				watchCode = base.CommandCliError
 				return
 
-			case <-time.After(1500 * time.Millisecond):
+			case <-time.After(pollInterval):
 				result, err = aClient.Authenticate(c.Context, c.FlagAuthMethodId, "token", map[string]any{
 					"token_id": startResp.TokenId,
 				})
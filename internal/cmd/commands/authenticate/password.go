@@ -28,13 +28,23 @@ var (
 var (
 	envPassword  = "BOUNDARY_AUTHENTICATE_PASSWORD_PASSWORD"
 	envLoginName = "BOUNDARY_AUTHENTICATE_PASSWORD_LOGIN_NAME"
+	envTotpCode  = "BOUNDARY_AUTHENTICATE_PASSWORD_TOTP_CODE"
+
+	envNewPassword = "BOUNDARY_AUTHENTICATE_PASSWORD_NEW_PASSWORD"
 )
 
+// totpCodeLen is the length of a TOTP code; longer values are treated as
+// recovery codes.
+const totpCodeLen = 6
+
 type PasswordCommand struct {
 	*base.Command
 
 	flagLoginName string
 	flagPassword  string
+	flagTotpCode  string
+
+	flagNewPassword string
 
 	Opts       []common.Option
 	parsedOpts *common.Options
@@ -74,6 +84,20 @@ func (c *PasswordCommand) Flags() *base.FlagSets {
 		Usage:  "The password associated with the login name. If blank, the command will prompt for the password to be entered interactively in a non-echoing way. Otherwise, this can refer to a file on disk (file://) from which a password will be read or an env var (env://) from which the password will be read.",
 	})
 
+	f.StringVar(&base.StringVar{
+		Name:   "new-password",
+		Target: &c.flagNewPassword,
+		EnvVar: envNewPassword,
+		Usage:  "A new password for the account, used only if its password has expired. The expired password is changed to it before authenticating. This can refer to a file on disk (file://) from which the password will be read or an env var (env://) from which the password will be read.",
+	})
+
+	f.StringVar(&base.StringVar{
+		Name:   "totp-code",
+		Target: &c.flagTotpCode,
+		EnvVar: envTotpCode,
+		Usage:  "The TOTP code or a recovery code of the account, if it has enrolled a TOTP second factor. If blank and one is required, the command will prompt for it to be entered interactively.",
+	})
+
 	f.StringVar(&base.StringVar{
 		Name:   "auth-method-id",
 		EnvVar: "BOUNDARY_AUTH_METHOD_ID",
@@ -184,11 +208,25 @@ func (c *PasswordCommand) Run(args []string) int {
 		c.flagPassword = password
 	}
 
-	result, err := aClient.Authenticate(c.Context, c.FlagAuthMethodId, "login",
-		map[string]any{
-			"login_name": c.flagLoginName,
-			"password":   c.flagPassword,
-		})
+	loginAttrs := map[string]any{
+		"login_name": c.flagLoginName,
+		"password":   c.flagPassword,
+	}
+	if c.flagNewPassword != "" {
+		newPassword, err := parseutil.MustParsePath(c.flagNewPassword)
+		switch {
+		case err == nil:
+		case errors.Is(err, parseutil.ErrNotParsed):
+			c.UI.Error("New password flag must be used with env:// or file:// syntax")
+			return base.CommandUserError
+		default:
+			c.UI.Error(fmt.Sprintf("Error parsing new password flag: %v", err))
+			return base.CommandUserError
+		}
+		loginAttrs["new_password"] = newPassword
+	}
+
+	result, err := aClient.Authenticate(c.Context, c.FlagAuthMethodId, "login", loginAttrs)
 	if err != nil {
 		if apiErr := api.AsServerError(err); apiErr != nil {
 			c.PrintApiError(apiErr, "Error from controller when performing authentication")
@@ -198,5 +236,37 @@ func (c *PasswordCommand) Run(args []string) int {
 		return base.CommandCliError
 	}
 
+	// An account with a TOTP second factor gets a pending token which must be
+	// completed with a code.
+	if tokenId, ok := result.Attributes["token_id"].(string); ok && tokenId != "" {
+		if c.flagTotpCode == "" {
+			fmt.Print("Please enter the TOTP code or a recovery code: ")
+			value, err := password.Read(os.Stdin)
+			fmt.Print("\n")
+			if err != nil {
+				c.UI.Error(fmt.Sprintf("An error occurred attempting to read the TOTP code. The raw error message is shown below but usually this is because you attempted to pipe a value into the command or you are executing outside of a terminal (TTY). The raw error was:\n\n%s", err.Error()))
+				return base.CommandUserError
+			}
+			c.flagTotpCode = strings.TrimSpace(value)
+		}
+		attrs := map[string]any{
+			"token_id": tokenId,
+		}
+		if len(c.flagTotpCode) == totpCodeLen {
+			attrs["code"] = c.flagTotpCode
+		} else {
+			attrs["recovery_code"] = c.flagTotpCode
+		}
+		result, err = aClient.Authenticate(c.Context, c.FlagAuthMethodId, "totp", attrs)
+		if err != nil {
+			if apiErr := api.AsServerError(err); apiErr != nil {
+				c.PrintApiError(apiErr, "Error from controller when performing authentication")
+				return base.CommandApiError
+			}
+			c.PrintCliError(fmt.Errorf("Error trying to perform authentication: %w", err))
+			return base.CommandCliError
+		}
+	}
+
 	return saveAndOrPrintToken(c.Command, result)
 }
@@ -0,0 +1,184 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package authmethodscmd
+
+import (
+	"github.com/hashicorp/boundary/api/authmethods"
+	"github.com/hashicorp/boundary/internal/cmd/base"
+)
+
+func init() {
+	extraJwtActionsFlagsMapFunc = extraJwtActionsFlagsMapFuncImpl
+	extraJwtFlagsFunc = extraJwtFlagsFuncImpl
+	extraJwtFlagsHandlingFunc = extraJwtFlagHandlingFuncImpl
+}
+
+type extraJwtCmdVars struct {
+	flagIssuer            string
+	flagJwksUrl           string
+	flagJwksCaCerts       []string
+	flagPublicKeys        []string
+	flagBoundAudiences    []string
+	flagSigningAlgorithms []string
+	flagAccountClaimMaps  []string
+}
+
+const (
+	jwtIssuerFlagName            = "issuer"
+	jwtJwksUrlFlagName           = "jwks-url"
+	jwtJwksCaCertsFlagName       = "jwks-ca-cert"
+	jwtPublicKeysFlagName        = "public-key"
+	jwtBoundAudiencesFlagName    = "bound-audience"
+	jwtSigningAlgorithmsFlagName = "signing-algorithm"
+	jwtAccountClaimMapsFlagName  = "account-claim-maps"
+)
+
+func extraJwtActionsFlagsMapFuncImpl() map[string][]string {
+	flags := map[string][]string{
+		"create": {
+			jwtIssuerFlagName,
+			jwtJwksUrlFlagName,
+			jwtJwksCaCertsFlagName,
+			jwtPublicKeysFlagName,
+			jwtBoundAudiencesFlagName,
+			jwtSigningAlgorithmsFlagName,
+			jwtAccountClaimMapsFlagName,
+		},
+	}
+	flags["update"] = flags["create"]
+	return flags
+}
+
+func extraJwtFlagsFuncImpl(c *JwtCommand, set *base.FlagSets, _ *base.FlagSet) {
+	f := set.NewFlagSet("JWT Auth Method Options")
+
+	for _, name := range flagsJwtMap[c.Func] {
+		switch name {
+		case jwtIssuerFlagName:
+			f.StringVar(&base.StringVar{
+				Name:   jwtIssuerFlagName,
+				Target: &c.flagIssuer,
+				Usage:  "The expected iss claim of presented tokens. If unset, any issuer is accepted.",
+			})
+		case jwtJwksUrlFlagName:
+			f.StringVar(&base.StringVar{
+				Name:   jwtJwksUrlFlagName,
+				Target: &c.flagJwksUrl,
+				Usage:  "The URL of the JSON Web Key Set used to verify token signatures. Cannot be combined with -public-key.",
+			})
+		case jwtJwksCaCertsFlagName:
+			f.StringSliceVar(&base.StringSliceVar{
+				Name:   jwtJwksCaCertsFlagName,
+				Target: &c.flagJwksCaCerts,
+				Usage:  "Optional PEM-encoded X.509 CA certificate that can be used as a trust anchor when fetching the JSON Web Key Set. May be specified multiple times.",
+			})
+		case jwtPublicKeysFlagName:
+			f.StringSliceVar(&base.StringSliceVar{
+				Name:   jwtPublicKeysFlagName,
+				Target: &c.flagPublicKeys,
+				Usage:  "A PEM-encoded public key used to verify token signatures. Cannot be combined with -jwks-url. May be specified multiple times.",
+			})
+		case jwtBoundAudiencesFlagName:
+			f.StringSliceVar(&base.StringSliceVar{
+				Name:   jwtBoundAudiencesFlagName,
+				Target: &c.flagBoundAudiences,
+				Usage:  "An audience that must be present in the aud claim of presented tokens. May be specified multiple times.",
+			})
+		case jwtSigningAlgorithmsFlagName:
+			f.StringSliceVar(&base.StringSliceVar{
+				Name:   jwtSigningAlgorithmsFlagName,
+				Target: &c.flagSigningAlgorithms,
+				Usage:  "A signing algorithm allowed for presented tokens. Defaults to RS256. May be specified multiple times.",
+			})
+		case jwtAccountClaimMapsFlagName:
+			f.StringSliceVar(&base.StringSliceVar{
+				Name:   jwtAccountClaimMapsFlagName,
+				Target: &c.flagAccountClaimMaps,
+				Usage:  `The optional account claim maps from custom claims to the standard claims of sub, name and email. These maps are represented as key=value where the key equals the token from-claim and the value equals the Boundary to-claim. For example "oid=sub". May be specified multiple times for different to-claims.`,
+			})
+		}
+	}
+}
+
+func (c *JwtCommand) extraJwtHelpFunc(helpMap map[string]func() string) string {
+	var helpStr string
+	switch c.Func {
+	case "create":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary auth-methods create jwt [options] [args]",
+			"",
+			"  Create a jwt-type auth method. Example:",
+			"",
+			`    $ boundary auth-methods create jwt -name ci -issuer https://token.actions.githubusercontent.com -jwks-url https://token.actions.githubusercontent.com/.well-known/jwks -bound-audience boundary -description "Jwt auth-method for CI workloads"`,
+			"",
+			"",
+		})
+
+	case "update":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary auth-methods update jwt [options] [args]",
+			"",
+			"  Update a jwt-type auth method given its ID. Example:",
+			"",
+			`    $ boundary auth-methods update jwt -id amjwt_1234567890 -name "devops" -description "Jwt auth-method for DevOps workloads"`,
+			"",
+			"",
+		})
+	}
+	return helpStr + c.Flags().Help()
+}
+
+func extraJwtFlagHandlingFuncImpl(c *JwtCommand, _ *base.FlagSets, opts *[]authmethods.Option) bool {
+	switch c.flagIssuer {
+	case "":
+	case "null":
+		*opts = append(*opts, authmethods.DefaultJwtAuthMethodIssuer())
+	default:
+		*opts = append(*opts, authmethods.WithJwtAuthMethodIssuer(c.flagIssuer))
+	}
+	switch c.flagJwksUrl {
+	case "":
+	case "null":
+		*opts = append(*opts, authmethods.DefaultJwtAuthMethodJwksUrl())
+	default:
+		*opts = append(*opts, authmethods.WithJwtAuthMethodJwksUrl(c.flagJwksUrl))
+	}
+	switch {
+	case len(c.flagJwksCaCerts) == 0:
+	case len(c.flagJwksCaCerts) == 1 && c.flagJwksCaCerts[0] == "null":
+		*opts = append(*opts, authmethods.DefaultJwtAuthMethodJwksCaCerts())
+	default:
+		*opts = append(*opts, authmethods.WithJwtAuthMethodJwksCaCerts(c.flagJwksCaCerts))
+	}
+	switch {
+	case len(c.flagPublicKeys) == 0:
+	case len(c.flagPublicKeys) == 1 && c.flagPublicKeys[0] == "null":
+		*opts = append(*opts, authmethods.DefaultJwtAuthMethodPublicKeys())
+	default:
+		*opts = append(*opts, authmethods.WithJwtAuthMethodPublicKeys(c.flagPublicKeys))
+	}
+	switch {
+	case len(c.flagBoundAudiences) == 0:
+	case len(c.flagBoundAudiences) == 1 && c.flagBoundAudiences[0] == "null":
+		*opts = append(*opts, authmethods.DefaultJwtAuthMethodBoundAudiences())
+	default:
+		*opts = append(*opts, authmethods.WithJwtAuthMethodBoundAudiences(c.flagBoundAudiences))
+	}
+	switch {
+	case len(c.flagSigningAlgorithms) == 0:
+	case len(c.flagSigningAlgorithms) == 1 && c.flagSigningAlgorithms[0] == "null":
+		*opts = append(*opts, authmethods.DefaultJwtAuthMethodSigningAlgorithms())
+	default:
+		*opts = append(*opts, authmethods.WithJwtAuthMethodSigningAlgorithms(c.flagSigningAlgorithms))
+	}
+	switch {
+	case len(c.flagAccountClaimMaps) == 0:
+	case len(c.flagAccountClaimMaps) == 1 && c.flagAccountClaimMaps[0] == "null":
+		*opts = append(*opts, authmethods.DefaultJwtAuthMethodAccountClaimMaps())
+	default:
+		*opts = append(*opts, authmethods.WithJwtAuthMethodAccountClaimMaps(c.flagAccountClaimMaps))
+	}
+
+	return true
+}
@@ -0,0 +1,324 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package authmethodscmd
+
+import (
+	"github.com/hashicorp/boundary/api/authmethods"
+	"github.com/hashicorp/boundary/internal/cmd/base"
+)
+
+func init() {
+	extraLdapActionsFlagsMapFunc = extraLdapActionsFlagsMapFuncImpl
+	extraLdapFlagsFunc = extraLdapFlagsFuncImpl
+	extraLdapFlagsHandlingFunc = extraLdapFlagHandlingFuncImpl
+}
+
+type extraLdapCmdVars struct {
+	flagUrls                 []string
+	flagStartTls             bool
+	flagInsecureTls          bool
+	flagDiscoverDn           bool
+	flagAnonGroupSearch      bool
+	flagUpnDomain            string
+	flagUserDn               string
+	flagUserAttr             string
+	flagUserFilter           string
+	flagEnableGroups         bool
+	flagGroupDn              string
+	flagGroupAttr            string
+	flagGroupFilter          string
+	flagCertificates         []string
+	flagBindDn               string
+	flagBindPassword         string
+	flagAccountAttributeMaps []string
+}
+
+const (
+	ldapUrlsFlagName                 = "url"
+	ldapStartTlsFlagName             = "start-tls"
+	ldapInsecureTlsFlagName          = "insecure-tls"
+	ldapDiscoverDnFlagName           = "discover-dn"
+	ldapAnonGroupSearchFlagName      = "anon-group-search"
+	ldapUpnDomainFlagName            = "upn-domain"
+	ldapUserDnFlagName               = "user-dn"
+	ldapUserAttrFlagName             = "user-attr"
+	ldapUserFilterFlagName           = "user-filter"
+	ldapEnableGroupsFlagName         = "enable-groups"
+	ldapGroupDnFlagName              = "group-dn"
+	ldapGroupAttrFlagName            = "group-attr"
+	ldapGroupFilterFlagName          = "group-filter"
+	ldapCertificatesFlagName         = "certificate"
+	ldapBindDnFlagName               = "bind-dn"
+	ldapBindPasswordFlagName         = "bind-password"
+	ldapAccountAttributeMapsFlagName = "account-attribute-map"
+)
+
+func extraLdapActionsFlagsMapFuncImpl() map[string][]string {
+	flags := map[string][]string{
+		"create": {
+			ldapUrlsFlagName,
+			ldapStartTlsFlagName,
+			ldapInsecureTlsFlagName,
+			ldapDiscoverDnFlagName,
+			ldapAnonGroupSearchFlagName,
+			ldapUpnDomainFlagName,
+			ldapUserDnFlagName,
+			ldapUserAttrFlagName,
+			ldapUserFilterFlagName,
+			ldapEnableGroupsFlagName,
+			ldapGroupDnFlagName,
+			ldapGroupAttrFlagName,
+			ldapGroupFilterFlagName,
+			ldapCertificatesFlagName,
+			ldapBindDnFlagName,
+			ldapBindPasswordFlagName,
+			ldapAccountAttributeMapsFlagName,
+		},
+	}
+	flags["update"] = flags["create"]
+	return flags
+}
+
+func extraLdapFlagsFuncImpl(c *LdapCommand, set *base.FlagSets, _ *base.FlagSet) {
+	f := set.NewFlagSet("LDAP Auth Method Options")
+
+	for _, name := range flagsLdapMap[c.Func] {
+		switch name {
+		case ldapUrlsFlagName:
+			f.StringSliceVar(&base.StringSliceVar{
+				Name:   ldapUrlsFlagName,
+				Target: &c.flagUrls,
+				Usage:  "The ldap URLs that specify the directory servers to connect to. May be specified multiple times.",
+			})
+		case ldapStartTlsFlagName:
+			f.BoolVar(&base.BoolVar{
+				Name:   ldapStartTlsFlagName,
+				Target: &c.flagStartTls,
+				Usage:  "Issue StartTLS command after connecting to the directory.",
+			})
+		case ldapInsecureTlsFlagName:
+			f.BoolVar(&base.BoolVar{
+				Name:   ldapInsecureTlsFlagName,
+				Target: &c.flagInsecureTls,
+				Usage:  "Skip the directory server's certificate validation. Insecure and should be used only for testing.",
+			})
+		case ldapDiscoverDnFlagName:
+			f.BoolVar(&base.BoolVar{
+				Name:   ldapDiscoverDnFlagName,
+				Target: &c.flagDiscoverDn,
+				Usage:  "Use anonymous bind to discover the bind DN of a user.",
+			})
+		case ldapAnonGroupSearchFlagName:
+			f.BoolVar(&base.BoolVar{
+				Name:   ldapAnonGroupSearchFlagName,
+				Target: &c.flagAnonGroupSearch,
+				Usage:  "Use anonymous bind when performing group search.",
+			})
+		case ldapUpnDomainFlagName:
+			f.StringVar(&base.StringVar{
+				Name:   ldapUpnDomainFlagName,
+				Target: &c.flagUpnDomain,
+				Usage:  "The userPrincipalDomain used to construct the UPN string for the authenticating user.",
+			})
+		case ldapUserDnFlagName:
+			f.StringVar(&base.StringVar{
+				Name:   ldapUserDnFlagName,
+				Target: &c.flagUserDn,
+				Usage:  "The base DN under which to perform user search.",
+			})
+		case ldapUserAttrFlagName:
+			f.StringVar(&base.StringVar{
+				Name:   ldapUserAttrFlagName,
+				Target: &c.flagUserAttr,
+				Usage:  "The attribute on user entries matching the username passed when authenticating.",
+			})
+		case ldapUserFilterFlagName:
+			f.StringVar(&base.StringVar{
+				Name:   ldapUserFilterFlagName,
+				Target: &c.flagUserFilter,
+				Usage:  "A go template used to construct an LDAP user search filter.",
+			})
+		case ldapEnableGroupsFlagName:
+			f.BoolVar(&base.BoolVar{
+				Name:   ldapEnableGroupsFlagName,
+				Target: &c.flagEnableGroups,
+				Usage:  "Find the authenticated user's groups during authentication.",
+			})
+		case ldapGroupDnFlagName:
+			f.StringVar(&base.StringVar{
+				Name:   ldapGroupDnFlagName,
+				Target: &c.flagGroupDn,
+				Usage:  "The base DN under which to perform group search.",
+			})
+		case ldapGroupAttrFlagName:
+			f.StringVar(&base.StringVar{
+				Name:   ldapGroupAttrFlagName,
+				Target: &c.flagGroupAttr,
+				Usage:  "The attribute that enumerates a user's group membership from entries returned by a group search.",
+			})
+		case ldapGroupFilterFlagName:
+			f.StringVar(&base.StringVar{
+				Name:   ldapGroupFilterFlagName,
+				Target: &c.flagGroupFilter,
+				Usage:  "A go template used to construct an LDAP group search filter.",
+			})
+		case ldapCertificatesFlagName:
+			f.StringSliceVar(&base.StringSliceVar{
+				Name:   ldapCertificatesFlagName,
+				Target: &c.flagCertificates,
+				Usage:  "Optional PEM-encoded X.509 CA certificate that can be used as a trust anchor when connecting to the directory. May be specified multiple times.",
+			})
+		case ldapBindDnFlagName:
+			f.StringVar(&base.StringVar{
+				Name:   ldapBindDnFlagName,
+				Target: &c.flagBindDn,
+				Usage:  "The distinguished name of the entry to bind to when performing user and group searches.",
+			})
+		case ldapBindPasswordFlagName:
+			f.StringVar(&base.StringVar{
+				Name:   ldapBindPasswordFlagName,
+				Target: &c.flagBindPassword,
+				Usage:  "The password to use along with the bind DN when performing user and group searches.",
+			})
+		case ldapAccountAttributeMapsFlagName:
+			f.StringSliceVar(&base.StringSliceVar{
+				Name:   ldapAccountAttributeMapsFlagName,
+				Target: &c.flagAccountAttributeMaps,
+				Usage:  `The optional account attribute maps from custom attributes to the standard attributes of fullName and email. These maps are represented as key=value where the key equals the from-attribute and the value equals the to-attribute. For example "preferredName=fullName". May be specified multiple times.`,
+			})
+		}
+	}
+}
+
+func (c *LdapCommand) extraLdapHelpFunc(helpMap map[string]func() string) string {
+	var helpStr string
+	switch c.Func {
+	case "create":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary auth-methods create ldap [options] [args]",
+			"",
+			"  Create an ldap-type auth method. Example:",
+			"",
+			`    $ boundary auth-methods create ldap -name prodops -url ldaps://ldap.example.com -user-dn "ou=people,dc=example,dc=com" -description "Ldap auth-method for ProdOps"`,
+			"",
+			"",
+		})
+
+	case "update":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary auth-methods update ldap [options] [args]",
+			"",
+			"  Update an ldap-type auth method given its ID. Example:",
+			"",
+			`    $ boundary auth-methods update ldap -id amldap_1234567890 -name "devops" -description "Ldap auth-method for DevOps"`,
+			"",
+			"",
+		})
+	}
+	return helpStr + c.Flags().Help()
+}
+
+func extraLdapFlagHandlingFuncImpl(c *LdapCommand, _ *base.FlagSets, opts *[]authmethods.Option) bool {
+	switch {
+	case len(c.flagUrls) == 0:
+	case len(c.flagUrls) == 1 && c.flagUrls[0] == "null":
+		*opts = append(*opts, authmethods.DefaultLdapAuthMethodUrls())
+	default:
+		*opts = append(*opts, authmethods.WithLdapAuthMethodUrls(c.flagUrls))
+	}
+	if c.flagStartTls {
+		*opts = append(*opts, authmethods.WithLdapAuthMethodStartTls(c.flagStartTls))
+	}
+	if c.flagInsecureTls {
+		*opts = append(*opts, authmethods.WithLdapAuthMethodInsecureTls(c.flagInsecureTls))
+	}
+	if c.flagDiscoverDn {
+		*opts = append(*opts, authmethods.WithLdapAuthMethodDiscoverDn(c.flagDiscoverDn))
+	}
+	if c.flagAnonGroupSearch {
+		*opts = append(*opts, authmethods.WithLdapAuthMethodAnonGroupSearch(c.flagAnonGroupSearch))
+	}
+	switch c.flagUpnDomain {
+	case "":
+	case "null":
+		*opts = append(*opts, authmethods.DefaultLdapAuthMethodUpnDomain())
+	default:
+		*opts = append(*opts, authmethods.WithLdapAuthMethodUpnDomain(c.flagUpnDomain))
+	}
+	switch c.flagUserDn {
+	case "":
+	case "null":
+		*opts = append(*opts, authmethods.DefaultLdapAuthMethodUserDn())
+	default:
+		*opts = append(*opts, authmethods.WithLdapAuthMethodUserDn(c.flagUserDn))
+	}
+	switch c.flagUserAttr {
+	case "":
+	case "null":
+		*opts = append(*opts, authmethods.DefaultLdapAuthMethodUserAttr())
+	default:
+		*opts = append(*opts, authmethods.WithLdapAuthMethodUserAttr(c.flagUserAttr))
+	}
+	switch c.flagUserFilter {
+	case "":
+	case "null":
+		*opts = append(*opts, authmethods.DefaultLdapAuthMethodUserFilter())
+	default:
+		*opts = append(*opts, authmethods.WithLdapAuthMethodUserFilter(c.flagUserFilter))
+	}
+	if c.flagEnableGroups {
+		*opts = append(*opts, authmethods.WithLdapAuthMethodEnableGroups(c.flagEnableGroups))
+	}
+	switch c.flagGroupDn {
+	case "":
+	case "null":
+		*opts = append(*opts, authmethods.DefaultLdapAuthMethodGroupDn())
+	default:
+		*opts = append(*opts, authmethods.WithLdapAuthMethodGroupDn(c.flagGroupDn))
+	}
+	switch c.flagGroupAttr {
+	case "":
+	case "null":
+		*opts = append(*opts, authmethods.DefaultLdapAuthMethodGroupAttr())
+	default:
+		*opts = append(*opts, authmethods.WithLdapAuthMethodGroupAttr(c.flagGroupAttr))
+	}
+	switch c.flagGroupFilter {
+	case "":
+	case "null":
+		*opts = append(*opts, authmethods.DefaultLdapAuthMethodGroupFilter())
+	default:
+		*opts = append(*opts, authmethods.WithLdapAuthMethodGroupFilter(c.flagGroupFilter))
+	}
+	switch {
+	case len(c.flagCertificates) == 0:
+	case len(c.flagCertificates) == 1 && c.flagCertificates[0] == "null":
+		*opts = append(*opts, authmethods.DefaultLdapAuthMethodCertificates())
+	default:
+		*opts = append(*opts, authmethods.WithLdapAuthMethodCertificates(c.flagCertificates))
+	}
+	switch c.flagBindDn {
+	case "":
+	case "null":
+		*opts = append(*opts, authmethods.DefaultLdapAuthMethodBindDn())
+	default:
+		*opts = append(*opts, authmethods.WithLdapAuthMethodBindDn(c.flagBindDn))
+	}
+	switch c.flagBindPassword {
+	case "":
+	case "null":
+		*opts = append(*opts, authmethods.DefaultLdapAuthMethodBindPassword())
+	default:
+		*opts = append(*opts, authmethods.WithLdapAuthMethodBindPassword(c.flagBindPassword))
+	}
+	switch {
+	case len(c.flagAccountAttributeMaps) == 0:
+	case len(c.flagAccountAttributeMaps) == 1 && c.flagAccountAttributeMaps[0] == "null":
+		*opts = append(*opts, authmethods.DefaultLdapAuthMethodAccountAttributeMaps())
+	default:
+		*opts = append(*opts, authmethods.WithLdapAuthMethodAccountAttributeMaps(c.flagAccountAttributeMaps))
+	}
+
+	return true
+}
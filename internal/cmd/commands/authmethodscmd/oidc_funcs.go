@@ -31,6 +31,9 @@ type extraOidcCmdVars struct {
 	flagAllowedAudiences                  []string
 	flagClaimsScopes                      []string
 	flagAccountClaimMaps                  []string
+	flagPrompts                           []string
+	flagAcrValues                         []string
+	flagEnablePkce                        string
 	flagDisableDiscoveredConfigValidation bool
 	flagDryRun                            bool
 }
@@ -47,6 +50,9 @@ const (
 	allowedAudienceFlagName                   = "allowed-audience"
 	claimsScopes                              = "claims-scopes"
 	accountClaimMaps                          = "account-claim-maps"
+	promptFlagName                            = "prompt"
+	acrValueFlagName                          = "acr-value"
+	enablePkceFlagName                        = "enable-pkce"
 	stateFlagName                             = "state"
 	disableDiscoveredConfigValidationFlagName = "disable-discovered-config-validation"
 	dryRunFlagName                            = "dry-run"
@@ -65,6 +71,9 @@ func extraOidcActionsFlagsMapFuncImpl() map[string][]string {
 			allowedAudienceFlagName,
 			claimsScopes,
 			accountClaimMaps,
+			promptFlagName,
+			acrValueFlagName,
+			enablePkceFlagName,
 		},
 		"change-state": {
 			idFlagName,
@@ -141,6 +150,24 @@ func extraOidcFlagsFuncImpl(c *OidcCommand, set *base.FlagSets, _ *base.FlagSet)
 				Target: &c.flagAccountClaimMaps,
 				Usage:  `The optional account claim maps from custom claims to the standard claims of sub, name and email.  These maps are represented as key=value where the key equals the Provider from-claim and the value equals the Boundary to-claim.  For example "oid=sub". May be specified multiple times for different to-claims.`,
 			})
+		case promptFlagName:
+			f.StringSliceVar(&base.StringSliceVar{
+				Name:   promptFlagName,
+				Target: &c.flagPrompts,
+				Usage:  `The optional OIDC "prompt" parameter sent to the provider. Supported values are "none", "login", "consent" and "select_account"; "none" cannot be combined with other values. May be specified multiple times.`,
+			})
+		case acrValueFlagName:
+			f.StringSliceVar(&base.StringSliceVar{
+				Name:   acrValueFlagName,
+				Target: &c.flagAcrValues,
+				Usage:  `The optional OIDC "acr_values" parameter sent to the provider. When set, the ID Token's "acr" claim must be one of them. May be specified multiple times.`,
+			})
+		case enablePkceFlagName:
+			f.StringVar(&base.StringVar{
+				Name:   enablePkceFlagName,
+				Target: &c.flagEnablePkce,
+				Usage:  "If set to true, the auth method uses PKCE (RFC 7636) with the S256 challenge method when authenticating with the provider.",
+			})
 		case stateFlagName:
 			f.StringVar(&base.StringVar{
 				Name:   stateFlagName,
@@ -277,6 +304,32 @@ func extraOidcFlagHandlingFuncImpl(c *OidcCommand, f *base.FlagSets, opts *[]aut
 	default:
 		*opts = append(*opts, authmethods.WithOidcAuthMethodAccountClaimMaps(c.flagAccountClaimMaps))
 	}
+	switch {
+	case len(c.flagPrompts) == 0:
+	case len(c.flagPrompts) == 1 && c.flagPrompts[0] == "null":
+		*opts = append(*opts, authmethods.DefaultOidcAuthMethodPrompts())
+	default:
+		*opts = append(*opts, authmethods.WithOidcAuthMethodPrompts(c.flagPrompts))
+	}
+	switch {
+	case len(c.flagAcrValues) == 0:
+	case len(c.flagAcrValues) == 1 && c.flagAcrValues[0] == "null":
+		*opts = append(*opts, authmethods.DefaultOidcAuthMethodAcrValues())
+	default:
+		*opts = append(*opts, authmethods.WithOidcAuthMethodAcrValues(c.flagAcrValues))
+	}
+	switch c.flagEnablePkce {
+	case "":
+	case "null":
+		*opts = append(*opts, authmethods.DefaultOidcAuthMethodEnablePkce())
+	default:
+		enable, err := strconv.ParseBool(c.flagEnablePkce)
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("Error parsing %q: %s", c.flagEnablePkce, err))
+			return false
+		}
+		*opts = append(*opts, authmethods.WithOidcAuthMethodEnablePkce(enable))
+	}
 	if c.flagDisableDiscoveredConfigValidation {
 		*opts = append(*opts, authmethods.WithOidcAuthMethodDisableDiscoveredConfigValidation(c.flagDisableDiscoveredConfigValidation))
 	}
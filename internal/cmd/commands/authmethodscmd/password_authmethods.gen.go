@@ -180,6 +180,14 @@ func (c *PasswordCommand) Run(args []string) int {
 		opts = append(opts, authmethods.WithFilter(c.FlagFilter))
 	}
 
+	if c.FlagPageSize != 0 {
+		opts = append(opts, authmethods.WithPageSize(uint32(c.FlagPageSize)))
+	}
+
+	if c.FlagListToken != "" {
+		opts = append(opts, authmethods.WithListToken(c.FlagListToken))
+	}
+
 	var version uint32
 
 	switch c.Func {
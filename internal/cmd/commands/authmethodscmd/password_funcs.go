@@ -18,14 +18,22 @@ func init() {
 }
 
 type extraPasswordCmdVars struct {
-	flagMinLoginNameLength string
-	flagMinPasswordLength  string
+	flagMinLoginNameLength   string
+	flagMinPasswordLength    string
+	flagMaxFailedAttempts    string
+	flagLockoutDuration      string
+	flagSourceIpRateLimit    string
+	flagMfaRequired          string
+	flagMinPasswordClasses   string
+	flagDenyCommonPasswords  string
+	flagPasswordHistoryCount string
+	flagMaxPasswordAge       string
 }
 
 func extraPasswordActionsFlagsMapFuncImpl() map[string][]string {
 	return map[string][]string{
-		"create": {"min-login-name-length", "min-password-length"},
-		"update": {"min-login-name-length", "min-password-length"},
+		"create": {"min-login-name-length", "min-password-length", "max-failed-attempts", "lockout-duration", "source-ip-rate-limit", "mfa-required", "min-password-classes", "deny-common-passwords", "password-history-count", "max-password-age"},
+		"update": {"min-login-name-length", "min-password-length", "max-failed-attempts", "lockout-duration", "source-ip-rate-limit", "mfa-required", "min-password-classes", "deny-common-passwords", "password-history-count", "max-password-age"},
 	}
 }
 
@@ -74,6 +82,54 @@ func extraPasswordFlagsFuncImpl(c *PasswordCommand, set *base.FlagSets, f *base.
 				Target: &c.flagMinPasswordLength,
 				Usage:  "The minimum length of passwords",
 			})
+		case "max-failed-attempts":
+			f.StringVar(&base.StringVar{
+				Name:   "max-failed-attempts",
+				Target: &c.flagMaxFailedAttempts,
+				Usage:  "The number of consecutive failed authentication attempts after which an account is locked. 0 disables account lockout",
+			})
+		case "lockout-duration":
+			f.StringVar(&base.StringVar{
+				Name:   "lockout-duration",
+				Target: &c.flagLockoutDuration,
+				Usage:  "The number of seconds a locked account stays locked. 0 keeps it locked until it is unlocked",
+			})
+		case "source-ip-rate-limit":
+			f.StringVar(&base.StringVar{
+				Name:   "source-ip-rate-limit",
+				Target: &c.flagSourceIpRateLimit,
+				Usage:  "The number of authentication attempts allowed per minute from a single source IP address. 0 disables rate limiting",
+			})
+		case "mfa-required":
+			f.StringVar(&base.StringVar{
+				Name:   "mfa-required",
+				Target: &c.flagMfaRequired,
+				Usage:  "If set to true, accounts must have a confirmed TOTP second factor to authenticate",
+			})
+		case "min-password-classes":
+			f.StringVar(&base.StringVar{
+				Name:   "min-password-classes",
+				Target: &c.flagMinPasswordClasses,
+				Usage:  "The minimum number of character classes (lowercase letters, uppercase letters, digits and symbols) passwords must contain, at most 4",
+			})
+		case "deny-common-passwords":
+			f.StringVar(&base.StringVar{
+				Name:   "deny-common-passwords",
+				Target: &c.flagDenyCommonPasswords,
+				Usage:  "If set to true, passwords found in a list of commonly used passwords are rejected",
+			})
+		case "password-history-count":
+			f.StringVar(&base.StringVar{
+				Name:   "password-history-count",
+				Target: &c.flagPasswordHistoryCount,
+				Usage:  "The number of previous passwords of an account which cannot be used again, at most 24. 0 disables the password history",
+			})
+		case "max-password-age":
+			f.StringVar(&base.StringVar{
+				Name:   "max-password-age",
+				Target: &c.flagMaxPasswordAge,
+				Usage:  "The number of seconds after which a password expires and must be changed at the next login. 0 means passwords do not expire",
+			})
 		}
 	}
 }
@@ -112,6 +168,110 @@ func extraPasswordFlagHandlingFuncImpl(c *PasswordCommand, _ *base.FlagSets, opt
 		addAttribute("min_password_length", uint32(length))
 	}
 
+	switch c.flagMaxFailedAttempts {
+	case "":
+	case "null":
+		addAttribute("max_failed_attempts", nil)
+	default:
+		attempts, err := strconv.ParseUint(c.flagMaxFailedAttempts, 10, 32)
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("Error parsing %q: %s", c.flagMaxFailedAttempts, err))
+			return false
+		}
+		addAttribute("max_failed_attempts", uint32(attempts))
+	}
+
+	switch c.flagLockoutDuration {
+	case "":
+	case "null":
+		addAttribute("lockout_duration", nil)
+	default:
+		duration, err := strconv.ParseUint(c.flagLockoutDuration, 10, 32)
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("Error parsing %q: %s", c.flagLockoutDuration, err))
+			return false
+		}
+		addAttribute("lockout_duration", uint32(duration))
+	}
+
+	switch c.flagSourceIpRateLimit {
+	case "":
+	case "null":
+		addAttribute("source_ip_rate_limit", nil)
+	default:
+		limit, err := strconv.ParseUint(c.flagSourceIpRateLimit, 10, 32)
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("Error parsing %q: %s", c.flagSourceIpRateLimit, err))
+			return false
+		}
+		addAttribute("source_ip_rate_limit", uint32(limit))
+	}
+
+	switch c.flagMfaRequired {
+	case "":
+	case "null":
+		addAttribute("mfa_required", nil)
+	default:
+		required, err := strconv.ParseBool(c.flagMfaRequired)
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("Error parsing %q: %s", c.flagMfaRequired, err))
+			return false
+		}
+		addAttribute("mfa_required", required)
+	}
+
+	switch c.flagMinPasswordClasses {
+	case "":
+	case "null":
+		addAttribute("min_password_classes", nil)
+	default:
+		classes, err := strconv.ParseUint(c.flagMinPasswordClasses, 10, 32)
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("Error parsing %q: %s", c.flagMinPasswordClasses, err))
+			return false
+		}
+		addAttribute("min_password_classes", uint32(classes))
+	}
+
+	switch c.flagDenyCommonPasswords {
+	case "":
+	case "null":
+		addAttribute("deny_common_passwords", nil)
+	default:
+		deny, err := strconv.ParseBool(c.flagDenyCommonPasswords)
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("Error parsing %q: %s", c.flagDenyCommonPasswords, err))
+			return false
+		}
+		addAttribute("deny_common_passwords", deny)
+	}
+
+	switch c.flagPasswordHistoryCount {
+	case "":
+	case "null":
+		addAttribute("password_history_count", nil)
+	default:
+		count, err := strconv.ParseUint(c.flagPasswordHistoryCount, 10, 32)
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("Error parsing %q: %s", c.flagPasswordHistoryCount, err))
+			return false
+		}
+		addAttribute("password_history_count", uint32(count))
+	}
+
+	switch c.flagMaxPasswordAge {
+	case "":
+	case "null":
+		addAttribute("max_password_age", nil)
+	default:
+		age, err := strconv.ParseUint(c.flagMaxPasswordAge, 10, 32)
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("Error parsing %q: %s", c.flagMaxPasswordAge, err))
+			return false
+		}
+		addAttribute("max_password_age", uint32(age))
+	}
+
 	if attributes != nil {
 		*opts = append(*opts, authmethods.WithAttributes(attributes))
 	}
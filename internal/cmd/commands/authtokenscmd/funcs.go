@@ -12,16 +12,121 @@ import (
 	"github.com/hashicorp/boundary/api/authtokens"
 	"github.com/hashicorp/boundary/internal/cmd/base"
 	"github.com/hashicorp/go-secure-stdlib/strutil"
+	"github.com/mitchellh/go-wordwrap"
 )
 
 const selfFlag = "self"
 
 func init() {
+	extraActionsFlagsMapFunc = extraActionsFlagsMapFuncImpl
+	extraSynopsisFunc = extraSynopsisFuncImpl
+	extraFlagsFunc = extraFlagsFuncImpl
 	extraFlagsHandlingFunc = extraFlagsHandlingFuncImpl
+	executeExtraActions = executeExtraActionsImpl
 }
 
-func extraFlagsHandlingFuncImpl(c *Command, _ *base.FlagSets, _ *[]authtokens.Option) bool {
-	if c.Func != "delete" && c.Func != "read" {
+type extraCmdVars struct {
+	flagUserId                    string
+	flagAccountId                 string
+	flagApproximateLastUsedBefore string
+	flagApproximateLastUsedAfter  string
+}
+
+func extraActionsFlagsMapFuncImpl() map[string][]string {
+	return map[string][]string{
+		"refresh": {"id"},
+		"list":    {"user-id", "account-id", "approximate-last-used-before", "approximate-last-used-after"},
+	}
+}
+
+func extraSynopsisFuncImpl(c *Command) string {
+	switch c.Func {
+	case "refresh":
+		return wordwrap.WrapString("Extend the expiration time of an auth token within Boundary", base.TermWidth)
+	}
+
+	return ""
+}
+
+func (c *Command) extraHelpFunc(helpMap map[string]func() string) string {
+	var helpStr string
+	switch c.Func {
+	case "refresh":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary auth-tokens refresh [options] [args]",
+			"",
+			`  Extend the expiration time of an auth token given its ID, or the stored token when the ID is "self". Expired and stale tokens cannot be refreshed, and the expiration time is never extended past the maximum lifetime of an auth token configured on the controller. Example:`,
+			"",
+			`    $ boundary auth-tokens refresh -id self`,
+			"",
+			"",
+		})
+
+	default:
+		helpStr = helpMap["base"]()
+	}
+	return helpStr + c.Flags().Help()
+}
+
+func extraFlagsFuncImpl(c *Command, _ *base.FlagSets, f *base.FlagSet) {
+	for _, name := range flagsMap[c.Func] {
+		switch name {
+		case "user-id":
+			f.StringVar(&base.StringVar{
+				Name:   "user-id",
+				Target: &c.flagUserId,
+				Usage:  "If set, only the auth tokens of this user are listed.",
+			})
+		case "account-id":
+			f.StringVar(&base.StringVar{
+				Name:   "account-id",
+				Target: &c.flagAccountId,
+				Usage:  "If set, only the auth tokens of this account are listed.",
+			})
+		case "approximate-last-used-before":
+			f.StringVar(&base.StringVar{
+				Name:   "approximate-last-used-before",
+				Target: &c.flagApproximateLastUsedBefore,
+				Usage:  "If set, only the auth tokens last used before this time, in RFC3339 format, are listed.",
+			})
+		case "approximate-last-used-after":
+			f.StringVar(&base.StringVar{
+				Name:   "approximate-last-used-after",
+				Target: &c.flagApproximateLastUsedAfter,
+				Usage:  "If set, only the auth tokens last used after this time, in RFC3339 format, are listed.",
+			})
+		}
+	}
+}
+
+func extraFlagsHandlingFuncImpl(c *Command, _ *base.FlagSets, opts *[]authtokens.Option) bool {
+	if c.Func == "list" {
+		if c.flagUserId != "" {
+			*opts = append(*opts, authtokens.WithUserId(c.flagUserId))
+		}
+		if c.flagAccountId != "" {
+			*opts = append(*opts, authtokens.WithAccountId(c.flagAccountId))
+		}
+		for _, v := range []struct {
+			name  string
+			value string
+			with  func(string) authtokens.Option
+		}{
+			{"approximate-last-used-before", c.flagApproximateLastUsedBefore, authtokens.WithApproximateLastUsedBefore},
+			{"approximate-last-used-after", c.flagApproximateLastUsedAfter, authtokens.WithApproximateLastUsedAfter},
+		} {
+			if v.value == "" {
+				continue
+			}
+			if _, err := time.Parse(time.RFC3339, v.value); err != nil {
+				c.PrintCliError(fmt.Errorf("Error parsing -%s as an RFC3339 time: %w", v.name, err))
+				return false
+			}
+			*opts = append(*opts, v.with(v.value))
+		}
+	}
+
+	if c.Func != "delete" && c.Func != "read" && c.Func != "refresh" {
 		if strutil.StrListContains(flagsMap[c.Func], "id") && c.FlagId == "" {
 			c.PrintCliError(errors.New("ID is required but not passed in via -id"))
 			return false
@@ -59,6 +164,18 @@ func extraFlagsHandlingFuncImpl(c *Command, _ *base.FlagSets, _ *[]authtokens.Op
 	return true
 }
 
+func executeExtraActionsImpl(c *Command, origResp *api.Response, origItem *authtokens.AuthToken, origItems []*authtokens.AuthToken, origError error, authTokenClient *authtokens.Client, _ uint32, opts []authtokens.Option) (*api.Response, *authtokens.AuthToken, []*authtokens.AuthToken, error) {
+	switch c.Func {
+	case "refresh":
+		result, err := authTokenClient.Refresh(c.Context, c.FlagId, opts...)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		return result.GetResponse(), result.GetItem(), nil, err
+	}
+	return origResp, origItem, origItems, origError
+}
+
 func (c *Command) printListTable(items []*authtokens.AuthToken) string {
 	if len(items) == 0 {
 		return "No auth tokens found"
@@ -761,8 +761,11 @@ func (c *Command) runTcpProxyV1(
 		c.connsLeftCh <- handshakeResult.GetConnectionsLeft()
 	}
 
-	// Get a wrapped net.Conn so we can use io.Copy
-	netConn := websocket.NetConn(c.proxyCtx, wsConn, websocket.MessageBinary)
+	// Get a wrapped net.Conn so we can use io.Copy. The session may be
+	// extended, so established connections are not closed at the expiration
+	// of the session certificate; the worker closes them when the session
+	// expires.
+	netConn := websocket.NetConn(c.Context, wsConn, websocket.MessageBinary)
 
 	localWg := new(sync.WaitGroup)
 	localWg.Add(2)
@@ -55,10 +55,9 @@ func httpOptions(c *Command, set *base.FlagSets) {
 	f.StringVar(&base.StringVar{
 		Name:       "scheme",
 		Target:     &c.flagHttpScheme,
-		Default:    "https",
 		EnvVar:     "BOUNDARY_CONNECT_HTTP_SCHEME",
 		Completion: complete.PredictNothing,
-		Usage:      `Specifies the scheme to use.`,
+		Usage:      `Specifies the scheme to use. Defaults to "http" for http-type targets, whose worker connects to the endpoint itself, and "https" otherwise.`,
 	})
 }
 
@@ -77,13 +76,25 @@ func (h *httpFlags) defaultExec() string {
 func (h *httpFlags) buildArgs(c *Command, port, ip, addr string) ([]string, error) {
 	var args []string
 	host := h.flagHttpHost
-	if host == "" && c.sessionAuthzData.GetEndpoint() != "" {
+	scheme := h.flagHttpScheme
+	if c.sessionAuthzData.GetEndpoint() != "" {
 		hostUrl := c.sessionAuthzData.GetEndpoint()
 		u, err := url.Parse(hostUrl)
 		if err != nil {
 			return nil, fmt.Errorf("error parsing endpoint URL: %w", err)
 		}
-		host = u.Hostname()
+		if host == "" {
+			host = u.Hostname()
+		}
+		// The endpoint scheme is the target type. The worker proxying an
+		// http-type target speaks http with the client and establishes tls
+		// with the endpoint itself when needed.
+		if scheme == "" && u.Scheme == "http" {
+			scheme = "http"
+		}
+	}
+	if scheme == "" {
+		scheme = "https"
 	}
 	switch h.flagHttpStyle {
 	case "curl":
@@ -95,9 +106,9 @@ func (h *httpFlags) buildArgs(c *Command, port, ip, addr string) ([]string, erro
 			host = strings.TrimSuffix(host, "/")
 			args = append(args, "-H", fmt.Sprintf("Host: %s", host))
 			args = append(args, "--resolve", fmt.Sprintf("%s:%s:%s", host, port, ip))
-			uri = fmt.Sprintf("%s://%s:%s", h.flagHttpScheme, host, port)
+			uri = fmt.Sprintf("%s://%s:%s", scheme, host, port)
 		} else {
-			uri = fmt.Sprintf("%s://%s", h.flagHttpScheme, addr)
+			uri = fmt.Sprintf("%s://%s", scheme, addr)
 		}
 		if h.flagHttpPath != "" {
 			uri = fmt.Sprintf("%s/%s", uri, strings.TrimPrefix(h.flagHttpPath, "/"))
@@ -91,7 +91,7 @@ var flagsMap = map[string][]string{
 
 	"delete": {"id"},
 
-	"list": {"credential-store-id", "filter"},
+	"list": {"credential-store-id", "filter", "page-size", "list-token"},
 }
 
 func (c *Command) Flags() *base.FlagSets {
@@ -173,6 +173,14 @@ func (c *Command) Run(args []string) int {
 		opts = append(opts, credentiallibraries.WithFilter(c.FlagFilter))
 	}
 
+	if c.FlagPageSize != 0 {
+		opts = append(opts, credentiallibraries.WithPageSize(uint32(c.FlagPageSize)))
+	}
+
+	if c.FlagListToken != "" {
+		opts = append(opts, credentiallibraries.WithListToken(c.FlagListToken))
+	}
+
 	var version uint32
 
 	if ok := extraFlagsHandlingFunc(c, f, &opts); !ok {
@@ -175,6 +175,14 @@ func (c *VaultGenericCommand) Run(args []string) int {
 		opts = append(opts, credentiallibraries.WithFilter(c.FlagFilter))
 	}
 
+	if c.FlagPageSize != 0 {
+		opts = append(opts, credentiallibraries.WithPageSize(uint32(c.FlagPageSize)))
+	}
+
+	if c.FlagListToken != "" {
+		opts = append(opts, credentiallibraries.WithListToken(c.FlagListToken))
+	}
+
 	var version uint32
 
 	switch c.Func {
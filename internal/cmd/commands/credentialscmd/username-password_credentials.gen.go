@@ -175,6 +175,14 @@ func (c *UsernamePasswordCommand) Run(args []string) int {
 		opts = append(opts, credentials.WithFilter(c.FlagFilter))
 	}
 
+	if c.FlagPageSize != 0 {
+		opts = append(opts, credentials.WithPageSize(uint32(c.FlagPageSize)))
+	}
+
+	if c.FlagListToken != "" {
+		opts = append(opts, credentials.WithListToken(c.FlagListToken))
+	}
+
 	var version uint32
 
 	switch c.Func {
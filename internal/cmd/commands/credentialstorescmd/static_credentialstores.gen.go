@@ -178,6 +178,14 @@ func (c *StaticCommand) Run(args []string) int {
 		opts = append(opts, credentialstores.WithFilter(c.FlagFilter))
 	}
 
+	if c.FlagPageSize != 0 {
+		opts = append(opts, credentialstores.WithPageSize(uint32(c.FlagPageSize)))
+	}
+
+	if c.FlagListToken != "" {
+		opts = append(opts, credentialstores.WithListToken(c.FlagListToken))
+	}
+
 	var version uint32
 
 	switch c.Func {
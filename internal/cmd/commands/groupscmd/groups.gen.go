@@ -103,7 +103,7 @@ var flagsMap = map[string][]string{
 
 	"delete": {"id"},
 
-	"list": {"scope-id", "filter", "recursive"},
+	"list": {"scope-id", "filter", "page-size", "list-token", "recursive"},
 }
 
 func (c *Command) Flags() *base.FlagSets {
@@ -206,6 +206,14 @@ func (c *Command) Run(args []string) int {
 		opts = append(opts, groups.WithFilter(c.FlagFilter))
 	}
 
+	if c.FlagPageSize != 0 {
+		opts = append(opts, groups.WithPageSize(uint32(c.FlagPageSize)))
+	}
+
+	if c.FlagListToken != "" {
+		opts = append(opts, groups.WithListToken(c.FlagListToken))
+	}
+
 	var version uint32
 
 	switch c.Func {
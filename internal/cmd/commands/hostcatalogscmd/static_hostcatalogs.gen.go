@@ -178,6 +178,14 @@ func (c *StaticCommand) Run(args []string) int {
 		opts = append(opts, hostcatalogs.WithFilter(c.FlagFilter))
 	}
 
+	if c.FlagPageSize != 0 {
+		opts = append(opts, hostcatalogs.WithPageSize(uint32(c.FlagPageSize)))
+	}
+
+	if c.FlagListToken != "" {
+		opts = append(opts, hostcatalogs.WithListToken(c.FlagListToken))
+	}
+
 	var version uint32
 
 	switch c.Func {
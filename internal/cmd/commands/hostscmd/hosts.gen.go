@@ -91,7 +91,7 @@ var flagsMap = map[string][]string{
 
 	"delete": {"id"},
 
-	"list": {"host-catalog-id", "filter"},
+	"list": {"host-catalog-id", "filter", "page-size", "list-token"},
 }
 
 func (c *Command) Flags() *base.FlagSets {
@@ -189,6 +189,14 @@ func (c *Command) Run(args []string) int {
 		opts = append(opts, hosts.WithFilter(c.FlagFilter))
 	}
 
+	if c.FlagPageSize != 0 {
+		opts = append(opts, hosts.WithPageSize(uint32(c.FlagPageSize)))
+	}
+
+	if c.FlagListToken != "" {
+		opts = append(opts, hosts.WithListToken(c.FlagListToken))
+	}
+
 	var version uint32
 
 	if ok := extraFlagsHandlingFunc(c, f, &opts); !ok {
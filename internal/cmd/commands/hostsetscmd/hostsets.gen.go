@@ -93,7 +93,7 @@ var flagsMap = map[string][]string{
 
 	"delete": {"id"},
 
-	"list": {"host-catalog-id", "filter"},
+	"list": {"host-catalog-id", "filter", "page-size", "list-token"},
 }
 
 func (c *Command) Flags() *base.FlagSets {
@@ -191,6 +191,14 @@ func (c *Command) Run(args []string) int {
 		opts = append(opts, hostsets.WithFilter(c.FlagFilter))
 	}
 
+	if c.FlagPageSize != 0 {
+		opts = append(opts, hostsets.WithPageSize(uint32(c.FlagPageSize)))
+	}
+
+	if c.FlagListToken != "" {
+		opts = append(opts, hostsets.WithListToken(c.FlagListToken))
+	}
+
 	var version uint32
 
 	switch c.Func {
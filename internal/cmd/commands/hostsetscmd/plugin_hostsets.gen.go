@@ -191,6 +191,14 @@ func (c *PluginCommand) Run(args []string) int {
 		opts = append(opts, hostsets.WithFilter(c.FlagFilter))
 	}
 
+	if c.FlagPageSize != 0 {
+		opts = append(opts, hostsets.WithPageSize(uint32(c.FlagPageSize)))
+	}
+
+	if c.FlagListToken != "" {
+		opts = append(opts, hostsets.WithListToken(c.FlagListToken))
+	}
+
 	var version uint32
 
 	switch c.Func {
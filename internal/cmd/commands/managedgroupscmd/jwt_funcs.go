@@ -0,0 +1,94 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package managedgroupscmd
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/boundary/api/managedgroups"
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/go-bexpr"
+)
+
+func init() {
+	extraJwtFlagsFunc = extraJwtFlagsFuncImpl
+	extraJwtActionsFlagsMapFunc = extraJwtActionsFlagsMapFuncImpl
+	extraJwtFlagsHandlingFunc = extraJwtFlagsHandlingFuncImpl
+}
+
+func extraJwtActionsFlagsMapFuncImpl() map[string][]string {
+	return map[string][]string{
+		"create": {filterFlagName},
+		"update": {filterFlagName},
+	}
+}
+
+type extraJwtCmdVars struct {
+	flagFilter string
+}
+
+func (c *JwtCommand) extraJwtHelpFunc(helpMap map[string]func() string) string {
+	var helpStr string
+	switch c.Func {
+	case "create":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary managed-groups create jwt [options] [args]",
+			"",
+			"  Create a jwt-type managed group. Example:",
+			"",
+			`    $ boundary managed-groups create jwt -auth-method-id amjwt_1234567890 -filter '"/token/repository" == "hashicorp/boundary"' -description "Workloads from the boundary repository"`,
+			"",
+			"",
+		})
+
+	case "update":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary managed-groups update jwt [options] [args]",
+			"",
+			"  Update a jwt-type managed group given its ID. Example:",
+			"",
+			`    $ boundary managed-groups update jwt -id mgjwt_1234567890 -name "boundary-ci" -description "Workloads from the boundary repository"`,
+			"",
+			"",
+		})
+	}
+	return helpStr + c.Flags().Help()
+}
+
+func extraJwtFlagsFuncImpl(c *JwtCommand, _ *base.FlagSets, f *base.FlagSet) {
+	for _, name := range flagsJwtMap[c.Func] {
+		switch name {
+		case filterFlagName:
+			f.StringVar(&base.StringVar{
+				Name:   filterFlagName,
+				Target: &c.flagFilter,
+				Usage:  `The filter defining the criteria against which the claims of the token presented at authentication will be evaluated to determine membership. Claims are available under "/token".`,
+			})
+		}
+	}
+}
+
+func extraJwtFlagsHandlingFuncImpl(c *JwtCommand, _ *base.FlagSets, opts *[]managedgroups.Option) bool {
+	switch c.flagFilter {
+	case "null":
+		c.UI.Error("Filter must be defined, and cannot be cleared.")
+		return false
+
+	case "":
+		if c.Func == "create" {
+			c.UI.Error("The -filter flag must be provided when creating a managed group.")
+			return false
+		}
+
+	default:
+		_, err := bexpr.CreateEvaluator(c.flagFilter)
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("Error when parsing filter to check validity: %v", err))
+			return false
+		}
+		*opts = append(*opts, managedgroups.WithJwtManagedGroupFilter(c.flagFilter))
+	}
+
+	return true
+}
@@ -0,0 +1,94 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package managedgroupscmd
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/boundary/api/managedgroups"
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/go-bexpr"
+)
+
+func init() {
+	extraPasswordFlagsFunc = extraPasswordFlagsFuncImpl
+	extraPasswordActionsFlagsMapFunc = extraPasswordActionsFlagsMapFuncImpl
+	extraPasswordFlagsHandlingFunc = extraPasswordFlagsHandlingFuncImpl
+}
+
+func extraPasswordActionsFlagsMapFuncImpl() map[string][]string {
+	return map[string][]string{
+		"create": {filterFlagName},
+		"update": {filterFlagName},
+	}
+}
+
+type extraPasswordCmdVars struct {
+	flagFilter string
+}
+
+func (c *PasswordCommand) extraPasswordHelpFunc(helpMap map[string]func() string) string {
+	var helpStr string
+	switch c.Func {
+	case "create":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary managed-groups create password [options] [args]",
+			"",
+			"  Create a password-type managed group. Example:",
+			"",
+			`    $ boundary managed-groups create password -auth-method-id ampw_1234567890 -filter '"/account/custom_attributes/employment" == "contractor"' -description "All contractor accounts"`,
+			"",
+			"",
+		})
+
+	case "update":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary managed-groups update password [options] [args]",
+			"",
+			"  Update a password-type managed group given its ID. Example:",
+			"",
+			`    $ boundary managed-groups update password -id mgpw_1234567890 -name "contractors" -description "All contractor accounts"`,
+			"",
+			"",
+		})
+	}
+	return helpStr + c.Flags().Help()
+}
+
+func extraPasswordFlagsFuncImpl(c *PasswordCommand, _ *base.FlagSets, f *base.FlagSet) {
+	for _, name := range flagsPasswordMap[c.Func] {
+		switch name {
+		case filterFlagName:
+			f.StringVar(&base.StringVar{
+				Name:   filterFlagName,
+				Target: &c.flagFilter,
+				Usage:  `The filter defining the criteria against which an account's login name, name, description and custom attributes will be evaluated to determine membership. Custom attributes are available under "/account/custom_attributes".`,
+			})
+		}
+	}
+}
+
+func extraPasswordFlagsHandlingFuncImpl(c *PasswordCommand, _ *base.FlagSets, opts *[]managedgroups.Option) bool {
+	switch c.flagFilter {
+	case "null":
+		c.UI.Error("Filter must be defined, and cannot be cleared.")
+		return false
+
+	case "":
+		if c.Func == "create" {
+			c.UI.Error("The -filter flag must be provided when creating a managed group.")
+			return false
+		}
+
+	default:
+		_, err := bexpr.CreateEvaluator(c.flagFilter)
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("Error when parsing filter to check validity: %v", err))
+			return false
+		}
+		*opts = append(*opts, managedgroups.WithPasswordManagedGroupFilter(c.flagFilter))
+	}
+
+	return true
+}
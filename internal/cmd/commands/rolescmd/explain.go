@@ -0,0 +1,222 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package rolescmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/boundary/api"
+	"github.com/hashicorp/boundary/api/roles"
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/boundary/internal/cmd/common"
+	"github.com/mitchellh/cli"
+	"github.com/mitchellh/go-wordwrap"
+	"github.com/posener/complete"
+)
+
+var (
+	_ cli.Command             = (*ExplainCommand)(nil)
+	_ cli.CommandAutocomplete = (*ExplainCommand)(nil)
+)
+
+type ExplainCommand struct {
+	*base.Command
+
+	flagUserId       string
+	flagAccountId    string
+	flagResourceType string
+	flagResourceId   string
+	flagPin          string
+	flagAction       string
+}
+
+func (c *ExplainCommand) Synopsis() string {
+	return wordwrap.WrapString("Explain whether a user is authorized to perform an action", base.TermWidth)
+}
+
+var flagsExplain = map[string][]string{
+	"explain": {"scope-id"},
+}
+
+func (c *ExplainCommand) Help() string {
+	return base.WrapForHelpText([]string{
+		"Usage: boundary roles explain [options]",
+		"",
+		"  Explain whether a user is authorized to perform an action, and which of their grants contribute to the decision. The user's grants are evaluated as they would be for a request made by the user. The scope is the scope of the resource, and you must be able to read the user. Example:",
+		"",
+		`    $ boundary roles explain -scope-id p_1234567890 -user-id u_1234567890 -resource-type target -resource-id ttcp_1234567890 -action authorize-session`,
+		"",
+		"  Leave out the resource ID to explain an action on a collection:",
+		"",
+		`    $ boundary roles explain -scope-id p_1234567890 -user-id u_1234567890 -resource-type target -action list`,
+		"",
+		"  Specific resources can be explained for scopes, users, groups, roles, targets and workers. Grant conditions, such as time windows and source CIDRs, are evaluated against this request, i.e. your address and the current time, rather than a request made by the user.",
+		"",
+		"",
+	}) + c.Flags().Help()
+}
+
+func (c *ExplainCommand) Flags() *base.FlagSets {
+	set := c.FlagSet(base.FlagSetHTTP | base.FlagSetClient | base.FlagSetOutputFormat)
+	f := set.NewFlagSet("Command Options")
+	common.PopulateCommonFlags(c.Command, f, "role", flagsExplain, "explain")
+
+	f.StringVar(&base.StringVar{
+		Name:   "user-id",
+		Target: &c.flagUserId,
+		Usage:  "The ID of the user to explain the action for.",
+	})
+	f.StringVar(&base.StringVar{
+		Name:   "account-id",
+		Target: &c.flagAccountId,
+		Usage:  "The ID of the user's account to use for grant templates. Defaults to the user's account if they have exactly one.",
+	})
+	f.StringVar(&base.StringVar{
+		Name:   "resource-type",
+		Target: &c.flagResourceType,
+		Usage:  "The type of the resource, e.g. target.",
+	})
+	f.StringVar(&base.StringVar{
+		Name:   "resource-id",
+		Target: &c.flagResourceId,
+		Usage:  "The ID of the resource. Leave empty for actions on a collection, such as list or create. Only scopes, users, groups, roles, targets and workers can be given.",
+	})
+	f.StringVar(&base.StringVar{
+		Name:   "pin",
+		Target: &c.flagPin,
+		Usage:  "The ID of the parent of the resource, for resources such as host sets whose grants can be pinned to it.",
+	})
+	f.StringVar(&base.StringVar{
+		Name:   "action",
+		Target: &c.flagAction,
+		Usage:  "The action to explain, e.g. read.",
+	})
+
+	return set
+}
+
+func (c *ExplainCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *ExplainCommand) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *ExplainCommand) Run(args []string) int {
+	f := c.Flags()
+
+	if err := f.Parse(args); err != nil {
+		c.PrintCliError(err)
+		return base.CommandUserError
+	}
+
+	switch {
+	case c.FlagScopeId == "":
+		c.PrintCliError(errors.New("Scope ID must be passed in via -scope-id or BOUNDARY_SCOPE_ID"))
+		return base.CommandUserError
+	case c.flagUserId == "":
+		c.PrintCliError(errors.New("User ID must be passed in via -user-id"))
+		return base.CommandUserError
+	case c.flagResourceType == "":
+		c.PrintCliError(errors.New("Resource type must be passed in via -resource-type"))
+		return base.CommandUserError
+	case c.flagAction == "":
+		c.PrintCliError(errors.New("Action must be passed in via -action"))
+		return base.CommandUserError
+	}
+
+	client, err := c.Client()
+	if c.WrapperCleanupFunc != nil {
+		defer func() {
+			if err := c.WrapperCleanupFunc(); err != nil {
+				c.PrintCliError(fmt.Errorf("Error cleaning kms wrapper: %w", err))
+			}
+		}()
+	}
+	if err != nil {
+		c.PrintCliError(fmt.Errorf("Error creating API client: %w", err))
+		return base.CommandCliError
+	}
+
+	var opts []roles.Option
+	if c.flagAccountId != "" {
+		opts = append(opts, roles.WithAccountId(c.flagAccountId))
+	}
+	if c.flagResourceId != "" {
+		opts = append(opts, roles.WithResourceId(c.flagResourceId))
+	}
+	if c.flagPin != "" {
+		opts = append(opts, roles.WithPin(c.flagPin))
+	}
+
+	result, err := roles.NewClient(client).Explain(c.Context, c.FlagScopeId, c.flagUserId, c.flagResourceType, c.flagAction, opts...)
+	if err != nil {
+		if apiErr := api.AsServerError(err); apiErr != nil {
+			c.PrintApiError(apiErr, "Error from controller when performing explain on role")
+			return base.CommandApiError
+		}
+		c.PrintCliError(fmt.Errorf("Error trying to explain: %w", err))
+		return base.CommandCliError
+	}
+
+	switch base.Format(c.UI) {
+	case "table":
+		c.UI.Output(printExplanationTable(result.GetItem()))
+
+	case "json":
+		if ok := c.PrintJsonItem(result.GetResponse()); !ok {
+			return base.CommandCliError
+		}
+	}
+
+	return base.CommandSuccess
+}
+
+func printExplanationTable(item *roles.Explanation) string {
+	nonAttributeMap := map[string]any{
+		"Authorized": item.Authorized,
+	}
+	if item.UserId != "" {
+		nonAttributeMap["User ID"] = item.UserId
+	}
+	if item.AccountId != "" {
+		nonAttributeMap["Account ID"] = item.AccountId
+	}
+
+	maxLength := base.MaxAttributesLength(nonAttributeMap, nil, nil)
+
+	ret := []string{
+		"",
+		"Explanation:",
+		base.WrapMap(2, maxLength+2, nonAttributeMap),
+	}
+
+	if len(item.Grants) == 0 {
+		ret = append(ret,
+			"",
+			"  No grants apply to the action",
+		)
+		return base.WrapForHelpText(ret)
+	}
+
+	ret = append(ret,
+		"",
+		"  Grants:",
+	)
+	for i, g := range item.Grants {
+		if i > 0 {
+			ret = append(ret, "")
+		}
+		ret = append(ret,
+			fmt.Sprintf("    Role ID:        %s", g.RoleId),
+			fmt.Sprintf("    Grant Scope ID: %s", g.GrantScopeId),
+			fmt.Sprintf("    Grant:          %s", g.Grant),
+			fmt.Sprintf("    Deny:           %t", g.Deny),
+		)
+	}
+
+	return base.WrapForHelpText(ret)
+}
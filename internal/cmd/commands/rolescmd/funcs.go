@@ -5,6 +5,7 @@ package rolescmd
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
@@ -25,21 +26,27 @@ func init() {
 }
 
 type extraCmdVars struct {
-	flagGrantScopeId string
-	flagPrincipals   []string
-	flagGrants       []string
+	flagGrantScopeId         string
+	flagMaxSessionsPerUser   string
+	flagMaxSessionsPerTarget string
+	flagPrincipals           []string
+	flagGrants               []string
+	flagGrantScopes          []string
 }
 
 func extraActionsFlagsMapFuncImpl() map[string][]string {
 	return map[string][]string{
-		"create":            {"grant-scope-id"},
-		"update":            {"grant-scope-id"},
-		"add-principals":    {"id", "principal", "version"},
-		"set-principals":    {"id", "principal", "version"},
-		"remove-principals": {"id", "principal", "version"},
-		"add-grants":        {"id", "grant", "version"},
-		"set-grants":        {"id", "grant", "version"},
-		"remove-grants":     {"id", "grant", "version"},
+		"create":              {"grant-scope-id", "max-sessions-per-user", "max-sessions-per-target"},
+		"update":              {"grant-scope-id", "max-sessions-per-user", "max-sessions-per-target"},
+		"add-principals":      {"id", "principal", "version"},
+		"set-principals":      {"id", "principal", "version"},
+		"remove-principals":   {"id", "principal", "version"},
+		"add-grants":          {"id", "grant", "version"},
+		"set-grants":          {"id", "grant", "version"},
+		"remove-grants":       {"id", "grant", "version"},
+		"add-grant-scopes":    {"id", "grant-scope-ids", "version"},
+		"set-grant-scopes":    {"id", "grant-scope-ids", "version"},
+		"remove-grant-scopes": {"id", "grant-scope-ids", "version"},
 	}
 }
 
@@ -49,6 +56,8 @@ func extraSynopsisFuncImpl(c *Command) string {
 		return c.principalsGrantsSynopsisFunc(c.Func, true)
 	case "add-grants", "set-grants", "remove-grants":
 		return c.principalsGrantsSynopsisFunc(c.Func, false)
+	case "add-grant-scopes", "set-grant-scopes", "remove-grant-scopes":
+		return c.grantScopesSynopsisFunc(c.Func)
 	}
 
 	return ""
@@ -71,6 +80,19 @@ func (c *Command) principalsGrantsSynopsisFunc(inFunc string, principals bool) s
 	return wordwrap.WrapString(fmt.Sprintf("%s a role", in), base.TermWidth)
 }
 
+func (c *Command) grantScopesSynopsisFunc(inFunc string) string {
+	var in string
+	switch {
+	case strings.HasPrefix(inFunc, "add"):
+		in = "Add grant scopes to"
+	case strings.HasPrefix(inFunc, "set"):
+		in = "Set the full contents of the grant scopes on"
+	case strings.HasPrefix(inFunc, "remove"):
+		in = "Remove grant scopes from"
+	}
+	return wordwrap.WrapString(fmt.Sprintf("%s a role", in), base.TermWidth)
+}
+
 func (c *Command) extraHelpFunc(helpMap map[string]func() string) string {
 	var helpStr string
 	switch c.Func {
@@ -140,6 +162,39 @@ func (c *Command) extraHelpFunc(helpMap map[string]func() string) string {
 			"",
 		})
 
+	case "add-grant-scopes":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary roles add-grant-scopes [options] [args]",
+			"",
+			`  Adds grant scopes to a role given its ID. The "grant-scope-id" flag can be specified multiple times. Example:`,
+			"",
+			`    $ boundary roles add-grant-scopes -id r_1234567890 -grant-scope-id children`,
+			"",
+			"",
+		})
+
+	case "set-grant-scopes":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary roles set-grant-scopes [options] [args]",
+			"",
+			`  Sets the complete set of grant scopes on a role given its ID. The "grant-scope-id" flag can be specified multiple times. Example:`,
+			"",
+			`    $ boundary roles set-grant-scopes -id r_1234567890 -grant-scope-id this -grant-scope-id p_1234567890`,
+			"",
+			"",
+		})
+
+	case "remove-grant-scopes":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary roles remove-grant-scopes [options] [args]",
+			"",
+			`  Removes grant scopes from a role given its ID. The "grant-scope-id" flag can be specified multiple times. Example:`,
+			"",
+			`    $ boundary roles remove-grant-scopes -id r_1234567890 -grant-scope-id p_1234567890`,
+			"",
+			"",
+		})
+
 	default:
 		helpStr = helpMap["base"]()
 	}
@@ -153,7 +208,25 @@ func extraFlagsFuncImpl(c *Command, _ *base.FlagSets, f *base.FlagSet) {
 			f.StringVar(&base.StringVar{
 				Name:   "grant-scope-id",
 				Target: &c.flagGrantScopeId,
-				Usage:  "The scope ID for grants set on the role",
+				Usage:  `Deprecated: use the grant scope subcommands instead. The scope ID for grants set on the role. Replaces the grant scopes of the role.`,
+			})
+		case "grant-scope-ids":
+			f.StringSliceVar(&base.StringSliceVar{
+				Name:   "grant-scope-id",
+				Target: &c.flagGrantScopes,
+				Usage:  `The grant scopes to add, remove, or set. May be specified multiple times. Can be a scope ID, "this", "children", or "descendants".`,
+			})
+		case "max-sessions-per-user":
+			f.StringVar(&base.StringVar{
+				Name:   "max-sessions-per-user",
+				Target: &c.flagMaxSessionsPerUser,
+				Usage:  `The maximum number of pending and active sessions a principal of the role may have on targets within the grant scope. Use "null" or 0 for no limit.`,
+			})
+		case "max-sessions-per-target":
+			f.StringVar(&base.StringVar{
+				Name:   "max-sessions-per-target",
+				Target: &c.flagMaxSessionsPerTarget,
+				Usage:  `The maximum number of pending and active sessions a principal of the role may have on any single target within the grant scope. Use "null" or 0 for no limit.`,
 			})
 		case "principal":
 			f.StringSliceVar(&base.StringSliceVar{
@@ -180,6 +253,32 @@ func extraFlagsHandlingFuncImpl(c *Command, _ *base.FlagSets, opts *[]roles.Opti
 		*opts = append(*opts, roles.WithGrantScopeId(c.flagGrantScopeId))
 	}
 
+	switch c.flagMaxSessionsPerUser {
+	case "":
+	case "null":
+		*opts = append(*opts, roles.DefaultMaxSessionsPerUser())
+	default:
+		max, err := strconv.ParseUint(c.flagMaxSessionsPerUser, 10, 32)
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("Error parsing %q: %s", c.flagMaxSessionsPerUser, err))
+			return false
+		}
+		*opts = append(*opts, roles.WithMaxSessionsPerUser(uint32(max)))
+	}
+
+	switch c.flagMaxSessionsPerTarget {
+	case "":
+	case "null":
+		*opts = append(*opts, roles.DefaultMaxSessionsPerTarget())
+	default:
+		max, err := strconv.ParseUint(c.flagMaxSessionsPerTarget, 10, 32)
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("Error parsing %q: %s", c.flagMaxSessionsPerTarget, err))
+			return false
+		}
+		*opts = append(*opts, roles.WithMaxSessionsPerTarget(uint32(max)))
+	}
+
 	switch c.Func {
 	case "add-principals", "remove-principals":
 		if len(c.flagPrincipals) == 0 {
@@ -193,6 +292,12 @@ func extraFlagsHandlingFuncImpl(c *Command, _ *base.FlagSets, opts *[]roles.Opti
 			return false
 		}
 
+	case "add-grant-scopes", "remove-grant-scopes":
+		if len(c.flagGrantScopes) == 0 {
+			c.UI.Error("No grant scopes supplied via -grant-scope-id")
+			return false
+		}
+
 	case "set-principals":
 		switch len(c.flagPrincipals) {
 		case 0:
@@ -214,6 +319,17 @@ func extraFlagsHandlingFuncImpl(c *Command, _ *base.FlagSets, opts *[]roles.Opti
 				c.flagGrants = nil
 			}
 		}
+
+	case "set-grant-scopes":
+		switch len(c.flagGrantScopes) {
+		case 0:
+			c.UI.Error("No grant scopes supplied via -grant-scope-id")
+			return false
+		case 1:
+			if c.flagGrantScopes[0] == "null" {
+				c.flagGrantScopes = nil
+			}
+		}
 	}
 
 	if len(c.flagGrants) > 0 {
@@ -267,6 +383,24 @@ func executeExtraActionsImpl(c *Command, origResp *api.Response, origItem *roles
 			return nil, nil, nil, err
 		}
 		return result.GetResponse(), result.GetItem(), nil, err
+	case "add-grant-scopes":
+		result, err := roleClient.AddGrantScopes(c.Context, c.FlagId, version, c.flagGrantScopes, opts...)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		return result.GetResponse(), result.GetItem(), nil, err
+	case "set-grant-scopes":
+		result, err := roleClient.SetGrantScopes(c.Context, c.FlagId, version, c.flagGrantScopes, opts...)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		return result.GetResponse(), result.GetItem(), nil, err
+	case "remove-grant-scopes":
+		result, err := roleClient.RemoveGrantScopes(c.Context, c.FlagId, version, c.flagGrantScopes, opts...)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		return result.GetResponse(), result.GetItem(), nil, err
 	}
 	return origResp, origItem, origItems, origError
 }
@@ -348,6 +482,12 @@ func printItemTable(item *roles.Role, resp *api.Response) string {
 	if item.GrantScopeId != "" {
 		nonAttributeMap["Grant Scope ID"] = item.GrantScopeId
 	}
+	if item.MaxSessionsPerUser > 0 {
+		nonAttributeMap["Max Sessions Per User"] = item.MaxSessionsPerUser
+	}
+	if item.MaxSessionsPerTarget > 0 {
+		nonAttributeMap["Max Sessions Per Target"] = item.MaxSessionsPerTarget
+	}
 
 	maxLength := base.MaxAttributesLength(nonAttributeMap, nil, nil)
 
@@ -373,6 +513,14 @@ func printItemTable(item *roles.Role, resp *api.Response) string {
 		)
 	}
 
+	if len(item.GrantScopeIds) > 0 {
+		ret = append(ret,
+			"",
+			"  Grant Scope IDs:",
+			base.WrapSlice(4, item.GrantScopeIds),
+		)
+	}
+
 	if len(item.Principals) > 0 {
 		ret = append(ret,
 			"",
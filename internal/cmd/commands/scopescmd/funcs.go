@@ -6,6 +6,7 @@ package scopescmd
 import (
 	"fmt"
 	"sort"
+	"strconv"
 	"time"
 
 	"github.com/hashicorp/boundary/api"
@@ -17,6 +18,8 @@ const (
 	flagPrimaryAuthMethodIdName     = "primary-auth-method-id"
 	flagSkipAdminRoleCreationName   = "skip-admin-role-creation"
 	flagSkipDefaultRoleCreationName = "skip-default-role-creation"
+	flagMaxSessionsPerUserName      = "max-sessions-per-user"
+	flagMaxSessionsPerTargetName    = "max-sessions-per-target"
 )
 
 func init() {
@@ -27,8 +30,8 @@ func init() {
 
 func extraActionsFlagsMapFuncImpl() map[string][]string {
 	return map[string][]string{
-		"create": {flagSkipAdminRoleCreationName, flagSkipDefaultRoleCreationName},
-		"update": {flagPrimaryAuthMethodIdName},
+		"create": {flagSkipAdminRoleCreationName, flagSkipDefaultRoleCreationName, flagMaxSessionsPerUserName, flagMaxSessionsPerTargetName},
+		"update": {flagPrimaryAuthMethodIdName, flagMaxSessionsPerUserName, flagMaxSessionsPerTargetName},
 	}
 }
 
@@ -36,6 +39,8 @@ type extraCmdVars struct {
 	flagSkipAdminRoleCreation   bool
 	flagSkipDefaultRoleCreation bool
 	flagPrimaryAuthMethodId     string
+	flagMaxSessionsPerUser      string
+	flagMaxSessionsPerTarget    string
 }
 
 func extraFlagsFuncImpl(c *Command, set *base.FlagSets, f *base.FlagSet) {
@@ -59,6 +64,18 @@ func extraFlagsFuncImpl(c *Command, set *base.FlagSets, f *base.FlagSet) {
 				Target: &c.flagPrimaryAuthMethodId,
 				Usage:  "If set, the primary auth method id for the scope.  A primary auth method is allowed to create users on first login and is also used as a source for account full name and email for a scope's users",
 			})
+		case flagMaxSessionsPerUserName:
+			f.StringVar(&base.StringVar{
+				Name:   flagMaxSessionsPerUserName,
+				Target: &c.flagMaxSessionsPerUser,
+				Usage:  `The maximum number of pending and active sessions a user may have on targets within the scope. Use "null" or 0 for no limit.`,
+			})
+		case flagMaxSessionsPerTargetName:
+			f.StringVar(&base.StringVar{
+				Name:   flagMaxSessionsPerTargetName,
+				Target: &c.flagMaxSessionsPerTarget,
+				Usage:  `The maximum number of pending and active sessions a user may have on any single target within the scope. Use "null" or 0 for no limit.`,
+			})
 		}
 	}
 }
@@ -73,6 +90,30 @@ func extraFlagsHandlingFuncImpl(c *Command, _ *base.FlagSets, opts *[]scopes.Opt
 	if c.flagPrimaryAuthMethodId != "" {
 		*opts = append(*opts, scopes.WithPrimaryAuthMethodId(c.flagPrimaryAuthMethodId))
 	}
+	switch c.flagMaxSessionsPerUser {
+	case "":
+	case "null":
+		*opts = append(*opts, scopes.DefaultMaxSessionsPerUser())
+	default:
+		max, err := strconv.ParseUint(c.flagMaxSessionsPerUser, 10, 32)
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("Error parsing %q: %s", c.flagMaxSessionsPerUser, err))
+			return false
+		}
+		*opts = append(*opts, scopes.WithMaxSessionsPerUser(uint32(max)))
+	}
+	switch c.flagMaxSessionsPerTarget {
+	case "":
+	case "null":
+		*opts = append(*opts, scopes.DefaultMaxSessionsPerTarget())
+	default:
+		max, err := strconv.ParseUint(c.flagMaxSessionsPerTarget, 10, 32)
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("Error parsing %q: %s", c.flagMaxSessionsPerTarget, err))
+			return false
+		}
+		*opts = append(*opts, scopes.WithMaxSessionsPerTarget(uint32(max)))
+	}
 
 	return true
 }
@@ -154,6 +195,12 @@ func printItemTable(item *scopes.Scope, resp *api.Response) string {
 	if item.PrimaryAuthMethodId != "" {
 		nonAttributeMap["Primary Auth Method ID"] = item.PrimaryAuthMethodId
 	}
+	if item.MaxSessionsPerUser > 0 {
+		nonAttributeMap["Max Sessions Per User"] = item.MaxSessionsPerUser
+	}
+	if item.MaxSessionsPerTarget > 0 {
+		nonAttributeMap["Max Sessions Per Target"] = item.MaxSessionsPerTarget
+	}
 
 	maxLength := base.MaxAttributesLength(nonAttributeMap, nil, nil)
 
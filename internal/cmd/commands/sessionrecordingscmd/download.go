@@ -0,0 +1,128 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sessionrecordingscmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/hashicorp/boundary/api"
+	"github.com/hashicorp/boundary/api/sessionrecordings"
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/mitchellh/cli"
+	"github.com/mitchellh/go-wordwrap"
+	"github.com/posener/complete"
+)
+
+var (
+	_ cli.Command             = (*DownloadCommand)(nil)
+	_ cli.CommandAutocomplete = (*DownloadCommand)(nil)
+)
+
+type DownloadCommand struct {
+	*base.Command
+
+	flagOutput string
+}
+
+func (c *DownloadCommand) Synopsis() string {
+	return wordwrap.WrapString("Download the contents of a session recording", base.TermWidth)
+}
+
+func (c *DownloadCommand) Help() string {
+	return base.WrapForHelpText([]string{
+		"Usage: boundary session-recordings download [args]",
+		"",
+		"  Download the contents of a session recording. If no output file is given the contents are written to stdout. Example:",
+		"",
+		`    $ boundary session-recordings download -id sr_1234567890 -output recording.rec`,
+		"",
+		"",
+	}) + c.Flags().Help()
+}
+
+func (c *DownloadCommand) Flags() *base.FlagSets {
+	set := c.FlagSet(base.FlagSetHTTP | base.FlagSetClient)
+	f := set.NewFlagSet("Command Options")
+
+	f.StringVar(&base.StringVar{
+		Name:   "id",
+		Target: &c.FlagId,
+		Usage:  "ID of the session recording to download",
+	})
+	f.StringVar(&base.StringVar{
+		Name:   "output",
+		Target: &c.flagOutput,
+		Usage:  "The file to write the session recording to. The file must not already exist. If not set the session recording is written to stdout.",
+	})
+
+	return set
+}
+
+func (c *DownloadCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictAnything
+}
+
+func (c *DownloadCommand) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *DownloadCommand) Run(args []string) int {
+	f := c.Flags()
+
+	if err := f.Parse(args); err != nil {
+		c.PrintCliError(err)
+		return base.CommandUserError
+	}
+
+	if c.FlagId == "" {
+		c.PrintCliError(errors.New("ID must be provided via -id"))
+		return base.CommandUserError
+	}
+
+	client, err := c.Client()
+	if c.WrapperCleanupFunc != nil {
+		defer func() {
+			if err := c.WrapperCleanupFunc(); err != nil {
+				c.PrintCliError(fmt.Errorf("Error cleaning kms wrapper: %w", err))
+			}
+		}()
+	}
+	if err != nil {
+		c.PrintCliError(fmt.Errorf("Error creating API client: %w", err))
+		return base.CommandCliError
+	}
+
+	out := io.Writer(os.Stdout)
+	if c.flagOutput != "" {
+		file, err := os.OpenFile(c.flagOutput, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+		if err != nil {
+			c.PrintCliError(fmt.Errorf("Error creating output file: %w", err))
+			return base.CommandUserError
+		}
+		defer file.Close()
+		out = file
+	}
+
+	srClient := sessionrecordings.NewClient(client)
+	body, err := srClient.Download(c.Context, c.FlagId)
+	if err != nil {
+		if apiErr := api.AsServerError(err); apiErr != nil {
+			c.PrintApiError(apiErr, "Error from controller when downloading session recording")
+			return base.CommandApiError
+		}
+		c.PrintCliError(fmt.Errorf("Error trying to download session recording: %w", err))
+		return base.CommandCliError
+	}
+	defer body.Close()
+
+	if _, err := io.Copy(out, body); err != nil {
+		c.PrintCliError(fmt.Errorf("Error writing session recording: %w", err))
+		return base.CommandCliError
+	}
+
+	return base.CommandSuccess
+}
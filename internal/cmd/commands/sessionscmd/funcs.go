@@ -5,7 +5,6 @@ package sessionscmd
 
 import (
 	"fmt"
-	"net"
 	"strconv"
 	"strings"
 	"time"
@@ -17,6 +16,7 @@ import (
 
 const (
 	flagIncludeTerminated = "include-terminated"
+	flagDuration          = "duration"
 )
 
 func init() {
@@ -29,12 +29,16 @@ func init() {
 func extraActionsFlagsMapFuncImpl() map[string][]string {
 	return map[string][]string{
 		"cancel": {"id"},
+		"extend": {"id", flagDuration},
 		"list":   {flagIncludeTerminated},
 	}
 }
 
 type extraCmdVars struct {
 	flagIncludeTerminated bool
+	flagDuration          string
+
+	extendDuration time.Duration
 }
 
 func extraFlagsFuncImpl(c *Command, set *base.FlagSets, f *base.FlagSet) {
@@ -46,6 +50,12 @@ func extraFlagsFuncImpl(c *Command, set *base.FlagSets, f *base.FlagSet) {
 				Target: &c.flagIncludeTerminated,
 				Usage:  "If set, terminated sessions will be included in the results.",
 			})
+		case flagDuration:
+			f.StringVar(&base.StringVar{
+				Name:   flagDuration,
+				Target: &c.flagDuration,
+				Usage:  "The duration the expiration time of the session is extended by. Can be specified as an integer number of seconds or a duration string.",
+			})
 		}
 	}
 }
@@ -54,6 +64,26 @@ func extraFlagsHandlingFuncImpl(c *Command, _ *base.FlagSets, opts *[]sessions.O
 	if c.flagIncludeTerminated {
 		*opts = append(*opts, sessions.WithIncludeTerminated(c.flagIncludeTerminated))
 	}
+	if c.Func == "extend" {
+		if c.flagDuration == "" {
+			c.UI.Error("Duration must be passed in via -duration")
+			return false
+		}
+		if seconds, err := strconv.ParseUint(c.flagDuration, 10, 32); err == nil {
+			c.extendDuration = time.Duration(seconds) * time.Second
+		} else {
+			dur, err := time.ParseDuration(c.flagDuration)
+			if err != nil {
+				c.UI.Error(fmt.Sprintf("Error parsing %q: %s", c.flagDuration, err))
+				return false
+			}
+			c.extendDuration = dur
+		}
+		if c.extendDuration < time.Second {
+			c.UI.Error("Duration must be at least one second")
+			return false
+		}
+	}
 	return true
 }
 
@@ -84,6 +114,17 @@ func (c *Command) extraHelpFunc(helpMap map[string]func() string) string {
 			"",
 		})
 
+	case "extend":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary sessions extend [options] [args]",
+			"",
+			"  Extend the expiration time of the session specified by ID by the given duration. The connections of the session are kept open until the new expiration time, but new connections can only be made until the session's original expiration time. Example:",
+			"",
+			`    $ boundary sessions extend -id s_1234567890 -duration 1h`,
+			"",
+			"",
+		})
+
 	default:
 		helpStr = helpMap["base"]()
 	}
@@ -99,6 +140,12 @@ func executeExtraActionsImpl(c *Command, origResp *api.Response, origItem *sessi
 			return nil, nil, nil, err
 		}
 		return result.GetResponse(), result.GetItem(), nil, err
+	case "extend":
+		result, err := sessionClient.Extend(c.Context, c.FlagId, version, c.extendDuration, opts...)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		return result.GetResponse(), result.GetItem(), nil, err
 	}
 	return origResp, origItem, origItems, origError
 }
@@ -215,6 +262,11 @@ func printItemTable(item *sessions.Session, resp *api.Response) string {
 	if len(strings.TrimSpace(item.TerminationReason)) > 0 {
 		nonAttributeMap["Termination Reason"] = item.TerminationReason
 	}
+	if item.ConnectionCount != 0 {
+		nonAttributeMap["Connection Count"] = item.ConnectionCount
+		nonAttributeMap["Total Bytes Up"] = item.TotalBytesUp
+		nonAttributeMap["Total Bytes Down"] = item.TotalBytesDown
+	}
 
 	maxLength := base.MaxAttributesLength(nonAttributeMap, nil, nil)
 
@@ -237,16 +289,12 @@ func printItemTable(item *sessions.Session, resp *api.Response) string {
 
 	var connectionsMaps []map[string]any
 	for _, sc := range item.Connections {
-		cm := map[string]any{
-			"Client Address":   net.JoinHostPort(sc.ClientTcpAddress, strconv.FormatUint(uint64(sc.ClientTcpPort), 10)),
-			"Endpoint Address": net.JoinHostPort(sc.EndpointTcpAddress, strconv.FormatUint(uint64(sc.EndpointTcpPort), 10)),
-			"Bytes Up":         sc.BytesUp,
-			"Bytes Down":       sc.BytesDown,
-		}
-		if len(sc.ClosedReason) != 0 {
-			cm["Closed Reason"] = sc.ClosedReason
+		connectionsMaps = append(connectionsMaps, connectionMap(sc))
+	}
+	if len(connectionsMaps) > 0 {
+		if l := len("Endpoint Address"); l > maxLength {
+			maxLength = l
 		}
-		connectionsMaps = append(connectionsMaps, cm)
 	}
 
 	ret := []string{
@@ -0,0 +1,187 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sessionscmd
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/boundary/api"
+	"github.com/hashicorp/boundary/api/sessions"
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/mitchellh/cli"
+	"github.com/mitchellh/go-wordwrap"
+	"github.com/posener/complete"
+)
+
+var (
+	_ cli.Command             = (*ListConnectionsCommand)(nil)
+	_ cli.CommandAutocomplete = (*ListConnectionsCommand)(nil)
+)
+
+type ListConnectionsCommand struct {
+	*base.Command
+}
+
+func (c *ListConnectionsCommand) Synopsis() string {
+	return wordwrap.WrapString("List the connections of a session", base.TermWidth)
+}
+
+func (c *ListConnectionsCommand) Help() string {
+	return base.WrapForHelpText([]string{
+		"Usage: boundary sessions list-connections [args]",
+		"",
+		"  List all the connections of a session, including the closed connections. Example:",
+		"",
+		`    $ boundary sessions list-connections -id s_1234567890`,
+		"",
+		"",
+	}) + c.Flags().Help()
+}
+
+func (c *ListConnectionsCommand) Flags() *base.FlagSets {
+	set := c.FlagSet(base.FlagSetHTTP | base.FlagSetClient | base.FlagSetOutputFormat)
+	f := set.NewFlagSet("Command Options")
+
+	f.StringVar(&base.StringVar{
+		Name:   "id",
+		Target: &c.FlagId,
+		Usage:  "ID of the session whose connections are listed",
+	})
+	f.StringVar(&base.StringVar{
+		Name:   "filter",
+		Target: &c.FlagFilter,
+		Usage:  "If set, the list operation will be filtered before being returned. The filter operates against each connection in the list. Using single quotes is recommended as filters contain double quotes. See https://www.boundaryproject.io/docs/concepts/filtering/resource-listing for details.",
+	})
+
+	return set
+}
+
+func (c *ListConnectionsCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictAnything
+}
+
+func (c *ListConnectionsCommand) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *ListConnectionsCommand) Run(args []string) int {
+	f := c.Flags()
+
+	if err := f.Parse(args); err != nil {
+		c.PrintCliError(err)
+		return base.CommandUserError
+	}
+
+	if c.FlagId == "" {
+		c.PrintCliError(errors.New("ID must be provided via -id"))
+		return base.CommandUserError
+	}
+
+	client, err := c.Client()
+	if c.WrapperCleanupFunc != nil {
+		defer func() {
+			if err := c.WrapperCleanupFunc(); err != nil {
+				c.PrintCliError(fmt.Errorf("Error cleaning kms wrapper: %w", err))
+			}
+		}()
+	}
+	if err != nil {
+		c.PrintCliError(fmt.Errorf("Error creating API client: %w", err))
+		return base.CommandCliError
+	}
+
+	var opts []sessions.Option
+	if c.FlagFilter != "" {
+		opts = append(opts, sessions.WithFilter(c.FlagFilter))
+	}
+
+	sessionClient := sessions.NewClient(client)
+	result, err := sessionClient.ListConnections(c.Context, c.FlagId, opts...)
+	if err != nil {
+		if apiErr := api.AsServerError(err); apiErr != nil {
+			c.PrintApiError(apiErr, "Error from controller when performing list-connections on session")
+			return base.CommandApiError
+		}
+		c.PrintCliError(fmt.Errorf("Error trying to list the connections of session: %w", err))
+		return base.CommandCliError
+	}
+
+	switch base.Format(c.UI) {
+	case "json":
+		if ok := c.PrintJsonItems(result.GetResponse()); !ok {
+			return base.CommandCliError
+		}
+
+	case "table":
+		c.UI.Output(printConnectionsTable(result.GetItems()))
+	}
+
+	return base.CommandSuccess
+}
+
+func printConnectionsTable(items []*sessions.Connection) string {
+	if len(items) == 0 {
+		return "No connections found"
+	}
+	maps := make([]map[string]any, 0, len(items))
+	maxLength := 0
+	for _, item := range items {
+		m := connectionMap(item)
+		for k := range m {
+			if len(k) > maxLength {
+				maxLength = len(k)
+			}
+		}
+		maps = append(maps, m)
+	}
+
+	output := []string{
+		"",
+		"Connection information:",
+	}
+	for i, m := range maps {
+		if i > 0 {
+			output = append(output, "")
+		}
+		output = append(output, base.WrapMap(2, maxLength+2, m))
+	}
+
+	return base.WrapForHelpText(output)
+}
+
+// connectionMap returns the fields of a connection printed in tables.
+func connectionMap(sc *sessions.Connection) map[string]any {
+	cm := map[string]any{
+		"Client Address":   net.JoinHostPort(sc.ClientTcpAddress, strconv.FormatUint(uint64(sc.ClientTcpPort), 10)),
+		"Endpoint Address": net.JoinHostPort(sc.EndpointTcpAddress, strconv.FormatUint(uint64(sc.EndpointTcpPort), 10)),
+		"Bytes Up":         sc.BytesUp,
+		"Bytes Down":       sc.BytesDown,
+	}
+	if sc.Id != "" {
+		cm["ID"] = sc.Id
+	}
+	if sc.Status != "" {
+		cm["Status"] = sc.Status
+	}
+	if sc.UserClientIp != "" {
+		cm["User Client IP"] = sc.UserClientIp
+	}
+	if !sc.CreatedTime.IsZero() {
+		cm["Created Time"] = sc.CreatedTime.Local().Format(time.RFC1123)
+	}
+	if !sc.ConnectedTime.IsZero() {
+		cm["Connected Time"] = sc.ConnectedTime.Local().Format(time.RFC1123)
+	}
+	if !sc.ClosedTime.IsZero() {
+		cm["Closed Time"] = sc.ClosedTime.Local().Format(time.RFC1123)
+	}
+	if len(sc.ClosedReason) != 0 {
+		cm["Closed Reason"] = sc.ClosedReason
+	}
+	return cm
+}
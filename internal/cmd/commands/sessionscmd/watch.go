@@ -0,0 +1,200 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sessionscmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/boundary/api"
+	"github.com/hashicorp/boundary/api/sessions"
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/boundary/internal/cmd/common"
+	"github.com/mitchellh/cli"
+	"github.com/mitchellh/go-wordwrap"
+	"github.com/posener/complete"
+)
+
+var (
+	_ cli.Command             = (*WatchCommand)(nil)
+	_ cli.CommandAutocomplete = (*WatchCommand)(nil)
+)
+
+var watchFlagsMap = map[string][]string{
+	"watch": {"scope-id", "filter", "recursive"},
+}
+
+type WatchCommand struct {
+	*base.Command
+
+	flagSince string
+}
+
+func (c *WatchCommand) Synopsis() string {
+	return wordwrap.WrapString("Watch the state changes of sessions", base.TermWidth)
+}
+
+func (c *WatchCommand) Help() string {
+	return base.WrapForHelpText([]string{
+		"Usage: boundary sessions watch [args]",
+		"",
+		"  Print the state changes of the sessions in a scope, and of their connections, as they happen, until interrupted. Example:",
+		"",
+		`    $ boundary sessions watch -scope-id p_1234567890`,
+		"",
+		"  The state changes of the sessions of a target can be watched using a filter:",
+		"",
+		`    $ boundary sessions watch -scope-id p_1234567890 -filter '"/item/target_id" == "ttcp_1234567890"'`,
+		"",
+		"",
+	}) + c.Flags().Help()
+}
+
+func (c *WatchCommand) Flags() *base.FlagSets {
+	set := c.FlagSet(base.FlagSetHTTP | base.FlagSetClient | base.FlagSetOutputFormat)
+	f := set.NewFlagSet("Command Options")
+	common.PopulateCommonFlags(c.Command, f, "session", watchFlagsMap, "watch")
+
+	f.StringVar(&base.StringVar{
+		Name:   "since",
+		Target: &c.flagSince,
+		Usage:  `If set, the state changes which happened since this time are printed first. The time is either formatted as RFC 3339, e.g. "2023-01-02T15:04:05Z", or a duration before now, e.g. "10m".`,
+	})
+
+	return set
+}
+
+func (c *WatchCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictAnything
+}
+
+func (c *WatchCommand) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *WatchCommand) Run(args []string) int {
+	f := c.Flags()
+
+	if err := f.Parse(args); err != nil {
+		c.PrintCliError(err)
+		return base.CommandUserError
+	}
+
+	if c.FlagScopeId == "" {
+		c.PrintCliError(errors.New("Scope ID must be passed in via -scope-id or BOUNDARY_SCOPE_ID"))
+		return base.CommandUserError
+	}
+
+	var opts []sessions.Option
+	if c.FlagRecursive {
+		opts = append(opts, sessions.WithRecursive(true))
+	}
+	if c.FlagFilter != "" {
+		opts = append(opts, sessions.WithFilter(c.FlagFilter))
+	}
+	if c.flagSince != "" {
+		since, err := parseSince(c.flagSince)
+		if err != nil {
+			c.PrintCliError(err)
+			return base.CommandUserError
+		}
+		opts = append(opts, sessions.WithSince(since.Format(time.RFC3339Nano)))
+	}
+
+	client, err := c.Client()
+	if c.WrapperCleanupFunc != nil {
+		defer func() {
+			if err := c.WrapperCleanupFunc(); err != nil {
+				c.PrintCliError(fmt.Errorf("Error cleaning kms wrapper: %w", err))
+			}
+		}()
+	}
+	if err != nil {
+		c.PrintCliError(fmt.Errorf("Error creating API client: %w", err))
+		return base.CommandCliError
+	}
+
+	sessionClient := sessions.NewClient(client)
+	watcher, err := sessionClient.Watch(c.Context, c.FlagScopeId, opts...)
+	if err != nil {
+		if apiErr := api.AsServerError(err); apiErr != nil {
+			c.PrintApiError(apiErr, "Error from controller when performing watch on sessions")
+			return base.CommandApiError
+		}
+		c.PrintCliError(fmt.Errorf("Error trying to watch sessions: %w", err))
+		return base.CommandCliError
+	}
+	defer watcher.Close()
+
+	for {
+		change, err := watcher.Next()
+		switch {
+		case errors.Is(err, context.Canceled):
+			// Interrupted
+			return base.CommandSuccess
+		case err != nil:
+			if apiErr := api.AsServerError(err); apiErr != nil {
+				c.PrintApiError(apiErr, "Error from controller when performing watch on sessions")
+				return base.CommandApiError
+			}
+			c.PrintCliError(fmt.Errorf("Error trying to watch sessions: %w", err))
+			return base.CommandCliError
+		}
+
+		switch base.Format(c.UI) {
+		case "json":
+			b, err := json.Marshal(change)
+			if err != nil {
+				c.PrintCliError(fmt.Errorf("Error formatting as JSON: %w", err))
+				return base.CommandCliError
+			}
+			c.UI.Output(string(b))
+
+		case "table":
+			c.UI.Output(printStateChange(change))
+		}
+	}
+}
+
+// parseSince parses a time formatted as RFC 3339, or a duration before now.
+func parseSince(s string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339Nano, s); err == nil {
+		return t, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d < 0 {
+		return time.Time{}, fmt.Errorf("Since must be a time formatted as RFC 3339 or a positive duration, got %q", s)
+	}
+	return time.Now().Add(-d), nil
+}
+
+// printStateChange returns a line describing the state change.
+func printStateChange(sc *sessions.StateChange) string {
+	var b strings.Builder
+	if !sc.Time.IsZero() {
+		b.WriteString(sc.Time.Local().Format(time.RFC3339))
+		b.WriteString("  ")
+	}
+	fmt.Fprintf(&b, "Session %s", sc.SessionId)
+	if sc.ConnectionId != "" {
+		fmt.Fprintf(&b, " Connection %s", sc.ConnectionId)
+	}
+	if sc.Status != "" {
+		fmt.Fprintf(&b, " is %s", sc.Status)
+	}
+	if sc.TargetId != "" {
+		fmt.Fprintf(&b, " (Target %s", sc.TargetId)
+		if sc.UserId != "" {
+			fmt.Fprintf(&b, ", User %s", sc.UserId)
+		}
+		b.WriteString(")")
+	} else if sc.UserId != "" {
+		fmt.Fprintf(&b, " (User %s)", sc.UserId)
+	}
+	return b.String()
+}
@@ -543,6 +543,12 @@ func printItemTable(item *targets.Target, resp *api.Response) string {
 		if resp.Map[globals.SessionMaxSecondsField] != nil {
 			nonAttributeMap["Session Max Seconds"] = item.SessionMaxSeconds
 		}
+		if resp.Map[globals.EnableSessionRecordingField] != nil {
+			nonAttributeMap["Enable Session Recording"] = item.EnableSessionRecording
+		}
+		if resp.Map[globals.SessionIdleTimeoutField] != nil {
+			nonAttributeMap["Session Idle Timeout"] = item.SessionIdleTimeout
+		}
 	}
 
 	maxLength := base.MaxAttributesLength(nonAttributeMap, item.Attributes, keySubstMap)
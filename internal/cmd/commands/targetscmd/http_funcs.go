@@ -0,0 +1,295 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package targetscmd
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/boundary/api/targets"
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/go-bexpr"
+)
+
+func init() {
+	extraHttpActionsFlagsMapFunc = extraHttpActionsFlagsMapFuncImpl
+	extraHttpFlagsFunc = extraHttpFlagsFuncImpl
+	extraHttpFlagsHandlingFunc = extraHttpFlagsHandlingFuncImpl
+}
+
+func extraHttpActionsFlagsMapFuncImpl() map[string][]string {
+	return map[string][]string{
+		"create": {"address", "default-port", "session-max-seconds", "session-connection-limit", "egress-worker-filter", "ingress-worker-filter", "enable-session-recording", "session-idle-timeout", "use-tls", "allowed-path"},
+		"update": {"address", "default-port", "session-max-seconds", "session-connection-limit", "worker-filter", "egress-worker-filter", "ingress-worker-filter", "enable-session-recording", "session-idle-timeout", "use-tls", "allowed-path"},
+	}
+}
+
+type extraHttpCmdVars struct {
+	flagDefaultPort            string
+	flagSessionMaxSeconds      string
+	flagSessionConnectionLimit string
+	flagWorkerFilter           string
+	flagEgressWorkerFilter     string
+	flagIngressWorkerFilter    string
+	flagAddress                string
+	flagEnableSessionRecording string
+	flagSessionIdleTimeout     string
+	flagUseTls                 string
+	flagAllowedPaths           []string
+}
+
+func (c *HttpCommand) extraHttpHelpFunc(helpMap map[string]func() string) string {
+	var helpStr string
+	switch c.Func {
+	case "create":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary targets create http [options] [args]",
+			"",
+			"  Create an http-type target. Example:",
+			"",
+			`    $ boundary targets create http -name dashboard -description "Http target for the ops dashboard" -use-tls true -allowed-path /dashboard`,
+			"",
+			"",
+		})
+
+	case "update":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary targets update http [options] [args]",
+			"",
+			"  Update an http-type target given its ID. Example:",
+			"",
+			`    $ boundary targets update http -id thttp_1234567890 -allowed-path /dashboard -allowed-path /api`,
+			"",
+			"",
+		})
+	}
+	return helpStr + c.Flags().Help()
+}
+
+func extraHttpFlagsFuncImpl(c *HttpCommand, set *base.FlagSets, f *base.FlagSet) {
+	fs := set.NewFlagSet("HTTP Target Options")
+
+	for _, name := range flagsHttpMap[c.Func] {
+		switch name {
+		case "address":
+			fs.StringVar(&base.StringVar{
+				Name:   "address",
+				Target: &c.flagAddress,
+				Usage:  "Optionally, a valid network address to connect to for this target. Can not be used alongside host sources.",
+			})
+		case "default-port":
+			fs.StringVar(&base.StringVar{
+				Name:   "default-port",
+				Target: &c.flagDefaultPort,
+				Usage:  "The default port to set on the target.",
+			})
+		case "session-max-seconds":
+			fs.StringVar(&base.StringVar{
+				Name:   "session-max-seconds",
+				Target: &c.flagSessionMaxSeconds,
+				Usage:  `The maximum lifetime of the session, including all connections. Can be specified as an integer number of seconds or a duration string.`,
+			})
+		case "session-connection-limit":
+			fs.StringVar(&base.StringVar{
+				Name:   "session-connection-limit",
+				Target: &c.flagSessionConnectionLimit,
+				Usage:  "The maximum number of connections allowed for a session. -1 means unlimited.",
+			})
+		case "worker-filter":
+			fs.StringVar(&base.StringVar{
+				Name:   "worker-filter",
+				Target: &c.flagWorkerFilter,
+				Usage:  "Deprecated: use egress or ingress filters instead.",
+			})
+		case "egress-worker-filter":
+			fs.StringVar(&base.StringVar{
+				Name:   "egress-worker-filter",
+				Target: &c.flagEgressWorkerFilter,
+				Usage:  "A boolean expression to filter which egress workers can handle sessions for this target.",
+			})
+		case "ingress-worker-filter":
+			fs.StringVar(&base.StringVar{
+				Name:   "ingress-worker-filter",
+				Target: &c.flagIngressWorkerFilter,
+				Usage:  "A boolean expression to filter which ingress workers can handle sessions for this target.",
+			})
+		case "enable-session-recording":
+			fs.StringVar(&base.StringVar{
+				Name:   "enable-session-recording",
+				Target: &c.flagEnableSessionRecording,
+				Usage:  "Whether connections to this target are recorded. Recorded connections are refused by workers which are unable to record them.",
+			})
+		case "session-idle-timeout":
+			fs.StringVar(&base.StringVar{
+				Name:   "session-idle-timeout",
+				Target: &c.flagSessionIdleTimeout,
+				Usage:  `The time after which a connection of a session over which no data is sent in either direction is closed. Can be specified as an integer number of seconds or a duration string. 0 means there is no idle timeout.`,
+			})
+		case "use-tls":
+			fs.StringVar(&base.StringVar{
+				Name:   "use-tls",
+				Target: &c.flagUseTls,
+				Usage:  "Whether the worker connects to the target's endpoints using https. The default port is 443 instead of 80 when set.",
+			})
+		case "allowed-path":
+			fs.StringSliceVar(&base.StringSliceVar{
+				Name:   "allowed-path",
+				Target: &c.flagAllowedPaths,
+				Usage:  `A path prefix requests to this target are allowed for. May be specified multiple times. If not set requests are allowed for all paths. Use "null" to clear the allowed paths.`,
+			})
+		}
+	}
+}
+
+func extraHttpFlagsHandlingFuncImpl(c *HttpCommand, _ *base.FlagSets, opts *[]targets.Option) bool {
+	switch c.flagDefaultPort {
+	case "":
+	case "null":
+		*opts = append(*opts, targets.DefaultHttpTargetDefaultPort())
+	default:
+		port, err := strconv.ParseUint(c.flagDefaultPort, 10, 32)
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("Error parsing %q: %s", c.flagDefaultPort, err))
+			return false
+		}
+		*opts = append(*opts, targets.WithHttpTargetDefaultPort(uint32(port)))
+	}
+
+	switch c.flagSessionMaxSeconds {
+	case "":
+	case "null":
+		*opts = append(*opts, targets.DefaultSessionMaxSeconds())
+	default:
+		var final uint32
+		dur, err := strconv.ParseUint(c.flagSessionMaxSeconds, 10, 32)
+		if err == nil {
+			final = uint32(dur)
+		} else {
+			dur, err := time.ParseDuration(c.flagSessionMaxSeconds)
+			if err != nil {
+				c.UI.Error(fmt.Sprintf("Error parsing %q: %s", c.flagSessionMaxSeconds, err))
+				return false
+			}
+			final = uint32(dur.Seconds())
+		}
+		*opts = append(*opts, targets.WithSessionMaxSeconds(final))
+	}
+
+	switch c.flagSessionConnectionLimit {
+	case "":
+	case "null":
+		*opts = append(*opts, targets.DefaultSessionConnectionLimit())
+	default:
+		limit, err := strconv.ParseInt(c.flagSessionConnectionLimit, 10, 32)
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("Error parsing %q: %s", c.flagSessionConnectionLimit, err))
+			return false
+		}
+		*opts = append(*opts, targets.WithSessionConnectionLimit(int32(limit)))
+	}
+
+	switch c.flagWorkerFilter {
+	case "":
+	case "null":
+		*opts = append(*opts, targets.DefaultWorkerFilter())
+	default:
+		if _, err := bexpr.CreateEvaluator(c.flagWorkerFilter); err != nil {
+			c.UI.Error(fmt.Sprintf("Unable to successfully parse worker filter expression: %s", err))
+			return false
+		}
+		*opts = append(*opts, targets.WithWorkerFilter(c.flagWorkerFilter))
+	}
+
+	switch c.flagEgressWorkerFilter {
+	case "":
+	case "null":
+		*opts = append(*opts, targets.DefaultEgressWorkerFilter())
+	default:
+		if _, err := bexpr.CreateEvaluator(c.flagEgressWorkerFilter); err != nil {
+			c.UI.Error(fmt.Sprintf("Unable to successfully parse egress filter expression: %s", err))
+			return false
+		}
+		*opts = append(*opts, targets.WithEgressWorkerFilter(c.flagEgressWorkerFilter))
+	}
+	switch c.flagIngressWorkerFilter {
+	case "":
+	case "null":
+		*opts = append(*opts, targets.DefaultIngressWorkerFilter())
+	default:
+		if _, err := bexpr.CreateEvaluator(c.flagIngressWorkerFilter); err != nil {
+			c.UI.Error(fmt.Sprintf("Unable to successfully parse ingress filter expression: %s", err))
+			return false
+		}
+		*opts = append(*opts, targets.WithIngressWorkerFilter(c.flagIngressWorkerFilter))
+	}
+
+	switch c.flagAddress {
+	case "":
+	case "null":
+		*opts = append(*opts, targets.DefaultAddress())
+	default:
+		*opts = append(*opts, targets.WithAddress(c.flagAddress))
+	}
+
+	switch c.flagEnableSessionRecording {
+	case "":
+	case "null":
+		*opts = append(*opts, targets.DefaultEnableSessionRecording())
+	default:
+		enable, err := strconv.ParseBool(c.flagEnableSessionRecording)
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("Error parsing %q: %s", c.flagEnableSessionRecording, err))
+			return false
+		}
+		*opts = append(*opts, targets.WithEnableSessionRecording(enable))
+	}
+
+	switch c.flagSessionIdleTimeout {
+	case "":
+	case "null":
+		*opts = append(*opts, targets.DefaultSessionIdleTimeout())
+	default:
+		var final uint32
+		timeout, err := strconv.ParseUint(c.flagSessionIdleTimeout, 10, 32)
+		if err == nil {
+			final = uint32(timeout)
+		} else {
+			timeout, err := time.ParseDuration(c.flagSessionIdleTimeout)
+			if err != nil {
+				c.UI.Error(fmt.Sprintf("Error parsing %q: %s", c.flagSessionIdleTimeout, err))
+				return false
+			}
+			final = uint32(timeout.Seconds())
+		}
+		*opts = append(*opts, targets.WithSessionIdleTimeout(final))
+	}
+
+	switch c.flagUseTls {
+	case "":
+	case "null":
+		*opts = append(*opts, targets.DefaultHttpTargetUseTls())
+	default:
+		useTls, err := strconv.ParseBool(c.flagUseTls)
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("Error parsing %q: %s", c.flagUseTls, err))
+			return false
+		}
+		*opts = append(*opts, targets.WithHttpTargetUseTls(useTls))
+	}
+
+	switch len(c.flagAllowedPaths) {
+	case 0:
+	case 1:
+		if c.flagAllowedPaths[0] == "null" {
+			*opts = append(*opts, targets.DefaultHttpTargetAllowedPaths())
+			break
+		}
+		fallthrough
+	default:
+		*opts = append(*opts, targets.WithHttpTargetAllowedPaths(c.flagAllowedPaths))
+	}
+
+	return true
+}
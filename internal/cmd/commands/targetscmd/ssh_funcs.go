@@ -22,8 +22,8 @@ func init() {
 
 func extraSshActionsFlagsMapFuncImpl() map[string][]string {
 	return map[string][]string{
-		"create": {"address", "default-port", "session-max-seconds", "session-connection-limit", "egress-worker-filter", "ingress-worker-filter"},
-		"update": {"address", "default-port", "session-max-seconds", "session-connection-limit", "worker-filter", "egress-worker-filter", "ingress-worker-filter"},
+		"create": {"address", "default-port", "session-max-seconds", "session-connection-limit", "egress-worker-filter", "ingress-worker-filter", "enable-session-recording", "session-idle-timeout", "host-key"},
+		"update": {"address", "default-port", "session-max-seconds", "session-connection-limit", "worker-filter", "egress-worker-filter", "ingress-worker-filter", "enable-session-recording", "session-idle-timeout", "host-key"},
 	}
 }
 
@@ -35,6 +35,9 @@ type extraSshCmdVars struct {
 	flagEgressWorkerFilter     string
 	flagIngressWorkerFilter    string
 	flagAddress                string
+	flagEnableSessionRecording string
+	flagSessionIdleTimeout     string
+	flagHostKeys               []string
 }
 
 func (c *SshCommand) extraSshHelpFunc(helpMap map[string]func() string) string {
@@ -112,6 +115,24 @@ func extraSshFlagsFuncImpl(c *SshCommand, set *base.FlagSets, f *base.FlagSet) {
 				Target: &c.flagIngressWorkerFilter,
 				Usage:  "A boolean expression to filter which ingress workers can handle sessions for this target.",
 			})
+		case "enable-session-recording":
+			fs.StringVar(&base.StringVar{
+				Name:   "enable-session-recording",
+				Target: &c.flagEnableSessionRecording,
+				Usage:  "Whether connections to this target are recorded. Recorded connections are refused by workers which are unable to record them.",
+			})
+		case "session-idle-timeout":
+			fs.StringVar(&base.StringVar{
+				Name:   "session-idle-timeout",
+				Target: &c.flagSessionIdleTimeout,
+				Usage:  `The time after which a connection of a session over which no data is sent in either direction is closed. Can be specified as an integer number of seconds or a duration string. 0 means there is no idle timeout.`,
+			})
+		case "host-key":
+			fs.StringSliceVar(&base.StringSliceVar{
+				Name:   "host-key",
+				Target: &c.flagHostKeys,
+				Usage:  `A public key, in the authorized_keys format, the target's endpoints may present as their host key. May be specified multiple times. Injected credentials are only sent to endpoints presenting one of these keys. Use "null" to clear the host keys.`,
+			})
 		}
 	}
 }
@@ -206,6 +227,51 @@ func extraSshFlagsHandlingFuncImpl(c *SshCommand, _ *base.FlagSets, opts *[]targ
 		*opts = append(*opts, targets.WithAddress(c.flagAddress))
 	}
 
+	switch c.flagEnableSessionRecording {
+	case "":
+	case "null":
+		*opts = append(*opts, targets.DefaultEnableSessionRecording())
+	default:
+		enable, err := strconv.ParseBool(c.flagEnableSessionRecording)
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("Error parsing %q: %s", c.flagEnableSessionRecording, err))
+			return false
+		}
+		*opts = append(*opts, targets.WithEnableSessionRecording(enable))
+	}
+
+	switch c.flagSessionIdleTimeout {
+	case "":
+	case "null":
+		*opts = append(*opts, targets.DefaultSessionIdleTimeout())
+	default:
+		var final uint32
+		timeout, err := strconv.ParseUint(c.flagSessionIdleTimeout, 10, 32)
+		if err == nil {
+			final = uint32(timeout)
+		} else {
+			timeout, err := time.ParseDuration(c.flagSessionIdleTimeout)
+			if err != nil {
+				c.UI.Error(fmt.Sprintf("Error parsing %q: %s", c.flagSessionIdleTimeout, err))
+				return false
+			}
+			final = uint32(timeout.Seconds())
+		}
+		*opts = append(*opts, targets.WithSessionIdleTimeout(final))
+	}
+
+	switch len(c.flagHostKeys) {
+	case 0:
+	case 1:
+		if c.flagHostKeys[0] == "null" {
+			*opts = append(*opts, targets.DefaultSshTargetHostKeys())
+			break
+		}
+		fallthrough
+	default:
+		*opts = append(*opts, targets.WithSshTargetHostKeys(c.flagHostKeys))
+	}
+
 	return true
 }
 
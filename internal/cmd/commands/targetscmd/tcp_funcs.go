@@ -21,8 +21,8 @@ func init() {
 
 func extraTcpActionsFlagsMapFuncImpl() map[string][]string {
 	return map[string][]string{
-		"create": {"address", "default-port", "session-max-seconds", "session-connection-limit", "egress-worker-filter", "ingress-worker-filter"},
-		"update": {"address", "default-port", "session-max-seconds", "session-connection-limit", "worker-filter", "egress-worker-filter", "ingress-worker-filter"},
+		"create": {"address", "default-port", "session-max-seconds", "session-connection-limit", "egress-worker-filter", "ingress-worker-filter", "enable-session-recording", "session-idle-timeout"},
+		"update": {"address", "default-port", "session-max-seconds", "session-connection-limit", "worker-filter", "egress-worker-filter", "ingress-worker-filter", "enable-session-recording", "session-idle-timeout"},
 	}
 }
 
@@ -34,6 +34,8 @@ type extraTcpCmdVars struct {
 	flagEgressWorkerFilter     string
 	flagIngressWorkerFilter    string
 	flagAddress                string
+	flagEnableSessionRecording string
+	flagSessionIdleTimeout     string
 }
 
 func (c *TcpCommand) extraTcpHelpFunc(helpMap map[string]func() string) string {
@@ -111,6 +113,18 @@ func extraTcpFlagsFuncImpl(c *TcpCommand, set *base.FlagSets, f *base.FlagSet) {
 				Target: &c.flagIngressWorkerFilter,
 				Usage:  "A boolean expression to filter which ingress workers can handle sessions for this target.",
 			})
+		case "enable-session-recording":
+			fs.StringVar(&base.StringVar{
+				Name:   "enable-session-recording",
+				Target: &c.flagEnableSessionRecording,
+				Usage:  "Whether connections to this target are recorded. Recorded connections are refused by workers which are unable to record them.",
+			})
+		case "session-idle-timeout":
+			fs.StringVar(&base.StringVar{
+				Name:   "session-idle-timeout",
+				Target: &c.flagSessionIdleTimeout,
+				Usage:  `The time after which a connection of a session over which no data is sent in either direction is closed. Can be specified as an integer number of seconds or a duration string. 0 means there is no idle timeout.`,
+			})
 		}
 	}
 }
@@ -205,5 +219,38 @@ func extraTcpFlagsHandlingFuncImpl(c *TcpCommand, _ *base.FlagSets, opts *[]targ
 		*opts = append(*opts, targets.WithAddress(c.flagAddress))
 	}
 
+	switch c.flagEnableSessionRecording {
+	case "":
+	case "null":
+		*opts = append(*opts, targets.DefaultEnableSessionRecording())
+	default:
+		enable, err := strconv.ParseBool(c.flagEnableSessionRecording)
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("Error parsing %q: %s", c.flagEnableSessionRecording, err))
+			return false
+		}
+		*opts = append(*opts, targets.WithEnableSessionRecording(enable))
+	}
+
+	switch c.flagSessionIdleTimeout {
+	case "":
+	case "null":
+		*opts = append(*opts, targets.DefaultSessionIdleTimeout())
+	default:
+		var final uint32
+		timeout, err := strconv.ParseUint(c.flagSessionIdleTimeout, 10, 32)
+		if err == nil {
+			final = uint32(timeout)
+		} else {
+			timeout, err := time.ParseDuration(c.flagSessionIdleTimeout)
+			if err != nil {
+				c.UI.Error(fmt.Sprintf("Error parsing %q: %s", c.flagSessionIdleTimeout, err))
+				return false
+			}
+			final = uint32(timeout.Seconds())
+		}
+		*opts = append(*opts, targets.WithSessionIdleTimeout(final))
+	}
+
 	return true
 }
@@ -180,6 +180,14 @@ func (c *TcpCommand) Run(args []string) int {
 		opts = append(opts, targets.WithFilter(c.FlagFilter))
 	}
 
+	if c.FlagPageSize != 0 {
+		opts = append(opts, targets.WithPageSize(uint32(c.FlagPageSize)))
+	}
+
+	if c.FlagListToken != "" {
+		opts = append(opts, targets.WithListToken(c.FlagListToken))
+	}
+
 	var version uint32
 
 	switch c.Func {
@@ -31,6 +31,7 @@ func extraActionsFlagsMapFuncImpl() map[string][]string {
 		"add-accounts":    {"id", "account", "version"},
 		"set-accounts":    {"id", "account", "version"},
 		"remove-accounts": {"id", "account", "version"},
+		"revoke-tokens":   {"id"},
 	}
 }
 
@@ -47,6 +48,9 @@ func extraSynopsisFuncImpl(c *Command) string {
 			in = "Remove accounts from"
 		}
 		return wordwrap.WrapString(fmt.Sprintf("%s a user within Boundary", in), base.TermWidth)
+
+	case "revoke-tokens":
+		return wordwrap.WrapString("Revoke all auth tokens of a user within Boundary", base.TermWidth)
 	}
 
 	return ""
@@ -88,6 +92,17 @@ func (c *Command) extraHelpFunc(helpMap map[string]func() string) string {
 			"",
 		})
 
+	case "revoke-tokens":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary users revoke-tokens [options] [args]",
+			"",
+			"  Revokes all auth tokens of a user given its ID, signing the user out of every client. Sessions already authorized for the user are not affected. Example:",
+			"",
+			`    $ boundary users revoke-tokens -id u_1234567890`,
+			"",
+			"",
+		})
+
 	default:
 		helpStr = helpMap["base"]()
 	}
@@ -150,6 +165,12 @@ func executeExtraActionsImpl(c *Command, origResp *api.Response, origItem *users
 			return nil, nil, nil, err
 		}
 		return result.GetResponse(), result.GetItem(), nil, err
+	case "revoke-tokens":
+		result, err := userClient.RevokeTokens(c.Context, c.FlagId, opts...)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		return result.GetResponse(), result.GetItem(), nil, err
 	}
 	return origResp, origItem, origItems, origError
 }
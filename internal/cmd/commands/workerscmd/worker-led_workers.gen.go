@@ -181,6 +181,14 @@ func (c *WorkerLedCommand) Run(args []string) int {
 		opts = append(opts, workers.WithFilter(c.FlagFilter))
 	}
 
+	if c.FlagPageSize != 0 {
+		opts = append(opts, workers.WithPageSize(uint32(c.FlagPageSize)))
+	}
+
+	if c.FlagListToken != "" {
+		opts = append(opts, workers.WithListToken(c.FlagListToken))
+	}
+
 	var version uint32
 
 	if ok := extraWorkerLedFlagsHandlingFunc(c, f, &opts); !ok {
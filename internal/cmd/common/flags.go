@@ -97,6 +97,13 @@ func PopulateCommonFlags(c *base.Command, f *base.FlagSet, resourceType string,
 				Target: &c.FlagCredentialStoreId,
 				Usage:  "The credential-store resource to use for the operation.",
 			})
+		case "service-account-id":
+			f.StringVar(&base.StringVar{
+				Name:   "service-account-id",
+				EnvVar: "BOUNDARY_SERVICE_ACCOUNT_ID",
+				Target: &c.FlagServiceAccountId,
+				Usage:  "The service-account resource to use for the operation.",
+			})
 		case "recursive":
 			f.BoolVar(&base.BoolVar{
 				Name:   "recursive",
@@ -114,6 +121,18 @@ func PopulateCommonFlags(c *base.Command, f *base.FlagSet, resourceType string,
 					Target: &c.FlagFilter,
 					Usage:  "If set, the list operation will be filtered before being returned. The filter operates against each item in the list. Using single quotes is recommended as filters contain double quotes. See https://www.boundaryproject.io/docs/concepts/filtering/resource-listing for details.",
 				})
+			case "page-size":
+				f.UintVar(&base.UintVar{
+					Name:   "page-size",
+					Target: &c.FlagPageSize,
+					Usage:  "If set, the list operation will request the items from the controller in pages of this size. All pages are returned.",
+				})
+			case "list-token":
+				f.StringVar(&base.StringVar{
+					Name:   "list-token",
+					Target: &c.FlagListToken,
+					Usage:  "If set, the list operation will return only the items changed, and the IDs of the items removed, since the listing that returned this list token.",
+				})
 			}
 		}
 	}
@@ -157,6 +157,12 @@ type Controller struct {
 	AuthTokenTimeToStale         any           `hcl:"auth_token_time_to_stale"`
 	AuthTokenTimeToStaleDuration time.Duration `hcl:"-"`
 
+	// AuthTokenMaxTimeToLive is the maximum lifetime of a token, measured
+	// from its creation, that refreshing the token cannot extend it past
+	// denoted by time.Duration
+	AuthTokenMaxTimeToLive         any           `hcl:"auth_token_max_time_to_live"`
+	AuthTokenMaxTimeToLiveDuration time.Duration `hcl:"-"`
+
 	// GracefulShutdownWait is the amount of time that we'll wait before actually
 	// starting the Controller shutdown. This allows the health endpoint to
 	// return a status code to indicate that the instance is shutting down.
@@ -188,6 +194,14 @@ type Controller struct {
 	//
 	// TODO: This field is currently internal.
 	SchedulerRunJobInterval time.Duration `hcl:"-"`
+
+	// RecordingStoragePath is the directory session recordings are read from
+	// when they are downloaded. Recordings are written by the worker which
+	// proxied the recorded connection, and the controller does not copy them,
+	// so this must be a file system shared by every controller and by every
+	// worker's recording_storage_path (e.g. the same NFS mount). Downloading a
+	// recording which is not in this directory fails.
+	RecordingStoragePath string `hcl:"recording_storage_path"`
 }
 
 func (c *Controller) InitNameIfEmpty() error {
@@ -253,6 +267,13 @@ type Worker struct {
 	// token used to register this worker to the cluster. It can be a path, env
 	// var, or direct value.
 	ControllerGeneratedActivationToken string `hcl:"controller_generated_activation_token"`
+
+	// RecordingStoragePath is the directory the worker writes session
+	// recordings to. Connections of recorded sessions are refused by a
+	// worker without a recording storage path. Recordings can only be
+	// downloaded when it is on a file system shared with the controllers'
+	// recording_storage_path.
+	RecordingStoragePath string `hcl:"recording_storage_path"`
 }
 
 type Database struct {
@@ -505,6 +526,14 @@ func Parse(d string) (*Config, error) {
 			result.Controller.AuthTokenTimeToStaleDuration = t
 		}
 
+		if result.Controller.AuthTokenMaxTimeToLive != "" {
+			t, err := parseutil.ParseDurationSecond(result.Controller.AuthTokenMaxTimeToLive)
+			if err != nil {
+				return result, err
+			}
+			result.Controller.AuthTokenMaxTimeToLiveDuration = t
+		}
+
 		if result.Controller.GracefulShutdownWait != "" {
 			t, err := parseutil.ParseDurationSecond(result.Controller.GracefulShutdownWait)
 			if err != nil {
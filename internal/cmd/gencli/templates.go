@@ -192,7 +192,7 @@ var flags{{ camelCase .SubActionPrefix }}Map = map[string][]string{
 	"delete": {"id"},
 	{{ end }}
 	{{ if eq $action "list" }}
-	"list": { "{{ kebabCase $input.Container }}-id", "filter" {{ if (eq $input.Container "Scope") }}, "recursive"{{ end }} },
+	"list": { "{{ kebabCase $input.Container }}-id", "filter", "page-size", "list-token" {{ if (eq $input.Container "Scope") }}, "recursive"{{ end }} },
 	{{ end }}
 	{{ end }}
 	{{ end }}
@@ -383,6 +383,14 @@ func (c *{{ camelCase .SubActionPrefix }}Command) Run(args []string) int {
 		opts = append(opts, {{ .Pkg }}.WithFilter(c.FlagFilter))
 	}
 
+	if c.FlagPageSize != 0 {
+		opts = append(opts, {{ .Pkg }}.WithPageSize(uint32(c.FlagPageSize)))
+	}
+
+	if c.FlagListToken != "" {
+		opts = append(opts, {{ .Pkg }}.WithListToken(c.FlagListToken))
+	}
+
 	{{ if .HasScopeName }}
 	switch c.FlagScopeName {
 	case "":
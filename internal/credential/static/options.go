@@ -3,6 +3,8 @@
 
 package static
 
+import "github.com/hashicorp/boundary/internal/db"
+
 // getOpts - iterate the inbound Options and return a struct
 func getOpts(opt ...Option) options {
 	opts := getDefaultOptions()
@@ -22,6 +24,7 @@ type options struct {
 	withLimit                int
 	withPublicId             string
 	withPrivateKeyPassphrase []byte
+	withPage                 *db.Page
 }
 
 func getDefaultOptions() options {
@@ -42,6 +45,14 @@ func WithName(name string) Option {
 	}
 }
 
+// WithPage provides an option to list one page of resources. Use WithLimit to
+// set the size of the page.
+func WithPage(p *db.Page) Option {
+	return func(o *options) {
+		o.withPage = p
+	}
+}
+
 // WithLimit provides an option to provide a limit. Intentionally allowing
 // negative integers. If WithLimit < 0, then unlimited results are
 // returned. If WithLimit == 0, then default limits are used for results.
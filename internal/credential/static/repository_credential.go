@@ -7,12 +7,14 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/boundary/internal/credential"
 	"github.com/hashicorp/boundary/internal/db"
 	"github.com/hashicorp/boundary/internal/errors"
 	"github.com/hashicorp/boundary/internal/kms"
 	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/hashicorp/boundary/internal/pagination"
 	"github.com/hashicorp/boundary/internal/types/subtypes"
 	"github.com/hashicorp/go-dbw"
 )
@@ -731,19 +733,19 @@ func (r *Repository) ListCredentials(ctx context.Context, storeId string, opt ..
 	}
 
 	var upCreds []*UsernamePasswordCredential
-	err := r.reader.SearchWhere(ctx, &upCreds, "store_id = ?", []any{storeId}, db.WithLimit(limit))
+	err := r.reader.SearchWhere(ctx, &upCreds, "store_id = ?", []any{storeId}, db.WithLimit(limit), db.WithPage(opts.withPage))
 	if err != nil {
 		return nil, errors.Wrap(ctx, err, op)
 	}
 
 	var spkCreds []*SshPrivateKeyCredential
-	err = r.reader.SearchWhere(ctx, &spkCreds, "store_id = ?", []any{storeId}, db.WithLimit(limit))
+	err = r.reader.SearchWhere(ctx, &spkCreds, "store_id = ?", []any{storeId}, db.WithLimit(limit), db.WithPage(opts.withPage))
 	if err != nil {
 		return nil, errors.Wrap(ctx, err, op)
 	}
 
 	var jsonCreds []*JsonCredential
-	err = r.reader.SearchWhere(ctx, &jsonCreds, "store_id = ?", []any{storeId}, db.WithLimit(limit))
+	err = r.reader.SearchWhere(ctx, &jsonCreds, "store_id = ?", []any{storeId}, db.WithLimit(limit), db.WithPage(opts.withPage))
 	if err != nil {
 		return nil, errors.Wrap(ctx, err, op)
 	}
@@ -775,7 +777,18 @@ func (r *Repository) ListCredentials(ctx context.Context, storeId string, opt ..
 		ret = append(ret, c)
 	}
 
-	return ret, nil
+	return pagination.MergeItems(ret, opts.withPage, limit), nil
+}
+
+// ListDeletedCredentialIds returns the static credentials of the credential
+// store deleted after since.
+func (r *Repository) ListDeletedCredentialIds(ctx context.Context, storeId string, since time.Time) ([]*pagination.DeletedResource, error) {
+	const op = "static.(Repository).ListDeletedCredentialIds"
+	deleted, err := pagination.ListDeletedIdsByParent(ctx, r.reader, "credential_static", storeId, since)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return deleted, nil
 }
 
 // DeleteCredential deletes publicId from the repository and returns
@@ -7,11 +7,13 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/boundary/internal/db"
 	"github.com/hashicorp/boundary/internal/errors"
 	"github.com/hashicorp/boundary/internal/kms"
 	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/hashicorp/boundary/internal/pagination"
 	"github.com/hashicorp/go-dbw"
 )
 
@@ -185,13 +187,24 @@ func (r *Repository) ListCredentialStores(ctx context.Context, projectIds []stri
 		limit = opts.withLimit
 	}
 	var credentialStores []*CredentialStore
-	err := r.reader.SearchWhere(ctx, &credentialStores, "project_id in (?)", []any{projectIds}, db.WithLimit(limit))
+	err := r.reader.SearchWhere(ctx, &credentialStores, "project_id in (?)", []any{projectIds}, db.WithLimit(limit), db.WithPage(opts.withPage))
 	if err != nil {
 		return nil, errors.Wrap(ctx, err, op)
 	}
 	return credentialStores, nil
 }
 
+// ListDeletedCredentialStoreIds returns the credential stores of all subtypes
+// in one of projectIds deleted after since.
+func (r *Repository) ListDeletedCredentialStoreIds(ctx context.Context, projectIds []string, since time.Time) ([]*pagination.DeletedResource, error) {
+	const op = "static.(Repository).ListDeletedCredentialStoreIds"
+	deleted, err := pagination.ListDeletedIds(ctx, r.reader, "credential_store", projectIds, since)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return deleted, nil
+}
+
 // DeleteCredentialStore deletes publicId from the repository and returns
 // the number of records deleted. All options are ignored.
 func (r *Repository) DeleteCredentialStore(ctx context.Context, publicId string, _ ...Option) (int, error) {
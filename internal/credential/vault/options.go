@@ -3,7 +3,10 @@
 
 package vault
 
-import "github.com/hashicorp/boundary/internal/credential"
+import (
+	"github.com/hashicorp/boundary/internal/credential"
+	"github.com/hashicorp/boundary/internal/db"
+)
 
 // getOpts - iterate the inbound Options and return a struct
 func getOpts(opt ...Option) options {
@@ -44,6 +47,8 @@ type options struct {
 	withKeyId           string
 	withCriticalOptions string
 	withExtensions      string
+
+	withPage *db.Page
 }
 
 func getDefaultOptions() options {
@@ -71,6 +76,14 @@ func WithWorkerFilter(filter string) Option {
 	}
 }
 
+// WithPage provides an option to list one page of resources. Use WithLimit to
+// set the size of the page.
+func WithPage(p *db.Page) Option {
+	return func(o *options) {
+		o.withPage = p
+	}
+}
+
 // WithLimit provides an option to provide a limit. Intentionally allowing
 // negative integers. If WithLimit < 0, then unlimited results are
 // returned. If WithLimit == 0, then default limits are used for results.
@@ -7,6 +7,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/boundary/internal/credential"
 	"github.com/hashicorp/boundary/internal/db"
@@ -14,6 +15,7 @@ import (
 	"github.com/hashicorp/boundary/internal/errors"
 	"github.com/hashicorp/boundary/internal/kms"
 	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/hashicorp/boundary/internal/pagination"
 	"github.com/hashicorp/go-dbw"
 	"github.com/hashicorp/go-secure-stdlib/strutil"
 )
@@ -466,9 +468,20 @@ func (r *Repository) ListCredentialLibraries(ctx context.Context, storeId string
 		limit = opts.withLimit
 	}
 	var libs []*CredentialLibrary
-	err := r.reader.SearchWhere(ctx, &libs, "store_id = ?", []any{storeId}, db.WithLimit(limit))
+	err := r.reader.SearchWhere(ctx, &libs, "store_id = ?", []any{storeId}, db.WithLimit(limit), db.WithPage(opts.withPage))
 	if err != nil {
 		return nil, errors.Wrap(ctx, err, op)
 	}
 	return libs, nil
 }
+
+// ListDeletedCredentialLibraryIds returns the credential libraries of all
+// subtypes of the credential store deleted after since.
+func (r *Repository) ListDeletedCredentialLibraryIds(ctx context.Context, storeId string, since time.Time) ([]*pagination.DeletedResource, error) {
+	const op = "vault.(Repository).ListDeletedCredentialLibraryIds"
+	deleted, err := pagination.ListDeletedIdsByParent(ctx, r.reader, "credential_library", storeId, since)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return deleted, nil
+}
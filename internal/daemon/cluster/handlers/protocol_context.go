@@ -0,0 +1,266 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package handlers
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/hashicorp/boundary/internal/daemon/controller/common"
+	"github.com/hashicorp/boundary/internal/errors"
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/servers/services"
+	"github.com/hashicorp/boundary/internal/server"
+	"github.com/hashicorp/boundary/internal/session"
+	"github.com/hashicorp/boundary/internal/target"
+	targethttp "github.com/hashicorp/boundary/internal/target/http"
+	"github.com/hashicorp/boundary/internal/target/postgres"
+	"github.com/hashicorp/boundary/internal/target/ssh"
+	"github.com/hashicorp/nodeenrollment"
+	"github.com/hashicorp/nodeenrollment/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// targetProtocolContext provides the protocol context for the target type of
+// the session being connected to. Only ssh, http and postgres targets have a
+// protocol context, connections to all other targets use noProtocolContext.
+func targetProtocolContext(
+	ctx context.Context,
+	sessRepo *session.Repository,
+	serversRepo *server.Repository,
+	workerAuthRepoFn common.WorkerAuthRepoStorageFactory,
+	targetRepoFn target.RepositoryFactory,
+	req *pbs.AuthorizeConnectionRequest,
+	route []string,
+) (*anypb.Any, error) {
+	sessInfo, _, err := sessRepo.LookupSession(ctx, req.GetSessionId())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "error looking up session: %v", err)
+	}
+	if sessInfo == nil {
+		return nil, status.Error(codes.Internal, "Invalid session info in lookup session response")
+	}
+	switch target.SubtypeFromId(sessInfo.TargetId) {
+	case ssh.Subtype:
+		pc, err := sshProtocolContext(ctx, sessRepo, workerAuthRepoFn, targetRepoFn, sessInfo, route)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "error creating ssh protocol context: %v", err)
+		}
+		return pc, nil
+	case targethttp.Subtype:
+		pc, err := httpProtocolContext(ctx, sessRepo, workerAuthRepoFn, targetRepoFn, sessInfo, route)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "error creating http protocol context: %v", err)
+		}
+		return pc, nil
+	case postgres.Subtype:
+		pc, err := postgresProtocolContext(ctx, sessRepo, workerAuthRepoFn, targetRepoFn, sessInfo, route)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "error creating postgres protocol context: %v", err)
+		}
+		return pc, nil
+	default:
+		return noProtocolContext(ctx, sessRepo, serversRepo, workerAuthRepoFn, targetRepoFn, req, route)
+	}
+}
+
+// sshProtocolContext returns a SshProtocolContext containing the injected
+// application credentials of the session and the host keys the endpoint is
+// allowed to present. The credentials are encrypted for the egress worker, the
+// last worker in the route, when it is a pki worker. kms workers do not have an
+// encryption key so their credentials are sent unencrypted over the cluster
+// connection, the same as in LookupSession.
+func sshProtocolContext(
+	ctx context.Context,
+	sessRepo *session.Repository,
+	workerAuthRepoFn common.WorkerAuthRepoStorageFactory,
+	targetRepoFn target.RepositoryFactory,
+	sessInfo *session.Session,
+	route []string,
+) (*anypb.Any, error) {
+	const op = "handlers.sshProtocolContext"
+	if targetRepoFn == nil {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing target repository function")
+	}
+	targetRepo, err := targetRepoFn()
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	t, _, _, err := targetRepo.LookupTarget(ctx, sessInfo.TargetId)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	sshTarget, ok := t.(*ssh.Target)
+	if !ok {
+		return nil, errors.New(ctx, errors.Internal, op, "target is not an ssh target")
+	}
+
+	creds, encrypted, err := injectedCredentials(ctx, sessRepo, workerAuthRepoFn, sessInfo, route)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	ret, err := anypb.New(&pbs.SshProtocolContext{
+		Credentials:          creds,
+		EncryptedCredentials: encrypted,
+		HostKeys:             sshTarget.GetHostKeyList(),
+	})
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return ret, nil
+}
+
+// httpProtocolContext returns a HttpProtocolContext containing the injected
+// application credentials of the session, encrypted the same way as in
+// sshProtocolContext, along with the http specific settings of the target.
+func httpProtocolContext(
+	ctx context.Context,
+	sessRepo *session.Repository,
+	workerAuthRepoFn common.WorkerAuthRepoStorageFactory,
+	targetRepoFn target.RepositoryFactory,
+	sessInfo *session.Session,
+	route []string,
+) (*anypb.Any, error) {
+	const op = "handlers.httpProtocolContext"
+	if targetRepoFn == nil {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing target repository function")
+	}
+	targetRepo, err := targetRepoFn()
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	t, _, _, err := targetRepo.LookupTarget(ctx, sessInfo.TargetId)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	httpTarget, ok := t.(*targethttp.Target)
+	if !ok {
+		return nil, errors.New(ctx, errors.Internal, op, "target is not an http target")
+	}
+	u, err := url.Parse(sessInfo.Endpoint)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("parsing session endpoint"))
+	}
+
+	creds, encrypted, err := injectedCredentials(ctx, sessRepo, workerAuthRepoFn, sessInfo, route)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	ret, err := anypb.New(&pbs.HttpProtocolContext{
+		Credentials:          creds,
+		EncryptedCredentials: encrypted,
+		UseTls:               httpTarget.GetUseTls(),
+		AllowedPaths:         httpTarget.GetAllowedPathPrefixes(),
+		Host:                 u.Host,
+	})
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return ret, nil
+}
+
+// postgresProtocolContext returns a PostgresProtocolContext containing the
+// injected application credentials of the session, encrypted the same way as in
+// sshProtocolContext, and the certificate authorities the database server's
+// certificate is verified against.
+func postgresProtocolContext(
+	ctx context.Context,
+	sessRepo *session.Repository,
+	workerAuthRepoFn common.WorkerAuthRepoStorageFactory,
+	targetRepoFn target.RepositoryFactory,
+	sessInfo *session.Session,
+	route []string,
+) (*anypb.Any, error) {
+	const op = "handlers.postgresProtocolContext"
+	if targetRepoFn == nil {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing target repository function")
+	}
+	targetRepo, err := targetRepoFn()
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	t, _, _, err := targetRepo.LookupTarget(ctx, sessInfo.TargetId)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	postgresTarget, ok := t.(*postgres.Target)
+	if !ok {
+		return nil, errors.New(ctx, errors.Internal, op, "target is not a postgres target")
+	}
+
+	u, err := url.Parse(sessInfo.Endpoint)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("parsing session endpoint"))
+	}
+	creds, encrypted, err := injectedCredentials(ctx, sessRepo, workerAuthRepoFn, sessInfo, route)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	ret, err := anypb.New(&pbs.PostgresProtocolContext{
+		Credentials:          creds,
+		EncryptedCredentials: encrypted,
+		Host:                 u.Hostname(),
+		CaCert:               postgresTarget.GetCaCert(),
+	})
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return ret, nil
+}
+
+// injectedCredentials returns the injected application credentials of the
+// session. If the egress worker, the last worker in the route, is a pki worker
+// the credentials are returned encrypted for it, otherwise they are returned
+// unencrypted.
+func injectedCredentials(
+	ctx context.Context,
+	sessRepo *session.Repository,
+	workerAuthRepoFn common.WorkerAuthRepoStorageFactory,
+	sessInfo *session.Session,
+	route []string,
+) ([]*pbs.Credential, []byte, error) {
+	const op = "handlers.injectedCredentials"
+	if len(route) == 0 {
+		return nil, nil, errors.New(ctx, errors.InvalidParameter, op, "empty route")
+	}
+
+	sessCreds, err := sessRepo.ListSessionCredentials(ctx, sessInfo.ProjectId, sessInfo.PublicId)
+	if err != nil {
+		return nil, nil, errors.Wrap(ctx, err, op)
+	}
+	injected := &pbs.InjectedCredentials{}
+	for _, c := range sessCreds {
+		m := &pbs.Credential{}
+		if err := proto.Unmarshal(c, m); err != nil {
+			return nil, nil, errors.Wrap(ctx, err, op, errors.WithMsg("unmarshaling credential"))
+		}
+		injected.Credentials = append(injected.Credentials, m)
+	}
+	if len(injected.GetCredentials()) == 0 {
+		return nil, nil, nil
+	}
+
+	workerAuthRepo, err := workerAuthRepoFn()
+	if err != nil {
+		return nil, nil, errors.Wrap(ctx, err, op)
+	}
+	authSet, err := workerAuthRepo.FindWorkerAuthByWorkerId(ctx, route[len(route)-1])
+	switch {
+	case errors.IsNotFoundError(err):
+		return injected.GetCredentials(), nil, nil
+	case err != nil:
+		return nil, nil, errors.Wrap(ctx, err, op)
+	}
+	nodeInfo, err := types.LoadNodeInformation(ctx, workerAuthRepo, authSet.Current.GetWorkerKeyIdentifier())
+	if err != nil {
+		return nil, nil, errors.Wrap(ctx, err, op)
+	}
+	encrypted, err := nodeenrollment.EncryptMessage(ctx, injected, nodeInfo)
+	if err != nil {
+		return nil, nil, errors.Wrap(ctx, err, op, errors.WithMsg("encrypting credentials"))
+	}
+	return nil, encrypted, nil
+}
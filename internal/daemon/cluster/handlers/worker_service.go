@@ -16,8 +16,10 @@ import (
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/servers/services"
 	"github.com/hashicorp/boundary/internal/kms"
 	"github.com/hashicorp/boundary/internal/observability/event"
+	"github.com/hashicorp/boundary/internal/recording"
 	"github.com/hashicorp/boundary/internal/server"
 	"github.com/hashicorp/boundary/internal/session"
+	"github.com/hashicorp/boundary/internal/target"
 	"github.com/hashicorp/boundary/internal/types/scope"
 	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/targets"
 	"github.com/hashicorp/go-bexpr"
@@ -34,6 +36,8 @@ type workerServiceServer struct {
 	serversRepoFn       common.ServersRepoFactory
 	workerAuthRepoFn    common.WorkerAuthRepoStorageFactory
 	sessionRepoFn       session.RepositoryFactory
+	recordingRepoFn     recording.RepositoryFactory
+	targetRepoFn        target.RepositoryFactory
 	connectionRepoFn    common.ConnectionRepoFactory
 	downstreams         common.Downstreamers
 	updateTimes         *sync.Map
@@ -53,10 +57,10 @@ var (
 
 	// getProtocolContext populates the protocol specific context fields
 	// depending on the protocol used to for the boundary connection. Defaults
-	// to noProtocolContext since tcp connections are the only protocol schemes
-	// available in OSS and are a straight forward proxy with no additional
-	// fields needed.
-	getProtocolContext = noProtocolContext
+	// to targetProtocolContext which provides the injected credentials for
+	// ssh, http and postgres targets. tcp connections are a straight forward
+	// proxy with no additional fields needed.
+	getProtocolContext = targetProtocolContext
 )
 
 // singleHopConnectionRoute returns a route consisting of the singlehop worker (the root worker id)
@@ -69,6 +73,8 @@ func NewWorkerServiceServer(
 	workerAuthRepoFn common.WorkerAuthRepoStorageFactory,
 	sessionRepoFn session.RepositoryFactory,
 	connectionRepoFn common.ConnectionRepoFactory,
+	recordingRepoFn recording.RepositoryFactory,
+	targetRepoFn target.RepositoryFactory,
 	downstreams common.Downstreamers,
 	updateTimes *sync.Map,
 	kms *kms.Kms,
@@ -79,6 +85,8 @@ func NewWorkerServiceServer(
 		workerAuthRepoFn:    workerAuthRepoFn,
 		sessionRepoFn:       sessionRepoFn,
 		connectionRepoFn:    connectionRepoFn,
+		recordingRepoFn:     recordingRepoFn,
+		targetRepoFn:        targetRepoFn,
 		downstreams:         downstreams,
 		updateTimes:         updateTimes,
 		kms:                 kms,
@@ -138,7 +146,9 @@ func (ws *workerServiceServer) Status(ctx context.Context, req *pbs.StatusReques
 		server.WithAddress(wStat.GetAddress()),
 		server.WithWorkerTags(workerTags...),
 		server.WithReleaseVersion(wStat.ReleaseVersion),
-		server.WithOperationalState(wStat.OperationalState))
+		server.WithOperationalState(wStat.OperationalState),
+		server.WithProxyProtocols(wStat.GetProxyProtocols()...),
+		server.WithRecordingStorage(wStat.GetRecordingStorage()))
 	opts := []server.Option{server.WithUpdateTags(req.GetUpdateTags())}
 	if wStat.GetPublicId() != "" {
 		opts = append(opts, server.WithPublicId(wStat.GetPublicId()))
@@ -314,7 +324,7 @@ func egressFilterSelector(sessionInfo *session.Session) string {
 }
 
 // noProtocolContext doesn't provide any protocol context since tcp doesn't need any
-func noProtocolContext(context.Context, *session.Repository, *server.Repository, common.WorkerAuthRepoStorageFactory, *pbs.AuthorizeConnectionRequest, []string) (*anypb.Any, error) {
+func noProtocolContext(context.Context, *session.Repository, *server.Repository, common.WorkerAuthRepoStorageFactory, target.RepositoryFactory, *pbs.AuthorizeConnectionRequest, []string) (*anypb.Any, error) {
 	return nil, nil
 }
 
@@ -446,6 +456,7 @@ func (ws *workerServiceServer) LookupSession(ctx context.Context, req *pbs.Looku
 		TargetId:        sessionInfo.TargetId,
 		UserId:          sessionInfo.UserId,
 		Credentials:     workerCreds,
+		IdleTimeout:     sessionInfo.IdleTimeout,
 	}
 	if resp.ConnectionsLeft != -1 {
 		resp.ConnectionsLeft -= int32(authzSummary.CurrentConnectionCount)
@@ -558,11 +569,18 @@ func (ws *workerServiceServer) AuthorizeConnection(ctx context.Context, req *pbs
 		ConnectionsLeft: authzSummary.ConnectionLimit,
 		Route:           route,
 	}
-	if pc, err := getProtocolContext(ctx, sessionRepo, serversRepo, ws.workerAuthRepoFn, req, route); err != nil {
+	if pc, err := getProtocolContext(ctx, sessionRepo, serversRepo, ws.workerAuthRepoFn, ws.targetRepoFn, req, route); err != nil {
 		return nil, err
 	} else {
 		ret.ProtocolContext = pc
 	}
+	if sessInfo.EnableSessionRecording {
+		sr, err := ws.createSessionRecording(ctx, sessInfo, connectionInfo.GetPublicId())
+		if err != nil {
+			return nil, err
+		}
+		ret.SessionRecordingId = sr.GetPublicId()
+	}
 	if ret.ConnectionsLeft != -1 {
 		ret.ConnectionsLeft -= int32(authzSummary.CurrentConnectionCount)
 	}
@@ -570,6 +588,27 @@ func (ws *workerServiceServer) AuthorizeConnection(ctx context.Context, req *pbs
 	return ret, nil
 }
 
+// createSessionRecording creates the session recording of the connection of
+// a session which is recorded.
+func (ws *workerServiceServer) createSessionRecording(ctx context.Context, sessInfo *session.Session, connectionId string) (*recording.SessionRecording, error) {
+	if ws.recordingRepoFn == nil {
+		return nil, status.Error(codes.FailedPrecondition, "Session recording is not supported by this controller.")
+	}
+	recordingRepo, err := ws.recordingRepoFn()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "error getting recording repo: %v", err)
+	}
+	sr, err := recording.NewSessionRecording(ctx, sessInfo.ProjectId, sessInfo.PublicId, connectionId, sessInfo.TargetId, sessInfo.UserId)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "error creating session recording: %v", err)
+	}
+	sr, err = recordingRepo.CreateSessionRecording(ctx, sr)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "error creating session recording: %v", err)
+	}
+	return sr, nil
+}
+
 func (ws *workerServiceServer) ConnectConnection(ctx context.Context, req *pbs.ConnectConnectionRequest) (*pbs.ConnectConnectionResponse, error) {
 	const op = "workers.(workerServiceServer).ConnectConnection"
 	connRepo, err := ws.connectionRepoFn()
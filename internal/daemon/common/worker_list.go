@@ -60,6 +60,30 @@ func (w WorkerList) SupportsFeature(f version.Feature) WorkerList {
 	return ret
 }
 
+// SupportsProxyProtocol returns a new WorkerList composed of all workers in
+// this WorkerList which have a handler for the provided proxy protocol.
+func (w WorkerList) SupportsProxyProtocol(protocol string) WorkerList {
+	var ret []*server.Worker
+	for _, worker := range w {
+		if worker.SupportsProxyProtocol(protocol) {
+			ret = append(ret, worker)
+		}
+	}
+	return ret
+}
+
+// SupportsSessionRecording returns a new WorkerList composed of all workers in
+// this WorkerList which have reported having recording storage configured.
+func (w WorkerList) SupportsSessionRecording() WorkerList {
+	var ret []*server.Worker
+	for _, worker := range w {
+		if worker.SupportsSessionRecording() {
+			ret = append(ret, worker)
+		}
+	}
+	return ret
+}
+
 // filtered returns a new workerList where all elements contained in it are the
 // ones which from the original workerList that pass the evaluator's evaluation.
 func (w WorkerList) Filtered(eval *bexpr.Evaluator) (WorkerList, error) {
@@ -0,0 +1,35 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package common
+
+import (
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/server"
+	"github.com/hashicorp/boundary/internal/types/scope"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorkerList_SupportsProxyProtocol(t *testing.T) {
+	unreported := server.NewWorker(scope.Global.String(), server.WithName("unreported"))
+	tcpAndSsh := server.NewWorker(scope.Global.String(), server.WithName("tcp-and-ssh"), server.WithProxyProtocols("tcp", "ssh"))
+	sshOnly := server.NewWorker(scope.Global.String(), server.WithName("ssh-only"), server.WithProxyProtocols("ssh"))
+	workers := WorkerList{unreported, tcpAndSsh, sshOnly}
+
+	assert.Equal(t, WorkerList{unreported, tcpAndSsh}, workers.SupportsProxyProtocol("tcp"))
+	assert.Equal(t, WorkerList{tcpAndSsh, sshOnly}, workers.SupportsProxyProtocol("ssh"))
+	assert.Empty(t, workers.SupportsProxyProtocol("http"))
+	assert.Empty(t, WorkerList{}.SupportsProxyProtocol("tcp"))
+}
+
+func TestWorkerList_SupportsSessionRecording(t *testing.T) {
+	unreported := server.NewWorker(scope.Global.String(), server.WithName("unreported"))
+	noStorage := server.NewWorker(scope.Global.String(), server.WithName("no-storage"), server.WithRecordingStorage(false))
+	withStorage := server.NewWorker(scope.Global.String(), server.WithName("with-storage"), server.WithRecordingStorage(true))
+	workers := WorkerList{unreported, noStorage, withStorage}
+
+	assert.Equal(t, WorkerList{withStorage}, workers.SupportsSessionRecording())
+	assert.Empty(t, WorkerList{unreported, noStorage}.SupportsSessionRecording())
+	assert.Empty(t, WorkerList{}.SupportsSessionRecording())
+}
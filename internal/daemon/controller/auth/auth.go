@@ -5,17 +5,22 @@ package auth
 
 import (
 	"context"
+	"crypto/sha256"
 	stderrors "errors"
 	"fmt"
 	"net/http"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/hashicorp/boundary/api/recovery"
 	"github.com/hashicorp/boundary/globals"
 	"github.com/hashicorp/boundary/internal/auth"
+	"github.com/hashicorp/boundary/internal/auth/jwt"
+	"github.com/hashicorp/boundary/internal/auth/ldap"
 	"github.com/hashicorp/boundary/internal/auth/oidc"
 	"github.com/hashicorp/boundary/internal/auth/password"
+	"github.com/hashicorp/boundary/internal/authtoken"
 	"github.com/hashicorp/boundary/internal/daemon/controller/common"
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers"
 	"github.com/hashicorp/boundary/internal/errors"
@@ -24,6 +29,7 @@ import (
 	"github.com/hashicorp/boundary/internal/iam"
 	"github.com/hashicorp/boundary/internal/kms"
 	"github.com/hashicorp/boundary/internal/observability/event"
+	"github.com/hashicorp/boundary/internal/pagination"
 	"github.com/hashicorp/boundary/internal/perms"
 	"github.com/hashicorp/boundary/internal/requests"
 	"github.com/hashicorp/boundary/internal/server"
@@ -65,8 +71,11 @@ type VerifyResults struct {
 	// lots of places that embed this value
 	UserId      string
 	AuthTokenId string
-	Error       error
-	Scope       *scopes.ScopeInfo
+	// ApiKeyId is set instead of AuthTokenId when the request was made with a
+	// service account's api key
+	ApiKeyId string
+	Error    error
+	Scope    *scopes.ScopeInfo
 
 	// AuthenticatedFinished means that the request has passed through the
 	// authentication system successfully. This does _not_ indicate whether a
@@ -104,12 +113,22 @@ type verifier struct {
 	serversRepoFn      common.ServersRepoFactory
 	passwordAuthRepoFn common.PasswordAuthRepoFactory
 	oidcAuthRepoFn     common.OidcAuthRepoFactory
+	ldapAuthRepoFn     common.LdapAuthRepoFactory
+	jwtAuthRepoFn      common.JwtAuthRepoFactory
 	kms                *kms.Kms
 	requestInfo        *authpb.RequestInfo
 	res                *perms.Resource
 	act                action.Type
 	ctx                context.Context
 	acl                perms.ACL
+	grants             []perms.GrantTuple
+	requestAttributes  *perms.RequestAttributes
+}
+
+// claimer is implemented by accounts which capture claims from their auth
+// method, which can then be used in grant templates
+type claimer interface {
+	Claims(context.Context) (map[string]any, error)
 }
 
 // TODO (jefferai 10/2022): NewVerifierContextWithAccounts performs the function
@@ -128,6 +147,8 @@ func NewVerifierContextWithAccounts(ctx context.Context,
 	serversRepoFn common.ServersRepoFactory,
 	passwordAuthRepoFn common.PasswordAuthRepoFactory,
 	oidcAuthRepoFn common.OidcAuthRepoFactory,
+	ldapAuthRepoFn common.LdapAuthRepoFactory,
+	jwtAuthRepoFn common.JwtAuthRepoFactory,
 	kms *kms.Kms,
 	requestInfo *authpb.RequestInfo,
 ) context.Context {
@@ -137,6 +158,8 @@ func NewVerifierContextWithAccounts(ctx context.Context,
 		serversRepoFn:      serversRepoFn,
 		passwordAuthRepoFn: passwordAuthRepoFn,
 		oidcAuthRepoFn:     oidcAuthRepoFn,
+		ldapAuthRepoFn:     ldapAuthRepoFn,
+		jwtAuthRepoFn:      jwtAuthRepoFn,
 		kms:                kms,
 		requestInfo:        requestInfo,
 	})
@@ -153,7 +176,7 @@ func NewVerifierContext(ctx context.Context,
 	kms *kms.Kms,
 	requestInfo *authpb.RequestInfo,
 ) context.Context {
-	return NewVerifierContextWithAccounts(ctx, iamRepoFn, authTokenRepoFn, serversRepoFn, nil, nil, kms, requestInfo)
+	return NewVerifierContextWithAccounts(ctx, iamRepoFn, authTokenRepoFn, serversRepoFn, nil, nil, nil, nil, kms, requestInfo)
 }
 
 // Verify takes in a context that has expected parameters as values and runs an
@@ -175,6 +198,13 @@ func Verify(ctx context.Context, opt ...Option) (ret VerifyResults) {
 
 	v.ctx = ctx
 
+	// The conditions of grants are evaluated against the attributes of the
+	// request, fixed at the time it is verified
+	v.requestAttributes = &perms.RequestAttributes{
+		Time:     time.Now(),
+		ClientIp: v.requestInfo.GetClientIp(),
+	}
+
 	ea := &event.Auth{}
 	defer event.WriteAudit(ctx, op, event.WithAuth(ea))
 
@@ -257,6 +287,7 @@ func Verify(ctx context.Context, opt ...Option) (ret VerifyResults) {
 		Id:      opts.withId,
 		Pin:     opts.withPin,
 		Type:    opts.withType,
+		Data:    opts.withResourceData,
 	}
 	// Global scope has no parent ID; account for this
 	if opts.withId == scope.Global.String() && opts.withType == resource.Scope {
@@ -276,11 +307,17 @@ func Verify(ctx context.Context, opt ...Option) (ret VerifyResults) {
 		event.WriteError(ctx, op, err, event.WithInfoMsg("error performing authn/authz check"))
 		return
 	}
+	v.grants = grantTuples
 
 	if ret.UserData.User.Id != nil {
 		ret.UserId = *ret.UserData.User.Id
 	}
-	ret.AuthTokenId = v.requestInfo.PublicId
+	switch {
+	case strings.HasPrefix(v.requestInfo.PublicId, authtoken.ApiKeyPrefix+"_"):
+		ret.ApiKeyId = v.requestInfo.PublicId
+	default:
+		ret.AuthTokenId = v.requestInfo.PublicId
+	}
 	ret.AuthenticationFinished = authResults.AuthenticationFinished
 	if !authResults.Authorized {
 		if v.requestInfo.DisableAuthzFailures {
@@ -370,19 +407,37 @@ func (v *verifier) decryptToken(ctx context.Context) {
 			return
 		}
 
-		at, err := tokenRepo.LookupAuthToken(v.ctx, v.requestInfo.PublicId)
-		if err != nil {
-			event.WriteError(ctx, op, err, event.WithInfoMsg("failed to look up auth token by public ID"))
-			v.requestInfo.TokenFormat = uint32(AuthTokenTypeUnknown)
-			return
-		}
-		if at == nil {
-			event.WriteError(ctx, op, stderrors.New("nil result from looking up auth token by public ID"))
-			v.requestInfo.TokenFormat = uint32(AuthTokenTypeUnknown)
-			return
+		var tokenScopeId string
+		switch {
+		case strings.HasPrefix(v.requestInfo.PublicId, authtoken.ApiKeyPrefix+"_"):
+			k, err := tokenRepo.LookupApiKey(v.ctx, v.requestInfo.PublicId)
+			if err != nil {
+				event.WriteError(ctx, op, err, event.WithInfoMsg("failed to look up api key by public ID"))
+				v.requestInfo.TokenFormat = uint32(AuthTokenTypeUnknown)
+				return
+			}
+			if k == nil {
+				event.WriteError(ctx, op, stderrors.New("nil result from looking up api key by public ID"))
+				v.requestInfo.TokenFormat = uint32(AuthTokenTypeUnknown)
+				return
+			}
+			tokenScopeId = k.GetScopeId()
+		default:
+			at, err := tokenRepo.LookupAuthToken(v.ctx, v.requestInfo.PublicId)
+			if err != nil {
+				event.WriteError(ctx, op, err, event.WithInfoMsg("failed to look up auth token by public ID"))
+				v.requestInfo.TokenFormat = uint32(AuthTokenTypeUnknown)
+				return
+			}
+			if at == nil {
+				event.WriteError(ctx, op, stderrors.New("nil result from looking up auth token by public ID"))
+				v.requestInfo.TokenFormat = uint32(AuthTokenTypeUnknown)
+				return
+			}
+			tokenScopeId = at.GetScopeId()
 		}
 
-		tokenWrapper, err := v.kms.GetWrapper(v.ctx, at.GetScopeId(), kms.KeyPurposeTokens)
+		tokenWrapper, err := v.kms.GetWrapper(v.ctx, tokenScopeId, kms.KeyPurposeTokens)
 		if err != nil {
 			event.WriteError(ctx, op, err, event.WithInfoMsg("unable to get wrapper for tokens; continuing as anonymous user"))
 			v.requestInfo.TokenFormat = uint32(AuthTokenTypeUnknown)
@@ -494,6 +549,10 @@ func (v verifier) performAuthCheck(ctx context.Context) (
 	// it's empty
 	userData.User.Id = util.Pointer(globals.AnonymousUserId)
 
+	// enrollmentToken is set when the token only allows its account to
+	// enroll a TOTP second factor
+	var enrollmentToken *authtoken.AuthToken
+
 	// Validate the token and fetch the corresponding user ID
 	switch v.requestInfo.TokenFormat {
 	case uint32(AuthTokenTypeUnknown):
@@ -514,6 +573,18 @@ func (v verifier) performAuthCheck(ctx context.Context) (
 			retErr = errors.Wrap(ctx, err, op)
 			return
 		}
+		if strings.HasPrefix(v.requestInfo.PublicId, authtoken.ApiKeyPrefix+"_") {
+			k, err := tokenRepo.ValidateApiKey(v.ctx, v.requestInfo.PublicId, v.requestInfo.Token, v.requestInfo.ClientIp)
+			if err != nil {
+				event.WriteError(ctx, op, err, event.WithInfoMsg("error validating api key; continuing as anonymous user"))
+				break
+			}
+			if k != nil {
+				// A service account has no auth account, so only the user is set
+				userData.User.Id = util.Pointer(k.GetServiceAccountId())
+			}
+			break
+		}
 		at, err := tokenRepo.ValidateToken(v.ctx, v.requestInfo.PublicId, v.requestInfo.Token)
 		if err != nil {
 			// Continue as the anonymous user as maybe this token is expired but
@@ -528,6 +599,10 @@ func (v verifier) performAuthCheck(ctx context.Context) (
 				event.WriteError(ctx, op, stderrors.New("perform auth check: valid token did not map to a user, likely because no account is associated with the user any longer; continuing as u_anon"), event.WithInfo("token_id", at.GetPublicId()))
 				userData.User.Id = util.Pointer(globals.AnonymousUserId)
 				userData.Account.Id = nil
+				break
+			}
+			if at.GetStatus() == string(authtoken.TotpEnrollmentStatus) {
+				enrollmentToken = at
 			}
 		}
 	}
@@ -538,51 +613,10 @@ func (v verifier) performAuthCheck(ctx context.Context) (
 		return
 	}
 
-	u, _, err := iamRepo.LookupUser(ctx, *userData.User.Id)
-	if err != nil {
-		retErr = errors.Wrap(ctx, err, op, errors.WithMsg("failed to lookup user"))
+	if err := v.lookupUserData(ctx, iamRepo, &userData); err != nil {
+		retErr = errors.Wrap(ctx, err, op)
 		return
 	}
-	userData.User.Name = util.Pointer(u.Name)
-	userData.User.Email = util.Pointer(u.Email)
-	userData.User.FullName = util.Pointer(u.FullName)
-
-	if userData.Account.Id != nil && *userData.Account.Id != "" && v.passwordAuthRepoFn != nil && v.oidcAuthRepoFn != nil {
-		const domain = "auth"
-		var acct auth.Account
-		var err error
-		switch subtypes.SubtypeFromId(domain, *userData.Account.Id) {
-		case password.Subtype:
-			repo, repoErr := v.passwordAuthRepoFn()
-			if repoErr != nil {
-				retErr = errors.Wrap(ctx, repoErr, op, errors.WithMsg("failed to get password auth repo"))
-				return
-			}
-			acct, err = repo.LookupAccount(ctx, *userData.Account.Id)
-		case oidc.Subtype:
-			repo, repoErr := v.oidcAuthRepoFn()
-			if repoErr != nil {
-				retErr = errors.Wrap(ctx, repoErr, op, errors.WithMsg("failed to get oidc auth repo"))
-				return
-			}
-			acct, err = repo.LookupAccount(ctx, *userData.Account.Id)
-		default:
-			retErr = errors.Wrap(ctx, err, op, errors.WithMsg("unrecognized account id type"))
-			return
-		}
-		if err != nil {
-			if errors.IsNotFoundError(err) {
-				retErr = errors.Wrap(ctx, err, op, errors.WithMsg("account doesn't exist"))
-				return
-			}
-			retErr = errors.Wrap(ctx, err, op, errors.WithMsg("error looking up account"))
-			return
-		}
-		userData.Account.Name = util.Pointer(acct.GetName())
-		userData.Account.Email = util.Pointer(acct.GetEmail())
-		userData.Account.LoginName = util.Pointer(acct.GetLoginName())
-		userData.Account.Subject = util.Pointer(acct.GetSubject())
-	}
 
 	// Look up scope details to return. We can skip a lookup when using the
 	// global scope
@@ -624,21 +658,112 @@ func (v verifier) performAuthCheck(ctx context.Context) (
 	}
 
 	var parsedGrants []perms.Grant
-
-	// Fetch and parse grants for this user ID (which may include grants for
-	// u_anon and u_auth)
-	grantTuples, err = iamRepo.GrantsForUser(v.ctx, *userData.User.Id)
+	switch {
+	case enrollmentToken != nil:
+		grantTuples, parsedGrants, err = totpEnrollmentGrants(ctx, enrollmentToken)
+	default:
+		grantTuples, parsedGrants, err = v.loadGrants(ctx, iamRepo, userData)
+	}
 	if err != nil {
 		retErr = errors.Wrap(ctx, err, op)
 		return
 	}
-	parsedGrants = make([]perms.Grant, 0, len(grantTuples))
+
+	retAcl = perms.NewACL(parsedGrants...)
+	aclResults = retAcl.Allowed(*v.res, v.act, *userData.User.Id, perms.WithRequestAttributes(v.requestAttributes))
+	// We don't set authenticated above because setting this but not authorized
+	// is used for further permissions checks, such as during recursive listing.
+	// So we want to make sure any code relying on that has the full set of
+	// grants successfully loaded.
+	aclResults.AuthenticationFinished = true
+	retErr = nil
+	return
+}
+
+// lookupUserData populates the user data with the details of the user and,
+// if set, the account.
+func (v verifier) lookupUserData(ctx context.Context, iamRepo *iam.Repository, userData *template.Data) error {
+	const op = "auth.(verifier).lookupUserData"
+	u, _, err := iamRepo.LookupUser(ctx, *userData.User.Id)
+	if err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithMsg("failed to lookup user"))
+	}
+	if u == nil {
+		return errors.New(ctx, errors.RecordNotFound, op, "user doesn't exist")
+	}
+	userData.User.Name = util.Pointer(u.Name)
+	userData.User.Email = util.Pointer(u.Email)
+	userData.User.FullName = util.Pointer(u.FullName)
+
+	if userData.Account.Id != nil && *userData.Account.Id != "" && v.passwordAuthRepoFn != nil && v.oidcAuthRepoFn != nil && v.ldapAuthRepoFn != nil && v.jwtAuthRepoFn != nil {
+		const domain = "auth"
+		var acct auth.Account
+		var err error
+		switch subtypes.SubtypeFromId(domain, *userData.Account.Id) {
+		case password.Subtype:
+			repo, repoErr := v.passwordAuthRepoFn()
+			if repoErr != nil {
+				return errors.Wrap(ctx, repoErr, op, errors.WithMsg("failed to get password auth repo"))
+			}
+			acct, err = repo.LookupAccount(ctx, *userData.Account.Id)
+		case oidc.Subtype:
+			repo, repoErr := v.oidcAuthRepoFn()
+			if repoErr != nil {
+				return errors.Wrap(ctx, repoErr, op, errors.WithMsg("failed to get oidc auth repo"))
+			}
+			acct, err = repo.LookupAccount(ctx, *userData.Account.Id)
+		case ldap.Subtype:
+			repo, repoErr := v.ldapAuthRepoFn()
+			if repoErr != nil {
+				return errors.Wrap(ctx, repoErr, op, errors.WithMsg("failed to get ldap auth repo"))
+			}
+			acct, err = repo.LookupAccount(ctx, *userData.Account.Id)
+		case jwt.Subtype:
+			repo, repoErr := v.jwtAuthRepoFn()
+			if repoErr != nil {
+				return errors.Wrap(ctx, repoErr, op, errors.WithMsg("failed to get jwt auth repo"))
+			}
+			acct, err = repo.LookupAccount(ctx, *userData.Account.Id)
+		default:
+			return errors.Wrap(ctx, err, op, errors.WithMsg("unrecognized account id type"))
+		}
+		if err != nil {
+			if errors.IsNotFoundError(err) {
+				return errors.Wrap(ctx, err, op, errors.WithMsg("account doesn't exist"))
+			}
+			return errors.Wrap(ctx, err, op, errors.WithMsg("error looking up account"))
+		}
+		userData.Account.Name = util.Pointer(acct.GetName())
+		userData.Account.Email = util.Pointer(acct.GetEmail())
+		userData.Account.LoginName = util.Pointer(acct.GetLoginName())
+		userData.Account.Subject = util.Pointer(acct.GetSubject())
+		if c, ok := acct.(claimer); ok {
+			userData.Account.Claims, err = c.Claims(ctx)
+			if err != nil {
+				return errors.Wrap(ctx, err, op, errors.WithMsg("error reading account claims"))
+			}
+		}
+	}
+	return nil
+}
+
+// loadGrants fetches the grants of the user, which may include grants for
+// u_anon and u_auth, and parses them using the user data for any templates.
+// The parsed grants are in the same order as the grant tuples.
+func (v verifier) loadGrants(ctx context.Context, iamRepo *iam.Repository, userData template.Data) ([]perms.GrantTuple, []perms.Grant, error) {
+	const op = "auth.(verifier).loadGrants"
+	grantTuples, err := iamRepo.GrantsForUser(ctx, *userData.User.Id)
+	if err != nil {
+		return nil, nil, errors.Wrap(ctx, err, op)
+	}
+	parsedGrants := make([]perms.Grant, 0, len(grantTuples))
 	// Note: Below, we always skip validation so that we don't error on formats
 	// that we've since restricted, e.g. "id=foo;actions=create,read". These
 	// will simply not have an effect.
 	for _, pair := range grantTuples {
 		permsOpts := []perms.Option{
 			perms.WithUserId(*userData.User.Id),
+			perms.WithTemplateData(&userData),
 			perms.WithSkipFinalValidation(true),
 		}
 		if userData.Account.Id != nil {
@@ -649,21 +774,28 @@ func (v verifier) performAuthCheck(ctx context.Context) (
 			pair.Grant,
 			permsOpts...)
 		if err != nil {
-			retErr = errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("failed to parse grant %#v", pair.Grant)))
-			return
+			return nil, nil, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("failed to parse grant %#v", pair.Grant)))
 		}
 		parsedGrants = append(parsedGrants, parsed)
 	}
+	return grantTuples, parsedGrants, nil
+}
 
-	retAcl = perms.NewACL(parsedGrants...)
-	aclResults = retAcl.Allowed(*v.res, v.act, *userData.User.Id)
-	// We don't set authenticated above because setting this but not authorized
-	// is used for further permissions checks, such as during recursive listing.
-	// So we want to make sure any code relying on that has the full set of
-	// grants successfully loaded.
-	aclResults.AuthenticationFinished = true
-	retErr = nil
-	return
+// totpEnrollmentGrants returns the only grants of a token with
+// authtoken.TotpEnrollmentStatus, which allow its account to enroll and
+// confirm a TOTP second factor. The grants of the user are not loaded until
+// the token is issued.
+func totpEnrollmentGrants(ctx context.Context, at *authtoken.AuthToken) ([]perms.GrantTuple, []perms.Grant, error) {
+	const op = "auth.totpEnrollmentGrants"
+	tuple := perms.GrantTuple{
+		ScopeId: at.GetScopeId(),
+		Grant:   fmt.Sprintf("id=%s;actions=%s,%s", at.GetAuthAccountId(), action.EnrollTotp, action.ConfirmTotp),
+	}
+	parsed, err := perms.Parse(tuple.ScopeId, tuple.Grant)
+	if err != nil {
+		return nil, nil, errors.Wrap(ctx, err, op)
+	}
+	return []perms.GrantTuple{tuple}, []perms.Grant{parsed}, nil
 }
 
 // FetchActionSetForId returns the allowed actions for a given ID using the
@@ -672,6 +804,31 @@ func (r *VerifyResults) FetchActionSetForId(ctx context.Context, id string, avai
 	return r.fetchActions(id, resource.Unknown, availableActions, opt...)
 }
 
+// AuthorizedDeletedIds returns the ids of the deleted resources on which the
+// caller is granted one of availableActions. res is the resource checked for
+// each deleted resource, with its id and recorded scope set. If the caller is
+// only granted :self actions on a resource, the resource must have belonged to
+// the caller.
+func (r *VerifyResults) AuthorizedDeletedIds(ctx context.Context, res perms.Resource, availableActions action.ActionSet, deleted []*pagination.DeletedResource) []string {
+	var ids []string
+	for _, d := range deleted {
+		dr := res
+		dr.Id = d.PublicId
+		if d.ScopeId != "" {
+			dr.ScopeId = d.ScopeId
+		}
+		authorizedActions := r.FetchActionSetForId(ctx, d.PublicId, availableActions, WithResource(&dr))
+		switch {
+		case len(authorizedActions) == 0:
+			continue
+		case authorizedActions.OnlySelf() && d.ParentId != r.UserId:
+			continue
+		}
+		ids = append(ids, d.PublicId)
+	}
+	return ids
+}
+
 // FetchActionSetForType returns the allowed actions for a given collection type
 // using the current set of ACLs and all other parameters the same (user, etc.)
 func (r *VerifyResults) FetchActionSetForType(ctx context.Context, typ resource.Type, availableActions action.ActionSet, opt ...Option) action.ActionSet {
@@ -713,7 +870,7 @@ func (r *VerifyResults) fetchActions(id string, typ resource.Type, availableActi
 
 	ret := make(action.ActionSet, 0, len(availableActions))
 	for _, act := range availableActions {
-		if r.v.acl.Allowed(*res, act, *r.UserData.User.Id).Authorized {
+		if r.v.acl.Allowed(*res, act, *r.UserData.User.Id, perms.WithRequestAttributes(r.v.requestAttributes)).Authorized {
 			ret = append(ret, act)
 		}
 	}
@@ -737,7 +894,7 @@ func (r *VerifyResults) FetchOutputFields(res perms.Resource, act action.Type) *
 		return ret
 	}
 
-	return r.v.acl.Allowed(res, act, *r.UserData.User.Id).OutputFields
+	return r.v.acl.Allowed(res, act, *r.UserData.User.Id, perms.WithRequestAttributes(r.v.requestAttributes)).OutputFields
 }
 
 // ACL returns the perms.ACL of the verifier.
@@ -749,6 +906,42 @@ func (r *VerifyResults) ACL() perms.ACL {
 	return r.v.acl
 }
 
+// RequestAttributes returns the attributes of the request that the conditions
+// of grants are evaluated against.
+func (r *VerifyResults) RequestAttributes() *perms.RequestAttributes {
+	if r.v == nil {
+		return nil
+	}
+
+	return r.v.requestAttributes
+}
+
+// GrantsHash returns a hash of the user and their grants. It is used to bind
+// values handed to the user, such as list tokens, to the grants in effect when
+// they were issued.
+func (r *VerifyResults) GrantsHash() []byte {
+	var grants []perms.GrantTuple
+	if r.v != nil {
+		grants = append(grants, r.v.grants...)
+	}
+	sort.Slice(grants, func(i, j int) bool {
+		switch {
+		case grants[i].RoleId != grants[j].RoleId:
+			return grants[i].RoleId < grants[j].RoleId
+		case grants[i].ScopeId != grants[j].ScopeId:
+			return grants[i].ScopeId < grants[j].ScopeId
+		default:
+			return grants[i].Grant < grants[j].Grant
+		}
+	})
+	h := sha256.New()
+	h.Write([]byte(r.UserId))
+	for _, g := range grants {
+		fmt.Fprintf(h, "\x00%s\x00%s\x00%s", g.RoleId, g.ScopeId, g.Grant)
+	}
+	return h.Sum(nil)
+}
+
 // GetTokenFromRequest pulls the token from either the Authorization header or
 // split cookies and parses it. If it cannot be parsed successfully, the issue
 // is logged and we return blank, so logic will continue as the anonymous user.
@@ -11,6 +11,7 @@ import (
 	"os"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/boundary/globals"
 	"github.com/hashicorp/boundary/internal/authtoken"
@@ -20,8 +21,14 @@ import (
 	"github.com/hashicorp/boundary/internal/iam"
 	"github.com/hashicorp/boundary/internal/kms"
 	"github.com/hashicorp/boundary/internal/observability/event"
+	"github.com/hashicorp/boundary/internal/pagination"
+	"github.com/hashicorp/boundary/internal/perms"
 	"github.com/hashicorp/boundary/internal/server"
 	"github.com/hashicorp/boundary/internal/tests/api"
+	"github.com/hashicorp/boundary/internal/types/action"
+	"github.com/hashicorp/boundary/internal/types/resource"
+	"github.com/hashicorp/boundary/internal/util"
+	"github.com/hashicorp/boundary/internal/util/template"
 	"github.com/hashicorp/eventlogger/filters/encrypt"
 	"github.com/hashicorp/go-hclog"
 	"github.com/stretchr/testify/assert"
@@ -261,3 +268,191 @@ func TestVerify_AuditEvent(t *testing.T) {
 		})
 	}
 }
+
+func TestVerify_TotpEnrollmentToken(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	testKms := kms.TestKms(t, conn, wrapper)
+	tokenRepo, err := authtoken.NewRepository(rw, rw, testKms)
+	require.NoError(t, err)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	tokenRepoFn := func() (*authtoken.Repository, error) {
+		return tokenRepo, nil
+	}
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	serversRepoFn := func() (*server.Repository, error) {
+		return server.NewRepository(rw, rw, testKms)
+	}
+
+	o, _ := iam.TestScopes(t, iamRepo)
+	at := authtoken.TestAuthToken(t, conn, testKms, o.GetPublicId(), authtoken.WithStatus(authtoken.TotpEnrollmentStatus))
+	role := iam.TestRole(t, conn, o.GetPublicId())
+	iam.TestRoleGrant(t, conn, role.GetPublicId(), "id=*;type=*;actions=*")
+	iam.TestUserRole(t, conn, role.GetPublicId(), at.GetIamUserId())
+	encToken, err := authtoken.EncryptToken(context.Background(), testKms, o.GetPublicId(), at.GetPublicId(), at.GetToken())
+	require.NoError(t, err)
+	tokValue := at.GetPublicId() + "_" + encToken
+
+	verify := func(t *testing.T, opt ...Option) VerifyResults {
+		t.Helper()
+		req := httptest.NewRequest("GET", "http://127.0.0.1/v1/accounts", nil)
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", tokValue))
+		requestInfo := authpb.RequestInfo{
+			Path:   req.URL.Path,
+			Method: req.Method,
+		}
+		requestInfo.PublicId, requestInfo.EncryptedToken, requestInfo.TokenFormat = GetTokenFromRequest(context.TODO(), testKms, req)
+		ctx := NewVerifierContext(context.Background(), iamRepoFn, tokenRepoFn, serversRepoFn, testKms, &requestInfo)
+		return Verify(ctx, opt...)
+	}
+	onAccount := func(id string, act action.Type) []Option {
+		return []Option{WithScopeId(o.GetPublicId()), WithId(id), WithType(resource.Account), WithAction(act)}
+	}
+
+	// the token only allows its account to enroll a second factor, whatever
+	// the grants of its user
+	for _, act := range []action.Type{action.EnrollTotp, action.ConfirmTotp} {
+		res := verify(t, onAccount(at.GetAuthAccountId(), act)...)
+		assert.NoError(t, res.Error, act.String())
+		assert.Equal(t, at.GetIamUserId(), res.UserId)
+	}
+	assert.Error(t, verify(t, onAccount(at.GetAuthAccountId(), action.Read)...).Error)
+	assert.Error(t, verify(t, onAccount(at.GetAuthAccountId(), action.RemoveTotp)...).Error)
+	assert.Error(t, verify(t, onAccount("acctpw_1234567890", action.EnrollTotp)...).Error)
+	assert.Error(t, verify(t, WithScopeId(o.GetPublicId()), WithType(resource.User), WithAction(action.List)).Error)
+
+	// once issued the grants of the user apply
+	issued, err := tokenRepo.IssueTotpEnrollmentToken(context.Background(), at.GetAuthAccountId(), at.GetPublicId())
+	require.NoError(t, err)
+	require.NotNil(t, issued)
+	assert.NoError(t, verify(t, onAccount(at.GetAuthAccountId(), action.Read)...).Error)
+	assert.NoError(t, verify(t, WithScopeId(o.GetPublicId()), WithType(resource.User), WithAction(action.List)).Error)
+}
+
+func TestVerify_ApiKey(t *testing.T) {
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	testKms := kms.TestKms(t, conn, wrapper)
+	tokenRepo, err := authtoken.NewRepository(rw, rw, testKms)
+	require.NoError(t, err)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	tokenRepoFn := func() (*authtoken.Repository, error) {
+		return tokenRepo, nil
+	}
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	serversRepoFn := func() (*server.Repository, error) {
+		return server.NewRepository(rw, rw, testKms)
+	}
+
+	o, _ := iam.TestScopes(t, iamRepo)
+	role := iam.TestRole(t, conn, o.GetPublicId())
+	iam.TestRoleGrant(t, conn, role.GetPublicId(), "id=*;type=user;actions=list")
+	newKey := func(t *testing.T, opt ...authtoken.Option) *authtoken.ApiKey {
+		t.Helper()
+		k := authtoken.TestApiKey(t, conn, testKms, o.GetPublicId(), opt...)
+		iam.TestUserRole(t, conn, role.GetPublicId(), k.GetServiceAccountId())
+		return k
+	}
+	key := newKey(t)
+	cidrKey := newKey(t, authtoken.WithAllowedCidrs([]string{"10.0.0.0/8"}))
+	expiredKey := newKey(t, authtoken.WithExpirationTime(time.Now().Add(time.Hour)))
+	_, err = rw.Exec(ctx, "update auth_api_key set expiration_time = create_time where public_id = ?", []any{expiredKey.GetPublicId()})
+	require.NoError(t, err)
+
+	verify := func(t *testing.T, k *authtoken.ApiKey, clientIp string, opt ...Option) VerifyResults {
+		t.Helper()
+		encToken, err := authtoken.EncryptToken(ctx, testKms, k.GetScopeId(), k.GetPublicId(), k.GetToken())
+		require.NoError(t, err)
+		req := httptest.NewRequest("GET", "http://127.0.0.1/v1/users", nil)
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s_%s", k.GetPublicId(), encToken))
+		requestInfo := authpb.RequestInfo{
+			Path:     req.URL.Path,
+			Method:   req.Method,
+			ClientIp: clientIp,
+		}
+		requestInfo.PublicId, requestInfo.EncryptedToken, requestInfo.TokenFormat = GetTokenFromRequest(ctx, testKms, req)
+		require.Equal(t, k.GetPublicId(), requestInfo.PublicId)
+		return Verify(NewVerifierContext(ctx, iamRepoFn, tokenRepoFn, serversRepoFn, testKms, &requestInfo), opt...)
+	}
+	listUsers := []Option{WithScopeId(o.GetPublicId()), WithType(resource.User), WithAction(action.List)}
+
+	// the grants of the service account of the key apply
+	res := verify(t, key, "192.168.1.1", listUsers...)
+	require.NoError(t, res.Error)
+	assert.Equal(t, key.GetServiceAccountId(), res.UserId)
+	assert.Equal(t, key.GetPublicId(), res.ApiKeyId)
+	assert.Empty(t, res.AuthTokenId)
+	assert.Error(t, verify(t, key, "192.168.1.1", WithScopeId(o.GetPublicId()), WithType(resource.Group), WithAction(action.List)).Error)
+
+	// a key limited to cidr blocks is only valid from addresses within them
+	res = verify(t, cidrKey, "10.1.2.3", listUsers...)
+	require.NoError(t, res.Error)
+	assert.Equal(t, cidrKey.GetServiceAccountId(), res.UserId)
+	res = verify(t, cidrKey, "192.168.1.1", listUsers...)
+	assert.Error(t, res.Error)
+	assert.Equal(t, globals.AnonymousUserId, res.UserId)
+
+	// an expired key is not valid
+	res = verify(t, expiredKey, "192.168.1.1", listUsers...)
+	assert.Error(t, res.Error)
+	assert.Equal(t, globals.AnonymousUserId, res.UserId)
+}
+
+func TestVerifyResults_GrantsHash(t *testing.T) {
+	t.Parallel()
+	g1 := perms.GrantTuple{RoleId: "r_1", ScopeId: "global", Grant: "id=*;type=*;actions=*"}
+	g2 := perms.GrantTuple{RoleId: "r_2", ScopeId: "p_1", Grant: "id=*;type=target;actions=list"}
+	results := func(userId string, grants ...perms.GrantTuple) *VerifyResults {
+		return &VerifyResults{UserId: userId, v: &verifier{grants: grants}}
+	}
+
+	hash := results("u_1", g1, g2).GrantsHash()
+	assert.Equal(t, hash, results("u_1", g2, g1).GrantsHash(), "order of grants must not matter")
+	assert.NotEqual(t, hash, results("u_2", g1, g2).GrantsHash())
+	assert.NotEqual(t, hash, results("u_1", g1).GrantsHash())
+	assert.Equal(t, results("u_1").GrantsHash(), (&VerifyResults{UserId: "u_1"}).GrantsHash())
+}
+
+func TestVerifyResults_AuthorizedDeletedIds(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	var grants []perms.Grant
+	for _, g := range []string{
+		"id=ttcp_1234567890;actions=read",
+		"id=*;type=session;actions=read:self,cancel:self",
+	} {
+		grant, err := perms.Parse("p_1234567890", g)
+		require.NoError(t, err)
+		grants = append(grants, grant)
+	}
+	results := &VerifyResults{
+		UserId:   "u_1234567890",
+		UserData: template.Data{User: template.User{Id: util.Pointer("u_1234567890")}},
+		v:        &verifier{acl: perms.NewACL(grants...), requestInfo: &authpb.RequestInfo{}},
+	}
+
+	targets := []*pagination.DeletedResource{
+		{PublicId: "ttcp_1234567890", ScopeId: "p_1234567890"},
+		{PublicId: "ttcp_0987654321", ScopeId: "p_1234567890"},
+		{PublicId: "ttcp_1234567890", ScopeId: "p_0987654321"},
+	}
+	assert.Equal(t, []string{"ttcp_1234567890"},
+		results.AuthorizedDeletedIds(ctx, perms.Resource{Type: resource.Target}, action.ActionSet{action.Read}, targets))
+
+	sessions := []*pagination.DeletedResource{
+		{PublicId: "s_1111111111", ScopeId: "p_1234567890", ParentId: "u_1234567890"},
+		{PublicId: "s_2222222222", ScopeId: "p_1234567890", ParentId: "u_0987654321"},
+		{PublicId: "s_3333333333", ScopeId: "p_0987654321", ParentId: "u_1234567890"},
+		// A resource without a recorded scope is checked in the scope of res
+		{PublicId: "s_4444444444", ParentId: "u_1234567890"},
+	}
+	assert.Equal(t, []string{"s_1111111111", "s_4444444444"},
+		results.AuthorizedDeletedIds(ctx, perms.Resource{ScopeId: "p_1234567890", Type: resource.Session}, action.ActionSet{action.ReadSelf, action.CancelSelf}, sessions))
+}
@@ -0,0 +1,142 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package auth
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/boundary/globals"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/perms"
+	"github.com/hashicorp/boundary/internal/types/action"
+	"github.com/hashicorp/boundary/internal/types/resource"
+	"github.com/hashicorp/boundary/internal/types/scope"
+	"github.com/hashicorp/boundary/internal/util/template"
+	"github.com/hashicorp/go-secure-stdlib/strutil"
+)
+
+// Explanation is the result of explaining whether a user is authorized to
+// perform an action on a resource.
+type Explanation struct {
+	// Authorized is whether the user is authorized to perform the action
+	Authorized bool
+
+	// UserId is the ID of the user the action was explained for
+	UserId string
+
+	// AccountId is the ID of the account used for grant templates, if any
+	AccountId string
+
+	// Grants are the grants of the user which apply to the action. The action
+	// is authorized if any apply and none of those deny it.
+	Grants []ExplainedGrant
+}
+
+// ExplainedGrant is a grant which applies to an explained action
+type ExplainedGrant struct {
+	// RoleId is the ID of the role the grant is from
+	RoleId string
+
+	// GrantScopeId is the ID of the scope the grant applies to through the role
+	GrantScopeId string
+
+	// Grant is the grant as it is set on the role
+	Grant string
+
+	// Deny is whether the grant denies the action
+	Deny bool
+}
+
+// Explain determines whether the given user is authorized to perform the
+// action on the resource, and which of their grants apply to it. The user's
+// grants are loaded and evaluated in the same way as Verify does for a request
+// made by the user, using the attributes of the current request, without
+// acting as the user.
+//
+// If accountId is set it must be one of the user's accounts, and it is used
+// for any templates in the grants. Otherwise the user's account is used if
+// they have exactly one. Resource filters are evaluated against res.Data, so
+// it should be set as it is when verifying requests for the resource.
+func (r *VerifyResults) Explain(ctx context.Context, userId, accountId string, res perms.Resource, act action.Type) (*Explanation, error) {
+	const op = "auth.(VerifyResults).Explain"
+	switch {
+	case r.v == nil:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing verifier")
+	case userId == "":
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing user id")
+	case userId == globals.RecoveryUserId:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "the recovery user does not have grants")
+	case act == action.Unknown:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing action")
+	}
+
+	v := verifier{
+		iamRepoFn:          r.v.iamRepoFn,
+		passwordAuthRepoFn: r.v.passwordAuthRepoFn,
+		oidcAuthRepoFn:     r.v.oidcAuthRepoFn,
+		ldapAuthRepoFn:     r.v.ldapAuthRepoFn,
+		jwtAuthRepoFn:      r.v.jwtAuthRepoFn,
+		requestAttributes:  r.v.requestAttributes,
+	}
+	iamRepo, err := v.iamRepoFn()
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	u, accountIds, err := iamRepo.LookupUser(ctx, userId)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("failed to lookup user"))
+	}
+	if u == nil {
+		return nil, errors.New(ctx, errors.RecordNotFound, op, "user doesn't exist")
+	}
+	switch {
+	case accountId != "":
+		if !strutil.StrListContains(accountIds, accountId) {
+			return nil, errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("account %q is not one of the user's accounts", accountId))
+		}
+	case len(accountIds) == 1:
+		accountId = accountIds[0]
+	}
+
+	userData := template.Data{
+		User: template.User{
+			Id: &userId,
+		},
+	}
+	if accountId != "" {
+		userData.Account.Id = &accountId
+	}
+	if err := v.lookupUserData(ctx, iamRepo, &userData); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	grantTuples, grants, err := v.loadGrants(ctx, iamRepo, userData)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+
+	// As in Verify, actions on the global scope are made in the global scope
+	if res.Type == resource.Scope && res.Id == scope.Global.String() {
+		res.ScopeId = scope.Global.String()
+	}
+
+	attrs := perms.WithRequestAttributes(v.requestAttributes)
+	ret := &Explanation{
+		Authorized: perms.NewACL(grants...).Allowed(res, act, userId, attrs).Authorized,
+		UserId:     userId,
+		AccountId:  accountId,
+	}
+	for i, g := range grants {
+		if !g.Applies(res, act, userId, attrs) {
+			continue
+		}
+		ret.Grants = append(ret.Grants, ExplainedGrant{
+			RoleId:       grantTuples[i].RoleId,
+			GrantScopeId: grantTuples[i].ScopeId,
+			Grant:        grantTuples[i].Grant,
+			Deny:         g.Deny(),
+		})
+	}
+	return ret, nil
+}
@@ -33,6 +33,7 @@ type options struct {
 	withRecoveryTokenNotAllowed bool
 	withAnonymousUserNotAllowed bool
 	withResource                *perms.Resource
+	withResourceData            map[string]any
 }
 
 func getDefaultOptions() options {
@@ -99,3 +100,11 @@ func WithResource(resource *perms.Resource) Option {
 		o.withResource = resource
 	}
 }
+
+// WithResourceData specifies the attributes of the resource that the resource
+// filters of grants are evaluated against
+func WithResourceData(data map[string]any) Option {
+	return func(o *options) {
+		o.withResourceData = data
+	}
+}
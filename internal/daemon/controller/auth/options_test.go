@@ -19,6 +19,7 @@ func Test_GetOpts(t *testing.T) {
 
 	withKms := new(kms.Kms)
 	res := new(perms.Resource)
+	data := map[string]any{"name": "foo"}
 
 	opts := getOpts(
 		WithScopeId("foo"),
@@ -31,6 +32,7 @@ func Test_GetOpts(t *testing.T) {
 		WithRecoveryTokenNotAllowed(true),
 		WithAnonymousUserNotAllowed(true),
 		WithResource(res),
+		WithResourceData(data),
 	)
 	exp := options{
 		withScopeId:                 "foo",
@@ -43,6 +45,7 @@ func Test_GetOpts(t *testing.T) {
 		withRecoveryTokenNotAllowed: true,
 		withAnonymousUserNotAllowed: true,
 		withResource:                res,
+		withResourceData:            data,
 	}
 	assert.Equal(t, exp, opts)
 }
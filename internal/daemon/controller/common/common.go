@@ -4,6 +4,8 @@
 package common
 
 import (
+	"github.com/hashicorp/boundary/internal/auth/jwt"
+	"github.com/hashicorp/boundary/internal/auth/ldap"
 	"github.com/hashicorp/boundary/internal/auth/oidc"
 	"github.com/hashicorp/boundary/internal/auth/password"
 	credstatic "github.com/hashicorp/boundary/internal/credential/static"
@@ -22,6 +24,8 @@ type (
 	StaticCredentialRepoFactory  = func() (*credstatic.Repository, error)
 	IamRepoFactory               = iam.IamRepoFactory
 	OidcAuthRepoFactory          = oidc.OidcRepoFactory
+	LdapAuthRepoFactory          func() (*ldap.Repository, error)
+	JwtAuthRepoFactory           func() (*jwt.Repository, error)
 	PasswordAuthRepoFactory      func() (*password.Repository, error)
 	ServersRepoFactory           func() (*server.Repository, error)
 	StaticRepoFactory            func() (*static.Repository, error)
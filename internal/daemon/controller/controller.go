@@ -11,6 +11,8 @@ import (
 	"sync"
 	"sync/atomic"
 
+	"github.com/hashicorp/boundary/internal/auth/jwt"
+	"github.com/hashicorp/boundary/internal/auth/ldap"
 	"github.com/hashicorp/boundary/internal/auth/oidc"
 	"github.com/hashicorp/boundary/internal/auth/password"
 	"github.com/hashicorp/boundary/internal/authtoken"
@@ -31,6 +33,7 @@ import (
 	kmsjob "github.com/hashicorp/boundary/internal/kms/job"
 	"github.com/hashicorp/boundary/internal/observability/event"
 	"github.com/hashicorp/boundary/internal/plugin/host"
+	"github.com/hashicorp/boundary/internal/recording"
 	"github.com/hashicorp/boundary/internal/scheduler"
 	"github.com/hashicorp/boundary/internal/scheduler/cleaner"
 	"github.com/hashicorp/boundary/internal/scheduler/job"
@@ -117,10 +120,13 @@ type Controller struct {
 	StaticCredentialRepoFn  common.StaticCredentialRepoFactory
 	IamRepoFn               common.IamRepoFactory
 	OidcRepoFn              common.OidcAuthRepoFactory
+	LdapRepoFn              common.LdapAuthRepoFactory
+	JwtRepoFn               common.JwtAuthRepoFactory
 	PasswordAuthRepoFn      common.PasswordAuthRepoFactory
 	ServersRepoFn           common.ServersRepoFactory
 	SessionRepoFn           session.RepositoryFactory
 	ConnectionRepoFn        common.ConnectionRepoFactory
+	RecordingRepoFn         recording.RepositoryFactory
 	StaticHostRepoFn        common.StaticRepoFactory
 	PluginHostRepoFn        common.PluginHostRepoFactory
 	HostPluginRepoFn        common.HostPluginRepoFactory
@@ -138,6 +144,14 @@ type Controller struct {
 	HealthService *health.Service
 
 	pkiConnManager *cluster.DownstreamManager
+
+	// recordingStorage is where session recordings are downloaded from. It is
+	// nil if the controller has no recording storage configured.
+	recordingStorage recording.Storage
+
+	// sessionStateChanges sends the session state changes to the clients
+	// watching the sessions.
+	sessionStateChanges *session.StateChangeBroker
 }
 
 func New(ctx context.Context, conf *Config) (*Controller, error) {
@@ -348,7 +362,8 @@ func New(ctx context.Context, conf *Config) (*Controller, error) {
 	c.AuthTokenRepoFn = func() (*authtoken.Repository, error) {
 		return authtoken.NewRepository(dbase, dbase, c.kms,
 			authtoken.WithTokenTimeToLiveDuration(c.conf.RawConfig.Controller.AuthTokenTimeToLiveDuration),
-			authtoken.WithTokenTimeToStaleDuration(c.conf.RawConfig.Controller.AuthTokenTimeToStaleDuration))
+			authtoken.WithTokenTimeToStaleDuration(c.conf.RawConfig.Controller.AuthTokenTimeToStaleDuration),
+			authtoken.WithTokenMaxTimeToLiveDuration(c.conf.RawConfig.Controller.AuthTokenMaxTimeToLiveDuration))
 	}
 	c.VaultCredentialRepoFn = func() (*vault.Repository, error) {
 		return vault.NewRepository(dbase, dbase, c.kms, c.scheduler)
@@ -362,6 +377,12 @@ func New(ctx context.Context, conf *Config) (*Controller, error) {
 	c.OidcRepoFn = func() (*oidc.Repository, error) {
 		return oidc.NewRepository(ctx, dbase, dbase, c.kms)
 	}
+	c.LdapRepoFn = func() (*ldap.Repository, error) {
+		return ldap.NewRepository(ctx, dbase, dbase, c.kms)
+	}
+	c.JwtRepoFn = func() (*jwt.Repository, error) {
+		return jwt.NewRepository(ctx, dbase, dbase, c.kms)
+	}
 	c.PasswordAuthRepoFn = func() (*password.Repository, error) {
 		return password.NewRepository(dbase, dbase, c.kms)
 	}
@@ -377,9 +398,22 @@ func New(ctx context.Context, conf *Config) (*Controller, error) {
 	c.ConnectionRepoFn = func() (*session.ConnectionRepository, error) {
 		return session.NewConnectionRepository(ctx, dbase, dbase, c.kms)
 	}
+	c.RecordingRepoFn = func() (*recording.Repository, error) {
+		return recording.NewRepository(ctx, dbase, dbase)
+	}
+	if path := c.conf.RawConfig.Controller.RecordingStoragePath; path != "" {
+		c.recordingStorage, err = recording.NewLocalStorage(ctx, path)
+		if err != nil {
+			return nil, fmt.Errorf("error creating recording storage: %w", err)
+		}
+	}
 	c.WorkerAuthRepoStorageFn = func() (*server.WorkerAuthRepositoryStorage, error) {
 		return server.NewRepositoryStorage(ctx, dbase, dbase, c.kms)
 	}
+	c.sessionStateChanges, err = session.NewStateChangeBroker(ctx, c.SessionRepoFn, session.DefaultStateChangePollInterval)
+	if err != nil {
+		return nil, fmt.Errorf("error creating session state change broker: %w", err)
+	}
 
 	// Check that credentials are available at startup, to avoid some harmless
 	// but nasty-looking errors
@@ -433,7 +467,7 @@ func (c *Controller) Start() error {
 		return fmt.Errorf("error starting scheduler: %w", err)
 	}
 
-	c.tickerWg.Add(5)
+	c.tickerWg.Add(6)
 	go func() {
 		defer c.tickerWg.Done()
 		c.startStatusTicking(c.baseContext)
@@ -450,6 +484,10 @@ func (c *Controller) Start() error {
 		defer c.tickerWg.Done()
 		c.startCloseExpiredPendingTokens(c.baseContext)
 	}()
+	go func() {
+		defer c.tickerWg.Done()
+		c.sessionStateChanges.Run(c.baseContext)
+	}()
 	if err := c.startWorkerConnectionMaintenanceTicking(c.baseContext, c.tickerWg, c.pkiConnManager); err != nil {
 		return errors.Wrap(c.baseContext, err, op)
 	}
@@ -22,6 +22,7 @@ import (
 	"github.com/hashicorp/boundary/internal/daemon/common"
 	"github.com/hashicorp/boundary/internal/daemon/controller/auth"
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/accounts"
+	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/apikeys"
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/authmethods"
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/authtokens"
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/credentiallibraries"
@@ -35,6 +36,8 @@ import (
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/managed_groups"
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/roles"
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/scopes"
+	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/serviceaccounts"
+	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/sessionrecordings"
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/sessions"
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/targets"
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/users"
@@ -70,8 +73,18 @@ func createMuxWithEndpoints(c *Controller, props HandlerProperties) (http.Handle
 		return nil, nil, err
 	}
 
+	sessionService, err := sessions.NewService(c.SessionRepoFn, c.IamRepoFn)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create session handler service: %w", err)
+	}
+	sessionWatchHandler, err := sessions.NewWatchHandler(sessionService, c.sessionStateChanges)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create session watch handler: %w", err)
+	}
+
 	mux := http.NewServeMux()
 	mux.Handle("/v1/", grpcGwMux)
+	mux.Handle(sessions.WatchPath, wrapHandlerWithRequestContext(sessionWatchHandler, c))
 	mux.Handle(uiPath, handleUi(c))
 
 	isUiRequest := func(req *http.Request) bool {
@@ -101,8 +114,10 @@ func (c *Controller) apiHandler(props HandlerProperties) (http.Handler, error) {
 	}
 	metricsHandler := metric.InstrumentApiHandler(eventsHandler)
 
-	// This wrap MUST be performed last. If you add a new wrapper, do so above.
-	return listenerutil.WrapCustomHeadersHandler(metricsHandler, props.ListenerConfig, isUiRequest), nil
+	// This wrap MUST be performed last, but for the flusher wrap which only
+	// recovers the flusher it hides. If you add a new wrapper, do so above.
+	customHeadersHandler := listenerutil.WrapCustomHeadersHandler(metricsHandler, props.ListenerConfig, isUiRequest)
+	return wrapHandlerWithFlusher(customHeadersHandler), nil
 }
 
 // GetHealthHandler returns a gRPC Gateway mux that is registered against the
@@ -154,14 +169,14 @@ func (c *Controller) registerGrpcServices(s *grpc.Server) error {
 		services.RegisterHostServiceServer(s, hs)
 	}
 	if _, ok := currentServices[services.AccountService_ServiceDesc.ServiceName]; !ok {
-		accts, err := accounts.NewService(c.PasswordAuthRepoFn, c.OidcRepoFn)
+		accts, err := accounts.NewService(c.PasswordAuthRepoFn, c.OidcRepoFn, c.LdapRepoFn, c.JwtRepoFn, c.AuthTokenRepoFn)
 		if err != nil {
 			return fmt.Errorf("failed to create account handler service: %w", err)
 		}
 		services.RegisterAccountServiceServer(s, accts)
 	}
 	if _, ok := currentServices[services.AuthMethodService_ServiceDesc.ServiceName]; !ok {
-		authMethods, err := authmethods.NewService(c.kms, c.PasswordAuthRepoFn, c.OidcRepoFn, c.IamRepoFn, c.AuthTokenRepoFn)
+		authMethods, err := authmethods.NewService(c.kms, c.PasswordAuthRepoFn, c.OidcRepoFn, c.LdapRepoFn, c.JwtRepoFn, c.IamRepoFn, c.AuthTokenRepoFn)
 		if err != nil {
 			return fmt.Errorf("failed to create auth method handler service: %w", err)
 		}
@@ -182,12 +197,26 @@ func (c *Controller) registerGrpcServices(s *grpc.Server) error {
 		services.RegisterScopeServiceServer(s, os)
 	}
 	if _, ok := currentServices[services.UserService_ServiceDesc.ServiceName]; !ok {
-		us, err := users.NewService(c.IamRepoFn)
+		us, err := users.NewService(c.IamRepoFn, c.AuthTokenRepoFn)
 		if err != nil {
 			return fmt.Errorf("failed to create user handler service: %w", err)
 		}
 		services.RegisterUserServiceServer(s, us)
 	}
+	if _, ok := currentServices[services.ServiceAccountService_ServiceDesc.ServiceName]; !ok {
+		sas, err := serviceaccounts.NewService(c.IamRepoFn)
+		if err != nil {
+			return fmt.Errorf("failed to create service account handler service: %w", err)
+		}
+		services.RegisterServiceAccountServiceServer(s, sas)
+	}
+	if _, ok := currentServices[services.ApiKeyService_ServiceDesc.ServiceName]; !ok {
+		aks, err := apikeys.NewService(c.AuthTokenRepoFn, c.IamRepoFn, c.kms)
+		if err != nil {
+			return fmt.Errorf("failed to create api key handler service: %w", err)
+		}
+		services.RegisterApiKeyServiceServer(s, aks)
+	}
 	if _, ok := currentServices[services.TargetService_ServiceDesc.ServiceName]; !ok {
 		ts, err := targets.NewService(
 			c.baseContext,
@@ -215,7 +244,7 @@ func (c *Controller) registerGrpcServices(s *grpc.Server) error {
 		services.RegisterGroupServiceServer(s, gs)
 	}
 	if _, ok := currentServices[services.RoleService_ServiceDesc.ServiceName]; !ok {
-		rs, err := roles.NewService(c.IamRepoFn)
+		rs, err := roles.NewService(c.IamRepoFn, c.TargetRepoFn, c.ServersRepoFn)
 		if err != nil {
 			return fmt.Errorf("failed to create role handler service: %w", err)
 		}
@@ -228,8 +257,15 @@ func (c *Controller) registerGrpcServices(s *grpc.Server) error {
 		}
 		services.RegisterSessionServiceServer(s, ss)
 	}
+	if _, ok := currentServices[services.SessionRecordingService_ServiceDesc.ServiceName]; !ok {
+		srs, err := sessionrecordings.NewService(c.baseContext, c.RecordingRepoFn, c.IamRepoFn, c.recordingStorage)
+		if err != nil {
+			return fmt.Errorf("failed to create session recording handler service: %w", err)
+		}
+		services.RegisterSessionRecordingServiceServer(s, srs)
+	}
 	if _, ok := currentServices[services.ManagedGroupService_ServiceDesc.ServiceName]; !ok {
-		mgs, err := managed_groups.NewService(c.OidcRepoFn)
+		mgs, err := managed_groups.NewService(c.PasswordAuthRepoFn, c.OidcRepoFn, c.LdapRepoFn, c.JwtRepoFn)
 		if err != nil {
 			return fmt.Errorf("failed to create managed groups handler service: %w", err)
 		}
@@ -300,6 +336,12 @@ func registerGrpcGatewayEndpoints(ctx context.Context, gwMux *runtime.ServeMux,
 	if err := services.RegisterUserServiceHandlerFromEndpoint(ctx, gwMux, gatewayTarget, dialOptions); err != nil {
 		return fmt.Errorf("failed to register user service handler: %w", err)
 	}
+	if err := services.RegisterServiceAccountServiceHandlerFromEndpoint(ctx, gwMux, gatewayTarget, dialOptions); err != nil {
+		return fmt.Errorf("failed to register service account service handler: %w", err)
+	}
+	if err := services.RegisterApiKeyServiceHandlerFromEndpoint(ctx, gwMux, gatewayTarget, dialOptions); err != nil {
+		return fmt.Errorf("failed to register api key service handler: %w", err)
+	}
 	if err := services.RegisterTargetServiceHandlerFromEndpoint(ctx, gwMux, gatewayTarget, dialOptions); err != nil {
 		return fmt.Errorf("failed to register target service handler: %w", err)
 	}
@@ -312,6 +354,9 @@ func registerGrpcGatewayEndpoints(ctx context.Context, gwMux *runtime.ServeMux,
 	if err := services.RegisterSessionServiceHandlerFromEndpoint(ctx, gwMux, gatewayTarget, dialOptions); err != nil {
 		return fmt.Errorf("failed to register session service handler: %w", err)
 	}
+	if err := services.RegisterSessionRecordingServiceHandlerFromEndpoint(ctx, gwMux, gatewayTarget, dialOptions); err != nil {
+		return fmt.Errorf("failed to register session recording service handler: %w", err)
+	}
 	if err := services.RegisterManagedGroupServiceHandlerFromEndpoint(ctx, gwMux, gatewayTarget, dialOptions); err != nil {
 		return fmt.Errorf("failed to register managed groups service handler: %w", err)
 	}
@@ -405,6 +450,47 @@ func wrapHandlerWithCommonFuncs(h http.Handler, c *Controller, props HandlerProp
 	})
 }
 
+// wrapHandlerWithRequestContext adds to the request context what
+// requestCtxInterceptor adds for the gRPC services, for the handlers which are
+// not served through the grpc-gateway.
+func wrapHandlerWithRequestContext(h http.Handler, c *Controller) http.Handler {
+	const op = "controller.wrapHandlerWithRequestContext"
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx, err := newRequestContext(
+			r.Context(),
+			r.Header.Get("Grpc-Metadata-"+requestInfoMdKey),
+			c.IamRepoFn,
+			c.AuthTokenRepoFn,
+			c.ServersRepoFn,
+			c.PasswordAuthRepoFn,
+			c.OidcRepoFn,
+			c.LdapRepoFn,
+			c.JwtRepoFn,
+			c.kms,
+			c.apiGrpcGatewayTicket,
+			c.conf.Eventer,
+		)
+		if err != nil {
+			event.WriteError(r.Context(), op, err, event.WithInfoMsg("error creating request context"))
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		h.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// wrapHandlerWithFlusher adds the flusher of the response to the request
+// context, as the response writers wrapping the response hide it from the
+// handlers streaming their response.
+func wrapHandlerWithFlusher(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if f, ok := w.(http.Flusher); ok {
+			r = r.WithContext(handlers.NewFlusherContext(r.Context(), f))
+		}
+		h.ServeHTTP(w, r)
+	})
+}
+
 func wrapHandlerWithCors(h http.Handler, props HandlerProperties) http.Handler {
 	allowedMethods := []string{
 		http.MethodDelete,
@@ -158,6 +158,9 @@ func TestHandleImplementedPaths(t *testing.T) {
 			"v1/roles/someid:add-grants",
 			"v1/roles/someid:set-grants",
 			"v1/roles/someid:remove-grants",
+			"v1/roles/someid:add-grant-scopes",
+			"v1/roles/someid:set-grant-scopes",
+			"v1/roles/someid:remove-grant-scopes",
 			"v1/roles/someid:add-principals",
 			"v1/roles/someid:set-principals",
 			"v1/roles/someid:remove-principals",
@@ -9,9 +9,14 @@ import (
 	"fmt"
 	"net/url"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/boundary/globals"
 	"github.com/hashicorp/boundary/internal/auth"
+	"github.com/hashicorp/boundary/internal/auth/jwt"
+	jwtstore "github.com/hashicorp/boundary/internal/auth/jwt/store"
+	"github.com/hashicorp/boundary/internal/auth/ldap"
+	ldapstore "github.com/hashicorp/boundary/internal/auth/ldap/store"
 	"github.com/hashicorp/boundary/internal/auth/oidc"
 	oidcstore "github.com/hashicorp/boundary/internal/auth/oidc/store"
 	"github.com/hashicorp/boundary/internal/auth/password"
@@ -19,9 +24,11 @@ import (
 	requestauth "github.com/hashicorp/boundary/internal/daemon/controller/auth"
 	"github.com/hashicorp/boundary/internal/daemon/controller/common"
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers"
+	"github.com/hashicorp/boundary/internal/db"
 	"github.com/hashicorp/boundary/internal/errors"
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
 	"github.com/hashicorp/boundary/internal/intglobals"
+	"github.com/hashicorp/boundary/internal/pagination"
 	"github.com/hashicorp/boundary/internal/perms"
 	"github.com/hashicorp/boundary/internal/requests"
 	"github.com/hashicorp/boundary/internal/types/action"
@@ -43,9 +50,12 @@ const (
 	idField           = "id"
 
 	// password field names
-	loginNameKey         = "login_name"
-	newPasswordField     = "new_password"
-	currentPasswordField = "current_password"
+	loginNameKey          = "login_name"
+	newPasswordField      = "new_password"
+	currentPasswordField  = "current_password"
+	codeField             = "code"
+	recoveryCodeField     = "recovery_code"
+	customAttributesField = "attributes.custom_attributes"
 
 	// oidc field names
 	issuerField     = "attributes.issuer"
@@ -53,12 +63,19 @@ const (
 	nameClaimField  = "attributes.full_name"
 	emailClaimField = "attributes.email"
 
+	// ldap field names
+	loginNameField      = "attributes.login_name"
+	dnField             = "attributes.dn"
+	memberOfGroupsField = "attributes.member_of_groups"
+
 	domain = "auth"
 )
 
 var (
 	pwMaskManager   handlers.MaskManager
 	oidcMaskManager handlers.MaskManager
+	ldapMaskManager handlers.MaskManager
+	jwtMaskManager  handlers.MaskManager
 
 	// IdActions contains the set of actions that can be performed on
 	// individual resources
@@ -70,6 +87,10 @@ var (
 			action.Delete,
 			action.SetPassword,
 			action.ChangePassword,
+			action.Unlock,
+			action.EnrollTotp,
+			action.ConfirmTotp,
+			action.RemoveTotp,
 		},
 		oidc.Subtype: {
 			action.NoOp,
@@ -77,6 +98,18 @@ var (
 			action.Update,
 			action.Delete,
 		},
+		ldap.Subtype: {
+			action.NoOp,
+			action.Read,
+			action.Update,
+			action.Delete,
+		},
+		jwt.Subtype: {
+			action.NoOp,
+			action.Read,
+			action.Update,
+			action.Delete,
+		},
 	}
 
 	// CollectionActions contains the set of actions that can be performed on
@@ -95,6 +128,12 @@ func init() {
 	if oidcMaskManager, err = handlers.NewMaskManager(handlers.MaskDestination{&oidcstore.Account{}}, handlers.MaskSource{&pb.Account{}, &pb.OidcAccountAttributes{}}); err != nil {
 		panic(err)
 	}
+	if ldapMaskManager, err = handlers.NewMaskManager(handlers.MaskDestination{&ldapstore.Account{}}, handlers.MaskSource{&pb.Account{}, &pb.LdapAccountAttributes{}}); err != nil {
+		panic(err)
+	}
+	if jwtMaskManager, err = handlers.NewMaskManager(handlers.MaskDestination{&jwtstore.Account{}}, handlers.MaskSource{&pb.Account{}, &pb.JwtAccountAttributes{}}); err != nil {
+		panic(err)
+	}
 }
 
 // Service handles request as described by the pbs.AccountServiceServer interface.
@@ -103,12 +142,15 @@ type Service struct {
 
 	pwRepoFn   common.PasswordAuthRepoFactory
 	oidcRepoFn common.OidcAuthRepoFactory
+	ldapRepoFn common.LdapAuthRepoFactory
+	jwtRepoFn  common.JwtAuthRepoFactory
+	atRepoFn   common.AuthTokenRepoFactory
 }
 
 var _ pbs.AccountServiceServer = (*Service)(nil)
 
 // NewService returns a account service which handles account related requests to boundary.
-func NewService(pwRepo common.PasswordAuthRepoFactory, oidcRepo common.OidcAuthRepoFactory) (Service, error) {
+func NewService(pwRepo common.PasswordAuthRepoFactory, oidcRepo common.OidcAuthRepoFactory, ldapRepo common.LdapAuthRepoFactory, jwtRepo common.JwtAuthRepoFactory, atRepo common.AuthTokenRepoFactory) (Service, error) {
 	const op = "accounts.NewService"
 	if pwRepo == nil {
 		return Service{}, errors.NewDeprecated(errors.InvalidParameter, op, "missing password repository")
@@ -116,7 +158,16 @@ func NewService(pwRepo common.PasswordAuthRepoFactory, oidcRepo common.OidcAuthR
 	if oidcRepo == nil {
 		return Service{}, errors.NewDeprecated(errors.InvalidParameter, op, "missing oidc repository provided")
 	}
-	return Service{pwRepoFn: pwRepo, oidcRepoFn: oidcRepo}, nil
+	if ldapRepo == nil {
+		return Service{}, errors.NewDeprecated(errors.InvalidParameter, op, "missing ldap repository provided")
+	}
+	if jwtRepo == nil {
+		return Service{}, errors.NewDeprecated(errors.InvalidParameter, op, "missing jwt repository provided")
+	}
+	if atRepo == nil {
+		return Service{}, errors.NewDeprecated(errors.InvalidParameter, op, "missing auth token repository provided")
+	}
+	return Service{pwRepoFn: pwRepo, oidcRepoFn: oidcRepo, ldapRepoFn: ldapRepo, jwtRepoFn: jwtRepo, atRepoFn: atRepo}, nil
 }
 
 // ListAccounts implements the interface pbs.AccountServiceServer.
@@ -128,30 +179,24 @@ func (s Service) ListAccounts(ctx context.Context, req *pbs.ListAccountsRequest)
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
-	ul, err := s.listFromRepo(ctx, req.GetAuthMethodId())
-	if err != nil {
-		return nil, err
-	}
-	if len(ul) == 0 {
-		return &pbs.ListAccountsResponse{}, nil
-	}
-
 	filter, err := handlers.NewFilter(req.GetFilter())
 	if err != nil {
 		return nil, err
 	}
-	finalItems := make([]*pb.Account, 0, len(ul))
 
-	res := perms.Resource{
-		ScopeId: authResults.Scope.Id,
-		Type:    resource.Account,
-		Pin:     req.GetAuthMethodId(),
+	listItemsFn := func(ctx context.Context, page *db.Page, limit int) ([]auth.Account, error) {
+		return s.listFromRepo(ctx, req.GetAuthMethodId(), page, limit)
 	}
-	for _, acct := range ul {
-		res.Id = acct.GetPublicId()
+	convertItemFn := func(ctx context.Context, acct auth.Account) (*pb.Account, bool, error) {
+		res := perms.Resource{
+			Id:      acct.GetPublicId(),
+			ScopeId: authResults.Scope.Id,
+			Type:    resource.Account,
+			Pin:     req.GetAuthMethodId(),
+		}
 		authorizedActions := authResults.FetchActionSetForId(ctx, acct.GetPublicId(), IdActions[subtypes.SubtypeFromId(domain, acct.GetPublicId())], requestauth.WithResource(&res)).Strings()
 		if len(authorizedActions) == 0 {
-			continue
+			return nil, false, nil
 		}
 
 		outputFields := authResults.FetchOutputFields(res, action.List).SelfOrDefaults(authResults.UserId)
@@ -166,20 +211,46 @@ func (s Service) ListAccounts(ctx context.Context, req *pbs.ListAccountsRequest)
 
 		item, err := toProto(ctx, acct, outputOpts...)
 		if err != nil {
-			return nil, err
+			return nil, false, err
 		}
 
 		// This comes last so that we can use item fields in the filter after
 		// the allowed fields are populated above
 		filterable, err := subtypes.Filterable(item)
 		if err != nil {
-			return nil, err
+			return nil, false, err
 		}
-		if filter.Match(filterable) {
-			finalItems = append(finalItems, item)
+		return item, filter.Match(filterable), nil
+	}
+	listDeletedIdsFn := func(ctx context.Context, since time.Time) ([]string, error) {
+		deleted, err := s.listDeletedIdsFromRepo(ctx, req.GetAuthMethodId(), since)
+		if err != nil {
+			return nil, err
 		}
+		res := perms.Resource{ScopeId: authResults.Scope.Id, Type: resource.Account, Pin: req.GetAuthMethodId()}
+		return authResults.AuthorizedDeletedIds(ctx, res, IdActions[subtypes.SubtypeFromId(domain, req.GetAuthMethodId())], deleted), nil
+	}
+
+	listReq := pagination.Request{
+		ResourceType: resource.Account,
+		GrantsHash:   authResults.GrantsHash(),
+		PageSize:     req.GetPageSize(),
+		ListToken:    req.GetListToken(),
+	}
+	page, err := pagination.List(ctx, listReq, listItemsFn, convertItemFn, listDeletedIdsFn)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &pbs.ListAccountsResponse{Items: page.Items}
+	if listReq.Paginated() {
+		resp.ResponseType = page.ResponseType()
+		resp.ListToken = page.ListToken
+		resp.SortBy = page.SortBy
+		resp.SortDir = pagination.SortDirAscending
+		resp.RemovedIds = page.RemovedIds
 	}
-	return &pbs.ListAccountsResponse{Items: finalItems}, nil
+	return resp, nil
 }
 
 // GetAccount implements the interface pbs.AccountServiceServer.
@@ -396,6 +467,155 @@ func (s Service) SetPassword(ctx context.Context, req *pbs.SetPasswordRequest) (
 	return &pbs.SetPasswordResponse{Item: item}, nil
 }
 
+// UnlockAccount implements the interface pbs.AccountServiceServer.
+func (s Service) UnlockAccount(ctx context.Context, req *pbs.UnlockAccountRequest) (*pbs.UnlockAccountResponse, error) {
+	const op = "accounts.(Service).UnlockAccount"
+
+	if err := validateUnlockAccountRequest(req); err != nil {
+		return nil, err
+	}
+
+	_, authResults := s.parentAndAuthResult(ctx, req.GetId(), action.Unlock)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	acct, err := s.unlockInRepo(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	outputFields, ok := requests.OutputFields(ctx)
+	if !ok {
+		return nil, errors.New(ctx, errors.Internal, op, "no request context found")
+	}
+
+	outputOpts := make([]handlers.Option, 0, 3)
+	outputOpts = append(outputOpts, handlers.WithOutputFields(outputFields))
+	if outputFields.Has(globals.ScopeField) {
+		outputOpts = append(outputOpts, handlers.WithScope(authResults.Scope))
+	}
+	if outputFields.Has(globals.AuthorizedActionsField) {
+		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, acct.GetPublicId(), IdActions[subtypes.SubtypeFromId(domain, acct.GetPublicId())]).Strings()))
+	}
+
+	item, err := toProto(ctx, acct, outputOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pbs.UnlockAccountResponse{Item: item}, nil
+}
+
+// EnrollTotp implements the interface pbs.AccountServiceServer.
+func (s Service) EnrollTotp(ctx context.Context, req *pbs.EnrollTotpRequest) (*pbs.EnrollTotpResponse, error) {
+	const op = "accounts.(Service).EnrollTotp"
+
+	if err := validateEnrollTotpRequest(req); err != nil {
+		return nil, err
+	}
+
+	_, authResults := s.parentAndAuthResult(ctx, req.GetId(), action.EnrollTotp)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	enrollment, err := s.enrollTotpInRepo(ctx, authResults.Scope.GetId(), req.GetId(), req.GetCode(), req.GetRecoveryCode())
+	if err != nil {
+		return nil, err
+	}
+
+	item, err := s.toAccountOutput(ctx, enrollment.Account, &authResults)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+
+	return &pbs.EnrollTotpResponse{
+		Item:          item,
+		Secret:        enrollment.Secret,
+		Url:           enrollment.Url,
+		RecoveryCodes: enrollment.RecoveryCodes,
+	}, nil
+}
+
+// ConfirmTotp implements the interface pbs.AccountServiceServer.
+func (s Service) ConfirmTotp(ctx context.Context, req *pbs.ConfirmTotpRequest) (*pbs.ConfirmTotpResponse, error) {
+	const op = "accounts.(Service).ConfirmTotp"
+
+	if err := validateConfirmTotpRequest(req); err != nil {
+		return nil, err
+	}
+
+	_, authResults := s.parentAndAuthResult(ctx, req.GetId(), action.ConfirmTotp)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	acct, err := s.confirmTotpInRepo(ctx, authResults.Scope.GetId(), req.GetId(), req.GetCode())
+	if err != nil {
+		return nil, err
+	}
+	// a token restricted to enrolling the second factor is issued now that
+	// it is confirmed
+	atRepo, err := s.atRepoFn()
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	if _, err := atRepo.IssueTotpEnrollmentToken(ctx, acct.GetPublicId(), authResults.AuthTokenId); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+
+	item, err := s.toAccountOutput(ctx, acct, &authResults)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+
+	return &pbs.ConfirmTotpResponse{Item: item}, nil
+}
+
+// RemoveTotp implements the interface pbs.AccountServiceServer.
+func (s Service) RemoveTotp(ctx context.Context, req *pbs.RemoveTotpRequest) (*pbs.RemoveTotpResponse, error) {
+	const op = "accounts.(Service).RemoveTotp"
+
+	if err := validateRemoveTotpRequest(req); err != nil {
+		return nil, err
+	}
+
+	_, authResults := s.parentAndAuthResult(ctx, req.GetId(), action.RemoveTotp)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	acct, err := s.removeTotpInRepo(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	item, err := s.toAccountOutput(ctx, acct, &authResults)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+
+	return &pbs.RemoveTotpResponse{Item: item}, nil
+}
+
+// toAccountOutput converts acct to its proto with the output fields of the
+// request.
+func (s Service) toAccountOutput(ctx context.Context, acct auth.Account, authResults *requestauth.VerifyResults) (*pb.Account, error) {
+	const op = "accounts.(Service).toAccountOutput"
+	outputFields, ok := requests.OutputFields(ctx)
+	if !ok {
+		return nil, errors.New(ctx, errors.Internal, op, "no request context found")
+	}
+
+	outputOpts := make([]handlers.Option, 0, 3)
+	outputOpts = append(outputOpts, handlers.WithOutputFields(outputFields))
+	if outputFields.Has(globals.ScopeField) {
+		outputOpts = append(outputOpts, handlers.WithScope(authResults.Scope))
+	}
+	if outputFields.Has(globals.AuthorizedActionsField) {
+		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, acct.GetPublicId(), IdActions[subtypes.SubtypeFromId(domain, acct.GetPublicId())]).Strings()))
+	}
+
+	return toProto(ctx, acct, outputOpts...)
+}
+
 // getFromRepo returns the account and, if available, managed groups the account
 // belongs to within the auth method
 func (s Service) getFromRepo(ctx context.Context, id string) (auth.Account, []string, error) {
@@ -414,6 +634,16 @@ func (s Service) getFromRepo(ctx context.Context, id string) (auth.Account, []st
 			}
 			return nil, nil, err
 		}
+		if a == nil {
+			return nil, nil, handlers.NotFoundErrorf("Account %q doesn't exist.", id)
+		}
+		mgs, err := repo.ListManagedGroupMembershipsByMember(ctx, a.GetPublicId())
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, mg := range mgs {
+			mgIds = append(mgIds, mg.GetManagedGroupId())
+		}
 		acct = a
 	case oidc.Subtype:
 		repo, err := s.oidcRepoFn()
@@ -435,6 +665,52 @@ func (s Service) getFromRepo(ctx context.Context, id string) (auth.Account, []st
 			mgIds = append(mgIds, mg.GetManagedGroupId())
 		}
 		acct = a
+	case ldap.Subtype:
+		repo, err := s.ldapRepoFn()
+		if err != nil {
+			return nil, nil, err
+		}
+		a, err := repo.LookupAccount(ctx, id)
+		if err != nil {
+			if errors.IsNotFoundError(err) {
+				return nil, nil, handlers.NotFoundErrorf("Account %q doesn't exist.", id)
+			}
+			return nil, nil, err
+		}
+		if a == nil {
+			return nil, nil, handlers.NotFoundErrorf("Account %q doesn't exist.", id)
+		}
+		mgs, err := repo.ListManagedGroupMembershipsByMember(ctx, a.GetPublicId())
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, mg := range mgs {
+			mgIds = append(mgIds, mg.GetManagedGroupId())
+		}
+		acct = a
+	case jwt.Subtype:
+		repo, err := s.jwtRepoFn()
+		if err != nil {
+			return nil, nil, err
+		}
+		a, err := repo.LookupAccount(ctx, id)
+		if err != nil {
+			if errors.IsNotFoundError(err) {
+				return nil, nil, handlers.NotFoundErrorf("Account %q doesn't exist.", id)
+			}
+			return nil, nil, err
+		}
+		if a == nil {
+			return nil, nil, handlers.NotFoundErrorf("Account %q doesn't exist.", id)
+		}
+		mgs, err := repo.ListManagedGroupMembershipsByMember(ctx, a.GetPublicId())
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, mg := range mgs {
+			mgIds = append(mgIds, mg.GetManagedGroupId())
+		}
+		acct = a
 	default:
 		return nil, nil, handlers.NotFoundErrorf("Unrecognized id.")
 	}
@@ -454,6 +730,9 @@ func (s Service) createPwInRepo(ctx context.Context, am auth.AuthMethod, item *p
 	if item.GetDescription() != nil {
 		opts = append(opts, password.WithDescription(item.GetDescription().GetValue()))
 	}
+	if len(pwAttrs.GetCustomAttributes()) > 0 {
+		opts = append(opts, password.WithCustomAttributes(pwAttrs.GetCustomAttributes()))
+	}
 	a, err := password.NewAccount(am.GetPublicId(), opts...)
 	if err != nil {
 		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to build account for creation: %v.", err)
@@ -469,6 +748,9 @@ func (s Service) createPwInRepo(ctx context.Context, am auth.AuthMethod, item *p
 	}
 	out, err := repo.CreateAccount(ctx, am.GetScopeId(), a, createOpts...)
 	if err != nil {
+		if perr := passwordPolicyError(err, "attributes.password"); perr != nil {
+			return nil, perr
+		}
 		return nil, errors.Wrap(ctx, err, op)
 	}
 	if out == nil {
@@ -516,6 +798,68 @@ func (s Service) createOidcInRepo(ctx context.Context, am auth.AuthMethod, item
 	return out, nil
 }
 
+func (s Service) createLdapInRepo(ctx context.Context, am auth.AuthMethod, item *pb.Account) (*ldap.Account, error) {
+	const op = "accounts.(Service).createLdapInRepo"
+	if item == nil {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing item")
+	}
+	var opts []ldap.Option
+	if item.GetName() != nil {
+		opts = append(opts, ldap.WithName(item.GetName().GetValue()))
+	}
+	if item.GetDescription() != nil {
+		opts = append(opts, ldap.WithDescription(item.GetDescription().GetValue()))
+	}
+	a, err := ldap.NewAccount(ctx, am.GetPublicId(), item.GetLdapAccountAttributes().GetLoginName(), opts...)
+	if err != nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to build account for creation: %v.", err)
+	}
+	repo, err := s.ldapRepoFn()
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := repo.CreateAccount(ctx, am.GetScopeId(), a)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to create account"))
+	}
+	if out == nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to create account but no error returned from repository.")
+	}
+	return out, nil
+}
+
+func (s Service) createJwtInRepo(ctx context.Context, am auth.AuthMethod, item *pb.Account) (*jwt.Account, error) {
+	const op = "accounts.(Service).createJwtInRepo"
+	if item == nil {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing item")
+	}
+	var opts []jwt.Option
+	if item.GetName() != nil {
+		opts = append(opts, jwt.WithName(item.GetName().GetValue()))
+	}
+	if item.GetDescription() != nil {
+		opts = append(opts, jwt.WithDescription(item.GetDescription().GetValue()))
+	}
+	a, err := jwt.NewAccount(ctx, am.GetPublicId(), item.GetJwtAccountAttributes().GetSubject(), opts...)
+	if err != nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to build account for creation: %v.", err)
+	}
+	repo, err := s.jwtRepoFn()
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := repo.CreateAccount(ctx, am.GetScopeId(), a)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to create account"))
+	}
+	if out == nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to create account but no error returned from repository.")
+	}
+	return out, nil
+}
+
 func (s Service) createInRepo(ctx context.Context, am auth.AuthMethod, item *pb.Account) (auth.Account, error) {
 	const op = "accounts.(Service).createInRepo"
 	if item == nil {
@@ -541,6 +885,24 @@ func (s Service) createInRepo(ctx context.Context, am auth.AuthMethod, item *pb.
 			return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to create account but no error returned from repository.")
 		}
 		out = am
+	case ldap.Subtype:
+		am, err := s.createLdapInRepo(ctx, am, item)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		if am == nil {
+			return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to create account but no error returned from repository.")
+		}
+		out = am
+	case jwt.Subtype:
+		am, err := s.createJwtInRepo(ctx, am, item)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		if am == nil {
+			return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to create account but no error returned from repository.")
+		}
+		out = am
 	}
 	return out, nil
 }
@@ -612,9 +974,77 @@ func (s Service) updateOidcInRepo(ctx context.Context, scopeId, amId, id string,
 	return out, nil
 }
 
-func (s Service) updateInRepo(ctx context.Context, scopeId, authMethodId string, req *pbs.UpdateAccountRequest) (auth.Account, error) {
-	const op = "accounts.(Service).updateInRepo"
-	var out auth.Account
+func (s Service) updateLdapInRepo(ctx context.Context, scopeId, id string, mask []string, item *pb.Account) (*ldap.Account, error) {
+	const op = "accounts.(Service).updateLdapInRepo"
+	if item == nil {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "nil account.")
+	}
+	u := ldap.AllocAccount()
+	u.PublicId = id
+	if item.GetName() != nil {
+		u.Name = item.GetName().GetValue()
+	}
+	if item.GetDescription() != nil {
+		u.Description = item.GetDescription().GetValue()
+	}
+
+	version := item.GetVersion()
+
+	dbMask := ldapMaskManager.Translate(mask)
+	if len(dbMask) == 0 {
+		return nil, handlers.InvalidArgumentErrorf("No valid fields included in the update mask.", map[string]string{"update_mask": "No valid fields provided in the update mask."})
+	}
+	repo, err := s.ldapRepoFn()
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	out, rowsUpdated, err := repo.UpdateAccount(ctx, scopeId, u, version, dbMask)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to update account"))
+	}
+	if rowsUpdated == 0 {
+		return nil, handlers.NotFoundErrorf("Account %q doesn't exist or incorrect version provided.", id)
+	}
+	return out, nil
+}
+
+func (s Service) updateJwtInRepo(ctx context.Context, scopeId, id string, mask []string, item *pb.Account) (*jwt.Account, error) {
+	const op = "accounts.(Service).updateJwtInRepo"
+	if item == nil {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "nil account.")
+	}
+	u := jwt.AllocAccount()
+	u.PublicId = id
+	if item.GetName() != nil {
+		u.Name = item.GetName().GetValue()
+	}
+	if item.GetDescription() != nil {
+		u.Description = item.GetDescription().GetValue()
+	}
+
+	version := item.GetVersion()
+
+	dbMask := jwtMaskManager.Translate(mask)
+	if len(dbMask) == 0 {
+		return nil, handlers.InvalidArgumentErrorf("No valid fields included in the update mask.", map[string]string{"update_mask": "No valid fields provided in the update mask."})
+	}
+	repo, err := s.jwtRepoFn()
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	out, rowsUpdated, err := repo.UpdateAccount(ctx, scopeId, u, version, dbMask)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to update account"))
+	}
+	if rowsUpdated == 0 {
+		return nil, handlers.NotFoundErrorf("Account %q doesn't exist or incorrect version provided.", id)
+	}
+	return out, nil
+}
+
+func (s Service) updateInRepo(ctx context.Context, scopeId, authMethodId string, req *pbs.UpdateAccountRequest) (auth.Account, error) {
+	const op = "accounts.(Service).updateInRepo"
+	var out auth.Account
 	switch subtypes.SubtypeFromId(domain, req.GetId()) {
 	case password.Subtype:
 		a, err := s.updatePwInRepo(ctx, scopeId, authMethodId, req.GetId(), req.GetUpdateMask().GetPaths(), req.GetItem())
@@ -634,6 +1064,24 @@ func (s Service) updateInRepo(ctx context.Context, scopeId, authMethodId string,
 			return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to update account but no error returned from repository.")
 		}
 		out = a
+	case ldap.Subtype:
+		a, err := s.updateLdapInRepo(ctx, scopeId, req.GetId(), req.GetUpdateMask().GetPaths(), req.GetItem())
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		if a == nil {
+			return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to update account but no error returned from repository.")
+		}
+		out = a
+	case jwt.Subtype:
+		a, err := s.updateJwtInRepo(ctx, scopeId, req.GetId(), req.GetUpdateMask().GetPaths(), req.GetItem())
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		if a == nil {
+			return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to update account but no error returned from repository.")
+		}
+		out = a
 	}
 	return out, nil
 }
@@ -655,6 +1103,18 @@ func (s Service) deleteFromRepo(ctx context.Context, scopeId, id string) (bool,
 			return false, iErr
 		}
 		rows, err = repo.DeleteAccount(ctx, scopeId, id)
+	case ldap.Subtype:
+		repo, iErr := s.ldapRepoFn()
+		if iErr != nil {
+			return false, iErr
+		}
+		rows, err = repo.DeleteAccount(ctx, scopeId, id)
+	case jwt.Subtype:
+		repo, iErr := s.jwtRepoFn()
+		if iErr != nil {
+			return false, iErr
+		}
+		rows, err = repo.DeleteAccount(ctx, scopeId, id)
 	}
 	if err != nil {
 		if errors.IsNotFoundError(err) {
@@ -665,7 +1125,7 @@ func (s Service) deleteFromRepo(ctx context.Context, scopeId, id string) (bool,
 	return rows > 0, nil
 }
 
-func (s Service) listFromRepo(ctx context.Context, authMethodId string) ([]auth.Account, error) {
+func (s Service) listFromRepo(ctx context.Context, authMethodId string, page *db.Page, limit int) ([]auth.Account, error) {
 	const op = "accounts.(Service).listFromRepo"
 
 	var outUl []auth.Account
@@ -675,7 +1135,7 @@ func (s Service) listFromRepo(ctx context.Context, authMethodId string) ([]auth.
 		if err != nil {
 			return nil, errors.Wrap(ctx, err, op)
 		}
-		pwl, err := pwRepo.ListAccounts(ctx, authMethodId)
+		pwl, err := pwRepo.ListAccounts(ctx, authMethodId, password.WithPage(page), password.WithLimit(limit))
 		if err != nil {
 			return nil, errors.Wrap(ctx, err, op)
 		}
@@ -687,17 +1147,86 @@ func (s Service) listFromRepo(ctx context.Context, authMethodId string) ([]auth.
 		if err != nil {
 			return nil, errors.Wrap(ctx, err, op)
 		}
-		oidcl, err := oidcRepo.ListAccounts(ctx, authMethodId)
+		oidcl, err := oidcRepo.ListAccounts(ctx, authMethodId, oidc.WithPage(page), oidc.WithLimit(limit))
 		if err != nil {
 			return nil, errors.Wrap(ctx, err, op)
 		}
 		for _, a := range oidcl {
 			outUl = append(outUl, a)
 		}
+	case ldap.Subtype:
+		ldapRepo, err := s.ldapRepoFn()
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		ldapl, err := ldapRepo.ListAccounts(ctx, authMethodId, ldap.WithPage(page), ldap.WithLimit(limit))
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		for _, a := range ldapl {
+			outUl = append(outUl, a)
+		}
+	case jwt.Subtype:
+		jwtRepo, err := s.jwtRepoFn()
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		jwtl, err := jwtRepo.ListAccounts(ctx, authMethodId, jwt.WithPage(page), jwt.WithLimit(limit))
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		for _, a := range jwtl {
+			outUl = append(outUl, a)
+		}
 	}
 	return outUl, nil
 }
 
+func (s Service) listDeletedIdsFromRepo(ctx context.Context, authMethodId string, since time.Time) ([]*pagination.DeletedResource, error) {
+	const op = "accounts.(Service).listDeletedIdsFromRepo"
+
+	var deleted []*pagination.DeletedResource
+	switch subtypes.SubtypeFromId(domain, authMethodId) {
+	case password.Subtype:
+		pwRepo, err := s.pwRepoFn()
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		deleted, err = pwRepo.ListDeletedAccountIds(ctx, authMethodId, since)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+	case oidc.Subtype:
+		oidcRepo, err := s.oidcRepoFn()
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		deleted, err = oidcRepo.ListDeletedAccountIds(ctx, authMethodId, since)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+	case ldap.Subtype:
+		ldapRepo, err := s.ldapRepoFn()
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		deleted, err = ldapRepo.ListDeletedAccountIds(ctx, authMethodId, since)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+	case jwt.Subtype:
+		jwtRepo, err := s.jwtRepoFn()
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		deleted, err = jwtRepo.ListDeletedAccountIds(ctx, authMethodId, since)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+	}
+	return deleted, nil
+}
+
 func (s Service) changePasswordInRepo(ctx context.Context, scopeId, id string, version uint32, currentPassword, newPassword string) (auth.Account, error) {
 	const op = "account.(Service).changePasswordInRepo"
 	repo, err := s.pwRepoFn()
@@ -706,12 +1235,12 @@ func (s Service) changePasswordInRepo(ctx context.Context, scopeId, id string, v
 	}
 	out, err := repo.ChangePassword(ctx, scopeId, id, currentPassword, newPassword, version)
 	if err != nil {
+		if perr := passwordPolicyError(err, "new_password"); perr != nil {
+			return nil, perr
+		}
 		switch {
 		case errors.IsNotFoundError(err):
 			return nil, handlers.NotFoundErrorf("Account not found.")
-		case errors.Match(errors.T(errors.PasswordTooShort), err):
-			return nil, handlers.InvalidArgumentErrorf("Error in provided request.",
-				map[string]string{"new_password": "Password is too short."})
 		case errors.Match(errors.T(errors.PasswordsEqual), err):
 			return nil, handlers.InvalidArgumentErrorf("Error in provided request.",
 				map[string]string{"new_password": "New password equal to current password."})
@@ -732,13 +1261,90 @@ func (s Service) setPasswordInRepo(ctx context.Context, scopeId, id string, vers
 		return nil, errors.Wrap(ctx, err, op)
 	}
 	out, err := repo.SetPassword(ctx, scopeId, id, pw, version)
+	if err != nil {
+		if perr := passwordPolicyError(err, "password"); perr != nil {
+			return nil, perr
+		}
+		if errors.IsNotFoundError(err) {
+			return nil, handlers.NotFoundErrorf("Account not found.")
+		}
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return out, nil
+}
+
+func (s Service) unlockInRepo(ctx context.Context, id string) (auth.Account, error) {
+	const op = "accounts.(Service).unlockInRepo"
+
+	repo, err := s.pwRepoFn()
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	out, err := repo.UnlockAccount(ctx, id)
+	if err != nil {
+		if errors.IsNotFoundError(err) {
+			return nil, handlers.NotFoundErrorf("Account not found.")
+		}
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return out, nil
+}
+
+func (s Service) enrollTotpInRepo(ctx context.Context, scopeId, id, code, recoveryCode string) (*password.TotpEnrollment, error) {
+	const op = "accounts.(Service).enrollTotpInRepo"
+
+	repo, err := s.pwRepoFn()
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	out, err := repo.EnrollTotp(ctx, scopeId, id, code, recoveryCode)
 	if err != nil {
 		switch {
 		case errors.IsNotFoundError(err):
 			return nil, handlers.NotFoundErrorf("Account not found.")
-		case errors.Match(errors.T(errors.PasswordTooShort), err):
+		case errors.Match(errors.T(errors.PasswordTotpInvalid), err):
 			return nil, handlers.InvalidArgumentErrorf("Error in provided request.",
-				map[string]string{"password": "Password is too short."})
+				map[string]string{codeField: "A valid code from the confirmed TOTP secret or a recovery code is required to replace it."})
+		case errors.Match(errors.T(errors.PasswordAccountLocked), err):
+			return nil, handlers.ApiErrorWithCodeAndMessage(codes.FailedPrecondition, "The account is locked.")
+		}
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return out, nil
+}
+
+func (s Service) confirmTotpInRepo(ctx context.Context, scopeId, id, code string) (auth.Account, error) {
+	const op = "accounts.(Service).confirmTotpInRepo"
+
+	repo, err := s.pwRepoFn()
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	out, err := repo.ConfirmTotp(ctx, scopeId, id, code)
+	if err != nil {
+		switch {
+		case errors.IsNotFoundError(err):
+			return nil, handlers.NotFoundErrorf("No TOTP secret is enrolled for the account.")
+		case errors.Match(errors.T(errors.PasswordTotpInvalid), err):
+			return nil, handlers.InvalidArgumentErrorf("Error in provided request.",
+				map[string]string{codeField: "The code does not match the enrolled TOTP secret."})
+		}
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return out, nil
+}
+
+func (s Service) removeTotpInRepo(ctx context.Context, id string) (auth.Account, error) {
+	const op = "accounts.(Service).removeTotpInRepo"
+
+	repo, err := s.pwRepoFn()
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	out, err := repo.RemoveTotp(ctx, id)
+	if err != nil {
+		if errors.IsNotFoundError(err) {
+			return nil, handlers.NotFoundErrorf("Account not found.")
 		}
 		return nil, errors.Wrap(ctx, err, op)
 	}
@@ -757,6 +1363,16 @@ func (s Service) parentAndAuthResult(ctx context.Context, id string, a action.Ty
 		res.Error = err
 		return nil, res
 	}
+	ldapRepo, err := s.ldapRepoFn()
+	if err != nil {
+		res.Error = err
+		return nil, res
+	}
+	jwtRepo, err := s.jwtRepoFn()
+	if err != nil {
+		res.Error = err
+		return nil, res
+	}
 
 	var parentId string
 	opts := []requestauth.Option{requestauth.WithType(resource.Account), requestauth.WithAction(a)}
@@ -787,6 +1403,28 @@ func (s Service) parentAndAuthResult(ctx context.Context, id string, a action.Ty
 				return nil, res
 			}
 			parentId = acct.GetAuthMethodId()
+		case ldap.Subtype:
+			acct, err := ldapRepo.LookupAccount(ctx, id)
+			if err != nil {
+				res.Error = err
+				return nil, res
+			}
+			if acct == nil {
+				res.Error = handlers.NotFoundError()
+				return nil, res
+			}
+			parentId = acct.GetAuthMethodId()
+		case jwt.Subtype:
+			acct, err := jwtRepo.LookupAccount(ctx, id)
+			if err != nil {
+				res.Error = err
+				return nil, res
+			}
+			if acct == nil {
+				res.Error = handlers.NotFoundError()
+				return nil, res
+			}
+			parentId = acct.GetAuthMethodId()
 		}
 		opts = append(opts, requestauth.WithId(id))
 	}
@@ -815,6 +1453,28 @@ func (s Service) parentAndAuthResult(ctx context.Context, id string, a action.Ty
 			return nil, res
 		}
 		authMeth = am
+	case ldap.Subtype:
+		am, err := ldapRepo.LookupAuthMethod(ctx, parentId)
+		if err != nil {
+			res.Error = err
+			return nil, res
+		}
+		if am == nil {
+			res.Error = handlers.NotFoundError()
+			return nil, res
+		}
+		authMeth = am
+	case jwt.Subtype:
+		am, err := jwtRepo.LookupAuthMethod(ctx, parentId)
+		if err != nil {
+			res.Error = err
+			return nil, res
+		}
+		if am == nil {
+			res.Error = handlers.NotFoundError()
+			return nil, res
+		}
+		authMeth = am
 	}
 	opts = append(opts, requestauth.WithScopeId(authMeth.GetScopeId()), requestauth.WithPin(parentId))
 	return authMeth, requestauth.Verify(ctx, opts...)
@@ -868,9 +1528,18 @@ func toProto(ctx context.Context, in auth.Account, opt ...handlers.Option) (*pb.
 		if !outputFields.Has(globals.AttributesField) {
 			break
 		}
+		customAttrs, err := i.GetCustomAttributeMap(ctx)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
 		out.Attrs = &pb.Account_PasswordAccountAttributes{
 			PasswordAccountAttributes: &pb.PasswordAccountAttributes{
-				LoginName: i.GetLoginName(),
+				LoginName:          i.GetLoginName(),
+				FailedAttemptCount: i.GetFailedAttemptCount(),
+				Locked:             i.GetLocked(),
+				LockedUntil:        i.GetLockedUntil().GetTimestamp(),
+				TotpEnrolled:       i.GetTotpEnrolled(),
+				CustomAttributes:   customAttrs,
 			},
 		}
 	case *oidc.Account:
@@ -909,6 +1578,51 @@ func toProto(ctx context.Context, in auth.Account, opt ...handlers.Option) (*pb.
 			}
 		}
 		out.Attrs = attrs
+	case *ldap.Account:
+		if outputFields.Has(globals.TypeField) {
+			out.Type = ldap.Subtype.String()
+		}
+		if !outputFields.Has(globals.AttributesField) {
+			break
+		}
+		groups, err := i.MemberOfGroupNames(ctx)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		out.Attrs = &pb.Account_LdapAccountAttributes{
+			LdapAccountAttributes: &pb.LdapAccountAttributes{
+				LoginName:      i.GetLoginName(),
+				FullName:       i.GetFullName(),
+				Email:          i.GetEmail(),
+				Dn:             i.GetDn(),
+				MemberOfGroups: groups,
+			},
+		}
+	case *jwt.Account:
+		if outputFields.Has(globals.TypeField) {
+			out.Type = jwt.Subtype.String()
+		}
+		if !outputFields.Has(globals.AttributesField) {
+			break
+		}
+		attrs := &pb.Account_JwtAccountAttributes{
+			JwtAccountAttributes: &pb.JwtAccountAttributes{
+				Subject:  i.GetSubject(),
+				Issuer:   i.GetIssuer(),
+				FullName: i.GetFullName(),
+				Email:    i.GetEmail(),
+			},
+		}
+		claims, err := i.Claims(ctx)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op, errors.WithMsg("error unmarshaling stored token claims"))
+		}
+		if claims != nil {
+			if attrs.JwtAccountAttributes.TokenClaims, err = structpb.NewStruct(claims); err != nil {
+				return nil, errors.Wrap(ctx, err, op, errors.WithMsg("error converting stored token claims to protobuf struct"))
+			}
+		}
+		out.Attrs = attrs
 	}
 	return &out, nil
 }
@@ -925,12 +1639,14 @@ func toStoragePwAccount(amId string, item *pb.Account) (*password.Account, error
 	if item.GetDescription() != nil {
 		opts = append(opts, password.WithDescription(item.GetDescription().GetValue()))
 	}
+	attrs := item.GetPasswordAccountAttributes()
+	// Set this regardless; it'll only take effect if the masks contain the value
+	opts = append(opts, password.WithCustomAttributes(attrs.GetCustomAttributes()))
 	u, err := password.NewAccount(amId, opts...)
 	if err != nil {
 		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to build account for creation: %v.", err)
 	}
 
-	attrs := item.GetPasswordAccountAttributes()
 	if attrs.GetLoginName() != "" {
 		u.LoginName = attrs.GetLoginName()
 	}
@@ -947,7 +1663,7 @@ func validateGetRequest(req *pbs.GetAccountRequest) error {
 	if req == nil {
 		return errors.NewDeprecated(errors.InvalidParameter, op, "nil request")
 	}
-	return handlers.ValidateGetRequest(handlers.NoopValidatorFn, req, intglobals.OldPasswordAccountPrefix, intglobals.NewPasswordAccountPrefix, oidc.AccountPrefix)
+	return handlers.ValidateGetRequest(handlers.NoopValidatorFn, req, intglobals.OldPasswordAccountPrefix, intglobals.NewPasswordAccountPrefix, oidc.AccountPrefix, ldap.AccountPrefix, jwt.AccountPrefix)
 }
 
 func validateCreateRequest(req *pbs.CreateAccountRequest) error {
@@ -973,6 +1689,7 @@ func validateCreateRequest(req *pbs.CreateAccountRequest) error {
 				if attrs.GetLoginName() == "" {
 					badFields[loginNameKey] = "This is a required field for this type."
 				}
+				validateCustomAttributes(attrs.GetCustomAttributes(), badFields)
 			}
 		case oidc.Subtype:
 			if req.GetItem().GetType() != "" && req.GetItem().GetType() != oidc.Subtype.String() {
@@ -1002,6 +1719,53 @@ func validateCreateRequest(req *pbs.CreateAccountRequest) error {
 					badFields[emailClaimField] = "This is a read only field."
 				}
 			}
+		case ldap.Subtype:
+			if req.GetItem().GetType() != "" && req.GetItem().GetType() != ldap.Subtype.String() {
+				badFields[typeField] = "Doesn't match the parent resource's type."
+			}
+			attrs := req.GetItem().GetLdapAccountAttributes()
+			switch {
+			case attrs == nil:
+				badFields["attributes"] = "This is a required field."
+			default:
+				if attrs.GetLoginName() == "" {
+					badFields[loginNameField] = "This is a required field for this type."
+				}
+				if attrs.GetFullName() != "" {
+					badFields[nameClaimField] = "This is a read only field."
+				}
+				if attrs.GetEmail() != "" {
+					badFields[emailClaimField] = "This is a read only field."
+				}
+				if attrs.GetDn() != "" {
+					badFields[dnField] = "This is a read only field."
+				}
+				if len(attrs.GetMemberOfGroups()) > 0 {
+					badFields[memberOfGroupsField] = "This is a read only field."
+				}
+			}
+		case jwt.Subtype:
+			if req.GetItem().GetType() != "" && req.GetItem().GetType() != jwt.Subtype.String() {
+				badFields[typeField] = "Doesn't match the parent resource's type."
+			}
+			attrs := req.GetItem().GetJwtAccountAttributes()
+			switch {
+			case attrs == nil:
+				badFields["attributes"] = "This is a required field."
+			default:
+				if attrs.GetSubject() == "" {
+					badFields[subjectField] = "This is a required field for this type."
+				}
+				if attrs.GetIssuer() != "" {
+					badFields[issuerField] = "This is a read only field."
+				}
+				if attrs.GetFullName() != "" {
+					badFields[nameClaimField] = "This is a read only field."
+				}
+				if attrs.GetEmail() != "" {
+					badFields[emailClaimField] = "This is a read only field."
+				}
+			}
 		default:
 			badFields[authMethodIdField] = "Unknown auth method type from ID."
 		}
@@ -1021,6 +1785,9 @@ func validateUpdateRequest(req *pbs.UpdateAccountRequest) error {
 			if req.GetItem().GetType() != "" && req.GetItem().GetType() != password.Subtype.String() {
 				badFields[typeField] = "Cannot modify the resource type."
 			}
+			if handlers.MaskContains(req.GetUpdateMask().GetPaths(), customAttributesField) {
+				validateCustomAttributes(req.GetItem().GetPasswordAccountAttributes().GetCustomAttributes(), badFields)
+			}
 		case oidc.Subtype:
 			if req.GetItem().GetType() != "" && req.GetItem().GetType() != oidc.Subtype.String() {
 				badFields[typeField] = "Cannot modify the resource type."
@@ -1037,9 +1804,30 @@ func validateUpdateRequest(req *pbs.UpdateAccountRequest) error {
 			if handlers.MaskContains(req.GetUpdateMask().GetPaths(), nameClaimField) {
 				badFields[nameClaimField] = "Field is read only."
 			}
+		case ldap.Subtype:
+			if req.GetItem().GetType() != "" && req.GetItem().GetType() != ldap.Subtype.String() {
+				badFields[typeField] = "Cannot modify the resource type."
+			}
+			for _, f := range []string{loginNameField, nameClaimField, emailClaimField, dnField, memberOfGroupsField} {
+				if handlers.MaskContains(req.GetUpdateMask().GetPaths(), f) {
+					badFields[f] = "Field is read only."
+				}
+			}
+		case jwt.Subtype:
+			if req.GetItem().GetType() != "" && req.GetItem().GetType() != jwt.Subtype.String() {
+				badFields[typeField] = "Cannot modify the resource type."
+			}
+			if handlers.MaskContains(req.GetUpdateMask().GetPaths(), subjectField) {
+				badFields[subjectField] = "Field cannot be updated."
+			}
+			for _, f := range []string{issuerField, nameClaimField, emailClaimField} {
+				if handlers.MaskContains(req.GetUpdateMask().GetPaths(), f) {
+					badFields[f] = "Field is read only."
+				}
+			}
 		}
 		return badFields
-	}, intglobals.OldPasswordAccountPrefix, intglobals.NewPasswordAccountPrefix, oidc.AccountPrefix)
+	}, intglobals.OldPasswordAccountPrefix, intglobals.NewPasswordAccountPrefix, oidc.AccountPrefix, ldap.AccountPrefix, jwt.AccountPrefix)
 }
 
 func validateDeleteRequest(req *pbs.DeleteAccountRequest) error {
@@ -1047,7 +1835,7 @@ func validateDeleteRequest(req *pbs.DeleteAccountRequest) error {
 	if req == nil {
 		return errors.NewDeprecated(errors.InvalidParameter, op, "nil request")
 	}
-	return handlers.ValidateDeleteRequest(handlers.NoopValidatorFn, req, intglobals.OldPasswordAccountPrefix, intglobals.NewPasswordAccountPrefix, oidc.AccountPrefix)
+	return handlers.ValidateDeleteRequest(handlers.NoopValidatorFn, req, intglobals.OldPasswordAccountPrefix, intglobals.NewPasswordAccountPrefix, oidc.AccountPrefix, ldap.AccountPrefix, jwt.AccountPrefix)
 }
 
 func validateListRequest(req *pbs.ListAccountsRequest) error {
@@ -1056,7 +1844,7 @@ func validateListRequest(req *pbs.ListAccountsRequest) error {
 		return errors.NewDeprecated(errors.InvalidParameter, op, "nil request")
 	}
 	badFields := map[string]string{}
-	if !handlers.ValidId(handlers.Id(req.GetAuthMethodId()), password.AuthMethodPrefix, oidc.AuthMethodPrefix) {
+	if !handlers.ValidId(handlers.Id(req.GetAuthMethodId()), password.AuthMethodPrefix, oidc.AuthMethodPrefix, ldap.AuthMethodPrefix, jwt.AuthMethodPrefix) {
 		badFields[authMethodIdField] = "Invalid formatted identifier."
 	}
 	if _, err := handlers.NewFilter(req.GetFilter()); err != nil {
@@ -1109,3 +1897,100 @@ func validateSetPasswordRequest(req *pbs.SetPasswordRequest) error {
 	}
 	return nil
 }
+
+func validateUnlockAccountRequest(req *pbs.UnlockAccountRequest) error {
+	const op = "accounts.validateUnlockAccountRequest"
+	if req == nil {
+		return errors.NewDeprecated(errors.InvalidParameter, op, "nil request")
+	}
+	badFields := map[string]string{}
+	if !handlers.ValidId(handlers.Id(req.GetId()), intglobals.OldPasswordAccountPrefix, intglobals.NewPasswordAccountPrefix) {
+		badFields[idField] = "Improperly formatted identifier."
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Error in provided request.", badFields)
+	}
+	return nil
+}
+
+func validateEnrollTotpRequest(req *pbs.EnrollTotpRequest) error {
+	const op = "accounts.validateEnrollTotpRequest"
+	if req == nil {
+		return errors.NewDeprecated(errors.InvalidParameter, op, "nil request")
+	}
+	badFields := map[string]string{}
+	if !handlers.ValidId(handlers.Id(req.GetId()), intglobals.OldPasswordAccountPrefix, intglobals.NewPasswordAccountPrefix) {
+		badFields[idField] = "Improperly formatted identifier."
+	}
+	if req.GetCode() != "" && req.GetRecoveryCode() != "" {
+		badFields[recoveryCodeField] = "Only one of a code or a recovery code may be provided."
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Error in provided request.", badFields)
+	}
+	return nil
+}
+
+func validateConfirmTotpRequest(req *pbs.ConfirmTotpRequest) error {
+	const op = "accounts.validateConfirmTotpRequest"
+	if req == nil {
+		return errors.NewDeprecated(errors.InvalidParameter, op, "nil request")
+	}
+	badFields := map[string]string{}
+	if !handlers.ValidId(handlers.Id(req.GetId()), intglobals.OldPasswordAccountPrefix, intglobals.NewPasswordAccountPrefix) {
+		badFields[idField] = "Improperly formatted identifier."
+	}
+	if req.GetCode() == "" {
+		badFields[codeField] = "This is a required field."
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Error in provided request.", badFields)
+	}
+	return nil
+}
+
+func validateRemoveTotpRequest(req *pbs.RemoveTotpRequest) error {
+	const op = "accounts.validateRemoveTotpRequest"
+	if req == nil {
+		return errors.NewDeprecated(errors.InvalidParameter, op, "nil request")
+	}
+	badFields := map[string]string{}
+	if !handlers.ValidId(handlers.Id(req.GetId()), intglobals.OldPasswordAccountPrefix, intglobals.NewPasswordAccountPrefix) {
+		badFields[idField] = "Improperly formatted identifier."
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Error in provided request.", badFields)
+	}
+	return nil
+}
+
+// passwordPolicyError returns an invalid argument error for field if err
+// is from a password which does not satisfy the password policy of its auth
+// method, and nil otherwise.
+func passwordPolicyError(err error, field string) error {
+	var msg string
+	switch {
+	case errors.Match(errors.T(errors.PasswordTooShort), err):
+		msg = "Password is too short."
+	case errors.Match(errors.T(errors.PasswordTooWeak), err):
+		msg = "Password does not contain enough of lowercase letters, uppercase letters, digits and symbols."
+	case errors.Match(errors.T(errors.PasswordDenied), err):
+		msg = "Password is too common."
+	case errors.Match(errors.T(errors.PasswordReused), err):
+		msg = "Password was used before."
+	default:
+		return nil
+	}
+	return handlers.InvalidArgumentErrorf("Error in provided request.", map[string]string{field: msg})
+}
+
+// validateCustomAttributes adds a bad field if any of the custom attributes of
+// a password account has an empty name.
+func validateCustomAttributes(attrs map[string]string, badFields map[string]string) {
+	for k := range attrs {
+		if strings.TrimSpace(k) == "" {
+			badFields[customAttributesField] = "Attribute names cannot be empty."
+			return
+		}
+	}
+}
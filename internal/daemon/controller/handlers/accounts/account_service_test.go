@@ -13,6 +13,8 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/hashicorp/boundary/globals"
+	"github.com/hashicorp/boundary/internal/auth/jwt"
+	"github.com/hashicorp/boundary/internal/auth/ldap"
 	"github.com/hashicorp/boundary/internal/auth/oidc"
 	"github.com/hashicorp/boundary/internal/auth/password"
 	"github.com/hashicorp/boundary/internal/authtoken"
@@ -25,7 +27,9 @@ import (
 	"github.com/hashicorp/boundary/internal/iam"
 	"github.com/hashicorp/boundary/internal/intglobals"
 	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/pagination"
 	"github.com/hashicorp/boundary/internal/types/action"
+	"github.com/hashicorp/boundary/internal/types/resource"
 	"github.com/hashicorp/boundary/internal/types/scope"
 	"github.com/hashicorp/boundary/internal/types/subtypes"
 	pb "github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/accounts"
@@ -49,6 +53,10 @@ var (
 		action.Delete.String(),
 		action.SetPassword.String(),
 		action.ChangePassword.String(),
+		action.Unlock.String(),
+		action.EnrollTotp.String(),
+		action.ConfirmTotp.String(),
+		action.RemoveTotp.String(),
 	}
 	oidcAuthorizedActions = []string{
 		action.NoOp.String(),
@@ -70,11 +78,23 @@ func TestNewService(t *testing.T) {
 	oidcRepoFn := func() (*oidc.Repository, error) {
 		return oidc.NewRepository(ctx, rw, rw, kmsCache)
 	}
+	ldapRepoFn := func() (*ldap.Repository, error) {
+		return ldap.NewRepository(ctx, rw, rw, kmsCache)
+	}
+	jwtRepoFn := func() (*jwt.Repository, error) {
+		return jwt.NewRepository(ctx, rw, rw, kmsCache)
+	}
+	atRepoFn := func() (*authtoken.Repository, error) {
+		return authtoken.NewRepository(rw, rw, kmsCache)
+	}
 
 	cases := []struct {
 		name     string
 		pwRepo   common.PasswordAuthRepoFactory
 		oidcRepo common.OidcAuthRepoFactory
+		ldapRepo common.LdapAuthRepoFactory
+		jwtRepo  common.JwtAuthRepoFactory
+		atRepo   common.AuthTokenRepoFactory
 		wantErr  bool
 	}{
 		{
@@ -84,22 +104,55 @@ func TestNewService(t *testing.T) {
 		{
 			name:     "nil-pw-repo",
 			oidcRepo: oidcRepoFn,
+			ldapRepo: ldapRepoFn,
+			jwtRepo:  jwtRepoFn,
+			atRepo:   atRepoFn,
 			wantErr:  true,
 		},
 		{
-			name:    "nil-oidc-repo",
-			pwRepo:  pwRepoFn,
-			wantErr: true,
+			name:     "nil-oidc-repo",
+			pwRepo:   pwRepoFn,
+			ldapRepo: ldapRepoFn,
+			jwtRepo:  jwtRepoFn,
+			atRepo:   atRepoFn,
+			wantErr:  true,
+		},
+		{
+			name:     "nil-ldap-repo",
+			pwRepo:   pwRepoFn,
+			oidcRepo: oidcRepoFn,
+			jwtRepo:  jwtRepoFn,
+			atRepo:   atRepoFn,
+			wantErr:  true,
+		},
+		{
+			name:     "nil-jwt-repo",
+			pwRepo:   pwRepoFn,
+			oidcRepo: oidcRepoFn,
+			ldapRepo: ldapRepoFn,
+			atRepo:   atRepoFn,
+			wantErr:  true,
+		},
+		{
+			name:     "nil-at-repo",
+			pwRepo:   pwRepoFn,
+			oidcRepo: oidcRepoFn,
+			ldapRepo: ldapRepoFn,
+			jwtRepo:  jwtRepoFn,
+			wantErr:  true,
 		},
 		{
 			name:     "success",
 			pwRepo:   pwRepoFn,
 			oidcRepo: oidcRepoFn,
+			ldapRepo: ldapRepoFn,
+			jwtRepo:  jwtRepoFn,
+			atRepo:   atRepoFn,
 		},
 	}
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
-			_, err := accounts.NewService(tc.pwRepo, tc.oidcRepo)
+			_, err := accounts.NewService(tc.pwRepo, tc.oidcRepo, tc.ldapRepo, tc.jwtRepo, tc.atRepo)
 			if tc.wantErr {
 				assert.Error(t, err)
 			} else {
@@ -121,11 +174,20 @@ func TestGet(t *testing.T) {
 	oidcRepoFn := func() (*oidc.Repository, error) {
 		return oidc.NewRepository(ctx, rw, rw, kmsCache)
 	}
+	ldapRepoFn := func() (*ldap.Repository, error) {
+		return ldap.NewRepository(ctx, rw, rw, kmsCache)
+	}
+	jwtRepoFn := func() (*jwt.Repository, error) {
+		return jwt.NewRepository(ctx, rw, rw, kmsCache)
+	}
+	atRepoFn := func() (*authtoken.Repository, error) {
+		return authtoken.NewRepository(rw, rw, kmsCache)
+	}
 	iamRepoFn := func() (*iam.Repository, error) {
 		return iam.NewRepository(rw, rw, kmsCache)
 	}
 
-	s, err := accounts.NewService(pwRepoFn, oidcRepoFn)
+	s, err := accounts.NewService(pwRepoFn, oidcRepoFn, ldapRepoFn, jwtRepoFn, atRepoFn)
 	require.NoError(t, err, "Couldn't create new auth token service.")
 
 	org, _ := iam.TestScopes(t, iam.TestRepo(t, conn, wrap))
@@ -264,6 +326,15 @@ func TestListPassword(t *testing.T) {
 	oidcRepoFn := func() (*oidc.Repository, error) {
 		return oidc.NewRepository(ctx, rw, rw, kms)
 	}
+	ldapRepoFn := func() (*ldap.Repository, error) {
+		return ldap.NewRepository(ctx, rw, rw, kms)
+	}
+	jwtRepoFn := func() (*jwt.Repository, error) {
+		return jwt.NewRepository(ctx, rw, rw, kms)
+	}
+	atRepoFn := func() (*authtoken.Repository, error) {
+		return authtoken.NewRepository(rw, rw, kms)
+	}
 	iamRepoFn := func() (*iam.Repository, error) {
 		return iam.NewRepository(rw, rw, kms)
 	}
@@ -359,7 +430,7 @@ func TestListPassword(t *testing.T) {
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
 			assert, require := assert.New(t), require.New(t)
-			s, err := accounts.NewService(pwRepoFn, oidcRepoFn)
+			s, err := accounts.NewService(pwRepoFn, oidcRepoFn, ldapRepoFn, jwtRepoFn, atRepoFn)
 			require.NoError(err, "Couldn't create new user service.")
 
 			// Test non-anon first
@@ -402,6 +473,15 @@ func TestListOidc(t *testing.T) {
 	oidcRepoFn := func() (*oidc.Repository, error) {
 		return oidc.NewRepository(ctx, rw, rw, kmsCache)
 	}
+	ldapRepoFn := func() (*ldap.Repository, error) {
+		return ldap.NewRepository(ctx, rw, rw, kmsCache)
+	}
+	jwtRepoFn := func() (*jwt.Repository, error) {
+		return jwt.NewRepository(ctx, rw, rw, kmsCache)
+	}
+	atRepoFn := func() (*authtoken.Repository, error) {
+		return authtoken.NewRepository(rw, rw, kmsCache)
+	}
 	iamRepoFn := func() (*iam.Repository, error) {
 		return iam.NewRepository(rw, rw, kmsCache)
 	}
@@ -513,7 +593,7 @@ func TestListOidc(t *testing.T) {
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
 			assert, require := assert.New(t), require.New(t)
-			s, err := accounts.NewService(pwRepoFn, oidcRepoFn)
+			s, err := accounts.NewService(pwRepoFn, oidcRepoFn, ldapRepoFn, jwtRepoFn, atRepoFn)
 			require.NoError(err, "Couldn't create new user service.")
 
 			got, gErr := s.ListAccounts(requestauth.DisabledAuthTestContext(iamRepoFn, o.GetPublicId()), tc.req)
@@ -547,6 +627,62 @@ func TestListOidc(t *testing.T) {
 	}
 }
 
+func TestList_Pagination(t *testing.T) {
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrap := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrap)
+	pwRepoFn := func() (*password.Repository, error) {
+		return password.NewRepository(rw, rw, kms)
+	}
+	oidcRepoFn := func() (*oidc.Repository, error) {
+		return oidc.NewRepository(ctx, rw, rw, kms)
+	}
+	ldapRepoFn := func() (*ldap.Repository, error) {
+		return ldap.NewRepository(ctx, rw, rw, kms)
+	}
+	jwtRepoFn := func() (*jwt.Repository, error) {
+		return jwt.NewRepository(ctx, rw, rw, kms)
+	}
+	atRepoFn := func() (*authtoken.Repository, error) {
+		return authtoken.NewRepository(rw, rw, kms)
+	}
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iam.NewRepository(rw, rw, kms)
+	}
+	s, err := accounts.NewService(pwRepoFn, oidcRepoFn, ldapRepoFn, jwtRepoFn, atRepoFn)
+	require.NoError(t, err)
+
+	o, _ := iam.TestScopes(t, iam.TestRepo(t, conn, wrap))
+	am := password.TestAuthMethods(t, conn, o.GetPublicId(), 1)[0]
+	// Paginated listings are oldest first
+	var want []string
+	for _, a := range password.TestMultipleAccounts(t, conn, am.GetPublicId(), 5) {
+		want = append(want, a.GetPublicId())
+	}
+	pagination.TestList(t,
+		requestauth.DisabledAuthTestContext(iamRepoFn, o.GetPublicId()),
+		requestauth.DisabledAuthTestContext(iamRepoFn, o.GetPublicId(), requestauth.WithUserId(globals.AnonymousUserId)),
+		resource.User,
+		want,
+		func(ctx context.Context, listToken string, pageSize uint32) (pagination.TestListResponse, []string, error) {
+			got, err := s.ListAccounts(ctx, &pbs.ListAccountsRequest{AuthMethodId: am.GetPublicId(), ListToken: listToken, PageSize: pageSize})
+			var ids []string
+			for _, item := range got.GetItems() {
+				ids = append(ids, item.GetId())
+			}
+			return got, ids, err
+		},
+		func(t *testing.T, id string) {
+			pwRepo, err := pwRepoFn()
+			require.NoError(t, err)
+			_, err = pwRepo.DeleteAccount(ctx, o.GetPublicId(), id)
+			require.NoError(t, err)
+		},
+	)
+}
+
 func TestDelete(t *testing.T) {
 	ctx := context.Background()
 	conn, _ := db.TestSetup(t, "postgres")
@@ -559,6 +695,15 @@ func TestDelete(t *testing.T) {
 	oidcRepoFn := func() (*oidc.Repository, error) {
 		return oidc.NewRepository(ctx, rw, rw, kmsCache)
 	}
+	ldapRepoFn := func() (*ldap.Repository, error) {
+		return ldap.NewRepository(ctx, rw, rw, kmsCache)
+	}
+	jwtRepoFn := func() (*jwt.Repository, error) {
+		return jwt.NewRepository(ctx, rw, rw, kmsCache)
+	}
+	atRepoFn := func() (*authtoken.Repository, error) {
+		return authtoken.NewRepository(rw, rw, kmsCache)
+	}
 	iamRepoFn := func() (*iam.Repository, error) {
 		return iam.NewRepository(rw, rw, kmsCache)
 	}
@@ -578,7 +723,7 @@ func TestDelete(t *testing.T) {
 	)
 	oidcA := oidc.TestAccount(t, conn, oidcAm, "test-subject")
 
-	s, err := accounts.NewService(pwRepoFn, oidcRepoFn)
+	s, err := accounts.NewService(pwRepoFn, oidcRepoFn, ldapRepoFn, jwtRepoFn, atRepoFn)
 	require.NoError(t, err, "Error when getting new user service.")
 
 	cases := []struct {
@@ -655,6 +800,15 @@ func TestDelete_twice(t *testing.T) {
 	oidcRepoFn := func() (*oidc.Repository, error) {
 		return oidc.NewRepository(ctx, rw, rw, kms)
 	}
+	ldapRepoFn := func() (*ldap.Repository, error) {
+		return ldap.NewRepository(ctx, rw, rw, kms)
+	}
+	jwtRepoFn := func() (*jwt.Repository, error) {
+		return jwt.NewRepository(ctx, rw, rw, kms)
+	}
+	atRepoFn := func() (*authtoken.Repository, error) {
+		return authtoken.NewRepository(rw, rw, kms)
+	}
 	iamRepoFn := func() (*iam.Repository, error) {
 		return iam.NewRepository(rw, rw, kms)
 	}
@@ -663,7 +817,7 @@ func TestDelete_twice(t *testing.T) {
 	am := password.TestAuthMethods(t, conn, o.GetPublicId(), 1)[0]
 	ac := password.TestAccount(t, conn, am.GetPublicId(), "name1")
 
-	s, err := accounts.NewService(pwRepoFn, oidcRepoFn)
+	s, err := accounts.NewService(pwRepoFn, oidcRepoFn, ldapRepoFn, jwtRepoFn, atRepoFn)
 	require.NoError(err, "Error when getting new user service")
 	req := &pbs.DeleteAccountRequest{
 		Id: ac.GetPublicId(),
@@ -687,11 +841,20 @@ func TestCreatePassword(t *testing.T) {
 	oidcRepoFn := func() (*oidc.Repository, error) {
 		return oidc.NewRepository(ctx, rw, rw, kms)
 	}
+	ldapRepoFn := func() (*ldap.Repository, error) {
+		return ldap.NewRepository(ctx, rw, rw, kms)
+	}
+	jwtRepoFn := func() (*jwt.Repository, error) {
+		return jwt.NewRepository(ctx, rw, rw, kms)
+	}
+	atRepoFn := func() (*authtoken.Repository, error) {
+		return authtoken.NewRepository(rw, rw, kms)
+	}
 	iamRepoFn := func() (*iam.Repository, error) {
 		return iam.NewRepository(rw, rw, kms)
 	}
 
-	s, err := accounts.NewService(pwRepoFn, oidcRepoFn)
+	s, err := accounts.NewService(pwRepoFn, oidcRepoFn, ldapRepoFn, jwtRepoFn, atRepoFn)
 	require.NoError(t, err, "Error when getting new account service.")
 
 	o, _ := iam.TestScopes(t, iam.TestRepo(t, conn, wrap))
@@ -931,11 +1094,20 @@ func TestCreateOidc(t *testing.T) {
 	oidcRepoFn := func() (*oidc.Repository, error) {
 		return oidc.NewRepository(ctx, rw, rw, kmsCache)
 	}
+	ldapRepoFn := func() (*ldap.Repository, error) {
+		return ldap.NewRepository(ctx, rw, rw, kmsCache)
+	}
+	jwtRepoFn := func() (*jwt.Repository, error) {
+		return jwt.NewRepository(ctx, rw, rw, kmsCache)
+	}
+	atRepoFn := func() (*authtoken.Repository, error) {
+		return authtoken.NewRepository(rw, rw, kmsCache)
+	}
 	iamRepoFn := func() (*iam.Repository, error) {
 		return iam.NewRepository(rw, rw, kmsCache)
 	}
 
-	s, err := accounts.NewService(pwRepoFn, oidcRepoFn)
+	s, err := accounts.NewService(pwRepoFn, oidcRepoFn, ldapRepoFn, jwtRepoFn, atRepoFn)
 	require.NoError(t, err, "Error when getting new account service.")
 
 	o, _ := iam.TestScopes(t, iam.TestRepo(t, conn, wrap))
@@ -1165,13 +1337,22 @@ func TestUpdatePassword(t *testing.T) {
 	oidcRepoFn := func() (*oidc.Repository, error) {
 		return oidc.NewRepository(ctx, rw, rw, kms)
 	}
+	ldapRepoFn := func() (*ldap.Repository, error) {
+		return ldap.NewRepository(ctx, rw, rw, kms)
+	}
+	jwtRepoFn := func() (*jwt.Repository, error) {
+		return jwt.NewRepository(ctx, rw, rw, kms)
+	}
+	atRepoFn := func() (*authtoken.Repository, error) {
+		return authtoken.NewRepository(rw, rw, kms)
+	}
 	iamRepoFn := func() (*iam.Repository, error) {
 		return iam.NewRepository(rw, rw, kms)
 	}
 
 	o, _ := iam.TestScopes(t, iam.TestRepo(t, conn, wrap))
 	am := password.TestAuthMethods(t, conn, o.GetPublicId(), 1)[0]
-	tested, err := accounts.NewService(pwRepoFn, oidcRepoFn)
+	tested, err := accounts.NewService(pwRepoFn, oidcRepoFn, ldapRepoFn, jwtRepoFn, atRepoFn)
 	require.NoError(t, err, "Error when getting new accounts service.")
 
 	defaultScopeInfo := &scopepb.ScopeInfo{Id: o.GetPublicId(), Type: o.GetType(), ParentScopeId: scope.Global.String()}
@@ -1552,6 +1733,15 @@ func TestUpdateOidc(t *testing.T) {
 	oidcRepoFn := func() (*oidc.Repository, error) {
 		return oidc.NewRepository(ctx, rw, rw, kmsCache)
 	}
+	ldapRepoFn := func() (*ldap.Repository, error) {
+		return ldap.NewRepository(ctx, rw, rw, kmsCache)
+	}
+	jwtRepoFn := func() (*jwt.Repository, error) {
+		return jwt.NewRepository(ctx, rw, rw, kmsCache)
+	}
+	atRepoFn := func() (*authtoken.Repository, error) {
+		return authtoken.NewRepository(rw, rw, kmsCache)
+	}
 	iamRepoFn := func() (*iam.Repository, error) {
 		return iam.NewRepository(rw, rw, kmsCache)
 	}
@@ -1567,7 +1757,7 @@ func TestUpdateOidc(t *testing.T) {
 		oidc.WithSigningAlgs(oidc.RS256),
 		oidc.WithApiUrl(oidc.TestConvertToUrls(t, "https://www.alice.com/callback")[0]))
 
-	tested, err := accounts.NewService(pwRepoFn, oidcRepoFn)
+	tested, err := accounts.NewService(pwRepoFn, oidcRepoFn, ldapRepoFn, jwtRepoFn, atRepoFn)
 	require.NoError(t, err, "Error when getting new auth_method service.")
 
 	defaultScopeInfo := &scopepb.ScopeInfo{Id: o.GetPublicId(), Type: o.GetType(), ParentScopeId: scope.Global.String()}
@@ -1939,12 +2129,21 @@ func TestSetPassword(t *testing.T) {
 	oidcRepoFn := func() (*oidc.Repository, error) {
 		return oidc.NewRepository(ctx, rw, rw, kms)
 	}
+	ldapRepoFn := func() (*ldap.Repository, error) {
+		return ldap.NewRepository(ctx, rw, rw, kms)
+	}
+	jwtRepoFn := func() (*jwt.Repository, error) {
+		return jwt.NewRepository(ctx, rw, rw, kms)
+	}
+	atRepoFn := func() (*authtoken.Repository, error) {
+		return authtoken.NewRepository(rw, rw, kms)
+	}
 	iamRepoFn := func() (*iam.Repository, error) {
 		return iam.NewRepository(rw, rw, kms)
 	}
 
 	o, _ := iam.TestScopes(t, iam.TestRepo(t, conn, wrap))
-	tested, err := accounts.NewService(pwRepoFn, oidcRepoFn)
+	tested, err := accounts.NewService(pwRepoFn, oidcRepoFn, ldapRepoFn, jwtRepoFn, atRepoFn)
 	require.NoError(t, err, "Error when getting new auth_method service.")
 
 	createAccount := func(t *testing.T, pw string) *pb.Account {
@@ -2078,12 +2277,21 @@ func TestChangePassword(t *testing.T) {
 	oidcRepoFn := func() (*oidc.Repository, error) {
 		return oidc.NewRepository(ctx, rw, rw, kms)
 	}
+	ldapRepoFn := func() (*ldap.Repository, error) {
+		return ldap.NewRepository(ctx, rw, rw, kms)
+	}
+	jwtRepoFn := func() (*jwt.Repository, error) {
+		return jwt.NewRepository(ctx, rw, rw, kms)
+	}
+	atRepoFn := func() (*authtoken.Repository, error) {
+		return authtoken.NewRepository(rw, rw, kms)
+	}
 	iamRepoFn := func() (*iam.Repository, error) {
 		return iam.NewRepository(rw, rw, kms)
 	}
 
 	o, _ := iam.TestScopes(t, iam.TestRepo(t, conn, wrap))
-	tested, err := accounts.NewService(pwRepoFn, oidcRepoFn)
+	tested, err := accounts.NewService(pwRepoFn, oidcRepoFn, ldapRepoFn, jwtRepoFn, atRepoFn)
 	require.NoError(t, err, "Error when getting new auth_method service.")
 
 	createAccount := func(t *testing.T, pw string) *pb.Account {
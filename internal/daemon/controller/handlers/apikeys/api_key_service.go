@@ -0,0 +1,608 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package apikeys
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/hashicorp/boundary/globals"
+	"github.com/hashicorp/boundary/internal/authtoken"
+	"github.com/hashicorp/boundary/internal/authtoken/store"
+	"github.com/hashicorp/boundary/internal/daemon/controller/auth"
+	"github.com/hashicorp/boundary/internal/daemon/controller/common"
+	"github.com/hashicorp/boundary/internal/daemon/controller/handlers"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/pagination"
+	"github.com/hashicorp/boundary/internal/perms"
+	"github.com/hashicorp/boundary/internal/requests"
+	"github.com/hashicorp/boundary/internal/types/action"
+	"github.com/hashicorp/boundary/internal/types/resource"
+	pb "github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/apikeys"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+const (
+	serviceAccountIdField = "service_account_id"
+	expirationTimeField   = "expiration_time"
+	allowedCidrsField     = "allowed_cidrs"
+	tokenField            = "token"
+)
+
+var (
+	maskManager handlers.MaskManager
+
+	// IdActions contains the set of actions that can be performed on
+	// individual resources
+	IdActions = action.ActionSet{
+		action.NoOp,
+		action.Read,
+		action.Update,
+		action.Delete,
+		action.Rotate,
+	}
+
+	// CollectionActions contains the set of actions that can be performed on
+	// this collection
+	CollectionActions = action.ActionSet{
+		action.Create,
+		action.List,
+	}
+)
+
+func init() {
+	var err error
+	if maskManager, err = handlers.NewMaskManager(handlers.MaskDestination{&store.ApiKey{}}, handlers.MaskSource{&pb.ApiKey{}}); err != nil {
+		panic(err)
+	}
+}
+
+// Service handles request as described by the pbs.ApiKeyServiceServer interface.
+type Service struct {
+	pbs.UnsafeApiKeyServiceServer
+
+	repoFn    common.AuthTokenRepoFactory
+	iamRepoFn common.IamRepoFactory
+	kms       *kms.Kms
+}
+
+var _ pbs.ApiKeyServiceServer = (*Service)(nil)
+
+// NewService returns an api key service which handles api key related
+// requests to boundary.
+func NewService(repo common.AuthTokenRepoFactory, iamRepo common.IamRepoFactory, kms *kms.Kms) (Service, error) {
+	const op = "apikeys.NewService"
+	if repo == nil {
+		return Service{}, errors.NewDeprecated(errors.InvalidParameter, op, "missing auth token repository")
+	}
+	if iamRepo == nil {
+		return Service{}, errors.NewDeprecated(errors.InvalidParameter, op, "missing iam repository")
+	}
+	if kms == nil {
+		return Service{}, errors.NewDeprecated(errors.InvalidParameter, op, "missing kms")
+	}
+	return Service{repoFn: repo, iamRepoFn: iamRepo, kms: kms}, nil
+}
+
+// ListApiKeys implements the interface pbs.ApiKeyServiceServer.
+func (s Service) ListApiKeys(ctx context.Context, req *pbs.ListApiKeysRequest) (*pbs.ListApiKeysResponse, error) {
+	if err := validateListRequest(req); err != nil {
+		return nil, err
+	}
+	_, authResults := s.parentAndAuthResult(ctx, req.GetServiceAccountId(), action.List)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	filter, err := handlers.NewFilter(req.GetFilter())
+	if err != nil {
+		return nil, err
+	}
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, err
+	}
+
+	listItemsFn := func(ctx context.Context, page *db.Page, limit int) ([]*authtoken.ApiKey, error) {
+		return repo.ListApiKeys(ctx, req.GetServiceAccountId(), authtoken.WithPage(page), authtoken.WithLimit(limit))
+	}
+	convertItemFn := func(ctx context.Context, k *authtoken.ApiKey) (*pb.ApiKey, bool, error) {
+		res := perms.Resource{
+			Id:      k.GetPublicId(),
+			ScopeId: authResults.Scope.Id,
+			Type:    resource.ApiKey,
+			Pin:     req.GetServiceAccountId(),
+		}
+		authorizedActions := authResults.FetchActionSetForId(ctx, k.GetPublicId(), IdActions, auth.WithResource(&res)).Strings()
+		if len(authorizedActions) == 0 {
+			return nil, false, nil
+		}
+
+		outputFields := authResults.FetchOutputFields(res, action.List).SelfOrDefaults(authResults.UserId)
+		outputOpts := make([]handlers.Option, 0, 3)
+		outputOpts = append(outputOpts, handlers.WithOutputFields(outputFields))
+		if outputFields.Has(globals.ScopeField) {
+			outputOpts = append(outputOpts, handlers.WithScope(authResults.Scope))
+		}
+		if outputFields.Has(globals.AuthorizedActionsField) {
+			outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authorizedActions))
+		}
+
+		item, err := toProto(ctx, k, "", outputOpts...)
+		if err != nil {
+			return nil, false, err
+		}
+		return item, filter.Match(item), nil
+	}
+
+	listDeletedIdsFn := func(ctx context.Context, since time.Time) ([]string, error) {
+		deleted, err := repo.ListDeletedApiKeyIds(ctx, req.GetServiceAccountId(), since)
+		if err != nil {
+			return nil, err
+		}
+		return authResults.AuthorizedDeletedIds(ctx, perms.Resource{ScopeId: authResults.Scope.Id, Type: resource.ApiKey, Pin: req.GetServiceAccountId()}, IdActions, deleted), nil
+	}
+	listReq := pagination.Request{
+		ResourceType: resource.ApiKey,
+		GrantsHash:   authResults.GrantsHash(),
+		PageSize:     req.GetPageSize(),
+		ListToken:    req.GetListToken(),
+	}
+	page, err := pagination.List(ctx, listReq, listItemsFn, convertItemFn, listDeletedIdsFn)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &pbs.ListApiKeysResponse{Items: page.Items}
+	if listReq.Paginated() {
+		resp.ResponseType = page.ResponseType()
+		resp.ListToken = page.ListToken
+		resp.SortBy = page.SortBy
+		resp.SortDir = pagination.SortDirAscending
+		resp.RemovedIds = page.RemovedIds
+	}
+	return resp, nil
+}
+
+// GetApiKey implements the interface pbs.ApiKeyServiceServer.
+func (s Service) GetApiKey(ctx context.Context, req *pbs.GetApiKeyRequest) (*pbs.GetApiKeyResponse, error) {
+	if err := validateGetRequest(req); err != nil {
+		return nil, err
+	}
+	_, authResults := s.parentAndAuthResult(ctx, req.GetId(), action.Read)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	k, err := s.getFromRepo(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	item, err := s.toApiKeyOutput(ctx, k, "", &authResults)
+	if err != nil {
+		return nil, err
+	}
+	return &pbs.GetApiKeyResponse{Item: item}, nil
+}
+
+// CreateApiKey implements the interface pbs.ApiKeyServiceServer. The key
+// value is only returned in the response to this request.
+func (s Service) CreateApiKey(ctx context.Context, req *pbs.CreateApiKeyRequest) (*pbs.CreateApiKeyResponse, error) {
+	if err := validateCreateRequest(req); err != nil {
+		return nil, err
+	}
+	sa, authResults := s.parentAndAuthResult(ctx, req.GetItem().GetServiceAccountId(), action.Create)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	k, err := s.createInRepo(ctx, sa, req.GetItem())
+	if err != nil {
+		return nil, err
+	}
+	token, err := s.encryptToken(ctx, k)
+	if err != nil {
+		return nil, err
+	}
+	item, err := s.toApiKeyOutput(ctx, k, token, &authResults)
+	if err != nil {
+		return nil, err
+	}
+	return &pbs.CreateApiKeyResponse{Item: item, Uri: fmt.Sprintf("api-keys/%s", item.GetId())}, nil
+}
+
+// UpdateApiKey implements the interface pbs.ApiKeyServiceServer.
+func (s Service) UpdateApiKey(ctx context.Context, req *pbs.UpdateApiKeyRequest) (*pbs.UpdateApiKeyResponse, error) {
+	if err := validateUpdateRequest(req); err != nil {
+		return nil, err
+	}
+	sa, authResults := s.parentAndAuthResult(ctx, req.GetId(), action.Update)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	k, err := s.updateInRepo(ctx, sa.GetPublicId(), req.GetId(), req.GetUpdateMask().GetPaths(), req.GetItem())
+	if err != nil {
+		return nil, err
+	}
+	item, err := s.toApiKeyOutput(ctx, k, "", &authResults)
+	if err != nil {
+		return nil, err
+	}
+	return &pbs.UpdateApiKeyResponse{Item: item}, nil
+}
+
+// DeleteApiKey implements the interface pbs.ApiKeyServiceServer. Deleting an
+// api key revokes it.
+func (s Service) DeleteApiKey(ctx context.Context, req *pbs.DeleteApiKeyRequest) (*pbs.DeleteApiKeyResponse, error) {
+	if err := validateDeleteRequest(req); err != nil {
+		return nil, err
+	}
+	_, authResults := s.parentAndAuthResult(ctx, req.GetId(), action.Delete)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	_, err := s.deleteFromRepo(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
+
+// RotateApiKey implements the interface pbs.ApiKeyServiceServer. The new key
+// value is only returned in the response to this request.
+func (s Service) RotateApiKey(ctx context.Context, req *pbs.RotateApiKeyRequest) (*pbs.RotateApiKeyResponse, error) {
+	if err := validateRotateRequest(req); err != nil {
+		return nil, err
+	}
+	_, authResults := s.parentAndAuthResult(ctx, req.GetId(), action.Rotate)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	k, err := s.rotateInRepo(ctx, req.GetId(), req.GetVersion())
+	if err != nil {
+		return nil, err
+	}
+	token, err := s.encryptToken(ctx, k)
+	if err != nil {
+		return nil, err
+	}
+	item, err := s.toApiKeyOutput(ctx, k, token, &authResults)
+	if err != nil {
+		return nil, err
+	}
+	return &pbs.RotateApiKeyResponse{Item: item}, nil
+}
+
+// toApiKeyOutput converts k to its proto with the output fields of the
+// request.
+func (s Service) toApiKeyOutput(ctx context.Context, k *authtoken.ApiKey, token string, authResults *auth.VerifyResults) (*pb.ApiKey, error) {
+	const op = "apikeys.(Service).toApiKeyOutput"
+	outputFields, ok := requests.OutputFields(ctx)
+	if !ok {
+		return nil, errors.New(ctx, errors.Internal, op, "no request context found")
+	}
+
+	outputOpts := make([]handlers.Option, 0, 3)
+	outputOpts = append(outputOpts, handlers.WithOutputFields(outputFields))
+	if outputFields.Has(globals.ScopeField) {
+		outputOpts = append(outputOpts, handlers.WithScope(authResults.Scope))
+	}
+	if outputFields.Has(globals.AuthorizedActionsField) {
+		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, k.GetPublicId(), IdActions).Strings()))
+	}
+	return toProto(ctx, k, token, outputOpts...)
+}
+
+// encryptToken returns the api key in the form presented as a bearer token:
+// the id followed by the encrypted key value.
+func (s Service) encryptToken(ctx context.Context, k *authtoken.ApiKey) (string, error) {
+	const op = "apikeys.(Service).encryptToken"
+	encrypted, err := authtoken.EncryptToken(ctx, s.kms, k.GetScopeId(), k.GetPublicId(), k.GetToken())
+	if err != nil {
+		return "", errors.Wrap(ctx, err, op)
+	}
+	return k.GetPublicId() + "_" + encrypted, nil
+}
+
+func (s Service) getFromRepo(ctx context.Context, id string) (*authtoken.ApiKey, error) {
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, err
+	}
+	k, err := repo.LookupApiKey(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if k == nil {
+		return nil, handlers.NotFoundErrorf("Api key %q doesn't exist.", id)
+	}
+	return k, nil
+}
+
+func (s Service) createInRepo(ctx context.Context, sa *iam.ServiceAccount, item *pb.ApiKey) (*authtoken.ApiKey, error) {
+	const op = "apikeys.(Service).createInRepo"
+	var opts []authtoken.Option
+	if item.GetName() != nil {
+		opts = append(opts, authtoken.WithName(item.GetName().GetValue()))
+	}
+	if item.GetDescription() != nil {
+		opts = append(opts, authtoken.WithDescription(item.GetDescription().GetValue()))
+	}
+	if item.GetExpirationTime() != nil {
+		opts = append(opts, authtoken.WithExpirationTime(item.GetExpirationTime().AsTime()))
+	}
+	if len(item.GetAllowedCidrs()) > 0 {
+		opts = append(opts, authtoken.WithAllowedCidrs(item.GetAllowedCidrs()))
+	}
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, err
+	}
+	out, err := repo.CreateApiKey(ctx, sa, opts...)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to create api key"))
+	}
+	if out == nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to create api key but no error returned from repository.")
+	}
+	return out, nil
+}
+
+func (s Service) updateInRepo(ctx context.Context, serviceAccountId, id string, mask []string, item *pb.ApiKey) (*authtoken.ApiKey, error) {
+	const op = "apikeys.(Service).updateInRepo"
+	var opts []authtoken.Option
+	if desc := item.GetDescription(); desc != nil {
+		opts = append(opts, authtoken.WithDescription(desc.GetValue()))
+	}
+	if name := item.GetName(); name != nil {
+		opts = append(opts, authtoken.WithName(name.GetValue()))
+	}
+	if exp := item.GetExpirationTime(); exp != nil {
+		opts = append(opts, authtoken.WithExpirationTime(exp.AsTime()))
+	}
+	if len(item.GetAllowedCidrs()) > 0 {
+		opts = append(opts, authtoken.WithAllowedCidrs(item.GetAllowedCidrs()))
+	}
+	k, err := authtoken.NewApiKey(ctx, serviceAccountId, opts...)
+	if err != nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to build api key for update: %v.", err)
+	}
+	k.PublicId = id
+	dbMask := maskManager.Translate(mask)
+	if len(dbMask) == 0 {
+		return nil, handlers.InvalidArgumentErrorf("No valid fields included in the update mask.", map[string]string{"update_mask": "No valid fields provided in the update mask."})
+	}
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, err
+	}
+	out, rowsUpdated, err := repo.UpdateApiKey(ctx, k, item.GetVersion(), dbMask)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to update api key"))
+	}
+	if rowsUpdated == 0 {
+		return nil, handlers.NotFoundErrorf("Api key %q doesn't exist or incorrect version provided.", id)
+	}
+	return out, nil
+}
+
+func (s Service) rotateInRepo(ctx context.Context, id string, version uint32) (*authtoken.ApiKey, error) {
+	const op = "apikeys.(Service).rotateInRepo"
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, err
+	}
+	out, err := repo.RotateApiKey(ctx, id, version)
+	if err != nil {
+		if errors.Match(errors.T(errors.VersionMismatch), err) {
+			return nil, handlers.NotFoundErrorf("Api key %q doesn't exist or incorrect version provided.", id)
+		}
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to rotate api key"))
+	}
+	return out, nil
+}
+
+func (s Service) deleteFromRepo(ctx context.Context, id string) (bool, error) {
+	const op = "apikeys.(Service).deleteFromRepo"
+	repo, err := s.repoFn()
+	if err != nil {
+		return false, err
+	}
+	rows, err := repo.DeleteApiKey(ctx, id)
+	if err != nil {
+		if errors.IsNotFoundError(err) {
+			return false, nil
+		}
+		return false, errors.Wrap(ctx, err, op, errors.WithMsg("unable to delete api key"))
+	}
+	return rows > 0, nil
+}
+
+func (s Service) parentAndAuthResult(ctx context.Context, id string, a action.Type) (*iam.ServiceAccount, auth.VerifyResults) {
+	res := auth.VerifyResults{}
+	repo, err := s.repoFn()
+	if err != nil {
+		res.Error = err
+		return nil, res
+	}
+	iamRepo, err := s.iamRepoFn()
+	if err != nil {
+		res.Error = err
+		return nil, res
+	}
+
+	var parentId string
+	opts := []auth.Option{auth.WithType(resource.ApiKey), auth.WithAction(a)}
+	switch a {
+	case action.List, action.Create:
+		parentId = id
+	default:
+		k, err := repo.LookupApiKey(ctx, id)
+		if err != nil {
+			res.Error = err
+			return nil, res
+		}
+		if k == nil {
+			res.Error = handlers.NotFoundError()
+			return nil, res
+		}
+		parentId = k.GetServiceAccountId()
+		opts = append(opts, auth.WithId(id))
+	}
+
+	sa, err := iamRepo.LookupServiceAccount(ctx, parentId)
+	if err != nil {
+		res.Error = err
+		return nil, res
+	}
+	if sa == nil {
+		res.Error = handlers.NotFoundError()
+		return nil, res
+	}
+	opts = append(opts, auth.WithScopeId(sa.GetScopeId()), auth.WithPin(parentId))
+	return sa, auth.Verify(ctx, opts...)
+}
+
+func toProto(ctx context.Context, in *authtoken.ApiKey, token string, opt ...handlers.Option) (*pb.ApiKey, error) {
+	opts := handlers.GetOpts(opt...)
+	if opts.WithOutputFields == nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "output fields not found when building api key proto")
+	}
+	outputFields := *opts.WithOutputFields
+
+	out := pb.ApiKey{}
+	if outputFields.Has(globals.IdField) {
+		out.Id = in.GetPublicId()
+	}
+	if outputFields.Has(serviceAccountIdField) {
+		out.ServiceAccountId = in.GetServiceAccountId()
+	}
+	if outputFields.Has(globals.DescriptionField) && in.GetDescription() != "" {
+		out.Description = wrapperspb.String(in.GetDescription())
+	}
+	if outputFields.Has(globals.NameField) && in.GetName() != "" {
+		out.Name = wrapperspb.String(in.GetName())
+	}
+	if outputFields.Has(globals.CreatedTimeField) {
+		out.CreatedTime = in.GetCreateTime().GetTimestamp()
+	}
+	if outputFields.Has(globals.UpdatedTimeField) {
+		out.UpdatedTime = in.GetUpdateTime().GetTimestamp()
+	}
+	if outputFields.Has(globals.VersionField) {
+		out.Version = in.GetVersion()
+	}
+	if outputFields.Has(expirationTimeField) {
+		out.ExpirationTime = in.GetExpirationTime().GetTimestamp()
+	}
+	if outputFields.Has(allowedCidrsField) {
+		out.AllowedCidrs = in.GetAllowedCidrList()
+	}
+	if outputFields.Has(globals.ApproximateLastUsedTimeField) {
+		out.ApproximateLastUsedTime = in.GetApproximateLastAccessTime().GetTimestamp()
+	}
+	if outputFields.Has(tokenField) {
+		out.Token = token
+	}
+	if outputFields.Has(globals.ScopeField) {
+		out.Scope = opts.WithScope
+	}
+	if outputFields.Has(globals.AuthorizedActionsField) {
+		out.AuthorizedActions = opts.WithAuthorizedActions
+	}
+	return &out, nil
+}
+
+// A validateX method should exist for each method above.  These methods do not make calls to any backing service but enforce
+// requirements on the structure of the request.  They verify that:
+//   - The path passed in is correctly formatted
+//   - All required parameters are set
+//   - There are no conflicting parameters provided
+func validateGetRequest(req *pbs.GetApiKeyRequest) error {
+	return handlers.ValidateGetRequest(handlers.NoopValidatorFn, req, authtoken.ApiKeyPrefix)
+}
+
+func validateCreateRequest(req *pbs.CreateApiKeyRequest) error {
+	return handlers.ValidateCreateRequest(req.GetItem(), func() map[string]string {
+		badFields := map[string]string{}
+		item := req.GetItem()
+		if !handlers.ValidId(handlers.Id(item.GetServiceAccountId()), iam.ServiceAccountPrefix) {
+			badFields[serviceAccountIdField] = "This field must be a valid service account id."
+		}
+		if item.GetExpirationTime() != nil && !item.GetExpirationTime().AsTime().After(time.Now()) {
+			badFields[expirationTimeField] = "Must be in the future."
+		}
+		validateCidrs(item.GetAllowedCidrs(), badFields)
+		if item.GetToken() != "" {
+			badFields[tokenField] = "This is a read only field."
+		}
+		if item.GetApproximateLastUsedTime() != nil {
+			badFields[globals.ApproximateLastUsedTimeField] = "This is a read only field."
+		}
+		return badFields
+	})
+}
+
+func validateUpdateRequest(req *pbs.UpdateApiKeyRequest) error {
+	return handlers.ValidateUpdateRequest(req, req.GetItem(), func() map[string]string {
+		badFields := map[string]string{}
+		item := req.GetItem()
+		if item.GetServiceAccountId() != "" {
+			badFields[serviceAccountIdField] = "This field is immutable."
+		}
+		validateCidrs(item.GetAllowedCidrs(), badFields)
+		if item.GetToken() != "" {
+			badFields[tokenField] = "This is a read only field."
+		}
+		if item.GetApproximateLastUsedTime() != nil {
+			badFields[globals.ApproximateLastUsedTimeField] = "This is a read only field."
+		}
+		return badFields
+	}, authtoken.ApiKeyPrefix)
+}
+
+func validateDeleteRequest(req *pbs.DeleteApiKeyRequest) error {
+	return handlers.ValidateDeleteRequest(handlers.NoopValidatorFn, req, authtoken.ApiKeyPrefix)
+}
+
+func validateRotateRequest(req *pbs.RotateApiKeyRequest) error {
+	badFields := map[string]string{}
+	if !handlers.ValidId(handlers.Id(req.GetId()), authtoken.ApiKeyPrefix) {
+		badFields[globals.IdField] = "Improperly formatted identifier."
+	}
+	if req.GetVersion() == 0 {
+		badFields[globals.VersionField] = "Required field."
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Invalid fields provided in request.", badFields)
+	}
+	return nil
+}
+
+func validateListRequest(req *pbs.ListApiKeysRequest) error {
+	badFields := map[string]string{}
+	if !handlers.ValidId(handlers.Id(req.GetServiceAccountId()), iam.ServiceAccountPrefix) {
+		badFields[serviceAccountIdField] = "Invalid formatted identifier."
+	}
+	if _, err := handlers.NewFilter(req.GetFilter()); err != nil {
+		badFields["filter"] = fmt.Sprintf("This field could not be parsed. %v", err)
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Error in provided request.", badFields)
+	}
+	return nil
+}
+
+func validateCidrs(cidrs []string, badFields map[string]string) {
+	for _, c := range cidrs {
+		if _, _, err := net.ParseCIDR(c); err != nil {
+			badFields[allowedCidrsField] = fmt.Sprintf("%q is not a valid cidr block.", c)
+			return
+		}
+	}
+}
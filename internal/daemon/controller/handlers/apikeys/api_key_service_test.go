@@ -0,0 +1,551 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package apikeys_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/boundary/globals"
+	"github.com/hashicorp/boundary/internal/authtoken"
+	"github.com/hashicorp/boundary/internal/daemon/controller/auth"
+	"github.com/hashicorp/boundary/internal/daemon/controller/handlers"
+	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/apikeys"
+	"github.com/hashicorp/boundary/internal/db"
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/pagination"
+	"github.com/hashicorp/boundary/internal/types/resource"
+	"github.com/hashicorp/boundary/internal/types/scope"
+	pb "github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/apikeys"
+	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/scopes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/protobuf/field_mask"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+var testAuthorizedActions = []string{"no-op", "read", "update", "delete", "rotate"}
+
+type testEnv struct {
+	s         apikeys.Service
+	repo      *authtoken.Repository
+	iamRepoFn func() (*iam.Repository, error)
+	org       *iam.Scope
+	sa        *iam.ServiceAccount
+}
+
+// Creates an org scoped service account and an api key service.
+func newTestEnv(t *testing.T) testEnv {
+	t.Helper()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrap := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrap)
+	iamRepo := iam.TestRepo(t, conn, wrap)
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	repo, err := authtoken.NewRepository(rw, rw, kms)
+	require.NoError(t, err)
+	repoFn := func() (*authtoken.Repository, error) {
+		return repo, nil
+	}
+	s, err := apikeys.NewService(repoFn, iamRepoFn, kms)
+	require.NoError(t, err)
+
+	o, _ := iam.TestScopes(t, iamRepo)
+	sa := iam.TestServiceAccount(t, iamRepo, o.GetPublicId())
+	return testEnv{s: s, repo: repo, iamRepoFn: iamRepoFn, org: o, sa: sa}
+}
+
+func (e testEnv) ctx() context.Context {
+	return auth.DisabledAuthTestContext(e.iamRepoFn, e.org.GetPublicId())
+}
+
+// createKey creates an api key for the service account of the environment
+// and returns it as it is stored.
+func (e testEnv) createKey(t *testing.T, opt ...authtoken.Option) *authtoken.ApiKey {
+	t.Helper()
+	k, err := e.repo.CreateApiKey(context.Background(), e.sa, opt...)
+	require.NoError(t, err)
+	k, err = e.repo.LookupApiKey(context.Background(), k.GetPublicId())
+	require.NoError(t, err)
+	return k
+}
+
+func (e testEnv) toWire(k *authtoken.ApiKey) *pb.ApiKey {
+	out := &pb.ApiKey{
+		Id:                      k.GetPublicId(),
+		ServiceAccountId:        k.GetServiceAccountId(),
+		Scope:                   &scopes.ScopeInfo{Id: e.org.GetPublicId(), Type: scope.Org.String(), ParentScopeId: scope.Global.String()},
+		CreatedTime:             k.GetCreateTime().GetTimestamp(),
+		UpdatedTime:             k.GetUpdateTime().GetTimestamp(),
+		Version:                 k.GetVersion(),
+		ExpirationTime:          k.GetExpirationTime().GetTimestamp(),
+		AllowedCidrs:            k.GetAllowedCidrList(),
+		ApproximateLastUsedTime: k.GetApproximateLastAccessTime().GetTimestamp(),
+		AuthorizedActions:       testAuthorizedActions,
+	}
+	if k.GetName() != "" {
+		out.Name = wrapperspb.String(k.GetName())
+	}
+	if k.GetDescription() != "" {
+		out.Description = wrapperspb.String(k.GetDescription())
+	}
+	return out
+}
+
+func TestGet(t *testing.T) {
+	e := newTestEnv(t)
+	k := e.createKey(t, authtoken.WithName("default"), authtoken.WithDescription("default"), authtoken.WithAllowedCidrs([]string{"10.0.0.0/8"}))
+
+	cases := []struct {
+		name string
+		req  *pbs.GetApiKeyRequest
+		res  *pbs.GetApiKeyResponse
+		err  error
+	}{
+		{
+			name: "Get an existing api key",
+			req:  &pbs.GetApiKeyRequest{Id: k.GetPublicId()},
+			res:  &pbs.GetApiKeyResponse{Item: e.toWire(k)},
+		},
+		{
+			name: "Get a non existing api key",
+			req:  &pbs.GetApiKeyRequest{Id: authtoken.ApiKeyPrefix + "_DoesntExis"},
+			err:  handlers.ApiErrorWithCode(codes.NotFound),
+		},
+		{
+			name: "Wrong id prefix",
+			req:  &pbs.GetApiKeyRequest{Id: "j_1234567890"},
+			err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "space in id",
+			req:  &pbs.GetApiKeyRequest{Id: authtoken.ApiKeyPrefix + "_1 23456789"},
+			err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, gErr := e.s.GetApiKey(e.ctx(), tc.req)
+			if tc.err != nil {
+				require.Error(gErr)
+				assert.True(errors.Is(gErr, tc.err), "GetApiKey(%+v) got error %v, wanted %v", tc.req, gErr, tc.err)
+				return
+			}
+			require.NoError(gErr)
+			assert.Empty(cmp.Diff(tc.res, got, protocmp.Transform()), "GetApiKey(%q) got response\n%q, wanted\n%q", tc.req, got, tc.res)
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	e := newTestEnv(t)
+	expiration := timestamppb.New(time.Now().Add(time.Hour).Truncate(time.Second))
+
+	cases := []struct {
+		name string
+		req  *pbs.CreateApiKeyRequest
+		res  *pbs.CreateApiKeyResponse
+		err  error
+	}{
+		{
+			name: "Create a valid api key",
+			req: &pbs.CreateApiKeyRequest{Item: &pb.ApiKey{
+				ServiceAccountId: e.sa.GetPublicId(),
+				Name:             wrapperspb.String("name"),
+				Description:      wrapperspb.String("desc"),
+				ExpirationTime:   expiration,
+				AllowedCidrs:     []string{"10.0.0.0/8", "192.168.1.0/24"},
+			}},
+			res: &pbs.CreateApiKeyResponse{
+				Uri: "api-keys/" + authtoken.ApiKeyPrefix + "_",
+				Item: &pb.ApiKey{
+					ServiceAccountId:  e.sa.GetPublicId(),
+					Scope:             &scopes.ScopeInfo{Id: e.org.GetPublicId(), Type: scope.Org.String(), ParentScopeId: scope.Global.String()},
+					Name:              wrapperspb.String("name"),
+					Description:       wrapperspb.String("desc"),
+					Version:           1,
+					ExpirationTime:    expiration,
+					AllowedCidrs:      []string{"10.0.0.0/8", "192.168.1.0/24"},
+					AuthorizedActions: testAuthorizedActions,
+				},
+			},
+		},
+		{
+			name: "Create an api key for a non existing service account",
+			req: &pbs.CreateApiKeyRequest{Item: &pb.ApiKey{
+				ServiceAccountId: iam.ServiceAccountPrefix + "_DoesntExis",
+			}},
+			err: handlers.ApiErrorWithCode(codes.NotFound),
+		},
+		{
+			name: "Invalid service account id",
+			req: &pbs.CreateApiKeyRequest{Item: &pb.ApiKey{
+				ServiceAccountId: "u_1234567890",
+			}},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Can't specify Id",
+			req: &pbs.CreateApiKeyRequest{Item: &pb.ApiKey{
+				Id:               authtoken.ApiKeyPrefix + "_notallowed",
+				ServiceAccountId: e.sa.GetPublicId(),
+			}},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Can't specify the token",
+			req: &pbs.CreateApiKeyRequest{Item: &pb.ApiKey{
+				ServiceAccountId: e.sa.GetPublicId(),
+				Token:            "token",
+			}},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Expiration time in the past",
+			req: &pbs.CreateApiKeyRequest{Item: &pb.ApiKey{
+				ServiceAccountId: e.sa.GetPublicId(),
+				ExpirationTime:   timestamppb.New(time.Now().Add(-time.Hour)),
+			}},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Invalid cidr",
+			req: &pbs.CreateApiKeyRequest{Item: &pb.ApiKey{
+				ServiceAccountId: e.sa.GetPublicId(),
+				AllowedCidrs:     []string{"10.0.0.0"},
+			}},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, gErr := e.s.CreateApiKey(e.ctx(), tc.req)
+			if tc.err != nil {
+				require.Error(gErr)
+				assert.True(errors.Is(gErr, tc.err), "CreateApiKey(%+v) got error %v, wanted %v", tc.req, gErr, tc.err)
+				return
+			}
+			require.NoError(gErr)
+			assert.Contains(got.GetUri(), tc.res.Uri)
+			assert.True(strings.HasPrefix(got.GetItem().GetId(), authtoken.ApiKeyPrefix+"_"))
+			// The key value is only returned on creation, as the id followed
+			// by the encrypted value
+			assert.True(strings.HasPrefix(got.GetItem().GetToken(), got.GetItem().GetId()+"_"))
+			assert.NotNil(got.GetItem().GetCreatedTime())
+			assert.NotNil(got.GetItem().GetUpdatedTime())
+
+			read, err := e.s.GetApiKey(e.ctx(), &pbs.GetApiKeyRequest{Id: got.GetItem().GetId()})
+			require.NoError(err)
+			assert.Empty(read.GetItem().GetToken())
+
+			// Clear all values which are hard to compare against.
+			got.Uri, tc.res.Uri = "", ""
+			got.Item.Id, got.Item.Token = "", ""
+			got.Item.CreatedTime, got.Item.UpdatedTime, got.Item.ApproximateLastUsedTime = nil, nil, nil
+			assert.Empty(cmp.Diff(tc.res, got, protocmp.Transform()), "CreateApiKey(%q) got response %q, wanted %q", tc.req, got, tc.res)
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	e := newTestEnv(t)
+
+	cases := []struct {
+		name  string
+		req   func(k *authtoken.ApiKey) *pbs.UpdateApiKeyRequest
+		check func(t *testing.T, k *authtoken.ApiKey, got *pb.ApiKey)
+		err   error
+	}{
+		{
+			name: "Update the name and description",
+			req: func(k *authtoken.ApiKey) *pbs.UpdateApiKeyRequest {
+				return &pbs.UpdateApiKeyRequest{
+					Id:         k.GetPublicId(),
+					UpdateMask: &field_mask.FieldMask{Paths: []string{"name", "description"}},
+					Item: &pb.ApiKey{
+						Version:     k.GetVersion(),
+						Name:        wrapperspb.String("new"),
+						Description: wrapperspb.String("desc"),
+					},
+				}
+			},
+			check: func(t *testing.T, k *authtoken.ApiKey, got *pb.ApiKey) {
+				assert.Equal(t, "new", got.GetName().GetValue())
+				assert.Equal(t, "desc", got.GetDescription().GetValue())
+				assert.Equal(t, []string{"10.0.0.0/8"}, got.GetAllowedCidrs())
+			},
+		},
+		{
+			name: "Update the allowed cidrs",
+			req: func(k *authtoken.ApiKey) *pbs.UpdateApiKeyRequest {
+				return &pbs.UpdateApiKeyRequest{
+					Id:         k.GetPublicId(),
+					UpdateMask: &field_mask.FieldMask{Paths: []string{"allowed_cidrs"}},
+					Item: &pb.ApiKey{
+						Version:      k.GetVersion(),
+						AllowedCidrs: []string{"192.168.1.0/24"},
+					},
+				}
+			},
+			check: func(t *testing.T, k *authtoken.ApiKey, got *pb.ApiKey) {
+				assert.Equal(t, k.GetName(), got.GetName().GetValue())
+				assert.Equal(t, []string{"192.168.1.0/24"}, got.GetAllowedCidrs())
+			},
+		},
+		{
+			name: "Incorrect version",
+			req: func(k *authtoken.ApiKey) *pbs.UpdateApiKeyRequest {
+				return &pbs.UpdateApiKeyRequest{
+					Id:         k.GetPublicId(),
+					UpdateMask: &field_mask.FieldMask{Paths: []string{"name"}},
+					Item:       &pb.ApiKey{Version: k.GetVersion() + 1, Name: wrapperspb.String("new")},
+				}
+			},
+			err: handlers.ApiErrorWithCode(codes.NotFound),
+		},
+		{
+			name: "No update mask",
+			req: func(k *authtoken.ApiKey) *pbs.UpdateApiKeyRequest {
+				return &pbs.UpdateApiKeyRequest{
+					Id:   k.GetPublicId(),
+					Item: &pb.ApiKey{Version: k.GetVersion(), Name: wrapperspb.String("new")},
+				}
+			},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Can't change the service account",
+			req: func(k *authtoken.ApiKey) *pbs.UpdateApiKeyRequest {
+				return &pbs.UpdateApiKeyRequest{
+					Id:         k.GetPublicId(),
+					UpdateMask: &field_mask.FieldMask{Paths: []string{"service_account_id"}},
+					Item:       &pb.ApiKey{Version: k.GetVersion(), ServiceAccountId: iam.ServiceAccountPrefix + "_1234567890"},
+				}
+			},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Can't set the token",
+			req: func(k *authtoken.ApiKey) *pbs.UpdateApiKeyRequest {
+				return &pbs.UpdateApiKeyRequest{
+					Id:         k.GetPublicId(),
+					UpdateMask: &field_mask.FieldMask{Paths: []string{"token"}},
+					Item:       &pb.ApiKey{Version: k.GetVersion(), Token: "token"},
+				}
+			},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Invalid cidr",
+			req: func(k *authtoken.ApiKey) *pbs.UpdateApiKeyRequest {
+				return &pbs.UpdateApiKeyRequest{
+					Id:         k.GetPublicId(),
+					UpdateMask: &field_mask.FieldMask{Paths: []string{"allowed_cidrs"}},
+					Item:       &pb.ApiKey{Version: k.GetVersion(), AllowedCidrs: []string{"not-a-cidr"}},
+				}
+			},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Non existing api key",
+			req: func(k *authtoken.ApiKey) *pbs.UpdateApiKeyRequest {
+				return &pbs.UpdateApiKeyRequest{
+					Id:         authtoken.ApiKeyPrefix + "_DoesntExis",
+					UpdateMask: &field_mask.FieldMask{Paths: []string{"name"}},
+					Item:       &pb.ApiKey{Version: k.GetVersion(), Name: wrapperspb.String("new")},
+				}
+			},
+			err: handlers.ApiErrorWithCode(codes.NotFound),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			k := e.createKey(t, authtoken.WithName(tc.name), authtoken.WithAllowedCidrs([]string{"10.0.0.0/8"}))
+			req := tc.req(k)
+			got, gErr := e.s.UpdateApiKey(e.ctx(), req)
+			if tc.err != nil {
+				require.Error(gErr)
+				assert.True(errors.Is(gErr, tc.err), "UpdateApiKey(%+v) got error %v, wanted %v", req, gErr, tc.err)
+				return
+			}
+			require.NoError(gErr)
+			assert.Equal(k.GetPublicId(), got.GetItem().GetId())
+			assert.Equal(k.GetVersion()+1, got.GetItem().GetVersion())
+			assert.Empty(got.GetItem().GetToken())
+			tc.check(t, k, got.GetItem())
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	e := newTestEnv(t)
+	k := e.createKey(t)
+
+	cases := []struct {
+		name string
+		req  *pbs.DeleteApiKeyRequest
+		err  error
+	}{
+		{
+			name: "Delete an existing api key",
+			req:  &pbs.DeleteApiKeyRequest{Id: k.GetPublicId()},
+		},
+		{
+			name: "Delete it twice",
+			req:  &pbs.DeleteApiKeyRequest{Id: k.GetPublicId()},
+			err:  handlers.ApiErrorWithCode(codes.NotFound),
+		},
+		{
+			name: "Delete a non existing api key",
+			req:  &pbs.DeleteApiKeyRequest{Id: authtoken.ApiKeyPrefix + "_doesntexis"},
+			err:  handlers.ApiErrorWithCode(codes.NotFound),
+		},
+		{
+			name: "Bad api key id formatting",
+			req:  &pbs.DeleteApiKeyRequest{Id: "bad_format"},
+			err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, gErr := e.s.DeleteApiKey(e.ctx(), tc.req)
+			if tc.err != nil {
+				require.Error(gErr)
+				assert.True(errors.Is(gErr, tc.err), "DeleteApiKey(%+v) got error %v, wanted %v", tc.req, gErr, tc.err)
+				return
+			}
+			require.NoError(gErr)
+			assert.Nil(got)
+		})
+	}
+
+	// Deleting an api key revokes it
+	got, err := e.repo.LookupApiKey(context.Background(), k.GetPublicId())
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
+
+func TestRotate(t *testing.T) {
+	ctx := context.Background()
+	e := newTestEnv(t)
+	created, err := e.repo.CreateApiKey(ctx, e.sa)
+	require.NoError(t, err)
+
+	cases := []struct {
+		name string
+		req  *pbs.RotateApiKeyRequest
+		err  error
+	}{
+		{
+			name: "Missing version",
+			req:  &pbs.RotateApiKeyRequest{Id: created.GetPublicId()},
+			err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Incorrect version",
+			req:  &pbs.RotateApiKeyRequest{Id: created.GetPublicId(), Version: created.GetVersion() + 1},
+			err:  handlers.ApiErrorWithCode(codes.NotFound),
+		},
+		{
+			name: "Non existing api key",
+			req:  &pbs.RotateApiKeyRequest{Id: authtoken.ApiKeyPrefix + "_doesntexis", Version: 1},
+			err:  handlers.ApiErrorWithCode(codes.NotFound),
+		},
+		{
+			name: "Bad api key id formatting",
+			req:  &pbs.RotateApiKeyRequest{Id: "bad_format", Version: 1},
+			err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, gErr := e.s.RotateApiKey(e.ctx(), tc.req)
+			require.Error(gErr)
+			assert.Nil(got)
+			assert.True(errors.Is(gErr, tc.err), "RotateApiKey(%+v) got error %v, wanted %v", tc.req, gErr, tc.err)
+		})
+	}
+
+	t.Run("Rotate an existing api key", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		got, err := e.s.RotateApiKey(e.ctx(), &pbs.RotateApiKeyRequest{Id: created.GetPublicId(), Version: created.GetVersion()})
+		require.NoError(err)
+		assert.Equal(created.GetPublicId(), got.GetItem().GetId())
+		assert.Equal(created.GetVersion()+1, got.GetItem().GetVersion())
+		assert.True(strings.HasPrefix(got.GetItem().GetToken(), created.GetPublicId()+"_"))
+
+		// The previous value is no longer valid
+		k, err := e.repo.ValidateApiKey(ctx, created.GetPublicId(), created.GetToken(), "")
+		require.NoError(err)
+		assert.Nil(k)
+
+		// A stale version can't rotate the key again
+		_, err = e.s.RotateApiKey(e.ctx(), &pbs.RotateApiKeyRequest{Id: created.GetPublicId(), Version: created.GetVersion()})
+		assert.True(errors.Is(err, handlers.ApiErrorWithCode(codes.NotFound)), "got error %v", err)
+	})
+}
+
+func TestList_Pagination(t *testing.T) {
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrap := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrap)
+	iamRepo := iam.TestRepo(t, conn, wrap)
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	repo, err := authtoken.NewRepository(rw, rw, kms)
+	require.NoError(t, err)
+	repoFn := func() (*authtoken.Repository, error) {
+		return repo, nil
+	}
+	s, err := apikeys.NewService(repoFn, iamRepoFn, kms)
+	require.NoError(t, err)
+
+	o, _ := iam.TestScopes(t, iamRepo)
+	sa := iam.TestServiceAccount(t, iamRepo, o.GetPublicId())
+	// Paginated listings are oldest first
+	var want []string
+	for i := 0; i < 5; i++ {
+		k, err := repo.CreateApiKey(ctx, sa)
+		require.NoError(t, err)
+		want = append(want, k.GetPublicId())
+	}
+	pagination.TestList(t,
+		auth.DisabledAuthTestContext(iamRepoFn, o.GetPublicId()),
+		auth.DisabledAuthTestContext(iamRepoFn, o.GetPublicId(), auth.WithUserId(globals.AnonymousUserId)),
+		resource.ServiceAccount,
+		want,
+		func(ctx context.Context, listToken string, pageSize uint32) (pagination.TestListResponse, []string, error) {
+			got, err := s.ListApiKeys(ctx, &pbs.ListApiKeysRequest{ServiceAccountId: sa.GetPublicId(), ListToken: listToken, PageSize: pageSize})
+			var ids []string
+			for _, item := range got.GetItems() {
+				ids = append(ids, item.GetId())
+			}
+			return got, ids, err
+		},
+		func(t *testing.T, id string) {
+			_, err := repo.DeleteApiKey(ctx, id)
+			require.NoError(t, err)
+		},
+	)
+}
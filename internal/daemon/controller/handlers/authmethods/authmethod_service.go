@@ -8,9 +8,12 @@ import (
 	"fmt"
 	"net/url"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/boundary/globals"
 	"github.com/hashicorp/boundary/internal/auth"
+	"github.com/hashicorp/boundary/internal/auth/jwt"
+	"github.com/hashicorp/boundary/internal/auth/ldap"
 	"github.com/hashicorp/boundary/internal/auth/oidc"
 	"github.com/hashicorp/boundary/internal/auth/password"
 	"github.com/hashicorp/boundary/internal/authtoken"
@@ -21,9 +24,11 @@ import (
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/accounts"
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/authtokens"
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/managed_groups"
+	"github.com/hashicorp/boundary/internal/db"
 	"github.com/hashicorp/boundary/internal/errors"
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
 	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/pagination"
 	"github.com/hashicorp/boundary/internal/perms"
 	"github.com/hashicorp/boundary/internal/requests"
 	"github.com/hashicorp/boundary/internal/types/action"
@@ -34,6 +39,7 @@ import (
 	pba "github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/authtokens"
 	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/scopes"
 	"github.com/hashicorp/go-secure-stdlib/strutil"
+	"golang.org/x/exp/maps"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/structpb"
@@ -84,14 +90,20 @@ type Service struct {
 	kms        *kms.Kms
 	pwRepoFn   common.PasswordAuthRepoFactory
 	oidcRepoFn common.OidcAuthRepoFactory
+	ldapRepoFn common.LdapAuthRepoFactory
+	jwtRepoFn  common.JwtAuthRepoFactory
 	iamRepoFn  common.IamRepoFactory
 	atRepoFn   common.AuthTokenRepoFactory
+
+	// pwAttempts limits the password authentication attempts from a source
+	// ip address.
+	pwAttempts *attemptLimiter
 }
 
 var _ pbs.AuthMethodServiceServer = (*Service)(nil)
 
 // NewService returns a auth method service which handles auth method related requests to boundary.
-func NewService(kms *kms.Kms, pwRepoFn common.PasswordAuthRepoFactory, oidcRepoFn common.OidcAuthRepoFactory, iamRepoFn common.IamRepoFactory, atRepoFn common.AuthTokenRepoFactory, opt ...handlers.Option) (Service, error) {
+func NewService(kms *kms.Kms, pwRepoFn common.PasswordAuthRepoFactory, oidcRepoFn common.OidcAuthRepoFactory, ldapRepoFn common.LdapAuthRepoFactory, jwtRepoFn common.JwtAuthRepoFactory, iamRepoFn common.IamRepoFactory, atRepoFn common.AuthTokenRepoFactory, opt ...handlers.Option) (Service, error) {
 	const op = "authmethods.NewService"
 	if kms == nil {
 		return Service{}, errors.NewDeprecated(errors.InvalidParameter, op, "missing kms")
@@ -102,13 +114,19 @@ func NewService(kms *kms.Kms, pwRepoFn common.PasswordAuthRepoFactory, oidcRepoF
 	if oidcRepoFn == nil {
 		return Service{}, fmt.Errorf("nil oidc repository provided")
 	}
+	if ldapRepoFn == nil {
+		return Service{}, errors.NewDeprecated(errors.InvalidParameter, op, "missing ldap repository")
+	}
+	if jwtRepoFn == nil {
+		return Service{}, errors.NewDeprecated(errors.InvalidParameter, op, "missing jwt repository")
+	}
 	if iamRepoFn == nil {
 		return Service{}, errors.NewDeprecated(errors.InvalidParameter, op, "missing iam repository")
 	}
 	if atRepoFn == nil {
 		return Service{}, fmt.Errorf("nil auth token repository provided")
 	}
-	s := Service{kms: kms, pwRepoFn: pwRepoFn, oidcRepoFn: oidcRepoFn, iamRepoFn: iamRepoFn, atRepoFn: atRepoFn}
+	s := Service{kms: kms, pwRepoFn: pwRepoFn, oidcRepoFn: oidcRepoFn, ldapRepoFn: ldapRepoFn, jwtRepoFn: jwtRepoFn, iamRepoFn: iamRepoFn, atRepoFn: atRepoFn, pwAttempts: newAttemptLimiter()}
 
 	return s, nil
 }
@@ -142,28 +160,23 @@ func (s Service) ListAuthMethods(ctx context.Context, req *pbs.ListAuthMethodsRe
 		return &pbs.ListAuthMethodsResponse{}, nil
 	}
 
-	ul, err := s.listFromRepo(ctx, scopeIds, authResults)
-	if err != nil {
-		return nil, err
-	}
-	if len(ul) == 0 {
-		return &pbs.ListAuthMethodsResponse{}, nil
-	}
-
 	filter, err := handlers.NewFilter(req.GetFilter())
 	if err != nil {
 		return nil, err
 	}
-	finalItems := make([]*pb.AuthMethod, 0, len(ul))
-	res := perms.Resource{
-		Type: resource.AuthMethod,
+
+	listItemsFn := func(ctx context.Context, page *db.Page, limit int) ([]auth.AuthMethod, error) {
+		return s.listFromRepo(ctx, scopeIds, page, limit)
 	}
-	for _, am := range ul {
-		res.Id = am.GetPublicId()
-		res.ScopeId = am.GetScopeId()
+	convertItemFn := func(ctx context.Context, am auth.AuthMethod) (*pb.AuthMethod, bool, error) {
+		res := perms.Resource{
+			Id:      am.GetPublicId(),
+			ScopeId: am.GetScopeId(),
+			Type:    resource.AuthMethod,
+		}
 		authorizedActions := authResults.FetchActionSetForId(ctx, am.GetPublicId(), IdActions[subtypes.SubtypeFromId(domain, am.GetPublicId())], requestauth.WithResource(&res)).Strings()
 		if len(authorizedActions) == 0 {
-			continue
+			return nil, false, nil
 		}
 
 		outputFields := authResults.FetchOutputFields(res, action.List).SelfOrDefaults(authResults.UserId)
@@ -178,27 +191,56 @@ func (s Service) ListAuthMethods(ctx context.Context, req *pbs.ListAuthMethodsRe
 		if outputFields.Has(globals.AuthorizedCollectionActionsField) {
 			collectionActions, err := requestauth.CalculateAuthorizedCollectionActions(ctx, authResults, collectionTypeMap, authResults.Scope.Id, am.GetPublicId())
 			if err != nil {
-				return nil, err
+				return nil, false, err
 			}
 			outputOpts = append(outputOpts, handlers.WithAuthorizedCollectionActions(collectionActions))
 		}
 
 		item, err := toAuthMethodProto(ctx, am, outputOpts...)
 		if err != nil {
-			return nil, err
+			return nil, false, err
 		}
 
 		// This comes last so that we can use item fields in the filter after
 		// the allowed fields are populated above
 		filterable, err := subtypes.Filterable(item)
 		if err != nil {
-			return nil, err
+			return nil, false, err
 		}
-		if filter.Match(filterable) {
-			finalItems = append(finalItems, item)
+		return item, filter.Match(filterable), nil
+	}
+
+	repo, err := s.pwRepoFn()
+	if err != nil {
+		return nil, err
+	}
+	listDeletedIdsFn := func(ctx context.Context, since time.Time) ([]string, error) {
+		deleted, err := repo.ListDeletedAuthMethodIds(ctx, scopeIds, since)
+		if err != nil {
+			return nil, err
 		}
+		return authResults.AuthorizedDeletedIds(ctx, perms.Resource{Type: resource.AuthMethod}, action.Union(maps.Values(IdActions)...), deleted), nil
+	}
+	listReq := pagination.Request{
+		ResourceType: resource.AuthMethod,
+		GrantsHash:   authResults.GrantsHash(),
+		PageSize:     req.GetPageSize(),
+		ListToken:    req.GetListToken(),
+	}
+	page, err := pagination.List(ctx, listReq, listItemsFn, convertItemFn, listDeletedIdsFn)
+	if err != nil {
+		return nil, err
 	}
-	return &pbs.ListAuthMethodsResponse{Items: finalItems}, nil
+
+	resp := &pbs.ListAuthMethodsResponse{Items: page.Items}
+	if listReq.Paginated() {
+		resp.ResponseType = page.ResponseType()
+		resp.ListToken = page.ListToken
+		resp.SortBy = page.SortBy
+		resp.SortDir = pagination.SortDirAscending
+		resp.RemovedIds = page.RemovedIds
+	}
+	return resp, nil
 }
 
 // GetAuthMethod implements the interface pbs.AuthMethodServiceServer.
@@ -427,6 +469,14 @@ func (s Service) Authenticate(ctx context.Context, req *pbs.AuthenticateRequest)
 		if err := validateAuthenticateOidcRequest(req); err != nil {
 			return nil, err
 		}
+	case ldap.Subtype:
+		if err := validateAuthenticateLdapRequest(req); err != nil {
+			return nil, err
+		}
+	case jwt.Subtype:
+		if err := validateAuthenticateJwtRequest(req); err != nil {
+			return nil, err
+		}
 	}
 
 	authResults := s.authResult(ctx, req.GetAuthMethodId(), action.Authenticate)
@@ -440,6 +490,12 @@ func (s Service) Authenticate(ctx context.Context, req *pbs.AuthenticateRequest)
 
 	case oidc.Subtype:
 		return s.authenticateOidc(ctx, req, &authResults)
+
+	case ldap.Subtype:
+		return s.authenticateLdap(ctx, req, &authResults)
+
+	case jwt.Subtype:
+		return s.authenticateJwt(ctx, req, &authResults)
 	}
 	return nil, errors.New(ctx, errors.Internal, op, "Invalid auth method subtype not caught in validation function.")
 }
@@ -462,6 +518,20 @@ func (s Service) getFromRepo(ctx context.Context, id string) (auth.AuthMethod, e
 		}
 		am, lookupErr = repo.LookupAuthMethod(ctx, id)
 
+	case ldap.Subtype:
+		repo, err := s.ldapRepoFn()
+		if err != nil {
+			return nil, err
+		}
+		am, lookupErr = repo.LookupAuthMethod(ctx, id)
+
+	case jwt.Subtype:
+		repo, err := s.jwtRepoFn()
+		if err != nil {
+			return nil, err
+		}
+		am, lookupErr = repo.LookupAuthMethod(ctx, id)
+
 	default:
 		return nil, handlers.NotFoundErrorf("Unrecognized id.")
 	}
@@ -479,7 +549,7 @@ func (s Service) getFromRepo(ctx context.Context, id string) (auth.AuthMethod, e
 	return am, nil
 }
 
-func (s Service) listFromRepo(ctx context.Context, scopeIds []string, authResults requestauth.VerifyResults) ([]auth.AuthMethod, error) {
+func (s Service) listFromRepo(ctx context.Context, scopeIds []string, page *db.Page, limit int) ([]auth.AuthMethod, error) {
 	const op = "authmethods.(Service).listFromRepo"
 	reqCtx, ok := requests.RequestContextFromCtx(ctx)
 	if !ok {
@@ -492,7 +562,7 @@ func (s Service) listFromRepo(ctx context.Context, scopeIds []string, authResult
 	if err != nil {
 		return nil, errors.Wrap(ctx, err, op)
 	}
-	ol, err := oidcRepo.ListAuthMethods(ctx, scopeIds, oidc.WithUnauthenticatedUser(reqCtx.UserId == globals.AnonymousUserId))
+	ol, err := oidcRepo.ListAuthMethods(ctx, scopeIds, oidc.WithUnauthenticatedUser(reqCtx.UserId == globals.AnonymousUserId), oidc.WithPage(page), oidc.WithLimit(limit))
 	if err != nil {
 		return nil, errors.Wrap(ctx, err, op)
 	}
@@ -504,14 +574,38 @@ func (s Service) listFromRepo(ctx context.Context, scopeIds []string, authResult
 	if err != nil {
 		return nil, errors.Wrap(ctx, err, op)
 	}
-	pl, err := repo.ListAuthMethods(ctx, scopeIds)
+	pl, err := repo.ListAuthMethods(ctx, scopeIds, password.WithPage(page), password.WithLimit(limit))
 	if err != nil {
 		return nil, errors.Wrap(ctx, err, op)
 	}
 	for _, item := range pl {
 		outUl = append(outUl, item)
 	}
-	return outUl, nil
+
+	ldapRepo, err := s.ldapRepoFn()
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	ll, err := ldapRepo.ListAuthMethods(ctx, scopeIds, ldap.WithPage(page), ldap.WithLimit(limit))
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	for _, item := range ll {
+		outUl = append(outUl, item)
+	}
+
+	jwtRepo, err := s.jwtRepoFn()
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	jl, err := jwtRepo.ListAuthMethods(ctx, scopeIds, jwt.WithPage(page), jwt.WithLimit(limit))
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	for _, item := range jl {
+		outUl = append(outUl, item)
+	}
+	return pagination.MergeItems(outUl, page, limit), nil
 }
 
 func (s Service) createInRepo(ctx context.Context, scopeId string, item *pb.AuthMethod) (auth.AuthMethod, error) {
@@ -536,6 +630,24 @@ func (s Service) createInRepo(ctx context.Context, scopeId string, item *pb.Auth
 			return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to create auth method but no error returned from repository.")
 		}
 		out = am
+	case ldap.Subtype:
+		am, err := s.createLdapInRepo(ctx, scopeId, item)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		if am == nil {
+			return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to create auth method but no error returned from repository.")
+		}
+		out = am
+	case jwt.Subtype:
+		am, err := s.createJwtInRepo(ctx, scopeId, item)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		if am == nil {
+			return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to create auth method but no error returned from repository.")
+		}
+		out = am
 	}
 	return out, nil
 }
@@ -567,6 +679,26 @@ func (s Service) updateInRepo(ctx context.Context, scopeId string, req *pbs.Upda
 		}
 		am = oam
 		dryRun = dr
+
+	case ldap.Subtype:
+		lam, err := s.updateLdapInRepo(ctx, scopeId, req.GetId(), req.GetUpdateMask().GetPaths(), req.GetItem())
+		if err != nil {
+			return nil, false, errors.Wrap(ctx, err, op)
+		}
+		if lam == nil {
+			return nil, false, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to update auth method but no error returned from repository.")
+		}
+		am = lam
+
+	case jwt.Subtype:
+		jam, err := s.updateJwtInRepo(ctx, scopeId, req.GetId(), req.GetUpdateMask().GetPaths(), req.GetItem())
+		if err != nil {
+			return nil, false, errors.Wrap(ctx, err, op)
+		}
+		if jam == nil {
+			return nil, false, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to update auth method but no error returned from repository.")
+		}
+		am = jam
 	}
 
 	return am, dryRun, nil
@@ -590,6 +722,20 @@ func (s Service) deleteFromRepo(ctx context.Context, scopeId, id string) (bool,
 			return false, errors.Wrap(ctx, err, op)
 		}
 		rows, dErr = repo.DeleteAuthMethod(ctx, id)
+
+	case ldap.Subtype:
+		repo, err := s.ldapRepoFn()
+		if err != nil {
+			return false, errors.Wrap(ctx, err, op)
+		}
+		rows, dErr = repo.DeleteAuthMethod(ctx, id)
+
+	case jwt.Subtype:
+		repo, err := s.jwtRepoFn()
+		if err != nil {
+			return false, errors.Wrap(ctx, err, op)
+		}
+		rows, dErr = repo.DeleteAuthMethod(ctx, id)
 	}
 
 	if dErr != nil {
@@ -697,6 +843,38 @@ func (s Service) authResult(ctx context.Context, id string, a action.Type) reque
 				return res
 			}
 			authMeth = am
+		case ldap.Subtype:
+			repo, err := s.ldapRepoFn()
+			if err != nil {
+				res.Error = err
+				return res
+			}
+			am, err := repo.LookupAuthMethod(ctx, id)
+			if err != nil {
+				res.Error = err
+				return res
+			}
+			if am == nil {
+				res.Error = handlers.NotFoundError()
+				return res
+			}
+			authMeth = am
+		case jwt.Subtype:
+			repo, err := s.jwtRepoFn()
+			if err != nil {
+				res.Error = err
+				return res
+			}
+			am, err := repo.LookupAuthMethod(ctx, id)
+			if err != nil {
+				res.Error = err
+				return res
+			}
+			if am == nil {
+				res.Error = handlers.NotFoundError()
+				return res
+			}
+			authMeth = am
 		default:
 			res.Error = errors.New(ctx, errors.InvalidPublicId, op, "unrecognized auth method type")
 			return res
@@ -759,8 +937,16 @@ func toAuthMethodProto(ctx context.Context, in auth.AuthMethod, opt ...handlers.
 		}
 		out.Attrs = &pb.AuthMethod_PasswordAuthMethodAttributes{
 			PasswordAuthMethodAttributes: &pb.PasswordAuthMethodAttributes{
-				MinLoginNameLength: i.GetMinLoginNameLength(),
-				MinPasswordLength:  i.GetMinPasswordLength(),
+				MinLoginNameLength:   i.GetMinLoginNameLength(),
+				MinPasswordLength:    i.GetMinPasswordLength(),
+				MaxFailedAttempts:    i.GetMaxFailedAttempts(),
+				LockoutDuration:      i.GetLockoutDuration(),
+				SourceIpRateLimit:    i.GetSourceIpRateLimit(),
+				MfaRequired:          i.GetMfaRequired(),
+				MinPasswordClasses:   i.GetMinPasswordClasses(),
+				DenyCommonPasswords:  i.GetDenyCommonPasswords(),
+				PasswordHistoryCount: i.GetPasswordHistoryCount(),
+				MaxPasswordAge:       i.GetMaxPasswordAge(),
 			},
 		}
 	case *oidc.AuthMethod:
@@ -779,10 +965,15 @@ func toAuthMethodProto(ctx context.Context, in auth.AuthMethod, opt ...handlers.
 			AllowedAudiences:  i.GetAudClaims(),
 			ClaimsScopes:      i.GetClaimsScopes(),
 			AccountClaimMaps:  i.GetAccountClaimMaps(),
+			Prompts:           i.GetPrompts(),
+			AcrValues:         i.GetAcrValues(),
 		}
 		if i.DisableDiscoveredConfigValidation {
 			attrs.DisableDiscoveredConfigValidation = true
 		}
+		if i.GetEnablePkce() {
+			attrs.EnablePkce = true
+		}
 		if i.GetIssuer() != "" {
 			attrs.Issuer = wrapperspb.String(i.Issuer)
 		}
@@ -801,6 +992,74 @@ func toAuthMethodProto(ctx context.Context, in auth.AuthMethod, opt ...handlers.
 		out.Attrs = &pb.AuthMethod_OidcAuthMethodsAttributes{
 			OidcAuthMethodsAttributes: attrs,
 		}
+	case *ldap.AuthMethod:
+		if outputFields.Has(globals.TypeField) {
+			out.Type = ldap.Subtype.String()
+		}
+		if !outputFields.Has(globals.AttributesField) {
+			break
+		}
+		attrs := &pb.LdapAuthMethodAttributes{
+			Urls:                 i.GetUrls(),
+			StartTls:             i.GetStartTls(),
+			InsecureTls:          i.GetInsecureTls(),
+			DiscoverDn:           i.GetDiscoverDn(),
+			AnonGroupSearch:      i.GetAnonGroupSearch(),
+			EnableGroups:         i.GetEnableGroups(),
+			Certificates:         i.GetCertificates(),
+			BindPasswordHmac:     i.GetBindPasswordHmac(),
+			AccountAttributeMaps: i.GetAccountAttributeMaps(),
+		}
+		if i.GetUpnDomain() != "" {
+			attrs.UpnDomain = wrapperspb.String(i.GetUpnDomain())
+		}
+		if i.GetUserDn() != "" {
+			attrs.UserDn = wrapperspb.String(i.GetUserDn())
+		}
+		if i.GetUserAttr() != "" {
+			attrs.UserAttr = wrapperspb.String(i.GetUserAttr())
+		}
+		if i.GetUserFilter() != "" {
+			attrs.UserFilter = wrapperspb.String(i.GetUserFilter())
+		}
+		if i.GetGroupDn() != "" {
+			attrs.GroupDn = wrapperspb.String(i.GetGroupDn())
+		}
+		if i.GetGroupAttr() != "" {
+			attrs.GroupAttr = wrapperspb.String(i.GetGroupAttr())
+		}
+		if i.GetGroupFilter() != "" {
+			attrs.GroupFilter = wrapperspb.String(i.GetGroupFilter())
+		}
+		if i.GetBindDn() != "" {
+			attrs.BindDn = wrapperspb.String(i.GetBindDn())
+		}
+		out.Attrs = &pb.AuthMethod_LdapAuthMethodAttributes{
+			LdapAuthMethodAttributes: attrs,
+		}
+	case *jwt.AuthMethod:
+		if outputFields.Has(globals.TypeField) {
+			out.Type = jwt.Subtype.String()
+		}
+		if !outputFields.Has(globals.AttributesField) {
+			break
+		}
+		attrs := &pb.JwtAuthMethodAttributes{
+			JwksCaCerts:       i.GetJwksCaCerts(),
+			PublicKeys:        i.GetPublicKeys(),
+			BoundAudiences:    i.GetBoundAudiences(),
+			SigningAlgorithms: i.GetSigningAlgorithms(),
+			AccountClaimMaps:  i.GetAccountClaimMaps(),
+		}
+		if i.GetIssuer() != "" {
+			attrs.Issuer = wrapperspb.String(i.GetIssuer())
+		}
+		if i.GetJwksUrl() != "" {
+			attrs.JwksUrl = wrapperspb.String(i.GetJwksUrl())
+		}
+		out.Attrs = &pb.AuthMethod_JwtAuthMethodAttributes{
+			JwtAuthMethodAttributes: attrs,
+		}
 	}
 	return &out, nil
 }
@@ -829,7 +1088,7 @@ func validateGetRequest(req *pbs.GetAuthMethodRequest) error {
 	if req == nil {
 		return errors.NewDeprecated(errors.InvalidParameter, op, "Missing request")
 	}
-	return handlers.ValidateGetRequest(handlers.NoopValidatorFn, req, password.AuthMethodPrefix, oidc.AuthMethodPrefix)
+	return handlers.ValidateGetRequest(handlers.NoopValidatorFn, req, password.AuthMethodPrefix, oidc.AuthMethodPrefix, ldap.AuthMethodPrefix, jwt.AuthMethodPrefix)
 }
 
 func validateCreateRequest(ctx context.Context, req *pbs.CreateAuthMethodRequest) error {
@@ -849,6 +1108,7 @@ func validateCreateRequest(ctx context.Context, req *pbs.CreateAuthMethodRequest
 		switch subtypes.SubtypeFromType(domain, req.GetItem().GetType()) {
 		case password.Subtype:
 			// Password attributes are not required when creating a password auth method.
+			validatePwAuthMethodAttributes(req.GetItem().GetPasswordAuthMethodAttributes(), badFields)
 		case oidc.Subtype:
 			attrs := req.GetItem().GetOidcAuthMethodsAttributes()
 			if attrs == nil {
@@ -911,6 +1171,17 @@ func validateCreateRequest(ctx context.Context, req *pbs.CreateAuthMethodRequest
 						}
 					}
 				}
+				if len(attrs.GetPrompts()) > 0 {
+					if err := oidc.ValidatePrompts(ctx, attrs.GetPrompts()...); err != nil {
+						badFields[promptsField] = fmt.Sprintf("Contains invalid prompts: %s.", errors.Convert(err).Msg)
+					}
+				}
+				for _, acr := range attrs.GetAcrValues() {
+					if strings.TrimSpace(acr) == "" {
+						badFields[acrValuesField] = "Contains an empty acr value."
+						break
+					}
+				}
 				if len(attrs.GetAccountClaimMaps()) > 0 {
 					acm, err := oidc.ParseAccountClaimMaps(ctx, attrs.GetAccountClaimMaps()...)
 					if err != nil {
@@ -925,6 +1196,20 @@ func validateCreateRequest(ctx context.Context, req *pbs.CreateAuthMethodRequest
 					}
 				}
 			}
+		case ldap.Subtype:
+			attrs := req.GetItem().GetLdapAuthMethodAttributes()
+			if attrs == nil {
+				badFields[attributesField] = "Attributes are required for creating an LDAP auth method."
+			} else {
+				validateLdapAttributes(ctx, attrs, nil, badFields)
+			}
+		case jwt.Subtype:
+			attrs := req.GetItem().GetJwtAuthMethodAttributes()
+			if attrs == nil {
+				badFields[attributesField] = "Attributes are required for creating a JWT auth method."
+			} else {
+				validateJwtAttributes(ctx, attrs, nil, badFields)
+			}
 		default:
 			badFields[typeField] = fmt.Sprintf("This is a required field and must be %q.", password.Subtype.String())
 		}
@@ -947,6 +1232,7 @@ func validateUpdateRequest(ctx context.Context, req *pbs.UpdateAuthMethodRequest
 			if req.GetItem().GetType() != "" && subtypes.SubtypeFromType(domain, req.GetItem().GetType()) != password.Subtype {
 				badFields[typeField] = "Cannot modify the resource type."
 			}
+			validatePwAuthMethodAttributes(req.GetItem().GetPasswordAuthMethodAttributes(), badFields)
 		case oidc.Subtype:
 			if req.GetItem().GetType() != "" && subtypes.SubtypeFromType(domain, req.GetItem().GetType()) != oidc.Subtype {
 				badFields[typeField] = "Cannot modify the resource type."
@@ -1026,6 +1312,17 @@ func validateUpdateRequest(ctx context.Context, req *pbs.UpdateAuthMethodRequest
 						}
 					}
 				}
+				if len(attrs.GetPrompts()) > 0 {
+					if err := oidc.ValidatePrompts(ctx, attrs.GetPrompts()...); err != nil {
+						badFields[promptsField] = fmt.Sprintf("Contains invalid prompts: %s.", errors.Convert(err).Msg)
+					}
+				}
+				for _, acr := range attrs.GetAcrValues() {
+					if strings.TrimSpace(acr) == "" {
+						badFields[acrValuesField] = "Contains an empty acr value."
+						break
+					}
+				}
 				if len(attrs.GetAccountClaimMaps()) > 0 {
 					acm, err := oidc.ParseAccountClaimMaps(ctx, attrs.GetAccountClaimMaps()...)
 					if err != nil {
@@ -1051,11 +1348,25 @@ func validateUpdateRequest(ctx context.Context, req *pbs.UpdateAuthMethodRequest
 					}
 				}
 			}
+		case ldap.Subtype:
+			if req.GetItem().GetType() != "" && subtypes.SubtypeFromType(domain, req.GetItem().GetType()) != ldap.Subtype {
+				badFields[typeField] = "Cannot modify the resource type."
+			}
+			if attrs := req.GetItem().GetLdapAuthMethodAttributes(); attrs != nil {
+				validateLdapAttributes(ctx, attrs, req.GetUpdateMask().GetPaths(), badFields)
+			}
+		case jwt.Subtype:
+			if req.GetItem().GetType() != "" && subtypes.SubtypeFromType(domain, req.GetItem().GetType()) != jwt.Subtype {
+				badFields[typeField] = "Cannot modify the resource type."
+			}
+			if attrs := req.GetItem().GetJwtAuthMethodAttributes(); attrs != nil {
+				validateJwtAttributes(ctx, attrs, req.GetUpdateMask().GetPaths(), badFields)
+			}
 		default:
 			badFields["id"] = "Incorrectly formatted identifier."
 		}
 		return badFields
-	}, password.AuthMethodPrefix, oidc.AuthMethodPrefix)
+	}, password.AuthMethodPrefix, oidc.AuthMethodPrefix, ldap.AuthMethodPrefix, jwt.AuthMethodPrefix)
 }
 
 func validateDeleteRequest(req *pbs.DeleteAuthMethodRequest) error {
@@ -1063,7 +1374,7 @@ func validateDeleteRequest(req *pbs.DeleteAuthMethodRequest) error {
 	if req == nil {
 		return errors.NewDeprecated(errors.InvalidParameter, op, "Missing request")
 	}
-	return handlers.ValidateDeleteRequest(handlers.NoopValidatorFn, req, password.AuthMethodPrefix, oidc.AuthMethodPrefix)
+	return handlers.ValidateDeleteRequest(handlers.NoopValidatorFn, req, password.AuthMethodPrefix, oidc.AuthMethodPrefix, ldap.AuthMethodPrefix, jwt.AuthMethodPrefix)
 }
 
 func validateListRequest(req *pbs.ListAuthMethodsRequest) error {
@@ -1127,7 +1438,7 @@ func validateAuthenticateRequest(req *pbs.AuthenticateRequest) error {
 	} else {
 		st := subtypes.SubtypeFromId(domain, req.GetAuthMethodId())
 		switch st {
-		case password.Subtype, oidc.Subtype:
+		case password.Subtype, oidc.Subtype, ldap.Subtype, jwt.Subtype:
 		default:
 			badFields[authMethodIdField] = "Unknown auth method type."
 		}
@@ -1231,12 +1542,23 @@ func transformAuthenticateRequestAttributes(msg proto.Message) error {
 	}
 	switch subtypes.SubtypeFromId(domain, authRequest.GetAuthMethodId()) {
 	case password.Subtype:
-		newAttrs := &pbs.PasswordLoginAttributes{}
-		if err := handlers.StructToProto(attrs, newAttrs); err != nil {
-			return err
-		}
-		authRequest.Attrs = &pbs.AuthenticateRequest_PasswordLoginAttributes{
-			PasswordLoginAttributes: newAttrs,
+		switch authRequest.GetCommand() {
+		case totpCommand:
+			newAttrs := &pbs.PasswordTotpAttributes{}
+			if err := handlers.StructToProto(attrs, newAttrs); err != nil {
+				return err
+			}
+			authRequest.Attrs = &pbs.AuthenticateRequest_PasswordTotpAttributes{
+				PasswordTotpAttributes: newAttrs,
+			}
+		default:
+			newAttrs := &pbs.PasswordLoginAttributes{}
+			if err := handlers.StructToProto(attrs, newAttrs); err != nil {
+				return err
+			}
+			authRequest.Attrs = &pbs.AuthenticateRequest_PasswordLoginAttributes{
+				PasswordLoginAttributes: newAttrs,
+			}
 		}
 	case oidc.Subtype:
 		switch authRequest.GetCommand() {
@@ -1267,6 +1589,22 @@ func transformAuthenticateRequestAttributes(msg proto.Message) error {
 		default:
 			return fmt.Errorf("%s: unknown command %q", op, authRequest.GetCommand())
 		}
+	case ldap.Subtype:
+		newAttrs := &pbs.LdapLoginAttributes{}
+		if err := handlers.StructToProto(attrs, newAttrs); err != nil {
+			return err
+		}
+		authRequest.Attrs = &pbs.AuthenticateRequest_LdapLoginAttributes{
+			LdapLoginAttributes: newAttrs,
+		}
+	case jwt.Subtype:
+		newAttrs := &pbs.JwtLoginAttributes{}
+		if err := handlers.StructToProto(attrs, newAttrs); err != nil {
+			return err
+		}
+		authRequest.Attrs = &pbs.AuthenticateRequest_JwtLoginAttributes{
+			JwtLoginAttributes: newAttrs,
+		}
 	default:
 		return &subtypes.UnknownSubtypeIDError{
 			ID: authRequest.GetAuthMethodId(),
@@ -1311,6 +1649,11 @@ func transformAuthenticateResponseAttributes(msg proto.Message) error {
 		if err != nil {
 			return err
 		}
+	case *pbs.AuthenticateResponse_PasswordTotpRequiredResponse:
+		newAttrs, err = handlers.ProtoToStruct(attrs.PasswordTotpRequiredResponse)
+		if err != nil {
+			return err
+		}
 	default:
 		return fmt.Errorf("%s: unknown attributes type %T", op, attrs)
 	}
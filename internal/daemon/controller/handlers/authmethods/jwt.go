@@ -0,0 +1,268 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package authmethods
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/boundary/internal/auth/jwt"
+	jwtstore "github.com/hashicorp/boundary/internal/auth/jwt/store"
+	"github.com/hashicorp/boundary/internal/daemon/controller/auth"
+	"github.com/hashicorp/boundary/internal/daemon/controller/handlers"
+	"github.com/hashicorp/boundary/internal/errors"
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
+	"github.com/hashicorp/boundary/internal/types/action"
+	pb "github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/authmethods"
+	pba "github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/authtokens"
+	capjwt "github.com/hashicorp/cap/jwt"
+	"google.golang.org/grpc/codes"
+)
+
+const (
+	// jwt field names
+	jwksUrlField        = "attributes.jwks_url"
+	jwksCaCertsField    = "attributes.jwks_ca_certs"
+	publicKeysField     = "attributes.public_keys"
+	boundAudiencesField = "attributes.bound_audiences"
+	tokenField          = "attributes.token"
+)
+
+var jwtMaskManager handlers.MaskManager
+
+func init() {
+	var err error
+	if jwtMaskManager, err = handlers.NewMaskManager(handlers.MaskDestination{&jwtstore.AuthMethod{}}, handlers.MaskSource{&pb.AuthMethod{}, &pb.JwtAuthMethodAttributes{}}); err != nil {
+		panic(err)
+	}
+
+	IdActions[jwt.Subtype] = action.ActionSet{
+		action.NoOp,
+		action.Read,
+		action.Update,
+		action.Delete,
+		action.Authenticate,
+	}
+}
+
+// createJwtInRepo creates a jwt auth method in a repo and returns the result.
+// This method should never return a nil AuthMethod without returning an error.
+func (s Service) createJwtInRepo(ctx context.Context, scopeId string, item *pb.AuthMethod) (*jwt.AuthMethod, error) {
+	u, err := toStorageJwtAuthMethod(ctx, scopeId, item)
+	if err != nil {
+		return nil, err
+	}
+	repo, err := s.jwtRepoFn()
+	if err != nil {
+		return nil, err
+	}
+	out, err := repo.CreateAuthMethod(ctx, u)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create auth method: %w", err)
+	}
+	return out, nil
+}
+
+func (s Service) updateJwtInRepo(ctx context.Context, scopeId, id string, mask []string, item *pb.AuthMethod) (*jwt.AuthMethod, error) {
+	u, err := toStorageJwtAuthMethod(ctx, scopeId, item)
+	if err != nil {
+		return nil, err
+	}
+
+	version := item.GetVersion()
+	u.PublicId = id
+
+	dbMask := jwtMaskManager.Translate(mask)
+	if len(dbMask) == 0 {
+		return nil, handlers.InvalidArgumentErrorf("No valid fields included in the update mask.", map[string]string{"update_mask": "No valid fields provided in the update mask."})
+	}
+
+	repo, err := s.jwtRepoFn()
+	if err != nil {
+		return nil, err
+	}
+	out, rowsUpdated, err := repo.UpdateAuthMethod(ctx, u, version, dbMask)
+	if err != nil {
+		return nil, fmt.Errorf("unable to update auth method: %w", err)
+	}
+	if rowsUpdated == 0 {
+		return nil, handlers.NotFoundErrorf("AuthMethod %q doesn't exist or incorrect version provided.", id)
+	}
+	return out, nil
+}
+
+func (s Service) authenticateJwt(ctx context.Context, req *pbs.AuthenticateRequest, authResults *auth.VerifyResults) (*pbs.AuthenticateResponse, error) {
+	reqAttrs := req.GetJwtLoginAttributes()
+	tok, err := s.authenticateWithJwtRepo(ctx, req.GetAuthMethodId(), reqAttrs.GetToken())
+	if err != nil {
+		return nil, err
+	}
+	return s.convertToAuthenticateResponse(ctx, req, authResults, tok)
+}
+
+func (s Service) authenticateWithJwtRepo(ctx context.Context, authMethodId, token string) (*pba.AuthToken, error) {
+	iamRepo, err := s.iamRepoFn()
+	if err != nil {
+		return nil, err
+	}
+	atRepo, err := s.atRepoFn()
+	if err != nil {
+		return nil, err
+	}
+	jwtRepo, err := s.jwtRepoFn()
+	if err != nil {
+		return nil, err
+	}
+
+	acct, err := jwtRepo.Authenticate(ctx, authMethodId, token)
+	if err != nil {
+		return nil, err
+	}
+	if acct == nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Unauthenticated, "Unable to authenticate.")
+	}
+
+	u, err := iamRepo.LookupUserWithLogin(ctx, acct.GetPublicId())
+	if err != nil {
+		return nil, err
+	}
+	tok, err := atRepo.CreateAuthToken(ctx, u, acct.GetPublicId())
+	if err != nil {
+		return nil, err
+	}
+
+	return s.ConvertInternalAuthTokenToApiAuthToken(
+		ctx,
+		tok,
+	)
+}
+
+func validateAuthenticateJwtRequest(req *pbs.AuthenticateRequest) error {
+	badFields := make(map[string]string)
+
+	attrs := req.GetJwtLoginAttributes()
+	switch {
+	case attrs == nil:
+		badFields["attributes"] = "This is a required field."
+	default:
+		if attrs.GetToken() == "" {
+			badFields[tokenField] = "This is a required field."
+		}
+		if req.GetCommand() == "" {
+			req.Command = loginCommand
+		}
+		if req.GetCommand() != loginCommand {
+			badFields[commandField] = "Invalid command for this auth method type."
+		}
+		tokenType := req.GetType()
+		if tokenType == "" {
+			// Fall back to deprecated field if type is not set
+			tokenType = req.GetTokenType()
+		}
+		tType := strings.ToLower(strings.TrimSpace(tokenType))
+		if tType != "" && tType != "token" && tType != "cookie" {
+			badFields[tokenTypeField] = `The only accepted types are "token" and "cookie".`
+		}
+	}
+
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Invalid fields provided in request.", badFields)
+	}
+	return nil
+}
+
+// validateJwtAttributes validates the jwt attributes of a create or update
+// request. When mask is nil every set attribute is validated, otherwise only
+// the attributes in the mask are.
+func validateJwtAttributes(ctx context.Context, attrs *pb.JwtAuthMethodAttributes, mask []string, badFields map[string]string) {
+	inMask := func(f string) bool {
+		return mask == nil || handlers.MaskContains(mask, f)
+	}
+	if mask == nil {
+		switch {
+		case attrs.GetJwksUrl().GetValue() == "" && len(attrs.GetPublicKeys()) == 0:
+			badFields[jwksUrlField] = "Either a jwks url or public keys must be provided."
+		case attrs.GetJwksUrl().GetValue() != "" && len(attrs.GetPublicKeys()) > 0:
+			badFields[publicKeysField] = "Cannot be set together with a jwks url."
+		}
+		if len(attrs.GetJwksCaCerts()) > 0 && attrs.GetJwksUrl().GetValue() == "" {
+			badFields[jwksCaCertsField] = "Field requires a jwks url."
+		}
+	}
+	// the audience and issuer of a token must be checked, so they can be
+	// neither omitted nor cleared
+	if inMask(issuerField) && attrs.GetIssuer().GetValue() == "" {
+		badFields[issuerField] = "This is a required field."
+	}
+	if inMask(boundAudiencesField) && len(attrs.GetBoundAudiences()) == 0 {
+		badFields[boundAudiencesField] = "At least one audience is required."
+	}
+	if inMask(jwksUrlField) && attrs.GetJwksUrl().GetValue() != "" {
+		u, err := url.Parse(attrs.GetJwksUrl().GetValue())
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			badFields[jwksUrlField] = "Must be a valid http or https url."
+		}
+	}
+	if inMask(jwksCaCertsField) && len(attrs.GetJwksCaCerts()) > 0 {
+		if _, err := jwt.ParseCertificates(ctx, attrs.GetJwksCaCerts()...); err != nil {
+			badFields[jwksCaCertsField] = fmt.Sprintf("Cannot parse CA certificates. %v", err.Error())
+		}
+	}
+	if inMask(publicKeysField) && len(attrs.GetPublicKeys()) > 0 {
+		if _, err := jwt.ParsePublicKeys(ctx, attrs.GetPublicKeys()...); err != nil {
+			badFields[publicKeysField] = fmt.Sprintf("Cannot parse public keys. %v", err.Error())
+		}
+	}
+	if inMask(boundAudiencesField) {
+		for _, a := range attrs.GetBoundAudiences() {
+			if strings.TrimSpace(a) == "" {
+				badFields[boundAudiencesField] = "Contains an empty audience."
+				break
+			}
+		}
+	}
+	if inMask(signingAlgorithmField) {
+		for _, sa := range attrs.GetSigningAlgorithms() {
+			if err := capjwt.SupportedSigningAlgorithm(capjwt.Alg(sa)); err != nil {
+				badFields[signingAlgorithmField] = fmt.Sprintf("Contains unsupported algorithm %q.", sa)
+				break
+			}
+		}
+	}
+	if inMask(accountClaimMapsField) && len(attrs.GetAccountClaimMaps()) > 0 {
+		acm, err := jwt.ParseAccountClaimMaps(ctx, attrs.GetAccountClaimMaps()...)
+		if err != nil {
+			badFields[accountClaimMapsField] = fmt.Sprintf("Contains invalid map %q", err.Error())
+		}
+		foundTo := make(map[string]bool, len(acm))
+		for _, m := range acm {
+			if foundTo[m.To] {
+				badFields[accountClaimMapsField] = fmt.Sprintf("%s=%s contains invalid map - multiple maps to the same Boundary to-claim %s", m.From, m.To, m.To)
+			}
+			foundTo[m.To] = true
+		}
+	}
+}
+
+func toStorageJwtAuthMethod(ctx context.Context, scopeId string, in *pb.AuthMethod) (*jwt.AuthMethod, error) {
+	const op = "authmethod_service.toStorageJwtAuthMethod"
+	if in == nil {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "nil auth method.")
+	}
+	attrs := in.GetJwtAuthMethodAttributes()
+	am := jwt.AllocAuthMethod()
+	am.ScopeId = scopeId
+	am.Name = in.GetName().GetValue()
+	am.Description = in.GetDescription().GetValue()
+	am.Issuer = attrs.GetIssuer().GetValue()
+	am.JwksUrl = attrs.GetJwksUrl().GetValue()
+	am.JwksCaCerts = attrs.GetJwksCaCerts()
+	am.PublicKeys = attrs.GetPublicKeys()
+	am.BoundAudiences = attrs.GetBoundAudiences()
+	am.SigningAlgorithms = attrs.GetSigningAlgorithms()
+	am.AccountClaimMaps = attrs.GetAccountClaimMaps()
+	return &am, nil
+}
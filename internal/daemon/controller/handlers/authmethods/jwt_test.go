@@ -0,0 +1,83 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package authmethods
+
+import (
+	"context"
+	"testing"
+
+	pb "github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/authmethods"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestValidateJwtAttributes(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	valid := func() *pb.JwtAuthMethodAttributes {
+		return &pb.JwtAuthMethodAttributes{
+			Issuer:         wrapperspb.String("https://issuer.example.com"),
+			JwksUrl:        wrapperspb.String("https://issuer.example.com/.well-known/jwks"),
+			BoundAudiences: []string{"boundary"},
+		}
+	}
+
+	tests := []struct {
+		name          string
+		attrs         func() *pb.JwtAuthMethodAttributes
+		mask          []string
+		wantBadFields []string
+	}{
+		{
+			name:  "valid-create",
+			attrs: valid,
+		},
+		{
+			name: "create-missing-issuer-and-audiences",
+			attrs: func() *pb.JwtAuthMethodAttributes {
+				a := valid()
+				a.Issuer = nil
+				a.BoundAudiences = nil
+				return a
+			},
+			wantBadFields: []string{issuerField, boundAudiencesField},
+		},
+		{
+			name: "update-clears-issuer",
+			attrs: func() *pb.JwtAuthMethodAttributes {
+				return &pb.JwtAuthMethodAttributes{}
+			},
+			mask:          []string{issuerField},
+			wantBadFields: []string{issuerField},
+		},
+		{
+			name: "update-clears-audiences",
+			attrs: func() *pb.JwtAuthMethodAttributes {
+				return &pb.JwtAuthMethodAttributes{}
+			},
+			mask:          []string{boundAudiencesField},
+			wantBadFields: []string{boundAudiencesField},
+		},
+		{
+			name: "update-other-field",
+			attrs: func() *pb.JwtAuthMethodAttributes {
+				return &pb.JwtAuthMethodAttributes{SigningAlgorithms: []string{"ES256"}}
+			},
+			mask: []string{signingAlgorithmField},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			badFields := map[string]string{}
+			validateJwtAttributes(ctx, tt.attrs(), tt.mask, badFields)
+			got := make([]string, 0, len(badFields))
+			for f := range badFields {
+				got = append(got, f)
+			}
+			assert.ElementsMatch(t, tt.wantBadFields, got)
+		})
+	}
+}
@@ -0,0 +1,244 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package authmethods
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/boundary/internal/auth/ldap"
+	ldapstore "github.com/hashicorp/boundary/internal/auth/ldap/store"
+	"github.com/hashicorp/boundary/internal/daemon/controller/auth"
+	"github.com/hashicorp/boundary/internal/daemon/controller/handlers"
+	"github.com/hashicorp/boundary/internal/errors"
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
+	"github.com/hashicorp/boundary/internal/types/action"
+	pb "github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/authmethods"
+	pba "github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/authtokens"
+	"google.golang.org/grpc/codes"
+)
+
+const (
+	// ldap field names
+	urlsField                 = "attributes.urls"
+	bindDnField               = "attributes.bind_dn"
+	bindPasswordHmacField     = "attributes.bind_password_hmac"
+	certificatesField         = "attributes.certificates"
+	accountAttributeMapsField = "attributes.account_attribute_maps"
+)
+
+var ldapMaskManager handlers.MaskManager
+
+func init() {
+	var err error
+	if ldapMaskManager, err = handlers.NewMaskManager(handlers.MaskDestination{&ldapstore.AuthMethod{}}, handlers.MaskSource{&pb.AuthMethod{}, &pb.LdapAuthMethodAttributes{}}); err != nil {
+		panic(err)
+	}
+
+	IdActions[ldap.Subtype] = action.ActionSet{
+		action.NoOp,
+		action.Read,
+		action.Update,
+		action.Delete,
+		action.Authenticate,
+	}
+}
+
+// createLdapInRepo creates an ldap auth method in a repo and returns the result.
+// This method should never return a nil AuthMethod without returning an error.
+func (s Service) createLdapInRepo(ctx context.Context, scopeId string, item *pb.AuthMethod) (*ldap.AuthMethod, error) {
+	u, err := toStorageLdapAuthMethod(ctx, scopeId, item)
+	if err != nil {
+		return nil, err
+	}
+	repo, err := s.ldapRepoFn()
+	if err != nil {
+		return nil, err
+	}
+	out, err := repo.CreateAuthMethod(ctx, u)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create auth method: %w", err)
+	}
+	return out, nil
+}
+
+func (s Service) updateLdapInRepo(ctx context.Context, scopeId, id string, mask []string, item *pb.AuthMethod) (*ldap.AuthMethod, error) {
+	u, err := toStorageLdapAuthMethod(ctx, scopeId, item)
+	if err != nil {
+		return nil, err
+	}
+
+	version := item.GetVersion()
+	u.PublicId = id
+
+	dbMask := ldapMaskManager.Translate(mask)
+	if len(dbMask) == 0 {
+		return nil, handlers.InvalidArgumentErrorf("No valid fields included in the update mask.", map[string]string{"update_mask": "No valid fields provided in the update mask."})
+	}
+
+	repo, err := s.ldapRepoFn()
+	if err != nil {
+		return nil, err
+	}
+	out, rowsUpdated, err := repo.UpdateAuthMethod(ctx, u, version, dbMask)
+	if err != nil {
+		return nil, fmt.Errorf("unable to update auth method: %w", err)
+	}
+	if rowsUpdated == 0 {
+		return nil, handlers.NotFoundErrorf("AuthMethod %q doesn't exist or incorrect version provided.", id)
+	}
+	return out, nil
+}
+
+func (s Service) authenticateLdap(ctx context.Context, req *pbs.AuthenticateRequest, authResults *auth.VerifyResults) (*pbs.AuthenticateResponse, error) {
+	reqAttrs := req.GetLdapLoginAttributes()
+	tok, err := s.authenticateWithLdapRepo(ctx, req.GetAuthMethodId(), reqAttrs.GetLoginName(), reqAttrs.GetPassword())
+	if err != nil {
+		return nil, err
+	}
+	return s.convertToAuthenticateResponse(ctx, req, authResults, tok)
+}
+
+func (s Service) authenticateWithLdapRepo(ctx context.Context, authMethodId, loginName, pw string) (*pba.AuthToken, error) {
+	iamRepo, err := s.iamRepoFn()
+	if err != nil {
+		return nil, err
+	}
+	atRepo, err := s.atRepoFn()
+	if err != nil {
+		return nil, err
+	}
+	ldapRepo, err := s.ldapRepoFn()
+	if err != nil {
+		return nil, err
+	}
+
+	acct, err := ldapRepo.Authenticate(ctx, authMethodId, loginName, pw)
+	if err != nil {
+		return nil, err
+	}
+	if acct == nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Unauthenticated, "Unable to authenticate.")
+	}
+
+	u, err := iamRepo.LookupUserWithLogin(ctx, acct.GetPublicId())
+	if err != nil {
+		return nil, err
+	}
+	tok, err := atRepo.CreateAuthToken(ctx, u, acct.GetPublicId())
+	if err != nil {
+		return nil, err
+	}
+
+	return s.ConvertInternalAuthTokenToApiAuthToken(
+		ctx,
+		tok,
+	)
+}
+
+func validateAuthenticateLdapRequest(req *pbs.AuthenticateRequest) error {
+	badFields := make(map[string]string)
+
+	attrs := req.GetLdapLoginAttributes()
+	switch {
+	case attrs == nil:
+		badFields["attributes"] = "This is a required field."
+	default:
+		if attrs.GetLoginName() == "" {
+			badFields["attributes.login_name"] = "This is a required field."
+		}
+		if attrs.GetPassword() == "" {
+			badFields["attributes.password"] = "This is a required field."
+		}
+		if req.GetCommand() == "" {
+			req.Command = loginCommand
+		}
+		if req.GetCommand() != loginCommand {
+			badFields[commandField] = "Invalid command for this auth method type."
+		}
+		tokenType := req.GetType()
+		if tokenType == "" {
+			// Fall back to deprecated field if type is not set
+			tokenType = req.GetTokenType()
+		}
+		tType := strings.ToLower(strings.TrimSpace(tokenType))
+		if tType != "" && tType != "token" && tType != "cookie" {
+			badFields[tokenTypeField] = `The only accepted types are "token" and "cookie".`
+		}
+	}
+
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Invalid fields provided in request.", badFields)
+	}
+	return nil
+}
+
+// validateLdapAttributes validates the ldap attributes of a create or update
+// request. When mask is nil every set attribute is validated, otherwise only
+// the attributes in the mask are.
+func validateLdapAttributes(ctx context.Context, attrs *pb.LdapAuthMethodAttributes, mask []string, badFields map[string]string) {
+	inMask := func(f string) bool {
+		return mask == nil || handlers.MaskContains(mask, f)
+	}
+	if inMask(urlsField) {
+		if len(attrs.GetUrls()) == 0 {
+			badFields[urlsField] = "At least one url is required."
+		}
+		for _, u := range attrs.GetUrls() {
+			parsed, err := url.Parse(u)
+			if err != nil || (parsed.Scheme != "ldap" && parsed.Scheme != "ldaps") || parsed.Host == "" {
+				badFields[urlsField] = fmt.Sprintf("%q is not a valid ldap or ldaps url.", u)
+				break
+			}
+		}
+	}
+	if attrs.GetBindPasswordHmac() != "" {
+		badFields[bindPasswordHmacField] = "Field is read only."
+	}
+	if attrs.GetBindPassword().GetValue() != "" && attrs.GetBindDn().GetValue() == "" && mask == nil {
+		badFields[bindDnField] = "Field is required when a bind password is provided."
+	}
+	if inMask(certificatesField) && len(attrs.GetCertificates()) > 0 {
+		if _, err := ldap.ParseCertificates(ctx, attrs.GetCertificates()...); err != nil {
+			badFields[certificatesField] = fmt.Sprintf("Cannot parse CA certificates. %v", err.Error())
+		}
+	}
+	if inMask(accountAttributeMapsField) && len(attrs.GetAccountAttributeMaps()) > 0 {
+		if _, err := ldap.ParseAccountAttributeMaps(ctx, attrs.GetAccountAttributeMaps()...); err != nil {
+			badFields[accountAttributeMapsField] = fmt.Sprintf("Contains invalid map %q", err.Error())
+		}
+	}
+}
+
+func toStorageLdapAuthMethod(ctx context.Context, scopeId string, in *pb.AuthMethod) (*ldap.AuthMethod, error) {
+	const op = "authmethod_service.toStorageLdapAuthMethod"
+	if in == nil {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "nil auth method.")
+	}
+	attrs := in.GetLdapAuthMethodAttributes()
+	am := ldap.AllocAuthMethod()
+	am.ScopeId = scopeId
+	am.Name = in.GetName().GetValue()
+	am.Description = in.GetDescription().GetValue()
+	am.StartTls = attrs.GetStartTls()
+	am.InsecureTls = attrs.GetInsecureTls()
+	am.DiscoverDn = attrs.GetDiscoverDn()
+	am.AnonGroupSearch = attrs.GetAnonGroupSearch()
+	am.UpnDomain = attrs.GetUpnDomain().GetValue()
+	am.UserDn = attrs.GetUserDn().GetValue()
+	am.UserAttr = attrs.GetUserAttr().GetValue()
+	am.UserFilter = attrs.GetUserFilter().GetValue()
+	am.EnableGroups = attrs.GetEnableGroups()
+	am.GroupDn = attrs.GetGroupDn().GetValue()
+	am.GroupAttr = attrs.GetGroupAttr().GetValue()
+	am.GroupFilter = attrs.GetGroupFilter().GetValue()
+	am.BindDn = attrs.GetBindDn().GetValue()
+	am.BindPassword = attrs.GetBindPassword().GetValue()
+	am.Urls = attrs.GetUrls()
+	am.Certificates = attrs.GetCertificates()
+	am.AccountAttributeMaps = attrs.GetAccountAttributeMaps()
+	return &am, nil
+}
@@ -46,6 +46,8 @@ const (
 	codeField                              = "attributes.code"
 	claimsScopesField                      = "attributes.claims_scopes"
 	accountClaimMapsField                  = "attributes.account_claim_maps"
+	promptsField                           = "attributes.prompts"
+	acrValuesField                         = "attributes.acr_values"
 )
 
 var oidcMaskManager handlers.MaskManager
@@ -170,8 +172,12 @@ func (s Service) authenticateOidcStart(ctx context.Context, req *pbs.Authenticat
 		return nil, errors.New(ctx, errors.InvalidParameter, op, "Nil request.")
 	}
 
-	var opts []oidc.Option
 	attrs := req.GetOidcStartAttributes()
+	if attrs.GetDeviceFlow() {
+		return s.authenticateOidcDeviceStart(ctx, req)
+	}
+
+	var opts []oidc.Option
 	if attrs.GetCachedRoundtripPayload() != "" {
 		opts = append(opts, oidc.WithRoundtripPayload(attrs.GetCachedRoundtripPayload()))
 	}
@@ -195,6 +201,34 @@ func (s Service) authenticateOidcStart(ctx context.Context, req *pbs.Authenticat
 	}, nil
 }
 
+func (s Service) authenticateOidcDeviceStart(ctx context.Context, req *pbs.AuthenticateRequest) (*pbs.AuthenticateResponse, error) {
+	const op = "authmethod_service.(Service).authenticateOidcDeviceStart"
+	if req == nil {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "Nil request.")
+	}
+
+	deviceAuth, tokenId, err := oidc.StartDeviceAuth(ctx, s.oidcRepoFn, req.GetAuthMethodId())
+	if err != nil {
+		// this event.WriteError(...) may cause a dup error to be emitted...
+		// it should be removed if that's the case.
+		event.WriteError(ctx, op, err, event.WithInfoMsg("error starting the oidc device authorization flow"))
+		return nil, errors.New(ctx, errors.Internal, op, "Error generating parameters for starting the OIDC device flow. See the controller's log for more information.")
+	}
+
+	return &pbs.AuthenticateResponse{
+		Command: req.GetCommand(),
+		Attrs: &pbs.AuthenticateResponse_OidcAuthMethodAuthenticateStartResponse{
+			OidcAuthMethodAuthenticateStartResponse: &pb.OidcAuthMethodAuthenticateStartResponse{
+				TokenId:                 tokenId,
+				UserCode:                deviceAuth.UserCode,
+				VerificationUri:         deviceAuth.VerificationUri,
+				VerificationUriComplete: deviceAuth.VerificationUriComplete,
+				Interval:                uint32(deviceAuth.Interval.Seconds()),
+			},
+		},
+	}, nil
+}
+
 // authenticateOidcCallback behaves differently than other service methods.
 // Because of the way it this is called by the end user, it should only return
 // an error if we are unable to lookup the auth method or the request
@@ -291,7 +325,7 @@ func (s Service) authenticateOidcToken(ctx context.Context, req *pbs.Authenticat
 		return nil, errors.New(ctx, errors.InvalidParameter, op, "Empty token ID in request attributes.")
 	}
 
-	token, err := oidc.TokenRequest(ctx, s.kms, s.atRepoFn, req.GetAuthMethodId(), attrs.TokenId)
+	token, err := oidc.TokenRequest(ctx, s.kms, s.oidcRepoFn, oidc.IamRepoFactory(s.iamRepoFn), s.atRepoFn, req.GetAuthMethodId(), attrs.TokenId)
 	if err != nil {
 		switch {
 		case errors.Match(errors.T(errors.Forbidden), err):
@@ -461,6 +495,22 @@ func toStorageOidcAuthMethod(ctx context.Context, scopeId string, in *pb.AuthMet
 		opts = append(opts, oidc.WithClaimsScopes(attrs.GetClaimsScopes()...))
 	}
 
+	if len(attrs.GetPrompts()) > 0 {
+		prompts := make([]oidc.PromptParam, 0, len(attrs.GetPrompts()))
+		for _, p := range attrs.GetPrompts() {
+			prompts = append(prompts, oidc.PromptParam(p))
+		}
+		opts = append(opts, oidc.WithPrompts(prompts...))
+	}
+
+	if len(attrs.GetAcrValues()) > 0 {
+		opts = append(opts, oidc.WithAcrValues(attrs.GetAcrValues()...))
+	}
+
+	if attrs.GetEnablePkce() {
+		opts = append(opts, oidc.WithEnablePkce(true))
+	}
+
 	if len(attrs.GetAccountClaimMaps()) > 0 {
 		claimsMap := make(map[string]oidc.AccountToClaim, len(attrs.GetAccountClaimMaps()))
 		for _, v := range attrs.GetAccountClaimMaps() {
@@ -7,13 +7,16 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/boundary/internal/auth/password"
 	pwstore "github.com/hashicorp/boundary/internal/auth/password/store"
+	"github.com/hashicorp/boundary/internal/authtoken"
 	"github.com/hashicorp/boundary/internal/daemon/controller/auth"
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers"
 	"github.com/hashicorp/boundary/internal/errors"
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
+	"github.com/hashicorp/boundary/internal/observability/event"
 	"github.com/hashicorp/boundary/internal/types/action"
 	pb "github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/authmethods"
 	pba "github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/authtokens"
@@ -22,9 +25,27 @@ import (
 
 const (
 	// password field names
-	loginNameField = "login_name"
-	passwordField  = "password"
-	loginCommand   = "login"
+	loginNameField    = "login_name"
+	passwordField     = "password"
+	loginCommand      = "login"
+	totpCommand       = "totp"
+	tokenIdField      = "attributes.token_id"
+	recoveryCodeField = "attributes.recovery_code"
+	newPasswordField  = "attributes.new_password"
+
+	minPasswordClassesField   = "attributes.min_password_classes"
+	passwordHistoryCountField = "attributes.password_history_count"
+
+	// maxPasswordClasses is the number of character classes: lowercase,
+	// uppercase, digits and symbols.
+	maxPasswordClasses = 4
+	// maxPasswordHistoryCount bounds the previous passwords checked when a
+	// password is set, since each check derives a key.
+	maxPasswordHistoryCount = 24
+
+	// pendingTotpTimeout is how long after the login command a pending token
+	// can be issued with the totp command.
+	pendingTotpTimeout = 5 * time.Minute
 )
 
 var pwMaskManager handlers.MaskManager
@@ -91,56 +112,221 @@ func (s Service) updatePwInRepo(ctx context.Context, scopeId, id string, mask []
 }
 
 func (s Service) authenticatePassword(ctx context.Context, req *pbs.AuthenticateRequest, authResults *auth.VerifyResults) (*pbs.AuthenticateResponse, error) {
-	reqAttrs := req.GetPasswordLoginAttributes()
-	tok, err := s.authenticateWithPwRepo(ctx, authResults.Scope.GetId(), req.GetAuthMethodId(), reqAttrs.LoginName, reqAttrs.Password)
-	if err != nil {
-		return nil, err
+	var tok *pba.AuthToken
+	switch req.GetCommand() {
+	case totpCommand:
+		reqAttrs := req.GetPasswordTotpAttributes()
+		var err error
+		tok, err = s.authenticateTotpWithPwRepo(ctx, authResults.Scope.GetId(), req.GetAuthMethodId(), reqAttrs.GetTokenId(), reqAttrs.GetCode(), reqAttrs.GetRecoveryCode())
+		if err != nil {
+			return nil, err
+		}
+	default:
+		reqAttrs := req.GetPasswordLoginAttributes()
+		var pendingTokenId string
+		var err error
+		tok, pendingTokenId, err = s.authenticateWithPwRepo(ctx, authResults.Scope.GetId(), req.GetAuthMethodId(), reqAttrs.LoginName, reqAttrs.Password, reqAttrs.GetNewPassword())
+		if err != nil {
+			return nil, err
+		}
+		if pendingTokenId != "" {
+			return &pbs.AuthenticateResponse{
+				Command: req.GetCommand(),
+				Attrs: &pbs.AuthenticateResponse_PasswordTotpRequiredResponse{
+					PasswordTotpRequiredResponse: &pbs.PasswordTotpRequiredResponse{
+						TokenId: pendingTokenId,
+					},
+				},
+				Type: req.GetType(),
+			}, nil
+		}
 	}
 	return s.convertToAuthenticateResponse(ctx, req, authResults, tok)
 }
 
-func (s Service) authenticateWithPwRepo(ctx context.Context, scopeId, authMethodId, loginName, pw string) (*pba.AuthToken, error) {
+// authenticateWithPwRepo authenticates loginName with its password. When the
+// account has a TOTP secret, a pending token is created instead of issuing
+// one and its id is returned; the token is issued by the totp command. When
+// the auth method requires a second factor and the account has none, the
+// returned token only allows the account to enroll one with enroll-totp and
+// confirm-totp.
+//
+// An expired password is changed to newPw before authenticating, and the
+// authentication fails if newPw is not provided.
+func (s Service) authenticateWithPwRepo(ctx context.Context, scopeId, authMethodId, loginName, pw, newPw string) (*pba.AuthToken, string, error) {
 	iamRepo, err := s.iamRepoFn()
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 	atRepo, err := s.atRepoFn()
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 	pwRepo, err := s.pwRepoFn()
 	if err != nil {
-		return nil, err
+		return nil, "", err
+	}
+
+	am, err := s.checkPwRateLimit(ctx, pwRepo, authMethodId)
+	if err != nil {
+		return nil, "", err
 	}
 
 	acct, err := pwRepo.Authenticate(ctx, scopeId, authMethodId, loginName, pw)
+	if err != nil && errors.Match(errors.T(errors.PasswordExpired), err) {
+		if newPw == "" {
+			return nil, "", handlers.ApiErrorWithCodeAndMessage(codes.FailedPrecondition, "The password has expired and must be changed; provide a new password.")
+		}
+		changed, cerr := pwRepo.ChangeExpiredPassword(ctx, scopeId, authMethodId, loginName, pw, newPw)
+		if cerr != nil {
+			if perr := passwordPolicyError(cerr, newPasswordField); perr != nil {
+				return nil, "", perr
+			}
+			if errors.Match(errors.T(errors.PasswordsEqual), cerr) {
+				return nil, "", handlers.InvalidArgumentErrorf("Error in provided request.",
+					map[string]string{newPasswordField: "New password equal to current password."})
+			}
+			return nil, "", cerr
+		}
+		if changed == nil {
+			return nil, "", handlers.ApiErrorWithCodeAndMessage(codes.Unauthenticated, "Unable to authenticate.")
+		}
+		acct, err = pwRepo.Authenticate(ctx, scopeId, authMethodId, loginName, newPw)
+	}
 	if err != nil {
-		return nil, err
+		if errors.Match(errors.T(errors.PasswordAccountLocked), err) {
+			// a locked account is reported like a wrong password so locking
+			// doesn't disclose which login names exist
+			return nil, "", handlers.ApiErrorWithCodeAndMessage(codes.Unauthenticated, "Unable to authenticate.")
+		}
+		return nil, "", err
 	}
 	if acct == nil {
-		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Unauthenticated, "Unable to authenticate.")
+		return nil, "", handlers.ApiErrorWithCodeAndMessage(codes.Unauthenticated, "Unable to authenticate.")
 	}
 
 	u, err := iamRepo.LookupUserWithLogin(ctx, acct.GetPublicId())
 	if err != nil {
+		return nil, "", err
+	}
+	var tokOpts []authtoken.Option
+	switch {
+	case acct.GetTotpEnrolled():
+		tok, err := atRepo.CreateAuthToken(ctx, u, acct.GetPublicId(), authtoken.WithStatus(authtoken.PendingStatus))
+		if err != nil {
+			return nil, "", err
+		}
+		return nil, tok.GetPublicId(), nil
+	case am.GetMfaRequired():
+		// the token only allows the account to enroll and confirm a second
+		// factor, and is issued once it is confirmed
+		tokOpts = append(tokOpts, authtoken.WithStatus(authtoken.TotpEnrollmentStatus))
+	}
+	tok, err := atRepo.CreateAuthToken(ctx, u, acct.GetPublicId(), tokOpts...)
+	if err != nil {
+		return nil, "", err
+	}
+
+	apiTok, err := s.ConvertInternalAuthTokenToApiAuthToken(
+		ctx,
+		tok,
+	)
+	return apiTok, "", err
+}
+
+// authenticateTotpWithPwRepo issues the pending token tokenId created by the
+// login command if code, or recoveryCode, is a valid second factor for its
+// account. The pending token is deleted if it is not, so the login command
+// must be repeated.
+func (s Service) authenticateTotpWithPwRepo(ctx context.Context, scopeId, authMethodId, tokenId, code, recoveryCode string) (*pba.AuthToken, error) {
+	atRepo, err := s.atRepoFn()
+	if err != nil {
+		return nil, err
+	}
+	pwRepo, err := s.pwRepoFn()
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.checkPwRateLimit(ctx, pwRepo, authMethodId); err != nil {
 		return nil, err
 	}
-	tok, err := atRepo.CreateAuthToken(ctx, u, acct.GetPublicId())
+
+	pending, err := atRepo.LookupAuthToken(ctx, tokenId)
 	if err != nil {
 		return nil, err
 	}
+	if pending == nil ||
+		pending.GetStatus() != string(authtoken.PendingStatus) ||
+		pending.GetAuthMethodId() != authMethodId {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Unauthenticated, "Unable to authenticate.")
+	}
+	if time.Since(pending.GetCreateTime().AsTime()) > pendingTotpTimeout {
+		if _, err := atRepo.DeleteAuthToken(ctx, tokenId); err != nil {
+			return nil, err
+		}
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Unauthenticated, "Unable to authenticate.")
+	}
 
+	acct, err := pwRepo.VerifyTotp(ctx, scopeId, authMethodId, pending.GetAuthAccountId(), code, recoveryCode)
+	if err != nil && !errors.Match(errors.T(errors.PasswordAccountLocked), err) {
+		return nil, err
+	}
+	if acct == nil {
+		if _, err := atRepo.DeleteAuthToken(ctx, tokenId); err != nil {
+			return nil, err
+		}
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Unauthenticated, "Unable to authenticate.")
+	}
+
+	tok, err := atRepo.IssueAuthToken(ctx, tokenId)
+	if err != nil {
+		return nil, err
+	}
 	return s.ConvertInternalAuthTokenToApiAuthToken(
 		ctx,
 		tok,
 	)
 }
 
+// checkPwRateLimit returns the password auth method authMethodId, or an
+// error if the source ip address of the request exceeded the rate limit of
+// the auth method.
+func (s Service) checkPwRateLimit(ctx context.Context, pwRepo *password.Repository, authMethodId string) (*password.AuthMethod, error) {
+	am, err := pwRepo.LookupAuthMethod(ctx, authMethodId)
+	if err != nil {
+		return nil, err
+	}
+	if am == nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Unauthenticated, "Unable to authenticate.")
+	}
+	if ri, ok := event.RequestInfoFromContext(ctx); ok {
+		if !s.pwAttempts.allow(authMethodId, ri.ClientIp, am.GetSourceIpRateLimit()) {
+			return nil, handlers.ApiErrorWithCodeAndMessage(codes.ResourceExhausted, "Too many authentication attempts.")
+		}
+	}
+	return am, nil
+}
+
 func validateAuthenticatePasswordRequest(req *pbs.AuthenticateRequest) error {
 	badFields := make(map[string]string)
 
 	attrs := req.GetPasswordLoginAttributes()
+	totpAttrs := req.GetPasswordTotpAttributes()
 	switch {
+	case totpAttrs != nil:
+		if req.GetCommand() != totpCommand {
+			badFields[commandField] = "Invalid command for this auth method type."
+		}
+		if totpAttrs.GetTokenId() == "" {
+			badFields[tokenIdField] = "This is a required field."
+		}
+		switch {
+		case totpAttrs.GetCode() == "" && totpAttrs.GetRecoveryCode() == "":
+			badFields[codeField] = "Either a code or a recovery code is required."
+		case totpAttrs.GetCode() != "" && totpAttrs.GetRecoveryCode() != "":
+			badFields[recoveryCodeField] = "Only one of a code or a recovery code may be provided."
+		}
 	case attrs == nil:
 		badFields["attributes"] = "This is a required field."
 	default:
@@ -174,6 +360,40 @@ func validateAuthenticatePasswordRequest(req *pbs.AuthenticateRequest) error {
 	return nil
 }
 
+// validatePwAuthMethodAttributes adds the invalid password auth method
+// attributes to badFields.
+func validatePwAuthMethodAttributes(attrs *pb.PasswordAuthMethodAttributes, badFields map[string]string) {
+	if attrs == nil {
+		return
+	}
+	if attrs.GetMinPasswordClasses() > maxPasswordClasses {
+		badFields[minPasswordClassesField] = fmt.Sprintf("Must be at most %d.", maxPasswordClasses)
+	}
+	if attrs.GetPasswordHistoryCount() > maxPasswordHistoryCount {
+		badFields[passwordHistoryCountField] = fmt.Sprintf("Must be at most %d.", maxPasswordHistoryCount)
+	}
+}
+
+// passwordPolicyError returns an invalid argument error for field if err
+// is from a password which does not satisfy the password policy of its auth
+// method, and nil otherwise.
+func passwordPolicyError(err error, field string) error {
+	var msg string
+	switch {
+	case errors.Match(errors.T(errors.PasswordTooShort), err):
+		msg = "Password is too short."
+	case errors.Match(errors.T(errors.PasswordTooWeak), err):
+		msg = "Password does not contain enough of lowercase letters, uppercase letters, digits and symbols."
+	case errors.Match(errors.T(errors.PasswordDenied), err):
+		msg = "Password is too common."
+	case errors.Match(errors.T(errors.PasswordReused), err):
+		msg = "Password was used before."
+	default:
+		return nil
+	}
+	return handlers.InvalidArgumentErrorf("Error in provided request.", map[string]string{field: msg})
+}
+
 func toStoragePwAuthMethod(scopeId string, item *pb.AuthMethod) (*password.AuthMethod, error) {
 	const op = "authmethod_service.toStoragePwAuthMethod"
 	if item == nil {
@@ -198,5 +418,13 @@ func toStoragePwAuthMethod(scopeId string, item *pb.AuthMethod) (*password.AuthM
 	if pwAttrs.GetMinPasswordLength() != 0 {
 		u.MinPasswordLength = pwAttrs.GetMinPasswordLength()
 	}
+	u.MaxFailedAttempts = pwAttrs.GetMaxFailedAttempts()
+	u.LockoutDuration = pwAttrs.GetLockoutDuration()
+	u.SourceIpRateLimit = pwAttrs.GetSourceIpRateLimit()
+	u.MfaRequired = pwAttrs.GetMfaRequired()
+	u.MinPasswordClasses = pwAttrs.GetMinPasswordClasses()
+	u.DenyCommonPasswords = pwAttrs.GetDenyCommonPasswords()
+	u.PasswordHistoryCount = pwAttrs.GetPasswordHistoryCount()
+	u.MaxPasswordAge = pwAttrs.GetMaxPasswordAge()
 	return u, nil
 }
@@ -0,0 +1,75 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package authmethods
+
+import (
+	"sync"
+	"time"
+)
+
+// rateLimitWindow is the window over which the source ip rate limit of a
+// password auth method is counted.
+const rateLimitWindow = time.Minute
+
+// attemptLimiter counts the authentication attempts made against an auth
+// method from a source ip address over fixed one minute windows. The counts
+// are kept in memory, so the limit applies per controller.
+type attemptLimiter struct {
+	mu        sync.Mutex
+	attempts  map[attemptKey]*attemptWindow
+	lastEvict time.Time
+	now       func() time.Time
+}
+
+type attemptKey struct {
+	authMethodId string
+	sourceIp     string
+}
+
+type attemptWindow struct {
+	start time.Time
+	count uint32
+}
+
+func newAttemptLimiter() *attemptLimiter {
+	return &attemptLimiter{
+		attempts: make(map[attemptKey]*attemptWindow),
+		now:      time.Now,
+	}
+}
+
+// allow records an authentication attempt against authMethodId from sourceIp
+// and reports whether it is within limit attempts for the current window. A
+// limit of 0 allows all attempts.
+func (l *attemptLimiter) allow(authMethodId, sourceIp string, limit uint32) bool {
+	if l == nil || limit == 0 {
+		return true
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+	l.evict(now)
+	k := attemptKey{authMethodId: authMethodId, sourceIp: sourceIp}
+	w, ok := l.attempts[k]
+	if !ok || now.Sub(w.start) >= rateLimitWindow {
+		w = &attemptWindow{start: now}
+		l.attempts[k] = w
+	}
+	w.count++
+	return w.count <= limit
+}
+
+// evict removes the windows which have ended, at most once per window. It
+// must be called with mu held.
+func (l *attemptLimiter) evict(now time.Time) {
+	if now.Sub(l.lastEvict) < rateLimitWindow {
+		return
+	}
+	l.lastEvict = now
+	for k, w := range l.attempts {
+		if now.Sub(w.start) >= rateLimitWindow {
+			delete(l.attempts, k)
+		}
+	}
+}
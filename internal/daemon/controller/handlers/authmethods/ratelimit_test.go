@@ -0,0 +1,38 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package authmethods
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAttemptLimiter(t *testing.T) {
+	now := time.Now()
+	l := newAttemptLimiter()
+	l.now = func() time.Time { return now }
+
+	// a limit of 0 allows all attempts
+	for i := 0; i < 5; i++ {
+		assert.True(t, l.allow("ampw_1", "127.0.0.1", 0))
+	}
+
+	assert.True(t, l.allow("ampw_1", "127.0.0.1", 2))
+	assert.True(t, l.allow("ampw_1", "127.0.0.1", 2))
+	assert.False(t, l.allow("ampw_1", "127.0.0.1", 2))
+
+	// other source ips and auth methods are counted apart
+	assert.True(t, l.allow("ampw_1", "127.0.0.2", 2))
+	assert.True(t, l.allow("ampw_2", "127.0.0.1", 2))
+
+	// the count starts over with the next window
+	now = now.Add(rateLimitWindow)
+	assert.True(t, l.allow("ampw_1", "127.0.0.1", 2))
+	assert.Len(t, l.attempts, 1)
+
+	var nilLimiter *attemptLimiter
+	assert.True(t, nilLimiter.allow("ampw_1", "127.0.0.1", 1))
+}
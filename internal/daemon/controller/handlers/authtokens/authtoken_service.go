@@ -6,15 +6,23 @@ package authtokens
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/hashicorp/boundary/globals"
+	"github.com/hashicorp/boundary/internal/auth/jwt"
+	"github.com/hashicorp/boundary/internal/auth/ldap"
+	"github.com/hashicorp/boundary/internal/auth/oidc"
 	"github.com/hashicorp/boundary/internal/authtoken"
 	"github.com/hashicorp/boundary/internal/daemon/controller/auth"
 	"github.com/hashicorp/boundary/internal/daemon/controller/common"
 	"github.com/hashicorp/boundary/internal/daemon/controller/common/scopeids"
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers"
+	"github.com/hashicorp/boundary/internal/db"
 	"github.com/hashicorp/boundary/internal/errors"
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/intglobals"
+	"github.com/hashicorp/boundary/internal/pagination"
 	"github.com/hashicorp/boundary/internal/perms"
 	"github.com/hashicorp/boundary/internal/requests"
 	"github.com/hashicorp/boundary/internal/types/action"
@@ -33,6 +41,8 @@ var (
 		action.ReadSelf,
 		action.Delete,
 		action.DeleteSelf,
+		action.Refresh,
+		action.RefreshSelf,
 	}
 
 	// CollectionActions contains the set of actions that can be performed on
@@ -93,32 +103,42 @@ func (s Service) ListAuthTokens(ctx context.Context, req *pbs.ListAuthTokensRequ
 		return &pbs.ListAuthTokensResponse{}, nil
 	}
 
-	ul, err := s.listFromRepo(ctx, scopeIds)
+	repo, err := s.repoFn()
 	if err != nil {
 		return nil, err
 	}
-	if len(ul) == 0 {
-		return &pbs.ListAuthTokensResponse{}, nil
-	}
 
 	filter, err := handlers.NewFilter(req.GetFilter())
 	if err != nil {
 		return nil, err
 	}
-	finalItems := make([]*pb.AuthToken, 0, len(ul))
-	res := perms.Resource{
-		Type: resource.AuthToken,
+
+	listOpts := []authtoken.Option{
+		authtoken.WithIamUserId(req.GetUserId()),
+		authtoken.WithAuthAccountId(req.GetAccountId()),
+	}
+	if req.GetApproximateLastUsedBefore() != nil {
+		listOpts = append(listOpts, authtoken.WithLastAccessedBefore(req.GetApproximateLastUsedBefore().AsTime()))
+	}
+	if req.GetApproximateLastUsedAfter() != nil {
+		listOpts = append(listOpts, authtoken.WithLastAccessedAfter(req.GetApproximateLastUsedAfter().AsTime()))
 	}
-	for _, at := range ul {
-		res.Id = at.GetPublicId()
-		res.ScopeId = at.GetScopeId()
+	listItemsFn := func(ctx context.Context, page *db.Page, limit int) ([]*authtoken.AuthToken, error) {
+		return repo.ListAuthTokens(ctx, scopeIds, append(listOpts, authtoken.WithPage(page), authtoken.WithLimit(limit))...)
+	}
+	convertItemFn := func(ctx context.Context, at *authtoken.AuthToken) (*pb.AuthToken, bool, error) {
+		res := perms.Resource{
+			Id:      at.GetPublicId(),
+			ScopeId: at.GetScopeId(),
+			Type:    resource.AuthToken,
+		}
 		authorizedActions := authResults.FetchActionSetForId(ctx, at.GetPublicId(), IdActions, auth.WithResource(&res))
 		if len(authorizedActions) == 0 {
-			continue
+			return nil, false, nil
 		}
 
 		if authorizedActions.OnlySelf() && at.GetIamUserId() != authResults.UserId {
-			continue
+			return nil, false, nil
 		}
 
 		outputFields := authResults.FetchOutputFields(res, action.List).SelfOrDefaults(authResults.UserId)
@@ -133,15 +153,39 @@ func (s Service) ListAuthTokens(ctx context.Context, req *pbs.ListAuthTokensRequ
 
 		item, err := toProto(ctx, at, outputOpts...)
 		if err != nil {
-			return nil, err
+			return nil, false, err
 		}
+		return item, filter.Match(item), nil
+	}
 
-		if filter.Match(item) {
-			finalItems = append(finalItems, item)
+	listDeletedIdsFn := func(ctx context.Context, since time.Time) ([]string, error) {
+		deleted, err := repo.ListDeletedIds(ctx, scopeIds, since)
+		if err != nil {
+			return nil, err
 		}
+		return authResults.AuthorizedDeletedIds(ctx, perms.Resource{Type: resource.AuthToken}, IdActions, deleted), nil
+	}
+
+	listReq := pagination.Request{
+		ResourceType: resource.AuthToken,
+		GrantsHash:   authResults.GrantsHash(),
+		PageSize:     req.GetPageSize(),
+		ListToken:    req.GetListToken(),
+	}
+	page, err := pagination.List(ctx, listReq, listItemsFn, convertItemFn, listDeletedIdsFn)
+	if err != nil {
+		return nil, err
 	}
 
-	return &pbs.ListAuthTokensResponse{Items: finalItems}, nil
+	resp := &pbs.ListAuthTokensResponse{Items: page.Items}
+	if listReq.Paginated() {
+		resp.ResponseType = page.ResponseType()
+		resp.ListToken = page.ListToken
+		resp.SortBy = page.SortBy
+		resp.SortDir = pagination.SortDirAscending
+		resp.RemovedIds = page.RemovedIds
+	}
+	return resp, nil
 }
 
 // GetAuthToken implements the interface pbs.AuthTokenServiceServer.
@@ -229,6 +273,66 @@ func (s Service) DeleteAuthToken(ctx context.Context, req *pbs.DeleteAuthTokenRe
 	return nil, nil
 }
 
+// RefreshAuthToken implements the interface pbs.AuthTokenServiceServer.
+func (s Service) RefreshAuthToken(ctx context.Context, req *pbs.RefreshAuthTokenRequest) (*pbs.RefreshAuthTokenResponse, error) {
+	const op = "authtokens.(Service).RefreshAuthToken"
+
+	if err := validateRefreshRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.RefreshSelf)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+
+	at, err := s.getFromRepo(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	var outputFields *perms.OutputFields
+	authorizedActions := authResults.FetchActionSetForId(ctx, at.GetPublicId(), IdActions)
+
+	// Check to see if we need to verify Refresh vs. just RefreshSelf
+	if at.GetIamUserId() != authResults.UserId {
+		if !authorizedActions.HasAction(action.Refresh) {
+			return nil, handlers.ForbiddenError()
+		}
+		outputFields = authResults.FetchOutputFields(perms.Resource{
+			Id:      at.GetPublicId(),
+			ScopeId: at.GetScopeId(),
+			Type:    resource.AuthToken,
+		}, action.Refresh).SelfOrDefaults(authResults.UserId)
+	} else {
+		var ok bool
+		outputFields, ok = requests.OutputFields(ctx)
+		if !ok {
+			return nil, errors.New(ctx, errors.Internal, op, "no request context found")
+		}
+	}
+
+	at, err = s.refreshInRepo(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	outputOpts := make([]handlers.Option, 0, 3)
+	outputOpts = append(outputOpts, handlers.WithOutputFields(outputFields))
+	if outputFields.Has(globals.ScopeField) {
+		outputOpts = append(outputOpts, handlers.WithScope(authResults.Scope))
+	}
+	if outputFields.Has(globals.AuthorizedActionsField) {
+		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authorizedActions.Strings()))
+	}
+
+	item, err := toProto(ctx, at, outputOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pbs.RefreshAuthTokenResponse{Item: item}, nil
+}
+
 func (s Service) getFromRepo(ctx context.Context, id string) (*authtoken.AuthToken, error) {
 	const op = "authtokens.(Service).getFromRepo"
 	repo, err := s.repoFn()
@@ -261,17 +365,17 @@ func (s Service) deleteFromRepo(ctx context.Context, id string) (bool, error) {
 	return rows > 0, nil
 }
 
-func (s Service) listFromRepo(ctx context.Context, scopeIds []string) ([]*authtoken.AuthToken, error) {
+func (s Service) refreshInRepo(ctx context.Context, id string) (*authtoken.AuthToken, error) {
+	const op = "authtokens.(Service).refreshInRepo"
 	repo, err := s.repoFn()
-	_ = repo
 	if err != nil {
-		return nil, err
+		return nil, errors.Wrap(ctx, err, op)
 	}
-	ul, err := repo.ListAuthTokens(ctx, scopeIds)
+	at, err := repo.RefreshAuthToken(ctx, id)
 	if err != nil {
-		return nil, err
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to refresh auth token"))
 	}
-	return ul, nil
+	return at, nil
 }
 
 func (s Service) authResult(ctx context.Context, id string, a action.Type) auth.VerifyResults {
@@ -376,12 +480,39 @@ func validateDeleteRequest(req *pbs.DeleteAuthTokenRequest) error {
 	return handlers.ValidateDeleteRequest(handlers.NoopValidatorFn, req, authtoken.AuthTokenPrefix)
 }
 
+func validateRefreshRequest(req *pbs.RefreshAuthTokenRequest) error {
+	badFields := map[string]string{}
+	if !handlers.ValidId(handlers.Id(req.GetId()), authtoken.AuthTokenPrefix) {
+		badFields["id"] = "Incorrectly formatted identifier."
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Errors in provided fields.", badFields)
+	}
+	return nil
+}
+
 func validateListRequest(req *pbs.ListAuthTokensRequest) error {
 	badFields := map[string]string{}
 	if !handlers.ValidId(handlers.Id(req.GetScopeId()), scope.Org.Prefix()) &&
 		req.GetScopeId() != scope.Global.String() {
 		badFields["scope_id"] = "This field must be 'global' or a valid org scope id."
 	}
+	if req.GetUserId() != "" && !handlers.ValidId(handlers.Id(req.GetUserId()), iam.UserPrefix) {
+		badFields[globals.UserIdField] = "Incorrectly formatted identifier."
+	}
+	if req.GetAccountId() != "" && !handlers.ValidId(handlers.Id(req.GetAccountId()),
+		intglobals.OldPasswordAccountPrefix,
+		intglobals.NewPasswordAccountPrefix,
+		oidc.AccountPrefix,
+		ldap.AccountPrefix,
+		jwt.AccountPrefix,
+	) {
+		badFields[globals.AccountIdField] = "Incorrectly formatted identifier."
+	}
+	if req.GetApproximateLastUsedBefore() != nil && req.GetApproximateLastUsedAfter() != nil &&
+		!req.GetApproximateLastUsedAfter().AsTime().Before(req.GetApproximateLastUsedBefore().AsTime()) {
+		badFields["approximate_last_used_after"] = "This field must be before approximate_last_used_before."
+	}
 	if _, err := handlers.NewFilter(req.GetFilter()); err != nil {
 		badFields["filter"] = fmt.Sprintf("This field could not be parsed. %v", err)
 	}
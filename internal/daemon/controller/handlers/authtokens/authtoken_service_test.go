@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/hashicorp/boundary/globals"
@@ -21,19 +22,22 @@ import (
 	authpb "github.com/hashicorp/boundary/internal/gen/controller/auth"
 	"github.com/hashicorp/boundary/internal/iam"
 	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/pagination"
 	"github.com/hashicorp/boundary/internal/requests"
 	"github.com/hashicorp/boundary/internal/server"
+	"github.com/hashicorp/boundary/internal/types/resource"
 	"github.com/hashicorp/boundary/internal/types/scope"
 	pb "github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/authtokens"
 	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/scopes"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
-var testAuthorizedActions = []string{"no-op", "read", "read:self", "delete", "delete:self"}
+var testAuthorizedActions = []string{"no-op", "read", "read:self", "delete", "delete:self", "refresh", "refresh:self"}
 
 func TestGetSelf(t *testing.T) {
 	conn, _ := db.TestSetup(t, "postgres")
@@ -384,6 +388,45 @@ func TestList(t *testing.T) {
 			req:  &pbs.ListAuthTokensRequest{ScopeId: orgWithSomeTokens.GetPublicId(), Filter: `"//id/"=="bad"`},
 			err:  handlers.InvalidArgumentErrorf("bad format", nil),
 		},
+		{
+			name: "User Id",
+			req:  &pbs.ListAuthTokensRequest{ScopeId: "global", Recursive: true, UserId: wantSomeTokens[0].GetUserId()},
+			res:  &pbs.ListAuthTokensResponse{Items: wantSomeTokens[:1]},
+		},
+		{
+			name: "Account Id",
+			req:  &pbs.ListAuthTokensRequest{ScopeId: "global", Recursive: true, AccountId: wantOtherTokens[0].GetAccountId()},
+			res:  &pbs.ListAuthTokensResponse{Items: wantOtherTokens[:1]},
+		},
+		{
+			name: "Last Used Before",
+			req:  &pbs.ListAuthTokensRequest{ScopeId: "global", Recursive: true, ApproximateLastUsedBefore: timestamppb.New(time.Now().Add(-time.Hour))},
+			res:  &pbs.ListAuthTokensResponse{},
+		},
+		{
+			name: "Last Used After",
+			req:  &pbs.ListAuthTokensRequest{ScopeId: "global", Recursive: true, ApproximateLastUsedAfter: timestamppb.New(time.Now().Add(-time.Hour))},
+			res:  &pbs.ListAuthTokensResponse{Items: allTokens},
+		},
+		{
+			name: "Bad User Id",
+			req:  &pbs.ListAuthTokensRequest{ScopeId: "global", UserId: "bad id"},
+			err:  handlers.InvalidArgumentErrorf("bad user id", nil),
+		},
+		{
+			name: "Bad Account Id",
+			req:  &pbs.ListAuthTokensRequest{ScopeId: "global", AccountId: "bad id"},
+			err:  handlers.InvalidArgumentErrorf("bad account id", nil),
+		},
+		{
+			name: "Empty Last Used Range",
+			req: &pbs.ListAuthTokensRequest{
+				ScopeId:                   "global",
+				ApproximateLastUsedBefore: timestamppb.New(time.Now().Add(-time.Hour)),
+				ApproximateLastUsedAfter:  timestamppb.New(time.Now()),
+			},
+			err: handlers.InvalidArgumentErrorf("empty range", nil),
+		},
 	}
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -416,6 +459,50 @@ func TestList(t *testing.T) {
 	}
 }
 
+func TestList_Pagination(t *testing.T) {
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrap := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrap)
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iam.TestRepo(t, conn, wrap), nil
+	}
+	repoFn := func() (*authtoken.Repository, error) {
+		return authtoken.NewRepository(rw, rw, kms)
+	}
+	s, err := authtokens.NewService(repoFn, iamRepoFn)
+	require.NoError(t, err)
+
+	o, _ := iam.TestScopes(t, iam.TestRepo(t, conn, wrap))
+	// Paginated listings are oldest first
+	var want []string
+	for i := 0; i < 5; i++ {
+		at := authtoken.TestAuthToken(t, conn, kms, o.GetPublicId())
+		want = append(want, at.GetPublicId())
+	}
+	pagination.TestList(t,
+		auth.DisabledAuthTestContext(iamRepoFn, o.GetPublicId()),
+		auth.DisabledAuthTestContext(iamRepoFn, o.GetPublicId(), auth.WithUserId(globals.AnonymousUserId)),
+		resource.Session,
+		want,
+		func(ctx context.Context, listToken string, pageSize uint32) (pagination.TestListResponse, []string, error) {
+			got, err := s.ListAuthTokens(ctx, &pbs.ListAuthTokensRequest{ScopeId: o.GetPublicId(), ListToken: listToken, PageSize: pageSize})
+			var ids []string
+			for _, item := range got.GetItems() {
+				ids = append(ids, item.GetId())
+			}
+			return got, ids, err
+		},
+		func(t *testing.T, id string) {
+			repo, err := repoFn()
+			require.NoError(t, err)
+			_, err = repo.DeleteAuthToken(ctx, id)
+			require.NoError(t, err)
+		},
+	)
+}
+
 func TestDeleteSelf(t *testing.T) {
 	conn, _ := db.TestSetup(t, "postgres")
 	rw := db.New(conn)
@@ -507,6 +594,87 @@ func TestDeleteSelf(t *testing.T) {
 	}
 }
 
+func TestRefreshSelf(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrap := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrap)
+
+	iamRepo := iam.TestRepo(t, conn, wrap)
+
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	tokenRepoFn := func() (*authtoken.Repository, error) {
+		return authtoken.NewRepository(rw, rw, kms, authtoken.WithTokenTimeToLiveDuration(8*24*time.Hour))
+	}
+	serversRepoFn := func() (*server.Repository, error) {
+		return server.NewRepository(rw, rw, kms)
+	}
+
+	a, err := authtokens.NewService(tokenRepoFn, iamRepoFn)
+	require.NoError(t, err, "Couldn't create new auth token service.")
+
+	o, _ := iam.TestScopes(t, iam.TestRepo(t, conn, wrap))
+	at1 := authtoken.TestAuthToken(t, conn, kms, o.GetPublicId())
+	at2 := authtoken.TestAuthToken(t, conn, kms, o.GetPublicId())
+
+	cases := []struct {
+		name      string
+		token     *authtoken.AuthToken
+		refreshId string
+		err       error
+	}{
+		{
+			name:      "at1 refresh at2",
+			token:     at1,
+			refreshId: at2.GetPublicId(),
+			err:       handlers.ApiErrorWithCodeAndMessage(codes.PermissionDenied, "Forbidden."),
+		},
+		{
+			name:      "at2 refresh at1",
+			token:     at2,
+			refreshId: at1.GetPublicId(),
+			err:       handlers.ApiErrorWithCodeAndMessage(codes.PermissionDenied, "Forbidden."),
+		},
+		{
+			name:      "at1 refresh self",
+			token:     at1,
+			refreshId: at1.GetPublicId(),
+		},
+		{
+			name:      "at2 refresh self",
+			token:     at2,
+			refreshId: at2.GetPublicId(),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			// Setup the auth request information
+			req := httptest.NewRequest("POST", fmt.Sprintf("http://127.0.0.1/v1/auth-tokens/%s:refresh", tc.refreshId), nil)
+			requestInfo := authpb.RequestInfo{
+				Path:        req.URL.Path,
+				Method:      req.Method,
+				TokenFormat: uint32(auth.AuthTokenTypeBearer),
+				PublicId:    tc.token.GetPublicId(),
+				Token:       tc.token.GetToken(),
+			}
+
+			ctx := auth.NewVerifierContext(context.Background(), iamRepoFn, tokenRepoFn, serversRepoFn, kms, &requestInfo)
+			got, err := a.RefreshAuthToken(ctx, &pbs.RefreshAuthTokenRequest{Id: tc.refreshId})
+			if tc.err != nil {
+				require.EqualError(err, tc.err.Error())
+				require.Nil(got)
+				return
+			}
+			require.NoError(err)
+			assert.Equal(tc.refreshId, got.GetItem().GetId())
+			assert.True(got.GetItem().GetExpirationTime().AsTime().After(tc.token.GetExpirationTime().AsTime()))
+		})
+	}
+}
+
 func TestDelete(t *testing.T) {
 	conn, _ := db.TestSetup(t, "postgres")
 	rw := db.New(conn)
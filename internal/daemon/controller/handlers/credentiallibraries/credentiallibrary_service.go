@@ -8,6 +8,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/boundary/globals"
 	"github.com/hashicorp/boundary/internal/credential"
@@ -16,8 +17,10 @@ import (
 	"github.com/hashicorp/boundary/internal/daemon/controller/auth"
 	"github.com/hashicorp/boundary/internal/daemon/controller/common"
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers"
+	"github.com/hashicorp/boundary/internal/db"
 	"github.com/hashicorp/boundary/internal/errors"
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
+	"github.com/hashicorp/boundary/internal/pagination"
 	"github.com/hashicorp/boundary/internal/perms"
 	"github.com/hashicorp/boundary/internal/requests"
 	"github.com/hashicorp/boundary/internal/types/action"
@@ -131,29 +134,29 @@ func (s Service) ListCredentialLibraries(ctx context.Context, req *pbs.ListCrede
 		return nil, authResults.Error
 	}
 
-	csl, err := s.listFromRepo(ctx, req.GetCredentialStoreId())
+	repo, err := s.repoFn()
 	if err != nil {
 		return nil, err
 	}
-	if len(csl) == 0 {
-		return &pbs.ListCredentialLibrariesResponse{}, nil
-	}
 
 	filter, err := handlers.NewFilter(req.GetFilter())
 	if err != nil {
 		return nil, err
 	}
-	finalItems := make([]*pb.CredentialLibrary, 0, len(csl))
-	res := perms.Resource{
-		ScopeId: authResults.Scope.Id,
-		Type:    resource.CredentialLibrary,
-		Pin:     req.GetCredentialStoreId(),
+
+	listItemsFn := func(ctx context.Context, page *db.Page, limit int) ([]credential.Library, error) {
+		return s.listFromRepo(ctx, req.GetCredentialStoreId(), page, limit)
 	}
-	for _, item := range csl {
-		res.Id = item.GetPublicId()
+	convertItemFn := func(ctx context.Context, item credential.Library) (*pb.CredentialLibrary, bool, error) {
+		res := perms.Resource{
+			Id:      item.GetPublicId(),
+			ScopeId: authResults.Scope.Id,
+			Type:    resource.CredentialLibrary,
+			Pin:     req.GetCredentialStoreId(),
+		}
 		authorizedActions := authResults.FetchActionSetForId(ctx, item.GetPublicId(), IdActions, auth.WithResource(&res)).Strings()
 		if len(authorizedActions) == 0 {
-			continue
+			return nil, false, nil
 		}
 
 		outputFields := authResults.FetchOutputFields(res, action.List).SelfOrDefaults(authResults.UserId)
@@ -166,20 +169,45 @@ func (s Service) ListCredentialLibraries(ctx context.Context, req *pbs.ListCrede
 			outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authorizedActions))
 		}
 
-		item, err := toProto(item, outputOpts...)
+		pbItem, err := toProto(item, outputOpts...)
 		if err != nil {
-			return nil, err
+			return nil, false, err
 		}
 
-		filterable, err := subtypes.Filterable(item)
+		filterable, err := subtypes.Filterable(pbItem)
 		if err != nil {
-			return nil, err
+			return nil, false, err
 		}
-		if filter.Match(filterable) {
-			finalItems = append(finalItems, item)
+		return pbItem, filter.Match(filterable), nil
+	}
+
+	listDeletedIdsFn := func(ctx context.Context, since time.Time) ([]string, error) {
+		deleted, err := repo.ListDeletedCredentialLibraryIds(ctx, req.GetCredentialStoreId(), since)
+		if err != nil {
+			return nil, err
 		}
+		return authResults.AuthorizedDeletedIds(ctx, perms.Resource{ScopeId: authResults.Scope.Id, Type: resource.CredentialLibrary, Pin: req.GetCredentialStoreId()}, IdActions, deleted), nil
+	}
+	listReq := pagination.Request{
+		ResourceType: resource.CredentialLibrary,
+		GrantsHash:   authResults.GrantsHash(),
+		PageSize:     req.GetPageSize(),
+		ListToken:    req.GetListToken(),
+	}
+	page, err := pagination.List(ctx, listReq, listItemsFn, convertItemFn, listDeletedIdsFn)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &pbs.ListCredentialLibrariesResponse{Items: page.Items}
+	if listReq.Paginated() {
+		resp.ResponseType = page.ResponseType()
+		resp.ListToken = page.ListToken
+		resp.SortBy = page.SortBy
+		resp.SortDir = pagination.SortDirAscending
+		resp.RemovedIds = page.RemovedIds
 	}
-	return &pbs.ListCredentialLibrariesResponse{Items: finalItems}, nil
+	return resp, nil
 }
 
 // GetCredentialLibrary implements the interface pbs.CredentialLibraryServiceServer.
@@ -337,17 +365,17 @@ func (s Service) DeleteCredentialLibrary(ctx context.Context, req *pbs.DeleteCre
 	return nil, nil
 }
 
-func (s Service) listFromRepo(ctx context.Context, storeId string) ([]credential.Library, error) {
+func (s Service) listFromRepo(ctx context.Context, storeId string, page *db.Page, limit int) ([]credential.Library, error) {
 	const op = "credentiallibraries.(Service).listFromRepo"
 	repo, err := s.repoFn()
 	if err != nil {
 		return nil, errors.Wrap(ctx, err, op)
 	}
-	genCsl, err := repo.ListCredentialLibraries(ctx, storeId)
+	genCsl, err := repo.ListCredentialLibraries(ctx, storeId, vault.WithPage(page), vault.WithLimit(limit))
 	if err != nil {
 		return nil, errors.Wrap(ctx, err, op)
 	}
-	certCsl, err := repo.ListSSHCertificateCredentialLibraries(ctx, storeId)
+	certCsl, err := repo.ListSSHCertificateCredentialLibraries(ctx, storeId, vault.WithPage(page), vault.WithLimit(limit))
 	if err != nil {
 		return nil, errors.Wrap(ctx, err, op)
 	}
@@ -359,7 +387,7 @@ func (s Service) listFromRepo(ctx context.Context, storeId string) ([]credential
 	for _, s := range certCsl {
 		csl = append(csl, s)
 	}
-	return csl, nil
+	return pagination.MergeItems(csl, page, limit), nil
 }
 
 func (s Service) getFromRepo(ctx context.Context, id string) (credential.Library, error) {
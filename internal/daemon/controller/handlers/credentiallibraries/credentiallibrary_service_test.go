@@ -23,7 +23,9 @@ import (
 	"github.com/hashicorp/boundary/internal/host/static"
 	"github.com/hashicorp/boundary/internal/iam"
 	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/pagination"
 	"github.com/hashicorp/boundary/internal/scheduler"
+	"github.com/hashicorp/boundary/internal/types/resource"
 	"github.com/hashicorp/boundary/internal/types/scope"
 	pb "github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/credentiallibraries"
 	scopepb "github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/scopes"
@@ -284,6 +286,53 @@ func TestList_Attributes(t *testing.T) {
 	}
 }
 
+func TestList_Pagination(t *testing.T) {
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	sche := scheduler.TestScheduler(t, conn, wrapper)
+	rw := db.New(conn)
+
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	repoFn := func() (*vault.Repository, error) {
+		return vault.NewRepository(rw, rw, kms, sche)
+	}
+	s, err := NewService(repoFn, iamRepoFn)
+	require.NoError(t, err)
+
+	_, prj := iam.TestScopes(t, iamRepo)
+	store := vault.TestCredentialStores(t, conn, wrapper, prj.GetPublicId(), 1)[0]
+	// Paginated listings are oldest first
+	var want []string
+	for _, l := range vault.TestCredentialLibraries(t, conn, wrapper, store.GetPublicId(), 5) {
+		want = append(want, l.GetPublicId())
+	}
+	pagination.TestList(t,
+		auth.DisabledAuthTestContext(iamRepoFn, prj.GetPublicId()),
+		auth.DisabledAuthTestContext(iamRepoFn, prj.GetPublicId(), auth.WithUserId(globals.AnonymousUserId)),
+		resource.Credential,
+		want,
+		func(ctx context.Context, listToken string, pageSize uint32) (pagination.TestListResponse, []string, error) {
+			got, err := s.ListCredentialLibraries(ctx, &pbs.ListCredentialLibrariesRequest{CredentialStoreId: store.GetPublicId(), ListToken: listToken, PageSize: pageSize})
+			var ids []string
+			for _, item := range got.GetItems() {
+				ids = append(ids, item.GetId())
+			}
+			return got, ids, err
+		},
+		func(t *testing.T, id string) {
+			repo, err := repoFn()
+			require.NoError(t, err)
+			_, err = repo.DeleteCredentialLibrary(ctx, prj.GetPublicId(), id)
+			require.NoError(t, err)
+		},
+	)
+}
+
 func TestCreate(t *testing.T) {
 	conn, _ := db.TestSetup(t, "postgres")
 	wrapper := db.TestWrapper(t)
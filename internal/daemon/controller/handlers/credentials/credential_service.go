@@ -9,6 +9,7 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/hashicorp/boundary/globals"
 	"github.com/hashicorp/boundary/internal/credential"
@@ -17,8 +18,10 @@ import (
 	"github.com/hashicorp/boundary/internal/daemon/controller/auth"
 	"github.com/hashicorp/boundary/internal/daemon/controller/common"
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers"
+	"github.com/hashicorp/boundary/internal/db"
 	"github.com/hashicorp/boundary/internal/errors"
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
+	"github.com/hashicorp/boundary/internal/pagination"
 	"github.com/hashicorp/boundary/internal/perms"
 	"github.com/hashicorp/boundary/internal/requests"
 	"github.com/hashicorp/boundary/internal/types/action"
@@ -111,29 +114,29 @@ func (s Service) ListCredentials(ctx context.Context, req *pbs.ListCredentialsRe
 		return nil, authResults.Error
 	}
 
-	creds, err := s.listFromRepo(ctx, req.GetCredentialStoreId())
+	repo, err := s.repoFn()
 	if err != nil {
 		return nil, err
 	}
-	if len(creds) == 0 {
-		return &pbs.ListCredentialsResponse{}, nil
-	}
 
 	filter, err := handlers.NewFilter(req.GetFilter())
 	if err != nil {
 		return nil, err
 	}
-	finalItems := make([]*pb.Credential, 0, len(creds))
-	res := perms.Resource{
-		ScopeId: authResults.Scope.Id,
-		Type:    resource.Credential,
-		Pin:     req.GetCredentialStoreId(),
+
+	listItemsFn := func(ctx context.Context, page *db.Page, limit int) ([]credential.Static, error) {
+		return s.listFromRepo(ctx, req.GetCredentialStoreId(), page, limit)
 	}
-	for _, item := range creds {
-		res.Id = item.GetPublicId()
+	convertItemFn := func(ctx context.Context, item credential.Static) (*pb.Credential, bool, error) {
+		res := perms.Resource{
+			Id:      item.GetPublicId(),
+			ScopeId: authResults.Scope.Id,
+			Type:    resource.Credential,
+			Pin:     req.GetCredentialStoreId(),
+		}
 		authorizedActions := authResults.FetchActionSetForId(ctx, item.GetPublicId(), IdActions, auth.WithResource(&res)).Strings()
 		if len(authorizedActions) == 0 {
-			continue
+			return nil, false, nil
 		}
 
 		outputFields := authResults.FetchOutputFields(res, action.List).SelfOrDefaults(authResults.UserId)
@@ -146,20 +149,45 @@ func (s Service) ListCredentials(ctx context.Context, req *pbs.ListCredentialsRe
 			outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authorizedActions))
 		}
 
-		item, err := toProto(item, outputOpts...)
+		pbItem, err := toProto(item, outputOpts...)
 		if err != nil {
-			return nil, err
+			return nil, false, err
 		}
 
-		filterable, err := subtypes.Filterable(item)
+		filterable, err := subtypes.Filterable(pbItem)
 		if err != nil {
-			return nil, err
+			return nil, false, err
 		}
-		if filter.Match(filterable) {
-			finalItems = append(finalItems, item)
+		return pbItem, filter.Match(filterable), nil
+	}
+
+	listDeletedIdsFn := func(ctx context.Context, since time.Time) ([]string, error) {
+		deleted, err := repo.ListDeletedCredentialIds(ctx, req.GetCredentialStoreId(), since)
+		if err != nil {
+			return nil, err
 		}
+		return authResults.AuthorizedDeletedIds(ctx, perms.Resource{ScopeId: authResults.Scope.Id, Type: resource.Credential, Pin: req.GetCredentialStoreId()}, IdActions, deleted), nil
+	}
+	listReq := pagination.Request{
+		ResourceType: resource.Credential,
+		GrantsHash:   authResults.GrantsHash(),
+		PageSize:     req.GetPageSize(),
+		ListToken:    req.GetListToken(),
+	}
+	page, err := pagination.List(ctx, listReq, listItemsFn, convertItemFn, listDeletedIdsFn)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &pbs.ListCredentialsResponse{Items: page.Items}
+	if listReq.Paginated() {
+		resp.ResponseType = page.ResponseType()
+		resp.ListToken = page.ListToken
+		resp.SortBy = page.SortBy
+		resp.SortDir = pagination.SortDirAscending
+		resp.RemovedIds = page.RemovedIds
 	}
-	return &pbs.ListCredentialsResponse{Items: finalItems}, nil
+	return resp, nil
 }
 
 // GetCredential implements the interface pbs.CredentialServiceServer.
@@ -301,13 +329,13 @@ func (s Service) DeleteCredential(ctx context.Context, req *pbs.DeleteCredential
 	return nil, nil
 }
 
-func (s Service) listFromRepo(ctx context.Context, storeId string) ([]credential.Static, error) {
+func (s Service) listFromRepo(ctx context.Context, storeId string, page *db.Page, limit int) ([]credential.Static, error) {
 	const op = "credentials.(Service).listFromRepo"
 	repo, err := s.repoFn()
 	if err != nil {
 		return nil, errors.Wrap(ctx, err, op)
 	}
-	up, err := repo.ListCredentials(ctx, storeId)
+	up, err := repo.ListCredentials(ctx, storeId, static.WithPage(page), static.WithLimit(limit))
 	if err != nil {
 		return nil, errors.Wrap(ctx, err, op)
 	}
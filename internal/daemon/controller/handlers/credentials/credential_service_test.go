@@ -24,7 +24,9 @@ import (
 	"github.com/hashicorp/boundary/internal/iam"
 	"github.com/hashicorp/boundary/internal/kms"
 	"github.com/hashicorp/boundary/internal/libs/crypto"
+	"github.com/hashicorp/boundary/internal/pagination"
 	"github.com/hashicorp/boundary/internal/scheduler"
+	"github.com/hashicorp/boundary/internal/types/resource"
 	"github.com/hashicorp/boundary/internal/types/scope"
 	pb "github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/credentials"
 	scopepb "github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/scopes"
@@ -203,6 +205,53 @@ func TestList(t *testing.T) {
 	}
 }
 
+func TestList_Pagination(t *testing.T) {
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	kkms := kms.TestKms(t, conn, wrapper)
+	rw := db.New(conn)
+
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	staticRepoFn := func() (*static.Repository, error) {
+		return static.NewRepository(ctx, rw, rw, kkms)
+	}
+	s, err := NewService(staticRepoFn, iamRepoFn)
+	require.NoError(t, err)
+
+	_, prj := iam.TestScopes(t, iamRepo)
+	store := static.TestCredentialStore(t, conn, wrapper, prj.GetPublicId())
+	// Paginated listings are oldest first
+	var want []string
+	for i := 0; i < 5; i++ {
+		c := static.TestUsernamePasswordCredential(t, conn, wrapper, fmt.Sprintf("user-%d", i), fmt.Sprintf("pass-%d", i), store.GetPublicId(), prj.GetPublicId())
+		want = append(want, c.GetPublicId())
+	}
+	pagination.TestList(t,
+		auth.DisabledAuthTestContext(iamRepoFn, prj.GetPublicId()),
+		auth.DisabledAuthTestContext(iamRepoFn, prj.GetPublicId(), auth.WithUserId(globals.AnonymousUserId)),
+		resource.CredentialLibrary,
+		want,
+		func(ctx context.Context, listToken string, pageSize uint32) (pagination.TestListResponse, []string, error) {
+			got, err := s.ListCredentials(ctx, &pbs.ListCredentialsRequest{CredentialStoreId: store.GetPublicId(), ListToken: listToken, PageSize: pageSize})
+			var ids []string
+			for _, item := range got.GetItems() {
+				ids = append(ids, item.GetId())
+			}
+			return got, ids, err
+		},
+		func(t *testing.T, id string) {
+			repo, err := staticRepoFn()
+			require.NoError(t, err)
+			_, err = repo.DeleteCredential(ctx, prj.GetPublicId(), id)
+			require.NoError(t, err)
+		},
+	)
+}
+
 func TestGet(t *testing.T) {
 	conn, _ := db.TestSetup(t, "postgres")
 	wrapper := db.TestWrapper(t)
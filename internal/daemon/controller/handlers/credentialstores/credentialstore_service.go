@@ -8,6 +8,7 @@ import (
 	"encoding/base64"
 	"encoding/pem"
 	"fmt"
+	"time"
 
 	"github.com/hashicorp/boundary/globals"
 	"github.com/hashicorp/boundary/internal/credential"
@@ -20,8 +21,10 @@ import (
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers"
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/credentiallibraries"
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/credentials"
+	"github.com/hashicorp/boundary/internal/db"
 	"github.com/hashicorp/boundary/internal/errors"
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
+	"github.com/hashicorp/boundary/internal/pagination"
 	"github.com/hashicorp/boundary/internal/perms"
 	"github.com/hashicorp/boundary/internal/requests"
 	"github.com/hashicorp/boundary/internal/types/action"
@@ -145,28 +148,23 @@ func (s Service) ListCredentialStores(ctx context.Context, req *pbs.ListCredenti
 		return &pbs.ListCredentialStoresResponse{}, nil
 	}
 
-	csl, err := s.listFromRepo(ctx, scopeIds)
-	if err != nil {
-		return nil, err
-	}
-	if len(csl) == 0 {
-		return &pbs.ListCredentialStoresResponse{}, nil
-	}
-
 	filter, err := handlers.NewFilter(req.GetFilter())
 	if err != nil {
 		return nil, err
 	}
-	finalItems := make([]*pb.CredentialStore, 0, len(csl))
-	res := perms.Resource{
-		Type: resource.CredentialStore,
+
+	listItemsFn := func(ctx context.Context, page *db.Page, limit int) ([]credential.Store, error) {
+		return s.listFromRepo(ctx, scopeIds, page, limit)
 	}
-	for _, item := range csl {
-		res.Id = item.GetPublicId()
-		res.ScopeId = item.GetProjectId()
+	convertItemFn := func(ctx context.Context, item credential.Store) (*pb.CredentialStore, bool, error) {
+		res := perms.Resource{
+			Id:      item.GetPublicId(),
+			ScopeId: item.GetProjectId(),
+			Type:    resource.CredentialStore,
+		}
 		authorizedActions := authResults.FetchActionSetForId(ctx, item.GetPublicId(), IdActions, auth.WithResource(&res)).Strings()
 		if len(authorizedActions) == 0 {
-			continue
+			return nil, false, nil
 		}
 
 		outputFields := authResults.FetchOutputFields(res, action.List).SelfOrDefaults(authResults.UserId)
@@ -181,25 +179,54 @@ func (s Service) ListCredentialStores(ctx context.Context, req *pbs.ListCredenti
 		if outputFields.Has(globals.AuthorizedCollectionActionsField) {
 			collectionActions, err := calculateAuthorizedCollectionActions(ctx, authResults, item.GetPublicId())
 			if err != nil {
-				return nil, err
+				return nil, false, err
 			}
 			outputOpts = append(outputOpts, handlers.WithAuthorizedCollectionActions(collectionActions))
 		}
 
-		item, err := toProto(ctx, item, outputOpts...)
+		pbItem, err := toProto(ctx, item, outputOpts...)
 		if err != nil {
-			return nil, err
+			return nil, false, err
 		}
 
-		filterable, err := subtypes.Filterable(item)
+		filterable, err := subtypes.Filterable(pbItem)
 		if err != nil {
-			return nil, err
+			return nil, false, err
 		}
-		if filter.Match(filterable) {
-			finalItems = append(finalItems, item)
+		return pbItem, filter.Match(filterable), nil
+	}
+
+	repo, err := s.staticRepoFn()
+	if err != nil {
+		return nil, err
+	}
+	listDeletedIdsFn := func(ctx context.Context, since time.Time) ([]string, error) {
+		deleted, err := repo.ListDeletedCredentialStoreIds(ctx, scopeIds, since)
+		if err != nil {
+			return nil, err
 		}
+		return authResults.AuthorizedDeletedIds(ctx, perms.Resource{Type: resource.CredentialStore}, IdActions, deleted), nil
+	}
+	listReq := pagination.Request{
+		ResourceType: resource.CredentialStore,
+		GrantsHash:   authResults.GrantsHash(),
+		PageSize:     req.GetPageSize(),
+		ListToken:    req.GetListToken(),
+	}
+	page, err := pagination.List(ctx, listReq, listItemsFn, convertItemFn, listDeletedIdsFn)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &pbs.ListCredentialStoresResponse{Items: page.Items}
+	if listReq.Paginated() {
+		resp.ResponseType = page.ResponseType()
+		resp.ListToken = page.ListToken
+		resp.SortBy = page.SortBy
+		resp.SortDir = pagination.SortDirAscending
+		resp.RemovedIds = page.RemovedIds
 	}
-	return &pbs.ListCredentialStoresResponse{Items: finalItems}, nil
+	return resp, nil
 }
 
 // GetCredentialStore implements the interface pbs.CredentialStoreServiceServer.
@@ -356,14 +383,14 @@ func (s Service) DeleteCredentialStore(ctx context.Context, req *pbs.DeleteCrede
 	return nil, nil
 }
 
-func (s Service) listFromRepo(ctx context.Context, scopeIds []string) ([]credential.Store, error) {
+func (s Service) listFromRepo(ctx context.Context, scopeIds []string, page *db.Page, limit int) ([]credential.Store, error) {
 	const op = "credentialstores.(Service).listFromRepo"
 
 	vaultRepo, err := s.vaultRepoFn()
 	if err != nil {
 		return nil, errors.Wrap(ctx, err, op)
 	}
-	vaultCsl, err := vaultRepo.ListCredentialStores(ctx, scopeIds)
+	vaultCsl, err := vaultRepo.ListCredentialStores(ctx, scopeIds, vault.WithPage(page), vault.WithLimit(limit))
 	if err != nil {
 		return nil, errors.Wrap(ctx, err, op)
 	}
@@ -372,7 +399,7 @@ func (s Service) listFromRepo(ctx context.Context, scopeIds []string) ([]credent
 	if err != nil {
 		return nil, errors.Wrap(ctx, err, op)
 	}
-	staticCsl, err := staticRepo.ListCredentialStores(ctx, scopeIds)
+	staticCsl, err := staticRepo.ListCredentialStores(ctx, scopeIds, static.WithPage(page), static.WithLimit(limit))
 	if err != nil {
 		return nil, errors.Wrap(ctx, err, op)
 	}
@@ -385,7 +412,7 @@ func (s Service) listFromRepo(ctx context.Context, scopeIds []string) ([]credent
 		csl = append(csl, s)
 	}
 
-	return csl, nil
+	return pagination.MergeItems(csl, page, limit), nil
 }
 
 func (s Service) getFromRepo(ctx context.Context, id string) (credential.Store, error) {
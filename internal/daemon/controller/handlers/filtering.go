@@ -16,7 +16,8 @@ type filterItem struct {
 }
 
 type Filter struct {
-	eval *bexpr.Evaluator
+	eval       *bexpr.Evaluator
+	expression string
 }
 
 // NewFilter returns a Filter which can be evluated against.  An empty string paramter indicates
@@ -30,7 +31,27 @@ func NewFilter(f string) (*Filter, error) {
 	if err != nil {
 		return nil, errors.WrapDeprecated(err, op, errors.WithMsg("couldn't build filter"), errors.WithCode(errors.InvalidParameter))
 	}
-	return &Filter{eval: e}, nil
+	return &Filter{eval: e, expression: f}, nil
+}
+
+// WhereClause returns a sql where clause, using named args, which is true for
+// every item the filter can match, given the columns of the fields of the
+// items. Repositories use it to only list the items which may match the
+// filter. The items must still be matched using Match: the output fields of an
+// item can hide a field the where clause compared, and the filter must not
+// reveal the values of hidden fields. An empty where clause is returned if the
+// filter cannot be translated.
+func (f *Filter) WhereClause(columns filter.Columns) (string, []any) {
+	if f.eval == nil {
+		return "", nil
+	}
+	where, args, err := filter.WhereClause(f.expression, columns)
+	if err != nil {
+		// The expression was parsed when the filter was created, so this
+		// should not happen; the filter is still evaluated by Match.
+		return "", nil
+	}
+	return where, args
 }
 
 // Match returns if the provided interface matches the filter.
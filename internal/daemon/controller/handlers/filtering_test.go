@@ -4,8 +4,10 @@
 package handlers
 
 import (
+	"database/sql"
 	"testing"
 
+	"github.com/hashicorp/boundary/internal/filter"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/protobuf/types/known/wrapperspb"
@@ -120,3 +122,19 @@ func TestNewFilter(t *testing.T) {
 		})
 	}
 }
+
+func TestFilter_WhereClause(t *testing.T) {
+	columns := filter.Columns{"/item/type": "type"}
+
+	f, err := NewFilter("")
+	require.NoError(t, err)
+	where, args := f.WhereClause(columns)
+	assert.Empty(t, where)
+	assert.Empty(t, args)
+
+	f, err = NewFilter(`"/item/type" == "tcp" and "/item/name" matches "foo"`)
+	require.NoError(t, err)
+	where, args = f.WhereClause(columns)
+	assert.Equal(t, "type = @filter_0", where)
+	assert.Equal(t, []any{sql.Named("filter_0", "tcp")}, args)
+}
@@ -0,0 +1,25 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package handlers
+
+import (
+	"context"
+	"net/http"
+)
+
+type flusherContextKey struct{}
+
+// NewFlusherContext returns ctx holding the flusher of a request's response.
+// The response writers wrapping the response do not all implement
+// http.Flusher, so handlers streaming their response get the flusher from the
+// request context.
+func NewFlusherContext(ctx context.Context, f http.Flusher) context.Context {
+	return context.WithValue(ctx, flusherContextKey{}, f)
+}
+
+// FlusherFromContext returns the flusher held by ctx, if any.
+func FlusherFromContext(ctx context.Context) (http.Flusher, bool) {
+	f, ok := ctx.Value(flusherContextKey{}).(http.Flusher)
+	return f, ok
+}
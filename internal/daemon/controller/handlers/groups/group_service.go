@@ -6,16 +6,19 @@ package groups
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/hashicorp/boundary/globals"
 	"github.com/hashicorp/boundary/internal/daemon/controller/auth"
 	"github.com/hashicorp/boundary/internal/daemon/controller/common"
 	"github.com/hashicorp/boundary/internal/daemon/controller/common/scopeids"
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers"
+	"github.com/hashicorp/boundary/internal/db"
 	"github.com/hashicorp/boundary/internal/errors"
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
 	"github.com/hashicorp/boundary/internal/iam"
 	"github.com/hashicorp/boundary/internal/iam/store"
+	"github.com/hashicorp/boundary/internal/pagination"
 	"github.com/hashicorp/boundary/internal/perms"
 	"github.com/hashicorp/boundary/internal/requests"
 	"github.com/hashicorp/boundary/internal/types/action"
@@ -104,28 +107,28 @@ func (s Service) ListGroups(ctx context.Context, req *pbs.ListGroupsRequest) (*p
 		return &pbs.ListGroupsResponse{}, nil
 	}
 
-	gl, err := s.listFromRepo(ctx, scopeIds)
+	repo, err := s.repoFn()
 	if err != nil {
 		return nil, err
 	}
-	if len(gl) == 0 {
-		return &pbs.ListGroupsResponse{}, nil
-	}
 
 	filter, err := handlers.NewFilter(req.GetFilter())
 	if err != nil {
 		return nil, err
 	}
-	finalItems := make([]*pb.Group, 0, len(gl))
-	res := perms.Resource{
-		Type: resource.Group,
+
+	listItemsFn := func(ctx context.Context, page *db.Page, limit int) ([]*iam.Group, error) {
+		return repo.ListGroups(ctx, scopeIds, iam.WithPage(page), iam.WithLimit(limit))
 	}
-	for _, item := range gl {
-		res.Id = item.GetPublicId()
-		res.ScopeId = item.GetScopeId()
+	convertItemFn := func(ctx context.Context, item *iam.Group) (*pb.Group, bool, error) {
+		res := perms.Resource{
+			Id:      item.GetPublicId(),
+			ScopeId: item.GetScopeId(),
+			Type:    resource.Group,
+		}
 		authorizedActions := authResults.FetchActionSetForId(ctx, item.GetPublicId(), IdActions, auth.WithResource(&res)).Strings()
 		if len(authorizedActions) == 0 {
-			continue
+			return nil, false, nil
 		}
 
 		outputFields := authResults.FetchOutputFields(res, action.List).SelfOrDefaults(authResults.UserId)
@@ -138,16 +141,40 @@ func (s Service) ListGroups(ctx context.Context, req *pbs.ListGroupsRequest) (*p
 			outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authorizedActions))
 		}
 
-		item, err := toProto(ctx, item, nil, outputOpts...)
+		pbItem, err := toProto(ctx, item, nil, outputOpts...)
 		if err != nil {
-			return nil, err
+			return nil, false, err
 		}
+		return pbItem, filter.Match(pbItem), nil
+	}
 
-		if filter.Match(item) {
-			finalItems = append(finalItems, item)
+	listDeletedIdsFn := func(ctx context.Context, since time.Time) ([]string, error) {
+		deleted, err := repo.ListDeletedGroupIds(ctx, scopeIds, since)
+		if err != nil {
+			return nil, err
 		}
+		return authResults.AuthorizedDeletedIds(ctx, perms.Resource{Type: resource.Group}, IdActions, deleted), nil
+	}
+	listReq := pagination.Request{
+		ResourceType: resource.Group,
+		GrantsHash:   authResults.GrantsHash(),
+		PageSize:     req.GetPageSize(),
+		ListToken:    req.GetListToken(),
+	}
+	page, err := pagination.List(ctx, listReq, listItemsFn, convertItemFn, listDeletedIdsFn)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &pbs.ListGroupsResponse{Items: page.Items}
+	if listReq.Paginated() {
+		resp.ResponseType = page.ResponseType()
+		resp.ListToken = page.ListToken
+		resp.SortBy = page.SortBy
+		resp.SortDir = pagination.SortDirAscending
+		resp.RemovedIds = page.RemovedIds
 	}
-	return &pbs.ListGroupsResponse{Items: finalItems}, nil
+	return resp, nil
 }
 
 // GetGroups implements the interface pbs.GroupServiceServer.
@@ -486,19 +513,6 @@ func (s Service) deleteFromRepo(ctx context.Context, id string) (bool, error) {
 	return rows > 0, nil
 }
 
-func (s Service) listFromRepo(ctx context.Context, scopeIds []string) ([]*iam.Group, error) {
-	const op = "groups.(Service).listFromRepo"
-	repo, err := s.repoFn()
-	if err != nil {
-		return nil, errors.Wrap(ctx, err, op)
-	}
-	gl, err := repo.ListGroups(ctx, scopeIds)
-	if err != nil {
-		return nil, errors.Wrap(ctx, err, op)
-	}
-	return gl, nil
-}
-
 func (s Service) addMembersInRepo(ctx context.Context, groupId string, userIds []string, version uint32) (*iam.Group, []*iam.GroupMember, error) {
 	const op = "groups.(Service).addMembersInRepo"
 	repo, err := s.repoFn()
@@ -18,6 +18,8 @@ import (
 	"github.com/hashicorp/boundary/internal/db"
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
 	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/pagination"
+	"github.com/hashicorp/boundary/internal/types/resource"
 	"github.com/hashicorp/boundary/internal/types/scope"
 	pb "github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/groups"
 	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/scopes"
@@ -347,6 +349,44 @@ func TestList(t *testing.T) {
 	}
 }
 
+func TestList_Pagination(t *testing.T) {
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrap := db.TestWrapper(t)
+	iamRepo := iam.TestRepo(t, conn, wrap)
+	repoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	s, err := groups.NewService(repoFn)
+	require.NoError(t, err)
+
+	o, _ := iam.TestScopes(t, iamRepo)
+	// Paginated listings are oldest first
+	var want []string
+	for i := 0; i < 5; i++ {
+		g := iam.TestGroup(t, conn, o.GetPublicId())
+		want = append(want, g.GetPublicId())
+	}
+	pagination.TestList(t,
+		auth.DisabledAuthTestContext(repoFn, o.GetPublicId()),
+		auth.DisabledAuthTestContext(repoFn, o.GetPublicId(), auth.WithUserId(globals.AnonymousUserId)),
+		resource.Role,
+		want,
+		func(ctx context.Context, listToken string, pageSize uint32) (pagination.TestListResponse, []string, error) {
+			got, err := s.ListGroups(ctx, &pbs.ListGroupsRequest{ScopeId: o.GetPublicId(), ListToken: listToken, PageSize: pageSize})
+			var ids []string
+			for _, item := range got.GetItems() {
+				ids = append(ids, item.GetId())
+			}
+			return got, ids, err
+		},
+		func(t *testing.T, id string) {
+			_, err := iamRepo.DeleteGroup(ctx, id)
+			require.NoError(t, err)
+		},
+	)
+}
+
 func TestDelete(t *testing.T) {
 	og, pg, repoFn := createDefaultGroupsAndRepo(t)
 
@@ -6,6 +6,7 @@ package host_catalogs
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/hashicorp/boundary/globals"
 	"github.com/hashicorp/boundary/internal/daemon/controller/auth"
@@ -14,6 +15,7 @@ import (
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers"
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/host_sets"
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/hosts"
+	"github.com/hashicorp/boundary/internal/db"
 	"github.com/hashicorp/boundary/internal/errors"
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
 	"github.com/hashicorp/boundary/internal/host"
@@ -21,6 +23,7 @@ import (
 	pluginstore "github.com/hashicorp/boundary/internal/host/plugin/store"
 	"github.com/hashicorp/boundary/internal/host/static"
 	"github.com/hashicorp/boundary/internal/host/static/store"
+	"github.com/hashicorp/boundary/internal/pagination"
 	"github.com/hashicorp/boundary/internal/perms"
 	hostplugin "github.com/hashicorp/boundary/internal/plugin/host"
 	"github.com/hashicorp/boundary/internal/requests"
@@ -141,28 +144,33 @@ func (s Service) ListHostCatalogs(ctx context.Context, req *pbs.ListHostCatalogs
 		return &pbs.ListHostCatalogsResponse{}, nil
 	}
 
-	items, pluginInfoMap, err := s.listFromRepo(ctx, scopeIds)
-	if err != nil {
-		return nil, err
-	}
-	if len(items) == 0 {
-		return &pbs.ListHostCatalogsResponse{}, nil
-	}
-
 	filter, err := handlers.NewFilter(req.GetFilter())
 	if err != nil {
 		return nil, err
 	}
-	finalItems := make([]*pb.HostCatalog, 0, len(items))
-	res := perms.Resource{
-		Type: resource.HostCatalog,
+
+	// The plugins of the listed catalogs are collected across the pages read
+	// from the repositories.
+	pluginInfoMap := make(map[string]*plugins.PluginInfo)
+	listItemsFn := func(ctx context.Context, page *db.Page, limit int) ([]host.Catalog, error) {
+		items, plgs, err := s.listFromRepo(ctx, scopeIds, page, limit)
+		if err != nil {
+			return nil, err
+		}
+		for id, plg := range plgs {
+			pluginInfoMap[id] = plg
+		}
+		return items, nil
 	}
-	for _, item := range items {
-		res.Id = item.GetPublicId()
-		res.ScopeId = item.GetProjectId()
+	convertItemFn := func(ctx context.Context, item host.Catalog) (*pb.HostCatalog, bool, error) {
+		res := perms.Resource{
+			Id:      item.GetPublicId(),
+			ScopeId: item.GetProjectId(),
+			Type:    resource.HostCatalog,
+		}
 		authorizedActions := authResults.FetchActionSetForId(ctx, item.GetPublicId(), IdActions, auth.WithResource(&res)).Strings()
 		if len(authorizedActions) == 0 {
-			continue
+			return nil, false, nil
 		}
 
 		outputFields := authResults.FetchOutputFields(res, action.List).SelfOrDefaults(authResults.UserId)
@@ -185,7 +193,7 @@ func (s Service) ListHostCatalogs(ctx context.Context, req *pbs.ListHostCatalogs
 			if subtype != "" {
 				collectionActions, err := auth.CalculateAuthorizedCollectionActions(ctx, authResults, collectionTypeMap[subtype], authResults.Scope.Id, item.GetPublicId())
 				if err != nil {
-					return nil, err
+					return nil, false, err
 				}
 				outputOpts = append(outputOpts, handlers.WithAuthorizedCollectionActions(collectionActions))
 			}
@@ -197,22 +205,51 @@ func (s Service) ListHostCatalogs(ctx context.Context, req *pbs.ListHostCatalogs
 			}
 		}
 
-		item, err := toProto(ctx, item, outputOpts...)
+		pbItem, err := toProto(ctx, item, outputOpts...)
 		if err != nil {
-			return nil, err
+			return nil, false, err
 		}
 
 		// This comes last so that we can use item fields in the filter after
 		// the allowed fields are populated above
-		filterable, err := subtypes.Filterable(item)
+		filterable, err := subtypes.Filterable(pbItem)
 		if err != nil {
-			return nil, err
+			return nil, false, err
 		}
-		if filter.Match(filterable) {
-			finalItems = append(finalItems, item)
+		return pbItem, filter.Match(filterable), nil
+	}
+
+	repo, err := s.staticRepoFn()
+	if err != nil {
+		return nil, err
+	}
+	listDeletedIdsFn := func(ctx context.Context, since time.Time) ([]string, error) {
+		deleted, err := repo.ListDeletedCatalogIds(ctx, scopeIds, since)
+		if err != nil {
+			return nil, err
 		}
+		return authResults.AuthorizedDeletedIds(ctx, perms.Resource{Type: resource.HostCatalog}, IdActions, deleted), nil
+	}
+	listReq := pagination.Request{
+		ResourceType: resource.HostCatalog,
+		GrantsHash:   authResults.GrantsHash(),
+		PageSize:     req.GetPageSize(),
+		ListToken:    req.GetListToken(),
+	}
+	page, err := pagination.List(ctx, listReq, listItemsFn, convertItemFn, listDeletedIdsFn)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &pbs.ListHostCatalogsResponse{Items: page.Items}
+	if listReq.Paginated() {
+		resp.ResponseType = page.ResponseType()
+		resp.ListToken = page.ListToken
+		resp.SortBy = page.SortBy
+		resp.SortDir = pagination.SortDirAscending
+		resp.RemovedIds = page.RemovedIds
 	}
-	return &pbs.ListHostCatalogsResponse{Items: finalItems}, nil
+	return resp, nil
 }
 
 // GetHostCatalog implements the interface pbs.HostCatalogServiceServer.
@@ -439,12 +476,12 @@ func (s Service) getFromRepo(ctx context.Context, id string) (host.Catalog, *plu
 	return cat, plg, nil
 }
 
-func (s Service) listFromRepo(ctx context.Context, projectIds []string) ([]host.Catalog, map[string]*plugins.PluginInfo, error) {
+func (s Service) listFromRepo(ctx context.Context, projectIds []string, page *db.Page, limit int) ([]host.Catalog, map[string]*plugins.PluginInfo, error) {
 	repo, err := s.staticRepoFn()
 	if err != nil {
 		return nil, nil, err
 	}
-	ul, err := repo.ListCatalogs(ctx, projectIds)
+	ul, err := repo.ListCatalogs(ctx, projectIds, static.WithPage(page), static.WithLimit(limit))
 	if err != nil {
 		return nil, nil, err
 	}
@@ -456,7 +493,7 @@ func (s Service) listFromRepo(ctx context.Context, projectIds []string) ([]host.
 	if err != nil {
 		return nil, nil, err
 	}
-	pl, plgs, err := pluginRepo.ListCatalogs(ctx, projectIds)
+	pl, plgs, err := pluginRepo.ListCatalogs(ctx, projectIds, host.WithPage(page), host.WithLimit(limit))
 	if err != nil {
 		return nil, nil, err
 	}
@@ -468,7 +505,7 @@ func (s Service) listFromRepo(ctx context.Context, projectIds []string) ([]host.
 		pluginsMap[plg.GetPublicId()] = toPluginInfo(plg)
 	}
 
-	return res, pluginsMap, nil
+	return pagination.MergeItems(res, page, limit), pluginsMap, nil
 }
 
 func (s Service) createStaticInRepo(ctx context.Context, projId string, item *pb.HostCatalog) (*static.HostCatalog, error) {
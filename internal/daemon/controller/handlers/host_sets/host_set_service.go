@@ -6,11 +6,13 @@ package host_sets
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/hashicorp/boundary/globals"
 	"github.com/hashicorp/boundary/internal/daemon/controller/auth"
 	"github.com/hashicorp/boundary/internal/daemon/controller/common"
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers"
+	"github.com/hashicorp/boundary/internal/db"
 	"github.com/hashicorp/boundary/internal/errors"
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
 	"github.com/hashicorp/boundary/internal/host"
@@ -19,6 +21,7 @@ import (
 	"github.com/hashicorp/boundary/internal/host/static"
 	staticstore "github.com/hashicorp/boundary/internal/host/static/store"
 	"github.com/hashicorp/boundary/internal/libs/endpoint"
+	"github.com/hashicorp/boundary/internal/pagination"
 	"github.com/hashicorp/boundary/internal/perms"
 	hostplugin "github.com/hashicorp/boundary/internal/plugin/host"
 	"github.com/hashicorp/boundary/internal/requests"
@@ -28,6 +31,7 @@ import (
 	pb "github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/hostsets"
 	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/plugins"
 	"github.com/hashicorp/go-secure-stdlib/strutil"
+	"golang.org/x/exp/maps"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/structpb"
@@ -111,31 +115,34 @@ func (s Service) ListHostSetsWithOptions(ctx context.Context, req *pbs.ListHostS
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
-	hl, plg, err := s.listFromRepo(ctx, req.GetHostCatalogId(), opt...)
-	if err != nil {
-		return nil, err
-	}
-	if len(hl) == 0 {
-		return &pbs.ListHostSetsResponse{}, nil
-	}
-
 	filter, err := handlers.NewFilter(req.GetFilter())
 	if err != nil {
 		return nil, err
 	}
-	finalItems := make([]*pb.HostSet, 0, len(hl))
 
-	res := perms.Resource{
-		ScopeId: authResults.Scope.Id,
-		Type:    resource.HostSet,
-		Pin:     req.GetHostCatalogId(),
-	}
-	for _, item := range hl {
-		res.Id = item.GetPublicId()
+	var plg *plugins.PluginInfo
+	listItemsFn := func(ctx context.Context, page *db.Page, limit int) ([]host.Set, error) {
+		listOpts := append([]host.Option{host.WithPage(page), host.WithLimit(limit)}, opt...)
+		hl, hlPlg, err := s.listFromRepo(ctx, req.GetHostCatalogId(), listOpts...)
+		if err != nil {
+			return nil, err
+		}
+		if hlPlg != nil {
+			plg = hlPlg
+		}
+		return hl, nil
+	}
+	convertItemFn := func(ctx context.Context, item host.Set) (*pb.HostSet, bool, error) {
+		res := perms.Resource{
+			Id:      item.GetPublicId(),
+			ScopeId: authResults.Scope.Id,
+			Type:    resource.HostSet,
+			Pin:     req.GetHostCatalogId(),
+		}
 		idActions := idActionsTypeMap[subtypes.SubtypeFromId(domain, res.Id)]
 		authorizedActions := authResults.FetchActionSetForId(ctx, item.GetPublicId(), idActions, auth.WithResource(&res)).Strings()
 		if len(authorizedActions) == 0 {
-			continue
+			return nil, false, nil
 		}
 
 		outputFields := authResults.FetchOutputFields(res, action.List).SelfOrDefaults(authResults.UserId)
@@ -151,22 +158,51 @@ func (s Service) ListHostSetsWithOptions(ctx context.Context, req *pbs.ListHostS
 			outputOpts = append(outputOpts, handlers.WithPlugin(plg))
 		}
 
-		item, err := toProto(ctx, item, nil, outputOpts...)
+		pbItem, err := toProto(ctx, item, nil, outputOpts...)
 		if err != nil {
-			return nil, err
+			return nil, false, err
 		}
 
 		// This comes last so that we can use item fields in the filter after
 		// the allowed fields are populated above
-		filterable, err := subtypes.Filterable(item)
+		filterable, err := subtypes.Filterable(pbItem)
 		if err != nil {
-			return nil, err
+			return nil, false, err
 		}
-		if filter.Match(filterable) {
-			finalItems = append(finalItems, item)
+		return pbItem, filter.Match(filterable), nil
+	}
+
+	repo, err := s.staticRepoFn()
+	if err != nil {
+		return nil, err
+	}
+	listDeletedIdsFn := func(ctx context.Context, since time.Time) ([]string, error) {
+		deleted, err := repo.ListDeletedSetIds(ctx, req.GetHostCatalogId(), since)
+		if err != nil {
+			return nil, err
 		}
+		return authResults.AuthorizedDeletedIds(ctx, perms.Resource{ScopeId: authResults.Scope.Id, Type: resource.HostSet, Pin: req.GetHostCatalogId()}, action.Union(maps.Values(idActionsTypeMap)...), deleted), nil
 	}
-	return &pbs.ListHostSetsResponse{Items: finalItems}, nil
+	listReq := pagination.Request{
+		ResourceType: resource.HostSet,
+		GrantsHash:   authResults.GrantsHash(),
+		PageSize:     req.GetPageSize(),
+		ListToken:    req.GetListToken(),
+	}
+	page, err := pagination.List(ctx, listReq, listItemsFn, convertItemFn, listDeletedIdsFn)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &pbs.ListHostSetsResponse{Items: page.Items}
+	if listReq.Paginated() {
+		resp.ResponseType = page.ResponseType()
+		resp.ListToken = page.ListToken
+		resp.SortBy = page.SortBy
+		resp.SortDir = pagination.SortDirAscending
+		resp.RemovedIds = page.RemovedIds
+	}
+	return resp, nil
 }
 
 // GetHostSet implements the interface pbs.HostSetServiceServer.
@@ -633,7 +669,11 @@ func (s Service) listFromRepo(ctx context.Context, catalogId string, opt ...host
 		if err != nil {
 			return nil, nil, err
 		}
-		sl, err := repo.ListSets(ctx, catalogId)
+		opts, err := host.GetOpts(opt...)
+		if err != nil {
+			return nil, nil, errors.Wrap(ctx, err, op)
+		}
+		sl, err := repo.ListSets(ctx, catalogId, static.WithPage(opts.WithPage), static.WithLimit(opts.WithLimit))
 		if err != nil {
 			return nil, nil, errors.Wrap(ctx, err, op)
 		}
@@ -8,17 +8,20 @@ import (
 	"fmt"
 	"net"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/boundary/globals"
 	"github.com/hashicorp/boundary/internal/daemon/controller/auth"
 	"github.com/hashicorp/boundary/internal/daemon/controller/common"
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers"
+	"github.com/hashicorp/boundary/internal/db"
 	"github.com/hashicorp/boundary/internal/errors"
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
 	"github.com/hashicorp/boundary/internal/host"
 	"github.com/hashicorp/boundary/internal/host/plugin"
 	"github.com/hashicorp/boundary/internal/host/static"
 	"github.com/hashicorp/boundary/internal/host/static/store"
+	"github.com/hashicorp/boundary/internal/pagination"
 	"github.com/hashicorp/boundary/internal/perms"
 	hostplugin "github.com/hashicorp/boundary/internal/plugin/host"
 	"github.com/hashicorp/boundary/internal/requests"
@@ -27,6 +30,7 @@ import (
 	"github.com/hashicorp/boundary/internal/types/subtypes"
 	pb "github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/hosts"
 	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/plugins"
+	"golang.org/x/exp/maps"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/protobuf/types/known/wrapperspb"
 )
@@ -96,31 +100,33 @@ func (s Service) ListHosts(ctx context.Context, req *pbs.ListHostsRequest) (*pbs
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
-	hl, plg, err := s.listFromRepo(ctx, req.GetHostCatalogId())
-	if err != nil {
-		return nil, err
-	}
-	if len(hl) == 0 {
-		return &pbs.ListHostsResponse{}, nil
-	}
-
 	filter, err := handlers.NewFilter(req.GetFilter())
 	if err != nil {
 		return nil, err
 	}
-	finalItems := make([]*pb.Host, 0, len(hl))
 
-	res := perms.Resource{
-		ScopeId: authResults.Scope.Id,
-		Type:    resource.Host,
-		Pin:     req.GetHostCatalogId(),
+	var plg *plugins.PluginInfo
+	listItemsFn := func(ctx context.Context, page *db.Page, limit int) ([]host.Host, error) {
+		hl, hlPlg, err := s.listFromRepo(ctx, req.GetHostCatalogId(), page, limit)
+		if err != nil {
+			return nil, err
+		}
+		if hlPlg != nil {
+			plg = hlPlg
+		}
+		return hl, nil
 	}
-	for _, item := range hl {
-		res.Id = item.GetPublicId()
+	convertItemFn := func(ctx context.Context, item host.Host) (*pb.Host, bool, error) {
+		res := perms.Resource{
+			Id:      item.GetPublicId(),
+			ScopeId: authResults.Scope.Id,
+			Type:    resource.Host,
+			Pin:     req.GetHostCatalogId(),
+		}
 		idActions := idActionsTypeMap[subtypes.SubtypeFromId(domain, res.Id)]
 		authorizedActions := authResults.FetchActionSetForId(ctx, item.GetPublicId(), idActions, auth.WithResource(&res)).Strings()
 		if len(authorizedActions) == 0 {
-			continue
+			return nil, false, nil
 		}
 
 		outputFields := authResults.FetchOutputFields(res, action.List).SelfOrDefaults(authResults.UserId)
@@ -136,22 +142,51 @@ func (s Service) ListHosts(ctx context.Context, req *pbs.ListHostsRequest) (*pbs
 			outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authorizedActions))
 		}
 		outputOpts = append(outputOpts, handlers.WithHostSetIds(item.GetSetIds()))
-		item, err := toProto(ctx, item, outputOpts...)
+		pbItem, err := toProto(ctx, item, outputOpts...)
 		if err != nil {
-			return nil, err
+			return nil, false, err
 		}
 
 		// This comes last so that we can use item fields in the filter after
 		// the allowed fields are populated above
-		filterable, err := subtypes.Filterable(item)
+		filterable, err := subtypes.Filterable(pbItem)
 		if err != nil {
-			return nil, err
+			return nil, false, err
 		}
-		if filter.Match(filterable) {
-			finalItems = append(finalItems, item)
+		return pbItem, filter.Match(filterable), nil
+	}
+
+	repo, err := s.staticRepoFn()
+	if err != nil {
+		return nil, err
+	}
+	listDeletedIdsFn := func(ctx context.Context, since time.Time) ([]string, error) {
+		deleted, err := repo.ListDeletedHostIds(ctx, req.GetHostCatalogId(), since)
+		if err != nil {
+			return nil, err
 		}
+		return authResults.AuthorizedDeletedIds(ctx, perms.Resource{ScopeId: authResults.Scope.Id, Type: resource.Host, Pin: req.GetHostCatalogId()}, action.Union(maps.Values(idActionsTypeMap)...), deleted), nil
+	}
+	listReq := pagination.Request{
+		ResourceType: resource.Host,
+		GrantsHash:   authResults.GrantsHash(),
+		PageSize:     req.GetPageSize(),
+		ListToken:    req.GetListToken(),
+	}
+	page, err := pagination.List(ctx, listReq, listItemsFn, convertItemFn, listDeletedIdsFn)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &pbs.ListHostsResponse{Items: page.Items}
+	if listReq.Paginated() {
+		resp.ResponseType = page.ResponseType()
+		resp.ListToken = page.ListToken
+		resp.SortBy = page.SortBy
+		resp.SortDir = pagination.SortDirAscending
+		resp.RemovedIds = page.RemovedIds
 	}
-	return &pbs.ListHostsResponse{Items: finalItems}, nil
+	return resp, nil
 }
 
 // GetHost implements the interface pbs.HostServiceServer.
@@ -409,7 +444,7 @@ func (s Service) deleteFromRepo(ctx context.Context, projectId, id string) (bool
 	return rows > 0, nil
 }
 
-func (s Service) listFromRepo(ctx context.Context, catalogId string) ([]host.Host, *plugins.PluginInfo, error) {
+func (s Service) listFromRepo(ctx context.Context, catalogId string, page *db.Page, limit int) ([]host.Host, *plugins.PluginInfo, error) {
 	var hosts []host.Host
 	var plg *plugins.PluginInfo
 	switch subtypes.SubtypeFromId(domain, catalogId) {
@@ -418,7 +453,7 @@ func (s Service) listFromRepo(ctx context.Context, catalogId string) ([]host.Hos
 		if err != nil {
 			return nil, nil, err
 		}
-		hl, err := repo.ListHosts(ctx, catalogId)
+		hl, err := repo.ListHosts(ctx, catalogId, static.WithPage(page), static.WithLimit(limit))
 		if err != nil {
 			return nil, nil, err
 		}
@@ -430,7 +465,7 @@ func (s Service) listFromRepo(ctx context.Context, catalogId string) ([]host.Hos
 		if err != nil {
 			return nil, nil, err
 		}
-		hl, hlPlg, err := repo.ListHostsByCatalogId(ctx, catalogId)
+		hl, hlPlg, err := repo.ListHostsByCatalogId(ctx, catalogId, plugin.WithPage(page), plugin.WithLimit(limit))
 		if err != nil {
 			return nil, nil, err
 		}
@@ -14,6 +14,8 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/hashicorp/boundary/globals"
+	"github.com/hashicorp/boundary/internal/auth/jwt"
+	"github.com/hashicorp/boundary/internal/auth/ldap"
 	"github.com/hashicorp/boundary/internal/auth/oidc"
 	"github.com/hashicorp/boundary/internal/auth/password"
 	"github.com/hashicorp/boundary/internal/authtoken"
@@ -26,7 +28,9 @@ import (
 	"github.com/hashicorp/boundary/internal/iam"
 	"github.com/hashicorp/boundary/internal/intglobals"
 	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/pagination"
 	"github.com/hashicorp/boundary/internal/types/action"
+	"github.com/hashicorp/boundary/internal/types/resource"
 	"github.com/hashicorp/boundary/internal/types/scope"
 	pb "github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/managedgroups"
 	scopepb "github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/scopes"
@@ -55,24 +59,63 @@ func TestNewService(t *testing.T) {
 	oidcRepoFn := func() (*oidc.Repository, error) {
 		return oidc.NewRepository(ctx, rw, rw, kmsCache)
 	}
+	ldapRepoFn := func() (*ldap.Repository, error) {
+		return ldap.NewRepository(ctx, rw, rw, kmsCache)
+	}
+	jwtRepoFn := func() (*jwt.Repository, error) {
+		return jwt.NewRepository(ctx, rw, rw, kmsCache)
+	}
+	pwRepoFn := func() (*password.Repository, error) {
+		return password.NewRepository(rw, rw, kmsCache)
+	}
 
 	cases := []struct {
 		name     string
+		pwRepo   common.PasswordAuthRepoFactory
 		oidcRepo common.OidcAuthRepoFactory
+		ldapRepo common.LdapAuthRepoFactory
+		jwtRepo  common.JwtAuthRepoFactory
 		wantErr  bool
 	}{
 		{
-			name:    "nil-oidc-repo",
-			wantErr: true,
+			name:     "nil-password-repo",
+			oidcRepo: oidcRepoFn,
+			ldapRepo: ldapRepoFn,
+			jwtRepo:  jwtRepoFn,
+			wantErr:  true,
+		},
+		{
+			name:     "nil-oidc-repo",
+			pwRepo:   pwRepoFn,
+			ldapRepo: ldapRepoFn,
+			jwtRepo:  jwtRepoFn,
+			wantErr:  true,
+		},
+		{
+			name:     "nil-ldap-repo",
+			pwRepo:   pwRepoFn,
+			oidcRepo: oidcRepoFn,
+			jwtRepo:  jwtRepoFn,
+			wantErr:  true,
+		},
+		{
+			name:     "nil-jwt-repo",
+			pwRepo:   pwRepoFn,
+			oidcRepo: oidcRepoFn,
+			ldapRepo: ldapRepoFn,
+			wantErr:  true,
 		},
 		{
 			name:     "success",
+			pwRepo:   pwRepoFn,
 			oidcRepo: oidcRepoFn,
+			ldapRepo: ldapRepoFn,
+			jwtRepo:  jwtRepoFn,
 		},
 	}
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
-			_, err := managed_groups.NewService(tc.oidcRepo)
+			_, err := managed_groups.NewService(tc.pwRepo, tc.oidcRepo, tc.ldapRepo, tc.jwtRepo)
 			if tc.wantErr {
 				assert.Error(t, err)
 			} else {
@@ -91,11 +134,20 @@ func TestGet(t *testing.T) {
 	oidcRepoFn := func() (*oidc.Repository, error) {
 		return oidc.NewRepository(ctx, rw, rw, kmsCache)
 	}
+	ldapRepoFn := func() (*ldap.Repository, error) {
+		return ldap.NewRepository(ctx, rw, rw, kmsCache)
+	}
+	jwtRepoFn := func() (*jwt.Repository, error) {
+		return jwt.NewRepository(ctx, rw, rw, kmsCache)
+	}
+	pwRepoFn := func() (*password.Repository, error) {
+		return password.NewRepository(rw, rw, kmsCache)
+	}
 	iamRepoFn := func() (*iam.Repository, error) {
 		return iam.NewRepository(rw, rw, kmsCache)
 	}
 
-	s, err := managed_groups.NewService(oidcRepoFn)
+	s, err := managed_groups.NewService(pwRepoFn, oidcRepoFn, ldapRepoFn, jwtRepoFn)
 	require.NoError(t, err, "Couldn't create new managed groups service.")
 
 	org, _ := iam.TestScopes(t, iam.TestRepo(t, conn, wrap))
@@ -196,6 +248,15 @@ func TestListOidc(t *testing.T) {
 	oidcRepoFn := func() (*oidc.Repository, error) {
 		return oidc.NewRepository(ctx, rw, rw, kmsCache)
 	}
+	ldapRepoFn := func() (*ldap.Repository, error) {
+		return ldap.NewRepository(ctx, rw, rw, kmsCache)
+	}
+	jwtRepoFn := func() (*jwt.Repository, error) {
+		return jwt.NewRepository(ctx, rw, rw, kmsCache)
+	}
+	pwRepoFn := func() (*password.Repository, error) {
+		return password.NewRepository(rw, rw, kmsCache)
+	}
 	iamRepoFn := func() (*iam.Repository, error) {
 		return iam.NewRepository(rw, rw, kmsCache)
 	}
@@ -305,7 +366,7 @@ func TestListOidc(t *testing.T) {
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
 			assert, require := assert.New(t), require.New(t)
-			s, err := managed_groups.NewService(oidcRepoFn)
+			s, err := managed_groups.NewService(pwRepoFn, oidcRepoFn, ldapRepoFn, jwtRepoFn)
 			require.NoError(err, "Couldn't create new managed group service.")
 
 			got, gErr := s.ListManagedGroups(auth.DisabledAuthTestContext(iamRepoFn, o.GetPublicId()), tc.req)
@@ -339,6 +400,63 @@ func TestListOidc(t *testing.T) {
 	}
 }
 
+func TestList_Pagination(t *testing.T) {
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrap := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, wrap)
+	oidcRepoFn := func() (*oidc.Repository, error) {
+		return oidc.NewRepository(ctx, rw, rw, kmsCache)
+	}
+	ldapRepoFn := func() (*ldap.Repository, error) {
+		return ldap.NewRepository(ctx, rw, rw, kmsCache)
+	}
+	jwtRepoFn := func() (*jwt.Repository, error) {
+		return jwt.NewRepository(ctx, rw, rw, kmsCache)
+	}
+	pwRepoFn := func() (*password.Repository, error) {
+		return password.NewRepository(rw, rw, kmsCache)
+	}
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iam.NewRepository(rw, rw, kmsCache)
+	}
+	s, err := managed_groups.NewService(pwRepoFn, oidcRepoFn, ldapRepoFn, jwtRepoFn)
+	require.NoError(t, err)
+
+	o, _ := iam.TestScopes(t, iam.TestRepo(t, conn, wrap))
+	databaseWrapper, err := kmsCache.GetWrapper(ctx, o.PublicId, kms.KeyPurposeDatabase)
+	require.NoError(t, err)
+	am := oidc.TestAuthMethod(t, conn, databaseWrapper, o.PublicId, oidc.ActivePrivateState, "alice-rp", "fido",
+		oidc.WithIssuer(oidc.TestConvertToUrls(t, "https://www.alice.com")[0]), oidc.WithSigningAlgs(oidc.RS256), oidc.WithApiUrl(oidc.TestConvertToUrls(t, "https://www.alice.com/callback")[0]))
+	// Paginated listings are oldest first
+	var want []string
+	for i := 0; i < 5; i++ {
+		mg := oidc.TestManagedGroup(t, conn, am, oidc.TestFakeManagedGroupFilter, oidc.WithName(strconv.Itoa(i)))
+		want = append(want, mg.GetPublicId())
+	}
+	pagination.TestList(t,
+		auth.DisabledAuthTestContext(iamRepoFn, o.GetPublicId()),
+		auth.DisabledAuthTestContext(iamRepoFn, o.GetPublicId(), auth.WithUserId(globals.AnonymousUserId)),
+		resource.Group,
+		want,
+		func(ctx context.Context, listToken string, pageSize uint32) (pagination.TestListResponse, []string, error) {
+			got, err := s.ListManagedGroups(ctx, &pbs.ListManagedGroupsRequest{AuthMethodId: am.GetPublicId(), ListToken: listToken, PageSize: pageSize})
+			var ids []string
+			for _, item := range got.GetItems() {
+				ids = append(ids, item.GetId())
+			}
+			return got, ids, err
+		},
+		func(t *testing.T, id string) {
+			oidcRepo, err := oidcRepoFn()
+			require.NoError(t, err)
+			_, err = oidcRepo.DeleteManagedGroup(ctx, o.GetPublicId(), id)
+			require.NoError(t, err)
+		},
+	)
+}
+
 func TestDelete(t *testing.T) {
 	ctx := context.Background()
 	conn, _ := db.TestSetup(t, "postgres")
@@ -348,6 +466,15 @@ func TestDelete(t *testing.T) {
 	oidcRepoFn := func() (*oidc.Repository, error) {
 		return oidc.NewRepository(ctx, rw, rw, kmsCache)
 	}
+	ldapRepoFn := func() (*ldap.Repository, error) {
+		return ldap.NewRepository(ctx, rw, rw, kmsCache)
+	}
+	jwtRepoFn := func() (*jwt.Repository, error) {
+		return jwt.NewRepository(ctx, rw, rw, kmsCache)
+	}
+	pwRepoFn := func() (*password.Repository, error) {
+		return password.NewRepository(rw, rw, kmsCache)
+	}
 	iamRepoFn := func() (*iam.Repository, error) {
 		return iam.NewRepository(rw, rw, kmsCache)
 	}
@@ -365,7 +492,7 @@ func TestDelete(t *testing.T) {
 	)
 	oidcMg := oidc.TestManagedGroup(t, conn, oidcAm, oidc.TestFakeManagedGroupFilter)
 
-	s, err := managed_groups.NewService(oidcRepoFn)
+	s, err := managed_groups.NewService(pwRepoFn, oidcRepoFn, ldapRepoFn, jwtRepoFn)
 	require.NoError(t, err, "Error when getting new user service.")
 
 	cases := []struct {
@@ -420,6 +547,15 @@ func TestDelete_twice(t *testing.T) {
 	oidcRepoFn := func() (*oidc.Repository, error) {
 		return oidc.NewRepository(ctx, rw, rw, kmsCache)
 	}
+	ldapRepoFn := func() (*ldap.Repository, error) {
+		return ldap.NewRepository(ctx, rw, rw, kmsCache)
+	}
+	jwtRepoFn := func() (*jwt.Repository, error) {
+		return jwt.NewRepository(ctx, rw, rw, kmsCache)
+	}
+	pwRepoFn := func() (*password.Repository, error) {
+		return password.NewRepository(rw, rw, kmsCache)
+	}
 	iamRepoFn := func() (*iam.Repository, error) {
 		return iam.NewRepository(rw, rw, kmsCache)
 	}
@@ -438,7 +574,7 @@ func TestDelete_twice(t *testing.T) {
 	)
 	oidcMg := oidc.TestManagedGroup(t, conn, oidcAm, oidc.TestFakeManagedGroupFilter)
 
-	s, err := managed_groups.NewService(oidcRepoFn)
+	s, err := managed_groups.NewService(pwRepoFn, oidcRepoFn, ldapRepoFn, jwtRepoFn)
 	require.NoError(err, "Error when getting new user service")
 	req := &pbs.DeleteManagedGroupRequest{
 		Id: oidcMg.GetPublicId(),
@@ -459,11 +595,20 @@ func TestCreateOidc(t *testing.T) {
 	oidcRepoFn := func() (*oidc.Repository, error) {
 		return oidc.NewRepository(ctx, rw, rw, kmsCache)
 	}
+	ldapRepoFn := func() (*ldap.Repository, error) {
+		return ldap.NewRepository(ctx, rw, rw, kmsCache)
+	}
+	jwtRepoFn := func() (*jwt.Repository, error) {
+		return jwt.NewRepository(ctx, rw, rw, kmsCache)
+	}
+	pwRepoFn := func() (*password.Repository, error) {
+		return password.NewRepository(rw, rw, kmsCache)
+	}
 	iamRepoFn := func() (*iam.Repository, error) {
 		return iam.NewRepository(rw, rw, kmsCache)
 	}
 
-	s, err := managed_groups.NewService(oidcRepoFn)
+	s, err := managed_groups.NewService(pwRepoFn, oidcRepoFn, ldapRepoFn, jwtRepoFn)
 	require.NoError(t, err, "Error when getting new managed group service.")
 
 	o, _ := iam.TestScopes(t, iam.TestRepo(t, conn, wrap))
@@ -661,6 +806,15 @@ func TestUpdateOidc(t *testing.T) {
 	oidcRepoFn := func() (*oidc.Repository, error) {
 		return oidc.NewRepository(ctx, rw, rw, kmsCache)
 	}
+	ldapRepoFn := func() (*ldap.Repository, error) {
+		return ldap.NewRepository(ctx, rw, rw, kmsCache)
+	}
+	jwtRepoFn := func() (*jwt.Repository, error) {
+		return jwt.NewRepository(ctx, rw, rw, kmsCache)
+	}
+	pwRepoFn := func() (*password.Repository, error) {
+		return password.NewRepository(rw, rw, kmsCache)
+	}
 	iamRepoFn := func() (*iam.Repository, error) {
 		return iam.NewRepository(rw, rw, kmsCache)
 	}
@@ -676,7 +830,7 @@ func TestUpdateOidc(t *testing.T) {
 		oidc.WithSigningAlgs(oidc.RS256),
 		oidc.WithApiUrl(oidc.TestConvertToUrls(t, "https://www.alice.com/callback")[0]))
 
-	tested, err := managed_groups.NewService(oidcRepoFn)
+	tested, err := managed_groups.NewService(pwRepoFn, oidcRepoFn, ldapRepoFn, jwtRepoFn)
 	require.NoError(t, err, "Error when getting new managed_groups service.")
 
 	defaultScopeInfo := &scopepb.ScopeInfo{Id: o.GetPublicId(), Type: o.GetType(), ParentScopeId: scope.Global.String()}
@@ -1036,3 +1190,83 @@ func TestUpdateOidc(t *testing.T) {
 		})
 	}
 }
+
+func TestPassword(t *testing.T) {
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrap := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, wrap)
+	oidcRepoFn := func() (*oidc.Repository, error) {
+		return oidc.NewRepository(ctx, rw, rw, kmsCache)
+	}
+	ldapRepoFn := func() (*ldap.Repository, error) {
+		return ldap.NewRepository(ctx, rw, rw, kmsCache)
+	}
+	jwtRepoFn := func() (*jwt.Repository, error) {
+		return jwt.NewRepository(ctx, rw, rw, kmsCache)
+	}
+	pwRepoFn := func() (*password.Repository, error) {
+		return password.NewRepository(rw, rw, kmsCache)
+	}
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iam.NewRepository(rw, rw, kmsCache)
+	}
+
+	s, err := managed_groups.NewService(pwRepoFn, oidcRepoFn, ldapRepoFn, jwtRepoFn)
+	require.NoError(t, err, "Error when getting new managed group service.")
+
+	o, _ := iam.TestScopes(t, iam.TestRepo(t, conn, wrap))
+	am := password.TestAuthMethod(t, conn, o.GetPublicId())
+	contractor := password.TestAccount(t, conn, am.GetPublicId(), "contractor",
+		password.WithCustomAttributes(map[string]string{"employment": "contractor"}))
+	employee := password.TestAccount(t, conn, am.GetPublicId(), "employee",
+		password.WithCustomAttributes(map[string]string{"employment": "employee"}))
+	authCtx := auth.DisabledAuthTestContext(iamRepoFn, o.GetPublicId())
+
+	created, err := s.CreateManagedGroup(authCtx, &pbs.CreateManagedGroupRequest{Item: &pb.ManagedGroup{
+		AuthMethodId: am.GetPublicId(),
+		Name:         &wrapperspb.StringValue{Value: "contractors"},
+		Attrs: &pb.ManagedGroup_PasswordManagedGroupAttributes{
+			PasswordManagedGroupAttributes: &pb.PasswordManagedGroupAttributes{
+				Filter: `"/account/custom_attributes/employment" == "contractor"`,
+			},
+		},
+	}})
+	require.NoError(t, err)
+	id := created.GetItem().GetId()
+	assert.True(t, strings.HasPrefix(id, intglobals.PasswordManagedGroupPrefix+"_"))
+	assert.Equal(t, password.Subtype.String(), created.GetItem().GetType())
+	assert.Equal(t, `"/account/custom_attributes/employment" == "contractor"`, created.GetItem().GetPasswordManagedGroupAttributes().GetFilter())
+
+	got, err := s.GetManagedGroup(authCtx, &pbs.GetManagedGroupRequest{Id: id})
+	require.NoError(t, err)
+	assert.Equal(t, []string{contractor.GetPublicId()}, got.GetItem().GetMemberIds())
+
+	_, err = s.UpdateManagedGroup(authCtx, &pbs.UpdateManagedGroupRequest{
+		Id:         id,
+		UpdateMask: &field_mask.FieldMask{Paths: []string{"attributes.filter"}},
+		Item: &pb.ManagedGroup{
+			Version: got.GetItem().GetVersion(),
+			Attrs: &pb.ManagedGroup_PasswordManagedGroupAttributes{
+				PasswordManagedGroupAttributes: &pb.PasswordManagedGroupAttributes{
+					Filter: `"/account/login_name" == "employee"`,
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+	got, err = s.GetManagedGroup(authCtx, &pbs.GetManagedGroupRequest{Id: id})
+	require.NoError(t, err)
+	assert.Equal(t, []string{employee.GetPublicId()}, got.GetItem().GetMemberIds())
+
+	list, err := s.ListManagedGroups(authCtx, &pbs.ListManagedGroupsRequest{AuthMethodId: am.GetPublicId()})
+	require.NoError(t, err)
+	require.Len(t, list.GetItems(), 1)
+	assert.Equal(t, id, list.GetItems()[0].GetId())
+
+	_, err = s.DeleteManagedGroup(authCtx, &pbs.DeleteManagedGroupRequest{Id: id})
+	require.NoError(t, err)
+	_, err = s.GetManagedGroup(authCtx, &pbs.GetManagedGroupRequest{Id: id})
+	assert.True(t, errors.Is(err, handlers.ApiErrorWithCode(codes.NotFound)))
+}
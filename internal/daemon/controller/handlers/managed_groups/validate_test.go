@@ -79,6 +79,58 @@ func TestValidateCreateRequest(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "mismatched password authmethod type",
+			item: &pb.ManagedGroup{
+				Type:         oidc.Subtype.String(),
+				AuthMethodId: password.AuthMethodPrefix + "_1234567890",
+			},
+			errContains: fieldError(globals.TypeField, "Doesn't match the parent resource's type."),
+		},
+		{
+			name: "missing password attributes",
+			item: &pb.ManagedGroup{
+				Type:         password.Subtype.String(),
+				AuthMethodId: password.AuthMethodPrefix + "_1234567890",
+			},
+			errContains: fieldError(globals.AttributesField, "Attribute fields is required."),
+		},
+		{
+			name: "missing password filter",
+			item: &pb.ManagedGroup{
+				Type:         password.Subtype.String(),
+				AuthMethodId: password.AuthMethodPrefix + "_1234567890",
+				Attrs: &pb.ManagedGroup_PasswordManagedGroupAttributes{
+					PasswordManagedGroupAttributes: &pb.PasswordManagedGroupAttributes{},
+				},
+			},
+			errContains: fieldError(attrFilterField, "This field is required."),
+		},
+		{
+			name: "bad password filter",
+			item: &pb.ManagedGroup{
+				Type:         password.Subtype.String(),
+				AuthMethodId: password.AuthMethodPrefix + "_1234567890",
+				Attrs: &pb.ManagedGroup_PasswordManagedGroupAttributes{
+					PasswordManagedGroupAttributes: &pb.PasswordManagedGroupAttributes{
+						Filter: "foobar",
+					},
+				},
+			},
+			errContains: "Error evaluating submitted filter",
+		},
+		{
+			name: "no password errors",
+			item: &pb.ManagedGroup{
+				Type:         password.Subtype.String(),
+				AuthMethodId: password.AuthMethodPrefix + "_1234567890",
+				Attrs: &pb.ManagedGroup_PasswordManagedGroupAttributes{
+					PasswordManagedGroupAttributes: &pb.PasswordManagedGroupAttributes{
+						Filter: `"/account/custom_attributes/employment" == "contractor"`,
+					},
+				},
+			},
+		},
 	}
 	for _, tc := range cases {
 		tc := tc // capture range variable
@@ -113,6 +165,35 @@ func TestValidateUpdateRequest(t *testing.T) {
 			},
 			errContains: fieldError(globals.TypeField, "Cannot modify the resource type."),
 		},
+		{
+			name: "password empty filter",
+			req: &pbs.UpdateManagedGroupRequest{
+				Id:         intglobals.PasswordManagedGroupPrefix + "_1234567890",
+				UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{attrFilterField}},
+				Item: &pb.ManagedGroup{
+					Version: 1,
+					Attrs: &pb.ManagedGroup_PasswordManagedGroupAttributes{
+						PasswordManagedGroupAttributes: &pb.PasswordManagedGroupAttributes{},
+					},
+				},
+			},
+			errContains: fieldError(attrFilterField, "Field cannot be empty."),
+		},
+		{
+			name: "password no error",
+			req: &pbs.UpdateManagedGroupRequest{
+				Id:         intglobals.PasswordManagedGroupPrefix + "_1234567890",
+				UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{attrFilterField}},
+				Item: &pb.ManagedGroup{
+					Version: 1,
+					Attrs: &pb.ManagedGroup_PasswordManagedGroupAttributes{
+						PasswordManagedGroupAttributes: &pb.PasswordManagedGroupAttributes{
+							Filter: `"/account/login_name" matches "^contractor-"`,
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "no error",
 			req: &pbs.UpdateManagedGroupRequest{
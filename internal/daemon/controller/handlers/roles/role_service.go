@@ -6,19 +6,25 @@ package roles
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/hashicorp/boundary/globals"
 	"github.com/hashicorp/boundary/internal/daemon/controller/auth"
 	"github.com/hashicorp/boundary/internal/daemon/controller/common"
 	"github.com/hashicorp/boundary/internal/daemon/controller/common/scopeids"
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers"
+	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/targets"
+	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/workers"
+	"github.com/hashicorp/boundary/internal/db"
 	"github.com/hashicorp/boundary/internal/errors"
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
 	"github.com/hashicorp/boundary/internal/iam"
 	"github.com/hashicorp/boundary/internal/iam/store"
 	"github.com/hashicorp/boundary/internal/intglobals"
+	"github.com/hashicorp/boundary/internal/pagination"
 	"github.com/hashicorp/boundary/internal/perms"
 	"github.com/hashicorp/boundary/internal/requests"
+	"github.com/hashicorp/boundary/internal/target"
 	"github.com/hashicorp/boundary/internal/types/action"
 	"github.com/hashicorp/boundary/internal/types/resource"
 	"github.com/hashicorp/boundary/internal/types/scope"
@@ -44,6 +50,9 @@ var (
 		action.AddGrants,
 		action.SetGrants,
 		action.RemoveGrants,
+		action.AddGrantScopes,
+		action.SetGrantScopes,
+		action.RemoveGrantScopes,
 	}
 
 	// CollectionActions contains the set of actions that can be performed on
@@ -51,6 +60,7 @@ var (
 	CollectionActions = action.ActionSet{
 		action.Create,
 		action.List,
+		action.Explain,
 	}
 )
 
@@ -65,18 +75,28 @@ func init() {
 type Service struct {
 	pbs.UnsafeRoleServiceServer
 
-	repoFn common.IamRepoFactory
+	repoFn        common.IamRepoFactory
+	targetRepoFn  target.RepositoryFactory
+	serversRepoFn common.ServersRepoFactory
 }
 
 var _ pbs.RoleServiceServer = (*Service)(nil)
 
 // NewService returns a role service which handles role related requests to boundary.
-func NewService(repo common.IamRepoFactory) (Service, error) {
+// The target and servers repositories are used to look up the resources that
+// actions are explained for.
+func NewService(repo common.IamRepoFactory, targetRepoFn target.RepositoryFactory, serversRepoFn common.ServersRepoFactory) (Service, error) {
 	const op = "roles.NewService"
 	if repo == nil {
 		return Service{}, errors.NewDeprecated(errors.InvalidParameter, op, "missing iam repository")
 	}
-	return Service{repoFn: repo}, nil
+	if targetRepoFn == nil {
+		return Service{}, errors.NewDeprecated(errors.InvalidParameter, op, "missing target repository")
+	}
+	if serversRepoFn == nil {
+		return Service{}, errors.NewDeprecated(errors.InvalidParameter, op, "missing servers repository")
+	}
+	return Service{repoFn: repo, targetRepoFn: targetRepoFn, serversRepoFn: serversRepoFn}, nil
 }
 
 // ListRoles implements the interface pbs.RoleServiceServer.
@@ -108,28 +128,46 @@ func (s Service) ListRoles(ctx context.Context, req *pbs.ListRolesRequest) (*pbs
 		return &pbs.ListRolesResponse{}, nil
 	}
 
-	items, err := s.listFromRepo(ctx, scopeIds)
+	repo, err := s.repoFn()
 	if err != nil {
 		return nil, err
 	}
-	if len(items) == 0 {
-		return &pbs.ListRolesResponse{}, nil
-	}
 
 	filter, err := handlers.NewFilter(req.GetFilter())
 	if err != nil {
 		return nil, err
 	}
-	finalItems := make([]*pb.Role, 0, len(items))
-	res := perms.Resource{
-		Type: resource.Role,
+
+	// grantScopes holds the grant scopes of the roles of the current page,
+	// keyed by role ID.
+	grantScopes := map[string][]*iam.RoleGrantScope{}
+	listItemsFn := func(ctx context.Context, page *db.Page, limit int) ([]*iam.Role, error) {
+		roles, err := repo.ListRoles(ctx, scopeIds, iam.WithPage(page), iam.WithLimit(limit))
+		if err != nil || len(roles) == 0 {
+			return roles, err
+		}
+		roleIds := make([]string, 0, len(roles))
+		for _, r := range roles {
+			roleIds = append(roleIds, r.GetPublicId())
+		}
+		roleGrantScopes, err := repo.ListRoleGrantScopes(ctx, roleIds, iam.WithLimit(-1))
+		if err != nil {
+			return nil, err
+		}
+		for _, rgs := range roleGrantScopes {
+			grantScopes[rgs.GetRoleId()] = append(grantScopes[rgs.GetRoleId()], rgs)
+		}
+		return roles, nil
 	}
-	for _, item := range items {
-		res.Id = item.GetPublicId()
-		res.ScopeId = item.GetScopeId()
+	convertItemFn := func(ctx context.Context, item *iam.Role) (*pb.Role, bool, error) {
+		res := perms.Resource{
+			Id:      item.GetPublicId(),
+			ScopeId: item.GetScopeId(),
+			Type:    resource.Role,
+		}
 		authorizedActions := authResults.FetchActionSetForId(ctx, item.GetPublicId(), IdActions, auth.WithResource(&res)).Strings()
 		if len(authorizedActions) == 0 {
-			continue
+			return nil, false, nil
 		}
 
 		outputFields := authResults.FetchOutputFields(res, action.List).SelfOrDefaults(authResults.UserId)
@@ -142,16 +180,40 @@ func (s Service) ListRoles(ctx context.Context, req *pbs.ListRolesRequest) (*pbs
 			outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authorizedActions))
 		}
 
-		item, err := toProto(ctx, item, nil, nil, outputOpts...)
+		pbItem, err := toProto(ctx, item, nil, nil, grantScopes[item.GetPublicId()], outputOpts...)
 		if err != nil {
-			return nil, err
+			return nil, false, err
 		}
+		return pbItem, filter.Match(pbItem), nil
+	}
 
-		if filter.Match(item) {
-			finalItems = append(finalItems, item)
+	listDeletedIdsFn := func(ctx context.Context, since time.Time) ([]string, error) {
+		deleted, err := repo.ListDeletedRoleIds(ctx, scopeIds, since)
+		if err != nil {
+			return nil, err
 		}
+		return authResults.AuthorizedDeletedIds(ctx, perms.Resource{Type: resource.Role}, IdActions, deleted), nil
+	}
+	listReq := pagination.Request{
+		ResourceType: resource.Role,
+		GrantsHash:   authResults.GrantsHash(),
+		PageSize:     req.GetPageSize(),
+		ListToken:    req.GetListToken(),
+	}
+	page, err := pagination.List(ctx, listReq, listItemsFn, convertItemFn, listDeletedIdsFn)
+	if err != nil {
+		return nil, err
 	}
-	return &pbs.ListRolesResponse{Items: finalItems}, nil
+
+	resp := &pbs.ListRolesResponse{Items: page.Items}
+	if listReq.Paginated() {
+		resp.ResponseType = page.ResponseType()
+		resp.ListToken = page.ListToken
+		resp.SortBy = page.SortBy
+		resp.SortDir = pagination.SortDirAscending
+		resp.RemovedIds = page.RemovedIds
+	}
+	return resp, nil
 }
 
 // GetRoles implements the interface pbs.RoleServiceServer.
@@ -165,7 +227,7 @@ func (s Service) GetRole(ctx context.Context, req *pbs.GetRoleRequest) (*pbs.Get
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
-	r, prs, rgs, err := s.getFromRepo(ctx, req.GetId())
+	r, prs, rgs, rgss, err := s.getFromRepo(ctx, req.GetId())
 	if err != nil {
 		return nil, err
 	}
@@ -184,7 +246,7 @@ func (s Service) GetRole(ctx context.Context, req *pbs.GetRoleRequest) (*pbs.Get
 		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, r.GetPublicId(), IdActions).Strings()))
 	}
 
-	item, err := toProto(ctx, r, prs, rgs, outputOpts...)
+	item, err := toProto(ctx, r, prs, rgs, rgss, outputOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -203,7 +265,7 @@ func (s Service) CreateRole(ctx context.Context, req *pbs.CreateRoleRequest) (*p
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
-	r, err := s.createInRepo(ctx, authResults.Scope.GetId(), req.GetItem())
+	r, rgss, err := s.createInRepo(ctx, authResults.Scope.GetId(), req.GetItem())
 	if err != nil {
 		return nil, err
 	}
@@ -222,7 +284,7 @@ func (s Service) CreateRole(ctx context.Context, req *pbs.CreateRoleRequest) (*p
 		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, r.GetPublicId(), IdActions).Strings()))
 	}
 
-	item, err := toProto(ctx, r, nil, nil, outputOpts...)
+	item, err := toProto(ctx, r, nil, nil, rgss, outputOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -241,7 +303,7 @@ func (s Service) UpdateRole(ctx context.Context, req *pbs.UpdateRoleRequest) (*p
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
-	r, prs, rgs, err := s.updateInRepo(ctx, authResults.Scope.GetId(), req.GetId(), req.GetUpdateMask().GetPaths(), req.GetItem())
+	r, prs, rgs, rgss, err := s.updateInRepo(ctx, authResults.Scope.GetId(), req.GetId(), req.GetUpdateMask().GetPaths(), req.GetItem())
 	if err != nil {
 		return nil, err
 	}
@@ -260,7 +322,7 @@ func (s Service) UpdateRole(ctx context.Context, req *pbs.UpdateRoleRequest) (*p
 		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, r.GetPublicId(), IdActions).Strings()))
 	}
 
-	item, err := toProto(ctx, r, prs, rgs, outputOpts...)
+	item, err := toProto(ctx, r, prs, rgs, rgss, outputOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -295,7 +357,7 @@ func (s Service) AddRolePrincipals(ctx context.Context, req *pbs.AddRolePrincipa
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
-	r, prs, rgs, err := s.addPrinciplesInRepo(ctx, req.GetId(), req.GetPrincipalIds(), req.GetVersion())
+	r, prs, rgs, rgss, err := s.addPrinciplesInRepo(ctx, req.GetId(), req.GetPrincipalIds(), req.GetVersion())
 	if err != nil {
 		return nil, err
 	}
@@ -314,7 +376,7 @@ func (s Service) AddRolePrincipals(ctx context.Context, req *pbs.AddRolePrincipa
 		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, r.GetPublicId(), IdActions).Strings()))
 	}
 
-	item, err := toProto(ctx, r, prs, rgs, outputOpts...)
+	item, err := toProto(ctx, r, prs, rgs, rgss, outputOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -333,7 +395,7 @@ func (s Service) SetRolePrincipals(ctx context.Context, req *pbs.SetRolePrincipa
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
-	r, prs, rgs, err := s.setPrinciplesInRepo(ctx, req.GetId(), req.GetPrincipalIds(), req.GetVersion())
+	r, prs, rgs, rgss, err := s.setPrinciplesInRepo(ctx, req.GetId(), req.GetPrincipalIds(), req.GetVersion())
 	if err != nil {
 		return nil, err
 	}
@@ -352,7 +414,7 @@ func (s Service) SetRolePrincipals(ctx context.Context, req *pbs.SetRolePrincipa
 		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, r.GetPublicId(), IdActions).Strings()))
 	}
 
-	item, err := toProto(ctx, r, prs, rgs, outputOpts...)
+	item, err := toProto(ctx, r, prs, rgs, rgss, outputOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -371,7 +433,7 @@ func (s Service) RemoveRolePrincipals(ctx context.Context, req *pbs.RemoveRolePr
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
-	r, prs, rgs, err := s.removePrinciplesInRepo(ctx, req.GetId(), req.GetPrincipalIds(), req.GetVersion())
+	r, prs, rgs, rgss, err := s.removePrinciplesInRepo(ctx, req.GetId(), req.GetPrincipalIds(), req.GetVersion())
 	if err != nil {
 		return nil, err
 	}
@@ -390,7 +452,7 @@ func (s Service) RemoveRolePrincipals(ctx context.Context, req *pbs.RemoveRolePr
 		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, r.GetPublicId(), IdActions).Strings()))
 	}
 
-	item, err := toProto(ctx, r, prs, rgs, outputOpts...)
+	item, err := toProto(ctx, r, prs, rgs, rgss, outputOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -409,7 +471,7 @@ func (s Service) AddRoleGrants(ctx context.Context, req *pbs.AddRoleGrantsReques
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
-	r, prs, rgs, err := s.addGrantsInRepo(ctx, req.GetId(), req.GetGrantStrings(), req.GetVersion())
+	r, prs, rgs, rgss, err := s.addGrantsInRepo(ctx, req.GetId(), req.GetGrantStrings(), req.GetVersion())
 	if err != nil {
 		return nil, err
 	}
@@ -428,7 +490,7 @@ func (s Service) AddRoleGrants(ctx context.Context, req *pbs.AddRoleGrantsReques
 		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, r.GetPublicId(), IdActions).Strings()))
 	}
 
-	item, err := toProto(ctx, r, prs, rgs, outputOpts...)
+	item, err := toProto(ctx, r, prs, rgs, rgss, outputOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -447,7 +509,7 @@ func (s Service) SetRoleGrants(ctx context.Context, req *pbs.SetRoleGrantsReques
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
-	r, prs, rgs, err := s.setGrantsInRepo(ctx, req.GetId(), req.GetGrantStrings(), req.GetVersion())
+	r, prs, rgs, rgss, err := s.setGrantsInRepo(ctx, req.GetId(), req.GetGrantStrings(), req.GetVersion())
 	if err != nil {
 		return nil, err
 	}
@@ -466,7 +528,7 @@ func (s Service) SetRoleGrants(ctx context.Context, req *pbs.SetRoleGrantsReques
 		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, r.GetPublicId(), IdActions).Strings()))
 	}
 
-	item, err := toProto(ctx, r, prs, rgs, outputOpts...)
+	item, err := toProto(ctx, r, prs, rgs, rgss, outputOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -485,7 +547,7 @@ func (s Service) RemoveRoleGrants(ctx context.Context, req *pbs.RemoveRoleGrants
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
-	r, prs, rgs, err := s.removeGrantsInRepo(ctx, req.GetId(), req.GetGrantStrings(), req.GetVersion())
+	r, prs, rgs, rgss, err := s.removeGrantsInRepo(ctx, req.GetId(), req.GetGrantStrings(), req.GetVersion())
 	if err != nil {
 		return nil, err
 	}
@@ -504,7 +566,7 @@ func (s Service) RemoveRoleGrants(ctx context.Context, req *pbs.RemoveRoleGrants
 		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, r.GetPublicId(), IdActions).Strings()))
 	}
 
-	item, err := toProto(ctx, r, prs, rgs, outputOpts...)
+	item, err := toProto(ctx, r, prs, rgs, rgss, outputOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -512,25 +574,171 @@ func (s Service) RemoveRoleGrants(ctx context.Context, req *pbs.RemoveRoleGrants
 	return &pbs.RemoveRoleGrantsResponse{Item: item}, nil
 }
 
-func (s Service) getFromRepo(ctx context.Context, id string) (*iam.Role, []*iam.PrincipalRole, []*iam.RoleGrant, error) {
+// AddRoleGrantScopes implements the interface pbs.RoleServiceServer.
+func (s Service) AddRoleGrantScopes(ctx context.Context, req *pbs.AddRoleGrantScopesRequest) (*pbs.AddRoleGrantScopesResponse, error) {
+	const op = "roles.(Service).AddRoleGrantScopes"
+
+	if err := validateAddRoleGrantScopesRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.AddGrantScopes)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	r, prs, rgs, rgss, err := s.addGrantScopesInRepo(ctx, req.GetId(), req.GetGrantScopeIds(), req.GetVersion())
+	if err != nil {
+		return nil, err
+	}
+
+	outputFields, ok := requests.OutputFields(ctx)
+	if !ok {
+		return nil, errors.New(ctx, errors.Internal, op, "no request context found")
+	}
+
+	outputOpts := make([]handlers.Option, 0, 3)
+	outputOpts = append(outputOpts, handlers.WithOutputFields(outputFields))
+	if outputFields.Has(globals.ScopeField) {
+		outputOpts = append(outputOpts, handlers.WithScope(authResults.Scope))
+	}
+	if outputFields.Has(globals.AuthorizedActionsField) {
+		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, r.GetPublicId(), IdActions).Strings()))
+	}
+
+	item, err := toProto(ctx, r, prs, rgs, rgss, outputOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pbs.AddRoleGrantScopesResponse{Item: item}, nil
+}
+
+// SetRoleGrantScopes implements the interface pbs.RoleServiceServer.
+func (s Service) SetRoleGrantScopes(ctx context.Context, req *pbs.SetRoleGrantScopesRequest) (*pbs.SetRoleGrantScopesResponse, error) {
+	const op = "roles.(Service).SetRoleGrantScopes"
+
+	if err := validateSetRoleGrantScopesRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.SetGrantScopes)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	r, prs, rgs, rgss, err := s.setGrantScopesInRepo(ctx, req.GetId(), req.GetGrantScopeIds(), req.GetVersion())
+	if err != nil {
+		return nil, err
+	}
+
+	outputFields, ok := requests.OutputFields(ctx)
+	if !ok {
+		return nil, errors.New(ctx, errors.Internal, op, "no request context found")
+	}
+
+	outputOpts := make([]handlers.Option, 0, 3)
+	outputOpts = append(outputOpts, handlers.WithOutputFields(outputFields))
+	if outputFields.Has(globals.ScopeField) {
+		outputOpts = append(outputOpts, handlers.WithScope(authResults.Scope))
+	}
+	if outputFields.Has(globals.AuthorizedActionsField) {
+		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, r.GetPublicId(), IdActions).Strings()))
+	}
+
+	item, err := toProto(ctx, r, prs, rgs, rgss, outputOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pbs.SetRoleGrantScopesResponse{Item: item}, nil
+}
+
+// RemoveRoleGrantScopes implements the interface pbs.RoleServiceServer.
+func (s Service) RemoveRoleGrantScopes(ctx context.Context, req *pbs.RemoveRoleGrantScopesRequest) (*pbs.RemoveRoleGrantScopesResponse, error) {
+	const op = "roles.(Service).RemoveRoleGrantScopes"
+
+	if err := validateRemoveRoleGrantScopesRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.RemoveGrantScopes)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	r, prs, rgs, rgss, err := s.removeGrantScopesInRepo(ctx, req.GetId(), req.GetGrantScopeIds(), req.GetVersion())
+	if err != nil {
+		return nil, err
+	}
+
+	outputFields, ok := requests.OutputFields(ctx)
+	if !ok {
+		return nil, errors.New(ctx, errors.Internal, op, "no request context found")
+	}
+
+	outputOpts := make([]handlers.Option, 0, 3)
+	outputOpts = append(outputOpts, handlers.WithOutputFields(outputFields))
+	if outputFields.Has(globals.ScopeField) {
+		outputOpts = append(outputOpts, handlers.WithScope(authResults.Scope))
+	}
+	if outputFields.Has(globals.AuthorizedActionsField) {
+		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, r.GetPublicId(), IdActions).Strings()))
+	}
+
+	item, err := toProto(ctx, r, prs, rgs, rgss, outputOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pbs.RemoveRoleGrantScopesResponse{Item: item}, nil
+}
+
+// Explain implements the interface pbs.RoleServiceServer.
+func (s Service) Explain(ctx context.Context, req *pbs.ExplainRequest) (*pbs.ExplainResponse, error) {
+	const op = "roles.(Service).Explain"
+
+	if err := validateExplainRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetScopeId(), action.Explain)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	if err := s.checkExplainedUser(ctx, authResults, req.GetUserId()); err != nil {
+		return nil, err
+	}
+	res, err := s.explainedResource(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	e, err := authResults.Explain(ctx, req.GetUserId(), req.GetAccountId(), *res, action.Map[req.GetAction()])
+	if err != nil {
+		switch {
+		case errors.IsNotFoundError(err):
+			return nil, handlers.NotFoundErrorf("User %q doesn't exist.", req.GetUserId())
+		case errors.Match(errors.T(errors.InvalidParameter), err):
+			return nil, handlers.InvalidArgumentErrorf("Error in provided request.",
+				map[string]string{"account_id": "Must be one of the user's accounts."})
+		}
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return &pbs.ExplainResponse{Item: explanationToProto(e)}, nil
+}
+
+func (s Service) getFromRepo(ctx context.Context, id string) (*iam.Role, []*iam.PrincipalRole, []*iam.RoleGrant, []*iam.RoleGrantScope, error) {
 	repo, err := s.repoFn()
 	if err != nil {
-		return nil, nil, nil, err
+		return nil, nil, nil, nil, err
 	}
-	out, pr, roleGrants, err := repo.LookupRole(ctx, id)
+	out, pr, roleGrants, roleGrantScopes, err := repo.LookupRole(ctx, id)
 	if err != nil {
 		if errors.IsNotFoundError(err) {
-			return nil, nil, nil, handlers.NotFoundErrorf("Role %q doesn't exist.", id)
+			return nil, nil, nil, nil, handlers.NotFoundErrorf("Role %q doesn't exist.", id)
 		}
-		return nil, nil, nil, err
+		return nil, nil, nil, nil, err
 	}
 	if out == nil {
-		return nil, nil, nil, handlers.NotFoundErrorf("Role %q doesn't exist.", id)
+		return nil, nil, nil, nil, handlers.NotFoundErrorf("Role %q doesn't exist.", id)
 	}
-	return out, pr, roleGrants, nil
+	return out, pr, roleGrants, roleGrantScopes, nil
 }
 
-func (s Service) createInRepo(ctx context.Context, scopeId string, item *pb.Role) (*iam.Role, error) {
+func (s Service) createInRepo(ctx context.Context, scopeId string, item *pb.Role) (*iam.Role, []*iam.RoleGrantScope, error) {
 	const op = "roles.(Service).createInRepo"
 	var opts []iam.Option
 	if item.GetName() != nil {
@@ -540,27 +748,33 @@ func (s Service) createInRepo(ctx context.Context, scopeId string, item *pb.Role
 		opts = append(opts, iam.WithDescription(item.GetDescription().GetValue()))
 	}
 	if item.GetGrantScopeId() != nil {
-		opts = append(opts, iam.WithGrantScopeId(item.GetGrantScopeId().GetValue()))
+		opts = append(opts, iam.WithGrantScopeIds([]string{item.GetGrantScopeId().GetValue()}))
+	}
+	if item.GetMaxSessionsPerUser() != nil {
+		opts = append(opts, iam.WithMaxSessionsPerUser(item.GetMaxSessionsPerUser().GetValue()))
+	}
+	if item.GetMaxSessionsPerTarget() != nil {
+		opts = append(opts, iam.WithMaxSessionsPerTarget(item.GetMaxSessionsPerTarget().GetValue()))
 	}
 	u, err := iam.NewRole(scopeId, opts...)
 	if err != nil {
-		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to build role for creation: %v.", err)
+		return nil, nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to build role for creation: %v.", err)
 	}
 	repo, err := s.repoFn()
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-	out, err := repo.CreateRole(ctx, u)
+	out, grantScopes, err := repo.CreateRole(ctx, u, opts...)
 	if err != nil {
-		return nil, errors.Wrap(ctx, err, op)
+		return nil, nil, errors.Wrap(ctx, err, op)
 	}
 	if out == nil {
-		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to create role but no error returned from repository.")
+		return nil, nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to create role but no error returned from repository.")
 	}
-	return out, nil
+	return out, grantScopes, nil
 }
 
-func (s Service) updateInRepo(ctx context.Context, scopeId, id string, mask []string, item *pb.Role) (*iam.Role, []*iam.PrincipalRole, []*iam.RoleGrant, error) {
+func (s Service) updateInRepo(ctx context.Context, scopeId, id string, mask []string, item *pb.Role) (*iam.Role, []*iam.PrincipalRole, []*iam.RoleGrant, []*iam.RoleGrantScope, error) {
 	const op = "roles.(Service).updateInRepo"
 	var opts []iam.Option
 	if desc := item.GetDescription(); desc != nil {
@@ -569,32 +783,66 @@ func (s Service) updateInRepo(ctx context.Context, scopeId, id string, mask []st
 	if name := item.GetName(); name != nil {
 		opts = append(opts, iam.WithName(name.GetValue()))
 	}
-	if grantScopeId := item.GetGrantScopeId(); grantScopeId != nil {
-		opts = append(opts, iam.WithGrantScopeId(grantScopeId.GetValue()))
+	if maxSessionsPerUser := item.GetMaxSessionsPerUser(); maxSessionsPerUser != nil {
+		opts = append(opts, iam.WithMaxSessionsPerUser(maxSessionsPerUser.GetValue()))
+	}
+	if maxSessionsPerTarget := item.GetMaxSessionsPerTarget(); maxSessionsPerTarget != nil {
+		opts = append(opts, iam.WithMaxSessionsPerTarget(maxSessionsPerTarget.GetValue()))
 	}
 	version := item.GetVersion()
 
 	u, err := iam.NewRole(scopeId, opts...)
 	if err != nil {
-		return nil, nil, nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to build role for update: %v.", err)
+		return nil, nil, nil, nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to build role for update: %v.", err)
 	}
 	u.PublicId = id
-	dbMask := maskManager.Translate(mask)
-	if len(dbMask) == 0 {
-		return nil, nil, nil, handlers.InvalidArgumentErrorf("No valid fields included in the update mask.", map[string]string{"update_mask": "No valid fields provided in the update mask."})
+	// The deprecated grant_scope_id field is no longer stored on the role, it
+	// replaces the grant scopes of the role instead.
+	var setGrantScope bool
+	roleMask := make([]string, 0, len(mask))
+	for _, f := range mask {
+		if f == globals.GrantScopeIdField {
+			setGrantScope = true
+			continue
+		}
+		roleMask = append(roleMask, f)
+	}
+	dbMask := maskManager.Translate(roleMask)
+	if len(dbMask) == 0 && !setGrantScope {
+		return nil, nil, nil, nil, handlers.InvalidArgumentErrorf("No valid fields included in the update mask.", map[string]string{"update_mask": "No valid fields provided in the update mask."})
 	}
 	repo, err := s.repoFn()
 	if err != nil {
-		return nil, nil, nil, err
+		return nil, nil, nil, nil, err
 	}
-	out, pr, gr, rowsUpdated, err := repo.UpdateRole(ctx, u, version, dbMask)
+	if len(dbMask) > 0 {
+		_, _, _, _, rowsUpdated, err := repo.UpdateRole(ctx, u, version, dbMask)
+		if err != nil {
+			return nil, nil, nil, nil, errors.Wrap(ctx, err, op)
+		}
+		if rowsUpdated == 0 {
+			return nil, nil, nil, nil, handlers.NotFoundErrorf("Role %q doesn't exist or incorrect version provided.", id)
+		}
+		version++
+	}
+	if setGrantScope {
+		// Clearing the field resets the grant scope to the role's own scope.
+		grantScopeIds := []string{globals.GrantScopeThis}
+		if grantScopeId := item.GetGrantScopeId(); grantScopeId != nil {
+			grantScopeIds = []string{grantScopeId.GetValue()}
+		}
+		if _, _, err := repo.SetRoleGrantScopes(ctx, id, version, grantScopeIds); err != nil {
+			return nil, nil, nil, nil, errors.Wrap(ctx, err, op)
+		}
+	}
+	out, pr, gr, gs, err := repo.LookupRole(ctx, id)
 	if err != nil {
-		return nil, nil, nil, errors.Wrap(ctx, err, op)
+		return nil, nil, nil, nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to look up role after update"))
 	}
-	if rowsUpdated == 0 {
-		return nil, nil, nil, handlers.NotFoundErrorf("Role %q doesn't exist or incorrect version provided.", id)
+	if out == nil {
+		return nil, nil, nil, nil, handlers.NotFoundErrorf("Role %q doesn't exist or incorrect version provided.", id)
 	}
-	return out, pr, gr, nil
+	return out, pr, gr, gs, nil
 }
 
 func (s Service) deleteFromRepo(ctx context.Context, id string) (bool, error) {
@@ -613,107 +861,95 @@ func (s Service) deleteFromRepo(ctx context.Context, id string) (bool, error) {
 	return rows > 0, nil
 }
 
-func (s Service) listFromRepo(ctx context.Context, scopeIds []string) ([]*iam.Role, error) {
-	repo, err := s.repoFn()
-	if err != nil {
-		return nil, err
-	}
-	rl, err := repo.ListRoles(ctx, scopeIds)
-	if err != nil {
-		return nil, err
-	}
-	return rl, nil
-}
-
-func (s Service) addPrinciplesInRepo(ctx context.Context, roleId string, principalIds []string, version uint32) (*iam.Role, []*iam.PrincipalRole, []*iam.RoleGrant, error) {
+func (s Service) addPrinciplesInRepo(ctx context.Context, roleId string, principalIds []string, version uint32) (*iam.Role, []*iam.PrincipalRole, []*iam.RoleGrant, []*iam.RoleGrantScope, error) {
 	const op = "roles.(Service).addPrincpleInRepo"
 	repo, err := s.repoFn()
 	if err != nil {
-		return nil, nil, nil, err
+		return nil, nil, nil, nil, err
 	}
 	_, err = repo.AddPrincipalRoles(ctx, roleId, version, strutil.RemoveDuplicates(principalIds, false))
 	if err != nil {
 		// TODO: Figure out a way to surface more helpful error info beyond the Internal error.
-		return nil, nil, nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to add principals to role: %v.", err)
+		return nil, nil, nil, nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to add principals to role: %v.", err)
 	}
-	out, pr, roleGrants, err := repo.LookupRole(ctx, roleId)
+	out, pr, roleGrants, roleGrantScopes, err := repo.LookupRole(ctx, roleId)
 	if err != nil {
-		return nil, nil, nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to look up role after adding principals"))
+		return nil, nil, nil, nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to look up role after adding principals"))
 	}
 	if out == nil {
-		return nil, nil, nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to lookup role after adding principals to it.")
+		return nil, nil, nil, nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to lookup role after adding principals to it.")
 	}
-	return out, pr, roleGrants, nil
+	return out, pr, roleGrants, roleGrantScopes, nil
 }
 
-func (s Service) setPrinciplesInRepo(ctx context.Context, roleId string, principalIds []string, version uint32) (*iam.Role, []*iam.PrincipalRole, []*iam.RoleGrant, error) {
+func (s Service) setPrinciplesInRepo(ctx context.Context, roleId string, principalIds []string, version uint32) (*iam.Role, []*iam.PrincipalRole, []*iam.RoleGrant, []*iam.RoleGrantScope, error) {
 	const op = "roles.(Service).setPrinciplesInRepo"
 	repo, err := s.repoFn()
 	if err != nil {
-		return nil, nil, nil, err
+		return nil, nil, nil, nil, err
 	}
 	_, _, err = repo.SetPrincipalRoles(ctx, roleId, version, strutil.RemoveDuplicates(principalIds, false))
 	if err != nil {
 		// TODO: Figure out a way to surface more helpful error info beyond the Internal error.
-		return nil, nil, nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to set principals on role: %v.", err)
+		return nil, nil, nil, nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to set principals on role: %v.", err)
 	}
-	out, pr, roleGrants, err := repo.LookupRole(ctx, roleId)
+	out, pr, roleGrants, roleGrantScopes, err := repo.LookupRole(ctx, roleId)
 	if err != nil {
-		return nil, nil, nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to look up role after setting principals"))
+		return nil, nil, nil, nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to look up role after setting principals"))
 	}
 	if out == nil {
-		return nil, nil, nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to lookup role after setting principals for it.")
+		return nil, nil, nil, nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to lookup role after setting principals for it.")
 	}
-	return out, pr, roleGrants, nil
+	return out, pr, roleGrants, roleGrantScopes, nil
 }
 
-func (s Service) removePrinciplesInRepo(ctx context.Context, roleId string, principalIds []string, version uint32) (*iam.Role, []*iam.PrincipalRole, []*iam.RoleGrant, error) {
+func (s Service) removePrinciplesInRepo(ctx context.Context, roleId string, principalIds []string, version uint32) (*iam.Role, []*iam.PrincipalRole, []*iam.RoleGrant, []*iam.RoleGrantScope, error) {
 	const op = "roles.(Service).removePrinciplesInRepo"
 	repo, err := s.repoFn()
 	if err != nil {
-		return nil, nil, nil, err
+		return nil, nil, nil, nil, err
 	}
 	_, err = repo.DeletePrincipalRoles(ctx, roleId, version, strutil.RemoveDuplicates(principalIds, false))
 	if err != nil {
 		// TODO: Figure out a way to surface more helpful error info beyond the Internal error.
-		return nil, nil, nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to remove principals from role: %v.", err)
+		return nil, nil, nil, nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to remove principals from role: %v.", err)
 	}
-	out, pr, roleGrants, err := repo.LookupRole(ctx, roleId)
+	out, pr, roleGrants, roleGrantScopes, err := repo.LookupRole(ctx, roleId)
 	if err != nil {
-		return nil, nil, nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to look up role after removing principals"))
+		return nil, nil, nil, nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to look up role after removing principals"))
 	}
 	if out == nil {
-		return nil, nil, nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to lookup role after removing principals from it.")
+		return nil, nil, nil, nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to lookup role after removing principals from it.")
 	}
-	return out, pr, roleGrants, nil
+	return out, pr, roleGrants, roleGrantScopes, nil
 }
 
-func (s Service) addGrantsInRepo(ctx context.Context, roleId string, grants []string, version uint32) (*iam.Role, []*iam.PrincipalRole, []*iam.RoleGrant, error) {
+func (s Service) addGrantsInRepo(ctx context.Context, roleId string, grants []string, version uint32) (*iam.Role, []*iam.PrincipalRole, []*iam.RoleGrant, []*iam.RoleGrantScope, error) {
 	const op = "service.(Service).addGrantsInRepo"
 	repo, err := s.repoFn()
 	if err != nil {
-		return nil, nil, nil, err
+		return nil, nil, nil, nil, err
 	}
 	_, err = repo.AddRoleGrants(ctx, roleId, version, strutil.RemoveDuplicates(grants, false))
 	if err != nil {
 		// TODO: Figure out a way to surface more helpful error info beyond the Internal error.
-		return nil, nil, nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to add grants to role: %v.", err)
+		return nil, nil, nil, nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to add grants to role: %v.", err)
 	}
-	out, pr, roleGrants, err := repo.LookupRole(ctx, roleId)
+	out, pr, roleGrants, roleGrantScopes, err := repo.LookupRole(ctx, roleId)
 	if err != nil {
-		return nil, nil, nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to look up role after adding grants"))
+		return nil, nil, nil, nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to look up role after adding grants"))
 	}
 	if out == nil {
-		return nil, nil, nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to lookup role after adding grants to it.")
+		return nil, nil, nil, nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to lookup role after adding grants to it.")
 	}
-	return out, pr, roleGrants, nil
+	return out, pr, roleGrants, roleGrantScopes, nil
 }
 
-func (s Service) setGrantsInRepo(ctx context.Context, roleId string, grants []string, version uint32) (*iam.Role, []*iam.PrincipalRole, []*iam.RoleGrant, error) {
+func (s Service) setGrantsInRepo(ctx context.Context, roleId string, grants []string, version uint32) (*iam.Role, []*iam.PrincipalRole, []*iam.RoleGrant, []*iam.RoleGrantScope, error) {
 	const op = "roles.(Service).setGrantsInRepo"
 	repo, err := s.repoFn()
 	if err != nil {
-		return nil, nil, nil, err
+		return nil, nil, nil, nil, err
 	}
 	// If no grant was provided, we clear the grants.
 	if grants == nil {
@@ -722,37 +958,236 @@ func (s Service) setGrantsInRepo(ctx context.Context, roleId string, grants []st
 	_, _, err = repo.SetRoleGrants(ctx, roleId, version, strutil.RemoveDuplicates(grants, false))
 	if err != nil {
 		// TODO: Figure out a way to surface more helpful error info beyond the Internal error.
-		return nil, nil, nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to set grants on role: %v.", err)
+		return nil, nil, nil, nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to set grants on role: %v.", err)
 	}
-	out, pr, roleGrants, err := repo.LookupRole(ctx, roleId)
+	out, pr, roleGrants, roleGrantScopes, err := repo.LookupRole(ctx, roleId)
 	if err != nil {
-		return nil, nil, nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to look up role after setting grants"))
+		return nil, nil, nil, nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to look up role after setting grants"))
 	}
 	if out == nil {
-		return nil, nil, nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to lookup role after setting grants on it.")
+		return nil, nil, nil, nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to lookup role after setting grants on it.")
 	}
-	return out, pr, roleGrants, nil
+	return out, pr, roleGrants, roleGrantScopes, nil
 }
 
-func (s Service) removeGrantsInRepo(ctx context.Context, roleId string, grants []string, version uint32) (*iam.Role, []*iam.PrincipalRole, []*iam.RoleGrant, error) {
+func (s Service) removeGrantsInRepo(ctx context.Context, roleId string, grants []string, version uint32) (*iam.Role, []*iam.PrincipalRole, []*iam.RoleGrant, []*iam.RoleGrantScope, error) {
 	const op = "roles.(Service).removeGrantsInRepo"
 	repo, err := s.repoFn()
 	if err != nil {
-		return nil, nil, nil, err
+		return nil, nil, nil, nil, err
 	}
 	_, err = repo.DeleteRoleGrants(ctx, roleId, version, strutil.RemoveDuplicates(grants, false))
 	if err != nil {
 		// TODO: Figure out a way to surface more helpful error info beyond the Internal error.
-		return nil, nil, nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to remove grants from role: %v", err)
+		return nil, nil, nil, nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to remove grants from role: %v", err)
 	}
-	out, pr, roleGrants, err := repo.LookupRole(ctx, roleId)
+	out, pr, roleGrants, roleGrantScopes, err := repo.LookupRole(ctx, roleId)
 	if err != nil {
-		return nil, nil, nil, errors.Wrap(ctx, err, op, errors.WithMsg("uable to look up role after removing grant"))
+		return nil, nil, nil, nil, errors.Wrap(ctx, err, op, errors.WithMsg("uable to look up role after removing grant"))
 	}
 	if out == nil {
-		return nil, nil, nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to lookup role after removing grants from it.")
+		return nil, nil, nil, nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to lookup role after removing grants from it.")
 	}
-	return out, pr, roleGrants, nil
+	return out, pr, roleGrants, roleGrantScopes, nil
+}
+
+func (s Service) addGrantScopesInRepo(ctx context.Context, roleId string, grantScopes []string, version uint32) (*iam.Role, []*iam.PrincipalRole, []*iam.RoleGrant, []*iam.RoleGrantScope, error) {
+	const op = "roles.(Service).addGrantScopesInRepo"
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+	_, err = repo.AddRoleGrantScopes(ctx, roleId, version, strutil.RemoveDuplicates(grantScopes, false))
+	if err != nil {
+		// TODO: Figure out a way to surface more helpful error info beyond the Internal error.
+		return nil, nil, nil, nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to add grant scopes to role: %v.", err)
+	}
+	out, pr, roleGrants, roleGrantScopes, err := repo.LookupRole(ctx, roleId)
+	if err != nil {
+		return nil, nil, nil, nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to look up role after adding grant scopes"))
+	}
+	if out == nil {
+		return nil, nil, nil, nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to lookup role after adding grant scopes to it.")
+	}
+	return out, pr, roleGrants, roleGrantScopes, nil
+}
+
+func (s Service) setGrantScopesInRepo(ctx context.Context, roleId string, grantScopes []string, version uint32) (*iam.Role, []*iam.PrincipalRole, []*iam.RoleGrant, []*iam.RoleGrantScope, error) {
+	const op = "roles.(Service).setGrantScopesInRepo"
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+	// If no grant scope was provided, we clear the grant scopes.
+	if grantScopes == nil {
+		grantScopes = []string{}
+	}
+	_, _, err = repo.SetRoleGrantScopes(ctx, roleId, version, strutil.RemoveDuplicates(grantScopes, false))
+	if err != nil {
+		// TODO: Figure out a way to surface more helpful error info beyond the Internal error.
+		return nil, nil, nil, nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to set grant scopes on role: %v.", err)
+	}
+	out, pr, roleGrants, roleGrantScopes, err := repo.LookupRole(ctx, roleId)
+	if err != nil {
+		return nil, nil, nil, nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to look up role after setting grant scopes"))
+	}
+	if out == nil {
+		return nil, nil, nil, nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to lookup role after setting grant scopes on it.")
+	}
+	return out, pr, roleGrants, roleGrantScopes, nil
+}
+
+func (s Service) removeGrantScopesInRepo(ctx context.Context, roleId string, grantScopes []string, version uint32) (*iam.Role, []*iam.PrincipalRole, []*iam.RoleGrant, []*iam.RoleGrantScope, error) {
+	const op = "roles.(Service).removeGrantScopesInRepo"
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+	_, err = repo.DeleteRoleGrantScopes(ctx, roleId, version, strutil.RemoveDuplicates(grantScopes, false))
+	if err != nil {
+		// TODO: Figure out a way to surface more helpful error info beyond the Internal error.
+		return nil, nil, nil, nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to remove grant scopes from role: %v", err)
+	}
+	out, pr, roleGrants, roleGrantScopes, err := repo.LookupRole(ctx, roleId)
+	if err != nil {
+		return nil, nil, nil, nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to look up role after removing grant scopes"))
+	}
+	if out == nil {
+		return nil, nil, nil, nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to lookup role after removing grant scopes from it.")
+	}
+	return out, pr, roleGrants, roleGrantScopes, nil
+}
+
+// checkExplainedUser checks that the caller can read the user whose
+// authorization is explained, so that explaining cannot be used to see the
+// grants of users outside of the caller's reach. Users the caller cannot read
+// are reported as not found.
+func (s Service) checkExplainedUser(ctx context.Context, authResults auth.VerifyResults, userId string) error {
+	repo, err := s.repoFn()
+	if err != nil {
+		return err
+	}
+	u, _, err := repo.LookupUser(ctx, userId)
+	if err != nil {
+		return err
+	}
+	if u == nil {
+		return handlers.NotFoundErrorf("User %q doesn't exist.", userId)
+	}
+	res := perms.Resource{Id: u.GetPublicId(), ScopeId: u.GetScopeId(), Type: resource.User}
+	if !authResults.FetchActionSetForId(ctx, u.GetPublicId(), action.ActionSet{action.Read}, auth.WithResource(&res)).HasAction(action.Read) {
+		return handlers.NotFoundErrorf("User %q doesn't exist.", userId)
+	}
+	return nil
+}
+
+// explainedResource returns the resource an action is explained for. A
+// specific resource is looked up so that its scope, which must be the scope of
+// the request, and the attributes that resource filters are evaluated against
+// are set as they are when verifying requests for it. Resources outside of the
+// scope of the request are reported as not found.
+func (s Service) explainedResource(ctx context.Context, req *pbs.ExplainRequest) (*perms.Resource, error) {
+	res := &perms.Resource{
+		ScopeId: req.GetScopeId(),
+		Id:      req.GetResourceId(),
+		Type:    resource.Map[req.GetResourceType()],
+		Pin:     req.GetPin(),
+	}
+	if res.Id == "" {
+		return res, nil
+	}
+
+	var scopeId string
+	var found bool
+	switch res.Type {
+	case resource.Scope:
+		if res.Id == scope.Global.String() {
+			// As in Verify, actions on the global scope are made in the
+			// global scope
+			found, scopeId = true, scope.Global.String()
+			break
+		}
+		repo, err := s.repoFn()
+		if err != nil {
+			return nil, err
+		}
+		scp, err := repo.LookupScope(ctx, res.Id)
+		if err != nil {
+			return nil, err
+		}
+		if found = scp != nil; found {
+			scopeId = scp.GetParentId()
+		}
+	case resource.User:
+		repo, err := s.repoFn()
+		if err != nil {
+			return nil, err
+		}
+		u, _, err := repo.LookupUser(ctx, res.Id)
+		if err != nil {
+			return nil, err
+		}
+		if found = u != nil; found {
+			scopeId = u.GetScopeId()
+		}
+	case resource.Group:
+		repo, err := s.repoFn()
+		if err != nil {
+			return nil, err
+		}
+		g, _, err := repo.LookupGroup(ctx, res.Id)
+		if err != nil {
+			return nil, err
+		}
+		if found = g != nil; found {
+			scopeId = g.GetScopeId()
+		}
+	case resource.Role:
+		repo, err := s.repoFn()
+		if err != nil {
+			return nil, err
+		}
+		r, _, _, _, err := repo.LookupRole(ctx, res.Id)
+		if err != nil {
+			return nil, err
+		}
+		if found = r != nil; found {
+			scopeId = r.GetScopeId()
+		}
+	case resource.Target:
+		repo, err := s.targetRepoFn()
+		if err != nil {
+			return nil, err
+		}
+		t, _, _, err := repo.LookupTarget(ctx, res.Id)
+		if err != nil {
+			return nil, err
+		}
+		if found = t != nil; found {
+			scopeId = t.GetProjectId()
+			res.Data = targets.ResourceData(t)
+		}
+	case resource.Worker:
+		repo, err := s.serversRepoFn()
+		if err != nil {
+			return nil, err
+		}
+		w, err := repo.LookupWorker(ctx, res.Id)
+		if err != nil {
+			return nil, err
+		}
+		if found = w != nil; found {
+			scopeId = w.GetScopeId()
+			res.Data = workers.ResourceData(w)
+		}
+	default:
+		return nil, handlers.InvalidArgumentErrorf("Error in provided request.",
+			map[string]string{"resource_id": fmt.Sprintf("Explaining actions on a specific %s is not supported; leave empty to explain actions on the collection.", res.Type.String())})
+	}
+	if !found || scopeId != req.GetScopeId() {
+		return nil, handlers.NotFoundErrorf("Resource %q doesn't exist in scope %q.", res.Id, req.GetScopeId())
+	}
+	return res, nil
 }
 
 func (s Service) authResult(ctx context.Context, id string, a action.Type) auth.VerifyResults {
@@ -766,7 +1201,7 @@ func (s Service) authResult(ctx context.Context, id string, a action.Type) auth.
 	var parentId string
 	opts := []auth.Option{auth.WithType(resource.Role), auth.WithAction(a)}
 	switch a {
-	case action.List, action.Create:
+	case action.List, action.Create, action.Explain:
 		parentId = id
 		scp, err := repo.LookupScope(ctx, parentId)
 		if err != nil {
@@ -778,7 +1213,7 @@ func (s Service) authResult(ctx context.Context, id string, a action.Type) auth.
 			return res
 		}
 	default:
-		r, _, _, err := repo.LookupRole(ctx, id)
+		r, _, _, _, err := repo.LookupRole(ctx, id)
 		if err != nil {
 			res.Error = err
 			return res
@@ -794,7 +1229,7 @@ func (s Service) authResult(ctx context.Context, id string, a action.Type) auth.
 	return auth.Verify(ctx, opts...)
 }
 
-func toProto(ctx context.Context, in *iam.Role, principals []*iam.PrincipalRole, grants []*iam.RoleGrant, opt ...handlers.Option) (*pb.Role, error) {
+func toProto(ctx context.Context, in *iam.Role, principals []*iam.PrincipalRole, grants []*iam.RoleGrant, grantScopes []*iam.RoleGrantScope, opt ...handlers.Option) (*pb.Role, error) {
 	opts := handlers.GetOpts(opt...)
 	if opts.WithOutputFields == nil {
 		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "output fields not found when building role proto")
@@ -829,8 +1264,26 @@ func toProto(ctx context.Context, in *iam.Role, principals []*iam.PrincipalRole,
 	if outputFields.Has(globals.AuthorizedActionsField) {
 		out.AuthorizedActions = opts.WithAuthorizedActions
 	}
-	if outputFields.Has(globals.GrantScopeIdField) && in.GetGrantScopeId() != "" {
-		out.GrantScopeId = &wrapperspb.StringValue{Value: in.GetGrantScopeId()}
+	if outputFields.Has(globals.GrantScopeIdField) && len(grantScopes) == 1 {
+		// The deprecated field can only represent a single scope ID
+		switch gs := grantScopes[0].GetScopeIdOrSpecial(); gs {
+		case globals.GrantScopeThis:
+			out.GrantScopeId = &wrapperspb.StringValue{Value: in.GetScopeId()}
+		case globals.GrantScopeChildren, globals.GrantScopeDescendants:
+		default:
+			out.GrantScopeId = &wrapperspb.StringValue{Value: gs}
+		}
+	}
+	if outputFields.Has(globals.GrantScopeIdsField) {
+		for _, gs := range grantScopes {
+			out.GrantScopeIds = append(out.GrantScopeIds, gs.GetScopeIdOrSpecial())
+		}
+	}
+	if outputFields.Has(globals.MaxSessionsPerUserField) && in.GetMaxSessionsPerUser() > 0 {
+		out.MaxSessionsPerUser = &wrapperspb.UInt32Value{Value: in.GetMaxSessionsPerUser()}
+	}
+	if outputFields.Has(globals.MaxSessionsPerTargetField) && in.GetMaxSessionsPerTarget() > 0 {
+		out.MaxSessionsPerTarget = &wrapperspb.UInt32Value{Value: in.GetMaxSessionsPerTarget()}
 	}
 	if outputFields.Has(globals.PrincipalIdsField) {
 		for _, p := range principals {
@@ -854,7 +1307,7 @@ func toProto(ctx context.Context, in *iam.Role, principals []*iam.PrincipalRole,
 	}
 	if outputFields.Has(globals.GrantsField) {
 		for _, g := range grants {
-			parsed, err := perms.Parse(in.GetGrantScopeId(), g.GetRawGrant())
+			parsed, err := perms.Parse(in.GetScopeId(), g.GetRawGrant())
 			if err != nil {
 				// This should never happen as we validate on the way in, but let's
 				// return what we can since we are still returning the raw grant
@@ -869,9 +1322,16 @@ func toProto(ctx context.Context, in *iam.Role, principals []*iam.PrincipalRole,
 					Raw:       g.GetRawGrant(),
 					Canonical: g.GetCanonicalGrant(),
 					Json: &pb.GrantJson{
-						Id:      parsed.Id(),
-						Type:    parsed.Type().String(),
-						Actions: actions,
+						Id:             parsed.Id(),
+						Type:           parsed.Type().String(),
+						Actions:        actions,
+						Deny:           parsed.Deny(),
+						Days:           parsed.Days(),
+						Hours:          parsed.Hours(),
+						TimeZone:       parsed.TimeZone(),
+						SourceCidrs:    parsed.SourceCidrs(),
+						Filter:         parsed.Filter(),
+						ResourceFilter: parsed.ResourceFilter(),
 					},
 				})
 			}
@@ -886,6 +1346,23 @@ func toProto(ctx context.Context, in *iam.Role, principals []*iam.PrincipalRole,
 //   - The path passed in is correctly formatted
 //   - All required parameters are set
 //   - There are no conflicting parameters provided
+func explanationToProto(in *auth.Explanation) *pb.Explanation {
+	out := &pb.Explanation{
+		Authorized: in.Authorized,
+		UserId:     in.UserId,
+		AccountId:  in.AccountId,
+	}
+	for _, g := range in.Grants {
+		out.Grants = append(out.Grants, &pb.ExplainedGrant{
+			RoleId:       g.RoleId,
+			GrantScopeId: g.GrantScopeId,
+			Grant:        g.Grant,
+			Deny:         g.Deny,
+		})
+	}
+	return out
+}
+
 func validateGetRequest(req *pbs.GetRoleRequest) error {
 	return handlers.ValidateGetRequest(handlers.NoopValidatorFn, req, iam.RolePrefix)
 }
@@ -974,8 +1451,12 @@ func validateAddRolePrincipalsRequest(req *pbs.AddRolePrincipalsRequest) error {
 	for _, id := range req.GetPrincipalIds() {
 		if !handlers.ValidId(handlers.Id(id), iam.GroupPrefix) &&
 			!handlers.ValidId(handlers.Id(id), iam.UserPrefix) &&
-			!handlers.ValidId(handlers.Id(id), intglobals.OidcManagedGroupPrefix) {
-			badFields["principal_ids"] = "Must only have valid user, group, and/or managed group ids."
+			!handlers.ValidId(handlers.Id(id), iam.ServiceAccountPrefix) &&
+			!handlers.ValidId(handlers.Id(id), intglobals.OidcManagedGroupPrefix) &&
+			!handlers.ValidId(handlers.Id(id), intglobals.LdapManagedGroupPrefix) &&
+			!handlers.ValidId(handlers.Id(id), intglobals.JwtManagedGroupPrefix) &&
+			!handlers.ValidId(handlers.Id(id), intglobals.PasswordManagedGroupPrefix) {
+			badFields["principal_ids"] = "Must only have valid user, service account, group, and/or managed group ids."
 			break
 		}
 		if id == globals.RecoveryUserId {
@@ -1000,8 +1481,12 @@ func validateSetRolePrincipalsRequest(req *pbs.SetRolePrincipalsRequest) error {
 	for _, id := range req.GetPrincipalIds() {
 		if !handlers.ValidId(handlers.Id(id), iam.GroupPrefix) &&
 			!handlers.ValidId(handlers.Id(id), iam.UserPrefix) &&
-			!handlers.ValidId(handlers.Id(id), intglobals.OidcManagedGroupPrefix) {
-			badFields["principal_ids"] = "Must only have valid user, group, and/or managed group ids."
+			!handlers.ValidId(handlers.Id(id), iam.ServiceAccountPrefix) &&
+			!handlers.ValidId(handlers.Id(id), intglobals.OidcManagedGroupPrefix) &&
+			!handlers.ValidId(handlers.Id(id), intglobals.LdapManagedGroupPrefix) &&
+			!handlers.ValidId(handlers.Id(id), intglobals.JwtManagedGroupPrefix) &&
+			!handlers.ValidId(handlers.Id(id), intglobals.PasswordManagedGroupPrefix) {
+			badFields["principal_ids"] = "Must only have valid user, service account, group, and/or managed group ids."
 			break
 		}
 		if id == globals.RecoveryUserId {
@@ -1029,8 +1514,12 @@ func validateRemoveRolePrincipalsRequest(req *pbs.RemoveRolePrincipalsRequest) e
 	for _, id := range req.GetPrincipalIds() {
 		if !handlers.ValidId(handlers.Id(id), iam.GroupPrefix) &&
 			!handlers.ValidId(handlers.Id(id), iam.UserPrefix) &&
-			!handlers.ValidId(handlers.Id(id), intglobals.OidcManagedGroupPrefix) {
-			badFields["principal_ids"] = "Must only have valid user, group, and/or managed group ids."
+			!handlers.ValidId(handlers.Id(id), iam.ServiceAccountPrefix) &&
+			!handlers.ValidId(handlers.Id(id), intglobals.OidcManagedGroupPrefix) &&
+			!handlers.ValidId(handlers.Id(id), intglobals.LdapManagedGroupPrefix) &&
+			!handlers.ValidId(handlers.Id(id), intglobals.JwtManagedGroupPrefix) &&
+			!handlers.ValidId(handlers.Id(id), intglobals.PasswordManagedGroupPrefix) {
+			badFields["principal_ids"] = "Must only have valid user, service account, group, and/or managed group ids."
 			break
 		}
 	}
@@ -1132,3 +1621,101 @@ func validateRemoveRoleGrantsRequest(req *pbs.RemoveRoleGrantsRequest) error {
 	}
 	return nil
 }
+
+func validateAddRoleGrantScopesRequest(req *pbs.AddRoleGrantScopesRequest) error {
+	badFields := map[string]string{}
+	if !handlers.ValidId(handlers.Id(req.GetId()), iam.RolePrefix) {
+		badFields["id"] = "Incorrectly formatted identifier."
+	}
+	if req.GetVersion() == 0 {
+		badFields["version"] = "Required field."
+	}
+	if len(req.GetGrantScopeIds()) == 0 {
+		badFields["grant_scope_ids"] = "Must be non-empty."
+	}
+	validateGrantScopeIds(req.GetGrantScopeIds(), badFields)
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Errors in provided fields.", badFields)
+	}
+	return nil
+}
+
+func validateSetRoleGrantScopesRequest(req *pbs.SetRoleGrantScopesRequest) error {
+	badFields := map[string]string{}
+	if !handlers.ValidId(handlers.Id(req.GetId()), iam.RolePrefix) {
+		badFields["id"] = "Incorrectly formatted identifier."
+	}
+	if req.GetVersion() == 0 {
+		badFields["version"] = "Required field."
+	}
+	validateGrantScopeIds(req.GetGrantScopeIds(), badFields)
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Errors in provided fields.", badFields)
+	}
+	return nil
+}
+
+func validateRemoveRoleGrantScopesRequest(req *pbs.RemoveRoleGrantScopesRequest) error {
+	badFields := map[string]string{}
+	if !handlers.ValidId(handlers.Id(req.GetId()), iam.RolePrefix) {
+		badFields["id"] = "Incorrectly formatted identifier."
+	}
+	if req.GetVersion() == 0 {
+		badFields["version"] = "Required field."
+	}
+	if len(req.GetGrantScopeIds()) == 0 {
+		badFields["grant_scope_ids"] = "Must be non-empty."
+	}
+	validateGrantScopeIds(req.GetGrantScopeIds(), badFields)
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Errors in provided fields.", badFields)
+	}
+	return nil
+}
+
+// validateGrantScopeIds adds an entry to badFields if any of the grant scope
+// ids is neither a special grant scope value nor a scope id.
+func validateGrantScopeIds(grantScopeIds []string, badFields map[string]string) {
+	for _, v := range grantScopeIds {
+		switch {
+		case v == globals.GrantScopeThis,
+			v == globals.GrantScopeChildren,
+			v == globals.GrantScopeDescendants,
+			v == scope.Global.String(),
+			handlers.ValidId(handlers.Id(v), scope.Org.Prefix()),
+			handlers.ValidId(handlers.Id(v), scope.Project.Prefix()):
+		default:
+			badFields["grant_scope_ids"] = fmt.Sprintf("Improperly formatted grant scope id %q.", v)
+			return
+		}
+	}
+}
+
+func validateExplainRequest(req *pbs.ExplainRequest) error {
+	badFields := map[string]string{}
+	if !handlers.ValidId(handlers.Id(req.GetScopeId()), scope.Org.Prefix()) &&
+		!handlers.ValidId(handlers.Id(req.GetScopeId()), scope.Project.Prefix()) &&
+		req.GetScopeId() != scope.Global.String() {
+		badFields["scope_id"] = "Improperly formatted field."
+	}
+	switch {
+	case req.GetUserId() == "":
+		badFields["user_id"] = "Required field."
+	case req.GetUserId() == globals.RecoveryUserId:
+		badFields["user_id"] = "The recovery user cannot be explained."
+	case !handlers.ValidId(handlers.Id(req.GetUserId()), iam.UserPrefix):
+		badFields["user_id"] = "Improperly formatted identifier."
+	}
+	switch resource.Map[req.GetResourceType()] {
+	case resource.Unknown, resource.All:
+		badFields["resource_type"] = "Must be a known resource type."
+	}
+	switch action.Map[req.GetAction()] {
+	case action.Unknown, action.All:
+		badFields["action"] = "Must be a known action."
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Errors in provided fields.", badFields)
+	}
+	return nil
+}
@@ -13,14 +13,22 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/hashicorp/boundary/globals"
 	"github.com/hashicorp/boundary/internal/auth/oidc"
+	"github.com/hashicorp/boundary/internal/authtoken"
 	"github.com/hashicorp/boundary/internal/daemon/controller/auth"
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers"
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/roles"
 	"github.com/hashicorp/boundary/internal/db"
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
+	authpb "github.com/hashicorp/boundary/internal/gen/controller/auth"
 	"github.com/hashicorp/boundary/internal/iam"
 	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/pagination"
 	"github.com/hashicorp/boundary/internal/perms"
+	"github.com/hashicorp/boundary/internal/requests"
+	"github.com/hashicorp/boundary/internal/server"
+	"github.com/hashicorp/boundary/internal/target"
+	"github.com/hashicorp/boundary/internal/target/tcp"
+	"github.com/hashicorp/boundary/internal/types/resource"
 	"github.com/hashicorp/boundary/internal/types/scope"
 	pb "github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/roles"
 	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/scopes"
@@ -37,7 +45,18 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
-var testAuthorizedActions = []string{"no-op", "read", "update", "delete", "add-principals", "set-principals", "remove-principals", "add-grants", "set-grants", "remove-grants"}
+var testAuthorizedActions = []string{"no-op", "read", "update", "delete", "add-principals", "set-principals", "remove-principals", "add-grants", "set-grants", "remove-grants", "add-grant-scopes", "set-grant-scopes", "remove-grant-scopes"}
+
+// noTargetRepoFn and noServersRepoFn stand in for the repositories of tests
+// which do not explain actions on targets or workers.
+var (
+	noTargetRepoFn = func(...target.Option) (*target.Repository, error) {
+		return nil, errors.New("no target repository")
+	}
+	noServersRepoFn = func() (*server.Repository, error) {
+		return nil, errors.New("no servers repository")
+	}
+)
 
 func createDefaultRolesAndRepo(t *testing.T) (*iam.Role, *iam.Role, func() (*iam.Repository, error)) {
 	t.Helper()
@@ -49,7 +68,7 @@ func createDefaultRolesAndRepo(t *testing.T) (*iam.Role, *iam.Role, func() (*iam
 	}
 
 	o, p := iam.TestScopes(t, iamRepo)
-	or := iam.TestRole(t, conn, o.GetPublicId(), iam.WithDescription("default"), iam.WithName("default"), iam.WithGrantScopeId(p.GetPublicId()))
+	or := iam.TestRole(t, conn, o.GetPublicId(), iam.WithDescription("default"), iam.WithName("default"), iam.WithGrantScopeIds([]string{p.GetPublicId()}))
 	pr := iam.TestRole(t, conn, p.GetPublicId(), iam.WithDescription("default"), iam.WithName("default"))
 	return or, pr, repoFn
 }
@@ -91,7 +110,8 @@ func TestGet(t *testing.T) {
 		Scope:             &scopes.ScopeInfo{Id: or.GetScopeId(), Type: scope.Org.String(), ParentScopeId: scope.Global.String()},
 		Name:              &wrapperspb.StringValue{Value: or.GetName()},
 		Description:       &wrapperspb.StringValue{Value: or.GetDescription()},
-		GrantScopeId:      &wrapperspb.StringValue{Value: pr.GetGrantScopeId()},
+		GrantScopeId:      &wrapperspb.StringValue{Value: pr.GetScopeId()},
+		GrantScopeIds:     []string{pr.GetScopeId()},
 		CreatedTime:       or.CreateTime.GetTimestamp(),
 		UpdatedTime:       or.UpdateTime.GetTimestamp(),
 		Version:           or.GetVersion(),
@@ -104,7 +124,8 @@ func TestGet(t *testing.T) {
 		Scope:             &scopes.ScopeInfo{Id: pr.GetScopeId(), Type: scope.Project.String(), ParentScopeId: or.GetScopeId()},
 		Name:              &wrapperspb.StringValue{Value: pr.GetName()},
 		Description:       &wrapperspb.StringValue{Value: pr.GetDescription()},
-		GrantScopeId:      &wrapperspb.StringValue{Value: pr.GetGrantScopeId()},
+		GrantScopeId:      &wrapperspb.StringValue{Value: pr.GetScopeId()},
+		GrantScopeIds:     []string{globals.GrantScopeThis},
 		CreatedTime:       pr.CreateTime.GetTimestamp(),
 		UpdatedTime:       pr.UpdateTime.GetTimestamp(),
 		Version:           pr.GetVersion(),
@@ -175,7 +196,7 @@ func TestGet(t *testing.T) {
 			req := proto.Clone(toMerge).(*pbs.GetRoleRequest)
 			proto.Merge(req, tc.req)
 
-			s, err := roles.NewService(repoFn)
+			s, err := roles.NewService(repoFn, noTargetRepoFn, noServersRepoFn)
 			require.NoError(err, "Couldn't create new role service.")
 
 			got, gErr := s.GetRole(auth.DisabledAuthTestContext(repoFn, tc.scopeId), req)
@@ -208,7 +229,8 @@ func TestList(t *testing.T) {
 			Scope:             &scopes.ScopeInfo{Id: or.GetScopeId(), Type: scope.Org.String(), ParentScopeId: scope.Global.String()},
 			CreatedTime:       or.GetCreateTime().GetTimestamp(),
 			UpdatedTime:       or.GetUpdateTime().GetTimestamp(),
-			GrantScopeId:      &wrapperspb.StringValue{Value: or.GetGrantScopeId()},
+			GrantScopeId:      &wrapperspb.StringValue{Value: or.GetScopeId()},
+			GrantScopeIds:     []string{globals.GrantScopeThis},
 			Version:           or.GetVersion(),
 			AuthorizedActions: testAuthorizedActions,
 		})
@@ -220,7 +242,8 @@ func TestList(t *testing.T) {
 			Scope:             &scopes.ScopeInfo{Id: pr.GetScopeId(), Type: scope.Project.String(), ParentScopeId: oNoRoles.GetPublicId()},
 			CreatedTime:       pr.GetCreateTime().GetTimestamp(),
 			UpdatedTime:       pr.GetUpdateTime().GetTimestamp(),
-			GrantScopeId:      &wrapperspb.StringValue{Value: pr.GetGrantScopeId()},
+			GrantScopeId:      &wrapperspb.StringValue{Value: pr.GetScopeId()},
+			GrantScopeIds:     []string{globals.GrantScopeThis},
 			Version:           pr.GetVersion(),
 			AuthorizedActions: testAuthorizedActions,
 		})
@@ -298,7 +321,7 @@ func TestList(t *testing.T) {
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
 			assert, require := assert.New(t), require.New(t)
-			s, err := roles.NewService(repoFn)
+			s, err := roles.NewService(repoFn, noTargetRepoFn, noServersRepoFn)
 			require.NoError(err, "Couldn't create new role service.")
 
 			// Test the non-anon case
@@ -328,10 +351,48 @@ func TestList(t *testing.T) {
 	}
 }
 
+func TestList_Pagination(t *testing.T) {
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrap := db.TestWrapper(t)
+	iamRepo := iam.TestRepo(t, conn, wrap)
+	repoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	s, err := roles.NewService(repoFn, noTargetRepoFn, noServersRepoFn)
+	require.NoError(t, err)
+
+	o, _ := iam.TestScopes(t, iamRepo, iam.WithSkipDefaultRoleCreation(true))
+	// Paginated listings are oldest first
+	var want []string
+	for i := 0; i < 5; i++ {
+		r := iam.TestRole(t, conn, o.GetPublicId())
+		want = append(want, r.GetPublicId())
+	}
+	pagination.TestList(t,
+		auth.DisabledAuthTestContext(repoFn, o.GetPublicId()),
+		auth.DisabledAuthTestContext(repoFn, o.GetPublicId(), auth.WithUserId(globals.AnonymousUserId)),
+		resource.Group,
+		want,
+		func(ctx context.Context, listToken string, pageSize uint32) (pagination.TestListResponse, []string, error) {
+			got, err := s.ListRoles(ctx, &pbs.ListRolesRequest{ScopeId: o.GetPublicId(), ListToken: listToken, PageSize: pageSize})
+			var ids []string
+			for _, item := range got.GetItems() {
+				ids = append(ids, item.GetId())
+			}
+			return got, ids, err
+		},
+		func(t *testing.T, id string) {
+			_, err := iamRepo.DeleteRole(ctx, id)
+			require.NoError(t, err)
+		},
+	)
+}
+
 func TestDelete(t *testing.T) {
 	or, pr, repoFn := createDefaultRolesAndRepo(t)
 
-	s, err := roles.NewService(repoFn)
+	s, err := roles.NewService(repoFn, noTargetRepoFn, noServersRepoFn)
 	require.NoError(t, err, "Error when getting new role service.")
 
 	cases := []struct {
@@ -397,7 +458,7 @@ func TestDelete_twice(t *testing.T) {
 	assert, require := assert.New(t), require.New(t)
 	or, pr, repoFn := createDefaultRolesAndRepo(t)
 
-	s, err := roles.NewService(repoFn)
+	s, err := roles.NewService(repoFn, noTargetRepoFn, noServersRepoFn)
 	require.NoError(err, "Error when getting new role service")
 	req := &pbs.DeleteRoleRequest{
 		Id: or.GetPublicId(),
@@ -447,6 +508,7 @@ func TestCreate(t *testing.T) {
 					Name:              &wrapperspb.StringValue{Value: "name"},
 					Description:       &wrapperspb.StringValue{Value: "desc"},
 					GrantScopeId:      &wrapperspb.StringValue{Value: defaultProjRole.ScopeId},
+					GrantScopeIds:     []string{defaultProjRole.ScopeId},
 					Version:           1,
 					AuthorizedActions: testAuthorizedActions,
 				},
@@ -468,6 +530,7 @@ func TestCreate(t *testing.T) {
 					Name:              &wrapperspb.StringValue{Value: "name"},
 					Description:       &wrapperspb.StringValue{Value: "desc"},
 					GrantScopeId:      &wrapperspb.StringValue{Value: defaultProjRole.ScopeId},
+					GrantScopeIds:     []string{defaultProjRole.ScopeId},
 					Version:           1,
 					AuthorizedActions: testAuthorizedActions,
 				},
@@ -490,6 +553,7 @@ func TestCreate(t *testing.T) {
 					Name:              &wrapperspb.StringValue{Value: "name"},
 					Description:       &wrapperspb.StringValue{Value: "desc"},
 					GrantScopeId:      &wrapperspb.StringValue{Value: defaultProjRole.ScopeId},
+					GrantScopeIds:     []string{globals.GrantScopeThis},
 					Version:           1,
 					AuthorizedActions: testAuthorizedActions,
 				},
@@ -542,7 +606,7 @@ func TestCreate(t *testing.T) {
 			req := proto.Clone(toMerge).(*pbs.CreateRoleRequest)
 			proto.Merge(req, tc.req)
 
-			s, err := roles.NewService(repoFn)
+			s, err := roles.NewService(repoFn, noTargetRepoFn, noServersRepoFn)
 			require.NoError(err, "Error when getting new role service.")
 
 			got, gErr := s.CreateRole(auth.DisabledAuthTestContext(repoFn, tc.req.GetItem().GetScopeId()), req)
@@ -593,7 +657,7 @@ func TestUpdate(t *testing.T) {
 	o, p := iam.TestScopes(t, iamRepo)
 	u := iam.TestUser(t, iamRepo, o.GetPublicId())
 
-	or := iam.TestRole(t, conn, o.GetPublicId(), iam.WithDescription("default"), iam.WithName("default"), iam.WithGrantScopeId(p.GetPublicId()))
+	or := iam.TestRole(t, conn, o.GetPublicId(), iam.WithDescription("default"), iam.WithName("default"))
 	_ = iam.TestRoleGrant(t, conn, or.GetPublicId(), grantString)
 	_ = iam.TestUserRole(t, conn, or.GetPublicId(), u.GetPublicId())
 
@@ -610,7 +674,7 @@ func TestUpdate(t *testing.T) {
 	var orVersion uint32 = 1
 	var prVersion uint32 = 1
 
-	tested, err := roles.NewService(repoFn)
+	tested, err := roles.NewService(repoFn, noTargetRepoFn, noServersRepoFn)
 	require.NoError(t, err, "Error when getting new role service.")
 
 	resetRoles := func(proj bool) {
@@ -618,14 +682,18 @@ func TestUpdate(t *testing.T) {
 		require.NoError(t, err, "Couldn't get a new repo")
 		if proj {
 			prVersion++
-			pr, _, _, _, err = repo.UpdateRole(context.Background(), pr, prVersion, []string{"Name", "Description"})
+			pr, _, _, _, _, err = repo.UpdateRole(context.Background(), pr, prVersion, []string{"Name", "Description"})
 			require.NoError(t, err, "Failed to reset the role")
 			prVersion++
 		} else {
 			orVersion++
-			or, _, _, _, err = repo.UpdateRole(context.Background(), or, orVersion, []string{"Name", "Description"})
+			or, _, _, _, _, err = repo.UpdateRole(context.Background(), or, orVersion, []string{"Name", "Description"})
 			require.NoError(t, err, "Failed to reset the role")
-			orVersion++
+			_, _, err = repo.SetRoleGrantScopes(context.Background(), or.GetPublicId(), orVersion+1, []string{globals.GrantScopeThis})
+			require.NoError(t, err, "Failed to reset the role grant scopes")
+			or, _, _, _, err = repo.LookupRole(context.Background(), or.GetPublicId())
+			require.NoError(t, err, "Failed to look up the reset role")
+			orVersion = or.GetVersion()
 		}
 	}
 
@@ -646,12 +714,11 @@ func TestUpdate(t *testing.T) {
 			scopeId: or.GetScopeId(),
 			req: &pbs.UpdateRoleRequest{
 				UpdateMask: &field_mask.FieldMask{
-					Paths: []string{"name", "description", "grant_scope_id"},
+					Paths: []string{"name", "description"},
 				},
 				Item: &pb.Role{
-					Name:         &wrapperspb.StringValue{Value: "new"},
-					Description:  &wrapperspb.StringValue{Value: "desc"},
-					GrantScopeId: &wrapperspb.StringValue{Value: or.GetScopeId()},
+					Name:        &wrapperspb.StringValue{Value: "new"},
+					Description: &wrapperspb.StringValue{Value: "desc"},
 				},
 			},
 			res: &pbs.UpdateRoleResponse{
@@ -663,6 +730,36 @@ func TestUpdate(t *testing.T) {
 					Description:       &wrapperspb.StringValue{Value: "desc"},
 					CreatedTime:       or.GetCreateTime().GetTimestamp(),
 					GrantScopeId:      &wrapperspb.StringValue{Value: or.GetScopeId()},
+					GrantScopeIds:     []string{globals.GrantScopeThis},
+					GrantStrings:      []string{grant.GetRaw()},
+					Grants:            []*pb.Grant{grant},
+					PrincipalIds:      []string{u.GetPublicId()},
+					Principals:        []*pb.Principal{principal},
+					AuthorizedActions: testAuthorizedActions,
+				},
+			},
+		},
+		{
+			name:    "Update the deprecated grant scope id",
+			scopeId: or.GetScopeId(),
+			req: &pbs.UpdateRoleRequest{
+				UpdateMask: &field_mask.FieldMask{
+					Paths: []string{"grant_scope_id"},
+				},
+				Item: &pb.Role{
+					GrantScopeId: &wrapperspb.StringValue{Value: p.GetPublicId()},
+				},
+			},
+			res: &pbs.UpdateRoleResponse{
+				Item: &pb.Role{
+					Id:                or.GetPublicId(),
+					ScopeId:           or.GetScopeId(),
+					Scope:             &scopes.ScopeInfo{Id: or.GetScopeId(), Type: scope.Org.String(), ParentScopeId: scope.Global.String()},
+					Name:              &wrapperspb.StringValue{Value: "default"},
+					Description:       &wrapperspb.StringValue{Value: "default"},
+					CreatedTime:       or.GetCreateTime().GetTimestamp(),
+					GrantScopeId:      &wrapperspb.StringValue{Value: p.GetPublicId()},
+					GrantScopeIds:     []string{p.GetPublicId()},
 					GrantStrings:      []string{grant.GetRaw()},
 					Grants:            []*pb.Grant{grant},
 					PrincipalIds:      []string{u.GetPublicId()},
@@ -692,6 +789,7 @@ func TestUpdate(t *testing.T) {
 					Description:       &wrapperspb.StringValue{Value: "desc"},
 					CreatedTime:       or.GetCreateTime().GetTimestamp(),
 					GrantScopeId:      &wrapperspb.StringValue{Value: or.GetScopeId()},
+					GrantScopeIds:     []string{globals.GrantScopeThis},
 					GrantStrings:      []string{grant.GetRaw()},
 					Grants:            []*pb.Grant{grant},
 					PrincipalIds:      []string{u.GetPublicId()},
@@ -722,6 +820,7 @@ func TestUpdate(t *testing.T) {
 					Description:       &wrapperspb.StringValue{Value: "desc"},
 					CreatedTime:       pr.GetCreateTime().GetTimestamp(),
 					GrantScopeId:      &wrapperspb.StringValue{Value: pr.GetScopeId()},
+					GrantScopeIds:     []string{globals.GrantScopeThis},
 					GrantStrings:      []string{grant.GetRaw()},
 					Grants:            []*pb.Grant{grant},
 					PrincipalIds:      []string{u.GetPublicId()},
@@ -752,6 +851,7 @@ func TestUpdate(t *testing.T) {
 					Description:       &wrapperspb.StringValue{Value: "desc"},
 					CreatedTime:       pr.GetCreateTime().GetTimestamp(),
 					GrantScopeId:      &wrapperspb.StringValue{Value: pr.GetScopeId()},
+					GrantScopeIds:     []string{globals.GrantScopeThis},
 					GrantStrings:      []string{grant.GetRaw()},
 					Grants:            []*pb.Grant{grant},
 					PrincipalIds:      []string{u.GetPublicId()},
@@ -813,6 +913,7 @@ func TestUpdate(t *testing.T) {
 					Description:       &wrapperspb.StringValue{Value: "default"},
 					CreatedTime:       or.GetCreateTime().GetTimestamp(),
 					GrantScopeId:      &wrapperspb.StringValue{Value: or.GetScopeId()},
+					GrantScopeIds:     []string{globals.GrantScopeThis},
 					GrantStrings:      []string{grant.GetRaw()},
 					Grants:            []*pb.Grant{grant},
 					PrincipalIds:      []string{u.GetPublicId()},
@@ -842,6 +943,7 @@ func TestUpdate(t *testing.T) {
 					Description:       &wrapperspb.StringValue{Value: "default"},
 					CreatedTime:       or.GetCreateTime().GetTimestamp(),
 					GrantScopeId:      &wrapperspb.StringValue{Value: or.GetScopeId()},
+					GrantScopeIds:     []string{globals.GrantScopeThis},
 					GrantStrings:      []string{grant.GetRaw()},
 					Grants:            []*pb.Grant{grant},
 					PrincipalIds:      []string{u.GetPublicId()},
@@ -871,6 +973,7 @@ func TestUpdate(t *testing.T) {
 					Description:       &wrapperspb.StringValue{Value: "notignored"},
 					CreatedTime:       or.GetCreateTime().GetTimestamp(),
 					GrantScopeId:      &wrapperspb.StringValue{Value: or.GetScopeId()},
+					GrantScopeIds:     []string{globals.GrantScopeThis},
 					GrantStrings:      []string{grant.GetRaw()},
 					Grants:            []*pb.Grant{grant},
 					PrincipalIds:      []string{u.GetPublicId()},
@@ -1018,7 +1121,7 @@ func TestAddPrincipal(t *testing.T) {
 		return iamRepo, nil
 	}
 	o, p := iam.TestScopes(t, iamRepo)
-	s, err := roles.NewService(repoFn)
+	s, err := roles.NewService(repoFn, noTargetRepoFn, noServersRepoFn)
 	require.NoError(t, err, "Error when getting new role service.")
 
 	ctx := context.Background()
@@ -1213,7 +1316,7 @@ func TestSetPrincipal(t *testing.T) {
 	repoFn := func() (*iam.Repository, error) {
 		return iamRepo, nil
 	}
-	s, err := roles.NewService(repoFn)
+	s, err := roles.NewService(repoFn, noTargetRepoFn, noServersRepoFn)
 	require.NoError(t, err, "Error when getting new role service.")
 
 	o, p := iam.TestScopes(t, iamRepo)
@@ -1403,7 +1506,7 @@ func TestRemovePrincipal(t *testing.T) {
 	repoFn := func() (*iam.Repository, error) {
 		return iamRepo, nil
 	}
-	s, err := roles.NewService(repoFn)
+	s, err := roles.NewService(repoFn, noTargetRepoFn, noServersRepoFn)
 	require.NoError(t, err, "Error when getting new role service.")
 
 	o, p := iam.TestScopes(t, iamRepo)
@@ -1653,7 +1756,7 @@ func TestAddGrants(t *testing.T) {
 	repoFn := func() (*iam.Repository, error) {
 		return iamRepo, nil
 	}
-	s, err := roles.NewService(repoFn)
+	s, err := roles.NewService(repoFn, noTargetRepoFn, noServersRepoFn)
 	require.NoError(t, err, "Error when getting new role service.")
 
 	addCases := []struct {
@@ -1792,7 +1895,7 @@ func TestSetGrants(t *testing.T) {
 		return iamRepo, nil
 	}
 
-	s, err := roles.NewService(repoFn)
+	s, err := roles.NewService(repoFn, noTargetRepoFn, noServersRepoFn)
 	require.NoError(t, err, "Error when getting new role service.")
 
 	setCases := []struct {
@@ -1928,7 +2031,7 @@ func TestRemoveGrants(t *testing.T) {
 	repoFn := func() (*iam.Repository, error) {
 		return iamRepo, nil
 	}
-	s, err := roles.NewService(repoFn)
+	s, err := roles.NewService(repoFn, noTargetRepoFn, noServersRepoFn)
 	require.NoError(t, err, "Error when getting new role service.")
 
 	removeCases := []struct {
@@ -2056,3 +2159,561 @@ func TestRemoveGrants(t *testing.T) {
 		})
 	}
 }
+
+func TestAddGrantScopes(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	wrap := db.TestWrapper(t)
+	iamRepo := iam.TestRepo(t, conn, wrap)
+	repoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	s, err := roles.NewService(repoFn, noTargetRepoFn, noServersRepoFn)
+	require.NoError(t, err, "Error when getting new role service.")
+
+	o, p := iam.TestScopes(t, iamRepo)
+	addCases := []struct {
+		name     string
+		existing []string
+		add      []string
+		result   []string
+		wantErr  bool
+	}{
+		{
+			name:   "Add children",
+			add:    []string{globals.GrantScopeChildren},
+			result: []string{globals.GrantScopeThis, globals.GrantScopeChildren},
+		},
+		{
+			name:   "Add duplicate project",
+			add:    []string{p.GetPublicId(), p.GetPublicId()},
+			result: []string{globals.GrantScopeThis, p.GetPublicId()},
+		},
+		{
+			name:     "Add this to role without this",
+			existing: []string{p.GetPublicId()},
+			add:      []string{globals.GrantScopeThis},
+			result:   []string{p.GetPublicId(), globals.GrantScopeThis},
+		},
+		{
+			name:    "Add existing grant scope",
+			add:     []string{globals.GrantScopeThis},
+			wantErr: true,
+		},
+		{
+			name:    "Add descendants to org role",
+			add:     []string{globals.GrantScopeDescendants},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range addCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			var opts []iam.Option
+			if tc.existing != nil {
+				opts = append(opts, iam.WithGrantScopeIds(tc.existing))
+			}
+			role := iam.TestRole(t, conn, o.GetPublicId(), opts...)
+			req := &pbs.AddRoleGrantScopesRequest{
+				Id:            role.GetPublicId(),
+				Version:       role.GetVersion(),
+				GrantScopeIds: tc.add,
+			}
+			got, err := s.AddRoleGrantScopes(auth.DisabledAuthTestContext(repoFn, o.GetPublicId()), req)
+			if tc.wantErr {
+				assert.Error(err)
+				return
+			}
+			require.NoError(err)
+			assert.ElementsMatch(tc.result, got.GetItem().GetGrantScopeIds())
+		})
+	}
+
+	role := iam.TestRole(t, conn, o.GetPublicId())
+	failCases := []struct {
+		name string
+		req  *pbs.AddRoleGrantScopesRequest
+		err  error
+	}{
+		{
+			name: "Bad Version",
+			req: &pbs.AddRoleGrantScopesRequest{
+				Id:            role.GetPublicId(),
+				GrantScopeIds: []string{globals.GrantScopeChildren},
+				Version:       role.GetVersion() + 2,
+			},
+			err: handlers.ApiErrorWithCode(codes.Internal),
+		},
+		{
+			name: "Bad Role Id",
+			req: &pbs.AddRoleGrantScopesRequest{
+				Id:            "bad id",
+				GrantScopeIds: []string{globals.GrantScopeChildren},
+				Version:       role.GetVersion(),
+			},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "No Grant Scopes",
+			req: &pbs.AddRoleGrantScopesRequest{
+				Id:      role.GetPublicId(),
+				Version: role.GetVersion(),
+			},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Bad Grant Scope",
+			req: &pbs.AddRoleGrantScopesRequest{
+				Id:            role.GetPublicId(),
+				GrantScopeIds: []string{globals.GrantScopeChildren, "everything"},
+				Version:       role.GetVersion(),
+			},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+	}
+	for _, tc := range failCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			_, gErr := s.AddRoleGrantScopes(auth.DisabledAuthTestContext(repoFn, o.GetPublicId()), tc.req)
+			if tc.err != nil {
+				require.Error(gErr)
+				assert.True(errors.Is(gErr, tc.err), "AddRoleGrantScopes(%+v) got error %#v, wanted %#v", tc.req, gErr, tc.err)
+			}
+		})
+	}
+}
+
+func TestSetGrantScopes(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	wrap := db.TestWrapper(t)
+	iamRepo := iam.TestRepo(t, conn, wrap)
+	repoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	s, err := roles.NewService(repoFn, noTargetRepoFn, noServersRepoFn)
+	require.NoError(t, err, "Error when getting new role service.")
+
+	o, p := iam.TestScopes(t, iamRepo)
+	setCases := []struct {
+		name     string
+		existing []string
+		set      []string
+		result   []string
+		wantErr  bool
+	}{
+		{
+			name:   "Set children",
+			set:    []string{globals.GrantScopeChildren},
+			result: []string{globals.GrantScopeChildren},
+		},
+		{
+			name:     "Set replaces existing",
+			existing: []string{p.GetPublicId()},
+			set:      []string{globals.GrantScopeThis, globals.GrantScopeChildren},
+			result:   []string{globals.GrantScopeThis, globals.GrantScopeChildren},
+		},
+		{
+			name:   "Set the role scope id",
+			set:    []string{o.GetPublicId(), p.GetPublicId()},
+			result: []string{globals.GrantScopeThis, p.GetPublicId()},
+		},
+		{
+			name:   "Set empty",
+			set:    nil,
+			result: nil,
+		},
+		{
+			name:    "Set children with child project",
+			set:     []string{globals.GrantScopeChildren, p.GetPublicId()},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range setCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			var opts []iam.Option
+			if tc.existing != nil {
+				opts = append(opts, iam.WithGrantScopeIds(tc.existing))
+			}
+			role := iam.TestRole(t, conn, o.GetPublicId(), opts...)
+			req := &pbs.SetRoleGrantScopesRequest{
+				Id:            role.GetPublicId(),
+				Version:       role.GetVersion(),
+				GrantScopeIds: tc.set,
+			}
+			got, err := s.SetRoleGrantScopes(auth.DisabledAuthTestContext(repoFn, o.GetPublicId()), req)
+			if tc.wantErr {
+				assert.Error(err)
+				return
+			}
+			require.NoError(err)
+			assert.ElementsMatch(tc.result, got.GetItem().GetGrantScopeIds())
+		})
+	}
+
+	role := iam.TestRole(t, conn, o.GetPublicId())
+	failCases := []struct {
+		name string
+		req  *pbs.SetRoleGrantScopesRequest
+		err  error
+	}{
+		{
+			name: "Bad Version",
+			req: &pbs.SetRoleGrantScopesRequest{
+				Id:            role.GetPublicId(),
+				GrantScopeIds: []string{globals.GrantScopeChildren},
+				Version:       role.GetVersion() + 2,
+			},
+			err: handlers.ApiErrorWithCode(codes.Internal),
+		},
+		{
+			name: "Bad Role Id",
+			req: &pbs.SetRoleGrantScopesRequest{
+				Id:            "bad id",
+				GrantScopeIds: []string{globals.GrantScopeChildren},
+				Version:       role.GetVersion(),
+			},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Bad Grant Scope",
+			req: &pbs.SetRoleGrantScopesRequest{
+				Id:            role.GetPublicId(),
+				GrantScopeIds: []string{""},
+				Version:       role.GetVersion(),
+			},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+	}
+	for _, tc := range failCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			_, gErr := s.SetRoleGrantScopes(auth.DisabledAuthTestContext(repoFn, o.GetPublicId()), tc.req)
+			if tc.err != nil {
+				require.Error(gErr)
+				assert.True(errors.Is(gErr, tc.err), "SetRoleGrantScopes(%+v) got error %#v, wanted %#v", tc.req, gErr, tc.err)
+			}
+		})
+	}
+}
+
+func TestRemoveGrantScopes(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	wrap := db.TestWrapper(t)
+	iamRepo := iam.TestRepo(t, conn, wrap)
+	repoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	s, err := roles.NewService(repoFn, noTargetRepoFn, noServersRepoFn)
+	require.NoError(t, err, "Error when getting new role service.")
+
+	o, p := iam.TestScopes(t, iamRepo)
+	removeCases := []struct {
+		name     string
+		existing []string
+		remove   []string
+		result   []string
+		wantErr  bool
+	}{
+		{
+			name:   "Remove this",
+			remove: []string{globals.GrantScopeThis},
+			result: nil,
+		},
+		{
+			name:     "Remove one of many",
+			existing: []string{globals.GrantScopeThis, p.GetPublicId()},
+			remove:   []string{p.GetPublicId()},
+			result:   []string{globals.GrantScopeThis},
+		},
+		{
+			name:     "Remove the role scope id",
+			existing: []string{globals.GrantScopeThis, p.GetPublicId()},
+			remove:   []string{o.GetPublicId()},
+			result:   []string{p.GetPublicId()},
+		},
+		{
+			name:   "Remove missing grant scope",
+			remove: []string{globals.GrantScopeChildren},
+			result: []string{globals.GrantScopeThis},
+		},
+	}
+
+	for _, tc := range removeCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			var opts []iam.Option
+			if tc.existing != nil {
+				opts = append(opts, iam.WithGrantScopeIds(tc.existing))
+			}
+			role := iam.TestRole(t, conn, o.GetPublicId(), opts...)
+			req := &pbs.RemoveRoleGrantScopesRequest{
+				Id:            role.GetPublicId(),
+				Version:       role.GetVersion(),
+				GrantScopeIds: tc.remove,
+			}
+			got, err := s.RemoveRoleGrantScopes(auth.DisabledAuthTestContext(repoFn, o.GetPublicId()), req)
+			if tc.wantErr {
+				assert.Error(err)
+				return
+			}
+			require.NoError(err)
+			assert.ElementsMatch(tc.result, got.GetItem().GetGrantScopeIds())
+		})
+	}
+
+	role := iam.TestRole(t, conn, o.GetPublicId())
+	failCases := []struct {
+		name string
+		req  *pbs.RemoveRoleGrantScopesRequest
+		err  error
+	}{
+		{
+			name: "Bad Version",
+			req: &pbs.RemoveRoleGrantScopesRequest{
+				Id:            role.GetPublicId(),
+				GrantScopeIds: []string{globals.GrantScopeThis},
+				Version:       role.GetVersion() + 2,
+			},
+			err: handlers.ApiErrorWithCode(codes.Internal),
+		},
+		{
+			name: "Bad Role Id",
+			req: &pbs.RemoveRoleGrantScopesRequest{
+				Id:            "bad id",
+				GrantScopeIds: []string{globals.GrantScopeThis},
+				Version:       role.GetVersion(),
+			},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "No Grant Scopes",
+			req: &pbs.RemoveRoleGrantScopesRequest{
+				Id:      role.GetPublicId(),
+				Version: role.GetVersion(),
+			},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+	}
+	for _, tc := range failCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			_, gErr := s.RemoveRoleGrantScopes(auth.DisabledAuthTestContext(repoFn, o.GetPublicId()), tc.req)
+			if tc.err != nil {
+				require.Error(gErr)
+				assert.True(errors.Is(gErr, tc.err), "RemoveRoleGrantScopes(%+v) got error %#v, wanted %#v", tc.req, gErr, tc.err)
+			}
+		})
+	}
+}
+
+func TestExplain(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	wrap := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, wrap)
+	iamRepo := iam.TestRepo(t, conn, wrap)
+	rw := db.New(conn)
+
+	ctx := context.Background()
+	repoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	tokenRepoFn := func() (*authtoken.Repository, error) {
+		return authtoken.NewRepository(rw, rw, kmsCache)
+	}
+	serversRepoFn := func() (*server.Repository, error) {
+		return server.NewRepository(rw, rw, kmsCache)
+	}
+	targetRepoFn := func(o ...target.Option) (*target.Repository, error) {
+		return target.NewRepository(ctx, rw, rw, kmsCache, o...)
+	}
+	s, err := roles.NewService(repoFn, targetRepoFn, serversRepoFn)
+	require.NoError(t, err)
+
+	o, p := iam.TestScopes(t, iamRepo)
+	otherOrg, otherProj := iam.TestScopes(t, iamRepo)
+
+	// The caller can explain in the project and read the users of its org
+	caller := authtoken.TestAuthToken(t, conn, kmsCache, o.GetPublicId())
+	explainRole := iam.TestRole(t, conn, p.GetPublicId())
+	iam.TestRoleGrant(t, conn, explainRole.GetPublicId(), "id=*;type=role;actions=explain")
+	iam.TestUserRole(t, conn, explainRole.GetPublicId(), caller.GetIamUserId())
+	readUsersRole := iam.TestRole(t, conn, o.GetPublicId())
+	iam.TestRoleGrant(t, conn, readUsersRole.GetPublicId(), "id=*;type=user;actions=read")
+	iam.TestUserRole(t, conn, readUsersRole.GetPublicId(), caller.GetIamUserId())
+
+	u := iam.TestUser(t, iamRepo, o.GetPublicId())
+	otherUser := iam.TestUser(t, iamRepo, otherOrg.GetPublicId())
+	const (
+		filteredAllow = `id=*;type=target;actions=read;resource_filter="/resource/name" == "allowed"`
+		allow         = `id=*;type=target;actions=authorize-session`
+		filteredDeny  = `id=*;type=target;actions=authorize-session;deny=true;resource_filter="/resource/name" == "denied"`
+	)
+	userRole := iam.TestRole(t, conn, p.GetPublicId())
+	for _, g := range []string{filteredAllow, allow, filteredDeny} {
+		iam.TestRoleGrant(t, conn, userRole.GetPublicId(), g)
+	}
+	iam.TestUserRole(t, conn, userRole.GetPublicId(), u.GetPublicId())
+	iam.TestUserRole(t, conn, userRole.GetPublicId(), otherUser.GetPublicId())
+
+	allowed := tcp.TestTarget(ctx, t, conn, p.GetPublicId(), "allowed")
+	denied := tcp.TestTarget(ctx, t, conn, p.GetPublicId(), "denied")
+	other := tcp.TestTarget(ctx, t, conn, p.GetPublicId(), "other")
+	otherProjTarget := tcp.TestTarget(ctx, t, conn, otherProj.GetPublicId(), "allowed")
+
+	explained := func(deny bool, grants ...string) []*pb.ExplainedGrant {
+		var out []*pb.ExplainedGrant
+		for _, g := range grants {
+			out = append(out, &pb.ExplainedGrant{
+				RoleId:       userRole.GetPublicId(),
+				GrantScopeId: p.GetPublicId(),
+				Grant:        g,
+				Deny:         deny && g == filteredDeny,
+			})
+		}
+		return out
+	}
+
+	cases := []struct {
+		name    string
+		req     *pbs.ExplainRequest
+		want    *pb.Explanation
+		wantErr error
+	}{
+		{
+			name: "filtered allow matches",
+			req: &pbs.ExplainRequest{
+				ScopeId:      p.GetPublicId(),
+				UserId:       u.GetPublicId(),
+				ResourceType: "target",
+				ResourceId:   allowed.GetPublicId(),
+				Action:       "read",
+			},
+			want: &pb.Explanation{
+				Authorized: true,
+				UserId:     u.GetPublicId(),
+				Grants:     explained(false, filteredAllow),
+			},
+		},
+		{
+			name: "filtered allow does not match",
+			req: &pbs.ExplainRequest{
+				ScopeId:      p.GetPublicId(),
+				UserId:       u.GetPublicId(),
+				ResourceType: "target",
+				ResourceId:   other.GetPublicId(),
+				Action:       "read",
+			},
+			want: &pb.Explanation{
+				UserId: u.GetPublicId(),
+			},
+		},
+		{
+			name: "filtered deny does not match",
+			req: &pbs.ExplainRequest{
+				ScopeId:      p.GetPublicId(),
+				UserId:       u.GetPublicId(),
+				ResourceType: "target",
+				ResourceId:   other.GetPublicId(),
+				Action:       "authorize-session",
+			},
+			want: &pb.Explanation{
+				Authorized: true,
+				UserId:     u.GetPublicId(),
+				Grants:     explained(true, allow),
+			},
+		},
+		{
+			name: "filtered deny matches",
+			req: &pbs.ExplainRequest{
+				ScopeId:      p.GetPublicId(),
+				UserId:       u.GetPublicId(),
+				ResourceType: "target",
+				ResourceId:   denied.GetPublicId(),
+				Action:       "authorize-session",
+			},
+			want: &pb.Explanation{
+				UserId: u.GetPublicId(),
+				Grants: explained(true, allow, filteredDeny),
+			},
+		},
+		{
+			name: "resource outside of scope",
+			req: &pbs.ExplainRequest{
+				ScopeId:      p.GetPublicId(),
+				UserId:       u.GetPublicId(),
+				ResourceType: "target",
+				ResourceId:   otherProjTarget.GetPublicId(),
+				Action:       "read",
+			},
+			wantErr: handlers.NotFoundError(),
+		},
+		{
+			name: "unknown resource",
+			req: &pbs.ExplainRequest{
+				ScopeId:      p.GetPublicId(),
+				UserId:       u.GetPublicId(),
+				ResourceType: "target",
+				ResourceId:   "ttcp_1234567890",
+				Action:       "read",
+			},
+			wantErr: handlers.NotFoundError(),
+		},
+		{
+			name: "unsupported resource type",
+			req: &pbs.ExplainRequest{
+				ScopeId:      p.GetPublicId(),
+				UserId:       u.GetPublicId(),
+				ResourceType: "host-catalog",
+				ResourceId:   "hcst_1234567890",
+				Action:       "read",
+			},
+			wantErr: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "user the caller cannot read",
+			req: &pbs.ExplainRequest{
+				ScopeId:      p.GetPublicId(),
+				UserId:       otherUser.GetPublicId(),
+				ResourceType: "target",
+				ResourceId:   allowed.GetPublicId(),
+				Action:       "read",
+			},
+			wantErr: handlers.NotFoundError(),
+		},
+		{
+			name: "scope without explain",
+			req: &pbs.ExplainRequest{
+				ScopeId:      otherProj.GetPublicId(),
+				UserId:       u.GetPublicId(),
+				ResourceType: "target",
+				ResourceId:   otherProjTarget.GetPublicId(),
+				Action:       "read",
+			},
+			wantErr: handlers.ForbiddenError(),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			requestInfo := authpb.RequestInfo{
+				Path:        "/v1/roles:explain",
+				Method:      "GET",
+				TokenFormat: uint32(auth.AuthTokenTypeBearer),
+				PublicId:    caller.GetPublicId(),
+				Token:       caller.GetToken(),
+			}
+			requestContext := context.WithValue(context.Background(), requests.ContextRequestInformationKey, &requests.RequestContext{})
+			ctx := auth.NewVerifierContext(requestContext, repoFn, tokenRepoFn, serversRepoFn, kmsCache, &requestInfo)
+			got, err := s.Explain(ctx, tc.req)
+			if tc.wantErr != nil {
+				require.Error(err)
+				assert.True(errors.Is(err, tc.wantErr), "Explain(%+v) got error %v, wanted %v", tc.req, err, tc.wantErr)
+				return
+			}
+			require.NoError(err)
+			assert.Empty(cmp.Diff(tc.want, got.GetItem(), protocmp.Transform()))
+		})
+	}
+}
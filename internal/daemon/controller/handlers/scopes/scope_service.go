@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/boundary/globals"
 	"github.com/hashicorp/boundary/internal/auth/oidc"
@@ -22,15 +23,19 @@ import (
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/groups"
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/host_catalogs"
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/roles"
+	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/serviceaccounts"
+	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/sessionrecordings"
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/sessions"
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/targets"
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/users"
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/workers"
+	"github.com/hashicorp/boundary/internal/db"
 	"github.com/hashicorp/boundary/internal/errors"
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
 	"github.com/hashicorp/boundary/internal/iam"
 	"github.com/hashicorp/boundary/internal/iam/store"
 	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/pagination"
 	"github.com/hashicorp/boundary/internal/perms"
 	"github.com/hashicorp/boundary/internal/requests"
 	"github.com/hashicorp/boundary/internal/types/action"
@@ -69,32 +74,35 @@ var (
 
 	scopeCollectionTypeMapMap = map[string]map[resource.Type]action.ActionSet{
 		scope.Global.String(): {
-			resource.AuthMethod: authmethods.CollectionActions,
-			resource.AuthToken:  authtokens.CollectionActions,
-			resource.Group:      groups.CollectionActions,
-			resource.Role:       roles.CollectionActions,
-			resource.Scope:      CollectionActions,
-			resource.User:       users.CollectionActions,
-			resource.Worker:     workers.CollectionActions,
+			resource.AuthMethod:     authmethods.CollectionActions,
+			resource.AuthToken:      authtokens.CollectionActions,
+			resource.Group:          groups.CollectionActions,
+			resource.Role:           roles.CollectionActions,
+			resource.Scope:          CollectionActions,
+			resource.ServiceAccount: serviceaccounts.CollectionActions,
+			resource.User:           users.CollectionActions,
+			resource.Worker:         workers.CollectionActions,
 		},
 
 		scope.Org.String(): {
-			resource.AuthMethod: authmethods.CollectionActions,
-			resource.AuthToken:  authtokens.CollectionActions,
-			resource.Group:      groups.CollectionActions,
-			resource.Role:       roles.CollectionActions,
-			resource.Scope:      CollectionActions,
-			resource.User:       users.CollectionActions,
+			resource.AuthMethod:     authmethods.CollectionActions,
+			resource.AuthToken:      authtokens.CollectionActions,
+			resource.Group:          groups.CollectionActions,
+			resource.Role:           roles.CollectionActions,
+			resource.Scope:          CollectionActions,
+			resource.ServiceAccount: serviceaccounts.CollectionActions,
+			resource.User:           users.CollectionActions,
 		},
 
 		scope.Project.String(): {
-			resource.CredentialStore: credentialstores.CollectionActions,
-			resource.Group:           groups.CollectionActions,
-			resource.HostCatalog:     host_catalogs.CollectionActions,
-			resource.Role:            roles.CollectionActions,
-			resource.Scope:           CollectionActions[2:], // Only Scope key actions are allowed on the project level
-			resource.Session:         sessions.CollectionActions,
-			resource.Target:          targets.CollectionActions,
+			resource.CredentialStore:  credentialstores.CollectionActions,
+			resource.Group:            groups.CollectionActions,
+			resource.HostCatalog:      host_catalogs.CollectionActions,
+			resource.Role:             roles.CollectionActions,
+			resource.Scope:            CollectionActions[2:], // Only Scope key actions are allowed on the project level
+			resource.Session:          sessions.CollectionActions,
+			resource.SessionRecording: sessionrecordings.CollectionActions,
+			resource.Target:           targets.CollectionActions,
 		},
 	}
 )
@@ -160,29 +168,33 @@ func (s Service) ListScopes(ctx context.Context, req *pbs.ListScopesRequest) (*p
 		return &pbs.ListScopesResponse{}, nil
 	}
 
-	pl, err := s.listFromRepo(ctx, scopeIds)
+	repo, err := s.repoFn()
 	if err != nil {
 		return nil, err
 	}
-	if len(pl) == 0 {
-		return &pbs.ListScopesResponse{}, nil
-	}
 
 	filter, err := handlers.NewFilter(req.GetFilter())
 	if err != nil {
 		return nil, err
 	}
-	finalItems := make([]*pb.Scope, 0, len(pl))
-	res := perms.Resource{
-		Type: resource.Scope,
+
+	listItemsFn := func(ctx context.Context, page *db.Page, limit int) ([]*iam.Scope, error) {
+		scps, err := repo.ListScopes(ctx, scopeIds, iam.WithPage(page), iam.WithLimit(limit))
+		if err != nil {
+			return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to list scopes: %v", err)
+		}
+		return scps, nil
 	}
-	for _, item := range pl {
-		res.Id = item.GetPublicId()
-		res.ScopeId = item.GetParentId()
+	convertItemFn := func(ctx context.Context, item *iam.Scope) (*pb.Scope, bool, error) {
+		res := perms.Resource{
+			Id:      item.GetPublicId(),
+			ScopeId: item.GetParentId(),
+			Type:    resource.Scope,
+		}
 
 		authorizedActions := authResults.FetchActionSetForId(ctx, item.GetPublicId(), IdActions, auth.WithResource(&res)).Strings()
 		if len(authorizedActions) == 0 {
-			continue
+			return nil, false, nil
 		}
 
 		outputFields := authResults.FetchOutputFields(res, action.List).SelfOrDefaults(authResults.UserId)
@@ -197,22 +209,48 @@ func (s Service) ListScopes(ctx context.Context, req *pbs.ListScopesRequest) (*p
 		if outputFields.Has(globals.AuthorizedCollectionActionsField) {
 			collectionActions, err := auth.CalculateAuthorizedCollectionActions(ctx, authResults, scopeCollectionTypeMapMap[item.Type], item.GetPublicId(), "")
 			if err != nil {
-				return nil, err
+				return nil, false, err
 			}
 			outputOpts = append(outputOpts, handlers.WithAuthorizedCollectionActions(collectionActions))
 		}
 
-		item, err := ToProto(ctx, item, outputOpts...)
+		pbItem, err := ToProto(ctx, item, outputOpts...)
 		if err != nil {
-			return nil, err
+			return nil, false, err
 		}
+		return pbItem, filter.Match(pbItem), nil
+	}
 
-		if filter.Match(item) {
-			finalItems = append(finalItems, item)
+	listDeletedIdsFn := func(ctx context.Context, since time.Time) ([]string, error) {
+		deleted, err := repo.ListDeletedScopeIds(ctx, scopeIds, since)
+		if err != nil {
+			return nil, err
 		}
+		return authResults.AuthorizedDeletedIds(ctx, perms.Resource{Type: resource.Scope}, IdActions, deleted), nil
+	}
+	listReq := pagination.Request{
+		ResourceType: resource.Scope,
+		GrantsHash:   authResults.GrantsHash(),
+		PageSize:     req.GetPageSize(),
+		ListToken:    req.GetListToken(),
 	}
-	SortScopes(finalItems)
-	return &pbs.ListScopesResponse{Items: finalItems}, nil
+	page, err := pagination.List(ctx, listReq, listItemsFn, convertItemFn, listDeletedIdsFn)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &pbs.ListScopesResponse{Items: page.Items}
+	if !listReq.Paginated() {
+		// Paginated responses keep the order of the listing instead.
+		SortScopes(resp.Items)
+		return resp, nil
+	}
+	resp.ResponseType = page.ResponseType()
+	resp.ListToken = page.ListToken
+	resp.SortBy = page.SortBy
+	resp.SortDir = pagination.SortDirAscending
+	resp.RemovedIds = page.RemovedIds
+	return resp, nil
 }
 
 // GetScopes implements the interface pbs.ScopeServiceServer.
@@ -532,6 +570,12 @@ func (s Service) createInRepo(ctx context.Context, authResults auth.VerifyResult
 	if item.GetDescription() != nil {
 		opts = append(opts, iam.WithDescription(item.GetDescription().GetValue()))
 	}
+	if item.GetMaxSessionsPerUser() != nil {
+		opts = append(opts, iam.WithMaxSessionsPerUser(item.GetMaxSessionsPerUser().GetValue()))
+	}
+	if item.GetMaxSessionsPerTarget() != nil {
+		opts = append(opts, iam.WithMaxSessionsPerTarget(item.GetMaxSessionsPerTarget().GetValue()))
+	}
 	opts = append(opts, iam.WithSkipAdminRoleCreation(req.GetSkipAdminRoleCreation()))
 	opts = append(opts, iam.WithSkipDefaultRoleCreation(req.GetSkipDefaultRoleCreation()))
 
@@ -580,6 +624,10 @@ func (s Service) updateInRepo(ctx context.Context, parentScope *pb.ScopeInfo, sc
 		scopePrimaryAuthMethodId = primaryAuthMethodId.GetValue()
 		opts = append(opts, iam.WithPrimaryAuthMethodId(scopePrimaryAuthMethodId))
 	}
+	maxSessionsPerUser := item.GetMaxSessionsPerUser().GetValue()
+	opts = append(opts, iam.WithMaxSessionsPerUser(maxSessionsPerUser))
+	maxSessionsPerTarget := item.GetMaxSessionsPerTarget().GetValue()
+	opts = append(opts, iam.WithMaxSessionsPerTarget(maxSessionsPerTarget))
 	version := item.GetVersion()
 
 	var iamScope *iam.Scope
@@ -594,6 +642,8 @@ func (s Service) updateInRepo(ctx context.Context, parentScope *pb.ScopeInfo, sc
 		iamScope.Description = scopeDesc
 		iamScope.Name = scopeName
 		iamScope.PrimaryAuthMethodId = scopePrimaryAuthMethodId
+		iamScope.MaxSessionsPerUser = maxSessionsPerUser
+		iamScope.MaxSessionsPerTarget = maxSessionsPerTarget
 	case parentScope.GetType() == scope.Global.String():
 		iamScope, err = iam.NewOrg(opts...)
 	case parentScope.GetType() == scope.Org.String():
@@ -657,18 +707,6 @@ func sortKeys(keys []*pb.Key) {
 	}
 }
 
-func (s Service) listFromRepo(ctx context.Context, scopeIds []string) ([]*iam.Scope, error) {
-	repo, err := s.repoFn()
-	if err != nil {
-		return nil, err
-	}
-	scps, err := repo.ListScopes(ctx, scopeIds)
-	if err != nil {
-		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to list scopes: %v", err)
-	}
-	return scps, nil
-}
-
 func (s Service) authResult(ctx context.Context, id string, a action.Type) auth.VerifyResults {
 	res := auth.VerifyResults{}
 	repo, err := s.repoFn()
@@ -752,6 +790,12 @@ func ToProto(ctx context.Context, in *iam.Scope, opt ...handlers.Option) (*pb.Sc
 	if outputFields.Has(globals.PrimaryAuthMethodIdField) && in.GetPrimaryAuthMethodId() != "" {
 		out.PrimaryAuthMethodId = &wrapperspb.StringValue{Value: in.GetPrimaryAuthMethodId()}
 	}
+	if outputFields.Has(globals.MaxSessionsPerUserField) && in.GetMaxSessionsPerUser() > 0 {
+		out.MaxSessionsPerUser = &wrapperspb.UInt32Value{Value: in.GetMaxSessionsPerUser()}
+	}
+	if outputFields.Has(globals.MaxSessionsPerTargetField) && in.GetMaxSessionsPerTarget() > 0 {
+		out.MaxSessionsPerTarget = &wrapperspb.UInt32Value{Value: in.GetMaxSessionsPerTarget()}
+	}
 
 	return &out, nil
 }
@@ -24,8 +24,10 @@ import (
 	authpb "github.com/hashicorp/boundary/internal/gen/controller/auth"
 	"github.com/hashicorp/boundary/internal/iam"
 	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/pagination"
 	"github.com/hashicorp/boundary/internal/perms"
 	"github.com/hashicorp/boundary/internal/server"
+	"github.com/hashicorp/boundary/internal/types/resource"
 	"github.com/hashicorp/boundary/internal/types/scope"
 	pb "github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/scopes"
 	wrappingKms "github.com/hashicorp/go-kms-wrapping/extras/kms/v2"
@@ -92,6 +94,7 @@ var globalAuthorizedCollectionActions = map[string]*structpb.ListValue{
 		Values: []*structpb.Value{
 			structpb.NewStringValue("create"),
 			structpb.NewStringValue("list"),
+			structpb.NewStringValue("explain"),
 		},
 	},
 	"scopes": {
@@ -104,6 +107,12 @@ var globalAuthorizedCollectionActions = map[string]*structpb.ListValue{
 			structpb.NewStringValue("destroy-key-version"),
 		},
 	},
+	"service-accounts": {
+		Values: []*structpb.Value{
+			structpb.NewStringValue("create"),
+			structpb.NewStringValue("list"),
+		},
+	},
 	"users": {
 		Values: []*structpb.Value{
 			structpb.NewStringValue("create"),
@@ -143,6 +152,7 @@ var orgAuthorizedCollectionActions = map[string]*structpb.ListValue{
 		Values: []*structpb.Value{
 			structpb.NewStringValue("create"),
 			structpb.NewStringValue("list"),
+			structpb.NewStringValue("explain"),
 		},
 	},
 	"scopes": {
@@ -155,6 +165,12 @@ var orgAuthorizedCollectionActions = map[string]*structpb.ListValue{
 			structpb.NewStringValue("destroy-key-version"),
 		},
 	},
+	"service-accounts": {
+		Values: []*structpb.Value{
+			structpb.NewStringValue("create"),
+			structpb.NewStringValue("list"),
+		},
+	},
 	"users": {
 		Values: []*structpb.Value{
 			structpb.NewStringValue("create"),
@@ -186,6 +202,7 @@ var projectAuthorizedCollectionActions = map[string]*structpb.ListValue{
 		Values: []*structpb.Value{
 			structpb.NewStringValue("create"),
 			structpb.NewStringValue("list"),
+			structpb.NewStringValue("explain"),
 		},
 	},
 	"sessions": {
@@ -193,6 +210,11 @@ var projectAuthorizedCollectionActions = map[string]*structpb.ListValue{
 			structpb.NewStringValue("list"),
 		},
 	},
+	"session-recordings": {
+		Values: []*structpb.Value{
+			structpb.NewStringValue("list"),
+		},
+	},
 	"scopes": {
 		Values: []*structpb.Value{
 			structpb.NewStringValue("list-keys"),
@@ -520,6 +542,45 @@ func TestList(t *testing.T) {
 	}
 }
 
+func TestList_Pagination(t *testing.T) {
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrap := db.TestWrapper(t)
+	iamRepo := iam.TestRepo(t, conn, wrap)
+	repoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	kms := kms.TestKms(t, conn, wrap)
+	s, err := scopes.NewService(ctx, repoFn, kms)
+	require.NoError(t, err)
+
+	o := iam.TestOrg(t, iamRepo)
+	// Paginated listings are oldest first
+	var want []string
+	for i := 0; i < 5; i++ {
+		p := iam.TestProject(t, iamRepo, o.GetPublicId())
+		want = append(want, p.GetPublicId())
+	}
+	pagination.TestList(t,
+		auth.DisabledAuthTestContext(repoFn, o.GetPublicId()),
+		auth.DisabledAuthTestContext(repoFn, o.GetPublicId(), auth.WithUserId(globals.AnonymousUserId)),
+		resource.User,
+		want,
+		func(ctx context.Context, listToken string, pageSize uint32) (pagination.TestListResponse, []string, error) {
+			got, err := s.ListScopes(ctx, &pbs.ListScopesRequest{ScopeId: o.GetPublicId(), ListToken: listToken, PageSize: pageSize})
+			var ids []string
+			for _, item := range got.GetItems() {
+				ids = append(ids, item.GetId())
+			}
+			return got, ids, err
+		},
+		func(t *testing.T, id string) {
+			_, err := iamRepo.DeleteScope(ctx, id)
+			require.NoError(t, err)
+		},
+	)
+}
+
 func TestDelete(t *testing.T) {
 	org, proj, repoFn, kms := createDefaultScopesRepoAndKms(t)
 
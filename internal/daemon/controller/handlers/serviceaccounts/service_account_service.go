@@ -0,0 +1,525 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package serviceaccounts
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/boundary/globals"
+	"github.com/hashicorp/boundary/internal/daemon/controller/auth"
+	"github.com/hashicorp/boundary/internal/daemon/controller/common"
+	"github.com/hashicorp/boundary/internal/daemon/controller/common/scopeids"
+	"github.com/hashicorp/boundary/internal/daemon/controller/handlers"
+	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/apikeys"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/iam/store"
+	"github.com/hashicorp/boundary/internal/pagination"
+	"github.com/hashicorp/boundary/internal/perms"
+	"github.com/hashicorp/boundary/internal/requests"
+	"github.com/hashicorp/boundary/internal/types/action"
+	"github.com/hashicorp/boundary/internal/types/resource"
+	"github.com/hashicorp/boundary/internal/types/scope"
+	pb "github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/serviceaccounts"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+var (
+	maskManager handlers.MaskManager
+
+	// IdActions contains the set of actions that can be performed on
+	// individual resources
+	IdActions = action.ActionSet{
+		action.NoOp,
+		action.Read,
+		action.Update,
+		action.Delete,
+	}
+
+	// CollectionActions contains the set of actions that can be performed on
+	// this collection
+	CollectionActions = action.ActionSet{
+		action.Create,
+		action.List,
+	}
+
+	collectionTypeMap = map[resource.Type]action.ActionSet{
+		resource.ApiKey: apikeys.CollectionActions,
+	}
+)
+
+func init() {
+	var err error
+	if maskManager, err = handlers.NewMaskManager(handlers.MaskDestination{&store.ServiceAccount{}}, handlers.MaskSource{&pb.ServiceAccount{}}); err != nil {
+		panic(err)
+	}
+}
+
+// Service handles request as described by the pbs.ServiceAccountServiceServer interface.
+type Service struct {
+	pbs.UnsafeServiceAccountServiceServer
+
+	repoFn common.IamRepoFactory
+}
+
+var _ pbs.ServiceAccountServiceServer = (*Service)(nil)
+
+// NewService returns a service account service which handles service account
+// related requests to boundary.
+func NewService(repo common.IamRepoFactory) (Service, error) {
+	const op = "serviceaccounts.NewService"
+	if repo == nil {
+		return Service{}, errors.NewDeprecated(errors.InvalidParameter, op, "missing iam repository")
+	}
+	return Service{repoFn: repo}, nil
+}
+
+// ListServiceAccounts implements the interface pbs.ServiceAccountServiceServer.
+func (s Service) ListServiceAccounts(ctx context.Context, req *pbs.ListServiceAccountsRequest) (*pbs.ListServiceAccountsResponse, error) {
+	if err := validateListRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetScopeId(), action.List)
+	if authResults.Error != nil {
+		// If it's forbidden, and it's a recursive request, and they're
+		// successfully authenticated but just not authorized, keep going as we
+		// may have authorization on downstream scopes. Or, if they've not
+		// authenticated, still process in case u_anon has permissions.
+		if (authResults.Error == handlers.ForbiddenError() || authResults.Error == handlers.UnauthenticatedError()) &&
+			req.GetRecursive() &&
+			authResults.AuthenticationFinished {
+		} else {
+			return nil, authResults.Error
+		}
+	}
+
+	scopeIds, scopeInfoMap, err := scopeids.GetListingScopeIds(
+		ctx, s.repoFn, authResults, req.GetScopeId(), resource.ServiceAccount, req.GetRecursive())
+	if err != nil {
+		return nil, err
+	}
+	// If no scopes match, return an empty response
+	if len(scopeIds) == 0 {
+		return &pbs.ListServiceAccountsResponse{}, nil
+	}
+
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, err
+	}
+
+	filter, err := handlers.NewFilter(req.GetFilter())
+	if err != nil {
+		return nil, err
+	}
+
+	listItemsFn := func(ctx context.Context, page *db.Page, limit int) ([]*iam.ServiceAccount, error) {
+		return repo.ListServiceAccounts(ctx, scopeIds, iam.WithPage(page), iam.WithLimit(limit))
+	}
+	convertItemFn := func(ctx context.Context, item *iam.ServiceAccount) (*pb.ServiceAccount, bool, error) {
+		res := perms.Resource{
+			Id:      item.GetPublicId(),
+			ScopeId: item.GetScopeId(),
+			Type:    resource.ServiceAccount,
+		}
+		authorizedActions := authResults.FetchActionSetForId(ctx, item.GetPublicId(), IdActions, auth.WithResource(&res)).Strings()
+		if len(authorizedActions) == 0 {
+			return nil, false, nil
+		}
+
+		outputFields := authResults.FetchOutputFields(res, action.List).SelfOrDefaults(authResults.UserId)
+		outputOpts := make([]handlers.Option, 0, 4)
+		outputOpts = append(outputOpts, handlers.WithOutputFields(outputFields))
+		if outputFields.Has(globals.ScopeField) {
+			outputOpts = append(outputOpts, handlers.WithScope(scopeInfoMap[item.GetScopeId()]))
+		}
+		if outputFields.Has(globals.AuthorizedActionsField) {
+			outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authorizedActions))
+		}
+		if outputFields.Has(globals.AuthorizedCollectionActionsField) {
+			collectionActions, err := auth.CalculateAuthorizedCollectionActions(ctx, authResults, collectionTypeMap, item.GetScopeId(), item.GetPublicId())
+			if err != nil {
+				return nil, false, err
+			}
+			outputOpts = append(outputOpts, handlers.WithAuthorizedCollectionActions(collectionActions))
+		}
+
+		pbItem, err := toProto(ctx, item, outputOpts...)
+		if err != nil {
+			return nil, false, err
+		}
+		return pbItem, filter.Match(pbItem), nil
+	}
+
+	listDeletedIdsFn := func(ctx context.Context, since time.Time) ([]string, error) {
+		deleted, err := repo.ListDeletedServiceAccountIds(ctx, scopeIds, since)
+		if err != nil {
+			return nil, err
+		}
+		return authResults.AuthorizedDeletedIds(ctx, perms.Resource{Type: resource.ServiceAccount}, IdActions, deleted), nil
+	}
+	listReq := pagination.Request{
+		ResourceType: resource.ServiceAccount,
+		GrantsHash:   authResults.GrantsHash(),
+		PageSize:     req.GetPageSize(),
+		ListToken:    req.GetListToken(),
+	}
+	page, err := pagination.List(ctx, listReq, listItemsFn, convertItemFn, listDeletedIdsFn)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &pbs.ListServiceAccountsResponse{Items: page.Items}
+	if listReq.Paginated() {
+		resp.ResponseType = page.ResponseType()
+		resp.ListToken = page.ListToken
+		resp.SortBy = page.SortBy
+		resp.SortDir = pagination.SortDirAscending
+		resp.RemovedIds = page.RemovedIds
+	}
+	return resp, nil
+}
+
+// GetServiceAccount implements the interface pbs.ServiceAccountServiceServer.
+func (s Service) GetServiceAccount(ctx context.Context, req *pbs.GetServiceAccountRequest) (*pbs.GetServiceAccountResponse, error) {
+	const op = "serviceaccounts.(Service).GetServiceAccount"
+
+	if err := validateGetRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.Read)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	sa, err := s.getFromRepo(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	outputOpts, err := s.outputOpts(ctx, authResults, sa)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	item, err := toProto(ctx, sa, outputOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pbs.GetServiceAccountResponse{Item: item}, nil
+}
+
+// CreateServiceAccount implements the interface pbs.ServiceAccountServiceServer.
+func (s Service) CreateServiceAccount(ctx context.Context, req *pbs.CreateServiceAccountRequest) (*pbs.CreateServiceAccountResponse, error) {
+	const op = "serviceaccounts.(Service).CreateServiceAccount"
+
+	if err := validateCreateRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetItem().GetScopeId(), action.Create)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	sa, err := s.createInRepo(ctx, authResults.Scope.GetId(), req.GetItem())
+	if err != nil {
+		return nil, err
+	}
+
+	outputOpts, err := s.outputOpts(ctx, authResults, sa)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	item, err := toProto(ctx, sa, outputOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return &pbs.CreateServiceAccountResponse{Item: item, Uri: fmt.Sprintf("service-accounts/%s", item.GetId())}, nil
+}
+
+// UpdateServiceAccount implements the interface pbs.ServiceAccountServiceServer.
+func (s Service) UpdateServiceAccount(ctx context.Context, req *pbs.UpdateServiceAccountRequest) (*pbs.UpdateServiceAccountResponse, error) {
+	const op = "serviceaccounts.(Service).UpdateServiceAccount"
+
+	if err := validateUpdateRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.Update)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	sa, err := s.updateInRepo(ctx, authResults.Scope.GetId(), req.GetId(), req.GetUpdateMask().GetPaths(), req.GetItem())
+	if err != nil {
+		return nil, err
+	}
+
+	outputOpts, err := s.outputOpts(ctx, authResults, sa)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	item, err := toProto(ctx, sa, outputOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pbs.UpdateServiceAccountResponse{Item: item}, nil
+}
+
+// DeleteServiceAccount implements the interface pbs.ServiceAccountServiceServer.
+func (s Service) DeleteServiceAccount(ctx context.Context, req *pbs.DeleteServiceAccountRequest) (*pbs.DeleteServiceAccountResponse, error) {
+	if err := validateDeleteRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.Delete)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	_, err := s.deleteFromRepo(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
+
+func (s Service) outputOpts(ctx context.Context, authResults auth.VerifyResults, sa *iam.ServiceAccount) ([]handlers.Option, error) {
+	const op = "serviceaccounts.(Service).outputOpts"
+	outputFields, ok := requests.OutputFields(ctx)
+	if !ok {
+		return nil, errors.New(ctx, errors.Internal, op, "no request context found")
+	}
+
+	outputOpts := make([]handlers.Option, 0, 4)
+	outputOpts = append(outputOpts, handlers.WithOutputFields(outputFields))
+	if outputFields.Has(globals.ScopeField) {
+		outputOpts = append(outputOpts, handlers.WithScope(authResults.Scope))
+	}
+	if outputFields.Has(globals.AuthorizedActionsField) {
+		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, sa.GetPublicId(), IdActions).Strings()))
+	}
+	if outputFields.Has(globals.AuthorizedCollectionActionsField) {
+		collectionActions, err := auth.CalculateAuthorizedCollectionActions(ctx, authResults, collectionTypeMap, authResults.Scope.Id, sa.GetPublicId())
+		if err != nil {
+			return nil, err
+		}
+		outputOpts = append(outputOpts, handlers.WithAuthorizedCollectionActions(collectionActions))
+	}
+	return outputOpts, nil
+}
+
+func (s Service) getFromRepo(ctx context.Context, id string) (*iam.ServiceAccount, error) {
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, err
+	}
+	sa, err := repo.LookupServiceAccount(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if sa == nil {
+		return nil, handlers.NotFoundErrorf("Service account %q doesn't exist.", id)
+	}
+	return sa, nil
+}
+
+func (s Service) createInRepo(ctx context.Context, scopeId string, item *pb.ServiceAccount) (*iam.ServiceAccount, error) {
+	const op = "serviceaccounts.(Service).createInRepo"
+	var opts []iam.Option
+	if item.GetName() != nil {
+		opts = append(opts, iam.WithName(item.GetName().GetValue()))
+	}
+	if item.GetDescription() != nil {
+		opts = append(opts, iam.WithDescription(item.GetDescription().GetValue()))
+	}
+	sa, err := iam.NewServiceAccount(ctx, scopeId, opts...)
+	if err != nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to build service account for creation: %v.", err)
+	}
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, err
+	}
+	out, err := repo.CreateServiceAccount(ctx, sa)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to create service account"))
+	}
+	if out == nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to create service account but no error returned from repository.")
+	}
+	return out, nil
+}
+
+func (s Service) updateInRepo(ctx context.Context, scopeId, id string, mask []string, item *pb.ServiceAccount) (*iam.ServiceAccount, error) {
+	const op = "serviceaccounts.(Service).updateInRepo"
+	var opts []iam.Option
+	if desc := item.GetDescription(); desc != nil {
+		opts = append(opts, iam.WithDescription(desc.GetValue()))
+	}
+	if name := item.GetName(); name != nil {
+		opts = append(opts, iam.WithName(name.GetValue()))
+	}
+	version := item.GetVersion()
+	sa, err := iam.NewServiceAccount(ctx, scopeId, opts...)
+	if err != nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to build service account for update: %v.", err)
+	}
+	sa.PublicId = id
+	dbMask := maskManager.Translate(mask)
+	if len(dbMask) == 0 {
+		return nil, handlers.InvalidArgumentErrorf("No valid fields included in the update mask.", map[string]string{"update_mask": "No valid fields provided in the update mask."})
+	}
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, err
+	}
+	out, rowsUpdated, err := repo.UpdateServiceAccount(ctx, sa, version, dbMask)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to update service account"))
+	}
+	if rowsUpdated == 0 {
+		return nil, handlers.NotFoundErrorf("Service account %q doesn't exist or incorrect version provided.", id)
+	}
+	return out, nil
+}
+
+func (s Service) deleteFromRepo(ctx context.Context, id string) (bool, error) {
+	const op = "serviceaccounts.(Service).deleteFromRepo"
+	repo, err := s.repoFn()
+	if err != nil {
+		return false, err
+	}
+	rows, err := repo.DeleteServiceAccount(ctx, id)
+	if err != nil {
+		if errors.IsNotFoundError(err) {
+			return false, nil
+		}
+		return false, errors.Wrap(ctx, err, op, errors.WithMsg("unable to delete service account"))
+	}
+	return rows > 0, nil
+}
+
+func (s Service) authResult(ctx context.Context, id string, a action.Type) auth.VerifyResults {
+	res := auth.VerifyResults{}
+	repo, err := s.repoFn()
+	if err != nil {
+		res.Error = err
+		return res
+	}
+
+	var parentId string
+	opts := []auth.Option{auth.WithType(resource.ServiceAccount), auth.WithAction(a)}
+	switch a {
+	case action.List, action.Create:
+		parentId = id
+		scp, err := repo.LookupScope(ctx, parentId)
+		if err != nil {
+			res.Error = err
+			return res
+		}
+		if scp == nil {
+			res.Error = handlers.NotFoundError()
+			return res
+		}
+	default:
+		sa, err := repo.LookupServiceAccount(ctx, id)
+		if err != nil {
+			res.Error = err
+			return res
+		}
+		if sa == nil {
+			res.Error = handlers.NotFoundError()
+			return res
+		}
+		parentId = sa.GetScopeId()
+		opts = append(opts, auth.WithId(id))
+	}
+	opts = append(opts, auth.WithScopeId(parentId))
+	return auth.Verify(ctx, opts...)
+}
+
+func toProto(ctx context.Context, in *iam.ServiceAccount, opt ...handlers.Option) (*pb.ServiceAccount, error) {
+	opts := handlers.GetOpts(opt...)
+	if opts.WithOutputFields == nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "output fields not found when building service account proto")
+	}
+	outputFields := *opts.WithOutputFields
+
+	out := pb.ServiceAccount{}
+	if outputFields.Has(globals.IdField) {
+		out.Id = in.GetPublicId()
+	}
+	if outputFields.Has(globals.ScopeIdField) {
+		out.ScopeId = in.GetScopeId()
+	}
+	if outputFields.Has(globals.DescriptionField) && in.GetDescription() != "" {
+		out.Description = wrapperspb.String(in.GetDescription())
+	}
+	if outputFields.Has(globals.NameField) && in.GetName() != "" {
+		out.Name = wrapperspb.String(in.GetName())
+	}
+	if outputFields.Has(globals.CreatedTimeField) {
+		out.CreatedTime = in.GetCreateTime().GetTimestamp()
+	}
+	if outputFields.Has(globals.UpdatedTimeField) {
+		out.UpdatedTime = in.GetUpdateTime().GetTimestamp()
+	}
+	if outputFields.Has(globals.VersionField) {
+		out.Version = in.GetVersion()
+	}
+	if outputFields.Has(globals.ScopeField) {
+		out.Scope = opts.WithScope
+	}
+	if outputFields.Has(globals.AuthorizedActionsField) {
+		out.AuthorizedActions = opts.WithAuthorizedActions
+	}
+	if outputFields.Has(globals.AuthorizedCollectionActionsField) {
+		out.AuthorizedCollectionActions = opts.WithAuthorizedCollectionActions
+	}
+	return &out, nil
+}
+
+// A validateX method should exist for each method above.  These methods do not make calls to any backing service but enforce
+// requirements on the structure of the request.  They verify that:
+//   - The path passed in is correctly formatted
+//   - All required parameters are set
+//   - There are no conflicting parameters provided
+func validateGetRequest(req *pbs.GetServiceAccountRequest) error {
+	return handlers.ValidateGetRequest(handlers.NoopValidatorFn, req, iam.ServiceAccountPrefix)
+}
+
+func validateCreateRequest(req *pbs.CreateServiceAccountRequest) error {
+	return handlers.ValidateCreateRequest(req.GetItem(), func() map[string]string {
+		badFields := map[string]string{}
+		if !handlers.ValidId(handlers.Id(req.GetItem().GetScopeId()), scope.Org.Prefix()) &&
+			scope.Global.String() != req.GetItem().GetScopeId() {
+			badFields["scope_id"] = "Must be 'global' or a valid org scope id."
+		}
+		return badFields
+	})
+}
+
+func validateUpdateRequest(req *pbs.UpdateServiceAccountRequest) error {
+	return handlers.ValidateUpdateRequest(req, req.GetItem(), handlers.NoopValidatorFn, iam.ServiceAccountPrefix)
+}
+
+func validateDeleteRequest(req *pbs.DeleteServiceAccountRequest) error {
+	return handlers.ValidateDeleteRequest(handlers.NoopValidatorFn, req, iam.ServiceAccountPrefix)
+}
+
+func validateListRequest(req *pbs.ListServiceAccountsRequest) error {
+	badFields := map[string]string{}
+	if !handlers.ValidId(handlers.Id(req.GetScopeId()), scope.Org.Prefix()) &&
+		req.GetScopeId() != scope.Global.String() {
+		badFields["scope_id"] = "Must be 'global' or a valid org scope id when listing."
+	}
+	if _, err := handlers.NewFilter(req.GetFilter()); err != nil {
+		badFields["filter"] = fmt.Sprintf("This field could not be parsed. %v", err)
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Improperly formatted identifier.", badFields)
+	}
+	return nil
+}
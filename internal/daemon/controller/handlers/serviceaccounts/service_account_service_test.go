@@ -0,0 +1,468 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package serviceaccounts_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/boundary/globals"
+	"github.com/hashicorp/boundary/internal/authtoken"
+	"github.com/hashicorp/boundary/internal/daemon/controller/auth"
+	"github.com/hashicorp/boundary/internal/daemon/controller/handlers"
+	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/serviceaccounts"
+	"github.com/hashicorp/boundary/internal/db"
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/pagination"
+	"github.com/hashicorp/boundary/internal/types/resource"
+	"github.com/hashicorp/boundary/internal/types/scope"
+	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/scopes"
+	pb "github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/serviceaccounts"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/protobuf/field_mask"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+var (
+	testAuthorizedActions = []string{"no-op", "read", "update", "delete"}
+
+	testAuthorizedCollectionActions = map[string]*structpb.ListValue{
+		"api-keys": {
+			Values: []*structpb.Value{
+				structpb.NewStringValue("create"),
+				structpb.NewStringValue("list"),
+			},
+		},
+	}
+)
+
+// Creates an org scoped service account.
+func createDefaultServiceAccountAndRepo(t *testing.T) (*iam.ServiceAccount, func() (*iam.Repository, error)) {
+	t.Helper()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrap := db.TestWrapper(t)
+	iamRepo := iam.TestRepo(t, conn, wrap)
+	repoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+
+	o, _ := iam.TestScopes(t, iamRepo)
+	sa := iam.TestServiceAccount(t, iamRepo, o.GetPublicId(), iam.WithName("default"), iam.WithDescription("default"))
+	return sa, repoFn
+}
+
+func TestGet(t *testing.T) {
+	sa, repoFn := createDefaultServiceAccountAndRepo(t)
+	s, err := serviceaccounts.NewService(repoFn)
+	require.NoError(t, err)
+
+	cases := []struct {
+		name string
+		req  *pbs.GetServiceAccountRequest
+		res  *pbs.GetServiceAccountResponse
+		err  error
+	}{
+		{
+			name: "Get an existing service account",
+			req:  &pbs.GetServiceAccountRequest{Id: sa.GetPublicId()},
+			res: &pbs.GetServiceAccountResponse{Item: &pb.ServiceAccount{
+				Id:                          sa.GetPublicId(),
+				ScopeId:                     sa.GetScopeId(),
+				Scope:                       &scopes.ScopeInfo{Id: sa.GetScopeId(), Type: scope.Org.String(), ParentScopeId: scope.Global.String()},
+				Name:                        wrapperspb.String(sa.GetName()),
+				Description:                 wrapperspb.String(sa.GetDescription()),
+				CreatedTime:                 sa.GetCreateTime().GetTimestamp(),
+				UpdatedTime:                 sa.GetUpdateTime().GetTimestamp(),
+				Version:                     1,
+				AuthorizedActions:           testAuthorizedActions,
+				AuthorizedCollectionActions: testAuthorizedCollectionActions,
+			}},
+		},
+		{
+			name: "Get a non existing service account",
+			req:  &pbs.GetServiceAccountRequest{Id: iam.ServiceAccountPrefix + "_DoesntExis"},
+			err:  handlers.ApiErrorWithCode(codes.NotFound),
+		},
+		{
+			name: "Wrong id prefix",
+			req:  &pbs.GetServiceAccountRequest{Id: "j_1234567890"},
+			err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "space in id",
+			req:  &pbs.GetServiceAccountRequest{Id: iam.ServiceAccountPrefix + "_1 23456789"},
+			err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, gErr := s.GetServiceAccount(auth.DisabledAuthTestContext(repoFn, sa.GetScopeId()), tc.req)
+			if tc.err != nil {
+				require.Error(gErr)
+				assert.True(errors.Is(gErr, tc.err), "GetServiceAccount(%+v) got error %v, wanted %v", tc.req, gErr, tc.err)
+				return
+			}
+			require.NoError(gErr)
+			assert.Empty(cmp.Diff(tc.res, got, protocmp.Transform()), "GetServiceAccount(%q) got response\n%q, wanted\n%q", tc.req, got, tc.res)
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	defaultSa, repoFn := createDefaultServiceAccountAndRepo(t)
+	s, err := serviceaccounts.NewService(repoFn)
+	require.NoError(t, err)
+	iamRepo, err := repoFn()
+	require.NoError(t, err)
+	_, proj := iam.TestScopes(t, iamRepo)
+
+	cases := []struct {
+		name string
+		req  *pbs.CreateServiceAccountRequest
+		res  *pbs.CreateServiceAccountResponse
+		err  error
+	}{
+		{
+			name: "Create a valid service account",
+			req: &pbs.CreateServiceAccountRequest{Item: &pb.ServiceAccount{
+				ScopeId:     defaultSa.GetScopeId(),
+				Name:        wrapperspb.String("name"),
+				Description: wrapperspb.String("desc"),
+			}},
+			res: &pbs.CreateServiceAccountResponse{
+				Uri: "service-accounts/" + iam.ServiceAccountPrefix + "_",
+				Item: &pb.ServiceAccount{
+					ScopeId:                     defaultSa.GetScopeId(),
+					Scope:                       &scopes.ScopeInfo{Id: defaultSa.GetScopeId(), Type: scope.Org.String(), ParentScopeId: scope.Global.String()},
+					Name:                        wrapperspb.String("name"),
+					Description:                 wrapperspb.String("desc"),
+					Version:                     1,
+					AuthorizedActions:           testAuthorizedActions,
+					AuthorizedCollectionActions: testAuthorizedCollectionActions,
+				},
+			},
+		},
+		{
+			name: "Create a global service account",
+			req: &pbs.CreateServiceAccountRequest{Item: &pb.ServiceAccount{
+				ScopeId: scope.Global.String(),
+				Name:    wrapperspb.String("name"),
+			}},
+			res: &pbs.CreateServiceAccountResponse{
+				Uri: "service-accounts/" + iam.ServiceAccountPrefix + "_",
+				Item: &pb.ServiceAccount{
+					ScopeId:                     scope.Global.String(),
+					Scope:                       &scopes.ScopeInfo{Id: scope.Global.String(), Type: scope.Global.String(), Name: scope.Global.String(), Description: "Global Scope"},
+					Name:                        wrapperspb.String("name"),
+					Version:                     1,
+					AuthorizedActions:           testAuthorizedActions,
+					AuthorizedCollectionActions: testAuthorizedCollectionActions,
+				},
+			},
+		},
+		{
+			name: "Duplicate name",
+			req: &pbs.CreateServiceAccountRequest{Item: &pb.ServiceAccount{
+				ScopeId: defaultSa.GetScopeId(),
+				Name:    wrapperspb.String(defaultSa.GetName()),
+			}},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Project scope",
+			req: &pbs.CreateServiceAccountRequest{Item: &pb.ServiceAccount{
+				ScopeId: proj.GetPublicId(),
+			}},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Can't specify Id",
+			req: &pbs.CreateServiceAccountRequest{Item: &pb.ServiceAccount{
+				ScopeId: defaultSa.GetScopeId(),
+				Id:      iam.ServiceAccountPrefix + "_notallowed",
+			}},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Can't specify Created Time",
+			req: &pbs.CreateServiceAccountRequest{Item: &pb.ServiceAccount{
+				ScopeId:     defaultSa.GetScopeId(),
+				CreatedTime: timestamppb.Now(),
+			}},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, gErr := s.CreateServiceAccount(auth.DisabledAuthTestContext(repoFn, tc.req.GetItem().GetScopeId()), tc.req)
+			if tc.err != nil {
+				require.Error(gErr)
+				assert.True(errors.Is(gErr, tc.err), "CreateServiceAccount(%+v) got error %v, wanted %v", tc.req, gErr, tc.err)
+				return
+			}
+			require.NoError(gErr)
+			assert.Contains(got.GetUri(), tc.res.Uri)
+			assert.True(strings.HasPrefix(got.GetItem().GetId(), iam.ServiceAccountPrefix+"_"))
+			assert.NotNil(got.GetItem().GetCreatedTime())
+			assert.NotNil(got.GetItem().GetUpdatedTime())
+
+			// Clear all values which are hard to compare against.
+			got.Uri, tc.res.Uri = "", ""
+			got.Item.Id = ""
+			got.Item.CreatedTime, got.Item.UpdatedTime = nil, nil
+			assert.Empty(cmp.Diff(tc.res, got, protocmp.Transform()), "CreateServiceAccount(%q) got response %q, wanted %q", tc.req, got, tc.res)
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	defaultSa, repoFn := createDefaultServiceAccountAndRepo(t)
+	s, err := serviceaccounts.NewService(repoFn)
+	require.NoError(t, err)
+	iamRepo, err := repoFn()
+	require.NoError(t, err)
+
+	cases := []struct {
+		name string
+		req  func(sa *iam.ServiceAccount) *pbs.UpdateServiceAccountRequest
+		res  func(sa *iam.ServiceAccount) *pb.ServiceAccount
+		err  error
+	}{
+		{
+			name: "Update the name and description",
+			req: func(sa *iam.ServiceAccount) *pbs.UpdateServiceAccountRequest {
+				return &pbs.UpdateServiceAccountRequest{
+					Id:         sa.GetPublicId(),
+					UpdateMask: &field_mask.FieldMask{Paths: []string{"name", "description"}},
+					Item: &pb.ServiceAccount{
+						Version:     sa.GetVersion(),
+						Name:        wrapperspb.String("new"),
+						Description: wrapperspb.String("desc"),
+					},
+				}
+			},
+			res: func(sa *iam.ServiceAccount) *pb.ServiceAccount {
+				return &pb.ServiceAccount{
+					Id:                          sa.GetPublicId(),
+					ScopeId:                     sa.GetScopeId(),
+					Scope:                       &scopes.ScopeInfo{Id: sa.GetScopeId(), Type: scope.Org.String(), ParentScopeId: scope.Global.String()},
+					Name:                        wrapperspb.String("new"),
+					Description:                 wrapperspb.String("desc"),
+					CreatedTime:                 sa.GetCreateTime().GetTimestamp(),
+					Version:                     sa.GetVersion() + 1,
+					AuthorizedActions:           testAuthorizedActions,
+					AuthorizedCollectionActions: testAuthorizedCollectionActions,
+				}
+			},
+		},
+		{
+			name: "Unset the description",
+			req: func(sa *iam.ServiceAccount) *pbs.UpdateServiceAccountRequest {
+				return &pbs.UpdateServiceAccountRequest{
+					Id:         sa.GetPublicId(),
+					UpdateMask: &field_mask.FieldMask{Paths: []string{"description"}},
+					Item:       &pb.ServiceAccount{Version: sa.GetVersion()},
+				}
+			},
+			res: func(sa *iam.ServiceAccount) *pb.ServiceAccount {
+				return &pb.ServiceAccount{
+					Id:                          sa.GetPublicId(),
+					ScopeId:                     sa.GetScopeId(),
+					Scope:                       &scopes.ScopeInfo{Id: sa.GetScopeId(), Type: scope.Org.String(), ParentScopeId: scope.Global.String()},
+					Name:                        wrapperspb.String(sa.GetName()),
+					CreatedTime:                 sa.GetCreateTime().GetTimestamp(),
+					Version:                     sa.GetVersion() + 1,
+					AuthorizedActions:           testAuthorizedActions,
+					AuthorizedCollectionActions: testAuthorizedCollectionActions,
+				}
+			},
+		},
+		{
+			name: "Duplicate name",
+			req: func(sa *iam.ServiceAccount) *pbs.UpdateServiceAccountRequest {
+				return &pbs.UpdateServiceAccountRequest{
+					Id:         sa.GetPublicId(),
+					UpdateMask: &field_mask.FieldMask{Paths: []string{"name"}},
+					Item:       &pb.ServiceAccount{Version: sa.GetVersion(), Name: wrapperspb.String(defaultSa.GetName())},
+				}
+			},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Incorrect version",
+			req: func(sa *iam.ServiceAccount) *pbs.UpdateServiceAccountRequest {
+				return &pbs.UpdateServiceAccountRequest{
+					Id:         sa.GetPublicId(),
+					UpdateMask: &field_mask.FieldMask{Paths: []string{"name"}},
+					Item:       &pb.ServiceAccount{Version: sa.GetVersion() + 1, Name: wrapperspb.String("new")},
+				}
+			},
+			err: handlers.ApiErrorWithCode(codes.NotFound),
+		},
+		{
+			name: "No update mask",
+			req: func(sa *iam.ServiceAccount) *pbs.UpdateServiceAccountRequest {
+				return &pbs.UpdateServiceAccountRequest{
+					Id:   sa.GetPublicId(),
+					Item: &pb.ServiceAccount{Version: sa.GetVersion(), Name: wrapperspb.String("new")},
+				}
+			},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Only non existent paths in mask",
+			req: func(sa *iam.ServiceAccount) *pbs.UpdateServiceAccountRequest {
+				return &pbs.UpdateServiceAccountRequest{
+					Id:         sa.GetPublicId(),
+					UpdateMask: &field_mask.FieldMask{Paths: []string{"nonexistent_field"}},
+					Item:       &pb.ServiceAccount{Version: sa.GetVersion(), Name: wrapperspb.String("new")},
+				}
+			},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Non existing service account",
+			req: func(sa *iam.ServiceAccount) *pbs.UpdateServiceAccountRequest {
+				return &pbs.UpdateServiceAccountRequest{
+					Id:         iam.ServiceAccountPrefix + "_DoesntExis",
+					UpdateMask: &field_mask.FieldMask{Paths: []string{"name"}},
+					Item:       &pb.ServiceAccount{Version: sa.GetVersion(), Name: wrapperspb.String("new")},
+				}
+			},
+			err: handlers.ApiErrorWithCode(codes.NotFound),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			sa := iam.TestServiceAccount(t, iamRepo, defaultSa.GetScopeId(), iam.WithName(tc.name), iam.WithDescription("default"))
+			req := tc.req(sa)
+			got, gErr := s.UpdateServiceAccount(auth.DisabledAuthTestContext(repoFn, sa.GetScopeId()), req)
+			if tc.err != nil {
+				require.Error(gErr)
+				assert.True(errors.Is(gErr, tc.err), "UpdateServiceAccount(%+v) got error %v, wanted %v", req, gErr, tc.err)
+				return
+			}
+			require.NoError(gErr)
+			assert.True(got.GetItem().GetUpdatedTime().AsTime().After(sa.GetUpdateTime().AsTime()))
+			got.Item.UpdatedTime = nil
+			assert.Empty(cmp.Diff(tc.res(sa), got.GetItem(), protocmp.Transform()), "UpdateServiceAccount(%q) got response %q", req, got)
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrap := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrap)
+	iamRepo := iam.TestRepo(t, conn, wrap)
+	repoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	tokenRepo, err := authtoken.NewRepository(rw, rw, kms)
+	require.NoError(t, err)
+	s, err := serviceaccounts.NewService(repoFn)
+	require.NoError(t, err)
+
+	o, _ := iam.TestScopes(t, iamRepo)
+	sa := iam.TestServiceAccount(t, iamRepo, o.GetPublicId())
+	k, err := tokenRepo.CreateApiKey(ctx, sa)
+	require.NoError(t, err)
+
+	cases := []struct {
+		name string
+		req  *pbs.DeleteServiceAccountRequest
+		err  error
+	}{
+		{
+			name: "Delete an existing service account",
+			req:  &pbs.DeleteServiceAccountRequest{Id: sa.GetPublicId()},
+		},
+		{
+			name: "Delete it twice",
+			req:  &pbs.DeleteServiceAccountRequest{Id: sa.GetPublicId()},
+			err:  handlers.ApiErrorWithCode(codes.NotFound),
+		},
+		{
+			name: "Delete a non existing service account",
+			req:  &pbs.DeleteServiceAccountRequest{Id: iam.ServiceAccountPrefix + "_doesntexis"},
+			err:  handlers.ApiErrorWithCode(codes.NotFound),
+		},
+		{
+			name: "Bad service account id formatting",
+			req:  &pbs.DeleteServiceAccountRequest{Id: "bad_format"},
+			err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, gErr := s.DeleteServiceAccount(auth.DisabledAuthTestContext(repoFn, o.GetPublicId()), tc.req)
+			if tc.err != nil {
+				require.Error(gErr)
+				assert.True(errors.Is(gErr, tc.err), "DeleteServiceAccount(%+v) got error %v, wanted %v", tc.req, gErr, tc.err)
+				return
+			}
+			require.NoError(gErr)
+			assert.Nil(got)
+		})
+	}
+
+	// The api keys of a deleted service account are deleted with it
+	got, err := tokenRepo.LookupApiKey(ctx, k.GetPublicId())
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
+
+func TestList_Pagination(t *testing.T) {
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrap := db.TestWrapper(t)
+	iamRepo := iam.TestRepo(t, conn, wrap)
+	repoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	s, err := serviceaccounts.NewService(repoFn)
+	require.NoError(t, err)
+
+	o, _ := iam.TestScopes(t, iamRepo)
+	// Paginated listings are oldest first
+	var want []string
+	for i := 0; i < 5; i++ {
+		sa := iam.TestServiceAccount(t, iamRepo, o.GetPublicId())
+		want = append(want, sa.GetPublicId())
+	}
+	pagination.TestList(t,
+		auth.DisabledAuthTestContext(repoFn, o.GetPublicId()),
+		auth.DisabledAuthTestContext(repoFn, o.GetPublicId(), auth.WithUserId(globals.AnonymousUserId)),
+		resource.ApiKey,
+		want,
+		func(ctx context.Context, listToken string, pageSize uint32) (pagination.TestListResponse, []string, error) {
+			got, err := s.ListServiceAccounts(ctx, &pbs.ListServiceAccountsRequest{ScopeId: o.GetPublicId(), ListToken: listToken, PageSize: pageSize})
+			var ids []string
+			for _, item := range got.GetItems() {
+				ids = append(ids, item.GetId())
+			}
+			return got, ids, err
+		},
+		func(t *testing.T, id string) {
+			_, err := iamRepo.DeleteServiceAccount(ctx, id)
+			require.NoError(t, err)
+		},
+	)
+}
@@ -0,0 +1,408 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sessionrecordings
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/hashicorp/boundary/globals"
+	"github.com/hashicorp/boundary/internal/daemon/controller/auth"
+	"github.com/hashicorp/boundary/internal/daemon/controller/common"
+	"github.com/hashicorp/boundary/internal/daemon/controller/handlers"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
+	"github.com/hashicorp/boundary/internal/pagination"
+	"github.com/hashicorp/boundary/internal/perms"
+	"github.com/hashicorp/boundary/internal/recording"
+	"github.com/hashicorp/boundary/internal/requests"
+	"github.com/hashicorp/boundary/internal/target"
+	"github.com/hashicorp/boundary/internal/types/action"
+	"github.com/hashicorp/boundary/internal/types/resource"
+	"github.com/hashicorp/boundary/internal/types/scope"
+	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/scopes"
+	pb "github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/sessionrecordings"
+	"google.golang.org/genproto/googleapis/api/httpbody"
+	"google.golang.org/grpc/codes"
+)
+
+// recordingContentType is the content type of a downloaded session recording,
+// which is a JSON object on each line.
+const recordingContentType = "application/x-ndjson"
+
+var (
+	// IdActions contains the set of actions that can be performed on
+	// individual resources
+	IdActions = action.ActionSet{
+		action.NoOp,
+		action.Read,
+		action.Download,
+	}
+
+	// CollectionActions contains the set of actions that can be performed on
+	// this collection
+	CollectionActions = action.ActionSet{
+		action.List,
+	}
+)
+
+// Service handles request as described by the pbs.SessionRecordingServiceServer interface.
+type Service struct {
+	pbs.UnsafeSessionRecordingServiceServer
+
+	repoFn    recording.RepositoryFactory
+	iamRepoFn common.IamRepoFactory
+	storage   recording.Storage
+}
+
+var _ pbs.SessionRecordingServiceServer = (*Service)(nil)
+
+// NewService returns a session recording service which handles session
+// recording related requests to boundary. Recordings are downloaded from
+// storage, they cannot be downloaded if it is nil.
+func NewService(ctx context.Context, repoFn recording.RepositoryFactory, iamRepoFn common.IamRepoFactory, storage recording.Storage) (Service, error) {
+	const op = "sessionrecordings.NewService"
+	if repoFn == nil {
+		return Service{}, errors.New(ctx, errors.InvalidParameter, op, "missing recording repository")
+	}
+	if iamRepoFn == nil {
+		return Service{}, errors.New(ctx, errors.InvalidParameter, op, "missing iam repository")
+	}
+	return Service{repoFn: repoFn, iamRepoFn: iamRepoFn, storage: storage}, nil
+}
+
+// GetSessionRecording implements the interface pbs.SessionRecordingServiceServer.
+func (s Service) GetSessionRecording(ctx context.Context, req *pbs.GetSessionRecordingRequest) (*pbs.GetSessionRecordingResponse, error) {
+	const op = "sessionrecordings.(Service).GetSessionRecording"
+
+	if err := validateGetRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.Read)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	sr, err := s.getFromRepo(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	outputFields, ok := requests.OutputFields(ctx)
+	if !ok {
+		return nil, errors.New(ctx, errors.Internal, op, "no request context found")
+	}
+	outputOpts := make([]handlers.Option, 0, 3)
+	outputOpts = append(outputOpts, handlers.WithOutputFields(outputFields))
+	if outputFields.Has(globals.ScopeField) {
+		outputOpts = append(outputOpts, handlers.WithScope(authResults.Scope))
+	}
+	if outputFields.Has(globals.AuthorizedActionsField) {
+		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, sr.GetPublicId(), IdActions).Strings()))
+	}
+
+	item, err := toProto(ctx, sr, outputOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return &pbs.GetSessionRecordingResponse{Item: item}, nil
+}
+
+// ListSessionRecordings implements the interface pbs.SessionRecordingServiceServer.
+func (s Service) ListSessionRecordings(ctx context.Context, req *pbs.ListSessionRecordingsRequest) (*pbs.ListSessionRecordingsResponse, error) {
+	const op = "sessionrecordings.(Service).ListSessionRecordings"
+
+	if err := validateListRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetScopeId(), action.List)
+	if authResults.Error != nil {
+		// If it's forbidden, and it's a recursive request, and they're
+		// successfully authenticated but just not authorized, keep going as we
+		// may have authorization on downstream scopes. Or, if they've not
+		// authenticated, still process in case u_anon has permissions.
+		if (authResults.Error == handlers.ForbiddenError() || authResults.Error == handlers.UnauthenticatedError()) &&
+			req.GetRecursive() &&
+			authResults.AuthenticationFinished {
+		} else {
+			return nil, authResults.Error
+		}
+	}
+
+	var scopeIds map[string]*scopes.ScopeInfo
+	var err error
+	if !req.GetRecursive() {
+		scopeIds = map[string]*scopes.ScopeInfo{authResults.Scope.Id: authResults.Scope}
+	} else {
+		scopeIds, err = authResults.ScopesAuthorizedForList(ctx, req.GetScopeId(), resource.SessionRecording)
+		if err != nil {
+			return nil, err
+		}
+	}
+	// Session recordings only exist in projects.
+	var projectIds []string
+	for id := range scopeIds {
+		if handlers.ValidId(handlers.Id(id), scope.Project.Prefix()) {
+			projectIds = append(projectIds, id)
+		}
+	}
+	if len(projectIds) == 0 {
+		return &pbs.ListSessionRecordingsResponse{}, nil
+	}
+
+	filter, err := handlers.NewFilter(req.GetFilter())
+	if err != nil {
+		return nil, err
+	}
+
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	listItemsFn := func(ctx context.Context, page *db.Page, limit int) ([]*recording.SessionRecording, error) {
+		return repo.ListSessionRecordings(ctx, projectIds, recording.WithPage(page), recording.WithLimit(limit))
+	}
+	convertItemFn := func(ctx context.Context, item *recording.SessionRecording) (*pb.SessionRecording, bool, error) {
+		res := perms.Resource{
+			Id:      item.GetPublicId(),
+			ScopeId: item.ProjectId,
+			Type:    resource.SessionRecording,
+		}
+		authorizedActions := authResults.FetchActionSetForId(ctx, item.GetPublicId(), IdActions, auth.WithResource(&res)).Strings()
+		if len(authorizedActions) == 0 {
+			return nil, false, nil
+		}
+
+		outputFields := authResults.FetchOutputFields(res, action.List).SelfOrDefaults(authResults.UserId)
+		outputOpts := make([]handlers.Option, 0, 3)
+		outputOpts = append(outputOpts, handlers.WithOutputFields(outputFields))
+		if outputFields.Has(globals.ScopeField) {
+			outputOpts = append(outputOpts, handlers.WithScope(scopeIds[item.ProjectId]))
+		}
+		if outputFields.Has(globals.AuthorizedActionsField) {
+			outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authorizedActions))
+		}
+
+		pbItem, err := toProto(ctx, item, outputOpts...)
+		if err != nil {
+			return nil, false, err
+		}
+		return pbItem, filter.Match(pbItem), nil
+	}
+
+	listDeletedIdsFn := func(ctx context.Context, since time.Time) ([]string, error) {
+		deleted, err := repo.ListDeletedSessionRecordingIds(ctx, projectIds, since)
+		if err != nil {
+			return nil, err
+		}
+		return authResults.AuthorizedDeletedIds(ctx, perms.Resource{Type: resource.SessionRecording}, IdActions, deleted), nil
+	}
+	listReq := pagination.Request{
+		ResourceType: resource.SessionRecording,
+		GrantsHash:   authResults.GrantsHash(),
+		PageSize:     req.GetPageSize(),
+		ListToken:    req.GetListToken(),
+	}
+	page, err := pagination.List(ctx, listReq, listItemsFn, convertItemFn, listDeletedIdsFn)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &pbs.ListSessionRecordingsResponse{Items: page.Items}
+	if listReq.Paginated() {
+		resp.ResponseType = page.ResponseType()
+		resp.ListToken = page.ListToken
+		resp.SortBy = page.SortBy
+		resp.SortDir = pagination.SortDirAscending
+		resp.RemovedIds = page.RemovedIds
+	}
+	return resp, nil
+}
+
+// DownloadSessionRecording implements the interface pbs.SessionRecordingServiceServer.
+func (s Service) DownloadSessionRecording(ctx context.Context, req *pbs.DownloadSessionRecordingRequest) (*httpbody.HttpBody, error) {
+	const op = "sessionrecordings.(Service).DownloadSessionRecording"
+
+	if err := validateDownloadRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.Download)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	if s.storage == nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.FailedPrecondition, "This controller has no recording storage configured.")
+	}
+	sr, err := s.getFromRepo(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	r, err := s.storage.Open(ctx, sr.GetPublicId())
+	if err != nil {
+		if errors.IsNotFoundError(err) {
+			// Recordings are written by workers, so they are only found here
+			// when the controller's recording storage is shared with the
+			// worker which recorded the connection.
+			return nil, handlers.ApiErrorWithCodeAndMessage(codes.FailedPrecondition,
+				fmt.Sprintf("The contents of session recording %q are not in this controller's recording storage. Recordings can only be downloaded when the recording_storage_path of the controllers and workers is on a shared file system.", sr.GetPublicId()))
+		}
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	defer r.Close()
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithCode(errors.Io))
+	}
+	return &httpbody.HttpBody{
+		ContentType: recordingContentType,
+		Data:        data,
+	}, nil
+}
+
+func (s Service) getFromRepo(ctx context.Context, id string) (*recording.SessionRecording, error) {
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, err
+	}
+	sr, err := repo.LookupSessionRecording(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if sr == nil {
+		return nil, handlers.NotFoundErrorf("Session recording %q doesn't exist.", id)
+	}
+	return sr, nil
+}
+
+func (s Service) authResult(ctx context.Context, id string, a action.Type) auth.VerifyResults {
+	res := auth.VerifyResults{}
+
+	var parentId string
+	opts := []auth.Option{auth.WithType(resource.SessionRecording), auth.WithAction(a)}
+	switch a {
+	case action.List:
+		parentId = id
+		iamRepo, err := s.iamRepoFn()
+		if err != nil {
+			res.Error = err
+			return res
+		}
+		scp, err := iamRepo.LookupScope(ctx, parentId)
+		if err != nil {
+			res.Error = err
+			return res
+		}
+		if scp == nil {
+			res.Error = handlers.NotFoundError()
+			return res
+		}
+	case action.Read, action.Download:
+		repo, err := s.repoFn()
+		if err != nil {
+			res.Error = err
+			return res
+		}
+		sr, err := repo.LookupSessionRecording(ctx, id)
+		if err != nil {
+			res.Error = err
+			return res
+		}
+		if sr == nil {
+			res.Error = handlers.NotFoundError()
+			return res
+		}
+		parentId = sr.ProjectId
+		opts = append(opts, auth.WithId(id))
+	default:
+		res.Error = stderrors.New("unsupported action")
+		return res
+	}
+	opts = append(opts, auth.WithScopeId(parentId))
+	return auth.Verify(ctx, opts...)
+}
+
+func toProto(ctx context.Context, in *recording.SessionRecording, opt ...handlers.Option) (*pb.SessionRecording, error) {
+	opts := handlers.GetOpts(opt...)
+	if opts.WithOutputFields == nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "output fields not found when building session recording proto")
+	}
+	outputFields := *opts.WithOutputFields
+
+	out := pb.SessionRecording{}
+	if outputFields.Has(globals.IdField) {
+		out.Id = in.GetPublicId()
+	}
+	if outputFields.Has(globals.ScopeIdField) {
+		out.ScopeId = in.ProjectId
+	}
+	if outputFields.Has(globals.ScopeField) {
+		out.Scope = opts.WithScope
+	}
+	if outputFields.Has(globals.SessionIdField) {
+		out.SessionId = in.SessionId
+	}
+	if outputFields.Has(globals.ConnectionIdField) {
+		out.ConnectionId = in.ConnectionId
+	}
+	if outputFields.Has(globals.TargetIdField) {
+		out.TargetId = in.TargetId
+	}
+	if outputFields.Has(globals.UserIdField) {
+		out.UserId = in.UserId
+	}
+	if outputFields.Has(globals.TypeField) {
+		out.Type = target.SubtypeFromId(in.TargetId).String()
+	}
+	if outputFields.Has(globals.CreatedTimeField) {
+		out.CreatedTime = in.CreateTime.GetTimestamp()
+	}
+	if outputFields.Has(globals.UpdatedTimeField) {
+		out.UpdatedTime = in.UpdateTime.GetTimestamp()
+	}
+	if outputFields.Has(globals.EndTimeField) && in.EndTime != nil {
+		out.EndTime = in.EndTime.GetTimestamp()
+	}
+	if outputFields.Has(globals.AuthorizedActionsField) {
+		out.AuthorizedActions = opts.WithAuthorizedActions
+	}
+	return &out, nil
+}
+
+// A validateX method should exist for each method above.  These methods do not make calls to any backing service but enforce
+// requirements on the structure of the request.  They verify that:
+//   - The path passed in is correctly formatted
+//   - All required parameters are set
+//   - There are no conflicting parameters provided
+func validateGetRequest(req *pbs.GetSessionRecordingRequest) error {
+	return handlers.ValidateGetRequest(handlers.NoopValidatorFn, req, recording.SessionRecordingPrefix)
+}
+
+func validateListRequest(req *pbs.ListSessionRecordingsRequest) error {
+	badFields := map[string]string{}
+	if !handlers.ValidId(handlers.Id(req.GetScopeId()), scope.Project.Prefix()) &&
+		!req.GetRecursive() {
+		badFields["scope_id"] = "This field must be a valid project scope ID or the list operation must be recursive."
+	}
+	if _, err := handlers.NewFilter(req.GetFilter()); err != nil {
+		badFields["filter"] = fmt.Sprintf("This field could not be parsed. %v", err)
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Improperly formatted identifier.", badFields)
+	}
+	return nil
+}
+
+func validateDownloadRequest(req *pbs.DownloadSessionRecordingRequest) error {
+	badFields := map[string]string{}
+	if !handlers.ValidId(handlers.Id(req.GetId()), recording.SessionRecordingPrefix) {
+		badFields["id"] = "Improperly formatted identifier."
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Improperly formatted identifier.", badFields)
+	}
+	return nil
+}
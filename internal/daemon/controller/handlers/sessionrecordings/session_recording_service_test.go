@@ -0,0 +1,340 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sessionrecordings_test
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/boundary/globals"
+	"github.com/hashicorp/boundary/internal/daemon/controller/auth"
+	"github.com/hashicorp/boundary/internal/daemon/controller/handlers"
+	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/sessionrecordings"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/pagination"
+	"github.com/hashicorp/boundary/internal/recording"
+	"github.com/hashicorp/boundary/internal/target/tcp"
+	"github.com/hashicorp/boundary/internal/types/resource"
+	"github.com/hashicorp/boundary/internal/types/scope"
+	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/scopes"
+	pb "github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/sessionrecordings"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/testing/protocmp"
+)
+
+var testAuthorizedActions = []string{"no-op", "read", "download"}
+
+func toWire(sr *recording.SessionRecording, orgId string) *pb.SessionRecording {
+	return &pb.SessionRecording{
+		Id:                sr.GetPublicId(),
+		ScopeId:           sr.ProjectId,
+		Scope:             &scopes.ScopeInfo{Id: sr.ProjectId, Type: scope.Project.String(), ParentScopeId: orgId},
+		SessionId:         sr.SessionId,
+		ConnectionId:      sr.ConnectionId,
+		TargetId:          sr.TargetId,
+		UserId:            sr.UserId,
+		Type:              tcp.Subtype.String(),
+		CreatedTime:       sr.GetCreateTime().GetTimestamp(),
+		UpdatedTime:       sr.GetUpdateTime().GetTimestamp(),
+		AuthorizedActions: testAuthorizedActions,
+	}
+}
+
+func TestNewService(t *testing.T) {
+	ctx := context.Background()
+	repoFn := func() (*recording.Repository, error) { return nil, nil }
+	iamRepoFn := func() (*iam.Repository, error) { return nil, nil }
+
+	_, err := sessionrecordings.NewService(ctx, nil, iamRepoFn, nil)
+	assert.Truef(t, errors.Match(errors.T(errors.InvalidParameter), err), "got: %v", err)
+	_, err = sessionrecordings.NewService(ctx, repoFn, nil, nil)
+	assert.Truef(t, errors.Match(errors.T(errors.InvalidParameter), err), "got: %v", err)
+	// Recording storage is optional
+	_, err = sessionrecordings.NewService(ctx, repoFn, iamRepoFn, nil)
+	assert.NoError(t, err)
+}
+
+func TestGet(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	wrap := db.TestWrapper(t)
+	rw := db.New(conn)
+	iamRepo := iam.TestRepo(t, conn, wrap)
+
+	ctx := context.Background()
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	repoFn := func() (*recording.Repository, error) {
+		return recording.NewRepository(ctx, rw, rw)
+	}
+
+	o, p := iam.TestScopes(t, iamRepo)
+	sr := recording.TestSessionRecording(t, conn, p.GetPublicId())
+
+	cases := []struct {
+		name string
+		req  *pbs.GetSessionRecordingRequest
+		res  *pbs.GetSessionRecordingResponse
+		err  error
+	}{
+		{
+			name: "Get a session recording",
+			req:  &pbs.GetSessionRecordingRequest{Id: sr.GetPublicId()},
+			res:  &pbs.GetSessionRecordingResponse{Item: toWire(sr, o.GetPublicId())},
+		},
+		{
+			name: "Get a non existent session recording",
+			req:  &pbs.GetSessionRecordingRequest{Id: recording.SessionRecordingPrefix + "_DoesntExis"},
+			err:  handlers.ApiErrorWithCode(codes.NotFound),
+		},
+		{
+			name: "Wrong id prefix",
+			req:  &pbs.GetSessionRecordingRequest{Id: "j_1234567890"},
+			err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "space in id",
+			req:  &pbs.GetSessionRecordingRequest{Id: recording.SessionRecordingPrefix + "_1 23456789"},
+			err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			s, err := sessionrecordings.NewService(ctx, repoFn, iamRepoFn, nil)
+			require.NoError(err, "Couldn't create new session recording service.")
+
+			got, gErr := s.GetSessionRecording(auth.DisabledAuthTestContext(iamRepoFn, p.GetPublicId()), tc.req)
+			if tc.err != nil {
+				require.Error(gErr)
+				assert.True(errors.Is(gErr, tc.err), "GetSessionRecording(%+v) got error %v, wanted %v", tc.req, gErr, tc.err)
+				return
+			}
+			require.NoError(gErr)
+			assert.Empty(cmp.Diff(tc.res, got, protocmp.Transform()), "GetSessionRecording(%q) got response\n%q, wanted\n%q", tc.req, got, tc.res)
+		})
+	}
+}
+
+func TestList(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	wrap := db.TestWrapper(t)
+	rw := db.New(conn)
+	iamRepo := iam.TestRepo(t, conn, wrap)
+
+	ctx := context.Background()
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	repoFn := func() (*recording.Repository, error) {
+		return recording.NewRepository(ctx, rw, rw)
+	}
+
+	_, pNoRecordings := iam.TestScopes(t, iamRepo)
+	o, pWithRecordings := iam.TestScopes(t, iamRepo)
+	_, pOther := iam.TestScopes(t, iamRepo)
+	recording.TestSessionRecording(t, conn, pOther.GetPublicId())
+
+	// Unpaginated listings are most recent first
+	var wantRecordings []*pb.SessionRecording
+	for i := 0; i < 5; i++ {
+		sr := recording.TestSessionRecording(t, conn, pWithRecordings.GetPublicId())
+		wantRecordings = append([]*pb.SessionRecording{toWire(sr, o.GetPublicId())}, wantRecordings...)
+	}
+
+	cases := []struct {
+		name    string
+		scopeId string
+		req     *pbs.ListSessionRecordingsRequest
+		res     *pbs.ListSessionRecordingsResponse
+		err     error
+	}{
+		{
+			name:    "List many session recordings",
+			scopeId: pWithRecordings.GetPublicId(),
+			req:     &pbs.ListSessionRecordingsRequest{ScopeId: pWithRecordings.GetPublicId()},
+			res:     &pbs.ListSessionRecordingsResponse{Items: wantRecordings},
+		},
+		{
+			name:    "List no session recordings",
+			scopeId: pNoRecordings.GetPublicId(),
+			req:     &pbs.ListSessionRecordingsRequest{ScopeId: pNoRecordings.GetPublicId()},
+			res:     &pbs.ListSessionRecordingsResponse{},
+		},
+		{
+			name:    "Filter to one session recording",
+			scopeId: pWithRecordings.GetPublicId(),
+			req:     &pbs.ListSessionRecordingsRequest{ScopeId: pWithRecordings.GetPublicId(), Filter: fmt.Sprintf(`"/item/id"==%q`, wantRecordings[1].GetId())},
+			res:     &pbs.ListSessionRecordingsResponse{Items: wantRecordings[1:2]},
+		},
+		{
+			name:    "Filter to no session recordings",
+			scopeId: pWithRecordings.GetPublicId(),
+			req:     &pbs.ListSessionRecordingsRequest{ScopeId: pWithRecordings.GetPublicId(), Filter: `"/item/id"=="doesnt match"`},
+			res:     &pbs.ListSessionRecordingsResponse{},
+		},
+		{
+			name:    "Filter Bad Format",
+			scopeId: pWithRecordings.GetPublicId(),
+			req:     &pbs.ListSessionRecordingsRequest{ScopeId: pWithRecordings.GetPublicId(), Filter: `"//id/"=="bad"`},
+			err:     handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name:    "Org scope",
+			scopeId: o.GetPublicId(),
+			req:     &pbs.ListSessionRecordingsRequest{ScopeId: o.GetPublicId()},
+			err:     handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			s, err := sessionrecordings.NewService(ctx, repoFn, iamRepoFn, nil)
+			require.NoError(err, "Couldn't create new session recording service.")
+
+			got, gErr := s.ListSessionRecordings(auth.DisabledAuthTestContext(iamRepoFn, tc.scopeId), tc.req)
+			if tc.err != nil {
+				require.Error(gErr)
+				assert.True(errors.Is(gErr, tc.err), "ListSessionRecordings(%q) got error %v, wanted %v", tc.req, gErr, tc.err)
+				return
+			}
+			require.NoError(gErr)
+			assert.Empty(cmp.Diff(tc.res, got, protocmp.Transform()), "ListSessionRecordings(%q) got response %q, wanted %q", tc.req, got, tc.res)
+		})
+	}
+}
+
+func TestList_Pagination(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	wrap := db.TestWrapper(t)
+	rw := db.New(conn)
+	iamRepo := iam.TestRepo(t, conn, wrap)
+
+	ctx := context.Background()
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	repoFn := func() (*recording.Repository, error) {
+		return recording.NewRepository(ctx, rw, rw)
+	}
+	s, err := sessionrecordings.NewService(ctx, repoFn, iamRepoFn, nil)
+	require.NoError(t, err)
+
+	_, p := iam.TestScopes(t, iamRepo)
+	// Paginated listings are oldest first
+	srs := make(map[string]*recording.SessionRecording)
+	var want []string
+	for i := 0; i < 5; i++ {
+		sr := recording.TestSessionRecording(t, conn, p.GetPublicId())
+		srs[sr.GetPublicId()] = sr
+		want = append(want, sr.GetPublicId())
+	}
+	pagination.TestList(t,
+		auth.DisabledAuthTestContext(iamRepoFn, p.GetPublicId()),
+		auth.DisabledAuthTestContext(iamRepoFn, p.GetPublicId(), auth.WithUserId(globals.AnonymousUserId)),
+		resource.Target,
+		want,
+		func(ctx context.Context, listToken string, pageSize uint32) (pagination.TestListResponse, []string, error) {
+			got, err := s.ListSessionRecordings(ctx, &pbs.ListSessionRecordingsRequest{ScopeId: p.GetPublicId(), ListToken: listToken, PageSize: pageSize})
+			var ids []string
+			for _, item := range got.GetItems() {
+				ids = append(ids, item.GetId())
+			}
+			return got, ids, err
+		},
+		func(t *testing.T, id string) {
+			_, err := rw.Delete(ctx, srs[id])
+			require.NoError(t, err)
+		},
+	)
+}
+
+func TestDownload(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	wrap := db.TestWrapper(t)
+	rw := db.New(conn)
+	iamRepo := iam.TestRepo(t, conn, wrap)
+
+	ctx := context.Background()
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	repoFn := func() (*recording.Repository, error) {
+		return recording.NewRepository(ctx, rw, rw)
+	}
+	storage, err := recording.NewLocalStorage(ctx, filepath.Join(t.TempDir(), "recordings"))
+	require.NoError(t, err)
+
+	_, p := iam.TestScopes(t, iamRepo)
+	stored := recording.TestSessionRecording(t, conn, p.GetPublicId())
+	w, err := storage.Create(ctx, stored.GetPublicId())
+	require.NoError(t, err)
+	_, err = w.Write([]byte(`{"type":"header"}` + "\n"))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	notStored := recording.TestSessionRecording(t, conn, p.GetPublicId())
+
+	cases := []struct {
+		name    string
+		storage recording.Storage
+		req     *pbs.DownloadSessionRecordingRequest
+		want    string
+		err     error
+	}{
+		{
+			name:    "Download a session recording",
+			storage: storage,
+			req:     &pbs.DownloadSessionRecordingRequest{Id: stored.GetPublicId()},
+			want:    `{"type":"header"}` + "\n",
+		},
+		{
+			name:    "Recording not in the storage",
+			storage: storage,
+			req:     &pbs.DownloadSessionRecordingRequest{Id: notStored.GetPublicId()},
+			err:     handlers.ApiErrorWithCode(codes.FailedPrecondition),
+		},
+		{
+			name: "No recording storage",
+			req:  &pbs.DownloadSessionRecordingRequest{Id: stored.GetPublicId()},
+			err:  handlers.ApiErrorWithCode(codes.FailedPrecondition),
+		},
+		{
+			name:    "Download a non existent session recording",
+			storage: storage,
+			req:     &pbs.DownloadSessionRecordingRequest{Id: recording.SessionRecordingPrefix + "_DoesntExis"},
+			err:     handlers.ApiErrorWithCode(codes.NotFound),
+		},
+		{
+			name:    "Wrong id prefix",
+			storage: storage,
+			req:     &pbs.DownloadSessionRecordingRequest{Id: "j_1234567890"},
+			err:     handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			s, err := sessionrecordings.NewService(ctx, repoFn, iamRepoFn, tc.storage)
+			require.NoError(err, "Couldn't create new session recording service.")
+
+			got, gErr := s.DownloadSessionRecording(auth.DisabledAuthTestContext(iamRepoFn, p.GetPublicId()), tc.req)
+			if tc.err != nil {
+				require.Error(gErr)
+				assert.True(errors.Is(gErr, tc.err), "DownloadSessionRecording(%+v) got error %v, wanted %v", tc.req, gErr, tc.err)
+				return
+			}
+			require.NoError(gErr)
+			assert.Equal("application/x-ndjson", got.GetContentType())
+			assert.Equal(tc.want, string(got.GetData()))
+		})
+	}
+}
@@ -7,13 +7,16 @@ import (
 	"context"
 	stderrors "errors"
 	"fmt"
+	"time"
 
 	"github.com/hashicorp/boundary/globals"
 	"github.com/hashicorp/boundary/internal/daemon/controller/auth"
 	"github.com/hashicorp/boundary/internal/daemon/controller/common"
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers"
+	"github.com/hashicorp/boundary/internal/db"
 	"github.com/hashicorp/boundary/internal/errors"
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
+	"github.com/hashicorp/boundary/internal/pagination"
 	"github.com/hashicorp/boundary/internal/perms"
 	"github.com/hashicorp/boundary/internal/requests"
 	"github.com/hashicorp/boundary/internal/session"
@@ -23,6 +26,7 @@ import (
 	"github.com/hashicorp/boundary/internal/types/scope"
 	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/scopes"
 	pb "github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/sessions"
+	"golang.org/x/exp/maps"
 	"google.golang.org/grpc/codes"
 )
 
@@ -35,6 +39,9 @@ var (
 		action.ReadSelf,
 		action.Cancel,
 		action.CancelSelf,
+		action.ListConnections,
+		action.ListConnectionsSelf,
+		action.Extend,
 	}
 
 	// CollectionActions contains the set of actions that can be performed on
@@ -151,7 +158,7 @@ func (s Service) ListSessions(ctx context.Context, req *pbs.ListSessionsRequest)
 		scopeIds, err = authResults.ScopesAuthorizedForList(ctx, req.GetScopeId(), resource.Session)
 	}
 
-	listPerms := authResults.ACL().ListPermissions(scopeIds, resource.Session, IdActions, authResults.UserId)
+	listPerms := authResults.ACL().ListPermissions(scopeIds, resource.Session, IdActions, authResults.UserId, perms.WithRequestAttributes(authResults.RequestAttributes()))
 
 	repo, err := s.repoFn(session.WithPermissions(&perms.UserPermissions{
 		UserId:      authResults.UserId,
@@ -161,28 +168,28 @@ func (s Service) ListSessions(ctx context.Context, req *pbs.ListSessionsRequest)
 		return nil, errors.Wrap(ctx, err, op)
 	}
 
-	sesList, err := repo.ListSessions(ctx, session.WithTerminated(req.GetIncludeTerminated()))
-	if err != nil {
-		return nil, err
-	}
-	if len(sesList) == 0 {
-		return &pbs.ListSessionsResponse{}, nil
-	}
-
 	filter, err := handlers.NewFilter(req.GetFilter())
 	if err != nil {
 		return nil, err
 	}
-	finalItems := make([]*pb.Session, 0, len(sesList))
-	res := perms.Resource{
-		Type: resource.Session,
+
+	where, whereArgs := filter.WhereClause(session.ListFilterColumns)
+	listItemsFn := func(ctx context.Context, page *db.Page, limit int) ([]*session.Session, error) {
+		return repo.ListSessions(ctx,
+			session.WithTerminated(req.GetIncludeTerminated()),
+			session.WithWhereClause(where, whereArgs),
+			session.WithPage(page),
+			session.WithLimit(limit))
 	}
-	for _, item := range sesList {
-		res.Id = item.GetPublicId()
-		res.ScopeId = item.GetProjectId()
+	convertItemFn := func(ctx context.Context, item *session.Session) (*pb.Session, bool, error) {
+		res := perms.Resource{
+			Id:      item.GetPublicId(),
+			ScopeId: item.GetProjectId(),
+			Type:    resource.Session,
+		}
 		authorizedActions := authResults.FetchActionSetForId(ctx, item.GetPublicId(), IdActions, auth.WithResource(&res)).Strings()
 		if len(authorizedActions) == 0 {
-			continue
+			return nil, false, nil
 		}
 
 		outputFields := authResults.FetchOutputFields(res, action.List).SelfOrDefaults(authResults.UserId)
@@ -195,17 +202,41 @@ func (s Service) ListSessions(ctx context.Context, req *pbs.ListSessionsRequest)
 			outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authorizedActions))
 		}
 
-		item, err := toProto(ctx, item, outputOpts...)
+		pbItem, err := toProto(ctx, item, outputOpts...)
 		if err != nil {
-			return nil, err
+			return nil, false, err
 		}
+		return pbItem, filter.Match(pbItem), nil
+	}
 
-		if filter.Match(item) {
-			finalItems = append(finalItems, item)
+	listDeletedIdsFn := func(ctx context.Context, since time.Time) ([]string, error) {
+		deleted, err := repo.ListDeletedIds(ctx, maps.Keys(scopeIds), since)
+		if err != nil {
+			return nil, err
 		}
+		return authResults.AuthorizedDeletedIds(ctx, perms.Resource{Type: resource.Session}, IdActions, deleted), nil
+	}
+
+	listReq := pagination.Request{
+		ResourceType: resource.Session,
+		GrantsHash:   authResults.GrantsHash(),
+		PageSize:     req.GetPageSize(),
+		ListToken:    req.GetListToken(),
+	}
+	page, err := pagination.List(ctx, listReq, listItemsFn, convertItemFn, listDeletedIdsFn)
+	if err != nil {
+		return nil, err
 	}
 
-	return &pbs.ListSessionsResponse{Items: finalItems}, nil
+	resp := &pbs.ListSessionsResponse{Items: page.Items}
+	if listReq.Paginated() {
+		resp.ResponseType = page.ResponseType()
+		resp.ListToken = page.ListToken
+		resp.SortBy = page.SortBy
+		resp.SortDir = pagination.SortDirAscending
+		resp.RemovedIds = page.RemovedIds
+	}
+	return resp, nil
 }
 
 // CancelSession implements the interface pbs.SessionServiceServer.
@@ -292,6 +323,122 @@ func (s Service) CancelSession(ctx context.Context, req *pbs.CancelSessionReques
 	return &pbs.CancelSessionResponse{Item: item}, nil
 }
 
+// ExtendSession implements the interface pbs.SessionServiceServer.
+func (s Service) ExtendSession(ctx context.Context, req *pbs.ExtendSessionRequest) (*pbs.ExtendSessionResponse, error) {
+	const op = "sessions.(Service).ExtendSession"
+
+	if err := validateExtendRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.Extend, false)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	ses, err := s.getFromRepo(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	if len(ses.States) > 0 {
+		switch ses.States[0].Status {
+		case session.StatusCanceling, session.StatusTerminated:
+			return nil, handlers.ApiErrorWithCodeAndMessage(codes.FailedPrecondition, "Session %q is no longer active.", req.GetId())
+		}
+	}
+
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, err
+	}
+	ses, err = repo.ExtendSession(ctx, req.GetId(), req.GetVersion(), time.Duration(req.GetDurationSeconds())*time.Second)
+	switch {
+	case err == nil:
+	case errors.Match(errors.T(errors.VersionMismatch), err):
+		return nil, handlers.ConflictErrorf(fmt.Sprintf("Session %q is not at version %d.", req.GetId(), req.GetVersion()))
+	case errors.Match(errors.T(errors.InvalidSessionState), err):
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.FailedPrecondition, "Session %q can no longer be extended.", req.GetId())
+	case errors.Match(errors.T(errors.InvalidParameter), err):
+		return nil, handlers.InvalidArgumentErrorf("Invalid fields provided in request.", map[string]string{
+			"duration_seconds": "The session can not be extended to expire later than its target's session_max_seconds from now.",
+		})
+	default:
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to extend session"))
+	}
+
+	outputFields, ok := requests.OutputFields(ctx)
+	if !ok {
+		return nil, errors.New(ctx, errors.Internal, op, "no request context found")
+	}
+	outputOpts := make([]handlers.Option, 0, 3)
+	outputOpts = append(outputOpts, handlers.WithOutputFields(outputFields))
+	if outputFields.Has(globals.ScopeField) {
+		outputOpts = append(outputOpts, handlers.WithScope(authResults.Scope))
+	}
+	if outputFields.Has(globals.AuthorizedActionsField) {
+		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, ses.GetPublicId(), IdActions).Strings()))
+	}
+
+	item, err := toProto(ctx, ses, outputOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return &pbs.ExtendSessionResponse{Item: item}, nil
+}
+
+// ListSessionConnections implements the interface pbs.SessionServiceServer.
+func (s Service) ListSessionConnections(ctx context.Context, req *pbs.ListSessionConnectionsRequest) (*pbs.ListSessionConnectionsResponse, error) {
+	const op = "sessions.(Service).ListSessionConnections"
+
+	if err := validateListConnectionsRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.ListConnectionsSelf, false)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	ses, err := s.getFromRepo(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	var outputFields *perms.OutputFields
+	authorizedActions := authResults.FetchActionSetForId(ctx, ses.GetPublicId(), IdActions)
+
+	// Check to see if we need to verify ListConnections vs. just
+	// ListConnectionsSelf
+	if ses.UserId != authResults.UserId {
+		if !authorizedActions.HasAction(action.ListConnections) {
+			return nil, handlers.ForbiddenError()
+		}
+		outputFields = authResults.FetchOutputFields(perms.Resource{
+			Id:      ses.GetPublicId(),
+			ScopeId: ses.ProjectId,
+			Type:    resource.Session,
+		}, action.ListConnections).SelfOrDefaults(authResults.UserId)
+	} else {
+		var ok bool
+		outputFields, ok = requests.OutputFields(ctx)
+		if !ok {
+			return nil, errors.New(ctx, errors.Internal, op, "no request context found")
+		}
+	}
+
+	filter, err := handlers.NewFilter(req.GetFilter())
+	if err != nil {
+		return nil, err
+	}
+	items := make([]*pb.Connection, 0, len(ses.Connections))
+	if !outputFields.Has(globals.ConnectionsField) {
+		return &pbs.ListSessionConnectionsResponse{Items: items}, nil
+	}
+	for _, c := range ses.Connections {
+		item := connectionToProto(c)
+		if filter.Match(item) {
+			items = append(items, item)
+		}
+	}
+	return &pbs.ListSessionConnectionsResponse{Items: items}, nil
+}
+
 func (s Service) getFromRepo(ctx context.Context, id string) (*session.Session, error) {
 	repo, err := s.repoFn()
 	if err != nil {
@@ -332,7 +479,7 @@ func (s Service) authResult(ctx context.Context, id string, a action.Type, ignor
 			res.Error = handlers.NotFoundError()
 			return res
 		}
-	case action.Read, action.ReadSelf, action.Cancel, action.CancelSelf:
+	case action.Read, action.ReadSelf, action.Cancel, action.CancelSelf, action.ListConnections, action.ListConnectionsSelf, action.Extend:
 		repo, err := s.repoFn()
 		if err != nil {
 			res.Error = err
@@ -444,23 +591,49 @@ func toProto(ctx context.Context, in *session.Session, opt ...handlers.Option) (
 		if outputFields.Has(globals.ConnectionsField) {
 			connections := make([]*pb.Connection, 0, len(in.Connections))
 			for _, c := range in.Connections {
-				connections = append(connections, &pb.Connection{
-					ClientTcpAddress:   c.ClientTcpAddress,
-					ClientTcpPort:      c.ClientTcpPort,
-					EndpointTcpAddress: c.EndpointTcpAddress,
-					EndpointTcpPort:    c.EndpointTcpPort,
-					BytesUp:            c.BytesUp,
-					BytesDown:          c.BytesDown,
-					ClosedReason:       c.ClosedReason,
-				})
+				connections = append(connections, connectionToProto(c))
 			}
 			out.Connections = append(out.Connections, connections...)
 		}
+		if outputFields.Has(globals.ConnectionCountField) {
+			out.ConnectionCount = uint32(len(in.Connections))
+		}
+		for _, c := range in.Connections {
+			if outputFields.Has(globals.TotalBytesUpField) {
+				out.TotalBytesUp += c.BytesUp
+			}
+			if outputFields.Has(globals.TotalBytesDownField) {
+				out.TotalBytesDown += c.BytesDown
+			}
+		}
 	}
 
 	return &out, nil
 }
 
+func connectionToProto(in *session.Connection) *pb.Connection {
+	out := &pb.Connection{
+		Id:                 in.GetPublicId(),
+		ClientTcpAddress:   in.ClientTcpAddress,
+		ClientTcpPort:      in.ClientTcpPort,
+		EndpointTcpAddress: in.EndpointTcpAddress,
+		EndpointTcpPort:    in.EndpointTcpPort,
+		BytesUp:            in.BytesUp,
+		BytesDown:          in.BytesDown,
+		ClosedReason:       in.ClosedReason,
+		UserClientIp:       in.UserClientIp,
+		Status:             in.Status().String(),
+		CreatedTime:        in.CreateTime.GetTimestamp(),
+	}
+	if t := in.StateStartTime(session.StatusConnected); t != nil {
+		out.ConnectedTime = t.GetTimestamp()
+	}
+	if t := in.StateStartTime(session.StatusClosed); t != nil {
+		out.ClosedTime = t.GetTimestamp()
+	}
+	return out
+}
+
 // A validateX method should exist for each method above.  These methods do not make calls to any backing service but enforce
 // requirements on the structure of the request.  They verify that:
 //   - The path passed in is correctly formatted
@@ -485,6 +658,37 @@ func validateListRequest(req *pbs.ListSessionsRequest) error {
 	return nil
 }
 
+func validateListConnectionsRequest(req *pbs.ListSessionConnectionsRequest) error {
+	badFields := map[string]string{}
+	if !handlers.ValidId(handlers.Id(req.GetId()), session.SessionPrefix) {
+		badFields["id"] = "Improperly formatted identifier."
+	}
+	if _, err := handlers.NewFilter(req.GetFilter()); err != nil {
+		badFields["filter"] = fmt.Sprintf("This field could not be parsed. %v", err)
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Improperly formatted identifier.", badFields)
+	}
+	return nil
+}
+
+func validateExtendRequest(req *pbs.ExtendSessionRequest) error {
+	badFields := map[string]string{}
+	if !handlers.ValidId(handlers.Id(req.GetId()), session.SessionPrefix) {
+		badFields["id"] = "Improperly formatted identifier."
+	}
+	if req.GetVersion() == 0 {
+		badFields["version"] = "Required field."
+	}
+	if req.GetDurationSeconds() == 0 {
+		badFields["duration_seconds"] = "This must be greater than zero."
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Invalid fields provided in request.", badFields)
+	}
+	return nil
+}
+
 func validateCancelRequest(req *pbs.CancelSessionRequest) error {
 	badFields := map[string]string{}
 	if !handlers.ValidId(handlers.Id(req.GetId()), session.SessionPrefix) {
@@ -22,11 +22,15 @@ import (
 	"github.com/hashicorp/boundary/internal/host/static"
 	"github.com/hashicorp/boundary/internal/iam"
 	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/pagination"
+	"github.com/hashicorp/boundary/internal/perms"
 	"github.com/hashicorp/boundary/internal/requests"
 	"github.com/hashicorp/boundary/internal/server"
 	"github.com/hashicorp/boundary/internal/session"
 	"github.com/hashicorp/boundary/internal/target"
 	"github.com/hashicorp/boundary/internal/target/tcp"
+	"github.com/hashicorp/boundary/internal/types/action"
+	"github.com/hashicorp/boundary/internal/types/resource"
 	"github.com/hashicorp/boundary/internal/types/scope"
 	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/scopes"
 	pb "github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/sessions"
@@ -36,7 +40,7 @@ import (
 	"google.golang.org/protobuf/testing/protocmp"
 )
 
-var testAuthorizedActions = []string{"read:self", "cancel:self"}
+var testAuthorizedActions = []string{"read:self", "cancel:self", "list-connections:self"}
 
 func TestGetSession(t *testing.T) {
 	conn, _ := db.TestSetup(t, "postgres")
@@ -268,6 +272,115 @@ func TestList_Self(t *testing.T) {
 	}
 }
 
+func TestListSessionConnections(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	wrap := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrap)
+
+	iamRepo := iam.TestRepo(t, conn, wrap)
+
+	rw := db.New(conn)
+
+	ctx := context.Background()
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	sessRepoFn := func(opt ...session.Option) (*session.Repository, error) {
+		return session.NewRepository(ctx, rw, rw, kms, opt...)
+	}
+	tokenRepoFn := func() (*authtoken.Repository, error) {
+		return authtoken.NewRepository(rw, rw, kms)
+	}
+	serversRepoFn := func() (*server.Repository, error) {
+		return server.NewRepository(rw, rw, kms)
+	}
+
+	o, p := iam.TestScopes(t, iamRepo)
+	at := authtoken.TestAuthToken(t, conn, kms, o.GetPublicId())
+	hc := static.TestCatalogs(t, conn, p.GetPublicId(), 1)[0]
+	hs := static.TestSets(t, conn, hc.GetPublicId(), 1)[0]
+	h := static.TestHosts(t, conn, hc.GetPublicId(), 1)[0]
+	static.TestSetMembers(t, conn, hs.GetPublicId(), []*static.Host{h})
+	tar := tcp.TestTarget(context.Background(), t, conn, p.GetPublicId(), "test", target.WithHostSources([]string{hs.GetPublicId()}))
+
+	sess := session.TestSession(t, conn, wrap, session.ComposedOf{
+		UserId:      at.GetIamUserId(),
+		HostId:      h.GetPublicId(),
+		TargetId:    tar.GetPublicId(),
+		HostSetId:   hs.GetPublicId(),
+		AuthTokenId: at.GetPublicId(),
+		ProjectId:   p.GetPublicId(),
+		Endpoint:    "tcp://127.0.0.1:22",
+	})
+	c1 := session.TestConnection(t, conn, sess.GetPublicId(), "127.0.0.1", 22, "127.0.0.2", 2222, "127.0.0.3")
+	c2 := session.TestConnection(t, conn, sess.GetPublicId(), "127.0.0.1", 23, "127.0.0.4", 2222, "127.0.0.3")
+
+	cases := []struct {
+		name    string
+		req     *pbs.ListSessionConnectionsRequest
+		wantIds []string
+		err     error
+	}{
+		{
+			name:    "List all connections",
+			req:     &pbs.ListSessionConnectionsRequest{Id: sess.GetPublicId()},
+			wantIds: []string{c1.GetPublicId(), c2.GetPublicId()},
+		},
+		{
+			name:    "Filter connections",
+			req:     &pbs.ListSessionConnectionsRequest{Id: sess.GetPublicId(), Filter: `"/item/endpoint_tcp_address" == "127.0.0.4"`},
+			wantIds: []string{c2.GetPublicId()},
+		},
+		{
+			name: "Bad filter",
+			req:  &pbs.ListSessionConnectionsRequest{Id: sess.GetPublicId(), Filter: `"/item/endpoint_tcp_address" == `},
+			err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "List connections of a non existent Session",
+			req:  &pbs.ListSessionConnectionsRequest{Id: session.SessionPrefix + "_DoesntExis"},
+			err:  handlers.ApiErrorWithCode(codes.NotFound),
+		},
+		{
+			name: "Wrong id prefix",
+			req:  &pbs.ListSessionConnectionsRequest{Id: "j_1234567890"},
+			err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+
+			s, err := sessions.NewService(sessRepoFn, iamRepoFn)
+			require.NoError(err, "Couldn't create new session service.")
+
+			requestInfo := authpb.RequestInfo{
+				TokenFormat: uint32(auth.AuthTokenTypeBearer),
+				PublicId:    at.GetPublicId(),
+				Token:       at.GetToken(),
+			}
+			requestContext := context.WithValue(context.Background(), requests.ContextRequestInformationKey, &requests.RequestContext{})
+			ctx := auth.NewVerifierContext(requestContext, iamRepoFn, tokenRepoFn, serversRepoFn, kms, &requestInfo)
+
+			got, gErr := s.ListSessionConnections(ctx, tc.req)
+			if tc.err != nil {
+				require.Error(gErr)
+				assert.True(errors.Is(gErr, tc.err), "ListSessionConnections(%+v) got error %v, wanted %v", tc.req, gErr, tc.err)
+				return
+			}
+			require.NoError(gErr)
+			var gotIds []string
+			for _, c := range got.GetItems() {
+				gotIds = append(gotIds, c.GetId())
+				assert.Equal(session.StatusAuthorized.String(), c.GetStatus())
+				assert.NotNil(c.GetCreatedTime())
+				assert.Nil(c.GetClosedTime())
+			}
+			assert.ElementsMatch(tc.wantIds, gotIds)
+		})
+	}
+}
+
 func TestList(t *testing.T) {
 	conn, _ := db.TestSetup(t, "postgres")
 	wrap := db.TestWrapper(t)
@@ -589,6 +702,201 @@ func TestList(t *testing.T) {
 	}
 }
 
+func TestList_FilterPushdown(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	wrap := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrap)
+	ctx := context.Background()
+
+	iamRepo := iam.TestRepo(t, conn, wrap)
+
+	rw := db.New(conn)
+	sessRepo, err := session.NewRepository(ctx, rw, rw, kms)
+	require.NoError(t, err)
+
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	sessRepoFn := func(opt ...session.Option) (*session.Repository, error) {
+		return session.NewRepository(ctx, rw, rw, kms, opt...)
+	}
+	tokenRepoFn := func() (*authtoken.Repository, error) {
+		return authtoken.NewRepository(rw, rw, kms)
+	}
+	serversRepoFn := func() (*server.Repository, error) {
+		return server.NewRepository(rw, rw, kms)
+	}
+	s, err := sessions.NewService(sessRepoFn, iamRepoFn)
+	require.NoError(t, err)
+
+	o, p := iam.TestScopes(t, iamRepo)
+	at := authtoken.TestAuthToken(t, conn, kms, o.GetPublicId())
+
+	hc := static.TestCatalogs(t, conn, p.GetPublicId(), 1)[0]
+	hs := static.TestSets(t, conn, hc.GetPublicId(), 1)[0]
+	h := static.TestHosts(t, conn, hc.GetPublicId(), 1)[0]
+	static.TestSetMembers(t, conn, hs.GetPublicId(), []*static.Host{h})
+	tar := tcp.TestTarget(ctx, t, conn, p.GetPublicId(), "test", target.WithHostSources([]string{hs.GetPublicId()}))
+	tarOther := tcp.TestTarget(ctx, t, conn, p.GetPublicId(), "other", target.WithHostSources([]string{hs.GetPublicId()}))
+
+	newSession := func(targetId string) *session.Session {
+		return session.TestSession(t, conn, wrap, session.ComposedOf{
+			UserId:      at.GetIamUserId(),
+			HostId:      h.GetPublicId(),
+			TargetId:    targetId,
+			HostSetId:   hs.GetPublicId(),
+			AuthTokenId: at.GetPublicId(),
+			ProjectId:   p.GetPublicId(),
+			Endpoint:    "tcp://127.0.0.1:22",
+		})
+	}
+
+	// Terminate a session first, as TerminateCompletedSessions terminates
+	// every canceling session.
+	terminated := newSession(tar.GetPublicId())
+	_, err = sessRepo.CancelSession(ctx, terminated.GetPublicId(), terminated.Version)
+	require.NoError(t, err)
+	cnt, err := sessRepo.TerminateCompletedSessions(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, cnt)
+
+	canceling := newSession(tarOther.GetPublicId())
+	_, err = sessRepo.CancelSession(ctx, canceling.GetPublicId(), canceling.Version)
+	require.NoError(t, err)
+
+	newSession(tar.GetPublicId())
+	newSession(tarOther.GetPublicId())
+	active := newSession(tarOther.GetPublicId())
+	session.TestState(t, conn, active.GetPublicId(), session.StatusActive)
+
+	requestInfo := authpb.RequestInfo{
+		TokenFormat: uint32(auth.AuthTokenTypeRecoveryKms),
+		PublicId:    at.GetPublicId(),
+		Token:       at.GetToken(),
+	}
+	requestContext := context.WithValue(context.Background(), requests.ContextRequestInformationKey, &requests.RequestContext{})
+	recoveryCtx := auth.NewVerifierContext(requestContext, iamRepoFn, tokenRepoFn, serversRepoFn, kms, &requestInfo)
+	list := func(t *testing.T, filter string) []string {
+		t.Helper()
+		got, err := s.ListSessions(recoveryCtx, &pbs.ListSessionsRequest{ScopeId: p.GetPublicId(), IncludeTerminated: true, Filter: filter})
+		require.NoError(t, err)
+		var ids []string
+		for _, item := range got.GetItems() {
+			ids = append(ids, item.GetId())
+		}
+		return ids
+	}
+
+	all, err := s.ListSessions(recoveryCtx, &pbs.ListSessionsRequest{ScopeId: p.GetPublicId(), IncludeTerminated: true})
+	require.NoError(t, err)
+	require.Len(t, all.GetItems(), 5)
+
+	permsRepo, err := sessRepoFn(session.WithPermissions(&perms.UserPermissions{
+		Permissions: []perms.Permission{
+			{
+				ScopeId:  p.GetPublicId(),
+				Resource: resource.Session,
+				Action:   action.List,
+				All:      true,
+			},
+		},
+	}))
+	require.NoError(t, err)
+
+	cases := []struct {
+		name        string
+		filter      string
+		wantCnt     int
+		pushedDown  bool
+		wantRepoCnt int
+	}{
+		{
+			name:        "status",
+			filter:      `"/item/status" == "pending"`,
+			wantCnt:     2,
+			pushedDown:  true,
+			wantRepoCnt: 2,
+		},
+		{
+			name:        "status or status",
+			filter:      `"/item/status" == "canceling" or "/item/status" == "terminated"`,
+			wantCnt:     2,
+			pushedDown:  true,
+			wantRepoCnt: 2,
+		},
+		{
+			name:        "target and status",
+			filter:      fmt.Sprintf(`"/item/target_id" == %q and "/item/status" == "active"`, tarOther.GetPublicId()),
+			wantCnt:     1,
+			pushedDown:  true,
+			wantRepoCnt: 1,
+		},
+		{
+			name:        "status and untranslated field",
+			filter:      `"/item/status" == "pending" and "/item/type" == "tcp"`,
+			wantCnt:     2,
+			pushedDown:  true,
+			wantRepoCnt: 2,
+		},
+		{
+			name:        "status and untranslated match",
+			filter:      fmt.Sprintf(`"/item/target_id" == %q and "/item/status" matches "^(pending|active)$"`, tarOther.GetPublicId()),
+			wantCnt:     2,
+			pushedDown:  true,
+			wantRepoCnt: 3,
+		},
+		{
+			name:    "status or untranslated field",
+			filter:  `"/item/status" == "active" or "/item/type" == "tcp"`,
+			wantCnt: 5,
+		},
+		{
+			name:    "negated status",
+			filter:  `"/item/status" != "pending"`,
+			wantCnt: 3,
+		},
+		{
+			name:        "no match",
+			filter:      fmt.Sprintf(`"/item/target_id" == %q and "/item/status" == "terminated"`, tarOther.GetPublicId()),
+			wantCnt:     0,
+			pushedDown:  true,
+			wantRepoCnt: 0,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			f, err := handlers.NewFilter(tc.filter)
+			require.NoError(err)
+			var want []string
+			for _, item := range all.GetItems() {
+				if f.Match(item) {
+					want = append(want, item.GetId())
+				}
+			}
+			require.Len(want, tc.wantCnt)
+			assert.ElementsMatch(want, list(t, tc.filter))
+
+			where, args := f.WhereClause(session.ListFilterColumns)
+			if !tc.pushedDown {
+				assert.Empty(where)
+				return
+			}
+			require.NotEmpty(where)
+			found, err := permsRepo.ListSessions(ctx, session.WithTerminated(true), session.WithWhereClause(where, args), session.WithLimit(-1))
+			require.NoError(err)
+			var got []string
+			for _, sess := range found {
+				got = append(got, sess.GetPublicId())
+			}
+			// The where clause selects every matching session, and only
+			// selects more when part of the filter was not translated.
+			assert.Subset(got, want)
+			assert.Len(got, tc.wantRepoCnt)
+		})
+	}
+}
+
 func convertStates(in []*session.State) (string, []*pb.SessionState) {
 	out := make([]*pb.SessionState, 0, len(in))
 	for _, s := range in {
@@ -606,6 +914,88 @@ func convertStates(in []*session.State) (string, []*pb.SessionState) {
 	return out[0].Status, out
 }
 
+func TestList_Pagination(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	wrap := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrap)
+	ctx := context.Background()
+
+	iamRepo := iam.TestRepo(t, conn, wrap)
+
+	rw := db.New(conn)
+	sessRepo, err := session.NewRepository(ctx, rw, rw, kms)
+	require.NoError(t, err)
+
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	sessRepoFn := func(opt ...session.Option) (*session.Repository, error) {
+		return session.NewRepository(ctx, rw, rw, kms, opt...)
+	}
+	tokenRepoFn := func() (*authtoken.Repository, error) {
+		return authtoken.NewRepository(rw, rw, kms)
+	}
+	serversRepoFn := func() (*server.Repository, error) {
+		return server.NewRepository(rw, rw, kms)
+	}
+	s, err := sessions.NewService(sessRepoFn, iamRepoFn)
+	require.NoError(t, err)
+
+	o, p := iam.TestScopes(t, iamRepo)
+	oOther, _ := iam.TestScopes(t, iamRepo)
+	at := authtoken.TestAuthToken(t, conn, kms, o.GetPublicId())
+	atOther := authtoken.TestAuthToken(t, conn, kms, oOther.GetPublicId())
+
+	hc := static.TestCatalogs(t, conn, p.GetPublicId(), 1)[0]
+	hs := static.TestSets(t, conn, hc.GetPublicId(), 1)[0]
+	h := static.TestHosts(t, conn, hc.GetPublicId(), 1)[0]
+	static.TestSetMembers(t, conn, hs.GetPublicId(), []*static.Host{h})
+	tar := tcp.TestTarget(ctx, t, conn, p.GetPublicId(), "test", target.WithHostSources([]string{hs.GetPublicId()}))
+
+	// Paginated listings are oldest first
+	var want []string
+	for i := 0; i < 5; i++ {
+		sess := session.TestSession(t, conn, wrap, session.ComposedOf{
+			UserId:      at.GetIamUserId(),
+			HostId:      h.GetPublicId(),
+			TargetId:    tar.GetPublicId(),
+			HostSetId:   hs.GetPublicId(),
+			AuthTokenId: at.GetPublicId(),
+			ProjectId:   p.GetPublicId(),
+			Endpoint:    "tcp://127.0.0.1:22",
+		})
+		want = append(want, sess.GetPublicId())
+	}
+
+	tokenCtx := func(at *authtoken.AuthToken) context.Context {
+		requestInfo := authpb.RequestInfo{
+			TokenFormat: uint32(auth.AuthTokenTypeBearer),
+			PublicId:    at.GetPublicId(),
+			Token:       at.GetToken(),
+		}
+		requestContext := context.WithValue(context.Background(), requests.ContextRequestInformationKey, &requests.RequestContext{})
+		return auth.NewVerifierContext(requestContext, iamRepoFn, tokenRepoFn, serversRepoFn, kms, &requestInfo)
+	}
+	pagination.TestList(t,
+		tokenCtx(at),
+		tokenCtx(atOther),
+		resource.Target,
+		want,
+		func(ctx context.Context, listToken string, pageSize uint32) (pagination.TestListResponse, []string, error) {
+			got, err := s.ListSessions(ctx, &pbs.ListSessionsRequest{ScopeId: p.GetPublicId(), ListToken: listToken, PageSize: pageSize})
+			var ids []string
+			for _, item := range got.GetItems() {
+				ids = append(ids, item.GetId())
+			}
+			return got, ids, err
+		},
+		func(t *testing.T, id string) {
+			_, err := sessRepo.DeleteSession(ctx, id)
+			require.NoError(t, err)
+		},
+	)
+}
+
 func TestCancel(t *testing.T) {
 	conn, _ := db.TestSetup(t, "postgres")
 	wrap := db.TestWrapper(t)
@@ -779,3 +1169,195 @@ func TestCancel(t *testing.T) {
 		})
 	}
 }
+
+func TestExtend(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	wrap := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrap)
+
+	iamRepo := iam.TestRepo(t, conn, wrap)
+
+	rw := db.New(conn)
+
+	ctx := context.Background()
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	sessRepoFn := func(opt ...session.Option) (*session.Repository, error) {
+		return session.NewRepository(ctx, rw, rw, kms, opt...)
+	}
+	tokenRepoFn := func() (*authtoken.Repository, error) {
+		return authtoken.NewRepository(rw, rw, kms)
+	}
+	serversRepoFn := func() (*server.Repository, error) {
+		return server.NewRepository(rw, rw, kms)
+	}
+	sessRepo, err := sessRepoFn()
+	require.NoError(t, err)
+
+	o, p := iam.TestScopes(t, iamRepo)
+	// The owner of the sessions can read and cancel them but has not been
+	// granted the extend action.
+	at := authtoken.TestAuthToken(t, conn, kms, o.GetPublicId())
+	extendToken := authtoken.TestAuthToken(t, conn, kms, o.GetPublicId())
+	extendRole := iam.TestRole(t, conn, p.GetPublicId())
+	iam.TestRoleGrant(t, conn, extendRole.GetPublicId(), "id=*;type=session;actions=extend")
+	iam.TestUserRole(t, conn, extendRole.GetPublicId(), extendToken.GetIamUserId())
+
+	hc := static.TestCatalogs(t, conn, p.GetPublicId(), 1)[0]
+	hs := static.TestSets(t, conn, hc.GetPublicId(), 1)[0]
+	h := static.TestHosts(t, conn, hc.GetPublicId(), 1)[0]
+	static.TestSetMembers(t, conn, hs.GetPublicId(), []*static.Host{h})
+	tar := tcp.TestTarget(context.Background(), t, conn, p.GetPublicId(), "test", target.WithHostSources([]string{hs.GetPublicId()}))
+
+	// newSession returns a pending session, expiring in an hour, as stored.
+	newSession := func(t *testing.T) *session.Session {
+		sess := session.TestSession(t, conn, wrap, session.ComposedOf{
+			UserId:      at.GetIamUserId(),
+			HostId:      h.GetPublicId(),
+			TargetId:    tar.GetPublicId(),
+			HostSetId:   hs.GetPublicId(),
+			AuthTokenId: at.GetPublicId(),
+			ProjectId:   p.GetPublicId(),
+			Endpoint:    "tcp://127.0.0.1:22",
+		})
+		sess, _, err := sessRepo.LookupSession(ctx, sess.GetPublicId())
+		require.NoError(t, err)
+		return sess
+	}
+
+	cases := []struct {
+		name string
+		// setup returns the session to extend
+		setup           func(t *testing.T) *session.Session
+		requester       *authtoken.AuthToken
+		version         func(*session.Session) uint32
+		durationSeconds uint32
+		err             error
+	}{
+		{
+			name:            "Extend a session",
+			setup:           newSession,
+			requester:       extendToken,
+			durationSeconds: 3600,
+		},
+		{
+			name:            "Owner without the extend grant",
+			setup:           newSession,
+			requester:       at,
+			durationSeconds: 3600,
+			err:             handlers.ApiErrorWithCode(codes.PermissionDenied),
+		},
+		{
+			name:            "Version conflict",
+			setup:           newSession,
+			requester:       extendToken,
+			version:         func(s *session.Session) uint32 { return s.Version + 1 },
+			durationSeconds: 3600,
+			err:             handlers.ApiErrorWithCode(codes.FailedPrecondition),
+		},
+		{
+			name:            "Beyond the target's session max seconds",
+			setup:           newSession,
+			requester:       extendToken,
+			durationSeconds: tar.GetSessionMaxSeconds(),
+			err:             handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name:      "Missing duration",
+			setup:     newSession,
+			requester: extendToken,
+			err:       handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Canceled session",
+			setup: func(t *testing.T) *session.Session {
+				sess := newSession(t)
+				sess, err := sessRepo.CancelSession(ctx, sess.GetPublicId(), sess.Version)
+				require.NoError(t, err)
+				return sess
+			},
+			requester:       extendToken,
+			durationSeconds: 3600,
+			err:             handlers.ApiErrorWithCode(codes.FailedPrecondition),
+		},
+		{
+			name: "Terminated session",
+			setup: func(t *testing.T) *session.Session {
+				sess := newSession(t)
+				_ = session.TestState(t, conn, sess.GetPublicId(), session.StatusTerminated)
+				sess, _, err := sessRepo.LookupSession(ctx, sess.GetPublicId())
+				require.NoError(t, err)
+				return sess
+			},
+			requester:       extendToken,
+			durationSeconds: 3600,
+			err:             handlers.ApiErrorWithCode(codes.FailedPrecondition),
+		},
+		{
+			name: "Expired session",
+			setup: func(t *testing.T) *session.Session {
+				sess := newSession(t)
+				_, err := rw.Exec(ctx, "update session set expiration_time = now() - interval '1 minute' where public_id = ?", []any{sess.GetPublicId()})
+				require.NoError(t, err)
+				sess, _, err = sessRepo.LookupSession(ctx, sess.GetPublicId())
+				require.NoError(t, err)
+				return sess
+			},
+			requester:       extendToken,
+			durationSeconds: 3600,
+			err:             handlers.ApiErrorWithCode(codes.FailedPrecondition),
+		},
+		{
+			name: "Non existing session",
+			setup: func(t *testing.T) *session.Session {
+				return &session.Session{PublicId: session.SessionPrefix + "_DoesntExis", Version: 1}
+			},
+			requester:       extendToken,
+			durationSeconds: 3600,
+			err:             handlers.ApiErrorWithCode(codes.NotFound),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+
+			s, err := sessions.NewService(sessRepoFn, iamRepoFn)
+			require.NoError(err, "Couldn't create new session service.")
+
+			sess := tc.setup(t)
+			version := sess.Version
+			if tc.version != nil {
+				version = tc.version(sess)
+			}
+			req := &pbs.ExtendSessionRequest{Id: sess.GetPublicId(), Version: version, DurationSeconds: tc.durationSeconds}
+
+			requestInfo := authpb.RequestInfo{
+				TokenFormat: uint32(auth.AuthTokenTypeBearer),
+				PublicId:    tc.requester.GetPublicId(),
+				Token:       tc.requester.GetToken(),
+			}
+			requestContext := context.WithValue(context.Background(), requests.ContextRequestInformationKey, &requests.RequestContext{})
+			ctx := auth.NewVerifierContext(requestContext, iamRepoFn, tokenRepoFn, serversRepoFn, kms, &requestInfo)
+			got, gErr := s.ExtendSession(ctx, req)
+			if tc.err != nil {
+				require.Error(gErr)
+				assert.True(errors.Is(gErr, tc.err), "ExtendSession(%+v) got error %v, wanted %v", req, gErr, tc.err)
+				assert.Nil(got)
+
+				if sess.ExpirationTime != nil {
+					found, _, err := sessRepo.LookupSession(context.Background(), sess.GetPublicId())
+					require.NoError(err)
+					assert.Equal(sess.ExpirationTime.AsTime(), found.ExpirationTime.AsTime())
+				}
+				return
+			}
+			require.NoError(gErr)
+			require.NotNil(got)
+			want := sess.ExpirationTime.AsTime().Add(time.Duration(tc.durationSeconds) * time.Second)
+			assert.Equal(want, got.GetItem().GetExpirationTime().AsTime())
+			assert.Equal(sess.Version+1, got.GetItem().GetVersion())
+			assert.Equal(session.StatusPending.String(), got.GetItem().GetStatus())
+		})
+	}
+}
@@ -0,0 +1,306 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sessions
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/boundary/globals"
+	"github.com/hashicorp/boundary/internal/daemon/controller/auth"
+	"github.com/hashicorp/boundary/internal/daemon/controller/handlers"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/observability/event"
+	"github.com/hashicorp/boundary/internal/perms"
+	"github.com/hashicorp/boundary/internal/session"
+	"github.com/hashicorp/boundary/internal/types/action"
+	"github.com/hashicorp/boundary/internal/types/resource"
+	"github.com/hashicorp/boundary/internal/types/scope"
+	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/scopes"
+	pb "github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/sessions"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// WatchPath is the path of the endpoint streaming the session state
+	// changes.
+	WatchPath = "/v1/sessions:watch"
+
+	// StateChangeEvent is the type of the server-sent events holding a state
+	// change.
+	StateChangeEvent = "state-change"
+
+	// watchKeepAliveInterval is the interval at which a comment is sent to the
+	// watching clients, so idle connections are not closed by proxies.
+	watchKeepAliveInterval = 15 * time.Second
+)
+
+// WatchHandler streams the state changes of the sessions, and of their
+// connections, as server-sent events. Each event has the type
+// StateChangeEvent, the JSON encoding of a pb.StateChange as data, and the
+// time of the state change in nanoseconds since the Unix epoch as id.
+//
+// The request has the same scope_id, recursive and filter query parameters as
+// the list request and is authorized the same way. A client resumes watching
+// by sending the id of the last event it received in the Last-Event-ID header,
+// or a time in the since query parameter, formatted as RFC 3339; the state
+// changes which happened from then on are sent first. A state change may be
+// sent again when resuming, but none is missed unless the client falls too far
+// behind, in which case the stream is closed.
+type WatchHandler struct {
+	service Service
+	broker  *session.StateChangeBroker
+}
+
+var _ http.Handler = (*WatchHandler)(nil)
+
+// NewWatchHandler returns a handler streaming the state changes the broker
+// publishes, authorized by the session service.
+func NewWatchHandler(service Service, broker *session.StateChangeBroker) (*WatchHandler, error) {
+	const op = "sessions.NewWatchHandler"
+	if service.repoFn == nil || service.iamRepoFn == nil {
+		return nil, errors.NewDeprecated(errors.InvalidParameter, op, "missing session service")
+	}
+	if broker == nil {
+		return nil, errors.NewDeprecated(errors.InvalidParameter, op, "missing state change broker")
+	}
+	return &WatchHandler{service: service, broker: broker}, nil
+}
+
+// watchRequest holds the parameters of a watch request.
+type watchRequest struct {
+	scopeId   string
+	recursive bool
+	filter    string
+	since     time.Time
+}
+
+// ServeHTTP implements http.Handler.
+func (h *WatchHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	const op = "sessions.(WatchHandler).ServeHTTP"
+	ctx := r.Context()
+	writeError := func(err error) {
+		handlers.ErrorHandler()(ctx, nil, handlers.JSONMarshaler(), w, r, err)
+	}
+
+	if r.Method != http.MethodGet {
+		writeError(handlers.ApiErrorWithCodeAndMessage(codes.Unimplemented, "Method %s is not supported.", r.Method))
+		return
+	}
+	req, err := parseWatchRequest(r)
+	if err != nil {
+		writeError(err)
+		return
+	}
+
+	authResults := h.service.authResult(ctx, req.scopeId, action.List, false)
+	if authResults.Error != nil {
+		// As when listing, keep going if the request is recursive, as they may
+		// have authorization on downstream scopes.
+		if (authResults.Error == handlers.ForbiddenError() || authResults.Error == handlers.UnauthenticatedError()) &&
+			req.recursive &&
+			authResults.AuthenticationFinished {
+		} else {
+			writeError(authResults.Error)
+			return
+		}
+	}
+
+	var scopeIds map[string]*scopes.ScopeInfo
+	if !req.recursive {
+		scopeIds = map[string]*scopes.ScopeInfo{authResults.Scope.Id: authResults.Scope}
+	} else {
+		scopeIds, err = authResults.ScopesAuthorizedForList(ctx, req.scopeId, resource.Session)
+		if err != nil {
+			writeError(err)
+			return
+		}
+	}
+	userPerms := &perms.UserPermissions{
+		UserId:      authResults.UserId,
+		Permissions: authResults.ACL().ListPermissions(scopeIds, resource.Session, IdActions, authResults.UserId, perms.WithRequestAttributes(authResults.RequestAttributes())),
+	}
+
+	filter, err := handlers.NewFilter(req.filter)
+	if err != nil {
+		writeError(err)
+		return
+	}
+	flusher, ok := handlers.FlusherFromContext(ctx)
+	if !ok {
+		writeError(errors.New(ctx, errors.Internal, op, "response streaming is not supported"))
+		return
+	}
+
+	// Subscribe before listing the missed state changes, so none happening
+	// in between is missed.
+	changes := h.broker.Subscribe(ctx, userPerms)
+	var missed []*session.StateChange
+	if !req.since.IsZero() {
+		repo, err := h.service.repoFn(session.WithPermissions(userPerms))
+		if err != nil {
+			writeError(errors.Wrap(ctx, err, op))
+			return
+		}
+		// The state changes are listed from a microsecond, the precision of
+		// the database, before the time the client saw last, as more changes
+		// may have happened at the same time.
+		missed, err = repo.ListStateChanges(ctx, req.since.Add(-time.Microsecond), session.WithLimit(-1))
+		if err != nil {
+			writeError(errors.Wrap(ctx, err, op))
+			return
+		}
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	// sent holds the missed state changes, which the broker can publish again.
+	sent := make(map[stateChangeKey]struct{}, len(missed))
+	send := func(c *session.StateChange) error {
+		item := stateChangeToProto(authResults, c)
+		if item == nil || !filter.Match(item) {
+			return nil
+		}
+		data, err := handlers.JSONMarshaler().Marshal(item)
+		if err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", c.StartTime.UnixNano(), StateChangeEvent, data); err != nil {
+			return err
+		}
+		flusher.Flush()
+		return nil
+	}
+	for _, c := range missed {
+		sent[keyOf(c)] = struct{}{}
+		if err := send(c); err != nil {
+			event.WriteError(ctx, op, err, event.WithInfoMsg("unable to send session state change"))
+			return
+		}
+	}
+
+	keepAlive := time.NewTicker(watchKeepAliveInterval)
+	defer keepAlive.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-keepAlive.C:
+			if _, err := io.WriteString(w, ":\n\n"); err != nil {
+				return
+			}
+			flusher.Flush()
+		case c, ok := <-changes:
+			if !ok {
+				// The client fell behind, or the request timed out; the
+				// client resumes watching from its last event.
+				return
+			}
+			if _, ok := sent[keyOf(c)]; ok {
+				continue
+			}
+			if err := send(c); err != nil {
+				event.WriteError(ctx, op, err, event.WithInfoMsg("unable to send session state change"))
+				return
+			}
+		}
+	}
+}
+
+// stateChangeToProto returns the state change with the fields the output
+// fields of the session allow, or nil if none are allowed.
+func stateChangeToProto(authResults auth.VerifyResults, c *session.StateChange) *pb.StateChange {
+	res := perms.Resource{
+		Id:      c.SessionId,
+		ScopeId: c.ProjectId,
+		Type:    resource.Session,
+	}
+	outputFields := authResults.FetchOutputFields(res, action.List).SelfOrDefaults(authResults.UserId)
+	if !outputFields.Has(globals.IdField) && !outputFields.Has(globals.StatusField) && !outputFields.Has(globals.ScopeIdField) &&
+		!outputFields.Has(globals.UserIdField) && !outputFields.Has(globals.TargetIdField) {
+		return nil
+	}
+	out := &pb.StateChange{}
+	if outputFields.Has(globals.IdField) {
+		out.SessionId = c.SessionId
+		out.ConnectionId = c.ConnectionId
+	}
+	if outputFields.Has(globals.ScopeIdField) {
+		out.ScopeId = c.ProjectId
+	}
+	if outputFields.Has(globals.UserIdField) {
+		out.UserId = c.UserId
+	}
+	if outputFields.Has(globals.TargetIdField) {
+		out.TargetId = c.TargetId
+	}
+	if outputFields.Has(globals.StatusField) {
+		out.Status = c.Status
+		out.Time = timestamppb.New(c.StartTime)
+	}
+	return out
+}
+
+// stateChangeKey identifies a state change.
+type stateChangeKey struct {
+	sessionId, connectionId, status string
+	time                            int64
+}
+
+func keyOf(c *session.StateChange) stateChangeKey {
+	return stateChangeKey{
+		sessionId:    c.SessionId,
+		connectionId: c.ConnectionId,
+		status:       c.Status,
+		time:         c.StartTime.UnixNano(),
+	}
+}
+
+func parseWatchRequest(r *http.Request) (*watchRequest, error) {
+	q := r.URL.Query()
+	req := &watchRequest{
+		scopeId: q.Get("scope_id"),
+		filter:  q.Get("filter"),
+	}
+	badFields := map[string]string{}
+	if v := q.Get("recursive"); v != "" {
+		var err error
+		if req.recursive, err = strconv.ParseBool(v); err != nil {
+			badFields["recursive"] = "This field must be a boolean."
+		}
+	}
+	if !handlers.ValidId(handlers.Id(req.scopeId), scope.Project.Prefix()) &&
+		!req.recursive {
+		badFields["scope_id"] = "This field must be a valid project scope ID or the watch must be recursive."
+	}
+	if _, err := handlers.NewFilter(req.filter); err != nil {
+		badFields["filter"] = fmt.Sprintf("This field could not be parsed. %v", err)
+	}
+	switch id := r.Header.Get("Last-Event-ID"); {
+	case id != "":
+		n, err := strconv.ParseInt(id, 10, 64)
+		if err != nil || n <= 0 {
+			badFields["Last-Event-ID"] = "This header must be the id of an event."
+			break
+		}
+		req.since = time.Unix(0, n)
+	case q.Get("since") != "":
+		t, err := time.Parse(time.RFC3339Nano, q.Get("since"))
+		if err != nil {
+			badFields["since"] = "This field must be a time formatted as RFC 3339."
+			break
+		}
+		req.since = t
+	}
+	if len(badFields) > 0 {
+		return nil, handlers.InvalidArgumentErrorf("Invalid watch request.", badFields)
+	}
+	return req, nil
+}
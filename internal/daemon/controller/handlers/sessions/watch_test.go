@@ -0,0 +1,96 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sessions_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/sessions"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/session"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatchHandler_InvalidRequest(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	repoFn := func(...session.Option) (*session.Repository, error) {
+		return nil, nil
+	}
+	iamRepoFn := func() (*iam.Repository, error) {
+		return nil, nil
+	}
+	s, err := sessions.NewService(repoFn, iamRepoFn)
+	require.NoError(t, err)
+	broker, err := session.NewStateChangeBroker(ctx, repoFn, 0)
+	require.NoError(t, err)
+	h, err := sessions.NewWatchHandler(s, broker)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		method     string
+		query      string
+		lastId     string
+		wantStatus int
+	}{
+		{
+			name:       "post",
+			method:     http.MethodPost,
+			query:      "scope_id=p_1234567890",
+			wantStatus: http.StatusNotImplemented,
+		},
+		{
+			name:       "missing-scope",
+			method:     http.MethodGet,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "global-not-recursive",
+			method:     http.MethodGet,
+			query:      "scope_id=global",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "bad-recursive",
+			method:     http.MethodGet,
+			query:      "scope_id=global&recursive=sometimes",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "bad-filter",
+			method:     http.MethodGet,
+			query:      "scope_id=p_1234567890&filter=%22",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "bad-since",
+			method:     http.MethodGet,
+			query:      "scope_id=p_1234567890&since=yesterday",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "bad-last-event-id",
+			method:     http.MethodGet,
+			query:      "scope_id=p_1234567890",
+			lastId:     "last",
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			r := httptest.NewRequest(tc.method, sessions.WatchPath+"?"+tc.query, nil)
+			if tc.lastId != "" {
+				r.Header.Set("Last-Event-ID", tc.lastId)
+			}
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+			assert.Equal(t, tc.wantStatus, w.Code, w.Body.String())
+		})
+	}
+}
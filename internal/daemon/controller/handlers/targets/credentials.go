@@ -174,6 +174,19 @@ func staticToWorkerCredential(ctx context.Context, cred credential.Static) (sess
 			},
 		}
 
+	case *credstatic.JsonCredential:
+		object := &structpb.Struct{}
+		if err := object.UnmarshalJSON(c.GetObject()); err != nil {
+			return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unmarshalling json credential"))
+		}
+		workerCred = &serverpb.Credential{
+			Credential: &serverpb.Credential_Json{
+				Json: &serverpb.Json{
+					Object: object,
+				},
+			},
+		}
+
 	default:
 		return nil, errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("unsupported credential %T", c))
 	}
@@ -0,0 +1,125 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package http
+
+import (
+	"strings"
+
+	"github.com/golang/protobuf/ptypes/wrappers"
+	"github.com/hashicorp/boundary/internal/daemon/controller/handlers"
+	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/targets"
+	"github.com/hashicorp/boundary/internal/target"
+	"github.com/hashicorp/boundary/internal/target/http"
+	httpStore "github.com/hashicorp/boundary/internal/target/http/store"
+	"github.com/hashicorp/boundary/internal/target/store"
+	pb "github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/targets"
+)
+
+const (
+	defaultPortField  = "attributes.default_port"
+	allowedPathsField = "attributes.allowed_paths"
+)
+
+type attribute struct {
+	*pb.HttpTargetAttributes
+}
+
+func (a *attribute) Options() []target.Option {
+	var opts []target.Option
+	if a.GetDefaultPort().GetValue() != 0 {
+		opts = append(opts, target.WithDefaultPort(a.GetDefaultPort().GetValue()))
+	}
+	if a.GetUseTls() != nil {
+		opts = append(opts, target.WithUseTls(a.GetUseTls().GetValue()))
+	}
+	if len(a.GetAllowedPaths()) > 0 {
+		opts = append(opts, target.WithAllowedPaths(a.GetAllowedPaths()))
+	}
+	return opts
+}
+
+// Vet validates the attributes of a new http target. The default port is
+// optional, it depends on use_tls when it is not provided.
+func (a *attribute) Vet() map[string]string {
+	badFields := map[string]string{}
+	if a.GetDefaultPort() != nil && a.GetDefaultPort().GetValue() == 0 {
+		badFields[defaultPortField] = "This field cannot be set to zero."
+	}
+	if msg := vetAllowedPaths(a.GetAllowedPaths()); msg != "" {
+		badFields[allowedPathsField] = msg
+	}
+	return badFields
+}
+
+func (a *attribute) VetForUpdate(p []string) map[string]string {
+	badFields := map[string]string{}
+	if handlers.MaskContains(p, defaultPortField) {
+		if a.GetDefaultPort() == nil {
+			badFields[defaultPortField] = "This field is required."
+		} else if a.GetDefaultPort().GetValue() == 0 {
+			badFields[defaultPortField] = "This cannot be set to zero."
+		}
+	}
+	if handlers.MaskContains(p, allowedPathsField) {
+		if msg := vetAllowedPaths(a.GetAllowedPaths()); msg != "" {
+			badFields[allowedPathsField] = msg
+		}
+	}
+	return badFields
+}
+
+// vetAllowedPaths returns a message describing why the allowed paths are
+// invalid, or an empty string if they are valid.
+func vetAllowedPaths(paths []string) string {
+	for _, p := range paths {
+		if !strings.HasPrefix(p, "/") || strings.ContainsAny(p, "?#\r\n") {
+			return "Each path must be a url path starting with '/'."
+		}
+	}
+	return ""
+}
+
+func newAttribute(m any) targets.Attributes {
+	a := &attribute{
+		&pb.HttpTargetAttributes{},
+	}
+	if httpAttr, ok := m.(*pb.Target_HttpTargetAttributes); ok {
+		a.HttpTargetAttributes = httpAttr.HttpTargetAttributes
+	}
+	return a
+}
+
+func setAttributes(t target.Target, out *pb.Target) error {
+	if t == nil {
+		return nil
+	}
+
+	attrs := &pb.Target_HttpTargetAttributes{
+		HttpTargetAttributes: &pb.HttpTargetAttributes{},
+	}
+	if t.GetDefaultPort() > 0 {
+		attrs.HttpTargetAttributes.DefaultPort = &wrappers.UInt32Value{Value: t.GetDefaultPort()}
+	}
+	if ht, ok := t.(*http.Target); ok {
+		attrs.HttpTargetAttributes.UseTls = &wrappers.BoolValue{Value: ht.GetUseTls()}
+		attrs.HttpTargetAttributes.AllowedPaths = ht.GetAllowedPathPrefixes()
+	}
+
+	out.Attrs = attrs
+	return nil
+}
+
+func init() {
+	var maskManager handlers.MaskManager
+	var err error
+
+	if maskManager, err = handlers.NewMaskManager(
+		handlers.MaskDestination{&httpStore.Target{}, &store.TargetAddress{}},
+		handlers.MaskSource{&pb.Target{}, &pb.HttpTargetAttributes{}},
+	); err != nil {
+		panic(err)
+	}
+
+	targets.Register(http.Subtype, maskManager, newAttribute, setAttributes)
+}
@@ -0,0 +1,132 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package postgres
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"strings"
+
+	"github.com/golang/protobuf/ptypes/wrappers"
+	"github.com/hashicorp/boundary/internal/daemon/controller/handlers"
+	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/targets"
+	"github.com/hashicorp/boundary/internal/target"
+	"github.com/hashicorp/boundary/internal/target/postgres"
+	postgresStore "github.com/hashicorp/boundary/internal/target/postgres/store"
+	"github.com/hashicorp/boundary/internal/target/store"
+	pb "github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/targets"
+)
+
+const (
+	defaultPortField = "attributes.default_port"
+	caCertField      = "attributes.ca_cert"
+)
+
+type attribute struct {
+	*pb.PostgresTargetAttributes
+}
+
+func (a *attribute) Options() []target.Option {
+	var opts []target.Option
+	if a.GetDefaultPort().GetValue() != 0 {
+		opts = append(opts, target.WithDefaultPort(a.GetDefaultPort().GetValue()))
+	}
+	if a.GetCaCert().GetValue() != "" {
+		opts = append(opts, target.WithCaCert(a.GetCaCert().GetValue()))
+	}
+	return opts
+}
+
+// Vet validates the attributes of a new postgres target. The default port is
+// optional, postgres.DefaultPort is used when it is not provided.
+func (a *attribute) Vet() map[string]string {
+	badFields := map[string]string{}
+	if a.GetDefaultPort() != nil && a.GetDefaultPort().GetValue() == 0 {
+		badFields[defaultPortField] = "This field cannot be set to zero."
+	}
+	if a.GetCaCert() != nil && !validCaCert(a.GetCaCert().GetValue()) {
+		badFields[caCertField] = "This field must contain only PEM encoded certificates."
+	}
+	return badFields
+}
+
+func (a *attribute) VetForUpdate(p []string) map[string]string {
+	badFields := map[string]string{}
+	if handlers.MaskContains(p, defaultPortField) {
+		if a.GetDefaultPort() == nil {
+			badFields[defaultPortField] = "This field is required."
+		} else if a.GetDefaultPort().GetValue() == 0 {
+			badFields[defaultPortField] = "This cannot be set to zero."
+		}
+	}
+	if handlers.MaskContains(p, caCertField) && a.GetCaCert() != nil && !validCaCert(a.GetCaCert().GetValue()) {
+		badFields[caCertField] = "This field must contain only PEM encoded certificates."
+	}
+	return badFields
+}
+
+// validCaCert reports whether caCert contains one or more PEM encoded
+// certificates and nothing else.
+func validCaCert(caCert string) bool {
+	rest := []byte(caCert)
+	var found bool
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			return false
+		}
+		if _, err := x509.ParseCertificate(block.Bytes); err != nil {
+			return false
+		}
+		found = true
+	}
+	return found && strings.TrimSpace(string(rest)) == ""
+}
+
+func newAttribute(m any) targets.Attributes {
+	a := &attribute{
+		&pb.PostgresTargetAttributes{},
+	}
+	if postgresAttr, ok := m.(*pb.Target_PostgresTargetAttributes); ok {
+		a.PostgresTargetAttributes = postgresAttr.PostgresTargetAttributes
+	}
+	return a
+}
+
+func setAttributes(t target.Target, out *pb.Target) error {
+	if t == nil {
+		return nil
+	}
+
+	attrs := &pb.Target_PostgresTargetAttributes{
+		PostgresTargetAttributes: &pb.PostgresTargetAttributes{},
+	}
+	if t.GetDefaultPort() > 0 {
+		attrs.PostgresTargetAttributes.DefaultPort = &wrappers.UInt32Value{Value: t.GetDefaultPort()}
+	}
+	if pt, ok := t.(*postgres.Target); ok && pt.GetCaCert() != "" {
+		attrs.PostgresTargetAttributes.CaCert = &wrappers.StringValue{Value: pt.GetCaCert()}
+	}
+
+	out.Attrs = attrs
+	return nil
+}
+
+func init() {
+	var maskManager handlers.MaskManager
+	var err error
+
+	if maskManager, err = handlers.NewMaskManager(
+		handlers.MaskDestination{&postgresStore.Target{}, &store.TargetAddress{}},
+		handlers.MaskSource{&pb.Target{}, &pb.PostgresTargetAttributes{}},
+	); err != nil {
+		panic(err)
+	}
+
+	targets.Register(postgres.Subtype, maskManager, newAttribute, setAttributes)
+}
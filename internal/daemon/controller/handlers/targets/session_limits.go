@@ -0,0 +1,131 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package targets
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/boundary/internal/daemon/controller/handlers"
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/observability/event"
+	"github.com/hashicorp/boundary/internal/session"
+	"github.com/hashicorp/boundary/internal/types/scope"
+	"google.golang.org/grpc/codes"
+)
+
+// sessionLimitOperation is the audit request operation of the event written
+// when a session is not authorized because the user has reached a session
+// limit.
+const sessionLimitOperation = "session-limit-exceeded"
+
+// exceededSessionLimit describes a session limit a user has reached.
+type exceededSessionLimit struct {
+	limit *iam.SessionLimit
+	// perTarget is true when the limit of sessions per target was reached,
+	// and false when the limit of sessions per user was.
+	perTarget bool
+	max       uint32
+}
+
+// sessionLimitCheck returns the check of the session limits which apply to
+// the sessions of userId on targetId in projectId, or nil if none apply. The
+// check is run by CreateSession on the counts of the user's active sessions
+// in the transaction creating the session, so that concurrent requests cannot
+// exceed a limit. When a limit has been reached an audit event is written.
+func (s Service) sessionLimitCheck(ctx context.Context, userId, projectId, targetId string) (func(context.Context, []*session.ActiveSessionCount) error, error) {
+	iamRepo, err := s.iamRepoFn()
+	if err != nil {
+		return nil, err
+	}
+	limits, err := iamRepo.ListSessionLimits(ctx, userId, projectId)
+	if err != nil {
+		return nil, err
+	}
+	if len(limits) == 0 {
+		return nil, nil
+	}
+	return func(ctx context.Context, counts []*session.ActiveSessionCount) error {
+		exceeded := findExceededSessionLimit(limits, counts, targetId)
+		if exceeded == nil {
+			return nil
+		}
+		auditSessionLimit(ctx, userId, targetId, exceeded)
+		kind := "sessions per user"
+		if exceeded.perTarget {
+			kind = "sessions per target"
+		}
+		return handlers.ApiErrorWithCodeAndMessage(codes.ResourceExhausted,
+			"The limit of %d %s set by %q has been reached.", exceeded.max, kind, exceeded.limit.ResourceId)
+	}, nil
+}
+
+// findExceededSessionLimit returns the first of the limits which the counts
+// of active sessions have reached, or nil if none has been. The limits are
+// checked in order and the limit of sessions per user of a limit is checked
+// before its limit of sessions per target.
+func findExceededSessionLimit(limits []*iam.SessionLimit, counts []*session.ActiveSessionCount, targetId string) *exceededSessionLimit {
+	for _, l := range limits {
+		var userCount, targetCount int
+		for _, c := range counts {
+			switch l.ScopeId {
+			case scope.Global.String(), c.ProjectId, c.OrgId:
+			default:
+				continue
+			}
+			userCount += c.Count
+			if c.TargetId == targetId {
+				targetCount += c.Count
+			}
+		}
+		if l.MaxSessionsPerUser > 0 && userCount >= int(l.MaxSessionsPerUser) {
+			return &exceededSessionLimit{limit: l, max: l.MaxSessionsPerUser}
+		}
+		if l.MaxSessionsPerTarget > 0 && targetCount >= int(l.MaxSessionsPerTarget) {
+			return &exceededSessionLimit{limit: l, perTarget: true, max: l.MaxSessionsPerTarget}
+		}
+	}
+	return nil
+}
+
+// auditSessionLimit writes the audit event of a session which was not
+// authorized because of the exceeded session limit.
+func auditSessionLimit(ctx context.Context, userId, targetId string, exceeded *exceededSessionLimit) {
+	const op = "targets.auditSessionLimit"
+	id, err := event.NewId(event.IdPrefix)
+	if err != nil {
+		event.WriteError(ctx, op, err, event.WithInfoMsg("unable to generate session limit event id"))
+		return
+	}
+	collection := "scopes"
+	if strings.HasPrefix(exceeded.limit.ResourceId, iam.RolePrefix+"_") {
+		collection = "roles"
+	}
+	opts := []event.Option{
+		// the exceeded limit is its own event and not part of the audit event
+		// of the authorize session request
+		event.WithId(id),
+		event.WithFlush(),
+		event.WithRequest(&event.Request{
+			Operation: sessionLimitOperation,
+			Endpoint:  fmt.Sprintf("/v1/%s/%s", collection, exceeded.limit.ResourceId),
+			Details:   &pbs.AuthorizeSessionRequest{Id: targetId},
+		}),
+		event.WithResponse(&event.Response{StatusCode: http.StatusTooManyRequests}),
+		event.WithAuth(&event.Auth{
+			UserInfo: &event.UserInfo{UserId: userId},
+		}),
+	}
+	if ri, ok := event.RequestInfoFromContext(ctx); ok {
+		info := *ri
+		info.EventId = id
+		opts = append(opts, event.WithRequestInfo(&info))
+	}
+	if err := event.WriteAudit(ctx, op, opts...); err != nil {
+		event.WriteError(ctx, op, err, event.WithInfoMsg("unable to write session limit audit event", "user_id", userId, "target_id", targetId))
+	}
+}
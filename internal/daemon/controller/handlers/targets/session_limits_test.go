@@ -0,0 +1,135 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package targets
+
+import (
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/session"
+	"github.com/hashicorp/boundary/internal/types/scope"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindExceededSessionLimit(t *testing.T) {
+	counts := []*session.ActiveSessionCount{
+		{ProjectId: "p_1", OrgId: "o_1", TargetId: "ttcp_1", Count: 2},
+		{ProjectId: "p_1", OrgId: "o_1", TargetId: "ttcp_2", Count: 1},
+		{ProjectId: "p_2", OrgId: "o_1", TargetId: "ttcp_3", Count: 3},
+		{ProjectId: "p_3", OrgId: "o_2", TargetId: "ttcp_4", Count: 1},
+	}
+
+	tests := []struct {
+		name     string
+		limits   []*iam.SessionLimit
+		targetId string
+		want     *exceededSessionLimit
+	}{
+		{
+			name:     "no-limits",
+			targetId: "ttcp_1",
+		},
+		{
+			name: "global-per-user",
+			limits: []*iam.SessionLimit{
+				{ResourceId: scope.Global.String(), ScopeId: scope.Global.String(), MaxSessionsPerUser: 7},
+			},
+			targetId: "ttcp_1",
+			want:     &exceededSessionLimit{max: 7},
+		},
+		{
+			name: "global-per-user-not-reached",
+			limits: []*iam.SessionLimit{
+				{ResourceId: scope.Global.String(), ScopeId: scope.Global.String(), MaxSessionsPerUser: 8},
+			},
+			targetId: "ttcp_1",
+		},
+		{
+			name: "org-per-user",
+			limits: []*iam.SessionLimit{
+				{ResourceId: "o_1", ScopeId: "o_1", MaxSessionsPerUser: 6},
+			},
+			targetId: "ttcp_1",
+			want:     &exceededSessionLimit{max: 6},
+		},
+		{
+			name: "org-per-user-not-reached",
+			limits: []*iam.SessionLimit{
+				{ResourceId: "o_1", ScopeId: "o_1", MaxSessionsPerUser: 7},
+			},
+			targetId: "ttcp_1",
+		},
+		{
+			name: "project-per-user",
+			limits: []*iam.SessionLimit{
+				{ResourceId: "p_1", ScopeId: "p_1", MaxSessionsPerUser: 3},
+			},
+			targetId: "ttcp_2",
+			want:     &exceededSessionLimit{max: 3},
+		},
+		{
+			name: "project-per-target",
+			limits: []*iam.SessionLimit{
+				{ResourceId: "p_1", ScopeId: "p_1", MaxSessionsPerTarget: 2},
+			},
+			targetId: "ttcp_1",
+			want:     &exceededSessionLimit{perTarget: true, max: 2},
+		},
+		{
+			name: "project-per-target-other-target",
+			limits: []*iam.SessionLimit{
+				{ResourceId: "p_1", ScopeId: "p_1", MaxSessionsPerTarget: 2},
+			},
+			targetId: "ttcp_2",
+		},
+		{
+			name: "project-per-target-new-target",
+			limits: []*iam.SessionLimit{
+				{ResourceId: "p_1", ScopeId: "p_1", MaxSessionsPerTarget: 1},
+			},
+			targetId: "ttcp_5",
+		},
+		{
+			name: "role-per-user",
+			limits: []*iam.SessionLimit{
+				{ResourceId: "r_1", ScopeId: "p_2", MaxSessionsPerUser: 3},
+			},
+			targetId: "ttcp_3",
+			want:     &exceededSessionLimit{max: 3},
+		},
+		{
+			name: "per-user-before-per-target",
+			limits: []*iam.SessionLimit{
+				{ResourceId: "p_1", ScopeId: "p_1", MaxSessionsPerUser: 3, MaxSessionsPerTarget: 2},
+			},
+			targetId: "ttcp_1",
+			want:     &exceededSessionLimit{max: 3},
+		},
+		{
+			name: "first-exceeded",
+			limits: []*iam.SessionLimit{
+				{ResourceId: "o_2", ScopeId: "o_2", MaxSessionsPerUser: 2},
+				{ResourceId: "r_1", ScopeId: "p_1", MaxSessionsPerTarget: 1},
+				{ResourceId: "p_1", ScopeId: "p_1", MaxSessionsPerUser: 1},
+			},
+			targetId: "ttcp_1",
+			want:     &exceededSessionLimit{perTarget: true, max: 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			got := findExceededSessionLimit(tt.limits, counts, tt.targetId)
+			if tt.want == nil {
+				assert.Nil(got)
+				return
+			}
+			if !assert.NotNil(got) {
+				return
+			}
+			assert.Equal(tt.want.perTarget, got.perTarget)
+			assert.Equal(tt.want.max, got.max)
+		})
+	}
+}
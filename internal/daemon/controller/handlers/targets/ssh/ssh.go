@@ -0,0 +1,122 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package ssh
+
+import (
+	"strings"
+
+	"github.com/golang/protobuf/ptypes/wrappers"
+	"github.com/hashicorp/boundary/internal/daemon/controller/handlers"
+	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/targets"
+	"github.com/hashicorp/boundary/internal/target"
+	"github.com/hashicorp/boundary/internal/target/ssh"
+	sshStore "github.com/hashicorp/boundary/internal/target/ssh/store"
+	"github.com/hashicorp/boundary/internal/target/store"
+	pb "github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/targets"
+	gossh "golang.org/x/crypto/ssh"
+)
+
+const (
+	defaultPortField = "attributes.default_port"
+	hostKeysField    = "attributes.host_keys"
+)
+
+type attribute struct {
+	*pb.SshTargetAttributes
+}
+
+func (a *attribute) Options() []target.Option {
+	var opts []target.Option
+	if a.GetDefaultPort().GetValue() != 0 {
+		opts = append(opts, target.WithDefaultPort(a.GetDefaultPort().GetValue()))
+	}
+	if len(a.GetHostKeys()) > 0 {
+		opts = append(opts, target.WithHostKeys(a.GetHostKeys()))
+	}
+	return opts
+}
+
+// Vet validates the attributes of a new ssh target. The default port is
+// optional, ssh.DefaultPort is used when it is not provided.
+func (a *attribute) Vet() map[string]string {
+	badFields := map[string]string{}
+	if a.GetDefaultPort() != nil && a.GetDefaultPort().GetValue() == 0 {
+		badFields[defaultPortField] = "This field cannot be set to zero."
+	}
+	if msg := vetHostKeys(a.GetHostKeys()); msg != "" {
+		badFields[hostKeysField] = msg
+	}
+	return badFields
+}
+
+func (a *attribute) VetForUpdate(p []string) map[string]string {
+	badFields := map[string]string{}
+	if handlers.MaskContains(p, defaultPortField) {
+		if a.GetDefaultPort() == nil {
+			badFields[defaultPortField] = "This field is required."
+		} else if a.GetDefaultPort().GetValue() == 0 {
+			badFields[defaultPortField] = "This cannot be set to zero."
+		}
+	}
+	if handlers.MaskContains(p, hostKeysField) {
+		if msg := vetHostKeys(a.GetHostKeys()); msg != "" {
+			badFields[hostKeysField] = msg
+		}
+	}
+	return badFields
+}
+
+// vetHostKeys returns a message describing why the host keys are invalid, or
+// an empty string if they are valid.
+func vetHostKeys(keys []string) string {
+	for _, k := range keys {
+		if _, _, _, _, err := gossh.ParseAuthorizedKey([]byte(k)); err != nil || strings.ContainsAny(k, "\r\n") {
+			return "Each host key must be a single public key in the authorized_keys format."
+		}
+	}
+	return ""
+}
+
+func newAttribute(m any) targets.Attributes {
+	a := &attribute{
+		&pb.SshTargetAttributes{},
+	}
+	if sshAttr, ok := m.(*pb.Target_SshTargetAttributes); ok {
+		a.SshTargetAttributes = sshAttr.SshTargetAttributes
+	}
+	return a
+}
+
+func setAttributes(t target.Target, out *pb.Target) error {
+	if t == nil {
+		return nil
+	}
+
+	attrs := &pb.Target_SshTargetAttributes{
+		SshTargetAttributes: &pb.SshTargetAttributes{},
+	}
+	if t.GetDefaultPort() > 0 {
+		attrs.SshTargetAttributes.DefaultPort = &wrappers.UInt32Value{Value: t.GetDefaultPort()}
+	}
+	if st, ok := t.(*ssh.Target); ok {
+		attrs.SshTargetAttributes.HostKeys = st.GetHostKeyList()
+	}
+
+	out.Attrs = attrs
+	return nil
+}
+
+func init() {
+	var maskManager handlers.MaskManager
+	var err error
+
+	if maskManager, err = handlers.NewMaskManager(
+		handlers.MaskDestination{&sshStore.Target{}, &store.TargetAddress{}},
+		handlers.MaskSource{&pb.Target{}, &pb.SshTargetAttributes{}},
+	); err != nil {
+		panic(err)
+	}
+
+	targets.Register(ssh.Subtype, maskManager, newAttribute, setAttributes)
+}
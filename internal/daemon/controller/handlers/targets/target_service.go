@@ -22,6 +22,7 @@ import (
 	"github.com/hashicorp/boundary/internal/daemon/controller/auth"
 	"github.com/hashicorp/boundary/internal/daemon/controller/common"
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers"
+	"github.com/hashicorp/boundary/internal/db"
 	"github.com/hashicorp/boundary/internal/db/timestamp"
 	"github.com/hashicorp/boundary/internal/errors"
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
@@ -29,6 +30,7 @@ import (
 	"github.com/hashicorp/boundary/internal/host/plugin"
 	"github.com/hashicorp/boundary/internal/host/static"
 	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/pagination"
 	"github.com/hashicorp/boundary/internal/perms"
 	"github.com/hashicorp/boundary/internal/requests"
 	"github.com/hashicorp/boundary/internal/server"
@@ -44,6 +46,7 @@ import (
 	"github.com/hashicorp/go-bexpr"
 	"github.com/hashicorp/go-secure-stdlib/strutil"
 	"github.com/mr-tron/base58"
+	"golang.org/x/exp/maps"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/timestamppb"
@@ -211,17 +214,23 @@ func (s Service) ListTargets(ctx context.Context, req *pbs.ListTargetsRequest) (
 	}
 
 	// Get all user permissions for the requested scope(s).
-	userPerms := authResults.ACL().ListPermissions(authzScopes, resource.Target, IdActions, authResults.UserId)
+	userPerms := authResults.ACL().ListPermissions(authzScopes, resource.Target, IdActions, authResults.UserId, perms.WithRequestAttributes(authResults.RequestAttributes()))
 	if len(userPerms) == 0 {
 		return &pbs.ListTargetsResponse{}, nil
 	}
 
-	tl, err := s.listFromRepo(ctx, userPerms)
+	repo, err := s.repoFn(target.WithPermissions(userPerms))
 	if err != nil {
 		return nil, err
 	}
-	if len(tl) == 0 {
-		return &pbs.ListTargetsResponse{}, nil
+
+	// Targets in scopes where grants have resource filters must be checked
+	// individually
+	resourceFiltered := make(map[string]bool, len(userPerms))
+	for _, p := range userPerms {
+		if p.ResourceFiltered {
+			resourceFiltered[p.ScopeId] = true
+		}
 	}
 
 	filter, err := handlers.NewFilter(req.GetFilter())
@@ -229,9 +238,17 @@ func (s Service) ListTargets(ctx context.Context, req *pbs.ListTargetsRequest) (
 		return nil, err
 	}
 
-	finalItems := make([]*pb.Target, 0, len(tl))
-	for _, item := range tl {
-		pr := perms.Resource{Id: item.GetPublicId(), ScopeId: item.GetProjectId(), Type: resource.Target}
+	where, whereArgs := filter.WhereClause(target.ListFilterColumns)
+	listItemsFn := func(ctx context.Context, page *db.Page, limit int) ([]target.Target, error) {
+		return repo.ListTargets(ctx, target.WithWhereClause(where, whereArgs), target.WithPage(page), target.WithLimit(limit))
+	}
+	convertItemFn := func(ctx context.Context, item target.Target) (*pb.Target, bool, error) {
+		pr := perms.Resource{Id: item.GetPublicId(), ScopeId: item.GetProjectId(), Type: resource.Target, Data: ResourceData(item)}
+		if resourceFiltered[item.GetProjectId()] {
+			if len(authResults.FetchActionSetForId(ctx, item.GetPublicId(), IdActions, auth.WithResource(&pr))) == 0 {
+				return nil, false, nil
+			}
+		}
 		outputFields := authResults.FetchOutputFields(pr, action.List).SelfOrDefaults(authResults.UserId)
 
 		outputOpts := make([]handlers.Option, 0, 3)
@@ -245,21 +262,46 @@ func (s Service) ListTargets(ctx context.Context, req *pbs.ListTargetsRequest) (
 			outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authorizedActions))
 		}
 
-		item, err := toProto(ctx, item, nil, nil, outputOpts...)
+		pbItem, err := toProto(ctx, item, nil, nil, outputOpts...)
 		if err != nil {
-			return nil, err
+			return nil, false, err
 		}
 
-		filterable, err := subtypes.Filterable(item)
+		filterable, err := subtypes.Filterable(pbItem)
 		if err != nil {
-			return nil, err
+			return nil, false, err
 		}
-		if filter.Match(filterable) {
-			finalItems = append(finalItems, item)
+		return pbItem, filter.Match(filterable), nil
+	}
+
+	listDeletedIdsFn := func(ctx context.Context, since time.Time) ([]string, error) {
+		deleted, err := repo.ListDeletedIds(ctx, maps.Keys(authzScopes), since)
+		if err != nil {
+			return nil, err
 		}
+		return authResults.AuthorizedDeletedIds(ctx, perms.Resource{Type: resource.Target}, IdActions, deleted), nil
+	}
+
+	listReq := pagination.Request{
+		ResourceType: resource.Target,
+		GrantsHash:   authResults.GrantsHash(),
+		PageSize:     req.GetPageSize(),
+		ListToken:    req.GetListToken(),
+	}
+	page, err := pagination.List(ctx, listReq, listItemsFn, convertItemFn, listDeletedIdsFn)
+	if err != nil {
+		return nil, err
 	}
 
-	return &pbs.ListTargetsResponse{Items: finalItems}, nil
+	resp := &pbs.ListTargetsResponse{Items: page.Items}
+	if listReq.Paginated() {
+		resp.ResponseType = page.ResponseType()
+		resp.ListToken = page.ListToken
+		resp.SortBy = page.SortBy
+		resp.SortDir = pagination.SortDirAscending
+		resp.RemovedIds = page.RemovedIds
+	}
+	return resp, nil
 }
 
 // GetTarget implements the interface pbs.TargetServiceServer.
@@ -722,6 +764,11 @@ func (s Service) AuthorizeSession(ctx context.Context, req *pbs.AuthorizeSession
 		return nil, handlers.NotFoundErrorf("Target %q not found.", t.GetPublicId())
 	}
 
+	limitCheck, err := s.sessionLimitCheck(ctx, authResults.UserId, authResults.Scope.Id, t.GetPublicId())
+	if err != nil {
+		return nil, err
+	}
+
 	// Instantiate some repos
 	sessionRepo, err := s.sessionRepoFn()
 	if err != nil {
@@ -842,6 +889,16 @@ func (s Service) AuthorizeSession(ctx context.Context, req *pbs.AuthorizeSession
 		return nil, err
 	}
 
+	// Only workers with a proxy handler for this target's type can proxy its
+	// connections
+	selectedWorkers = wl.WorkerList(selectedWorkers).SupportsProxyProtocol(t.GetType().String())
+
+	// Recorded sessions can only be proxied by workers which are able to
+	// store the recording
+	if t.GetEnableSessionRecording() {
+		selectedWorkers = wl.WorkerList(selectedWorkers).SupportsSessionRecording()
+	}
+
 	selectedWorkers, err = AuthorizeSessionWorkerFilterFn(ctx, t, selectedWorkers, h, s.downstreams)
 	if err != nil {
 		return nil, err
@@ -873,20 +930,22 @@ func (s Service) AuthorizeSession(ctx context.Context, req *pbs.AuthorizeSession
 	expTime := timestamppb.Now()
 	expTime.Seconds += int64(t.GetSessionMaxSeconds())
 	sessionComposition := session.ComposedOf{
-		UserId:              authResults.UserId,
-		HostId:              hostId,
-		TargetId:            t.GetPublicId(),
-		HostSetId:           hostSetId,
-		AuthTokenId:         authResults.AuthTokenId,
-		ProjectId:           authResults.Scope.Id,
-		Endpoint:            endpointUrl.String(),
-		ExpirationTime:      &timestamp.Timestamp{Timestamp: expTime},
-		ConnectionLimit:     t.GetSessionConnectionLimit(),
-		WorkerFilter:        t.GetWorkerFilter(),
-		EgressWorkerFilter:  t.GetEgressWorkerFilter(),
-		IngressWorkerFilter: t.GetIngressWorkerFilter(),
-		DynamicCredentials:  dynCreds,
-		StaticCredentials:   staticCreds,
+		UserId:                 authResults.UserId,
+		HostId:                 hostId,
+		TargetId:               t.GetPublicId(),
+		HostSetId:              hostSetId,
+		AuthTokenId:            authResults.AuthTokenId,
+		ProjectId:              authResults.Scope.Id,
+		Endpoint:               endpointUrl.String(),
+		ExpirationTime:         &timestamp.Timestamp{Timestamp: expTime},
+		ConnectionLimit:        t.GetSessionConnectionLimit(),
+		WorkerFilter:           t.GetWorkerFilter(),
+		EgressWorkerFilter:     t.GetEgressWorkerFilter(),
+		IngressWorkerFilter:    t.GetIngressWorkerFilter(),
+		DynamicCredentials:     dynCreds,
+		StaticCredentials:      staticCreds,
+		EnableSessionRecording: t.GetEnableSessionRecording(),
+		IdleTimeout:            t.GetSessionIdleTimeout(),
 	}
 	sess, err := session.New(sessionComposition)
 	if err != nil {
@@ -896,7 +955,11 @@ func (s Service) AuthorizeSession(ctx context.Context, req *pbs.AuthorizeSession
 	if err != nil {
 		return nil, err
 	}
-	sess, err = sessionRepo.CreateSession(ctx, wrapper, sess, wl.WorkerList(selectedWorkers).Addresses())
+	var createOpts []session.Option
+	if limitCheck != nil {
+		createOpts = append(createOpts, session.WithActiveSessionCountCheck(limitCheck))
+	}
+	sess, err = sessionRepo.CreateSession(ctx, wrapper, sess, wl.WorkerList(selectedWorkers).Addresses(), createOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -1066,6 +1129,12 @@ func (s Service) createInRepo(ctx context.Context, item *pb.Target) (target.Targ
 	if item.GetAddress() != nil {
 		opts = append(opts, target.WithAddress(strings.TrimSpace(item.GetAddress().GetValue())))
 	}
+	if item.GetEnableSessionRecording() != nil {
+		opts = append(opts, target.WithEnableSessionRecording(item.GetEnableSessionRecording().GetValue()))
+	}
+	if item.GetSessionIdleTimeout() != nil {
+		opts = append(opts, target.WithSessionIdleTimeout(item.GetSessionIdleTimeout().GetValue()))
+	}
 
 	attr, err := subtypeRegistry.newAttribute(target.SubtypeFromType(item.GetType()), item.GetAttrs())
 	if err != nil {
@@ -1117,6 +1186,12 @@ func (s Service) updateInRepo(ctx context.Context, scopeId, id string, mask []st
 	if ingressFilter := item.GetIngressWorkerFilter(); ingressFilter != nil {
 		opts = append(opts, target.WithIngressWorkerFilter(item.GetIngressWorkerFilter().GetValue()))
 	}
+	if item.GetEnableSessionRecording() != nil {
+		opts = append(opts, target.WithEnableSessionRecording(item.GetEnableSessionRecording().GetValue()))
+	}
+	if item.GetSessionIdleTimeout() != nil {
+		opts = append(opts, target.WithSessionIdleTimeout(item.GetSessionIdleTimeout().GetValue()))
+	}
 	if item.GetAddress() != nil {
 		dbMask = append(dbMask, "Address")
 		opts = append(opts, target.WithAddress(strings.TrimSpace(item.GetAddress().GetValue())))
@@ -1178,18 +1253,6 @@ func (s Service) deleteFromRepo(ctx context.Context, id string) (bool, error) {
 	return rows > 0, nil
 }
 
-func (s Service) listFromRepo(ctx context.Context, perms []perms.Permission) ([]target.Target, error) {
-	repo, err := s.repoFn(target.WithPermissions(perms))
-	if err != nil {
-		return nil, err
-	}
-	ul, err := repo.ListTargets(ctx)
-	if err != nil {
-		return nil, err
-	}
-	return ul, nil
-}
-
 func (s Service) addHostSourcesInRepo(ctx context.Context, targetId string, hostSourceIds []string, version uint32) (target.Target, []target.HostSource, []target.CredentialSource, error) {
 	repo, err := s.repoFn()
 	if err != nil {
@@ -1391,7 +1454,7 @@ func (s Service) authResult(ctx context.Context, id string, a action.Type, looku
 		}
 		id = t.GetPublicId()
 		parentId = t.GetProjectId()
-		opts = append(opts, auth.WithId(id))
+		opts = append(opts, auth.WithId(id), auth.WithResourceData(ResourceData(t)))
 	}
 	opts = append(opts, auth.WithScopeId(parentId))
 	ret := auth.Verify(ctx, opts...)
@@ -1447,6 +1510,12 @@ func toProto(ctx context.Context, in target.Target, hostSources []target.HostSou
 	if outputFields.Has(globals.IngressWorkerFilterField) && in.GetIngressWorkerFilter() != "" {
 		out.IngressWorkerFilter = wrapperspb.String(in.GetIngressWorkerFilter())
 	}
+	if outputFields.Has(globals.EnableSessionRecordingField) && in.GetEnableSessionRecording() {
+		out.EnableSessionRecording = wrapperspb.Bool(true)
+	}
+	if outputFields.Has(globals.SessionIdleTimeoutField) && in.GetSessionIdleTimeout() != 0 {
+		out.SessionIdleTimeout = wrapperspb.UInt32(in.GetSessionIdleTimeout())
+	}
 	if outputFields.Has(globals.ScopeField) {
 		out.Scope = opts.WithScope
 	}
@@ -1960,3 +2029,18 @@ func validateAuthorizeSessionRequest(req *pbs.AuthorizeSessionRequest) error {
 	}
 	return nil
 }
+
+// ResourceData returns the attributes of the target that the resource filters
+// of grants are evaluated against.
+func ResourceData(t target.Target) map[string]any {
+	data := map[string]any{
+		"name":        t.GetName(),
+		"description": t.GetDescription(),
+		"type":        t.GetType().String(),
+		"address":     t.GetAddress(),
+	}
+	if port := t.GetDefaultPort(); port != 0 {
+		data["attributes"] = map[string]any{"default_port": port}
+	}
+	return data
+}
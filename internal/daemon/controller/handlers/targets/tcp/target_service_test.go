@@ -18,6 +18,8 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/hashicorp/boundary/globals"
+	"github.com/hashicorp/boundary/internal/auth/jwt"
+	"github.com/hashicorp/boundary/internal/auth/ldap"
 	"github.com/hashicorp/boundary/internal/auth/oidc"
 	"github.com/hashicorp/boundary/internal/auth/password"
 	"github.com/hashicorp/boundary/internal/authtoken"
@@ -36,6 +38,8 @@ import (
 	"github.com/hashicorp/boundary/internal/host/static"
 	"github.com/hashicorp/boundary/internal/iam"
 	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/pagination"
+	"github.com/hashicorp/boundary/internal/perms"
 	"github.com/hashicorp/boundary/internal/plugin/host"
 	"github.com/hashicorp/boundary/internal/requests"
 	"github.com/hashicorp/boundary/internal/scheduler"
@@ -43,6 +47,8 @@ import (
 	"github.com/hashicorp/boundary/internal/session"
 	"github.com/hashicorp/boundary/internal/target"
 	"github.com/hashicorp/boundary/internal/target/tcp"
+	"github.com/hashicorp/boundary/internal/types/action"
+	"github.com/hashicorp/boundary/internal/types/resource"
 	"github.com/hashicorp/boundary/internal/types/scope"
 	credlibpb "github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/credentiallibraries"
 	credpb "github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/credentials"
@@ -407,6 +413,248 @@ func TestList(t *testing.T) {
 	}
 }
 
+func TestList_Pagination(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+
+	rw := db.New(conn)
+
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	tokenRepoFn := func() (*authtoken.Repository, error) {
+		return authtoken.NewRepository(rw, rw, kms)
+	}
+	serversRepoFn := func() (*server.Repository, error) {
+		return server.NewRepository(rw, rw, kms)
+	}
+	repo, err := target.NewRepository(context.Background(), rw, rw, kms)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	org, proj := iam.TestScopes(t, iamRepo)
+	at := authtoken.TestAuthToken(t, conn, kms, org.GetPublicId())
+	r := iam.TestRole(t, conn, proj.GetPublicId())
+	_ = iam.TestUserRole(t, conn, r.GetPublicId(), at.GetIamUserId())
+	_ = iam.TestRoleGrant(t, conn, r.GetPublicId(), "id=*;type=*;actions=*")
+	otherAt := authtoken.TestAuthToken(t, conn, kms, org.GetPublicId())
+	_ = iam.TestUserRole(t, conn, r.GetPublicId(), otherAt.GetIamUserId())
+
+	// Paginated listings are oldest first
+	var want []string
+	for i := 0; i < 5; i++ {
+		tar := tcp.TestTarget(ctx, t, conn, proj.GetPublicId(), fmt.Sprintf("tar%d", i))
+		want = append(want, tar.GetPublicId())
+	}
+
+	s, err := testService(t, ctx, conn, kms, wrapper)
+	require.NoError(t, err)
+	tokenCtx := func(at *authtoken.AuthToken) context.Context {
+		requestInfo := authpb.RequestInfo{
+			TokenFormat: uint32(auth.AuthTokenTypeBearer),
+			PublicId:    at.GetPublicId(),
+			Token:       at.GetToken(),
+		}
+		requestContext := context.WithValue(context.Background(), requests.ContextRequestInformationKey, &requests.RequestContext{})
+		return auth.NewVerifierContext(requestContext, iamRepoFn, tokenRepoFn, serversRepoFn, kms, &requestInfo)
+	}
+	pagination.TestList(t,
+		tokenCtx(at),
+		tokenCtx(otherAt),
+		resource.Host,
+		want,
+		func(ctx context.Context, listToken string, pageSize uint32) (pagination.TestListResponse, []string, error) {
+			got, err := s.ListTargets(ctx, &pbs.ListTargetsRequest{ScopeId: proj.GetPublicId(), ListToken: listToken, PageSize: pageSize})
+			var ids []string
+			for _, item := range got.GetItems() {
+				ids = append(ids, item.GetId())
+			}
+			return got, ids, err
+		},
+		func(t *testing.T, id string) {
+			_, err := repo.DeleteTarget(ctx, id)
+			require.NoError(t, err)
+		},
+	)
+}
+
+func TestList_FilterPushdown(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+
+	rw := db.New(conn)
+
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	tokenRepoFn := func() (*authtoken.Repository, error) {
+		return authtoken.NewRepository(rw, rw, kms)
+	}
+	serversRepoFn := func() (*server.Repository, error) {
+		return server.NewRepository(rw, rw, kms)
+	}
+
+	ctx := context.Background()
+	org, proj := iam.TestScopes(t, iamRepo)
+	_, otherProj := iam.TestScopes(t, iamRepo)
+	at := authtoken.TestAuthToken(t, conn, kms, org.GetPublicId())
+	for _, p := range []string{proj.GetPublicId(), otherProj.GetPublicId()} {
+		r := iam.TestRole(t, conn, p)
+		_ = iam.TestUserRole(t, conn, r.GetPublicId(), at.GetIamUserId())
+		_ = iam.TestRoleGrant(t, conn, r.GetPublicId(), "id=*;type=*;actions=*")
+	}
+
+	tcp.TestTarget(ctx, t, conn, proj.GetPublicId(), "web-0", target.WithDescription("prod"))
+	tcp.TestTarget(ctx, t, conn, proj.GetPublicId(), "web-1", target.WithDescription("dev"))
+	tcp.TestTarget(ctx, t, conn, proj.GetPublicId(), "db-0", target.WithDescription("prod"))
+	tcp.TestTarget(ctx, t, conn, otherProj.GetPublicId(), "web-0", target.WithDescription("prod"))
+	tcp.TestTarget(ctx, t, conn, otherProj.GetPublicId(), "db-0", target.WithDescription("dev"))
+
+	s, err := testService(t, ctx, conn, kms, wrapper)
+	require.NoError(t, err)
+	requestInfo := authpb.RequestInfo{
+		TokenFormat: uint32(auth.AuthTokenTypeBearer),
+		PublicId:    at.GetPublicId(),
+		Token:       at.GetToken(),
+	}
+	requestContext := context.WithValue(context.Background(), requests.ContextRequestInformationKey, &requests.RequestContext{})
+	tokenCtx := auth.NewVerifierContext(requestContext, iamRepoFn, tokenRepoFn, serversRepoFn, kms, &requestInfo)
+	list := func(t *testing.T, filter string) []string {
+		t.Helper()
+		got, err := s.ListTargets(tokenCtx, &pbs.ListTargetsRequest{ScopeId: scope.Global.String(), Recursive: true, Filter: filter})
+		require.NoError(t, err)
+		var ids []string
+		for _, item := range got.GetItems() {
+			ids = append(ids, item.GetId())
+		}
+		return ids
+	}
+
+	all, err := s.ListTargets(tokenCtx, &pbs.ListTargetsRequest{ScopeId: scope.Global.String(), Recursive: true})
+	require.NoError(t, err)
+	require.Len(t, all.GetItems(), 5)
+
+	repo, err := target.NewRepository(ctx, rw, rw, kms, target.WithPermissions([]perms.Permission{
+		{
+			ScopeId:  proj.GetPublicId(),
+			Resource: resource.Target,
+			Action:   action.List,
+			All:      true,
+		},
+		{
+			ScopeId:  otherProj.GetPublicId(),
+			Resource: resource.Target,
+			Action:   action.List,
+			All:      true,
+		},
+	}))
+	require.NoError(t, err)
+
+	cases := []struct {
+		name        string
+		filter      string
+		wantCnt     int
+		pushedDown  bool
+		wantRepoCnt int
+	}{
+		{
+			name:        "name",
+			filter:      `"/item/name" == "web-0"`,
+			wantCnt:     2,
+			pushedDown:  true,
+			wantRepoCnt: 2,
+		},
+		{
+			name:        "name and scope",
+			filter:      fmt.Sprintf(`"/item/name" == "web-0" and "/item/scope_id" == %q`, otherProj.GetPublicId()),
+			wantCnt:     1,
+			pushedDown:  true,
+			wantRepoCnt: 1,
+		},
+		{
+			name:        "type",
+			filter:      fmt.Sprintf(`"/item/type" == %q`, tcp.Subtype.String()),
+			wantCnt:     5,
+			pushedDown:  true,
+			wantRepoCnt: 5,
+		},
+		{
+			name:        "other type",
+			filter:      `"/item/type" == "ssh"`,
+			wantCnt:     0,
+			pushedDown:  true,
+			wantRepoCnt: 0,
+		},
+		{
+			name:        "description or name",
+			filter:      `"/item/description" == "dev" or "/item/name" == "db-0"`,
+			wantCnt:     3,
+			pushedDown:  true,
+			wantRepoCnt: 3,
+		},
+		{
+			name:        "description and untranslated match",
+			filter:      `"/item/description" == "prod" and "/item/name" matches "^web-"`,
+			wantCnt:     2,
+			pushedDown:  true,
+			wantRepoCnt: 3,
+		},
+		{
+			name:        "description and untranslated field",
+			filter:      fmt.Sprintf(`"/item/description" == "prod" and "/item/scope/id" == %q`, proj.GetPublicId()),
+			wantCnt:     2,
+			pushedDown:  true,
+			wantRepoCnt: 3,
+		},
+		{
+			name:    "name or untranslated match",
+			filter:  `"/item/name" == "db-0" or "/item/name" matches "^web-1"`,
+			wantCnt: 3,
+		},
+		{
+			name:    "negated description",
+			filter:  `"/item/description" != "prod"`,
+			wantCnt: 2,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			f, err := handlers.NewFilter(tc.filter)
+			require.NoError(err)
+			var want []string
+			for _, item := range all.GetItems() {
+				if f.Match(item) {
+					want = append(want, item.GetId())
+				}
+			}
+			require.Len(want, tc.wantCnt)
+			assert.ElementsMatch(want, list(t, tc.filter))
+
+			where, args := f.WhereClause(target.ListFilterColumns)
+			if !tc.pushedDown {
+				assert.Empty(where)
+				return
+			}
+			require.NotEmpty(where)
+			found, err := repo.ListTargets(ctx, target.WithWhereClause(where, args), target.WithLimit(-1))
+			require.NoError(err)
+			var got []string
+			for _, tar := range found {
+				got = append(got, tar.GetPublicId())
+			}
+			// The where clause selects every matching target, and only
+			// selects more when part of the filter was not translated.
+			assert.Subset(got, want)
+			assert.Len(got, tc.wantRepoCnt)
+		})
+	}
+}
+
 func TestDelete(t *testing.T) {
 	t.Parallel()
 	conn, _ := db.TestSetup(t, "postgres")
@@ -2430,6 +2678,12 @@ func TestAuthorizeSession(t *testing.T) {
 	oidcAuthRepoFn := func() (*oidc.Repository, error) {
 		return oidc.NewRepository(ctx, rw, rw, kms)
 	}
+	ldapAuthRepoFn := func() (*ldap.Repository, error) {
+		return ldap.NewRepository(ctx, rw, rw, kms)
+	}
+	jwtAuthRepoFn := func() (*jwt.Repository, error) {
+		return jwt.NewRepository(ctx, rw, rw, kms)
+	}
 
 	plg := host.TestPlugin(t, conn, "test")
 	plgm := map[string]plgpb.HostPluginServiceClient{
@@ -2477,6 +2731,8 @@ func TestAuthorizeSession(t *testing.T) {
 		serversRepoFn,
 		passwordAuthRepoFn,
 		oidcAuthRepoFn,
+		ldapAuthRepoFn,
+		jwtAuthRepoFn,
 		kms,
 		&authpb.RequestInfo{
 			Token:       at.GetToken(),
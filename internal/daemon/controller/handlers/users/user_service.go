@@ -6,6 +6,7 @@ package users
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/hashicorp/boundary/globals"
 	"github.com/hashicorp/boundary/internal/auth/oidc"
@@ -13,11 +14,13 @@ import (
 	"github.com/hashicorp/boundary/internal/daemon/controller/common"
 	"github.com/hashicorp/boundary/internal/daemon/controller/common/scopeids"
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers"
+	"github.com/hashicorp/boundary/internal/db"
 	"github.com/hashicorp/boundary/internal/errors"
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
 	"github.com/hashicorp/boundary/internal/iam"
 	"github.com/hashicorp/boundary/internal/iam/store"
 	"github.com/hashicorp/boundary/internal/intglobals"
+	"github.com/hashicorp/boundary/internal/pagination"
 	"github.com/hashicorp/boundary/internal/perms"
 	"github.com/hashicorp/boundary/internal/requests"
 	"github.com/hashicorp/boundary/internal/types/action"
@@ -42,6 +45,7 @@ var (
 		action.AddAccounts,
 		action.SetAccounts,
 		action.RemoveAccounts,
+		action.RevokeTokens,
 	}
 
 	// CollectionActions contains the set of actions that can be performed on
@@ -63,18 +67,22 @@ func init() {
 type Service struct {
 	pbs.UnsafeUserServiceServer
 
-	repoFn common.IamRepoFactory
+	repoFn   common.IamRepoFactory
+	atRepoFn common.AuthTokenRepoFactory
 }
 
 var _ pbs.UserServiceServer = (*Service)(nil)
 
 // NewService returns a user service which handles user related requests to boundary.
-func NewService(repo common.IamRepoFactory) (Service, error) {
+func NewService(repo common.IamRepoFactory, atRepoFn common.AuthTokenRepoFactory) (Service, error) {
 	const op = "users.NewService"
 	if repo == nil {
 		return Service{}, errors.NewDeprecated(errors.InvalidParameter, op, "missing iam repository")
 	}
-	return Service{repoFn: repo}, nil
+	if atRepoFn == nil {
+		return Service{}, errors.NewDeprecated(errors.InvalidParameter, op, "missing auth token repository")
+	}
+	return Service{repoFn: repo, atRepoFn: atRepoFn}, nil
 }
 
 // ListUsers implements the interface pbs.UserServiceServer.
@@ -106,28 +114,28 @@ func (s Service) ListUsers(ctx context.Context, req *pbs.ListUsersRequest) (*pbs
 		return &pbs.ListUsersResponse{}, nil
 	}
 
-	ul, err := s.listFromRepo(ctx, scopeIds)
+	repo, err := s.repoFn()
 	if err != nil {
 		return nil, err
 	}
-	if len(ul) == 0 {
-		return &pbs.ListUsersResponse{}, nil
-	}
 
 	filter, err := handlers.NewFilter(req.GetFilter())
 	if err != nil {
 		return nil, err
 	}
-	finalItems := make([]*pb.User, 0, len(ul))
-	res := perms.Resource{
-		Type: resource.User,
+
+	listItemsFn := func(ctx context.Context, page *db.Page, limit int) ([]*iam.User, error) {
+		return repo.ListUsers(ctx, scopeIds, iam.WithPage(page), iam.WithLimit(limit))
 	}
-	for _, item := range ul {
-		res.Id = item.GetPublicId()
-		res.ScopeId = item.GetScopeId()
+	convertItemFn := func(ctx context.Context, item *iam.User) (*pb.User, bool, error) {
+		res := perms.Resource{
+			Id:      item.GetPublicId(),
+			ScopeId: item.GetScopeId(),
+			Type:    resource.User,
+		}
 		authorizedActions := authResults.FetchActionSetForId(ctx, item.GetPublicId(), IdActions, auth.WithResource(&res)).Strings()
 		if len(authorizedActions) == 0 {
-			continue
+			return nil, false, nil
 		}
 
 		outputFields := authResults.FetchOutputFields(res, action.List).SelfOrDefaults(*authResults.UserData.User.Id)
@@ -140,16 +148,40 @@ func (s Service) ListUsers(ctx context.Context, req *pbs.ListUsersRequest) (*pbs
 			outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authorizedActions))
 		}
 
-		item, err := toProto(ctx, item, nil, outputOpts...)
+		pbItem, err := toProto(ctx, item, nil, outputOpts...)
 		if err != nil {
-			return nil, err
+			return nil, false, err
 		}
+		return pbItem, filter.Match(pbItem), nil
+	}
 
-		if filter.Match(item) {
-			finalItems = append(finalItems, item)
+	listDeletedIdsFn := func(ctx context.Context, since time.Time) ([]string, error) {
+		deleted, err := repo.ListDeletedUserIds(ctx, scopeIds, since)
+		if err != nil {
+			return nil, err
 		}
+		return authResults.AuthorizedDeletedIds(ctx, perms.Resource{Type: resource.User}, IdActions, deleted), nil
+	}
+	listReq := pagination.Request{
+		ResourceType: resource.User,
+		GrantsHash:   authResults.GrantsHash(),
+		PageSize:     req.GetPageSize(),
+		ListToken:    req.GetListToken(),
+	}
+	page, err := pagination.List(ctx, listReq, listItemsFn, convertItemFn, listDeletedIdsFn)
+	if err != nil {
+		return nil, err
 	}
-	return &pbs.ListUsersResponse{Items: finalItems}, nil
+
+	resp := &pbs.ListUsersResponse{Items: page.Items}
+	if listReq.Paginated() {
+		resp.ResponseType = page.ResponseType()
+		resp.ListToken = page.ListToken
+		resp.SortBy = page.SortBy
+		resp.SortDir = pagination.SortDirAscending
+		resp.RemovedIds = page.RemovedIds
+	}
+	return resp, nil
 }
 
 // GetUsers implements the interface pbs.UserServiceServer.
@@ -395,6 +427,44 @@ func (s Service) RemoveUserAccounts(ctx context.Context, req *pbs.RemoveUserAcco
 	return &pbs.RemoveUserAccountsResponse{Item: item}, nil
 }
 
+// RevokeUserTokens implements the interface pbs.UserServiceServer.
+func (s Service) RevokeUserTokens(ctx context.Context, req *pbs.RevokeUserTokensRequest) (*pbs.RevokeUserTokensResponse, error) {
+	const op = "users.(Service).RevokeUserTokens"
+
+	if err := validateRevokeUserTokensRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.RevokeTokens)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	u, accts, err := s.revokeTokensInRepo(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	outputFields, ok := requests.OutputFields(ctx)
+	if !ok {
+		return nil, errors.New(ctx, errors.Internal, op, "no request context found")
+	}
+
+	outputOpts := make([]handlers.Option, 0, 3)
+	outputOpts = append(outputOpts, handlers.WithOutputFields(outputFields))
+	if outputFields.Has(globals.ScopeField) {
+		outputOpts = append(outputOpts, handlers.WithScope(authResults.Scope))
+	}
+	if outputFields.Has(globals.AuthorizedActionsField) {
+		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, u.GetPublicId(), IdActions).Strings()))
+	}
+
+	item, err := toProto(ctx, u, accts, outputOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pbs.RevokeUserTokensResponse{Item: item}, nil
+}
+
 func (s Service) getFromRepo(ctx context.Context, id string) (*iam.User, []string, error) {
 	repo, err := s.repoFn()
 	if err != nil {
@@ -489,18 +559,6 @@ func (s Service) deleteFromRepo(ctx context.Context, id string) (bool, error) {
 	return rows > 0, nil
 }
 
-func (s Service) listFromRepo(ctx context.Context, scopeIds []string) ([]*iam.User, error) {
-	repo, err := s.repoFn()
-	if err != nil {
-		return nil, err
-	}
-	ul, err := repo.ListUsers(ctx, scopeIds)
-	if err != nil {
-		return nil, err
-	}
-	return ul, nil
-}
-
 func (s Service) addInRepo(ctx context.Context, userId string, accountIds []string, version uint32) (*iam.User, []string, error) {
 	const op = "users.(Service).addInRepo"
 	repo, err := s.repoFn()
@@ -561,6 +619,18 @@ func (s Service) removeInRepo(ctx context.Context, userId string, accountIds []s
 	return out, accts, nil
 }
 
+func (s Service) revokeTokensInRepo(ctx context.Context, userId string) (*iam.User, []string, error) {
+	const op = "users.(Service).revokeTokensInRepo"
+	atRepo, err := s.atRepoFn()
+	if err != nil {
+		return nil, nil, err
+	}
+	if _, err := atRepo.DeleteAuthTokensForUser(ctx, userId); err != nil {
+		return nil, nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to revoke auth tokens of user"))
+	}
+	return s.getFromRepo(ctx, userId)
+}
+
 func (s Service) authResult(ctx context.Context, id string, a action.Type) auth.VerifyResults {
 	res := auth.VerifyResults{}
 	repo, err := s.repoFn()
@@ -785,3 +855,14 @@ func validateRemoveUserAccountsRequest(req *pbs.RemoveUserAccountsRequest) error
 	}
 	return nil
 }
+
+func validateRevokeUserTokensRequest(req *pbs.RevokeUserTokensRequest) error {
+	badFields := map[string]string{}
+	if !handlers.ValidId(handlers.Id(req.GetId()), iam.UserPrefix) {
+		badFields["id"] = "Incorrectly formatted identifier."
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Errors in provided fields.", badFields)
+	}
+	return nil
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/hashicorp/boundary/globals"
 	"github.com/hashicorp/boundary/internal/auth/oidc"
 	"github.com/hashicorp/boundary/internal/auth/password"
+	"github.com/hashicorp/boundary/internal/authtoken"
 	"github.com/hashicorp/boundary/internal/daemon/controller/auth"
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers"
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/users"
@@ -22,6 +23,8 @@ import (
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
 	"github.com/hashicorp/boundary/internal/iam"
 	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/pagination"
+	"github.com/hashicorp/boundary/internal/types/resource"
 	"github.com/hashicorp/boundary/internal/types/scope"
 	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/scopes"
 	pb "github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/users"
@@ -36,9 +39,9 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
-var testAuthorizedActions = []string{"no-op", "read", "update", "delete", "add-accounts", "set-accounts", "remove-accounts"}
+var testAuthorizedActions = []string{"no-op", "read", "update", "delete", "add-accounts", "set-accounts", "remove-accounts", "revoke-tokens"}
 
-func createDefaultUserAndRepo(t *testing.T, withAccts bool) (*iam.User, []string, func() (*iam.Repository, error)) {
+func createDefaultUserAndRepo(t *testing.T, withAccts bool) (*iam.User, []string, func() (*iam.Repository, error), func() (*authtoken.Repository, error)) {
 	t.Helper()
 	conn, _ := db.TestSetup(t, "postgres")
 	wrap := db.TestWrapper(t)
@@ -46,16 +49,20 @@ func createDefaultUserAndRepo(t *testing.T, withAccts bool) (*iam.User, []string
 	repoFn := func() (*iam.Repository, error) {
 		return repo, nil
 	}
+	rw := db.New(conn)
+	kmsCache := kms.TestKms(t, conn, wrap)
+	atRepoFn := func() (*authtoken.Repository, error) {
+		return authtoken.NewRepository(rw, rw, kmsCache)
+	}
 	o, _ := iam.TestScopes(t, repo)
 	u := iam.TestUser(t, repo, o.GetPublicId(), iam.WithDescription("default"), iam.WithName("default"))
 
 	switch withAccts {
 	case false:
-		return u, nil, repoFn
+		return u, nil, repoFn, atRepoFn
 	default:
 		require := require.New(t)
 		ctx := context.Background()
-		kmsCache := kms.TestKms(t, conn, wrap)
 		databaseWrap, err := kmsCache.GetWrapper(ctx, o.PublicId, kms.KeyPurposeDatabase)
 		require.NoError(err)
 		primaryAm := oidc.TestAuthMethod(t, conn, databaseWrap, o.PublicId, oidc.ActivePublicState, "alice-rp", "fido",
@@ -78,12 +85,12 @@ func createDefaultUserAndRepo(t *testing.T, withAccts bool) (*iam.User, []string
 		// reload the user with their accounts
 		u, accts, err := repo.LookupUser(ctx, u.PublicId)
 		require.NoError(err)
-		return u, accts, repoFn
+		return u, accts, repoFn, atRepoFn
 	}
 }
 
 func TestGet(t *testing.T) {
-	u, uAccts, repoFn := createDefaultUserAndRepo(t, true)
+	u, uAccts, repoFn, atRepoFn := createDefaultUserAndRepo(t, true)
 
 	toMerge := &pbs.GetUserRequest{
 		Id: u.GetPublicId(),
@@ -143,7 +150,7 @@ func TestGet(t *testing.T) {
 			req := proto.Clone(toMerge).(*pbs.GetUserRequest)
 			proto.Merge(req, tc.req)
 
-			s, err := users.NewService(repoFn)
+			s, err := users.NewService(repoFn, atRepoFn)
 			require.NoError(err, "Couldn't create new user service.")
 
 			got, gErr := s.GetUser(auth.DisabledAuthTestContext(repoFn, u.GetScopeId()), req)
@@ -170,6 +177,10 @@ func TestList(t *testing.T) {
 	oWithUsers, _ := iam.TestScopes(t, repo)
 
 	kmsCache := kms.TestKms(t, conn, wrap)
+	rw := db.New(conn)
+	atRepoFn := func() (*authtoken.Repository, error) {
+		return authtoken.NewRepository(rw, rw, kmsCache)
+	}
 	databaseWrap, err := kmsCache.GetWrapper(context.Background(), oWithUsers.PublicId, kms.KeyPurposeDatabase)
 	require.NoError(t, err)
 	primaryAm := oidc.TestAuthMethod(t, conn, databaseWrap, oWithUsers.PublicId, oidc.ActivePublicState, "alice-rp", "fido",
@@ -182,7 +193,7 @@ func TestList(t *testing.T) {
 	secondaryAm := password.TestAuthMethods(t, conn, oWithUsers.PublicId, 1)
 	require.Len(t, secondaryAm, 1)
 
-	s, err := users.NewService(repoFn)
+	s, err := users.NewService(repoFn, atRepoFn)
 	require.NoError(t, err)
 
 	var wantUsers []*pb.User
@@ -328,10 +339,53 @@ func (s sortableUsers) Len() int           { return len(s.users) }
 func (s sortableUsers) Less(i, j int) bool { return s.users[i].GetId() < s.users[j].GetId() }
 func (s sortableUsers) Swap(i, j int)      { s.users[i], s.users[j] = s.users[j], s.users[i] }
 
+func TestList_Pagination(t *testing.T) {
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrap := db.TestWrapper(t)
+	iamRepo := iam.TestRepo(t, conn, wrap)
+	repoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	kmsCache := kms.TestKms(t, conn, wrap)
+	rw := db.New(conn)
+	atRepoFn := func() (*authtoken.Repository, error) {
+		return authtoken.NewRepository(rw, rw, kmsCache)
+	}
+	s, err := users.NewService(repoFn, atRepoFn)
+	require.NoError(t, err)
+
+	o, _ := iam.TestScopes(t, iamRepo)
+	// Paginated listings are oldest first
+	var want []string
+	for i := 0; i < 5; i++ {
+		u := iam.TestUser(t, iamRepo, o.GetPublicId())
+		want = append(want, u.GetPublicId())
+	}
+	pagination.TestList(t,
+		auth.DisabledAuthTestContext(repoFn, o.GetPublicId()),
+		auth.DisabledAuthTestContext(repoFn, o.GetPublicId(), auth.WithUserId(globals.AnonymousUserId)),
+		resource.Account,
+		want,
+		func(ctx context.Context, listToken string, pageSize uint32) (pagination.TestListResponse, []string, error) {
+			got, err := s.ListUsers(ctx, &pbs.ListUsersRequest{ScopeId: o.GetPublicId(), ListToken: listToken, PageSize: pageSize})
+			var ids []string
+			for _, item := range got.GetItems() {
+				ids = append(ids, item.GetId())
+			}
+			return got, ids, err
+		},
+		func(t *testing.T, id string) {
+			_, err := iamRepo.DeleteUser(ctx, id)
+			require.NoError(t, err)
+		},
+	)
+}
+
 func TestDelete(t *testing.T) {
-	u, _, repoFn := createDefaultUserAndRepo(t, false)
+	u, _, repoFn, atRepoFn := createDefaultUserAndRepo(t, false)
 
-	s, err := users.NewService(repoFn)
+	s, err := users.NewService(repoFn, atRepoFn)
 	require.NoError(t, err, "Error when getting new user service.")
 
 	cases := []struct {
@@ -376,9 +430,9 @@ func TestDelete(t *testing.T) {
 
 func TestDelete_twice(t *testing.T) {
 	assert, require := assert.New(t), require.New(t)
-	u, _, repoFn := createDefaultUserAndRepo(t, false)
+	u, _, repoFn, atRepoFn := createDefaultUserAndRepo(t, false)
 
-	s, err := users.NewService(repoFn)
+	s, err := users.NewService(repoFn, atRepoFn)
 	require.NoError(err, "Error when getting new user service")
 	req := &pbs.DeleteUserRequest{
 		Id: u.GetPublicId(),
@@ -392,7 +446,7 @@ func TestDelete_twice(t *testing.T) {
 }
 
 func TestCreate(t *testing.T) {
-	defaultUser, _, repoFn := createDefaultUserAndRepo(t, false)
+	defaultUser, _, repoFn, atRepoFn := createDefaultUserAndRepo(t, false)
 	defaultCreated := defaultUser.GetCreateTime().GetTimestamp().AsTime()
 
 	cases := []struct {
@@ -470,7 +524,7 @@ func TestCreate(t *testing.T) {
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
 			assert, require := assert.New(t), require.New(t)
-			s, err := users.NewService(repoFn)
+			s, err := users.NewService(repoFn, atRepoFn)
 			require.NoError(err, "Error when getting new user service.")
 
 			got, gErr := s.CreateUser(auth.DisabledAuthTestContext(repoFn, tc.req.GetItem().GetScopeId()), tc.req)
@@ -498,8 +552,8 @@ func TestCreate(t *testing.T) {
 }
 
 func TestUpdate(t *testing.T) {
-	u, _, repoFn := createDefaultUserAndRepo(t, false)
-	tested, err := users.NewService(repoFn)
+	u, _, repoFn, atRepoFn := createDefaultUserAndRepo(t, false)
+	tested, err := users.NewService(repoFn, atRepoFn)
 	require.NoError(t, err, "Error when getting new user service.")
 
 	created := u.GetCreateTime().GetTimestamp().AsTime()
@@ -775,7 +829,11 @@ func TestAddAccount(t *testing.T) {
 	repoFn := func() (*iam.Repository, error) {
 		return iamRepo, nil
 	}
-	s, err := users.NewService(repoFn)
+	rw := db.New(conn)
+	atRepoFn := func() (*authtoken.Repository, error) {
+		return authtoken.NewRepository(rw, rw, kmsCache)
+	}
+	s, err := users.NewService(repoFn, atRepoFn)
 	require.NoError(t, err, "Error when getting new user service.")
 
 	o, _ := iam.TestScopes(t, iamRepo)
@@ -920,7 +978,11 @@ func TestSetAccount(t *testing.T) {
 	repoFn := func() (*iam.Repository, error) {
 		return iamRepo, nil
 	}
-	s, err := users.NewService(repoFn)
+	rw := db.New(conn)
+	atRepoFn := func() (*authtoken.Repository, error) {
+		return authtoken.NewRepository(rw, rw, kmsCache)
+	}
+	s, err := users.NewService(repoFn, atRepoFn)
 	require.NoError(t, err, "Error when getting new user service.")
 
 	o, _ := iam.TestScopes(t, iamRepo)
@@ -1067,7 +1129,11 @@ func TestRemoveAccount(t *testing.T) {
 	repoFn := func() (*iam.Repository, error) {
 		return iamRepo, nil
 	}
-	s, err := users.NewService(repoFn)
+	rw := db.New(conn)
+	atRepoFn := func() (*authtoken.Repository, error) {
+		return authtoken.NewRepository(rw, rw, kmsCache)
+	}
+	s, err := users.NewService(repoFn, atRepoFn)
 	require.NoError(t, err, "Error when getting new user service.")
 
 	o, _ := iam.TestScopes(t, iamRepo)
@@ -1220,3 +1286,62 @@ func TestRemoveAccount(t *testing.T) {
 		})
 	}
 }
+
+func TestRevokeTokens(t *testing.T) {
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrap := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, wrap)
+	iamRepo := iam.TestRepo(t, conn, wrap)
+	repoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	rw := db.New(conn)
+	atRepoFn := func() (*authtoken.Repository, error) {
+		return authtoken.NewRepository(rw, rw, kmsCache)
+	}
+	s, err := users.NewService(repoFn, atRepoFn)
+	require.NoError(t, err, "Error when getting new user service.")
+
+	o, _ := iam.TestScopes(t, iamRepo)
+	at := authtoken.TestAuthToken(t, conn, kmsCache, o.GetPublicId())
+	other := authtoken.TestAuthToken(t, conn, kmsCache, o.GetPublicId())
+
+	got, err := s.RevokeUserTokens(auth.DisabledAuthTestContext(repoFn, o.GetPublicId()), &pbs.RevokeUserTokensRequest{Id: at.GetIamUserId()})
+	require.NoError(t, err)
+	assert.Equal(t, at.GetIamUserId(), got.GetItem().GetId())
+
+	atRepo, err := atRepoFn()
+	require.NoError(t, err)
+	revoked, err := atRepo.LookupAuthToken(ctx, at.GetPublicId())
+	require.NoError(t, err)
+	assert.Nil(t, revoked)
+	kept, err := atRepo.LookupAuthToken(ctx, other.GetPublicId())
+	require.NoError(t, err)
+	assert.NotNil(t, kept)
+
+	failCases := []struct {
+		name string
+		req  *pbs.RevokeUserTokensRequest
+		err  error
+	}{
+		{
+			name: "Bad User Id",
+			req:  &pbs.RevokeUserTokensRequest{Id: "bad id"},
+			err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Not Found",
+			req:  &pbs.RevokeUserTokensRequest{Id: iam.UserPrefix + "_DoesntExis"},
+			err:  handlers.ApiErrorWithCode(codes.NotFound),
+		},
+	}
+	for _, tc := range failCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			_, gErr := s.RevokeUserTokens(auth.DisabledAuthTestContext(repoFn, o.GetPublicId()), tc.req)
+			require.Error(gErr)
+			assert.True(errors.Is(gErr, tc.err), "RevokeUserTokens(%+v) got error %v, wanted %v", tc.req, gErr, tc.err)
+		})
+	}
+}
@@ -9,14 +9,17 @@ import (
 	"encoding/hex"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/boundary/globals"
 	"github.com/hashicorp/boundary/internal/daemon/controller/auth"
 	"github.com/hashicorp/boundary/internal/daemon/controller/common"
 	"github.com/hashicorp/boundary/internal/daemon/controller/common/scopeids"
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers"
+	"github.com/hashicorp/boundary/internal/db"
 	"github.com/hashicorp/boundary/internal/errors"
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
+	"github.com/hashicorp/boundary/internal/pagination"
 	"github.com/hashicorp/boundary/internal/perms"
 	"github.com/hashicorp/boundary/internal/requests"
 	"github.com/hashicorp/boundary/internal/server"
@@ -138,28 +141,28 @@ func (s Service) ListWorkers(ctx context.Context, req *pbs.ListWorkersRequest) (
 		return &pbs.ListWorkersResponse{}, nil
 	}
 
-	ul, err := s.listFromRepo(ctx, scopeIds)
+	filter, err := handlers.NewFilter(req.GetFilter())
 	if err != nil {
 		return nil, err
 	}
-	if len(ul) == 0 {
-		return &pbs.ListWorkersResponse{}, nil
-	}
 
-	filter, err := handlers.NewFilter(req.GetFilter())
+	repo, err := s.repoFn()
 	if err != nil {
 		return nil, err
 	}
-	finalItems := make([]*pb.Worker, 0, len(ul))
-	res := perms.Resource{
-		Type: resource.Worker,
+	listItemsFn := func(ctx context.Context, page *db.Page, limit int) ([]*server.Worker, error) {
+		return repo.ListWorkers(ctx, scopeIds, server.WithLiveness(-1), server.WithPage(page), server.WithLimit(limit))
 	}
-	for _, item := range ul {
-		res.Id = item.GetPublicId()
-		res.ScopeId = item.GetScopeId()
+	convertItemFn := func(ctx context.Context, item *server.Worker) (*pb.Worker, bool, error) {
+		res := perms.Resource{
+			Id:      item.GetPublicId(),
+			ScopeId: item.GetScopeId(),
+			Type:    resource.Worker,
+			Data:    ResourceData(item),
+		}
 		authorizedActions := authResults.FetchActionSetForId(ctx, item.GetPublicId(), IdActions, auth.WithResource(&res)).Strings()
 		if len(authorizedActions) == 0 {
-			continue
+			return nil, false, nil
 		}
 
 		outputFields := authResults.FetchOutputFields(res, action.List).SelfOrDefaults(authResults.UserId)
@@ -172,16 +175,40 @@ func (s Service) ListWorkers(ctx context.Context, req *pbs.ListWorkersRequest) (
 			outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authorizedActions))
 		}
 
-		item, err := s.toProto(ctx, item, outputOpts...)
+		pbItem, err := s.toProto(ctx, item, outputOpts...)
 		if err != nil {
-			return nil, err
+			return nil, false, err
 		}
+		return pbItem, filter.Match(pbItem), nil
+	}
 
-		if filter.Match(item) {
-			finalItems = append(finalItems, item)
+	listDeletedIdsFn := func(ctx context.Context, since time.Time) ([]string, error) {
+		deleted, err := repo.ListDeletedWorkerIds(ctx, scopeIds, since)
+		if err != nil {
+			return nil, err
 		}
+		return authResults.AuthorizedDeletedIds(ctx, perms.Resource{Type: resource.Worker}, IdActions, deleted), nil
+	}
+	listReq := pagination.Request{
+		ResourceType: resource.Worker,
+		GrantsHash:   authResults.GrantsHash(),
+		PageSize:     req.GetPageSize(),
+		ListToken:    req.GetListToken(),
+	}
+	page, err := pagination.List(ctx, listReq, listItemsFn, convertItemFn, listDeletedIdsFn)
+	if err != nil {
+		return nil, err
 	}
-	return &pbs.ListWorkersResponse{Items: finalItems}, nil
+
+	resp := &pbs.ListWorkersResponse{Items: page.Items}
+	if listReq.Paginated() {
+		resp.ResponseType = page.ResponseType()
+		resp.ListToken = page.ListToken
+		resp.SortBy = page.SortBy
+		resp.SortDir = pagination.SortDirAscending
+		resp.RemovedIds = page.RemovedIds
+	}
+	return resp, nil
 }
 
 // GetWorker implements the interface pbs.WorkerServiceServer.
@@ -517,18 +544,6 @@ func (s Service) ReinitializeCertificateAuthority(ctx context.Context, req *pbs.
 	return &pbs.ReinitializeCertificateAuthorityResponse{Item: ca}, nil
 }
 
-func (s Service) listFromRepo(ctx context.Context, scopeIds []string) ([]*server.Worker, error) {
-	repo, err := s.repoFn()
-	if err != nil {
-		return nil, err
-	}
-	wl, err := repo.ListWorkers(ctx, scopeIds, server.WithLiveness(-1))
-	if err != nil {
-		return nil, err
-	}
-	return wl, nil
-}
-
 func (s Service) getFromRepo(ctx context.Context, id string) (*server.Worker, error) {
 	repo, err := s.repoFn()
 	if err != nil {
@@ -715,7 +730,7 @@ func (s Service) authResult(ctx context.Context, id string, a action.Type) auth.
 			return res
 		}
 		parentId = w.GetScopeId()
-		opts = append(opts, auth.WithId(id))
+		opts = append(opts, auth.WithId(id), auth.WithResourceData(ResourceData(w)))
 	}
 	opts = append(opts, auth.WithScopeId(parentId))
 	return auth.Verify(ctx, opts...)
@@ -1129,3 +1144,15 @@ func validateReinitCaRequest(req *pbs.ReinitializeCertificateAuthorityRequest) e
 
 	return nil
 }
+
+// ResourceData returns the attributes of the worker that the resource filters
+// of grants are evaluated against.
+func ResourceData(w *server.Worker) map[string]any {
+	return map[string]any{
+		"name":        w.GetName(),
+		"description": w.GetDescription(),
+		"type":        w.GetType(),
+		"address":     w.GetAddress(),
+		"tags":        w.CanonicalTags(),
+	}
+}
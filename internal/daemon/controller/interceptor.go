@@ -53,6 +53,8 @@ func requestCtxInterceptor(
 	serversRepoFn common.ServersRepoFactory,
 	passwordAuthRepoFn common.PasswordAuthRepoFactory,
 	oidcAuthRepoFn common.OidcAuthRepoFactory,
+	ldapAuthRepoFn common.LdapAuthRepoFactory,
+	jwtAuthRepoFn common.JwtAuthRepoFactory,
 	kms *kms.Kms,
 	ticket string,
 	eventer *event.Eventer,
@@ -95,52 +97,11 @@ func requestCtxInterceptor(
 			return nil, errors.New(interceptorCtx, errors.Internal, op, fmt.Sprintf("expected 1 value for %s metadata and got %d", requestInfoMdKey, len(values)))
 		}
 
-		decoded, err := base58.FastBase58Decoding(values[0])
+		requestCtx, err := newRequestContext(interceptorCtx, values[0], iamRepoFn, authTokenRepoFn, serversRepoFn, passwordAuthRepoFn, oidcAuthRepoFn, ldapAuthRepoFn, jwtAuthRepoFn, kms, ticket, eventer)
 		if err != nil {
-			return nil, errors.Wrap(interceptorCtx, err, op, errors.WithCode(errors.Internal), errors.WithMsg("unable to decode request info"))
-		}
-		var requestInfo authpb.RequestInfo
-		if err := proto.Unmarshal(decoded, &requestInfo); err != nil {
-			return nil, errors.Wrap(interceptorCtx, err, op, errors.WithCode(errors.Internal), errors.WithMsg("unable to unmarshal request info"))
-		}
-		switch {
-		case requestInfo.Ticket == "":
-			return nil, errors.New(interceptorCtx, errors.Internal, op, "Invalid context (missing ticket)")
-		case requestInfo.Ticket != ticket:
-			return nil, errors.New(interceptorCtx, errors.Internal, op, "Invalid context (bad ticket)")
-		}
-
-		interceptorCtx = auth.NewVerifierContextWithAccounts(interceptorCtx, iamRepoFn, authTokenRepoFn, serversRepoFn, passwordAuthRepoFn, oidcAuthRepoFn, kms, &requestInfo)
-
-		// Add general request information to the context. The information from
-		// the auth verifier context is pretty specifically curated to
-		// authentication/authorization verification so this is more
-		// general-purpose.
-		//
-		// We could use requests.NewRequestContext but this saves an immediate
-		// lookup.
-		interceptorCtx = context.WithValue(interceptorCtx, requests.ContextRequestInformationKey, &requests.RequestContext{
-			Path:   requestInfo.Path,
-			Method: requestInfo.Method,
-		})
-
-		// This event request info is required by downstream handlers
-		info := &event.RequestInfo{
-			EventId:  requestInfo.EventId,
-			Id:       requestInfo.TraceId,
-			PublicId: requestInfo.PublicId,
-			Method:   requestInfo.Method,
-			Path:     requestInfo.Path,
-			ClientIp: requestInfo.ClientIp,
-		}
-		interceptorCtx, err = event.NewRequestInfoContext(interceptorCtx, info)
-		if err != nil {
-			return nil, errors.Wrap(interceptorCtx, err, op, errors.WithCode(errors.Internal), errors.WithMsg("unable to create context with request info"))
-		}
-		interceptorCtx, err = event.NewEventerContext(interceptorCtx, eventer)
-		if err != nil {
-			return nil, errors.Wrap(interceptorCtx, err, op, errors.WithCode(errors.Internal), errors.WithMsg("unable to create context with eventer"))
+			return nil, errors.Wrap(interceptorCtx, err, op)
 		}
+		interceptorCtx = requestCtx
 
 		// Calls the handler
 		h, err := handler(interceptorCtx, req)
@@ -149,6 +110,75 @@ func requestCtxInterceptor(
 	}, nil
 }
 
+// newRequestContext returns ctx with the auth verifier and the request
+// information decoded from encodedRequestInfo, the value of the
+// requestInfoMdKey metadata set by wrapHandlerWithCommonFuncs. The ticket in
+// the request information must match the given ticket.
+func newRequestContext(
+	ctx context.Context,
+	encodedRequestInfo string,
+	iamRepoFn common.IamRepoFactory,
+	authTokenRepoFn common.AuthTokenRepoFactory,
+	serversRepoFn common.ServersRepoFactory,
+	passwordAuthRepoFn common.PasswordAuthRepoFactory,
+	oidcAuthRepoFn common.OidcAuthRepoFactory,
+	ldapAuthRepoFn common.LdapAuthRepoFactory,
+	jwtAuthRepoFn common.JwtAuthRepoFactory,
+	kms *kms.Kms,
+	ticket string,
+	eventer *event.Eventer,
+) (context.Context, error) {
+	const op = "controller.newRequestContext"
+	decoded, err := base58.FastBase58Decoding(encodedRequestInfo)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithCode(errors.Internal), errors.WithMsg("unable to decode request info"))
+	}
+	var requestInfo authpb.RequestInfo
+	if err := proto.Unmarshal(decoded, &requestInfo); err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithCode(errors.Internal), errors.WithMsg("unable to unmarshal request info"))
+	}
+	switch {
+	case requestInfo.Ticket == "":
+		return nil, errors.New(ctx, errors.Internal, op, "Invalid context (missing ticket)")
+	case requestInfo.Ticket != ticket:
+		return nil, errors.New(ctx, errors.Internal, op, "Invalid context (bad ticket)")
+	}
+
+	ctx = auth.NewVerifierContextWithAccounts(ctx, iamRepoFn, authTokenRepoFn, serversRepoFn, passwordAuthRepoFn, oidcAuthRepoFn, ldapAuthRepoFn, jwtAuthRepoFn, kms, &requestInfo)
+
+	// Add general request information to the context. The information from
+	// the auth verifier context is pretty specifically curated to
+	// authentication/authorization verification so this is more
+	// general-purpose.
+	//
+	// We could use requests.NewRequestContext but this saves an immediate
+	// lookup.
+	ctx = context.WithValue(ctx, requests.ContextRequestInformationKey, &requests.RequestContext{
+		Path:   requestInfo.Path,
+		Method: requestInfo.Method,
+	})
+
+	// This event request info is required by downstream handlers
+	info := &event.RequestInfo{
+		EventId:  requestInfo.EventId,
+		Id:       requestInfo.TraceId,
+		PublicId: requestInfo.PublicId,
+		Method:   requestInfo.Method,
+		Path:     requestInfo.Path,
+		ClientIp: requestInfo.ClientIp,
+	}
+	ctx, err = event.NewRequestInfoContext(ctx, info)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithCode(errors.Internal), errors.WithMsg("unable to create context with request info"))
+	}
+	ctx, err = event.NewEventerContext(ctx, eventer)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithCode(errors.Internal), errors.WithMsg("unable to create context with eventer"))
+	}
+
+	return ctx, nil
+}
+
 func errorInterceptor(
 	_ context.Context,
 ) grpc.UnaryServerInterceptor {
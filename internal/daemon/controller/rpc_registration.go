@@ -37,7 +37,7 @@ func registerControllerServerCoordinationService(ctx context.Context, c *Control
 	}
 
 	workerService := handlers.NewWorkerServiceServer(c.ServersRepoFn, c.WorkerAuthRepoStorageFn,
-		c.SessionRepoFn, c.ConnectionRepoFn, c.downstreamWorkers, c.workerStatusUpdateTimes, c.kms, c.livenessTimeToStale)
+		c.SessionRepoFn, c.ConnectionRepoFn, c.RecordingRepoFn, c.TargetRepoFn, c.downstreamWorkers, c.workerStatusUpdateTimes, c.kms, c.livenessTimeToStale)
 	pbs.RegisterServerCoordinationServiceServer(server, workerService)
 	return nil
 }
@@ -55,7 +55,7 @@ func registerControllerSessionService(ctx context.Context, c *Controller, server
 	}
 
 	workerService := handlers.NewWorkerServiceServer(c.ServersRepoFn, c.WorkerAuthRepoStorageFn,
-		c.SessionRepoFn, c.ConnectionRepoFn, c.downstreamWorkers, c.workerStatusUpdateTimes, c.kms, c.livenessTimeToStale)
+		c.SessionRepoFn, c.ConnectionRepoFn, c.RecordingRepoFn, c.TargetRepoFn, c.downstreamWorkers, c.workerStatusUpdateTimes, c.kms, c.livenessTimeToStale)
 	pbs.RegisterSessionServiceServer(server, workerService)
 	return nil
 }
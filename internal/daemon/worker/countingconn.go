@@ -4,10 +4,16 @@
 package worker
 
 import (
+	"context"
 	"net"
 	"sync"
+	"time"
 )
 
+// maxIdleCheckInterval is the longest time between two checks of whether a
+// countingConn is idle.
+const maxIdleCheckInterval = time.Second
+
 // countingConn is a `net.Conn` implementation that records the bytes that go
 // across Read() and Write(). All other `net.Conn` function calls are a
 // pass-through to the underlying `net.Conn`, meaning it's also safe to call
@@ -55,3 +61,36 @@ func (c *countingConn) Write(in []byte) (int, error) {
 	c.mu.Unlock()
 	return n, err
 }
+
+// waitIdle waits until no bytes have been read or written across the
+// connection for the timeout, in which case it returns true, or until ctx is
+// done, in which case it returns false. The bytes counted are checked
+// periodically, so the connection is found idle up to a tenth of the timeout,
+// or a second, after it has become idle.
+func (c *countingConn) waitIdle(ctx context.Context, timeout time.Duration) bool {
+	interval := timeout / 10
+	if interval > maxIdleCheckInterval {
+		interval = maxIdleCheckInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	lastRead, lastWritten := c.BytesRead(), c.BytesWritten()
+	lastActive := time.Now()
+	for {
+		select {
+		case <-ctx.Done():
+			return false
+		case now := <-ticker.C:
+			read, written := c.BytesRead(), c.BytesWritten()
+			if read != lastRead || written != lastWritten {
+				lastRead, lastWritten = read, written
+				lastActive = now
+				continue
+			}
+			if now.Sub(lastActive) >= timeout {
+				return true
+			}
+		}
+	}
+}
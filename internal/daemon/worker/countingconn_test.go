@@ -4,11 +4,14 @@
 package worker
 
 import (
+	"context"
 	"fmt"
 	"net"
 	"sync"
 	"testing"
+	"time"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -154,6 +157,40 @@ func TestCountingConnConcurrentCalls(t *testing.T) {
 	require.EqualValues(t, concurrentWrites*len(bytesToWrite), conn.BytesWritten())
 }
 
+func TestCountingConnWaitIdle(t *testing.T) {
+	t.Parallel()
+	const timeout = 100 * time.Millisecond
+
+	t.Run("idle", func(t *testing.T) {
+		conn := &countingConn{Conn: &testNetConn{bytesToRead: 10}}
+		start := time.Now()
+		assert.True(t, conn.waitIdle(context.Background(), timeout))
+		assert.GreaterOrEqual(t, time.Since(start), timeout)
+	})
+	t.Run("active", func(t *testing.T) {
+		conn := &countingConn{Conn: &testNetConn{bytesToRead: 10}}
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			for i := 0; i < 10; i++ {
+				time.Sleep(timeout / 4)
+				_, err := conn.Write([]byte("hello"))
+				assert.NoError(t, err)
+			}
+		}()
+		start := time.Now()
+		assert.True(t, conn.waitIdle(context.Background(), timeout))
+		assert.GreaterOrEqual(t, time.Since(start), 10*timeout/4+timeout)
+		<-done
+	})
+	t.Run("canceled", func(t *testing.T) {
+		conn := &countingConn{Conn: &testNetConn{bytesToRead: 10}}
+		ctx, cancel := context.WithTimeout(context.Background(), timeout/2)
+		defer cancel()
+		assert.False(t, conn.waitIdle(ctx, timeout))
+	})
+}
+
 type testNetConn struct {
 	net.Conn // So we don't have to implement the entire interface.
 
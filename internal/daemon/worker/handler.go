@@ -13,6 +13,7 @@ import (
 	"net/url"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/boundary/globals"
 	"github.com/hashicorp/boundary/internal/daemon/common"
@@ -23,10 +24,13 @@ import (
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/servers/services"
 	"github.com/hashicorp/boundary/internal/observability/event"
 	"github.com/hashicorp/boundary/internal/proxy"
+	"github.com/hashicorp/boundary/internal/recording"
+	isession "github.com/hashicorp/boundary/internal/session"
 	"github.com/hashicorp/boundary/internal/util"
 	"github.com/hashicorp/go-secure-stdlib/listenerutil"
 	"github.com/hashicorp/nodeenrollment"
 	"github.com/hashicorp/nodeenrollment/types"
+	"go.uber.org/atomic"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/anypb"
 	"google.golang.org/protobuf/types/known/timestamppb"
@@ -135,8 +139,15 @@ func (w *Worker) handleProxy(listenerCfg *listenerutil.ListenerConfig, sessionMa
 		// Later calls will cause this to noop if they return a different status
 		defer conn.Close(websocket.StatusNormalClosure, "done")
 
-		connCtx, connCancel := context.WithDeadline(ctx, sess.GetExpiration())
+		// The connection is closed when the session expires. The session can
+		// be extended, so the connection context has no deadline.
+		connCtx, connCancel := context.WithCancel(ctx)
 		defer connCancel()
+		go func() {
+			if w.waitSessionExpiration(connCtx, sessionManager, sess) {
+				connCancel()
+			}
+		}()
 
 		var handshake proxy.ClientHandshake
 		if err := wspb.Read(connCtx, conn, &handshake); err != nil {
@@ -231,6 +242,7 @@ func (w *Worker) handleProxy(listenerCfg *listenerutil.ListenerConfig, sessionMa
 			return
 		}
 
+		var idleTimedOut atomic.Bool
 		defer func() {
 			ccd := map[string]*session.ConnectionCloseData{
 				acResp.GetConnectionId(): {
@@ -239,6 +251,9 @@ func (w *Worker) handleProxy(listenerCfg *listenerutil.ListenerConfig, sessionMa
 					BytesDown: cc.BytesWritten(),
 				},
 			}
+			if idleTimedOut.Load() {
+				ccd[acResp.GetConnectionId()].Reason = isession.ConnectionIdleTimeout
+			}
 			if sessionManager.RequestCloseConnections(ctx, ccd) {
 				event.WriteSysEvent(ctx, op, "connection closed", "session_id", sessionId, "connection_id", acResp.GetConnectionId())
 			}
@@ -282,8 +297,9 @@ func (w *Worker) handleProxy(listenerCfg *listenerutil.ListenerConfig, sessionMa
 			return
 		}
 
-		// Verify the protocol has a supported proxy before calling RequestAuthorizeConnection
-		handleProxyFn, err := proxyHandlers.GetHandler(workerId, acResp.GetProtocolContext())
+		// Verify the protocol has a supported proxy before calling RequestAuthorizeConnection.
+		// The endpoint scheme is the type of the target the session was authorized for.
+		handleProxyFn, err := proxyHandlers.GetHandler(workerId, endpointUrl.Scheme, protocolCtx)
 		if err != nil {
 			conn.Close(proxyHandlers.WebsocketStatusProtocolSetupError, "unable to get proxy handler")
 			event.WriteError(ctx, op, err)
@@ -294,6 +310,28 @@ func (w *Worker) handleProxy(listenerCfg *listenerutil.ListenerConfig, sessionMa
 			conn.Close(proxyHandlers.WebsocketStatusProtocolSetupError, "error getting decryption function")
 			event.WriteError(ctx, op, err)
 		}
+		if srId := acResp.GetSessionRecordingId(); srId != "" {
+			// The connection must not be proxied unless it can be recorded.
+			rec, err := w.newRecorder(ctx, srId, recording.Header{
+				Protocol:     endpointUrl.Scheme,
+				SessionId:    sess.GetId(),
+				ConnectionId: acResp.GetConnectionId(),
+				TargetId:     sess.GetTargetId(),
+				UserId:       sess.GetUserId(),
+				Endpoint:     sess.GetEndpoint(),
+			})
+			if err != nil {
+				conn.Close(proxyHandlers.WebsocketStatusProtocolSetupError, "unable to record connection")
+				event.WriteError(ctx, op, err, event.WithInfoMsg("error creating session recording", "session_recording_id", srId))
+				return
+			}
+			defer func() {
+				if err := rec.Close(); err != nil {
+					event.WriteError(ctx, op, err, event.WithInfoMsg("error closing session recording", "session_recording_id", srId))
+				}
+			}()
+			ctx = recording.NewContext(ctx, rec)
+		}
 		runProxy, err := handleProxyFn(ctx, decryptFn, cc, pDialer, acResp.GetConnectionId(), protocolCtx)
 		if err != nil {
 			conn.Close(proxyHandlers.WebsocketStatusProtocolSetupError, "unable to setup proxying")
@@ -321,10 +359,84 @@ func (w *Worker) handleProxy(listenerCfg *listenerutil.ListenerConfig, sessionMa
 			return
 		}
 
+		if idleTimeout := sess.GetIdleTimeout(); idleTimeout > 0 {
+			go func() {
+				if cc.waitIdle(connCtx, idleTimeout) {
+					event.WriteSysEvent(ctx, op, "closing idle connection", "session_id", sessionId, "connection_id", acResp.GetConnectionId())
+					idleTimedOut.Store(true)
+					connCancel()
+				}
+			}()
+		}
+
 		runProxy(ctx)
 	}, nil
 }
 
+// expirationLookupLeeway is how long before a session expires the worker looks
+// up the session again, to learn whether the session has been extended.
+const expirationLookupLeeway = 10 * time.Second
+
+// waitSessionExpiration waits until the session expires, in which case it
+// returns true, or until ctx is done, in which case it returns false. Shortly
+// before the session expires, the worker looks up the session again, so that
+// the session expires later when it has been extended.
+func (w *Worker) waitSessionExpiration(ctx context.Context, sessionManager session.Manager, sess session.Session) bool {
+	const op = "worker.(Worker).waitSessionExpiration"
+	var lookedUp time.Time
+	for {
+		expiration := sess.GetExpiration()
+		wait := time.Until(expiration)
+		if wait <= 0 {
+			return true
+		}
+		lookup := !expiration.Equal(lookedUp)
+		if lookup {
+			wait -= expirationLookupLeeway
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return false
+		case <-timer.C:
+		}
+		if !lookup {
+			continue
+		}
+		lookedUp = expiration
+		lastSuccess := w.LastStatusSuccess()
+		if lastSuccess == nil || lastSuccess.GetWorkerId() == "" {
+			continue
+		}
+		lookupCtx, cancel := context.WithTimeout(ctx, session.ValidateSessionTimeout)
+		_, err := sessionManager.LoadLocalSession(lookupCtx, sess.GetId(), lastSuccess.GetWorkerId())
+		cancel()
+		if err != nil {
+			event.WriteError(ctx, op, err, event.WithInfoMsg("error looking up session before it expires", "session_id", sess.GetId()))
+		}
+	}
+}
+
+// newRecorder returns a Recorder writing the recording with the provided id to
+// the recording storage of the worker.
+func (w *Worker) newRecorder(ctx context.Context, id string, h recording.Header) (*recording.Recorder, error) {
+	const op = "worker.(Worker).newRecorder"
+	if w.recordingStorage == nil {
+		return nil, errors.New(ctx, errors.Internal, op, "worker has no recording storage configured")
+	}
+	rw, err := w.recordingStorage.Create(ctx, id)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	rec, err := recording.NewRecorder(ctx, rw, h)
+	if err != nil {
+		rw.Close()
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return rec, nil
+}
+
 // credDecryptFn returns a DecryptFn if the worker is a pki worker with
 // WorkerAuthStorage defined. An error is returned if there is an error
 // loading the node credentials.
@@ -0,0 +1,303 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package http provides the worker proxy handler for http targets. The
+// handler reads each http request sent by the client, enforces the target's
+// path allowlist, injects the credential provided by the controller as the
+// Authorization header and forwards the request to the endpoint, so the
+// client never has access to the credential.
+package http
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/hashicorp/boundary/internal/daemon/worker/proxy"
+	"github.com/hashicorp/boundary/internal/errors"
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/servers/services"
+	"github.com/hashicorp/boundary/internal/observability/event"
+	"github.com/hashicorp/boundary/internal/recording"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// tokenField is the field of a json credential containing the token injected
+// as a bearer token.
+const tokenField = "token"
+
+// rootCAs is the set of root certificate authorities used to verify the
+// endpoint's certificate. If nil the host's root certificate authorities are
+// used. It is only set in tests.
+var rootCAs *x509.CertPool
+
+func init() {
+	if err := proxy.RegisterHandler(proxy.HttpHandlerName, handleProxy); err != nil {
+		panic(err)
+	}
+	if err := proxy.RegisterProtocolContext(proxy.HttpHandlerName, &pbs.HttpProtocolContext{}); err != nil {
+		panic(err)
+	}
+}
+
+// handleProxy creates an http proxy between the incoming conn and the
+// connection to the endpoint dialed by the ProxyDialer. If the
+// HttpProtocolContext requires tls the tls handshake with the endpoint is
+// completed before handleProxy returns.
+//
+// handleProxy returns a ProxyConnFn which reads the requests sent by the client
+// one at a time until either connection is closed. Requests for paths outside
+// of the allowed paths are answered with 403 Forbidden and the connection is
+// closed. All other requests are sent to the endpoint with the Host header set
+// to the endpoint's host and, if a credential was injected, with the
+// Authorization header set from the credential, replacing any provided by the
+// client. Each request line and the status of its response are written as a
+// system event. When ctx carries a recording.Recorder the data read from each
+// connection is recorded on channel 0, the requests are recorded as sent by
+// the client so the injected credential is never recorded.
+func handleProxy(ctx context.Context, decryptFn proxy.DecryptFn, conn net.Conn, out *proxy.ProxyDialer, connId string, pi *anypb.Any) (proxy.ProxyConnFn, error) {
+	const op = "http.HandleProxy"
+	switch {
+	case conn == nil:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "conn is nil")
+	case out == nil:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "proxy dialer is nil")
+	case len(connId) == 0:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "connection id is empty")
+	}
+
+	pc, creds, err := protocolContext(ctx, decryptFn, pi)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	authorization, err := authorizationHeader(ctx, creds)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+
+	remoteConn, err := out.Dial(ctx)
+	if err != nil {
+		return nil, err
+	}
+	host := pc.GetHost()
+	if host == "" {
+		host = remoteConn.RemoteAddr().String()
+	}
+	if pc.GetUseTls() {
+		serverName, _, err := net.SplitHostPort(host)
+		if err != nil {
+			serverName = host
+		}
+		tlsConn := tls.Client(remoteConn, &tls.Config{
+			ServerName: serverName,
+			RootCAs:    rootCAs,
+			MinVersion: tls.VersionTLS12,
+		})
+		if err := tlsConn.HandshakeContext(ctx); err != nil {
+			_ = remoteConn.Close()
+			return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to establish tls connection to endpoint"))
+		}
+		remoteConn = tlsConn
+	}
+
+	var fromRemote, fromClient io.Reader = remoteConn, conn
+	if rec := recording.FromContext(ctx); rec != nil {
+		fromRemote = io.TeeReader(remoteConn, rec.DataWriter(0, recording.Outbound, ""))
+		fromClient = io.TeeReader(conn, rec.DataWriter(0, recording.Inbound, ""))
+	}
+
+	p := &httpProxy{
+		connId:        connId,
+		client:        conn,
+		clientReader:  bufio.NewReader(fromClient),
+		remote:        remoteConn,
+		remoteReader:  bufio.NewReader(fromRemote),
+		host:          host,
+		authorization: authorization,
+		allowedPaths:  pc.GetAllowedPaths(),
+	}
+	return func(ctx context.Context) {
+		defer conn.Close()
+		defer remoteConn.Close()
+		for p.proxyRequest(ctx) {
+		}
+	}, nil
+}
+
+// protocolContext returns the HttpProtocolContext and its credentials,
+// decrypting them with decryptFn if they are encrypted.
+func protocolContext(ctx context.Context, decryptFn proxy.DecryptFn, pi *anypb.Any) (*pbs.HttpProtocolContext, []*pbs.Credential, error) {
+	const op = "http.protocolContext"
+	pc := &pbs.HttpProtocolContext{}
+	if pi == nil {
+		return pc, nil, nil
+	}
+	if err := pi.UnmarshalTo(pc); err != nil {
+		return nil, nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to unmarshal http protocol context"))
+	}
+	if len(pc.GetEncryptedCredentials()) == 0 {
+		return pc, pc.GetCredentials(), nil
+	}
+	if decryptFn == nil {
+		return nil, nil, errors.New(ctx, errors.InvalidParameter, op, "credentials are encrypted but decrypt function is nil")
+	}
+	injected := &pbs.InjectedCredentials{}
+	if err := decryptFn(ctx, pc.GetEncryptedCredentials(), injected); err != nil {
+		return nil, nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to decrypt credentials"))
+	}
+	return pc, injected.GetCredentials(), nil
+}
+
+// authorizationHeader returns the value of the Authorization header for the
+// provided credentials. A username password credential is sent using basic
+// authentication and the "token" field of a json credential is sent as a
+// bearer token. An empty string is returned if there are no credentials. At
+// most one credential can be injected.
+func authorizationHeader(ctx context.Context, creds []*pbs.Credential) (string, error) {
+	const op = "http.authorizationHeader"
+	switch len(creds) {
+	case 0:
+		return "", nil
+	case 1:
+	default:
+		return "", errors.New(ctx, errors.InvalidParameter, op, "only one credential can be injected")
+	}
+	switch cred := creds[0].GetCredential().(type) {
+	case *pbs.Credential_UsernamePassword:
+		username := cred.UsernamePassword.GetUsername()
+		if username == "" {
+			return "", errors.New(ctx, errors.InvalidParameter, op, "credential is missing username")
+		}
+		userPass := username + ":" + cred.UsernamePassword.GetPassword()
+		return "Basic " + base64.StdEncoding.EncodeToString([]byte(userPass)), nil
+
+	case *pbs.Credential_Json:
+		token, ok := cred.Json.GetObject().GetFields()[tokenField]
+		if !ok || token.GetStringValue() == "" {
+			return "", errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("json credential is missing string field %q", tokenField))
+		}
+		return "Bearer " + token.GetStringValue(), nil
+
+	default:
+		return "", errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("unsupported credential %T", cred))
+	}
+}
+
+// httpProxy proxies the requests of a single client connection.
+type httpProxy struct {
+	connId        string
+	client        net.Conn
+	clientReader  *bufio.Reader
+	remote        net.Conn
+	remoteReader  *bufio.Reader
+	host          string
+	authorization string
+	allowedPaths  []string
+}
+
+// proxyRequest proxies the next request read from the client and its
+// response. It returns false once no more requests can be proxied on the
+// connection.
+func (p *httpProxy) proxyRequest(ctx context.Context) bool {
+	const op = "http.(httpProxy).proxyRequest"
+	req, err := http.ReadRequest(p.clientReader)
+	if err != nil {
+		if !isClosedErr(err) {
+			event.WriteError(ctx, op, err, event.WithInfoMsg("unable to read request from client", "connection_id", p.connId))
+			p.writeStatus(http.StatusBadRequest)
+		}
+		return false
+	}
+	defer req.Body.Close()
+
+	if !pathAllowed(p.allowedPaths, req.URL.Path) {
+		p.logRequest(ctx, req, http.StatusForbidden)
+		p.writeStatus(http.StatusForbidden)
+		return false
+	}
+
+	req.Host = p.host
+	if p.authorization != "" {
+		req.Header.Set("Authorization", p.authorization)
+	}
+	if err := req.Write(p.remote); err != nil {
+		event.WriteError(ctx, op, err, event.WithInfoMsg("unable to send request to endpoint", "connection_id", p.connId))
+		p.logRequest(ctx, req, http.StatusBadGateway)
+		p.writeStatus(http.StatusBadGateway)
+		return false
+	}
+	resp, err := http.ReadResponse(p.remoteReader, req)
+	if err != nil {
+		event.WriteError(ctx, op, err, event.WithInfoMsg("unable to read response from endpoint", "connection_id", p.connId))
+		p.logRequest(ctx, req, http.StatusBadGateway)
+		p.writeStatus(http.StatusBadGateway)
+		return false
+	}
+	defer resp.Body.Close()
+	p.logRequest(ctx, req, resp.StatusCode)
+	if err := resp.Write(p.client); err != nil {
+		return false
+	}
+	return !req.Close && !resp.Close
+}
+
+// logRequest writes the request line of req and the status of its response as
+// a system event.
+func (p *httpProxy) logRequest(ctx context.Context, req *http.Request, status int) {
+	const op = "http.(httpProxy).logRequest"
+	event.WriteSysEvent(ctx, op, "proxied http request",
+		"connection_id", p.connId,
+		"method", req.Method,
+		"path", req.URL.Path,
+		"proto", req.Proto,
+		"status", status,
+	)
+}
+
+// writeStatus writes a response with the provided status to the client. The
+// response closes the connection.
+func (p *httpProxy) writeStatus(status int) {
+	resp := &http.Response{
+		StatusCode: status,
+		ProtoMajor: 1,
+		ProtoMinor: 1,
+		Close:      true,
+		Header:     http.Header{"Content-Type": []string{"text/plain; charset=utf-8"}},
+	}
+	body := http.StatusText(status) + "\n"
+	resp.ContentLength = int64(len(body))
+	resp.Body = io.NopCloser(strings.NewReader(body))
+	_ = resp.Write(p.client)
+}
+
+// pathAllowed reports whether the request path p is allowed by the allowed
+// path prefixes. A prefix allows the path equal to it and all paths below it.
+// The path is cleaned first so dot segments cannot be used to leave an allowed
+// prefix. All paths are allowed if there are no allowed path prefixes.
+func pathAllowed(allowed []string, p string) bool {
+	if len(allowed) == 0 {
+		return true
+	}
+	p = path.Clean("/" + p)
+	for _, prefix := range allowed {
+		prefix = strings.TrimSuffix(prefix, "/")
+		if p == prefix || strings.HasPrefix(p, prefix+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// isClosedErr reports whether err is the result of either connection being
+// closed while reading a request.
+func isClosedErr(err error) bool {
+	return stderrors.Is(err, io.EOF) || stderrors.Is(err, io.ErrUnexpectedEOF) || stderrors.Is(err, net.ErrClosed)
+}
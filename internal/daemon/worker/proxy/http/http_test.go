@@ -0,0 +1,462 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package http
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/daemon/worker/proxy"
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/servers/services"
+	"github.com/hashicorp/boundary/internal/recording"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestHandleProxy_Errors(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		l.Close()
+	})
+	dialer, err := proxy.NewProxyDialer(context.Background(), func(...proxy.Option) (net.Conn, error) {
+		return net.Dial("tcp", l.Addr().String())
+	})
+	require.NoError(t, err)
+	c, _ := net.Pipe()
+
+	cases := []struct {
+		name        string
+		conn        net.Conn
+		dialer      *proxy.ProxyDialer
+		connId      string
+		decryptFn   proxy.DecryptFn
+		protocolCtx *anypb.Any
+	}{
+		{
+			name:   "nil connection",
+			dialer: dialer,
+			connId: "someconnectionid",
+		},
+		{
+			name:   "nil dialer",
+			conn:   c,
+			connId: "someconnectionid",
+		},
+		{
+			name:   "no connection id",
+			conn:   c,
+			dialer: dialer,
+		},
+		{
+			name:   "wrong protocol context",
+			conn:   c,
+			dialer: dialer,
+			connId: "someconnectionid",
+			protocolCtx: &anypb.Any{
+				TypeUrl: "some.type.information",
+				Value:   []byte("this is just for this test"),
+			},
+		},
+		{
+			name:        "encrypted credentials without decrypt function",
+			conn:        c,
+			dialer:      dialer,
+			connId:      "someconnectionid",
+			protocolCtx: testProtocolContext(t, &pbs.HttpProtocolContext{EncryptedCredentials: []byte("encrypted")}),
+		},
+		{
+			name:   "decrypt failure",
+			conn:   c,
+			dialer: dialer,
+			connId: "someconnectionid",
+			decryptFn: func(context.Context, []byte, proto.Message) error {
+				return fmt.Errorf("decrypt failure")
+			},
+			protocolCtx: testProtocolContext(t, &pbs.HttpProtocolContext{EncryptedCredentials: []byte("encrypted")}),
+		},
+		{
+			name:   "unsupported credential",
+			conn:   c,
+			dialer: dialer,
+			connId: "someconnectionid",
+			protocolCtx: testProtocolContext(t, &pbs.HttpProtocolContext{
+				Credentials: []*pbs.Credential{{}},
+			}),
+		},
+		{
+			name:   "missing username",
+			conn:   c,
+			dialer: dialer,
+			connId: "someconnectionid",
+			protocolCtx: testProtocolContext(t, &pbs.HttpProtocolContext{
+				Credentials: []*pbs.Credential{testUsernamePassword("", "password")},
+			}),
+		},
+		{
+			name:   "json without token",
+			conn:   c,
+			dialer: dialer,
+			connId: "someconnectionid",
+			protocolCtx: testProtocolContext(t, &pbs.HttpProtocolContext{
+				Credentials: []*pbs.Credential{testJson(t, map[string]any{"username": "user"})},
+			}),
+		},
+		{
+			name:   "multiple credentials",
+			conn:   c,
+			dialer: dialer,
+			connId: "someconnectionid",
+			protocolCtx: testProtocolContext(t, &pbs.HttpProtocolContext{
+				Credentials: []*pbs.Credential{
+					testUsernamePassword("user", "password"),
+					testJson(t, map[string]any{"token": "token"}),
+				},
+			}),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			fn, err := handleProxy(context.Background(), tc.decryptFn, tc.conn, tc.dialer, tc.connId, tc.protocolCtx)
+			assert.Error(t, err)
+			assert.Nil(t, fn)
+		})
+	}
+}
+
+func TestHandleProxy(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	endpoint := httptest.NewServer(testHandler())
+	t.Cleanup(endpoint.Close)
+	endpointUrl, err := url.Parse(endpoint.URL)
+	require.NoError(t, err)
+
+	// decryptFn simulates a worker decrypting credentials the controller
+	// encrypted for it.
+	decryptFn := func(_ context.Context, from []byte, to proto.Message) error {
+		return proto.Unmarshal(from, to)
+	}
+	encrypted, err := proto.Marshal(&pbs.InjectedCredentials{Credentials: []*pbs.Credential{testUsernamePassword("user", "password")}})
+	require.NoError(t, err)
+
+	cases := []struct {
+		name          string
+		protocolCtx   *pbs.HttpProtocolContext
+		clientAuth    string
+		path          string
+		wantStatus    int
+		wantAuth      string
+		wantConnected bool
+	}{
+		{
+			name:          "no credentials",
+			protocolCtx:   &pbs.HttpProtocolContext{Host: "example.com"},
+			clientAuth:    "Bearer client",
+			path:          "/dashboard",
+			wantStatus:    http.StatusOK,
+			wantAuth:      "Bearer client",
+			wantConnected: true,
+		},
+		{
+			name:          "username password",
+			protocolCtx:   &pbs.HttpProtocolContext{Host: "example.com", Credentials: []*pbs.Credential{testUsernamePassword("user", "password")}},
+			clientAuth:    "Bearer client",
+			path:          "/dashboard",
+			wantStatus:    http.StatusOK,
+			wantAuth:      "Basic dXNlcjpwYXNzd29yZA==",
+			wantConnected: true,
+		},
+		{
+			name:          "json token",
+			protocolCtx:   &pbs.HttpProtocolContext{Host: "example.com", Credentials: []*pbs.Credential{testJson(t, map[string]any{"token": "secret"})}},
+			path:          "/dashboard",
+			wantStatus:    http.StatusOK,
+			wantAuth:      "Bearer secret",
+			wantConnected: true,
+		},
+		{
+			name:          "encrypted credentials",
+			protocolCtx:   &pbs.HttpProtocolContext{Host: "example.com", EncryptedCredentials: encrypted},
+			path:          "/dashboard",
+			wantStatus:    http.StatusOK,
+			wantAuth:      "Basic dXNlcjpwYXNzd29yZA==",
+			wantConnected: true,
+		},
+		{
+			name:          "allowed path",
+			protocolCtx:   &pbs.HttpProtocolContext{Host: "example.com", AllowedPaths: []string{"/api", "/dashboard/"}},
+			path:          "/dashboard/graphs",
+			wantStatus:    http.StatusOK,
+			wantConnected: true,
+		},
+		{
+			name:        "disallowed path",
+			protocolCtx: &pbs.HttpProtocolContext{Host: "example.com", AllowedPaths: []string{"/api"}},
+			path:        "/apis",
+			wantStatus:  http.StatusForbidden,
+		},
+		{
+			name:        "dot segments leaving allowed path",
+			protocolCtx: &pbs.HttpProtocolContext{Host: "example.com", AllowedPaths: []string{"/api"}},
+			path:        "/api/../admin",
+			wantStatus:  http.StatusForbidden,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			clientConn, workerConn := testConns(t)
+			dialer, err := proxy.NewProxyDialer(ctx, func(...proxy.Option) (net.Conn, error) {
+				return net.Dial("tcp", endpointUrl.Host)
+			})
+			require.NoError(err)
+
+			fn, err := handleProxy(ctx, decryptFn, workerConn, dialer, "someconnectionid", testProtocolContext(t, tc.protocolCtx))
+			require.NoError(err)
+			require.NotNil(fn)
+			done := make(chan struct{})
+			go func() {
+				fn(ctx)
+				close(done)
+			}()
+
+			clientReader := bufio.NewReader(clientConn)
+			for i := 0; i < 2; i++ {
+				resp := testRequest(t, clientConn, clientReader, tc.path, tc.clientAuth)
+				assert.Equal(tc.wantStatus, resp.StatusCode)
+				if !tc.wantConnected {
+					assert.True(resp.Close)
+					break
+				}
+				assert.Equal("example.com", resp.Header.Get("X-Host"))
+				assert.Equal(tc.wantAuth, resp.Header.Get("X-Authorization"))
+				body, err := io.ReadAll(resp.Body)
+				require.NoError(err)
+				assert.Equal(tc.path, string(body))
+			}
+			require.NoError(clientConn.Close())
+			<-done
+		})
+	}
+}
+
+func TestHandleProxy_Tls(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	assert, require := assert.New(t), require.New(t)
+
+	endpoint := httptest.NewTLSServer(testHandler())
+	t.Cleanup(endpoint.Close)
+	endpointUrl, err := url.Parse(endpoint.URL)
+	require.NoError(err)
+	rootCAs = endpoint.Client().Transport.(*http.Transport).TLSClientConfig.RootCAs
+	t.Cleanup(func() { rootCAs = nil })
+
+	newDialer := func() *proxy.ProxyDialer {
+		dialer, err := proxy.NewProxyDialer(ctx, func(...proxy.Option) (net.Conn, error) {
+			return net.Dial("tcp", endpointUrl.Host)
+		})
+		require.NoError(err)
+		return dialer
+	}
+
+	// The test server's certificate is only valid for example.com, its
+	// subdomains and the loopback addresses.
+	clientConn, workerConn := testConns(t)
+	fn, err := handleProxy(ctx, nil, workerConn, newDialer(), "someconnectionid", testProtocolContext(t, &pbs.HttpProtocolContext{
+		Host:   "127.0.0.1:" + endpointUrl.Port(),
+		UseTls: true,
+	}))
+	require.NoError(err)
+	go fn(ctx)
+	resp := testRequest(t, clientConn, bufio.NewReader(clientConn), "/dashboard", "")
+	assert.Equal(http.StatusOK, resp.StatusCode)
+	assert.Equal("127.0.0.1:"+endpointUrl.Port(), resp.Header.Get("X-Host"))
+
+	_, workerConn = testConns(t)
+	_, err = handleProxy(ctx, nil, workerConn, newDialer(), "someconnectionid", testProtocolContext(t, &pbs.HttpProtocolContext{
+		Host:   "boundary.test",
+		UseTls: true,
+	}))
+	assert.Error(err)
+}
+
+func TestHandleProxy_Recording(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	assert, require := assert.New(t), require.New(t)
+
+	endpoint := httptest.NewServer(testHandler())
+	t.Cleanup(endpoint.Close)
+	endpointUrl, err := url.Parse(endpoint.URL)
+	require.NoError(err)
+
+	storage, err := recording.NewLocalStorage(ctx, t.TempDir())
+	require.NoError(err)
+	w, err := storage.Create(ctx, "sr_1234567890")
+	require.NoError(err)
+	rec, err := recording.NewRecorder(ctx, w, recording.Header{
+		Protocol:     proxy.HttpHandlerName,
+		SessionId:    "s_1234567890",
+		ConnectionId: "sc_1234567890",
+	})
+	require.NoError(err)
+
+	clientConn, workerConn := testConns(t)
+	dialer, err := proxy.NewProxyDialer(ctx, func(...proxy.Option) (net.Conn, error) {
+		return net.Dial("tcp", endpointUrl.Host)
+	})
+	require.NoError(err)
+	pc := testProtocolContext(t, &pbs.HttpProtocolContext{Credentials: []*pbs.Credential{testUsernamePassword("user", "password")}})
+	fn, err := handleProxy(recording.NewContext(ctx, rec), nil, workerConn, dialer, "sc_1234567890", pc)
+	require.NoError(err)
+	done := make(chan struct{})
+	go func() {
+		fn(ctx)
+		close(done)
+	}()
+
+	resp := testRequest(t, clientConn, bufio.NewReader(clientConn), "/dashboard", "")
+	assert.Equal(http.StatusOK, resp.StatusCode)
+	require.NoError(clientConn.Close())
+	<-done
+	require.NoError(rec.Close())
+
+	r, err := storage.Open(ctx, "sr_1234567890")
+	require.NoError(err)
+	defer r.Close()
+	rr, err := recording.NewReader(ctx, r)
+	require.NoError(err)
+	assert.Equal(proxy.HttpHandlerName, rr.Header().Protocol)
+
+	var inbound, outbound []byte
+	for {
+		e, err := rr.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(err)
+		require.Equal(recording.DataEvent, e.Type)
+		switch e.Direction {
+		case recording.Inbound:
+			inbound = append(inbound, e.Data...)
+		case recording.Outbound:
+			outbound = append(outbound, e.Data...)
+		}
+	}
+	assert.True(strings.HasPrefix(string(inbound), "GET /dashboard HTTP/1.1\r\n"))
+	assert.NotContains(string(inbound), "Authorization")
+	assert.True(strings.HasPrefix(string(outbound), "HTTP/1.1 200 OK\r\n"))
+}
+
+func TestPathAllowed(t *testing.T) {
+	allowed := []string{"/api", "/dashboard/"}
+	cases := []struct {
+		path string
+		want bool
+	}{
+		{path: "/api", want: true},
+		{path: "/api/", want: true},
+		{path: "/api/v1/users", want: true},
+		{path: "/dashboard", want: true},
+		{path: "/dashboard/graphs", want: true},
+		{path: "/apis", want: false},
+		{path: "/", want: false},
+		{path: "", want: false},
+		{path: "/api/../admin", want: false},
+		{path: "/admin/../api/v1", want: true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.path, func(t *testing.T) {
+			assert.Equal(t, tc.want, pathAllowed(allowed, tc.path))
+		})
+	}
+	assert.True(t, pathAllowed(nil, "/anything"))
+	assert.True(t, pathAllowed([]string{"/"}, "/anything"))
+}
+
+// testHandler responds with the request path as the body and the Host and
+// Authorization headers it received as the X-Host and X-Authorization
+// headers.
+func testHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Host", r.Host)
+		w.Header().Set("X-Authorization", r.Header.Get("Authorization"))
+		_, _ = io.WriteString(w, r.URL.Path)
+	})
+}
+
+// testRequest sends a GET request for path on conn and returns the response.
+func testRequest(t *testing.T, conn net.Conn, r *bufio.Reader, path, auth string) *http.Response {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodGet, "http://"+conn.RemoteAddr().String()+path, nil)
+	require.NoError(t, err)
+	if auth != "" {
+		req.Header.Set("Authorization", auth)
+	}
+	require.NoError(t, req.Write(conn))
+	resp, err := http.ReadResponse(r, req)
+	require.NoError(t, err)
+	t.Cleanup(func() { resp.Body.Close() })
+	return resp
+}
+
+func testProtocolContext(t *testing.T, pc *pbs.HttpProtocolContext) *anypb.Any {
+	t.Helper()
+	a, err := anypb.New(pc)
+	require.NoError(t, err)
+	return a
+}
+
+func testUsernamePassword(username, password string) *pbs.Credential {
+	return &pbs.Credential{
+		Credential: &pbs.Credential_UsernamePassword{
+			UsernamePassword: &pbs.UsernamePassword{Username: username, Password: password},
+		},
+	}
+}
+
+func testJson(t *testing.T, object map[string]any) *pbs.Credential {
+	t.Helper()
+	s, err := structpb.NewStruct(object)
+	require.NoError(t, err)
+	return &pbs.Credential{
+		Credential: &pbs.Credential_Json{
+			Json: &pbs.Json{Object: s},
+		},
+	}
+}
+
+// testConns returns both ends of a tcp connection, simulating the client's
+// connection and the connection passed to the handler by the worker.
+func testConns(t *testing.T) (clientConn, workerConn net.Conn) {
+	t.Helper()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer l.Close()
+	clientConn, err = net.Dial("tcp", l.Addr().String())
+	require.NoError(t, err)
+	workerConn, err = l.Accept()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		clientConn.Close()
+		workerConn.Close()
+	})
+	return clientConn, workerConn
+}
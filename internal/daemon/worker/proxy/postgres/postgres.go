@@ -0,0 +1,339 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package postgres provides the worker proxy handler for postgres targets. The
+// handler authenticates to the database server with the credential injected by
+// the controller and then accepts the client without authentication, since the
+// client has already been authorized by the session. The client never has
+// access to the credential.
+package postgres
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"net"
+	"sort"
+	"sync"
+
+	"github.com/hashicorp/boundary/internal/daemon/worker/proxy"
+	"github.com/hashicorp/boundary/internal/errors"
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/servers/services"
+	"github.com/hashicorp/boundary/internal/observability/event"
+	"github.com/hashicorp/boundary/internal/recording"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgproto3/v2"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+const (
+	// connectionFailure is the postgres error code sent to the client when
+	// the connection to the database server fails for a reason other than an
+	// error reported by the database server.
+	connectionFailure = "08006"
+
+	// sslNotSupported is the response to an SSLRequest or GSSEncRequest
+	// refusing encryption. The client's connection to the worker is already
+	// encrypted by the session.
+	sslNotSupported = 'N'
+)
+
+func init() {
+	if err := proxy.RegisterHandler(proxy.PostgresHandlerName, handleProxy); err != nil {
+		panic(err)
+	}
+	if err := proxy.RegisterProtocolContext(proxy.PostgresHandlerName, &pbs.PostgresProtocolContext{}); err != nil {
+		panic(err)
+	}
+}
+
+// handleProxy creates a postgres proxy between the incoming conn and a
+// connection to the database server dialed by the ProxyDialer. The startup
+// message of the client is read and the connection to the database server is
+// authenticated with the username password credential in the
+// PostgresProtocolContext before handleProxy returns. The database and runtime
+// parameters of the client's startup message are used, the user is replaced
+// with the credential's username. If no credentials are provided the
+// connection is proxied by the tcp handler, leaving authentication to the
+// client.
+//
+// The connection to the database server always uses tls. The server's
+// certificate must be valid for the endpoint's host and signed by one of the
+// certificate authorities in the PostgresProtocolContext, otherwise the
+// connection fails and the credential is never sent. Encryption requested by
+// the client is refused, the client's connection is already encrypted by the
+// session.
+//
+// handleProxy returns a ProxyConnFn which completes the client's startup by
+// sending it the authentication and parameters of the database server's
+// connection and then proxies the data between the two connections until
+// either of them is closed. When ctx carries a recording.Recorder the client's
+// startup message, the startup response sent to it and the data copied in
+// each direction are recorded on channel 0.
+func handleProxy(ctx context.Context, decryptFn proxy.DecryptFn, conn net.Conn, out *proxy.ProxyDialer, connId string, pi *anypb.Any) (proxy.ProxyConnFn, error) {
+	const op = "postgres.HandleProxy"
+	switch {
+	case conn == nil:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "conn is nil")
+	case out == nil:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "proxy dialer is nil")
+	case len(connId) == 0:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "connection id is empty")
+	}
+
+	pc, creds, err := protocolContext(ctx, decryptFn, pi)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	if len(creds) == 0 {
+		tcpHandler, err := proxy.GetHandler("", proxy.TcpHandlerName, nil)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		return tcpHandler(ctx, decryptFn, conn, out, connId, nil)
+	}
+	username, password, err := usernamePassword(ctx, creds)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	if pc.GetHost() == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing host")
+	}
+	if pc.GetCaCert() == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing ca cert, credentials are only injected into connections to a verified database server")
+	}
+	rootCAs := x509.NewCertPool()
+	if !rootCAs.AppendCertsFromPEM([]byte(pc.GetCaCert())) {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "ca cert contains no valid certificates")
+	}
+
+	rec := recording.FromContext(ctx)
+	client := pgproto3.NewBackend(pgproto3.NewChunkReader(conn), conn)
+	msg, err := receiveStartupMessage(ctx, conn, client)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	if rec != nil {
+		if err := rec.RecordData(0, recording.Inbound, "", msg.Encode(nil)); err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+	}
+
+	switch msg := msg.(type) {
+	case *pgproto3.CancelRequest:
+		// A cancel request is sent by clients on a new connection. It contains
+		// the key of the database server's connection, which was sent to the
+		// client unchanged, so it is forwarded as is.
+		remoteConn, err := out.Dial(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return func(context.Context) {
+			defer conn.Close()
+			defer remoteConn.Close()
+			_, _ = remoteConn.Write(msg.Encode(nil))
+		}, nil
+
+	case *pgproto3.StartupMessage:
+		config, err := connectConfig(ctx, out, pc.GetHost(), rootCAs, username, password, msg.Parameters)
+		if err != nil {
+			sendError(client, err)
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		pgConn, err := pgconn.ConnectConfig(ctx, config)
+		if err != nil {
+			sendError(client, err)
+			return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to establish postgres connection to endpoint"))
+		}
+		hc, err := pgConn.Hijack()
+		if err != nil {
+			_ = pgConn.Close(ctx)
+			sendError(client, err)
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		return proxyConnFn(conn, hc, rec), nil
+
+	default:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("unexpected startup message %T", msg))
+	}
+}
+
+// protocolContext returns the PostgresProtocolContext and its credentials,
+// decrypting them with decryptFn if they are encrypted.
+func protocolContext(ctx context.Context, decryptFn proxy.DecryptFn, pi *anypb.Any) (*pbs.PostgresProtocolContext, []*pbs.Credential, error) {
+	const op = "postgres.protocolContext"
+	pc := &pbs.PostgresProtocolContext{}
+	if pi == nil {
+		return pc, nil, nil
+	}
+	if err := pi.UnmarshalTo(pc); err != nil {
+		return nil, nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to unmarshal postgres protocol context"))
+	}
+	if len(pc.GetEncryptedCredentials()) == 0 {
+		return pc, pc.GetCredentials(), nil
+	}
+	if decryptFn == nil {
+		return nil, nil, errors.New(ctx, errors.InvalidParameter, op, "credentials are encrypted but decrypt function is nil")
+	}
+	injected := &pbs.InjectedCredentials{}
+	if err := decryptFn(ctx, pc.GetEncryptedCredentials(), injected); err != nil {
+		return nil, nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to decrypt credentials"))
+	}
+	return pc, injected.GetCredentials(), nil
+}
+
+// usernamePassword returns the username and password of the provided
+// credentials. Exactly one username password credential must be provided.
+func usernamePassword(ctx context.Context, creds []*pbs.Credential) (string, string, error) {
+	const op = "postgres.usernamePassword"
+	if len(creds) != 1 {
+		return "", "", errors.New(ctx, errors.InvalidParameter, op, "only one credential can be injected")
+	}
+	up := creds[0].GetUsernamePassword()
+	if up == nil {
+		return "", "", errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("unsupported credential %T", creds[0].GetCredential()))
+	}
+	if up.GetUsername() == "" {
+		return "", "", errors.New(ctx, errors.InvalidParameter, op, "credential is missing username")
+	}
+	return up.GetUsername(), up.GetPassword(), nil
+}
+
+// receiveStartupMessage returns the first startup message of the client which
+// is not a request for encryption. Requests for encryption are refused.
+func receiveStartupMessage(ctx context.Context, conn net.Conn, client *pgproto3.Backend) (pgproto3.FrontendMessage, error) {
+	const op = "postgres.receiveStartupMessage"
+	for {
+		msg, err := client.ReceiveStartupMessage()
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to read startup message from client"))
+		}
+		switch msg.(type) {
+		case *pgproto3.SSLRequest, *pgproto3.GSSEncRequest:
+			if _, err := conn.Write([]byte{sslNotSupported}); err != nil {
+				return nil, errors.Wrap(ctx, err, op)
+			}
+		default:
+			return msg, nil
+		}
+	}
+}
+
+// connectConfig returns the configuration of the connection to the database
+// server. Connections are dialed with the ProxyDialer. The database and
+// runtime parameters are taken from the client's startup parameters.
+func connectConfig(ctx context.Context, out *proxy.ProxyDialer, host string, rootCAs *x509.CertPool, username, password string, params map[string]string) (*pgconn.Config, error) {
+	const op = "postgres.connectConfig"
+	// A Config must be created by ParseConfig. Every setting which may have
+	// been read from the worker's environment is replaced below.
+	config, err := pgconn.ParseConfig("")
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	config.Host = host
+	config.User = username
+	config.Password = password
+	config.Database = params["database"]
+	config.RuntimeParams = make(map[string]string, len(params))
+	for k, v := range params {
+		if k == "user" || k == "database" {
+			continue
+		}
+		config.RuntimeParams[k] = v
+	}
+	config.KerberosSrvName = ""
+	config.KerberosSpn = ""
+	config.ValidateConnect = nil
+	config.AfterConnect = nil
+
+	// The equivalent of sslmode=verify-full: the connection fails if the
+	// server does not support tls or its certificate can not be verified, so
+	// the password is never sent to an unverified server.
+	config.TLSConfig = &tls.Config{
+		ServerName: host,
+		RootCAs:    rootCAs,
+	}
+	config.Fallbacks = nil
+
+	// The ProxyDialer already knows the endpoint's address.
+	config.LookupFunc = func(context.Context, string) ([]string, error) {
+		return []string{host}, nil
+	}
+	config.DialFunc = func(ctx context.Context, _, _ string) (net.Conn, error) {
+		return out.Dial(ctx)
+	}
+	return config, nil
+}
+
+// sendError sends err to the client as a fatal error. Errors reported by the
+// database server are sent as they were received.
+func sendError(client *pgproto3.Backend, err error) {
+	resp := &pgproto3.ErrorResponse{
+		Severity: "FATAL",
+		Code:     connectionFailure,
+		Message:  "unable to connect to the database server",
+	}
+	var pgErr *pgconn.PgError
+	if stderrors.As(err, &pgErr) {
+		resp.Code = pgErr.Code
+		resp.Message = pgErr.Message
+		resp.Detail = pgErr.Detail
+		resp.Hint = pgErr.Hint
+	}
+	_ = client.Send(resp)
+}
+
+// proxyConnFn returns a ProxyConnFn which completes the startup of the client
+// connection with the state of the hijacked database server connection and then
+// proxies between the two connections.
+func proxyConnFn(conn net.Conn, hc *pgconn.HijackedConn, rec *recording.Recorder) proxy.ProxyConnFn {
+	const op = "postgres.proxyConnFn"
+	remoteConn := hc.Conn
+	var toClient io.Writer = conn
+	var fromRemote, fromClient io.Reader = remoteConn, conn
+	if rec != nil {
+		toClient = io.MultiWriter(rec.DataWriter(0, recording.Outbound, ""), conn)
+		fromRemote = io.TeeReader(remoteConn, rec.DataWriter(0, recording.Outbound, ""))
+		fromClient = io.TeeReader(conn, rec.DataWriter(0, recording.Inbound, ""))
+	}
+
+	return func(ctx context.Context) {
+		var buf []byte
+		buf = (&pgproto3.AuthenticationOk{}).Encode(buf)
+		names := make([]string, 0, len(hc.ParameterStatuses))
+		for name := range hc.ParameterStatuses {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			buf = (&pgproto3.ParameterStatus{Name: name, Value: hc.ParameterStatuses[name]}).Encode(buf)
+		}
+		buf = (&pgproto3.BackendKeyData{ProcessID: hc.PID, SecretKey: hc.SecretKey}).Encode(buf)
+		buf = (&pgproto3.ReadyForQuery{TxStatus: hc.TxStatus}).Encode(buf)
+		if _, err := toClient.Write(buf); err != nil {
+			event.WriteError(ctx, op, err, event.WithInfoMsg("unable to complete startup with client"))
+			_ = conn.Close()
+			_ = remoteConn.Close()
+			return
+		}
+
+		connWg := new(sync.WaitGroup)
+		connWg.Add(2)
+		go func() {
+			defer connWg.Done()
+			_, _ = io.Copy(conn, fromRemote)
+			_ = conn.Close()
+			_ = remoteConn.Close()
+		}()
+		go func() {
+			defer connWg.Done()
+			_, _ = io.Copy(remoteConn, fromClient)
+			_ = remoteConn.Close()
+			_ = conn.Close()
+		}()
+		connWg.Wait()
+	}
+}
@@ -0,0 +1,588 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package postgres
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"math/big"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/daemon/worker/proxy"
+	_ "github.com/hashicorp/boundary/internal/daemon/worker/proxy/tcp"
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/servers/services"
+	"github.com/hashicorp/boundary/internal/recording"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgproto3/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+func TestHandleProxy_Errors(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		l.Close()
+	})
+	dialer, err := proxy.NewProxyDialer(context.Background(), func(...proxy.Option) (net.Conn, error) {
+		return net.Dial("tcp", l.Addr().String())
+	})
+	require.NoError(t, err)
+	c, _ := net.Pipe()
+
+	cases := []struct {
+		name        string
+		conn        net.Conn
+		dialer      *proxy.ProxyDialer
+		connId      string
+		decryptFn   proxy.DecryptFn
+		protocolCtx *anypb.Any
+	}{
+		{
+			name:   "nil connection",
+			dialer: dialer,
+			connId: "someconnectionid",
+		},
+		{
+			name:   "nil dialer",
+			conn:   c,
+			connId: "someconnectionid",
+		},
+		{
+			name:   "no connection id",
+			conn:   c,
+			dialer: dialer,
+		},
+		{
+			name:   "wrong protocol context",
+			conn:   c,
+			dialer: dialer,
+			connId: "someconnectionid",
+			protocolCtx: &anypb.Any{
+				TypeUrl: "some.type.information",
+				Value:   []byte("this is just for this test"),
+			},
+		},
+		{
+			name:        "encrypted credentials without decrypt function",
+			conn:        c,
+			dialer:      dialer,
+			connId:      "someconnectionid",
+			protocolCtx: testProtocolContext(t, &pbs.PostgresProtocolContext{Host: "localhost", EncryptedCredentials: []byte("encrypted")}),
+		},
+		{
+			name:   "decrypt failure",
+			conn:   c,
+			dialer: dialer,
+			connId: "someconnectionid",
+			decryptFn: func(context.Context, []byte, proto.Message) error {
+				return fmt.Errorf("decrypt failure")
+			},
+			protocolCtx: testProtocolContext(t, &pbs.PostgresProtocolContext{Host: "localhost", EncryptedCredentials: []byte("encrypted")}),
+		},
+		{
+			name:   "unsupported credential",
+			conn:   c,
+			dialer: dialer,
+			connId: "someconnectionid",
+			protocolCtx: testProtocolContext(t, &pbs.PostgresProtocolContext{
+				Host:        "localhost",
+				Credentials: []*pbs.Credential{{}},
+			}),
+		},
+		{
+			name:   "missing username",
+			conn:   c,
+			dialer: dialer,
+			connId: "someconnectionid",
+			protocolCtx: testProtocolContext(t, &pbs.PostgresProtocolContext{
+				Host:        "localhost",
+				Credentials: []*pbs.Credential{testUsernamePassword("", "password")},
+			}),
+		},
+		{
+			name:   "multiple credentials",
+			conn:   c,
+			dialer: dialer,
+			connId: "someconnectionid",
+			protocolCtx: testProtocolContext(t, &pbs.PostgresProtocolContext{
+				Host: "localhost",
+				Credentials: []*pbs.Credential{
+					testUsernamePassword("user", "password"),
+					testUsernamePassword("user", "password"),
+				},
+			}),
+		},
+		{
+			name:   "missing host",
+			conn:   c,
+			dialer: dialer,
+			connId: "someconnectionid",
+			protocolCtx: testProtocolContext(t, &pbs.PostgresProtocolContext{
+				Credentials: []*pbs.Credential{testUsernamePassword("user", "password")},
+			}),
+		},
+		{
+			name:   "missing ca cert",
+			conn:   c,
+			dialer: dialer,
+			connId: "someconnectionid",
+			protocolCtx: testProtocolContext(t, &pbs.PostgresProtocolContext{
+				Host:        "localhost",
+				Credentials: []*pbs.Credential{testUsernamePassword("user", "password")},
+			}),
+		},
+		{
+			name:   "invalid ca cert",
+			conn:   c,
+			dialer: dialer,
+			connId: "someconnectionid",
+			protocolCtx: testProtocolContext(t, &pbs.PostgresProtocolContext{
+				Host:        "localhost",
+				CaCert:      "not a certificate",
+				Credentials: []*pbs.Credential{testUsernamePassword("user", "password")},
+			}),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			fn, err := handleProxy(context.Background(), tc.decryptFn, tc.conn, tc.dialer, tc.connId, tc.protocolCtx)
+			assert.Error(t, err)
+			assert.Nil(t, fn)
+		})
+	}
+}
+
+func TestHandleProxy(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	// decryptFn simulates a worker decrypting credentials the controller
+	// encrypted for it.
+	decryptFn := func(_ context.Context, from []byte, to proto.Message) error {
+		return proto.Unmarshal(from, to)
+	}
+	encrypted, err := proto.Marshal(&pbs.InjectedCredentials{Credentials: []*pbs.Credential{testUsernamePassword("user", "password")}})
+	require.NoError(t, err)
+	tlsConfig, caCert := testTlsConfig(t)
+	_, otherCaCert := testTlsConfig(t)
+
+	cases := []struct {
+		name        string
+		tlsConfig   *tls.Config
+		protocolCtx *pbs.PostgresProtocolContext
+		wantErrCode string
+	}{
+		{
+			name:        "username password",
+			tlsConfig:   tlsConfig,
+			protocolCtx: &pbs.PostgresProtocolContext{Host: "localhost", CaCert: caCert, Credentials: []*pbs.Credential{testUsernamePassword("user", "password")}},
+		},
+		{
+			name:        "encrypted credentials",
+			tlsConfig:   tlsConfig,
+			protocolCtx: &pbs.PostgresProtocolContext{Host: "localhost", CaCert: caCert, EncryptedCredentials: encrypted},
+		},
+		{
+			name:        "invalid credentials",
+			tlsConfig:   tlsConfig,
+			protocolCtx: &pbs.PostgresProtocolContext{Host: "localhost", CaCert: caCert, Credentials: []*pbs.Credential{testUsernamePassword("user", "wrong")}},
+			wantErrCode: "28P01",
+		},
+		{
+			name:        "server without tls",
+			protocolCtx: &pbs.PostgresProtocolContext{Host: "localhost", CaCert: caCert, Credentials: []*pbs.Credential{testUsernamePassword("user", "password")}},
+			wantErrCode: connectionFailure,
+		},
+		{
+			name:        "untrusted certificate authority",
+			tlsConfig:   tlsConfig,
+			protocolCtx: &pbs.PostgresProtocolContext{Host: "localhost", CaCert: otherCaCert, Credentials: []*pbs.Credential{testUsernamePassword("user", "password")}},
+			wantErrCode: connectionFailure,
+		},
+		{
+			name:        "certificate for another host",
+			tlsConfig:   tlsConfig,
+			protocolCtx: &pbs.PostgresProtocolContext{Host: "db.example.com", CaCert: caCert, Credentials: []*pbs.Credential{testUsernamePassword("user", "password")}},
+			wantErrCode: connectionFailure,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			endpoint := testPostgresServer(t, ctx, tc.tlsConfig)
+			clientConn, workerConn := testConns(t)
+			dialer, err := proxy.NewProxyDialer(ctx, func(...proxy.Option) (net.Conn, error) {
+				return net.Dial("tcp", endpoint.addr)
+			})
+			require.NoError(err)
+
+			// The client sends its startup message before the handler
+			// returns, so it connects concurrently.
+			type result struct {
+				conn *pgconn.PgConn
+				err  error
+			}
+			connected := make(chan result, 1)
+			go func() {
+				c, err := testClient(ctx, clientConn)
+				connected <- result{conn: c, err: err}
+			}()
+
+			fn, err := handleProxy(ctx, decryptFn, workerConn, dialer, "someconnectionid", testProtocolContext(t, tc.protocolCtx))
+			if tc.wantErrCode != "" {
+				assert.Error(err)
+				assert.Nil(fn)
+				res := <-connected
+				var pgErr *pgconn.PgError
+				require.ErrorAs(res.err, &pgErr)
+				assert.Equal(tc.wantErrCode, pgErr.Code)
+				return
+			}
+			require.NoError(err)
+			require.NotNil(fn)
+			done := make(chan struct{})
+			go func() {
+				fn(ctx)
+				close(done)
+			}()
+
+			res := <-connected
+			require.NoError(res.err)
+			client := res.conn
+			assert.Equal("14.0", client.ParameterStatus("server_version"))
+			assert.Equal(uint32(1234), client.PID())
+			assert.Equal(uint32(5678), client.SecretKey())
+
+			results, err := client.Exec(ctx, "select 1").ReadAll()
+			require.NoError(err)
+			require.Len(results, 1)
+			assert.Equal("user ran select 1 on db", string(results[0].CommandTag))
+			assert.True(<-endpoint.tls)
+
+			require.NoError(client.Close(ctx))
+			<-done
+		})
+	}
+
+	t.Run("cancel request", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		endpoint := testPostgresServer(t, ctx, nil)
+		clientConn, workerConn := testConns(t)
+		dialer, err := proxy.NewProxyDialer(ctx, func(...proxy.Option) (net.Conn, error) {
+			return net.Dial("tcp", endpoint.addr)
+		})
+		require.NoError(err)
+
+		_, err = clientConn.Write((&pgproto3.CancelRequest{ProcessID: 1234, SecretKey: 5678}).Encode(nil))
+		require.NoError(err)
+		pc := testProtocolContext(t, &pbs.PostgresProtocolContext{Host: "localhost", CaCert: caCert, Credentials: []*pbs.Credential{testUsernamePassword("user", "password")}})
+		fn, err := handleProxy(ctx, nil, workerConn, dialer, "someconnectionid", pc)
+		require.NoError(err)
+		fn(ctx)
+		assert.Equal(&pgproto3.CancelRequest{ProcessID: 1234, SecretKey: 5678}, <-endpoint.cancels)
+	})
+
+	t.Run("no credentials proxies tcp", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		endpoint := testPostgresServer(t, ctx, nil)
+		clientConn, workerConn := testConns(t)
+		dialer, err := proxy.NewProxyDialer(ctx, func(...proxy.Option) (net.Conn, error) {
+			return net.Dial("tcp", endpoint.addr)
+		})
+		require.NoError(err)
+
+		fn, err := handleProxy(ctx, nil, workerConn, dialer, "someconnectionid", testProtocolContext(t, &pbs.PostgresProtocolContext{Host: "localhost"}))
+		require.NoError(err)
+		require.NotNil(fn)
+		go fn(ctx)
+
+		// Without injected credentials the client must authenticate itself.
+		_, err = testClient(ctx, clientConn)
+		var pgErr *pgconn.PgError
+		require.ErrorAs(err, &pgErr)
+		assert.Equal("28P01", pgErr.Code)
+	})
+}
+
+func TestHandleProxy_Recording(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	assert, require := assert.New(t), require.New(t)
+
+	tlsConfig, caCert := testTlsConfig(t)
+	endpoint := testPostgresServer(t, ctx, tlsConfig)
+	storage, err := recording.NewLocalStorage(ctx, t.TempDir())
+	require.NoError(err)
+	w, err := storage.Create(ctx, "sr_1234567890")
+	require.NoError(err)
+	rec, err := recording.NewRecorder(ctx, w, recording.Header{
+		Protocol:     proxy.PostgresHandlerName,
+		SessionId:    "s_1234567890",
+		ConnectionId: "sc_1234567890",
+	})
+	require.NoError(err)
+
+	clientConn, workerConn := testConns(t)
+	dialer, err := proxy.NewProxyDialer(ctx, func(...proxy.Option) (net.Conn, error) {
+		return net.Dial("tcp", endpoint.addr)
+	})
+	require.NoError(err)
+	connected := make(chan *pgconn.PgConn, 1)
+	go func() {
+		c, err := testClient(ctx, clientConn)
+		assert.NoError(err)
+		connected <- c
+	}()
+	pc := testProtocolContext(t, &pbs.PostgresProtocolContext{Host: "localhost", CaCert: caCert, Credentials: []*pbs.Credential{testUsernamePassword("user", "password")}})
+	fn, err := handleProxy(recording.NewContext(ctx, rec), nil, workerConn, dialer, "sc_1234567890", pc)
+	require.NoError(err)
+	done := make(chan struct{})
+	go func() {
+		fn(ctx)
+		close(done)
+	}()
+	client := <-connected
+	require.NotNil(client)
+	_, err = client.Exec(ctx, "select 1").ReadAll()
+	require.NoError(err)
+	require.NoError(client.Close(ctx))
+	<-done
+	require.NoError(rec.Close())
+
+	r, err := storage.Open(ctx, "sr_1234567890")
+	require.NoError(err)
+	defer r.Close()
+	rr, err := recording.NewReader(ctx, r)
+	require.NoError(err)
+	assert.Equal(proxy.PostgresHandlerName, rr.Header().Protocol)
+
+	var inbound, outbound []byte
+	for {
+		e, err := rr.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(err)
+		require.Equal(recording.DataEvent, e.Type)
+		switch e.Direction {
+		case recording.Inbound:
+			inbound = append(inbound, e.Data...)
+		case recording.Outbound:
+			outbound = append(outbound, e.Data...)
+		}
+	}
+
+	// The recording replays the client's side of the connection, it never
+	// contains the injected password.
+	server := pgproto3.NewBackend(pgproto3.NewChunkReader(bytes.NewReader(inbound)), io.Discard)
+	msg, err := server.ReceiveStartupMessage()
+	require.NoError(err)
+	assert.Equal("someone-else", msg.(*pgproto3.StartupMessage).Parameters["user"])
+	msg, err = server.Receive()
+	require.NoError(err)
+	assert.Equal("select 1", msg.(*pgproto3.Query).String)
+	assert.NotContains(string(inbound), "password")
+
+	frontend := pgproto3.NewFrontend(pgproto3.NewChunkReader(bytes.NewReader(outbound)), io.Discard)
+	bmsg, err := frontend.Receive()
+	require.NoError(err)
+	assert.IsType(&pgproto3.AuthenticationOk{}, bmsg)
+	assert.NotContains(string(outbound), "password")
+}
+
+// testClient connects to the worker over conn as a client which does not
+// provide a password and does not request tls.
+func testClient(ctx context.Context, conn net.Conn) (*pgconn.PgConn, error) {
+	config, err := pgconn.ParseConfig("host=localhost user=someone-else database=db sslmode=disable")
+	if err != nil {
+		return nil, err
+	}
+	config.DialFunc = func(context.Context, string, string) (net.Conn, error) {
+		return conn, nil
+	}
+	config.LookupFunc = func(_ context.Context, host string) ([]string, error) {
+		return []string{host}, nil
+	}
+	return pgconn.ConnectConfig(ctx, config)
+}
+
+func testProtocolContext(t *testing.T, pc *pbs.PostgresProtocolContext) *anypb.Any {
+	t.Helper()
+	a, err := anypb.New(pc)
+	require.NoError(t, err)
+	return a
+}
+
+func testUsernamePassword(username, password string) *pbs.Credential {
+	return &pbs.Credential{
+		Credential: &pbs.Credential_UsernamePassword{
+			UsernamePassword: &pbs.UsernamePassword{Username: username, Password: password},
+		},
+	}
+}
+
+// testConns returns both ends of a tcp connection, simulating the client's
+// connection and the connection passed to the handler by the worker.
+func testConns(t *testing.T) (clientConn, workerConn net.Conn) {
+	t.Helper()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer l.Close()
+	clientConn, err = net.Dial("tcp", l.Addr().String())
+	require.NoError(t, err)
+	workerConn, err = l.Accept()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		clientConn.Close()
+		workerConn.Close()
+	})
+	return clientConn, workerConn
+}
+
+// testTlsConfig returns a tls configuration with a self signed certificate
+// for localhost and the PEM encoding of that certificate.
+func testTlsConfig(t *testing.T) (*tls.Config, string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		DNSNames:     []string{"localhost"},
+		NotBefore:    time.Now().Add(-time.Minute),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+	return &tls.Config{
+		Certificates: []tls.Certificate{{Certificate: [][]byte{der}, PrivateKey: key}},
+	}, string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+}
+
+// testServer is a postgres server which accepts the user "user" with the
+// password "password". Queries complete with the command tag "<user> ran
+// <query> on <database>". Whether each authenticated connection used tls is
+// sent on tls and the cancel requests it receives are sent on cancels.
+type testServer struct {
+	addr    string
+	tls     chan bool
+	cancels chan *pgproto3.CancelRequest
+}
+
+// testPostgresServer starts a testServer. The server supports tls if
+// tlsConfig is not nil.
+func testPostgresServer(t *testing.T, ctx context.Context, tlsConfig *tls.Config) *testServer {
+	t.Helper()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go func() {
+		<-ctx.Done()
+		l.Close()
+	}()
+	s := &testServer{
+		addr:    l.Addr().String(),
+		tls:     make(chan bool, 10),
+		cancels: make(chan *pgproto3.CancelRequest, 10),
+	}
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn, tlsConfig)
+		}
+	}()
+	return s
+}
+
+func (s *testServer) serve(conn net.Conn, tlsConfig *tls.Config) {
+	defer conn.Close()
+	var usedTls bool
+	backend := pgproto3.NewBackend(pgproto3.NewChunkReader(conn), conn)
+	var startup *pgproto3.StartupMessage
+	for startup == nil {
+		msg, err := backend.ReceiveStartupMessage()
+		if err != nil {
+			return
+		}
+		switch msg := msg.(type) {
+		case *pgproto3.SSLRequest:
+			if tlsConfig == nil {
+				_, _ = conn.Write([]byte{'N'})
+				continue
+			}
+			_, _ = conn.Write([]byte{'S'})
+			tlsConn := tls.Server(conn, tlsConfig)
+			if err := tlsConn.Handshake(); err != nil {
+				return
+			}
+			conn = tlsConn
+			usedTls = true
+			backend = pgproto3.NewBackend(pgproto3.NewChunkReader(conn), conn)
+		case *pgproto3.CancelRequest:
+			c := *msg
+			s.cancels <- &c
+			return
+		case *pgproto3.StartupMessage:
+			startup = msg
+		default:
+			return
+		}
+	}
+
+	_ = backend.Send(&pgproto3.AuthenticationCleartextPassword{})
+	if err := backend.SetAuthType(pgproto3.AuthTypeCleartextPassword); err != nil {
+		return
+	}
+	msg, err := backend.Receive()
+	if err != nil {
+		return
+	}
+	password, ok := msg.(*pgproto3.PasswordMessage)
+	if !ok || startup.Parameters["user"] != "user" || password.Password != "password" {
+		_ = backend.Send(&pgproto3.ErrorResponse{
+			Severity: "FATAL",
+			Code:     "28P01",
+			Message:  fmt.Sprintf("password authentication failed for user %q", startup.Parameters["user"]),
+		})
+		return
+	}
+	s.tls <- usedTls
+	_ = backend.Send(&pgproto3.AuthenticationOk{})
+	_ = backend.Send(&pgproto3.ParameterStatus{Name: "server_version", Value: "14.0"})
+	_ = backend.Send(&pgproto3.BackendKeyData{ProcessID: 1234, SecretKey: 5678})
+	_ = backend.Send(&pgproto3.ReadyForQuery{TxStatus: 'I'})
+
+	for {
+		msg, err := backend.Receive()
+		if err != nil {
+			return
+		}
+		switch msg := msg.(type) {
+		case *pgproto3.Query:
+			tag := fmt.Sprintf("%s ran %s on %s", startup.Parameters["user"], msg.String, startup.Parameters["database"])
+			_ = backend.Send(&pgproto3.CommandComplete{CommandTag: []byte(tag)})
+			_ = backend.Send(&pgproto3.ReadyForQuery{TxStatus: 'I'})
+		case *pgproto3.Terminate:
+			return
+		}
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"context"
 	"errors"
 	"net"
+	"sort"
 	"sync"
 
 	"google.golang.org/protobuf/proto"
@@ -14,21 +15,36 @@ import (
 )
 
 var (
-	TcpHandlerName = "tcp"
+	TcpHandlerName      = "tcp"
+	SshHandlerName      = "ssh"
+	HttpHandlerName     = "http"
+	PostgresHandlerName = "postgres"
 
 	// handlers is the map of registered handlers
 	handlers sync.Map
 
+	// protocolContexts maps the full name of a protocol context message to
+	// the protocol whose handler understands it
+	protocolContexts sync.Map
+
 	// ErrUnknownProtocol specifies the provided protocol has no registered handler
 	ErrUnknownProtocol = errors.New("proxy: handler not found for protocol")
 
 	// ErrProtocolAlreadyRegistered specifies the provided protocol has already been registered
 	ErrProtocolAlreadyRegistered = errors.New("proxy: protocol already registered")
 
-	// GetHandler returns the handler registered for the provided worker and
-	// protocolContext. If a protocol cannot be determined or the protocol is
-	// not registered nil, ErrUnknownProtocol is returned.
-	GetHandler = tcpOnly
+	// ErrProtocolContextAlreadyRegistered specifies the provided protocol
+	// context message has already been registered for a protocol
+	ErrProtocolContextAlreadyRegistered = errors.New("proxy: protocol context already registered")
+
+	// ErrProtocolMismatch specifies the target type and the protocol context
+	// provided to GetHandler resolve to different protocols
+	ErrProtocolMismatch = errors.New("proxy: protocol context does not match target type")
+
+	// GetHandler returns the handler registered for the provided worker,
+	// target type and protocolContext. If a protocol cannot be determined or
+	// the protocol is not registered nil, ErrUnknownProtocol is returned.
+	GetHandler = protocolHandler
 )
 
 // DecryptFn decrypts the provided bytes into a proto.Message
@@ -45,6 +61,8 @@ type ProxyConnFn func(ctx context.Context)
 // established.
 type Handler func(context.Context, DecryptFn, net.Conn, *ProxyDialer, string, *anypb.Any) (ProxyConnFn, error)
 
+// RegisterHandler registers the handler for the provided protocol. The
+// protocol is the name of the target type the handler proxies connections for.
 func RegisterHandler(protocol string, handler Handler) error {
 	_, loaded := handlers.LoadOrStore(protocol, handler)
 	if loaded {
@@ -53,9 +71,50 @@ func RegisterHandler(protocol string, handler Handler) error {
 	return nil
 }
 
-// tcpOnly returns only the TCP protocol.
-func tcpOnly(string, proto.Message) (Handler, error) {
-	handler, ok := handlers.Load(TcpHandlerName)
+// RegisterProtocolContext registers the type of the provided message as the
+// protocol context sent by the controller for connections using the provided
+// protocol. This allows a handler to be selected by the protocol context alone
+// when the target type is not known.
+func RegisterProtocolContext(protocol string, m proto.Message) error {
+	_, loaded := protocolContexts.LoadOrStore(string(m.ProtoReflect().Descriptor().FullName()), protocol)
+	if loaded {
+		return ErrProtocolContextAlreadyRegistered
+	}
+	return nil
+}
+
+// RegisteredProtocols returns the sorted names of the protocols which have a
+// registered handler.
+func RegisteredProtocols() []string {
+	var protocols []string
+	handlers.Range(func(k, _ any) bool {
+		protocols = append(protocols, k.(string))
+		return true
+	})
+	sort.Strings(protocols)
+	return protocols
+}
+
+// protocolHandler returns the handler for the protocol identified by the
+// target type and the protocol context. When both identify a protocol they
+// must agree. When neither does the tcp handler is returned, since controllers
+// which provide neither only support tcp targets.
+func protocolHandler(_ string, targetType string, protocolCtx *anypb.Any) (Handler, error) {
+	protocol := targetType
+	if protocolCtx != nil {
+		if p, ok := protocolContexts.Load(string(protocolCtx.MessageName())); ok {
+			switch {
+			case protocol == "":
+				protocol = p.(string)
+			case protocol != p.(string):
+				return nil, ErrProtocolMismatch
+			}
+		}
+	}
+	if protocol == "" {
+		protocol = TcpHandlerName
+	}
+	handler, ok := handlers.Load(protocol)
 	if !ok {
 		return nil, ErrUnknownProtocol
 	}
@@ -5,13 +5,18 @@ package proxy
 
 import (
 	"context"
+	"errors"
 	"net"
+	"strings"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+	"nhooyr.io/websocket"
 )
 
 func TestRegisterHandler(t *testing.T) {
@@ -38,22 +43,209 @@ func TestRegisterHandler(t *testing.T) {
 	require.NoError(err)
 }
 
-func TestAlwaysTcpGetHandler(t *testing.T) {
+func TestRegisterProtocolContext(t *testing.T) {
 	assert, require := assert.New(t), require.New(t)
+	t.Cleanup(func() {
+		protocolContexts.Delete(string((&wrapperspb.StringValue{}).ProtoReflect().Descriptor().FullName()))
+	})
+
+	require.NoError(RegisterProtocolContext("protocol", &wrapperspb.StringValue{}))
+
+	// Register the same message for a different protocol
+	err := RegisterProtocolContext("new-protocol", &wrapperspb.StringValue{})
+	require.Error(err)
+	assert.ErrorIs(err, ErrProtocolContextAlreadyRegistered)
+}
+
+func TestRegisteredProtocols(t *testing.T) {
 	fn := func(context.Context, DecryptFn, net.Conn, *ProxyDialer, string, *anypb.Any) (ProxyConnFn, error) {
 		return nil, nil
 	}
-	oldHandler := handlers
 	t.Cleanup(func() {
-		handlers = oldHandler
+		handlers.Delete("registered-b")
+		handlers.Delete("registered-a")
 	})
-	handlers = sync.Map{}
-	_, err := tcpOnly("wid", nil)
-	assert.ErrorIs(err, ErrUnknownProtocol)
+	require.NoError(t, RegisterHandler("registered-b", fn))
+	require.NoError(t, RegisterHandler("registered-a", fn))
+
+	got := RegisteredProtocols()
+	assert.Subset(t, got, []string{"registered-a", "registered-b"})
+	assert.IsIncreasing(t, got)
+}
+
+func TestProtocolHandler(t *testing.T) {
+	tcpFn := func(context.Context, DecryptFn, net.Conn, *ProxyDialer, string, *anypb.Any) (ProxyConnFn, error) {
+		return nil, errors.New("tcp")
+	}
+	otherFn := func(context.Context, DecryptFn, net.Conn, *ProxyDialer, string, *anypb.Any) (ProxyConnFn, error) {
+		return nil, errors.New("other")
+	}
+	otherCtx, err := anypb.New(&wrapperspb.StringValue{Value: "other context"})
+	require.NoError(t, err)
+	unregisteredCtx, err := anypb.New(&wrapperspb.BytesValue{Value: []byte("unregistered context")})
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		handlers.Delete(TcpHandlerName)
+		handlers.Delete("other")
+		protocolContexts.Delete(string(otherCtx.MessageName()))
+	})
+
+	_, err = protocolHandler("wid", "", nil)
+	assert.ErrorIs(t, err, ErrUnknownProtocol)
+
+	require.NoError(t, RegisterHandler(TcpHandlerName, tcpFn))
+	require.NoError(t, RegisterHandler("other", otherFn))
+	require.NoError(t, RegisterProtocolContext("other", &wrapperspb.StringValue{}))
+
+	cases := []struct {
+		name        string
+		targetType  string
+		protocolCtx *anypb.Any
+		wantHandler string
+		wantErr     error
+	}{
+		{
+			name:        "no type or context defaults to tcp",
+			wantHandler: "tcp",
+		},
+		{
+			name:        "tcp target type",
+			targetType:  "tcp",
+			wantHandler: "tcp",
+		},
+		{
+			name:        "other target type",
+			targetType:  "other",
+			wantHandler: "other",
+		},
+		{
+			name:        "other protocol context",
+			protocolCtx: otherCtx,
+			wantHandler: "other",
+		},
+		{
+			name:        "other target type and protocol context",
+			targetType:  "other",
+			protocolCtx: otherCtx,
+			wantHandler: "other",
+		},
+		{
+			name:        "unregistered protocol context uses target type",
+			targetType:  "other",
+			protocolCtx: unregisteredCtx,
+			wantHandler: "other",
+		},
+		{
+			name:        "unregistered protocol context defaults to tcp",
+			protocolCtx: unregisteredCtx,
+			wantHandler: "tcp",
+		},
+		{
+			name:        "mismatched target type and protocol context",
+			targetType:  "tcp",
+			protocolCtx: otherCtx,
+			wantErr:     ErrProtocolMismatch,
+		},
+		{
+			name:       "unknown target type",
+			targetType: "unknown",
+			wantErr:    ErrUnknownProtocol,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			handler, err := protocolHandler("wid", tc.targetType, tc.protocolCtx)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				assert.Nil(t, handler)
+				return
+			}
+			require.NoError(t, err)
+			require.NotNil(t, handler)
+			_, err = handler(context.Background(), nil, nil, nil, "", nil)
+			assert.EqualError(t, err, tc.wantHandler)
+		})
+	}
+}
+
+// TestGetHandler_SecondHandler registers a handler for a protocol other than
+// tcp and proxies a connection through the handler returned by GetHandler.
+func TestGetHandler_SecondHandler(t *testing.T) {
+	require, assert := require.New(t), assert.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	const protocol = "upper"
+	// upper proxies the client's data to the endpoint after upper casing it.
+	upper := func(ctx context.Context, _ DecryptFn, conn net.Conn, out *ProxyDialer, _ string, protocolCtx *anypb.Any) (ProxyConnFn, error) {
+		var prefix wrapperspb.StringValue
+		if err := protocolCtx.UnmarshalTo(&prefix); err != nil {
+			return nil, err
+		}
+		remoteConn, err := out.Dial(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return func(ctx context.Context) {
+			defer remoteConn.Close()
+			defer conn.Close()
+			buf := make([]byte, 1024)
+			for {
+				n, err := conn.Read(buf)
+				if err != nil {
+					return
+				}
+				if n == 0 {
+					continue
+				}
+				if _, err := remoteConn.Write([]byte(prefix.GetValue() + strings.ToUpper(string(buf[:n])))); err != nil {
+					return
+				}
+			}
+		}, nil
+	}
+	t.Cleanup(func() {
+		handlers.Delete(protocol)
+		protocolContexts.Delete(string((&wrapperspb.StringValue{}).ProtoReflect().Descriptor().FullName()))
+	})
+	require.NoError(RegisterHandler(protocol, upper))
+	require.NoError(RegisterProtocolContext(protocol, &wrapperspb.StringValue{}))
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(err)
+	defer l.Close()
+	endpointConns := make(chan net.Conn, 1)
+	go func() {
+		c, err := l.Accept()
+		if err != nil {
+			return
+		}
+		endpointConns <- c
+	}()
+
+	clientConn, proxyConn := TestWsConn(t, ctx)
+	dialer, err := NewProxyDialer(ctx, func(...Option) (net.Conn, error) {
+		return net.Dial("tcp", l.Addr().String())
+	})
+	require.NoError(err)
+
+	protocolCtx, err := anypb.New(&wrapperspb.StringValue{Value: "> "})
+	require.NoError(err)
+	handler, err := GetHandler("wid", "", protocolCtx)
+	require.NoError(err)
+	conn := websocket.NetConn(ctx, proxyConn, websocket.MessageBinary)
+	runProxy, err := handler(ctx, nil, conn, dialer, "connid", protocolCtx)
+	require.NoError(err)
+	go runProxy(ctx)
 
-	require.NoError(RegisterHandler("tcp", fn))
+	endpointConn := <-endpointConns
+	defer endpointConn.Close()
 
-	handler, err := tcpOnly("wid", nil)
+	require.NoError(clientConn.Write(ctx, websocket.MessageBinary, []byte("hello")))
+	buf := make([]byte, 1024)
+	require.NoError(endpointConn.SetReadDeadline(time.Now().Add(5 * time.Second)))
+	n, err := endpointConn.Read(buf)
 	require.NoError(err)
-	require.NotNil(handler)
+	assert.Equal("> HELLO", string(buf[:n]))
 }
@@ -0,0 +1,434 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package ssh provides the worker proxy handler for ssh targets. The handler
+// terminates the client's ssh connection on the worker and opens a new ssh
+// connection to the endpoint which is authenticated with the credentials
+// injected by the controller, so the client never has access to them.
+package ssh
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+
+	"github.com/hashicorp/boundary/internal/daemon/worker/proxy"
+	"github.com/hashicorp/boundary/internal/errors"
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/servers/services"
+	"github.com/hashicorp/boundary/internal/observability/event"
+	"github.com/hashicorp/boundary/internal/recording"
+	"golang.org/x/crypto/ssh"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+func init() {
+	if err := proxy.RegisterHandler(proxy.SshHandlerName, handleProxy); err != nil {
+		panic(err)
+	}
+	if err := proxy.RegisterProtocolContext(proxy.SshHandlerName, &pbs.SshProtocolContext{}); err != nil {
+		panic(err)
+	}
+}
+
+// handleProxy creates an ssh proxy between the incoming conn and an ssh
+// connection to the endpoint dialed by the ProxyDialer. The connection to the
+// endpoint is authenticated with the credentials in the SshProtocolContext
+// before handleProxy returns, once the endpoint has presented one of the host
+// keys in the SshProtocolContext. If no credentials are provided the
+// connection is proxied by the tcp handler, leaving authentication to the
+// client.
+//
+// handleProxy returns a ProxyConnFn which performs the ssh handshake with the
+// client, using an ephemeral host key and no client authentication, and then
+// proxies all channels and requests between the two ssh connections until
+// either of them is closed. When ctx carries a recording.Recorder the opening
+// and closing of each channel and the data and requests sent over it are
+// recorded, global requests are recorded on channel 0.
+func handleProxy(ctx context.Context, decryptFn proxy.DecryptFn, conn net.Conn, out *proxy.ProxyDialer, connId string, pi *anypb.Any) (proxy.ProxyConnFn, error) {
+	const op = "ssh.HandleProxy"
+	switch {
+	case conn == nil:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "conn is nil")
+	case out == nil:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "proxy dialer is nil")
+	case len(connId) == 0:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "connection id is empty")
+	}
+
+	pc := &pbs.SshProtocolContext{}
+	if pi != nil {
+		if err := pi.UnmarshalTo(pc); err != nil {
+			return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to unmarshal ssh protocol context"))
+		}
+	}
+	creds, err := injectedCredentials(ctx, decryptFn, pc)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	if len(creds) == 0 {
+		tcpHandler, err := proxy.GetHandler("", proxy.TcpHandlerName, nil)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		return tcpHandler(ctx, decryptFn, conn, out, connId, nil)
+	}
+
+	clientConfig, err := clientConfig(ctx, creds, pc.GetHostKeys())
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	serverConfig, err := serverConfig(ctx)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+
+	remoteConn, err := out.Dial(ctx)
+	if err != nil {
+		return nil, err
+	}
+	remoteSshConn, remoteChans, remoteReqs, err := ssh.NewClientConn(remoteConn, remoteConn.RemoteAddr().String(), clientConfig)
+	if err != nil {
+		_ = remoteConn.Close()
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to establish ssh connection to endpoint"))
+	}
+
+	rec := recording.FromContext(ctx)
+	return func(ctx context.Context) {
+		defer remoteSshConn.Close()
+		clientSshConn, clientChans, clientReqs, err := ssh.NewServerConn(conn, serverConfig)
+		if err != nil {
+			event.WriteError(ctx, op, err, event.WithInfoMsg("unable to establish ssh connection with client"))
+			_ = conn.Close()
+			return
+		}
+		defer clientSshConn.Close()
+
+		chanWg := new(sync.WaitGroup)
+		chanWg.Add(2)
+		go forwardGlobalRequests(remoteSshConn, clientReqs, rec, recording.Inbound)
+		go forwardGlobalRequests(clientSshConn, remoteReqs, rec, recording.Outbound)
+		go forwardChannels(remoteSshConn, clientChans, chanWg, rec, recording.Inbound)
+		go forwardChannels(clientSshConn, remoteChans, chanWg, rec, recording.Outbound)
+
+		// Wait for either connection to close, the deferred calls close the
+		// other one.
+		closed := make(chan struct{}, 2)
+		go func() {
+			_ = clientSshConn.Wait()
+			closed <- struct{}{}
+		}()
+		go func() {
+			_ = remoteSshConn.Wait()
+			closed <- struct{}{}
+		}()
+		<-closed
+
+		// Close both connections and wait for their channels to be closed, so
+		// the channels are completely recorded when the ProxyConnFn returns.
+		_ = clientSshConn.Close()
+		_ = remoteSshConn.Close()
+		chanWg.Wait()
+	}, nil
+}
+
+// injectedCredentials returns the credentials from the SshProtocolContext,
+// decrypting them with decryptFn if they are encrypted.
+func injectedCredentials(ctx context.Context, decryptFn proxy.DecryptFn, pc *pbs.SshProtocolContext) ([]*pbs.Credential, error) {
+	const op = "ssh.injectedCredentials"
+	if len(pc.GetEncryptedCredentials()) == 0 {
+		return pc.GetCredentials(), nil
+	}
+	if decryptFn == nil {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "credentials are encrypted but decrypt function is nil")
+	}
+	injected := &pbs.InjectedCredentials{}
+	if err := decryptFn(ctx, pc.GetEncryptedCredentials(), injected); err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to decrypt credentials"))
+	}
+	return injected.GetCredentials(), nil
+}
+
+// clientConfig returns the configuration used to authenticate to the
+// endpoint with the provided credentials. All credentials must be for the same
+// user. The endpoint must present one of hostKeys, public keys in the
+// authorized_keys format, as its host key. An error is returned if there are
+// no host keys, since the credentials must not be sent to an endpoint which
+// cannot be verified.
+func clientConfig(ctx context.Context, creds []*pbs.Credential, hostKeys []string) (*ssh.ClientConfig, error) {
+	const op = "ssh.clientConfig"
+	hostKeyCallback, hostKeyAlgorithms, err := verifyHostKey(ctx, hostKeys)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	var username string
+	var auths []ssh.AuthMethod
+	for _, c := range creds {
+		var credUsername string
+		var credAuths []ssh.AuthMethod
+		switch cred := c.GetCredential().(type) {
+		case *pbs.Credential_UsernamePassword:
+			credUsername = cred.UsernamePassword.GetUsername()
+			password := cred.UsernamePassword.GetPassword()
+			// Servers often only accept passwords through keyboard-interactive
+			// authentication, so every question is answered with the password.
+			credAuths = append(credAuths,
+				ssh.Password(password),
+				ssh.KeyboardInteractive(func(_, _ string, questions []string, _ []bool) ([]string, error) {
+					answers := make([]string, len(questions))
+					for i := range answers {
+						answers[i] = password
+					}
+					return answers, nil
+				}),
+			)
+
+		case *pbs.Credential_SshPrivateKey:
+			credUsername = cred.SshPrivateKey.GetUsername()
+			signer, err := parsePrivateKey(cred.SshPrivateKey.GetPrivateKey(), cred.SshPrivateKey.GetPrivateKeyPassphrase())
+			if err != nil {
+				return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to parse ssh private key"))
+			}
+			credAuths = append(credAuths, ssh.PublicKeys(signer))
+
+		case *pbs.Credential_SshCertificate:
+			credUsername = cred.SshCertificate.GetUsername()
+			signer, err := parsePrivateKey(cred.SshCertificate.GetPrivateKey(), "")
+			if err != nil {
+				return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to parse ssh certificate private key"))
+			}
+			pub, _, _, _, err := ssh.ParseAuthorizedKey([]byte(cred.SshCertificate.GetCertificate()))
+			if err != nil {
+				return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to parse ssh certificate"))
+			}
+			cert, ok := pub.(*ssh.Certificate)
+			if !ok {
+				return nil, errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("ssh certificate is a %q key", pub.Type()))
+			}
+			certSigner, err := ssh.NewCertSigner(cert, signer)
+			if err != nil {
+				return nil, errors.Wrap(ctx, err, op, errors.WithMsg("ssh certificate does not match private key"))
+			}
+			credAuths = append(credAuths, ssh.PublicKeys(certSigner))
+
+		default:
+			return nil, errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("unsupported credential %T", cred))
+		}
+
+		switch {
+		case credUsername == "":
+			return nil, errors.New(ctx, errors.InvalidParameter, op, "credential is missing username")
+		case username == "":
+			username = credUsername
+		case username != credUsername:
+			return nil, errors.New(ctx, errors.InvalidParameter, op, "credentials are for different usernames")
+		}
+		auths = append(auths, credAuths...)
+	}
+	return &ssh.ClientConfig{
+		User:              username,
+		Auth:              auths,
+		HostKeyCallback:   hostKeyCallback,
+		HostKeyAlgorithms: hostKeyAlgorithms,
+	}, nil
+}
+
+// verifyHostKey returns a HostKeyCallback which only accepts the host keys
+// parsed from keys, and the host key algorithms of those keys so the endpoint
+// presents one of them.
+func verifyHostKey(ctx context.Context, keys []string) (ssh.HostKeyCallback, []string, error) {
+	const op = "ssh.verifyHostKey"
+	if len(keys) == 0 {
+		return nil, nil, errors.New(ctx, errors.InvalidParameter, op, "target has no host keys to verify the endpoint with")
+	}
+	var pubKeys []ssh.PublicKey
+	var algorithms []string
+	for _, k := range keys {
+		pub, _, _, _, err := ssh.ParseAuthorizedKey([]byte(k))
+		if err != nil {
+			return nil, nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to parse host key"))
+		}
+		pubKeys = append(pubKeys, pub)
+		if pub.Type() == ssh.KeyAlgoRSA {
+			// ssh-rsa keys are used with the sha2 signature algorithms by
+			// current servers.
+			algorithms = append(algorithms, ssh.KeyAlgoRSASHA512, ssh.KeyAlgoRSASHA256)
+		}
+		algorithms = append(algorithms, pub.Type())
+	}
+	callback := func(_ string, _ net.Addr, key ssh.PublicKey) error {
+		for _, pub := range pubKeys {
+			if bytes.Equal(pub.Marshal(), key.Marshal()) {
+				return nil
+			}
+		}
+		return fmt.Errorf("endpoint host key %s %s does not match any host key of the target", key.Type(), ssh.FingerprintSHA256(key))
+	}
+	return callback, algorithms, nil
+}
+
+func parsePrivateKey(key, passphrase string) (ssh.Signer, error) {
+	if passphrase != "" {
+		return ssh.ParsePrivateKeyWithPassphrase([]byte(key), []byte(passphrase))
+	}
+	return ssh.ParsePrivateKey([]byte(key))
+}
+
+// serverConfig returns the configuration for the ssh connection with the
+// client. The host key is generated for each connection. The client has
+// already been authorized by the session so no client authentication is
+// required.
+func serverConfig(ctx context.Context) (*ssh.ServerConfig, error) {
+	const op = "ssh.serverConfig"
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to generate host key"))
+	}
+	signer, err := ssh.NewSignerFromKey(key)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to create host key signer"))
+	}
+	cfg := &ssh.ServerConfig{
+		NoClientAuth: true,
+	}
+	cfg.AddHostKey(signer)
+	return cfg, nil
+}
+
+// forwardGlobalRequests sends each request to the other connection and
+// replies with its response until reqs is closed. Requests are recorded with
+// rec, if it is not nil, as sent in the direction dir. A request which cannot
+// be recorded is rejected.
+func forwardGlobalRequests(to ssh.Conn, reqs <-chan *ssh.Request, rec *recording.Recorder, dir recording.Direction) {
+	for req := range reqs {
+		if rec != nil {
+			if err := rec.RecordRequest(0, dir, req.Type, req.Payload); err != nil {
+				if req.WantReply {
+					_ = req.Reply(false, nil)
+				}
+				continue
+			}
+		}
+		ok, payload, err := to.SendRequest(req.Type, req.WantReply, req.Payload)
+		if req.WantReply {
+			_ = req.Reply(ok && err == nil, payload)
+		}
+	}
+}
+
+// forwardChannels opens a channel on the other connection for each new
+// channel and proxies between the two until either is closed. If the other
+// connection rejects the channel the new channel is rejected with the same
+// reason. The channels are recorded with rec, if it is not nil, and dir is
+// the direction of the connection the new channels were opened from. wg is
+// done once chans is closed and all of its channels are closed.
+func forwardChannels(to ssh.Conn, chans <-chan ssh.NewChannel, wg *sync.WaitGroup, rec *recording.Recorder, dir recording.Direction) {
+	defer wg.Done()
+	for newCh := range chans {
+		wg.Add(1)
+		go func(newCh ssh.NewChannel) {
+			defer wg.Done()
+			toCh, toReqs, err := to.OpenChannel(newCh.ChannelType(), newCh.ExtraData())
+			if err != nil {
+				var openErr *ssh.OpenChannelError
+				if stderrors.As(err, &openErr) {
+					_ = newCh.Reject(openErr.Reason, openErr.Message)
+					return
+				}
+				_ = newCh.Reject(ssh.ConnectionFailed, err.Error())
+				return
+			}
+			ch, reqs, err := newCh.Accept()
+			if err != nil {
+				_ = toCh.Close()
+				return
+			}
+			var chanRec *channelRecorder
+			if rec != nil {
+				channel, err := rec.RecordChannelOpen(newCh.ChannelType(), newCh.ExtraData())
+				if err != nil {
+					// The channel is not proxied if it cannot be recorded.
+					_ = ch.Close()
+					_ = toCh.Close()
+					return
+				}
+				defer func() { _ = rec.RecordChannelClose(channel) }()
+				chanRec = &channelRecorder{rec: rec, channel: channel}
+			}
+			proxyChannel(ch, reqs, toCh, toReqs, chanRec, dir)
+		}(newCh)
+	}
+}
+
+// channelRecorder records the data and requests sent over a channel.
+type channelRecorder struct {
+	rec     *recording.Recorder
+	channel uint32
+}
+
+// proxyChannel proxies the data and requests between the two channels until
+// both are closed. aDir is the direction of the data and requests sent from a
+// to b. They are recorded with cr, if it is not nil.
+func proxyChannel(a ssh.Channel, aReqs <-chan *ssh.Request, b ssh.Channel, bReqs <-chan *ssh.Request, cr *channelRecorder, aDir recording.Direction) {
+	// A channel is only closed once the replies to the requests it has sent
+	// have been forwarded to it, otherwise a request can be left without a
+	// reply when the other channel replies to it and closes right after.
+	var inFlight sync.RWMutex
+	go pipeChannel(b, a, aReqs, &inFlight, cr, aDir)
+	pipeChannel(a, b, bReqs, &inFlight, cr, aDir.Opposite())
+}
+
+// pipeChannel copies the data and requests received on src to dst. Once src
+// has no more data dst is sent an EOF, and once src is closed dst is closed.
+// The data and requests are recorded with cr, if it is not nil, before they
+// are sent to dst. Data stops being copied once it cannot be recorded, and
+// requests which cannot be recorded are rejected.
+func pipeChannel(dst, src ssh.Channel, srcReqs <-chan *ssh.Request, inFlight *sync.RWMutex, cr *channelRecorder, dir recording.Direction) {
+	var data, stderr io.Reader = src, src.Stderr()
+	if cr != nil {
+		data = io.TeeReader(data, cr.rec.DataWriter(cr.channel, dir, ""))
+		stderr = io.TeeReader(stderr, cr.rec.DataWriter(cr.channel, dir, recording.StderrStream))
+	}
+	dataDone := make(chan struct{})
+	go func() {
+		var wg sync.WaitGroup
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			_, _ = io.Copy(dst, data)
+		}()
+		go func() {
+			defer wg.Done()
+			_, _ = io.Copy(dst.Stderr(), stderr)
+		}()
+		wg.Wait()
+		_ = dst.CloseWrite()
+		close(dataDone)
+	}()
+
+	for req := range srcReqs {
+		if cr != nil {
+			if err := cr.rec.RecordRequest(cr.channel, dir, req.Type, req.Payload); err != nil {
+				if req.WantReply {
+					_ = req.Reply(false, nil)
+				}
+				continue
+			}
+		}
+		inFlight.RLock()
+		ok, err := dst.SendRequest(req.Type, req.WantReply, req.Payload)
+		if req.WantReply {
+			_ = req.Reply(ok && err == nil, nil)
+		}
+		inFlight.RUnlock()
+	}
+	<-dataDone
+	inFlight.Lock()
+	_ = dst.Close()
+	inFlight.Unlock()
+}
@@ -0,0 +1,565 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package ssh
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/binary"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/daemon/worker/proxy"
+	_ "github.com/hashicorp/boundary/internal/daemon/worker/proxy/tcp"
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/servers/services"
+	"github.com/hashicorp/boundary/internal/recording"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+func TestHandleProxy_Errors(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		l.Close()
+	})
+	dialer, err := proxy.NewProxyDialer(context.Background(), func(...proxy.Option) (net.Conn, error) {
+		return net.Dial("tcp", l.Addr().String())
+	})
+	require.NoError(t, err)
+	c, _ := net.Pipe()
+
+	cases := []struct {
+		name        string
+		conn        net.Conn
+		dialer      *proxy.ProxyDialer
+		connId      string
+		decryptFn   proxy.DecryptFn
+		protocolCtx *anypb.Any
+	}{
+		{
+			name:   "nil connection",
+			dialer: dialer,
+			connId: "someconnectionid",
+		},
+		{
+			name:   "nil dialer",
+			conn:   c,
+			connId: "someconnectionid",
+		},
+		{
+			name:   "no connection id",
+			conn:   c,
+			dialer: dialer,
+		},
+		{
+			name:   "wrong protocol context",
+			conn:   c,
+			dialer: dialer,
+			connId: "someconnectionid",
+			protocolCtx: &anypb.Any{
+				TypeUrl: "some.type.information",
+				Value:   []byte("this is just for this test"),
+			},
+		},
+		{
+			name:        "encrypted credentials without decrypt function",
+			conn:        c,
+			dialer:      dialer,
+			connId:      "someconnectionid",
+			protocolCtx: testProtocolContext(t, &pbs.SshProtocolContext{EncryptedCredentials: []byte("encrypted")}),
+		},
+		{
+			name:   "decrypt failure",
+			conn:   c,
+			dialer: dialer,
+			connId: "someconnectionid",
+			decryptFn: func(context.Context, []byte, proto.Message) error {
+				return fmt.Errorf("decrypt failure")
+			},
+			protocolCtx: testProtocolContext(t, &pbs.SshProtocolContext{EncryptedCredentials: []byte("encrypted")}),
+		},
+		{
+			name:   "unsupported credential",
+			conn:   c,
+			dialer: dialer,
+			connId: "someconnectionid",
+			protocolCtx: testProtocolContext(t, &pbs.SshProtocolContext{
+				Credentials: []*pbs.Credential{{}},
+				HostKeys:    []string{testHostKey(t)},
+			}),
+		},
+		{
+			name:   "missing username",
+			conn:   c,
+			dialer: dialer,
+			connId: "someconnectionid",
+			protocolCtx: testProtocolContext(t, &pbs.SshProtocolContext{
+				Credentials: []*pbs.Credential{testUsernamePassword("", "password")},
+				HostKeys:    []string{testHostKey(t)},
+			}),
+		},
+		{
+			name:   "different usernames",
+			conn:   c,
+			dialer: dialer,
+			connId: "someconnectionid",
+			protocolCtx: testProtocolContext(t, &pbs.SshProtocolContext{
+				Credentials: []*pbs.Credential{
+					testUsernamePassword("user1", "password"),
+					testUsernamePassword("user2", "password"),
+				},
+				HostKeys: []string{testHostKey(t)},
+			}),
+		},
+		{
+			name:   "invalid private key",
+			conn:   c,
+			dialer: dialer,
+			connId: "someconnectionid",
+			protocolCtx: testProtocolContext(t, &pbs.SshProtocolContext{
+				Credentials: []*pbs.Credential{{
+					Credential: &pbs.Credential_SshPrivateKey{
+						SshPrivateKey: &pbs.SshPrivateKey{Username: "user", PrivateKey: "not a key"},
+					},
+				}},
+				HostKeys: []string{testHostKey(t)},
+			}),
+		},
+		{
+			name:   "no host keys",
+			conn:   c,
+			dialer: dialer,
+			connId: "someconnectionid",
+			protocolCtx: testProtocolContext(t, &pbs.SshProtocolContext{
+				Credentials: []*pbs.Credential{testUsernamePassword("user", "password")},
+			}),
+		},
+		{
+			name:   "invalid host key",
+			conn:   c,
+			dialer: dialer,
+			connId: "someconnectionid",
+			protocolCtx: testProtocolContext(t, &pbs.SshProtocolContext{
+				Credentials: []*pbs.Credential{testUsernamePassword("user", "password")},
+				HostKeys:    []string{"not a key"},
+			}),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			fn, err := handleProxy(context.Background(), tc.decryptFn, tc.conn, tc.dialer, tc.connId, tc.protocolCtx)
+			assert.Error(t, err)
+			assert.Nil(t, fn)
+		})
+	}
+}
+
+func TestHandleProxy(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	userKey, userPrivateKey := testKey(t)
+	caKey, _ := testKey(t)
+	cert := &ssh.Certificate{
+		Key:             userKey.PublicKey(),
+		CertType:        ssh.UserCert,
+		ValidPrincipals: []string{"user"},
+		ValidBefore:     ssh.CertTimeInfinity,
+	}
+	require.NoError(t, cert.SignCert(rand.Reader, caKey))
+
+	passwordCred := testUsernamePassword("user", "password")
+	privateKeyCred := &pbs.Credential{
+		Credential: &pbs.Credential_SshPrivateKey{
+			SshPrivateKey: &pbs.SshPrivateKey{Username: "user", PrivateKey: userPrivateKey},
+		},
+	}
+	certCred := &pbs.Credential{
+		Credential: &pbs.Credential_SshCertificate{
+			SshCertificate: &pbs.SshCertificate{
+				Username:    "user",
+				PrivateKey:  userPrivateKey,
+				Certificate: string(ssh.MarshalAuthorizedKey(cert)),
+			},
+		},
+	}
+
+	certChecker := &ssh.CertChecker{
+		IsUserAuthority: func(auth ssh.PublicKey) bool {
+			return bytes.Equal(auth.Marshal(), caKey.PublicKey().Marshal())
+		},
+	}
+	endpoint, hostKey := testSshServer(t, ctx, &ssh.ServerConfig{
+		PasswordCallback: func(c ssh.ConnMetadata, password []byte) (*ssh.Permissions, error) {
+			if c.User() == "user" && string(password) == "password" {
+				return nil, nil
+			}
+			return nil, fmt.Errorf("invalid password")
+		},
+		PublicKeyCallback: func(c ssh.ConnMetadata, key ssh.PublicKey) (*ssh.Permissions, error) {
+			if _, ok := key.(*ssh.Certificate); ok {
+				return certChecker.Authenticate(c, key)
+			}
+			if c.User() == "user" && bytes.Equal(key.Marshal(), userKey.PublicKey().Marshal()) {
+				return nil, nil
+			}
+			return nil, fmt.Errorf("invalid public key")
+		},
+	})
+
+	// decryptFn simulates a worker decrypting credentials the controller
+	// encrypted for it.
+	decryptFn := func(_ context.Context, from []byte, to proto.Message) error {
+		return proto.Unmarshal(from, to)
+	}
+	encrypted, err := proto.Marshal(&pbs.InjectedCredentials{Credentials: []*pbs.Credential{passwordCred}})
+	require.NoError(t, err)
+
+	cases := []struct {
+		name        string
+		protocolCtx *pbs.SshProtocolContext
+		wantErr     bool
+	}{
+		{
+			name:        "username password",
+			protocolCtx: &pbs.SshProtocolContext{Credentials: []*pbs.Credential{passwordCred}, HostKeys: []string{hostKey}},
+		},
+		{
+			name:        "private key",
+			protocolCtx: &pbs.SshProtocolContext{Credentials: []*pbs.Credential{privateKeyCred}, HostKeys: []string{hostKey}},
+		},
+		{
+			name:        "certificate",
+			protocolCtx: &pbs.SshProtocolContext{Credentials: []*pbs.Credential{certCred}, HostKeys: []string{hostKey}},
+		},
+		{
+			name:        "multiple credentials",
+			protocolCtx: &pbs.SshProtocolContext{Credentials: []*pbs.Credential{testUsernamePassword("user", "wrong"), privateKeyCred}, HostKeys: []string{hostKey}},
+		},
+		{
+			name:        "encrypted credentials",
+			protocolCtx: &pbs.SshProtocolContext{EncryptedCredentials: encrypted, HostKeys: []string{hostKey}},
+		},
+		{
+			name:        "invalid credentials",
+			protocolCtx: &pbs.SshProtocolContext{Credentials: []*pbs.Credential{testUsernamePassword("user", "wrong")}, HostKeys: []string{hostKey}},
+			wantErr:     true,
+		},
+		{
+			name:        "no host keys",
+			protocolCtx: &pbs.SshProtocolContext{Credentials: []*pbs.Credential{passwordCred}},
+			wantErr:     true,
+		},
+		{
+			name:        "host key mismatch",
+			protocolCtx: &pbs.SshProtocolContext{Credentials: []*pbs.Credential{passwordCred}, HostKeys: []string{testHostKey(t)}},
+			wantErr:     true,
+		},
+		{
+			name:        "one of multiple host keys",
+			protocolCtx: &pbs.SshProtocolContext{Credentials: []*pbs.Credential{passwordCred}, HostKeys: []string{testHostKey(t), hostKey}},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			clientConn, workerConn := testConns(t)
+			dialer, err := proxy.NewProxyDialer(ctx, func(...proxy.Option) (net.Conn, error) {
+				return net.Dial("tcp", endpoint)
+			})
+			require.NoError(err)
+
+			fn, err := handleProxy(ctx, decryptFn, workerConn, dialer, "someconnectionid", testProtocolContext(t, tc.protocolCtx))
+			if tc.wantErr {
+				assert.Error(err)
+				assert.Nil(fn)
+				return
+			}
+			require.NoError(err)
+			require.NotNil(fn)
+			done := make(chan struct{})
+			go func() {
+				fn(ctx)
+				close(done)
+			}()
+
+			// The client authenticates with neither the injected credentials
+			// nor the endpoint's host key.
+			c, chans, reqs, err := ssh.NewClientConn(clientConn, clientConn.RemoteAddr().String(), &ssh.ClientConfig{
+				User:            "someone-else",
+				HostKeyCallback: ssh.InsecureIgnoreHostKey(),
+			})
+			require.NoError(err)
+			client := ssh.NewClient(c, chans, reqs)
+			sess, err := client.NewSession()
+			require.NoError(err)
+			out, err := sess.Output("whoami")
+			require.NoError(err)
+			assert.Equal("user ran whoami", string(out))
+
+			sess, err = client.NewSession()
+			require.NoError(err)
+			err = sess.Run("exit")
+			var exitErr *ssh.ExitError
+			require.ErrorAs(err, &exitErr)
+			assert.Equal(3, exitErr.ExitStatus())
+
+			require.NoError(client.Close())
+			<-done
+		})
+	}
+
+	t.Run("no credentials proxies tcp", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		clientConn, workerConn := testConns(t)
+		dialer, err := proxy.NewProxyDialer(ctx, func(...proxy.Option) (net.Conn, error) {
+			return net.Dial("tcp", endpoint)
+		})
+		require.NoError(err)
+
+		fn, err := handleProxy(ctx, nil, workerConn, dialer, "someconnectionid", testProtocolContext(t, &pbs.SshProtocolContext{}))
+		require.NoError(err)
+		require.NotNil(fn)
+		go fn(ctx)
+
+		// Without injected credentials the client must authenticate itself.
+		c, chans, reqs, err := ssh.NewClientConn(clientConn, clientConn.RemoteAddr().String(), &ssh.ClientConfig{
+			User:            "user",
+			Auth:            []ssh.AuthMethod{ssh.Password("password")},
+			HostKeyCallback: ssh.InsecureIgnoreHostKey(),
+		})
+		require.NoError(err)
+		client := ssh.NewClient(c, chans, reqs)
+		defer client.Close()
+		sess, err := client.NewSession()
+		require.NoError(err)
+		out, err := sess.Output("whoami")
+		require.NoError(err)
+		assert.Equal("user ran whoami", string(out))
+	})
+}
+
+func TestHandleProxy_Recording(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	assert, require := assert.New(t), require.New(t)
+
+	endpoint, hostKey := testSshServer(t, ctx, &ssh.ServerConfig{
+		PasswordCallback: func(c ssh.ConnMetadata, password []byte) (*ssh.Permissions, error) {
+			return nil, nil
+		},
+	})
+	storage, err := recording.NewLocalStorage(ctx, t.TempDir())
+	require.NoError(err)
+	w, err := storage.Create(ctx, "sr_1234567890")
+	require.NoError(err)
+	rec, err := recording.NewRecorder(ctx, w, recording.Header{
+		Protocol:     proxy.SshHandlerName,
+		SessionId:    "s_1234567890",
+		ConnectionId: "sc_1234567890",
+	})
+	require.NoError(err)
+
+	clientConn, workerConn := testConns(t)
+	dialer, err := proxy.NewProxyDialer(ctx, func(...proxy.Option) (net.Conn, error) {
+		return net.Dial("tcp", endpoint)
+	})
+	require.NoError(err)
+	pc := testProtocolContext(t, &pbs.SshProtocolContext{
+		Credentials: []*pbs.Credential{testUsernamePassword("user", "password")},
+		HostKeys:    []string{hostKey},
+	})
+	fn, err := handleProxy(recording.NewContext(ctx, rec), nil, workerConn, dialer, "sc_1234567890", pc)
+	require.NoError(err)
+	done := make(chan struct{})
+	go func() {
+		fn(ctx)
+		close(done)
+	}()
+
+	c, chans, reqs, err := ssh.NewClientConn(clientConn, clientConn.RemoteAddr().String(), &ssh.ClientConfig{
+		User:            "someone-else",
+		HostKeyCallback: ssh.InsecureIgnoreHostKey(),
+	})
+	require.NoError(err)
+	client := ssh.NewClient(c, chans, reqs)
+	sess, err := client.NewSession()
+	require.NoError(err)
+	out, err := sess.Output("whoami")
+	require.NoError(err)
+	assert.Equal("user ran whoami", string(out))
+	require.NoError(client.Close())
+	<-done
+	require.NoError(rec.Close())
+
+	r, err := storage.Open(ctx, "sr_1234567890")
+	require.NoError(err)
+	defer r.Close()
+	rr, err := recording.NewReader(ctx, r)
+	require.NoError(err)
+	assert.Equal(proxy.SshHandlerName, rr.Header().Protocol)
+
+	var opened, closed bool
+	var requests []string
+	var data []byte
+	for {
+		e, err := rr.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(err)
+		switch e.Type {
+		case recording.ChannelOpenEvent:
+			assert.Equal("session", e.Name)
+			assert.Equal(uint32(1), e.Channel)
+			opened = true
+		case recording.ChannelCloseEvent:
+			assert.Equal(uint32(1), e.Channel)
+			closed = true
+		case recording.RequestEvent:
+			if e.Channel == 1 {
+				requests = append(requests, string(e.Direction)+":"+e.Name)
+			}
+		case recording.DataEvent:
+			assert.Equal(recording.Outbound, e.Direction)
+			data = append(data, e.Data...)
+		}
+	}
+	assert.True(opened)
+	assert.True(closed)
+	assert.Equal([]string{"i:exec", "o:exit-status"}, requests)
+	assert.Equal("user ran whoami", string(data))
+}
+
+func testProtocolContext(t *testing.T, pc *pbs.SshProtocolContext) *anypb.Any {
+	t.Helper()
+	a, err := anypb.New(pc)
+	require.NoError(t, err)
+	return a
+}
+
+func testUsernamePassword(username, password string) *pbs.Credential {
+	return &pbs.Credential{
+		Credential: &pbs.Credential_UsernamePassword{
+			UsernamePassword: &pbs.UsernamePassword{Username: username, Password: password},
+		},
+	}
+}
+
+// testKey returns a signer for a new ed25519 key and the PEM encoded private
+// key.
+func testKey(t *testing.T) (ssh.Signer, string) {
+	t.Helper()
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	signer, err := ssh.NewSignerFromKey(key)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	return signer, string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+}
+
+// testHostKey returns the public key of a new ed25519 key in the
+// authorized_keys format.
+func testHostKey(t *testing.T) string {
+	t.Helper()
+	signer, _ := testKey(t)
+	return strings.TrimSpace(string(ssh.MarshalAuthorizedKey(signer.PublicKey())))
+}
+
+// testConns returns both ends of a tcp connection, simulating the client's
+// connection and the connection passed to the handler by the worker.
+func testConns(t *testing.T) (clientConn, workerConn net.Conn) {
+	t.Helper()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer l.Close()
+	clientConn, err = net.Dial("tcp", l.Addr().String())
+	require.NoError(t, err)
+	workerConn, err = l.Accept()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		clientConn.Close()
+		workerConn.Close()
+	})
+	return clientConn, workerConn
+}
+
+// testSshServer starts an ssh server which handles exec requests on session
+// channels. The command "exit" exits with status 3, every other command
+// responds with the authenticated user and the command. It returns the
+// address of the server and its host key in the authorized_keys format.
+func testSshServer(t *testing.T, ctx context.Context, cfg *ssh.ServerConfig) (string, string) {
+	t.Helper()
+	hostKey, _ := testKey(t)
+	cfg.AddHostKey(hostKey)
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go func() {
+		<-ctx.Done()
+		l.Close()
+	}()
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				sc, chans, reqs, err := ssh.NewServerConn(conn, cfg)
+				if err != nil {
+					conn.Close()
+					return
+				}
+				defer sc.Close()
+				go ssh.DiscardRequests(reqs)
+				for newCh := range chans {
+					if newCh.ChannelType() != "session" {
+						_ = newCh.Reject(ssh.UnknownChannelType, "unsupported channel type")
+						continue
+					}
+					ch, chReqs, err := newCh.Accept()
+					if err != nil {
+						continue
+					}
+					go func() {
+						defer ch.Close()
+						for req := range chReqs {
+							if req.Type != "exec" || len(req.Payload) < 4 {
+								_ = req.Reply(false, nil)
+								continue
+							}
+							_ = req.Reply(true, nil)
+							cmd := string(req.Payload[4:])
+							status := make([]byte, 4)
+							if cmd == "exit" {
+								binary.BigEndian.PutUint32(status, 3)
+							} else {
+								_, _ = fmt.Fprintf(ch, "%s ran %s", sc.User(), cmd)
+							}
+							_ = ch.CloseWrite()
+							_, _ = ch.SendRequest("exit-status", false, status)
+							return
+						}
+					}()
+				}
+			}()
+		}
+	}()
+	return l.Addr().String(), strings.TrimSpace(string(ssh.MarshalAuthorizedKey(hostKey.PublicKey())))
+}
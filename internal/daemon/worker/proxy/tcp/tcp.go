@@ -11,6 +11,7 @@ import (
 
 	"github.com/hashicorp/boundary/internal/daemon/worker/proxy"
 	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/recording"
 	"google.golang.org/protobuf/types/known/anypb"
 )
 
@@ -26,7 +27,9 @@ func init() {
 //
 // handleProxy returns a ProxyConnFn which starts the copy between the
 // connections and blocks until an error (EOF on happy path) is received on
-// either connection.
+// either connection. When ctx carries a recording.Recorder the data copied in
+// each direction is recorded on channel 0 before it is forwarded, and the
+// connection is closed if it cannot be recorded.
 func handleProxy(ctx context.Context, _ proxy.DecryptFn, conn net.Conn, out *proxy.ProxyDialer, connId string, _ *anypb.Any) (proxy.ProxyConnFn, error) {
 	const op = "tcp.HandleProxy"
 	switch {
@@ -42,18 +45,24 @@ func handleProxy(ctx context.Context, _ proxy.DecryptFn, conn net.Conn, out *pro
 		return nil, err
 	}
 
+	var fromRemote, fromClient io.Reader = remoteConn, conn
+	if rec := recording.FromContext(ctx); rec != nil {
+		fromRemote = io.TeeReader(remoteConn, rec.DataWriter(0, recording.Outbound, ""))
+		fromClient = io.TeeReader(conn, rec.DataWriter(0, recording.Inbound, ""))
+	}
+
 	return func(ctx context.Context) {
 		connWg := new(sync.WaitGroup)
 		connWg.Add(2)
 		go func() {
 			defer connWg.Done()
-			_, _ = io.Copy(conn, remoteConn)
+			_, _ = io.Copy(conn, fromRemote)
 			_ = conn.Close()
 			_ = remoteConn.Close()
 		}()
 		go func() {
 			defer connWg.Done()
-			_, _ = io.Copy(remoteConn, conn)
+			_, _ = io.Copy(remoteConn, fromClient)
 			_ = remoteConn.Close()
 			_ = conn.Close()
 		}()
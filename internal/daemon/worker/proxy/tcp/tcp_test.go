@@ -8,6 +8,7 @@ import (
 	"crypto/ed25519"
 	"crypto/rand"
 	"crypto/x509"
+	"io"
 	"math/big"
 	"net"
 	"sync"
@@ -17,6 +18,7 @@ import (
 	"github.com/hashicorp/boundary/internal/daemon/worker/proxy"
 	"github.com/hashicorp/boundary/internal/daemon/worker/session"
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/servers/services"
+	"github.com/hashicorp/boundary/internal/recording"
 	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/targets"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -203,6 +205,87 @@ func TestHandleTcpProxyV1(t *testing.T) {
 	cancelCtx()
 }
 
+func TestHandleProxy_Recording(t *testing.T) {
+	ctx := context.Background()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		l.Close()
+	})
+	go func() {
+		// Echo everything received back with a prefix.
+		c, err := l.Accept()
+		if err != nil {
+			return
+		}
+		defer c.Close()
+		buf := make([]byte, 32)
+		n, _ := c.Read(buf)
+		_, _ = c.Write(append([]byte("echo:"), buf[:n]...))
+	}()
+	dialer, err := proxy.NewProxyDialer(ctx, func(...proxy.Option) (net.Conn, error) {
+		return net.Dial("tcp", l.Addr().String())
+	})
+	require.NoError(t, err)
+
+	storage, err := recording.NewLocalStorage(ctx, t.TempDir())
+	require.NoError(t, err)
+	w, err := storage.Create(ctx, "sr_1234567890")
+	require.NoError(t, err)
+	rec, err := recording.NewRecorder(ctx, w, recording.Header{
+		Protocol:     proxy.TcpHandlerName,
+		SessionId:    "s_1234567890",
+		ConnectionId: "sc_1234567890",
+	})
+	require.NoError(t, err)
+
+	client, conn := net.Pipe()
+	fn, err := handleProxy(recording.NewContext(ctx, rec), nil, conn, dialer, "sc_1234567890", nil)
+	require.NoError(t, err)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		fn(ctx)
+	}()
+
+	_, err = client.Write([]byte("hello"))
+	require.NoError(t, err)
+	got := make([]byte, 32)
+	n, err := client.Read(got)
+	require.NoError(t, err)
+	assert.Equal(t, "echo:hello", string(got[:n]))
+	client.Close()
+	<-done
+	require.NoError(t, rec.Close())
+
+	r, err := storage.Open(ctx, "sr_1234567890")
+	require.NoError(t, err)
+	defer r.Close()
+	rr, err := recording.NewReader(ctx, r)
+	require.NoError(t, err)
+	assert.Equal(t, proxy.TcpHandlerName, rr.Header().Protocol)
+	assert.Equal(t, "sc_1234567890", rr.Header().ConnectionId)
+
+	var in, out []byte
+	for {
+		e, err := rr.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		assert.Equal(t, recording.DataEvent, e.Type)
+		assert.Zero(t, e.Channel)
+		switch e.Direction {
+		case recording.Inbound:
+			in = append(in, e.Data...)
+		case recording.Outbound:
+			out = append(out, e.Data...)
+		}
+	}
+	assert.Equal(t, "hello", string(in))
+	assert.Equal(t, "echo:hello", string(out))
+}
+
 func createTestCert(t *testing.T) ([]byte, ed25519.PublicKey, ed25519.PrivateKey) {
 	pub, priv, err := ed25519.GenerateKey(rand.Reader)
 	require.NoError(t, err)
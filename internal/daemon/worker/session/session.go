@@ -48,11 +48,15 @@ type ConnectionCloseData struct {
 	SessionId string
 	BytesUp   int64
 	BytesDown int64
+	// Reason is the reason the connection was closed. The reason is unknown
+	// when it is not set.
+	Reason session.ClosedReason
 }
 
 // Session is the local representation of a session.  After initial loading
 // the only values that will change will be the status (readable from
-// GetStatus()) and the Connections (GetLocalConnections()).
+// GetStatus()), the Connections (GetLocalConnections()) and, when the session
+// is extended and looked up again, the expiration (GetExpiration()).
 type Session interface {
 	// ApplyLocalConnectionStatus set's a connection's status to the one provided.
 	// If there is no connection with the provided id, an error is returned.
@@ -71,9 +75,14 @@ type Session interface {
 	GetTofuToken() string
 	GetConnectionLimit() int32
 	GetEndpoint() string
+	GetTargetId() string
+	GetUserId() string
 	GetHostKeys() ([]crypto.Signer, error)
 	GetCredentials() []*pbs.Credential
 	GetExpiration() time.Time
+	// GetIdleTimeout returns the idle timeout of the connections of the
+	// session, or 0 when they have no idle timeout.
+	GetIdleTimeout() time.Duration
 	GetCertificate() *x509.Certificate
 	GetPrivateKey() []byte
 	GetId() string
@@ -232,6 +241,18 @@ func (s *sess) GetEndpoint() string {
 	return s.resp.GetEndpoint()
 }
 
+func (s *sess) GetTargetId() string {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.resp.GetTargetId()
+}
+
+func (s *sess) GetUserId() string {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.resp.GetUserId()
+}
+
 func (s *sess) GetHostKeys() ([]crypto.Signer, error) {
 	s.lock.RLock()
 	defer s.lock.RUnlock()
@@ -272,6 +293,12 @@ func (s *sess) GetExpiration() time.Time {
 	return s.resp.GetExpiration().AsTime()
 }
 
+func (s *sess) GetIdleTimeout() time.Duration {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return time.Duration(s.resp.GetIdleTimeout()) * time.Second
+}
+
 func (s *sess) GetCertificate() *x509.Certificate {
 	s.lock.RLock()
 	defer s.lock.RUnlock()
@@ -541,9 +568,13 @@ func closeConnections(ctx context.Context, sessClient pbs.SessionServiceClient,
 func makeCloseConnectionRequest(closeInfo map[string]*ConnectionCloseData) *pbs.CloseConnectionRequest {
 	closeData := make([]*pbs.CloseConnectionRequestData, 0, len(closeInfo))
 	for connId, data := range closeInfo {
+		reason := data.Reason
+		if reason == "" {
+			reason = session.UnknownReason
+		}
 		closeData = append(closeData, &pbs.CloseConnectionRequestData{
 			ConnectionId: connId,
-			Reason:       session.UnknownReason.String(),
+			Reason:       reason.String(),
 			BytesUp:      data.BytesUp,
 			BytesDown:    data.BytesDown,
 		})
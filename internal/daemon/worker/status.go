@@ -12,6 +12,7 @@ import (
 
 	"github.com/hashicorp/boundary/internal/daemon/cluster"
 	"github.com/hashicorp/boundary/internal/daemon/worker/common"
+	proxyHandlers "github.com/hashicorp/boundary/internal/daemon/worker/proxy"
 	"github.com/hashicorp/boundary/internal/daemon/worker/session"
 	pb "github.com/hashicorp/boundary/internal/gen/controller/servers"
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/servers/services"
@@ -183,6 +184,8 @@ func (w *Worker) sendWorkerStatus(cancelCtx context.Context, sessionManager sess
 			KeyId:            keyId,
 			ReleaseVersion:   versionInfo.FullVersionNumber(false),
 			OperationalState: w.operationalState.Load().(server.OperationalState).String(),
+			ProxyProtocols:   proxyHandlers.RegisteredProtocols(),
+			RecordingStorage: w.recordingStorage != nil,
 		},
 		ConnectedWorkerKeyIdentifiers: connectedWorkerKeyIds,
 		UpdateTags:                    w.updateTags.Load(),
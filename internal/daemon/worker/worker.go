@@ -28,6 +28,7 @@ import (
 	pb "github.com/hashicorp/boundary/internal/gen/controller/servers"
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/servers/services"
 	"github.com/hashicorp/boundary/internal/observability/event"
+	"github.com/hashicorp/boundary/internal/recording"
 	"github.com/hashicorp/boundary/internal/server"
 	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/go-secure-stdlib/base62"
@@ -146,6 +147,10 @@ type Worker struct {
 	statusLock sync.Mutex
 
 	pkiConnManager *cluster.DownstreamManager
+
+	// recordingStorage is where the recordings of recorded connections are
+	// written. It is nil if the worker has no recording storage configured.
+	recordingStorage recording.Storage
 }
 
 func New(conf *Config) (*Worker, error) {
@@ -243,6 +248,14 @@ func New(conf *Config) (*Worker, error) {
 		return nil, fmt.Errorf("exactly one proxy listener is required")
 	}
 
+	if path := conf.RawConfig.Worker.RecordingStoragePath; path != "" {
+		storage, err := recording.NewLocalStorage(context.Background(), path)
+		if err != nil {
+			return nil, fmt.Errorf("error creating recording storage: %w", err)
+		}
+		w.recordingStorage = storage
+	}
+
 	return w, nil
 }
 
@@ -119,7 +119,10 @@ func getDbwOptions(ctx context.Context, rw *Db, i any, opType OpType, opt ...Opt
 	if opts.withWhereClause != "" {
 		dbwOpts = append(dbwOpts, dbw.WithWhere(opts.withWhereClause, opts.withWhereClauseArgs...))
 	}
-	if opts.withOrder != "" {
+	switch {
+	case opts.withPage != nil:
+		dbwOpts = append(dbwOpts, dbw.WithOrder(opts.withPage.Order()))
+	case opts.withOrder != "":
 		dbwOpts = append(dbwOpts, dbw.WithOrder(opts.withOrder))
 	}
 	if len(opts.withPrngValues) > 0 {
@@ -167,6 +170,8 @@ type Options struct {
 	withWhereClauseArgs []any
 	withOrder           string
 
+	withPage *Page
+
 	// withPrngValues is used to switch the ID generation to a pseudo-random mode
 	withPrngValues []string
 
@@ -299,6 +304,15 @@ func WithOrder(withOrder string) Option {
 	}
 }
 
+// WithPage provides an option to search for one page of resources using keyset
+// pagination. The page's order replaces any order provided with WithOrder. Use
+// WithLimit to set the size of the page.
+func WithPage(p *Page) Option {
+	return func(o *Options) {
+		o.withPage = p
+	}
+}
+
 // WithPrngValues provides an option to provide values to seed an PRNG when generating IDs
 func WithPrngValues(withPrngValues []string) Option {
 	return func(o *Options) {
@@ -174,6 +174,16 @@ func Test_getOpts(t *testing.T) {
 		testOpts.withOrder = "version desc"
 		assert.Equal(opts, testOpts)
 	})
+	t.Run("WithPage", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := GetOpts()
+		testOpts := getDefaultOptions()
+		assert.Equal(opts, testOpts)
+		p := &Page{LastItemId: "ttcp_1234567890"}
+		opts = GetOpts(WithPage(p))
+		testOpts.withPage = p
+		assert.Equal(opts, testOpts)
+	})
 	t.Run("WithGormFormatter", func(t *testing.T) {
 		assert := assert.New(t)
 		// test default of false
@@ -0,0 +1,76 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package db
+
+import (
+	"database/sql"
+	"strings"
+	"time"
+)
+
+// Page describes one page of a list of resources retrieved with keyset
+// pagination. Resources are ordered by their create time and public id, or by
+// their update time and public id when UpdatedAfter is set. The resources must
+// have public_id, create_time and update_time columns.
+//
+// The zero value describes the first page of all resources.
+type Page struct {
+	// UpdatedAfter, if not zero, limits the page to the resources updated
+	// after it and orders the resources by update time.
+	UpdatedAfter time.Time
+
+	// LastItemId is the public id of the last resource of the previous page.
+	// If empty the page starts with the first resource.
+	LastItemId string
+
+	// LastItemTime is the create time of the last resource of the previous
+	// page, or its update time if UpdatedAfter is set.
+	LastItemTime time.Time
+}
+
+// Order returns the order by clause which orders the resources of the page.
+func (p *Page) Order() string {
+	if !p.UpdatedAfter.IsZero() {
+		return "update_time asc, public_id asc"
+	}
+	return "create_time asc, public_id asc"
+}
+
+// Where returns the where clause and args restricting where to the resources
+// of the page. Named args are used for the page's conditions if args contains
+// any sql.NamedArg, positional args are used otherwise.
+func (p *Page) Where(where string, args []any) (string, []any) {
+	named := false
+	for _, a := range args {
+		if _, ok := a.(sql.NamedArg); ok {
+			named = true
+			break
+		}
+	}
+	var clauses []string
+	if where != "" {
+		clauses = append(clauses, "("+where+")")
+	}
+	column := "create_time"
+	if !p.UpdatedAfter.IsZero() {
+		column = "update_time"
+		if named {
+			clauses = append(clauses, "update_time > @page_updated_after")
+			args = append(args, sql.Named("page_updated_after", p.UpdatedAfter))
+		} else {
+			clauses = append(clauses, "update_time > ?")
+			args = append(args, p.UpdatedAfter)
+		}
+	}
+	if p.LastItemId != "" {
+		if named {
+			clauses = append(clauses, "("+column+", public_id) > (@page_last_item_time, @page_last_item_id)")
+			args = append(args, sql.Named("page_last_item_time", p.LastItemTime), sql.Named("page_last_item_id", p.LastItemId))
+		} else {
+			clauses = append(clauses, "("+column+", public_id) > (?, ?)")
+			args = append(args, p.LastItemTime, p.LastItemId)
+		}
+	}
+	return strings.Join(clauses, " and "), args
+}
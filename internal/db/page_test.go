@@ -0,0 +1,91 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package db
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPage(t *testing.T) {
+	t.Parallel()
+	updatedAfter := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	lastItemTime := time.Date(2023, 2, 3, 4, 5, 6, 0, time.UTC)
+	tests := []struct {
+		name      string
+		page      *Page
+		where     string
+		args      []any
+		wantWhere string
+		wantArgs  []any
+		wantOrder string
+	}{
+		{
+			name:      "first-page",
+			page:      &Page{},
+			where:     "scope_id = ?",
+			args:      []any{"global"},
+			wantWhere: "(scope_id = ?)",
+			wantArgs:  []any{"global"},
+			wantOrder: "create_time asc, public_id asc",
+		},
+		{
+			name:      "first-page-no-where",
+			page:      &Page{},
+			wantWhere: "",
+			wantOrder: "create_time asc, public_id asc",
+		},
+		{
+			name:      "next-page",
+			page:      &Page{LastItemId: "ttcp_1234567890", LastItemTime: lastItemTime},
+			where:     "scope_id = ?",
+			args:      []any{"global"},
+			wantWhere: "(scope_id = ?) and (create_time, public_id) > (?, ?)",
+			wantArgs:  []any{"global", lastItemTime, "ttcp_1234567890"},
+			wantOrder: "create_time asc, public_id asc",
+		},
+		{
+			name:      "first-refresh-page",
+			page:      &Page{UpdatedAfter: updatedAfter},
+			where:     "scope_id = ?",
+			args:      []any{"global"},
+			wantWhere: "(scope_id = ?) and update_time > ?",
+			wantArgs:  []any{"global", updatedAfter},
+			wantOrder: "update_time asc, public_id asc",
+		},
+		{
+			name:      "next-refresh-page",
+			page:      &Page{UpdatedAfter: updatedAfter, LastItemId: "ttcp_1234567890", LastItemTime: lastItemTime},
+			wantWhere: "update_time > ? and (update_time, public_id) > (?, ?)",
+			wantArgs:  []any{updatedAfter, lastItemTime, "ttcp_1234567890"},
+			wantOrder: "update_time asc, public_id asc",
+		},
+		{
+			name:      "named-args",
+			page:      &Page{UpdatedAfter: updatedAfter, LastItemId: "ttcp_1234567890", LastItemTime: lastItemTime},
+			where:     "project_id = @project_id",
+			args:      []any{sql.Named("project_id", "p_1234567890")},
+			wantWhere: "(project_id = @project_id) and update_time > @page_updated_after and (update_time, public_id) > (@page_last_item_time, @page_last_item_id)",
+			wantArgs: []any{
+				sql.Named("project_id", "p_1234567890"),
+				sql.Named("page_updated_after", updatedAfter),
+				sql.Named("page_last_item_time", lastItemTime),
+				sql.Named("page_last_item_id", "ttcp_1234567890"),
+			},
+			wantOrder: "update_time asc, public_id asc",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			where, args := tt.page.Where(tt.where, tt.args)
+			assert.Equal(tt.wantWhere, where)
+			assert.Equal(tt.wantArgs, args)
+			assert.Equal(tt.wantOrder, tt.page.Order())
+		})
+	}
+}
@@ -509,7 +509,7 @@ func (rw *Db) LookupWhere(ctx context.Context, resource any, where string, args
 //
 // Supports the WithLimit option.  If WithLimit < 0, then unlimited results are returned.
 // If WithLimit == 0, then default limits are used for results.
-// Supports the WithOrder and WithDebug options.
+// Supports the WithOrder, WithPage and WithDebug options.
 func (rw *Db) SearchWhere(ctx context.Context, resources any, where string, args []any, opt ...Option) error {
 	const op = "db.SearchWhere"
 	if rw.underlying == nil {
@@ -519,6 +519,9 @@ func (rw *Db) SearchWhere(ctx context.Context, resources any, where string, args
 	if err != nil {
 		return errors.Wrap(ctx, err, op)
 	}
+	if p := GetOpts(opt...).withPage; p != nil {
+		where, args = p.Where(where, args)
+	}
 	if err := dbw.New(rw.underlying.wrapped.Load()).SearchWhere(ctx, resources, where, args, dbwOpts...); err != nil {
 		return wrapError(ctx, err, op)
 	}
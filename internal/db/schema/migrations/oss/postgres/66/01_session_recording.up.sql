@@ -0,0 +1,117 @@
+-- Copyright (c) HashiCorp, Inc.
+-- SPDX-License-Identifier: MPL-2.0
+
+begin;
+
+  alter table target_tcp
+    add column enable_session_recording boolean not null default false;
+
+  alter table target_ssh
+    add column enable_session_recording boolean not null default false;
+
+  -- Replaces target_all_subtypes defined in 65/01_target_ssh.up.sql
+  -- Replaced in 67/01_target_http.up.sql
+  create or replace view target_all_subtypes as
+  select public_id,
+         project_id,
+         name,
+         description,
+         default_port,
+         session_max_seconds,
+         session_connection_limit,
+         version,
+         create_time,
+         update_time,
+         worker_filter,
+         egress_worker_filter,
+         ingress_worker_filter,
+         'tcp' as type,
+         enable_session_recording
+    from target_tcp
+   union
+  select public_id,
+         project_id,
+         name,
+         description,
+         default_port,
+         session_max_seconds,
+         session_connection_limit,
+         version,
+         create_time,
+         update_time,
+         worker_filter,
+         egress_worker_filter,
+         ingress_worker_filter,
+         'ssh' as type,
+         enable_session_recording
+    from target_ssh;
+
+  -- The value of enable_session_recording of the target when the session was
+  -- authorized.
+  alter table session
+    add column enable_session_recording boolean not null default false;
+
+  -- Replaces trigger from 59/01_target_ingress_egress_worker_filters.up.sql
+  -- Replaced in 71/01_session_idle_timeout.up.sql
+  drop trigger immutable_columns on session;
+  create trigger immutable_columns before update on session
+    for each row execute procedure immutable_columns('public_id', 'certificate', 'expiration_time', 'connection_limit',
+      'create_time', 'endpoint', 'worker_filter', 'egress_worker_filter', 'ingress_worker_filter', 'enable_session_recording');
+
+  -- session_recording does not reference the session, connection, target or
+  -- user since recordings must be retained after those have been deleted.
+  create table session_recording (
+    public_id wt_public_id primary key,
+    project_id wt_scope_id not null
+      constraint iam_scope_project_fkey
+        references iam_scope_project (scope_id)
+        on delete cascade
+        on update cascade,
+    session_id wt_public_id not null,
+    connection_id wt_public_id not null
+      constraint session_recording_connection_id_uq
+        unique,
+    target_id wt_public_id not null,
+    user_id wt_user_id,
+    end_time timestamp with time zone,
+    create_time wt_timestamp,
+    update_time wt_timestamp,
+    constraint end_time_not_before_create_time
+      check (end_time >= create_time)
+  );
+  comment on table session_recording is
+    'session_recording is a table where each row is the recording of a single session connection. '
+    'The recording itself is held in the recording storage of the workers and controllers under the public id.';
+
+  create index session_recording_project_id_ix
+    on session_recording (project_id);
+
+  create trigger immutable_columns before update on session_recording
+    for each row execute procedure immutable_columns('public_id', 'project_id', 'session_id', 'connection_id',
+      'target_id', 'user_id', 'create_time');
+
+  create trigger update_time_column before update on session_recording
+    for each row execute procedure update_time_column();
+
+  create trigger default_create_time_column before insert on session_recording
+    for each row execute procedure default_create_time();
+
+  -- end_session_recording sets the end time of the recording of a connection
+  -- when the connection is closed.
+  create function end_session_recording() returns trigger
+  as $$
+  begin
+    if new.closed_reason is not null then
+      update session_recording
+         set end_time = now()
+       where connection_id = new.public_id
+         and end_time is null;
+    end if;
+    return new;
+  end;
+  $$ language plpgsql;
+
+  create trigger end_session_recording after update of closed_reason on session_connection
+    for each row execute procedure end_session_recording();
+
+commit;
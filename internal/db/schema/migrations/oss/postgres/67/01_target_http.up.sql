@@ -0,0 +1,140 @@
+-- Copyright (c) HashiCorp, Inc.
+-- SPDX-License-Identifier: MPL-2.0
+
+begin;
+
+  create table target_http (
+    public_id wt_public_id primary key
+      constraint target_fkey
+        references target (public_id)
+        on delete cascade
+        on update cascade,
+    project_id wt_scope_id not null,
+    name text not null, -- name is not optional for a target subtype
+    description text,
+    default_port int not null
+      constraint default_port_must_be_greater_than_0
+        check(default_port > 0),
+    -- max duration of the session in seconds.
+    -- default is 8 hours
+    session_max_seconds int not null default 28800
+      constraint session_max_seconds_must_be_greater_than_0
+        check(session_max_seconds > 0),
+    -- limit on number of session connections allowed. -1 equals no limit
+    session_connection_limit int not null default -1
+      constraint session_connection_limit_must_be_greater_than_0_or_negative_1
+        check(session_connection_limit > 0 or session_connection_limit = -1),
+    worker_filter wt_bexprfilter,
+    egress_worker_filter wt_bexprfilter,
+    ingress_worker_filter wt_bexprfilter,
+    enable_session_recording boolean not null default false,
+    -- use_tls is true when the worker connects to the endpoint with https.
+    use_tls boolean not null default false,
+    -- allowed_paths is a newline separated list of the url path prefixes
+    -- clients may request. All paths are allowed when it is null.
+    allowed_paths text
+      constraint allowed_paths_must_not_be_empty
+        check(length(trim(allowed_paths)) > 0),
+    create_time wt_timestamp,
+    update_time wt_timestamp,
+    version wt_version,
+    constraint target_http_project_id_name_uq
+      unique(project_id, name) -- name must be unique within a project
+  );
+  comment on table target_http is
+    'target_http is a table where each row is a resource that represents an http target. '
+    'Workers proxy the http requests of connections to an http target, inject the '
+    'credentials of the session into them and only allow requests for the allowed paths.';
+
+  create trigger insert_target_subtype before insert on target_http
+    for each row execute procedure insert_target_subtype();
+
+  create trigger delete_target_subtype after delete on target_http
+    for each row execute procedure delete_target_subtype();
+
+  create trigger immutable_columns before update on target_http
+    for each row execute procedure immutable_columns('public_id', 'project_id', 'create_time');
+
+  create trigger update_version_column after update on target_http
+    for each row execute procedure update_version_column();
+
+  create trigger update_time_column before update on target_http
+    for each row execute procedure update_time_column();
+
+  create trigger default_create_time_column before insert on target_http
+    for each row execute procedure default_create_time();
+
+  -- validate_filter_values_on_update and validate_filter_values_on_insert are
+  -- defined in 59/01_target_ingress_egress_worker_filters.up.sql
+  create trigger update_http_target_filter_validate before update on target_http
+    for each row execute procedure validate_filter_values_on_update();
+
+  create trigger insert_http_target_filter_validate before insert on target_http
+    for each row execute procedure validate_filter_values_on_insert();
+
+  insert into oplog_ticket
+    (name, version)
+  values
+    ('target_http', 1);
+
+  -- Replaces target_all_subtypes defined in 66/01_session_recording.up.sql
+  -- Replaced in 68/01_target_postgres.up.sql
+  create or replace view target_all_subtypes as
+  select public_id,
+         project_id,
+         name,
+         description,
+         default_port,
+         session_max_seconds,
+         session_connection_limit,
+         version,
+         create_time,
+         update_time,
+         worker_filter,
+         egress_worker_filter,
+         ingress_worker_filter,
+         'tcp' as type,
+         enable_session_recording,
+         false as use_tls,
+         null as allowed_paths
+    from target_tcp
+   union
+  select public_id,
+         project_id,
+         name,
+         description,
+         default_port,
+         session_max_seconds,
+         session_connection_limit,
+         version,
+         create_time,
+         update_time,
+         worker_filter,
+         egress_worker_filter,
+         ingress_worker_filter,
+         'ssh' as type,
+         enable_session_recording,
+         false as use_tls,
+         null as allowed_paths
+    from target_ssh
+   union
+  select public_id,
+         project_id,
+         name,
+         description,
+         default_port,
+         session_max_seconds,
+         session_connection_limit,
+         version,
+         create_time,
+         update_time,
+         worker_filter,
+         egress_worker_filter,
+         ingress_worker_filter,
+         'http' as type,
+         enable_session_recording,
+         use_tls,
+         allowed_paths
+    from target_http;
+
+commit;
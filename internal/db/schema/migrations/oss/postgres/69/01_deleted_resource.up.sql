@@ -0,0 +1,74 @@
+-- Copyright (c) HashiCorp, Inc.
+-- SPDX-License-Identifier: MPL-2.0
+
+begin;
+
+  create table deleted_resource (
+    public_id wt_public_id primary key,
+    table_name text not null
+      constraint table_name_must_not_be_empty
+        check(length(trim(table_name)) > 0),
+    delete_time wt_timestamp
+  );
+  comment on table deleted_resource is
+    'deleted_resource is a table where each row records the deletion of a resource. '
+    'It is used to report the resources deleted since a list token was issued. '
+    'Rows are removed once they are older than the lifetime of a list token.';
+
+  create index deleted_resource_table_name_delete_time_ix
+    on deleted_resource (table_name, delete_time);
+
+  create function insert_deleted_resource() returns trigger
+  as $$
+  begin
+    insert into deleted_resource
+      (public_id,     table_name)
+    values
+      (old.public_id, tg_table_name)
+    on conflict (public_id) do update
+      set delete_time = now();
+    return null;
+  end;
+  $$ language plpgsql;
+  comment on function insert_deleted_resource is
+    'insert_deleted_resource is an after delete trigger function which records the public_id of '
+    'the deleted row in the deleted_resource table.';
+
+  create trigger insert_deleted_resource after delete on target
+    for each row execute function insert_deleted_resource();
+  create trigger insert_deleted_resource after delete on session
+    for each row execute function insert_deleted_resource();
+  create trigger insert_deleted_resource after delete on session_recording
+    for each row execute function insert_deleted_resource();
+  create trigger insert_deleted_resource after delete on auth_method
+    for each row execute function insert_deleted_resource();
+  create trigger insert_deleted_resource after delete on auth_account
+    for each row execute function insert_deleted_resource();
+  create trigger insert_deleted_resource after delete on auth_managed_group
+    for each row execute function insert_deleted_resource();
+  create trigger insert_deleted_resource after delete on auth_token
+    for each row execute function insert_deleted_resource();
+  create trigger insert_deleted_resource after delete on credential_store
+    for each row execute function insert_deleted_resource();
+  create trigger insert_deleted_resource after delete on credential_library
+    for each row execute function insert_deleted_resource();
+  create trigger insert_deleted_resource after delete on credential_static
+    for each row execute function insert_deleted_resource();
+  create trigger insert_deleted_resource after delete on host_catalog
+    for each row execute function insert_deleted_resource();
+  create trigger insert_deleted_resource after delete on host_set
+    for each row execute function insert_deleted_resource();
+  create trigger insert_deleted_resource after delete on host
+    for each row execute function insert_deleted_resource();
+  create trigger insert_deleted_resource after delete on iam_scope
+    for each row execute function insert_deleted_resource();
+  create trigger insert_deleted_resource after delete on iam_user
+    for each row execute function insert_deleted_resource();
+  create trigger insert_deleted_resource after delete on iam_group
+    for each row execute function insert_deleted_resource();
+  create trigger insert_deleted_resource after delete on iam_role
+    for each row execute function insert_deleted_resource();
+  create trigger insert_deleted_resource after delete on server_worker
+    for each row execute function insert_deleted_resource();
+
+commit;
@@ -0,0 +1,14 @@
+-- Copyright (c) HashiCorp, Inc.
+-- SPDX-License-Identifier: MPL-2.0
+
+begin;
+
+  -- The session state changes are watched by listing the states of sessions
+  -- and connections which started after a given time.
+  create index session_state_start_time_ix
+    on session_state (start_time);
+
+  create index session_connection_state_start_time_ix
+    on session_connection_state (start_time);
+
+commit;
@@ -0,0 +1,165 @@
+-- Copyright (c) HashiCorp, Inc.
+-- SPDX-License-Identifier: MPL-2.0
+
+begin;
+
+  -- idle timeout of the connections of a session in seconds. A connection
+  -- which sends and receives no data for this long is closed by the worker.
+  -- 0 equals no idle timeout.
+  alter table target_tcp
+    add column session_idle_timeout int not null default 0
+      constraint session_idle_timeout_must_not_be_negative
+        check(session_idle_timeout >= 0);
+
+  alter table target_ssh
+    add column session_idle_timeout int not null default 0
+      constraint session_idle_timeout_must_not_be_negative
+        check(session_idle_timeout >= 0);
+
+  alter table target_http
+    add column session_idle_timeout int not null default 0
+      constraint session_idle_timeout_must_not_be_negative
+        check(session_idle_timeout >= 0);
+
+  alter table target_postgres
+    add column session_idle_timeout int not null default 0
+      constraint session_idle_timeout_must_not_be_negative
+        check(session_idle_timeout >= 0);
+
+  -- Replaces target_all_subtypes defined in 68/01_target_postgres.up.sql
+  create or replace view target_all_subtypes as
+  select public_id,
+         project_id,
+         name,
+         description,
+         default_port,
+         session_max_seconds,
+         session_connection_limit,
+         version,
+         create_time,
+         update_time,
+         worker_filter,
+         egress_worker_filter,
+         ingress_worker_filter,
+         'tcp' as type,
+         enable_session_recording,
+         false as use_tls,
+         null as allowed_paths,
+         session_idle_timeout
+    from target_tcp
+   union
+  select public_id,
+         project_id,
+         name,
+         description,
+         default_port,
+         session_max_seconds,
+         session_connection_limit,
+         version,
+         create_time,
+         update_time,
+         worker_filter,
+         egress_worker_filter,
+         ingress_worker_filter,
+         'ssh' as type,
+         enable_session_recording,
+         false as use_tls,
+         null as allowed_paths,
+         session_idle_timeout
+    from target_ssh
+   union
+  select public_id,
+         project_id,
+         name,
+         description,
+         default_port,
+         session_max_seconds,
+         session_connection_limit,
+         version,
+         create_time,
+         update_time,
+         worker_filter,
+         egress_worker_filter,
+         ingress_worker_filter,
+         'http' as type,
+         enable_session_recording,
+         use_tls,
+         allowed_paths,
+         session_idle_timeout
+    from target_http
+   union
+  select public_id,
+         project_id,
+         name,
+         description,
+         default_port,
+         session_max_seconds,
+         session_connection_limit,
+         version,
+         create_time,
+         update_time,
+         worker_filter,
+         egress_worker_filter,
+         ingress_worker_filter,
+         'postgres' as type,
+         enable_session_recording,
+         false as use_tls,
+         null as allowed_paths,
+         session_idle_timeout
+    from target_postgres;
+
+  -- The value of session_idle_timeout of the target when the session was
+  -- authorized.
+  alter table session
+    add column idle_timeout int not null default 0
+      constraint idle_timeout_must_not_be_negative
+        check(idle_timeout >= 0);
+
+  -- Replaces trigger from 66/01_session_recording.up.sql
+  -- The expiration_time of a session is no longer immutable, since a session
+  -- can be extended.
+  drop trigger immutable_columns on session;
+  create trigger immutable_columns before update on session
+    for each row execute procedure immutable_columns('public_id', 'certificate', 'connection_limit',
+      'create_time', 'endpoint', 'worker_filter', 'egress_worker_filter', 'ingress_worker_filter', 'enable_session_recording',
+      'idle_timeout');
+
+  create function extend_session_expiration_time() returns trigger
+  as $$
+  begin
+    if new.expiration_time < old.expiration_time then
+      raise exception 'session expiration_time can only be extended';
+    end if;
+    return new;
+  end;
+  $$ language plpgsql;
+  comment on function extend_session_expiration_time is
+    'extend_session_expiration_time is a before update trigger function for the session table '
+    'which ensures the expiration time of a session is only ever extended.';
+
+  create trigger extend_session_expiration_time before update of expiration_time on session
+    for each row execute procedure extend_session_expiration_time();
+
+  -- Replaces the constraint defined in 0/51_connection.up.sql
+  alter table session_connection_closed_reason_enm
+    drop constraint only_predefined_session_connection_closed_reasons_allowed;
+
+  alter table session_connection_closed_reason_enm
+    add constraint only_predefined_session_connection_closed_reasons_allowed
+      check (
+        name in (
+          'unknown',
+          'timed out',
+          'closed by end-user',
+          'canceled',
+          'network error',
+          'system error',
+          'idle timeout'
+        )
+      );
+
+  insert into session_connection_closed_reason_enm (name)
+  values
+    ('idle timeout');
+
+commit;
@@ -0,0 +1,82 @@
+-- Copyright (c) HashiCorp, Inc.
+-- SPDX-License-Identifier: MPL-2.0
+
+begin;
+
+  -- max_failed_attempts is the number of consecutive failed authentication
+  -- attempts after which an account is locked. 0 disables account lockout.
+  -- lockout_duration is the number of seconds a locked account stays locked.
+  -- 0 means the account stays locked until it is unlocked by an admin.
+  -- source_ip_rate_limit is the number of authentication attempts allowed per
+  -- minute from a single source ip address. 0 disables rate limiting.
+  alter table auth_password_method
+    add column max_failed_attempts int not null default 0
+      constraint max_failed_attempts_must_not_be_negative
+        check(max_failed_attempts >= 0),
+    add column lockout_duration int not null default 0
+      constraint lockout_duration_must_not_be_negative
+        check(lockout_duration >= 0),
+    add column source_ip_rate_limit int not null default 0
+      constraint source_ip_rate_limit_must_not_be_negative
+        check(source_ip_rate_limit >= 0);
+
+  -- Replaces auth_password_method_with_is_primary defined in 2/20_pass.up.sql
+  create or replace view auth_password_method_with_is_primary as
+  select
+    case when s.primary_auth_method_id is not null then
+      true
+    else false end
+    as is_primary_auth_method,
+    am.public_id,
+    am.scope_id,
+    am.password_conf_id,
+    am.name,
+    am.description,
+    am.create_time,
+    am.update_time,
+    am.version,
+    am.min_login_name_length,
+    am.min_password_length,
+    am.max_failed_attempts,
+    am.lockout_duration,
+    am.source_ip_rate_limit
+  from
+    auth_password_method am
+    left outer join iam_scope s on am.public_id = s.primary_auth_method_id;
+  comment on view auth_password_method_with_is_primary is
+    'password auth method with an is_primary_auth_method bool';
+
+  -- auth_password_account_lockout records the consecutive failed
+  -- authentication attempts of a password account. It is kept apart from
+  -- auth_password_account so recording a failed attempt does not change the
+  -- version of the account. A row is deleted when the account successfully
+  -- authenticates or is unlocked.
+  create table auth_password_account_lockout (
+    password_account_id wt_public_id primary key
+      constraint auth_password_account_fkey
+        references auth_password_account (public_id)
+        on delete cascade
+        on update cascade,
+    failed_attempt_count int not null default 0
+      constraint failed_attempt_count_must_not_be_negative
+        check(failed_attempt_count >= 0),
+    -- locked_time is the time the account was locked. The account is locked
+    -- while locked_time is set and the lockout_duration of its auth method
+    -- has not passed since then; a lockout_duration of 0 never passes.
+    locked_time timestamp with time zone,
+    create_time wt_timestamp,
+    update_time wt_timestamp
+  );
+  comment on table auth_password_account_lockout is
+    'auth_password_account_lockout is a table where each row records the consecutive failed authentication attempts of a password account.';
+
+  create trigger update_time_column before update on auth_password_account_lockout
+    for each row execute procedure update_time_column();
+
+  create trigger default_create_time_column before insert on auth_password_account_lockout
+    for each row execute procedure default_create_time();
+
+  create trigger immutable_columns before update on auth_password_account_lockout
+    for each row execute procedure immutable_columns('password_account_id', 'create_time');
+
+commit;
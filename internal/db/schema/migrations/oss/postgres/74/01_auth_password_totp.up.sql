@@ -0,0 +1,99 @@
+-- Copyright (c) HashiCorp, Inc.
+-- SPDX-License-Identifier: MPL-2.0
+
+begin;
+
+  -- mfa_required makes a confirmed totp secret a mandatory second factor for
+  -- the accounts of the auth method.
+  alter table auth_password_method
+    add column mfa_required boolean not null default false;
+
+  -- Replaces auth_password_method_with_is_primary defined in
+  -- 73/01_auth_password_lockout.up.sql
+  create or replace view auth_password_method_with_is_primary as
+  select
+    case when s.primary_auth_method_id is not null then
+      true
+    else false end
+    as is_primary_auth_method,
+    am.public_id,
+    am.scope_id,
+    am.password_conf_id,
+    am.name,
+    am.description,
+    am.create_time,
+    am.update_time,
+    am.version,
+    am.min_login_name_length,
+    am.min_password_length,
+    am.max_failed_attempts,
+    am.lockout_duration,
+    am.source_ip_rate_limit,
+    am.mfa_required
+  from
+    auth_password_method am
+    left outer join iam_scope s on am.public_id = s.primary_auth_method_id;
+  comment on view auth_password_method_with_is_primary is
+    'password auth method with an is_primary_auth_method bool';
+
+  -- auth_password_totp holds the encrypted totp secret enrolled for a password
+  -- account. An account has at most one secret; enrolling again replaces it.
+  create table auth_password_totp (
+    password_account_id wt_public_id primary key
+      constraint auth_password_account_fkey
+        references auth_password_account (public_id)
+        on delete cascade
+        on update cascade,
+    secret bytea not null
+      constraint secret_must_not_be_empty
+        check(length(secret) > 0),
+    key_id kms_private_id not null
+      constraint kms_data_key_version_fkey
+        references kms_data_key_version (private_id)
+        on delete restrict
+        on update cascade,
+    confirmed boolean not null default false,
+    -- last_used_step is the time step of the last accepted code so a code
+    -- cannot be used twice.
+    last_used_step bigint not null default 0
+      constraint last_used_step_must_not_be_negative
+        check(last_used_step >= 0),
+    create_time wt_timestamp,
+    update_time wt_timestamp
+  );
+  comment on table auth_password_totp is
+    'auth_password_totp is a table where each row is the totp secret of a password account.';
+
+  create trigger update_time_column before update on auth_password_totp
+    for each row execute procedure update_time_column();
+
+  create trigger default_create_time_column before insert on auth_password_totp
+    for each row execute procedure default_create_time();
+
+  create trigger immutable_columns before update on auth_password_totp
+    for each row execute procedure immutable_columns('password_account_id', 'create_time');
+
+  -- auth_password_recovery_code holds the hashes of the unused recovery codes
+  -- of a password account. A row is deleted when its code is used.
+  create table auth_password_recovery_code (
+    password_account_id wt_public_id not null
+      constraint auth_password_totp_fkey
+        references auth_password_totp (password_account_id)
+        on delete cascade
+        on update cascade,
+    code_hash bytea not null
+      constraint code_hash_must_not_be_empty
+        check(length(code_hash) > 0),
+    create_time wt_timestamp,
+    primary key(password_account_id, code_hash)
+  );
+  comment on table auth_password_recovery_code is
+    'auth_password_recovery_code is a table where each row is the hash of an unused recovery code of a password account.';
+
+  create trigger default_create_time_column before insert on auth_password_recovery_code
+    for each row execute procedure default_create_time();
+
+  create trigger immutable_columns before update on auth_password_recovery_code
+    for each row execute procedure immutable_columns('password_account_id', 'code_hash', 'create_time');
+
+commit;
@@ -0,0 +1,111 @@
+-- Copyright (c) HashiCorp, Inc.
+-- SPDX-License-Identifier: MPL-2.0
+
+begin;
+
+  -- min_password_classes is the minimum number of character classes
+  -- (lowercase, uppercase, digits and symbols) a password must contain.
+  -- deny_common_passwords rejects passwords found in a list of common
+  -- passwords.
+  -- password_history_count is the number of previous passwords of an account
+  -- which cannot be used again. 0 disables the password history.
+  -- max_password_age is the number of seconds after which a password expires
+  -- and must be changed at the next login. 0 means passwords do not expire.
+  alter table auth_password_method
+    add column min_password_classes int not null default 0
+      constraint min_password_classes_must_be_between_0_and_4
+        check(min_password_classes between 0 and 4),
+    add column deny_common_passwords boolean not null default false,
+    add column password_history_count int not null default 0
+      constraint password_history_count_must_be_between_0_and_24
+        check(password_history_count between 0 and 24),
+    add column max_password_age int not null default 0
+      constraint max_password_age_must_not_be_negative
+        check(max_password_age >= 0);
+
+  -- Replaces auth_password_method_with_is_primary defined in
+  -- 74/01_auth_password_totp.up.sql
+  create or replace view auth_password_method_with_is_primary as
+  select
+    case when s.primary_auth_method_id is not null then
+      true
+    else false end
+    as is_primary_auth_method,
+    am.public_id,
+    am.scope_id,
+    am.password_conf_id,
+    am.name,
+    am.description,
+    am.create_time,
+    am.update_time,
+    am.version,
+    am.min_login_name_length,
+    am.min_password_length,
+    am.max_failed_attempts,
+    am.lockout_duration,
+    am.source_ip_rate_limit,
+    am.mfa_required,
+    am.min_password_classes,
+    am.deny_common_passwords,
+    am.password_history_count,
+    am.max_password_age
+  from
+    auth_password_method am
+    left outer join iam_scope s on am.public_id = s.primary_auth_method_id;
+  comment on view auth_password_method_with_is_primary is
+    'password auth method with an is_primary_auth_method bool';
+
+  -- Replaces auth_password_current_conf defined in 0/14_auth_password_views.up.sql
+  -- The view is dropped since columns are added before the columns of
+  -- auth_password_conf_union.
+  drop view auth_password_current_conf;
+  create view auth_password_current_conf as
+      select pm.min_login_name_length, pm.min_password_length,
+             pm.min_password_classes, pm.deny_common_passwords,
+             pm.password_history_count, pm.max_password_age,
+             c.*
+        from auth_password_method pm
+  inner join auth_password_conf_union c
+          on pm.password_conf_id = c.password_conf_id;
+
+  -- auth_password_argon2_history holds the previous passwords of a password
+  -- account. A row is a copy of the argon2 credential which was replaced when
+  -- the password was changed.
+  create table auth_password_argon2_history (
+    private_id wt_private_id primary key,
+    password_account_id wt_public_id not null
+      constraint auth_password_account_fkey
+        references auth_password_account (public_id)
+        on delete cascade
+        on update cascade,
+    password_conf_id wt_private_id not null
+      constraint auth_password_argon2_conf_fkey
+        references auth_password_argon2_conf (private_id)
+        on delete cascade
+        on update cascade,
+    salt bytea not null
+      constraint salt_must_not_be_empty
+        check(length(salt) > 0),
+    derived_key bytea not null
+      constraint derived_key_must_not_be_empty
+        check(length(derived_key) > 0),
+    key_id kms_private_id not null
+      constraint kms_data_key_version_fkey
+        references kms_data_key_version (private_id)
+        on delete restrict
+        on update cascade,
+    create_time wt_timestamp
+  );
+  comment on table auth_password_argon2_history is
+    'auth_password_argon2_history is a table where each row is a previous password of a password account.';
+
+  create index auth_password_argon2_history_password_account_id_create_time_ix
+    on auth_password_argon2_history (password_account_id, create_time desc);
+
+  create trigger default_create_time_column before insert on auth_password_argon2_history
+    for each row execute procedure default_create_time();
+
+  create trigger immutable_columns before update on auth_password_argon2_history
+    for each row execute procedure immutable_columns('private_id', 'password_account_id', 'password_conf_id', 'derived_key', 'create_time');
+
+commit;
@@ -0,0 +1,91 @@
+-- Copyright (c) HashiCorp, Inc.
+-- SPDX-License-Identifier: MPL-2.0
+
+begin;
+
+  -- Service accounts are non-human principals. They are stored in iam_user so
+  -- they can be role principals and resolve grants like users, and are told
+  -- apart by their 'sa' public id prefix. A service account cannot have auth
+  -- accounts; it authenticates with api keys only.
+  create function auth_account_not_service_account() returns trigger
+  as $$
+  begin
+    if new.iam_user_id like 'sa\_%' then
+      raise exception 'auth account % cannot be associated with service account %', new.public_id, new.iam_user_id;
+    end if;
+    return new;
+  end;
+  $$ language plpgsql;
+  comment on function auth_account_not_service_account is
+    'auth_account_not_service_account is a before insert or update trigger function '
+    'which prevents associating an auth account with a service account.';
+
+  create trigger auth_account_not_service_account before insert or update of iam_user_id on auth_account
+    for each row execute procedure auth_account_not_service_account();
+
+  -- auth_api_key is a long-lived credential of a service account. The key
+  -- belongs to the scope of its service account, which is copied into scope_id
+  -- so the key can be decrypted without a join.
+  create table auth_api_key (
+    public_id wt_public_id primary key,
+    service_account_id wt_public_id not null
+      constraint iam_user_fkey
+        references iam_user (public_id)
+        on delete cascade
+        on update cascade
+      constraint service_account_id_must_have_service_account_prefix
+        check(service_account_id like 'sa\_%'),
+    scope_id wt_scope_id not null
+      constraint iam_scope_fkey
+        references iam_scope (public_id)
+        on delete cascade
+        on update cascade,
+    name wt_name,
+    description wt_description,
+    token bytea not null unique,
+    key_id kms_private_id not null
+      constraint kms_data_key_version_fkey
+        references kms_data_key_version (private_id)
+        on delete restrict
+        on update cascade,
+    -- allowed_cidrs is a newline separated list of the cidr blocks requests
+    -- using the key must come from; null allows any address.
+    allowed_cidrs text
+      constraint allowed_cidrs_must_not_be_empty
+        check(length(trim(allowed_cidrs)) > 0),
+    -- expiration_time is null for a key which does not expire.
+    expiration_time timestamp with time zone
+      constraint create_time_must_not_be_after_expiration_time
+        check(create_time <= expiration_time),
+    approximate_last_access_time wt_timestamp,
+    create_time wt_timestamp,
+    update_time wt_timestamp,
+    version wt_version,
+    constraint auth_api_key_service_account_id_name_uq
+      unique(service_account_id, name)
+  );
+  comment on table auth_api_key is
+    'auth_api_key is a table where each row is an api key of a service account.';
+
+  create index auth_api_key_service_account_id_ix
+    on auth_api_key (service_account_id);
+
+  create trigger default_create_time_column before insert on auth_api_key
+    for each row execute procedure default_create_time();
+
+  create trigger update_time_column before update on auth_api_key
+    for each row execute procedure update_time_column();
+
+  create trigger update_version_column after update on auth_api_key
+    for each row execute procedure update_version_column();
+
+  create trigger update_last_access_time before update on auth_api_key
+    for each row execute procedure update_last_access_time();
+
+  create trigger immutable_columns before update on auth_api_key
+    for each row execute procedure immutable_columns('public_id', 'service_account_id', 'scope_id', 'create_time');
+
+  create trigger insert_deleted_resource after delete on auth_api_key
+    for each row execute function insert_deleted_resource();
+
+commit;
@@ -0,0 +1,151 @@
+-- Copyright (c) HashiCorp, Inc.
+-- SPDX-License-Identifier: MPL-2.0
+
+begin;
+
+  -- enable_pkce indicates that authentication requests of the oidc auth method
+  -- will use a PKCE code challenge and verifier.
+  alter table auth_oidc_method
+    add column enable_pkce boolean not null default false;
+
+  create table auth_oidc_prompt_enm (
+    name text primary key
+      constraint only_predefined_auth_oidc_prompts_allowed
+      check (
+        name in (
+          'none',
+          'login',
+          'consent',
+          'select_account')
+      )
+  );
+  comment on table auth_oidc_prompt_enm is
+    'auth_oidc_prompt_enm entries are the prompt values supported by oidc auth methods.';
+
+  insert into auth_oidc_prompt_enm (name)
+    values
+      ('none'),
+      ('login'),
+      ('consent'),
+      ('select_account');
+
+  create trigger immutable_columns before update on auth_oidc_prompt_enm
+    for each row execute procedure immutable_columns('name');
+
+  -- auth_oidc_prompt entries are the optional prompt values for a specific oidc
+  -- auth method.  There can be 0 or more for each parent oidc auth method.  If
+  -- an auth method has any prompts, they will be added to authentication
+  -- requests.
+  create table auth_oidc_prompt (
+    create_time wt_timestamp,
+    oidc_method_id wt_public_id
+      constraint auth_oidc_method_fkey
+      references auth_oidc_method(public_id)
+      on delete cascade
+      on update cascade,
+    prompt text not null
+      constraint auth_oidc_prompt_enm_fkey
+      references auth_oidc_prompt_enm(name)
+      on delete restrict
+      on update cascade,
+    primary key(oidc_method_id, prompt)
+  );
+  comment on table auth_oidc_prompt is
+    'auth_oidc_prompt entries are the optional prompt values for a specific oidc auth method.  There can be 0 or more for each parent oidc auth method.';
+
+  create trigger default_create_time_column before insert on auth_oidc_prompt
+    for each row execute procedure default_create_time();
+
+  -- the prompt value none cannot be combined with any other prompt value.
+  create function auth_oidc_prompt_none_is_exclusive() returns trigger
+  as $$
+  begin
+    perform from auth_oidc_prompt
+      where oidc_method_id = new.oidc_method_id
+        and (prompt = 'none') != (new.prompt = 'none');
+    if found then
+      raise exception 'prompt none cannot be combined with other prompts';
+    end if;
+    return new;
+  end;
+  $$ language plpgsql;
+  comment on function auth_oidc_prompt_none_is_exclusive is
+    'auth_oidc_prompt_none_is_exclusive ensures the prompt value none is not combined with any other prompt value.';
+
+  create trigger auth_oidc_prompt_none_is_exclusive before insert on auth_oidc_prompt
+    for each row execute procedure auth_oidc_prompt_none_is_exclusive();
+
+  -- auth_oidc_acr_value entries are the optional authentication context class
+  -- references for a specific oidc auth method.  There can be 0 or more for
+  -- each parent oidc auth method.  If an auth method has any acr values, they
+  -- will be added to authentication requests and an ID token must contain an
+  -- acr claim matching one of them to be valid.
+  create table auth_oidc_acr_value (
+    create_time wt_timestamp,
+    oidc_method_id wt_public_id
+      constraint auth_oidc_method_fkey
+      references auth_oidc_method(public_id)
+      on delete cascade
+      on update cascade,
+    acr_value text not null
+      constraint acr_value_must_not_be_empty
+        check(length(trim(acr_value)) > 0)
+      constraint acr_value_must_be_less_than_1024_chars
+        check(length(trim(acr_value)) < 1024),
+    primary key(oidc_method_id, acr_value)
+  );
+  comment on table auth_oidc_acr_value is
+    'auth_oidc_acr_value entries are the optional authentication context class references for a specific oidc auth method.  There can be 0 or more for each parent oidc auth method.';
+
+  create trigger default_create_time_column before insert on auth_oidc_acr_value
+    for each row execute procedure default_create_time();
+
+  -- Replaces oidc_auth_method_with_value_obj defined in
+  -- 56/02_add_data_key_foreign_key_references.up.sql
+  drop view oidc_auth_method_with_value_obj;
+  create view oidc_auth_method_with_value_obj as
+  select
+    case when s.primary_auth_method_id is not null then
+      true
+    else false end
+    as is_primary_auth_method,
+    am.public_id,
+    am.scope_id,
+    am.name,
+    am.description,
+    am.create_time,
+    am.update_time,
+    am.version,
+    am.state,
+    am.api_url,
+    am.disable_discovered_config_validation,
+    am.issuer,
+    am.client_id,
+    am.client_secret,
+    am.client_secret_hmac,
+    am.key_id,
+    am.max_age,
+    am.enable_pkce,
+    -- the string_agg(..) column will be null if there are no associated value objects
+    string_agg(distinct alg.signing_alg_name, '|') as algs,
+    string_agg(distinct aud.aud_claim, '|') as auds,
+    string_agg(distinct cert.certificate, '|') as certs,
+    string_agg(distinct cs.scope, '|') as claims_scopes,
+    string_agg(distinct concat_ws('=', acm.from_claim, acm.to_claim), '|') as account_claim_maps,
+    string_agg(distinct p.prompt, '|') as prompts,
+    string_agg(distinct acr.acr_value, '|') as acr_values
+  from
+    auth_oidc_method am
+    left outer join iam_scope                   s     on am.public_id = s.primary_auth_method_id
+    left outer join auth_oidc_signing_alg       alg   on am.public_id = alg.oidc_method_id
+    left outer join auth_oidc_aud_claim         aud   on am.public_id = aud.oidc_method_id
+    left outer join auth_oidc_certificate       cert  on am.public_id = cert.oidc_method_id
+    left outer join auth_oidc_scope             cs    on am.public_id = cs.oidc_method_id
+    left outer join auth_oidc_account_claim_map acm   on am.public_id = acm.oidc_method_id
+    left outer join auth_oidc_prompt            p     on am.public_id = p.oidc_method_id
+    left outer join auth_oidc_acr_value         acr   on am.public_id = acr.oidc_method_id
+  group by am.public_id, is_primary_auth_method; -- there can be only one public_id + is_primary_auth_method, so group by isn't a problem.
+  comment on view oidc_auth_method_with_value_obj is
+    'oidc auth method with its associated value objects (algs, auds, certs, scopes, account claim maps, prompts, acr values) as columns with | delimited values';
+
+commit;
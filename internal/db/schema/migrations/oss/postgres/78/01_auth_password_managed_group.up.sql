@@ -0,0 +1,118 @@
+-- Copyright (c) HashiCorp, Inc.
+-- SPDX-License-Identifier: MPL-2.0
+
+begin;
+
+  -- custom_attributes is a json encoded object of user-defined string
+  -- attributes of the account, which password managed group filters can match
+  -- on.
+  alter table auth_password_account
+    add column custom_attributes text
+      constraint custom_attributes_must_not_be_empty
+        check(length(trim(custom_attributes)) > 0);
+
+  create table auth_password_managed_group (
+    public_id wt_public_id primary key,
+    auth_method_id wt_public_id not null,
+    name wt_name,
+    description wt_description,
+    create_time wt_timestamp,
+    update_time wt_timestamp,
+    version wt_version,
+    filter wt_bexprfilter not null,
+    -- Ensure that this managed group relates to a password auth method, as
+    -- opposed to other types
+    constraint auth_password_method_fkey
+      foreign key (auth_method_id) -- fk1
+        references auth_password_method (public_id)
+        on delete cascade
+        on update cascade,
+    -- Ensure it relates to an abstract managed group
+    constraint auth_managed_group_fkey
+      foreign key (auth_method_id, public_id) -- fk2
+        references auth_managed_group (auth_method_id, public_id)
+        on delete cascade
+        on update cascade,
+    constraint auth_password_managed_group_auth_method_id_name_uq
+      unique(auth_method_id, name)
+  );
+  comment on table auth_password_managed_group is
+    'auth_password_managed_group entries are subtypes of auth_managed_group and represent a password managed group.';
+
+  create trigger immutable_columns before update on auth_password_managed_group
+    for each row execute procedure immutable_columns('public_id', 'auth_method_id', 'create_time');
+
+  create trigger default_create_time_column before insert on auth_password_managed_group
+    for each row execute procedure default_create_time();
+
+  create trigger update_time_column before update on auth_password_managed_group
+    for each row execute procedure update_time_column();
+
+  create trigger update_version_column after update on auth_password_managed_group
+    for each row execute procedure update_version_column();
+
+  create trigger insert_managed_group_subtype before insert on auth_password_managed_group
+    for each row execute procedure insert_managed_group_subtype();
+
+  create trigger delete_managed_group_subtype after delete on auth_password_managed_group
+    for each row execute procedure delete_managed_group_subtype();
+
+  -- Mappings of account to password managed groups. This is a non-abstract
+  -- table so that it is a natural aggregate for the oplog.
+  create table auth_password_managed_group_member_account (
+    create_time wt_timestamp,
+    managed_group_id wt_public_id
+      references auth_password_managed_group(public_id)
+      on delete cascade
+      on update cascade,
+    member_id wt_public_id
+      references auth_password_account(public_id)
+      on delete cascade
+      on update cascade,
+    primary key (managed_group_id, member_id)
+  );
+  comment on table auth_password_managed_group_member_account is
+    'auth_password_managed_group_member_account is the join table for managed password groups and accounts.';
+
+  create function auth_immutable_managed_password_group_member_account() returns trigger
+  as $$
+  begin
+    raise exception 'managed password group members are immutable';
+  end;
+  $$ language plpgsql;
+
+  create trigger default_create_time_column before insert on auth_password_managed_group_member_account
+    for each row execute procedure default_create_time();
+
+  create trigger auth_immutable_managed_password_group_member_account before update on auth_password_managed_group_member_account
+    for each row execute procedure auth_immutable_managed_password_group_member_account();
+
+  -- Replaces view from 72/01_ldap.up.sql
+  create or replace view auth_managed_group_member_account as
+  select
+    oidc.create_time,
+    oidc.managed_group_id,
+    oidc.member_id
+  from
+    auth_oidc_managed_group_member_account oidc
+  union
+  select
+    ldap.create_time,
+    ldap.managed_group_id,
+    ldap.member_id
+  from
+    auth_ldap_managed_group_member_account ldap
+  union
+  select
+    pw.create_time,
+    pw.managed_group_id,
+    pw.member_id
+  from
+    auth_password_managed_group_member_account pw;
+
+  insert into oplog_ticket
+    (name, version)
+  values
+    ('auth_password_managed_group', 1);
+
+commit;
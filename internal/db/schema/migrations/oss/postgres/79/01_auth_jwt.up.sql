@@ -0,0 +1,552 @@
+-- Copyright (c) HashiCorp, Inc.
+-- SPDX-License-Identifier: MPL-2.0
+
+begin;
+
+  -- auth_jwt_method entries are the current jwt auth methods configured for
+  -- existing scopes. A jwt auth method validates externally issued tokens
+  -- against either a jwks url or a set of static public keys.
+  create table auth_jwt_method (
+    public_id wt_public_id primary key,
+    scope_id wt_scope_id not null,
+    name wt_name,
+    description wt_description,
+    create_time wt_timestamp,
+    update_time wt_timestamp,
+    version wt_version,
+    issuer text
+      constraint issuer_not_empty
+        check(length(trim(issuer)) > 0),
+    jwks_url wt_url
+      constraint jwks_url_must_be_http_or_https
+        check(jwks_url ~* '^https?://'),
+    constraint auth_method_fkey
+      foreign key (scope_id, public_id)
+        references auth_method (scope_id, public_id)
+        on delete cascade
+        on update cascade,
+    constraint auth_jwt_method_scope_id_name_uq
+      unique(scope_id, name),
+    constraint auth_jwt_method_scope_id_public_id_uq
+      unique(scope_id, public_id)
+  );
+  comment on table auth_jwt_method is
+    'auth_jwt_method entries are the current jwt auth methods configured for existing scopes.';
+
+  create trigger insert_auth_method_subtype before insert on auth_jwt_method
+    for each row execute procedure insert_auth_method_subtype();
+
+  create trigger update_auth_method_subtype before update on auth_jwt_method
+    for each row execute procedure update_auth_method_subtype();
+
+  create trigger delete_auth_method_subtype after delete on auth_jwt_method
+    for each row execute procedure delete_auth_method_subtype();
+
+  create trigger update_time_column before update on auth_jwt_method
+    for each row execute procedure update_time_column();
+
+  create trigger immutable_columns before update on auth_jwt_method
+    for each row execute procedure immutable_columns('public_id', 'scope_id', 'create_time');
+
+  create trigger default_create_time_column before insert on auth_jwt_method
+    for each row execute procedure default_create_time();
+
+  create trigger update_version_column after update on auth_jwt_method
+    for each row execute procedure update_version_column();
+
+  -- auth_jwt_certificate entries are optional PEM encoded x509 certificates.
+  -- Each entry is a single certificate. If an auth method has any cert
+  -- entries, they are used as trust anchors when fetching the auth method's
+  -- jwks (instead of the host system's cert chain).
+  create table auth_jwt_certificate (
+    create_time wt_timestamp,
+    jwt_method_id wt_public_id
+      constraint auth_jwt_method_fkey
+        references auth_jwt_method(public_id)
+        on delete cascade
+        on update cascade,
+    certificate text not null
+      constraint certificate_must_not_be_empty
+        check(length(trim(certificate)) > 0),
+    primary key(jwt_method_id, certificate)
+  );
+  comment on table auth_jwt_certificate is
+    'auth_jwt_certificate entries are optional PEM encoded x509 certificates. Each entry is a single certificate. '
+    'If an auth method has any cert entries, they are used as trust anchors when fetching the auth method''s jwks.';
+
+  create trigger default_create_time_column before insert on auth_jwt_certificate
+    for each row execute procedure default_create_time();
+
+  -- auth_jwt_public_key entries are PEM encoded public keys used to verify
+  -- the signatures of presented tokens.
+  create table auth_jwt_public_key (
+    create_time wt_timestamp,
+    jwt_method_id wt_public_id
+      constraint auth_jwt_method_fkey
+        references auth_jwt_method(public_id)
+        on delete cascade
+        on update cascade,
+    public_key text not null
+      constraint public_key_must_not_be_empty
+        check(length(trim(public_key)) > 0),
+    primary key(jwt_method_id, public_key)
+  );
+  comment on table auth_jwt_public_key is
+    'auth_jwt_public_key entries are PEM encoded public keys used to verify the signatures of presented tokens.';
+
+  create trigger default_create_time_column before insert on auth_jwt_public_key
+    for each row execute procedure default_create_time();
+
+  -- auth_jwt_bound_audience entries are the audiences of a jwt auth method.
+  -- If an auth method has any entries, the aud claim of a presented token
+  -- must contain at least one of them.
+  create table auth_jwt_bound_audience (
+    create_time wt_timestamp,
+    jwt_method_id wt_public_id
+      constraint auth_jwt_method_fkey
+        references auth_jwt_method(public_id)
+        on delete cascade
+        on update cascade,
+    audience text not null
+      constraint audience_must_not_be_empty
+        check(length(trim(audience)) > 0)
+      constraint audience_must_be_less_than_1024_chars
+        check(length(trim(audience)) < 1024),
+    primary key(jwt_method_id, audience)
+  );
+  comment on table auth_jwt_bound_audience is
+    'auth_jwt_bound_audience entries are the audiences of a jwt auth method.';
+
+  create trigger default_create_time_column before insert on auth_jwt_bound_audience
+    for each row execute procedure default_create_time();
+
+  -- auth_jwt_signing_alg entries are the signing algorithms allowed for a jwt
+  -- auth method. The allowed values are shared with oidc auth methods.
+  create table auth_jwt_signing_alg (
+    create_time wt_timestamp,
+    jwt_method_id wt_public_id
+      constraint auth_jwt_method_fkey
+        references auth_jwt_method(public_id)
+        on delete cascade
+        on update cascade,
+    signing_alg_name text
+      constraint auth_oidc_signing_alg_enm_fkey
+        references auth_oidc_signing_alg_enm(name)
+        on delete restrict
+        on update cascade,
+    primary key(jwt_method_id, signing_alg_name)
+  );
+  comment on table auth_jwt_signing_alg is
+    'auth_jwt_signing_alg entries are the signing algorithms allowed for a jwt auth method.';
+
+  create trigger default_create_time_column before insert on auth_jwt_signing_alg
+    for each row execute procedure default_create_time();
+
+  -- auth_jwt_account_claim_map entries are optional maps from custom claims
+  -- to the standard claims of sub, name and email.
+  create table auth_jwt_account_claim_map (
+    create_time wt_timestamp,
+    jwt_method_id wt_public_id
+      constraint auth_jwt_method_fkey
+        references auth_jwt_method(public_id)
+        on delete cascade
+        on update cascade,
+    from_claim text not null
+      constraint from_claim_must_not_be_empty
+        check(length(trim(from_claim)) > 0)
+      constraint from_claim_must_be_less_than_1024_chars
+        check(length(trim(from_claim)) < 1024),
+    to_claim text not null
+      constraint to_claim_valid_values
+        check (to_claim in ('sub', 'name', 'email')),
+    primary key(jwt_method_id, to_claim)
+  );
+  comment on table auth_jwt_account_claim_map is
+    'auth_jwt_account_claim_map entries are optional maps from custom claims to the standard claims of sub, name and email.';
+
+  create trigger default_create_time_column before insert on auth_jwt_account_claim_map
+    for each row execute procedure default_create_time();
+
+  create view jwt_auth_method_with_value_obj as
+  select
+    case when s.primary_auth_method_id is not null then
+      true
+    else false end
+    as is_primary_auth_method,
+    am.public_id,
+    am.scope_id,
+    am.name,
+    am.description,
+    am.create_time,
+    am.update_time,
+    am.version,
+    am.issuer,
+    am.jwks_url,
+    -- the string_agg(..) column will be null if there are no associated value objects
+    string_agg(distinct cert.certificate, '|') as certs,
+    string_agg(distinct pk.public_key, '|') as public_keys,
+    string_agg(distinct aud.audience, '|') as audiences,
+    string_agg(distinct alg.signing_alg_name, '|') as algs,
+    string_agg(distinct concat_ws('=', acm.from_claim, acm.to_claim), '|') as account_claim_maps
+  from
+    auth_jwt_method am
+    left outer join iam_scope                  s     on am.public_id = s.primary_auth_method_id
+    left outer join auth_jwt_certificate       cert  on am.public_id = cert.jwt_method_id
+    left outer join auth_jwt_public_key        pk    on am.public_id = pk.jwt_method_id
+    left outer join auth_jwt_bound_audience    aud   on am.public_id = aud.jwt_method_id
+    left outer join auth_jwt_signing_alg       alg   on am.public_id = alg.jwt_method_id
+    left outer join auth_jwt_account_claim_map acm   on am.public_id = acm.jwt_method_id
+  group by am.public_id, is_primary_auth_method; -- there can be only one public_id + is_primary_auth_method, so group by isn't a problem.
+  comment on view jwt_auth_method_with_value_obj is
+    'jwt auth method with its associated value objects (certs, public keys, audiences, algs, account claim maps) as columns with | delimited values';
+
+  -- auth_jwt_account entries are subtypes of auth_account and represent a
+  -- jwt account.
+  create table auth_jwt_account (
+    public_id wt_public_id primary key,
+    auth_method_id wt_public_id not null,
+    -- The scope_id type is not wt_scope_id because the domain check is
+    -- executed before the insert trigger which retrieves the scope_id
+    -- causing an insert to fail.
+    scope_id text not null,
+    name wt_name,
+    description wt_description,
+    create_time wt_timestamp,
+    update_time wt_timestamp,
+    version wt_version,
+    issuer text
+      constraint issuer_must_not_be_empty
+        check(length(trim(issuer)) > 0),
+    subject text not null
+      constraint subject_must_not_be_empty
+        check(length(trim(subject)) > 0)
+      constraint subject_must_be_less_than_256_chars
+        check(length(trim(subject)) <= 255),
+    full_name wt_full_name,
+    email wt_email,
+    token_claims text
+      constraint token_claims_must_not_be_empty
+        check(length(trim(token_claims)) > 0),
+    constraint auth_jwt_method_fkey
+      foreign key (scope_id, auth_method_id)
+        references auth_jwt_method (scope_id, public_id)
+        on delete cascade
+        on update cascade,
+    constraint auth_account_fkey
+      foreign key (scope_id, auth_method_id, public_id)
+        references auth_account (scope_id, auth_method_id, public_id)
+        on delete cascade
+        on update cascade,
+    constraint auth_jwt_account_auth_method_id_name_uq
+      unique(auth_method_id, name),
+    -- ###############################################################
+    -- any change to this constraints name must be aligned with the
+    -- acctUpsertQuery const in internal/auth/jwt/query.go
+    -- ###############################################################
+    constraint auth_jwt_account_auth_method_id_subject_uq
+      unique(auth_method_id, subject),
+    constraint auth_jwt_account_auth_method_id_public_id_uq
+      unique(auth_method_id, public_id)
+  );
+  comment on table auth_jwt_account is
+    'auth_jwt_account entries are subtypes of auth_account and represent a jwt account.';
+
+  create trigger update_time_column before update on auth_jwt_account
+    for each row execute procedure update_time_column();
+
+  create trigger immutable_columns before update on auth_jwt_account
+    for each row execute procedure immutable_columns('public_id', 'auth_method_id', 'scope_id', 'create_time', 'subject');
+
+  create trigger default_create_time_column before insert on auth_jwt_account
+    for each row execute procedure default_create_time();
+
+  create trigger update_version_column after update on auth_jwt_account
+    for each row execute procedure update_version_column();
+
+  -- jwt accounts use predictable public ids so the same function used for
+  -- oidc accounts is used to insert the base auth_account.
+  create trigger insert_auth_jwt_account_subtype before insert on auth_jwt_account
+    for each row execute procedure insert_auth_oidc_account_subtype();
+
+  create trigger delete_auth_account_subtype after delete on auth_jwt_account
+    for each row execute procedure delete_auth_account_subtype();
+
+  create table auth_jwt_managed_group (
+    public_id wt_public_id primary key,
+    auth_method_id wt_public_id not null,
+    name wt_name,
+    description wt_description,
+    create_time wt_timestamp,
+    update_time wt_timestamp,
+    version wt_version,
+    filter wt_bexprfilter not null,
+    -- Ensure that this managed group relates to a jwt auth method, as
+    -- opposed to other types
+    constraint auth_jwt_method_fkey
+      foreign key (auth_method_id) -- fk1
+        references auth_jwt_method (public_id)
+        on delete cascade
+        on update cascade,
+    -- Ensure it relates to an abstract managed group
+    constraint auth_managed_group_fkey
+      foreign key (auth_method_id, public_id) -- fk2
+        references auth_managed_group (auth_method_id, public_id)
+        on delete cascade
+        on update cascade,
+    constraint auth_jwt_managed_group_auth_method_id_name_uq
+      unique(auth_method_id, name)
+  );
+  comment on table auth_jwt_managed_group is
+    'auth_jwt_managed_group entries are subtypes of auth_managed_group and represent a jwt managed group.';
+
+  create trigger immutable_columns before update on auth_jwt_managed_group
+    for each row execute procedure immutable_columns('public_id', 'auth_method_id', 'create_time');
+
+  create trigger default_create_time_column before insert on auth_jwt_managed_group
+    for each row execute procedure default_create_time();
+
+  create trigger update_time_column before update on auth_jwt_managed_group
+    for each row execute procedure update_time_column();
+
+  create trigger update_version_column after update on auth_jwt_managed_group
+    for each row execute procedure update_version_column();
+
+  create trigger insert_managed_group_subtype before insert on auth_jwt_managed_group
+    for each row execute procedure insert_managed_group_subtype();
+
+  create trigger delete_managed_group_subtype after delete on auth_jwt_managed_group
+    for each row execute procedure delete_managed_group_subtype();
+
+  -- Mappings of account to jwt managed groups. This is a non-abstract table
+  -- so that it is a natural aggregate for the oplog.
+  create table auth_jwt_managed_group_member_account (
+    create_time wt_timestamp,
+    managed_group_id wt_public_id
+      references auth_jwt_managed_group(public_id)
+      on delete cascade
+      on update cascade,
+    member_id wt_public_id
+      references auth_jwt_account(public_id)
+      on delete cascade
+      on update cascade,
+    primary key (managed_group_id, member_id)
+  );
+  comment on table auth_jwt_managed_group_member_account is
+    'auth_jwt_managed_group_member_account is the join table for managed jwt groups and accounts.';
+
+  create function auth_immutable_managed_jwt_group_member_account() returns trigger
+  as $$
+  begin
+    raise exception 'managed jwt group members are immutable';
+  end;
+  $$ language plpgsql;
+
+  create trigger default_create_time_column before insert on auth_jwt_managed_group_member_account
+    for each row execute procedure default_create_time();
+
+  create trigger auth_immutable_managed_jwt_group_member_account before update on auth_jwt_managed_group_member_account
+    for each row execute procedure auth_immutable_managed_jwt_group_member_account();
+
+  -- Replaces view from 78/01_auth_password_managed_group.up.sql
+  create or replace view auth_managed_group_member_account as
+  select
+    oidc.create_time,
+    oidc.managed_group_id,
+    oidc.member_id
+  from
+    auth_oidc_managed_group_member_account oidc
+  union
+  select
+    ldap.create_time,
+    ldap.managed_group_id,
+    ldap.member_id
+  from
+    auth_ldap_managed_group_member_account ldap
+  union
+  select
+    pw.create_time,
+    pw.managed_group_id,
+    pw.member_id
+  from
+    auth_password_managed_group_member_account pw
+  union
+  select
+    jwt.create_time,
+    jwt.managed_group_id,
+    jwt.member_id
+  from
+    auth_jwt_managed_group_member_account jwt;
+
+  -- Replaces view from 72/01_ldap.up.sql
+  create or replace view iam_acct_info as
+  select
+    aa.iam_user_id,
+    oa.subject as login_name,
+    oa.public_id as primary_account_id,
+    oa.full_name as full_name,
+    oa.email as email
+  from
+    iam_scope s,
+    auth_account aa,
+    auth_oidc_account oa
+  where
+    aa.public_id = oa.public_id and
+    aa.auth_method_id = s.primary_auth_method_id
+  union
+  select
+    aa.iam_user_id,
+    pa.login_name,
+    pa.public_id as primary_account_id,
+    '' as full_name,
+    '' as email
+  from
+    iam_scope s,
+    auth_account aa,
+    auth_password_account pa
+  where
+    aa.public_id = pa.public_id and
+    aa.auth_method_id = s.primary_auth_method_id
+  union
+  select
+    aa.iam_user_id,
+    la.login_name,
+    la.public_id as primary_account_id,
+    la.full_name as full_name,
+    la.email as email
+  from
+    iam_scope s,
+    auth_account aa,
+    auth_ldap_account la
+  where
+    aa.public_id = la.public_id and
+    aa.auth_method_id = s.primary_auth_method_id
+  union
+  select
+    aa.iam_user_id,
+    ja.subject as login_name,
+    ja.public_id as primary_account_id,
+    ja.full_name as full_name,
+    ja.email as email
+  from
+    iam_scope s,
+    auth_account aa,
+    auth_jwt_account ja
+  where
+    aa.public_id = ja.public_id and
+    aa.auth_method_id = s.primary_auth_method_id;
+
+  -- Replaces view from 72/01_ldap.up.sql
+  create or replace view whx_user_dimension_source as
+       select -- id is the first column in the target view
+              u.public_id                       as user_id,
+              coalesce(u.name, 'None')          as user_name,
+              coalesce(u.description, 'None')   as user_description,
+              coalesce(aa.public_id, 'None')    as auth_account_id,
+              case
+                   when apa.public_id is not null then 'password auth account'
+                   when aoa.public_id is not null then 'oidc auth account'
+                   when ala.public_id is not null then 'ldap auth account'
+                   when aja.public_id is not null then 'jwt auth account'
+                   else 'None'
+                   end                          as auth_account_type,
+              case
+                   when apa.public_id is not null then coalesce(apa.name, 'None')
+                   when aoa.public_id is not null then coalesce(aoa.name, 'None')
+                   when ala.public_id is not null then coalesce(ala.name, 'None')
+                   when aja.public_id is not null then coalesce(aja.name, 'None')
+                   else 'None'
+                   end                          as auth_account_name,
+              case
+                   when apa.public_id is not null then coalesce(apa.description, 'None')
+                   when aoa.public_id is not null then coalesce(aoa.description, 'None')
+                   when ala.public_id is not null then coalesce(ala.description, 'None')
+                   when aja.public_id is not null then coalesce(aja.description, 'None')
+                   else 'None'
+                   end                          as auth_account_description,
+              case
+                  when apa.public_id is not null then 'Not Applicable'
+                  when aoa.public_id is not null then aoa.subject
+                  when ala.public_id is not null then coalesce(ala.dn, ala.login_name)
+                  when aja.public_id is not null then aja.subject
+                  else 'None'
+                  end                           as auth_account_external_id,
+              case
+                  when apa.public_id is not null then 'Not Applicable'
+                  when  aoa.public_id is not null
+                    and aoa.full_name is not null then aoa.full_name
+                  when  ala.public_id is not null
+                    and ala.full_name is not null then ala.full_name
+                  when  aja.public_id is not null
+                    and aja.full_name is not null then aja.full_name
+                  else 'None'
+                  end                           as auth_account_full_name,
+              case
+                  when apa.public_id is not null then 'Not Applicable'
+                  when  aoa.public_id is not null
+                    and aoa.email is not null then aoa.email
+                  when  ala.public_id is not null
+                    and ala.email is not null then ala.email
+                  when  aja.public_id is not null
+                    and aja.email is not null then aja.email
+                  else 'None'
+                  end                           as auth_account_email,
+              coalesce(am.public_id, 'None')    as auth_method_id,
+              case
+                   when apa.public_id is not null then 'password auth method'
+                   when aoa.public_id is not null then 'oidc auth method'
+                   when ala.public_id is not null then 'ldap auth method'
+                   when aja.public_id is not null then 'jwt auth method'
+                   else 'None'
+                   end                          as auth_method_type,
+              case
+                   when apm.public_id is not null then coalesce(apm.name, 'None')
+                   when aom.public_id is not null then coalesce(aom.name, 'None')
+                   when alm.public_id is not null then coalesce(alm.name, 'None')
+                   when ajm.public_id is not null then coalesce(ajm.name, 'None')
+                   else 'None'
+                   end                          as auth_method_name,
+              case
+                   when apm.public_id is not null then coalesce(apm.description, 'None')
+                   when aom.public_id is not null then coalesce(aom.description, 'None')
+                   when alm.public_id is not null then coalesce(alm.description, 'None')
+                   when ajm.public_id is not null then coalesce(ajm.description, 'None')
+                   else 'None'
+                   end                          as auth_method_description,
+              case
+                  when apa.public_id is not null then 'Not Applicable'
+                  when aom.public_id is not null then aom.issuer
+                  when alm.public_id is not null then
+                    (select string_agg(url.url, ', ' order by url.connection_priority)
+                       from auth_ldap_url as url
+                      where url.ldap_method_id = alm.public_id)
+                  when ajm.public_id is not null then
+                    coalesce(ajm.issuer, ajm.jwks_url, 'None')
+                  else 'None'
+                  end                           as auth_method_external_id,
+              org.public_id                     as user_organization_id,
+              coalesce(org.name, 'None')        as user_organization_name,
+              coalesce(org.description, 'None') as user_organization_description
+         from iam_user as u
+    left join auth_account as aa on           u.public_id       = aa.iam_user_id
+    left join auth_method as am on            aa.auth_method_id = am.public_id
+    left join auth_password_account as apa on aa.public_id      = apa.public_id
+    left join auth_password_method as apm on  am.public_id      = apm.public_id
+    left join auth_oidc_account as aoa on     aa.public_id      = aoa.public_id
+    left join auth_oidc_method as aom on      am.public_id      = aom.public_id
+    left join auth_ldap_account as ala on     aa.public_id      = ala.public_id
+    left join auth_ldap_method as alm on      am.public_id      = alm.public_id
+    left join auth_jwt_account as aja on      aa.public_id      = aja.public_id
+    left join auth_jwt_method as ajm on       am.public_id      = ajm.public_id
+         join iam_scope as org on             u.scope_id        = org.public_id
+  ;
+
+  -- The tickets for oplog are the subtypes not the base types because no
+  -- updates are done to any values in the base types.
+  insert into oplog_ticket
+    (name, version)
+  values
+    ('auth_jwt_method', 1), -- auth method is the root aggregate itself and all of its value objects.
+    ('auth_jwt_account', 1),
+    ('auth_jwt_managed_group', 1);
+
+commit;
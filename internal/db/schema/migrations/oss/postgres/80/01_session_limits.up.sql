@@ -0,0 +1,32 @@
+-- Copyright (c) HashiCorp, Inc.
+-- SPDX-License-Identifier: MPL-2.0
+
+begin;
+
+  -- max_sessions_per_user limits the number of pending and active sessions a
+  -- user may have on targets within the scope, and max_sessions_per_target
+  -- limits the number a user may have on any single target within the scope.
+  -- A value of 0 means there is no limit.
+  alter table iam_scope
+    add column max_sessions_per_user int not null default 0
+      constraint max_sessions_per_user_must_not_be_negative
+        check(max_sessions_per_user >= 0),
+    add column max_sessions_per_target int not null default 0
+      constraint max_sessions_per_target_must_not_be_negative
+        check(max_sessions_per_target >= 0);
+
+  -- The session limits of a role apply to its principals on targets within
+  -- the role's grant scope.
+  alter table iam_role
+    add column max_sessions_per_user int not null default 0
+      constraint max_sessions_per_user_must_not_be_negative
+        check(max_sessions_per_user >= 0),
+    add column max_sessions_per_target int not null default 0
+      constraint max_sessions_per_target_must_not_be_negative
+        check(max_sessions_per_target >= 0);
+
+  -- The active sessions of a user are counted when a session is authorized.
+  create index session_user_id_ix
+    on session (user_id);
+
+commit;
@@ -0,0 +1,167 @@
+-- Copyright (c) HashiCorp, Inc.
+-- SPDX-License-Identifier: MPL-2.0
+
+begin;
+
+  -- iam_role_grant_scope entries are the scopes the grants of a role apply
+  -- to. scope_id_or_special is either the id of a scope or one of the special
+  -- values: 'this' for the role's own scope, 'children' for all of the direct
+  -- child scopes of the role's scope and 'descendants' for all of the scopes
+  -- below the role's scope.
+  create table iam_role_grant_scope (
+    create_time wt_timestamp,
+    role_id wt_role_id -- pk
+      references iam_role(public_id)
+      on delete cascade
+      on update cascade,
+    scope_id_or_special text -- pk
+      constraint scope_id_or_special_is_valid
+        check(
+          scope_id_or_special in ('this', 'children', 'descendants')
+          or length(trim(scope_id_or_special)) > 10
+        ),
+    primary key(role_id, scope_id_or_special)
+  );
+  comment on table iam_role_grant_scope is
+    'iam_role_grant_scope entries are the scopes the grants of a role apply to.';
+
+  create function iam_immutable_role_grant_scope() returns trigger
+  as $$
+  begin
+    raise exception 'role grant scopes are immutable';
+  end;
+  $$ language plpgsql;
+
+  create trigger immutable_role_grant_scope before update on iam_role_grant_scope
+    for each row execute procedure iam_immutable_role_grant_scope();
+
+  create trigger default_create_time_column before insert on iam_role_grant_scope
+    for each row execute procedure default_create_time();
+
+  -- iam_role_grant_scope_valid ensures a grant scope is valid for the scope of
+  -- its role: 'descendants' is only valid for roles in the global scope,
+  -- 'children' is not valid for roles in a project, and a scope id must be
+  -- the id of a scope below the role's scope. 'children' and 'descendants'
+  -- may not be combined, nor combined with the ids of scopes they already
+  -- cover.
+  create function iam_role_grant_scope_valid() returns trigger
+  as $$
+  declare
+    role_scope_id text;
+    role_scope_type text;
+    grant_scope_parent_id text;
+    grant_scope_grandparent_id text;
+  begin
+    select r.scope_id, s.type
+      from iam_role r
+      join iam_scope s on s.public_id = r.scope_id
+     where r.public_id = new.role_id
+      into role_scope_id, role_scope_type;
+
+    case new.scope_id_or_special
+      when 'this' then
+        return new;
+      when 'children' then
+        if role_scope_type = 'project' then
+          raise exception 'invalid to set grant scope children when role scope type is project';
+        end if;
+        perform from iam_role_grant_scope
+         where role_id = new.role_id
+           and scope_id_or_special = 'descendants';
+        if found then
+          raise exception 'invalid to set grant scope children when role has grant scope descendants';
+        end if;
+        perform from iam_role_grant_scope rgs
+          join iam_scope s on s.public_id = rgs.scope_id_or_special
+         where rgs.role_id = new.role_id
+           and s.parent_id = role_scope_id;
+        if found then
+          raise exception 'invalid to set grant scope children when role has a child scope as a grant scope';
+        end if;
+        return new;
+      when 'descendants' then
+        if role_scope_type != 'global' then
+          raise exception 'invalid to set grant scope descendants when role scope type is not global';
+        end if;
+        perform from iam_role_grant_scope
+         where role_id = new.role_id
+           and scope_id_or_special not in ('this', 'descendants');
+        if found then
+          raise exception 'invalid to set grant scope descendants when role has other grant scopes than this';
+        end if;
+        return new;
+      else
+        if new.scope_id_or_special = role_scope_id then
+          raise exception 'invalid to set the role scope id as a grant scope, use this';
+        end if;
+        select s.parent_id, p.parent_id
+          from iam_scope s
+          left join iam_scope p on p.public_id = s.parent_id
+         where s.public_id = new.scope_id_or_special
+          into grant_scope_parent_id, grant_scope_grandparent_id;
+        if not found then
+          raise exception 'grant scope % does not exist', new.scope_id_or_special;
+        end if;
+        if role_scope_type = 'project' then
+          raise exception 'invalid to set a grant scope id when role scope type is project';
+        end if;
+        if grant_scope_parent_id is distinct from role_scope_id
+          and grant_scope_grandparent_id is distinct from role_scope_id then
+          raise exception 'grant scope % is not below the role scope', new.scope_id_or_special;
+        end if;
+        perform from iam_role_grant_scope
+         where role_id = new.role_id
+           and scope_id_or_special = 'descendants';
+        if found then
+          raise exception 'invalid to set grant scope % when role has grant scope descendants', new.scope_id_or_special;
+        end if;
+        if grant_scope_parent_id = role_scope_id then
+          perform from iam_role_grant_scope
+           where role_id = new.role_id
+             and scope_id_or_special = 'children';
+          if found then
+            raise exception 'invalid to set grant scope % when role has grant scope children', new.scope_id_or_special;
+          end if;
+        end if;
+        return new;
+    end case;
+  end;
+  $$ language plpgsql;
+
+  create trigger ensure_role_grant_scope_valid before insert on iam_role_grant_scope
+    for each row execute procedure iam_role_grant_scope_valid();
+
+  -- A scope which is deleted is no longer a grant scope of any role.
+  create function iam_delete_role_grant_scope() returns trigger
+  as $$
+  begin
+    delete from iam_role_grant_scope
+     where scope_id_or_special = old.public_id;
+    return old;
+  end;
+  $$ language plpgsql;
+
+  create trigger delete_role_grant_scope after delete on iam_scope
+    for each row execute procedure iam_delete_role_grant_scope();
+
+  -- Migrate the grant scope of existing roles.
+  insert into iam_role_grant_scope
+    (role_id, scope_id_or_special)
+  select public_id,
+         case
+           when grant_scope_id = scope_id then 'this'
+           else grant_scope_id
+         end
+    from iam_role;
+
+  drop trigger ensure_grant_scope_id_valid on iam_role;
+  drop function grant_scope_id_valid;
+
+  alter table iam_role
+    drop column grant_scope_id;
+
+  -- Supports removing the grant scopes of a deleted scope.
+  create index iam_role_grant_scope_scope_id_or_special_ix
+    on iam_role_grant_scope (scope_id_or_special);
+
+commit;
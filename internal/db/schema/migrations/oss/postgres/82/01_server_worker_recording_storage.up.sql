@@ -0,0 +1,71 @@
+-- Copyright (c) HashiCorp, Inc.
+-- SPDX-License-Identifier: MPL-2.0
+
+begin;
+
+alter table server_worker
+  add column recording_storage boolean not null default false;
+comment on column server_worker.recording_storage is
+  'recording_storage is true when the worker has reported that it has recording storage configured.';
+
+drop view server_worker_aggregate;
+-- Updates view created in 64/01_server_worker_proxy_protocol.up.sql to add
+-- whether the worker has reported having recording storage
+create view server_worker_aggregate as
+with worker_config_tags(worker_id, source, tags) as (
+  select
+    ct.worker_id,
+    ct.source,
+    -- keys and tags can be any lowercase printable character so use uppercase characters as delimitors.
+    string_agg(distinct concat_ws('Y', ct.key, ct.value), 'Z') as tags
+  from server_worker_tag ct
+  group by ct.worker_id, ct.source
+),
+connection_count (worker_id, count) as (
+ select
+   worker_id,
+   count(1) as count
+ from session_connection
+ where closed_reason is null
+ group by worker_id
+),
+worker_proxy_protocols (worker_id, protocols) as (
+  select
+    pp.worker_id,
+    -- protocols are lowercase so use an uppercase character as the delimitor.
+    string_agg(pp.protocol, 'Z' order by pp.protocol) as protocols
+  from server_worker_proxy_protocol pp
+  group by pp.worker_id
+)
+select
+  w.public_id,
+  w.scope_id,
+  w.description,
+  w.name,
+  w.address,
+  w.create_time,
+  w.update_time,
+  w.version,
+  w.last_status_time,
+  w.type,
+  w.release_version,
+  w.operational_state,
+  w.recording_storage,
+  cc.count as active_connection_count,
+  -- keys and tags can be any lowercase printable character so use uppercase characters as delimitors.
+  wt.tags as api_tags,
+  ct.tags as worker_config_tags,
+  pp.protocols as proxy_protocols
+from server_worker w
+ left join worker_config_tags wt on
+    w.public_id = wt.worker_id and wt.source = 'api'
+ left join worker_config_tags ct on
+    w.public_id = ct.worker_id and ct.source = 'configuration'
+ left join connection_count as cc on
+    w.public_id = cc.worker_id
+ left join worker_proxy_protocols as pp on
+    w.public_id = pp.worker_id;
+comment on view server_worker_aggregate is
+  'server_worker_aggregate contains the worker resource with its worker provided config values, its configuration and api provided tags, its reported proxy protocols, and whether it has recording storage.';
+
+commit;
@@ -0,0 +1,101 @@
+-- Copyright (c) HashiCorp, Inc.
+-- SPDX-License-Identifier: MPL-2.0
+
+begin;
+
+  -- host_keys is a newline separated list of the public keys, in the
+  -- authorized_keys format, the endpoint of the target is allowed to present
+  -- as its host key. Credentials are only injected into connections to an
+  -- endpoint presenting one of these keys.
+  alter table target_ssh
+    add column host_keys text
+      constraint host_keys_must_not_be_empty
+        check(length(trim(host_keys)) > 0);
+
+  -- Replaces target_all_subtypes defined in 71/01_session_idle_timeout.up.sql
+  create or replace view target_all_subtypes as
+  select public_id,
+         project_id,
+         name,
+         description,
+         default_port,
+         session_max_seconds,
+         session_connection_limit,
+         version,
+         create_time,
+         update_time,
+         worker_filter,
+         egress_worker_filter,
+         ingress_worker_filter,
+         'tcp' as type,
+         enable_session_recording,
+         false as use_tls,
+         null as allowed_paths,
+         session_idle_timeout,
+         null as host_keys
+    from target_tcp
+   union
+  select public_id,
+         project_id,
+         name,
+         description,
+         default_port,
+         session_max_seconds,
+         session_connection_limit,
+         version,
+         create_time,
+         update_time,
+         worker_filter,
+         egress_worker_filter,
+         ingress_worker_filter,
+         'ssh' as type,
+         enable_session_recording,
+         false as use_tls,
+         null as allowed_paths,
+         session_idle_timeout,
+         host_keys
+    from target_ssh
+   union
+  select public_id,
+         project_id,
+         name,
+         description,
+         default_port,
+         session_max_seconds,
+         session_connection_limit,
+         version,
+         create_time,
+         update_time,
+         worker_filter,
+         egress_worker_filter,
+         ingress_worker_filter,
+         'http' as type,
+         enable_session_recording,
+         use_tls,
+         allowed_paths,
+         session_idle_timeout,
+         null as host_keys
+    from target_http
+   union
+  select public_id,
+         project_id,
+         name,
+         description,
+         default_port,
+         session_max_seconds,
+         session_connection_limit,
+         version,
+         create_time,
+         update_time,
+         worker_filter,
+         egress_worker_filter,
+         ingress_worker_filter,
+         'postgres' as type,
+         enable_session_recording,
+         false as use_tls,
+         null as allowed_paths,
+         session_idle_timeout,
+         null as host_keys
+    from target_postgres;
+
+commit;
@@ -0,0 +1,160 @@
+-- Copyright (c) HashiCorp, Inc.
+-- SPDX-License-Identifier: MPL-2.0
+
+begin;
+
+  -- scope_id and parent_id record where a deleted resource was listed, so the
+  -- ids of deleted resources are only reported to callers listing that scope
+  -- or parent resource. Rows recorded before this migration have neither and
+  -- are never reported; they are removed once they are older than the
+  -- lifetime of a list token.
+  alter table deleted_resource
+    add column scope_id text,
+    add column parent_id text;
+  comment on column deleted_resource.scope_id is
+    'scope_id is the id of the scope the deleted resource was in.';
+  comment on column deleted_resource.parent_id is
+    'parent_id is the id of the resource the deleted resource was listed under, '
+    'or of the user the deleted resource belonged to.';
+
+  create index deleted_resource_table_name_scope_id_delete_time_ix
+    on deleted_resource (table_name, scope_id, delete_time);
+  create index deleted_resource_table_name_parent_id_delete_time_ix
+    on deleted_resource (table_name, parent_id, delete_time);
+
+  -- Replaces function defined in 69/01_deleted_resource.up.sql
+  -- The optional trigger arguments are the names of the columns of the deleted
+  -- row holding its scope id and its parent id. An empty name records null.
+  create or replace function insert_deleted_resource() returns trigger
+  as $$
+  declare
+    old_row jsonb := to_jsonb(old);
+    old_scope_id text;
+    old_parent_id text;
+  begin
+    if tg_nargs > 0 and tg_argv[0] <> '' then
+      old_scope_id = old_row ->> tg_argv[0];
+    end if;
+    if tg_nargs > 1 and tg_argv[1] <> '' then
+      old_parent_id = old_row ->> tg_argv[1];
+    end if;
+    insert into deleted_resource
+      (public_id,     table_name,    scope_id,     parent_id)
+    values
+      (old.public_id, tg_table_name, old_scope_id, old_parent_id)
+    on conflict (public_id) do update
+      set delete_time = now(),
+          scope_id    = excluded.scope_id,
+          parent_id   = excluded.parent_id;
+    return null;
+  end;
+  $$ language plpgsql;
+  comment on function insert_deleted_resource is
+    'insert_deleted_resource is an after delete trigger function which records the public_id of '
+    'the deleted row in the deleted_resource table. The trigger arguments name the columns holding '
+    'the scope id and the parent id of the deleted row.';
+
+  -- An auth token has no scope of its own, it is in the scope of its auth
+  -- account and belongs to the user of the account.
+  create function insert_deleted_auth_token() returns trigger
+  as $$
+  declare
+    account_scope_id text;
+    account_user_id text;
+  begin
+    select scope_id,         iam_user_id
+      into account_scope_id, account_user_id
+      from auth_account
+     where public_id = old.auth_account_id;
+    insert into deleted_resource
+      (public_id,     table_name,    scope_id,         parent_id)
+    values
+      (old.public_id, tg_table_name, account_scope_id, account_user_id)
+    on conflict (public_id) do update
+      set delete_time = now(),
+          scope_id    = excluded.scope_id,
+          parent_id   = excluded.parent_id;
+    return null;
+  end;
+  $$ language plpgsql;
+  comment on function insert_deleted_auth_token is
+    'insert_deleted_auth_token is an after delete trigger function which records the deleted '
+    'auth token in the deleted_resource table with the scope and the user of its auth account.';
+
+  -- The auth tokens of an auth account are deleted before the account, while
+  -- the account can still be read by insert_deleted_auth_token. The foreign
+  -- key cascade would delete them after the account.
+  create function delete_auth_account_auth_tokens() returns trigger
+  as $$
+  begin
+    delete from auth_token
+     where auth_account_id = old.public_id;
+    return old;
+  end;
+  $$ language plpgsql;
+  comment on function delete_auth_account_auth_tokens is
+    'delete_auth_account_auth_tokens is a before delete trigger function which deletes the '
+    'auth tokens of the deleted auth account.';
+
+  create trigger delete_auth_account_auth_tokens before delete on auth_account
+    for each row execute function delete_auth_account_auth_tokens();
+
+  drop trigger insert_deleted_resource on target;
+  create trigger insert_deleted_resource after delete on target
+    for each row execute function insert_deleted_resource('project_id');
+  drop trigger insert_deleted_resource on session;
+  create trigger insert_deleted_resource after delete on session
+    for each row execute function insert_deleted_resource('project_id', 'user_id');
+  drop trigger insert_deleted_resource on session_recording;
+  create trigger insert_deleted_resource after delete on session_recording
+    for each row execute function insert_deleted_resource('project_id', 'user_id');
+  drop trigger insert_deleted_resource on auth_method;
+  create trigger insert_deleted_resource after delete on auth_method
+    for each row execute function insert_deleted_resource('scope_id');
+  drop trigger insert_deleted_resource on auth_account;
+  create trigger insert_deleted_resource after delete on auth_account
+    for each row execute function insert_deleted_resource('scope_id', 'auth_method_id');
+  drop trigger insert_deleted_resource on auth_managed_group;
+  create trigger insert_deleted_resource after delete on auth_managed_group
+    for each row execute function insert_deleted_resource('', 'auth_method_id');
+  drop trigger insert_deleted_resource on auth_token;
+  create trigger insert_deleted_resource after delete on auth_token
+    for each row execute function insert_deleted_auth_token();
+  drop trigger insert_deleted_resource on auth_api_key;
+  create trigger insert_deleted_resource after delete on auth_api_key
+    for each row execute function insert_deleted_resource('scope_id', 'service_account_id');
+  drop trigger insert_deleted_resource on credential_store;
+  create trigger insert_deleted_resource after delete on credential_store
+    for each row execute function insert_deleted_resource('project_id');
+  drop trigger insert_deleted_resource on credential_library;
+  create trigger insert_deleted_resource after delete on credential_library
+    for each row execute function insert_deleted_resource('project_id', 'store_id');
+  drop trigger insert_deleted_resource on credential_static;
+  create trigger insert_deleted_resource after delete on credential_static
+    for each row execute function insert_deleted_resource('project_id', 'store_id');
+  drop trigger insert_deleted_resource on host_catalog;
+  create trigger insert_deleted_resource after delete on host_catalog
+    for each row execute function insert_deleted_resource('project_id');
+  drop trigger insert_deleted_resource on host_set;
+  create trigger insert_deleted_resource after delete on host_set
+    for each row execute function insert_deleted_resource('', 'catalog_id');
+  drop trigger insert_deleted_resource on host;
+  create trigger insert_deleted_resource after delete on host
+    for each row execute function insert_deleted_resource('', 'catalog_id');
+  drop trigger insert_deleted_resource on iam_scope;
+  create trigger insert_deleted_resource after delete on iam_scope
+    for each row execute function insert_deleted_resource('parent_id');
+  drop trigger insert_deleted_resource on iam_user;
+  create trigger insert_deleted_resource after delete on iam_user
+    for each row execute function insert_deleted_resource('scope_id');
+  drop trigger insert_deleted_resource on iam_group;
+  create trigger insert_deleted_resource after delete on iam_group
+    for each row execute function insert_deleted_resource('scope_id');
+  drop trigger insert_deleted_resource on iam_role;
+  create trigger insert_deleted_resource after delete on iam_role
+    for each row execute function insert_deleted_resource('scope_id');
+  drop trigger insert_deleted_resource on server_worker;
+  create trigger insert_deleted_resource after delete on server_worker
+    for each row execute function insert_deleted_resource('scope_id');
+
+commit;
@@ -0,0 +1,31 @@
+-- Copyright (c) HashiCorp, Inc.
+-- SPDX-License-Identifier: MPL-2.0
+
+begin;
+
+  -- drop constraint so we can add totp enrollment required
+  alter table auth_token_status_enm
+    drop constraint name_only_predefined_auth_token_states_allowed;
+
+  -- A token with the totp enrollment required status is issued to an account
+  -- of a password auth method with mfa_required set that has no confirmed
+  -- totp secret. It only allows the account to enroll and confirm one, and is
+  -- issued once the secret is confirmed.
+  -- This replaces the constraint defined in 2/05_authtoken.up.sql
+  alter table auth_token_status_enm
+    add constraint name_only_predefined_auth_token_states_allowed
+      check (
+        name in (
+          'auth token pending',
+          'token issued',
+          'authentication failed',
+          'system error',
+          'totp enrollment required'
+        )
+      );
+
+  insert into auth_token_status_enm (name)
+  values
+    ('totp enrollment required');
+
+commit;
@@ -0,0 +1,105 @@
+-- Copyright (c) HashiCorp, Inc.
+-- SPDX-License-Identifier: MPL-2.0
+
+begin;
+
+  -- ca_cert contains the PEM encoded certificates of the certificate
+  -- authorities the database server of the target is verified against.
+  -- Credentials are only injected into connections to a database server
+  -- presenting a certificate signed by one of these authorities.
+  alter table target_postgres
+    add column ca_cert text
+      constraint ca_cert_must_not_be_empty
+        check(length(trim(ca_cert)) > 0);
+
+  -- Replaces target_all_subtypes defined in 83/01_target_ssh_host_keys.up.sql
+  create or replace view target_all_subtypes as
+  select public_id,
+         project_id,
+         name,
+         description,
+         default_port,
+         session_max_seconds,
+         session_connection_limit,
+         version,
+         create_time,
+         update_time,
+         worker_filter,
+         egress_worker_filter,
+         ingress_worker_filter,
+         'tcp' as type,
+         enable_session_recording,
+         false as use_tls,
+         null as allowed_paths,
+         session_idle_timeout,
+         null as host_keys,
+         null as ca_cert
+    from target_tcp
+   union
+  select public_id,
+         project_id,
+         name,
+         description,
+         default_port,
+         session_max_seconds,
+         session_connection_limit,
+         version,
+         create_time,
+         update_time,
+         worker_filter,
+         egress_worker_filter,
+         ingress_worker_filter,
+         'ssh' as type,
+         enable_session_recording,
+         false as use_tls,
+         null as allowed_paths,
+         session_idle_timeout,
+         host_keys,
+         null as ca_cert
+    from target_ssh
+   union
+  select public_id,
+         project_id,
+         name,
+         description,
+         default_port,
+         session_max_seconds,
+         session_connection_limit,
+         version,
+         create_time,
+         update_time,
+         worker_filter,
+         egress_worker_filter,
+         ingress_worker_filter,
+         'http' as type,
+         enable_session_recording,
+         use_tls,
+         allowed_paths,
+         session_idle_timeout,
+         null as host_keys,
+         null as ca_cert
+    from target_http
+   union
+  select public_id,
+         project_id,
+         name,
+         description,
+         default_port,
+         session_max_seconds,
+         session_connection_limit,
+         version,
+         create_time,
+         update_time,
+         worker_filter,
+         egress_worker_filter,
+         ingress_worker_filter,
+         'postgres' as type,
+         enable_session_recording,
+         false as use_tls,
+         null as allowed_paths,
+         session_idle_timeout,
+         null as host_keys,
+         ca_cert
+    from target_postgres;
+
+commit;
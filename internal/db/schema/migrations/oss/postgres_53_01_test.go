@@ -89,7 +89,17 @@ values
 	require.NoError(t, err)
 	assert.Equal(t, 1, num)
 
-	role := iam.TestRole(t, conn, pId)
+	// The role is inserted directly since the iam role schema has changed
+	// since this migration.
+	roleId := "r_1234567890"
+	num, err = rw.Exec(ctx, `
+insert into iam_role
+	(public_id, scope_id)
+values
+	(?, ?)
+	`, []any{roleId, pId})
+	require.NoError(t, err)
+	assert.Equal(t, 1, num)
 
 	initialGrants := map[string]bool{
 		"id=*;type=target;actions=read,authorize-session,add-host-sets,add-host-sets":            true,
@@ -100,11 +110,11 @@ values
 
 	// Insert grants
 	for grant := range initialGrants {
-		iam.TestRoleGrant(t, conn, role.GetPublicId(), grant)
+		iam.TestRoleGrant(t, conn, roleId, grant)
 	}
 
-	// Fetch the role
-	_, _, initialRoleGrants, err := iamRepo.LookupRole(ctx, role.GetPublicId())
+	// Fetch the role grants
+	initialRoleGrants, err := iamRepo.ListRoleGrants(ctx, roleId)
 	require.NoError(t, err)
 	require.Len(t, initialRoleGrants, len(initialGrants))
 
@@ -150,7 +160,7 @@ values
 	}
 
 	// Fetch the role again
-	_, _, updatedRoleGrants, err := iamRepo.LookupRole(ctx, role.GetPublicId())
+	updatedRoleGrants, err := iamRepo.ListRoleGrants(ctx, roleId)
 	require.NoError(t, err)
 	require.Len(t, updatedRoleGrants, len(updatedGrants))
 
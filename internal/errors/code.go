@@ -71,6 +71,29 @@ const (
 	// new passwords are equal.
 	PasswordsEqual Code = 203
 
+	// PasswordAccountLocked is returned from Authenticate when the account
+	// is locked after too many failed authentication attempts.
+	PasswordAccountLocked Code = 204
+
+	// PasswordTotpInvalid is returned when a TOTP code does not match the
+	// TOTP secret of an account.
+	PasswordTotpInvalid Code = 205
+
+	// PasswordTooWeak results from attempting to set a password which does
+	// not contain enough character classes.
+	PasswordTooWeak Code = 206
+
+	// PasswordDenied results from attempting to set a common password.
+	PasswordDenied Code = 207
+
+	// PasswordReused results from attempting to set a password which is in
+	// the password history of the account.
+	PasswordReused Code = 208
+
+	// PasswordExpired is returned from Authenticate when the password of the
+	// account is older than the max password age of its auth method.
+	PasswordExpired Code = 209
+
 	Encrypt Code = 300 // Encrypt represents an error occurred during the underlying encryption process
 	Decrypt Code = 301 // Decrypt represents an error occurred during the underlying decryption process
 	Encode  Code = 302 // Encode represents an error occurred during the underlying encoding/marshaling process
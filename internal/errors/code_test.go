@@ -165,6 +165,36 @@ func TestCode_Both_String_Info(t *testing.T) {
 			c:    PasswordsEqual,
 			want: PasswordsEqual,
 		},
+		{
+			name: "PasswordAccountLocked",
+			c:    PasswordAccountLocked,
+			want: PasswordAccountLocked,
+		},
+		{
+			name: "PasswordTotpInvalid",
+			c:    PasswordTotpInvalid,
+			want: PasswordTotpInvalid,
+		},
+		{
+			name: "PasswordTooWeak",
+			c:    PasswordTooWeak,
+			want: PasswordTooWeak,
+		},
+		{
+			name: "PasswordDenied",
+			c:    PasswordDenied,
+			want: PasswordDenied,
+		},
+		{
+			name: "PasswordReused",
+			c:    PasswordReused,
+			want: PasswordReused,
+		},
+		{
+			name: "PasswordExpired",
+			c:    PasswordExpired,
+			want: PasswordExpired,
+		},
 		{
 			name: "Encrypt",
 			c:    Encrypt,
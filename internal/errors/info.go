@@ -119,6 +119,30 @@ var errorCodeInfo = map[Code]Info{
 		Message: "old and new password are equal",
 		Kind:    Password,
 	},
+	PasswordAccountLocked: {
+		Message: "account is locked",
+		Kind:    Password,
+	},
+	PasswordTotpInvalid: {
+		Message: "invalid totp code",
+		Kind:    Password,
+	},
+	PasswordTooWeak: {
+		Message: "password too weak",
+		Kind:    Password,
+	},
+	PasswordDenied: {
+		Message: "password is too common",
+		Kind:    Password,
+	},
+	PasswordReused: {
+		Message: "password was used before",
+		Kind:    Password,
+	},
+	PasswordExpired: {
+		Message: "password expired",
+		Kind:    Password,
+	},
 	Encrypt: {
 		Message: "error occurred during encrypt",
 		Kind:    Encryption,
@@ -4,6 +4,7 @@
 package filter
 
 import (
+	"database/sql"
 	"reflect"
 	"testing"
 	"time"
@@ -54,3 +55,108 @@ func TestWellKnownTypeFilterHook(t *testing.T) {
 		assert.Equal(expect, actual.Interface())
 	})
 }
+
+func TestWhereClause(t *testing.T) {
+	columns := Columns{
+		"/item/type":     "type",
+		"/item/name":     "name",
+		"/item/scope_id": "project_id",
+	}
+	tests := []struct {
+		name       string
+		expression string
+		columns    Columns
+		wantWhere  string
+		wantArgs   []any
+		wantErr    bool
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name:       "no-columns",
+			expression: `"/item/type" == "tcp"`,
+		},
+		{
+			name:       "equal",
+			expression: `"/item/type" == "tcp"`,
+			columns:    columns,
+			wantWhere:  "type = @filter_0",
+			wantArgs:   []any{sql.Named("filter_0", "tcp")},
+		},
+		{
+			name:       "bexpr-selector",
+			expression: `item.type == "tcp"`,
+			columns:    columns,
+			wantWhere:  "type = @filter_0",
+			wantArgs:   []any{sql.Named("filter_0", "tcp")},
+		},
+		{
+			name:       "and",
+			expression: `"/item/type" == "tcp" and "/item/scope_id" == "p_1234567890"`,
+			columns:    columns,
+			wantWhere:  "(type = @filter_0 and project_id = @filter_1)",
+			wantArgs:   []any{sql.Named("filter_0", "tcp"), sql.Named("filter_1", "p_1234567890")},
+		},
+		{
+			name:       "and-with-remainder",
+			expression: `"/item/description" matches "foo" and "/item/name" == "bar"`,
+			columns:    columns,
+			wantWhere:  "name = @filter_0",
+			wantArgs:   []any{sql.Named("filter_0", "bar")},
+		},
+		{
+			name:       "or",
+			expression: `"/item/type" == "tcp" or "/item/type" == "ssh"`,
+			columns:    columns,
+			wantWhere:  "(type = @filter_0 or type = @filter_1)",
+			wantArgs:   []any{sql.Named("filter_0", "tcp"), sql.Named("filter_1", "ssh")},
+		},
+		{
+			name:       "or-with-remainder",
+			expression: `"/item/type" == "tcp" or "/item/description" == "foo"`,
+			columns:    columns,
+		},
+		{
+			name:       "or-with-remainder-and",
+			expression: `("/item/type" == "tcp" or "/item/description" == "foo") and "/item/name" == "bar"`,
+			columns:    columns,
+			wantWhere:  "name = @filter_1",
+			wantArgs:   []any{sql.Named("filter_1", "bar")},
+		},
+		{
+			name:       "not",
+			expression: `not "/item/type" == "tcp"`,
+			columns:    columns,
+		},
+		{
+			name:       "not-equal",
+			expression: `"/item/type" != "tcp"`,
+			columns:    columns,
+		},
+		{
+			name:       "equal-empty",
+			expression: `"/item/name" == ""`,
+			columns:    columns,
+		},
+		{
+			name:       "invalid",
+			expression: `"/item/type" ==`,
+			columns:    columns,
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			where, args, err := WhereClause(tt.expression, tt.columns)
+			if tt.wantErr {
+				require.Error(err)
+				return
+			}
+			require.NoError(err)
+			assert.Equal(tt.wantWhere, where)
+			assert.Equal(tt.wantArgs, args)
+		})
+	}
+}
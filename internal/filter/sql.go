@@ -0,0 +1,91 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package filter
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-bexpr/grammar"
+)
+
+// Columns maps the fields of a listed item, identified by their JSON pointer
+// such as "/item/name", to the sql expressions returning their values in the
+// query listing the items. Only string fields can be mapped.
+type Columns map[string]string
+
+// WhereClause translates a filter expression into a sql where clause, using
+// named args, which is true for every item the expression can match. Only
+// comparisons of a field mapped in columns to a non-empty string with == are
+// translated. The untranslated parts of the expression are treated as true,
+// so the where clause narrows down the items to evaluate the filter against
+// but does not replace the evaluation. An empty where clause is returned if no
+// part of the expression can be translated.
+func WhereClause(expression string, columns Columns) (string, []any, error) {
+	const op = "filter.WhereClause"
+	if expression == "" || len(columns) == 0 {
+		return "", nil, nil
+	}
+	ast, err := grammar.Parse("", []byte(expression))
+	if err != nil {
+		return "", nil, fmt.Errorf("%s: %w", op, err)
+	}
+	expr, ok := ast.(grammar.Expression)
+	if !ok {
+		return "", nil, fmt.Errorf("%s: unexpected expression type %T", op, ast)
+	}
+	b := &whereBuilder{columns: columns}
+	where, args, ok := b.translate(expr)
+	if !ok {
+		return "", nil, nil
+	}
+	return where, args, nil
+}
+
+type whereBuilder struct {
+	columns Columns
+	argCnt  int
+}
+
+// translate returns the where clause of expr and its args. It returns false if
+// the clause would always be true.
+func (b *whereBuilder) translate(expr grammar.Expression) (string, []any, bool) {
+	switch e := expr.(type) {
+	case *grammar.BinaryExpression:
+		left, leftArgs, leftOk := b.translate(e.Left)
+		right, rightArgs, rightOk := b.translate(e.Right)
+		switch e.Operator {
+		case grammar.BinaryOpAnd:
+			switch {
+			case leftOk && rightOk:
+				return "(" + left + " and " + right + ")", append(leftArgs, rightArgs...), true
+			case leftOk:
+				return left, leftArgs, true
+			case rightOk:
+				return right, rightArgs, true
+			}
+		case grammar.BinaryOpOr:
+			// Either side can match items the other side does not, so the
+			// clause is only restrictive if both sides are.
+			if leftOk && rightOk {
+				return "(" + left + " or " + right + ")", append(leftArgs, rightArgs...), true
+			}
+		}
+	case *grammar.MatchExpression:
+		// Negations are not translated: an empty field of an item can be
+		// null in the database, and != would not match it.
+		if e.Operator != grammar.MatchEqual || e.Value == nil || e.Value.Raw == "" {
+			return "", nil, false
+		}
+		column, ok := b.columns["/"+strings.Join(e.Selector.Path, "/")]
+		if !ok {
+			return "", nil, false
+		}
+		name := fmt.Sprintf("filter_%d", b.argCnt)
+		b.argCnt++
+		return fmt.Sprintf("%s = @%s", column, name), []any{sql.Named(name, e.Value.Raw)}, true
+	}
+	return "", nil, false
+}
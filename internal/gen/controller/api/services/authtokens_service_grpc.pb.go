@@ -30,6 +30,10 @@ type AuthTokenServiceClient interface {
 	// DeleteAuthToken removes a Auth Token from Boundary. If the provided
 	// Auth Token id is malformed or not provided an error is returned.
 	DeleteAuthToken(ctx context.Context, in *DeleteAuthTokenRequest, opts ...grpc.CallOption) (*DeleteAuthTokenResponse, error)
+	// RefreshAuthToken extends the expiration time of an Auth Token that is
+	// neither expired nor stale. The expiration time is never extended past the
+	// maximum lifetime of an Auth Token configured on the controller.
+	RefreshAuthToken(ctx context.Context, in *RefreshAuthTokenRequest, opts ...grpc.CallOption) (*RefreshAuthTokenResponse, error)
 }
 
 type authTokenServiceClient struct {
@@ -67,6 +71,15 @@ func (c *authTokenServiceClient) DeleteAuthToken(ctx context.Context, in *Delete
 	return out, nil
 }
 
+func (c *authTokenServiceClient) RefreshAuthToken(ctx context.Context, in *RefreshAuthTokenRequest, opts ...grpc.CallOption) (*RefreshAuthTokenResponse, error) {
+	out := new(RefreshAuthTokenResponse)
+	err := c.cc.Invoke(ctx, "/controller.api.services.v1.AuthTokenService/RefreshAuthToken", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // AuthTokenServiceServer is the server API for AuthTokenService service.
 // All implementations must embed UnimplementedAuthTokenServiceServer
 // for forward compatibility
@@ -83,6 +96,10 @@ type AuthTokenServiceServer interface {
 	// DeleteAuthToken removes a Auth Token from Boundary. If the provided
 	// Auth Token id is malformed or not provided an error is returned.
 	DeleteAuthToken(context.Context, *DeleteAuthTokenRequest) (*DeleteAuthTokenResponse, error)
+	// RefreshAuthToken extends the expiration time of an Auth Token that is
+	// neither expired nor stale. The expiration time is never extended past the
+	// maximum lifetime of an Auth Token configured on the controller.
+	RefreshAuthToken(context.Context, *RefreshAuthTokenRequest) (*RefreshAuthTokenResponse, error)
 	mustEmbedUnimplementedAuthTokenServiceServer()
 }
 
@@ -99,6 +116,9 @@ func (UnimplementedAuthTokenServiceServer) ListAuthTokens(context.Context, *List
 func (UnimplementedAuthTokenServiceServer) DeleteAuthToken(context.Context, *DeleteAuthTokenRequest) (*DeleteAuthTokenResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteAuthToken not implemented")
 }
+func (UnimplementedAuthTokenServiceServer) RefreshAuthToken(context.Context, *RefreshAuthTokenRequest) (*RefreshAuthTokenResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RefreshAuthToken not implemented")
+}
 func (UnimplementedAuthTokenServiceServer) mustEmbedUnimplementedAuthTokenServiceServer() {}
 
 // UnsafeAuthTokenServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -166,6 +186,24 @@ func _AuthTokenService_DeleteAuthToken_Handler(srv interface{}, ctx context.Cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AuthTokenService_RefreshAuthToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RefreshAuthTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthTokenServiceServer).RefreshAuthToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/controller.api.services.v1.AuthTokenService/RefreshAuthToken",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthTokenServiceServer).RefreshAuthToken(ctx, req.(*RefreshAuthTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // AuthTokenService_ServiceDesc is the grpc.ServiceDesc for AuthTokenService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -185,6 +223,10 @@ var AuthTokenService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteAuthToken",
 			Handler:    _AuthTokenService_DeleteAuthToken_Handler,
 		},
+		{
+			MethodName: "RefreshAuthToken",
+			Handler:    _AuthTokenService_RefreshAuthToken_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "controller/api/services/v1/authtokens_service.proto",
@@ -28,10 +28,20 @@ type SessionServiceClient interface {
 	// the Sessions being retrieved. If the scope ID is missing, malformed, or
 	// reference a non existing scope, an error is returned.
 	ListSessions(ctx context.Context, in *ListSessionsRequest, opts ...grpc.CallOption) (*ListSessionsResponse, error)
+	// ListSessionConnections returns the connections of an existing Session,
+	// including the connections that are closed. An error is returned if the
+	// request attempts to list the connections of a Session that does not
+	// exist.
+	ListSessionConnections(ctx context.Context, in *ListSessionConnectionsRequest, opts ...grpc.CallOption) (*ListSessionConnectionsResponse, error)
 	// CancelSession cancels an existing Session in boundary.  An error
 	// is returned if the request attempts to cancel a Session that does
 	// not exist.
 	CancelSession(ctx context.Context, in *CancelSessionRequest, opts ...grpc.CallOption) (*CancelSessionResponse, error)
+	// ExtendSession extends the expiration time of an existing Session by the
+	// requested duration, and the workers proxying the connections of the
+	// Session keep them open until the new expiration time. An error is
+	// returned if the Session does not exist or is no longer active.
+	ExtendSession(ctx context.Context, in *ExtendSessionRequest, opts ...grpc.CallOption) (*ExtendSessionResponse, error)
 }
 
 type sessionServiceClient struct {
@@ -60,6 +70,15 @@ func (c *sessionServiceClient) ListSessions(ctx context.Context, in *ListSession
 	return out, nil
 }
 
+func (c *sessionServiceClient) ListSessionConnections(ctx context.Context, in *ListSessionConnectionsRequest, opts ...grpc.CallOption) (*ListSessionConnectionsResponse, error) {
+	out := new(ListSessionConnectionsResponse)
+	err := c.cc.Invoke(ctx, "/controller.api.services.v1.SessionService/ListSessionConnections", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *sessionServiceClient) CancelSession(ctx context.Context, in *CancelSessionRequest, opts ...grpc.CallOption) (*CancelSessionResponse, error) {
 	out := new(CancelSessionResponse)
 	err := c.cc.Invoke(ctx, "/controller.api.services.v1.SessionService/CancelSession", in, out, opts...)
@@ -69,6 +88,15 @@ func (c *sessionServiceClient) CancelSession(ctx context.Context, in *CancelSess
 	return out, nil
 }
 
+func (c *sessionServiceClient) ExtendSession(ctx context.Context, in *ExtendSessionRequest, opts ...grpc.CallOption) (*ExtendSessionResponse, error) {
+	out := new(ExtendSessionResponse)
+	err := c.cc.Invoke(ctx, "/controller.api.services.v1.SessionService/ExtendSession", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // SessionServiceServer is the server API for SessionService service.
 // All implementations must embed UnimplementedSessionServiceServer
 // for forward compatibility
@@ -83,10 +111,20 @@ type SessionServiceServer interface {
 	// the Sessions being retrieved. If the scope ID is missing, malformed, or
 	// reference a non existing scope, an error is returned.
 	ListSessions(context.Context, *ListSessionsRequest) (*ListSessionsResponse, error)
+	// ListSessionConnections returns the connections of an existing Session,
+	// including the connections that are closed. An error is returned if the
+	// request attempts to list the connections of a Session that does not
+	// exist.
+	ListSessionConnections(context.Context, *ListSessionConnectionsRequest) (*ListSessionConnectionsResponse, error)
 	// CancelSession cancels an existing Session in boundary.  An error
 	// is returned if the request attempts to cancel a Session that does
 	// not exist.
 	CancelSession(context.Context, *CancelSessionRequest) (*CancelSessionResponse, error)
+	// ExtendSession extends the expiration time of an existing Session by the
+	// requested duration, and the workers proxying the connections of the
+	// Session keep them open until the new expiration time. An error is
+	// returned if the Session does not exist or is no longer active.
+	ExtendSession(context.Context, *ExtendSessionRequest) (*ExtendSessionResponse, error)
 	mustEmbedUnimplementedSessionServiceServer()
 }
 
@@ -100,9 +138,15 @@ func (UnimplementedSessionServiceServer) GetSession(context.Context, *GetSession
 func (UnimplementedSessionServiceServer) ListSessions(context.Context, *ListSessionsRequest) (*ListSessionsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListSessions not implemented")
 }
+func (UnimplementedSessionServiceServer) ListSessionConnections(context.Context, *ListSessionConnectionsRequest) (*ListSessionConnectionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSessionConnections not implemented")
+}
 func (UnimplementedSessionServiceServer) CancelSession(context.Context, *CancelSessionRequest) (*CancelSessionResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CancelSession not implemented")
 }
+func (UnimplementedSessionServiceServer) ExtendSession(context.Context, *ExtendSessionRequest) (*ExtendSessionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExtendSession not implemented")
+}
 func (UnimplementedSessionServiceServer) mustEmbedUnimplementedSessionServiceServer() {}
 
 // UnsafeSessionServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -152,6 +196,24 @@ func _SessionService_ListSessions_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _SessionService_ListSessionConnections_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSessionConnectionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SessionServiceServer).ListSessionConnections(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/controller.api.services.v1.SessionService/ListSessionConnections",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SessionServiceServer).ListSessionConnections(ctx, req.(*ListSessionConnectionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _SessionService_CancelSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(CancelSessionRequest)
 	if err := dec(in); err != nil {
@@ -170,6 +232,24 @@ func _SessionService_CancelSession_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _SessionService_ExtendSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExtendSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SessionServiceServer).ExtendSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/controller.api.services.v1.SessionService/ExtendSession",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SessionServiceServer).ExtendSession(ctx, req.(*ExtendSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // SessionService_ServiceDesc is the grpc.ServiceDesc for SessionService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -185,10 +265,18 @@ var SessionService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListSessions",
 			Handler:    _SessionService_ListSessions_Handler,
 		},
+		{
+			MethodName: "ListSessionConnections",
+			Handler:    _SessionService_ListSessionConnections_Handler,
+		},
 		{
 			MethodName: "CancelSession",
 			Handler:    _SessionService_CancelSession_Handler,
 		},
+		{
+			MethodName: "ExtendSession",
+			Handler:    _SessionService_ExtendSession_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "controller/api/services/v1/session_service.proto",
@@ -3,6 +3,8 @@
 
 package host
 
+import "github.com/hashicorp/boundary/internal/db"
+
 // GetOpts - iterate the inbound Options and return a struct
 func GetOpts(opt ...Option) (options, error) {
 	opts := getDefaultOptions()
@@ -22,6 +24,7 @@ type options struct {
 	WithLimit             int
 	WithOrderByCreateTime bool
 	Ascending             bool
+	WithPage              *db.Page
 }
 
 func getDefaultOptions() options {
@@ -47,3 +50,12 @@ func WithOrderByCreateTime(ascending bool) Option {
 		return nil
 	}
 }
+
+// WithPage provides an option to list one page of resources. Use WithLimit to
+// set the size of the page.
+func WithPage(p *db.Page) Option {
+	return func(o *options) error {
+		o.WithPage = p
+		return nil
+	}
+}
@@ -3,7 +3,10 @@
 
 package plugin
 
-import "google.golang.org/protobuf/types/known/structpb"
+import (
+	"github.com/hashicorp/boundary/internal/db"
+	"google.golang.org/protobuf/types/known/structpb"
+)
 
 // getOpts - iterate the inbound Options and return a struct
 func getOpts(opt ...Option) options {
@@ -32,6 +35,7 @@ type options struct {
 	withLimit               int
 	withSetIds              []string
 	withSecretsHmac         []byte
+	withPage                *db.Page
 }
 
 func getDefaultOptions() options {
@@ -119,6 +123,14 @@ func WithLimit(l int) Option {
 	}
 }
 
+// WithPage provides an option to list one page of resources. Use WithLimit to
+// set the size of the page.
+func WithPage(p *db.Page) Option {
+	return func(o *options) {
+		o.withPage = p
+	}
+}
+
 // WithSetIds provides the ability to restrict lookups to particular matching
 // sets.
 func WithSetIds(with []string) Option {
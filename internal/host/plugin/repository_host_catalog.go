@@ -589,7 +589,8 @@ func (r *Repository) LookupCatalog(ctx context.Context, id string, _ ...Option)
 	return c, plg, nil
 }
 
-// ListCatalogs returns a slice of HostCatalogs for the project IDs. WithLimit is the only option supported.
+// ListCatalogs returns a slice of HostCatalogs for the project IDs. WithLimit
+// and WithPage are the only options supported.
 func (r *Repository) ListCatalogs(ctx context.Context, projectIds []string, opt ...host.Option) ([]*HostCatalog, []*hostplugin.Plugin, error) {
 	const op = "plugin.(Repository).ListCatalogs"
 	if len(projectIds) == 0 {
@@ -605,7 +606,7 @@ func (r *Repository) ListCatalogs(ctx context.Context, projectIds []string, opt
 		limit = opts.WithLimit
 	}
 	var hostCatalogs []*HostCatalog
-	if err := r.reader.SearchWhere(ctx, &hostCatalogs, "project_id in (?)", []any{projectIds}, db.WithLimit(limit)); err != nil {
+	if err := r.reader.SearchWhere(ctx, &hostCatalogs, "project_id in (?)", []any{projectIds}, db.WithLimit(limit), db.WithPage(opts.WithPage)); err != nil {
 		return nil, nil, errors.Wrap(ctx, err, op)
 	}
 	plgIds := make([]string, 0, len(hostCatalogs))
@@ -593,8 +593,8 @@ func (r *Repository) LookupSet(ctx context.Context, publicId string, _ ...host.O
 	return sets[0], plg, nil
 }
 
-// ListSets returns a slice of HostSets for the catalogId. WithLimit is the
-// only option supported.
+// ListSets returns a slice of HostSets for the catalogId. WithLimit and
+// WithPage are the only options supported.
 func (r *Repository) ListSets(ctx context.Context, catalogId string, opt ...host.Option) ([]*HostSet, *hostplugin.Plugin, error) {
 	const op = "plugin.(Repository).ListSets"
 	if catalogId == "" {
@@ -713,7 +713,7 @@ func (r *Repository) getSets(ctx context.Context, publicId string, catalogId str
 		where, args = "catalog_id = ?", append(args, catalogId)
 	}
 
-	dbArgs := []db.Option{db.WithLimit(limit)}
+	dbArgs := []db.Option{db.WithLimit(limit), db.WithPage(opts.WithPage)}
 
 	if opts.WithOrderByCreateTime {
 		if opts.Ascending {
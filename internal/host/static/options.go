@@ -3,6 +3,8 @@
 
 package static
 
+import "github.com/hashicorp/boundary/internal/db"
+
 // getOpts - iterate the inbound Options and return a struct
 func getOpts(opt ...Option) options {
 	opts := getDefaultOptions()
@@ -22,6 +24,7 @@ type options struct {
 	withLimit       int
 	withAddress     string
 	withPublicId    string
+	withPage        *db.Page
 }
 
 func getDefaultOptions() options {
@@ -55,6 +58,14 @@ func WithLimit(l int) Option {
 	}
 }
 
+// WithPage provides an option to list one page of resources. Use WithLimit to
+// set the size of the page.
+func WithPage(p *db.Page) Option {
+	return func(o *options) {
+		o.withPage = p
+	}
+}
+
 // WithAddress provides an optional address.
 func WithAddress(address string) Option {
 	return func(o *options) {
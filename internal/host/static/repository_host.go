@@ -7,11 +7,13 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/boundary/internal/db"
 	"github.com/hashicorp/boundary/internal/errors"
 	"github.com/hashicorp/boundary/internal/kms"
 	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/hashicorp/boundary/internal/pagination"
 	"github.com/hashicorp/go-dbw"
 )
 
@@ -226,7 +228,7 @@ func (r *Repository) LookupHost(ctx context.Context, publicId string, opt ...Opt
 }
 
 // ListHosts returns a slice of Hosts for the catalogId.
-// WithLimit is the only option supported.
+// WithLimit and WithPage are the only options supported.
 func (r *Repository) ListHosts(ctx context.Context, catalogId string, opt ...Option) ([]*Host, error) {
 	const op = "static.(Repository).ListHosts"
 	if catalogId == "" {
@@ -239,7 +241,7 @@ func (r *Repository) ListHosts(ctx context.Context, catalogId string, opt ...Opt
 		limit = opts.withLimit
 	}
 	var aggs []*hostAgg
-	err := r.reader.SearchWhere(ctx, &aggs, "catalog_id = ?", []any{catalogId}, db.WithLimit(limit))
+	err := r.reader.SearchWhere(ctx, &aggs, "catalog_id = ?", []any{catalogId}, db.WithLimit(limit), db.WithPage(opts.withPage))
 	if err != nil {
 		return nil, errors.Wrap(ctx, err, op)
 	}
@@ -251,6 +253,17 @@ func (r *Repository) ListHosts(ctx context.Context, catalogId string, opt ...Opt
 	return hosts, nil
 }
 
+// ListDeletedHostIds returns the hosts of all subtypes of the host catalog
+// deleted after since.
+func (r *Repository) ListDeletedHostIds(ctx context.Context, catalogId string, since time.Time) ([]*pagination.DeletedResource, error) {
+	const op = "static.(Repository).ListDeletedHostIds"
+	deleted, err := pagination.ListDeletedIdsByParent(ctx, r.reader, "host", catalogId, since)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return deleted, nil
+}
+
 // DeleteHost deletes the host for the provided id from the repository
 // returning a count of the number of records deleted. All options are
 // ignored.
@@ -7,11 +7,13 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/boundary/internal/db"
 	"github.com/hashicorp/boundary/internal/errors"
 	"github.com/hashicorp/boundary/internal/kms"
 	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/hashicorp/boundary/internal/pagination"
 )
 
 // CreateCatalog inserts c into the repository and returns a new
@@ -203,7 +205,8 @@ func (r *Repository) LookupCatalog(ctx context.Context, id string, opt ...Option
 	return c, nil
 }
 
-// ListCatalogs returns a slice of HostCatalogs for the project IDs. WithLimit is the only option supported.
+// ListCatalogs returns a slice of HostCatalogs for the project IDs. WithLimit
+// and WithPage are the only options supported.
 func (r *Repository) ListCatalogs(ctx context.Context, projectIds []string, opt ...Option) ([]*HostCatalog, error) {
 	const op = "static.(Repository).ListCatalogs"
 	if len(projectIds) == 0 {
@@ -216,13 +219,24 @@ func (r *Repository) ListCatalogs(ctx context.Context, projectIds []string, opt
 		limit = opts.withLimit
 	}
 	var hostCatalogs []*HostCatalog
-	err := r.reader.SearchWhere(ctx, &hostCatalogs, "project_id in (?)", []any{projectIds}, db.WithLimit(limit))
+	err := r.reader.SearchWhere(ctx, &hostCatalogs, "project_id in (?)", []any{projectIds}, db.WithLimit(limit), db.WithPage(opts.withPage))
 	if err != nil {
 		return nil, errors.Wrap(ctx, err, op)
 	}
 	return hostCatalogs, nil
 }
 
+// ListDeletedCatalogIds returns the host catalogs of all subtypes in one of
+// projectIds deleted after since.
+func (r *Repository) ListDeletedCatalogIds(ctx context.Context, projectIds []string, since time.Time) ([]*pagination.DeletedResource, error) {
+	const op = "static.(Repository).ListDeletedCatalogIds"
+	deleted, err := pagination.ListDeletedIds(ctx, r.reader, "host_catalog", projectIds, since)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return deleted, nil
+}
+
 // DeleteCatalog deletes id from the repository returning a count of the
 // number of records deleted.
 func (r *Repository) DeleteCatalog(ctx context.Context, id string, opt ...Option) (int, error) {
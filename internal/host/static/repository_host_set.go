@@ -7,12 +7,14 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/boundary/internal/db"
 	"github.com/hashicorp/boundary/internal/errors"
 	"github.com/hashicorp/boundary/internal/host"
 	"github.com/hashicorp/boundary/internal/kms"
 	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/hashicorp/boundary/internal/pagination"
 	"github.com/hashicorp/go-dbw"
 )
 
@@ -247,8 +249,8 @@ func (r *Repository) lookupSet(ctx context.Context, publicId string, opt ...Opti
 	return s, hosts, nil
 }
 
-// ListSets returns a slice of HostSets for the catalogId. WithLimit is the
-// only option supported.
+// ListSets returns a slice of HostSets for the catalogId. WithLimit and
+// WithPage are the only options supported.
 func (r *Repository) ListSets(ctx context.Context, catalogId string, opt ...Option) ([]*HostSet, error) {
 	const op = "static.(Repository).ListSets"
 	if catalogId == "" {
@@ -261,13 +263,24 @@ func (r *Repository) ListSets(ctx context.Context, catalogId string, opt ...Opti
 		limit = opts.withLimit
 	}
 	var sets []*HostSet
-	err := r.reader.SearchWhere(ctx, &sets, "catalog_id = ?", []any{catalogId}, db.WithLimit(limit))
+	err := r.reader.SearchWhere(ctx, &sets, "catalog_id = ?", []any{catalogId}, db.WithLimit(limit), db.WithPage(opts.withPage))
 	if err != nil {
 		return nil, errors.Wrap(ctx, err, op)
 	}
 	return sets, nil
 }
 
+// ListDeletedSetIds returns the host sets of all subtypes of the host catalog
+// deleted after since.
+func (r *Repository) ListDeletedSetIds(ctx context.Context, catalogId string, since time.Time) ([]*pagination.DeletedResource, error) {
+	const op = "static.(Repository).ListDeletedSetIds"
+	deleted, err := pagination.ListDeletedIdsByParent(ctx, r.reader, "host_set", catalogId, since)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return deleted, nil
+}
+
 // DeleteSet deletes the host set for the provided id from the repository
 // returning a count of the number of records deleted. All options are
 // ignored.
@@ -16,6 +16,10 @@ const (
 	GroupPrefix     = "g"
 	RolePrefix      = "r"
 	RoleGrantPrefix = "rg"
+
+	// ServiceAccountPrefix is the prefix of service account ids. Service
+	// accounts are stored in iam_user alongside users.
+	ServiceAccountPrefix = "sa"
 )
 
 func newRoleId() (string, error) {
@@ -34,6 +38,14 @@ func newUserId() (string, error) {
 	return id, nil
 }
 
+func newServiceAccountId() (string, error) {
+	id, err := db.NewPublicId(ServiceAccountPrefix)
+	if err != nil {
+		return "", errors.WrapDeprecated(err, "iam.newServiceAccountId")
+	}
+	return id, nil
+}
+
 func newGroupId() (string, error) {
 	id, err := db.NewPublicId(GroupPrefix)
 	if err != nil {
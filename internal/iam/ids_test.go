@@ -25,6 +25,11 @@ func Test_PublicIds(t *testing.T) {
 		require.NoError(t, err)
 		assert.True(t, strings.HasPrefix(id, UserPrefix+"_"))
 	})
+	t.Run("service account", func(t *testing.T) {
+		id, err := newServiceAccountId()
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(id, ServiceAccountPrefix+"_"))
+	})
 	t.Run("group", func(t *testing.T) {
 		id, err := newGroupId()
 		require.NoError(t, err)
@@ -3,7 +3,11 @@
 
 package iam
 
-import "io"
+import (
+	"io"
+
+	"github.com/hashicorp/boundary/internal/db"
+)
 
 // getOpts - iterate the inbound Options and return a struct
 func getOpts(opt ...Option) options {
@@ -23,7 +27,7 @@ type options struct {
 	withName                    string
 	withDescription             string
 	withLimit                   int
-	withGrantScopeId            string
+	withGrantScopeIds           []string
 	withSkipVetForWrite         bool
 	withDisassociate            bool
 	withSkipAdminRoleCreation   bool
@@ -32,6 +36,9 @@ type options struct {
 	withRandomReader            io.Reader
 	withAccountIds              []string
 	withPrimaryAuthMethodId     string
+	withPage                    *db.Page
+	withMaxSessionsPerUser      uint32
+	withMaxSessionsPerTarget    uint32
 }
 
 func getDefaultOptions() options {
@@ -40,7 +47,6 @@ func getDefaultOptions() options {
 		withName:            "",
 		withDescription:     "",
 		withLimit:           0,
-		withGrantScopeId:    "",
 		withSkipVetForWrite: false,
 	}
 }
@@ -75,11 +81,19 @@ func WithLimit(limit int) Option {
 	}
 }
 
-// WithGrantScopeId provides an option to specify the scope ID for grants in
-// roles.
-func WithGrantScopeId(id string) Option {
+// WithPage provides an option to list one page of resources. Use WithLimit to
+// set the size of the page.
+func WithPage(p *db.Page) Option {
 	return func(o *options) {
-		o.withGrantScopeId = id
+		o.withPage = p
+	}
+}
+
+// WithGrantScopeIds provides an option to specify the grant scopes of a role:
+// scope IDs or the special values "this", "children" and "descendants".
+func WithGrantScopeIds(ids []string) Option {
+	return func(o *options) {
+		o.withGrantScopeIds = ids
 	}
 }
 
@@ -144,3 +158,21 @@ func WithPrimaryAuthMethodId(id string) Option {
 		o.withPrimaryAuthMethodId = id
 	}
 }
+
+// WithMaxSessionsPerUser provides an option to specify the maximum number of
+// pending and active sessions a user may have within a scope or the grant
+// scope of a role.
+func WithMaxSessionsPerUser(max uint32) Option {
+	return func(o *options) {
+		o.withMaxSessionsPerUser = max
+	}
+}
+
+// WithMaxSessionsPerTarget provides an option to specify the maximum number
+// of pending and active sessions a user may have on any single target within
+// a scope or the grant scope of a role.
+func WithMaxSessionsPerTarget(max uint32) Option {
+	return func(o *options) {
+		o.withMaxSessionsPerTarget = max
+	}
+}
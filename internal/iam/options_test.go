@@ -44,11 +44,11 @@ func Test_GetOpts(t *testing.T) {
 		testOpts.withLimit = 1
 		assert.Equal(opts, testOpts)
 	})
-	t.Run("WithGrantScopeId", func(t *testing.T) {
+	t.Run("WithGrantScopeIds", func(t *testing.T) {
 		assert := assert.New(t)
-		opts := getOpts(WithGrantScopeId("o_1234"))
+		opts := getOpts(WithGrantScopeIds([]string{"o_1234", "children"}))
 		testOpts := getDefaultOptions()
-		testOpts.withGrantScopeId = "o_1234"
+		testOpts.withGrantScopeIds = []string{"o_1234", "children"}
 		assert.Equal(opts, testOpts)
 	})
 	t.Run("WithDisassociate", func(t *testing.T) {
@@ -82,4 +82,18 @@ func Test_GetOpts(t *testing.T) {
 		testOpts.withPrimaryAuthMethodId = "test"
 		assert.Equal(opts, testOpts)
 	})
+	t.Run("WithMaxSessionsPerUser", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithMaxSessionsPerUser(5))
+		testOpts := getDefaultOptions()
+		testOpts.withMaxSessionsPerUser = 5
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithMaxSessionsPerTarget", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithMaxSessionsPerTarget(2))
+		testOpts := getDefaultOptions()
+		testOpts.withMaxSessionsPerTarget = 2
+		assert.Equal(opts, testOpts)
+	})
 }
@@ -111,4 +111,105 @@ const (
 	select * from final
 	order by action, member_id;
 	`
+
+	// userRolesCte selects the ids of the roles a user is a principal of,
+	// directly, through one of its groups or through one of its managed
+	// groups into user_group_roles. The users cte must be completed with a
+	// where clause selecting the user ids.
+	userRolesCte = `
+users (id) as (
+  select public_id
+    from iam_user
+  %s -- anonUser || authUser
+),
+user_groups (id) as (
+  select group_id
+    from iam_group_member_user,
+         users
+   where member_id in (users.id)
+),
+user_accounts (id) as (
+  select public_id
+    from auth_account,
+         users
+   where iam_user_id in (users.id)
+),
+user_managed_groups (id) as (
+  select managed_group_id
+    from auth_managed_group_member_account,
+         user_accounts
+   where member_id in (user_accounts.id)
+),
+managed_group_roles (role_id) as (
+  select role_id
+    from iam_managed_group_role,
+         user_managed_groups
+   where principal_id in (user_managed_groups.id)
+),
+group_roles (role_id) as (
+  select role_id
+    from iam_group_role,
+         user_groups
+   where principal_id in (user_groups.id)
+),
+user_roles (role_id) as (
+  select role_id
+    from iam_user_role,
+         users
+   where principal_id in (users.id)
+),
+user_group_roles (role_id) as (
+  select role_id
+    from group_roles
+   union
+  select role_id
+    from user_roles
+   union
+  select role_id
+    from managed_group_roles
+)`
+
+	// roleGrantScopesCte resolves the grant scopes of the roles selected by
+	// the roles (role_id, role_scope_id) cte into role_grant_scopes, with a
+	// row for each scope the grants of a role apply to: "this" resolves to the
+	// role's scope, "children" to the direct child scopes of the role's scope
+	// and "descendants" to all of the scopes below the role's scope.
+	roleGrantScopesCte = `
+role_grant_scopes (role_id, grant_scope_id) as (
+  select roles.role_id,
+         case iam_role_grant_scope.scope_id_or_special
+           when 'this' then roles.role_scope_id
+           else iam_role_grant_scope.scope_id_or_special
+         end
+    from roles
+   inner
+    join iam_role_grant_scope
+      on roles.role_id = iam_role_grant_scope.role_id
+   where iam_role_grant_scope.scope_id_or_special not in ('children', 'descendants')
+   union
+  select roles.role_id,
+         child.public_id
+    from roles
+   inner
+    join iam_role_grant_scope
+      on roles.role_id = iam_role_grant_scope.role_id
+   inner
+    join iam_scope child
+      on roles.role_scope_id = child.parent_id
+   where iam_role_grant_scope.scope_id_or_special in ('children', 'descendants')
+   union
+  select roles.role_id,
+         grandchild.public_id
+    from roles
+   inner
+    join iam_role_grant_scope
+      on roles.role_id = iam_role_grant_scope.role_id
+   inner
+    join iam_scope child
+      on roles.role_scope_id = child.parent_id
+   inner
+    join iam_scope grandchild
+      on child.public_id = grandchild.parent_id
+   where iam_role_grant_scope.scope_id_or_special = 'descendants'
+)`
 )
@@ -7,11 +7,13 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/boundary/internal/db"
 	"github.com/hashicorp/boundary/internal/errors"
 	"github.com/hashicorp/boundary/internal/kms"
 	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/hashicorp/boundary/internal/pagination"
 	"github.com/hashicorp/boundary/internal/types/scope"
 )
 
@@ -62,7 +64,18 @@ func (r *Repository) list(ctx context.Context, resources any, where string, args
 		// non-zero signals an override of the default limit for the repo.
 		limit = opts.withLimit
 	}
-	return r.reader.SearchWhere(ctx, resources, where, args, db.WithLimit(limit))
+	return r.reader.SearchWhere(ctx, resources, where, args, db.WithLimit(limit), db.WithPage(opts.withPage))
+}
+
+// listDeletedIds returns the rows in one of scopeIds deleted from tableName
+// after since.
+func (r *Repository) listDeletedIds(ctx context.Context, tableName string, scopeIds []string, since time.Time) ([]*pagination.DeletedResource, error) {
+	const op = "iam.(Repository).listDeletedIds"
+	deleted, err := pagination.ListDeletedIds(ctx, r.reader, tableName, scopeIds, since)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return deleted, nil
 }
 
 // create will create a new iam resource in the db repository with an oplog entry
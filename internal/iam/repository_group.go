@@ -7,11 +7,13 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/boundary/internal/db"
 	"github.com/hashicorp/boundary/internal/errors"
 	"github.com/hashicorp/boundary/internal/kms"
 	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/hashicorp/boundary/internal/pagination"
 	"github.com/hashicorp/go-dbw"
 )
 
@@ -187,6 +189,12 @@ func (r *Repository) ListGroups(ctx context.Context, withScopeIds []string, opt
 	return grps, nil
 }
 
+// ListDeletedGroupIds returns the groups in one of scopeIds deleted after
+// since.
+func (r *Repository) ListDeletedGroupIds(ctx context.Context, scopeIds []string, since time.Time) ([]*pagination.DeletedResource, error) {
+	return r.listDeletedIds(ctx, "iam_group", scopeIds, since)
+}
+
 // ListGroupMembers of a group and supports WithLimit option.
 func (r *Repository) ListGroupMembers(ctx context.Context, withGroupId string, opt ...Option) ([]*GroupMember, error) {
 	const op = "iam.(Repository).ListGroupMembers"
@@ -595,7 +595,8 @@ func splitPrincipals(ctx context.Context, principals []string) (users, groups, m
 	const op = "iam.splitPrincipals"
 	for _, principal := range principals {
 		switch {
-		case strings.HasPrefix(principal, UserPrefix):
+		case strings.HasPrefix(principal, UserPrefix), strings.HasPrefix(principal, ServiceAccountPrefix+"_"):
+			// service accounts are role principals the same way users are
 			if users == nil {
 				users = make([]string, 0, len(principals))
 			}
@@ -605,7 +606,10 @@ func splitPrincipals(ctx context.Context, principals []string) (users, groups, m
 				groups = make([]string, 0, len(principals))
 			}
 			groups = append(groups, principal)
-		case strings.HasPrefix(principal, intglobals.OidcManagedGroupPrefix):
+		case strings.HasPrefix(principal, intglobals.OidcManagedGroupPrefix),
+			strings.HasPrefix(principal, intglobals.LdapManagedGroupPrefix),
+			strings.HasPrefix(principal, intglobals.JwtManagedGroupPrefix),
+			strings.HasPrefix(principal, intglobals.PasswordManagedGroupPrefix):
 			if managedGroups == nil {
 				managedGroups = make([]string, 0, len(principals))
 			}
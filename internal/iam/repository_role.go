@@ -7,87 +7,167 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
+	"github.com/hashicorp/boundary/globals"
 	"github.com/hashicorp/boundary/internal/db"
 	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/hashicorp/boundary/internal/pagination"
 	"github.com/hashicorp/go-dbw"
 )
 
 // CreateRole will create a role in the repository and return the written
-// role.  No options are currently supported.
-func (r *Repository) CreateRole(ctx context.Context, role *Role, _ ...Option) (*Role, error) {
+// role and its grant scopes. WithGrantScopeIds is the only supported option;
+// when no grant scope ids are given the grants of the role apply to its own
+// scope.
+func (r *Repository) CreateRole(ctx context.Context, role *Role, opt ...Option) (*Role, []*RoleGrantScope, error) {
 	const op = "iam.(Repository).CreateRole"
 	if role == nil {
-		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing role")
+		return nil, nil, errors.New(ctx, errors.InvalidParameter, op, "missing role")
 	}
 	if role.Role == nil {
-		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing role store")
+		return nil, nil, errors.New(ctx, errors.InvalidParameter, op, "missing role store")
 	}
 	if role.PublicId != "" {
-		return nil, errors.New(ctx, errors.InvalidParameter, op, "public id not empty")
+		return nil, nil, errors.New(ctx, errors.InvalidParameter, op, "public id not empty")
 	}
 	if role.ScopeId == "" {
-		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing scope id")
+		return nil, nil, errors.New(ctx, errors.InvalidParameter, op, "missing scope id")
+	}
+	opts := getOpts(opt...)
+	grantScopeIds := opts.withGrantScopeIds
+	if len(grantScopeIds) == 0 {
+		grantScopeIds = []string{globals.GrantScopeThis}
 	}
 	id, err := newRoleId()
 	if err != nil {
-		return nil, errors.Wrap(ctx, err, op)
+		return nil, nil, errors.Wrap(ctx, err, op)
 	}
 	c := role.Clone().(*Role)
 	c.PublicId = id
-	resource, err := r.create(ctx, c)
+
+	newRoleGrantScopes := make([]any, 0, len(grantScopeIds))
+	seen := map[string]bool{}
+	for _, grantScopeId := range grantScopeIds {
+		grantScopeId = normalizeGrantScope(c.ScopeId, grantScopeId)
+		if seen[grantScopeId] {
+			continue
+		}
+		seen[grantScopeId] = true
+		roleGrantScope, err := NewRoleGrantScope(ctx, id, grantScopeId)
+		if err != nil {
+			return nil, nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to create in memory role grant scope"))
+		}
+		newRoleGrantScopes = append(newRoleGrantScopes, roleGrantScope)
+	}
+
+	metadata, err := r.stdMetadata(ctx, c)
+	if err != nil {
+		return nil, nil, errors.Wrap(ctx, err, op, errors.WithMsg("error getting metadata"))
+	}
+	metadata["op-type"] = []string{oplog.OpType_OP_TYPE_CREATE.String()}
+	scope, err := c.GetScope(ctx, r.reader)
+	if err != nil {
+		return nil, nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get scope"))
+	}
+	oplogWrapper, err := r.kms.GetWrapper(ctx, scope.GetPublicId(), kms.KeyPurposeOplog)
+	if err != nil {
+		return nil, nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get oplog wrapper"))
+	}
+
+	var returnedRole *Role
+	_, err = r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) error {
+			returnedRole = c.Clone().(*Role)
+			if err := w.Create(ctx, returnedRole, db.WithOplog(oplogWrapper, metadata)); err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+
+			roleTicket, err := w.GetTicket(ctx, returnedRole)
+			if err != nil {
+				return errors.Wrap(ctx, err, op, errors.WithMsg("unable to get ticket"))
+			}
+			msgs := make([]*oplog.Message, 0, len(newRoleGrantScopes))
+			if err := w.CreateItems(ctx, newRoleGrantScopes, db.NewOplogMsgs(&msgs)); err != nil {
+				return errors.Wrap(ctx, err, op, errors.WithMsg("unable to add grant scopes"))
+			}
+			grantScopeMetadata := oplog.Metadata{
+				"op-type":            []string{oplog.OpType_OP_TYPE_CREATE.String()},
+				"scope-id":           []string{scope.PublicId},
+				"scope-type":         []string{scope.Type},
+				"resource-public-id": []string{id},
+			}
+			if err := w.WriteOplogEntryWith(ctx, oplogWrapper, roleTicket, grantScopeMetadata, msgs); err != nil {
+				return errors.Wrap(ctx, err, op, errors.WithMsg("unable to write oplog"))
+			}
+			return nil
+		},
+	)
 	if err != nil {
 		if errors.IsUniqueError(err) {
-			return nil, errors.New(ctx, errors.NotUnique, op, fmt.Sprintf("role %s already exists in scope %s", role.Name, role.ScopeId))
+			return nil, nil, errors.New(ctx, errors.NotUnique, op, fmt.Sprintf("role %s already exists in scope %s", role.Name, role.ScopeId))
 		}
-		return nil, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("for %s", c.PublicId)))
+		return nil, nil, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("for %s", c.PublicId)))
 	}
-	return resource.(*Role), nil
+	roleGrantScopes := make([]*RoleGrantScope, 0, len(newRoleGrantScopes))
+	for _, grantScope := range newRoleGrantScopes {
+		roleGrantScopes = append(roleGrantScopes, grantScope.(*RoleGrantScope))
+	}
+	return returnedRole, roleGrantScopes, nil
 }
 
 // UpdateRole will update a role in the repository and return the written role.
 // fieldMaskPaths provides field_mask.proto paths for fields that should be
 // updated.  Fields will be set to NULL if the field is a zero value and
-// included in fieldMask. Name, Description, and GrantScopeId are the only
-// updatable fields, If no updatable fields are included in the fieldMaskPaths,
-// then an error is returned.
-func (r *Repository) UpdateRole(ctx context.Context, role *Role, version uint32, fieldMaskPaths []string, _ ...Option) (*Role, []*PrincipalRole, []*RoleGrant, int, error) {
+// included in fieldMask. Name, Description, MaxSessionsPerUser and
+// MaxSessionsPerTarget are the only updatable fields, If no updatable fields
+// are included in the fieldMaskPaths, then an error is returned.
+func (r *Repository) UpdateRole(ctx context.Context, role *Role, version uint32, fieldMaskPaths []string, _ ...Option) (*Role, []*PrincipalRole, []*RoleGrant, []*RoleGrantScope, int, error) {
 	const op = "iam.(Repository).UpdateRole"
 	if role == nil {
-		return nil, nil, nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing role")
+		return nil, nil, nil, nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing role")
 	}
 	if role.Role == nil {
-		return nil, nil, nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing role store")
+		return nil, nil, nil, nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing role store")
 	}
 	if role.PublicId == "" {
-		return nil, nil, nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing public id")
+		return nil, nil, nil, nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing public id")
 	}
 	for _, f := range fieldMaskPaths {
 		switch {
 		case strings.EqualFold("name", f):
 		case strings.EqualFold("description", f):
-		case strings.EqualFold("grantscopeid", f):
+		case strings.EqualFold("maxsessionsperuser", f):
+		case strings.EqualFold("maxsessionspertarget", f):
 		default:
-			return nil, nil, nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidFieldMask, op, fmt.Sprintf("invalid field mask: %s", f))
+			return nil, nil, nil, nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidFieldMask, op, fmt.Sprintf("invalid field mask: %s", f))
 		}
 	}
 	var dbMask, nullFields []string
 	dbMask, nullFields = dbw.BuildUpdatePaths(
 		map[string]any{
-			"name":         role.Name,
-			"description":  role.Description,
-			"GrantScopeId": role.GrantScopeId,
+			"name":                 role.Name,
+			"description":          role.Description,
+			"MaxSessionsPerUser":   role.MaxSessionsPerUser,
+			"MaxSessionsPerTarget": role.MaxSessionsPerTarget,
 		},
 		fieldMaskPaths,
-		nil,
+		// a session limit of 0 means there is no limit
+		[]string{"MaxSessionsPerUser", "MaxSessionsPerTarget"},
 	)
 	if len(dbMask) == 0 && len(nullFields) == 0 {
-		return nil, nil, nil, db.NoRowsAffected, errors.E(ctx, errors.WithCode(errors.EmptyFieldMask), errors.WithOp(op))
+		return nil, nil, nil, nil, db.NoRowsAffected, errors.E(ctx, errors.WithCode(errors.EmptyFieldMask), errors.WithOp(op))
 	}
 	var resource Resource
 	var rowsUpdated int
 	var pr []*PrincipalRole
 	var rg []*RoleGrant
+	var rgs []*RoleGrantScope
 	_, err := r.writer.DoTx(
 		ctx,
 		db.StdRetryCnt,
@@ -111,29 +191,34 @@ func (r *Repository) UpdateRole(ctx context.Context, role *Role, version uint32,
 			if err != nil {
 				return errors.Wrap(ctx, err, op)
 			}
+			rgs, err = repo.ListRoleGrantScopes(ctx, []string{role.PublicId})
+			if err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
 			return nil
 		},
 	)
 	if err != nil {
 		if errors.IsUniqueError(err) {
-			return nil, nil, nil, db.NoRowsAffected, errors.New(ctx, errors.NotUnique, op, fmt.Sprintf("role %s already exists in org %s", role.Name, role.ScopeId))
+			return nil, nil, nil, nil, db.NoRowsAffected, errors.New(ctx, errors.NotUnique, op, fmt.Sprintf("role %s already exists in org %s", role.Name, role.ScopeId))
 		}
-		return nil, nil, nil, db.NoRowsAffected, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("for %s", role.PublicId)))
+		return nil, nil, nil, nil, db.NoRowsAffected, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("for %s", role.PublicId)))
 	}
-	return resource.(*Role), pr, rg, rowsUpdated, nil
+	return resource.(*Role), pr, rg, rgs, rowsUpdated, nil
 }
 
 // LookupRole will look up a role in the repository.  If the role is not
 // found, it will return nil, nil.
-func (r *Repository) LookupRole(ctx context.Context, withPublicId string, _ ...Option) (*Role, []*PrincipalRole, []*RoleGrant, error) {
+func (r *Repository) LookupRole(ctx context.Context, withPublicId string, _ ...Option) (*Role, []*PrincipalRole, []*RoleGrant, []*RoleGrantScope, error) {
 	const op = "iam.(Repository).LookupRole"
 	if withPublicId == "" {
-		return nil, nil, nil, errors.New(ctx, errors.InvalidParameter, op, "missing public id")
+		return nil, nil, nil, nil, errors.New(ctx, errors.InvalidParameter, op, "missing public id")
 	}
 	role := allocRole()
 	role.PublicId = withPublicId
 	var pr []*PrincipalRole
 	var rg []*RoleGrant
+	var rgs []*RoleGrantScope
 	_, err := r.writer.DoTx(
 		ctx,
 		db.StdRetryCnt,
@@ -154,16 +239,20 @@ func (r *Repository) LookupRole(ctx context.Context, withPublicId string, _ ...O
 			if err != nil {
 				return errors.Wrap(ctx, err, op)
 			}
+			rgs, err = repo.ListRoleGrantScopes(ctx, []string{withPublicId})
+			if err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
 			return nil
 		},
 	)
 	if err != nil {
 		if errors.IsNotFoundError(err) {
-			return nil, nil, nil, nil
+			return nil, nil, nil, nil, nil
 		}
-		return nil, nil, nil, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("for %s", withPublicId)))
+		return nil, nil, nil, nil, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("for %s", withPublicId)))
 	}
-	return &role, pr, rg, nil
+	return &role, pr, rg, rgs, nil
 }
 
 // DeleteRole will delete a role from the repository.
@@ -197,3 +286,8 @@ func (r *Repository) ListRoles(ctx context.Context, withScopeIds []string, opt .
 	}
 	return roles, nil
 }
+
+// ListDeletedRoleIds returns the roles in one of scopeIds deleted after since.
+func (r *Repository) ListDeletedRoleIds(ctx context.Context, scopeIds []string, since time.Time) ([]*pagination.DeletedResource, error) {
+	return r.listDeletedIds(ctx, "iam_role", scopeIds, since)
+}
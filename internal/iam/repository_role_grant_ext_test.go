@@ -9,6 +9,7 @@ import (
 	mathrand "math/rand"
 	"testing"
 
+	"github.com/hashicorp/boundary/globals"
 	"github.com/hashicorp/boundary/internal/auth/oidc"
 	"github.com/hashicorp/boundary/internal/db"
 	"github.com/hashicorp/boundary/internal/iam"
@@ -253,3 +254,82 @@ func TestGrantsForUser(t *testing.T) {
 		t.Log("finished user", user.PublicId, "total roles", len(expectedRoleIds), "roles from users", rolesFromUsers, "roles from groups", rolesFromGroups, "roles from managed groups", rolesFromManagedGroups)
 	}
 }
+
+func TestGrantsForUser_GrantScopes(t *testing.T) {
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrap := db.TestWrapper(t)
+	iamRepo := iam.TestRepo(t, conn, wrap)
+
+	o1, p1 := iam.TestScopes(t, iamRepo, iam.WithSkipAdminRoleCreation(true), iam.WithSkipDefaultRoleCreation(true))
+	o2, p2 := iam.TestScopes(t, iamRepo, iam.WithSkipAdminRoleCreation(true), iam.WithSkipDefaultRoleCreation(true))
+	user := iam.TestUser(t, iamRepo, o1.PublicId)
+
+	tests := []struct {
+		name        string
+		roleScopeId string
+		grantScopes []string
+		want        []string
+	}{
+		{
+			name:        "this",
+			roleScopeId: o1.PublicId,
+			grantScopes: []string{globals.GrantScopeThis},
+			want:        []string{o1.PublicId},
+		},
+		{
+			name:        "org-children",
+			roleScopeId: o1.PublicId,
+			grantScopes: []string{globals.GrantScopeChildren},
+			want:        []string{p1.PublicId},
+		},
+		{
+			name:        "global-children",
+			roleScopeId: scope.Global.String(),
+			grantScopes: []string{globals.GrantScopeChildren},
+			want:        []string{o1.PublicId, o2.PublicId},
+		},
+		{
+			name:        "global-this-and-descendants",
+			roleScopeId: scope.Global.String(),
+			grantScopes: []string{globals.GrantScopeThis, globals.GrantScopeDescendants},
+			want:        []string{scope.Global.String(), o1.PublicId, o2.PublicId, p1.PublicId, p2.PublicId},
+		},
+		{
+			name:        "global-ids",
+			roleScopeId: scope.Global.String(),
+			grantScopes: []string{o1.PublicId, p2.PublicId},
+			want:        []string{o1.PublicId, p2.PublicId},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			role := iam.TestRole(t, conn, tt.roleScopeId, iam.WithGrantScopeIds(tt.grantScopes))
+			iam.TestRoleGrant(t, conn, role.PublicId, "id=*;type=*;actions=*")
+			iam.TestUserRole(t, conn, role.PublicId, user.PublicId)
+			t.Cleanup(func() {
+				_, err := iamRepo.DeleteRole(ctx, role.PublicId)
+				require.NoError(t, err)
+			})
+
+			tuples, err := iamRepo.GrantsForUser(ctx, user.PublicId)
+			require.NoError(t, err)
+			// Other scopes may exist from other tests sharing the database,
+			// so only consider the scopes created by this test.
+			created := map[string]bool{
+				scope.Global.String(): true,
+				o1.PublicId:           true,
+				o2.PublicId:           true,
+				p1.PublicId:           true,
+				p2.PublicId:           true,
+			}
+			var got []string
+			for _, tuple := range tuples {
+				if tuple.RoleId == role.PublicId && created[tuple.ScopeId] {
+					got = append(got, tuple.ScopeId)
+				}
+			}
+			assert.ElementsMatch(t, tt.want, got)
+		})
+	}
+}
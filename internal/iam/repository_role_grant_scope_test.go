@@ -0,0 +1,376 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package iam
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/boundary/globals"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/types/scope"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func grantScopeValues(grantScopes []*RoleGrantScope) []string {
+	var values []string
+	for _, gs := range grantScopes {
+		values = append(values, gs.GetScopeIdOrSpecial())
+	}
+	return values
+}
+
+func TestRepository_AddRoleGrantScopes(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	repo := TestRepo(t, conn, wrapper)
+	org, proj := TestScopes(t, repo)
+
+	tests := []struct {
+		name        string
+		roleVersion uint32
+		grantScopes []string
+		want        []string
+		wantErrIs   errors.Code
+		wantErr     bool
+	}{
+		{
+			name:        "valid",
+			roleVersion: 1,
+			grantScopes: []string{proj.PublicId},
+			want:        []string{globals.GrantScopeThis, proj.PublicId},
+		},
+		{
+			name:        "existing-after-normalizing",
+			roleVersion: 1,
+			grantScopes: []string{globals.GrantScopeChildren, org.PublicId},
+			wantErr:     true,
+		},
+		{
+			name:        "no-grant-scopes",
+			roleVersion: 1,
+			wantErrIs:   errors.InvalidParameter,
+		},
+		{
+			name:        "zero-version",
+			roleVersion: 0,
+			grantScopes: []string{proj.PublicId},
+			wantErrIs:   errors.InvalidParameter,
+		},
+		{
+			name:        "bad-version",
+			roleVersion: 1000,
+			grantScopes: []string{proj.PublicId},
+			wantErr:     true,
+		},
+		{
+			name:        "invalid-grant-scope",
+			roleVersion: 1,
+			grantScopes: []string{globals.GrantScopeDescendants},
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			role := TestRole(t, conn, org.PublicId)
+			got, err := repo.AddRoleGrantScopes(ctx, role.PublicId, tt.roleVersion, tt.grantScopes)
+			if tt.wantErr || tt.wantErrIs != 0 {
+				require.Error(err)
+				if tt.wantErrIs != 0 {
+					assert.Truef(errors.Match(errors.T(tt.wantErrIs), err), "unexpected error %s", err.Error())
+				}
+				return
+			}
+			require.NoError(err)
+			assert.Len(got, len(tt.grantScopes))
+
+			found, err := repo.ListRoleGrantScopes(ctx, []string{role.PublicId})
+			require.NoError(err)
+			assert.ElementsMatch(tt.want, grantScopeValues(found))
+
+			r, _, _, _, err := repo.LookupRole(ctx, role.PublicId)
+			require.NoError(err)
+			assert.Equal(tt.roleVersion+1, r.GetVersion())
+		})
+	}
+}
+
+func TestRepository_SetRoleGrantScopes(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	repo := TestRepo(t, conn, wrapper)
+	org, proj := TestScopes(t, repo)
+
+	tests := []struct {
+		name        string
+		existing    []string
+		grantScopes []string
+		want        []string
+		wantVersion uint32
+		wantErrIs   errors.Code
+	}{
+		{
+			name:        "replace",
+			existing:    []string{globals.GrantScopeThis, proj.PublicId},
+			grantScopes: []string{globals.GrantScopeChildren},
+			want:        []string{globals.GrantScopeChildren},
+			wantVersion: 2,
+		},
+		{
+			name:        "keep-and-add",
+			grantScopes: []string{org.PublicId, proj.PublicId},
+			want:        []string{globals.GrantScopeThis, proj.PublicId},
+			wantVersion: 2,
+		},
+		{
+			name:        "unchanged",
+			grantScopes: []string{globals.GrantScopeThis},
+			want:        []string{globals.GrantScopeThis},
+			wantVersion: 1,
+		},
+		{
+			name:        "clear",
+			grantScopes: []string{},
+			want:        nil,
+			wantVersion: 2,
+		},
+		{
+			name:      "nil",
+			wantErrIs: errors.InvalidParameter,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			var opts []Option
+			if tt.existing != nil {
+				opts = append(opts, WithGrantScopeIds(tt.existing))
+			}
+			role := TestRole(t, conn, org.PublicId, opts...)
+			got, _, err := repo.SetRoleGrantScopes(ctx, role.PublicId, role.Version, tt.grantScopes)
+			if tt.wantErrIs != 0 {
+				require.Error(err)
+				assert.Truef(errors.Match(errors.T(tt.wantErrIs), err), "unexpected error %s", err.Error())
+				return
+			}
+			require.NoError(err)
+			assert.ElementsMatch(tt.want, grantScopeValues(got))
+
+			found, err := repo.ListRoleGrantScopes(ctx, []string{role.PublicId})
+			require.NoError(err)
+			assert.ElementsMatch(tt.want, grantScopeValues(found))
+
+			r, _, _, _, err := repo.LookupRole(ctx, role.PublicId)
+			require.NoError(err)
+			assert.Equal(tt.wantVersion, r.GetVersion())
+		})
+	}
+}
+
+func TestRepository_DeleteRoleGrantScopes(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	repo := TestRepo(t, conn, wrapper)
+	org, proj := TestScopes(t, repo)
+
+	tests := []struct {
+		name        string
+		roleVersion uint32
+		grantScopes []string
+		want        []string
+		wantDeleted int
+		wantErrIs   errors.Code
+		wantErr     bool
+	}{
+		{
+			name:        "valid",
+			roleVersion: 1,
+			grantScopes: []string{proj.PublicId},
+			want:        []string{globals.GrantScopeThis},
+			wantDeleted: 1,
+		},
+		{
+			name:        "role-scope-id",
+			roleVersion: 1,
+			grantScopes: []string{org.PublicId},
+			want:        []string{proj.PublicId},
+			wantDeleted: 1,
+		},
+		{
+			name:        "missing",
+			roleVersion: 1,
+			grantScopes: []string{globals.GrantScopeChildren},
+			want:        []string{globals.GrantScopeThis, proj.PublicId},
+		},
+		{
+			name:        "no-grant-scopes",
+			roleVersion: 1,
+			wantErrIs:   errors.InvalidParameter,
+		},
+		{
+			name:        "bad-version",
+			roleVersion: 1000,
+			grantScopes: []string{proj.PublicId},
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			role := TestRole(t, conn, org.PublicId, WithGrantScopeIds([]string{globals.GrantScopeThis, proj.PublicId}))
+			deleted, err := repo.DeleteRoleGrantScopes(ctx, role.PublicId, tt.roleVersion, tt.grantScopes)
+			if tt.wantErr || tt.wantErrIs != 0 {
+				require.Error(err)
+				if tt.wantErrIs != 0 {
+					assert.Truef(errors.Match(errors.T(tt.wantErrIs), err), "unexpected error %s", err.Error())
+				}
+				return
+			}
+			require.NoError(err)
+			assert.Equal(tt.wantDeleted, deleted)
+
+			found, err := repo.ListRoleGrantScopes(ctx, []string{role.PublicId})
+			require.NoError(err)
+			assert.ElementsMatch(tt.want, grantScopeValues(found))
+		})
+	}
+}
+
+func TestRepository_ListRoleGrantScopes(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	repo := TestRepo(t, conn, wrapper)
+	org, proj := TestScopes(t, repo)
+
+	r1 := TestRole(t, conn, org.PublicId, WithGrantScopeIds([]string{globals.GrantScopeThis, proj.PublicId}))
+	r2 := TestRole(t, conn, org.PublicId, WithGrantScopeIds([]string{globals.GrantScopeChildren}))
+
+	t.Run("missing-role-ids", func(t *testing.T) {
+		_, err := repo.ListRoleGrantScopes(ctx, nil)
+		require.Error(t, err)
+		assert.True(t, errors.Match(errors.T(errors.InvalidParameter), err))
+	})
+	t.Run("one-role", func(t *testing.T) {
+		got, err := repo.ListRoleGrantScopes(ctx, []string{r1.PublicId})
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{globals.GrantScopeThis, proj.PublicId}, grantScopeValues(got))
+	})
+	t.Run("many-roles", func(t *testing.T) {
+		got, err := repo.ListRoleGrantScopes(ctx, []string{r1.PublicId, r2.PublicId})
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{globals.GrantScopeThis, proj.PublicId, globals.GrantScopeChildren}, grantScopeValues(got))
+	})
+	t.Run("with-limit", func(t *testing.T) {
+		got, err := repo.ListRoleGrantScopes(ctx, []string{r1.PublicId, r2.PublicId}, WithLimit(1))
+		require.NoError(t, err)
+		assert.Len(t, got, 1)
+	})
+}
+
+func TestRepository_SetRoleGrantScopes_Validation(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	repo := TestRepo(t, conn, wrapper)
+	org, proj := TestScopes(t, repo)
+	org2, proj2 := TestScopes(t, repo)
+
+	tests := []struct {
+		name        string
+		scopeId     string
+		grantScopes []string
+		want        []string
+		wantErrMsg  string
+	}{
+		{
+			name:        "set grant scope in project with same scope",
+			scopeId:     proj.PublicId,
+			grantScopes: []string{proj.PublicId},
+			want:        []string{globals.GrantScopeThis},
+		},
+		{
+			name:        "set grant scope in org with same scope",
+			scopeId:     org2.PublicId,
+			grantScopes: []string{org2.PublicId},
+			want:        []string{globals.GrantScopeThis},
+		},
+		{
+			name:        "set grant scope in project with different scope",
+			scopeId:     proj.PublicId,
+			grantScopes: []string{proj2.PublicId},
+			wantErrMsg:  "invalid to set a grant scope id when role scope type is project",
+		},
+		{
+			name:        "set grant scope children in project",
+			scopeId:     proj.PublicId,
+			grantScopes: []string{globals.GrantScopeChildren},
+			wantErrMsg:  "invalid to set grant scope children when role scope type is project",
+		},
+		{
+			name:        "set grant scope in org",
+			scopeId:     org2.PublicId,
+			grantScopes: []string{proj2.PublicId},
+			want:        []string{proj2.PublicId},
+		},
+		{
+			name:        "set grant scope in external project",
+			scopeId:     org.PublicId,
+			grantScopes: []string{proj2.PublicId},
+			wantErrMsg:  "is not below the role scope",
+		},
+		{
+			name:        "set grant scope in global",
+			scopeId:     org.PublicId,
+			grantScopes: []string{scope.Global.String()},
+			wantErrMsg:  "is not below the role scope",
+		},
+		{
+			name:        "set grant scope to parent",
+			scopeId:     proj2.PublicId,
+			grantScopes: []string{org2.PublicId},
+			wantErrMsg:  "invalid to set a grant scope id when role scope type is project",
+		},
+		{
+			name:        "set grant scope in global to grandchild project",
+			scopeId:     scope.Global.String(),
+			grantScopes: []string{globals.GrantScopeThis, proj.PublicId},
+			want:        []string{globals.GrantScopeThis, proj.PublicId},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			role := TestRole(t, conn, tt.scopeId)
+			got, _, err := repo.SetRoleGrantScopes(ctx, role.PublicId, role.Version, tt.grantScopes)
+			if tt.wantErrMsg != "" {
+				require.Error(err)
+				assert.Contains(err.Error(), tt.wantErrMsg)
+
+				// The failed set must leave the existing grant scopes in place
+				found, err := repo.ListRoleGrantScopes(ctx, []string{role.PublicId})
+				require.NoError(err)
+				assert.ElementsMatch([]string{globals.GrantScopeThis}, grantScopeValues(found))
+				return
+			}
+			require.NoError(err)
+			assert.ElementsMatch(tt.want, grantScopeValues(got))
+
+			found, err := repo.ListRoleGrantScopes(ctx, []string{role.PublicId})
+			require.NoError(err)
+			assert.ElementsMatch(tt.want, grantScopeValues(found))
+		})
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/hashicorp/boundary/globals"
 	"github.com/hashicorp/boundary/internal/db"
 	dbassert "github.com/hashicorp/boundary/internal/db/assert"
 	"github.com/hashicorp/boundary/internal/errors"
@@ -35,12 +36,13 @@ func TestRepository_CreateRole(t *testing.T) {
 		opt  []Option
 	}
 	tests := []struct {
-		name        string
-		args        args
-		wantDup     bool
-		wantErr     bool
-		wantErrMsg  string
-		wantIsError errors.Code
+		name            string
+		args            args
+		wantDup         bool
+		wantGrantScopes []string
+		wantErr         bool
+		wantErrMsg      string
+		wantIsError     errors.Code
 	}{
 		{
 			name: "valid-org",
@@ -64,6 +66,19 @@ func TestRepository_CreateRole(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "valid-org-with-grant-scopes",
+			args: args{
+				role: func() *Role {
+					r, err := NewRole(org.PublicId, WithName("valid-org-with-grant-scopes"+id))
+					assert.NoError(t, err)
+					return r
+				}(),
+				opt: []Option{WithGrantScopeIds([]string{org.PublicId, proj.PublicId})},
+			},
+			wantGrantScopes: []string{globals.GrantScopeThis, proj.PublicId},
+			wantErr:         false,
+		},
 		{
 			name: "bad-public-id",
 			args: args{
@@ -149,11 +164,11 @@ func TestRepository_CreateRole(t *testing.T) {
 			if tt.wantDup {
 				dup, err := NewRole(org.PublicId, tt.args.opt...)
 				assert.NoError(err)
-				dup, err = repo.CreateRole(context.Background(), dup, tt.args.opt...)
+				dup, _, err = repo.CreateRole(context.Background(), dup, tt.args.opt...)
 				assert.NoError(err)
 				assert.NotNil(dup)
 			}
-			grp, err := repo.CreateRole(context.Background(), tt.args.role, tt.args.opt...)
+			grp, grantScopes, err := repo.CreateRole(context.Background(), tt.args.role, tt.args.opt...)
 			if tt.wantErr {
 				assert.Error(err)
 				assert.Nil(grp)
@@ -165,9 +180,20 @@ func TestRepository_CreateRole(t *testing.T) {
 			assert.NotNil(grp.CreateTime)
 			assert.NotNil(grp.UpdateTime)
 
-			foundGrp, _, _, err := repo.LookupRole(context.Background(), grp.PublicId)
+			wantGrantScopes := tt.wantGrantScopes
+			if wantGrantScopes == nil {
+				wantGrantScopes = []string{globals.GrantScopeThis}
+			}
+			var gotGrantScopes []string
+			for _, gs := range grantScopes {
+				gotGrantScopes = append(gotGrantScopes, gs.GetScopeIdOrSpecial())
+			}
+			assert.ElementsMatch(wantGrantScopes, gotGrantScopes)
+
+			foundGrp, _, _, foundGrantScopes, err := repo.LookupRole(context.Background(), grp.PublicId)
 			assert.NoError(err)
 			assert.True(proto.Equal(foundGrp, grp))
+			assert.Len(foundGrantScopes, len(wantGrantScopes))
 
 			err = db.TestVerifyOplog(t, rw, grp.PublicId, db.WithOperation(oplog.OpType_OP_TYPE_CREATE), db.WithCreateNotBefore(10*time.Second))
 			assert.NoError(err)
@@ -393,7 +419,7 @@ func TestRepository_UpdateRole(t *testing.T) {
 				_ = TestUserRole(t, conn, r.GetPublicId(), u.GetPublicId())
 				_ = TestRoleGrant(t, conn, r.GetPublicId(), "id=*;type=*;actions=*")
 				r.Name = tt.args.name
-				_, _, _, _, err := repo.UpdateRole(context.Background(), r, r.Version, tt.args.fieldMaskPaths, tt.args.opt...)
+				_, _, _, _, _, err := repo.UpdateRole(context.Background(), r, r.Version, tt.args.fieldMaskPaths, tt.args.opt...)
 				assert.NoError(err)
 			}
 
@@ -425,7 +451,7 @@ func TestRepository_UpdateRole(t *testing.T) {
 			updateRole.Name = tt.args.name
 			updateRole.Description = tt.args.description
 
-			roleAfterUpdate, principals, grants, updatedRows, err := repo.UpdateRole(context.Background(), &updateRole, r.Version, tt.args.fieldMaskPaths, tt.args.opt...)
+			roleAfterUpdate, principals, grants, _, updatedRows, err := repo.UpdateRole(context.Background(), &updateRole, r.Version, tt.args.fieldMaskPaths, tt.args.opt...)
 			if tt.wantErr {
 				assert.Error(err)
 				assert.True(errors.Match(errors.T(tt.wantIsError), err))
@@ -448,7 +474,7 @@ func TestRepository_UpdateRole(t *testing.T) {
 			default:
 				assert.NotEqual(r.UpdateTime, roleAfterUpdate.UpdateTime)
 			}
-			foundRole, _, _, err := repo.LookupRole(context.Background(), r.PublicId)
+			foundRole, _, _, _, err := repo.LookupRole(context.Background(), r.PublicId)
 			assert.NoError(err)
 			assert.True(proto.Equal(roleAfterUpdate, foundRole))
 			underlyingDB, err := conn.SqlDB(ctx)
@@ -541,7 +567,7 @@ func TestRepository_DeleteRole(t *testing.T) {
 			}
 			assert.NoError(err)
 			assert.Equal(tt.wantRowsDeleted, deletedRows)
-			foundRole, _, _, err := repo.LookupRole(context.Background(), tt.args.role.PublicId)
+			foundRole, _, _, _, err := repo.LookupRole(context.Background(), tt.args.role.PublicId)
 			assert.NoError(err)
 			assert.Nil(foundRole)
 
@@ -8,12 +8,14 @@ import (
 	"crypto/rand"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/boundary/globals"
 	"github.com/hashicorp/boundary/internal/db"
 	"github.com/hashicorp/boundary/internal/errors"
 	"github.com/hashicorp/boundary/internal/kms"
 	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/hashicorp/boundary/internal/pagination"
 	"github.com/hashicorp/boundary/internal/types/resource"
 	"github.com/hashicorp/boundary/internal/types/scope"
 	"github.com/hashicorp/go-dbw"
@@ -233,6 +235,16 @@ func (r *Repository) CreateScope(ctx context.Context, s *Scope, userId string, o
 				}
 				msgs = append(msgs, roleGrantOplogMsgs...)
 
+				roleGrantScope, err := NewRoleGrantScope(ctx, adminRolePublicId, globals.GrantScopeThis)
+				if err != nil {
+					return errors.Wrap(ctx, err, op, errors.WithMsg("unable to create in memory role grant scope"))
+				}
+				roleGrantScopeOplogMsgs := make([]*oplog.Message, 0, 1)
+				if err := w.CreateItems(ctx, []any{roleGrantScope}, db.NewOplogMsgs(&roleGrantScopeOplogMsgs)); err != nil {
+					return errors.Wrap(ctx, err, op, errors.WithMsg("unable to add grant scopes"))
+				}
+				msgs = append(msgs, roleGrantScopeOplogMsgs...)
+
 				rolePrincipal, err := NewUserRole(adminRolePublicId, userId)
 				if err != nil {
 					return errors.Wrap(ctx, err, op, errors.WithMsg("unable to create in memory role user"))
@@ -291,7 +303,7 @@ func (r *Repository) CreateScope(ctx context.Context, s *Scope, userId string, o
 
 					switch s.Type {
 					case scope.Project.String():
-						roleGrant, err := NewRoleGrant(defaultRolePublicId, "id=*;type=session;actions=list,read:self,cancel:self")
+						roleGrant, err := NewRoleGrant(defaultRolePublicId, "id=*;type=session;actions=list,read:self,cancel:self,list-connections:self")
 						if err != nil {
 							return errors.Wrap(ctx, err, op, errors.WithMsg("unable to create in memory role grant"))
 						}
@@ -316,13 +328,13 @@ func (r *Repository) CreateScope(ctx context.Context, s *Scope, userId string, o
 						}
 						grants = append(grants, roleGrant)
 
-						roleGrant, err = NewRoleGrant(defaultRolePublicId, "id={{.Account.Id}};actions=read,change-password")
+						roleGrant, err = NewRoleGrant(defaultRolePublicId, "id={{.Account.Id}};actions=read,change-password,enroll-totp,confirm-totp")
 						if err != nil {
 							return errors.Wrap(ctx, err, op, errors.WithMsg("unable to create in memory role grant"))
 						}
 						grants = append(grants, roleGrant)
 
-						roleGrant, err = NewRoleGrant(defaultRolePublicId, "id=*;type=auth-token;actions=list,read:self,delete:self")
+						roleGrant, err = NewRoleGrant(defaultRolePublicId, "id=*;type=auth-token;actions=list,read:self,delete:self,refresh:self")
 						if err != nil {
 							return errors.Wrap(ctx, err, op, errors.WithMsg("unable to create in memory role grant"))
 						}
@@ -336,6 +348,19 @@ func (r *Repository) CreateScope(ctx context.Context, s *Scope, userId string, o
 					msgs = append(msgs, roleGrantOplogMsgs...)
 				}
 
+				// Grant scopes
+				{
+					roleGrantScope, err := NewRoleGrantScope(ctx, defaultRolePublicId, globals.GrantScopeThis)
+					if err != nil {
+						return errors.Wrap(ctx, err, op, errors.WithMsg("unable to create in memory role grant scope"))
+					}
+					roleGrantScopeOplogMsgs := make([]*oplog.Message, 0, 1)
+					if err := w.CreateItems(ctx, []any{roleGrantScope}, db.NewOplogMsgs(&roleGrantScopeOplogMsgs)); err != nil {
+						return errors.Wrap(ctx, err, op, errors.WithMsg("unable to add grant scopes"))
+					}
+					msgs = append(msgs, roleGrantScopeOplogMsgs...)
+				}
+
 				// Principals
 				{
 					principals := []any{}
@@ -383,7 +408,8 @@ func (r *Repository) CreateScope(ctx context.Context, s *Scope, userId string, o
 // UpdateScope will update a scope in the repository and return the written
 // scope.  fieldMaskPaths provides field_mask.proto paths for fields that should
 // be updated.  Fields will be set to NULL if the field is a zero value and
-// included in fieldMask. Name and Description are the only updatable fields,
+// included in fieldMask. Name, Description, PrimaryAuthMethodId,
+// MaxSessionsPerUser and MaxSessionsPerTarget are the only updatable fields,
 // and everything else is ignored.  If no updatable fields are included in the
 // fieldMaskPaths, then an error is returned.
 func (r *Repository) UpdateScope(ctx context.Context, scope *Scope, version uint32, fieldMaskPaths []string, _ ...Option) (*Scope, int, error) {
@@ -400,12 +426,15 @@ func (r *Repository) UpdateScope(ctx context.Context, scope *Scope, version uint
 	var dbMask, nullFields []string
 	dbMask, nullFields = dbw.BuildUpdatePaths(
 		map[string]any{
-			"name":                scope.Name,
-			"description":         scope.Description,
-			"PrimaryAuthMethodId": scope.PrimaryAuthMethodId, // gorm: it's important that the field start with a capital letter.
+			"name":                 scope.Name,
+			"description":          scope.Description,
+			"PrimaryAuthMethodId":  scope.PrimaryAuthMethodId, // gorm: it's important that the field start with a capital letter.
+			"MaxSessionsPerUser":   scope.MaxSessionsPerUser,
+			"MaxSessionsPerTarget": scope.MaxSessionsPerTarget,
 		},
 		fieldMaskPaths,
-		nil,
+		// a session limit of 0 means there is no limit
+		[]string{"MaxSessionsPerUser", "MaxSessionsPerTarget"},
 	)
 	// nada to update, so reload scope from db and return it
 	if len(dbMask) == 0 && len(nullFields) == 0 {
@@ -474,6 +503,12 @@ func (r *Repository) ListScopes(ctx context.Context, withParentIds []string, opt
 	return items, nil
 }
 
+// ListDeletedScopeIds returns the scopes whose parent is one of parentIds
+// deleted after since.
+func (r *Repository) ListDeletedScopeIds(ctx context.Context, parentIds []string, since time.Time) ([]*pagination.DeletedResource, error) {
+	return r.listDeletedIds(ctx, "iam_scope", parentIds, since)
+}
+
 // ListScopesRecursively allows for recursive listing of scopes based on a root scope
 // ID. It returns the root scope ID as a part of the set.
 func (r *Repository) ListScopesRecursively(ctx context.Context, rootScopeId string, opt ...Option) ([]*Scope, error) {
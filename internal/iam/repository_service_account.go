@@ -0,0 +1,176 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package iam
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/pagination"
+	"github.com/hashicorp/go-dbw"
+)
+
+// whereServiceAccount restricts a search of iam_user to service accounts.
+const whereServiceAccount = `public_id like 'sa\_%'`
+
+// CreateServiceAccount will create a service account in the repository and
+// return the written service account. The WithPublicId option is supported
+// and all other options are ignored.
+func (r *Repository) CreateServiceAccount(ctx context.Context, sa *ServiceAccount, opt ...Option) (*ServiceAccount, error) {
+	const op = "iam.(Repository).CreateServiceAccount"
+	if sa == nil || sa.ServiceAccount == nil {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing service account")
+	}
+	if sa.PublicId != "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "public id is not empty")
+	}
+	s := sa.Clone().(*ServiceAccount)
+
+	opts := getOpts(opt...)
+	if opts.withPublicId != "" {
+		if !strings.HasPrefix(opts.withPublicId, ServiceAccountPrefix+"_") {
+			return nil, errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("passed-in public ID %q has wrong prefix, should be %q", opts.withPublicId, ServiceAccountPrefix))
+		}
+		s.PublicId = opts.withPublicId
+	} else {
+		id, err := newServiceAccountId()
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		s.PublicId = id
+	}
+
+	resource, err := r.create(ctx, s)
+	if err != nil {
+		if errors.IsUniqueError(err) {
+			return nil, errors.New(ctx, errors.NotUnique, op, fmt.Sprintf("service account %s already exists in scope %s", sa.Name, sa.ScopeId))
+		}
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("for %s", s.PublicId)))
+	}
+	return resource.(*ServiceAccount), nil
+}
+
+// UpdateServiceAccount will update a service account in the repository and
+// return the written service account. fieldMaskPaths provides field_mask.proto
+// paths for fields that should be updated. Fields will be set to NULL if the
+// field is a zero value and included in fieldMask. Name and Description are the
+// only updatable fields, if no updatable fields are included in the
+// fieldMaskPaths, then an error is returned.
+func (r *Repository) UpdateServiceAccount(ctx context.Context, sa *ServiceAccount, version uint32, fieldMaskPaths []string, _ ...Option) (*ServiceAccount, int, error) {
+	const op = "iam.(Repository).UpdateServiceAccount"
+	if sa == nil || sa.ServiceAccount == nil {
+		return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing service account")
+	}
+	if sa.PublicId == "" {
+		return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing public id")
+	}
+	if !strings.HasPrefix(sa.PublicId, ServiceAccountPrefix+"_") {
+		return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidPublicId, op, fmt.Sprintf("%q is not a service account id", sa.PublicId))
+	}
+	for _, f := range fieldMaskPaths {
+		switch {
+		case strings.EqualFold("name", f):
+		case strings.EqualFold("description", f):
+		default:
+			return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidFieldMask, op, fmt.Sprintf("invalid field mask: %s", f))
+		}
+	}
+	var dbMask, nullFields []string
+	dbMask, nullFields = dbw.BuildUpdatePaths(
+		map[string]any{
+			"name":        sa.Name,
+			"description": sa.Description,
+		},
+		fieldMaskPaths,
+		nil,
+	)
+	if len(dbMask) == 0 && len(nullFields) == 0 {
+		return nil, db.NoRowsAffected, errors.New(ctx, errors.EmptyFieldMask, op, "empty field mask")
+	}
+	resource, rowsUpdated, err := r.update(ctx, sa.Clone().(*ServiceAccount), version, dbMask, nullFields)
+	if err != nil {
+		if errors.IsUniqueError(err) {
+			return nil, db.NoRowsAffected, errors.New(ctx, errors.NotUnique, op, fmt.Sprintf("service account %s already exists in scope %s", sa.Name, sa.ScopeId))
+		}
+		return nil, db.NoRowsAffected, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("for %s", sa.PublicId)))
+	}
+	return resource.(*ServiceAccount), rowsUpdated, nil
+}
+
+// LookupServiceAccount will look up a service account in the repository. If
+// the service account is not found, it will return nil, nil.
+func (r *Repository) LookupServiceAccount(ctx context.Context, id string, _ ...Option) (*ServiceAccount, error) {
+	const op = "iam.(Repository).LookupServiceAccount"
+	if id == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing public id")
+	}
+	if !strings.HasPrefix(id, ServiceAccountPrefix+"_") {
+		return nil, nil
+	}
+	sa := AllocServiceAccount()
+	sa.PublicId = id
+	if err := r.reader.LookupByPublicId(ctx, &sa); err != nil {
+		if errors.IsNotFoundError(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("for %s", id)))
+	}
+	return &sa, nil
+}
+
+// DeleteServiceAccount will delete a service account, and with it all of its
+// api keys, from the repository.
+func (r *Repository) DeleteServiceAccount(ctx context.Context, id string, _ ...Option) (int, error) {
+	const op = "iam.(Repository).DeleteServiceAccount"
+	if id == "" {
+		return db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "missing public id")
+	}
+	sa, err := r.LookupServiceAccount(ctx, id)
+	if err != nil {
+		return db.NoRowsAffected, errors.Wrap(ctx, err, op)
+	}
+	if sa == nil {
+		return db.NoRowsAffected, nil
+	}
+	rowsDeleted, err := r.delete(ctx, sa)
+	if err != nil {
+		return db.NoRowsAffected, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("for %s", id)))
+	}
+	return rowsDeleted, nil
+}
+
+// ListServiceAccounts lists service accounts in the given scopes and supports
+// the WithLimit and WithPage options.
+func (r *Repository) ListServiceAccounts(ctx context.Context, withScopeIds []string, opt ...Option) ([]*ServiceAccount, error) {
+	const op = "iam.(Repository).ListServiceAccounts"
+	if len(withScopeIds) == 0 {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing scope id")
+	}
+	var serviceAccounts []*ServiceAccount
+	if err := r.list(ctx, &serviceAccounts, "scope_id in (?) and "+whereServiceAccount, []any{withScopeIds}, opt...); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return serviceAccounts, nil
+}
+
+// ListDeletedServiceAccountIds returns the service accounts in one of scopeIds
+// deleted after since.
+func (r *Repository) ListDeletedServiceAccountIds(ctx context.Context, scopeIds []string, since time.Time) ([]*pagination.DeletedResource, error) {
+	const op = "iam.(Repository).ListDeletedServiceAccountIds"
+	deleted, err := r.listDeletedIds(ctx, defaultUserTableName, scopeIds, since)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	sas := make([]*pagination.DeletedResource, 0, len(deleted))
+	for _, d := range deleted {
+		if strings.HasPrefix(d.PublicId, ServiceAccountPrefix+"_") {
+			sas = append(sas, d)
+		}
+	}
+	return sas, nil
+}
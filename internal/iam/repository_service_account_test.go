@@ -0,0 +1,182 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package iam_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/pagination"
+	"github.com/hashicorp/boundary/internal/types/scope"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_CreateServiceAccount(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	repo := iam.TestRepo(t, conn, wrapper)
+	org, proj := iam.TestScopes(t, repo)
+
+	tests := []struct {
+		name      string
+		scopeId   string
+		opts      []iam.Option
+		wantIsErr errors.Code
+	}{
+		{
+			name:    "global",
+			scopeId: "global",
+			opts:    []iam.Option{iam.WithName("global-sa")},
+		},
+		{
+			name:    "org",
+			scopeId: org.GetPublicId(),
+			opts:    []iam.Option{iam.WithName("org-sa"), iam.WithDescription("desc")},
+		},
+		{
+			name:      "duplicate-name",
+			scopeId:   org.GetPublicId(),
+			opts:      []iam.Option{iam.WithName("org-sa")},
+			wantIsErr: errors.NotUnique,
+		},
+		{
+			name:      "project",
+			scopeId:   proj.GetPublicId(),
+			wantIsErr: errors.InvalidParameter,
+		},
+		{
+			name:      "user-public-id",
+			scopeId:   org.GetPublicId(),
+			opts:      []iam.Option{iam.WithPublicId("u_1234567890")},
+			wantIsErr: errors.InvalidPublicId,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			sa, err := iam.NewServiceAccount(ctx, tt.scopeId, tt.opts...)
+			require.NoError(err)
+			got, err := repo.CreateServiceAccount(ctx, sa, tt.opts...)
+			if tt.wantIsErr != 0 {
+				assert.Truef(errors.Match(errors.T(tt.wantIsErr), err), "Unexpected error %s", err)
+				assert.Nil(got)
+				return
+			}
+			require.NoError(err)
+			assert.True(strings.HasPrefix(got.GetPublicId(), iam.ServiceAccountPrefix+"_"))
+			assert.Equal(tt.scopeId, got.GetScopeId())
+
+			found, err := repo.LookupServiceAccount(ctx, got.GetPublicId())
+			require.NoError(err)
+			assert.Equal(got.GetName(), found.GetName())
+		})
+	}
+}
+
+func TestRepository_UpdateServiceAccount(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	repo := iam.TestRepo(t, conn, wrapper)
+	org, _ := iam.TestScopes(t, repo)
+	sa := iam.TestServiceAccount(t, repo, org.GetPublicId(), iam.WithName("name"))
+
+	upd := sa.Clone().(*iam.ServiceAccount)
+	upd.Name = "updated"
+	upd.Description = ""
+	got, rowsUpdated, err := repo.UpdateServiceAccount(ctx, upd, sa.GetVersion(), []string{"Name", "Description"})
+	require.NoError(t, err)
+	assert.Equal(t, 1, rowsUpdated)
+	assert.Equal(t, "updated", got.GetName())
+
+	_, _, err = repo.UpdateServiceAccount(ctx, upd, got.GetVersion(), []string{"ScopeId"})
+	assert.Truef(t, errors.Match(errors.T(errors.InvalidFieldMask), err), "Unexpected error %s", err)
+
+	u := iam.TestUser(t, repo, org.GetPublicId())
+	upd.PublicId = u.GetPublicId()
+	_, _, err = repo.UpdateServiceAccount(ctx, upd, u.GetVersion(), []string{"Name"})
+	assert.Truef(t, errors.Match(errors.T(errors.InvalidPublicId), err), "Unexpected error %s", err)
+}
+
+func TestRepository_ListServiceAccounts(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	repo := iam.TestRepo(t, conn, wrapper)
+	org, _ := iam.TestScopes(t, repo)
+
+	u := iam.TestUser(t, repo, org.GetPublicId())
+	sa := iam.TestServiceAccount(t, repo, org.GetPublicId())
+
+	serviceAccounts, err := repo.ListServiceAccounts(ctx, []string{org.GetPublicId()})
+	require.NoError(t, err)
+	require.Len(t, serviceAccounts, 1)
+	assert.Equal(t, sa.GetPublicId(), serviceAccounts[0].GetPublicId())
+
+	// service accounts are stored with users but are not listed as users
+	users, err := repo.ListUsers(ctx, []string{org.GetPublicId()})
+	require.NoError(t, err)
+	require.Len(t, users, 1)
+	assert.Equal(t, u.GetPublicId(), users[0].GetPublicId())
+
+	// a user is not a service account
+	found, err := repo.LookupServiceAccount(ctx, u.GetPublicId())
+	require.NoError(t, err)
+	assert.Nil(t, found)
+}
+
+func TestRepository_DeleteServiceAccount(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	repo := iam.TestRepo(t, conn, wrapper)
+	org, _ := iam.TestScopes(t, repo)
+	u := iam.TestUser(t, repo, org.GetPublicId())
+	sa := iam.TestServiceAccount(t, repo, org.GetPublicId())
+
+	since := time.Now().Add(-time.Minute)
+	deleted, err := repo.DeleteServiceAccount(ctx, sa.GetPublicId())
+	require.NoError(t, err)
+	assert.Equal(t, 1, deleted)
+
+	removed, err := repo.ListDeletedServiceAccountIds(ctx, []string{org.GetPublicId()}, since)
+	require.NoError(t, err)
+	assert.Equal(t, []*pagination.DeletedResource{{PublicId: sa.GetPublicId(), ScopeId: org.GetPublicId()}}, removed)
+
+	removed, err = repo.ListDeletedServiceAccountIds(ctx, []string{scope.Global.String()}, since)
+	require.NoError(t, err)
+	assert.Empty(t, removed)
+
+	// users can't be deleted as service accounts
+	deleted, err = repo.DeleteServiceAccount(ctx, u.GetPublicId())
+	require.NoError(t, err)
+	assert.Equal(t, 0, deleted)
+}
+
+func TestRepository_ServiceAccountPrincipalRole(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	repo := iam.TestRepo(t, conn, wrapper)
+	org, _ := iam.TestScopes(t, repo)
+	sa := iam.TestServiceAccount(t, repo, org.GetPublicId())
+	role := iam.TestRole(t, conn, org.GetPublicId())
+
+	got, err := repo.AddPrincipalRoles(ctx, role.GetPublicId(), role.GetVersion(), []string{sa.GetPublicId()})
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, sa.GetPublicId(), got[0].GetPrincipalId())
+}
@@ -0,0 +1,106 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package iam
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/hashicorp/boundary/internal/errors"
+)
+
+// SessionLimit is a limit on the number of pending and active sessions of a
+// user. It is defined by a scope or by a role the user is a principal of.
+type SessionLimit struct {
+	// ResourceId is the id of the scope or role which defines the limit.
+	ResourceId string
+	// ScopeId is the id of the scope the limit applies to. The sessions of
+	// the user on targets within the scope or its child scopes are counted
+	// against the limit.
+	ScopeId string
+	// MaxSessionsPerUser is the maximum number of sessions the user may have
+	// within the scope. 0 means there is no limit.
+	MaxSessionsPerUser uint32
+	// MaxSessionsPerTarget is the maximum number of sessions the user may
+	// have on any single target within the scope. 0 means there is no limit.
+	MaxSessionsPerTarget uint32
+}
+
+// ListSessionLimits returns the session limits which apply to the sessions of
+// userId on targets in projectId. These are the limits of the project, of its
+// parent org and of the global scope, along with the limits of the roles
+// userId is a principal of whose grant scopes include the project. Scopes and
+// roles without limits are not returned.
+func (r *Repository) ListSessionLimits(ctx context.Context, userId, projectId string, _ ...Option) ([]*SessionLimit, error) {
+	const op = "iam.(Repository).ListSessionLimits"
+	switch {
+	case userId == "":
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing user id")
+	case projectId == "":
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing project id")
+	}
+
+	const (
+		authUser           = `where public_id in ('u_anon', 'u_auth', @user_id)`
+		sessionLimitsQuery = `
+with
+` + userRolesCte + `,
+roles (role_id, role_scope_id) as (
+  select public_id,
+         scope_id
+    from iam_role
+   where public_id in (select role_id from user_group_roles)
+),
+` + roleGrantScopesCte + `,
+project (public_id, parent_id) as (
+  select public_id,
+         parent_id
+    from iam_scope
+   where public_id = @project_id
+)
+select public_id as resource_id,
+       public_id as scope_id,
+       max_sessions_per_user,
+       max_sessions_per_target
+  from iam_scope
+ where (public_id in (select public_id from project)
+        or public_id in (select parent_id from project)
+        or public_id = 'global')
+   and (max_sessions_per_user > 0 or max_sessions_per_target > 0)
+ union all
+select iam_role.public_id as resource_id,
+       role_grant_scopes.grant_scope_id as scope_id,
+       iam_role.max_sessions_per_user,
+       iam_role.max_sessions_per_target
+  from iam_role
+ inner
+  join role_grant_scopes
+    on iam_role.public_id = role_grant_scopes.role_id
+ where role_grant_scopes.grant_scope_id = @project_id
+   and (iam_role.max_sessions_per_user > 0 or iam_role.max_sessions_per_target > 0);
+`
+	)
+
+	rows, err := r.reader.Query(ctx, fmt.Sprintf(sessionLimitsQuery, authUser), []any{
+		sql.Named("user_id", userId),
+		sql.Named("project_id", projectId),
+	})
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	defer rows.Close()
+	var limits []*SessionLimit
+	for rows.Next() {
+		var l SessionLimit
+		if err := r.reader.ScanRows(ctx, rows, &l); err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		limits = append(limits, &l)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return limits, nil
+}
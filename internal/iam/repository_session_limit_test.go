@@ -0,0 +1,110 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package iam
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_ListSessionLimits(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	repo := TestRepo(t, conn, wrapper)
+
+	org := TestOrg(t, repo, WithMaxSessionsPerUser(10))
+	proj := TestProject(t, repo, org.PublicId, WithMaxSessionsPerTarget(2))
+	otherProj := TestProject(t, repo, org.PublicId)
+	user := TestUser(t, repo, org.PublicId)
+
+	userRole := TestRole(t, conn, proj.PublicId, WithMaxSessionsPerUser(3))
+	TestUserRole(t, conn, userRole.PublicId, user.PublicId)
+
+	orgRole := TestRole(t, conn, org.PublicId, WithGrantScopeIds([]string{proj.PublicId}), WithMaxSessionsPerTarget(4))
+	TestUserRole(t, conn, orgRole.PublicId, user.PublicId)
+
+	grp := TestGroup(t, conn, org.PublicId)
+	TestGroupMember(t, conn, grp.PublicId, user.PublicId)
+	groupRole := TestRole(t, conn, proj.PublicId, WithMaxSessionsPerUser(5))
+	TestGroupRole(t, conn, groupRole.PublicId, grp.PublicId)
+
+	// a role of the user without limits
+	TestUserRole(t, conn, TestRole(t, conn, proj.PublicId).PublicId, user.PublicId)
+	// a role with limits the user is not a principal of
+	TestRole(t, conn, proj.PublicId, WithMaxSessionsPerUser(1))
+	// a role of the user with limits which is granted on another project
+	otherRole := TestRole(t, conn, otherProj.PublicId, WithMaxSessionsPerUser(1))
+	TestUserRole(t, conn, otherRole.PublicId, user.PublicId)
+
+	orgLimit := &SessionLimit{ResourceId: org.PublicId, ScopeId: org.PublicId, MaxSessionsPerUser: 10}
+
+	tests := []struct {
+		name      string
+		userId    string
+		projectId string
+		want      []*SessionLimit
+		wantErrIs errors.Code
+	}{
+		{
+			name:      "missing-user-id",
+			projectId: proj.PublicId,
+			wantErrIs: errors.InvalidParameter,
+		},
+		{
+			name:      "missing-project-id",
+			userId:    user.PublicId,
+			wantErrIs: errors.InvalidParameter,
+		},
+		{
+			name:      "project",
+			userId:    user.PublicId,
+			projectId: proj.PublicId,
+			want: []*SessionLimit{
+				orgLimit,
+				{ResourceId: proj.PublicId, ScopeId: proj.PublicId, MaxSessionsPerTarget: 2},
+				{ResourceId: userRole.PublicId, ScopeId: proj.PublicId, MaxSessionsPerUser: 3},
+				{ResourceId: orgRole.PublicId, ScopeId: proj.PublicId, MaxSessionsPerTarget: 4},
+				{ResourceId: groupRole.PublicId, ScopeId: proj.PublicId, MaxSessionsPerUser: 5},
+			},
+		},
+		{
+			name:      "other-project",
+			userId:    user.PublicId,
+			projectId: otherProj.PublicId,
+			want: []*SessionLimit{
+				orgLimit,
+				{ResourceId: otherRole.PublicId, ScopeId: otherProj.PublicId, MaxSessionsPerUser: 1},
+			},
+		},
+		{
+			name:      "other-user",
+			userId:    TestUser(t, repo, org.PublicId).PublicId,
+			projectId: proj.PublicId,
+			want: []*SessionLimit{
+				orgLimit,
+				{ResourceId: proj.PublicId, ScopeId: proj.PublicId, MaxSessionsPerTarget: 2},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, err := repo.ListSessionLimits(ctx, tt.userId, tt.projectId)
+			if tt.wantErrIs != 0 {
+				require.Error(err)
+				assert.Truef(errors.Match(errors.T(tt.wantErrIs), err), "unexpected error %s", err.Error())
+				return
+			}
+			require.NoError(err)
+			assert.ElementsMatch(tt.want, got)
+		})
+	}
+}
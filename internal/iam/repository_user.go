@@ -7,11 +7,13 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/boundary/internal/db"
 	"github.com/hashicorp/boundary/internal/errors"
 	"github.com/hashicorp/boundary/internal/kms"
 	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/hashicorp/boundary/internal/pagination"
 	"github.com/hashicorp/boundary/internal/types/scope"
 	"github.com/hashicorp/go-dbw"
 )
@@ -221,6 +223,11 @@ func (r *Repository) ListUsers(ctx context.Context, withScopeIds []string, opt .
 	return users, nil
 }
 
+// ListDeletedUserIds returns the users in one of scopeIds deleted after since.
+func (r *Repository) ListDeletedUserIds(ctx context.Context, scopeIds []string, since time.Time) ([]*pagination.DeletedResource, error) {
+	return r.listDeletedIds(ctx, "iam_user", scopeIds, since)
+}
+
 // LookupUserWithLogin will attempt to lookup the user with a matching
 // account id and return the user if found. If a user is not found and the
 // account's scope is not the PrimaryAuthMethod, then an error is returned.
@@ -878,7 +885,7 @@ func (r *Repository) getUsers(ctx context.Context, userId string, scopeIds []str
 		// non-zero signals an override of the default limit for the repo.
 		limit = opts.withLimit
 	}
-	dbArgs = append(dbArgs, db.WithLimit(limit))
+	dbArgs = append(dbArgs, db.WithLimit(limit), db.WithPage(opts.withPage))
 
 	var args []any
 	var where []string
@@ -886,7 +893,8 @@ func (r *Repository) getUsers(ctx context.Context, userId string, scopeIds []str
 	case userId != "":
 		where, args = append(where, "public_id = ?"), append(args, userId)
 	default:
-		where, args = append(where, "scope_id in(?)"), append(args, scopeIds)
+		// service accounts are stored with the users but listed separately
+		where, args = append(where, "scope_id in(?)", "not "+whereServiceAccount), append(args, scopeIds)
 	}
 	var usersAcctInfo []*userAccountInfo
 	err := r.reader.SearchWhere(ctx, &usersAcctInfo, strings.Join(where, " and "), args, dbArgs...)
@@ -33,7 +33,8 @@ var (
 )
 
 // NewRole creates a new in memory role with a scope (project/org)
-// allowed options include: withDescripion, WithName, withGrantScopeId.
+// allowed options include: withDescripion, WithName, WithMaxSessionsPerUser,
+// WithMaxSessionsPerTarget.
 func NewRole(scopeId string, opt ...Option) (*Role, error) {
 	const op = "iam.NewRole"
 	if scopeId == "" {
@@ -42,10 +43,11 @@ func NewRole(scopeId string, opt ...Option) (*Role, error) {
 	opts := getOpts(opt...)
 	r := &Role{
 		Role: &store.Role{
-			ScopeId:      scopeId,
-			Name:         opts.withName,
-			Description:  opts.withDescription,
-			GrantScopeId: opts.withGrantScopeId,
+			ScopeId:              scopeId,
+			Name:                 opts.withName,
+			Description:          opts.withDescription,
+			MaxSessionsPerUser:   opts.withMaxSessionsPerUser,
+			MaxSessionsPerTarget: opts.withMaxSessionsPerTarget,
 		},
 	}
 	return r, nil
@@ -95,6 +97,9 @@ func (*Role) Actions() map[string]action.Type {
 	ret[action.AddGrants.String()] = action.AddGrants
 	ret[action.RemoveGrants.String()] = action.RemoveGrants
 	ret[action.SetGrants.String()] = action.SetGrants
+	ret[action.AddGrantScopes.String()] = action.AddGrantScopes
+	ret[action.RemoveGrantScopes.String()] = action.RemoveGrantScopes
+	ret[action.SetGrantScopes.String()] = action.SetGrantScopes
 	ret[action.AddPrincipals.String()] = action.AddPrincipals
 	ret[action.RemovePrincipals.String()] = action.RemovePrincipals
 	ret[action.SetPrincipals.String()] = action.SetPrincipals
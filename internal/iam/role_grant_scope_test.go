@@ -0,0 +1,308 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package iam
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/boundary/globals"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/iam/store"
+	"github.com/hashicorp/boundary/internal/types/scope"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestRoleGrantScope_New(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	tests := []struct {
+		name       string
+		roleId     string
+		grantScope string
+		wantIsErr  errors.Code
+	}{
+		{
+			name:       "missing-role-id",
+			grantScope: globals.GrantScopeThis,
+			wantIsErr:  errors.InvalidParameter,
+		},
+		{
+			name:      "missing-grant-scope",
+			roleId:    "r_1234567890",
+			wantIsErr: errors.InvalidParameter,
+		},
+		{
+			name:       "unknown-grant-scope",
+			roleId:     "r_1234567890",
+			grantScope: "everything",
+			wantIsErr:  errors.InvalidParameter,
+		},
+		{
+			name:       "this",
+			roleId:     "r_1234567890",
+			grantScope: globals.GrantScopeThis,
+		},
+		{
+			name:       "children",
+			roleId:     "r_1234567890",
+			grantScope: globals.GrantScopeChildren,
+		},
+		{
+			name:       "descendants",
+			roleId:     "r_1234567890",
+			grantScope: globals.GrantScopeDescendants,
+		},
+		{
+			name:       "global",
+			roleId:     "r_1234567890",
+			grantScope: scope.Global.String(),
+		},
+		{
+			name:       "org",
+			roleId:     "r_1234567890",
+			grantScope: "o_1234567890",
+		},
+		{
+			name:       "project",
+			roleId:     "r_1234567890",
+			grantScope: "p_1234567890",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, err := NewRoleGrantScope(ctx, tt.roleId, tt.grantScope)
+			if tt.wantIsErr != 0 {
+				require.Error(err)
+				assert.True(errors.Match(errors.T(tt.wantIsErr), err))
+				return
+			}
+			require.NoError(err)
+			assert.Equal(tt.roleId, got.GetRoleId())
+			assert.Equal(tt.grantScope, got.GetScopeIdOrSpecial())
+		})
+	}
+}
+
+func TestRoleGrantScope_Create(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	repo := TestRepo(t, conn, wrapper)
+	org, proj := TestScopes(t, repo)
+	otherOrg, otherProj := TestScopes(t, repo)
+
+	tests := []struct {
+		name       string
+		roleScope  string
+		existing   []string
+		grantScope string
+		wantErr    bool
+	}{
+		{
+			name:       "global-descendants",
+			roleScope:  scope.Global.String(),
+			grantScope: globals.GrantScopeDescendants,
+		},
+		{
+			name:       "global-children",
+			roleScope:  scope.Global.String(),
+			grantScope: globals.GrantScopeChildren,
+		},
+		{
+			name:       "global-org",
+			roleScope:  scope.Global.String(),
+			grantScope: org.PublicId,
+		},
+		{
+			name:       "global-project",
+			roleScope:  scope.Global.String(),
+			grantScope: proj.PublicId,
+		},
+		{
+			name:       "global-children-and-project",
+			roleScope:  scope.Global.String(),
+			existing:   []string{globals.GrantScopeChildren},
+			grantScope: proj.PublicId,
+		},
+		{
+			name:       "global-children-and-org",
+			roleScope:  scope.Global.String(),
+			existing:   []string{globals.GrantScopeChildren},
+			grantScope: org.PublicId,
+			wantErr:    true,
+		},
+		{
+			name:       "global-descendants-and-project",
+			roleScope:  scope.Global.String(),
+			existing:   []string{globals.GrantScopeDescendants},
+			grantScope: proj.PublicId,
+			wantErr:    true,
+		},
+		{
+			name:       "global-descendants-and-children",
+			roleScope:  scope.Global.String(),
+			existing:   []string{globals.GrantScopeDescendants},
+			grantScope: globals.GrantScopeChildren,
+			wantErr:    true,
+		},
+		{
+			name:       "global-own-scope",
+			roleScope:  scope.Global.String(),
+			grantScope: scope.Global.String(),
+			wantErr:    true,
+		},
+		{
+			name:       "org-children",
+			roleScope:  org.PublicId,
+			grantScope: globals.GrantScopeChildren,
+		},
+		{
+			name:       "org-project",
+			roleScope:  org.PublicId,
+			grantScope: proj.PublicId,
+		},
+		{
+			name:       "org-descendants",
+			roleScope:  org.PublicId,
+			grantScope: globals.GrantScopeDescendants,
+			wantErr:    true,
+		},
+		{
+			name:       "org-other-project",
+			roleScope:  org.PublicId,
+			grantScope: otherProj.PublicId,
+			wantErr:    true,
+		},
+		{
+			name:       "org-other-org",
+			roleScope:  org.PublicId,
+			grantScope: otherOrg.PublicId,
+			wantErr:    true,
+		},
+		{
+			name:       "org-missing-scope",
+			roleScope:  org.PublicId,
+			grantScope: "p_1234567890",
+			wantErr:    true,
+		},
+		{
+			name:       "project-this",
+			roleScope:  proj.PublicId,
+			existing:   []string{},
+			grantScope: globals.GrantScopeThis,
+		},
+		{
+			name:       "project-children",
+			roleScope:  proj.PublicId,
+			grantScope: globals.GrantScopeChildren,
+			wantErr:    true,
+		},
+		{
+			name:       "project-other-project",
+			roleScope:  proj.PublicId,
+			grantScope: otherProj.PublicId,
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			role := TestRole(t, conn, tt.roleScope, WithGrantScopeIds(tt.existing))
+			if tt.existing != nil {
+				// TestRole defaults to "this" for an empty slice, so remove
+				// any grant scopes which were not asked for.
+				db.TestDeleteWhere(t, conn, func() any { a := allocRoleGrantScope(); return &a }(), "role_id = ?", role.PublicId)
+				for _, gs := range tt.existing {
+					TestRoleGrantScope(t, conn, role.PublicId, gs)
+				}
+			}
+			gs, err := NewRoleGrantScope(ctx, role.PublicId, tt.grantScope)
+			require.NoError(err)
+			err = db.New(conn).Create(ctx, gs)
+			if tt.wantErr {
+				assert.Error(err)
+				return
+			}
+			require.NoError(err)
+			assert.NotNil(gs.GetCreateTime())
+
+			// ensure duplicate grant scopes aren't allowed
+			assert.Error(db.New(conn).Create(ctx, gs.Clone().(*RoleGrantScope)))
+		})
+	}
+	t.Run("deleted-scope", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		o, p := TestScopes(t, repo)
+		role := TestRole(t, conn, o.PublicId, WithGrantScopeIds([]string{globals.GrantScopeThis, p.PublicId}))
+		_, err := repo.DeleteScope(ctx, p.PublicId)
+		require.NoError(err)
+		grantScopes, err := repo.ListRoleGrantScopes(ctx, []string{role.PublicId})
+		require.NoError(err)
+		require.Len(grantScopes, 1)
+		assert.Equal(globals.GrantScopeThis, grantScopes[0].GetScopeIdOrSpecial())
+	})
+}
+
+func TestRoleGrantScope_Clone(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	t.Run("valid", func(t *testing.T) {
+		assert := assert.New(t)
+		g, err := NewRoleGrantScope(ctx, "r_1234567890", globals.GrantScopeThis)
+		assert.NoError(err)
+		cp := g.Clone()
+		assert.True(proto.Equal(cp.(*RoleGrantScope).RoleGrantScope, g.RoleGrantScope))
+	})
+	t.Run("not-equal", func(t *testing.T) {
+		assert := assert.New(t)
+		g, err := NewRoleGrantScope(ctx, "r_1234567890", globals.GrantScopeThis)
+		assert.NoError(err)
+		g2, err := NewRoleGrantScope(ctx, "r_1234567890", globals.GrantScopeChildren)
+		assert.NoError(err)
+		cp := g.Clone()
+		assert.False(proto.Equal(cp.(*RoleGrantScope).RoleGrantScope, g2.RoleGrantScope))
+	})
+}
+
+func TestRoleGrantScope_SetTableName(t *testing.T) {
+	defaultTableName := defaultRoleGrantScopeTable
+	tests := []struct {
+		name        string
+		initialName string
+		setNameTo   string
+		want        string
+	}{
+		{
+			name:        "new-name",
+			initialName: "",
+			setNameTo:   "new-name",
+			want:        "new-name",
+		},
+		{
+			name:        "reset to default",
+			initialName: "initial",
+			setNameTo:   "",
+			want:        defaultTableName,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			def := allocRoleGrantScope()
+			require.Equal(defaultTableName, def.TableName())
+			s := &RoleGrantScope{
+				RoleGrantScope: &store.RoleGrantScope{},
+				tableName:      tt.initialName,
+			}
+			s.SetTableName(tt.setNameTo)
+			assert.Equal(tt.want, s.TableName())
+		})
+	}
+}
@@ -217,7 +217,6 @@ func Test_RoleUpdate(t *testing.T) {
 	repo := TestRepo(t, conn, wrapper)
 	id := testId(t)
 	org, proj := TestScopes(t, repo)
-	org2, proj2 := TestScopes(t, repo)
 	rw := db.New(conn)
 	type args struct {
 		name            string
@@ -225,7 +224,6 @@ func Test_RoleUpdate(t *testing.T) {
 		fieldMaskPaths  []string
 		nullPaths       []string
 		scopeId         string
-		grantScopeId    string
 		scopeIdOverride string
 		opts            []db.Option
 	}
@@ -323,80 +321,6 @@ func Test_RoleUpdate(t *testing.T) {
 			wantErr:        false,
 			wantRowsUpdate: 1,
 		},
-		{
-			name: "set grant scope in project with same scope",
-			args: args{
-				name:           "set grant scope in project with same scope",
-				fieldMaskPaths: []string{"Name", "GrantScopeId"},
-				scopeId:        proj.PublicId,
-				grantScopeId:   proj.PublicId,
-			},
-			wantRowsUpdate: 1,
-		},
-		{
-			name: "set grant scope in org with same scope",
-			args: args{
-				name:           "set grant scope in org with same scope",
-				fieldMaskPaths: []string{"Name", "GrantScopeId"},
-				scopeId:        org2.PublicId,
-				grantScopeId:   org2.PublicId,
-			},
-			wantRowsUpdate: 1,
-		},
-		{
-			name: "set grant scope in project with different scope",
-			args: args{
-				name:           "set grant scope in project with different scope",
-				fieldMaskPaths: []string{"GrantScopeId"},
-				scopeId:        proj.PublicId,
-				grantScopeId:   proj2.PublicId,
-			},
-			wantErr:    true,
-			wantErrMsg: "db.Update: invalid to set grant_scope_id to non-same scope_id when role scope type is project: integrity violation: error #1104",
-		},
-		{
-			name: "set grant scope in org",
-			args: args{
-				name:           "set grant scope in org",
-				fieldMaskPaths: []string{"Name", "GrantScopeId"},
-				scopeId:        org2.PublicId,
-				grantScopeId:   proj2.PublicId,
-			},
-			wantRowsUpdate: 1,
-		},
-		{
-			name: "set grant scope in external project",
-			args: args{
-				name:           "set grant scope in external project",
-				fieldMaskPaths: []string{"GrantScopeId"},
-				scopeId:        org.PublicId,
-				grantScopeId:   proj2.PublicId,
-			},
-			wantErr:    true,
-			wantErrMsg: "db.Update: grant_scope_id is not a child project of the role scope: integrity violation: error #1104",
-		},
-		{
-			name: "set grant scope in global",
-			args: args{
-				name:           "set grant scope in global",
-				fieldMaskPaths: []string{"GrantScopeId"},
-				scopeId:        org.PublicId,
-				grantScopeId:   "global",
-			},
-			wantErr:    true,
-			wantErrMsg: "db.Update: grant_scope_id is not a child project of the role scope: integrity violation: error #1104",
-		},
-		{
-			name: "set grant scope to parent",
-			args: args{
-				name:           "set grant scope to parent",
-				fieldMaskPaths: []string{"GrantScopeId"},
-				scopeId:        proj2.PublicId,
-				grantScopeId:   org2.PublicId,
-			},
-			wantErr:    true,
-			wantErrMsg: "db.Update: invalid to set grant_scope_id to non-same scope_id when role scope type is project: integrity violation: error #1104",
-		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -422,7 +346,6 @@ func Test_RoleUpdate(t *testing.T) {
 			}
 			updateRole.Name = tt.args.name
 			updateRole.Description = tt.args.description
-			updateRole.GrantScopeId = tt.args.grantScopeId
 
 			updatedRows, err := rw.Update(context.Background(), &updateRole, tt.args.fieldMaskPaths, tt.args.nullPaths, tt.args.opts...)
 			if tt.wantErr {
@@ -441,9 +364,6 @@ func Test_RoleUpdate(t *testing.T) {
 			foundRole.PublicId = role.GetPublicId()
 			err = rw.LookupByPublicId(context.Background(), &foundRole)
 			require.NoError(err)
-			if tt.args.grantScopeId == "" {
-				updateRole.GrantScopeId = role.ScopeId
-			}
 			assert.True(proto.Equal(updateRole, foundRole))
 			if len(tt.args.nullPaths) != 0 {
 				underlyingDB, err := conn.SqlDB(ctx)
@@ -548,6 +468,9 @@ func TestRole_Actions(t *testing.T) {
 	assert.Equal(a[action.AddGrants.String()], action.AddGrants)
 	assert.Equal(a[action.RemoveGrants.String()], action.RemoveGrants)
 	assert.Equal(a[action.SetGrants.String()], action.SetGrants)
+	assert.Equal(a[action.AddGrantScopes.String()], action.AddGrantScopes)
+	assert.Equal(a[action.RemoveGrantScopes.String()], action.RemoveGrantScopes)
+	assert.Equal(a[action.SetGrantScopes.String()], action.SetGrantScopes)
 	assert.Equal(a[action.AddPrincipals.String()], action.AddPrincipals)
 	assert.Equal(a[action.RemovePrincipals.String()], action.RemovePrincipals)
 	assert.Equal(a[action.SetPrincipals.String()], action.SetPrincipals)
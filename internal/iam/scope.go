@@ -59,7 +59,8 @@ func NewProject(orgPublicId string, opt ...Option) (*Scope, error) {
 // friendly name. WithDescription specifies the scope's description. WithScope
 // specifies the Scope's parent and must be filled in. The type of the parent is
 // used to determine the type of the child. WithPrimaryAuthMethodId specifies
-// the primary auth method for the scope
+// the primary auth method for the scope. WithMaxSessionsPerUser and
+// WithMaxSessionsPerTarget specify the session limits of the scope.
 func newScope(parent *Scope, opt ...Option) (*Scope, error) {
 	const op = "iam.newScope"
 	if parent == nil || parent.PublicId == "" {
@@ -79,11 +80,13 @@ func newScope(parent *Scope, opt ...Option) (*Scope, error) {
 	opts := getOpts(opt...)
 	s := &Scope{
 		Scope: &store.Scope{
-			Type:                typ.String(),
-			Name:                opts.withName,
-			Description:         opts.withDescription,
-			ParentId:            parent.PublicId,
-			PrimaryAuthMethodId: opts.withPrimaryAuthMethodId,
+			Type:                 typ.String(),
+			Name:                 opts.withName,
+			Description:          opts.withDescription,
+			ParentId:             parent.PublicId,
+			PrimaryAuthMethodId:  opts.withPrimaryAuthMethodId,
+			MaxSessionsPerUser:   opts.withMaxSessionsPerUser,
+			MaxSessionsPerTarget: opts.withMaxSessionsPerTarget,
 		},
 	}
 
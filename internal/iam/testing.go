@@ -8,6 +8,7 @@ import (
 	"crypto/rand"
 	"testing"
 
+	"github.com/hashicorp/boundary/globals"
 	"github.com/hashicorp/boundary/internal/auth/store"
 	"github.com/hashicorp/boundary/internal/db"
 	dbassert "github.com/hashicorp/boundary/internal/db/assert"
@@ -174,6 +175,19 @@ func TestUser(t testing.TB, repo *Repository, scopeId string, opt ...Option) *Us
 	return user
 }
 
+// TestServiceAccount creates a service account suitable for testing.
+func TestServiceAccount(t testing.TB, repo *Repository, scopeId string, opt ...Option) *ServiceAccount {
+	t.Helper()
+	require := require.New(t)
+
+	sa, err := NewServiceAccount(context.Background(), scopeId, opt...)
+	require.NoError(err)
+	sa, err = repo.CreateServiceAccount(context.Background(), sa)
+	require.NoError(err)
+	require.NotEmpty(sa.PublicId)
+	return sa
+}
+
 // TestRole creates a role suitable for testing.
 func TestRole(t testing.TB, conn *db.DB, scopeId string, opt ...Option) *Role {
 	t.Helper()
@@ -192,9 +206,29 @@ func TestRole(t testing.TB, conn *db.DB, scopeId string, opt ...Option) *Role {
 	opts := getOpts(opt...)
 	require.Equal(opts.withDescription, role.Description)
 	require.Equal(opts.withName, role.Name)
+
+	grantScopeIds := opts.withGrantScopeIds
+	if len(grantScopeIds) == 0 {
+		grantScopeIds = []string{globals.GrantScopeThis}
+	}
+	for _, gsi := range grantScopeIds {
+		TestRoleGrantScope(t, conn, role.PublicId, normalizeGrantScope(scopeId, gsi))
+	}
 	return role
 }
 
+func TestRoleGrantScope(t testing.TB, conn *db.DB, roleId, grantScopeId string, opt ...Option) *RoleGrantScope {
+	t.Helper()
+	require := require.New(t)
+	rw := db.New(conn)
+
+	gs, err := NewRoleGrantScope(context.Background(), roleId, grantScopeId, opt...)
+	require.NoError(err)
+	err = rw.Create(context.Background(), gs)
+	require.NoError(err)
+	return gs
+}
+
 func TestRoleGrant(t testing.TB, conn *db.DB, roleId, grant string, opt ...Option) *RoleGrant {
 	t.Helper()
 	require := require.New(t)
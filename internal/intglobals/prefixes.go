@@ -13,6 +13,18 @@ const (
 	// from the OIDC auth method.
 	OidcManagedGroupPrefix = "mgoidc"
 
+	// LdapManagedGroupPrefix defines the prefix for ManagedGroup public ids
+	// from the LDAP auth method.
+	LdapManagedGroupPrefix = "mgldap"
+
+	// PasswordManagedGroupPrefix defines the prefix for ManagedGroup public
+	// ids from the password auth method.
+	PasswordManagedGroupPrefix = "mgpw"
+
+	// JwtManagedGroupPrefix defines the prefix for ManagedGroup public ids
+	// from the JWT auth method.
+	JwtManagedGroupPrefix = "mgjwt"
+
 	// OldPasswordAccountPrefix is the previously-used account prefix
 	OldPasswordAccountPrefix = "apw"
 
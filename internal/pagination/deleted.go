@@ -0,0 +1,111 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package pagination
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/util"
+)
+
+const (
+	listDeletedIdsQuery = `
+select public_id, scope_id, parent_id
+  from deleted_resource
+ where table_name = ?
+   and scope_id in (?)
+   and delete_time > ?
+`
+	listDeletedIdsByParentQuery = `
+select public_id, scope_id, parent_id
+  from deleted_resource
+ where table_name = ?
+   and parent_id = ?
+   and delete_time > ?
+`
+)
+
+// DeletedResource is a resource deleted from a table with an
+// insert_deleted_resource trigger.
+type DeletedResource struct {
+	PublicId string
+	// ScopeId is the id of the scope the resource was in. It is empty for
+	// resources which are listed under a parent resource.
+	ScopeId string
+	// ParentId is the id of the resource the resource was listed under, or of
+	// the user the resource belonged to.
+	ParentId string
+}
+
+// ListDeletedIds returns the resources in one of scopeIds which were deleted
+// from tableName after since. Deletions are recorded by the
+// insert_deleted_resource trigger of the table.
+func ListDeletedIds(ctx context.Context, r db.Reader, tableName string, scopeIds []string, since time.Time) ([]*DeletedResource, error) {
+	const op = "pagination.ListDeletedIds"
+	switch {
+	case util.IsNil(r):
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing reader")
+	case tableName == "":
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing table name")
+	case len(scopeIds) == 0:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing scope ids")
+	}
+	deleted, err := listDeleted(ctx, r, listDeletedIdsQuery, []any{tableName, scopeIds, since})
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return deleted, nil
+}
+
+// ListDeletedIdsByParent returns the resources listed under parentId which
+// were deleted from tableName after since. Deletions are recorded by the
+// insert_deleted_resource trigger of the table.
+func ListDeletedIdsByParent(ctx context.Context, r db.Reader, tableName string, parentId string, since time.Time) ([]*DeletedResource, error) {
+	const op = "pagination.ListDeletedIdsByParent"
+	switch {
+	case util.IsNil(r):
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing reader")
+	case tableName == "":
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing table name")
+	case parentId == "":
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing parent id")
+	}
+	deleted, err := listDeleted(ctx, r, listDeletedIdsByParentQuery, []any{tableName, parentId, since})
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return deleted, nil
+}
+
+func listDeleted(ctx context.Context, r db.Reader, query string, args []any) ([]*DeletedResource, error) {
+	const op = "pagination.listDeleted"
+	rows, err := r.Query(ctx, query, args)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	defer rows.Close()
+	var deleted []*DeletedResource
+	for rows.Next() {
+		var id string
+		var scopeId, parentId *string
+		if err := rows.Scan(&id, &scopeId, &parentId); err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		d := &DeletedResource{PublicId: id}
+		if scopeId != nil {
+			d.ScopeId = *scopeId
+		}
+		if parentId != nil {
+			d.ParentId = *parentId
+		}
+		deleted = append(deleted, d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return deleted, nil
+}
@@ -0,0 +1,114 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package pagination_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/authtoken"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/host/static"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/pagination"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListDeletedIds(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	org, _ := iam.TestScopes(t, iamRepo)
+	otherOrg, _ := iam.TestScopes(t, iamRepo)
+	u := iam.TestUser(t, iamRepo, org.GetPublicId())
+	otherU := iam.TestUser(t, iamRepo, otherOrg.GetPublicId())
+
+	since := time.Now().Add(-time.Minute)
+	for _, id := range []string{u.GetPublicId(), otherU.GetPublicId()} {
+		n, err := iamRepo.DeleteUser(ctx, id)
+		require.NoError(t, err)
+		require.Equal(t, 1, n)
+	}
+
+	got, err := pagination.ListDeletedIds(ctx, rw, "iam_user", []string{org.GetPublicId()}, since)
+	require.NoError(t, err)
+	assert.Equal(t, []*pagination.DeletedResource{{PublicId: u.GetPublicId(), ScopeId: org.GetPublicId()}}, got)
+
+	got, err = pagination.ListDeletedIds(ctx, rw, "iam_user", []string{org.GetPublicId(), otherOrg.GetPublicId()}, since)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []*pagination.DeletedResource{
+		{PublicId: u.GetPublicId(), ScopeId: org.GetPublicId()},
+		{PublicId: otherU.GetPublicId(), ScopeId: otherOrg.GetPublicId()},
+	}, got)
+
+	got, err = pagination.ListDeletedIds(ctx, rw, "iam_user", []string{org.GetPublicId()}, time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	assert.Empty(t, got)
+
+	_, err = pagination.ListDeletedIds(ctx, nil, "iam_user", []string{org.GetPublicId()}, since)
+	assert.True(t, errors.Match(errors.T(errors.InvalidParameter), err))
+	_, err = pagination.ListDeletedIds(ctx, rw, "", []string{org.GetPublicId()}, since)
+	assert.True(t, errors.Match(errors.T(errors.InvalidParameter), err))
+	_, err = pagination.ListDeletedIds(ctx, rw, "iam_user", nil, since)
+	assert.True(t, errors.Match(errors.T(errors.InvalidParameter), err))
+}
+
+func TestListDeletedIds_AuthToken(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	org, _ := iam.TestScopes(t, iamRepo)
+	at := authtoken.TestAuthToken(t, conn, kms, org.GetPublicId())
+
+	// Deleting the account deletes its auth tokens, which are recorded with
+	// the scope and the user of the account.
+	since := time.Now().Add(-time.Minute)
+	_, err := rw.Exec(ctx, "delete from auth_account where public_id = ?", []any{at.GetAuthAccountId()})
+	require.NoError(t, err)
+
+	got, err := pagination.ListDeletedIds(ctx, rw, "auth_token", []string{org.GetPublicId()}, since)
+	require.NoError(t, err)
+	assert.Equal(t, []*pagination.DeletedResource{{PublicId: at.GetPublicId(), ScopeId: org.GetPublicId(), ParentId: at.GetIamUserId()}}, got)
+}
+
+func TestListDeletedIdsByParent(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	_, prj := iam.TestScopes(t, iamRepo)
+	repo, err := static.NewRepository(rw, rw, kms)
+	require.NoError(t, err)
+	catalogs := static.TestCatalogs(t, conn, prj.GetPublicId(), 2)
+	h := static.TestHosts(t, conn, catalogs[0].GetPublicId(), 1)[0]
+	otherH := static.TestHosts(t, conn, catalogs[1].GetPublicId(), 1)[0]
+
+	since := time.Now().Add(-time.Minute)
+	for _, id := range []string{h.GetPublicId(), otherH.GetPublicId()} {
+		n, err := repo.DeleteHost(ctx, prj.GetPublicId(), id)
+		require.NoError(t, err)
+		require.Equal(t, 1, n)
+	}
+
+	got, err := pagination.ListDeletedIdsByParent(ctx, rw, "host", catalogs[0].GetPublicId(), since)
+	require.NoError(t, err)
+	assert.Equal(t, []*pagination.DeletedResource{{PublicId: h.GetPublicId(), ParentId: catalogs[0].GetPublicId()}}, got)
+
+	_, err = pagination.ListDeletedIdsByParent(ctx, rw, "host", "", since)
+	assert.True(t, errors.Match(errors.T(errors.InvalidParameter), err))
+}
@@ -0,0 +1,108 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package pagination
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/types/resource"
+	"github.com/mr-tron/base58"
+)
+
+// listTokenVersion is the version of the encoding of list tokens.
+const listTokenVersion = 1
+
+// listToken is the state of a paginated listing which is sent to clients as an
+// opaque string. A listing is made of passes. The first pass lists all
+// resources ordered by create time. Every following pass is a refresh which
+// lists the resources updated after the previous pass started, ordered by
+// update time. A pass spans one or more pages.
+type listToken struct {
+	Version int `json:"v"`
+
+	// ResourceType is the type of the listed resources.
+	ResourceType string `json:"rt"`
+
+	// GrantsHash is the hash of the grants of the user the token was issued
+	// to. The token is rejected if the grants of the user changed.
+	GrantsHash []byte `json:"gh"`
+
+	// PassStartTime is the time the current pass started. It is not set when
+	// the token starts a new pass.
+	PassStartTime time.Time `json:"ps,omitempty"`
+
+	// UpdatedAfter is set if the current pass is a refresh. It is the time the
+	// previous pass started.
+	UpdatedAfter time.Time `json:"ua,omitempty"`
+
+	// LastItemId and LastItemTime identify the last item of the previous page
+	// of the current pass. They are not set when the token starts a new pass.
+	LastItemId   string    `json:"li,omitempty"`
+	LastItemTime time.Time `json:"lt,omitempty"`
+}
+
+// encode returns the opaque string representation of the token.
+func (t *listToken) encode(ctx context.Context) (string, error) {
+	const op = "pagination.(listToken).encode"
+	b, err := json.Marshal(t)
+	if err != nil {
+		return "", errors.Wrap(ctx, err, op)
+	}
+	return base58.FastBase58Encoding(b), nil
+}
+
+// decodeListToken decodes the list token s and validates it was issued for the
+// resource type and grants hash and has not expired.
+func decodeListToken(ctx context.Context, s string, resourceType resource.Type, grantsHash []byte, now time.Time) (*listToken, error) {
+	const op = "pagination.decodeListToken"
+	b, err := base58.FastBase58Decoding(s)
+	if err != nil {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "list token is not valid")
+	}
+	t := &listToken{}
+	if err := json.Unmarshal(b, t); err != nil {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "list token is not valid")
+	}
+	switch {
+	case t.Version != listTokenVersion:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "list token is not valid")
+	case t.ResourceType != resourceType.String():
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "list token was issued for a different resource type")
+	case !bytes.Equal(t.GrantsHash, grantsHash):
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "list token was issued for different grants")
+	case t.LastItemId != "" && t.PassStartTime.IsZero():
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "list token is not valid")
+	case now.Sub(t.referenceTime()) > ListTokenLifetime:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "list token has expired")
+	}
+	return t, nil
+}
+
+// refreshing reports whether the current pass is a refresh.
+func (t *listToken) refreshing() bool {
+	return !t.UpdatedAfter.IsZero()
+}
+
+// referenceTime returns the oldest time the token relies on. Deleted resources
+// are only reported for the lifetime of a list token after this time.
+func (t *listToken) referenceTime() time.Time {
+	if t.refreshing() {
+		return t.UpdatedAfter
+	}
+	return t.PassStartTime
+}
+
+// page returns the page which follows the token's last item.
+func (t *listToken) page() *db.Page {
+	return &db.Page{
+		UpdatedAfter: t.UpdatedAfter,
+		LastItemId:   t.LastItemId,
+		LastItemTime: t.LastItemTime,
+	}
+}
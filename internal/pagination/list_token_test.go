@@ -0,0 +1,151 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package pagination
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/types/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListToken(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	now := time.Now()
+	grantsHash := []byte("grants")
+	encode := func(t *testing.T, tok *listToken) string {
+		t.Helper()
+		s, err := tok.encode(ctx)
+		require.NoError(t, err)
+		return s
+	}
+	pageToken := &listToken{
+		Version:       listTokenVersion,
+		ResourceType:  resource.Target.String(),
+		GrantsHash:    grantsHash,
+		PassStartTime: now.Add(-time.Minute).UTC(),
+		LastItemId:    "ttcp_1234567890",
+		LastItemTime:  now.Add(-time.Hour).UTC(),
+	}
+	refreshToken := &listToken{
+		Version:      listTokenVersion,
+		ResourceType: resource.Target.String(),
+		GrantsHash:   grantsHash,
+		UpdatedAfter: now.Add(-time.Minute).UTC(),
+	}
+
+	tests := []struct {
+		name            string
+		token           string
+		want            *listToken
+		wantPage        *db.Page
+		wantErrContains string
+	}{
+		{
+			name:     "page",
+			token:    encode(t, pageToken),
+			want:     pageToken,
+			wantPage: &db.Page{LastItemId: "ttcp_1234567890", LastItemTime: pageToken.LastItemTime},
+		},
+		{
+			name:     "refresh",
+			token:    encode(t, refreshToken),
+			want:     refreshToken,
+			wantPage: &db.Page{UpdatedAfter: refreshToken.UpdatedAfter},
+		},
+		{
+			name:            "not-base58",
+			token:           "0OIl",
+			wantErrContains: "list token is not valid",
+		},
+		{
+			name:            "not-json",
+			token:           "3yZe7d",
+			wantErrContains: "list token is not valid",
+		},
+		{
+			name: "unknown-version",
+			token: encode(t, &listToken{
+				Version:      listTokenVersion + 1,
+				ResourceType: resource.Target.String(),
+				GrantsHash:   grantsHash,
+				UpdatedAfter: now,
+			}),
+			wantErrContains: "list token is not valid",
+		},
+		{
+			name: "last-item-without-pass-start",
+			token: encode(t, &listToken{
+				Version:      listTokenVersion,
+				ResourceType: resource.Target.String(),
+				GrantsHash:   grantsHash,
+				UpdatedAfter: now,
+				LastItemId:   "ttcp_1234567890",
+			}),
+			wantErrContains: "list token is not valid",
+		},
+		{
+			name: "expired-page",
+			token: encode(t, &listToken{
+				Version:       listTokenVersion,
+				ResourceType:  resource.Target.String(),
+				GrantsHash:    grantsHash,
+				PassStartTime: now.Add(-ListTokenLifetime - time.Minute),
+				LastItemId:    "ttcp_1234567890",
+			}),
+			wantErrContains: "list token has expired",
+		},
+		{
+			name: "expired-refresh",
+			token: encode(t, &listToken{
+				Version:       listTokenVersion,
+				ResourceType:  resource.Target.String(),
+				GrantsHash:    grantsHash,
+				PassStartTime: now,
+				UpdatedAfter:  now.Add(-ListTokenLifetime - time.Minute),
+				LastItemId:    "ttcp_1234567890",
+			}),
+			wantErrContains: "list token has expired",
+		},
+		{
+			name:            "other-resource-type",
+			token:           encode(t, &listToken{Version: listTokenVersion, ResourceType: resource.Session.String(), GrantsHash: grantsHash, UpdatedAfter: now}),
+			wantErrContains: "list token was issued for a different resource type",
+		},
+		{
+			name:            "other-grants",
+			token:           encode(t, &listToken{Version: listTokenVersion, ResourceType: resource.Target.String(), GrantsHash: []byte("other grants"), UpdatedAfter: now}),
+			wantErrContains: "list token was issued for different grants",
+		},
+		{
+			name:            "test-expired",
+			token:           TestExpiredListToken(t, encode(t, pageToken)),
+			wantErrContains: "list token has expired",
+		},
+		{
+			name:            "test-other-resource-type",
+			token:           TestListTokenForResourceType(t, encode(t, refreshToken), resource.Session),
+			wantErrContains: "list token was issued for a different resource type",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := decodeListToken(ctx, tt.token, resource.Target, grantsHash, now)
+			if tt.wantErrContains != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErrContains)
+				assert.Nil(t, got)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantPage, got.page())
+		})
+	}
+}
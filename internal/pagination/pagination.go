@@ -0,0 +1,305 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package pagination provides cursor based pagination of the resources
+// returned by list requests.
+//
+// A paginated listing starts with a request without a list token. The first
+// pass of the listing returns all resources ordered by create time, one page at
+// a time, using the list token returned with each page to request the next one.
+// Once a pass is complete, the returned list token requests a refresh: a new
+// pass which returns the resources updated since the previous pass started,
+// ordered by update time, and the ids of the resources deleted since then.
+//
+// Pages are read from the repositories using keyset pagination (see db.Page),
+// so the cost of a page does not depend on its position in the listing.
+// Resources the caller is not authorized to see, or which do not match the
+// request's filter, are skipped while filling a page.
+package pagination
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/db/timestamp"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/types/resource"
+)
+
+const (
+	// DefaultPageSize is the page size used when a list token is provided
+	// without a page size.
+	DefaultPageSize = 1000
+
+	// MaxPageSize is the largest page size. Larger page sizes are reduced to
+	// it.
+	MaxPageSize = 10000
+
+	// ListTokenLifetime is how long a list token can be used. A refresh token
+	// expires this long after the pass preceding the refresh started.
+	ListTokenLifetime = 30 * 24 * time.Hour
+
+	// updateBuffer is subtracted from the start time of a pass to account for
+	// transactions which were in progress when the pass started, and for
+	// clock differences between controllers and the database. Resources
+	// updated within the buffer may be returned twice.
+	updateBuffer = 30 * time.Second
+)
+
+const (
+	// CompleteResponseType is the response type of a page which completes a
+	// pass over the resources.
+	CompleteResponseType = "complete"
+
+	// DeltaResponseType is the response type of a page which is followed by
+	// more pages.
+	DeltaResponseType = "delta"
+
+	// SortByCreatedTime is the sort order of the first pass.
+	SortByCreatedTime = "created_time"
+
+	// SortByUpdatedTime is the sort order of a refresh.
+	SortByUpdatedTime = "updated_time"
+
+	// SortDirAscending is the sort direction of all passes.
+	SortDirAscending = "asc"
+)
+
+// Item is a resource which can be paginated.
+type Item interface {
+	GetPublicId() string
+	GetCreateTime() *timestamp.Timestamp
+	GetUpdateTime() *timestamp.Timestamp
+}
+
+// ListItemsFunc returns up to limit items of page, in the order of page. If
+// page is nil the request is not paginated: the items are returned in any
+// order and limit is zero, requesting the repository's default limit.
+type ListItemsFunc[T Item] func(ctx context.Context, page *db.Page, limit int) ([]T, error)
+
+// ConvertItemFunc converts an item to its representation in the response. It
+// returns false if the item must not be returned, for example because the
+// caller is not authorized to see it or it does not match the request's
+// filter.
+type ConvertItemFunc[T Item, P any] func(ctx context.Context, item T) (P, bool, error)
+
+// ListDeletedIdsFunc returns the ids of the resources deleted after since.
+type ListDeletedIdsFunc func(ctx context.Context, since time.Time) ([]string, error)
+
+// Request describes a list request.
+type Request struct {
+	// ResourceType is the type of the listed resources.
+	ResourceType resource.Type
+
+	// GrantsHash is the hash of the caller's grants. List tokens can only be
+	// used by callers with the same grants.
+	GrantsHash []byte
+
+	// PageSize is the page size requested by the caller.
+	PageSize uint32
+
+	// ListToken is the list token provided by the caller.
+	ListToken string
+}
+
+// Paginated reports whether the request asked for pagination. Requests without
+// a page size or a list token return all resources in a single response, as
+// they did before pagination was supported.
+func (r Request) Paginated() bool {
+	return r.PageSize > 0 || r.ListToken != ""
+}
+
+// Response is a page of a list response.
+type Response[P any] struct {
+	// Items are the items of the page.
+	Items []P
+
+	// Complete is true if the page completes the current pass.
+	Complete bool
+
+	// ListToken is the list token requesting the next page, or a refresh if
+	// the page completes the current pass. It is empty if the request was
+	// not paginated.
+	ListToken string
+
+	// SortBy is the sort order of the items.
+	SortBy string
+
+	// RemovedIds are the ids of the resources deleted since the previous pass
+	// started. They are only set on the first page of a refresh.
+	RemovedIds []string
+}
+
+// ResponseType returns the response type of the page.
+func (r *Response[P]) ResponseType() string {
+	if r.Complete {
+		return CompleteResponseType
+	}
+	return DeltaResponseType
+}
+
+// List returns the page of items requested by req. Items are read with
+// listItemsFn, converted with convertItemFn and, for the first page of a
+// refresh, the ids of deleted resources are read with listDeletedIdsFn.
+//
+// If req is not paginated all items returned by a single call to listItemsFn
+// are converted and returned.
+func List[T Item, P any](ctx context.Context, req Request, listItemsFn ListItemsFunc[T], convertItemFn ConvertItemFunc[T, P], listDeletedIdsFn ListDeletedIdsFunc) (*Response[P], error) {
+	const op = "pagination.List"
+	switch {
+	case listItemsFn == nil:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing list items function")
+	case convertItemFn == nil:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing convert item function")
+	case listDeletedIdsFn == nil:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing list deleted ids function")
+	}
+
+	if !req.Paginated() {
+		items, err := listItemsFn(ctx, nil, 0)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		resp := &Response[P]{Complete: true}
+		for _, item := range items {
+			p, ok, err := convertItemFn(ctx, item)
+			if err != nil {
+				return nil, errors.Wrap(ctx, err, op)
+			}
+			if ok {
+				resp.Items = append(resp.Items, p)
+			}
+		}
+		return resp, nil
+	}
+
+	now := time.Now()
+	tok := &listToken{
+		Version:      listTokenVersion,
+		ResourceType: req.ResourceType.String(),
+		GrantsHash:   req.GrantsHash,
+	}
+	if req.ListToken != "" {
+		var err error
+		if tok, err = decodeListToken(ctx, req.ListToken, req.ResourceType, req.GrantsHash, now); err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+	}
+	pageSize := int(req.PageSize)
+	switch {
+	case pageSize == 0:
+		pageSize = DefaultPageSize
+	case pageSize > MaxPageSize:
+		pageSize = MaxPageSize
+	}
+
+	resp := &Response[P]{SortBy: SortByCreatedTime}
+	if tok.refreshing() {
+		resp.SortBy = SortByUpdatedTime
+	}
+	if tok.LastItemId == "" {
+		// This is the first page of a pass.
+		tok.PassStartTime = now.Add(-updateBuffer)
+		if tok.refreshing() {
+			var err error
+			if resp.RemovedIds, err = listDeletedIdsFn(ctx, tok.UpdatedAfter); err != nil {
+				return nil, errors.Wrap(ctx, err, op)
+			}
+		}
+	}
+
+	// Items are read pageSize+1 at a time so a full page is followed by at
+	// least one more item unless the pass is complete. Items which are not
+	// returned still move the page forward.
+	page := tok.page()
+	limit := pageSize + 1
+	var lastItem T
+	var haveLastItem bool
+fill:
+	for {
+		items, err := listItemsFn(ctx, page, limit)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		for _, item := range items {
+			if len(resp.Items) == pageSize {
+				break fill
+			}
+			p, ok, err := convertItemFn(ctx, item)
+			if err != nil {
+				return nil, errors.Wrap(ctx, err, op)
+			}
+			if ok {
+				resp.Items = append(resp.Items, p)
+			}
+			lastItem, haveLastItem = item, true
+		}
+		if len(items) < limit {
+			resp.Complete = true
+			break
+		}
+		page = nextPage(page, items[len(items)-1])
+	}
+
+	next := *tok
+	switch {
+	case resp.Complete:
+		// The next pass refreshes the items updated since this pass started.
+		next = listToken{
+			Version:      tok.Version,
+			ResourceType: tok.ResourceType,
+			GrantsHash:   tok.GrantsHash,
+			UpdatedAfter: tok.PassStartTime,
+		}
+	case haveLastItem:
+		next.LastItemId = lastItem.GetPublicId()
+		next.LastItemTime = itemTime(lastItem, tok.refreshing())
+	}
+	var err error
+	if resp.ListToken, err = next.encode(ctx); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return resp, nil
+}
+
+// MergeItems combines the items read from several repositories into a single
+// page: it sorts items in the order of page and keeps the first limit items.
+// Each repository must have returned up to limit items of page. If page is nil
+// the request is not paginated and items are returned unchanged.
+func MergeItems[T Item](items []T, page *db.Page, limit int) []T {
+	if page == nil {
+		return items
+	}
+	refreshing := !page.UpdatedAfter.IsZero()
+	sort.Slice(items, func(i, j int) bool {
+		ti, tj := itemTime(items[i], refreshing), itemTime(items[j], refreshing)
+		if !ti.Equal(tj) {
+			return ti.Before(tj)
+		}
+		return items[i].GetPublicId() < items[j].GetPublicId()
+	})
+	if limit > 0 && len(items) > limit {
+		items = items[:limit]
+	}
+	return items
+}
+
+// nextPage returns the page which follows item in the order of page.
+func nextPage(page *db.Page, item Item) *db.Page {
+	return &db.Page{
+		UpdatedAfter: page.UpdatedAfter,
+		LastItemId:   item.GetPublicId(),
+		LastItemTime: itemTime(item, !page.UpdatedAfter.IsZero()),
+	}
+}
+
+// itemTime returns the time of item used to order the items of a pass: its
+// update time when refreshing and its create time otherwise.
+func itemTime(item Item, refreshing bool) time.Time {
+	if refreshing {
+		return item.GetUpdateTime().AsTime()
+	}
+	return item.GetCreateTime().AsTime()
+}
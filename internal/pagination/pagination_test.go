@@ -0,0 +1,335 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package pagination
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/db/timestamp"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/types/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testItem struct {
+	id         string
+	createTime time.Time
+	updateTime time.Time
+}
+
+func (i *testItem) GetPublicId() string { return i.id }
+
+func (i *testItem) GetCreateTime() *timestamp.Timestamp { return timestamp.New(i.createTime) }
+
+func (i *testItem) GetUpdateTime() *timestamp.Timestamp { return timestamp.New(i.updateTime) }
+
+// testRepo is an in memory repository implementing keyset pagination like
+// db.Page does in the database.
+type testRepo struct {
+	items   []*testItem
+	deleted map[string]time.Time
+	calls   int
+}
+
+func (r *testRepo) list(_ context.Context, page *db.Page, limit int) ([]*testItem, error) {
+	r.calls++
+	items := append([]*testItem(nil), r.items...)
+	if page == nil {
+		return items, nil
+	}
+	key := func(i *testItem) time.Time {
+		if !page.UpdatedAfter.IsZero() {
+			return i.updateTime
+		}
+		return i.createTime
+	}
+	sort.Slice(items, func(a, b int) bool {
+		if !key(items[a]).Equal(key(items[b])) {
+			return key(items[a]).Before(key(items[b]))
+		}
+		return items[a].id < items[b].id
+	})
+	var ret []*testItem
+	for _, i := range items {
+		switch {
+		case !page.UpdatedAfter.IsZero() && !i.updateTime.After(page.UpdatedAfter):
+			continue
+		case page.LastItemId != "" && key(i).Before(page.LastItemTime):
+			continue
+		case page.LastItemId != "" && key(i).Equal(page.LastItemTime) && i.id <= page.LastItemId:
+			continue
+		}
+		ret = append(ret, i)
+		if len(ret) == limit {
+			break
+		}
+	}
+	return ret, nil
+}
+
+func (r *testRepo) listDeletedIds(_ context.Context, since time.Time) ([]string, error) {
+	var ids []string
+	for id, t := range r.deleted {
+		if t.After(since) {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	return ids, nil
+}
+
+func (r *testRepo) add(id string, t time.Time) *testItem {
+	i := &testItem{id: id, createTime: t, updateTime: t}
+	r.items = append(r.items, i)
+	return i
+}
+
+func (r *testRepo) delete(id string, t time.Time) {
+	for idx, i := range r.items {
+		if i.id == id {
+			r.items = append(r.items[:idx], r.items[idx+1:]...)
+			r.deleted[id] = t
+			return
+		}
+	}
+}
+
+// convert returns the ids of the items, skipping the items with ids starting
+// with "hidden".
+func convert(_ context.Context, i *testItem) (string, bool, error) {
+	return i.id, !strings.HasPrefix(i.id, "hidden"), nil
+}
+
+func newTestRepo(t *testing.T, n int, start time.Time) *testRepo {
+	t.Helper()
+	r := &testRepo{deleted: map[string]time.Time{}}
+	for i := 0; i < n; i++ {
+		r.add(fmt.Sprintf("item_%02d", i), start.Add(time.Duration(i)*time.Second))
+	}
+	return r
+}
+
+func TestList_NotPaginated(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	repo := newTestRepo(t, 5, time.Now().Add(-time.Hour))
+	repo.add("hidden_1", time.Now().Add(-time.Hour))
+	resp, err := List(ctx, Request{ResourceType: resource.Target}, repo.list, convert, repo.listDeletedIds)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"item_00", "item_01", "item_02", "item_03", "item_04"}, resp.Items)
+	assert.True(t, resp.Complete)
+	assert.Empty(t, resp.ListToken)
+	assert.Equal(t, 1, repo.calls)
+}
+
+func TestList_Pages(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	grantsHash := []byte("grants")
+	repo := newTestRepo(t, 7, time.Now().Add(-time.Hour))
+	repo.add("hidden_1", time.Now().Add(-time.Hour).Add(2500*time.Millisecond))
+
+	req := Request{ResourceType: resource.Target, GrantsHash: grantsHash, PageSize: 3}
+	var got []string
+	var pages int
+	for {
+		resp, err := List(ctx, req, repo.list, convert, repo.listDeletedIds)
+		require.NoError(t, err)
+		pages++
+		assert.LessOrEqual(t, len(resp.Items), 3)
+		assert.Equal(t, SortByCreatedTime, resp.SortBy)
+		assert.Empty(t, resp.RemovedIds)
+		require.NotEmpty(t, resp.ListToken)
+		got = append(got, resp.Items...)
+		req.ListToken = resp.ListToken
+		if resp.Complete {
+			assert.Equal(t, CompleteResponseType, resp.ResponseType())
+			break
+		}
+		assert.Equal(t, DeltaResponseType, resp.ResponseType())
+		require.Less(t, pages, 10)
+	}
+	assert.Equal(t, []string{"item_00", "item_01", "item_02", "item_03", "item_04", "item_05", "item_06"}, got)
+	assert.Equal(t, 3, pages)
+
+	// A refresh returns nothing until items change.
+	resp, err := List(ctx, req, repo.list, convert, repo.listDeletedIds)
+	require.NoError(t, err)
+	assert.Empty(t, resp.Items)
+	assert.Empty(t, resp.RemovedIds)
+	assert.True(t, resp.Complete)
+	assert.Equal(t, SortByUpdatedTime, resp.SortBy)
+	req.ListToken = resp.ListToken
+
+	// Refreshes return the updated and created items and the ids of the
+	// deleted items.
+	now := time.Now()
+	repo.items[1].updateTime = now
+	repo.add("item_07", now)
+	repo.add("item_08", now.Add(time.Millisecond))
+	repo.delete("item_03", now)
+	req.PageSize = 2
+	resp, err = List(ctx, req, repo.list, convert, repo.listDeletedIds)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"item_01", "item_07"}, resp.Items)
+	assert.Equal(t, []string{"item_03"}, resp.RemovedIds)
+	assert.False(t, resp.Complete)
+	req.ListToken = resp.ListToken
+
+	resp, err = List(ctx, req, repo.list, convert, repo.listDeletedIds)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"item_08"}, resp.Items)
+	assert.Empty(t, resp.RemovedIds)
+	assert.True(t, resp.Complete)
+}
+
+func TestList_PageSize(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	repo := newTestRepo(t, 3, time.Now().Add(-time.Hour))
+
+	var limits []int
+	listFn := func(ctx context.Context, page *db.Page, limit int) ([]*testItem, error) {
+		limits = append(limits, limit)
+		return repo.list(ctx, page, limit)
+	}
+
+	_, err := List(ctx, Request{ResourceType: resource.Target, PageSize: MaxPageSize + 1}, listFn, convert, repo.listDeletedIds)
+	require.NoError(t, err)
+	resp, err := List(ctx, Request{ResourceType: resource.Target, PageSize: 1}, listFn, convert, repo.listDeletedIds)
+	require.NoError(t, err)
+	_, err = List(ctx, Request{ResourceType: resource.Target, ListToken: resp.ListToken}, listFn, convert, repo.listDeletedIds)
+	require.NoError(t, err)
+	assert.Equal(t, []int{MaxPageSize + 1, 2, DefaultPageSize + 1}, limits)
+}
+
+func TestMergeItems(t *testing.T) {
+	t.Parallel()
+	now := time.Now()
+	a := &testItem{id: "a", createTime: now.Add(2 * time.Second), updateTime: now}
+	b := &testItem{id: "b", createTime: now, updateTime: now.Add(time.Second)}
+	c := &testItem{id: "c", createTime: now, updateTime: now.Add(2 * time.Second)}
+
+	assert.Equal(t, []*testItem{a, c, b}, MergeItems([]*testItem{a, c, b}, nil, 2))
+	assert.Equal(t, []*testItem{b, c}, MergeItems([]*testItem{a, c, b}, &db.Page{}, 2))
+	assert.Equal(t, []*testItem{a, b, c}, MergeItems([]*testItem{c, b, a}, &db.Page{UpdatedAfter: now.Add(-time.Hour)}, 0))
+}
+
+func TestList_Errors(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	repo := newTestRepo(t, 3, time.Now().Add(-time.Hour))
+	resp, err := List(ctx, Request{ResourceType: resource.Target, GrantsHash: []byte("grants"), PageSize: 1}, repo.list, convert, repo.listDeletedIds)
+	require.NoError(t, err)
+	tok := resp.ListToken
+
+	tests := []struct {
+		name             string
+		req              Request
+		listItemsFn      ListItemsFunc[*testItem]
+		convertItemFn    ConvertItemFunc[*testItem, string]
+		listDeletedIdsFn ListDeletedIdsFunc
+		wantErrCode      errors.Code
+		wantErrContains  string
+	}{
+		{
+			name:             "missing-list-items-fn",
+			req:              Request{ResourceType: resource.Target},
+			convertItemFn:    convert,
+			listDeletedIdsFn: repo.listDeletedIds,
+			wantErrCode:      errors.InvalidParameter,
+			wantErrContains:  "missing list items function",
+		},
+		{
+			name:             "missing-convert-item-fn",
+			req:              Request{ResourceType: resource.Target},
+			listItemsFn:      repo.list,
+			listDeletedIdsFn: repo.listDeletedIds,
+			wantErrCode:      errors.InvalidParameter,
+			wantErrContains:  "missing convert item function",
+		},
+		{
+			name:            "missing-list-deleted-ids-fn",
+			req:             Request{ResourceType: resource.Target},
+			listItemsFn:     repo.list,
+			convertItemFn:   convert,
+			wantErrCode:     errors.InvalidParameter,
+			wantErrContains: "missing list deleted ids function",
+		},
+		{
+			name:             "invalid-token",
+			req:              Request{ResourceType: resource.Target, GrantsHash: []byte("grants"), ListToken: "not a token"},
+			listItemsFn:      repo.list,
+			convertItemFn:    convert,
+			listDeletedIdsFn: repo.listDeletedIds,
+			wantErrCode:      errors.InvalidParameter,
+			wantErrContains:  "list token is not valid",
+		},
+		{
+			name:             "other-resource-type",
+			req:              Request{ResourceType: resource.Session, GrantsHash: []byte("grants"), ListToken: tok},
+			listItemsFn:      repo.list,
+			convertItemFn:    convert,
+			listDeletedIdsFn: repo.listDeletedIds,
+			wantErrCode:      errors.InvalidParameter,
+			wantErrContains:  "different resource type",
+		},
+		{
+			name:             "expired-token",
+			req:              Request{ResourceType: resource.Target, GrantsHash: []byte("grants"), ListToken: TestExpiredListToken(t, tok)},
+			listItemsFn:      repo.list,
+			convertItemFn:    convert,
+			listDeletedIdsFn: repo.listDeletedIds,
+			wantErrCode:      errors.InvalidParameter,
+			wantErrContains:  "list token has expired",
+		},
+		{
+			name:             "other-grants",
+			req:              Request{ResourceType: resource.Target, GrantsHash: []byte("other grants"), ListToken: tok},
+			listItemsFn:      repo.list,
+			convertItemFn:    convert,
+			listDeletedIdsFn: repo.listDeletedIds,
+			wantErrCode:      errors.InvalidParameter,
+			wantErrContains:  "different grants",
+		},
+		{
+			name: "list-error",
+			req:  Request{ResourceType: resource.Target, PageSize: 1},
+			listItemsFn: func(ctx context.Context, _ *db.Page, _ int) ([]*testItem, error) {
+				return nil, errors.New(ctx, errors.Internal, "test", "list failure")
+			},
+			convertItemFn:    convert,
+			listDeletedIdsFn: repo.listDeletedIds,
+			wantErrCode:      errors.Internal,
+			wantErrContains:  "list failure",
+		},
+		{
+			name:        "convert-error",
+			req:         Request{ResourceType: resource.Target, PageSize: 1},
+			listItemsFn: repo.list,
+			convertItemFn: func(ctx context.Context, _ *testItem) (string, bool, error) {
+				return "", false, errors.New(ctx, errors.Internal, "test", "convert failure")
+			},
+			listDeletedIdsFn: repo.listDeletedIds,
+			wantErrCode:      errors.Internal,
+			wantErrContains:  "convert failure",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := List(ctx, tt.req, tt.listItemsFn, tt.convertItemFn, tt.listDeletedIdsFn)
+			require.Error(t, err)
+			assert.Nil(t, got)
+			assert.True(t, errors.Match(errors.T(tt.wantErrCode), err))
+			assert.Contains(t, err.Error(), tt.wantErrContains)
+		})
+	}
+}
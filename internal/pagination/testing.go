@@ -0,0 +1,129 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package pagination
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/types/resource"
+	"github.com/mr-tron/base58"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestListResponse is implemented by the responses of the paginated list
+// methods of the handlers.
+type TestListResponse interface {
+	GetResponseType() string
+	GetSortBy() string
+	GetSortDir() string
+	GetListToken() string
+	GetRemovedIds() []string
+}
+
+// TestListFunc lists at most pageSize items with ctx, continuing the listing
+// of listToken if it is set. It returns the response along with the ids of
+// its items.
+type TestListFunc func(ctx context.Context, listToken string, pageSize uint32) (TestListResponse, []string, error)
+
+// TestList tests a paginated listing. want holds the ids of the five items
+// listed with ctx, oldest first, and deleteFn deletes one of them. The list
+// tokens issued to ctx must be rejected when used with otherGrantsCtx or for
+// a listing of otherResource.
+func TestList(t *testing.T, ctx, otherGrantsCtx context.Context, otherResource resource.Type, want []string, list TestListFunc, deleteFn func(t *testing.T, id string)) {
+	t.Helper()
+	require.Len(t, want, 5)
+	listPage := func(t *testing.T, listToken string, pageSize uint32) (TestListResponse, []string) {
+		t.Helper()
+		got, ids, err := list(ctx, listToken, pageSize)
+		require.NoError(t, err)
+		return got, ids
+	}
+
+	// The first pass returns all items, two at a time
+	got, ids := listPage(t, "", 2)
+	assert.Equal(t, want[0:2], ids)
+	assert.Equal(t, DeltaResponseType, got.GetResponseType())
+	assert.Equal(t, SortByCreatedTime, got.GetSortBy())
+	assert.Equal(t, SortDirAscending, got.GetSortDir())
+	require.NotEmpty(t, got.GetListToken())
+
+	got, ids = listPage(t, got.GetListToken(), 2)
+	assert.Equal(t, want[2:4], ids)
+	assert.Equal(t, DeltaResponseType, got.GetResponseType())
+
+	got, ids = listPage(t, got.GetListToken(), 2)
+	assert.Equal(t, want[4:], ids)
+	assert.Equal(t, CompleteResponseType, got.GetResponseType())
+
+	// A refresh returns the ids of the items deleted since the first pass
+	// started
+	deleteFn(t, want[0])
+	got, ids = listPage(t, got.GetListToken(), 0)
+	assert.Equal(t, []string{want[0]}, got.GetRemovedIds())
+	assert.NotContains(t, ids, want[0])
+	assert.Equal(t, SortByUpdatedTime, got.GetSortBy())
+	assert.Equal(t, CompleteResponseType, got.GetResponseType())
+	require.NotEmpty(t, got.GetListToken())
+
+	// Malformed, expired and foreign list tokens are rejected
+	tok := got.GetListToken()
+	for name, tc := range map[string]struct {
+		ctx   context.Context
+		token string
+	}{
+		"malformed":      {ctx, "not-a-list-token"},
+		"expired":        {ctx, TestExpiredListToken(t, tok)},
+		"other-resource": {ctx, TestListTokenForResourceType(t, tok, otherResource)},
+		"other-grants":   {otherGrantsCtx, tok},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, _, err := list(tc.ctx, tc.token, 0)
+			assert.Truef(t, errors.Match(errors.T(errors.InvalidParameter), err), "got: %v", err)
+		})
+	}
+}
+
+// TestExpiredListToken returns token with all of its times moved back by
+// ListTokenLifetime, so it is rejected as expired.
+func TestExpiredListToken(t testing.TB, token string) string {
+	t.Helper()
+	return testModifyListToken(t, token, func(tok *listToken) {
+		expire := func(tm time.Time) time.Time {
+			if tm.IsZero() {
+				return tm
+			}
+			return tm.Add(-ListTokenLifetime - time.Minute)
+		}
+		tok.PassStartTime = expire(tok.PassStartTime)
+		tok.UpdatedAfter = expire(tok.UpdatedAfter)
+		tok.LastItemTime = expire(tok.LastItemTime)
+	})
+}
+
+// TestListTokenForResourceType returns token as if it was issued for a
+// listing of resourceType.
+func TestListTokenForResourceType(t testing.TB, token string, resourceType resource.Type) string {
+	t.Helper()
+	return testModifyListToken(t, token, func(tok *listToken) {
+		tok.ResourceType = resourceType.String()
+	})
+}
+
+func testModifyListToken(t testing.TB, s string, modify func(*listToken)) string {
+	t.Helper()
+	require := require.New(t)
+	b, err := base58.FastBase58Decoding(s)
+	require.NoError(err)
+	tok := &listToken{}
+	require.NoError(json.Unmarshal(b, tok))
+	modify(tok)
+	s, err = tok.encode(context.Background())
+	require.NoError(err)
+	return s
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/hashicorp/boundary/internal/types/action"
 	"github.com/hashicorp/boundary/internal/types/resource"
 	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/scopes"
+	"github.com/hashicorp/go-secure-stdlib/strutil"
 )
 
 // ACL provides an entry point into the permissions engine for determining if an
@@ -40,6 +41,9 @@ type Permission struct {
 	ResourceIds []string // Any specific resource ids that have been referred in the grant's `id` field, if applicable.
 	OnlySelf    bool     // The grant only allows actions against the user's own resources.
 	All         bool     // We got a wildcard in the grant string's `id` field.
+
+	DeniedResourceIds []string // Any specific resource ids excluded by a deny grant, only set when All is true.
+	ResourceFiltered  bool     // Some grants only apply to resources matching a filter, so each resource must be checked with Allowed.
 }
 
 // UserPermissions is a set of Permissions for a User.
@@ -63,6 +67,10 @@ type Resource struct {
 	// Pin if defined would constrain the resource within the collection of the
 	// pin id.
 	Pin string `json:"pin,omitempty"`
+
+	// Data contains the attributes of the resource that the resource filters
+	// of grants are evaluated against, e.g. its name.
+	Data map[string]any `json:"-"`
 }
 
 // NewACL creates an ACL from the grants provided.
@@ -79,6 +87,10 @@ func NewACL(grants ...Grant) ACL {
 }
 
 // Allowed determines if the grants for an ACL allow an action for a resource.
+// Deny grants take precedence: if any deny grant matches the resource and
+// action, the action is not authorized and no output fields are returned.
+// Grants with conditions only apply if their conditions hold for the request
+// attributes given with WithRequestAttributes.
 func (a ACL) Allowed(r Resource, aType action.Type, userId string, opt ...Option) (results ACLResults) {
 	opts := getOpts(opt...)
 
@@ -91,8 +103,19 @@ func (a ACL) Allowed(r Resource, aType action.Type, userId string, opt ...Option
 	if len(split) == 2 {
 		parentAction = action.Map[split[0]]
 	}
-	// Now, go through and check the cases indicated above
+
+	// Check for a deny grant first
 	for _, grant := range grants {
+		if grant.deny && grant.applies(r, aType, parentAction, userId, opts) {
+			return
+		}
+	}
+
+	// Now, go through and check the cases indicated in matchesResource
+	for _, grant := range grants {
+		if grant.deny {
+			continue
+		}
 		var outputFieldsOnly bool
 		switch {
 		case len(grant.actions) == 0:
@@ -104,14 +127,7 @@ func (a ACL) Allowed(r Resource, aType action.Type, userId string, opt ...Option
 			} else {
 				continue
 			}
-		case grant.actions[aType]:
-			// We have this action
-		case grant.actions[parentAction]:
-			// We don't have this action, but it's a subaction and we have the
-			// parent action. As an example, if we are looking for "read:self"
-			// and have "read", this is sufficient.
-		case grant.actions[action.All]:
-			// All actions are allowed
+		case grant.matchesAction(aType, parentAction):
 		default:
 			// No actions in the grant match what we're looking for, so continue
 			// with the next grant
@@ -124,112 +140,206 @@ func (a ACL) Allowed(r Resource, aType action.Type, userId string, opt ...Option
 		// If the action was not found above but we did find output fields in
 		// patterns that match, we do not authorize the request, but we do build
 		// up the output fields patterns.
-		//
-		// Note that when using IsActionOrParent it is merely to test whether it
-		// is an allowed format since some formats operate ony on collections
-		// (or don't operate at all on collections) and we want to ensure that
-		// it is/isn't a create or list command or subcommand to know whether
-		// that form is valid. The actual checking of whether the given action
-		// is granted to the user already happened above.
-		var found bool
-		switch {
-		// Case 1: We only allow specific actions on specific types for the
-		// anonymous user. ID being supplied or not doesn't matter in this case,
-		// it must be an explicit type and action(s); adding this as an explicit
-		// case here prevents duplicating logic in two of the other more
-		// general-purpose cases below (3 and 4). See notes there about ID being
-		// present or not.
-		case !opts.withSkipAnonymousUserRestrictions &&
-			(userId == globals.AnonymousUserId || userId == ""):
-			switch {
-			// Allow discovery of scopes, so that auth methods within can be
-			// discovered
-			case grant.typ == r.Type &&
-				grant.typ == resource.Scope &&
-				(aType == action.List || aType == action.NoOp):
-				found = true
-
-			// Allow discovery of and authenticating to auth methods
-			case grant.typ == r.Type &&
-				grant.typ == resource.AuthMethod &&
-				(aType == action.List || aType == action.NoOp || aType == action.Authenticate):
-				found = true
+		if grant.matchesResource(r, aType, userId, opts.withSkipAnonymousUserRestrictions) &&
+			grant.conditionsHold(r, aType, userId, opts.withRequestAttributes) &&
+			grant.resourceFilterMatches(r) {
+			if !outputFieldsOnly {
+				results.Authorized = true
+			}
+			fields, _ := grant.OutputFields.Fields()
+			results.OutputFields = results.OutputFields.AddFields(fields)
+			if results.OutputFields.Has("*") && results.Authorized {
+				return
 			}
+		}
+	}
+	return
+}
 
-		// Case 2:
-		// id=<resource.id>;actions=<action> where ID cannot be a wildcard; or
-		// id=<resource.id>;output_fields=<fields> where fields cannot be a
-		// wildcard.
-		case grant.id == r.Id &&
-			grant.id != "" &&
-			grant.id != "*" &&
-			grant.typ == resource.Unknown &&
-			!action.List.IsActionOrParent(aType) &&
-			!action.Create.IsActionOrParent(aType):
+// Applies reports whether the grant applies to the given action on the
+// resource: the grant is for the resource's scope, its actions include the
+// action, it matches the resource, its conditions hold and the resource
+// matches its resource filter. These are the grants which Allowed authorizes
+// a request with, or denies it with if they are deny grants.
+func (g Grant) Applies(r Resource, aType action.Type, userId string, opt ...Option) bool {
+	if g.scope.Id != r.ScopeId {
+		return false
+	}
+	var parentAction action.Type
+	if split := strings.Split(aType.String(), ":"); len(split) == 2 {
+		parentAction = action.Map[split[0]]
+	}
+	return g.applies(r, aType, parentAction, userId, getOpts(opt...))
+}
 
-			found = true
+func (g Grant) applies(r Resource, aType, parentAction action.Type, userId string, opts options) bool {
+	// The anonymous user restrictions only ever narrow what is allowed, so
+	// they are skipped for deny grants
+	skipAnonymousUserRestrictions := g.deny || opts.withSkipAnonymousUserRestrictions
+	return g.matchesAction(aType, parentAction) &&
+		g.matchesResource(r, aType, userId, skipAnonymousUserRestrictions) &&
+		g.conditionsHold(r, aType, userId, opts.withRequestAttributes) &&
+		g.resourceFilterMatches(r)
+}
 
-		// Case 3: type=<resource.type>;actions=<action> when action is list or
-		// create. Must be a top level collection, otherwise must be one of the
-		// two formats specified in cases 4 or 5. Or,
-		// type=resource.type;output_fields=<fields> and no action. This is more
-		// of a semantic difference compared to 4 more than a security
-		// difference; this type is for clarity as it ties more closely to the
-		// concept of create and list as actions on a collection, operating on a
-		// collection directly. The format in case 4 will still work for
-		// create/list on collections but that's more of a shortcut to allow
-		// things like id=*;type=*;actions=* for admin flows so that you don't
-		// need to separate out explicit collection actions into separate typed
-		// grants for each collection within a role. This does mean there are
-		// "two ways of doing things" but it's a reasonable UX tradeoff given
-		// that "all IDs" can reasonably be construed to include "and the one
-		// I'm making" and "all of them for listing".
-		case grant.id == "" &&
-			r.Id == "" &&
-			grant.typ == r.Type &&
-			grant.typ != resource.Unknown &&
-			topLevelType(r.Type) &&
-			(action.List.IsActionOrParent(aType) ||
-				action.Create.IsActionOrParent(aType)):
+// matchesAction reports whether the grant's actions include the given action.
+func (g Grant) matchesAction(aType, parentAction action.Type) bool {
+	switch {
+	case g.actions[aType]:
+		// We have this action
+	case g.actions[parentAction]:
+		// We don't have this action, but it's a subaction and we have the
+		// parent action. As an example, if we are looking for "read:self"
+		// and have "read", this is sufficient.
+	case g.actions[action.All]:
+		// All actions are allowed
+	default:
+		return false
+	}
+	return true
+}
 
-			found = true
+// conditionsHold reports whether the grant's conditions, if any, hold for the
+// request. If they can't be evaluated, e.g. because there are no request
+// attributes, a grant which allows actions is treated as not applying and one
+// which denies actions as applying.
+func (g Grant) conditionsHold(r Resource, aType action.Type, userId string, attrs *RequestAttributes) bool {
+	if g.conditions == nil {
+		return true
+	}
+	hold, err := g.conditions.evaluate(r, aType, userId, attrs)
+	if err != nil {
+		return g.deny
+	}
+	return hold
+}
 
-		// Case 4:
-		// id=*;type=<resource.type>;actions=<action> where type cannot be
-		// unknown but can be a wildcard to allow any resource at all; or
-		// id=*;type=<resource.type>;output_fields=<fields> with no action.
-		case grant.id == "*" &&
-			grant.typ != resource.Unknown &&
-			(grant.typ == r.Type ||
-				grant.typ == resource.All):
+// resourceFilterMatches reports whether the resource matches the grant's
+// resource filter, if any. The filter is only evaluated for specific
+// resources; for collections, a grant which allows actions applies and one
+// which denies actions doesn't. If the filter can't be evaluated, e.g. because
+// there is no data for the resource or a template in the filter has no value,
+// a grant which allows actions doesn't apply and one which denies actions
+// does.
+func (g Grant) resourceFilterMatches(r Resource) bool {
+	switch {
+	case g.resourceFilter == "":
+		return true
+	case r.Id == "":
+		return !g.deny
+	case g.resourceFilterEval == nil, r.Data == nil:
+		return g.deny
+	}
+	data := make(map[string]any, len(r.Data)+2)
+	for k, v := range r.Data {
+		data[k] = v
+	}
+	data["id"] = r.Id
+	data["scope_id"] = r.ScopeId
+	match, err := g.resourceFilterEval.Evaluate(map[string]any{"resource": data})
+	if err != nil {
+		return g.deny
+	}
+	return match
+}
 
+// matchesResource reports whether the grant applies to the given resource.
+//
+// Note that when using IsActionOrParent it is merely to test whether it is an
+// allowed format since some formats operate ony on collections (or don't
+// operate at all on collections) and we want to ensure that it is/isn't a
+// create or list command or subcommand to know whether that form is valid. The
+// actual checking of whether the given action is granted to the user happens
+// in matchesAction.
+func (g Grant) matchesResource(r Resource, aType action.Type, userId string, skipAnonymousUserRestrictions bool) bool {
+	var found bool
+	switch {
+	// Case 1: We only allow specific actions on specific types for the
+	// anonymous user. ID being supplied or not doesn't matter in this case,
+	// it must be an explicit type and action(s); adding this as an explicit
+	// case here prevents duplicating logic in two of the other more
+	// general-purpose cases below (3 and 4). See notes there about ID being
+	// present or not.
+	case !skipAnonymousUserRestrictions &&
+		(userId == globals.AnonymousUserId || userId == ""):
+		switch {
+		// Allow discovery of scopes, so that auth methods within can be
+		// discovered
+		case g.typ == r.Type &&
+			g.typ == resource.Scope &&
+			(aType == action.List || aType == action.NoOp):
 			found = true
 
-		// Case 5:
-		// id=<pin>;type=<resource.type>;actions=<action> where type can be a
-		// wildcard and this this is operating on a non-top-level type. Same for
-		// output fields only.
-		case grant.id != "" &&
-			grant.id == r.Pin &&
-			grant.typ != resource.Unknown &&
-			(grant.typ == r.Type || grant.typ == resource.All) &&
-			!topLevelType(r.Type):
-
+		// Allow discovery of and authenticating to auth methods
+		case g.typ == r.Type &&
+			g.typ == resource.AuthMethod &&
+			(aType == action.List || aType == action.NoOp || aType == action.Authenticate):
 			found = true
 		}
 
-		if found {
-			if !outputFieldsOnly {
-				results.Authorized = true
-			}
-			fields, _ := grant.OutputFields.Fields()
-			results.OutputFields = results.OutputFields.AddFields(fields)
-			if results.OutputFields.Has("*") && results.Authorized {
-				return
-			}
-		}
+	// Case 2:
+	// id=<resource.id>;actions=<action> where ID cannot be a wildcard; or
+	// id=<resource.id>;output_fields=<fields> where fields cannot be a
+	// wildcard.
+	case g.id == r.Id &&
+		g.id != "" &&
+		g.id != "*" &&
+		g.typ == resource.Unknown &&
+		!action.List.IsActionOrParent(aType) &&
+		!action.Create.IsActionOrParent(aType):
+
+		found = true
+
+	// Case 3: type=<resource.type>;actions=<action> when action is list or
+	// create. Must be a top level collection, otherwise must be one of the
+	// two formats specified in cases 4 or 5. Or,
+	// type=resource.type;output_fields=<fields> and no action. This is more
+	// of a semantic difference compared to 4 more than a security
+	// difference; this type is for clarity as it ties more closely to the
+	// concept of create and list as actions on a collection, operating on a
+	// collection directly. The format in case 4 will still work for
+	// create/list on collections but that's more of a shortcut to allow
+	// things like id=*;type=*;actions=* for admin flows so that you don't
+	// need to separate out explicit collection actions into separate typed
+	// grants for each collection within a role. This does mean there are
+	// "two ways of doing things" but it's a reasonable UX tradeoff given
+	// that "all IDs" can reasonably be construed to include "and the one
+	// I'm making" and "all of them for listing".
+	case g.id == "" &&
+		r.Id == "" &&
+		g.typ == r.Type &&
+		g.typ != resource.Unknown &&
+		topLevelType(r.Type) &&
+		(action.List.IsActionOrParent(aType) ||
+			action.Create.IsActionOrParent(aType)):
+
+		found = true
+
+	// Case 4:
+	// id=*;type=<resource.type>;actions=<action> where type cannot be
+	// unknown but can be a wildcard to allow any resource at all; or
+	// id=*;type=<resource.type>;output_fields=<fields> with no action.
+	case g.id == "*" &&
+		g.typ != resource.Unknown &&
+		(g.typ == r.Type ||
+			g.typ == resource.All):
+
+		found = true
+
+	// Case 5:
+	// id=<pin>;type=<resource.type>;actions=<action> where type can be a
+	// wildcard and this this is operating on a non-top-level type. Same for
+	// output fields only.
+	case g.id != "" &&
+		g.id == r.Pin &&
+		g.typ != resource.Unknown &&
+		(g.typ == r.Type || g.typ == resource.All) &&
+		!topLevelType(r.Type):
+
+		found = true
 	}
-	return
+
+	return found
 }
 
 // ListPermissions builds a set of Permissions based on the grants in the ACL.
@@ -238,11 +348,24 @@ func (a ACL) Allowed(r Resource, aType action.Type, userId string, opt ...Option
 // or for action.All in order for a Permission to be created for the scope.
 // The set of "id actions" is resource dependant, but will generally include all
 // actions that can be taken on an individual resource.
+//
+// A deny grant excludes resources from the Permission only if it denies
+// action.All or every one of the "id actions". A deny grant with a wildcard id
+// excludes the scope entirely.
+//
+// The conditions of grants are evaluated for listing the requested type in
+// the scope, using the request attributes given with WithRequestAttributes.
+//
+// Grants with a resource filter are treated as though they applied to every
+// resource, with ResourceFiltered set on the Permission so that the caller
+// checks each resource with Allowed.
 func (a ACL) ListPermissions(requestedScopes map[string]*scopes.ScopeInfo,
 	requestedType resource.Type,
 	idActions action.ActionSet,
 	userId string,
+	opt ...Option,
 ) []Permission {
+	opts := getOpts(opt...)
 	perms := make([]Permission, 0, len(requestedScopes))
 	for scopeId := range requestedScopes {
 		p := Permission{
@@ -260,7 +383,36 @@ func (a ACL) ListPermissions(requestedScopes map[string]*scopes.ScopeInfo,
 
 		// Get grants for a specific scope id from the source of truth.
 		grants := a.scopeMap[scopeId]
+		var deniedAll bool
+		var deniedIds []string
 		for _, grant := range grants {
+			if !grant.conditionsHold(Resource{ScopeId: scopeId, Type: requestedType}, action.List, userId, opts.withRequestAttributes) {
+				continue
+			}
+			if grant.deny {
+				// A deny grant without a type names a specific id, which may be
+				// of the requested type; excluding it otherwise is harmless.
+				if grant.typ != requestedType && grant.typ != resource.All && grant.typ != resource.Unknown {
+					continue
+				}
+				if !grant.deniesAll(idActions) {
+					continue
+				}
+				if grant.resourceFilter != "" {
+					// Which resources are denied depends on the filter
+					p.ResourceFiltered = true
+					continue
+				}
+				switch grant.id {
+				case "*":
+					deniedAll = true
+				case "":
+				default:
+					deniedIds = append(deniedIds, grant.id)
+				}
+				continue
+			}
+
 			// This grant doesn't match what we're looking for, ignore.
 			if grant.typ != requestedType && grant.typ != resource.All {
 				continue
@@ -291,6 +443,9 @@ func (a ACL) ListPermissions(requestedScopes map[string]*scopes.ScopeInfo,
 				}
 			}
 			p.OnlySelf = p.OnlySelf && excludeList.OnlySelf()
+			if grant.resourceFilter != "" {
+				p.ResourceFiltered = true
+			}
 
 			switch grant.id {
 			case "*":
@@ -302,6 +457,22 @@ func (a ACL) ListPermissions(requestedScopes map[string]*scopes.ScopeInfo,
 			}
 		}
 
+		if deniedAll {
+			continue
+		}
+		if len(deniedIds) > 0 {
+			if p.All {
+				p.DeniedResourceIds = deniedIds
+			}
+			var resourceIds []string
+			for _, id := range p.ResourceIds {
+				if !strutil.StrListContains(deniedIds, id) {
+					resourceIds = append(resourceIds, id)
+				}
+			}
+			p.ResourceIds = resourceIds
+		}
+
 		if p.All || len(p.ResourceIds) > 0 {
 			perms = append(perms, p)
 		}
@@ -310,6 +481,24 @@ func (a ACL) ListPermissions(requestedScopes map[string]*scopes.ScopeInfo,
 	return perms
 }
 
+// deniesAll reports whether the grant's actions include action.All or every
+// one of the given actions.
+func (g Grant) deniesAll(actions action.ActionSet) bool {
+	if len(actions) == 0 {
+		return g.actions[action.All]
+	}
+	for _, a := range actions {
+		var parentAction action.Type
+		if split := strings.Split(a.String(), ":"); len(split) == 2 {
+			parentAction = action.Map[split[0]]
+		}
+		if !g.matchesAction(a, parentAction) {
+			return false
+		}
+	}
+	return true
+}
+
 func topLevelType(typ resource.Type) bool {
 	switch typ {
 	case resource.AuthMethod,
@@ -320,6 +509,8 @@ func topLevelType(typ resource.Type) bool {
 		resource.Role,
 		resource.Scope,
 		resource.Session,
+		resource.ServiceAccount,
+		resource.SessionRecording,
 		resource.Target,
 		resource.User,
 		resource.Worker:
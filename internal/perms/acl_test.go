@@ -7,12 +7,15 @@ import (
 	"encoding/json"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/boundary/globals"
 	"github.com/hashicorp/boundary/internal/intglobals"
 	"github.com/hashicorp/boundary/internal/types/action"
 	"github.com/hashicorp/boundary/internal/types/resource"
 	"github.com/hashicorp/boundary/internal/types/scope"
+	"github.com/hashicorp/boundary/internal/util"
+	"github.com/hashicorp/boundary/internal/util/template"
 	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/scopes"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -352,6 +355,111 @@ func Test_ACLAllowed(t *testing.T) {
 				{action: action.CreateWorkerLed, authorized: true},
 			},
 		},
+		{
+			name:     "deny overrides wildcard allow",
+			resource: Resource{ScopeId: "p_a", Id: "ttcp_1", Type: resource.Target},
+			scopeGrants: []scopeGrant{
+				{
+					scope: "p_a",
+					grants: []string{
+						"id=*;type=*;actions=*;output_fields=*",
+						"id=ttcp_1;actions=authorize-session,delete;deny=true",
+					},
+				},
+			},
+			actionsAuthorized: []actionAuthorized{
+				{action: action.Read, authorized: true, outputFields: []string{"*"}},
+				{action: action.AuthorizeSession},
+				{action: action.Delete},
+			},
+		},
+		{
+			name:     "deny does not match other ids",
+			resource: Resource{ScopeId: "p_a", Id: "ttcp_2", Type: resource.Target},
+			scopeGrants: []scopeGrant{
+				{
+					scope: "p_a",
+					grants: []string{
+						"id=*;type=*;actions=*",
+						"id=ttcp_1;actions=*;deny=true",
+					},
+				},
+			},
+			actionsAuthorized: []actionAuthorized{
+				{action: action.AuthorizeSession, authorized: true},
+				{action: action.Delete, authorized: true},
+			},
+		},
+		{
+			name:     "deny parent action denies subaction",
+			resource: Resource{ScopeId: "o_a", Id: "at_1", Type: resource.AuthToken},
+			scopeGrants: []scopeGrant{
+				{
+					scope: "o_a",
+					grants: []string{
+						"id=*;type=auth-token;actions=*",
+						"id=*;type=auth-token;actions=read;deny=true",
+					},
+				},
+			},
+			actionsAuthorized: []actionAuthorized{
+				{action: action.Read},
+				{action: action.ReadSelf},
+				{action: action.Delete, authorized: true},
+			},
+		},
+		{
+			name:     "deny collection action",
+			resource: Resource{ScopeId: "p_a", Type: resource.Target},
+			scopeGrants: []scopeGrant{
+				{
+					scope: "p_a",
+					grants: []string{
+						"id=*;type=target;actions=*",
+						"type=target;actions=list;deny=true",
+					},
+				},
+			},
+			actionsAuthorized: []actionAuthorized{
+				{action: action.List},
+				{action: action.Create, authorized: true},
+			},
+		},
+		{
+			name:     "deny in another scope",
+			resource: Resource{ScopeId: "p_a", Id: "ttcp_1", Type: resource.Target},
+			scopeGrants: []scopeGrant{
+				{
+					scope:  "p_a",
+					grants: []string{"id=*;type=target;actions=*"},
+				},
+				{
+					scope:  "p_b",
+					grants: []string{"id=*;type=target;actions=*;deny=true"},
+				},
+			},
+			actionsAuthorized: []actionAuthorized{
+				{action: action.Read, authorized: true},
+			},
+		},
+		{
+			name:     "deny for anonymous user",
+			resource: Resource{ScopeId: "o_a", Type: resource.AuthMethod},
+			userId:   globals.AnonymousUserId,
+			scopeGrants: []scopeGrant{
+				{
+					scope: "o_a",
+					grants: []string{
+						"id=*;type=auth-method;actions=list,authenticate",
+						"id=*;type=auth-method;actions=list;deny=true",
+					},
+				},
+			},
+			actionsAuthorized: []actionAuthorized{
+				{action: action.List},
+				{action: action.Authenticate, authorized: true},
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -379,6 +487,401 @@ func Test_ACLAllowed(t *testing.T) {
 	}
 }
 
+func Test_ACLAllowedConditions(t *testing.T) {
+	t.Parallel()
+
+	// A Monday
+	monday := time.Date(2023, time.March, 6, 10, 30, 0, 0, time.UTC)
+	res := Resource{ScopeId: "p_a", Id: "ttcp_1", Type: resource.Target}
+
+	tests := []struct {
+		name       string
+		grants     []string
+		attrs      *RequestAttributes
+		authorized bool
+	}{
+		{
+			name:       "no request attributes",
+			grants:     []string{"id=*;type=target;actions=authorize-session;days=mon"},
+			authorized: false,
+		},
+		{
+			name:       "within change window",
+			grants:     []string{"id=*;type=target;actions=authorize-session;days=mon,tue;hours=09:00-17:00;source_cidrs=10.0.0.0/8"},
+			attrs:      &RequestAttributes{Time: monday, ClientIp: "10.0.0.1"},
+			authorized: true,
+		},
+		{
+			name:       "outside change window",
+			grants:     []string{"id=*;type=target;actions=authorize-session;days=mon;hours=12:00-17:00"},
+			attrs:      &RequestAttributes{Time: monday},
+			authorized: false,
+		},
+		{
+			name:       "outside corporate network",
+			grants:     []string{"id=*;type=target;actions=authorize-session;source_cidrs=10.0.0.0/8"},
+			attrs:      &RequestAttributes{Time: monday, ClientIp: "192.168.0.1"},
+			authorized: false,
+		},
+		{
+			name: "unconditional grant still applies",
+			grants: []string{
+				"id=*;type=target;actions=authorize-session;source_cidrs=10.0.0.0/8",
+				"id=ttcp_1;actions=authorize-session",
+			},
+			attrs:      &RequestAttributes{Time: monday, ClientIp: "192.168.0.1"},
+			authorized: true,
+		},
+		{
+			name:       "matching filter",
+			grants:     []string{`{"id":"*","type":"target","actions":["authorize-session"],"filter":"\"/resource/id\" == \"ttcp_1\""}`},
+			attrs:      &RequestAttributes{Time: monday},
+			authorized: true,
+		},
+		{
+			name:       "other filter",
+			grants:     []string{`{"id":"*","type":"target","actions":["authorize-session"],"filter":"\"/resource/id\" == \"ttcp_2\""}`},
+			attrs:      &RequestAttributes{Time: monday},
+			authorized: false,
+		},
+		{
+			name: "conditional deny holds",
+			grants: []string{
+				"id=*;type=target;actions=*",
+				"id=*;type=target;actions=authorize-session;deny=true;days=sat,sun",
+			},
+			attrs:      &RequestAttributes{Time: monday.AddDate(0, 0, 5)},
+			authorized: false,
+		},
+		{
+			name: "conditional deny does not hold",
+			grants: []string{
+				"id=*;type=target;actions=*",
+				"id=*;type=target;actions=authorize-session;deny=true;days=sat,sun",
+			},
+			attrs:      &RequestAttributes{Time: monday},
+			authorized: true,
+		},
+		{
+			name: "conditional deny without request attributes",
+			grants: []string{
+				"id=*;type=target;actions=*",
+				"id=*;type=target;actions=authorize-session;deny=true;days=sat,sun",
+			},
+			authorized: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var grants []Grant
+			for _, g := range test.grants {
+				grant, err := Parse("p_a", g)
+				require.NoError(t, err)
+				grants = append(grants, grant)
+			}
+			acl := NewACL(grants...)
+			result := acl.Allowed(res, action.AuthorizeSession, "u_1234567890", WithRequestAttributes(test.attrs))
+			assert.Equal(t, test.authorized, result.Authorized)
+		})
+	}
+
+	t.Run("list permissions", func(t *testing.T) {
+		grants := make([]Grant, 0, 3)
+		for _, g := range []string{
+			"id=*;type=session;actions=read;source_cidrs=10.0.0.0/8",
+			"id=*;type=session;actions=*;deny=true;source_cidrs=10.1.0.0/16",
+			"id=*;type=target;actions=read;hours=09:00-17:00",
+		} {
+			grant, err := Parse("p_a", g)
+			require.NoError(t, err)
+			grants = append(grants, grant)
+		}
+		acl := NewACL(grants...)
+		scopeInfos := map[string]*scopes.ScopeInfo{"p_a": nil}
+		allowed := []Permission{{ScopeId: "p_a", Resource: resource.Session, Action: action.List, All: true}}
+
+		assert.Empty(t, acl.ListPermissions(scopeInfos, resource.Session, action.ActionSet{action.Read}, "u_1234567890"))
+		assert.Equal(t, allowed, acl.ListPermissions(scopeInfos, resource.Session, action.ActionSet{action.Read}, "u_1234567890",
+			WithRequestAttributes(&RequestAttributes{Time: monday, ClientIp: "10.0.0.1"})))
+		assert.Empty(t, acl.ListPermissions(scopeInfos, resource.Session, action.ActionSet{action.Read}, "u_1234567890",
+			WithRequestAttributes(&RequestAttributes{Time: monday, ClientIp: "10.1.0.1"})))
+		assert.Empty(t, acl.ListPermissions(scopeInfos, resource.Target, action.ActionSet{action.Read}, "u_1234567890",
+			WithRequestAttributes(&RequestAttributes{Time: monday.Add(-2 * time.Hour)})))
+	})
+}
+
+func Test_ACLAllowedResourceFilter(t *testing.T) {
+	t.Parallel()
+
+	data := &template.Data{
+		User:    template.User{Id: util.Pointer("u_1234567890")},
+		Account: template.Account{Claims: map[string]any{"team": "blue"}},
+	}
+	blue := Resource{ScopeId: "p_a", Id: "ttcp_1", Type: resource.Target, Data: map[string]any{"name": "blue-db"}}
+	red := Resource{ScopeId: "p_a", Id: "ttcp_2", Type: resource.Target, Data: map[string]any{"name": "red-db"}}
+	noData := Resource{ScopeId: "p_a", Id: "ttcp_3", Type: resource.Target}
+	collection := Resource{ScopeId: "p_a", Type: resource.Target}
+
+	tests := []struct {
+		name       string
+		grants     []string
+		data       *template.Data
+		res        Resource
+		act        action.Type
+		authorized bool
+	}{
+		{
+			name:       "matching resource",
+			grants:     []string{`id=*;type=target;actions=authorize-session;resource_filter="/resource/name" matches "{{account.claims.team}}-.*"`},
+			data:       data,
+			res:        blue,
+			act:        action.AuthorizeSession,
+			authorized: true,
+		},
+		{
+			name:       "other resource",
+			grants:     []string{`id=*;type=target;actions=authorize-session;resource_filter="/resource/name" matches "{{account.claims.team}}-.*"`},
+			data:       data,
+			res:        red,
+			act:        action.AuthorizeSession,
+			authorized: false,
+		},
+		{
+			name:       "id and scope",
+			grants:     []string{`id=*;type=target;actions=read;resource_filter="/resource/id" == "ttcp_1" and "/resource/scope_id" == "p_a"`},
+			data:       data,
+			res:        blue,
+			act:        action.Read,
+			authorized: true,
+		},
+		{
+			name:       "missing template value",
+			grants:     []string{`id=*;type=target;actions=authorize-session;resource_filter="/resource/name" matches "{{account.claims.team}}-.*"`},
+			res:        blue,
+			act:        action.AuthorizeSession,
+			authorized: false,
+		},
+		{
+			name:       "missing resource data",
+			grants:     []string{`id=*;type=target;actions=authorize-session;resource_filter="/resource/name" matches "{{account.claims.team}}-.*"`},
+			data:       data,
+			res:        noData,
+			act:        action.AuthorizeSession,
+			authorized: false,
+		},
+		{
+			name:       "collection",
+			grants:     []string{`id=*;type=target;actions=list,read;resource_filter="/resource/name" matches "{{account.claims.team}}-.*"`},
+			data:       data,
+			res:        collection,
+			act:        action.List,
+			authorized: true,
+		},
+		{
+			name: "matching deny",
+			grants: []string{
+				"id=*;type=target;actions=*",
+				`id=*;type=target;actions=authorize-session;deny=true;resource_filter="/resource/name" not matches "{{account.claims.team}}-.*"`,
+			},
+			data:       data,
+			res:        red,
+			act:        action.AuthorizeSession,
+			authorized: false,
+		},
+		{
+			name: "other deny",
+			grants: []string{
+				"id=*;type=target;actions=*",
+				`id=*;type=target;actions=authorize-session;deny=true;resource_filter="/resource/name" not matches "{{account.claims.team}}-.*"`,
+			},
+			data:       data,
+			res:        blue,
+			act:        action.AuthorizeSession,
+			authorized: true,
+		},
+		{
+			name: "deny with missing template value",
+			grants: []string{
+				"id=*;type=target;actions=*",
+				`id=*;type=target;actions=authorize-session;deny=true;resource_filter="/resource/name" not matches "{{account.claims.team}}-.*"`,
+			},
+			res:        blue,
+			act:        action.AuthorizeSession,
+			authorized: false,
+		},
+		{
+			name: "deny on collection",
+			grants: []string{
+				"id=*;type=target;actions=*",
+				`id=*;type=target;actions=*;deny=true;resource_filter="/resource/name" not matches "{{account.claims.team}}-.*"`,
+			},
+			data:       data,
+			res:        collection,
+			act:        action.List,
+			authorized: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var grants []Grant
+			for _, g := range test.grants {
+				grant, err := Parse("p_a", g, WithTemplateData(test.data))
+				require.NoError(t, err)
+				grants = append(grants, grant)
+			}
+			acl := NewACL(grants...)
+			result := acl.Allowed(test.res, test.act, "u_1234567890")
+			assert.Equal(t, test.authorized, result.Authorized)
+		})
+	}
+
+	t.Run("list permissions", func(t *testing.T) {
+		grants := make([]Grant, 0, 3)
+		for _, g := range []string{
+			`id=*;type=target;actions=read;resource_filter="/resource/name" matches "{{account.claims.team}}-.*"`,
+			"id=*;type=worker;actions=read",
+			`id=*;type=worker;actions=*;deny=true;resource_filter="{{account.claims.team}}" not in "/resource/tags/team"`,
+		} {
+			grant, err := Parse("p_a", g, WithTemplateData(data))
+			require.NoError(t, err)
+			grants = append(grants, grant)
+		}
+		acl := NewACL(grants...)
+		scopeInfos := map[string]*scopes.ScopeInfo{"p_a": nil}
+
+		assert.Equal(t,
+			[]Permission{{ScopeId: "p_a", Resource: resource.Target, Action: action.List, All: true, ResourceFiltered: true}},
+			acl.ListPermissions(scopeInfos, resource.Target, action.ActionSet{action.Read}, "u_1234567890"))
+		assert.Equal(t,
+			[]Permission{{ScopeId: "p_a", Resource: resource.Worker, Action: action.List, All: true, ResourceFiltered: true}},
+			acl.ListPermissions(scopeInfos, resource.Worker, action.ActionSet{action.Read}, "u_1234567890"))
+
+		worker := Resource{ScopeId: "p_a", Id: "w_1", Type: resource.Worker, Data: map[string]any{"tags": map[string][]string{"team": {"red", "blue"}}}}
+		assert.True(t, acl.Allowed(worker, action.Read, "u_1234567890").Authorized)
+		worker.Data = map[string]any{"tags": map[string][]string{"team": {"red"}}}
+		assert.False(t, acl.Allowed(worker, action.Read, "u_1234567890").Authorized)
+	})
+}
+
+func Test_GrantApplies(t *testing.T) {
+	t.Parallel()
+
+	monday := time.Date(2023, time.March, 6, 10, 30, 0, 0, time.UTC)
+	res := Resource{ScopeId: "p_a", Id: "ttcp_1", Type: resource.Target}
+
+	tests := []struct {
+		name    string
+		scope   string
+		grant   string
+		act     action.Type
+		userId  string
+		attrs   *RequestAttributes
+		applies bool
+	}{
+		{
+			name:    "matching grant",
+			grant:   "id=*;type=target;actions=read,authorize-session",
+			act:     action.AuthorizeSession,
+			applies: true,
+		},
+		{
+			name:    "matching id",
+			grant:   "id=ttcp_1;actions=*",
+			act:     action.Read,
+			applies: true,
+		},
+		{
+			name:    "parent action",
+			grant:   "id=*;type=target;actions=read",
+			act:     action.ReadSelf,
+			applies: true,
+		},
+		{
+			name:    "other action",
+			grant:   "id=*;type=target;actions=read",
+			act:     action.AuthorizeSession,
+			applies: false,
+		},
+		{
+			name:    "other id",
+			grant:   "id=ttcp_2;actions=*",
+			act:     action.Read,
+			applies: false,
+		},
+		{
+			name:    "other type",
+			grant:   "id=*;type=host-catalog;actions=*",
+			act:     action.Read,
+			applies: false,
+		},
+		{
+			name:    "other scope",
+			scope:   "p_b",
+			grant:   "id=*;type=target;actions=*",
+			act:     action.Read,
+			applies: false,
+		},
+		{
+			name:    "matching deny",
+			grant:   "id=*;type=target;actions=authorize-session;deny=true",
+			act:     action.AuthorizeSession,
+			applies: true,
+		},
+		{
+			name:    "deny for anonymous user",
+			grant:   "id=*;type=target;actions=authorize-session;deny=true",
+			act:     action.AuthorizeSession,
+			userId:  globals.AnonymousUserId,
+			applies: true,
+		},
+		{
+			name:    "allow for anonymous user",
+			grant:   "id=*;type=target;actions=authorize-session",
+			act:     action.AuthorizeSession,
+			userId:  globals.AnonymousUserId,
+			applies: false,
+		},
+		{
+			name:    "conditions hold",
+			grant:   "id=*;type=target;actions=authorize-session;days=mon",
+			act:     action.AuthorizeSession,
+			attrs:   &RequestAttributes{Time: monday},
+			applies: true,
+		},
+		{
+			name:    "conditions don't hold",
+			grant:   "id=*;type=target;actions=authorize-session;days=tue",
+			act:     action.AuthorizeSession,
+			attrs:   &RequestAttributes{Time: monday},
+			applies: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			scope := test.scope
+			if scope == "" {
+				scope = "p_a"
+			}
+			userId := test.userId
+			if userId == "" {
+				userId = "u_1234567890"
+			}
+			grant, err := Parse(scope, test.grant)
+			require.NoError(t, err)
+			assert.Equal(t, test.applies, grant.Applies(res, test.act, userId, WithRequestAttributes(test.attrs)))
+
+			// A grant which applies on its own decides the request
+			acl := NewACL(grant)
+			if test.applies {
+				assert.Equal(t, !grant.Deny(), acl.Allowed(res, test.act, userId, WithRequestAttributes(test.attrs)).Authorized)
+			}
+		})
+	}
+}
+
 func TestACL_ListPermissions(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -776,6 +1279,95 @@ func TestACL_ListPermissions(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Deny all ids",
+			aclGrants: []scopeGrant{
+				{
+					scope: "o_1",
+					grants: []string{
+						"id=*;type=session;actions=list,read",
+						"id=*;type=session;actions=*;deny=true",
+					},
+				},
+				{
+					scope:  "o_2",
+					grants: []string{"id=*;type=session;actions=list,read"},
+				},
+			},
+			scopes:       map[string]*scopes.ScopeInfo{"o_1": nil, "o_2": nil},
+			resourceType: resource.Session,
+			actionSet:    action.ActionSet{action.List, action.Read},
+			expPermissions: []Permission{
+				{
+					ScopeId:  "o_2",
+					Resource: resource.Session,
+					Action:   action.List,
+					All:      true,
+				},
+			},
+		},
+		{
+			name: "Deny specific ids",
+			aclGrants: []scopeGrant{
+				{
+					scope: "o_1",
+					grants: []string{
+						"id=*;type=session;actions=list,read",
+						"id=s_1;actions=list,read;deny=true",
+					},
+				},
+				{
+					scope: "o_2",
+					grants: []string{
+						"id=s_2;type=session;actions=read",
+						"id=s_3;type=session;actions=read",
+						"id=s_2;actions=*;deny=true",
+					},
+				},
+			},
+			scopes:       map[string]*scopes.ScopeInfo{"o_1": nil, "o_2": nil},
+			resourceType: resource.Session,
+			actionSet:    action.ActionSet{action.List, action.Read},
+			expPermissions: []Permission{
+				{
+					ScopeId:           "o_1",
+					Resource:          resource.Session,
+					Action:            action.List,
+					All:               true,
+					DeniedResourceIds: []string{"s_1"},
+				},
+				{
+					ScopeId:     "o_2",
+					Resource:    resource.Session,
+					Action:      action.List,
+					ResourceIds: []string{"s_3"},
+				},
+			},
+		},
+		{
+			name: "Deny some actions",
+			aclGrants: []scopeGrant{
+				{
+					scope: "o_1",
+					grants: []string{
+						"id=*;type=session;actions=list,read,cancel",
+						"id=*;type=session;actions=cancel;deny=true",
+						"id=s_1;actions=read;deny=true",
+					},
+				},
+			},
+			scopes:       map[string]*scopes.ScopeInfo{"o_1": nil},
+			resourceType: resource.Session,
+			actionSet:    action.ActionSet{action.List, action.Read, action.Cancel},
+			expPermissions: []Permission{
+				{
+					ScopeId:  "o_1",
+					Resource: resource.Session,
+					Action:   action.List,
+					All:      true,
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -851,11 +1443,11 @@ func Test_AnonRestrictions(t *testing.T) {
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			require, assert := require.New(t), assert.New(t)
-			for i := resource.Type(1); i <= resource.Credential; i++ {
+			for i := resource.Type(1); i <= resource.ApiKey; i++ {
 				if i == resource.Controller || i == resource.Worker {
 					continue
 				}
-				for j := action.Type(1); j <= action.ReadCertificateAuthority; j++ {
+				for j := action.Type(1); j <= action.Explain; j++ {
 					res := Resource{
 						ScopeId: scope.Global.String(),
 						Id:      "foobar",
@@ -0,0 +1,326 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package perms
+
+import (
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/types/action"
+	"github.com/hashicorp/go-bexpr"
+)
+
+// RequestAttributes are the attributes of a request that the conditions of
+// grants are evaluated against.
+type RequestAttributes struct {
+	// Time is the time of the request. If not set the current time is used.
+	Time time.Time
+
+	// ClientIp is the IP address of the API client making the request.
+	ClientIp string
+}
+
+// days maps the accepted day names to weekdays; the short names are used in
+// the canonical form.
+var days = map[string]time.Weekday{
+	"sun":       time.Sunday,
+	"sunday":    time.Sunday,
+	"mon":       time.Monday,
+	"monday":    time.Monday,
+	"tue":       time.Tuesday,
+	"tuesday":   time.Tuesday,
+	"wed":       time.Wednesday,
+	"wednesday": time.Wednesday,
+	"thu":       time.Thursday,
+	"thursday":  time.Thursday,
+	"fri":       time.Friday,
+	"friday":    time.Friday,
+	"sat":       time.Saturday,
+	"saturday":  time.Saturday,
+}
+
+// weekdays is the canonical order of days, starting on Monday
+var weekdays = []time.Weekday{
+	time.Monday,
+	time.Tuesday,
+	time.Wednesday,
+	time.Thursday,
+	time.Friday,
+	time.Saturday,
+	time.Sunday,
+}
+
+func shortDayName(d time.Weekday) string {
+	return strings.ToLower(d.String()[:3])
+}
+
+// conditions restrict when a grant applies. Every condition which is set must
+// hold for the grant to apply.
+type conditions struct {
+	// The days of the week, the hours of the day, and the time zone they
+	// are in. These are the canonical string forms.
+	days     []string
+	hours    []string
+	timeZone string
+
+	// The CIDR ranges the API client's IP address must be within
+	sourceCidrs []string
+
+	// A bexpr filter evaluated against the request
+	filter string
+
+	// The values below are populated when the conditions are validated
+	weekdays    map[time.Weekday]bool
+	hourRanges  []hourRange
+	location    *time.Location
+	sourceNets  []*net.IPNet
+	filterEval  *bexpr.Evaluator
+	isValidated bool
+}
+
+// hourRange is a range of minutes since midnight. The end is exclusive and
+// may be before the start, in which case the range wraps around midnight.
+type hourRange struct {
+	start int
+	end   int
+}
+
+func (h hourRange) contains(minute int) bool {
+	if h.start < h.end {
+		return minute >= h.start && minute < h.end
+	}
+	return minute >= h.start || minute < h.end
+}
+
+func (h hourRange) String() string {
+	return fmt.Sprintf("%02d:%02d-%02d:%02d", h.start/60, h.start%60, h.end/60, h.end%60)
+}
+
+// parseMinute parses a time of day formatted as HH:MM into minutes since
+// midnight. 24:00 is only accepted if allowEndOfDay is set.
+func parseMinute(s string, allowEndOfDay bool) (int, bool) {
+	var h, m int
+	if len(s) != 5 || s[2] != ':' {
+		return 0, false
+	}
+	if _, err := fmt.Sscanf(s, "%02d:%02d", &h, &m); err != nil {
+		return 0, false
+	}
+	switch {
+	case h == 24 && m == 0 && allowEndOfDay:
+	case h < 0 || h > 23 || m < 0 || m > 59:
+		return 0, false
+	}
+	return h*60 + m, true
+}
+
+func (c *conditions) clone() *conditions {
+	if c == nil {
+		return nil
+	}
+	ret := *c
+	return &ret
+}
+
+// parseAndValidate validates the conditions and populates the values they are
+// evaluated with, normalizing the string forms.
+func (c *conditions) parseAndValidate() error {
+	const op = "perms.(conditions).parseAndValidate"
+	if c.isValidated {
+		return nil
+	}
+
+	if len(c.days) > 0 {
+		c.weekdays = make(map[time.Weekday]bool, len(c.days))
+		for _, d := range c.days {
+			wd, ok := days[strings.ToLower(d)]
+			if !ok {
+				return errors.NewDeprecated(errors.InvalidParameter, op, fmt.Sprintf("unknown day %q", d))
+			}
+			c.weekdays[wd] = true
+		}
+		c.days = make([]string, 0, len(c.weekdays))
+		for _, wd := range weekdays {
+			if c.weekdays[wd] {
+				c.days = append(c.days, shortDayName(wd))
+			}
+		}
+	}
+
+	if len(c.hours) > 0 {
+		c.hourRanges = make([]hourRange, 0, len(c.hours))
+		for i, h := range c.hours {
+			startEnd := strings.Split(h, "-")
+			if len(startEnd) != 2 {
+				return errors.NewDeprecated(errors.InvalidParameter, op, fmt.Sprintf("hours %q not formatted as HH:MM-HH:MM", h))
+			}
+			start, ok := parseMinute(strings.TrimSpace(startEnd[0]), false)
+			if !ok {
+				return errors.NewDeprecated(errors.InvalidParameter, op, fmt.Sprintf("hours %q not formatted as HH:MM-HH:MM", h))
+			}
+			end, ok := parseMinute(strings.TrimSpace(startEnd[1]), true)
+			if !ok {
+				return errors.NewDeprecated(errors.InvalidParameter, op, fmt.Sprintf("hours %q not formatted as HH:MM-HH:MM", h))
+			}
+			if start == end {
+				return errors.NewDeprecated(errors.InvalidParameter, op, fmt.Sprintf("hours %q is an empty range", h))
+			}
+			r := hourRange{start: start, end: end}
+			c.hourRanges = append(c.hourRanges, r)
+			c.hours[i] = r.String()
+		}
+	}
+
+	if c.timeZone != "" {
+		if len(c.days) == 0 && len(c.hours) == 0 {
+			return errors.NewDeprecated(errors.InvalidParameter, op, "time zone specified without days or hours")
+		}
+		loc, err := time.LoadLocation(c.timeZone)
+		if err != nil {
+			return errors.NewDeprecated(errors.InvalidParameter, op, fmt.Sprintf("unknown time zone %q", c.timeZone))
+		}
+		c.location = loc
+	}
+
+	if len(c.sourceCidrs) > 0 {
+		c.sourceNets = make([]*net.IPNet, 0, len(c.sourceCidrs))
+		for i, cidr := range c.sourceCidrs {
+			_, ipNet, err := net.ParseCIDR(cidr)
+			if err != nil {
+				return errors.NewDeprecated(errors.InvalidParameter, op, fmt.Sprintf("invalid source cidr %q", cidr))
+			}
+			c.sourceNets = append(c.sourceNets, ipNet)
+			c.sourceCidrs[i] = ipNet.String()
+		}
+	}
+
+	if c.filter != "" {
+		// The filter must not end the segment early in the canonical form
+		if strings.Contains(c.filter, ";") {
+			return errors.NewDeprecated(errors.InvalidParameter, op, "filter cannot contain semicolons")
+		}
+		eval, err := bexpr.CreateEvaluator(c.filter, bexpr.WithTagName("json"))
+		if err != nil {
+			return errors.NewDeprecated(errors.InvalidParameter, op, fmt.Sprintf("invalid filter %q: %s", c.filter, err.Error()))
+		}
+		c.filterEval = eval
+	}
+
+	c.isValidated = true
+	return nil
+}
+
+// filterInput is what the filter of a grant is evaluated against
+type filterInput struct {
+	User     filterUser     `json:"user"`
+	Request  filterRequest  `json:"request"`
+	Resource filterResource `json:"resource"`
+}
+
+type filterUser struct {
+	Id string `json:"id"`
+}
+
+type filterRequest struct {
+	Action   string `json:"action"`
+	ClientIp string `json:"client_ip"`
+	Time     string `json:"time"`
+	Day      string `json:"day"`
+}
+
+type filterResource struct {
+	Id      string `json:"id"`
+	Type    string `json:"type"`
+	ScopeId string `json:"scope_id"`
+	Pin     string `json:"pin"`
+}
+
+// evaluate reports whether the conditions hold for a request for the given
+// action on the resource. It returns an error if the conditions can't be
+// evaluated with the request attributes.
+func (c *conditions) evaluate(r Resource, aType action.Type, userId string, attrs *RequestAttributes) (bool, error) {
+	const op = "perms.(conditions).evaluate"
+	if !c.isValidated {
+		return false, errors.NewDeprecated(errors.InvalidParameter, op, "conditions not validated")
+	}
+	if attrs == nil {
+		return false, errors.NewDeprecated(errors.InvalidParameter, op, "missing request attributes")
+	}
+
+	now := attrs.Time
+	if now.IsZero() {
+		now = time.Now()
+	}
+	switch {
+	case c.location != nil:
+		now = now.In(c.location)
+	default:
+		now = now.UTC()
+	}
+
+	if len(c.weekdays) > 0 && !c.weekdays[now.Weekday()] {
+		return false, nil
+	}
+
+	if len(c.hourRanges) > 0 {
+		minute := now.Hour()*60 + now.Minute()
+		var inRange bool
+		for _, h := range c.hourRanges {
+			if h.contains(minute) {
+				inRange = true
+				break
+			}
+		}
+		if !inRange {
+			return false, nil
+		}
+	}
+
+	if len(c.sourceNets) > 0 {
+		ip := net.ParseIP(attrs.ClientIp)
+		if ip == nil {
+			return false, errors.NewDeprecated(errors.InvalidParameter, op, fmt.Sprintf("unable to parse client ip %q", attrs.ClientIp))
+		}
+		var inNet bool
+		for _, n := range c.sourceNets {
+			if n.Contains(ip) {
+				inNet = true
+				break
+			}
+		}
+		if !inNet {
+			return false, nil
+		}
+	}
+
+	if c.filterEval != nil {
+		in := filterInput{
+			User: filterUser{Id: userId},
+			Request: filterRequest{
+				Action:   aType.String(),
+				ClientIp: attrs.ClientIp,
+				Time:     now.Format(time.RFC3339),
+				Day:      shortDayName(now.Weekday()),
+			},
+			Resource: filterResource{
+				Id:      r.Id,
+				Type:    r.Type.String(),
+				ScopeId: r.ScopeId,
+				Pin:     r.Pin,
+			},
+		}
+		match, err := c.filterEval.Evaluate(in)
+		if err != nil {
+			return false, errors.WrapDeprecated(err, op)
+		}
+		if !match {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
@@ -0,0 +1,223 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package perms
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/types/action"
+	"github.com/hashicorp/boundary/internal/types/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ConditionsParseAndValidate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		input     conditions
+		errResult string
+		days      []string
+		hours     []string
+		cidrs     []string
+	}{
+		{
+			name:  "days",
+			input: conditions{days: []string{"Sunday", "fri", "MON", "mon"}},
+			days:  []string{"mon", "fri", "sun"},
+		},
+		{
+			name:      "unknown day",
+			input:     conditions{days: []string{"mon", "funday"}},
+			errResult: `perms.(conditions).parseAndValidate: unknown day "funday": parameter violation: error #100`,
+		},
+		{
+			name:  "hours",
+			input: conditions{hours: []string{"09:00-17:30", "22:00 - 24:00", "23:00-01:00"}},
+			hours: []string{"09:00-17:30", "22:00-24:00", "23:00-01:00"},
+		},
+		{
+			name:      "bad hours format",
+			input:     conditions{hours: []string{"9-17"}},
+			errResult: `perms.(conditions).parseAndValidate: hours "9-17" not formatted as HH:MM-HH:MM: parameter violation: error #100`,
+		},
+		{
+			name:      "bad hours value",
+			input:     conditions{hours: []string{"09:00-17:60"}},
+			errResult: `perms.(conditions).parseAndValidate: hours "09:00-17:60" not formatted as HH:MM-HH:MM: parameter violation: error #100`,
+		},
+		{
+			name:      "start of day at end of day",
+			input:     conditions{hours: []string{"24:00-01:00"}},
+			errResult: `perms.(conditions).parseAndValidate: hours "24:00-01:00" not formatted as HH:MM-HH:MM: parameter violation: error #100`,
+		},
+		{
+			name:      "empty hours range",
+			input:     conditions{hours: []string{"09:00-09:00"}},
+			errResult: `perms.(conditions).parseAndValidate: hours "09:00-09:00" is an empty range: parameter violation: error #100`,
+		},
+		{
+			name:  "time zone",
+			input: conditions{days: []string{"mon"}, timeZone: "UTC"},
+			days:  []string{"mon"},
+		},
+		{
+			name:      "time zone without days or hours",
+			input:     conditions{timeZone: "UTC"},
+			errResult: `perms.(conditions).parseAndValidate: time zone specified without days or hours: parameter violation: error #100`,
+		},
+		{
+			name:      "unknown time zone",
+			input:     conditions{hours: []string{"09:00-17:00"}, timeZone: "Mars/Olympus_Mons"},
+			errResult: `perms.(conditions).parseAndValidate: unknown time zone "Mars/Olympus_Mons": parameter violation: error #100`,
+		},
+		{
+			name:  "source cidrs",
+			input: conditions{sourceCidrs: []string{"10.1.2.3/8", "2001:db8::1/32"}},
+			cidrs: []string{"10.0.0.0/8", "2001:db8::/32"},
+		},
+		{
+			name:      "bad source cidr",
+			input:     conditions{sourceCidrs: []string{"10.0.0.1"}},
+			errResult: `perms.(conditions).parseAndValidate: invalid source cidr "10.0.0.1": parameter violation: error #100`,
+		},
+		{
+			name:  "filter",
+			input: conditions{filter: `"/resource/id" matches "ttcp_.*"`},
+		},
+		{
+			name:      "bad filter",
+			input:     conditions{filter: `"/resource/id" ===`},
+			errResult: `perms.(conditions).parseAndValidate: invalid filter`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			err := test.input.parseAndValidate()
+			if test.errResult != "" {
+				require.Error(err)
+				assert.Contains(err.Error(), test.errResult)
+				return
+			}
+			require.NoError(err)
+			assert.True(test.input.isValidated)
+			assert.Equal(test.days, test.input.days)
+			assert.Equal(test.hours, test.input.hours)
+			assert.Equal(test.cidrs, test.input.sourceCidrs)
+		})
+	}
+}
+
+func Test_ConditionsEvaluate(t *testing.T) {
+	t.Parallel()
+
+	// A Monday
+	monday := time.Date(2023, time.March, 6, 10, 30, 0, 0, time.UTC)
+	res := Resource{ScopeId: "p_1234567890", Id: "ttcp_1234567890", Type: resource.Target}
+
+	tests := []struct {
+		name    string
+		input   conditions
+		attrs   *RequestAttributes
+		want    bool
+		wantErr bool
+	}{
+		{
+			name:    "no request attributes",
+			input:   conditions{days: []string{"mon"}},
+			wantErr: true,
+		},
+		{
+			name:  "matching day",
+			input: conditions{days: []string{"mon", "tue"}},
+			attrs: &RequestAttributes{Time: monday},
+			want:  true,
+		},
+		{
+			name:  "other day",
+			input: conditions{days: []string{"sat", "sun"}},
+			attrs: &RequestAttributes{Time: monday},
+		},
+		{
+			name:  "matching hours",
+			input: conditions{hours: []string{"08:00-09:00", "10:00-11:00"}},
+			attrs: &RequestAttributes{Time: monday},
+			want:  true,
+		},
+		{
+			name:  "end of hours is exclusive",
+			input: conditions{hours: []string{"09:00-10:30"}},
+			attrs: &RequestAttributes{Time: monday},
+		},
+		{
+			name:  "hours wrapping midnight",
+			input: conditions{hours: []string{"22:00-11:00"}},
+			attrs: &RequestAttributes{Time: monday},
+			want:  true,
+		},
+		{
+			name:  "time zone moves the day",
+			input: conditions{days: []string{"mon"}, hours: []string{"09:00-17:00"}, timeZone: "Pacific/Auckland"},
+			attrs: &RequestAttributes{Time: monday},
+		},
+		{
+			name:  "time zone moves the hours",
+			input: conditions{hours: []string{"04:00-06:00"}, timeZone: "America/New_York"},
+			attrs: &RequestAttributes{Time: monday},
+			want:  true,
+		},
+		{
+			name:  "matching source cidr",
+			input: conditions{sourceCidrs: []string{"192.168.0.0/16", "10.0.0.0/8"}},
+			attrs: &RequestAttributes{Time: monday, ClientIp: "10.1.2.3"},
+			want:  true,
+		},
+		{
+			name:  "other source cidr",
+			input: conditions{sourceCidrs: []string{"10.0.0.0/8"}},
+			attrs: &RequestAttributes{Time: monday, ClientIp: "172.16.0.1"},
+		},
+		{
+			name:    "missing client ip",
+			input:   conditions{sourceCidrs: []string{"10.0.0.0/8"}},
+			attrs:   &RequestAttributes{Time: monday},
+			wantErr: true,
+		},
+		{
+			name:  "matching filter",
+			input: conditions{filter: `"/resource/id" matches "ttcp_.*" and "/request/day" == "mon" and "/user/id" == "u_1234567890"`},
+			attrs: &RequestAttributes{Time: monday},
+			want:  true,
+		},
+		{
+			name:  "other filter",
+			input: conditions{filter: `"/request/client_ip" == "10.0.0.1"`},
+			attrs: &RequestAttributes{Time: monday, ClientIp: "10.0.0.2"},
+		},
+		{
+			name:  "all conditions",
+			input: conditions{days: []string{"mon"}, hours: []string{"10:00-11:00"}, sourceCidrs: []string{"10.0.0.0/8"}, filter: `"/request/action" == "read"`},
+			attrs: &RequestAttributes{Time: monday, ClientIp: "10.0.0.1"},
+			want:  true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			require.NoError(test.input.parseAndValidate())
+			got, err := test.input.evaluate(res, action.Read, "u_1234567890", test.attrs)
+			if test.wantErr {
+				require.Error(err)
+				return
+			}
+			require.NoError(err)
+			assert.Equal(test.want, got)
+		})
+	}
+}
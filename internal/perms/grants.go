@@ -15,6 +15,7 @@ import (
 	"github.com/hashicorp/boundary/internal/types/action"
 	"github.com/hashicorp/boundary/internal/types/resource"
 	"github.com/hashicorp/boundary/internal/types/scope"
+	"github.com/hashicorp/go-bexpr"
 )
 
 // GrantTuple is simply a struct that can be reference from other code to return
@@ -52,6 +53,18 @@ type Grant struct {
 	// The set of output fields granted
 	OutputFields *OutputFields
 
+	// Whether the grant denies rather than allows its actions
+	deny bool
+
+	// The conditions which must hold for the grant to apply, if any
+	conditions *conditions
+
+	// A filter which resources must match for the grant to apply, and the
+	// evaluator for it once any templates are substituted. The evaluator is
+	// nil if a template in the filter has no value.
+	resourceFilter     string
+	resourceFilterEval *bexpr.Evaluator
+
 	// This is used as a temporary staging area before validating permissions to
 	// allow the same validation code across grant string formats
 	actionsBeingParsed []string
@@ -65,6 +78,61 @@ func (g Grant) Type() resource.Type {
 	return g.typ
 }
 
+// Deny returns whether the grant denies rather than allows its actions. A
+// matching deny grant takes precedence over any allow grants.
+func (g Grant) Deny() bool {
+	return g.deny
+}
+
+// Days returns the days of the week the grant applies on, if restricted.
+func (g Grant) Days() []string {
+	if g.conditions == nil {
+		return nil
+	}
+	return g.conditions.days
+}
+
+// Hours returns the ranges of hours of the day the grant applies in, if
+// restricted.
+func (g Grant) Hours() []string {
+	if g.conditions == nil {
+		return nil
+	}
+	return g.conditions.hours
+}
+
+// TimeZone returns the time zone of the grant's days and hours, if set.
+func (g Grant) TimeZone() string {
+	if g.conditions == nil {
+		return ""
+	}
+	return g.conditions.timeZone
+}
+
+// SourceCidrs returns the CIDR ranges the API client's IP address must be
+// within for the grant to apply, if restricted.
+func (g Grant) SourceCidrs() []string {
+	if g.conditions == nil {
+		return nil
+	}
+	return g.conditions.sourceCidrs
+}
+
+// Filter returns the filter the request must match for the grant to apply, if
+// set.
+func (g Grant) Filter() string {
+	if g.conditions == nil {
+		return ""
+	}
+	return g.conditions.filter
+}
+
+// ResourceFilter returns the filter which resources must match for the grant
+// to apply, if any.
+func (g Grant) ResourceFilter() string {
+	return g.resourceFilter
+}
+
 func (g Grant) Actions() (typs []action.Type, strs []string) {
 	typs = make([]action.Type, 0, len(g.actions))
 	strs = make([]string, 0, len(g.actions))
@@ -98,7 +166,12 @@ func (g Grant) clone() *Grant {
 		scope: g.scope,
 		id:    g.id,
 		typ:   g.typ,
+		deny:  g.deny,
+
+		resourceFilter:     g.resourceFilter,
+		resourceFilterEval: g.resourceFilterEval,
 	}
+	ret.conditions = g.conditions.clone()
 	if g.actionsBeingParsed != nil {
 		ret.actionsBeingParsed = append(ret.actionsBeingParsed, g.actionsBeingParsed...)
 	}
@@ -139,6 +212,32 @@ func (g Grant) CanonicalString() string {
 		builder = append(builder, fmt.Sprintf("actions=%s", strings.Join(actions, ",")))
 	}
 
+	if g.deny {
+		builder = append(builder, "deny=true")
+	}
+
+	if c := g.conditions; c != nil {
+		if len(c.days) > 0 {
+			builder = append(builder, fmt.Sprintf("days=%s", strings.Join(c.days, ",")))
+		}
+		if len(c.hours) > 0 {
+			builder = append(builder, fmt.Sprintf("hours=%s", strings.Join(c.hours, ",")))
+		}
+		if c.timeZone != "" {
+			builder = append(builder, fmt.Sprintf("time_zone=%s", c.timeZone))
+		}
+		if len(c.sourceCidrs) > 0 {
+			builder = append(builder, fmt.Sprintf("source_cidrs=%s", strings.Join(c.sourceCidrs, ",")))
+		}
+		if c.filter != "" {
+			builder = append(builder, fmt.Sprintf("filter=%s", c.filter))
+		}
+	}
+
+	if g.resourceFilter != "" {
+		builder = append(builder, fmt.Sprintf("resource_filter=%s", g.resourceFilter))
+	}
+
 	if outFields, hasSetFields := g.OutputFields.Fields(); hasSetFields {
 		builder = append(builder, fmt.Sprintf("output_fields=%s", strings.Join(outFields, ",")))
 	}
@@ -164,6 +263,29 @@ func (g Grant) MarshalJSON() ([]byte, error) {
 		sort.Strings(actions)
 		res["actions"] = actions
 	}
+	if g.deny {
+		res["deny"] = true
+	}
+	if c := g.conditions; c != nil {
+		if len(c.days) > 0 {
+			res["days"] = c.days
+		}
+		if len(c.hours) > 0 {
+			res["hours"] = c.hours
+		}
+		if c.timeZone != "" {
+			res["time_zone"] = c.timeZone
+		}
+		if len(c.sourceCidrs) > 0 {
+			res["source_cidrs"] = c.sourceCidrs
+		}
+		if c.filter != "" {
+			res["filter"] = c.filter
+		}
+	}
+	if g.resourceFilter != "" {
+		res["resource_filter"] = g.resourceFilter
+	}
 	if outFields, hasSetFields := g.OutputFields.Fields(); hasSetFields {
 		res["output_fields"] = outFields
 	}
@@ -220,6 +342,63 @@ func (g *Grant) unmarshalJSON(data []byte) error {
 			}
 		}
 	}
+	if rawDeny, ok := raw["deny"]; ok {
+		deny, ok := rawDeny.(bool)
+		if !ok {
+			return errors.NewDeprecated(errors.InvalidParameter, op, fmt.Sprintf("unable to interpret %q as bool", "deny"))
+		}
+		g.deny = deny
+	}
+	for _, key := range []string{"days", "hours", "source_cidrs"} {
+		rawValues, ok := raw[key]
+		if !ok {
+			continue
+		}
+		interfaceValues, ok := rawValues.([]any)
+		if !ok {
+			return errors.NewDeprecated(errors.InvalidParameter, op, fmt.Sprintf("unable to interpret %q as array", key))
+		}
+		values := make([]string, 0, len(interfaceValues))
+		for _, v := range interfaceValues {
+			value, ok := v.(string)
+			switch {
+			case !ok:
+				return errors.NewDeprecated(errors.InvalidParameter, op, fmt.Sprintf("unable to interpret %v in %s array as string", v, key))
+			case value == "":
+				return errors.NewDeprecated(errors.InvalidParameter, op, fmt.Sprintf("empty value found in %s array", key))
+			}
+			values = append(values, value)
+		}
+		if err := g.setCondition(key, values); err != nil {
+			return errors.WrapDeprecated(err, op)
+		}
+	}
+	for _, key := range []string{"time_zone", "filter"} {
+		rawValue, ok := raw[key]
+		if !ok {
+			continue
+		}
+		value, ok := rawValue.(string)
+		switch {
+		case !ok:
+			return errors.NewDeprecated(errors.InvalidParameter, op, fmt.Sprintf("unable to interpret %q as string", key))
+		case value == "":
+			return errors.NewDeprecated(errors.InvalidParameter, op, fmt.Sprintf("empty %q found", key))
+		}
+		if err := g.setCondition(key, []string{value}); err != nil {
+			return errors.WrapDeprecated(err, op)
+		}
+	}
+	if rawResourceFilter, ok := raw["resource_filter"]; ok {
+		resourceFilter, ok := rawResourceFilter.(string)
+		switch {
+		case !ok:
+			return errors.NewDeprecated(errors.InvalidParameter, op, fmt.Sprintf("unable to interpret %q as string", "resource_filter"))
+		case resourceFilter == "":
+			return errors.NewDeprecated(errors.InvalidParameter, op, fmt.Sprintf("empty %q found", "resource_filter"))
+		}
+		g.resourceFilter = resourceFilter
+	}
 	if rawOutputFields, ok := raw["output_fields"]; ok {
 		interfaceOutputFields, ok := rawOutputFields.([]any)
 		if !ok {
@@ -253,6 +432,10 @@ func (g *Grant) unmarshalText(grantString string) error {
 	segments := strings.Split(grantString, ";")
 	for _, segment := range segments {
 		kv := strings.Split(segment, "=")
+		if strings.HasPrefix(segment, "filter=") || strings.HasPrefix(segment, "resource_filter=") {
+			// The value of a filter may itself contain equal signs
+			kv = strings.SplitN(segment, "=", 2)
+		}
 
 		// Ensure we don't accept "foo=bar=baz", "=foo", or "foo="
 		switch {
@@ -287,6 +470,35 @@ func (g *Grant) unmarshalText(grantString string) error {
 				}
 			}
 
+		case "deny":
+			switch strings.ToLower(kv[1]) {
+			case "true":
+				g.deny = true
+			case "false":
+				g.deny = false
+			default:
+				return errors.NewDeprecated(errors.InvalidParameter, op, fmt.Sprintf("unable to interpret %q as bool", "deny"))
+			}
+
+		case "days", "hours", "source_cidrs":
+			values := strings.Split(kv[1], ",")
+			for _, v := range values {
+				if v == "" {
+					return errors.NewDeprecated(errors.InvalidParameter, op, fmt.Sprintf("empty value found in %s", kv[0]))
+				}
+			}
+			if err := g.setCondition(kv[0], values); err != nil {
+				return errors.WrapDeprecated(err, op)
+			}
+
+		case "time_zone", "filter":
+			if err := g.setCondition(kv[0], []string{kv[1]}); err != nil {
+				return errors.WrapDeprecated(err, op)
+			}
+
+		case "resource_filter":
+			g.resourceFilter = kv[1]
+
 		case "output_fields":
 			switch len(kv[1]) {
 			case 0:
@@ -300,6 +512,33 @@ func (g *Grant) unmarshalText(grantString string) error {
 	return nil
 }
 
+// setCondition sets the values of a condition of the grant; they are validated
+// by Parse.
+func (g *Grant) setCondition(key string, values []string) error {
+	const op = "perms.(Grant).setCondition"
+	if len(values) == 0 {
+		return errors.NewDeprecated(errors.InvalidParameter, op, fmt.Sprintf("missing %s values", key))
+	}
+	if g.conditions == nil {
+		g.conditions = new(conditions)
+	}
+	switch key {
+	case "days":
+		g.conditions.days = values
+	case "hours":
+		g.conditions.hours = values
+	case "source_cidrs":
+		g.conditions.sourceCidrs = values
+	case "time_zone":
+		g.conditions.timeZone = values[0]
+	case "filter":
+		g.conditions.filter = values[0]
+	default:
+		return errors.NewDeprecated(errors.InvalidParameter, op, fmt.Sprintf("unknown condition %q", key))
+	}
+	return nil
+}
+
 // Parse parses a grant string. Note that this does not do checking
 // of the validity of IDs and such; that's left for other parts of the system.
 // We may not check at all (e.g. let it be an authz-time failure) or could check
@@ -345,22 +584,20 @@ func Parse(scopeId, grantString string, opt ...Option) (Grant, error) {
 
 	opts := getOpts(opt...)
 
-	// Check for templated values ID, and substitute in with the authenticated values
-	// if so
-	if grant.id != "" && strings.HasPrefix(grant.id, "{{") {
-		id := strings.TrimSuffix(strings.TrimPrefix(grant.id, "{{"), "}}")
-		id = strings.TrimSpace(id)
-		switch id {
-		case "user.id", ".User.Id":
-			if opts.withUserId != "" {
-				grant.id = strings.ToValidUTF8(opts.withUserId, string(unicode.ReplacementChar))
-			}
-		case "account.id", ".Account.Id":
-			if opts.withAccountId != "" {
-				grant.id = strings.ToValidUTF8(opts.withAccountId, string(unicode.ReplacementChar))
+	// Check for templated values in the ID, and substitute in with the
+	// authenticated values if so. If any of the values are missing the ID is
+	// left as-is. The substituted ID must be a single, well-formed resource ID
+	// as the values may come from the user or an IdP.
+	if grant.id != "" {
+		id, resolved, unknown := resolveTemplates(grant.id, opts, nil)
+		switch {
+		case unknown != "":
+			return Grant{}, errors.NewDeprecated(errors.InvalidParameter, op, fmt.Sprintf("unknown template %q in grant %q value", unknown, "id"))
+		case resolved && id != grant.id:
+			if err := validateTemplatedId(id); err != nil {
+				return Grant{}, errors.WrapDeprecated(err, op)
 			}
-		default:
-			return Grant{}, errors.NewDeprecated(errors.InvalidParameter, op, fmt.Sprintf("unknown template %q in grant %q value", grant.id, "id"))
+			grant.id = id
 		}
 	}
 
@@ -372,6 +609,20 @@ func Parse(scopeId, grantString string, opt ...Option) (Grant, error) {
 		return Grant{}, errors.WrapDeprecated(err, op)
 	}
 
+	if err := grant.validateDeny(); err != nil {
+		return Grant{}, errors.WrapDeprecated(err, op)
+	}
+
+	if grant.conditions != nil {
+		if err := grant.conditions.parseAndValidate(); err != nil {
+			return Grant{}, errors.WrapDeprecated(err, op)
+		}
+	}
+
+	if err := grant.parseAndValidateResourceFilter(opts); err != nil {
+		return Grant{}, errors.WrapDeprecated(err, op)
+	}
+
 	if !opts.withSkipFinalValidation {
 		// Filter out some forms that don't make sense
 
@@ -423,8 +674,16 @@ func Parse(scopeId, grantString string, opt ...Option) (Grant, error) {
 		// This might be zero if output fields is populated
 		if len(grant.actions) > 0 {
 			// Create a dummy resource and pass it through Allowed and ensure that
-			// we get allowed.
-			acl := NewACL(grant)
+			// we get allowed. A deny grant is checked as if it were an allow
+			// grant, to ensure that it would match something. Conditions and
+			// resource filters are dropped since there is no request or
+			// resource to evaluate them against.
+			check := grant.clone()
+			check.deny = false
+			check.conditions = nil
+			check.resourceFilter = ""
+			check.resourceFilterEval = nil
+			acl := NewACL(*check)
 			r := Resource{
 				ScopeId: scopeId,
 				Id:      grant.id,
@@ -463,6 +722,54 @@ func (g Grant) validateType() error {
 	return nil
 }
 
+// validateDeny ensures that a deny grant names the actions it denies. Output
+// fields cannot be denied, so they are not allowed on deny grants.
+func (g Grant) validateDeny() error {
+	const op = "perms.(Grant).validateDeny"
+	if !g.deny {
+		return nil
+	}
+	if len(g.actions) == 0 {
+		return errors.NewDeprecated(errors.InvalidParameter, op, "deny grants must specify actions")
+	}
+	if _, hasSetFields := g.OutputFields.Fields(); hasSetFields {
+		return errors.NewDeprecated(errors.InvalidParameter, op, "deny grants cannot specify output fields")
+	}
+	return nil
+}
+
+// parseAndValidateResourceFilter validates the resource filter of the grant,
+// if any, and creates its evaluator once the templates in it are substituted.
+// If a template has no value, the filter is validated as though it were
+// empty and no evaluator is created.
+func (g *Grant) parseAndValidateResourceFilter(opts options) error {
+	const op = "perms.(Grant).parseAndValidateResourceFilter"
+	if g.resourceFilter == "" {
+		return nil
+	}
+	switch {
+	case g.id == "":
+		return errors.NewDeprecated(errors.InvalidParameter, op, "resource filters require an id")
+	case g.typ != resource.Target && g.typ != resource.Worker:
+		return errors.NewDeprecated(errors.InvalidParameter, op, "resource filters are only supported for target and worker types")
+	case strings.Contains(g.resourceFilter, ";"):
+		// The filter must not end the segment early in the canonical form
+		return errors.NewDeprecated(errors.InvalidParameter, op, "resource filter cannot contain semicolons")
+	}
+	filter, resolved, unknown := resolveTemplates(g.resourceFilter, opts, escapeFilterString)
+	if unknown != "" {
+		return errors.NewDeprecated(errors.InvalidParameter, op, fmt.Sprintf("unknown template %q in grant %q value", unknown, "resource_filter"))
+	}
+	eval, err := bexpr.CreateEvaluator(filter, bexpr.WithTagName("json"))
+	if err != nil {
+		return errors.NewDeprecated(errors.InvalidParameter, op, fmt.Sprintf("invalid resource filter %q: %s", g.resourceFilter, err.Error()))
+	}
+	if resolved {
+		g.resourceFilterEval = eval
+	}
+	return nil
+}
+
 func (g *Grant) parseAndValidateActions() error {
 	const op = "perms.(Grant).parseAndValidateActions"
 	if len(g.actionsBeingParsed) == 0 {
@@ -11,6 +11,8 @@ import (
 	"github.com/hashicorp/boundary/internal/types/action"
 	"github.com/hashicorp/boundary/internal/types/resource"
 	"github.com/hashicorp/boundary/internal/types/scope"
+	"github.com/hashicorp/boundary/internal/util"
+	"github.com/hashicorp/boundary/internal/util/template"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -115,7 +117,7 @@ func Test_ActionParsingValidation(t *testing.T) {
 func Test_ValidateType(t *testing.T) {
 	t.Parallel()
 	var g Grant
-	for i := resource.Unknown; i <= resource.Credential; i++ {
+	for i := resource.Unknown; i <= resource.ApiKey; i++ {
 		g.typ = i
 		if i == resource.Controller {
 			assert.Error(t, g.validateType())
@@ -201,6 +203,23 @@ func Test_MarshalingAndCloning(t *testing.T) {
 			jsonOutput:      `{"actions":["create","read"],"id":"baz","output_fields":["id","name","version"],"type":"group"}`,
 			canonicalString: `id=baz;type=group;actions=create,read;output_fields=id,name,version`,
 		},
+		{
+			name: "deny",
+			input: Grant{
+				id: "baz",
+				scope: Scope{
+					Type: scope.Project,
+				},
+				typ: resource.Group,
+				actions: map[action.Type]bool{
+					action.Delete: true,
+					action.Update: true,
+				},
+				deny: true,
+			},
+			jsonOutput:      `{"actions":["delete","update"],"deny":true,"id":"baz","type":"group"}`,
+			canonicalString: `id=baz;type=group;actions=delete,update;deny=true`,
+		},
 	}
 
 	for _, test := range tests {
@@ -319,6 +338,27 @@ func Test_Unmarshaling(t *testing.T) {
 			textInput: `actions=,`,
 			textErr:   `perms.(Grant).unmarshalText: empty action found: parameter violation: error #100`,
 		},
+		{
+			name: "good deny",
+			expected: Grant{
+				deny: true,
+			},
+			jsonInput: `{"deny":true}`,
+			textInput: `deny=TRUE`,
+		},
+		{
+			name:      "false deny",
+			expected:  Grant{},
+			jsonInput: `{"deny":false}`,
+			textInput: `deny=false`,
+		},
+		{
+			name:      "bad deny",
+			jsonInput: `{"deny":"yes"}`,
+			jsonErr:   `perms.(Grant).unmarshalJSON: unable to interpret "deny" as bool: parameter violation: error #100`,
+			textInput: `deny=yes`,
+			textErr:   `perms.(Grant).unmarshalText: unable to interpret "deny" as bool: parameter violation: error #100`,
+		},
 		{
 			name:      "bad json action",
 			jsonInput: `{"actions":[1, true]}`,
@@ -657,6 +697,52 @@ func Test_Parse(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:  "good text deny",
+			input: `id=*;type=target;actions=authorize-session;deny=true`,
+			expected: Grant{
+				scope: Scope{
+					Id:   "o_scope",
+					Type: scope.Org,
+				},
+				id:  "*",
+				typ: resource.Target,
+				actions: map[action.Type]bool{
+					action.AuthorizeSession: true,
+				},
+				deny: true,
+			},
+		},
+		{
+			name:  "good json deny",
+			input: `{"id":"foobar","actions":["*"],"deny":true}`,
+			expected: Grant{
+				scope: Scope{
+					Id:   "o_scope",
+					Type: scope.Org,
+				},
+				id: "foobar",
+				actions: map[action.Type]bool{
+					action.All: true,
+				},
+				deny: true,
+			},
+		},
+		{
+			name:  "deny with output fields",
+			input: `id=foobar;actions=read;output_fields=id;deny=true`,
+			err:   `perms.Parse: perms.(Grant).validateDeny: deny grants cannot specify output fields: parameter violation: error #100`,
+		},
+		{
+			name:  "deny without actions",
+			input: `id=foobar;output_fields=id;deny=true`,
+			err:   `perms.Parse: perms.(Grant).validateDeny: deny grants must specify actions: parameter violation: error #100`,
+		},
+		{
+			name:  "deny that would not match",
+			input: `id=*;actions=read;deny=true`,
+			err:   `perms.Parse: parsed grant string would not result in any action being authorized: parameter violation: error #100`,
+		},
 		{
 			name:   "bad user id template",
 			input:  `id={{superman}};actions=create,read`,
@@ -753,6 +839,252 @@ func Test_Parse(t *testing.T) {
 	}
 }
 
+func Test_ParseTemplatedId(t *testing.T) {
+	t.Parallel()
+
+	data := func(email string, claims map[string]any) *template.Data {
+		return &template.Data{Account: template.Account{Email: util.Pointer(email), Claims: claims}}
+	}
+
+	tests := []struct {
+		name     string
+		input    string
+		data     *template.Data
+		err      string
+		expected string
+	}{
+		{
+			name:     "valid email id",
+			input:    `id={{account.email}};actions=read`,
+			data:     data("ttcp_1234567890", nil),
+			expected: "ttcp_1234567890",
+		},
+		{
+			name:     "valid partial claim id",
+			input:    `id=ttcp_{{account.claims.team}};actions=read`,
+			data:     data("", map[string]any{"team": "1234567890"}),
+			expected: "ttcp_1234567890",
+		},
+		{
+			name:  "wildcard email",
+			input: `id={{account.email}};actions=read`,
+			data:  data("*", nil),
+			err:   `perms.Parse: perms.validateTemplatedId: templated id resolved to a wildcard: parameter violation: error #100`,
+		},
+		{
+			name:  "wildcard claim",
+			input: `id={{account.claims.team}};type=*;actions=*`,
+			data:  data("", map[string]any{"team": "*"}),
+			err:   `perms.Parse: perms.validateTemplatedId: templated id resolved to a wildcard: parameter violation: error #100`,
+		},
+		{
+			name:  "email with reserved characters",
+			input: `id={{account.email}};actions=read`,
+			data:  data("x;type=*;actions=*", nil),
+			err:   `perms.Parse: perms.validateTemplatedId: templated id "x;type=*;actions=*" contains reserved characters: parameter violation: error #100`,
+		},
+		{
+			name:  "claim with template",
+			input: `id={{account.claims.team}};actions=read`,
+			data:  data("", map[string]any{"team": "{{user.id}}"}),
+			err:   `perms.Parse: perms.validateTemplatedId: templated id "{{user.id}}" contains reserved characters: parameter violation: error #100`,
+		},
+		{
+			name:  "empty claim resolved from parts",
+			input: `id={{account.claims.a}}{{account.claims.b}};actions=read`,
+			data:  data("", map[string]any{"a": "", "b": ""}),
+			// Missing values leave the id as-is, which matches no resource
+			expected: "{{account.claims.a}}{{account.claims.b}}",
+		},
+		{
+			name:  "malformed email id",
+			input: `id={{account.email}};actions=read`,
+			data:  data("jim@example.com", nil),
+			err:   `perms.Parse: perms.validateTemplatedId: templated id "jim@example.com" is not a valid resource id: parameter violation: error #100`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			grant, err := Parse("o_scope", tt.input, WithTemplateData(tt.data))
+			if tt.err != "" {
+				require.Error(err)
+				assert.Equal(tt.err, err.Error())
+				return
+			}
+			require.NoError(err)
+			assert.Equal(tt.expected, grant.Id())
+		})
+	}
+}
+
+func Test_ParseConditions(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		input     string
+		err       string
+		canonical string
+		json      string
+	}{
+		{
+			name:      "text",
+			input:     "id=*;type=target;actions=authorize-session;days=fri,Monday;hours=09:00-17:00;time_zone=Europe/Berlin;source_cidrs=10.1.0.0/8",
+			canonical: "id=*;type=target;actions=authorize-session;days=mon,fri;hours=09:00-17:00;time_zone=Europe/Berlin;source_cidrs=10.0.0.0/8",
+			json:      `{"actions":["authorize-session"],"days":["mon","fri"],"hours":["09:00-17:00"],"id":"*","source_cidrs":["10.0.0.0/8"],"time_zone":"Europe/Berlin","type":"target"}`,
+		},
+		{
+			name:      "json",
+			input:     `{"id":"*","type":"target","actions":["read"],"filter":"\"/resource/id\" == \"ttcp_1234567890\"","deny":true}`,
+			canonical: `id=*;type=target;actions=read;deny=true;filter="/resource/id" == "ttcp_1234567890"`,
+			json:      `{"actions":["read"],"deny":true,"filter":"\"/resource/id\" == \"ttcp_1234567890\"","id":"*","type":"target"}`,
+		},
+		{
+			name:      "text filter",
+			input:     `id=*;type=target;actions=read;filter="/resource/id" matches "ttcp_.*" and "/user/id" == "u_1234567890"`,
+			canonical: `id=*;type=target;actions=read;filter="/resource/id" matches "ttcp_.*" and "/user/id" == "u_1234567890"`,
+			json:      `{"actions":["read"],"filter":"\"/resource/id\" matches \"ttcp_.*\" and \"/user/id\" == \"u_1234567890\"","id":"*","type":"target"}`,
+		},
+		{
+			name:  "filter with semicolon",
+			input: `{"id":"*","type":"target","actions":["read"],"filter":"\"/resource/id\" == \";\""}`,
+			err:   `perms.Parse: perms.(conditions).parseAndValidate: filter cannot contain semicolons: parameter violation: error #100`,
+		},
+		{
+			name:  "empty text condition",
+			input: "id=*;type=target;actions=read;days=mon,",
+			err:   `perms.Parse: unable to parse grant string: perms.(Grant).unmarshalText: empty value found in days: parameter violation: error #100`,
+		},
+		{
+			name:  "bad json condition",
+			input: `{"id":"*","type":"target","actions":["read"],"hours":"09:00-17:00"}`,
+			err:   `perms.Parse: unable to parse JSON grant string: perms.(Grant).unmarshalJSON: unable to interpret "hours" as array: parameter violation: error #100`,
+		},
+		{
+			name:  "empty json condition",
+			input: `{"id":"*","type":"target","actions":["read"],"time_zone":""}`,
+			err:   `perms.Parse: unable to parse JSON grant string: perms.(Grant).unmarshalJSON: empty "time_zone" found: parameter violation: error #100`,
+		},
+		{
+			name:  "invalid condition",
+			input: "id=*;type=target;actions=read;days=someday",
+			err:   `perms.Parse: perms.(conditions).parseAndValidate: unknown day "someday": parameter violation: error #100`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			grant, err := Parse("p_1234567890", test.input)
+			if test.err != "" {
+				require.Error(err)
+				assert.Equal(test.err, err.Error())
+				return
+			}
+			require.NoError(err)
+			assert.Equal(test.canonical, grant.CanonicalString())
+			out, err := grant.MarshalJSON()
+			require.NoError(err)
+			assert.Equal(test.json, string(out))
+
+			// The canonical and JSON forms parse to the same grant
+			for _, in := range []string{grant.CanonicalString(), string(out)} {
+				reparsed, err := Parse("p_1234567890", in)
+				require.NoError(err)
+				assert.Equal(test.canonical, reparsed.CanonicalString())
+			}
+		})
+	}
+}
+
+func Test_ParseResourceFilter(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		input     string
+		opt       []Option
+		err       string
+		canonical string
+		json      string
+		evaluable bool
+	}{
+		{
+			name:      "text",
+			input:     `id=*;type=target;actions=read,authorize-session;resource_filter="/resource/name" matches "{{ account.claims.team }}-.*"`,
+			opt:       []Option{WithTemplateData(&template.Data{Account: template.Account{Claims: map[string]any{"team": "blue"}}})},
+			canonical: `id=*;type=target;actions=authorize-session,read;resource_filter="/resource/name" matches "{{ account.claims.team }}-.*"`,
+			json:      `{"actions":["authorize-session","read"],"id":"*","resource_filter":"\"/resource/name\" matches \"{{ account.claims.team }}-.*\"","type":"target"}`,
+			evaluable: true,
+		},
+		{
+			name:      "json",
+			input:     `{"id":"*","type":"worker","actions":["read"],"resource_filter":"\"{{account.email}}\" in \"/resource/tags/owner\""}`,
+			opt:       []Option{WithTemplateData(&template.Data{Account: template.Account{Email: util.Pointer("jim@example.com")}})},
+			canonical: `id=*;type=worker;actions=read;resource_filter="{{account.email}}" in "/resource/tags/owner"`,
+			json:      `{"actions":["read"],"id":"*","resource_filter":"\"{{account.email}}\" in \"/resource/tags/owner\"","type":"worker"}`,
+			evaluable: true,
+		},
+		{
+			name:      "template without value",
+			input:     `id=*;type=target;actions=read;resource_filter="/resource/name" == "{{account.claims.team}}"`,
+			canonical: `id=*;type=target;actions=read;resource_filter="/resource/name" == "{{account.claims.team}}"`,
+			json:      `{"actions":["read"],"id":"*","resource_filter":"\"/resource/name\" == \"{{account.claims.team}}\"","type":"target"}`,
+		},
+		{
+			name:  "unknown template",
+			input: `id=*;type=target;actions=read;resource_filter="/resource/name" == "{{account.team}}"`,
+			err:   `perms.Parse: perms.(Grant).parseAndValidateResourceFilter: unknown template "{{account.team}}" in grant "resource_filter" value: parameter violation: error #100`,
+		},
+		{
+			name:  "no id",
+			input: `type=target;actions=list;resource_filter="/resource/name" == "foo"`,
+			err:   `perms.Parse: perms.(Grant).parseAndValidateResourceFilter: resource filters require an id: parameter violation: error #100`,
+		},
+		{
+			name:  "unsupported type",
+			input: `id=*;type=host-catalog;actions=read;resource_filter="/resource/name" == "foo"`,
+			err:   `perms.Parse: perms.(Grant).parseAndValidateResourceFilter: resource filters are only supported for target and worker types: parameter violation: error #100`,
+		},
+		{
+			name:  "semicolon",
+			input: `{"id":"*","type":"target","actions":["read"],"resource_filter":"\"/resource/name\" == \";\""}`,
+			err:   `perms.Parse: perms.(Grant).parseAndValidateResourceFilter: resource filter cannot contain semicolons: parameter violation: error #100`,
+		},
+		{
+			name:  "empty json",
+			input: `{"id":"*","type":"target","actions":["read"],"resource_filter":""}`,
+			err:   `perms.Parse: unable to parse JSON grant string: perms.(Grant).unmarshalJSON: empty "resource_filter" found: parameter violation: error #100`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			grant, err := Parse("p_1234567890", test.input, test.opt...)
+			if test.err != "" {
+				require.Error(err)
+				assert.Equal(test.err, err.Error())
+				return
+			}
+			require.NoError(err)
+			assert.Equal(test.canonical, grant.CanonicalString())
+			assert.Equal(test.evaluable, grant.resourceFilterEval != nil)
+			out, err := grant.MarshalJSON()
+			require.NoError(err)
+			assert.Equal(test.json, string(out))
+
+			// The canonical and JSON forms parse to the same grant
+			for _, in := range []string{grant.CanonicalString(), string(out)} {
+				reparsed, err := Parse("p_1234567890", in, test.opt...)
+				require.NoError(err)
+				assert.Equal(test.canonical, reparsed.CanonicalString())
+			}
+		})
+	}
+}
+
 func TestHasActionOrSubaction(t *testing.T) {
 	tests := []struct {
 		name string
@@ -3,6 +3,8 @@
 
 package perms
 
+import "github.com/hashicorp/boundary/internal/util/template"
+
 // getOpts - iterate the inbound Options and return a struct
 func getOpts(opt ...Option) options {
 	opts := getDefaultOptions()
@@ -23,6 +25,8 @@ type options struct {
 	withAccountId                     string
 	withSkipFinalValidation           bool
 	withSkipAnonymousUserRestrictions bool
+	withRequestAttributes             *RequestAttributes
+	withTemplateData                  *template.Data
 }
 
 func getDefaultOptions() options {
@@ -44,6 +48,15 @@ func WithAccountId(accountId string) Option {
 	}
 }
 
+// WithTemplateData provides the user and account data to be used for any
+// templating in grant strings. IDs given with WithUserId and WithAccountId
+// take precedence over the IDs in the data.
+func WithTemplateData(data *template.Data) Option {
+	return func(o *options) {
+		o.withTemplateData = data
+	}
+}
+
 // WithSkipFinalValidation allows skipping the validity step where we ensure we
 // can run a resource described by the grant successfully through the ACL check
 func WithSkipFinalValidation(skipFinalValidation bool) Option {
@@ -60,3 +73,12 @@ func WithSkipAnonymousUserRestrictions(with bool) Option {
 		o.withSkipAnonymousUserRestrictions = with
 	}
 }
+
+// WithRequestAttributes provides the attributes of the request that the
+// conditions of grants are evaluated against. Without them, grants with
+// conditions which allow actions do not apply and those which deny actions do.
+func WithRequestAttributes(attrs *RequestAttributes) Option {
+	return func(o *options) {
+		o.withRequestAttributes = attrs
+	}
+}
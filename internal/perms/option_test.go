@@ -6,6 +6,8 @@ package perms
 import (
 	"testing"
 
+	"github.com/hashicorp/boundary/internal/util"
+	"github.com/hashicorp/boundary/internal/util/template"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -43,4 +45,20 @@ func Test_GetOpts(t *testing.T) {
 		opts = getOpts(WithSkipAnonymousUserRestrictions(true))
 		assert.True(opts.withSkipAnonymousUserRestrictions)
 	})
+	t.Run("with-request-attributes", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts()
+		assert.Nil(opts.withRequestAttributes)
+		attrs := &RequestAttributes{ClientIp: "127.0.0.1"}
+		opts = getOpts(WithRequestAttributes(attrs))
+		assert.Equal(attrs, opts.withRequestAttributes)
+	})
+	t.Run("with-template-data", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts()
+		assert.Nil(opts.withTemplateData)
+		data := &template.Data{Account: template.Account{Email: util.Pointer("jim@example.com")}}
+		opts = getOpts(WithTemplateData(data))
+		assert.Equal(data, opts.withTemplateData)
+	})
 }
@@ -0,0 +1,173 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package perms
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/types/scope"
+	"github.com/hashicorp/boundary/internal/util/template"
+)
+
+// templateRegex matches a template, such as {{account.email}}, within a grant
+// value. The name of the template is the first submatch.
+var templateRegex = regexp.MustCompile(`{{\s*([^{}\s]*)\s*}}`)
+
+// claimsTemplatePrefix is the prefix of templates referring to the claims of
+// an account, e.g. {{account.claims.team}}. Nested claims are separated by
+// dots.
+const claimsTemplatePrefix = "account.claims."
+
+// templateFields maps the names of the templates, other than those for
+// claims, to the values they refer to. The names used by credential
+// templates, e.g. {{.Account.Email}}, are accepted as well.
+var templateFields = map[string]func(template.Data) *string{
+	"user.id":            func(d template.Data) *string { return d.User.Id },
+	".User.Id":           func(d template.Data) *string { return d.User.Id },
+	"user.name":          func(d template.Data) *string { return d.User.Name },
+	".User.Name":         func(d template.Data) *string { return d.User.Name },
+	"user.full_name":     func(d template.Data) *string { return d.User.FullName },
+	".User.FullName":     func(d template.Data) *string { return d.User.FullName },
+	"user.email":         func(d template.Data) *string { return d.User.Email },
+	".User.Email":        func(d template.Data) *string { return d.User.Email },
+	"account.id":         func(d template.Data) *string { return d.Account.Id },
+	".Account.Id":        func(d template.Data) *string { return d.Account.Id },
+	"account.name":       func(d template.Data) *string { return d.Account.Name },
+	".Account.Name":      func(d template.Data) *string { return d.Account.Name },
+	"account.login_name": func(d template.Data) *string { return d.Account.LoginName },
+	".Account.LoginName": func(d template.Data) *string { return d.Account.LoginName },
+	"account.subject":    func(d template.Data) *string { return d.Account.Subject },
+	".Account.Subject":   func(d template.Data) *string { return d.Account.Subject },
+	"account.email":      func(d template.Data) *string { return d.Account.Email },
+	".Account.Email":     func(d template.Data) *string { return d.Account.Email },
+}
+
+// templateValue returns the value of the named template and whether it has
+// one. The last return value is false if the template is unknown.
+func templateValue(name string, opts options) (string, bool, bool) {
+	var data template.Data
+	if opts.withTemplateData != nil {
+		data = *opts.withTemplateData
+	}
+	// The IDs given directly take precedence over the template data
+	if opts.withUserId != "" {
+		data.User.Id = &opts.withUserId
+	}
+	if opts.withAccountId != "" {
+		data.Account.Id = &opts.withAccountId
+	}
+
+	if path, ok := strings.CutPrefix(name, claimsTemplatePrefix); ok {
+		if path == "" {
+			return "", false, false
+		}
+		value, ok := claimValue(data.Account.Claims, strings.Split(path, "."))
+		return value, ok, true
+	}
+
+	field, ok := templateFields[name]
+	if !ok {
+		return "", false, false
+	}
+	value := field(data)
+	if value == nil || *value == "" {
+		return "", false, true
+	}
+	return *value, true, true
+}
+
+// claimValue returns the string form of the claim at the given path. Only
+// claims with string, number or boolean values can be used.
+func claimValue(claims map[string]any, path []string) (string, bool) {
+	var value any = claims
+	for _, name := range path {
+		m, ok := value.(map[string]any)
+		if !ok {
+			return "", false
+		}
+		if value, ok = m[name]; !ok {
+			return "", false
+		}
+	}
+	switch v := value.(type) {
+	case string:
+		return v, v != ""
+	case bool:
+		return strconv.FormatBool(v), true
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), true
+	case json.Number:
+		return v.String(), true
+	default:
+		return "", false
+	}
+}
+
+// resolveTemplates substitutes the templates in s with their values, passing
+// each value through escape if it is set. Templates without a value are
+// substituted with an empty string. It reports whether every template had a
+// value; if a template is unknown it is returned and s is not substituted.
+func resolveTemplates(s string, opts options, escape func(string) string) (result string, resolved bool, unknown string) {
+	matches := templateRegex.FindAllStringSubmatchIndex(s, -1)
+	if len(matches) == 0 {
+		return s, true, ""
+	}
+	var builder strings.Builder
+	resolved = true
+	var last int
+	for _, m := range matches {
+		builder.WriteString(s[last:m[0]])
+		last = m[1]
+		value, ok, known := templateValue(s[m[2]:m[3]], opts)
+		if !known {
+			return "", false, s[m[0]:m[1]]
+		}
+		if !ok {
+			resolved = false
+			continue
+		}
+		value = strings.ToValidUTF8(value, string(unicode.ReplacementChar))
+		if escape != nil {
+			value = escape(value)
+		}
+		builder.WriteString(value)
+	}
+	builder.WriteString(s[last:])
+	return builder.String(), resolved, ""
+}
+
+// templatedIdRegex matches the resource IDs that a templated grant ID may
+// resolve to: a type prefix and a public ID suffix, e.g. u_1234567890.
+var templatedIdRegex = regexp.MustCompile(`^[a-z]+_[A-Za-z0-9]+$`)
+
+// validateTemplatedId checks the ID resulting from substituting the templates
+// in a grant ID. Template values may be controlled by a user or an IdP, so the
+// result must not be able to widen the grant, e.g. by resolving to a wildcard.
+func validateTemplatedId(id string) error {
+	const op = "perms.validateTemplatedId"
+	switch {
+	case id == "":
+		return errors.NewDeprecated(errors.InvalidParameter, op, "templated id resolved to an empty value")
+	case id == "*":
+		return errors.NewDeprecated(errors.InvalidParameter, op, "templated id resolved to a wildcard")
+	case strings.ContainsAny(id, "{};=,"):
+		return errors.NewDeprecated(errors.InvalidParameter, op, fmt.Sprintf("templated id %q contains reserved characters", id))
+	case id != scope.Global.String() && !templatedIdRegex.MatchString(id):
+		return errors.NewDeprecated(errors.InvalidParameter, op, fmt.Sprintf("templated id %q is not a valid resource id", id))
+	}
+	return nil
+}
+
+// escapeFilterString escapes a value for use within a double-quoted string in
+// a filter.
+func escapeFilterString(s string) string {
+	quoted := strconv.Quote(s)
+	return quoted[1 : len(quoted)-1]
+}
@@ -0,0 +1,141 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package perms
+
+import (
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/util"
+	"github.com/hashicorp/boundary/internal/util/template"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ResolveTemplates(t *testing.T) {
+	t.Parallel()
+
+	data := &template.Data{
+		User: template.User{
+			Id:       util.Pointer("u_1234567890"),
+			Name:     util.Pointer("jim"),
+			FullName: util.Pointer("Jim Lambert"),
+			Email:    util.Pointer(""),
+		},
+		Account: template.Account{
+			Id:        util.Pointer("acctoidc_1234567890"),
+			LoginName: util.Pointer("jimlambert"),
+			Email:     util.Pointer("jim@example.com"),
+			Claims: map[string]any{
+				"team":   "blue",
+				"level":  float64(3),
+				"admin":  false,
+				"groups": []any{"dev", "ops"},
+				"org":    map[string]any{"unit": `r&d "east"`},
+			},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		input    string
+		opts     options
+		escape   func(string) string
+		want     string
+		resolved bool
+		unknown  string
+	}{
+		{
+			name:     "no templates",
+			input:    "ttcp_1234567890",
+			opts:     options{withTemplateData: data},
+			want:     "ttcp_1234567890",
+			resolved: true,
+		},
+		{
+			name:     "user fields",
+			input:    "{{user.id}}/{{ user.name }}/{{user.full_name}}",
+			opts:     options{withTemplateData: data},
+			want:     "u_1234567890/jim/Jim Lambert",
+			resolved: true,
+		},
+		{
+			name:     "account fields",
+			input:    "{{account.id}}/{{account.login_name}}/{{.Account.Email}}",
+			opts:     options{withTemplateData: data},
+			want:     "acctoidc_1234567890/jimlambert/jim@example.com",
+			resolved: true,
+		},
+		{
+			name:     "ids take precedence",
+			input:    "{{.User.Id}}/{{account.id}}",
+			opts:     options{withTemplateData: data, withUserId: "u_other", withAccountId: "acctpw_other"},
+			want:     "u_other/acctpw_other",
+			resolved: true,
+		},
+		{
+			name:     "ids without data",
+			input:    "{{user.id}}",
+			opts:     options{withUserId: "u_1234567890"},
+			want:     "u_1234567890",
+			resolved: true,
+		},
+		{
+			name:     "claims",
+			input:    "{{account.claims.team}}-{{account.claims.level}}-{{account.claims.admin}}-{{account.claims.org.unit}}",
+			opts:     options{withTemplateData: data},
+			want:     `blue-3-false-r&d "east"`,
+			resolved: true,
+		},
+		{
+			name:     "escaped claims",
+			input:    `"/resource/name" == "{{account.claims.org.unit}}"`,
+			opts:     options{withTemplateData: data},
+			escape:   escapeFilterString,
+			want:     `"/resource/name" == "r&d \"east\""`,
+			resolved: true,
+		},
+		{
+			name:  "empty value",
+			input: "{{user.email}}",
+			opts:  options{withTemplateData: data},
+		},
+		{
+			name:  "list claim",
+			input: "{{account.claims.groups}}",
+			opts:  options{withTemplateData: data},
+		},
+		{
+			name:  "missing claim",
+			input: "prefix-{{account.claims.org.region}}",
+			opts:  options{withTemplateData: data},
+			want:  "prefix-",
+		},
+		{
+			name:  "no data",
+			input: "{{account.email}}",
+		},
+		{
+			name:    "unknown template",
+			input:   "{{account.email}}-{{account.team}}",
+			opts:    options{withTemplateData: data},
+			unknown: "{{account.team}}",
+		},
+		{
+			name:    "missing claim name",
+			input:   "{{account.claims.}}",
+			unknown: "{{account.claims.}}",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert := assert.New(t)
+			got, resolved, unknown := resolveTemplates(test.input, test.opts, test.escape)
+			assert.Equal(test.unknown, unknown)
+			assert.Equal(test.resolved, resolved)
+			if test.unknown == "" {
+				assert.Equal(test.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package recording
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/hashicorp/boundary/internal/errors"
+)
+
+// maxLineSize is the largest line of a recording a Reader will read.
+const maxLineSize = 16 * 1024 * 1024
+
+// Reader reads the events of a recording.
+type Reader struct {
+	s      *bufio.Scanner
+	header Header
+}
+
+// NewReader reads the header of the recording read from r and returns a
+// Reader for the events of the recording.
+func NewReader(ctx context.Context, r io.Reader) (*Reader, error) {
+	const op = "recording.NewReader"
+	if r == nil {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing reader")
+	}
+	s := bufio.NewScanner(r)
+	s.Buffer(nil, maxLineSize)
+	if !s.Scan() {
+		if err := s.Err(); err != nil {
+			return nil, errors.Wrap(ctx, err, op, errors.WithCode(errors.Io))
+		}
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing header")
+	}
+	var h Header
+	if err := json.Unmarshal(s.Bytes(), &h); err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithCode(errors.InvalidParameter), errors.WithMsg("invalid header"))
+	}
+	if h.Version != Version {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("unsupported recording version %d", h.Version))
+	}
+	return &Reader{s: s, header: h}, nil
+}
+
+// Header returns the header of the recording.
+func (r *Reader) Header() Header {
+	return r.header
+}
+
+// Next returns the next event of the recording. io.EOF is returned when there
+// are no more events.
+func (r *Reader) Next() (*Event, error) {
+	if !r.s.Scan() {
+		if err := r.s.Err(); err != nil {
+			return nil, fmt.Errorf("recording: reading event: %w", err)
+		}
+		return nil, io.EOF
+	}
+	var e Event
+	if err := json.Unmarshal(r.s.Bytes(), &e); err != nil {
+		return nil, fmt.Errorf("recording: invalid event: %w", err)
+	}
+	return &e, nil
+}
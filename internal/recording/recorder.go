@@ -0,0 +1,168 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package recording
+
+import (
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/errors"
+)
+
+// ErrRecorderClosed is returned when recording an event with a closed Recorder.
+var ErrRecorderClosed = stderrors.New("recording: recorder is closed")
+
+// Recorder writes a recording. It is safe for concurrent use.
+type Recorder struct {
+	l           sync.Mutex
+	w           io.WriteCloser
+	enc         *json.Encoder
+	start       time.Time
+	lastChannel uint32
+	closed      bool
+}
+
+// NewRecorder writes the header of a recording to w and returns a Recorder
+// which writes the events of the recording to w. The Version and StartTime of
+// the header are set by NewRecorder. w is closed when the Recorder is closed.
+func NewRecorder(ctx context.Context, w io.WriteCloser, h Header) (*Recorder, error) {
+	const op = "recording.NewRecorder"
+	if w == nil {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing writer")
+	}
+	if h.SessionId == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing session id")
+	}
+	if h.ConnectionId == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing connection id")
+	}
+	h.Version = Version
+	h.StartTime = time.Now().UTC()
+	r := &Recorder{
+		w:     w,
+		enc:   json.NewEncoder(w),
+		start: h.StartTime,
+	}
+	if err := r.enc.Encode(&h); err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("writing header"))
+	}
+	return r, nil
+}
+
+// Record sets the time of e and writes it to the recording.
+func (r *Recorder) Record(e *Event) error {
+	r.l.Lock()
+	defer r.l.Unlock()
+	if r.closed {
+		return ErrRecorderClosed
+	}
+	e.Time = time.Since(r.start).Seconds()
+	if err := r.enc.Encode(e); err != nil {
+		return fmt.Errorf("recording: writing %s event: %w", e.Type, err)
+	}
+	return nil
+}
+
+// RecordData records data sent in the direction dir over the channel.
+func (r *Recorder) RecordData(channel uint32, dir Direction, stream string, data []byte) error {
+	return r.Record(&Event{
+		Type:      DataEvent,
+		Channel:   channel,
+		Direction: dir,
+		Stream:    stream,
+		Data:      data,
+	})
+}
+
+// RecordChannelOpen records the opening of a channel of the provided type and
+// returns the number of the channel.
+func (r *Recorder) RecordChannelOpen(channelType string, extraData []byte) (uint32, error) {
+	r.l.Lock()
+	r.lastChannel++
+	channel := r.lastChannel
+	r.l.Unlock()
+	if err := r.Record(&Event{
+		Type:    ChannelOpenEvent,
+		Channel: channel,
+		Name:    channelType,
+		Data:    extraData,
+	}); err != nil {
+		return 0, err
+	}
+	return channel, nil
+}
+
+// RecordChannelClose records the closing of the channel.
+func (r *Recorder) RecordChannelClose(channel uint32) error {
+	return r.Record(&Event{
+		Type:    ChannelCloseEvent,
+		Channel: channel,
+	})
+}
+
+// RecordRequest records a request sent in the direction dir over the channel.
+func (r *Recorder) RecordRequest(channel uint32, dir Direction, requestType string, payload []byte) error {
+	return r.Record(&Event{
+		Type:      RequestEvent,
+		Channel:   channel,
+		Direction: dir,
+		Name:      requestType,
+		Data:      payload,
+	})
+}
+
+// DataWriter returns an io.Writer which records everything written to it as
+// data sent in the direction dir over the channel.
+func (r *Recorder) DataWriter(channel uint32, dir Direction, stream string) io.Writer {
+	return &dataWriter{r: r, channel: channel, dir: dir, stream: stream}
+}
+
+// Close closes the recording. Events can no longer be recorded once the
+// Recorder is closed.
+func (r *Recorder) Close() error {
+	r.l.Lock()
+	defer r.l.Unlock()
+	if r.closed {
+		return nil
+	}
+	r.closed = true
+	if err := r.w.Close(); err != nil {
+		return fmt.Errorf("recording: closing recording: %w", err)
+	}
+	return nil
+}
+
+type dataWriter struct {
+	r       *Recorder
+	channel uint32
+	dir     Direction
+	stream  string
+}
+
+func (w *dataWriter) Write(p []byte) (int, error) {
+	if err := w.r.RecordData(w.channel, w.dir, w.stream, p); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+type recorderKey struct{}
+
+// NewContext returns a context which carries the Recorder the connection being
+// proxied is recorded with.
+func NewContext(ctx context.Context, r *Recorder) context.Context {
+	return context.WithValue(ctx, recorderKey{}, r)
+}
+
+// FromContext returns the Recorder carried by ctx, or nil if the connection
+// is not recorded.
+func FromContext(ctx context.Context) *Recorder {
+	r, _ := ctx.Value(recorderKey{}).(*Recorder)
+	return r
+}
@@ -0,0 +1,142 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package recording
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecorder_RoundTrip(t *testing.T) {
+	ctx := context.Background()
+	assert, require := assert.New(t), require.New(t)
+
+	s, err := NewLocalStorage(ctx, filepath.Join(t.TempDir(), "recordings"))
+	require.NoError(err)
+
+	w, err := s.Create(ctx, "sr_1234567890")
+	require.NoError(err)
+	rec, err := NewRecorder(ctx, w, Header{
+		Protocol:     "ssh",
+		SessionId:    "s_1234567890",
+		ConnectionId: "sc_1234567890",
+	})
+	require.NoError(err)
+
+	ch, err := rec.RecordChannelOpen("session", nil)
+	require.NoError(err)
+	assert.Equal(uint32(1), ch)
+	require.NoError(rec.RecordRequest(ch, Inbound, "shell", nil))
+	_, err = rec.DataWriter(ch, Outbound, "").Write([]byte("hello"))
+	require.NoError(err)
+	_, err = rec.DataWriter(ch, Outbound, StderrStream).Write([]byte("oops"))
+	require.NoError(err)
+	require.NoError(rec.RecordChannelClose(ch))
+	require.NoError(rec.Close())
+	require.NoError(rec.Close())
+	assert.ErrorIs(rec.RecordChannelClose(ch), ErrRecorderClosed)
+
+	rc, err := s.Open(ctx, "sr_1234567890")
+	require.NoError(err)
+	defer rc.Close()
+	r, err := NewReader(ctx, rc)
+	require.NoError(err)
+	h := r.Header()
+	assert.Equal(Version, h.Version)
+	assert.Equal("ssh", h.Protocol)
+	assert.Equal("s_1234567890", h.SessionId)
+	assert.Equal("sc_1234567890", h.ConnectionId)
+	assert.False(h.StartTime.IsZero())
+
+	want := []Event{
+		{Type: ChannelOpenEvent, Channel: 1, Name: "session"},
+		{Type: RequestEvent, Channel: 1, Direction: Inbound, Name: "shell"},
+		{Type: DataEvent, Channel: 1, Direction: Outbound, Data: []byte("hello")},
+		{Type: DataEvent, Channel: 1, Direction: Outbound, Stream: StderrStream, Data: []byte("oops")},
+		{Type: ChannelCloseEvent, Channel: 1},
+	}
+	for _, w := range want {
+		e, err := r.Next()
+		require.NoError(err)
+		e.Time = 0
+		assert.Equal(w, *e)
+	}
+	_, err = r.Next()
+	assert.ErrorIs(err, io.EOF)
+}
+
+func TestNewRecorder_Validation(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name string
+		w    io.WriteCloser
+		h    Header
+	}{
+		{
+			name: "missing-writer",
+			h:    Header{SessionId: "s_1234567890", ConnectionId: "sc_1234567890"},
+		},
+		{
+			name: "missing-session-id",
+			w:    nopWriteCloser{io.Discard},
+			h:    Header{ConnectionId: "sc_1234567890"},
+		},
+		{
+			name: "missing-connection-id",
+			w:    nopWriteCloser{io.Discard},
+			h:    Header{SessionId: "s_1234567890"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewRecorder(ctx, tt.w, tt.h)
+			assert.True(t, errors.Match(errors.T(errors.InvalidParameter), err))
+		})
+	}
+}
+
+func TestLocalStorage(t *testing.T) {
+	ctx := context.Background()
+	assert, require := assert.New(t), require.New(t)
+
+	dir := t.TempDir()
+	s, err := NewLocalStorage(ctx, dir)
+	require.NoError(err)
+
+	_, err = s.Open(ctx, "sr_missing")
+	assert.True(errors.Match(errors.T(errors.RecordNotFound), err))
+
+	for _, name := range []string{"", "../sr_1234567890", "sr/1234567890"} {
+		_, err = s.Create(ctx, name)
+		assert.True(errors.Match(errors.T(errors.InvalidParameter), err), "name %q", name)
+	}
+
+	w, err := s.Create(ctx, "sr_1234567890")
+	require.NoError(err)
+	require.NoError(w.Close())
+	_, err = s.Create(ctx, "sr_1234567890")
+	assert.Error(err)
+
+	fi, err := os.Stat(filepath.Join(dir, "sr_1234567890"+fileExtension))
+	require.NoError(err)
+	assert.Equal(os.FileMode(0o600), fi.Mode().Perm())
+
+	f := filepath.Join(dir, "file")
+	require.NoError(os.WriteFile(f, nil, 0o600))
+	_, err = NewLocalStorage(ctx, f)
+	assert.Error(err)
+}
+
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error { return nil }
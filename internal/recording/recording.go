@@ -0,0 +1,93 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package recording provides session recordings. A recording is written by the
+// worker proxying a connection and contains a Header followed by the Events
+// that occurred on the connection, each encoded as a JSON object on its own
+// line. Recordings are kept in a Storage shared by the workers and the
+// controllers, the controller keeps a SessionRecording for each of them.
+package recording
+
+import "time"
+
+// Version is the version of the recording format written by a Recorder.
+const Version = 1
+
+// Direction is the direction data travelled through the worker.
+type Direction string
+
+const (
+	// Inbound is data sent by the client to the endpoint.
+	Inbound Direction = "i"
+	// Outbound is data sent by the endpoint to the client.
+	Outbound Direction = "o"
+)
+
+// Opposite returns the direction opposite to d.
+func (d Direction) Opposite() Direction {
+	if d == Inbound {
+		return Outbound
+	}
+	return Inbound
+}
+
+// EventType is the type of an Event.
+type EventType string
+
+const (
+	// DataEvent is data sent over the connection or over a channel.
+	DataEvent EventType = "data"
+	// ChannelOpenEvent is the opening of a channel within the connection.
+	ChannelOpenEvent EventType = "channel-open"
+	// ChannelCloseEvent is the closing of a channel within the connection.
+	ChannelCloseEvent EventType = "channel-close"
+	// RequestEvent is a request sent over the connection or over a channel.
+	RequestEvent EventType = "request"
+)
+
+// StderrStream is the Stream of a DataEvent for data sent over the extended
+// (stderr) data stream of a channel.
+const StderrStream = "stderr"
+
+// Header is the first line of a recording.
+type Header struct {
+	// Version of the recording format.
+	Version int `json:"version"`
+	// Protocol of the recorded connection, e.g. tcp or ssh.
+	Protocol string `json:"protocol"`
+	// SessionId of the session the connection belongs to.
+	SessionId string `json:"session_id"`
+	// ConnectionId of the recorded connection.
+	ConnectionId string `json:"connection_id"`
+	// TargetId of the target of the session.
+	TargetId string `json:"target_id,omitempty"`
+	// UserId of the user that requested the session.
+	UserId string `json:"user_id,omitempty"`
+	// Endpoint the connection was proxied to.
+	Endpoint string `json:"endpoint,omitempty"`
+	// StartTime of the recording. The time of every Event is relative to it.
+	StartTime time.Time `json:"start_time"`
+}
+
+// Event is something which occurred on a recorded connection.
+type Event struct {
+	// Time is the number of seconds between the start of the recording and
+	// the event.
+	Time float64 `json:"t"`
+	// Type of the event.
+	Type EventType `json:"type"`
+	// Channel the event occurred on. Channels are numbered from 1 in the order
+	// they were opened, 0 is the connection itself.
+	Channel uint32 `json:"channel,omitempty"`
+	// Direction of the data or request.
+	Direction Direction `json:"dir,omitempty"`
+	// Stream is set for a DataEvent for data not sent over the main data
+	// stream of a channel.
+	Stream string `json:"stream,omitempty"`
+	// Name is the channel type of a ChannelOpenEvent or the request type of a
+	// RequestEvent.
+	Name string `json:"name,omitempty"`
+	// Data is the data of a DataEvent, the extra data of a ChannelOpenEvent
+	// or the payload of a RequestEvent.
+	Data []byte `json:"data,omitempty"`
+}
@@ -0,0 +1,124 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package recording
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/pagination"
+	"github.com/hashicorp/boundary/internal/util"
+)
+
+// Repository is the session recording database repository
+type Repository struct {
+	reader db.Reader
+	writer db.Writer
+
+	// defaultLimit provides a default for limiting the number of results returned from the repo
+	defaultLimit int
+}
+
+// RepositoryFactory is a function that creates a Repository.
+type RepositoryFactory func() (*Repository, error)
+
+// NewRepository creates a new session recording Repository. Supports the
+// options: WithLimit, which sets a default limit on results returned by repo
+// operations.
+func NewRepository(ctx context.Context, r db.Reader, w db.Writer, opt ...Option) (*Repository, error) {
+	const op = "recording.NewRepository"
+	if util.IsNil(r) {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "nil reader")
+	}
+	if util.IsNil(w) {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "nil writer")
+	}
+	opts := getOpts(opt...)
+	if opts.withLimit == 0 {
+		// zero signals the boundary defaults should be used.
+		opts.withLimit = db.DefaultLimit
+	}
+	return &Repository{
+		reader:       r,
+		writer:       w,
+		defaultLimit: opts.withLimit,
+	}, nil
+}
+
+// CreateSessionRecording inserts the session recording into the repository
+// and returns a new SessionRecording containing its PublicId. No options are
+// currently supported.
+func (r *Repository) CreateSessionRecording(ctx context.Context, sr *SessionRecording, _ ...Option) (*SessionRecording, error) {
+	const op = "recording.(Repository).CreateSessionRecording"
+	if sr == nil {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing session recording")
+	}
+	if sr.PublicId != "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "public id is not empty")
+	}
+	id, err := newSessionRecordingId(ctx)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	newSr := sr.Clone()
+	newSr.PublicId = id
+	if err := r.writer.Create(ctx, newSr); err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("for connection %s", sr.ConnectionId)))
+	}
+	return newSr, nil
+}
+
+// LookupSessionRecording will look up a session recording in the repository.
+// If the session recording is not found, it will return nil, nil. No options
+// are currently supported.
+func (r *Repository) LookupSessionRecording(ctx context.Context, publicId string, _ ...Option) (*SessionRecording, error) {
+	const op = "recording.(Repository).LookupSessionRecording"
+	if publicId == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing public id")
+	}
+	sr := AllocSessionRecording()
+	sr.PublicId = publicId
+	if err := r.reader.LookupById(ctx, sr); err != nil {
+		if errors.IsNotFoundError(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("failed for %s", publicId)))
+	}
+	return sr, nil
+}
+
+// ListSessionRecordings lists the session recordings of sessions in the
+// provided projects, most recent first unless WithPage is provided. Supports
+// the WithLimit and WithPage options.
+func (r *Repository) ListSessionRecordings(ctx context.Context, projectIds []string, opt ...Option) ([]*SessionRecording, error) {
+	const op = "recording.(Repository).ListSessionRecordings"
+	if len(projectIds) == 0 {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing project ids")
+	}
+	opts := getOpts(opt...)
+	limit := r.defaultLimit
+	if opts.withLimit != 0 {
+		// non-zero signals an override of the default limit for the repo.
+		limit = opts.withLimit
+	}
+	var srs []*SessionRecording
+	if err := r.reader.SearchWhere(ctx, &srs, "project_id in (?)", []any{projectIds}, db.WithLimit(limit), db.WithOrder("create_time desc"), db.WithPage(opts.withPage)); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return srs, nil
+}
+
+// ListDeletedSessionRecordingIds returns the session recordings in one of
+// scopeIds deleted after since.
+func (r *Repository) ListDeletedSessionRecordingIds(ctx context.Context, scopeIds []string, since time.Time) ([]*pagination.DeletedResource, error) {
+	const op = "recording.(Repository).ListDeletedSessionRecordingIds"
+	deleted, err := pagination.ListDeletedIds(ctx, r.reader, "session_recording", scopeIds, since)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return deleted, nil
+}
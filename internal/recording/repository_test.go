@@ -0,0 +1,302 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package recording
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRepository(t *testing.T) {
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+
+	tests := []struct {
+		name      string
+		reader    db.Reader
+		writer    db.Writer
+		opts      []Option
+		wantLimit int
+		wantErr   errors.Code
+	}{
+		{
+			name:      "valid",
+			reader:    rw,
+			writer:    rw,
+			wantLimit: db.DefaultLimit,
+		},
+		{
+			name:      "valid-with-limit",
+			reader:    rw,
+			writer:    rw,
+			opts:      []Option{WithLimit(5)},
+			wantLimit: 5,
+		},
+		{
+			name:    "nil-reader",
+			writer:  rw,
+			wantErr: errors.InvalidParameter,
+		},
+		{
+			name:    "nil-writer",
+			reader:  rw,
+			wantErr: errors.InvalidParameter,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, err := NewRepository(ctx, tt.reader, tt.writer, tt.opts...)
+			if tt.wantErr != 0 {
+				assert.Truef(errors.Match(errors.T(tt.wantErr), err), "want err: %q got: %q", tt.wantErr, err)
+				assert.Nil(got)
+				return
+			}
+			require.NoError(err)
+			require.NotNil(got)
+			assert.Equal(tt.wantLimit, got.defaultLimit)
+		})
+	}
+}
+
+func TestRepository_CreateSessionRecording(t *testing.T) {
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	_, prj := iam.TestScopes(t, iamRepo)
+
+	repo, err := NewRepository(ctx, rw, rw)
+	require.NoError(t, err)
+
+	newSr := func() *SessionRecording {
+		sr, err := NewSessionRecording(ctx, prj.GetPublicId(), "s_1234567890", "sc_1234567890", "ttcp_1234567890", "u_1234567890")
+		require.NoError(t, err)
+		return sr
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		in := newSr()
+		got, err := repo.CreateSessionRecording(ctx, in)
+		require.NoError(err)
+		require.NotNil(got)
+		assert.True(len(got.PublicId) > len(SessionRecordingPrefix))
+		assert.Empty(in.PublicId, "the input should not be modified")
+		assert.Equal(in.ProjectId, got.ProjectId)
+		assert.Equal(in.SessionId, got.SessionId)
+		assert.Equal(in.ConnectionId, got.ConnectionId)
+		assert.Equal(in.TargetId, got.TargetId)
+		assert.Equal(in.UserId, got.UserId)
+		assert.NotNil(got.CreateTime)
+		assert.Nil(got.EndTime)
+
+		// A connection is only recorded once
+		_, err = repo.CreateSessionRecording(ctx, newSr())
+		assert.Truef(errors.Match(errors.T(errors.NotUnique), err), "want err: %q got: %q", errors.NotUnique, err)
+	})
+	t.Run("nil-session-recording", func(t *testing.T) {
+		_, err := repo.CreateSessionRecording(ctx, nil)
+		assert.Truef(t, errors.Match(errors.T(errors.InvalidParameter), err), "want err: %q got: %q", errors.InvalidParameter, err)
+	})
+	t.Run("public-id-set", func(t *testing.T) {
+		in := newSr()
+		in.PublicId = "sr_1234567890"
+		_, err := repo.CreateSessionRecording(ctx, in)
+		assert.Truef(t, errors.Match(errors.T(errors.InvalidParameter), err), "want err: %q got: %q", errors.InvalidParameter, err)
+	})
+	t.Run("missing-connection-id", func(t *testing.T) {
+		in := newSr()
+		in.ConnectionId = ""
+		_, err := repo.CreateSessionRecording(ctx, in)
+		assert.Truef(t, errors.Match(errors.T(errors.InvalidParameter), err), "want err: %q got: %q", errors.InvalidParameter, err)
+	})
+}
+
+func TestRepository_LookupSessionRecording(t *testing.T) {
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	_, prj := iam.TestScopes(t, iamRepo)
+	sr := TestSessionRecording(t, conn, prj.GetPublicId())
+
+	repo, err := NewRepository(ctx, rw, rw)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		id      string
+		want    *SessionRecording
+		wantErr errors.Code
+	}{
+		{
+			name: "found",
+			id:   sr.GetPublicId(),
+			want: sr,
+		},
+		{
+			name: "not-found",
+			id:   SessionRecordingPrefix + "_1234567890",
+		},
+		{
+			name:    "missing-id",
+			wantErr: errors.InvalidParameter,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, err := repo.LookupSessionRecording(ctx, tt.id)
+			if tt.wantErr != 0 {
+				assert.Truef(errors.Match(errors.T(tt.wantErr), err), "want err: %q got: %q", tt.wantErr, err)
+				return
+			}
+			require.NoError(err)
+			assert.Equal(tt.want, got)
+		})
+	}
+}
+
+func TestRepository_ListSessionRecordings(t *testing.T) {
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	_, prj := iam.TestScopes(t, iamRepo)
+	_, otherPrj := iam.TestScopes(t, iamRepo)
+	_, emptyPrj := iam.TestScopes(t, iamRepo)
+
+	var want []*SessionRecording
+	for i := 0; i < 3; i++ {
+		// Listing is most recent first
+		want = append([]*SessionRecording{TestSessionRecording(t, conn, prj.GetPublicId())}, want...)
+	}
+	other := TestSessionRecording(t, conn, otherPrj.GetPublicId())
+
+	repo, err := NewRepository(ctx, rw, rw)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		projectIds []string
+		opts       []Option
+		want       []*SessionRecording
+		wantErr    errors.Code
+	}{
+		{
+			name:       "one-project",
+			projectIds: []string{prj.GetPublicId()},
+			want:       want,
+		},
+		{
+			name:       "multiple-projects",
+			projectIds: []string{prj.GetPublicId(), otherPrj.GetPublicId()},
+			want:       append([]*SessionRecording{other}, want...),
+		},
+		{
+			name:       "empty-project",
+			projectIds: []string{emptyPrj.GetPublicId()},
+		},
+		{
+			name:       "with-limit",
+			projectIds: []string{prj.GetPublicId()},
+			opts:       []Option{WithLimit(2)},
+			want:       want[:2],
+		},
+		{
+			name:    "missing-project-ids",
+			wantErr: errors.InvalidParameter,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, err := repo.ListSessionRecordings(ctx, tt.projectIds, tt.opts...)
+			if tt.wantErr != 0 {
+				assert.Truef(errors.Match(errors.T(tt.wantErr), err), "want err: %q got: %q", tt.wantErr, err)
+				return
+			}
+			require.NoError(err)
+			assert.Equal(tt.want, got)
+		})
+	}
+
+	t.Run("with-page", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		// Pages are ordered by create time, oldest first
+		var wantPaged []*SessionRecording
+		for i := len(want) - 1; i >= 0; i-- {
+			wantPaged = append(wantPaged, want[i])
+		}
+		var got []*SessionRecording
+		page := &db.Page{}
+		for {
+			items, err := repo.ListSessionRecordings(ctx, []string{prj.GetPublicId()}, WithLimit(1), WithPage(page))
+			require.NoError(err)
+			if len(items) == 0 {
+				break
+			}
+			require.Len(items, 1)
+			got = append(got, items...)
+			page = &db.Page{
+				LastItemTime: items[0].GetCreateTime().AsTime(),
+				LastItemId:   items[0].GetPublicId(),
+			}
+		}
+		assert.Equal(wantPaged, got)
+	})
+}
+
+func TestRepository_ListDeletedSessionRecordingIds(t *testing.T) {
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	_, prj := iam.TestScopes(t, iamRepo)
+	_, otherPrj := iam.TestScopes(t, iamRepo)
+	kept := TestSessionRecording(t, conn, prj.GetPublicId())
+	deleted := TestSessionRecording(t, conn, prj.GetPublicId())
+	otherDeleted := TestSessionRecording(t, conn, otherPrj.GetPublicId())
+
+	repo, err := NewRepository(ctx, rw, rw)
+	require.NoError(t, err)
+
+	since := time.Now().Add(-time.Minute)
+	projectIds := []string{prj.GetPublicId()}
+	got, err := repo.ListDeletedSessionRecordingIds(ctx, projectIds, since)
+	require.NoError(t, err)
+	assert.Empty(t, got)
+
+	n, err := rw.Delete(ctx, deleted)
+	require.NoError(t, err)
+	require.Equal(t, 1, n)
+	n, err = rw.Delete(ctx, otherDeleted)
+	require.NoError(t, err)
+	require.Equal(t, 1, n)
+
+	// Recordings deleted from other projects are not returned
+	got, err = repo.ListDeletedSessionRecordingIds(ctx, projectIds, since)
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, deleted.GetPublicId(), got[0].PublicId)
+	assert.Equal(t, prj.GetPublicId(), got[0].ScopeId)
+	assert.NotEqual(t, kept.GetPublicId(), got[0].PublicId)
+
+	got, err = repo.ListDeletedSessionRecordingIds(ctx, projectIds, time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	assert.Empty(t, got)
+}
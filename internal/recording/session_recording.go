@@ -0,0 +1,174 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package recording
+
+import (
+	"context"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/db/timestamp"
+	"github.com/hashicorp/boundary/internal/errors"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// SessionRecordingPrefix for session recording PK ids
+	SessionRecordingPrefix = "sr"
+
+	defaultSessionRecordingTableName = "session_recording"
+)
+
+// SessionRecording is the recording of a single connection of a session. The
+// recording itself is held in a Storage under the PublicId of the
+// SessionRecording. A SessionRecording is retained after its session is
+// deleted.
+type SessionRecording struct {
+	// PublicId is used to access the session recording via an API
+	PublicId string `json:"public_id,omitempty" gorm:"primary_key"`
+	// ProjectId of the session that was recorded
+	ProjectId string `json:"project_id,omitempty" gorm:"default:null"`
+	// SessionId of the session that was recorded
+	SessionId string `json:"session_id,omitempty" gorm:"default:null"`
+	// ConnectionId of the connection that was recorded
+	ConnectionId string `json:"connection_id,omitempty" gorm:"default:null"`
+	// TargetId of the target of the session
+	TargetId string `json:"target_id,omitempty" gorm:"default:null"`
+	// UserId of the user that requested the session
+	UserId string `json:"user_id,omitempty" gorm:"default:null"`
+	// EndTime of the recording, set when the connection is closed
+	EndTime *timestamp.Timestamp `json:"end_time,omitempty" gorm:"default:null"`
+	// CreateTime from the RDBMS
+	CreateTime *timestamp.Timestamp `json:"create_time,omitempty" gorm:"default:current_timestamp"`
+	// UpdateTime from the RDBMS
+	UpdateTime *timestamp.Timestamp `json:"update_time,omitempty" gorm:"default:current_timestamp"`
+
+	tableName string `gorm:"-"`
+}
+
+var _ db.VetForWriter = (*SessionRecording)(nil)
+
+// NewSessionRecording creates a new in memory session recording of the
+// connection. No options are currently supported.
+func NewSessionRecording(ctx context.Context, projectId, sessionId, connectionId, targetId, userId string, _ ...Option) (*SessionRecording, error) {
+	const op = "recording.NewSessionRecording"
+	sr := &SessionRecording{
+		ProjectId:    projectId,
+		SessionId:    sessionId,
+		ConnectionId: connectionId,
+		TargetId:     targetId,
+		UserId:       userId,
+	}
+	if err := sr.validateNew(ctx); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return sr, nil
+}
+
+// AllocSessionRecording will allocate a SessionRecording.
+func AllocSessionRecording() *SessionRecording {
+	return &SessionRecording{}
+}
+
+// Clone creates a clone of the SessionRecording.
+func (sr *SessionRecording) Clone() *SessionRecording {
+	clone := &SessionRecording{
+		PublicId:     sr.PublicId,
+		ProjectId:    sr.ProjectId,
+		SessionId:    sr.SessionId,
+		ConnectionId: sr.ConnectionId,
+		TargetId:     sr.TargetId,
+		UserId:       sr.UserId,
+	}
+	if sr.EndTime != nil {
+		clone.EndTime = proto.Clone(sr.EndTime).(*timestamp.Timestamp)
+	}
+	if sr.CreateTime != nil {
+		clone.CreateTime = proto.Clone(sr.CreateTime).(*timestamp.Timestamp)
+	}
+	if sr.UpdateTime != nil {
+		clone.UpdateTime = proto.Clone(sr.UpdateTime).(*timestamp.Timestamp)
+	}
+	return clone
+}
+
+// GetPublicId returns the public id of the session recording.
+func (sr *SessionRecording) GetPublicId() string {
+	return sr.PublicId
+}
+
+// GetCreateTime returns the create time of the session recording.
+func (sr *SessionRecording) GetCreateTime() *timestamp.Timestamp {
+	return sr.CreateTime
+}
+
+// GetUpdateTime returns the update time of the session recording.
+func (sr *SessionRecording) GetUpdateTime() *timestamp.Timestamp {
+	return sr.UpdateTime
+}
+
+// VetForWrite implements db.VetForWrite() interface and validates the session
+// recording before it's written. Only the EndTime of a session recording can
+// be updated.
+func (sr *SessionRecording) VetForWrite(ctx context.Context, _ db.Reader, opType db.OpType, opt ...db.Option) error {
+	const op = "recording.(SessionRecording).VetForWrite"
+	if sr.PublicId == "" {
+		return errors.New(ctx, errors.InvalidParameter, op, "missing public id")
+	}
+	switch opType {
+	case db.CreateOp:
+		if err := sr.validateNew(ctx); err != nil {
+			return errors.Wrap(ctx, err, op)
+		}
+	case db.UpdateOp:
+		opts := db.GetOpts(opt...)
+		for _, f := range opts.WithFieldMaskPaths {
+			if f != "EndTime" {
+				return errors.New(ctx, errors.InvalidParameter, op, f+" is immutable")
+			}
+		}
+	}
+	return nil
+}
+
+// TableName returns the tablename to override the default gorm table name
+func (sr *SessionRecording) TableName() string {
+	if sr.tableName != "" {
+		return sr.tableName
+	}
+	return defaultSessionRecordingTableName
+}
+
+// SetTableName sets the tablename and satisfies the ReplayableMessage
+// interface. If the caller attempts to set the name to "" the name will be
+// reset to the default name.
+func (sr *SessionRecording) SetTableName(n string) {
+	sr.tableName = n
+}
+
+// validateNew checks everything but the session recording's PublicId
+func (sr *SessionRecording) validateNew(ctx context.Context) error {
+	const op = "recording.(SessionRecording).validateNew"
+	if sr.ProjectId == "" {
+		return errors.New(ctx, errors.InvalidParameter, op, "missing project id")
+	}
+	if sr.SessionId == "" {
+		return errors.New(ctx, errors.InvalidParameter, op, "missing session id")
+	}
+	if sr.ConnectionId == "" {
+		return errors.New(ctx, errors.InvalidParameter, op, "missing connection id")
+	}
+	if sr.TargetId == "" {
+		return errors.New(ctx, errors.InvalidParameter, op, "missing target id")
+	}
+	return nil
+}
+
+func newSessionRecordingId(ctx context.Context) (string, error) {
+	const op = "recording.newSessionRecordingId"
+	id, err := db.NewPublicId(SessionRecordingPrefix)
+	if err != nil {
+		return "", errors.Wrap(ctx, err, op)
+	}
+	return id, nil
+}
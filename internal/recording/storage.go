@@ -0,0 +1,99 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package recording
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"github.com/hashicorp/boundary/internal/errors"
+)
+
+// Storage holds recordings. Recordings are named by the public id of their
+// SessionRecording.
+type Storage interface {
+	// Create returns a writer for a new recording with the provided name. It
+	// is an error if the recording already exists.
+	Create(ctx context.Context, name string) (io.WriteCloser, error)
+	// Open returns a reader for the recording with the provided name. A
+	// RecordNotFound error is returned if the recording does not exist.
+	Open(ctx context.Context, name string) (io.ReadCloser, error)
+}
+
+// fileExtension is the extension of the files LocalStorage keeps recordings in.
+const fileExtension = ".rec"
+
+var validName = regexp.MustCompile(`^[a-zA-Z0-9_]+$`)
+
+// LocalStorage is a Storage which keeps each recording in a file in a
+// directory of the local file system.
+type LocalStorage struct {
+	path string
+}
+
+var _ Storage = (*LocalStorage)(nil)
+
+// NewLocalStorage returns a LocalStorage keeping recordings in the directory
+// at path. The directory is created if it does not exist.
+func NewLocalStorage(ctx context.Context, path string) (*LocalStorage, error) {
+	const op = "recording.NewLocalStorage"
+	if path == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing path")
+	}
+	if err := os.MkdirAll(path, 0o700); err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithCode(errors.Io))
+	}
+	fi, err := os.Stat(path)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithCode(errors.Io))
+	}
+	if !fi.IsDir() {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("%s is not a directory", path))
+	}
+	return &LocalStorage{path: path}, nil
+}
+
+// Create creates the file of the recording with the provided name.
+func (s *LocalStorage) Create(ctx context.Context, name string) (io.WriteCloser, error) {
+	const op = "recording.(LocalStorage).Create"
+	fn, err := s.fileName(ctx, name)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	f, err := os.OpenFile(fn, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithCode(errors.Io))
+	}
+	return f, nil
+}
+
+// Open opens the file of the recording with the provided name.
+func (s *LocalStorage) Open(ctx context.Context, name string) (io.ReadCloser, error) {
+	const op = "recording.(LocalStorage).Open"
+	fn, err := s.fileName(ctx, name)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	f, err := os.Open(fn)
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		return nil, errors.New(ctx, errors.RecordNotFound, op, fmt.Sprintf("recording %s not found", name))
+	case err != nil:
+		return nil, errors.Wrap(ctx, err, op, errors.WithCode(errors.Io))
+	}
+	return f, nil
+}
+
+func (s *LocalStorage) fileName(ctx context.Context, name string) (string, error) {
+	const op = "recording.(LocalStorage).fileName"
+	if !validName.MatchString(name) {
+		return "", errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("invalid recording name %q", name))
+	}
+	return filepath.Join(s.path, name+fileExtension), nil
+}
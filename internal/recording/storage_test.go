@@ -0,0 +1,120 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package recording
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewLocalStorage(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	file := filepath.Join(dir, "file")
+	require.NoError(t, os.WriteFile(file, nil, 0o600))
+
+	tests := []struct {
+		name    string
+		path    string
+		wantErr errors.Code
+	}{
+		{
+			name:    "missing-path",
+			wantErr: errors.InvalidParameter,
+		},
+		{
+			name:    "not-a-directory",
+			path:    file,
+			wantErr: errors.Io,
+		},
+		{
+			name:    "under-a-file",
+			path:    filepath.Join(file, "recordings"),
+			wantErr: errors.Io,
+		},
+		{
+			name: "existing-directory",
+			path: dir,
+		},
+		{
+			name: "new-directory",
+			path: filepath.Join(dir, "new", "recordings"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, err := NewLocalStorage(ctx, tt.path)
+			if tt.wantErr != 0 {
+				assert.Truef(errors.Match(errors.T(tt.wantErr), err), "want err: %q got: %q", tt.wantErr, err)
+				assert.Nil(got)
+				return
+			}
+			require.NoError(err)
+			require.NotNil(got)
+			fi, err := os.Stat(tt.path)
+			require.NoError(err)
+			assert.True(fi.IsDir())
+		})
+	}
+}
+
+func TestLocalStorage_CreateOpen(t *testing.T) {
+	ctx := context.Background()
+	assert, require := assert.New(t), require.New(t)
+	dir := t.TempDir()
+	s, err := NewLocalStorage(ctx, dir)
+	require.NoError(err)
+
+	w, err := s.Create(ctx, "sr_1234567890")
+	require.NoError(err)
+	_, err = w.Write([]byte("recording"))
+	require.NoError(err)
+	require.NoError(w.Close())
+
+	fi, err := os.Stat(filepath.Join(dir, "sr_1234567890"+fileExtension))
+	require.NoError(err)
+	assert.Equal(os.FileMode(0o600), fi.Mode().Perm())
+
+	// Existing recordings are never overwritten
+	_, err = s.Create(ctx, "sr_1234567890")
+	assert.Truef(errors.Match(errors.T(errors.Io), err), "want err: %q got: %q", errors.Io, err)
+
+	r, err := s.Open(ctx, "sr_1234567890")
+	require.NoError(err)
+	defer r.Close()
+	got, err := io.ReadAll(r)
+	require.NoError(err)
+	assert.Equal("recording", string(got))
+
+	_, err = s.Open(ctx, "sr_0987654321")
+	assert.Truef(errors.Match(errors.T(errors.RecordNotFound), err), "want err: %q got: %q", errors.RecordNotFound, err)
+}
+
+func TestLocalStorage_InvalidName(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	s, err := NewLocalStorage(ctx, filepath.Join(dir, "recordings"))
+	require.NoError(t, err)
+
+	for _, name := range []string{"", "../sr_1234567890", "sr/1234567890", "sr_1234567890.rec", "sr 1234567890"} {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			_, err := s.Create(ctx, name)
+			assert.Truef(errors.Match(errors.T(errors.InvalidParameter), err), "want err: %q got: %q", errors.InvalidParameter, err)
+			_, err = s.Open(ctx, name)
+			assert.Truef(errors.Match(errors.T(errors.InvalidParameter), err), "want err: %q got: %q", errors.InvalidParameter, err)
+		})
+	}
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1, "only the recordings directory should exist")
+}
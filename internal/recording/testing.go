@@ -0,0 +1,35 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package recording
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSessionRecording creates a test session recording in the project. The
+// session, connection, target and user of the recording are not looked up,
+// so random ids are used for them.
+func TestSessionRecording(t testing.TB, conn *db.DB, projectId string) *SessionRecording {
+	t.Helper()
+	require := require.New(t)
+	ctx := context.Background()
+	rw := db.New(conn)
+
+	newId := func(prefix string) string {
+		id, err := db.NewPublicId(prefix)
+		require.NoError(err)
+		return id
+	}
+	sr, err := NewSessionRecording(ctx, projectId, newId("s"), newId("sc"), newId("ttcp"), newId("u"))
+	require.NoError(err)
+	repo, err := NewRepository(ctx, rw, rw)
+	require.NoError(err)
+	sr, err = repo.CreateSessionRecording(ctx, sr)
+	require.NoError(err)
+	return sr
+}
@@ -12,7 +12,7 @@ import (
 	"github.com/hashicorp/boundary/internal/util"
 )
 
-// RegisterJob registers the cleaner job with the provided scheduler.
+// RegisterJob registers the cleaner jobs with the provided scheduler.
 func RegisterJob(ctx context.Context, s *scheduler.Scheduler, w db.Writer) error {
 	const op = "cleaner.RegisterJob"
 	if s == nil {
@@ -25,6 +25,9 @@ func RegisterJob(ctx context.Context, s *scheduler.Scheduler, w db.Writer) error
 	if err := s.RegisterJob(ctx, newCleanerJob(w)); err != nil {
 		return errors.Wrap(ctx, err, op)
 	}
+	if err := s.RegisterJob(ctx, newDeletedIdsCleanerJob(w)); err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
 
 	return nil
 }
@@ -0,0 +1,66 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package cleaner
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/pagination"
+	"github.com/hashicorp/boundary/internal/scheduler"
+)
+
+// deletedIdsRetention is how long deleted resources are recorded. It exceeds
+// the lifetime of a list token so every deletion can be reported to the
+// holders of a valid list token.
+const deletedIdsRetention = pagination.ListTokenLifetime + 24*time.Hour
+
+const deleteExpiredDeletedIdsQuery = `
+delete from deleted_resource
+ where delete_time < now() - make_interval(secs => ?)
+`
+
+type deletedIdsCleanerJob struct {
+	w db.Writer
+}
+
+func newDeletedIdsCleanerJob(w db.Writer) *deletedIdsCleanerJob {
+	return &deletedIdsCleanerJob{
+		w: w,
+	}
+}
+
+// Status reports the job’s current status.
+func (c *deletedIdsCleanerJob) Status() scheduler.JobStatus {
+	return scheduler.JobStatus{}
+}
+
+// Run deletes the records of deleted resources which are older than
+// deletedIdsRetention.
+func (c *deletedIdsCleanerJob) Run(ctx context.Context) error {
+	const op = "cleaner.(deletedIdsCleanerJob).Run"
+
+	if _, err := c.w.Exec(ctx, deleteExpiredDeletedIdsQuery, []any{deletedIdsRetention.Seconds()}); err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+
+	return nil
+}
+
+// NextRunIn returns the duration until the next job run should be scheduled.
+func (c *deletedIdsCleanerJob) NextRunIn(_ context.Context) (time.Duration, error) {
+	return time.Hour, nil
+}
+
+// Name is the unique name of the job.
+func (c *deletedIdsCleanerJob) Name() string {
+	return "deleted_ids_cleaner"
+}
+
+// Description is the human readable description of the job.
+func (c *deletedIdsCleanerJob) Description() string {
+	return "Cleans the records of deleted resources which are older than the lifetime of list tokens"
+}
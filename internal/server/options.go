@@ -7,6 +7,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/hashicorp/boundary/internal/db"
 	"github.com/hashicorp/boundary/version"
 	"github.com/hashicorp/nodeenrollment/types"
 )
@@ -52,6 +53,9 @@ type options struct {
 	withFeature                            version.Feature
 	withDirectlyConnected                  bool
 	withWorkerPool                         []string
+	withProxyProtocols                     []string
+	withRecordingStorage                   bool
+	withPage                               *db.Page
 }
 
 func getDefaultOptions() options {
@@ -98,6 +102,14 @@ func WithLimit(limit int) Option {
 	}
 }
 
+// WithPage provides an option to list one page of workers. Use WithLimit to set
+// the size of the page.
+func WithPage(p *db.Page) Option {
+	return func(o *options) {
+		o.withPage = p
+	}
+}
+
 // WithLiveness indicates how far back we want to search for server entries.
 // Use 0 for the default liveness (15 seconds). A liveness value of -1 removes
 // the liveliness condition.
@@ -249,3 +261,19 @@ func WithWorkerPool(workerIds []string) Option {
 		o.withWorkerPool = workerIds
 	}
 }
+
+// WithProxyProtocols provides the proxy protocols a worker has reported it has
+// a handler for.
+func WithProxyProtocols(protocols ...string) Option {
+	return func(o *options) {
+		o.withProxyProtocols = protocols
+	}
+}
+
+// WithRecordingStorage provides whether a worker has reported it has recording
+// storage configured.
+func WithRecordingStorage(recordingStorage bool) Option {
+	return func(o *options) {
+		o.withRecordingStorage = recordingStorage
+	}
+}
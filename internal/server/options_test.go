@@ -236,4 +236,20 @@ func Test_GetOpts(t *testing.T) {
 		testOpts.withNewIdFunc = nil
 		assert.Equal(t, opts, testOpts)
 	})
+	t.Run("WithProxyProtocols", func(t *testing.T) {
+		opts := GetOpts(WithProxyProtocols("tcp", "ssh"))
+		testOpts := getDefaultOptions()
+		testOpts.withProxyProtocols = []string{"tcp", "ssh"}
+		opts.withNewIdFunc = nil
+		testOpts.withNewIdFunc = nil
+		assert.Equal(t, opts, testOpts)
+	})
+	t.Run("WithRecordingStorage", func(t *testing.T) {
+		opts := GetOpts(WithRecordingStorage(true))
+		testOpts := getDefaultOptions()
+		testOpts.withRecordingStorage = true
+		opts.withNewIdFunc = nil
+		testOpts.withNewIdFunc = nil
+		assert.Equal(t, opts, testOpts)
+	})
 }
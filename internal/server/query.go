@@ -14,6 +14,12 @@ const (
 	and
 		worker_id = ?`
 
+	deleteProxyProtocolsByWorkerIdSql = `
+	delete
+	from server_worker_proxy_protocol
+	where
+		worker_id = ?`
+
 	deleteWorkerAuthQuery = `
 		delete from worker_auth_authorized
  		where worker_key_identifier = @worker_key_identifier;
@@ -7,10 +7,12 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/boundary/internal/db"
 	"github.com/hashicorp/boundary/internal/errors"
 	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/pagination"
 	"github.com/hashicorp/boundary/internal/server/store"
 	"github.com/hashicorp/boundary/internal/types/scope"
 	"github.com/hashicorp/go-dbw"
@@ -167,7 +169,7 @@ func lookupWorker(ctx context.Context, reader db.Reader, id string) (*Worker, er
 // then the last status update time is ignored.
 // If WithLimit < 0, then unlimited results are returned. If WithLimit == 0, then
 // default limits are used for results.
-// Also supports: WithWorkerType, WithActiveWorkers
+// Also supports: WithWorkerType, WithActiveWorkers, WithPage
 func (r *Repository) ListWorkers(ctx context.Context, scopeIds []string, opt ...Option) ([]*Worker, error) {
 	const op = "server.(Repository).ListWorkers"
 	switch {
@@ -218,6 +220,7 @@ func (r *Repository) ListWorkers(ctx context.Context, scopeIds []string, opt ...
 		strings.Join(where, " and "),
 		whereArgs,
 		db.WithLimit(limit),
+		db.WithPage(opts.withPage),
 	); err != nil {
 		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("error searching for workers"))
 	}
@@ -233,12 +236,24 @@ func (r *Repository) ListWorkers(ctx context.Context, scopeIds []string, opt ...
 	return workers, nil
 }
 
+// ListDeletedWorkerIds returns the workers in one of scopeIds deleted after
+// since.
+func (r *Repository) ListDeletedWorkerIds(ctx context.Context, scopeIds []string, since time.Time) ([]*pagination.DeletedResource, error) {
+	const op = "server.(Repository).ListDeletedWorkerIds"
+	deleted, err := pagination.ListDeletedIds(ctx, r.reader, "server_worker", scopeIds, since)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return deleted, nil
+}
+
 // UpsertWorkerStatus will update the address and last status time for a worker.
 // If the worker is a kms worker that hasn't been seen yet, it'll attempt to
 // create a new one, but will return an error if another worker (kms or other)
 // has the same name.  This returns the Worker object with the changes applied.
 // The WithPublicId, WithKeyId, and WithUpdateTags options are
-// the only ones used. All others are ignored.
+// the only ones used. All others are ignored. Any proxy protocols set on the
+// worker replace the ones previously reported.
 // Workers are intentionally not oplogged.
 func (r *Repository) UpsertWorkerStatus(ctx context.Context, worker *Worker, opt ...Option) (*Worker, error) {
 	const op = "server.UpsertWorkerStatus"
@@ -304,7 +319,7 @@ func (r *Repository) UpsertWorkerStatus(ctx context.Context, worker *Worker, opt
 				// "description" since we want description changes for PKI-based
 				// workers to come via API only. We can't really guard on this
 				// in the DB so we need to be sure to not include it here.
-				n, err := w.Update(ctx, workerClone, []string{"address", "ReleaseVersion", "OperationalState"}, nil)
+				n, err := w.Update(ctx, workerClone, []string{"address", "ReleaseVersion", "OperationalState", "RecordingStorage"}, nil)
 				if err != nil {
 					return errors.Wrap(ctx, err, op, errors.WithMsg("unable to update status of pki worker"))
 				}
@@ -321,7 +336,7 @@ func (r *Repository) UpsertWorkerStatus(ctx context.Context, worker *Worker, opt
 				workerClone.Type = KmsWorkerType.String()
 				workerCreateConflict := &db.OnConflict{
 					Target: db.Columns{"public_id"},
-					Action: append(db.SetColumns([]string{"address", "release_version", "operational_state"}),
+					Action: append(db.SetColumns([]string{"address", "release_version", "operational_state", "recording_storage"}),
 						db.SetColumnValues(map[string]any{"last_status_time": "now()"})...),
 				}
 				var withRowsAffected int64
@@ -351,6 +366,15 @@ func (r *Repository) UpsertWorkerStatus(ctx context.Context, worker *Worker, opt
 				}
 			}
 
+			// Workers which don't report their proxy protocols only support
+			// tcp, which is assumed when there are none stored, so only
+			// replace the stored protocols when some were reported.
+			if len(workerClone.proxyProtocols) > 0 {
+				if err := setWorkerProxyProtocols(ctx, w, workerClone.GetPublicId(), workerClone.proxyProtocols); err != nil {
+					return errors.Wrap(ctx, err, op, errors.WithMsg("error setting worker proxy protocols"))
+				}
+			}
+
 			wAgg := &workerAggregate{PublicId: workerClone.GetPublicId()}
 			if err := reader.LookupById(ctx, wAgg); err != nil {
 				return errors.Wrap(ctx, err, op, errors.WithMsg("error looking up worker aggregate"))
@@ -370,6 +394,47 @@ func (r *Repository) UpsertWorkerStatus(ctx context.Context, worker *Worker, opt
 	return ret, nil
 }
 
+// setWorkerProxyProtocols removes all existing proxy protocols for the worker
+// id and creates new ones based on the ones provided.  This function should be
+// called from inside a db transaction.
+// Worker proxy protocols are intentionally not oplogged.
+func setWorkerProxyProtocols(ctx context.Context, w db.Writer, id string, protocols []string) error {
+	const op = "server.setWorkerProxyProtocols"
+	switch {
+	case id == "":
+		return errors.New(ctx, errors.InvalidParameter, op, "worker id is empty")
+	case isNil(w):
+		return errors.New(ctx, errors.InvalidParameter, op, "db.Writer is nil")
+	}
+	_, err := w.Exec(ctx, deleteProxyProtocolsByWorkerIdSql, []any{id})
+	if err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("couldn't delete existing proxy protocols for worker %q", id)))
+	}
+	if len(protocols) == 0 {
+		return nil
+	}
+
+	uProtocols := make([]any, 0, len(protocols))
+	seen := make(map[string]struct{}, len(protocols))
+	for _, p := range protocols {
+		if p == "" {
+			return errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("found empty proxy protocol for worker %s", id))
+		}
+		if _, ok := seen[p]; ok {
+			continue
+		}
+		seen[p] = struct{}{}
+		uProtocols = append(uProtocols, &store.WorkerProxyProtocol{
+			WorkerId: id,
+			Protocol: p,
+		})
+	}
+	if err = w.CreateItems(ctx, uProtocols); err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("error creating proxy protocols for worker %q", id)))
+	}
+	return nil
+}
+
 // setWorkerTags removes all existing tags from the same source and worker id
 // and creates new ones based on the ones provided.  This function should be
 // called from inside a db transaction.
@@ -484,6 +484,74 @@ func TestUpsertWorkerStatus(t *testing.T) {
 	})
 }
 
+func TestUpsertWorkerStatus_ProxyProtocols(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	require.NoError(t, kmsCache.CreateKeys(context.Background(), scope.Global.String(), kms.WithRandomReader(rand.Reader)))
+	repo, err := server.NewRepository(rw, rw, kmsCache)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	wStatus1 := server.NewWorker(scope.Global.String(),
+		server.WithAddress("address"), server.WithName("config_name_protocols"))
+	worker, err := repo.UpsertWorkerStatus(ctx, wStatus1)
+	require.NoError(t, err)
+	assert.Equal(t, []string{server.TcpProxyProtocol}, worker.GetProxyProtocols())
+
+	wStatus2 := server.NewWorker(scope.Global.String(),
+		server.WithAddress("address"), server.WithName("config_name_protocols"),
+		server.WithProxyProtocols("tcp", "ssh", "tcp"))
+	worker, err = repo.UpsertWorkerStatus(ctx, wStatus2)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ssh", "tcp"}, worker.GetProxyProtocols())
+
+	// Not reporting any protocols leaves the reported protocols in place
+	worker, err = repo.UpsertWorkerStatus(ctx, wStatus1)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ssh", "tcp"}, worker.GetProxyProtocols())
+
+	wStatus3 := server.NewWorker(scope.Global.String(),
+		server.WithAddress("address"), server.WithName("config_name_protocols"),
+		server.WithProxyProtocols("ssh"))
+	worker, err = repo.UpsertWorkerStatus(ctx, wStatus3)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ssh"}, worker.GetProxyProtocols())
+	assert.False(t, worker.SupportsProxyProtocol(server.TcpProxyProtocol))
+}
+
+func TestUpsertWorkerStatus_RecordingStorage(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	require.NoError(t, kmsCache.CreateKeys(context.Background(), scope.Global.String(), kms.WithRandomReader(rand.Reader)))
+	repo, err := server.NewRepository(rw, rw, kmsCache)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	wStatus1 := server.NewWorker(scope.Global.String(),
+		server.WithAddress("address"), server.WithName("config_name_recording"))
+	worker, err := repo.UpsertWorkerStatus(ctx, wStatus1)
+	require.NoError(t, err)
+	assert.False(t, worker.SupportsSessionRecording())
+
+	wStatus2 := server.NewWorker(scope.Global.String(),
+		server.WithAddress("address"), server.WithName("config_name_recording"),
+		server.WithRecordingStorage(true))
+	worker, err = repo.UpsertWorkerStatus(ctx, wStatus2)
+	require.NoError(t, err)
+	assert.True(t, worker.SupportsSessionRecording())
+
+	// A worker which no longer reports recording storage can no longer record
+	worker, err = repo.UpsertWorkerStatus(ctx, wStatus1)
+	require.NoError(t, err)
+	assert.False(t, worker.SupportsSessionRecording())
+}
+
 func TestTagUpdatingListing(t *testing.T) {
 	require := require.New(t)
 	conn, _ := db.TestSetup(t, "postgres")
@@ -12,3 +12,9 @@ func (*Controller) TableName() string {
 func (*WorkerTag) TableName() string {
 	return "server_worker_tag"
 }
+
+// TableName overrides the table name used by WorkerProxyProtocol to
+// `server_worker_proxy_protocol`
+func (*WorkerProxyProtocol) TableName() string {
+	return "server_worker_proxy_protocol"
+}
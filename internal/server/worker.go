@@ -27,6 +27,10 @@ const (
 	ActiveOperationalState   OperationalState = "active"
 	ShutdownOperationalState OperationalState = "shutdown"
 	UnknownOperationalState  OperationalState = "unknown"
+
+	// TcpProxyProtocol is the proxy protocol supported by workers which have
+	// not reported the proxy protocols they support.
+	TcpProxyProtocol = "tcp"
 )
 
 func (t WorkerType) Valid() bool {
@@ -86,9 +90,10 @@ func AttachWorkerIdToState(ctx context.Context, workerId string) (*structpb.Stru
 type Worker struct {
 	*store.Worker
 
-	activeConnectionCount uint32 `gorm:"-"`
-	apiTags               []*Tag `gorm:"-"`
-	configTags            []*Tag `gorm:"-"`
+	activeConnectionCount uint32   `gorm:"-"`
+	apiTags               []*Tag   `gorm:"-"`
+	configTags            []*Tag   `gorm:"-"`
+	proxyProtocols        []string `gorm:"-"`
 
 	// inputTags is not specified to be api or config tags and is not intended
 	// to be read by clients.  Since config tags and api tags are applied in
@@ -102,8 +107,8 @@ type Worker struct {
 }
 
 // NewWorker returns a new Worker. Valid options are WithName, WithDescription
-// WithAddress, and WithWorkerTags. All other options are ignored.  This does
-// not set any of the worker reported values.
+// WithAddress, WithWorkerTags, WithProxyProtocols and WithRecordingStorage.
+// All other options are ignored.  This does not set any of the worker reported values.
 func NewWorker(scopeId string, opt ...Option) *Worker {
 	opts := GetOpts(opt...)
 	return &Worker{
@@ -114,8 +119,10 @@ func NewWorker(scopeId string, opt ...Option) *Worker {
 			Address:          opts.withAddress,
 			ReleaseVersion:   opts.withReleaseVersion,
 			OperationalState: opts.withOperationalState,
+			RecordingStorage: opts.withRecordingStorage,
 		},
-		inputTags: opts.withWorkerTags,
+		inputTags:      opts.withWorkerTags,
+		proxyProtocols: opts.withProxyProtocols,
 	}
 }
 
@@ -150,6 +157,10 @@ func (w *Worker) clone() *Worker {
 			cWorker.inputTags = append(cWorker.inputTags, &Tag{Key: t.Key, Value: t.Value})
 		}
 	}
+	if w.proxyProtocols != nil {
+		cWorker.proxyProtocols = make([]string, len(w.proxyProtocols))
+		copy(cWorker.proxyProtocols, w.proxyProtocols)
+	}
 	return cWorker
 }
 
@@ -196,6 +207,33 @@ func (w *Worker) GetApiTags() map[string][]string {
 	return tags
 }
 
+// GetProxyProtocols returns the proxy protocols this worker has reported it
+// has a handler for. Workers which have never reported their proxy protocols
+// only support tcp, so in that case only tcp is returned.
+func (w *Worker) GetProxyProtocols() []string {
+	if len(w.proxyProtocols) == 0 {
+		return []string{TcpProxyProtocol}
+	}
+	return w.proxyProtocols
+}
+
+// SupportsProxyProtocol returns true if the worker has reported it has a
+// handler for the provided proxy protocol.
+func (w *Worker) SupportsProxyProtocol(protocol string) bool {
+	for _, p := range w.GetProxyProtocols() {
+		if p == protocol {
+			return true
+		}
+	}
+	return false
+}
+
+// SupportsSessionRecording returns true if the worker has reported it has
+// recording storage configured, and so is able to record sessions.
+func (w *Worker) SupportsSessionRecording() bool {
+	return w.GetRecordingStorage()
+}
+
 // GetLastStatusTime contains the last time the worker has reported to the
 // controller its connection status.  If the worker has never reported to a
 // controller then nil is returned.
@@ -227,9 +265,11 @@ type workerAggregate struct {
 	ApiTags               string
 	ActiveConnectionCount uint32
 	OperationalState      string
+	RecordingStorage      bool
 	// Config Fields
 	LastStatusTime   *timestamp.Timestamp
 	WorkerConfigTags string
+	ProxyProtocols   string
 }
 
 func (a *workerAggregate) toWorker(ctx context.Context) (*Worker, error) {
@@ -248,6 +288,7 @@ func (a *workerAggregate) toWorker(ctx context.Context) (*Worker, error) {
 			Type:             a.Type,
 			ReleaseVersion:   a.ReleaseVersion,
 			OperationalState: a.OperationalState,
+			RecordingStorage: a.RecordingStorage,
 		},
 		activeConnectionCount: a.ActiveConnectionCount,
 	}
@@ -263,9 +304,23 @@ func (a *workerAggregate) toWorker(ctx context.Context) (*Worker, error) {
 	}
 	worker.configTags = tags
 
+	worker.proxyProtocols = proxyProtocolsFromAggregatedString(a.ProxyProtocols)
+
 	return worker, nil
 }
 
+// proxyProtocolsFromAggregatedString parses a deliminated string in the format
+// returned by the database for the server_worker_aggregate view. The string is
+// in the format of protocol1Zprotocol2. Z is chosen for the deliminator since
+// protocols are restricted from having capitalized letters in them.
+func proxyProtocolsFromAggregatedString(s string) []string {
+	if s == "" {
+		return nil
+	}
+	const aggregateDelimiter = "Z"
+	return strings.Split(s, aggregateDelimiter)
+}
+
 // tagsForAggregatedTagString parses a deliminated string in the format returned
 // by the database for the server_worker_aggregate view and returns []*Tag.
 // The string is in the format of key1Yvalue1Zkey2Yvalue2Zkey3Yvalue3. Y and Z
@@ -42,6 +42,24 @@ func TestWorkerCanonicalTags(t *testing.T) {
 	assert.ElementsMatch(t, got["key3"], []string{"configs key3 unique"})
 }
 
+func TestWorkerProxyProtocols(t *testing.T) {
+	w := NewWorker(scope.Global.String())
+	assert.Equal(t, []string{TcpProxyProtocol}, w.GetProxyProtocols())
+	assert.True(t, w.SupportsProxyProtocol(TcpProxyProtocol))
+	assert.False(t, w.SupportsProxyProtocol("ssh"))
+
+	w = NewWorker(scope.Global.String(), WithProxyProtocols("ssh", "tcp"))
+	assert.Equal(t, []string{"ssh", "tcp"}, w.GetProxyProtocols())
+	assert.True(t, w.SupportsProxyProtocol("ssh"))
+	assert.False(t, w.SupportsProxyProtocol("http"))
+
+	w = NewWorker(scope.Global.String(), WithProxyProtocols("ssh"))
+	assert.False(t, w.SupportsProxyProtocol(TcpProxyProtocol))
+
+	assert.Nil(t, proxyProtocolsFromAggregatedString(""))
+	assert.Equal(t, []string{"ssh", "tcp"}, proxyProtocolsFromAggregatedString("sshZtcp"))
+}
+
 func TestWorkerAggregate(t *testing.T) {
 	t.Parallel()
 	conn, _ := db.TestSetup(t, "postgres")
@@ -99,6 +117,25 @@ func TestWorkerAggregate(t *testing.T) {
 		assert.Equal(t, "address", got.GetAddress())
 	})
 
+	t.Run("Worker with proxy protocols", func(t *testing.T) {
+		id, err := newWorkerId(ctx)
+		require.NoError(t, err)
+		w := NewWorker(scope.Global.String(),
+			WithAddress("address"),
+			WithName(strings.ToLower(id)))
+		w.Type = KmsWorkerType.String()
+		w.PublicId = id
+		require.NoError(t, rw.Create(ctx, w))
+		require.NoError(t, rw.CreateItems(ctx, []any{
+			&store.WorkerProxyProtocol{WorkerId: id, Protocol: "tcp"},
+			&store.WorkerProxyProtocol{WorkerId: id, Protocol: "ssh"},
+		}))
+
+		got := getAggWorker(id)
+		assert.Equal(t, id, got.GetPublicId())
+		assert.Equal(t, []string{"ssh", "tcp"}, got.GetProxyProtocols())
+	})
+
 	t.Run("Worker with a config tag", func(t *testing.T) {
 		id, err := newWorkerId(ctx)
 		require.NoError(t, err)
@@ -0,0 +1,59 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package session
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+)
+
+// ActiveSessionCount is the number of pending and active sessions of a user
+// on a target.
+type ActiveSessionCount struct {
+	ProjectId string
+	// OrgId is the id of the org containing the project.
+	OrgId    string
+	TargetId string
+	Count    int
+}
+
+// ListActiveSessionCounts returns the number of pending and active sessions
+// of userId on each target it has such sessions on.
+func (r *Repository) ListActiveSessionCounts(ctx context.Context, userId string) ([]*ActiveSessionCount, error) {
+	const op = "session.(Repository).ListActiveSessionCounts"
+	if userId == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing user id")
+	}
+	counts, err := listActiveSessionCounts(ctx, r.reader, userId)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return counts, nil
+}
+
+// listActiveSessionCounts counts the pending and active sessions of userId
+// using the reader, which may be part of a transaction.
+func listActiveSessionCounts(ctx context.Context, reader db.Reader, userId string) ([]*ActiveSessionCount, error) {
+	const op = "session.listActiveSessionCounts"
+	rows, err := reader.Query(ctx, activeSessionCounts, []any{sql.Named("user_id", userId)})
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	defer rows.Close()
+	var counts []*ActiveSessionCount
+	for rows.Next() {
+		var c ActiveSessionCount
+		if err := rows.Scan(&c.ProjectId, &c.OrgId, &c.TargetId, &c.Count); err != nil {
+			return nil, errors.Wrap(ctx, err, op, errors.WithMsg("scan row failed"))
+		}
+		counts = append(counts, &c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return counts, nil
+}
@@ -45,6 +45,10 @@ type Connection struct {
 	// Version of the connection
 	Version uint32 `json:"version,omitempty" gorm:"default:null"`
 
+	// States of the connection in descending order of start time, which are
+	// for read only and are ignored during write operations
+	States []*ConnectionState `gorm:"-"`
+
 	tableName string `gorm:"-"`
 }
 
@@ -52,6 +56,26 @@ func (c *Connection) GetPublicId() string {
 	return c.PublicId
 }
 
+// Status returns the current status of the connection, or an empty status if
+// the states of the connection were not read.
+func (c *Connection) Status() ConnectionStatus {
+	if len(c.States) == 0 {
+		return ""
+	}
+	return c.States[0].Status
+}
+
+// StateStartTime returns the time the connection entered the given status, or
+// nil if it never did or the states of the connection were not read.
+func (c *Connection) StateStartTime(status ConnectionStatus) *timestamp.Timestamp {
+	for _, s := range c.States {
+		if s.Status == status {
+			return s.StartTime
+		}
+	}
+	return nil
+}
+
 var (
 	_ Cloneable       = (*Connection)(nil)
 	_ db.VetForWriter = (*Connection)(nil)
@@ -111,6 +135,12 @@ func (c *Connection) Clone() any {
 			},
 		}
 	}
+	if c.States != nil {
+		clone.States = make([]*ConnectionState, 0, len(c.States))
+		for _, s := range c.States {
+			clone.States = append(clone.States, s.Clone().(*ConnectionState))
+		}
+	}
 	return clone
 }
 
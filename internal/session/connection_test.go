@@ -8,10 +8,12 @@ import (
 	"testing"
 
 	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/db/timestamp"
 	"github.com/hashicorp/boundary/internal/errors"
 	"github.com/hashicorp/boundary/internal/iam"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 func TestConnection_Create(t *testing.T) {
@@ -277,3 +279,37 @@ func TestConnection_SetTableName(t *testing.T) {
 		})
 	}
 }
+
+func TestConnection_Status(t *testing.T) {
+	t.Parallel()
+	ts := func(s int64) *timestamp.Timestamp {
+		return &timestamp.Timestamp{Timestamp: &timestamppb.Timestamp{Seconds: s}}
+	}
+	t.Run("no-states", func(t *testing.T) {
+		assert := assert.New(t)
+		c := AllocConnection()
+		assert.Equal(ConnectionStatus(""), c.Status())
+		assert.Nil(c.StateStartTime(StatusConnected))
+	})
+	t.Run("closed", func(t *testing.T) {
+		assert := assert.New(t)
+		c := AllocConnection()
+		c.States = []*ConnectionState{
+			{Status: StatusClosed, StartTime: ts(30)},
+			{Status: StatusConnected, StartTime: ts(20), EndTime: ts(30)},
+			{Status: StatusAuthorized, StartTime: ts(10), EndTime: ts(20)},
+		}
+		assert.Equal(StatusClosed, c.Status())
+		assert.Equal(ts(20), c.StateStartTime(StatusConnected))
+		assert.Equal(ts(30), c.StateStartTime(StatusClosed))
+	})
+	t.Run("never-connected", func(t *testing.T) {
+		assert := assert.New(t)
+		c := AllocConnection()
+		c.States = []*ConnectionState{
+			{Status: StatusAuthorized, StartTime: ts(10)},
+		}
+		assert.Equal(StatusAuthorized, c.Status())
+		assert.Nil(c.StateStartTime(StatusConnected))
+	})
+}
@@ -4,6 +4,7 @@
 package session
 
 import (
+	"context"
 	"crypto/rand"
 	"io"
 	"time"
@@ -40,6 +41,10 @@ type options struct {
 	withPermissions              *perms.UserPermissions
 	withIgnoreDecryptionFailures bool
 	withRandomReader             io.Reader
+	withPage                     *db.Page
+	withWhereClause              string
+	withWhereClauseArgs          []any
+	withActiveSessionCountCheck  func(context.Context, []*ActiveSessionCount) error
 }
 
 func getDefaultOptions() options {
@@ -66,6 +71,15 @@ func WithOrderByCreateTime(orderBy db.OrderBy) Option {
 	}
 }
 
+// WithPage provides an option to list one page of sessions. The page's order
+// replaces the order provided with WithOrderByCreateTime. Use WithLimit to set
+// the size of the page.
+func WithPage(p *db.Page) Option {
+	return func(o *options) {
+		o.withPage = p
+	}
+}
+
 // WithProjectIds allows specifying a project ID criteria for the function.
 func WithProjectIds(projectIds []string) Option {
 	return func(o *options) {
@@ -124,6 +138,16 @@ func WithTerminated(withTerminated bool) Option {
 	}
 }
 
+// WithWhereClause is used to restrict a list request to the sessions matching
+// a where clause using named args, such as the where clause of a list filter
+// translated using ListFilterColumns.
+func WithWhereClause(where string, args []any) Option {
+	return func(o *options) {
+		o.withWhereClause = where
+		o.withWhereClauseArgs = args
+	}
+}
+
 // WithPermissions is used to include user permissions when constructing a
 // Repository.
 func WithPermissions(p *perms.UserPermissions) Option {
@@ -142,6 +166,17 @@ func WithIgnoreDecryptionFailures(ignoreFailures bool) Option {
 	}
 }
 
+// WithActiveSessionCountCheck is used by CreateSession to check the counts
+// of the user's pending and active sessions, as returned by
+// ListActiveSessionCounts, in the transaction creating the session. The
+// creation of the user's sessions is serialized while the check runs, and the
+// session is not created if it returns an error, which is returned as-is.
+func WithActiveSessionCountCheck(fn func(context.Context, []*ActiveSessionCount) error) Option {
+	return func(o *options) {
+		o.withActiveSessionCountCheck = fn
+	}
+}
+
 // WithRandomReader is used to configure the random source
 // to use when generating secrets. Defaults to crypto/rand.Reader.
 func WithRandomReader(rand io.Reader) Option {
@@ -434,6 +434,85 @@ where session_id = ?
 `
 )
 
+const (
+	// lockUserSessions takes a lock, held until the end of the transaction,
+	// which serializes the creation of the sessions of a user whose session
+	// limits are checked.
+	lockUserSessions = `
+select pg_advisory_xact_lock(hashtext('session_limit'), hashtext(@user_id));
+`
+
+	// targetSessionMaxSeconds selects the session_max_seconds of the target
+	// of a session. It caps how far in the future a session can be extended.
+	targetSessionMaxSeconds = `
+select session_max_seconds
+  from target_all_subtypes
+ where public_id = @target_id;
+`
+
+	// activeSessionCounts counts the pending and active sessions of a user
+	// per project and target, along with the org of each project.
+	activeSessionCounts = `
+  select coalesce(s.project_id, '') as project_id,
+         coalesce(p.parent_id, '') as org_id,
+         coalesce(s.target_id, '') as target_id,
+         count(*) as count
+    from session s
+    join session_state ss
+      on ss.session_id = s.public_id
+     and ss.end_time is null
+     and ss.state in ('pending', 'active')
+    left join iam_scope p
+      on p.public_id = s.project_id
+   where s.user_id = @user_id
+group by s.project_id, p.parent_id, s.target_id;
+`
+)
+
+const (
+	// listStateChangesTemplate lists the states of the sessions, and of their
+	// connections, which started after @since. The where clause selecting the
+	// sessions is added using fmt.Sprintf.
+	listStateChangesTemplate = `
+with
+sessions (public_id, project_id, user_id, target_id) as (
+  select public_id,
+         project_id,
+         coalesce(user_id, ''),
+         coalesce(target_id, '')
+    from session
+   where %s
+)
+  select s.public_id as session_id,
+         s.project_id,
+         s.user_id,
+         s.target_id,
+         '' as connection_id,
+         ss.state as status,
+         ss.start_time
+    from session_state ss
+    join sessions s
+      on s.public_id = ss.session_id
+   where ss.start_time > @since
+union all
+  select s.public_id as session_id,
+         s.project_id,
+         s.user_id,
+         s.target_id,
+         sc.public_id as connection_id,
+         scs.state as status,
+         scs.start_time
+    from session_connection_state scs
+    join session_connection sc
+      on sc.public_id = scs.connection_id
+    join sessions s
+      on s.public_id = sc.session_id
+   where scs.start_time > @since
+order by start_time, session_id, connection_id
+   limit @limit;
+`
+)
+
 const (
 	sessionCredentialDynamicBatchInsertBase = `
 insert into session_credential_dynamic
@@ -104,6 +104,10 @@ func (r *Repository) listPermissionWhereClauses() ([]string, []any) {
 			clauses = append(clauses, fmt.Sprintf("public_id = any(@public_id_%d)", inClauseCnt))
 			args = append(args, sql.Named(fmt.Sprintf("public_id_%d", inClauseCnt), "{"+strings.Join(p.ResourceIds, ",")+"}"))
 		}
+		if len(p.DeniedResourceIds) > 0 {
+			clauses = append(clauses, fmt.Sprintf("public_id != all(@denied_id_%d)", inClauseCnt))
+			args = append(args, sql.Named(fmt.Sprintf("denied_id_%d", inClauseCnt), "{"+strings.Join(p.DeniedResourceIds, ",")+"}"))
+		}
 
 		if p.OnlySelf {
 			inClauseCnt++
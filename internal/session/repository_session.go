@@ -14,8 +14,10 @@ import (
 	"github.com/hashicorp/boundary/internal/db"
 	"github.com/hashicorp/boundary/internal/db/timestamp"
 	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/filter"
 	"github.com/hashicorp/boundary/internal/kms"
 	"github.com/hashicorp/boundary/internal/observability/event"
+	"github.com/hashicorp/boundary/internal/pagination"
 	"github.com/hashicorp/boundary/internal/util"
 	wrapping "github.com/hashicorp/go-kms-wrapping/v2"
 )
@@ -24,7 +26,7 @@ import (
 // its State of "Pending".  The following fields must be empty when creating a
 // session: WorkerId, and PublicId.  No options are
 // currently supported.
-func (r *Repository) CreateSession(ctx context.Context, sessionWrapper wrapping.Wrapper, newSession *Session, workerAddresses []string, _ ...Option) (*Session, error) {
+func (r *Repository) CreateSession(ctx context.Context, sessionWrapper wrapping.Wrapper, newSession *Session, workerAddresses []string, opt ...Option) (*Session, error) {
 	const op = "session.(Repository).CreateSession"
 	if newSession == nil {
 		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing session")
@@ -79,12 +81,30 @@ func (r *Repository) CreateSession(ctx context.Context, sessionWrapper wrapping.
 		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("failed to encrypt session"))
 	}
 
+	opts := getOpts(opt...)
+
 	var returnedSession *Session
+	var checkErr error
 	_, err = r.writer.DoTx(
 		ctx,
 		db.StdRetryCnt,
 		db.ExpBackoff{},
 		func(read db.Reader, w db.Writer) error {
+			if opts.withActiveSessionCountCheck != nil {
+				// Hold a lock on the user's sessions until the transaction
+				// ends, so that concurrent requests cannot all pass the check
+				if _, err := w.Exec(ctx, lockUserSessions, []any{sql.Named("user_id", newSession.UserId)}); err != nil {
+					return errors.Wrap(ctx, err, op, errors.WithMsg("unable to lock user sessions"))
+				}
+				counts, err := listActiveSessionCounts(ctx, read, newSession.UserId)
+				if err != nil {
+					return errors.Wrap(ctx, err, op)
+				}
+				if checkErr = opts.withActiveSessionCountCheck(ctx, counts); checkErr != nil {
+					return checkErr
+				}
+			}
+
 			returnedSession = newSession.Clone().(*Session)
 			returnedSession.DynamicCredentials = nil
 			returnedSession.StaticCredentials = nil
@@ -168,6 +188,9 @@ func (r *Repository) CreateSession(ctx context.Context, sessionWrapper wrapping.
 			return nil
 		},
 	)
+	if checkErr != nil {
+		return nil, checkErr
+	}
 	if err != nil {
 		return nil, errors.Wrap(ctx, err, op)
 	}
@@ -257,6 +280,22 @@ func (r *Repository) LookupSession(ctx context.Context, sessionId string, opt ..
 	return &session, authzSummary, nil
 }
 
+// ListFilterColumns maps the fields of the sessions returned by the api to
+// the columns of the sessions listed by ListSessions. It is used to translate
+// list filters into where clauses for WithWhereClause.
+var ListFilterColumns = filter.Columns{
+	"/item/id":                 "public_id",
+	"/item/scope_id":           "project_id",
+	"/item/target_id":          "target_id",
+	"/item/user_id":            "user_id",
+	"/item/host_id":            "host_id",
+	"/item/host_set_id":        "host_set_id",
+	"/item/auth_token_id":      "auth_token_id",
+	"/item/endpoint":           "endpoint",
+	"/item/termination_reason": "termination_reason",
+	"/item/status":             "(select ss.state from session_state ss where ss.session_id = s.public_id and ss.end_time is null)",
+}
+
 // ListSessions lists sessions. Sessions returned will be limited by the list
 // permissions of the repository. Supports the WithTerminated, WithLimit,
 // WithOrderByCreateTime options.
@@ -269,16 +308,13 @@ func (r *Repository) ListSessions(ctx context.Context, opt ...Option) ([]*Sessio
 		return nil, nil
 	}
 
-	var whereClause string
-	if len(where) > 0 {
-		whereClause = " where (" + strings.Join(where, " or ") + ")"
-		if !opts.withTerminated {
-			whereClause += "and termination_reason is null"
-		}
-	} else {
-		if !opts.withTerminated {
-			whereClause = "where termination_reason is null"
-		}
+	whereClause := "(" + strings.Join(where, " or ") + ")"
+	if !opts.withTerminated {
+		whereClause += " and termination_reason is null"
+	}
+	if opts.withWhereClause != "" {
+		whereClause += " and (" + opts.withWhereClause + ")"
+		args = append(args, opts.withWhereClauseArgs...)
 	}
 
 	var limit string
@@ -291,14 +327,16 @@ func (r *Repository) ListSessions(ctx context.Context, opt ...Option) ([]*Sessio
 		limit = fmt.Sprintf("limit %d", opts.withLimit)
 	}
 	var withOrder string
-	switch opts.withOrderByCreateTime {
-	case db.AscendingOrderBy:
+	switch {
+	case opts.withPage != nil:
+		whereClause, args = opts.withPage.Where(whereClause, args)
+		withOrder = "order by " + opts.withPage.Order()
+	case opts.withOrderByCreateTime == db.AscendingOrderBy:
 		withOrder = "order by create_time asc"
-	case db.DescendingOrderBy:
-		fallthrough
 	default:
 		withOrder = "order by create_time"
 	}
+	whereClause = "where " + whereClause
 
 	q := sessionList
 	query := fmt.Sprintf(q, whereClause, withOrder, limit, withOrder)
@@ -324,6 +362,16 @@ func (r *Repository) ListSessions(ctx context.Context, opt ...Option) ([]*Sessio
 	return sessions, nil
 }
 
+// ListDeletedIds returns the sessions in one of projectIds deleted after since.
+func (r *Repository) ListDeletedIds(ctx context.Context, projectIds []string, since time.Time) ([]*pagination.DeletedResource, error) {
+	const op = "session.(Repository).ListDeletedIds"
+	deleted, err := pagination.ListDeletedIds(ctx, r.reader, "session", projectIds, since)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return deleted, nil
+}
+
 // DeleteSession will delete a session from the repository.
 func (r *Repository) DeleteSession(ctx context.Context, publicId string, _ ...Option) (int, error) {
 	const op = "session.(Repository).DeleteSession"
@@ -387,6 +435,115 @@ func (r *Repository) CancelSession(ctx context.Context, sessionId string, sessio
 	return s, nil
 }
 
+// ExtendSession extends the expiration time of a pending or active session,
+// which has not expired yet, by the given duration. The session can not be
+// extended to expire more than the session_max_seconds of its target from
+// now. The workers proxying the connections of the session get the new
+// expiration time when they look up the session.
+// Supported Options:
+//   - WithIgnoreDecryptionFailures
+func (r *Repository) ExtendSession(ctx context.Context, sessionId string, sessionVersion uint32, duration time.Duration, opt ...Option) (*Session, error) {
+	const op = "session.(Repository).ExtendSession"
+	if sessionId == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing session id")
+	}
+	if sessionVersion == 0 {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing session version")
+	}
+	if duration <= 0 {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "duration must be greater than zero")
+	}
+	opts := getOpts(opt...)
+
+	updatedSession := AllocSession()
+	_, err := r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(reader db.Reader, w db.Writer) error {
+			current := AllocSession()
+			current.PublicId = sessionId
+			if err := reader.LookupById(ctx, &current); err != nil {
+				return errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("failed for %s", sessionId)))
+			}
+			states, err := fetchStates(ctx, reader, sessionId, db.WithOrder("start_time desc"))
+			if err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			if len(states) == 0 || (states[0].Status != StatusPending && states[0].Status != StatusActive) {
+				return errors.New(ctx, errors.InvalidSessionState, op, fmt.Sprintf("session %s is not pending or active", sessionId))
+			}
+			now := time.Now()
+			expiration := current.ExpirationTime.AsTime()
+			if !expiration.After(now) {
+				return errors.New(ctx, errors.InvalidSessionState, op, fmt.Sprintf("session %s has expired", sessionId))
+			}
+			maxSeconds, err := targetSessionMaxSecondsTx(ctx, reader, current.TargetId)
+			if err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			newExpiration := expiration.Add(duration)
+			if newExpiration.After(now.Add(time.Duration(maxSeconds) * time.Second)) {
+				return errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("session %s can not be extended to expire more than %d seconds from now", sessionId, maxSeconds))
+			}
+
+			updatedSession.PublicId = sessionId
+			updatedSession.ExpirationTime = timestamp.New(newExpiration)
+			updatedSession.Version = sessionVersion + 1
+			rowsUpdated, err := w.Update(ctx, &updatedSession, []string{"ExpirationTime", "Version"}, nil, db.WithVersion(&sessionVersion))
+			if err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			if rowsUpdated == 0 {
+				return errors.New(ctx, errors.VersionMismatch, op, fmt.Sprintf("session %s is not at version %d", sessionId, sessionVersion))
+			}
+			if rowsUpdated != 1 {
+				return errors.New(ctx, errors.MultipleRecords, op, fmt.Sprintf("updated session and %d rows updated", rowsUpdated))
+			}
+			if err := decryptAndMaybeUpdateSession(ctx, r.kms, &updatedSession, w); err != nil && !opts.withIgnoreDecryptionFailures {
+				return errors.Wrap(ctx, err, op)
+			}
+			updatedSession.States = states
+			hostSetHost, err := fetchHostSetHost(ctx, reader, sessionId)
+			if err != nil && !errors.IsNotFoundError(err) {
+				return errors.Wrap(ctx, err, op)
+			}
+			updatedSession.HostId = hostSetHost.HostId
+			updatedSession.HostSetId = hostSetHost.HostSetId
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to extend session"))
+	}
+	return &updatedSession, nil
+}
+
+// targetSessionMaxSecondsTx returns the session_max_seconds of the target. An
+// InvalidSessionState error is returned if the target has been deleted.
+func targetSessionMaxSecondsTx(ctx context.Context, reader db.Reader, targetId string) (uint32, error) {
+	const op = "session.targetSessionMaxSecondsTx"
+	if targetId == "" {
+		return 0, errors.New(ctx, errors.InvalidSessionState, op, "the target of the session has been deleted")
+	}
+	rows, err := reader.Query(ctx, targetSessionMaxSeconds, []any{sql.Named("target_id", targetId)})
+	if err != nil {
+		return 0, errors.Wrap(ctx, err, op)
+	}
+	defer rows.Close()
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return 0, errors.Wrap(ctx, err, op)
+		}
+		return 0, errors.New(ctx, errors.InvalidSessionState, op, fmt.Sprintf("target %s of the session has been deleted", targetId))
+	}
+	var maxSeconds uint32
+	if err := rows.Scan(&maxSeconds); err != nil {
+		return 0, errors.Wrap(ctx, err, op, errors.WithMsg("scan row failed"))
+	}
+	return maxSeconds, nil
+}
+
 // TerminateCompletedSessions will terminate sessions in the repo based on:
 //   - sessions that have exhausted their connection limit and all their connections are closed.
 //   - sessions that are expired and all their connections are closed.
@@ -779,6 +936,17 @@ func fetchConnections(ctx context.Context, r db.Reader, sessionId string, opt ..
 	if len(connections) == 0 {
 		return nil, nil
 	}
+	var states []*ConnectionState
+	if err := r.SearchWhere(ctx, &states, "connection_id in (select public_id from session_connection where session_id = ?)", []any{sessionId}, db.WithOrder("start_time desc")); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	statesByConnection := make(map[string][]*ConnectionState, len(connections))
+	for _, s := range states {
+		statesByConnection[s.ConnectionId] = append(statesByConnection[s.ConnectionId], s)
+	}
+	for _, c := range connections {
+		c.States = statesByConnection[c.PublicId]
+	}
 	return connections, nil
 }
 
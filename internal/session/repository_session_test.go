@@ -5,7 +5,9 @@ package session
 
 import (
 	"context"
+	stderrors "errors"
 	"fmt"
+	"sync"
 	"testing"
 	"time"
 
@@ -499,6 +501,74 @@ func TestRepository_CreateSession(t *testing.T) {
 	}
 }
 
+func TestRepository_CreateSession_ActiveSessionCountCheck(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	ctx := context.Background()
+	repo, err := NewRepository(ctx, rw, rw, kmsCache)
+	require.NoError(t, err)
+
+	composedOf := TestSessionParams(t, conn, wrapper, iamRepo)
+	sessionWrapper, err := kmsCache.GetWrapper(ctx, composedOf.ProjectId, kms.KeyPurposeSessions)
+	require.NoError(t, err)
+
+	const maxSessions = 2
+	errLimit := stderrors.New("limit reached")
+	check := func(_ context.Context, counts []*ActiveSessionCount) error {
+		var total int
+		for _, c := range counts {
+			total += c.Count
+		}
+		if total >= maxSessions {
+			return errLimit
+		}
+		return nil
+	}
+
+	// Concurrent requests must not be able to exceed the limit
+	const requests = 5
+	errs := make(chan error, requests)
+	var wg sync.WaitGroup
+	for i := 0; i < requests; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s := &Session{
+				UserId:         composedOf.UserId,
+				HostId:         composedOf.HostId,
+				TargetId:       composedOf.TargetId,
+				HostSetId:      composedOf.HostSetId,
+				AuthTokenId:    composedOf.AuthTokenId,
+				ProjectId:      composedOf.ProjectId,
+				Endpoint:       composedOf.Endpoint,
+				ExpirationTime: composedOf.ExpirationTime,
+			}
+			_, err := repo.CreateSession(ctx, sessionWrapper, s, []string{"1.2.3.4"}, WithActiveSessionCountCheck(check))
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	var created int
+	for err := range errs {
+		if err == nil {
+			created++
+			continue
+		}
+		assert.ErrorIs(t, err, errLimit)
+	}
+	assert.Equal(t, maxSessions, created)
+
+	counts, err := repo.ListActiveSessionCounts(ctx, composedOf.UserId)
+	require.NoError(t, err)
+	require.Len(t, counts, 1)
+	assert.Equal(t, maxSessions, counts[0].Count)
+}
 func TestRepository_updateState(t *testing.T) {
 	t.Parallel()
 	conn, _ := db.TestSetup(t, "postgres")
@@ -1129,6 +1199,151 @@ func TestRepository_CancelSession(t *testing.T) {
 	}
 }
 
+func TestRepository_ExtendSession(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	testKms := kms.TestKms(t, conn, wrapper)
+	repo, err := NewRepository(ctx, rw, rw, testKms)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		session     func() *Session
+		version     func(*Session) uint32
+		duration    time.Duration
+		wantIsError errors.Code
+	}{
+		{
+			name:     "valid",
+			session:  func() *Session { return TestDefaultSession(t, conn, wrapper, iamRepo) },
+			duration: time.Hour,
+		},
+		{
+			name: "active",
+			session: func() *Session {
+				s := TestDefaultSession(t, conn, wrapper, iamRepo)
+				s, _, err := repo.ActivateSession(ctx, s.PublicId, s.Version, TestTofu(t))
+				require.NoError(t, err)
+				return s
+			},
+			duration: time.Hour,
+		},
+		{
+			name:        "missing-session-id",
+			session:     func() *Session { return &Session{Version: 1} },
+			duration:    time.Hour,
+			wantIsError: errors.InvalidParameter,
+		},
+		{
+			name:        "missing-version",
+			session:     func() *Session { return TestDefaultSession(t, conn, wrapper, iamRepo) },
+			version:     func(*Session) uint32 { return 0 },
+			duration:    time.Hour,
+			wantIsError: errors.InvalidParameter,
+		},
+		{
+			name:        "zero-duration",
+			session:     func() *Session { return TestDefaultSession(t, conn, wrapper, iamRepo) },
+			wantIsError: errors.InvalidParameter,
+		},
+		{
+			name:        "version-mismatch",
+			session:     func() *Session { return TestDefaultSession(t, conn, wrapper, iamRepo) },
+			version:     func(s *Session) uint32 { return s.Version + 1 },
+			duration:    time.Hour,
+			wantIsError: errors.VersionMismatch,
+		},
+		{
+			name: "canceling",
+			session: func() *Session {
+				s := TestDefaultSession(t, conn, wrapper, iamRepo)
+				s, err := repo.CancelSession(ctx, s.PublicId, s.Version)
+				require.NoError(t, err)
+				return s
+			},
+			duration:    time.Hour,
+			wantIsError: errors.InvalidSessionState,
+		},
+		{
+			name: "terminated",
+			session: func() *Session {
+				s := TestDefaultSession(t, conn, wrapper, iamRepo)
+				_ = TestState(t, conn, s.PublicId, StatusTerminated)
+				s, _, err := repo.LookupSession(ctx, s.PublicId)
+				require.NoError(t, err)
+				return s
+			},
+			duration:    time.Hour,
+			wantIsError: errors.InvalidSessionState,
+		},
+		{
+			name: "expired",
+			session: func() *Session {
+				s := TestDefaultSession(t, conn, wrapper, iamRepo)
+				_, err := rw.Exec(ctx, "update session set expiration_time = now() - interval '1 minute' where public_id = ?", []any{s.PublicId})
+				require.NoError(t, err)
+				s, _, err = repo.LookupSession(ctx, s.PublicId)
+				require.NoError(t, err)
+				return s
+			},
+			duration:    time.Hour,
+			wantIsError: errors.InvalidSessionState,
+		},
+		{
+			name:    "beyond-target-session-max-seconds",
+			session: func() *Session { return TestDefaultSession(t, conn, wrapper, iamRepo) },
+			// The session expires in an hour and its target's
+			// session_max_seconds is 8 hours.
+			duration:    8 * time.Hour,
+			wantIsError: errors.InvalidParameter,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			orig := tt.session()
+			if orig.PublicId != "" {
+				// Compare against the expiration time as stored.
+				var err error
+				orig, _, err = repo.LookupSession(ctx, orig.PublicId)
+				require.NoError(err)
+			}
+			version := orig.Version
+			if tt.version != nil {
+				version = tt.version(orig)
+			}
+			got, err := repo.ExtendSession(ctx, orig.PublicId, version, tt.duration)
+			if tt.wantIsError != 0 {
+				require.Error(err)
+				assert.Truef(errors.Match(errors.T(tt.wantIsError), err), "unexpected error %s", err.Error())
+				assert.Nil(got)
+				if orig.PublicId == "" {
+					return
+				}
+				found, _, err := repo.LookupSession(ctx, orig.PublicId)
+				require.NoError(err)
+				assert.Equal(orig.ExpirationTime.AsTime(), found.ExpirationTime.AsTime())
+				assert.Equal(orig.Version, found.Version)
+				return
+			}
+			require.NoError(err)
+			require.NotNil(got)
+			assert.Equal(orig.ExpirationTime.AsTime().Add(tt.duration), got.ExpirationTime.AsTime())
+			assert.Equal(orig.Version+1, got.Version)
+			assert.Equal(orig.States[0].Status, got.States[0].Status)
+
+			found, _, err := repo.LookupSession(ctx, orig.PublicId)
+			require.NoError(err)
+			assert.Equal(got.ExpirationTime.AsTime(), found.ExpirationTime.AsTime())
+			assert.Equal(got.Version, found.Version)
+		})
+	}
+}
+
 func TestRepository_CancelSessionViaFKNull(t *testing.T) {
 	t.Parallel()
 	conn, _ := db.TestSetup(t, "postgres")
@@ -63,6 +63,14 @@ type ComposedOf struct {
 	// StaticCredentials are static credentials that will be retrieved
 	// for the session. StaticCredentials optional.
 	StaticCredentials []*StaticCredential
+	// EnableSessionRecording is whether the connections of the session are
+	// recorded. It is the value set on the target when the session was
+	// created.
+	EnableSessionRecording bool
+	// IdleTimeout is the idle timeout of the connections of the session in
+	// seconds. It is the value set on the target when the session was
+	// created.
+	IdleTimeout uint32
 }
 
 // Session contains information about a user's session with a target
@@ -110,6 +118,14 @@ type Session struct {
 	EgressWorkerFilter  string `json:"-" gorm:"default:null"`
 	IngressWorkerFilter string `json:"-" gorm:"default:null"`
 
+	// EnableSessionRecording is whether the connections of the session are
+	// recorded
+	EnableSessionRecording bool `json:"-" gorm:"default:null"`
+
+	// IdleTimeout is the idle timeout of the connections of the session in
+	// seconds
+	IdleTimeout uint32 `json:"-" gorm:"default:null"`
+
 	// key_id is the ID of the key version used to encrypt any fields in this struct
 	KeyId string `json:"key_id,omitempty" gorm:"default:null"`
 
@@ -147,6 +163,14 @@ func (s Session) GetUserId() string {
 	return s.UserId
 }
 
+func (s Session) GetCreateTime() *timestamp.Timestamp {
+	return s.CreateTime
+}
+
+func (s Session) GetUpdateTime() *timestamp.Timestamp {
+	return s.UpdateTime
+}
+
 var (
 	_ Cloneable                     = (*Session)(nil)
 	_ db.VetForWriter               = (*Session)(nil)
@@ -157,20 +181,22 @@ var (
 func New(c ComposedOf, _ ...Option) (*Session, error) {
 	const op = "session.New"
 	s := Session{
-		UserId:              c.UserId,
-		HostId:              c.HostId,
-		TargetId:            c.TargetId,
-		HostSetId:           c.HostSetId,
-		AuthTokenId:         c.AuthTokenId,
-		ProjectId:           c.ProjectId,
-		Endpoint:            c.Endpoint,
-		ExpirationTime:      c.ExpirationTime,
-		ConnectionLimit:     c.ConnectionLimit,
-		WorkerFilter:        c.WorkerFilter,
-		EgressWorkerFilter:  c.EgressWorkerFilter,
-		IngressWorkerFilter: c.IngressWorkerFilter,
-		DynamicCredentials:  c.DynamicCredentials,
-		StaticCredentials:   c.StaticCredentials,
+		UserId:                 c.UserId,
+		HostId:                 c.HostId,
+		TargetId:               c.TargetId,
+		HostSetId:              c.HostSetId,
+		AuthTokenId:            c.AuthTokenId,
+		ProjectId:              c.ProjectId,
+		Endpoint:               c.Endpoint,
+		ExpirationTime:         c.ExpirationTime,
+		ConnectionLimit:        c.ConnectionLimit,
+		WorkerFilter:           c.WorkerFilter,
+		EgressWorkerFilter:     c.EgressWorkerFilter,
+		IngressWorkerFilter:    c.IngressWorkerFilter,
+		DynamicCredentials:     c.DynamicCredentials,
+		StaticCredentials:      c.StaticCredentials,
+		EnableSessionRecording: c.EnableSessionRecording,
+		IdleTimeout:            c.IdleTimeout,
 	}
 	if err := s.validateNewSession(); err != nil {
 		return nil, errors.WrapDeprecated(err, op)
@@ -186,21 +212,23 @@ func AllocSession() Session {
 // Clone creates a clone of the Session
 func (s *Session) Clone() any {
 	clone := &Session{
-		PublicId:            s.PublicId,
-		UserId:              s.UserId,
-		HostId:              s.HostId,
-		TargetId:            s.TargetId,
-		HostSetId:           s.HostSetId,
-		AuthTokenId:         s.AuthTokenId,
-		ProjectId:           s.ProjectId,
-		TerminationReason:   s.TerminationReason,
-		Version:             s.Version,
-		Endpoint:            s.Endpoint,
-		ConnectionLimit:     s.ConnectionLimit,
-		WorkerFilter:        s.WorkerFilter,
-		EgressWorkerFilter:  s.EgressWorkerFilter,
-		IngressWorkerFilter: s.IngressWorkerFilter,
-		KeyId:               s.KeyId,
+		PublicId:               s.PublicId,
+		UserId:                 s.UserId,
+		HostId:                 s.HostId,
+		TargetId:               s.TargetId,
+		HostSetId:              s.HostSetId,
+		AuthTokenId:            s.AuthTokenId,
+		ProjectId:              s.ProjectId,
+		TerminationReason:      s.TerminationReason,
+		Version:                s.Version,
+		Endpoint:               s.Endpoint,
+		ConnectionLimit:        s.ConnectionLimit,
+		WorkerFilter:           s.WorkerFilter,
+		EgressWorkerFilter:     s.EgressWorkerFilter,
+		IngressWorkerFilter:    s.IngressWorkerFilter,
+		EnableSessionRecording: s.EnableSessionRecording,
+		IdleTimeout:            s.IdleTimeout,
+		KeyId:                  s.KeyId,
 	}
 	if len(s.States) > 0 {
 		clone.States = make([]*State, 0, len(s.States))
@@ -308,8 +336,8 @@ func (s *Session) VetForWrite(ctx context.Context, _ db.Reader, opType db.OpType
 			return errors.New(ctx, errors.InvalidParameter, op, "update time is immutable")
 		case contains(opts.WithFieldMaskPaths, "Endpoint"):
 			return errors.New(ctx, errors.InvalidParameter, op, "endpoint is immutable")
-		case contains(opts.WithFieldMaskPaths, "ExpirationTime"):
-			return errors.New(ctx, errors.InvalidParameter, op, "expiration time is immutable")
+		case contains(opts.WithFieldMaskPaths, "ExpirationTime") && s.ExpirationTime.GetTimestamp() == nil:
+			return errors.New(ctx, errors.InvalidParameter, op, "missing expiration time")
 		case contains(opts.WithFieldMaskPaths, "ConnectionLimit"):
 			return errors.New(ctx, errors.InvalidParameter, op, "connection limit is immutable")
 		case contains(opts.WithFieldMaskPaths, "WorkerFilter"):
@@ -318,6 +346,10 @@ func (s *Session) VetForWrite(ctx context.Context, _ db.Reader, opType db.OpType
 			return errors.New(ctx, errors.InvalidParameter, op, "egress worker filter is immutable")
 		case contains(opts.WithFieldMaskPaths, "IngressWorkerFilter"):
 			return errors.New(ctx, errors.InvalidParameter, op, "ingress worker filter is immutable")
+		case contains(opts.WithFieldMaskPaths, "EnableSessionRecording"):
+			return errors.New(ctx, errors.InvalidParameter, op, "enable session recording is immutable")
+		case contains(opts.WithFieldMaskPaths, "IdleTimeout"):
+			return errors.New(ctx, errors.InvalidParameter, op, "idle timeout is immutable")
 		case contains(opts.WithFieldMaskPaths, "DynamicCredentials"):
 			return errors.New(ctx, errors.InvalidParameter, op, "dynamic credentials are immutable")
 		case contains(opts.WithFieldMaskPaths, "StaticCredentials"):
@@ -0,0 +1,289 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package session
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/observability/event"
+	"github.com/hashicorp/boundary/internal/perms"
+	"github.com/hashicorp/boundary/internal/types/action"
+	"github.com/hashicorp/boundary/internal/util"
+	"github.com/hashicorp/go-secure-stdlib/strutil"
+)
+
+const (
+	// DefaultStateChangePollInterval is the interval at which a
+	// StateChangeBroker polls for state changes while it has subscribers.
+	DefaultStateChangePollInterval = time.Second
+
+	// stateChangeLookback is how far back in time a StateChangeBroker looks
+	// for state changes it has not seen yet. A state starts at the start time
+	// of the transaction inserting it, so it can be committed, and become
+	// visible, after states which started later.
+	stateChangeLookback = 10 * time.Second
+
+	// stateChangeBufferSize is the number of state changes buffered for a
+	// subscriber. A subscriber falling further behind is unsubscribed.
+	stateChangeBufferSize = 256
+)
+
+// StateChange is the transition of a session, or of one of its connections,
+// to a new state.
+type StateChange struct {
+	SessionId string
+	ProjectId string
+	UserId    string
+	TargetId  string
+	// ConnectionId is the id of the connection which changed state, or empty if
+	// the session changed state.
+	ConnectionId string
+	Status       string
+	StartTime    time.Time
+}
+
+func (c *StateChange) key() string {
+	return fmt.Sprintf("%s/%s/%s/%d", c.SessionId, c.ConnectionId, c.Status, c.StartTime.UnixNano())
+}
+
+// ListStateChanges lists the state changes of the sessions, and of their
+// connections, which happened after since, in the order they happened. Only the
+// sessions the repository's permissions allow to list are included. Supports
+// the WithLimit option.
+func (r *Repository) ListStateChanges(ctx context.Context, since time.Time, opt ...Option) ([]*StateChange, error) {
+	const op = "session.(Repository).ListStateChanges"
+	where, args := r.listPermissionWhereClauses()
+	if len(where) == 0 {
+		return nil, nil
+	}
+	changes, err := r.listStateChanges(ctx, strings.Join(where, " or "), args, since, opt...)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return changes, nil
+}
+
+// listStateChanges lists the state changes which happened after since of the
+// sessions selected by the where clause.
+func (r *Repository) listStateChanges(ctx context.Context, where string, args []any, since time.Time, opt ...Option) ([]*StateChange, error) {
+	const op = "session.(Repository).listStateChanges"
+	opts := getOpts(opt...)
+	limit := r.defaultLimit
+	if opts.withLimit != 0 {
+		limit = opts.withLimit
+	}
+	if limit < 0 {
+		// The query always limits the changes; a negative limit means all the
+		// changes.
+		limit = 0
+	}
+	args = append(args, sql.Named("since", since), sql.Named("limit", sql.NullInt64{Int64: int64(limit), Valid: limit > 0}))
+	rows, err := r.reader.Query(ctx, fmt.Sprintf(listStateChangesTemplate, where), args)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	defer rows.Close()
+	var changes []*StateChange
+	for rows.Next() {
+		var c StateChange
+		if err := rows.Scan(&c.SessionId, &c.ProjectId, &c.UserId, &c.TargetId, &c.ConnectionId, &c.Status, &c.StartTime); err != nil {
+			return nil, errors.Wrap(ctx, err, op, errors.WithMsg("scan row failed"))
+		}
+		changes = append(changes, &c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return changes, nil
+}
+
+// StateChangePermitted reports whether the permissions allow listing the
+// session which changed state.
+func StateChangePermitted(p *perms.UserPermissions, c *StateChange) bool {
+	if p == nil || c == nil {
+		return false
+	}
+	for _, perm := range p.Permissions {
+		switch {
+		case perm.Action != action.List,
+			perm.ScopeId != c.ProjectId,
+			len(perm.ResourceIds) > 0 && !strutil.StrListContains(perm.ResourceIds, c.SessionId),
+			strutil.StrListContains(perm.DeniedResourceIds, c.SessionId),
+			perm.OnlySelf && p.UserId != c.UserId:
+			continue
+		}
+		return true
+	}
+	return false
+}
+
+// StateChangeBroker polls the database for the state changes of all the
+// sessions and sends them to its subscribers, so watching sessions costs one
+// query per poll interval however many subscribers there are. It only polls
+// while it has subscribers.
+type StateChangeBroker struct {
+	repoFn       RepositoryFactory
+	pollInterval time.Duration
+
+	mu          sync.Mutex
+	subscribers map[*stateChangeSubscriber]struct{}
+	wakeup      chan struct{}
+}
+
+type stateChangeSubscriber struct {
+	permissions *perms.UserPermissions
+	ch          chan *StateChange
+}
+
+// NewStateChangeBroker creates a StateChangeBroker listing the state changes
+// using the repositories of repoFn. A pollInterval of zero means
+// DefaultStateChangePollInterval. The broker does nothing until Run is called.
+func NewStateChangeBroker(ctx context.Context, repoFn RepositoryFactory, pollInterval time.Duration) (*StateChangeBroker, error) {
+	const op = "session.NewStateChangeBroker"
+	if util.IsNil(repoFn) {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing repository factory")
+	}
+	if pollInterval < 0 {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "negative poll interval")
+	}
+	if pollInterval == 0 {
+		pollInterval = DefaultStateChangePollInterval
+	}
+	return &StateChangeBroker{
+		repoFn:       repoFn,
+		pollInterval: pollInterval,
+		subscribers:  make(map[*stateChangeSubscriber]struct{}),
+		wakeup:       make(chan struct{}, 1),
+	}, nil
+}
+
+// Subscribe returns a channel receiving the state changes, happening from now
+// on, of the sessions the permissions allow to list. The channel is closed
+// when ctx is done, or if the subscriber does not keep up with the state
+// changes.
+func (b *StateChangeBroker) Subscribe(ctx context.Context, permissions *perms.UserPermissions) <-chan *StateChange {
+	sub := &stateChangeSubscriber{
+		permissions: permissions,
+		ch:          make(chan *StateChange, stateChangeBufferSize),
+	}
+	b.mu.Lock()
+	b.subscribers[sub] = struct{}{}
+	b.mu.Unlock()
+	select {
+	case b.wakeup <- struct{}{}:
+	default:
+	}
+	go func() {
+		<-ctx.Done()
+		b.unsubscribe(sub)
+	}()
+	return sub.ch
+}
+
+func (b *StateChangeBroker) unsubscribe(sub *stateChangeSubscriber) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if _, ok := b.subscribers[sub]; ok {
+		delete(b.subscribers, sub)
+		close(sub.ch)
+	}
+}
+
+func (b *StateChangeBroker) hasSubscribers() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.subscribers) > 0
+}
+
+// publish sends the change to the subscribers permitted to see it.
+func (b *StateChangeBroker) publish(c *StateChange) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for sub := range b.subscribers {
+		if !StateChangePermitted(sub.permissions, c) {
+			continue
+		}
+		select {
+		case sub.ch <- c:
+		default:
+			delete(b.subscribers, sub)
+			close(sub.ch)
+		}
+	}
+}
+
+// Run polls for state changes until ctx is done.
+func (b *StateChangeBroker) Run(ctx context.Context) {
+	// seen holds the keys of the changes published within the lookback window,
+	// so the changes listed again are not published twice.
+	seen := make(map[string]time.Time)
+	var since time.Time
+	timer := time.NewTimer(b.pollInterval)
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-b.wakeup:
+		case <-timer.C:
+		}
+		if !b.hasSubscribers() {
+			// Nothing polls while nobody watches; subscribing wakes the
+			// broker up.
+			since = time.Time{}
+			seen = make(map[string]time.Time)
+			continue
+		}
+		if since.IsZero() {
+			// The changes which happened before the first subscription are
+			// only marked as seen.
+			since = b.poll(ctx, time.Now(), seen, false)
+		}
+		since = b.poll(ctx, since, seen, true)
+		timer.Reset(b.pollInterval)
+	}
+}
+
+// poll publishes the changes which happened after since minus the lookback
+// window and were not published yet, or only marks them as seen if publish is
+// false. It returns the start time of the latest change, or since if it is
+// later.
+func (b *StateChangeBroker) poll(ctx context.Context, since time.Time, seen map[string]time.Time, publish bool) time.Time {
+	const op = "session.(StateChangeBroker).poll"
+	repo, err := b.repoFn()
+	if err != nil {
+		event.WriteError(ctx, op, err, event.WithInfoMsg("unable to create session repository"))
+		return since
+	}
+	changes, err := repo.listStateChanges(ctx, "true", nil, since.Add(-stateChangeLookback), WithLimit(-1))
+	if err != nil {
+		event.WriteError(ctx, op, err, event.WithInfoMsg("unable to list session state changes"))
+		return since
+	}
+	for _, c := range changes {
+		k := c.key()
+		if _, ok := seen[k]; ok {
+			continue
+		}
+		seen[k] = c.StartTime
+		if publish {
+			b.publish(c)
+		}
+		if c.StartTime.After(since) {
+			since = c.StartTime
+		}
+	}
+	for k, t := range seen {
+		if t.Before(since.Add(-stateChangeLookback)) {
+			delete(seen, k)
+		}
+	}
+	return since
+}
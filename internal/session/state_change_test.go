@@ -0,0 +1,172 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package session
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/perms"
+	"github.com/hashicorp/boundary/internal/types/action"
+	"github.com/hashicorp/boundary/internal/types/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStateChangePermitted(t *testing.T) {
+	t.Parallel()
+	change := &StateChange{
+		SessionId: "s_1234567890",
+		ProjectId: "p_1234567890",
+		UserId:    "u_1234567890",
+		Status:    StatusActive.String(),
+	}
+	tests := []struct {
+		name        string
+		permissions *perms.UserPermissions
+		want        bool
+	}{
+		{
+			name: "nil-permissions",
+		},
+		{
+			name: "list-project",
+			permissions: &perms.UserPermissions{
+				Permissions: []perms.Permission{
+					{ScopeId: "p_1234567890", Resource: resource.Session, Action: action.List},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "list-other-project",
+			permissions: &perms.UserPermissions{
+				Permissions: []perms.Permission{
+					{ScopeId: "p_0987654321", Resource: resource.Session, Action: action.List},
+				},
+			},
+		},
+		{
+			name: "not-list",
+			permissions: &perms.UserPermissions{
+				Permissions: []perms.Permission{
+					{ScopeId: "p_1234567890", Resource: resource.Session, Action: action.Read},
+				},
+			},
+		},
+		{
+			name: "list-session-id",
+			permissions: &perms.UserPermissions{
+				Permissions: []perms.Permission{
+					{ScopeId: "p_1234567890", Resource: resource.Session, Action: action.List, ResourceIds: []string{"s_1234567890"}},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "list-other-session-id",
+			permissions: &perms.UserPermissions{
+				Permissions: []perms.Permission{
+					{ScopeId: "p_1234567890", Resource: resource.Session, Action: action.List, ResourceIds: []string{"s_0987654321"}},
+				},
+			},
+		},
+		{
+			name: "list-denied-session-id",
+			permissions: &perms.UserPermissions{
+				Permissions: []perms.Permission{
+					{ScopeId: "p_1234567890", Resource: resource.Session, Action: action.List, DeniedResourceIds: []string{"s_1234567890"}},
+				},
+			},
+		},
+		{
+			name: "list-self",
+			permissions: &perms.UserPermissions{
+				UserId: "u_1234567890",
+				Permissions: []perms.Permission{
+					{ScopeId: "p_1234567890", Resource: resource.Session, Action: action.List, OnlySelf: true},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "list-self-other-user",
+			permissions: &perms.UserPermissions{
+				UserId: "u_0987654321",
+				Permissions: []perms.Permission{
+					{ScopeId: "p_1234567890", Resource: resource.Session, Action: action.List, OnlySelf: true},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, StateChangePermitted(tt.permissions, change))
+		})
+	}
+}
+
+func TestRepository_ListStateChanges(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	kms := kms.TestKms(t, conn, wrapper)
+	ctx := context.Background()
+
+	before := time.Now().Add(-time.Minute)
+	s := TestDefaultSession(t, conn, wrapper, iamRepo)
+	c := TestConnection(t, conn, s.PublicId, "127.0.0.1", 22, "127.0.0.1", 2222, "127.0.0.1")
+
+	newRepo := func(projectId string) *Repository {
+		repo, err := NewRepository(ctx, rw, rw, kms, WithPermissions(&perms.UserPermissions{
+			Permissions: []perms.Permission{
+				{ScopeId: projectId, Resource: resource.Session, Action: action.List},
+			},
+		}))
+		require.NoError(t, err)
+		return repo
+	}
+
+	t.Run("permitted", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		got, err := newRepo(s.ProjectId).ListStateChanges(ctx, before, WithLimit(-1))
+		require.NoError(err)
+		var sessionPending, connectionConnected bool
+		for i, sc := range got {
+			if i > 0 {
+				assert.False(sc.StartTime.Before(got[i-1].StartTime), "state changes are not in order")
+			}
+			if sc.SessionId != s.PublicId {
+				continue
+			}
+			assert.Equal(s.ProjectId, sc.ProjectId)
+			assert.Equal(s.UserId, sc.UserId)
+			assert.Equal(s.TargetId, sc.TargetId)
+			switch {
+			case sc.ConnectionId == "" && sc.Status == StatusPending.String():
+				sessionPending = true
+			case sc.ConnectionId == c.PublicId && sc.Status == StatusConnected.String():
+				connectionConnected = true
+			}
+		}
+		assert.True(sessionPending)
+		assert.True(connectionConnected)
+	})
+	t.Run("since-later", func(t *testing.T) {
+		got, err := newRepo(s.ProjectId).ListStateChanges(ctx, time.Now().Add(time.Minute))
+		require.NoError(t, err)
+		assert.Empty(t, got)
+	})
+	t.Run("other-project", func(t *testing.T) {
+		got, err := newRepo("p_1234567890").ListStateChanges(ctx, before)
+		require.NoError(t, err)
+		assert.Empty(t, got)
+	})
+}
@@ -0,0 +1,124 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package http
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/boundary/internal/credential"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/target"
+)
+
+type targetHooks struct{}
+
+func init() {
+	target.Register(Subtype, targetHooks{}, TargetPrefix)
+}
+
+const (
+	// TargetPrefix is the prefix for public ids of a http.Target.
+	TargetPrefix = "thttp"
+)
+
+// Vet validates that the given target.Target is a http.Target and that it
+// has a Target store.
+func (h targetHooks) Vet(ctx context.Context, t target.Target) error {
+	const op = "http.vet"
+
+	tt, ok := t.(*Target)
+	if !ok {
+		return errors.New(ctx, errors.InvalidParameter, op, "target is not a http.Target")
+	}
+
+	if tt == nil {
+		return errors.New(ctx, errors.InvalidParameter, op, "missing target")
+	}
+
+	if tt.Target == nil {
+		return errors.New(ctx, errors.InvalidParameter, op, "missing target store")
+	}
+	if tt.GetDefaultPort() == 0 {
+		return errors.New(ctx, errors.InvalidParameter, op, "missing target default port")
+	}
+	if err := vetAllowedPaths(ctx, tt.GetAllowedPathPrefixes()); err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	return nil
+}
+
+// VetForUpdate validates that the given target.Target is a http.Target,
+// and that it has a Target store, that it isn't attempting to clear or
+// set to zero the default port and that the allowed paths are valid.
+func (h targetHooks) VetForUpdate(ctx context.Context, t target.Target, paths []string) error {
+	const op = "http.vetForUpdate"
+
+	tt, ok := t.(*Target)
+	if !ok {
+		return errors.New(ctx, errors.InvalidParameter, op, "target is not a http.Target")
+	}
+
+	switch {
+	case tt == nil:
+		return errors.New(ctx, errors.InvalidParameter, op, "missing target")
+	case tt.Target == nil:
+		return errors.New(ctx, errors.InvalidParameter, op, "missing target store")
+	}
+
+	for _, f := range paths {
+		switch {
+		case strings.EqualFold("defaultport", f) && tt.GetDefaultPort() == 0:
+			return errors.New(ctx, errors.InvalidParameter, op, "clearing or setting default port to zero")
+		case strings.EqualFold("allowedpaths", f):
+			if err := vetAllowedPaths(ctx, tt.GetAllowedPathPrefixes()); err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+		}
+	}
+
+	return nil
+}
+
+// VetCredentialSources checks that all the provided credential sources have a
+// CredentialPurpose of BrokeredPurpose or InjectedApplicationPurpose. Any other
+// CredentialPurpose will result in an error.
+func (h targetHooks) VetCredentialSources(ctx context.Context, libs []*target.CredentialLibrary, creds []*target.StaticCredential) error {
+	const op = "http.VetCredentialSources"
+
+	for _, c := range libs {
+		if !supportedPurpose(c.GetCredentialPurpose()) {
+			return errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("http.Target only supports credential purposes: %q and %q", credential.BrokeredPurpose, credential.InjectedApplicationPurpose))
+		}
+	}
+	for _, c := range creds {
+		if !supportedPurpose(c.GetCredentialPurpose()) {
+			return errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("http.Target only supports credential purposes: %q and %q", credential.BrokeredPurpose, credential.InjectedApplicationPurpose))
+		}
+	}
+	return nil
+}
+
+func supportedPurpose(p string) bool {
+	switch credential.Purpose(p) {
+	case credential.BrokeredPurpose, credential.InjectedApplicationPurpose:
+		return true
+	}
+	return false
+}
+
+// vetAllowedPaths checks that every allowed path is an absolute url path.
+func vetAllowedPaths(ctx context.Context, paths []string) error {
+	const op = "http.vetAllowedPaths"
+	for _, p := range paths {
+		switch {
+		case !strings.HasPrefix(p, "/"):
+			return errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("allowed path %q does not start with /", p))
+		case strings.ContainsAny(p, "?#\r\n"):
+			return errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("allowed path %q is not a url path", p))
+		}
+	}
+	return nil
+}
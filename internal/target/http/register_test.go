@@ -0,0 +1,128 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package http
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/credential"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/target"
+	"github.com/hashicorp/boundary/internal/target/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewTarget_DefaultPort(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	tar, err := target.New(ctx, Subtype, "p_1234567890")
+	require.NoError(t, err)
+	assert.Equal(t, uint32(DefaultPort), tar.GetDefaultPort())
+
+	tar, err = target.New(ctx, Subtype, "p_1234567890", target.WithUseTls(true))
+	require.NoError(t, err)
+	assert.Equal(t, uint32(DefaultTlsPort), tar.GetDefaultPort())
+
+	tar, err = target.New(ctx, Subtype, "p_1234567890", target.WithUseTls(true), target.WithDefaultPort(8443))
+	require.NoError(t, err)
+	assert.Equal(t, uint32(8443), tar.GetDefaultPort())
+}
+
+func TestTarget_AllowedPathPrefixes(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	tar, err := target.New(ctx, Subtype, "p_1234567890")
+	require.NoError(t, err)
+	assert.Empty(t, tar.(*Target).GetAllowedPathPrefixes())
+
+	tar, err = target.New(ctx, Subtype, "p_1234567890", target.WithAllowedPaths([]string{"/api", "/ui/"}))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"/api", "/ui/"}, tar.(*Target).GetAllowedPathPrefixes())
+	assert.NoError(t, targetHooks{}.Vet(ctx, tar))
+
+	tar.(*Target).SetAllowedPathPrefixes([]string{"/api", "ui"})
+	err = targetHooks{}.Vet(ctx, tar)
+	require.Error(t, err)
+	assert.True(t, errors.Match(errors.T(errors.InvalidParameter), err))
+
+	tar.(*Target).SetAllowedPathPrefixes([]string{"/api?x=y"})
+	err = targetHooks{}.VetForUpdate(ctx, tar, []string{"AllowedPaths"})
+	require.Error(t, err)
+	assert.True(t, errors.Match(errors.T(errors.InvalidParameter), err))
+	assert.NoError(t, targetHooks{}.VetForUpdate(ctx, tar, []string{"Name"}))
+}
+
+func TestTargetHooks_VetForUpdate(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	tar, err := target.New(ctx, Subtype, "p_1234567890")
+	require.NoError(t, err)
+	assert.NoError(t, targetHooks{}.VetForUpdate(ctx, tar, []string{"DefaultPort"}))
+
+	tar.SetDefaultPort(0)
+	err = targetHooks{}.VetForUpdate(ctx, tar, []string{"DefaultPort"})
+	require.Error(t, err)
+	assert.True(t, errors.Match(errors.T(errors.InvalidParameter), err))
+	assert.NoError(t, targetHooks{}.VetForUpdate(ctx, tar, []string{"Name"}))
+}
+
+func TestTargetHooks_VetCredentialSources(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	lib := func(p credential.Purpose) *target.CredentialLibrary {
+		return &target.CredentialLibrary{
+			CredentialLibrary: &store.CredentialLibrary{CredentialPurpose: string(p)},
+		}
+	}
+	static := func(p credential.Purpose) *target.StaticCredential {
+		return &target.StaticCredential{
+			StaticCredential: &store.StaticCredential{CredentialPurpose: string(p)},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		libs    []*target.CredentialLibrary
+		creds   []*target.StaticCredential
+		wantErr bool
+	}{
+		{
+			name:  "brokered",
+			libs:  []*target.CredentialLibrary{lib(credential.BrokeredPurpose)},
+			creds: []*target.StaticCredential{static(credential.BrokeredPurpose)},
+		},
+		{
+			name:  "injected-application",
+			libs:  []*target.CredentialLibrary{lib(credential.InjectedApplicationPurpose)},
+			creds: []*target.StaticCredential{static(credential.InjectedApplicationPurpose)},
+		},
+		{
+			name:    "library-unsupported-purpose",
+			libs:    []*target.CredentialLibrary{lib("unknown")},
+			wantErr: true,
+		},
+		{
+			name:    "static-unsupported-purpose",
+			creds:   []*target.StaticCredential{static("unknown")},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := targetHooks{}.VetCredentialSources(ctx, tt.libs, tt.creds)
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.True(t, errors.Match(errors.T(errors.InvalidParameter), err))
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
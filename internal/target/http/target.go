@@ -0,0 +1,272 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package http provides a Target subtype for a HTTP Target.
+// Importing this package will register it with the target package and
+// allow the target.Repository to support http.Targets.
+package http
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/db/timestamp"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/hashicorp/boundary/internal/target"
+	"github.com/hashicorp/boundary/internal/target/http/store"
+	targetstore "github.com/hashicorp/boundary/internal/target/store"
+	"github.com/hashicorp/boundary/internal/types/subtypes"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	defaultTableName = "target_http"
+	Subtype          = subtypes.Subtype("http")
+
+	// DefaultPort is the default port of a http.Target which does not use
+	// tls when one is not provided.
+	DefaultPort = 80
+	// DefaultTlsPort is the default port of a http.Target which uses tls
+	// when one is not provided.
+	DefaultTlsPort = 443
+)
+
+// Target is a resources that represets a networked service
+// that can be accessed via HTTP. It is a subtype of target.Target.
+type Target struct {
+	*store.Target
+	// Network address assigned to the Target.
+	Address   string `json:"address,omitempty" gorm:"-"`
+	tableName string `gorm:"-"`
+}
+
+// Ensure Target implements interfaces
+var (
+	_ target.Target           = (*Target)(nil)
+	_ target.SubtypeFielder   = (*Target)(nil)
+	_ db.VetForWriter         = (*Target)(nil)
+	_ oplog.ReplayableMessage = (*Target)(nil)
+)
+
+// NewTarget creates a new in memory http target.  WithName, WithDescription,
+// WithDefaultPort, WithUseTls and WithAllowedPaths options are supported. If
+// WithDefaultPort is not provided the target's default port is DefaultTlsPort
+// when it uses tls and DefaultPort otherwise.
+func (h targetHooks) NewTarget(projectId string, opt ...target.Option) (target.Target, error) {
+	const op = "http.NewTarget"
+	opts := target.GetOpts(opt...)
+	if projectId == "" {
+		return nil, errors.NewDeprecated(errors.InvalidParameter, op, "missing project id")
+	}
+	t := &Target{
+		Target: &store.Target{
+			ProjectId:              projectId,
+			Name:                   opts.WithName,
+			Description:            opts.WithDescription,
+			DefaultPort:            opts.WithDefaultPort,
+			SessionConnectionLimit: opts.WithSessionConnectionLimit,
+			SessionMaxSeconds:      opts.WithSessionMaxSeconds,
+			WorkerFilter:           opts.WithWorkerFilter,
+			EgressWorkerFilter:     opts.WithEgressWorkerFilter,
+			IngressWorkerFilter:    opts.WithIngressWorkerFilter,
+			EnableSessionRecording: opts.WithEnableSessionRecording,
+			SessionIdleTimeout:     opts.WithSessionIdleTimeout,
+			UseTls:                 opts.WithUseTls,
+			AllowedPaths:           joinPaths(opts.WithAllowedPaths),
+		},
+		Address: opts.WithAddress,
+	}
+	if t.DefaultPort == 0 {
+		t.DefaultPort = DefaultPort
+		if t.UseTls {
+			t.DefaultPort = DefaultTlsPort
+		}
+	}
+	return t, nil
+}
+
+// AllocTarget will allocate a http target
+func (h targetHooks) AllocTarget() target.Target {
+	return &Target{
+		Target: &store.Target{},
+	}
+}
+
+// Clone creates a clone of the Target
+func (t *Target) Clone() target.Target {
+	cp := proto.Clone(t.Target)
+	return &Target{
+		Target:  cp.(*store.Target),
+		Address: t.Address,
+	}
+}
+
+// VetForWrite implements db.VetForWrite() interface and validates the http target
+// before it's written.
+func (t *Target) VetForWrite(ctx context.Context, _ db.Reader, opType db.OpType, _ ...db.Option) error {
+	const op = "http.(Target).VetForWrite"
+	if t.PublicId == "" {
+		return errors.New(ctx, errors.InvalidParameter, op, "missing public id")
+	}
+	if opType == db.CreateOp {
+		if t.ProjectId == "" {
+			return errors.New(ctx, errors.InvalidParameter, op, "missing project id")
+		}
+		if t.Name == "" {
+			return errors.New(ctx, errors.InvalidParameter, op, "missing name")
+		}
+	}
+	return nil
+}
+
+// TableName returns the tablename to override the default gorm table name
+func (t *Target) TableName() string {
+	if t.tableName != "" {
+		return t.tableName
+	}
+	return defaultTableName
+}
+
+// SetTableName sets the tablename and satisfies the ReplayableMessage
+// interface. If the caller attempts to set the name to "" the name will be
+// reset to the default name.
+func (t *Target) SetTableName(n string) {
+	t.tableName = n
+}
+
+// Oplog provides the oplog.Metadata for recording operations taken on a Target.
+func (t *Target) Oplog(op oplog.OpType) oplog.Metadata {
+	metadata := oplog.Metadata{
+		"resource-public-id": []string{t.PublicId},
+		"resource-type":      []string{"http target"},
+		"op-type":            []string{op.String()},
+		"project-id":         []string{t.ProjectId},
+	}
+	return metadata
+}
+
+func (t *Target) GetType() subtypes.Subtype {
+	return Subtype
+}
+
+func (t *Target) GetAddress() string {
+	return t.Address
+}
+
+func (t *Target) SetPublicId(ctx context.Context, publicId string) error {
+	const op = "http.(Target).SetPublicId"
+	if !strings.HasPrefix(publicId, TargetPrefix+"_") {
+		return errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("passed-in public ID %q has wrong prefix, should be %q", publicId, TargetPrefix))
+	}
+
+	t.PublicId = publicId
+	return nil
+}
+
+func (t *Target) SetProjectId(projectId string) {
+	t.ProjectId = projectId
+}
+
+func (t *Target) SetName(name string) {
+	t.Name = name
+}
+
+func (t *Target) SetDescription(description string) {
+	t.Description = description
+}
+
+func (t *Target) SetVersion(v uint32) {
+	t.Version = v
+}
+
+func (t *Target) SetDefaultPort(port uint32) {
+	t.DefaultPort = port
+}
+
+func (t *Target) SetCreateTime(ts *timestamp.Timestamp) {
+	t.CreateTime = ts
+}
+
+func (t *Target) SetUpdateTime(ts *timestamp.Timestamp) {
+	t.UpdateTime = ts
+}
+
+func (t *Target) SetSessionMaxSeconds(s uint32) {
+	t.SessionMaxSeconds = s
+}
+
+func (t *Target) SetSessionConnectionLimit(limit int32) {
+	t.SessionConnectionLimit = limit
+}
+
+func (t *Target) SetWorkerFilter(filter string) {
+	t.WorkerFilter = filter
+}
+
+func (t *Target) SetEgressWorkerFilter(filter string) {
+	t.EgressWorkerFilter = filter
+}
+
+func (t *Target) SetIngressWorkerFilter(filter string) {
+	t.IngressWorkerFilter = filter
+}
+
+func (t *Target) SetEnableSessionRecording(enable bool) {
+	t.EnableSessionRecording = enable
+}
+
+func (t *Target) SetSessionIdleTimeout(timeout uint32) {
+	t.SessionIdleTimeout = timeout
+}
+
+func (t *Target) SetAddress(address string) {
+	t.Address = address
+}
+
+func (t *Target) SetUseTls(useTls bool) {
+	t.UseTls = useTls
+}
+
+// GetAllowedPathPrefixes returns the url path prefixes clients are allowed to
+// request. All paths are allowed when it is empty.
+func (t *Target) GetAllowedPathPrefixes() []string {
+	if t.AllowedPaths == "" {
+		return nil
+	}
+	return strings.Split(t.AllowedPaths, pathSeparator)
+}
+
+// SetAllowedPathPrefixes sets the url path prefixes clients are allowed to
+// request.
+func (t *Target) SetAllowedPathPrefixes(paths []string) {
+	t.AllowedPaths = joinPaths(paths)
+}
+
+// SubtypeFields implements target.SubtypeFielder.
+func (t *Target) SubtypeFields() map[string]any {
+	return map[string]any{
+		"UseTls":       t.UseTls,
+		"AllowedPaths": t.AllowedPaths,
+	}
+}
+
+// NonNullableSubtypeFields implements target.SubtypeFielder.
+func (t *Target) NonNullableSubtypeFields() []string {
+	return []string{"UseTls"}
+}
+
+// SetSubtypeFields implements target.SubtypeFielder.
+func (t *Target) SetSubtypeFields(tv *targetstore.TargetView) {
+	t.UseTls = tv.GetUseTls()
+	t.AllowedPaths = tv.GetAllowedPaths()
+}
+
+// pathSeparator separates the path prefixes in the AllowedPaths of a Target.
+const pathSeparator = "\n"
+
+func joinPaths(paths []string) string {
+	return strings.Join(paths, pathSeparator)
+}
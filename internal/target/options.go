@@ -6,6 +6,7 @@ package target
 import (
 	"time"
 
+	"github.com/hashicorp/boundary/internal/db"
 	"github.com/hashicorp/boundary/internal/perms"
 	"github.com/hashicorp/boundary/internal/types/subtypes"
 )
@@ -45,6 +46,15 @@ type options struct {
 	WithIngressWorkerFilter    string
 	WithTargetIds              []string
 	WithAddress                string
+	WithEnableSessionRecording bool
+	WithSessionIdleTimeout     uint32
+	WithUseTls                 bool
+	WithAllowedPaths           []string
+	WithHostKeys               []string
+	WithCaCert                 string
+	WithPage                   *db.Page
+	WithWhereClause            string
+	WithWhereClauseArgs        []any
 }
 
 func getDefaultOptions() options {
@@ -69,6 +79,15 @@ func getDefaultOptions() options {
 		WithEgressWorkerFilter:     "",
 		WithIngressWorkerFilter:    "",
 		WithAddress:                "",
+		WithEnableSessionRecording: false,
+		WithSessionIdleTimeout:     0,
+		WithUseTls:                 false,
+		WithAllowedPaths:           nil,
+		WithHostKeys:               nil,
+		WithCaCert:                 "",
+		WithPage:                   nil,
+		WithWhereClause:            "",
+		WithWhereClauseArgs:        nil,
 	}
 }
 
@@ -95,6 +114,24 @@ func WithLimit(limit int) Option {
 	}
 }
 
+// WithPage provides an option to list one page of targets. Use WithLimit to set
+// the size of the page.
+func WithPage(p *db.Page) Option {
+	return func(o *options) {
+		o.WithPage = p
+	}
+}
+
+// WithWhereClause provides an option to restrict a list request to the targets
+// matching a where clause using named args, such as the where clause of a list
+// filter translated using ListFilterColumns.
+func WithWhereClause(where string, args []any) Option {
+	return func(o *options) {
+		o.WithWhereClause = where
+		o.WithWhereClauseArgs = args
+	}
+}
+
 // WithDefaultPort provides an option to specify the default target port.
 func WithDefaultPort(p uint32) Option {
 	return func(o *options) {
@@ -198,6 +235,22 @@ func WithIngressWorkerFilter(filter string) Option {
 	}
 }
 
+// WithEnableSessionRecording provides an optional value for whether the
+// connections of sessions created for the target are recorded
+func WithEnableSessionRecording(enable bool) Option {
+	return func(o *options) {
+		o.WithEnableSessionRecording = enable
+	}
+}
+
+// WithSessionIdleTimeout provides an optional idle timeout, in seconds, of the
+// connections of sessions created for the target
+func WithSessionIdleTimeout(timeout uint32) Option {
+	return func(o *options) {
+		o.WithSessionIdleTimeout = timeout
+	}
+}
+
 // WithTargetIds provides an option to search by specific target IDs
 func WithTargetIds(with []string) Option {
 	return func(o *options) {
@@ -213,6 +266,39 @@ func WithPermissions(perms []perms.Permission) Option {
 	}
 }
 
+// WithUseTls provides an optional value for whether the worker connects to
+// the endpoint of an http target with https
+func WithUseTls(useTls bool) Option {
+	return func(o *options) {
+		o.WithUseTls = useTls
+	}
+}
+
+// WithAllowedPaths provides an optional list of the url path prefixes clients
+// are allowed to request from an http target
+func WithAllowedPaths(paths []string) Option {
+	return func(o *options) {
+		o.WithAllowedPaths = paths
+	}
+}
+
+// WithHostKeys provides an optional list of the public keys, in the
+// authorized_keys format, the endpoint of an ssh target is allowed to present
+// as its host key
+func WithHostKeys(keys []string) Option {
+	return func(o *options) {
+		o.WithHostKeys = keys
+	}
+}
+
+// WithCaCert provides optional PEM encoded certificates of the certificate
+// authorities the database server of a postgres target is verified against
+func WithCaCert(caCert string) Option {
+	return func(o *options) {
+		o.WithCaCert = caCert
+	}
+}
+
 // WithAddress provides an optional network address
 func WithAddress(address string) Option {
 	return func(o *options) {
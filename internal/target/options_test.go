@@ -126,6 +126,34 @@ func Test_GetOpts(t *testing.T) {
 		testOpts.WithIngressWorkerFilter = `"/foo" == "bar"`
 		assert.Equal(opts, testOpts)
 	})
+	t.Run("WithUseTls", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := GetOpts(WithUseTls(true))
+		testOpts := getDefaultOptions()
+		testOpts.WithUseTls = true
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithAllowedPaths", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := GetOpts(WithAllowedPaths([]string{"/api", "/ui"}))
+		testOpts := getDefaultOptions()
+		testOpts.WithAllowedPaths = []string{"/api", "/ui"}
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithHostKeys", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := GetOpts(WithHostKeys([]string{"ssh-ed25519 AAAA", "ssh-rsa AAAA"}))
+		testOpts := getDefaultOptions()
+		testOpts.WithHostKeys = []string{"ssh-ed25519 AAAA", "ssh-rsa AAAA"}
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithCaCert", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := GetOpts(WithCaCert("-----BEGIN CERTIFICATE-----"))
+		testOpts := getDefaultOptions()
+		testOpts.WithCaCert = "-----BEGIN CERTIFICATE-----"
+		assert.Equal(opts, testOpts)
+	})
 	t.Run("WithPermissions", func(t *testing.T) {
 		assert := assert.New(t)
 		opts := GetOpts(WithPermissions([]perms.Permission{{ScopeId: "test1"}, {ScopeId: "test2"}}))
@@ -0,0 +1,141 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package postgres
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/boundary/internal/credential"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/target"
+)
+
+type targetHooks struct{}
+
+func init() {
+	target.Register(Subtype, targetHooks{}, TargetPrefix)
+}
+
+const (
+	// TargetPrefix is the prefix for public ids of a postgres.Target.
+	TargetPrefix = "tpg"
+)
+
+// Vet validates that the given target.Target is a postgres.Target and that it
+// has a Target store.
+func (h targetHooks) Vet(ctx context.Context, t target.Target) error {
+	const op = "postgres.vet"
+
+	tt, ok := t.(*Target)
+	if !ok {
+		return errors.New(ctx, errors.InvalidParameter, op, "target is not a postgres.Target")
+	}
+
+	if tt == nil {
+		return errors.New(ctx, errors.InvalidParameter, op, "missing target")
+	}
+
+	if tt.Target == nil {
+		return errors.New(ctx, errors.InvalidParameter, op, "missing target store")
+	}
+	if tt.GetDefaultPort() == 0 {
+		return errors.New(ctx, errors.InvalidParameter, op, "missing target default port")
+	}
+	if err := vetCaCert(ctx, tt.GetCaCert()); err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	return nil
+}
+
+// VetForUpdate validates that the given target.Target is a postgres.Target,
+// and that it has a Target store, that it isn't attempting to clear or
+// set to zero the default port and that the CA certificates are valid.
+func (h targetHooks) VetForUpdate(ctx context.Context, t target.Target, paths []string) error {
+	const op = "postgres.vetForUpdate"
+
+	tt, ok := t.(*Target)
+	if !ok {
+		return errors.New(ctx, errors.InvalidParameter, op, "target is not a postgres.Target")
+	}
+
+	switch {
+	case tt == nil:
+		return errors.New(ctx, errors.InvalidParameter, op, "missing target")
+	case tt.Target == nil:
+		return errors.New(ctx, errors.InvalidParameter, op, "missing target store")
+	}
+
+	for _, f := range paths {
+		switch {
+		case strings.EqualFold("defaultport", f) && tt.GetDefaultPort() == 0:
+			return errors.New(ctx, errors.InvalidParameter, op, "clearing or setting default port to zero")
+		case strings.EqualFold("cacert", f):
+			if err := vetCaCert(ctx, tt.GetCaCert()); err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+		}
+	}
+
+	return nil
+}
+
+// VetCredentialSources checks that all the provided credential sources have a
+// CredentialPurpose of BrokeredPurpose or InjectedApplicationPurpose. Any other
+// CredentialPurpose will result in an error.
+func (h targetHooks) VetCredentialSources(ctx context.Context, libs []*target.CredentialLibrary, creds []*target.StaticCredential) error {
+	const op = "postgres.VetCredentialSources"
+
+	for _, c := range libs {
+		if !supportedPurpose(c.GetCredentialPurpose()) {
+			return errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("postgres.Target only supports credential purposes: %q and %q", credential.BrokeredPurpose, credential.InjectedApplicationPurpose))
+		}
+	}
+	for _, c := range creds {
+		if !supportedPurpose(c.GetCredentialPurpose()) {
+			return errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("postgres.Target only supports credential purposes: %q and %q", credential.BrokeredPurpose, credential.InjectedApplicationPurpose))
+		}
+	}
+	return nil
+}
+
+func supportedPurpose(p string) bool {
+	switch credential.Purpose(p) {
+	case credential.BrokeredPurpose, credential.InjectedApplicationPurpose:
+		return true
+	}
+	return false
+}
+
+// vetCaCert checks that caCert, if set, contains only PEM encoded
+// certificates.
+func vetCaCert(ctx context.Context, caCert string) error {
+	const op = "postgres.vetCaCert"
+	if caCert == "" {
+		return nil
+	}
+	rest := []byte(caCert)
+	var found bool
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			return errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("ca cert contains a %q PEM block", block.Type))
+		}
+		if _, err := x509.ParseCertificate(block.Bytes); err != nil {
+			return errors.New(ctx, errors.InvalidParameter, op, "ca cert contains an invalid certificate", errors.WithWrap(err))
+		}
+		found = true
+	}
+	if !found || len(strings.TrimSpace(string(rest))) > 0 {
+		return errors.New(ctx, errors.InvalidParameter, op, "ca cert is not a list of PEM encoded certificates")
+	}
+	return nil
+}
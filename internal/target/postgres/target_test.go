@@ -0,0 +1,456 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package postgres_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	dbassert "github.com/hashicorp/boundary/internal/db/assert"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/hashicorp/boundary/internal/target"
+	"github.com/hashicorp/boundary/internal/target/postgres"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestTarget_Create(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	_, prj := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	ctx := context.Background()
+	caCert := postgres.TestCaCert(t)
+	type args struct {
+		projectId string
+		opt       []target.Option
+	}
+	tests := []struct {
+		name          string
+		args          args
+		want          target.Target
+		wantErr       bool
+		wantIsErr     errors.Code
+		create        bool
+		wantCreateErr bool
+	}{
+		{
+			name:      "empty-projectId",
+			args:      args{},
+			wantErr:   true,
+			wantIsErr: errors.InvalidParameter,
+		},
+		{
+			name: "valid-proj-id",
+			args: args{
+				projectId: prj.PublicId,
+				opt:       []target.Option{target.WithName("valid-proj-id")},
+			},
+			want: func() target.Target {
+				t, _ := target.New(
+					ctx,
+					postgres.Subtype,
+					prj.PublicId,
+					target.WithName("valid-proj-id"),
+					target.WithSessionMaxSeconds(uint32((8 * time.Hour).Seconds())),
+					target.WithSessionConnectionLimit(-1),
+				)
+				return t
+			}(),
+			create: true,
+		},
+		{
+			name: "with-default-port",
+			args: args{
+				projectId: prj.PublicId,
+				opt:       []target.Option{target.WithName("with-default-port"), target.WithDefaultPort(6543)},
+			},
+			want: func() target.Target {
+				t, _ := target.New(
+					ctx,
+					postgres.Subtype,
+					prj.PublicId,
+					target.WithName("with-default-port"),
+					target.WithDefaultPort(6543),
+					target.WithSessionMaxSeconds(uint32((8 * time.Hour).Seconds())),
+					target.WithSessionConnectionLimit(-1),
+				)
+				return t
+			}(),
+			create: true,
+		},
+		{
+			name: "with-ca-cert",
+			args: args{
+				projectId: prj.PublicId,
+				opt:       []target.Option{target.WithName("with-ca-cert"), target.WithCaCert(caCert)},
+			},
+			want: func() target.Target {
+				t, _ := target.New(
+					ctx,
+					postgres.Subtype,
+					prj.PublicId,
+					target.WithName("with-ca-cert"),
+					target.WithCaCert(caCert),
+					target.WithSessionMaxSeconds(uint32((8 * time.Hour).Seconds())),
+					target.WithSessionConnectionLimit(-1),
+				)
+				return t
+			}(),
+			create: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, err := target.New(ctx, postgres.Subtype, tt.args.projectId, tt.args.opt...)
+			if tt.wantErr {
+				require.Error(err)
+				assert.True(errors.Match(errors.T(tt.wantIsErr), err))
+				return
+			}
+			require.NoError(err)
+			assert.Equal(tt.want, got)
+			if tt.create {
+				id, err := db.NewPublicId(postgres.TargetPrefix)
+				require.NoError(err)
+				got.SetPublicId(ctx, id)
+				err = db.New(conn).Create(ctx, got)
+				if tt.wantCreateErr {
+					assert.Error(err)
+					return
+				}
+
+				assert.NoError(err)
+			}
+		})
+	}
+}
+
+func TestTarget_Delete(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	_, proj := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	ctx := context.Background()
+
+	tests := []struct {
+		name            string
+		target          target.Target
+		wantRowsDeleted int
+		wantErr         bool
+		wantErrMsg      string
+	}{
+		{
+			name:            "valid",
+			target:          postgres.TestTarget(ctx, t, conn, proj.PublicId, postgres.TestTargetName(t, proj.PublicId)),
+			wantErr:         false,
+			wantRowsDeleted: 1,
+		},
+		{
+			name: "bad-id",
+			target: func() target.Target {
+				tar, _ := target.New(ctx, postgres.Subtype, proj.PublicId)
+
+				id, err := db.NewPublicId(postgres.TargetPrefix)
+				require.NoError(t, err)
+				tar.SetPublicId(ctx, id)
+				tar.SetName(postgres.TestTargetName(t, proj.PublicId))
+				return tar
+			}(),
+			wantErr:         false,
+			wantRowsDeleted: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			deleteTarget := postgres.NewTestTarget("")
+			deleteTarget.SetPublicId(ctx, tt.target.GetPublicId())
+			deletedRows, err := rw.Delete(context.Background(), deleteTarget)
+			if tt.wantErr {
+				require.Error(err)
+				return
+			}
+			require.NoError(err)
+			if tt.wantRowsDeleted == 0 {
+				assert.Equal(tt.wantRowsDeleted, deletedRows)
+				return
+			}
+			assert.Equal(tt.wantRowsDeleted, deletedRows)
+			foundTarget := postgres.NewTestTarget("")
+			foundTarget.SetPublicId(ctx, tt.target.GetPublicId())
+			err = rw.LookupById(context.Background(), foundTarget)
+			require.Error(err)
+			assert.True(errors.IsNotFoundError(err))
+		})
+	}
+}
+
+func TestTarget_Update(t *testing.T) {
+	t.Parallel()
+	id := postgres.TestId(t)
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	_, proj := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+
+	type args struct {
+		name           string
+		description    string
+		fieldMaskPaths []string
+		nullPaths      []string
+		ProjectId      string
+	}
+	tests := []struct {
+		name           string
+		args           args
+		wantRowsUpdate int
+		wantErr        bool
+		wantErrMsg     string
+		wantDup        bool
+	}{
+		{
+			name: "valid",
+			args: args{
+				name:           "valid" + id,
+				fieldMaskPaths: []string{"Name"},
+				ProjectId:      proj.PublicId,
+			},
+			wantErr:        false,
+			wantRowsUpdate: 1,
+		},
+		{
+			name: "proj-id-not-in-mask",
+			args: args{
+				name:           "proj-id" + id,
+				fieldMaskPaths: []string{"Name"},
+				ProjectId:      proj.PublicId,
+			},
+			wantErr:        false,
+			wantRowsUpdate: 1,
+		},
+		{
+			name: "empty-project-id",
+			args: args{
+				name:           "empty-project-id" + id,
+				fieldMaskPaths: []string{"Name"},
+				ProjectId:      "",
+			},
+			wantErr:        false,
+			wantRowsUpdate: 1,
+		},
+		{
+			name: "dup-name",
+			args: args{
+				name:           "dup-name" + id,
+				fieldMaskPaths: []string{"Name"},
+				ProjectId:      proj.PublicId,
+			},
+			wantErr:    true,
+			wantDup:    true,
+			wantErrMsg: `db.Update: duplicate key value violates unique constraint "target_postgres_project_id_name_uq": unique constraint violation: integrity violation: error #1002`,
+		},
+		{
+			name: "set description null",
+			args: args{
+				name:           "set description null" + id,
+				fieldMaskPaths: []string{"Name"},
+				nullPaths:      []string{"Description"},
+				ProjectId:      proj.PublicId,
+			},
+			wantErr:        false,
+			wantRowsUpdate: 1,
+		},
+		{
+			name: "set name null",
+			args: args{
+				description:    "set description null" + id,
+				fieldMaskPaths: []string{"Description"},
+				nullPaths:      []string{"Name"},
+				ProjectId:      proj.PublicId,
+			},
+			wantErr:    true,
+			wantErrMsg: `db.Update: name must not be empty: not null constraint violated: integrity violation: error #1001`,
+		},
+		{
+			name: "set description null",
+			args: args{
+				name:           "set name null" + id,
+				fieldMaskPaths: []string{"Name"},
+				nullPaths:      []string{"Description"},
+				ProjectId:      proj.PublicId,
+			},
+			wantErr:        false,
+			wantRowsUpdate: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			ctx := context.Background()
+			if tt.wantDup {
+				target := postgres.TestTarget(ctx, t, conn, proj.PublicId, postgres.TestTargetName(t, proj.PublicId))
+				target.SetName(tt.args.name)
+				_, err := rw.Update(ctx, target, tt.args.fieldMaskPaths, tt.args.nullPaths)
+				require.NoError(err)
+			}
+
+			id := postgres.TestId(t)
+			tar := postgres.TestTarget(ctx, t, conn, proj.PublicId, id, target.WithDescription(id))
+
+			updateTarget := postgres.NewTestTarget(tt.args.ProjectId)
+			updateTarget.SetPublicId(ctx, tar.GetPublicId())
+			updateTarget.SetName(tt.args.name)
+			updateTarget.SetDescription(tt.args.description)
+
+			updatedRows, err := rw.Update(ctx, updateTarget, tt.args.fieldMaskPaths, tt.args.nullPaths)
+			if tt.wantErr {
+				require.Error(err)
+				assert.Equal(0, updatedRows)
+				assert.Equal(tt.wantErrMsg, err.Error())
+				err = db.TestVerifyOplog(t, rw, tar.GetPublicId(), db.WithOperation(oplog.OpType_OP_TYPE_UPDATE), db.WithCreateNotBefore(10*time.Second))
+				require.Error(err)
+				assert.Contains(err.Error(), "record not found")
+				return
+			}
+			require.NoError(err)
+			assert.Equal(tt.wantRowsUpdate, updatedRows)
+			assert.NotEqual(tar.GetUpdateTime(), updateTarget.GetUpdateTime())
+			foundTarget := postgres.NewTestTarget(tt.args.ProjectId)
+			foundTarget.SetPublicId(ctx, tar.GetPublicId())
+			err = rw.LookupByPublicId(ctx, foundTarget)
+			require.NoError(err)
+			assert.True(proto.Equal(updateTarget.(*postgres.Target).Target, foundTarget.(*postgres.Target).Target))
+			if len(tt.args.nullPaths) != 0 {
+				underlyingDB, err := conn.SqlDB(ctx)
+				require.NoError(err)
+				dbassert := dbassert.New(t, underlyingDB)
+				for _, f := range tt.args.nullPaths {
+					ft := foundTarget.(*postgres.Target)
+					dbassert.IsNull(&ft, f)
+				}
+			}
+		})
+	}
+	t.Run("update dup names in diff projects", func(t *testing.T) {
+		ctx := context.Background()
+		assert, require := assert.New(t), require.New(t)
+		id := postgres.TestId(t)
+		_, proj2 := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+		_ = postgres.TestTarget(ctx, t, conn, proj2.PublicId, id, target.WithDescription(id))
+		projTarget := postgres.TestTarget(ctx, t, conn, proj.PublicId, id)
+		projTarget.SetName(id)
+		updatedRows, err := rw.Update(ctx, projTarget, []string{"Name"}, nil)
+		require.NoError(err)
+		assert.Equal(1, updatedRows)
+
+		foundTarget, _ := target.New(ctx, postgres.Subtype, proj2.PublicId)
+		foundTarget.SetPublicId(ctx, projTarget.GetPublicId())
+		err = rw.LookupByPublicId(ctx, foundTarget)
+		require.NoError(err)
+		assert.Equal(id, projTarget.GetName())
+	})
+}
+
+func TestTarget_Clone(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	t.Run("valid", func(t *testing.T) {
+		assert := assert.New(t)
+		_, proj := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+		tar := postgres.TestTarget(ctx, t, conn, proj.PublicId, postgres.TestTargetName(t, proj.PublicId),
+			target.WithAddress("8.8.8.8"),
+		)
+		cp := tar.Clone()
+		assert.True(proto.Equal(cp.(*postgres.Target).Target, tar.(*postgres.Target).Target))
+	})
+	t.Run("not-equal", func(t *testing.T) {
+		assert := assert.New(t)
+		_, proj := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+		_, proj2 := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+		target1 := postgres.TestTarget(ctx, t, conn, proj.PublicId, postgres.TestTargetName(t, proj.PublicId))
+		target2 := postgres.TestTarget(ctx, t, conn, proj2.PublicId, postgres.TestTargetName(t, proj2.PublicId))
+
+		cp := target1.Clone()
+		assert.True(!proto.Equal(cp.(*postgres.Target).Target, target2.(*postgres.Target).Target))
+	})
+}
+
+func TestTable_SetTableName(t *testing.T) {
+	t.Parallel()
+	defaultTableName := postgres.DefaultTableName
+	ctx := context.Background()
+	tests := []struct {
+		name      string
+		setNameTo string
+		want      string
+	}{
+		{
+			name:      "new-name",
+			setNameTo: "new-name",
+			want:      "new-name",
+		},
+		{
+			name:      "reset to default",
+			setNameTo: "",
+			want:      defaultTableName,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			def, _ := target.New(ctx, postgres.Subtype, "testScope")
+			require.Equal(defaultTableName, def.(*postgres.Target).TableName())
+			ss, _ := target.New(ctx, postgres.Subtype, "testScope")
+			s := ss.(*postgres.Target)
+			s.SetTableName(tt.setNameTo)
+			assert.Equal(tt.want, s.TableName())
+		})
+	}
+}
+
+func TestTarget_oplog(t *testing.T) {
+	ctx := context.Background()
+	id := postgres.TestId(t)
+	tests := []struct {
+		name   string
+		target target.Target
+		op     oplog.OpType
+		want   oplog.Metadata
+	}{
+		{
+			name: "simple",
+			target: func() target.Target {
+				tar, _ := target.New(ctx, postgres.Subtype, id)
+				if err := tar.SetPublicId(ctx, id); err != nil {
+					t.Fatalf("failed to set public id: %s", err)
+				}
+				return tar
+			}(),
+			op: oplog.OpType_OP_TYPE_CREATE,
+			want: oplog.Metadata{
+				"resource-public-id": []string{id},
+				"resource-type":      []string{"postgres target"},
+				"op-type":            []string{oplog.OpType_OP_TYPE_CREATE.String()},
+				"project-id":         []string{id},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			got := tt.target.Oplog(tt.op)
+			assert.Equal(got, tt.want)
+		})
+	}
+}
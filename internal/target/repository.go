@@ -8,12 +8,15 @@ import (
 	"database/sql"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/boundary/internal/boundary"
 	"github.com/hashicorp/boundary/internal/db"
 	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/filter"
 	"github.com/hashicorp/boundary/internal/kms"
 	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/hashicorp/boundary/internal/pagination"
 	"github.com/hashicorp/boundary/internal/perms"
 	"github.com/hashicorp/boundary/internal/types/action"
 	"github.com/hashicorp/boundary/internal/types/resource"
@@ -224,6 +227,20 @@ func (r *Repository) FetchAuthzProtectedEntitiesByScope(ctx context.Context, pro
 	return targetsMap, nil
 }
 
+// ListFilterColumns maps the fields of the targets returned by the api to the
+// columns of the targets listed by ListTargets. It is used to translate list
+// filters into where clauses for WithWhereClause.
+var ListFilterColumns = filter.Columns{
+	"/item/id":                    "public_id",
+	"/item/scope_id":              "project_id",
+	"/item/name":                  "name",
+	"/item/description":           "description",
+	"/item/type":                  "type",
+	"/item/worker_filter":         "worker_filter",
+	"/item/egress_worker_filter":  "egress_worker_filter",
+	"/item/ingress_worker_filter": "ingress_worker_filter",
+}
+
 // ListTargets lists targets in a project based on the data in the WithPermissions option
 // provided to the Repository constructor. If no permissions are available, this function
 // is a no-op.
@@ -246,9 +263,15 @@ func (r *Repository) ListTargets(ctx context.Context, opt ...Option) ([]Target,
 		limit = opts.WithLimit
 	}
 
+	whereClause := strings.Join(where, " or ")
+	if opts.WithWhereClause != "" {
+		whereClause = "(" + whereClause + ") and (" + opts.WithWhereClause + ")"
+		args = append(args, opts.WithWhereClauseArgs...)
+	}
+
 	var foundTargets []*targetView
-	err := r.reader.SearchWhere(ctx, &foundTargets, strings.Join(where, " or "), args,
-		db.WithLimit(limit))
+	err := r.reader.SearchWhere(ctx, &foundTargets, whereClause, args,
+		db.WithLimit(limit), db.WithPage(opts.WithPage))
 	if err != nil {
 		return nil, errors.Wrap(ctx, err, op)
 	}
@@ -284,6 +307,16 @@ func (r *Repository) ListTargets(ctx context.Context, opt ...Option) ([]Target,
 	return targets, nil
 }
 
+// ListDeletedIds returns the targets in one of projectIds deleted after since.
+func (r *Repository) ListDeletedIds(ctx context.Context, projectIds []string, since time.Time) ([]*pagination.DeletedResource, error) {
+	const op = "target.(Repository).ListDeletedIds"
+	deleted, err := pagination.ListDeletedIds(ctx, r.reader, "target", projectIds, since)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return deleted, nil
+}
+
 func (r *Repository) listPermissionWhereClauses() ([]string, []any) {
 	var where []string
 	var args []any
@@ -303,6 +336,10 @@ func (r *Repository) listPermissionWhereClauses() ([]string, []any) {
 			clauses = append(clauses, fmt.Sprintf("public_id = any(@public_id_%d)", inClauseCnt))
 			args = append(args, sql.Named(fmt.Sprintf("public_id_%d", inClauseCnt), "{"+strings.Join(p.ResourceIds, ",")+"}"))
 		}
+		if len(p.DeniedResourceIds) > 0 {
+			clauses = append(clauses, fmt.Sprintf("public_id != all(@denied_id_%d)", inClauseCnt))
+			args = append(args, sql.Named(fmt.Sprintf("denied_id_%d", inClauseCnt), "{"+strings.Join(p.DeniedResourceIds, ",")+"}"))
+		}
 
 		where = append(where, fmt.Sprintf("(%s)", strings.Join(clauses, " and ")))
 	}
@@ -507,30 +544,40 @@ func (r *Repository) UpdateTarget(ctx context.Context, target Target, version ui
 		case strings.EqualFold("workerfilter", f):
 		case strings.EqualFold("egressworkerfilter", f):
 		case strings.EqualFold("ingressworkerfilter", f):
+		case strings.EqualFold("enablesessionrecording", f):
+		case strings.EqualFold("sessionidletimeout", f):
 		case strings.EqualFold("address", f):
 			target.SetAddress(strings.TrimSpace(target.GetAddress()))
 			addressEndpoint = target.GetAddress()
+		case isSubtypeField(target, f):
 		default:
 			return nil, nil, nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidFieldMask, op, fmt.Sprintf("invalid field mask: %s", f))
 		}
 	}
 
+	updateFields := map[string]any{
+		"Name":                   target.GetName(),
+		"Description":            target.GetDescription(),
+		"DefaultPort":            target.GetDefaultPort(),
+		"SessionMaxSeconds":      target.GetSessionMaxSeconds(),
+		"SessionConnectionLimit": target.GetSessionConnectionLimit(),
+		"WorkerFilter":           target.GetWorkerFilter(),
+		"EgressWorkerFilter":     target.GetEgressWorkerFilter(),
+		"IngressWorkerFilter":    target.GetIngressWorkerFilter(),
+		"Address":                target.GetAddress(),
+		"EnableSessionRecording": target.GetEnableSessionRecording(),
+		"SessionIdleTimeout":     target.GetSessionIdleTimeout(),
+	}
+	nonNullableFields := []string{"SessionMaxSeconds", "SessionConnectionLimit", "EnableSessionRecording", "SessionIdleTimeout"}
+	if sf, ok := target.(SubtypeFielder); ok {
+		for k, v := range sf.SubtypeFields() {
+			updateFields[k] = v
+		}
+		nonNullableFields = append(nonNullableFields, sf.NonNullableSubtypeFields()...)
+	}
+
 	var dbMask, nullFields []string
-	dbMask, nullFields = dbw.BuildUpdatePaths(
-		map[string]any{
-			"Name":                   target.GetName(),
-			"Description":            target.GetDescription(),
-			"DefaultPort":            target.GetDefaultPort(),
-			"SessionMaxSeconds":      target.GetSessionMaxSeconds(),
-			"SessionConnectionLimit": target.GetSessionConnectionLimit(),
-			"WorkerFilter":           target.GetWorkerFilter(),
-			"EgressWorkerFilter":     target.GetEgressWorkerFilter(),
-			"IngressWorkerFilter":    target.GetIngressWorkerFilter(),
-			"Address":                target.GetAddress(),
-		},
-		fieldMaskPaths,
-		[]string{"SessionMaxSeconds", "SessionConnectionLimit"},
-	)
+	dbMask, nullFields = dbw.BuildUpdatePaths(updateFields, fieldMaskPaths, nonNullableFields)
 	if len(dbMask) == 0 && len(nullFields) == 0 {
 		return nil, nil, nil, db.NoRowsAffected, errors.New(ctx, errors.EmptyFieldMask, op, "empty field mask")
 	}
@@ -653,3 +700,18 @@ func (r *Repository) UpdateTarget(ctx context.Context, target Target, version ui
 	}
 	return returnedTarget, hostSources, credSources, rowsUpdated, nil
 }
+
+// isSubtypeField reports whether f is the field mask path of a field specific
+// to the subtype of t.
+func isSubtypeField(t Target, f string) bool {
+	sf, ok := t.(SubtypeFielder)
+	if !ok {
+		return false
+	}
+	for k := range sf.SubtypeFields() {
+		if strings.EqualFold(k, f) {
+			return true
+		}
+	}
+	return false
+}
@@ -222,6 +222,21 @@ func TestRepositoryListPermissionWhereClauses(t *testing.T) {
 				sql.Named("public_id_1", "{resourceid1,resourceid2}"),
 			},
 		},
+		{
+			name: "onePermissionDeniedResourceIds",
+			perms: []perms.Permission{
+				{
+					ScopeId:           "scope_a",
+					Action:            action.List,
+					DeniedResourceIds: []string{"resourceid1", "resourceid2"},
+				},
+			},
+			expWhere: []string{"(project_id = @project_id_1 and public_id != all(@denied_id_1))"},
+			expArgs: []any{
+				sql.Named("project_id_1", "scope_a"),
+				sql.Named("denied_id_1", "{resourceid1,resourceid2}"),
+			},
+		},
 		{
 			name: "multiplePermissionsAllResources",
 			perms: []perms.Permission{
@@ -0,0 +1,128 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package ssh
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/credential"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/target"
+	"github.com/hashicorp/boundary/internal/target/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewTarget_DefaultPort(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	tar, err := target.New(ctx, Subtype, "p_1234567890")
+	require.NoError(t, err)
+	assert.Equal(t, uint32(DefaultPort), tar.GetDefaultPort())
+
+	tar, err = target.New(ctx, Subtype, "p_1234567890", target.WithDefaultPort(2222))
+	require.NoError(t, err)
+	assert.Equal(t, uint32(2222), tar.GetDefaultPort())
+}
+
+func TestTarget_HostKeyList(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	const (
+		ed25519Key = "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIOMqqnkVzrm0SdG6UOoqKLsabgH5C9okWi0dh2l9GKJl"
+		otherKey   = "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAILM+rvN+ot98qgEN796jTiQfZfG1KaT0PtFDJ/XFSqti host"
+	)
+
+	tar, err := target.New(ctx, Subtype, "p_1234567890")
+	require.NoError(t, err)
+	assert.Empty(t, tar.(*Target).GetHostKeyList())
+
+	tar, err = target.New(ctx, Subtype, "p_1234567890", target.WithHostKeys([]string{ed25519Key, otherKey}))
+	require.NoError(t, err)
+	assert.Equal(t, []string{ed25519Key, otherKey}, tar.(*Target).GetHostKeyList())
+	assert.NoError(t, targetHooks{}.Vet(ctx, tar))
+
+	tar.(*Target).SetHostKeyList([]string{ed25519Key, "ssh-ed25519 not-base64"})
+	err = targetHooks{}.Vet(ctx, tar)
+	require.Error(t, err)
+	assert.True(t, errors.Match(errors.T(errors.InvalidParameter), err))
+
+	tar.(*Target).SetHostKeyList([]string{"not a key"})
+	err = targetHooks{}.VetForUpdate(ctx, tar, []string{"HostKeys"})
+	require.Error(t, err)
+	assert.True(t, errors.Match(errors.T(errors.InvalidParameter), err))
+	assert.NoError(t, targetHooks{}.VetForUpdate(ctx, tar, []string{"Name"}))
+}
+
+func TestTargetHooks_VetForUpdate(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	tar, err := target.New(ctx, Subtype, "p_1234567890")
+	require.NoError(t, err)
+	assert.NoError(t, targetHooks{}.VetForUpdate(ctx, tar, []string{"DefaultPort"}))
+
+	tar.SetDefaultPort(0)
+	err = targetHooks{}.VetForUpdate(ctx, tar, []string{"DefaultPort"})
+	require.Error(t, err)
+	assert.True(t, errors.Match(errors.T(errors.InvalidParameter), err))
+	assert.NoError(t, targetHooks{}.VetForUpdate(ctx, tar, []string{"Name"}))
+}
+
+func TestTargetHooks_VetCredentialSources(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	lib := func(p credential.Purpose) *target.CredentialLibrary {
+		return &target.CredentialLibrary{
+			CredentialLibrary: &store.CredentialLibrary{CredentialPurpose: string(p)},
+		}
+	}
+	static := func(p credential.Purpose) *target.StaticCredential {
+		return &target.StaticCredential{
+			StaticCredential: &store.StaticCredential{CredentialPurpose: string(p)},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		libs    []*target.CredentialLibrary
+		creds   []*target.StaticCredential
+		wantErr bool
+	}{
+		{
+			name:  "brokered",
+			libs:  []*target.CredentialLibrary{lib(credential.BrokeredPurpose)},
+			creds: []*target.StaticCredential{static(credential.BrokeredPurpose)},
+		},
+		{
+			name:  "injected-application",
+			libs:  []*target.CredentialLibrary{lib(credential.InjectedApplicationPurpose)},
+			creds: []*target.StaticCredential{static(credential.InjectedApplicationPurpose)},
+		},
+		{
+			name:    "library-unsupported-purpose",
+			libs:    []*target.CredentialLibrary{lib("unknown")},
+			wantErr: true,
+		},
+		{
+			name:    "static-unsupported-purpose",
+			creds:   []*target.StaticCredential{static("unknown")},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := targetHooks{}.VetCredentialSources(ctx, tt.libs, tt.creds)
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.True(t, errors.Match(errors.T(errors.InvalidParameter), err))
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
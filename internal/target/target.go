@@ -31,6 +31,8 @@ type Target interface {
 	GetWorkerFilter() string
 	GetEgressWorkerFilter() string
 	GetIngressWorkerFilter() string
+	GetEnableSessionRecording() bool
+	GetSessionIdleTimeout() uint32
 	GetAddress() string
 	Clone() Target
 	SetPublicId(context.Context, string) error
@@ -46,10 +48,27 @@ type Target interface {
 	SetWorkerFilter(string)
 	SetEgressWorkerFilter(string)
 	SetIngressWorkerFilter(string)
+	SetEnableSessionRecording(bool)
+	SetSessionIdleTimeout(uint32)
 	SetAddress(string)
 	Oplog(op oplog.OpType) oplog.Metadata
 }
 
+// SubtypeFielder is implemented by the targets of subtypes which have fields
+// that are not common to all targets. The fields are columns of the subtype's
+// table and of the target_all_subtypes view.
+type SubtypeFielder interface {
+	// SubtypeFields returns the values of the fields specific to the subtype
+	// which can be updated, keyed by their field mask path.
+	SubtypeFields() map[string]any
+	// NonNullableSubtypeFields returns the field mask paths of the subtype
+	// specific fields which are set to their zero value instead of null.
+	NonNullableSubtypeFields() []string
+	// SetSubtypeFields sets the fields specific to the subtype from the
+	// target view.
+	SetSubtypeFields(*store.TargetView)
+}
+
 const (
 	targetsViewDefaultTable = "target_all_subtypes"
 )
@@ -136,6 +155,11 @@ func (t *targetView) targetSubtype(ctx context.Context, address string) (Target,
 	tt.SetWorkerFilter(t.WorkerFilter)
 	tt.SetEgressWorkerFilter(t.EgressWorkerFilter)
 	tt.SetIngressWorkerFilter(t.IngressWorkerFilter)
+	tt.SetEnableSessionRecording(t.EnableSessionRecording)
+	tt.SetSessionIdleTimeout(t.SessionIdleTimeout)
 	tt.SetAddress(address)
+	if sf, ok := tt.(SubtypeFielder); ok {
+		sf.SetSubtypeFields(t.TargetView)
+	}
 	return tt, nil
 }
@@ -129,6 +129,14 @@ func (t *Target) GetIngressWorkerFilter() string {
 	return t.IngressWorkerFilter
 }
 
+func (t *Target) GetEnableSessionRecording() bool {
+	return t.EnableSessionRecording
+}
+
+func (t *Target) GetSessionIdleTimeout() uint32 {
+	return t.SessionIdleTimeout
+}
+
 func (t *Target) GetAddress() string {
 	return t.Address
 }
@@ -194,6 +202,14 @@ func (t *Target) SetIngressWorkerFilter(filter string) {
 	t.IngressWorkerFilter = filter
 }
 
+func (t *Target) SetEnableSessionRecording(enable bool) {
+	t.EnableSessionRecording = enable
+}
+
+func (t *Target) SetSessionIdleTimeout(timeout uint32) {
+	t.SessionIdleTimeout = timeout
+}
+
 func (t *Target) SetAddress(a string) {
 	t.Address = a
 }
@@ -282,6 +298,8 @@ func New(projectId string, opt ...target.Option) (target.Target, error) {
 			WorkerFilter:           opts.WithWorkerFilter,
 			EgressWorkerFilter:     opts.WithEgressWorkerFilter,
 			IngressWorkerFilter:    opts.WithIngressWorkerFilter,
+			EnableSessionRecording: opts.WithEnableSessionRecording,
+			SessionIdleTimeout:     opts.WithSessionIdleTimeout,
 		},
 	}
 	return t, nil
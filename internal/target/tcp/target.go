@@ -61,6 +61,8 @@ func (h targetHooks) NewTarget(projectId string, opt ...target.Option) (target.T
 			WorkerFilter:           opts.WithWorkerFilter,
 			EgressWorkerFilter:     opts.WithEgressWorkerFilter,
 			IngressWorkerFilter:    opts.WithIngressWorkerFilter,
+			EnableSessionRecording: opts.WithEnableSessionRecording,
+			SessionIdleTimeout:     opts.WithSessionIdleTimeout,
 		},
 		Address: opts.WithAddress,
 	}
@@ -193,6 +195,14 @@ func (t *Target) SetIngressWorkerFilter(filter string) {
 	t.IngressWorkerFilter = filter
 }
 
+func (t *Target) SetEnableSessionRecording(enable bool) {
+	t.EnableSessionRecording = enable
+}
+
+func (t *Target) SetSessionIdleTimeout(timeout uint32) {
+	t.SessionIdleTimeout = timeout
+}
+
 func (t *Target) SetAddress(address string) {
 	t.Address = address
 }
@@ -110,6 +110,25 @@ func TestCustom(t *testing.T) {
 			require.NoError(err)
 			assert.EqualValues(updatedRole.Item.Version, version)
 			assert.Empty(updatedRole.Item.Grants)
+			version++
+
+			assert.Equal([]string{"this"}, updatedRole.Item.GrantScopeIds)
+			updatedRole, err = rc.RemoveGrantScopes(tc.Context(), updatedRole.Item.Id, updatedRole.Item.Version, []string{"this"})
+			require.NoError(err)
+			assert.EqualValues(updatedRole.Item.Version, version)
+			assert.Empty(updatedRole.Item.GrantScopeIds)
+			version++
+
+			updatedRole, err = rc.AddGrantScopes(tc.Context(), updatedRole.Item.Id, updatedRole.Item.Version, []string{tt.scopeId})
+			require.NoError(err)
+			assert.EqualValues(updatedRole.Item.Version, version)
+			assert.Equal([]string{"this"}, updatedRole.Item.GrantScopeIds)
+			version++
+
+			updatedRole, err = rc.SetGrantScopes(tc.Context(), updatedRole.Item.Id, updatedRole.Item.Version, nil)
+			require.NoError(err)
+			assert.EqualValues(updatedRole.Item.Version, version)
+			assert.Empty(updatedRole.Item.GrantScopeIds)
 		})
 	}
 }
@@ -97,7 +97,7 @@ function role_has_grant() {
 
 function has_default_role_actions() {
   local out=$1
-  local actions=('read' 'update' 'delete' 'add-principals' 'set-principals' 'remove-principals' 'add-grants' 'set-grants' 'remove-grants')
+  local actions=('read' 'update' 'delete' 'add-principals' 'set-principals' 'remove-principals' 'add-grants' 'set-grants' 'remove-grants' 'add-grant-scopes' 'set-grant-scopes' 'remove-grant-scopes')
 
   for action in ${actions[@]}; do
     $(has_authorized_action "$out" "$action") || {
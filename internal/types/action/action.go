@@ -67,6 +67,22 @@ const (
 	RotateScopeKeys                    Type = 53
 	ListScopeKeyVersionDestructionJobs Type = 54
 	DestroyScopeKeyVersion             Type = 55
+	Download                           Type = 56
+	ListConnections                    Type = 57
+	ListConnectionsSelf                Type = 58
+	Extend                             Type = 59
+	Unlock                             Type = 60
+	EnrollTotp                         Type = 61
+	ConfirmTotp                        Type = 62
+	RemoveTotp                         Type = 63
+	Rotate                             Type = 64
+	Refresh                            Type = 65
+	RefreshSelf                        Type = 66
+	RevokeTokens                       Type = 67
+	AddGrantScopes                     Type = 68
+	SetGrantScopes                     Type = 69
+	RemoveGrantScopes                  Type = 70
+	Explain                            Type = 71
 
 	// When adding new actions, be sure to update:
 	//
@@ -130,6 +146,22 @@ var Map = map[string]Type{
 	RotateScopeKeys.String():                    RotateScopeKeys,
 	ListScopeKeyVersionDestructionJobs.String(): ListScopeKeyVersionDestructionJobs,
 	DestroyScopeKeyVersion.String():             DestroyScopeKeyVersion,
+	Download.String():                           Download,
+	ListConnections.String():                    ListConnections,
+	ListConnectionsSelf.String():                ListConnectionsSelf,
+	Extend.String():                             Extend,
+	Unlock.String():                             Unlock,
+	EnrollTotp.String():                         EnrollTotp,
+	ConfirmTotp.String():                        ConfirmTotp,
+	RemoveTotp.String():                         RemoveTotp,
+	Rotate.String():                             Rotate,
+	Refresh.String():                            Refresh,
+	RefreshSelf.String():                        RefreshSelf,
+	RevokeTokens.String():                       RevokeTokens,
+	AddGrantScopes.String():                     AddGrantScopes,
+	SetGrantScopes.String():                     SetGrantScopes,
+	RemoveGrantScopes.String():                  RemoveGrantScopes,
+	Explain.String():                            Explain,
 }
 
 var DeprecatedMap = map[string]Type{
@@ -199,6 +231,22 @@ func (a Type) String() string {
 		"rotate-keys",
 		"list-key-version-destruction-jobs",
 		"destroy-key-version",
+		"download",
+		"list-connections",
+		"list-connections:self",
+		"extend",
+		"unlock",
+		"enroll-totp",
+		"confirm-totp",
+		"remove-totp",
+		"rotate",
+		"refresh",
+		"refresh:self",
+		"revoke-tokens",
+		"add-grant-scopes",
+		"set-grant-scopes",
+		"remove-grant-scopes",
+		"explain",
 	}[a]
 }
 
@@ -237,6 +285,20 @@ func (a ActionSet) HasAction(act Type) bool {
 	return false
 }
 
+// Union returns the actions which are in any of the action sets, without
+// duplicates.
+func Union(sets ...ActionSet) ActionSet {
+	var ret ActionSet
+	for _, set := range sets {
+		for _, v := range set {
+			if !ret.HasAction(v) {
+				ret = append(ret, v)
+			}
+		}
+	}
+	return ret
+}
+
 // OnlySelf returns true if all actions in the action set are self types. An
 // empty set returns false. This may not be what you want so the caller should
 // validate length and act appropriately as well.
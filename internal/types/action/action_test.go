@@ -134,6 +134,70 @@ func TestAction(t *testing.T) {
 			action: DestroyScopeKeyVersion,
 			want:   "destroy-key-version",
 		},
+		{
+			action: Download,
+			want:   "download",
+		},
+		{
+			action: ListConnections,
+			want:   "list-connections",
+		},
+		{
+			action: ListConnectionsSelf,
+			want:   "list-connections:self",
+		},
+		{
+			action: Extend,
+			want:   "extend",
+		},
+		{
+			action: Unlock,
+			want:   "unlock",
+		},
+		{
+			action: EnrollTotp,
+			want:   "enroll-totp",
+		},
+		{
+			action: ConfirmTotp,
+			want:   "confirm-totp",
+		},
+		{
+			action: RemoveTotp,
+			want:   "remove-totp",
+		},
+		{
+			action: Rotate,
+			want:   "rotate",
+		},
+		{
+			action: Refresh,
+			want:   "refresh",
+		},
+		{
+			action: RefreshSelf,
+			want:   "refresh:self",
+		},
+		{
+			action: RevokeTokens,
+			want:   "revoke-tokens",
+		},
+		{
+			action: AddGrantScopes,
+			want:   "add-grant-scopes",
+		},
+		{
+			action: SetGrantScopes,
+			want:   "set-grant-scopes",
+		},
+		{
+			action: RemoveGrantScopes,
+			want:   "remove-grant-scopes",
+		},
+		{
+			action: Explain,
+			want:   "explain",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.want, func(t *testing.T) {
@@ -29,6 +29,9 @@ const (
 	CredentialStore
 	CredentialLibrary
 	Credential
+	SessionRecording
+	ServiceAccount
+	ApiKey
 	// NOTE: When adding a new type, be sure to update:
 	//
 	// * The Grant.validateType function and test
@@ -64,6 +67,9 @@ func (r Type) String() string {
 		"credential-store",
 		"credential-library",
 		"credential",
+		"session-recording",
+		"service-account",
+		"api-key",
 	}[r]
 }
 
@@ -97,4 +103,7 @@ var Map = map[string]Type{
 	CredentialStore.String():   CredentialStore,
 	CredentialLibrary.String(): CredentialLibrary,
 	Credential.String():        Credential,
+	SessionRecording.String():  SessionRecording,
+	ServiceAccount.String():    ServiceAccount,
+	ApiKey.String():            ApiKey,
 }
@@ -90,6 +90,18 @@ func Test_Resource(t *testing.T) {
 			typeString: "credential-library",
 			want:       CredentialLibrary,
 		},
+		{
+			typeString: "session-recording",
+			want:       SessionRecording,
+		},
+		{
+			typeString: "service-account",
+			want:       ServiceAccount,
+		},
+		{
+			typeString: "api-key",
+			want:       ApiKey,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.typeString, func(t *testing.T) {
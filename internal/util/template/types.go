@@ -33,4 +33,9 @@ type Account struct {
 	LoginName *string
 	Subject   *string
 	Email     *string
+
+	// Claims contains the claims captured on the account by auth methods
+	// which support them, e.g. the ID token and userinfo claims of OIDC
+	// accounts.
+	Claims map[string]any
 }
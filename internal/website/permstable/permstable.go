@@ -722,6 +722,20 @@ var session = &Resource{
 						"id=<id>;actions=cancel",
 					},
 				},
+				{
+					Name:        "list-connections",
+					Description: "List the connections of a session",
+					Examples: []string{
+						"id=<id>;actions=list-connections",
+					},
+				},
+				{
+					Name:        "extend",
+					Description: "Extend the expiration time of a session",
+					Examples: []string{
+						"id=<id>;actions=extend",
+					},
+				},
 				{
 					Name:        "read:self",
 					Description: "Read a session, which must be associated with the calling user",
@@ -736,6 +750,13 @@ var session = &Resource{
 						"id=*;type=session;actions=cancel:self",
 					},
 				},
+				{
+					Name:        "list-connections:self",
+					Description: "List the connections of a session, which must be associated with the calling user",
+					Examples: []string{
+						"id=*;type=session;actions=list-connections:self",
+					},
+				},
 			},
 		},
 	},
@@ -12,6 +12,9 @@ import (
 	"github.com/hashicorp/boundary/internal/daemon/controller"
 	wrapping "github.com/hashicorp/go-kms-wrapping/v2"
 
+	_ "github.com/hashicorp/boundary/internal/daemon/controller/handlers/targets/http"
+	_ "github.com/hashicorp/boundary/internal/daemon/controller/handlers/targets/postgres"
+	_ "github.com/hashicorp/boundary/internal/daemon/controller/handlers/targets/ssh"
 	_ "github.com/hashicorp/boundary/internal/daemon/controller/handlers/targets/tcp"
 )
 